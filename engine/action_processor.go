@@ -92,7 +92,7 @@ func (ap *ActionProcessor) processAllInRaise(player *models.Player, amount int,
 }
 
 func (ap *ActionProcessor) processAllIn(player *models.Player, currentBet *int, minRaise *int) error {
-	if err := ap.validator.validateAllIn(player.Chips); err != nil {
+	if err := ap.validator.validateAllIn(player.Bet, player.Chips); err != nil {
 		return err
 	}
 
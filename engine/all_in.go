@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+
+	"poker-engine/models"
+)
+
+// allInEquitySamples is the number of runouts sampled when an all-in is too
+// large to enumerate, which in practice means preflop. It is far below
+// DefaultEquitySamples because the snapshot is taken under the game lock;
+// the error of about one percent averages out of a running total.
+const allInEquitySamples = 2000
+
+// AllInEquity works out what each player still in a hand could expect to win
+// when the betting is over with cards to come: for every pot, their equity
+// against the others eligible for it times its amount. A pot only one player
+// is eligible for is theirs outright.
+func AllInEquity(pot models.Pot, players []*models.Player, board []models.Card, rng *rand.Rand) ([]models.AllInEquity, error) {
+	var contenders []*models.Player
+	for _, p := range players {
+		if !isNotFolded(p) || len(p.Cards) == 0 {
+			continue
+		}
+		if len(p.Cards) != 2 {
+			return nil, fmt.Errorf("player %s has %d hole cards", p.PlayerID, len(p.Cards))
+		}
+		contenders = append(contenders, p)
+	}
+
+	expected := make(map[string]float64, len(contenders))
+	total := 0
+	share := func(amount int, eligible []*models.Player) error {
+		total += amount
+		if len(eligible) == 1 {
+			expected[eligible[0].PlayerID] += float64(amount)
+			return nil
+		}
+		ranges := make([]Range, len(eligible))
+		for i, p := range eligible {
+			combo := Combo{p.Cards[0], p.Cards[1]}
+			ranges[i] = Range{Notation: combo.String(), Combos: []Combo{combo}}
+		}
+		result, err := CalculateEquity(ranges, board, allInEquitySamples, rng)
+		if err != nil {
+			return err
+		}
+		for i, p := range eligible {
+			expected[p.PlayerID] += result.Ranges[i].Equity * float64(amount)
+		}
+		return nil
+	}
+
+	if pot.Main > 0 {
+		if err := share(pot.Main, contenders); err != nil {
+			return nil, err
+		}
+	}
+	for _, side := range pot.Side {
+		if side.Amount == 0 {
+			continue
+		}
+		var eligible []*models.Player
+		for _, p := range contenders {
+			for _, id := range side.EligiblePlayers {
+				if p.PlayerID == id {
+					eligible = append(eligible, p)
+					break
+				}
+			}
+		}
+		if len(eligible) == 0 {
+			continue
+		}
+		if err := share(side.Amount, eligible); err != nil {
+			return nil, err
+		}
+	}
+
+	equities := make([]models.AllInEquity, 0, len(contenders))
+	for _, p := range contenders {
+		entry := models.AllInEquity{PlayerID: p.PlayerID, Expected: expected[p.PlayerID]}
+		if total > 0 {
+			entry.Equity = entry.Expected / float64(total)
+		}
+		equities = append(equities, entry)
+	}
+	return equities, nil
+}
+
+// snapshotAllInEquity records every player's equity once nobody can act
+// again before the river. The hand's deck seed seeds the sampling, so a
+// replayed hand gets the same figures. Caller must hold g.mu.
+func (g *Game) snapshotAllInEquity() {
+	hand := g.table.CurrentHand
+	if hand.BettingRound == models.RoundRiver || countPlayers(g.table.Players, isNotFolded) < 2 {
+		return
+	}
+	if g.potCalculator == nil {
+		g.potCalculator = NewPotCalculator()
+	}
+	pot := g.potCalculator.CalculateHandPots(g.table.Players)
+	rng := rand.New(rand.NewSource(hand.DeckSeed + int64(hand.HandNumber)))
+	equities, err := AllInEquity(pot, g.table.Players, hand.CommunityCards, rng)
+	if err != nil {
+		log.Printf("[ALL_IN] Table %s hand #%d: no equity snapshot: %v", g.table.TableID, hand.HandNumber, err)
+		return
+	}
+	g.allInEquity = equities
+
+	parts := make([]string, len(equities))
+	for i, e := range equities {
+		parts[i] = fmt.Sprintf("%s=%.1f%%", e.PlayerID, e.Equity*100)
+	}
+	log.Printf("[ALL_IN] Table %s hand #%d all-in on the %s: %s",
+		g.table.TableID, hand.HandNumber, hand.BettingRound, strings.Join(parts, " "))
+}
+
+// allInResults fills in what each player of the all-in snapshot won. Caller
+// must hold g.mu.
+func (g *Game) allInResults() []models.AllInEquity {
+	if len(g.allInEquity) == 0 {
+		return nil
+	}
+	won := make(map[string]int, len(g.table.Winners))
+	for _, winner := range g.table.Winners {
+		won[winner.PlayerID] += winner.Amount
+	}
+	results := make([]models.AllInEquity, len(g.allInEquity))
+	for i, e := range g.allInEquity {
+		e.Won = won[e.PlayerID]
+		results[i] = e
+	}
+	return results
+}
@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestAllInEquity_SidePots(t *testing.T) {
+	short := models.NewPlayer("short", "Short", 0, 0)
+	short.Cards = mustCards(t, "AhAd")
+	big := models.NewPlayer("big", "Big", 1, 0)
+	big.Cards = mustCards(t, "KcKs")
+	folded := models.NewPlayer("folded", "Folded", 2, 0)
+	folded.Cards = mustCards(t, "QcQd")
+	folded.Status = models.StatusFolded
+	other := models.NewPlayer("other", "Other", 3, 0)
+	other.Cards = mustCards(t, "2c3d")
+
+	pot := models.Pot{Main: 400, Side: []models.SidePot{
+		{Amount: 300, EligiblePlayers: []string{"big", "other"}},
+		{Amount: 50, EligiblePlayers: []string{"big"}},
+	}}
+	equities, err := AllInEquity(pot, []*models.Player{short, big, folded, nil, other}, mustCards(t, "2h7c9d"), rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("AllInEquity failed: %v", err)
+	}
+	if len(equities) != 3 {
+		t.Fatalf("Expected the three players still in, got %+v", equities)
+	}
+
+	byPlayer := map[string]models.AllInEquity{}
+	sum := 0.0
+	for _, e := range equities {
+		byPlayer[e.PlayerID] = e
+		sum += e.Expected
+	}
+	if math.Abs(sum-750) > 1e-6 {
+		t.Errorf("Expected the whole pot of 750 to be shared out, got %v", sum)
+	}
+	// Aces can only win the main pot, and the uncalled 50 is big's
+	if e := byPlayer["short"]; e.Expected > 400 || e.Expected < 200 {
+		t.Errorf("Expected aces to be favourites for the main pot only, got %+v", e)
+	}
+	if e := byPlayer["big"]; e.Expected < 50+150 {
+		t.Errorf("Expected kings to be favourites for the side pot, got %+v", e)
+	}
+	if e := byPlayer["other"]; math.Abs(e.Equity-e.Expected/750) > 1e-9 {
+		t.Errorf("Expected equity to be the share of the whole pot, got %+v", e)
+	}
+
+	// More players than a calculation takes can't be snapshot
+	var crowd []*models.Player
+	for i, hole := range []string{"AhAd", "KcKs", "QcQd", "JhJd", "ThTd", "9h9d", "8h8d"} {
+		p := models.NewPlayer(string(rune('a'+i)), "", i, 0)
+		p.Cards = mustCards(t, hole)
+		crowd = append(crowd, p)
+	}
+	if _, err := AllInEquity(models.Pot{Main: 700}, crowd, nil, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("Expected a seven-way all-in to be refused")
+	}
+}
+
+func TestGame_AllInEquityOnHandComplete(t *testing.T) {
+	table := &models.Table{
+		TableID:  "all-in-table",
+		GameType: models.GameTypeTournament,
+		Status:   models.StatusWaiting,
+		Config:   models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 2, ActionTimeout: 30},
+		Players: []*models.Player{
+			models.NewPlayer("p1", "Player 1", 0, 1000),
+			models.NewPlayer("p2", "Player 2", 1, 1000),
+		},
+		CurrentHand: &models.CurrentHand{HandNumber: 0, DealerPosition: -1},
+	}
+	var completed []models.HandCompleteEvent
+	game := NewGame(table, nil, func(e models.Event) {
+		if e.Event == "handComplete" {
+			completed = append(completed, e.Data.(models.HandCompleteEvent))
+		}
+	})
+	game.SetSynchronousEvents(true)
+
+	// Kings hit a king on the turn against aces
+	if err := game.SetScenario(Scenario{
+		Button:    0,
+		HoleCards: map[int][]models.Card{0: mustCards(t, "AhAd"), 1: mustCards(t, "KcKs")},
+		Board:     mustCards(t, "2h7c9dKd3s"),
+	}); err != nil {
+		t.Fatalf("SetScenario failed: %v", err)
+	}
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	first := table.Players[table.CurrentHand.CurrentPosition].PlayerID
+	second := "p1"
+	if first == "p1" {
+		second = "p2"
+	}
+	if err := game.ProcessAction(first, models.ActionAllIn, 0); err != nil {
+		t.Fatalf("All-in failed: %v", err)
+	}
+	if err := game.ProcessAction(second, models.ActionCall, 0); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+
+	if len(completed) != 1 || len(completed[0].AllInEquity) != 2 {
+		t.Fatalf("Expected one hand with an equity snapshot, got %+v", completed)
+	}
+	for _, e := range completed[0].AllInEquity {
+		switch e.PlayerID {
+		case "p1":
+			if e.Equity < 0.75 || e.Won != 0 {
+				t.Errorf("Expected aces to be about 80%% and lose, got %+v", e)
+			}
+		case "p2":
+			if e.Equity > 0.25 || e.Won != 2000 {
+				t.Errorf("Expected kings to be about 20%% and win 2000, got %+v", e)
+			}
+		}
+	}
+
+	// A hand won without a showdown has no snapshot
+	table.Players[0].Chips, table.Players[1].Chips = 1000, 1000
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if err := game.ProcessAction(table.Players[table.CurrentHand.CurrentPosition].PlayerID, models.ActionFold, 0); err != nil {
+		t.Fatalf("Fold failed: %v", err)
+	}
+	if len(completed) != 2 || completed[1].AllInEquity != nil {
+		t.Errorf("Expected the folded hand without a snapshot, got %+v", completed)
+	}
+}
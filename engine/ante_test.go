@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"poker-engine/models"
+	"testing"
+)
+
+func newAnteGame(ante int, stacks ...int) (*Game, *models.Table) {
+	table := &models.Table{
+		TableID:  "ante-table",
+		GameType: models.GameTypeTournament,
+		Status:   models.StatusWaiting,
+		Config: models.TableConfig{
+			SmallBlind: 10,
+			BigBlind:   20,
+			Ante:       ante,
+			MaxPlayers: len(stacks),
+		},
+		Players: make([]*models.Player, len(stacks)),
+		CurrentHand: &models.CurrentHand{
+			HandNumber:     0,
+			DealerPosition: -1,
+		},
+	}
+	for i, chips := range stacks {
+		id := string(rune('a' + i))
+		table.Players[i] = models.NewPlayer(id, "Player "+id, i, chips)
+	}
+	return NewGame(table, nil, nil), table
+}
+
+func totalChips(table *models.Table) int {
+	total := 0
+	for _, p := range table.Players {
+		if p != nil {
+			total += p.Chips
+		}
+	}
+	return total
+}
+
+func TestGame_PostsAntes(t *testing.T) {
+	game, table := newAnteGame(5, 1000, 1000, 1000)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	hand := table.CurrentHand
+	if hand.Pot.Main != 15 {
+		t.Errorf("Expected antes of 15 in the pot, got %d", hand.Pot.Main)
+	}
+	if hand.CurrentBet != 20 {
+		t.Errorf("Expected antes not to change the bet to call, got %d", hand.CurrentBet)
+	}
+
+	for _, p := range table.Players {
+		if p.TotalInvestedThisHand != 5+p.Bet {
+			t.Errorf("Expected %s to have invested the ante plus %d, got %d", p.PlayerID, p.Bet, p.TotalInvestedThisHand)
+		}
+		if p.Chips != 1000-p.TotalInvestedThisHand {
+			t.Errorf("Expected %s to have %d chips, got %d", p.PlayerID, 1000-p.TotalInvestedThisHand, p.Chips)
+		}
+	}
+}
+
+func TestGame_ShortStackAllInOnAnte(t *testing.T) {
+	game, table := newAnteGame(5, 1000, 1000, 1000, 3)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	short := table.Players[3]
+	if short.Status != models.StatusAllIn || short.Chips != 0 {
+		t.Errorf("Expected the short stack to be all-in, got status %s with %d chips", short.Status, short.Chips)
+	}
+	if short.TotalInvestedThisHand != 3 || short.Bet != 0 {
+		t.Errorf("Expected the short stack to post 3 as dead money, got invested %d bet %d", short.TotalInvestedThisHand, short.Bet)
+	}
+	if len(short.Cards) != 2 {
+		t.Errorf("Expected the all-in player to be dealt in, got %d cards", len(short.Cards))
+	}
+	if table.CurrentHand.Pot.Main != 18 {
+		t.Errorf("Expected antes of 18 in the pot, got %d", table.CurrentHand.Pot.Main)
+	}
+	if current := table.Players[table.CurrentHand.CurrentPosition]; current.Status != models.StatusActive {
+		t.Errorf("Expected the first player to act to have chips, got %s (%s)", current.PlayerID, current.Status)
+	}
+}
+
+func TestGame_EveryoneAllInOnAntesRunsOut(t *testing.T) {
+	game, table := newAnteGame(5, 2, 5, 5)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	if table.Status != models.StatusHandComplete {
+		t.Fatalf("Expected the hand to run out with nobody able to act, got status %s", table.Status)
+	}
+	if len(table.CurrentHand.CommunityCards) != 5 {
+		t.Errorf("Expected a full board, got %d cards", len(table.CurrentHand.CommunityCards))
+	}
+
+	// The 2 chip stack can only win 2 from each player
+	pot := table.CurrentHand.Pot
+	if pot.Main != 6 || len(pot.Side) != 1 || pot.Side[0].Amount != 6 {
+		t.Errorf("Expected a main pot of 6 and a side pot of 6, got %+v", pot)
+	}
+	if len(pot.Side) == 1 && len(pot.Side[0].EligiblePlayers) != 2 {
+		t.Errorf("Expected the side pot to exclude the short stack, got %v", pot.Side[0].EligiblePlayers)
+	}
+	if total := totalChips(table); total != 12 {
+		t.Errorf("Expected all 12 chips to be paid out, got %d", total)
+	}
+}
+
+func TestCalculateHandPots_IncludesAntes(t *testing.T) {
+	// p1 went all-in for the ante, p2 and p3 put in the ante and 100 more
+	players := []*models.Player{
+		{PlayerID: "p1", TotalInvestedThisHand: 5, Status: models.StatusAllIn},
+		{PlayerID: "p2", TotalInvestedThisHand: 110, Status: models.StatusActive},
+		{PlayerID: "p3", TotalInvestedThisHand: 110, Status: models.StatusActive},
+	}
+
+	pot := NewPotCalculator().CalculateHandPots(players)
+	if pot.Main != 15 {
+		t.Errorf("Expected main pot 15, got %d", pot.Main)
+	}
+	if len(pot.Side) != 1 || pot.Side[0].Amount != 210 {
+		t.Errorf("Expected one side pot of 210, got %+v", pot.Side)
+	}
+}
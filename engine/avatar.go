@@ -0,0 +1,25 @@
+package engine
+
+import "fmt"
+
+// SetPlayerAvatar sets the picture shown for a seated player, or clears it
+// with an empty URL. It has no effect on play.
+func (t *Table) SetPlayerAvatar(playerID, url string) error {
+	return t.game.SetPlayerAvatar(playerID, url)
+}
+
+// SetPlayerAvatar updates a seated player's avatar
+func (g *Game) SetPlayerAvatar(playerID, url string) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return fmt.Errorf("player not found")
+	}
+	if player.AvatarURL != url {
+		player.AvatarURL = url
+		g.publishSnapshot()
+	}
+	return nil
+}
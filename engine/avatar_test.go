@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestGame_SetPlayerAvatar(t *testing.T) {
+	table := &models.Table{
+		TableID:     "avatar-table",
+		GameType:    models.GameTypeCash,
+		Status:      models.StatusWaiting,
+		Config:      models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 2},
+		Players:     []*models.Player{models.NewPlayer("p1", "Player 1", 0, 1000), nil},
+		CurrentHand: &models.CurrentHand{DealerPosition: -1},
+	}
+	game := NewGame(table, func(string, uint64) {}, func(models.Event) {})
+	game.SetSynchronousEvents(true)
+	game.Snapshot()
+
+	if err := game.SetPlayerAvatar("p9", "/avatars/p9.png"); err == nil {
+		t.Error("Expected an error setting the avatar of an unknown player")
+	}
+	if err := game.SetPlayerAvatar("p1", "/avatars/p1.png"); err != nil {
+		t.Fatalf("SetPlayerAvatar failed: %v", err)
+	}
+	if url := game.Snapshot().Players[0].AvatarURL; url != "/avatars/p1.png" {
+		t.Errorf("Expected the snapshot to show the avatar, got %q", url)
+	}
+	if err := game.SetPlayerAvatar("p1", ""); err != nil {
+		t.Fatalf("SetPlayerAvatar failed: %v", err)
+	}
+	if url := game.Snapshot().Players[0].AvatarURL; url != "" {
+		t.Errorf("Expected a cleared avatar, got %q", url)
+	}
+}
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"poker-engine/models"
+)
+
+// minAwayTimeout is the shortest turn an away player gets
+const minAwayTimeout = 5 * time.Second
+
+// SetPlayerAway marks a seated player as away or back. Away players get a
+// third of the usual time to act, and other players see the flag in the
+// table state. Acting clears it.
+func (t *Table) SetPlayerAway(playerID string, away bool) error {
+	return t.game.SetPlayerAway(playerID, away)
+}
+
+// SetPlayerAway updates a player's away flag and announces the change
+func (g *Game) SetPlayerAway(playerID string, away bool) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return fmt.Errorf("player not found")
+	}
+	g.setAway(player, away)
+	return nil
+}
+
+// markPresent clears the away flag of a player who has just acted.
+// Caller must hold g.mu.
+func (g *Game) markPresent(player *models.Player) {
+	g.setAway(player, false)
+}
+
+// setAway changes the flag and emits playerAway. Caller must hold g.mu.
+func (g *Game) setAway(player *models.Player, away bool) {
+	if player.Away == away {
+		return
+	}
+	player.Away = away
+
+	g.publishSnapshot()
+	g.emit(models.Event{
+		Event:   "playerAway",
+		TableID: g.table.TableID,
+		Data: map[string]interface{}{
+			"playerId": player.PlayerID,
+			"away":     away,
+		},
+	})
+}
+
+// actionTimeoutFor returns how long a player has to act. A disconnected
+// player's first turn gets the disconnect grace; after that they are timed
+// like an away player. Caller must hold g.mu.
+func (g *Game) actionTimeoutFor(player *models.Player) time.Duration {
+	timeout := time.Duration(g.table.Config.ActionTimeout) * time.Second
+	if player.Disconnected && !player.DisconnectGraceUsed {
+		if grace := time.Duration(g.table.Config.DisconnectGrace) * time.Second; grace > timeout {
+			return grace
+		}
+	}
+	if !player.Away && !player.Disconnected {
+		return timeout
+	}
+	shortened := timeout / 3
+	if shortened < minAwayTimeout {
+		shortened = minAwayTimeout
+	}
+	if shortened > timeout {
+		return timeout
+	}
+	return shortened
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"poker-engine/models"
+)
+
+func TestGame_AwayPlayerGetsShorterTimer(t *testing.T) {
+	var events []models.Event
+	table := &models.Table{
+		TableID:  "away-table",
+		GameType: models.GameTypeCash,
+		Status:   models.StatusWaiting,
+		Config:   models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 2, ActionTimeout: 30},
+		Players: []*models.Player{
+			models.NewPlayer("p1", "Player 1", 0, 1000),
+			models.NewPlayer("p2", "Player 2", 1, 1000),
+		},
+		CurrentHand: &models.CurrentHand{HandNumber: 0, DealerPosition: -1},
+	}
+	game := NewGame(table, func(string, uint64) {}, func(e models.Event) { events = append(events, e) })
+	game.SetSynchronousEvents(true)
+
+	if err := game.SetPlayerAway("p9", true); err == nil {
+		t.Error("Expected an error marking an unknown player away")
+	}
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	current := table.Players[table.CurrentHand.CurrentPosition]
+	other := table.Players[1-table.CurrentHand.CurrentPosition]
+	if err := game.SetPlayerAway(other.PlayerID, true); err != nil {
+		t.Fatalf("SetPlayerAway failed: %v", err)
+	}
+	if !game.Snapshot().Players[other.SeatNumber].Away {
+		t.Error("Expected the snapshot to show the player as away")
+	}
+
+	if err := game.ProcessAction(current.PlayerID, models.ActionCall, 0); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	remaining := time.Until(*table.CurrentHand.ActionDeadline)
+	if remaining > 11*time.Second || remaining < 9*time.Second {
+		t.Errorf("Expected the away player to get about 10 seconds, got %v", remaining)
+	}
+
+	if err := game.ProcessAction(other.PlayerID, models.ActionCheck, 0); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if other.Away {
+		t.Error("Expected acting to clear the away flag")
+	}
+
+	var changes []bool
+	for _, e := range events {
+		if e.Event == "playerAway" {
+			changes = append(changes, e.Data.(map[string]interface{})["away"].(bool))
+		}
+	}
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Errorf("Expected playerAway events for away then back, got %v", changes)
+	}
+}
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"fmt"
+
+	"poker-engine/models"
+)
+
+// NextBigBlind returns the player due to post the big blind in the next hand.
+// Tournament balancing moves this player, so nobody skips or repeats a blind.
+func (t *Table) NextBigBlind() (string, bool) {
+	return t.game.NextBigBlind()
+}
+
+// UnseatPlayer takes a player and their stack off the table so a tournament
+// can seat them at another table. Only allowed between hands.
+func (t *Table) UnseatPlayer(playerID string) (*models.Player, error) {
+	return t.game.UnseatPlayer(playerID)
+}
+
+// SeatPlayer seats a player moved from another tournament table with the stack
+// they had there, in the first empty seat, and returns the seat. A player
+// seated during a hand sits it out as folded and is dealt in from the next one.
+func (t *Table) SeatPlayer(playerID, playerName string, chips int) (int, error) {
+	return t.game.SeatPlayer(playerID, playerName, chips)
+}
+
+// NextBigBlind works out the next hand's big blind the way StartNewHand will
+func (g *Game) NextBigBlind() (string, bool) {
+	g.mu.Lock()
+	defer g.unlock()
+
+	seats, _, ok := g.upcomingBlindSeats()
+	if !ok {
+		return "", false
+	}
+	return g.table.Players[seats.BigBlind].PlayerID, true
+}
+
+// upcomingBlindSeats works out the next hand's button and blinds and the
+// number of players dealt in. Caller must hold g.mu.
+func (g *Game) upcomingBlindSeats() (BlindSeats, int, bool) {
+	players := g.table.Players
+	live := countPlayers(players, isActiveWithChips)
+	if live < 2 {
+		return BlindSeats{}, live, false
+	}
+
+	pf := NewPositionFinder(players)
+	var forced *int
+	if g.forcedButton != nil && *g.forcedButton < len(players) && isActiveWithChips(players[*g.forcedButton]) {
+		forced = g.forcedButton
+	}
+	seats, _ := correctBlindSeats(pf, g.nextBlindSeats(pf, live, forced), live)
+	return seats, live, true
+}
+
+// UnseatPlayer removes a player between hands and returns them as they were
+func (g *Game) UnseatPlayer(playerID string) (*models.Player, error) {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if g.table.Status == models.StatusPlaying {
+		return nil, fmt.Errorf("cannot unseat a player while a hand is in progress")
+	}
+
+	for i, p := range g.table.Players {
+		if p != nil && p.PlayerID == playerID {
+			g.table.Players[i] = nil
+			g.cancelSeatChange(playerID)
+			delete(g.frozen, playerID)
+			g.publishSnapshot()
+			return p.Clone(), nil
+		}
+	}
+	return nil, fmt.Errorf("player not found")
+}
+
+// SeatPlayer validates and seats a moved player
+func (g *Game) SeatPlayer(playerID, playerName string, chips int) (int, error) {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if chips <= 0 {
+		return 0, fmt.Errorf("a moved player must have chips")
+	}
+	if findPlayerByID(g.table.Players, playerID) != nil {
+		return 0, fmt.Errorf("player %s is already seated", playerID)
+	}
+
+	seat := -1
+	for i, p := range g.table.Players {
+		if p == nil {
+			seat = i
+			break
+		}
+	}
+	if seat < 0 {
+		return 0, fmt.Errorf("table is full")
+	}
+
+	player := models.NewPlayer(playerID, playerName, seat, chips)
+	if g.table.Status == models.StatusPlaying {
+		player.Status = models.StatusFolded
+	}
+	g.table.Players[seat] = player
+	g.publishSnapshot()
+	return seat, nil
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func newBalanceTestTable(tableID string, players int) *Table {
+	config := models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 6, StartingChips: 1000}
+	table := NewTable(tableID, models.GameTypeTournament, config, nil, nil)
+	for i := 0; i < players; i++ {
+		table.AddPlayer(fmt.Sprintf("%s-p%d", tableID, i), fmt.Sprintf("Player %d", i), i, 0)
+	}
+	return table
+}
+
+func TestTable_NextBigBlindMatchesNextHand(t *testing.T) {
+	for _, players := range []int{2, 3, 5} {
+		table := newBalanceTestTable("bb", players)
+		if err := table.StartGame(); err != nil {
+			t.Fatalf("%d players: failed to start hand: %v", players, err)
+		}
+		foldToEnd(t, table)
+
+		for hand := 1; hand < players+1; hand++ {
+			expected, ok := table.NextBigBlind()
+			if !ok {
+				t.Fatalf("%d players: expected a next big blind", players)
+			}
+			if err := table.StartGame(); err != nil {
+				t.Fatalf("%d players: failed to start hand: %v", players, err)
+			}
+			state := table.GetState()
+			if got := state.Players[state.CurrentHand.BigBlindPosition].PlayerID; got != expected {
+				t.Errorf("%d players, hand %d: expected big blind %s, got %s", players, hand+1, expected, got)
+			}
+			foldToEnd(t, table)
+		}
+	}
+
+	if _, ok := newBalanceTestTable("lonely", 1).NextBigBlind(); ok {
+		t.Error("Expected no big blind with one player")
+	}
+}
+
+func TestTable_MovePlayerBetweenTables(t *testing.T) {
+	from := newBalanceTestTable("from", 4)
+	to := newBalanceTestTable("to", 2)
+
+	if err := from.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if _, err := from.UnseatPlayer("from-p0"); err == nil {
+		t.Error("Expected unseating during a hand to fail")
+	}
+	foldToEnd(t, from)
+
+	moved, err := from.UnseatPlayer("from-p0")
+	if err != nil {
+		t.Fatalf("UnseatPlayer failed: %v", err)
+	}
+	if from.GetState().Players[0] != nil {
+		t.Error("Expected the seat to be empty after unseating")
+	}
+
+	// The target table is mid-hand, so the moved player waits for the next one
+	if err := to.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	seat, err := to.SeatPlayer(moved.PlayerID, moved.PlayerName, moved.Chips)
+	if err != nil {
+		t.Fatalf("SeatPlayer failed: %v", err)
+	}
+	if seat != 2 {
+		t.Errorf("Expected the first empty seat 2, got %d", seat)
+	}
+	player := to.GetState().Players[seat]
+	if player.Chips != moved.Chips || player.Status != models.StatusFolded {
+		t.Errorf("Expected %d chips folded for the rest of the hand, got %d %s", moved.Chips, player.Chips, player.Status)
+	}
+	if _, err := to.SeatPlayer(moved.PlayerID, moved.PlayerName, moved.Chips); err == nil {
+		t.Error("Expected seating the same player twice to fail")
+	}
+
+	foldToEnd(t, to)
+	if err := to.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if status := to.GetState().Players[seat].Status; status == models.StatusFolded || status == models.StatusSittingOut {
+		t.Errorf("Expected the moved player to be dealt in, got %s", status)
+	}
+}
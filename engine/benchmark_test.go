@@ -0,0 +1,200 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"poker-engine/models"
+	"testing"
+)
+
+// Benchmarks for the engine hot paths. Baseline numbers live in
+// testdata/bench_baseline.txt and are enforced by `make bench-check`.
+
+// quietLogs silences engine logging for the duration of a benchmark,
+// since log writes would otherwise dominate the measurement
+func quietLogs(b *testing.B) {
+	log.SetOutput(io.Discard)
+	b.Cleanup(func() { log.SetOutput(os.Stderr) })
+}
+
+func newBenchGame(numPlayers int) *Game {
+	config := models.TableConfig{
+		SmallBlind:    10,
+		BigBlind:      20,
+		MaxPlayers:    numPlayers,
+		StartingChips: 1000000000,
+		ActionTimeout: 0,
+	}
+
+	table := &models.Table{
+		TableID:  "bench-table",
+		GameType: models.GameTypeTournament,
+		Status:   models.StatusWaiting,
+		Config:   config,
+		Players:  make([]*models.Player, numPlayers),
+		CurrentHand: &models.CurrentHand{
+			DealerPosition: -1,
+		},
+	}
+	for i := 0; i < numPlayers; i++ {
+		table.Players[i] = models.NewPlayer(fmt.Sprintf("p%d", i), fmt.Sprintf("Player %d", i), i, config.StartingChips)
+	}
+
+	return NewGame(table, nil, nil)
+}
+
+// passiveAction returns check when possible, otherwise call
+func passiveAction(g *Game) (string, models.PlayerAction) {
+	hand := g.table.CurrentHand
+	player := g.table.Players[hand.CurrentPosition]
+	if player.Bet < hand.CurrentBet {
+		return player.PlayerID, models.ActionCall
+	}
+	return player.PlayerID, models.ActionCheck
+}
+
+func BenchmarkStartNewHand(b *testing.B) {
+	g := newBenchGame(9)
+
+	quietLogs(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.table.Status = models.StatusWaiting
+		if err := g.StartNewHand(); err != nil {
+			b.Fatalf("StartNewHand failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkProcessAction(b *testing.B) {
+	g := newBenchGame(6)
+	if err := g.StartNewHand(); err != nil {
+		b.Fatalf("StartNewHand failed: %v", err)
+	}
+
+	quietLogs(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if g.table.Status != models.StatusPlaying {
+			b.StopTimer()
+			g.table.Status = models.StatusWaiting
+			if err := g.StartNewHand(); err != nil {
+				b.Fatalf("StartNewHand failed: %v", err)
+			}
+			b.StartTimer()
+		}
+
+		playerID, action := passiveAction(g)
+		if err := g.ProcessAction(playerID, action, 0); err != nil {
+			b.Fatalf("ProcessAction failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkEvaluateHand(b *testing.B) {
+	hole := []models.Card{
+		{Rank: models.Ace, Suit: models.Hearts},
+		{Rank: models.King, Suit: models.Hearts},
+	}
+	board := []models.Card{
+		{Rank: models.Queen, Suit: models.Hearts},
+		{Rank: models.Seven, Suit: models.Clubs},
+		{Rank: models.Seven, Suit: models.Diamonds},
+		{Rank: models.Two, Suit: models.Spades},
+		{Rank: models.Nine, Suit: models.Hearts},
+	}
+
+	quietLogs(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = EvaluateHand(hole, board)
+	}
+}
+
+// newSidePotPlayers builds a multi-way all-in with three distinct stack sizes
+func newSidePotPlayers() []*models.Player {
+	investments := []int{100, 250, 250, 600, 1000, 1000}
+	players := make([]*models.Player, len(investments))
+	for i, invested := range investments {
+		p := models.NewPlayer(fmt.Sprintf("p%d", i), fmt.Sprintf("Player %d", i), i, 0)
+		p.Bet = invested
+		p.TotalInvestedThisHand = invested
+		p.Status = models.StatusAllIn
+		p.Cards = []models.Card{
+			{Rank: models.Rank("23456789TJQKA"[i : i+1]), Suit: models.Clubs},
+			{Rank: models.Rank("23456789TJQKA"[i+6 : i+7]), Suit: models.Diamonds},
+		}
+		players[i] = p
+	}
+	return players
+}
+
+func BenchmarkCalculatePots(b *testing.B) {
+	players := newSidePotPlayers()
+	pc := NewPotCalculator()
+
+	quietLogs(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = pc.CalculatePots(players)
+	}
+}
+
+func BenchmarkDistributeWinnings(b *testing.B) {
+	players := newSidePotPlayers()
+	pot := NewPotCalculator().CalculatePots(players)
+	board := []models.Card{
+		{Rank: models.Ace, Suit: models.Hearts},
+		{Rank: models.Jack, Suit: models.Spades},
+		{Rank: models.Seven, Suit: models.Hearts},
+		{Rank: models.Four, Suit: models.Spades},
+		{Rank: models.Two, Suit: models.Hearts},
+	}
+
+	quietLogs(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = DistributeWinnings(pot, players, board)
+	}
+}
+
+// newShowdownPlayers builds a 9-way showdown with no side pots
+func newShowdownPlayers() []*models.Player {
+	players := make([]*models.Player, 9)
+	for i := range players {
+		p := models.NewPlayer(fmt.Sprintf("p%d", i), fmt.Sprintf("Player %d", i), i, 0)
+		p.Bet = 500
+		p.TotalInvestedThisHand = 500
+		p.Cards = []models.Card{
+			{Rank: models.Rank("23456789TJQKA"[i : i+1]), Suit: models.Spades},
+			{Rank: models.Rank("23456789TJQKA"[i+4 : i+5]), Suit: models.Diamonds},
+		}
+		players[i] = p
+	}
+	return players
+}
+
+func BenchmarkDistributeWinnings_FullTable(b *testing.B) {
+	players := newShowdownPlayers()
+	pot := NewPotCalculator().CalculatePots(players)
+	board := []models.Card{
+		{Rank: models.Ace, Suit: models.Hearts},
+		{Rank: models.Jack, Suit: models.Clubs},
+		{Rank: models.Seven, Suit: models.Hearts},
+		{Rank: models.Four, Suit: models.Clubs},
+		{Rank: models.Two, Suit: models.Hearts},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = DistributeWinnings(pot, players, board)
+	}
+}
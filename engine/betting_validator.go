@@ -1,10 +1,18 @@
 package engine
 
-import "fmt"
+import (
+	"fmt"
+
+	"poker-engine/models"
+)
 
 type BettingValidator struct {
 	currentBet int
 	minRaise   int
+	mode       string // One of the models.Betting modes; empty means no limit
+	pot        int    // Chips in the middle, this round's bets included
+	betSize    int    // Fixed limit: the size of every bet and raise this round
+	raises     int    // Bets and raises made this round
 }
 
 func NewBettingValidator(currentBet, minRaise int) *BettingValidator {
@@ -14,6 +22,15 @@ func NewBettingValidator(currentBet, minRaise int) *BettingValidator {
 	}
 }
 
+// withLimits applies a betting mode to the validator
+func (bv *BettingValidator) withLimits(mode string, pot, betSize, raises int) *BettingValidator {
+	bv.mode = mode
+	bv.pot = pot
+	bv.betSize = betSize
+	bv.raises = raises
+	return bv
+}
+
 func (bv *BettingValidator) validateCheck(playerBet int) error {
 	if playerBet < bv.currentBet {
 		return fmt.Errorf("cannot check - must call, raise, or fold")
@@ -30,26 +47,117 @@ func (bv *BettingValidator) validateRaise(amount, playerBet int) error {
 		return fmt.Errorf("raise amount %d is less than current bet %d", amount, playerBet)
 	}
 
-	minTotalBet := bv.currentBet + bv.minRaise
+	if bv.capped() {
+		return fmt.Errorf("betting is capped at %d bets this round", models.FixedLimitCap)
+	}
+
+	minTotalBet := bv.minTotalBet()
 	if amount < minTotalBet {
 		return fmt.Errorf("raise must be at least %d (current bet %d + min raise %d)",
-			minTotalBet, bv.currentBet, bv.minRaise)
+			minTotalBet, bv.currentBet, minTotalBet-bv.currentBet)
+	}
+
+	if limit, ok := bv.raiseLimit(playerBet); ok && amount > limit {
+		return fmt.Errorf("raise of %d is over the %s limit of %d", amount, bv.mode, limit)
 	}
 
 	return nil
 }
 
-func (bv *BettingValidator) validateAllIn(playerChips int) error {
+// validateAllIn checks a player can put all their chips in. Under a limit an
+// all-in that raises must fit within the limit.
+func (bv *BettingValidator) validateAllIn(playerBet, playerChips int) error {
 	if playerChips <= 0 {
 		return fmt.Errorf("player has no chips to go all-in")
 	}
+	total := playerBet + playerChips
+	if total <= bv.currentBet {
+		return nil
+	}
+	if bv.capped() {
+		return fmt.Errorf("betting is capped at %d bets this round, call instead", models.FixedLimitCap)
+	}
+	if limit, ok := bv.raiseLimit(playerBet); ok && total > limit {
+		return fmt.Errorf("all-in of %d is over the %s limit of %d, raise instead", total, bv.mode, limit)
+	}
+	return nil
+}
+
+// validateStraddle checks a player can post a full straddle
+func (bv *BettingValidator) validateStraddle(amount, playerChips int) error {
+	if amount <= bv.currentBet {
+		return fmt.Errorf("straddle of %d must be more than the big blind %d", amount, bv.currentBet)
+	}
+	if playerChips < amount {
+		return fmt.Errorf("straddle of %d needs more chips than the %d available", amount, playerChips)
+	}
 	return nil
 }
 
 func (bv *BettingValidator) minTotalBet() int {
+	if bv.mode == models.BettingFixedLimit {
+		return bv.currentBet + bv.betSize
+	}
 	return bv.currentBet + bv.minRaise
 }
 
+// raiseLimit returns the most a player's bet may total after a bet or raise
+// under a pot or fixed limit, and false under no limit
+func (bv *BettingValidator) raiseLimit(playerBet int) (int, bool) {
+	switch bv.mode {
+	case models.BettingPotLimit:
+		// Calling first, then raising by the pot that makes
+		call := bv.currentBet - playerBet
+		return bv.currentBet + bv.pot + call, true
+	case models.BettingFixedLimit:
+		return bv.currentBet + bv.betSize, true
+	}
+	return 0, false
+}
+
+// capped reports whether a fixed limit round has had all the bets it allows
+func (bv *BettingValidator) capped() bool {
+	return bv.mode == models.BettingFixedLimit && bv.raises >= models.FixedLimitCap
+}
+
+// raiseBounds returns the smallest and largest totals a player's bet may be
+// raised to, or zeros when they can't raise. A stack short of the smallest
+// raise can still go all in for less.
+func (bv *BettingValidator) raiseBounds(playerBet, playerChips int) (int, int) {
+	allIn := playerBet + playerChips
+	if allIn <= bv.currentBet || bv.capped() {
+		return 0, 0
+	}
+	max := allIn
+	if limit, ok := bv.raiseLimit(playerBet); ok && limit < max {
+		max = limit
+	}
+	min := bv.minTotalBet()
+	if min > max {
+		min = max
+	}
+	return min, max
+}
+
+// legalActions returns the actions a player with a bet and chips behind may
+// take, in the order fold, check, call, raise, all in
+func (bv *BettingValidator) legalActions(playerBet, playerChips int) []models.PlayerAction {
+	actions := []models.PlayerAction{models.ActionFold}
+	if bv.validateCheck(playerBet) == nil {
+		actions = append(actions, models.ActionCheck)
+	} else if playerChips > 0 {
+		actions = append(actions, models.ActionCall)
+	}
+	// A stack short of a full raise can only go all in
+	if _, max := bv.raiseBounds(playerBet, playerChips); max > 0 && bv.minTotalBet() <= max {
+		actions = append(actions, models.ActionRaise)
+	}
+	if bv.validateAllIn(playerBet, playerChips) == nil {
+		actions = append(actions, models.ActionAllIn)
+	}
+	return actions
+}
+
 func (bv *BettingValidator) isFullRaise(playerBet int) bool {
 	return playerBet >= bv.minTotalBet()
 }
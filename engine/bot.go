@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"poker-engine/models"
+)
+
+// Bot difficulties
+const (
+	BotEasy   = "easy"   // Loose and passive: plays most hands and seldom raises
+	BotMedium = "medium" // Tight-aggressive: plays few hands, and bets and raises them
+	BotHard   = "hard"   // Tight-aggressive with finer equity estimates and the odd bluff
+)
+
+var ErrUnknownBotDifficulty = errors.New("bot difficulty must be easy, medium or hard")
+
+// botStyle is how a difficulty plays. Hand strength is the bot's equity
+// against a random hand for every opponent still in, times the number of
+// players in the hand, so 1 is a fair share of the pot whatever their number.
+type botStyle struct {
+	samples  int     // Deals sampled to estimate equity
+	call     float64 // Strength needed to put chips in when the pot odds allow it
+	raise    float64 // Strength needed to bet or raise
+	bluff    float64 // Chance of betting a hand too weak to, when checked to
+	sizing   float64 // Bets and raises, as a share of the pot once the bot has called
+	overcall float64 // Share of the pot odds the bot needs; below 1 calls light
+}
+
+var botStyles = map[string]botStyle{
+	BotEasy:   {samples: 500, call: 0.7, raise: 1.7, sizing: 0.5, overcall: 0.7},
+	BotMedium: {samples: 2000, call: 1.0, raise: 1.25, sizing: 0.75, overcall: 1},
+	BotHard:   {samples: 4000, call: 0.95, raise: 1.2, bluff: 0.1, sizing: 0.75, overcall: 1},
+}
+
+// Bot decides the actions of a computer player from what a player in its
+// seat could see: its own cards, the board, the bets and the pot
+type Bot struct {
+	Difficulty string
+	style      botStyle
+	rng        *rand.Rand
+}
+
+// NewBot creates a bot of a difficulty, its chance decisions drawn from seed
+func NewBot(difficulty string, seed int64) (*Bot, error) {
+	style, ok := botStyles[difficulty]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBotDifficulty, difficulty)
+	}
+	return &Bot{Difficulty: difficulty, style: style, rng: rand.New(rand.NewSource(seed))}, nil
+}
+
+// Decide picks the bot's action, and the raise total for a raise, on its
+// turn. Only actions the turn lists as legal are chosen.
+func (b *Bot) Decide(table *models.Table, turn models.ActionRequiredEvent) (models.PlayerAction, int) {
+	legal := make(map[models.PlayerAction]bool, len(turn.LegalActions))
+	for _, action := range turn.LegalActions {
+		legal[action] = true
+	}
+	passive := func() (models.PlayerAction, int) {
+		if legal[models.ActionCheck] {
+			return models.ActionCheck, 0
+		}
+		return models.ActionFold, 0
+	}
+
+	player := findPlayerByID(table.Players, turn.PlayerID)
+	if player == nil || len(player.Cards) != 2 || table.CurrentHand == nil {
+		return passive()
+	}
+	strength, equity := b.strength(table, player)
+
+	pot := table.CurrentHand.Pot.Main
+	for _, side := range table.CurrentHand.Pot.Side {
+		pot += side.Amount
+	}
+	for _, p := range table.Players {
+		if p != nil {
+			pot += p.Bet
+		}
+	}
+
+	if strength >= b.style.raise || (turn.CallAmount == 0 && b.rng.Float64() < b.style.bluff) {
+		if action, amount, ok := b.raise(legal, turn, player, pot); ok {
+			return action, amount
+		}
+	}
+	if turn.CallAmount == 0 {
+		return passive()
+	}
+	potOdds := float64(turn.CallAmount) / float64(pot+turn.CallAmount)
+	if strength >= b.style.call && equity >= potOdds*b.style.overcall {
+		if legal[models.ActionCall] {
+			return models.ActionCall, 0
+		}
+		if legal[models.ActionAllIn] {
+			return models.ActionAllIn, 0
+		}
+	}
+	return passive()
+}
+
+// raise sizes a bet or raise to a share of the pot, within the turn's bounds,
+// moving all in when that is what the size comes to
+func (b *Bot) raise(legal map[models.PlayerAction]bool, turn models.ActionRequiredEvent, player *models.Player, pot int) (models.PlayerAction, int, bool) {
+	allIn := player.Bet + player.Chips
+	if !legal[models.ActionRaise] || turn.MinRaiseTo == 0 {
+		// Short of a full raise, the bot can only move all in
+		return models.ActionAllIn, 0, legal[models.ActionAllIn]
+	}
+	to := player.Bet + turn.CallAmount + int(b.style.sizing*float64(pot+turn.CallAmount))
+	to = maxInt(to, turn.MinRaiseTo)
+	if turn.MaxRaiseTo > 0 {
+		to = minInt(to, turn.MaxRaiseTo)
+	}
+	if to >= allIn && legal[models.ActionAllIn] {
+		return models.ActionAllIn, 0, true
+	}
+	return models.ActionRaise, to, true
+}
+
+// strength estimates the bot's hand strength and its equity against the
+// players still in the hand, whose cards it doesn't look at
+func (b *Bot) strength(table *models.Table, player *models.Player) (float64, float64) {
+	opponents := 0
+	for _, p := range table.Players {
+		if p != nil && p != player && (p.Status == models.StatusActive || p.Status == models.StatusAllIn) {
+			opponents++
+		}
+	}
+	if opponents == 0 {
+		return 1, 1
+	}
+	opponents = minInt(opponents, MaxEquityRanges-1)
+
+	hand := Combo{player.Cards[0], player.Cards[1]}
+	ranges := []Range{{Notation: hand.String(), Combos: []Combo{hand}}}
+	random := randomRange(hand.mask() | boardMask(table.CurrentHand.CommunityCards))
+	for i := 0; i < opponents; i++ {
+		ranges = append(ranges, random)
+	}
+	result, err := CalculateEquity(ranges, table.CurrentHand.CommunityCards, b.style.samples, b.rng)
+	if err != nil {
+		return 0, 0
+	}
+	equity := result.Ranges[0].Equity
+	return equity * float64(opponents+1), equity
+}
+
+func boardMask(board []models.Card) uint64 {
+	var mask uint64
+	for _, card := range board {
+		mask |= cardBit(card)
+	}
+	return mask
+}
+
+// randomRange is every starting hand free of the used cards
+func randomRange(used uint64) Range {
+	deck := remainingDeck(used)
+	r := Range{Notation: "random", Combos: make([]Combo, 0, len(deck)*(len(deck)-1)/2)}
+	for i := range deck {
+		for j := i + 1; j < len(deck); j++ {
+			r.Combos = append(r.Combos, Combo{deck[i], deck[j]})
+		}
+	}
+	return r
+}
+
+// SetPlayerBot marks a seated player as a bot, or as a person, so the table
+// can show who is which. It has no effect on play.
+func (t *Table) SetPlayerBot(playerID string, isBot bool) error {
+	return t.game.SetPlayerBot(playerID, isBot)
+}
+
+// SetPlayerBot updates whether a seated player is a bot
+func (g *Game) SetPlayerBot(playerID string, isBot bool) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return fmt.Errorf("player not found")
+	}
+	if player.IsBot != isBot {
+		player.IsBot = isBot
+		g.publishSnapshot()
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func botTable(t *testing.T, hand string, board string, opponents int) *models.Table {
+	cards, err := ParseCards(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	community, err := ParseCards(board)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot := models.NewPlayer("bot", "Bot", 0, 1000)
+	bot.Cards = cards
+	bot.Status = models.StatusActive
+	players := []*models.Player{bot}
+	for i := 1; i <= opponents; i++ {
+		p := models.NewPlayer("p"+string(rune('0'+i)), "Player", i, 1000)
+		p.Status = models.StatusActive
+		players = append(players, p)
+	}
+	players[1].Bet = 100
+	return &models.Table{
+		TableID:  "bot-table",
+		GameType: models.GameTypeCash,
+		Config:   models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 6},
+		Players:  players,
+		CurrentHand: &models.CurrentHand{
+			BettingRound:   models.RoundFlop,
+			CommunityCards: community,
+			Pot:            models.Pot{Main: 200},
+			CurrentBet:     100,
+		},
+	}
+}
+
+func facingBet() models.ActionRequiredEvent {
+	return models.ActionRequiredEvent{
+		PlayerID:     "bot",
+		LegalActions: []models.PlayerAction{models.ActionFold, models.ActionCall, models.ActionRaise, models.ActionAllIn},
+		CallAmount:   100,
+		MinRaiseTo:   200,
+		MaxRaiseTo:   1000,
+	}
+}
+
+func TestNewBot_RejectsUnknownDifficulty(t *testing.T) {
+	if _, err := NewBot("expert", 1); !errors.Is(err, ErrUnknownBotDifficulty) {
+		t.Errorf("Expected ErrUnknownBotDifficulty, got %v", err)
+	}
+	for _, difficulty := range []string{BotEasy, BotMedium, BotHard} {
+		if _, err := NewBot(difficulty, 1); err != nil {
+			t.Errorf("NewBot(%q) failed: %v", difficulty, err)
+		}
+	}
+}
+
+func TestBot_TightAggressive(t *testing.T) {
+	bot, err := NewBot(BotMedium, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Top set raises, within the turn's bounds
+	action, amount := bot.Decide(botTable(t, "AhAd", "As7c2d", 1), facingBet())
+	if action != models.ActionRaise || amount < 200 || amount > 1000 {
+		t.Errorf("Expected a raise with top set, got %s %d", action, amount)
+	}
+
+	// Nothing folds to a bet
+	if action, _ := bot.Decide(botTable(t, "8h3d", "AsKcQd", 2), facingBet()); action != models.ActionFold {
+		t.Errorf("Expected a fold with no hand, got %s", action)
+	}
+
+	// Nothing checks when it can
+	turn := models.ActionRequiredEvent{
+		PlayerID:     "bot",
+		LegalActions: []models.PlayerAction{models.ActionFold, models.ActionCheck, models.ActionRaise, models.ActionAllIn},
+		MinRaiseTo:   20,
+		MaxRaiseTo:   1000,
+	}
+	table := botTable(t, "8h3d", "AsKcQd", 2)
+	table.Players[1].Bet = 0
+	if action, _ := bot.Decide(table, turn); action != models.ActionCheck {
+		t.Errorf("Expected a check with no hand, got %s", action)
+	}
+}
+
+func TestBot_OnlyLegalActions(t *testing.T) {
+	bot, err := NewBot(BotHard, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Facing an all-in there is no raise to make, so a strong hand calls
+	turn := models.ActionRequiredEvent{
+		PlayerID:     "bot",
+		LegalActions: []models.PlayerAction{models.ActionFold, models.ActionCall},
+		CallAmount:   100,
+	}
+	if action, _ := bot.Decide(botTable(t, "AhAd", "As7c2d", 1), turn); action != models.ActionCall {
+		t.Errorf("Expected a call, got %s", action)
+	}
+
+	// A raise that comes to the whole stack is an all-in
+	table := botTable(t, "AhAd", "As7c2d", 1)
+	table.Players[0].Chips = 150
+	turn = facingBet()
+	turn.MaxRaiseTo = 150
+	if action, _ := bot.Decide(table, turn); action != models.ActionAllIn {
+		t.Errorf("Expected an all-in, got %s", action)
+	}
+
+	// A player the table doesn't know checks or folds
+	turn.PlayerID = "nobody"
+	if action, _ := bot.Decide(table, turn); action != models.ActionFold {
+		t.Errorf("Expected a fold, got %s", action)
+	}
+}
+
+func TestGame_SetPlayerBot(t *testing.T) {
+	table := &models.Table{
+		TableID:     "bot-flag-table",
+		GameType:    models.GameTypeCash,
+		Status:      models.StatusWaiting,
+		Config:      models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 2},
+		Players:     []*models.Player{models.NewPlayer("p1", "Player 1", 0, 1000), nil},
+		CurrentHand: &models.CurrentHand{DealerPosition: -1},
+	}
+	game := NewGame(table, func(string, uint64) {}, func(models.Event) {})
+	game.SetSynchronousEvents(true)
+	game.Snapshot()
+
+	if err := game.SetPlayerBot("p9", true); err == nil {
+		t.Error("Expected an error marking an unknown player")
+	}
+	if err := game.SetPlayerBot("p1", true); err != nil {
+		t.Fatalf("SetPlayerBot failed: %v", err)
+	}
+	if !game.Snapshot().Players[0].IsBot {
+		t.Error("Expected the snapshot to mark the bot")
+	}
+}
@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"poker-engine/models"
+)
+
+// ButtonAudit describes the button and blind assignment of the current hand
+type ButtonAudit struct {
+	HandNumber         int      `json:"hand_number"`
+	Status             string   `json:"status"`
+	DealerPosition     int      `json:"dealer_position"`
+	SmallBlindPosition int      `json:"small_blind_position"`
+	BigBlindPosition   int      `json:"big_blind_position"`
+	ForcedButton       *int     `json:"forced_button,omitempty"`
+	LastCorrections    []string `json:"last_corrections,omitempty"`
+}
+
+// AuditButton returns the current button and blind positions together with any
+// pending forced button and the corrections applied when the hand started
+func (t *Table) AuditButton() ButtonAudit {
+	return t.game.AuditButton()
+}
+
+// ForceButton places the button on the given seat for the next hand.
+// It is meant for correcting a stuck or misplaced button on a live table.
+func (t *Table) ForceButton(seat int) error {
+	return t.game.ForceButton(seat)
+}
+
+// RestoreHandPosition carries the hand number and button over from a previous
+// run of the table, so hand numbers keep counting up and the button moves on
+// from where it was after a restart. Only allowed between hands.
+func (t *Table) RestoreHandPosition(lastHandNumber, dealerPosition int) error {
+	return t.game.RestoreHandPosition(lastHandNumber, dealerPosition)
+}
+
+// AuditButton returns a consistent view of the button state
+func (g *Game) AuditButton() ButtonAudit {
+	g.mu.Lock()
+	defer g.unlock()
+
+	audit := ButtonAudit{
+		Status:          string(g.table.Status),
+		LastCorrections: append([]string(nil), g.positionCorrections...),
+	}
+	if h := g.table.CurrentHand; h != nil {
+		audit.HandNumber = h.HandNumber
+		audit.DealerPosition = h.DealerPosition
+		audit.SmallBlindPosition = h.SmallBlindPosition
+		audit.BigBlindPosition = h.BigBlindPosition
+	}
+	if g.forcedButton != nil {
+		seat := *g.forcedButton
+		audit.ForcedButton = &seat
+	}
+	return audit
+}
+
+// ForceButton validates the seat and stores it as the next hand's button
+func (g *Game) ForceButton(seat int) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if seat < 0 || seat >= len(g.table.Players) {
+		return fmt.Errorf("invalid seat number")
+	}
+	if !isActiveWithChips(g.table.Players[seat]) {
+		return fmt.Errorf("seat %d has no active player with chips", seat)
+	}
+
+	g.forcedButton = &seat
+	return nil
+}
+
+// RestoreHandPosition validates and applies a recovered hand number and button
+func (g *Game) RestoreHandPosition(lastHandNumber, dealerPosition int) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if g.table.Status == models.StatusPlaying {
+		return fmt.Errorf("cannot restore hand position while a hand is in progress")
+	}
+	if lastHandNumber < 0 {
+		return fmt.Errorf("invalid hand number %d", lastHandNumber)
+	}
+	if dealerPosition < -1 || dealerPosition >= len(g.table.Players) {
+		return fmt.Errorf("invalid dealer position %d", dealerPosition)
+	}
+
+	if g.table.CurrentHand == nil {
+		g.table.CurrentHand = &models.CurrentHand{}
+	}
+	g.table.CurrentHand.HandNumber = lastHandNumber
+	g.table.CurrentHand.DealerPosition = dealerPosition
+	g.lastBlinds = nil // The blinds are placed behind the restored button
+	g.publishSnapshot()
+	return nil
+}
+
+// takeForcedButton consumes a forced button if its seat can still hold the button.
+// Caller must hold g.mu.
+func (g *Game) takeForcedButton() (int, bool) {
+	if g.forcedButton == nil {
+		return 0, false
+	}
+	seat := *g.forcedButton
+	g.forcedButton = nil
+
+	if seat >= len(g.table.Players) || !isActiveWithChips(g.table.Players[seat]) {
+		log.Printf("[BUTTON] Forced button seat %d is no longer playable on table %s - ignoring", seat, g.table.TableID)
+		return 0, false
+	}
+	return seat, true
+}
+
+// positionViolations checks that the button sits on a live player and that the
+// blinds follow it clockwise (heads-up: the button posts the small blind)
+func positionViolations(pf *PositionFinder, dealerPos, sbPos, bbPos, activePlayers int) []string {
+	var violations []string
+	inRange := func(pos int) bool { return pos >= 0 && pos < len(pf.players) }
+
+	if !inRange(dealerPos) || !isActiveWithChips(pf.players[dealerPos]) {
+		violations = append(violations, fmt.Sprintf("button on seat %d without an active player", dealerPos))
+		return violations
+	}
+	if !inRange(sbPos) || !inRange(bbPos) {
+		violations = append(violations, fmt.Sprintf("blind positions %d/%d out of range", sbPos, bbPos))
+		return violations
+	}
+
+	if activePlayers == 2 {
+		if sbPos != dealerPos {
+			violations = append(violations, fmt.Sprintf("heads-up small blind on seat %d, expected button seat %d", sbPos, dealerPos))
+		}
+	} else if expected := pf.findNextWithChips(dealerPos); sbPos != expected {
+		violations = append(violations, fmt.Sprintf("small blind on seat %d, expected %d", sbPos, expected))
+	}
+	if expected := pf.findNextWithChips(sbPos); bbPos != expected || bbPos == sbPos {
+		violations = append(violations, fmt.Sprintf("big blind on seat %d, expected %d", bbPos, expected))
+	}
+	return violations
+}
+
+// enforcePositionInvariants validates the positions chosen for a new hand and
+// recomputes them from the next playable button seat when they are inconsistent.
+// Corrections are kept for AuditButton and reported by emitPositionCorrections.
+// Caller must hold g.mu.
+func (g *Game) enforcePositionInvariants(pf *PositionFinder, seats BlindSeats, activePlayers int) BlindSeats {
+	corrected, violations := correctBlindSeats(pf, seats, activePlayers)
+	g.positionCorrections = violations
+	if len(violations) == 0 {
+		return seats
+	}
+
+	log.Printf("[BUTTON] WARNING: Corrected positions on table %s: dealer %d->%d, SB %d->%d, BB %d->%d (%v)",
+		g.table.TableID, seats.Button, corrected.Button, seats.SmallBlind, corrected.SmallBlind,
+		seats.BigBlind, corrected.BigBlind, violations)
+
+	return corrected
+}
+
+// emitPositionCorrections fires a positionsCorrected warning event for the hand
+// that just started, if its positions had to be fixed. Caller must hold g.mu.
+func (g *Game) emitPositionCorrections() {
+	if len(g.positionCorrections) == 0 || g.onEvent == nil {
+		return
+	}
+	// CRITICAL DEADLOCK FIX: Fire event asynchronously
+	event := models.Event{
+		Event:   "positionsCorrected",
+		TableID: g.table.TableID,
+		Data: map[string]interface{}{
+			"handNumber":         g.table.CurrentHand.HandNumber,
+			"violations":         append([]string(nil), g.positionCorrections...),
+			"dealerPosition":     g.table.CurrentHand.DealerPosition,
+			"smallBlindPosition": g.table.CurrentHand.SmallBlindPosition,
+			"bigBlindPosition":   g.table.CurrentHand.BigBlindPosition,
+		},
+	}
+	g.emit(event)
+}
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"poker-engine/models"
+	"sort"
+	"time"
+)
+
+// ColorUpMethod decides how odd chips are handled when a denomination is removed
+type ColorUpMethod string
+
+const (
+	// ColorUpChipRace pools odd chips and races for the new chips: each player
+	// draws once per odd chip and the best draws each win one chip, at most one
+	// per player. Nobody can be raced off the table.
+	ColorUpChipRace ColorUpMethod = "chip_race"
+	// ColorUpRoundUp rounds every odd amount up to one chip of the new denomination
+	ColorUpRoundUp ColorUpMethod = "round_up"
+)
+
+// ColorUpResult describes how one player's stack changed in a color-up
+type ColorUpResult struct {
+	PlayerID    string `json:"playerId"`
+	ChipsBefore int    `json:"chipsBefore"`
+	ChipsAfter  int    `json:"chipsAfter"`
+	OddChips    int    `json:"oddChips"` // Chips of the removed denomination that could not be exchanged
+	WonRace     bool   `json:"wonRace"`
+}
+
+// colorUpRequest is a color-up waiting for the current hand to end
+type colorUpRequest struct {
+	removedDenomination int
+	minDenomination     int
+	method              ColorUpMethod
+}
+
+// ColorUp schedules removal of chips smaller than minDenomination. It is applied
+// before the next hand starts, so stacks never change mid-hand.
+func (t *Table) ColorUp(removedDenomination, minDenomination int, method ColorUpMethod) error {
+	return t.game.ColorUp(removedDenomination, minDenomination, method)
+}
+
+// ColorUp validates and queues a color-up request
+func (g *Game) ColorUp(removedDenomination, minDenomination int, method ColorUpMethod) error {
+	if removedDenomination <= 0 || minDenomination <= removedDenomination {
+		return fmt.Errorf("new minimum denomination %d must exceed removed denomination %d", minDenomination, removedDenomination)
+	}
+	switch method {
+	case ColorUpChipRace, ColorUpRoundUp:
+	default:
+		return fmt.Errorf("unknown color-up method %q", method)
+	}
+
+	g.mu.Lock()
+	defer g.unlock()
+
+	g.pendingColorUp = &colorUpRequest{
+		removedDenomination: removedDenomination,
+		minDenomination:     minDenomination,
+		method:              method,
+	}
+	return nil
+}
+
+// applyColorUp runs a pending color-up and fires a colorUp event with the results.
+// Caller must hold g.mu.
+func (g *Game) applyColorUp() {
+	req := g.pendingColorUp
+	if req == nil {
+		return
+	}
+	g.pendingColorUp = nil
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	results := colorUpStacks(g.table.Players, req.removedDenomination, req.minDenomination, req.method, rng)
+
+	log.Printf("[COLOR_UP] Table %s: removed %d chips, minimum denomination now %d (%s, %d stacks changed)",
+		g.table.TableID, req.removedDenomination, req.minDenomination, req.method, len(results))
+
+	g.publishSnapshot()
+	// CRITICAL DEADLOCK FIX: Fire event asynchronously
+	if g.onEvent != nil {
+		event := models.Event{
+			Event:   "colorUp",
+			TableID: g.table.TableID,
+			Data: map[string]interface{}{
+				"removedDenomination": req.removedDenomination,
+				"minDenomination":     req.minDenomination,
+				"method":              string(req.method),
+				"results":             results,
+			},
+		}
+		g.emit(event)
+	}
+}
+
+// colorUpStacks rounds every stack to a multiple of newMin and returns the
+// stacks that held odd chips. Stacks are assumed to be made of oldMin chips.
+func colorUpStacks(players []*models.Player, oldMin, newMin int, method ColorUpMethod, rng *rand.Rand) []ColorUpResult {
+	type contender struct {
+		player *models.Player
+		result *ColorUpResult
+		draw   int
+	}
+
+	var results []ColorUpResult
+	var contenders []contender
+	totalOdd := 0
+
+	for _, p := range players {
+		if p == nil || p.Chips <= 0 {
+			continue
+		}
+		remainder := p.Chips % newMin
+		if remainder == 0 {
+			continue
+		}
+
+		oddChips := (remainder + oldMin - 1) / oldMin
+		results = append(results, ColorUpResult{
+			PlayerID:    p.PlayerID,
+			ChipsBefore: p.Chips,
+			ChipsAfter:  p.Chips - remainder,
+			OddChips:    oddChips,
+		})
+		totalOdd += remainder
+
+		// One draw per odd chip; only the best draw counts
+		best := 0
+		for i := 0; i < oddChips; i++ {
+			if draw := rng.Intn(1 << 30); draw > best {
+				best = draw
+			}
+		}
+		contenders = append(contenders, contender{player: p, draw: best})
+	}
+
+	for i := range contenders {
+		contenders[i].result = &results[i]
+	}
+
+	switch method {
+	case ColorUpRoundUp:
+		for _, c := range contenders {
+			c.result.ChipsAfter += newMin
+			c.result.WonRace = true
+		}
+	default:
+		// The pooled odd chips buy this many new chips, rounding half up
+		awards := (totalOdd + newMin/2) / newMin
+		sort.SliceStable(contenders, func(i, j int) bool { return contenders[i].draw > contenders[j].draw })
+		for i, c := range contenders {
+			if i < awards {
+				c.result.ChipsAfter += newMin
+				c.result.WonRace = true
+			} else if c.result.ChipsAfter == 0 {
+				// A player cannot be raced off; they keep one chip of the new denomination
+				c.result.ChipsAfter = newMin
+			}
+		}
+	}
+
+	for _, c := range contenders {
+		c.player.Chips = c.result.ChipsAfter
+	}
+	return results
+}
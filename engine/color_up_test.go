@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"math/rand"
+	"poker-engine/models"
+	"testing"
+)
+
+func newColorUpPlayers(stacks ...int) []*models.Player {
+	players := make([]*models.Player, len(stacks))
+	for i, chips := range stacks {
+		players[i] = models.NewPlayer(string(rune('a'+i)), "Player", i, chips)
+	}
+	return players
+}
+
+func TestColorUpStacks_ChipRace(t *testing.T) {
+	// 5-chips removed, 25 is the new minimum. Odd amounts: 10, 15, 5, 0 = 30 -> one new chip
+	players := newColorUpPlayers(1010, 515, 5, 2000)
+
+	results := colorUpStacks(players, 5, 25, ColorUpChipRace, rand.New(rand.NewSource(1)))
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 stacks with odd chips, got %d", len(results))
+	}
+
+	winners := 0
+	for _, r := range results {
+		if r.WonRace {
+			winners++
+		}
+	}
+	if winners != 1 {
+		t.Errorf("Expected exactly one race winner, got %d", winners)
+	}
+
+	for _, p := range players {
+		if p.Chips%25 != 0 {
+			t.Errorf("Player %s left with %d, not a multiple of 25", p.PlayerID, p.Chips)
+		}
+		if p.Chips == 0 {
+			t.Errorf("Player %s was raced off the table", p.PlayerID)
+		}
+	}
+	if players[3].Chips != 2000 {
+		t.Errorf("Stack without odd chips should be untouched, got %d", players[3].Chips)
+	}
+}
+
+func TestColorUpStacks_RoundUp(t *testing.T) {
+	players := newColorUpPlayers(1010, 1025, 5)
+
+	colorUpStacks(players, 5, 25, ColorUpRoundUp, rand.New(rand.NewSource(1)))
+
+	for i, want := range []int{1025, 1025, 25} {
+		if players[i].Chips != want {
+			t.Errorf("Player %d: expected %d chips, got %d", i, want, players[i].Chips)
+		}
+	}
+}
+
+func TestTable_ColorUpAppliedBeforeNextHand(t *testing.T) {
+	config := models.TableConfig{SmallBlind: 5, BigBlind: 10, MaxPlayers: 3, StartingChips: 1000, ActionTimeout: 0}
+	table := NewTable("color-up", models.GameTypeTournament, config, nil, nil)
+	for i, id := range []string{"p1", "p2", "p3"} {
+		table.AddPlayer(id, id, i, 0)
+	}
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+
+	if err := table.ColorUp(5, 25, ColorUpChipRace); err != nil {
+		t.Fatalf("ColorUp failed: %v", err)
+	}
+	if err := table.ColorUp(25, 25, ColorUpChipRace); err == nil {
+		t.Error("Expected error when the new minimum does not exceed the removed denomination")
+	}
+	if err := table.ColorUp(5, 25, "coin_flip"); err == nil {
+		t.Error("Expected error for an unknown method")
+	}
+
+	foldToEnd(t, table)
+	if err := table.DealNewHand(); err != nil {
+		t.Fatalf("Failed to deal next hand: %v", err)
+	}
+
+	for _, p := range table.GetState().Players {
+		if (p.Chips+p.Bet)%25 != 0 {
+			t.Errorf("Player %s stack %d+%d is not colored up", p.PlayerID, p.Chips, p.Bet)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+)
+
+// BlindSeats are the seats of a hand's button and blinds. Under the dead button
+// rule the big blind always moves to the next player and the small blind and
+// button follow it onto the seats the blinds had last hand, so the button can
+// sit on an empty seat and the small blind can be dead when that player busted
+// or left.
+type BlindSeats struct {
+	Button         int  `json:"button"`
+	SmallBlind     int  `json:"smallBlind"`
+	BigBlind       int  `json:"bigBlind"`
+	DeadButton     bool `json:"deadButton,omitempty"`     // Nobody is dealt in on the button seat
+	DeadSmallBlind bool `json:"deadSmallBlind,omitempty"` // Nobody posts the small blind
+}
+
+// blindSeatsForNewHand picks the button and blinds of the hand being dealt:
+// a replayed hand's recorded seats, an operator's forced button, or the dead
+// button rule. Caller must hold g.mu.
+func (g *Game) blindSeatsForNewHand(pf *PositionFinder, activePlayers int) BlindSeats {
+	if g.forcedBlinds != nil {
+		seats := *g.forcedBlinds
+		g.forcedBlinds = nil
+		g.positionCorrections = nil
+		return seats
+	}
+
+	var forced *int
+	if seat, ok := g.takeForcedButton(); ok {
+		log.Printf("[BUTTON] Using forced button seat %d on table %s", seat, g.table.TableID)
+		forced = &seat
+	}
+	return g.enforcePositionInvariants(pf, g.nextBlindSeats(pf, activePlayers, forced), activePlayers)
+}
+
+// nextBlindSeats works out the next hand's button and blinds. The big blind
+// moves on from last hand's big blind to the next player with chips, so busted
+// or departed players never make anyone skip or repeat it. Heads-up the other
+// player has the button and posts the small blind. A forced button, or a table
+// without a last hand to follow, moves the button and places the blinds
+// behind it. Caller must hold g.mu.
+func (g *Game) nextBlindSeats(pf *PositionFinder, activePlayers int, forcedButton *int) BlindSeats {
+	if forcedButton != nil {
+		return blindsBehindButton(pf, *forcedButton, activePlayers)
+	}
+
+	last := g.lastBlinds
+	if last == nil {
+		hand := g.table.CurrentHand
+		if hand == nil || hand.DealerPosition < 0 || hand.DealerPosition >= len(pf.players) {
+			return blindsBehindButton(pf, pf.findFirstWithChips(), activePlayers)
+		}
+		return blindsBehindButton(pf, pf.findNextWithChips(hand.DealerPosition), activePlayers)
+	}
+
+	bb := pf.findNextWithChips(last.BigBlind)
+	if activePlayers == 2 {
+		button := pf.findNextWithChips(bb)
+		return BlindSeats{Button: button, SmallBlind: button, BigBlind: bb}
+	}
+
+	seats := BlindSeats{Button: last.SmallBlind, SmallBlind: last.BigBlind, BigBlind: bb}
+	if seats.Button == bb {
+		// Players joined between the blinds, so there is no seat left for the
+		// button behind the small blind; move it on normally instead
+		return blindsBehindButton(pf, pf.findNextWithChips(last.Button), activePlayers)
+	}
+	seats.DeadButton = !isActiveWithChips(pf.players[seats.Button])
+	seats.DeadSmallBlind = !isActiveWithChips(pf.players[seats.SmallBlind])
+	return seats
+}
+
+// blindsBehindButton places the blinds on the live players after the button
+func blindsBehindButton(pf *PositionFinder, button, activePlayers int) BlindSeats {
+	sb, bb := pf.calculateBlindPositions(button, activePlayers)
+	return BlindSeats{Button: button, SmallBlind: sb, BigBlind: bb}
+}
+
+// deadButtonViolations checks positions with a dead button or small blind: the
+// big blind must be live, and no live player may sit between the button, the
+// small blind and the big blind
+func deadButtonViolations(pf *PositionFinder, seats BlindSeats) []string {
+	inRange := func(pos int) bool { return pos >= 0 && pos < len(pf.players) }
+
+	if !inRange(seats.BigBlind) || !isActiveWithChips(pf.players[seats.BigBlind]) {
+		return []string{fmt.Sprintf("big blind on seat %d without an active player", seats.BigBlind)}
+	}
+	if !inRange(seats.Button) || !inRange(seats.SmallBlind) {
+		return []string{fmt.Sprintf("button and small blind positions %d/%d out of range", seats.Button, seats.SmallBlind)}
+	}
+	if seats.Button == seats.SmallBlind || seats.Button == seats.BigBlind || seats.SmallBlind == seats.BigBlind {
+		return []string{fmt.Sprintf("button and blinds share seats %d/%d/%d", seats.Button, seats.SmallBlind, seats.BigBlind)}
+	}
+
+	var violations []string
+	if expected := pf.findNextWithChips(seats.SmallBlind); expected != seats.BigBlind {
+		violations = append(violations, fmt.Sprintf("big blind on seat %d, expected %d", seats.BigBlind, expected))
+	}
+	first := seats.SmallBlind
+	if seats.DeadSmallBlind {
+		first = seats.BigBlind
+	}
+	if next := pf.findNextWithChips(seats.Button); next != first {
+		violations = append(violations, fmt.Sprintf("player on seat %d sits between the button and the blinds", next))
+	}
+	return violations
+}
+
+// correctBlindSeats validates positions and recomputes them from the next
+// playable button seat when they are inconsistent, returning what was wrong
+func correctBlindSeats(pf *PositionFinder, seats BlindSeats, activePlayers int) (BlindSeats, []string) {
+	var violations []string
+	if seats.DeadButton || seats.DeadSmallBlind {
+		violations = deadButtonViolations(pf, seats)
+	} else {
+		violations = positionViolations(pf, seats.Button, seats.SmallBlind, seats.BigBlind, activePlayers)
+	}
+	if len(violations) == 0 {
+		return seats, nil
+	}
+
+	dealerPos := seats.Button
+	if dealerPos < 0 || dealerPos >= len(pf.players) {
+		dealerPos = pf.findFirstWithChips()
+	} else if !isActiveWithChips(pf.players[dealerPos]) {
+		dealerPos = pf.findNextWithChips(dealerPos)
+	}
+	return blindsBehindButton(pf, dealerPos, activePlayers), violations
+}
@@ -0,0 +1,196 @@
+package engine
+
+import (
+	"testing"
+
+	"poker-engine/models"
+)
+
+// startDeadButtonHand starts the next hand and checks NextBigBlind predicted
+// it. StartGame places the first button itself, so the first hand isn't checked.
+func startDeadButtonHand(t *testing.T, table *Table) *models.CurrentHand {
+	t.Helper()
+	first := table.GetState().CurrentHand.HandNumber == 0
+	expected, ok := table.NextBigBlind()
+	if !ok {
+		t.Fatal("Expected a next big blind")
+	}
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	state := table.GetState()
+	if got := state.Players[state.CurrentHand.BigBlindPosition].PlayerID; got != expected && !first {
+		t.Errorf("NextBigBlind predicted %s, but %s posted the big blind", expected, got)
+	}
+	return state.CurrentHand
+}
+
+func bust(table *Table, seat int) {
+	table.GetState().Players[seat].Chips = 0
+}
+
+func TestDeadButton_BigBlindBustsLeavesDeadSmallBlind(t *testing.T) {
+	table := newBalanceTestTable("dsb", 4)
+	hand := startDeadButtonHand(t, table)
+	if hand.DealerPosition != 1 || hand.SmallBlindPosition != 2 || hand.BigBlindPosition != 3 {
+		t.Fatalf("Expected positions 1/2/3, got %d/%d/%d", hand.DealerPosition, hand.SmallBlindPosition, hand.BigBlindPosition)
+	}
+	foldToEnd(t, table)
+	bust(table, 3)
+
+	// Seat 0 is next for the big blind. The small blind falls on the busted
+	// player's empty seat and nobody posts it.
+	hand = startDeadButtonHand(t, table)
+	if hand.DealerPosition != 2 || hand.SmallBlindPosition != 3 || hand.BigBlindPosition != 0 {
+		t.Errorf("Expected positions 2/3/0, got %d/%d/%d", hand.DealerPosition, hand.SmallBlindPosition, hand.BigBlindPosition)
+	}
+	if !hand.DeadSmallBlind {
+		t.Error("Expected a dead small blind")
+	}
+	if hand.CurrentBet != 20 {
+		t.Errorf("Expected only the big blind posted, got current bet %d", hand.CurrentBet)
+	}
+	players := table.GetState().Players
+	if players[3] != nil || !players[2].IsDealer || players[0].Bet != 20 || players[1].Bet != 0 {
+		t.Error("Expected the button on seat 2 and only seat 0 to post")
+	}
+	if current := players[hand.CurrentPosition].PlayerID; current != "dsb-p1" {
+		t.Errorf("Expected seat 1 to act first, got %s", current)
+	}
+	foldToEnd(t, table)
+
+	// Then the button moves onto the empty seat
+	hand = startDeadButtonHand(t, table)
+	if hand.DealerPosition != 3 || hand.SmallBlindPosition != 0 || hand.BigBlindPosition != 1 || hand.DeadSmallBlind {
+		t.Errorf("Expected a dead button on seat 3 with blinds 0/1, got %d/%d/%d", hand.DealerPosition, hand.SmallBlindPosition, hand.BigBlindPosition)
+	}
+	foldToEnd(t, table)
+
+	hand = startDeadButtonHand(t, table)
+	if hand.DealerPosition != 0 || hand.SmallBlindPosition != 1 || hand.BigBlindPosition != 2 {
+		t.Errorf("Expected positions 0/1/2, got %d/%d/%d", hand.DealerPosition, hand.SmallBlindPosition, hand.BigBlindPosition)
+	}
+}
+
+func TestDeadButton_SmallBlindBustsLeavesDeadButton(t *testing.T) {
+	table := newBalanceTestTable("db", 5)
+	startDeadButtonHand(t, table)
+	foldToEnd(t, table)
+	bust(table, 2)
+
+	hand := startDeadButtonHand(t, table)
+	if hand.DealerPosition != 2 || hand.SmallBlindPosition != 3 || hand.BigBlindPosition != 4 || hand.DeadSmallBlind {
+		t.Errorf("Expected a dead button on seat 2 with blinds 3/4, got %d/%d/%d", hand.DealerPosition, hand.SmallBlindPosition, hand.BigBlindPosition)
+	}
+	players := table.GetState().Players
+	if players[3].Bet != 10 || players[4].Bet != 20 {
+		t.Errorf("Expected both blinds posted, got %d/%d", players[3].Bet, players[4].Bet)
+	}
+	for _, p := range players {
+		if p != nil && p.IsDealer {
+			t.Errorf("Expected nobody to hold a dead button, got %s", p.PlayerID)
+		}
+	}
+
+	// The small blind acts first after the flop
+	for table.GetState().CurrentHand.BettingRound == models.RoundPreflop {
+		p := players[hand.CurrentPosition]
+		action := models.ActionCall
+		if p.Bet == hand.CurrentBet {
+			action = models.ActionCheck
+		}
+		if err := table.ProcessAction(p.PlayerID, action, 0); err != nil {
+			t.Fatalf("%s failed to %s: %v", p.PlayerID, action, err)
+		}
+	}
+	if hand.CurrentPosition != 3 {
+		t.Errorf("Expected seat 3 to act first on the flop, got %d", hand.CurrentPosition)
+	}
+}
+
+func TestDeadButton_BlindsBothBust(t *testing.T) {
+	table := newBalanceTestTable("both", 5)
+	startDeadButtonHand(t, table)
+	foldToEnd(t, table)
+	bust(table, 2)
+	bust(table, 3)
+
+	hand := startDeadButtonHand(t, table)
+	if hand.DealerPosition != 2 || hand.SmallBlindPosition != 3 || hand.BigBlindPosition != 4 || !hand.DeadSmallBlind {
+		t.Errorf("Expected a dead button and small blind with the big blind on 4, got %d/%d/%d", hand.DealerPosition, hand.SmallBlindPosition, hand.BigBlindPosition)
+	}
+}
+
+func TestDeadButton_HeadsUpTransitions(t *testing.T) {
+	// Hand one has the button on seat 1 and the blinds on seats 2 and 0
+	for busted := 0; busted < 3; busted++ {
+		table := newBalanceTestTable("hu", 3)
+		first := startDeadButtonHand(t, table)
+		foldToEnd(t, table)
+		lastBigBlind := table.GetState().Players[first.BigBlindPosition].PlayerID
+		bust(table, busted)
+
+		hand := startDeadButtonHand(t, table)
+		players := table.GetState().Players
+		if hand.DealerPosition != hand.SmallBlindPosition {
+			t.Errorf("seat %d busted: expected the button to post the small blind, got %d/%d", busted, hand.DealerPosition, hand.SmallBlindPosition)
+		}
+		if players[hand.BigBlindPosition].PlayerID == lastBigBlind {
+			t.Errorf("seat %d busted: %s posted the big blind twice in a row", busted, lastBigBlind)
+		}
+		if hand.DeadSmallBlind || players[hand.DealerPosition] == nil {
+			t.Errorf("seat %d busted: expected live heads-up positions, got %d/%d", busted, hand.DealerPosition, hand.BigBlindPosition)
+		}
+		foldToEnd(t, table)
+
+		// Heads-up the big blind alternates
+		next := startDeadButtonHand(t, table)
+		if next.BigBlindPosition != hand.DealerPosition || next.DealerPosition != hand.BigBlindPosition {
+			t.Errorf("seat %d busted: expected the blinds to swap, got %d/%d after %d/%d",
+				busted, next.DealerPosition, next.BigBlindPosition, hand.DealerPosition, hand.BigBlindPosition)
+		}
+	}
+}
+
+func TestDeadButton_NewPlayerJoinsHeadsUp(t *testing.T) {
+	table := newBalanceTestTable("join", 2)
+	startDeadButtonHand(t, table)
+	foldToEnd(t, table)
+	startDeadButtonHand(t, table)
+	foldToEnd(t, table)
+	if _, err := table.SeatPlayer("join-p2", "Player 2", 1000); err != nil {
+		t.Fatalf("SeatPlayer failed: %v", err)
+	}
+
+	// Three-handed again: the big blind still moves one player on
+	last := table.GetState().CurrentHand.BigBlindPosition
+	hand := startDeadButtonHand(t, table)
+	if hand.BigBlindPosition == last {
+		t.Errorf("Expected the big blind to move on from seat %d", last)
+	}
+	if hand.DeadSmallBlind || hand.SmallBlindPosition == hand.DealerPosition {
+		t.Errorf("Expected three live positions, got %d/%d/%d", hand.DealerPosition, hand.SmallBlindPosition, hand.BigBlindPosition)
+	}
+}
+
+func TestDeadButton_ReplayKeepsDeadSmallBlind(t *testing.T) {
+	table := newBalanceTestTable("replay", 4)
+	startDeadButtonHand(t, table)
+	foldToEnd(t, table)
+	bust(table, 3)
+	startDeadButtonHand(t, table)
+	foldToEnd(t, table)
+
+	replayed, err := ReplayHand(table.HandRecord())
+	if err != nil {
+		t.Fatalf("ReplayHand failed: %v", err)
+	}
+	if !replayed.CurrentHand.DeadSmallBlind || replayed.CurrentHand.BigBlindPosition != 0 {
+		t.Errorf("Expected the replay to deal a dead small blind, got big blind %d", replayed.CurrentHand.BigBlindPosition)
+	}
+	for i, p := range table.GetState().Players {
+		if p != nil && replayed.Players[i].Chips != p.Chips {
+			t.Errorf("Seat %d: replay ended with %d chips, original with %d", i, replayed.Players[i].Chips, p.Chips)
+		}
+	}
+}
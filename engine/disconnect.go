@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"poker-engine/models"
+)
+
+// SetPlayerConnected records that a player's connection dropped or came
+// back. A disconnected player keeps their seat and their hand: their first
+// turn gets the table's disconnect grace, later turns the shorter away timer,
+// and only then are they checked or folded. Reconnecting restores the usual
+// timer.
+func (t *Table) SetPlayerConnected(playerID string, connected bool) error {
+	return t.game.SetPlayerConnected(playerID, connected)
+}
+
+// SetPlayerConnected updates a player's connection state and announces the change
+func (g *Game) SetPlayerConnected(playerID string, connected bool) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return fmt.Errorf("player not found")
+	}
+	if player.Disconnected == !connected {
+		return nil
+	}
+	player.Disconnected = !connected
+	player.DisconnectGraceUsed = false
+
+	g.publishSnapshot()
+	g.emit(models.Event{
+		Event:   "playerConnection",
+		TableID: g.table.TableID,
+		Data: map[string]interface{}{
+			"playerId":  playerID,
+			"connected": connected,
+		},
+	})
+
+	// A player who drops on their turn gets the grace for that turn
+	if connected || g.table.Status != models.StatusPlaying || g.table.CurrentHand == nil {
+		return nil
+	}
+	position := g.table.CurrentHand.CurrentPosition
+	if position < 0 || position >= len(g.table.Players) || g.table.Players[position] != player {
+		return nil
+	}
+	grace := time.Duration(g.table.Config.DisconnectGrace) * time.Second
+	if deadline := g.table.CurrentHand.ActionDeadline; deadline != nil && time.Until(*deadline) < grace {
+		g.stopActionTimer()
+		player.DisconnectGraceUsed = true
+		g.armActionTimer(player, grace)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"poker-engine/models"
+)
+
+func newDisconnectTestGame(t *testing.T, events *[]models.Event) (*Game, *models.Table) {
+	t.Helper()
+	table := &models.Table{
+		TableID:  "dc-table",
+		GameType: models.GameTypeCash,
+		Status:   models.StatusWaiting,
+		Config:   models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 2, ActionTimeout: 30, DisconnectGrace: 90},
+		Players: []*models.Player{
+			models.NewPlayer("p1", "Player 1", 0, 1000),
+			models.NewPlayer("p2", "Player 2", 1, 1000),
+		},
+		CurrentHand: &models.CurrentHand{HandNumber: 0, DealerPosition: -1},
+	}
+	game := NewGame(table, func(string, uint64) {}, func(e models.Event) { *events = append(*events, e) })
+	game.SetSynchronousEvents(true)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	return game, table
+}
+
+func assertDeadlineAbout(t *testing.T, table *models.Table, want time.Duration) {
+	t.Helper()
+	remaining := time.Until(*table.CurrentHand.ActionDeadline)
+	if remaining > want+time.Second || remaining < want-time.Second {
+		t.Errorf("Expected a deadline about %v away, got %v", want, remaining)
+	}
+}
+
+func TestDisconnect_GraceOnFirstTurnOnly(t *testing.T) {
+	var events []models.Event
+	game, table := newDisconnectTestGame(t, &events)
+
+	current := table.Players[table.CurrentHand.CurrentPosition]
+	other := table.Players[1-table.CurrentHand.CurrentPosition]
+	if err := game.SetPlayerConnected("p9", false); err == nil {
+		t.Error("Expected an error for an unknown player")
+	}
+	if err := game.SetPlayerConnected(other.PlayerID, false); err != nil {
+		t.Fatalf("SetPlayerConnected failed: %v", err)
+	}
+	if !game.Snapshot().Players[other.SeatNumber].Disconnected {
+		t.Error("Expected the snapshot to show the player as disconnected")
+	}
+
+	// First turn after the disconnect gets the grace
+	if err := game.ProcessAction(current.PlayerID, models.ActionCall, 0); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	assertDeadlineAbout(t, table, 90*time.Second)
+
+	// The player is still in the hand when the grace runs out
+	if err := game.HandleTimeout(other.PlayerID, table.CurrentHand.DeadlineToken); err != nil {
+		t.Fatalf("HandleTimeout failed: %v", err)
+	}
+	if other.Status != models.StatusActive || other.LastAction != models.ActionCheck {
+		t.Fatalf("Expected the disconnected player to be checked, got %s/%s", other.Status, other.LastAction)
+	}
+
+	// Later turns are timed like an away player's
+	for table.Players[table.CurrentHand.CurrentPosition] != other {
+		if err := game.ProcessAction(current.PlayerID, models.ActionCheck, 0); err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+	}
+	assertDeadlineAbout(t, table, 10*time.Second)
+
+	var changes []bool
+	for _, e := range events {
+		if e.Event == "playerConnection" {
+			changes = append(changes, e.Data.(map[string]interface{})["connected"].(bool))
+		}
+	}
+	if len(changes) != 1 || changes[0] {
+		t.Errorf("Expected one playerConnection event for the disconnect, got %v", changes)
+	}
+}
+
+func TestDisconnect_OnOwnTurnExtendsDeadline(t *testing.T) {
+	var events []models.Event
+	game, table := newDisconnectTestGame(t, &events)
+	current := table.Players[table.CurrentHand.CurrentPosition]
+
+	token := table.CurrentHand.DeadlineToken
+	if err := game.SetPlayerConnected(current.PlayerID, false); err != nil {
+		t.Fatalf("SetPlayerConnected failed: %v", err)
+	}
+	assertDeadlineAbout(t, table, 90*time.Second)
+
+	// The timeout of the original deadline is stale now
+	game.HandleTimeout(current.PlayerID, token)
+	if current.Status != models.StatusActive || current.LastAction != "" {
+		t.Fatal("Expected the original deadline's timeout to be ignored")
+	}
+
+	// Coming back restores the usual timer from the next turn
+	if err := game.SetPlayerConnected(current.PlayerID, true); err != nil {
+		t.Fatalf("SetPlayerConnected failed: %v", err)
+	}
+	if current.Disconnected || current.DisconnectGraceUsed {
+		t.Error("Expected reconnecting to clear the disconnect state")
+	}
+	if timeout := game.actionTimeoutFor(current); timeout != 30*time.Second {
+		t.Errorf("Expected the usual 30 second timer, got %v", timeout)
+	}
+}
@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"fmt"
+
+	"poker-engine/models"
+)
+
+// DuplicateDeal fixes the cards, button and stacks of the next hand so the
+// same deal can be played at several tables. Cards are dealt in seat order, so
+// tables with the same seats filled deal the same hole cards to the same seats
+// and run out the same board.
+type DuplicateDeal struct {
+	Seed   int64 `json:"seed"`   // Deck seed of the hand
+	Button int   `json:"button"` // Button seat
+	Stack  int   `json:"stack"`  // Stack every seated player starts the hand with, 0 to keep stacks
+}
+
+// SetNextDeal makes the next hand a duplicate deal. Only allowed between hands.
+func (t *Table) SetNextDeal(deal DuplicateDeal) error {
+	return t.game.SetNextDeal(deal)
+}
+
+// SetNextDeal validates the deal and applies its stacks, button and seed
+func (g *Game) SetNextDeal(deal DuplicateDeal) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if g.table.Status == models.StatusPlaying {
+		return fmt.Errorf("cannot set the next deal while a hand is in progress")
+	}
+	if deal.Stack < 0 {
+		return fmt.Errorf("invalid stack %d", deal.Stack)
+	}
+	if deal.Button < 0 || deal.Button >= len(g.table.Players) || g.table.Players[deal.Button] == nil {
+		return fmt.Errorf("button seat %d has no player", deal.Button)
+	}
+
+	if deal.Stack > 0 {
+		for _, p := range g.table.Players {
+			if p != nil {
+				p.Chips = deal.Stack
+			}
+		}
+	}
+	if !isActiveWithChips(g.table.Players[deal.Button]) {
+		return fmt.Errorf("seat %d has no active player with chips", deal.Button)
+	}
+
+	button := deal.Button
+	seed := deal.Seed
+	g.forcedButton = &button
+	g.nextDeckSeed = &seed
+	g.publishSnapshot()
+	return nil
+}
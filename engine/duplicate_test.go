@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestGame_DuplicateDealRepeatsAcrossTables(t *testing.T) {
+	deal := DuplicateDeal{Seed: 7, Button: 1, Stack: 1000}
+
+	var tables []*models.Table
+	for _, stacks := range [][]int{{500, 1500, 900}, {1200, 300, 2000}} {
+		game, table := newAnteGame(0, stacks...)
+		if err := game.SetNextDeal(deal); err != nil {
+			t.Fatalf("SetNextDeal failed: %v", err)
+		}
+		if err := game.StartNewHand(); err != nil {
+			t.Fatalf("Failed to start hand: %v", err)
+		}
+		if table.CurrentHand.DealerPosition != deal.Button {
+			t.Errorf("Expected the button on seat %d, got %d", deal.Button, table.CurrentHand.DealerPosition)
+		}
+		if err := game.SetNextDeal(deal); err == nil {
+			t.Error("Expected setting a deal during a hand to fail")
+		}
+		playToShowdown(t, game, table)
+		tables = append(tables, table)
+	}
+
+	first, second := tables[0], tables[1]
+	for i := range first.Players {
+		if !reflect.DeepEqual(first.Players[i].Cards, second.Players[i].Cards) {
+			t.Errorf("Expected seat %d to hold the same cards, got %v and %v", i, first.Players[i].Cards, second.Players[i].Cards)
+		}
+		if first.Players[i].Chips != second.Players[i].Chips {
+			t.Errorf("Expected seat %d to end with the same stack, got %d and %d", i, first.Players[i].Chips, second.Players[i].Chips)
+		}
+	}
+	if !reflect.DeepEqual(first.CurrentHand.CommunityCards, second.CurrentHand.CommunityCards) {
+		t.Errorf("Expected the same board, got %v and %v", first.CurrentHand.CommunityCards, second.CurrentHand.CommunityCards)
+	}
+	if totalChips(first) != 3000 {
+		t.Errorf("Expected every stack to start at %d, got %d chips in play", deal.Stack, totalChips(first))
+	}
+}
+
+func TestGame_SetNextDealValidation(t *testing.T) {
+	game, _ := newAnteGame(0, 1000, 0)
+	if err := game.SetNextDeal(DuplicateDeal{Button: 5}); err == nil {
+		t.Error("Expected an empty button seat to be rejected")
+	}
+	if err := game.SetNextDeal(DuplicateDeal{Button: 1}); err == nil {
+		t.Error("Expected a button on a player without chips to be rejected")
+	}
+	if err := game.SetNextDeal(DuplicateDeal{Button: 0, Stack: -1}); err == nil {
+		t.Error("Expected a negative stack to be rejected")
+	}
+	if err := game.SetNextDeal(DuplicateDeal{Button: 1, Stack: 500}); err != nil {
+		t.Errorf("Expected the stack reset to make the button playable, got %v", err)
+	}
+}
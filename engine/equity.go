@@ -0,0 +1,639 @@
+package engine
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+
+	"poker-engine/models"
+)
+
+const (
+	// MaxEquityRanges is the most ranges one calculation accepts
+	MaxEquityRanges = 6
+
+	// exactEquityLimit is the most showdowns enumerated exhaustively; larger
+	// calculations are sampled instead
+	exactEquityLimit = 300000
+
+	// DefaultEquitySamples is the number of deals sampled when a calculation is
+	// too large to enumerate
+	DefaultEquitySamples = 50000
+
+	// maxEquityRejections bounds the deals discarded for reusing a card before a
+	// sampled calculation gives up
+	maxEquityRejections = 20
+)
+
+var (
+	ErrTooFewRanges   = errors.New("at least two ranges are required")
+	ErrTooManyRanges  = fmt.Errorf("at most %d ranges are allowed", MaxEquityRanges)
+	ErrInvalidBoard   = errors.New("board must have 0, 3, 4 or 5 distinct cards")
+	ErrNoPossibleDeal = errors.New("the ranges and board leave no possible deal")
+)
+
+var (
+	equityRanks = "23456789TJQKA"
+	equitySuits = "hdcs"
+)
+
+// Combo is one two-card starting hand
+type Combo [2]models.Card
+
+func (c Combo) String() string {
+	return c[0].String() + c[1].String()
+}
+
+func (c Combo) mask() uint64 {
+	return cardBit(c[0]) | cardBit(c[1])
+}
+
+// Range is the set of starting hands a player may hold
+type Range struct {
+	Notation string
+	Combos   []Combo
+}
+
+// ParseRange parses a range written in the usual notation: pairs ("QQ"),
+// suited and offsuit hands ("AKs", "AKo", or "AK" for both), "+" to include
+// every better kicker or pair ("77+", "ATs+"), dashes for spans ("A2s-A5s",
+// "22-66") and single combos ("AhKd"), separated by commas or spaces.
+func ParseRange(notation string) (Range, error) {
+	r := Range{Notation: strings.TrimSpace(notation)}
+	seen := make(map[uint64]bool)
+
+	tokens := strings.FieldsFunc(notation, func(c rune) bool { return c == ',' || c == ' ' })
+	if len(tokens) == 0 {
+		return r, fmt.Errorf("empty range")
+	}
+	for _, token := range tokens {
+		combos, err := parseRangeToken(token)
+		if err != nil {
+			return r, fmt.Errorf("range %q: %w", notation, err)
+		}
+		for _, combo := range combos {
+			if m := combo.mask(); !seen[m] {
+				seen[m] = true
+				r.Combos = append(r.Combos, combo)
+			}
+		}
+	}
+	return r, nil
+}
+
+// ParseCards parses a run of cards such as a board: "Ah7d2c"
+func ParseCards(s string) ([]models.Card, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	if len(s)%2 != 0 {
+		return nil, fmt.Errorf("invalid cards %q", s)
+	}
+	cards := make([]models.Card, 0, len(s)/2)
+	for i := 0; i < len(s); i += 2 {
+		card, ok := parseCard(s[i], s[i+1])
+		if !ok {
+			return nil, fmt.Errorf("invalid card %q", s[i:i+2])
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// parseRangeToken expands one hand class, span or combo into its combos
+func parseRangeToken(token string) ([]Combo, error) {
+	if len(token) == 4 && strings.IndexByte(equitySuits, token[1]) >= 0 {
+		first, ok1 := parseCard(token[0], token[1])
+		second, ok2 := parseCard(token[2], token[3])
+		if !ok1 || !ok2 || first == second {
+			return nil, fmt.Errorf("invalid combo %q", token)
+		}
+		return []Combo{{first, second}}, nil
+	}
+
+	if from, to, ok := strings.Cut(token, "-"); ok {
+		hi1, lo1, kind1, err1 := parseHandClass(from)
+		hi2, lo2, kind2, err2 := parseHandClass(to)
+		if err1 != nil || err2 != nil || kind1 != kind2 {
+			return nil, fmt.Errorf("invalid span %q", token)
+		}
+		var combos []Combo
+		switch {
+		case hi1 == lo1 && hi2 == lo2:
+			lo, hi := minInt(hi1, hi2), maxInt(hi1, hi2)
+			for rank := lo; rank <= hi; rank++ {
+				combos = append(combos, handClassCombos(rank, rank, kind1)...)
+			}
+		case hi1 == hi2 && hi1 != lo1 && hi2 != lo2:
+			lo, hi := minInt(lo1, lo2), maxInt(lo1, lo2)
+			for kicker := lo; kicker <= hi; kicker++ {
+				combos = append(combos, handClassCombos(hi1, kicker, kind1)...)
+			}
+		default:
+			return nil, fmt.Errorf("invalid span %q", token)
+		}
+		return combos, nil
+	}
+
+	plus := strings.HasSuffix(token, "+")
+	hi, lo, kind, err := parseHandClass(strings.TrimSuffix(token, "+"))
+	if err != nil {
+		return nil, err
+	}
+	if !plus {
+		return handClassCombos(hi, lo, kind), nil
+	}
+
+	var combos []Combo
+	if hi == lo {
+		for rank := hi; rank < len(equityRanks); rank++ {
+			combos = append(combos, handClassCombos(rank, rank, kind)...)
+		}
+		return combos, nil
+	}
+	for kicker := lo; kicker < hi; kicker++ {
+		combos = append(combos, handClassCombos(hi, kicker, kind)...)
+	}
+	return combos, nil
+}
+
+// parseHandClass parses "AKs", "AKo", "AK" or "QQ" into rank indexes, higher
+// first, and the suitedness: 's', 'o' or 0 for either
+func parseHandClass(s string) (hi, lo int, kind byte, err error) {
+	if len(s) != 2 && len(s) != 3 {
+		return 0, 0, 0, fmt.Errorf("invalid hand %q", s)
+	}
+	hi = strings.IndexByte(equityRanks, upperByte(s[0]))
+	lo = strings.IndexByte(equityRanks, upperByte(s[1]))
+	if hi < 0 || lo < 0 {
+		return 0, 0, 0, fmt.Errorf("invalid hand %q", s)
+	}
+	if lo > hi {
+		hi, lo = lo, hi
+	}
+	if len(s) == 3 {
+		kind = s[2]
+		if (kind != 's' && kind != 'o') || (hi == lo && kind == 's') {
+			return 0, 0, 0, fmt.Errorf("invalid hand %q", s)
+		}
+	}
+	return hi, lo, kind, nil
+}
+
+// handClassCombos lists the combos of a hand class
+func handClassCombos(hi, lo int, kind byte) []Combo {
+	var combos []Combo
+	for s1 := 0; s1 < len(equitySuits); s1++ {
+		for s2 := 0; s2 < len(equitySuits); s2++ {
+			if hi == lo && s2 <= s1 {
+				continue
+			}
+			if (kind == 's' && s1 != s2) || (kind == 'o' && s1 == s2) {
+				continue
+			}
+			combos = append(combos, Combo{equityCard(hi, s1), equityCard(lo, s2)})
+		}
+	}
+	return combos
+}
+
+func parseCard(rank, suit byte) (models.Card, bool) {
+	r := strings.IndexByte(equityRanks, upperByte(rank))
+	s := strings.IndexByte(equitySuits, suit|0x20)
+	if r < 0 || s < 0 {
+		return models.Card{}, false
+	}
+	return equityCard(r, s), true
+}
+
+func equityCard(rank, suit int) models.Card {
+	return models.Card{Rank: models.Rank(equityRanks[rank : rank+1]), Suit: models.Suit(equitySuits[suit : suit+1])}
+}
+
+func upperByte(b byte) byte {
+	if b >= 'a' && b <= 'z' {
+		return b - 0x20
+	}
+	return b
+}
+
+// cardBit gives every card of the deck its own bit
+func cardBit(c models.Card) uint64 {
+	r := strings.IndexByte(equityRanks, c.Rank[0])
+	s := strings.IndexByte(equitySuits, c.Suit[0])
+	return 1 << uint(r*4+s)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// RangeEquity is how one range fares against the others
+type RangeEquity struct {
+	Range  string  `json:"range"`
+	Combos int     `json:"combos"` // Combos left once those blocked by the board are removed
+	Equity float64 `json:"equity"` // Average share of the pot won
+	Win    float64 `json:"win"`    // Share of showdowns won outright
+	Tie    float64 `json:"tie"`    // Share of showdowns split
+}
+
+// EquityResult is the outcome of a range against range calculation
+type EquityResult struct {
+	Ranges    []RangeEquity `json:"ranges"`
+	Board     string        `json:"board"`
+	Showdowns int           `json:"showdowns"` // Showdowns enumerated or sampled
+	Exact     bool          `json:"exact"`     // Every possible deal was enumerated
+}
+
+// CalculateEquity works out the equity of each range against the others on a
+// board. Every deal is enumerated when that takes at most exactEquityLimit
+// showdowns; otherwise the given number of deals is sampled with rng.
+func CalculateEquity(ranges []Range, board []models.Card, samples int, rng *rand.Rand) (*EquityResult, error) {
+	if len(ranges) < 2 {
+		return nil, ErrTooFewRanges
+	}
+	if len(ranges) > MaxEquityRanges {
+		return nil, ErrTooManyRanges
+	}
+
+	var boardMask uint64
+	for _, card := range board {
+		boardMask |= cardBit(card)
+	}
+	if n := len(board); (n != 0 && n < 3) || n > 5 || countBits(boardMask) != n {
+		return nil, ErrInvalidBoard
+	}
+
+	calc := &equityCalc{
+		board:  board,
+		combos: make([][]Combo, len(ranges)),
+		share:  make([]float64, len(ranges)),
+		wins:   make([]int, len(ranges)),
+		ties:   make([]int, len(ranges)),
+		hands:  make([]Combo, len(ranges)),
+		values: make([]int, len(ranges)),
+		full:   make([]models.Card, 5),
+	}
+	copy(calc.full, board)
+
+	work := 1
+	for i, r := range ranges {
+		for _, combo := range r.Combos {
+			if combo.mask()&boardMask == 0 {
+				calc.combos[i] = append(calc.combos[i], combo)
+			}
+		}
+		if len(calc.combos[i]) == 0 {
+			return nil, fmt.Errorf("range %q: %w", r.Notation, ErrNoPossibleDeal)
+		}
+		work = saturatingMul(work, len(calc.combos[i]))
+	}
+	work = saturatingMul(work, binomial(52-len(board)-2*len(ranges), 5-len(board)))
+
+	exact := work <= exactEquityLimit
+	if exact {
+		calc.enumerate(0, boardMask)
+	} else {
+		calc.sample(boardMask, samples, rng)
+	}
+	if calc.showdowns == 0 {
+		return nil, ErrNoPossibleDeal
+	}
+
+	result := &EquityResult{Board: cardsString(board), Showdowns: calc.showdowns, Exact: exact}
+	total := float64(calc.showdowns)
+	for i, r := range ranges {
+		result.Ranges = append(result.Ranges, RangeEquity{
+			Range:  r.Notation,
+			Combos: len(calc.combos[i]),
+			Equity: calc.share[i] / total,
+			Win:    float64(calc.wins[i]) / total,
+			Tie:    float64(calc.ties[i]) / total,
+		})
+	}
+	return result, nil
+}
+
+// equityCalc holds the running totals and scratch space of one calculation
+type equityCalc struct {
+	board  []models.Card
+	combos [][]Combo
+
+	showdowns int
+	share     []float64
+	wins      []int
+	ties      []int
+
+	hands  []Combo
+	values []int
+	full   []models.Card
+	hole   [2]models.Card
+}
+
+// enumerate deals every combo of range i onward that fits with the cards used
+// so far, then runs out every remaining board
+func (c *equityCalc) enumerate(i int, used uint64) {
+	if i < len(c.combos) {
+		for _, combo := range c.combos[i] {
+			if m := combo.mask(); m&used == 0 {
+				c.hands[i] = combo
+				c.enumerate(i+1, used|m)
+			}
+		}
+		return
+	}
+
+	deck := remainingDeck(used)
+	need := 5 - len(c.board)
+	idx := make([]int, need)
+	for k := range idx {
+		idx[k] = k
+	}
+	for {
+		for k, d := range idx {
+			c.full[len(c.board)+k] = deck[d]
+		}
+		c.showdown()
+
+		// Advance to the next combination of runout cards
+		k := need - 1
+		for k >= 0 && idx[k] == len(deck)-need+k {
+			k--
+		}
+		if k < 0 {
+			return
+		}
+		idx[k]++
+		for j := k + 1; j < need; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// sample deals random combos and runouts. A deal that uses a card twice is
+// redrawn from scratch so each possible deal stays equally likely.
+func (c *equityCalc) sample(boardMask uint64, samples int, rng *rand.Rand) {
+	if samples <= 0 {
+		samples = DefaultEquitySamples
+	}
+	rejected := 0
+	for c.showdowns < samples && rejected <= samples*maxEquityRejections {
+		used := boardMask
+		fits := true
+		for i, combos := range c.combos {
+			combo := combos[rng.Intn(len(combos))]
+			m := combo.mask()
+			if m&used != 0 {
+				fits = false
+				break
+			}
+			used |= m
+			c.hands[i] = combo
+		}
+		if !fits {
+			rejected++
+			continue
+		}
+
+		deck := remainingDeck(used)
+		for k := len(c.board); k < 5; k++ {
+			d := k - len(c.board) + rng.Intn(len(deck)-(k-len(c.board)))
+			deck[k-len(c.board)], deck[d] = deck[d], deck[k-len(c.board)]
+			c.full[k] = deck[k-len(c.board)]
+		}
+		c.showdown()
+	}
+}
+
+// showdown evaluates the current deal and splits the pot between the winners
+func (c *equityCalc) showdown() {
+	best := -1
+	for i, hand := range c.hands {
+		c.hole[0], c.hole[1] = hand[0], hand[1]
+		c.values[i] = EvaluateHand(c.hole[:], c.full).Value
+		if c.values[i] > best {
+			best = c.values[i]
+		}
+	}
+
+	winners := 0
+	for _, v := range c.values {
+		if v == best {
+			winners++
+		}
+	}
+	for i, v := range c.values {
+		if v != best {
+			continue
+		}
+		c.share[i] += 1 / float64(winners)
+		if winners == 1 {
+			c.wins[i]++
+		} else {
+			c.ties[i]++
+		}
+	}
+	c.showdowns++
+}
+
+// remainingDeck lists the cards not in used
+func remainingDeck(used uint64) []models.Card {
+	deck := make([]models.Card, 0, 52)
+	for r := 0; r < len(equityRanks); r++ {
+		for s := 0; s < len(equitySuits); s++ {
+			if used&(1<<uint(r*4+s)) == 0 {
+				deck = append(deck, equityCard(r, s))
+			}
+		}
+	}
+	return deck
+}
+
+func countBits(m uint64) int {
+	n := 0
+	for ; m != 0; m &= m - 1 {
+		n++
+	}
+	return n
+}
+
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
+
+// saturatingMul multiplies without overflowing past the exact enumeration limit
+func saturatingMul(a, b int) int {
+	if a > exactEquityLimit || b > exactEquityLimit {
+		return exactEquityLimit + 1
+	}
+	return a * b
+}
+
+func cardsString(cards []models.Card) string {
+	var sb strings.Builder
+	for _, card := range cards {
+		sb.WriteString(card.String())
+	}
+	return sb.String()
+}
+
+// EquityCalculator parses ranges and caches the results of recent
+// calculations, so replaying a spot in training or post-hand analysis is
+// answered without enumerating it again. Safe for concurrent use.
+type EquityCalculator struct {
+	samples  int
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // Most recently used first
+}
+
+type equityCacheEntry struct {
+	key    string
+	result *EquityResult
+}
+
+// NewEquityCalculator creates a calculator that samples the given number of
+// deals when a calculation is too large to enumerate and keeps up to capacity
+// results
+func NewEquityCalculator(samples, capacity int) *EquityCalculator {
+	if samples <= 0 {
+		samples = DefaultEquitySamples
+	}
+	return &EquityCalculator{
+		samples:  samples,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Calculate returns the equity of each range in notation against the others
+// on board. Ranges that expand to the same combos share a cache entry however
+// they were written, and sampled results are seeded from the input so the
+// same question always gets the same answer.
+func (ec *EquityCalculator) Calculate(notations []string, board string) (*EquityResult, error) {
+	if len(notations) < 2 {
+		return nil, ErrTooFewRanges
+	}
+	if len(notations) > MaxEquityRanges {
+		return nil, ErrTooManyRanges
+	}
+
+	ranges := make([]Range, len(notations))
+	for i, notation := range notations {
+		r, err := ParseRange(notation)
+		if err != nil {
+			return nil, err
+		}
+		ranges[i] = r
+	}
+	boardCards, err := ParseCards(board)
+	if err != nil {
+		return nil, err
+	}
+
+	key := equityCacheKey(ranges, boardCards)
+	if cached := ec.lookup(key); cached != nil {
+		return withNotations(cached, ranges, boardCards), nil
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	rng := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	result, err := CalculateEquity(ranges, boardCards, ec.samples, rng)
+	if err != nil {
+		return nil, err
+	}
+	ec.store(key, result)
+	return withNotations(result, ranges, boardCards), nil
+}
+
+func (ec *EquityCalculator) lookup(key string) *EquityResult {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	elem, ok := ec.entries[key]
+	if !ok {
+		return nil
+	}
+	ec.order.MoveToFront(elem)
+	return elem.Value.(*equityCacheEntry).result
+}
+
+func (ec *EquityCalculator) store(key string, result *EquityResult) {
+	if ec.capacity <= 0 {
+		return
+	}
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if elem, ok := ec.entries[key]; ok {
+		ec.order.MoveToFront(elem)
+		return
+	}
+	ec.entries[key] = ec.order.PushFront(&equityCacheEntry{key: key, result: result})
+	for ec.order.Len() > ec.capacity {
+		oldest := ec.order.Back()
+		ec.order.Remove(oldest)
+		delete(ec.entries, oldest.Value.(*equityCacheEntry).key)
+	}
+}
+
+// equityCacheKey identifies a calculation by the combos of each range, in
+// order, and the board cards in any order
+func equityCacheKey(ranges []Range, board []models.Card) string {
+	var sb strings.Builder
+	for _, r := range ranges {
+		masks := make([]uint64, len(r.Combos))
+		for i, combo := range r.Combos {
+			masks[i] = combo.mask()
+		}
+		sort.Slice(masks, func(i, j int) bool { return masks[i] < masks[j] })
+		for _, m := range masks {
+			fmt.Fprintf(&sb, "%x,", m)
+		}
+		sb.WriteByte('|')
+	}
+	var boardMask uint64
+	for _, card := range board {
+		boardMask |= cardBit(card)
+	}
+	fmt.Fprintf(&sb, "%x", boardMask)
+	return sb.String()
+}
+
+// withNotations copies a cached result with the ranges and board written as
+// requested
+func withNotations(result *EquityResult, ranges []Range, board []models.Card) *EquityResult {
+	copied := *result
+	copied.Board = cardsString(board)
+	copied.Ranges = make([]RangeEquity, len(result.Ranges))
+	for i, re := range result.Ranges {
+		re.Range = ranges[i].Notation
+		copied.Ranges[i] = re
+	}
+	return &copied
+}
@@ -0,0 +1,189 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		notation string
+		combos   int
+	}{
+		{"AA", 6},
+		{"AKs", 4},
+		{"AKo", 12},
+		{"KA", 16},
+		{"TT+", 30},
+		{"ATs+", 16},
+		{"A2s-A5s", 16},
+		{"44-22", 18},
+		{"AhKd", 1},
+		{"AA, KK ,AA", 12},
+		{"QQ+,AK,AhKh", 34},
+	}
+	for _, c := range cases {
+		r, err := ParseRange(c.notation)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", c.notation, err)
+			continue
+		}
+		if len(r.Combos) != c.combos {
+			t.Errorf("%q: expected %d combos, got %d", c.notation, c.combos, len(r.Combos))
+		}
+	}
+
+	for _, notation := range []string{"", "AAs", "AXo", "AKx", "AhAh", "AKs-QJs", "22-A5s", "AK+s"} {
+		if _, err := ParseRange(notation); err == nil {
+			t.Errorf("%q: expected a parse error", notation)
+		}
+	}
+}
+
+func mustRange(t *testing.T, notation string) Range {
+	t.Helper()
+	r, err := ParseRange(notation)
+	if err != nil {
+		t.Fatalf("ParseRange(%q) failed: %v", notation, err)
+	}
+	return r
+}
+
+func TestCalculateEquity_Exact(t *testing.T) {
+	// A made hand on the river wins every showdown
+	board, _ := ParseCards("2c7s9dJcQs")
+	result, err := CalculateEquity([]Range{mustRange(t, "AhAd"), mustRange(t, "KhKd")}, board, 0, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("CalculateEquity failed: %v", err)
+	}
+	if !result.Exact || result.Showdowns != 1 || result.Ranges[0].Equity != 1 || result.Ranges[1].Win != 0 {
+		t.Errorf("Expected aces to win the single showdown, got %+v", result)
+	}
+
+	// Both players play the board
+	board, _ = ParseCards("TsJsQsKsAs")
+	result, err = CalculateEquity([]Range{mustRange(t, "AhKh"), mustRange(t, "2c3d")}, board, 0, nil)
+	if err != nil {
+		t.Fatalf("CalculateEquity failed: %v", err)
+	}
+	for _, re := range result.Ranges {
+		if re.Equity != 0.5 || re.Tie != 1 {
+			t.Errorf("Expected a split pot, got %+v", re)
+		}
+	}
+
+	// Every turn and river is enumerated from the flop
+	board, _ = ParseCards("2h7c9d")
+	result, err = CalculateEquity([]Range{mustRange(t, "AhAd"), mustRange(t, "KcKs")}, board, 0, nil)
+	if err != nil {
+		t.Fatalf("CalculateEquity failed: %v", err)
+	}
+	if !result.Exact || result.Showdowns != 990 {
+		t.Errorf("Expected 990 exact showdowns, got %d (exact %v)", result.Showdowns, result.Exact)
+	}
+	// Kings mostly need one of the two kings left
+	if eq := result.Ranges[1].Equity; eq < 0.04 || eq > 0.1 {
+		t.Errorf("Expected kings to have about 8%% equity, got %.4f", eq)
+	}
+	if sum := result.Ranges[0].Equity + result.Ranges[1].Equity; math.Abs(sum-1) > 1e-9 {
+		t.Errorf("Expected equities to sum to 1, got %v", sum)
+	}
+}
+
+func TestCalculateEquity_Sampled(t *testing.T) {
+	ranges := []Range{mustRange(t, "AA"), mustRange(t, "KK")}
+	result, err := CalculateEquity(ranges, nil, 20000, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatalf("CalculateEquity failed: %v", err)
+	}
+	if result.Exact || result.Showdowns != 20000 {
+		t.Errorf("Expected 20000 sampled showdowns, got %d (exact %v)", result.Showdowns, result.Exact)
+	}
+	if eq := result.Ranges[0].Equity; math.Abs(eq-0.82) > 0.015 {
+		t.Errorf("Expected aces to have about 82%% equity against kings, got %.4f", eq)
+	}
+
+	three := []Range{mustRange(t, "QQ+"), mustRange(t, "AK"), mustRange(t, "22-99")}
+	result, err = CalculateEquity(three, nil, 5000, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatalf("CalculateEquity failed: %v", err)
+	}
+	sum := 0.0
+	for _, re := range result.Ranges {
+		sum += re.Equity
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("Expected equities to sum to 1, got %v", sum)
+	}
+}
+
+func TestCalculateEquity_Errors(t *testing.T) {
+	aa, kk := mustRange(t, "AA"), mustRange(t, "KK")
+	pair, _ := ParseCards("2c3d")
+	dup, _ := ParseCards("2c2c7h")
+	aces, _ := ParseCards("AhAdAc")
+
+	cases := []struct {
+		name   string
+		ranges []Range
+		board  []models.Card
+		err    error
+	}{
+		{"one range", []Range{aa}, nil, ErrTooFewRanges},
+		{"seven ranges", []Range{aa, aa, aa, aa, aa, aa, aa}, nil, ErrTooManyRanges},
+		{"two card board", []Range{aa, kk}, pair, ErrInvalidBoard},
+		{"repeated card", []Range{aa, kk}, dup, ErrInvalidBoard},
+		{"blocked range", []Range{aa, kk}, aces, ErrNoPossibleDeal},
+		{"same combo", []Range{mustRange(t, "AhAd"), mustRange(t, "AhAd")}, nil, ErrNoPossibleDeal},
+	}
+	for _, c := range cases {
+		_, err := CalculateEquity(c.ranges, c.board, 1000, rand.New(rand.NewSource(1)))
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestEquityCalculator_Cache(t *testing.T) {
+	calc := NewEquityCalculator(2000, 2)
+
+	first, err := calc.Calculate([]string{"AK", "QQ"}, "")
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// The same combos written another way are answered from the cache
+	second, err := calc.Calculate([]string{"AKs,AKo", "QQ"}, "")
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if first.Ranges[0].Equity != second.Ranges[0].Equity || len(calc.entries) != 1 {
+		t.Errorf("Expected one cached result, got %d entries", len(calc.entries))
+	}
+	if second.Ranges[0].Range != "AKs,AKo" || first.Ranges[0].Range != "AK" {
+		t.Errorf("Expected results labelled as requested, got %q and %q", first.Ranges[0].Range, second.Ranges[0].Range)
+	}
+
+	if _, err := calc.Calculate([]string{"AA", "KK"}, "2h7c9d"); err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if _, err := calc.Calculate([]string{"AA", "KK"}, "9d7c2h"); err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if _, err := calc.Calculate([]string{"JJ", "TT"}, ""); err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if len(calc.entries) != 2 || calc.order.Len() != 2 {
+		t.Errorf("Expected the cache to hold 2 results, got %d", len(calc.entries))
+	}
+	if _, ok := calc.entries[equityCacheKey([]Range{mustRange(t, "AK"), mustRange(t, "QQ")}, nil)]; ok {
+		t.Error("Expected the least recently used result to be evicted")
+	}
+
+	if _, err := calc.Calculate([]string{"AA", "K"}, ""); err == nil {
+		t.Error("Expected an invalid range to fail")
+	}
+}
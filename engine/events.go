@@ -0,0 +1,65 @@
+package engine
+
+import "poker-engine/models"
+
+// SetSynchronousEvents switches event delivery for tests and embedders. By
+// default each event fires on its own goroutine, so handlers may see them out
+// of order. With synchronous events they are queued and delivered in order by
+// the call that produced them, after the game lock is released, so handlers
+// can still call back into the game.
+func (t *Table) SetSynchronousEvents(enabled bool) {
+	t.game.SetSynchronousEvents(enabled)
+}
+
+// SetSynchronousEvents switches between ordered and goroutine event delivery
+func (g *Game) SetSynchronousEvents(enabled bool) {
+	g.mu.Lock()
+	defer g.unlock()
+	g.syncEvents = enabled
+}
+
+// emit fires an event. Caller must hold g.mu.
+func (g *Game) emit(event models.Event) {
+	if g.onEvent == nil {
+		return
+	}
+	if g.syncEvents {
+		g.eventQueue = append(g.eventQueue, event)
+		return
+	}
+	go g.onEvent(event)
+}
+
+// emitInline fires an event before the caller returns. Pause and Resume have
+// always delivered their events this way. Caller must hold g.mu.
+func (g *Game) emitInline(event models.Event) {
+	if g.onEvent == nil {
+		return
+	}
+	if g.syncEvents {
+		g.eventQueue = append(g.eventQueue, event)
+		return
+	}
+	g.onEvent(event)
+}
+
+// unlock releases g.mu and delivers any queued events in order. Events queued
+// by handlers calling back into the game join the end of the queue rather
+// than being delivered out of turn.
+func (g *Game) unlock() {
+	if g.drainingEvents || len(g.eventQueue) == 0 {
+		g.mu.Unlock()
+		return
+	}
+
+	g.drainingEvents = true
+	for len(g.eventQueue) > 0 {
+		event := g.eventQueue[0]
+		g.eventQueue = g.eventQueue[1:]
+		g.mu.Unlock()
+		g.onEvent(event)
+		g.mu.Lock()
+	}
+	g.drainingEvents = false
+	g.mu.Unlock()
+}
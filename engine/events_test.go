@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestGame_SynchronousEventsInOrder(t *testing.T) {
+	var events []string
+	var game *Game
+	table := &models.Table{
+		TableID:  "sync-table",
+		GameType: models.GameTypeCash,
+		Status:   models.StatusWaiting,
+		Config:   models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 2, ActionTimeout: 30},
+		Players: []*models.Player{
+			models.NewPlayer("p1", "Player 1", 0, 1000),
+			models.NewPlayer("p2", "Player 2", 1, 1000),
+		},
+		CurrentHand: &models.CurrentHand{HandNumber: 0, DealerPosition: -1},
+	}
+
+	// The handler folds for whoever is asked to act, calling back into the game
+	game = NewGame(table, nil, func(e models.Event) {
+		events = append(events, e.Event)
+		if e.Event == "actionRequired" {
+			data := e.Data.(models.ActionRequiredEvent)
+			if err := game.ProcessAction(data.PlayerID, models.ActionFold, 0); err != nil {
+				t.Errorf("Fold from the event handler failed: %v", err)
+			}
+		}
+	})
+	game.SetSynchronousEvents(true)
+
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	// Every event has been delivered by the time StartNewHand returns
+	expected := []string{"handStart", "actionRequired", "playerAction", "handComplete"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected events %v, got %v", expected, events)
+	}
+	if table.Status != models.StatusHandComplete {
+		t.Errorf("Expected the hand to be over, got %s", table.Status)
+	}
+}
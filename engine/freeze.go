@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"errors"
+
+	"poker-engine/models"
+)
+
+// ErrPlayerFrozen is returned for every action and chip movement of a frozen
+// player, so transports can tell a freeze apart from an ordinary rejection
+var ErrPlayerFrozen = errors.New("player is frozen")
+
+// SetPlayerFrozen freezes or unfreezes a player at the table. A frozen player's
+// actions are rejected with ErrPlayerFrozen and they are sat out: folded if
+// they are still in the hand, and not dealt into later hands. Unfreezing lets
+// them sit back in; it does not sit them in by itself.
+func (t *Table) SetPlayerFrozen(playerID string, frozen bool) {
+	t.game.SetPlayerFrozen(playerID, frozen)
+}
+
+// SetPlayerFrozen updates the frozen set and sits the player out if seated.
+// Players who are not seated can be frozen too, which covers a player joining
+// while a freeze is being applied.
+func (g *Game) SetPlayerFrozen(playerID string, frozen bool) {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if frozen == g.frozen[playerID] {
+		return
+	}
+	if frozen {
+		if g.frozen == nil {
+			g.frozen = make(map[string]bool)
+		}
+		g.frozen[playerID] = true
+	} else {
+		delete(g.frozen, playerID)
+	}
+
+	if player := findPlayerByID(g.table.Players, playerID); player != nil && frozen {
+		g.sitOutFrozen(player)
+	}
+
+	g.publishSnapshot()
+	g.emit(models.Event{
+		Event:   "playerFrozen",
+		TableID: g.table.TableID,
+		Data: map[string]interface{}{
+			"playerId": playerID,
+			"frozen":   frozen,
+		},
+	})
+}
+
+// IsPlayerFrozen reports whether a player is frozen at the table
+func (t *Table) IsPlayerFrozen(playerID string) bool {
+	t.game.mu.Lock()
+	defer t.game.unlock()
+	return t.game.frozen[playerID]
+}
+
+// sitOutFrozenPlayers keeps frozen players out of the next hand, including
+// any who were all-in when they were frozen. Caller must hold g.mu.
+func (g *Game) sitOutFrozenPlayers() {
+	for _, p := range g.table.Players {
+		if p != nil && g.frozen[p.PlayerID] {
+			p.Status = models.StatusSittingOut
+		}
+	}
+}
+
+// sitOutFrozen takes a frozen player out of play. A player still in the hand
+// folds, and if it was their turn the hand moves on as it would after a
+// timeout. All-in players keep their stake in the pot and are sat out when
+// the next hand starts. Caller must hold g.mu.
+func (g *Game) sitOutFrozen(player *models.Player) {
+	playing := g.table.Status == models.StatusPlaying && g.table.CurrentHand != nil
+	if playing && player.Status == models.StatusAllIn {
+		return
+	}
+
+	wasTurn := false
+	if playing && player.Status == models.StatusActive {
+		position := g.table.CurrentHand.CurrentPosition
+		wasTurn = position >= 0 && position < len(g.table.Players) && g.table.Players[position] == player
+		player.LastAction = models.ActionFold
+		player.LastActionAmount = 0
+		player.HasActedThisRound = true
+	}
+	player.Status = models.StatusSittingOut
+
+	if !wasTurn {
+		return
+	}
+	g.stopActionTimer()
+	if g.isBettingRoundComplete() {
+		g.advanceToNextRound()
+	} else {
+		g.moveToNextPlayer()
+	}
+}
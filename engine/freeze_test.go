@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestGame_FrozenPlayerIsSatOutAndRejected(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000, 1000)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	frozen := table.Players[table.CurrentHand.CurrentPosition]
+	game.SetPlayerFrozen(frozen.PlayerID, true)
+
+	if frozen.Status != models.StatusSittingOut || frozen.LastAction != models.ActionFold {
+		t.Errorf("Expected the frozen player to be folded and sat out, got %s after %s", frozen.Status, frozen.LastAction)
+	}
+	if table.Players[table.CurrentHand.CurrentPosition] == frozen {
+		t.Error("Expected the turn to move on from the frozen player")
+	}
+	if err := game.ProcessAction(frozen.PlayerID, models.ActionCall, 0); !errors.Is(err, ErrPlayerFrozen) {
+		t.Errorf("Expected ErrPlayerFrozen, got %v", err)
+	}
+
+	// The rest of the hand plays out without them
+	for i := 0; table.Status == models.StatusPlaying && i < 20; i++ {
+		player := table.Players[table.CurrentHand.CurrentPosition]
+		action := models.ActionCheck
+		if player.Bet < table.CurrentHand.CurrentBet {
+			action = models.ActionCall
+		}
+		if err := game.ProcessAction(player.PlayerID, action, 0); err != nil {
+			t.Fatalf("Action by %s failed: %v", player.PlayerID, err)
+		}
+	}
+	if table.Status != models.StatusHandComplete {
+		t.Fatalf("Expected the hand to finish, got %s", table.Status)
+	}
+
+	// Frozen players are not dealt in and cannot sit back in or top up
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start the next hand: %v", err)
+	}
+	if frozen.Status != models.StatusSittingOut || frozen.TotalInvestedThisHand != 0 {
+		t.Errorf("Expected the frozen player to sit the next hand out, got %s having put in %d", frozen.Status, frozen.TotalInvestedThisHand)
+	}
+	table.Status = models.StatusHandComplete
+	table.GameType = models.GameTypeCash
+	if err := game.AddChips(frozen.PlayerID, 100); !errors.Is(err, ErrPlayerFrozen) {
+		t.Errorf("Expected adding chips to be refused, got %v", err)
+	}
+
+	game.SetPlayerFrozen(frozen.PlayerID, false)
+	if frozen.Status != models.StatusSittingOut {
+		t.Error("Expected unfreezing to leave the player sat out until they sit in")
+	}
+	if err := game.AddChips(frozen.PlayerID, 100); err != nil {
+		t.Errorf("Expected an unfrozen player to be able to add chips, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"poker-engine/models"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,16 +15,42 @@ type Game struct {
 	table           *models.Table
 	potCalculator   *PotCalculator
 	actionTimer     *time.Timer
-	onTimeout       func(string)
+	onTimeout       func(playerID string, deadline uint64)
 	onEvent         func(models.Event)
 	mu              sync.Mutex     // Protects all game state modifications
 	pausedAt        *time.Time
 	pauseDuration   time.Duration
 	timerRemaining  time.Duration
+	snapshot        atomic.Pointer[models.Table] // Last published read-only state
+	seatChanges     []seatChangeRequest          // Seat moves waiting for the current hand to end
+	forcedButton    *int                         // Button seat set by an operator for the next hand
+	forcedBlinds    *BlindSeats                  // Button and blinds of a replayed hand
+	nextScenario    *Scenario                    // Cards of the next hand, set up for practice
+	straddler       string                       // Player straddling the next hand
+	lastBlinds      *BlindSeats                  // Button and blinds of the last hand dealt, nil when unknown
+	positionCorrections []string                 // Position invariant violations fixed at the last hand start
+	pendingColorUp  *colorUpRequest              // Denomination removal applied before the next hand
+	nextDeckSeed    *int64                       // Deck seed of the next hand, set when replaying a hand
+	replay          bool                         // Replaying a recorded hand, so actions arrive back to back
+	handRecord      *HandRecord                  // Record of the current or last hand, for replays
+	syncEvents      bool                         // Deliver events in order once the lock is released
+	eventQueue      []models.Event               // Events waiting for delivery when syncEvents is set
+	drainingEvents  bool                         // An unlock is delivering the queue
+	limiter         *actionLimiter               // Action rate limits, nil when off
+	frozen          map[string]bool              // Players whose actions are rejected until unfrozen
+	heldBetweenHands bool                        // No new hand starts until released
+	timeBanks       map[string]time.Duration     // Reserve left for players who have drawn on their time bank
+	timeBankPlayer  string                       // Player acting on reserve time, empty when nobody is
+	timeBankStart   time.Time                    // When timeBankPlayer started drawing on their reserve
+	pausedTimeBank  string                       // Player who was in their time bank when the game paused
+	allInEquity     []models.AllInEquity         // Equity snapshot of the current hand's all-in, nil when there was none
+	showCardsTimer  *time.Timer                  // Closes the uncontested winner's chance to show their cards
 }
 
 // NewGame creates a new Game instance with the given table, timeout handler, and event handler.
-func NewGame(table *models.Table, onTimeout func(string), onEvent func(models.Event)) *Game {
+// onTimeout is called with the token of the deadline that expired, which
+// must be passed back to HandleTimeout.
+func NewGame(table *models.Table, onTimeout func(playerID string, deadline uint64), onEvent func(models.Event)) *Game {
 	return &Game{
 		table:         table,
 		potCalculator: NewPotCalculator(),
@@ -32,18 +59,51 @@ func NewGame(table *models.Table, onTimeout func(string), onEvent func(models.Ev
 	}
 }
 
+// publishSnapshot stores a fresh read-only copy of the table state.
+// Caller must hold g.mu. It runs before every event so handlers always
+// observe the state the event describes.
+func (g *Game) publishSnapshot() {
+	g.table.Version++
+	g.snapshot.Store(g.table.Clone())
+}
+
+// refreshSnapshot publishes a snapshot for changes made outside the game lock
+func (g *Game) refreshSnapshot() {
+	g.mu.Lock()
+	defer g.unlock()
+	g.publishSnapshot()
+}
+
+// Snapshot returns the last published table state without taking the game lock.
+// The returned table is shared between readers and must not be modified.
+func (g *Game) Snapshot() *models.Table {
+	if snapshot := g.snapshot.Load(); snapshot != nil {
+		return snapshot
+	}
+	g.refreshSnapshot()
+	return g.snapshot.Load()
+}
+
 func (g *Game) StartNewHand() error {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlock()
 
 	if g.table == nil {
 		return fmt.Errorf("game table is nil")
 	}
+	if g.heldBetweenHands {
+		return ErrHandsHeld
+	}
 
+	g.dropShowCards()
 	g.table.Winners = nil
 	g.table.Status = models.StatusPlaying
 
 	g.removeBustedPlayers()
+	g.applySeatChanges()
+	g.applyColorUp()
+	g.sitOutFrozenPlayers()
+	g.syncTimeBanks()
 
 	activePlayers := countPlayers(g.table.Players, isActiveWithChips)
 	if activePlayers < 2 {
@@ -51,21 +111,52 @@ func (g *Game) StartNewHand() error {
 		return fmt.Errorf("not enough players to start hand")
 	}
 
-	g.table.Deck = models.NewDeck()
+	// Seats are recorded before the deal so the hand can be replayed from them
+	record := &HandRecord{
+		TableID:  g.table.TableID,
+		GameType: g.table.GameType,
+		Config:   g.table.Config,
+		Players:  recordSeats(g.table.Players),
+	}
+
+	if g.nextDeckSeed != nil {
+		g.table.Deck = models.NewDeckWithSeed(*g.nextDeckSeed)
+		g.nextDeckSeed = nil
+	} else {
+		g.table.Deck = models.NewDeck()
+	}
+	scenario := g.nextScenario
+	g.nextScenario = nil
+	if scenario != nil {
+		g.table.Deck.Remove(scenario.cards())
+		record.Scenario = scenario
+	}
 
 	// Reset players BEFORE finding dealer position to ensure folded/busted status from previous hand doesn't affect rotation
 	g.resetPlayers()
 
 	positionFinder := NewPositionFinder(g.table.Players)
-	dealerPos := g.findDealerPosition(positionFinder)
-	sbPos, bbPos := positionFinder.calculateBlindPositions(dealerPos, activePlayers)
+	seats := g.blindSeatsForNewHand(positionFinder, activePlayers)
+	dealerPos, sbPos, bbPos := seats.Button, seats.SmallBlind, seats.BigBlind
+	g.lastBlinds = &seats
 
-	g.assignPositions(dealerPos, sbPos, bbPos)
-	g.postBlinds(sbPos, bbPos)
+	g.assignPositions(seats)
+	antes := g.postAntes()
+	g.postBlinds(seats)
+	g.postSeatChangeBlinds(sbPos, bbPos, activePlayers)
 
 	g.initializeHand(dealerPos, sbPos, bbPos)
+	g.table.CurrentHand.Pot.Main = antes
+	g.table.CurrentHand.DeadSmallBlind = seats.DeadSmallBlind
+
+	record.HandNumber = g.table.CurrentHand.HandNumber
+	record.DealerPosition = dealerPos
+	record.Blinds = &seats
+	record.DeckSeed = g.table.Deck.Seed()
+	g.handRecord = record
+	g.postStraddle(bbPos, activePlayers)
 
-	if err := g.dealPlayerCards(); err != nil {
+	if err := g.dealPlayerCards(scenario); err != nil {
 		g.table.Status = models.StatusWaiting
 		return err
 	}
@@ -75,6 +166,7 @@ func (g *Game) StartNewHand() error {
 	// Add hand started to history
 	g.addHandStartedHistory()
 
+	g.publishSnapshot()
 	// CRITICAL DEADLOCK FIX: Fire event asynchronously
 	if g.onEvent != nil {
 		event := models.Event{
@@ -87,7 +179,18 @@ func (g *Game) StartNewHand() error {
 				"bigBlindPosition":   g.table.CurrentHand.BigBlindPosition,
 			},
 		}
-		go g.onEvent(event)
+		g.emit(event)
+	}
+	g.emitPositionCorrections()
+
+	// Antes and blinds can put every player all-in before anyone acts. Nobody
+	// had a decision to make, so this doesn't count toward inactivity.
+	if countPlayers(g.table.Players, canAct) == 0 {
+		g.table.CurrentHand.HasRealActionThisHand = true
+		g.snapshotAllInEquity()
+		g.dealAllRemainingCards()
+		g.completeHand()
+		return nil
 	}
 
 	g.startActionTimer()
@@ -98,6 +201,7 @@ func (g *Game) removeBustedPlayers() {
 	for i, p := range g.table.Players {
 		if p != nil && p.Chips <= 0 {
 			g.table.Players[i] = nil
+			g.publishSnapshot()
 			// CRITICAL DEADLOCK FIX: Fire event asynchronously
 			if g.onEvent != nil {
 				event := models.Event{
@@ -108,24 +212,65 @@ func (g *Game) removeBustedPlayers() {
 						"playerName": p.PlayerName,
 					},
 				}
-				go g.onEvent(event)
+				g.emit(event)
 			}
 		}
 	}
 }
 
-func (g *Game) findDealerPosition(positionFinder *PositionFinder) int {
-	// If this is the first hand or dealer position is invalid, find first player with chips
-	if g.table.CurrentHand.DealerPosition < 0 || g.table.CurrentHand.DealerPosition >= len(g.table.Players) {
-		return positionFinder.findFirstWithChips()
+// AddChips adds to a player's stack between hands within the buy-in limits
+func (g *Game) AddChips(playerID string, amount int) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	player, _, err := g.checkAddChips(playerID, amount)
+	if err != nil {
+		return err
 	}
+	player.AddChips(amount)
+	g.publishSnapshot()
+	return nil
+}
 
-	// Find the next player with chips after the current dealer
-	nextPos := positionFinder.findNextWithChips(g.table.CurrentHand.DealerPosition)
+// CheckAddChips runs AddChips' checks without adding anything and returns the
+// stack the player would end up with
+func (g *Game) CheckAddChips(playerID string, amount int) (int, error) {
+	g.mu.Lock()
+	defer g.unlock()
 
-	// Always return the next position - if only one player has chips, this will be the same as current
-	// but the logic is correct (dealer stays with the only player who can play)
-	return nextPos
+	_, newTotal, err := g.checkAddChips(playerID, amount)
+	return newTotal, err
+}
+
+func (g *Game) checkAddChips(playerID string, amount int) (*models.Player, int, error) {
+	if g.table.GameType == models.GameTypeTournament {
+		return nil, 0, fmt.Errorf("cannot add chips in tournament mode")
+	}
+	if amount <= 0 {
+		return nil, 0, fmt.Errorf("amount must be positive")
+	}
+	if g.table.Status == models.StatusPlaying {
+		return nil, 0, fmt.Errorf("cannot add chips while a hand is in progress")
+	}
+
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return nil, 0, fmt.Errorf("player not found")
+	}
+	if g.frozen[playerID] {
+		return nil, 0, ErrPlayerFrozen
+	}
+
+	newTotal := player.Chips + amount
+	if g.table.Config.MinBuyIn > 0 && newTotal < g.table.Config.MinBuyIn {
+		return nil, 0, fmt.Errorf("adding %d chips would leave %d, below the min buy-in of %d",
+			amount, newTotal, g.table.Config.MinBuyIn)
+	}
+	if g.table.Config.MaxBuyIn > 0 && newTotal > g.table.Config.MaxBuyIn {
+		return nil, 0, fmt.Errorf("adding %d chips would exceed max buy-in of %d (current: %d)",
+			amount, g.table.Config.MaxBuyIn, player.Chips)
+	}
+	return player, newTotal, nil
 }
 
 func (g *Game) resetPlayers() {
@@ -136,23 +281,51 @@ func (g *Game) resetPlayers() {
 	}
 }
 
-func (g *Game) assignPositions(dealerPos, sbPos, bbPos int) {
-	if g.table.Players[dealerPos] != nil {
-		g.table.Players[dealerPos].IsDealer = true
+func (g *Game) assignPositions(seats BlindSeats) {
+	if p := g.table.Players[seats.Button]; p != nil && !seats.DeadButton {
+		p.IsDealer = true
 	}
-	if g.table.Players[sbPos] != nil {
-		g.table.Players[sbPos].IsSmallBlind = true
+	if p := g.table.Players[seats.SmallBlind]; p != nil && !seats.DeadSmallBlind {
+		p.IsSmallBlind = true
 	}
-	if g.table.Players[bbPos] != nil {
-		g.table.Players[bbPos].IsBigBlind = true
+	if p := g.table.Players[seats.BigBlind]; p != nil {
+		p.IsBigBlind = true
 	}
 }
 
-func (g *Game) postBlinds(sbPos, bbPos int) {
-	if sbPlayer := g.table.Players[sbPos]; sbPlayer != nil {
+// postAntes collects the ante from every player dealt into the hand. Antes
+// are dead money: they go straight into the pot and don't count toward the
+// player's bet, so the big blind must still be called in full. A player who
+// can't cover the ante is all-in for what they have. Returns the total posted.
+func (g *Game) postAntes() int {
+	ante := g.table.Config.Ante
+	if ante <= 0 {
+		return 0
+	}
+
+	total := 0
+	for _, player := range g.table.Players {
+		if player == nil || player.Status != models.StatusActive {
+			continue
+		}
+		amount := ante
+		if amount >= player.Chips {
+			amount = player.Chips
+			player.Status = models.StatusAllIn
+		}
+		player.Chips -= amount
+		player.TotalInvestedThisHand += amount
+		total += amount
+	}
+	return total
+}
+
+// postBlinds posts the blinds. A dead small blind is not posted by anyone.
+func (g *Game) postBlinds(seats BlindSeats) {
+	if sbPlayer := g.table.Players[seats.SmallBlind]; sbPlayer != nil && !seats.DeadSmallBlind {
 		g.postBlind(sbPlayer, g.table.Config.SmallBlind, true)
 	}
-	if bbPlayer := g.table.Players[bbPos]; bbPlayer != nil {
+	if bbPlayer := g.table.Players[seats.BigBlind]; bbPlayer != nil {
 		g.postBlind(bbPlayer, g.table.Config.BigBlind, false)
 	}
 }
@@ -165,12 +338,14 @@ func (g *Game) postBlind(player *models.Player, blindAmount int, isSmallBlind bo
 	}
 	player.Bet = amount
 	player.Chips -= amount
+	player.TotalInvestedThisHand += amount
 	player.HasActedThisRound = false
 }
 
 func (g *Game) initializeHand(dealerPos, sbPos, bbPos int) {
 	positionFinder := NewPositionFinder(g.table.Players)
 	handNumber := g.table.CurrentHand.HandNumber + 1
+	g.allInEquity = nil
 
 	g.table.CurrentHand = &models.CurrentHand{
 		HandNumber:         handNumber,
@@ -182,13 +357,22 @@ func (g *Game) initializeHand(dealerPos, sbPos, bbPos int) {
 		Pot:                models.Pot{Main: 0, Side: []models.SidePot{}},
 		CurrentBet:         g.table.Config.BigBlind,
 		MinRaise:           g.table.Config.BigBlind,
-		CurrentPosition:    positionFinder.findNextActive(bbPos),
+		Raises:             1,
+		CurrentPosition:    positionFinder.findNext(bbPos, canAct),
+		DeckSeed:           g.table.Deck.Seed(),
+		DeadlineToken:      g.table.CurrentHand.DeadlineToken, // Keeps counting so old timers can't match
 	}
 }
 
-func (g *Game) dealPlayerCards() error {
-	for _, player := range g.table.Players {
-		if player != nil && player.Status == models.StatusActive {
+// dealPlayerCards deals two cards to every player in the hand. A scenario's
+// hole cards go to their seats and its board is stacked to come next.
+func (g *Game) dealPlayerCards(scenario *Scenario) error {
+	for i, player := range g.table.Players {
+		if player != nil && (player.Status == models.StatusActive || player.Status == models.StatusAllIn) {
+			if scenario != nil && scenario.HoleCards[i] != nil {
+				player.Cards = append([]models.Card(nil), scenario.HoleCards[i]...)
+				continue
+			}
 			cards, err := g.table.Deck.DealMultiple(2)
 			if err != nil {
 				return fmt.Errorf("failed to deal cards: %v", err)
@@ -196,12 +380,15 @@ func (g *Game) dealPlayerCards() error {
 			player.Cards = cards
 		}
 	}
+	if scenario != nil {
+		g.table.Deck.PutOnTop(scenario.Board)
+	}
 	return nil
 }
 
 func (g *Game) ProcessAction(playerID string, action models.PlayerAction, amount int) error {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlock()
 
 	// Log incoming action with full context for debugging
 	log.Printf("[ACTION_VALIDATE] player=%s action=%s amount=%d round=%s position=%d sequence=%d",
@@ -218,6 +405,10 @@ func (g *Game) ProcessAction(playerID string, action models.PlayerAction, amount
 		return fmt.Errorf("game is paused, actions not allowed")
 	}
 
+	if action == models.ActionStraddle {
+		return g.declareStraddle(playerID)
+	}
+
 	if g.table.Status != models.StatusPlaying {
 		return fmt.Errorf("hand is not in progress")
 	}
@@ -231,25 +422,43 @@ func (g *Game) ProcessAction(playerID string, action models.PlayerAction, amount
 		return fmt.Errorf("player not found")
 	}
 
+	if g.frozen[playerID] {
+		log.Printf("[ACTION_REJECTED] player=%s reason=frozen", playerID)
+		return ErrPlayerFrozen
+	}
+
+	if err := g.checkPlayerRate(playerID); err != nil {
+		log.Printf("[ACTION_REJECTED] player=%s reason=%v", playerID, err)
+		return err
+	}
+
 	// Use comprehensive turn validator
 	turnValidator := NewTurnValidator(g.table)
+	turnValidator.allowRapidActions = g.replay
 	if err := turnValidator.ValidateTurn(playerID); err != nil {
 		log.Printf("[ACTION_REJECTED] player=%s reason=%v", playerID, err)
 		return err
 	}
+	if err := g.checkTableRate(); err != nil {
+		log.Printf("[ACTION_REJECTED] player=%s reason=%v", playerID, err)
+		return err
+	}
 
 	log.Printf("[ACTION_ACCEPTED] player=%s action=%s seq=%d",
 		playerID, action, g.table.CurrentHand.ActionSequence)
 
 	g.stopActionTimer()
 
-	validator := NewBettingValidator(g.table.CurrentHand.CurrentBet, g.table.CurrentHand.MinRaise)
+	validator := g.bettingValidator()
 	processor := NewActionProcessor(validator, g.table.Players)
 
 	if err := g.executeAction(processor, player, action, amount); err != nil {
 		return err
 	}
 
+	g.recordAction(RecordedAction{PlayerID: playerID, Action: action, Amount: amount})
+	g.markPresent(player)
+
 	// Update action tracking fields
 	player.HasActedThisRound = true
 	g.table.CurrentHand.ActionSequence++
@@ -264,6 +473,7 @@ func (g *Game) ProcessAction(playerID string, action models.PlayerAction, amount
 	// CRITICAL DEADLOCK FIX: Fire event asynchronously to prevent deadlock
 	// If event handler tries to call ProcessAction, it would deadlock waiting for mutex
 	// TODO: Full fix requires collecting events and firing after mutex release
+	g.publishSnapshot()
 	if g.onEvent != nil {
 		event := models.Event{
 			Event:   "playerAction",
@@ -275,7 +485,7 @@ func (g *Game) ProcessAction(playerID string, action models.PlayerAction, amount
 			},
 		}
 		// Fire event in goroutine to prevent deadlock
-		go g.onEvent(event)
+		g.emit(event)
 	}
 
 	if g.isBettingRoundComplete() {
@@ -288,19 +498,27 @@ func (g *Game) ProcessAction(playerID string, action models.PlayerAction, amount
 }
 
 func (g *Game) executeAction(processor *ActionProcessor, player *models.Player, action models.PlayerAction, amount int) error {
+	betBefore := g.table.CurrentHand.CurrentBet
+	var err error
 	switch action {
 	case models.ActionFold:
 		processor.processFold(player)
 	case models.ActionCheck:
-		return processor.processCheck(player)
+		err = processor.processCheck(player)
 	case models.ActionCall:
 		processor.processCall(player, g.table.CurrentHand.CurrentBet)
 	case models.ActionRaise:
-		return processor.processRaise(player, amount, &g.table.CurrentHand.CurrentBet, &g.table.CurrentHand.MinRaise)
+		err = processor.processRaise(player, amount, &g.table.CurrentHand.CurrentBet, &g.table.CurrentHand.MinRaise)
 	case models.ActionAllIn:
-		return processor.processAllIn(player, &g.table.CurrentHand.CurrentBet, &g.table.CurrentHand.MinRaise)
+		err = processor.processAllIn(player, &g.table.CurrentHand.CurrentBet, &g.table.CurrentHand.MinRaise)
 	}
-	return nil
+	if err == nil {
+		g.trackFinalBet(player, action, betBefore)
+		if processor.validator.isFullRaise(player.Bet) {
+			g.table.CurrentHand.Raises++
+		}
+	}
+	return err
 }
 
 func (g *Game) moveToNextPlayer() {
@@ -348,17 +566,17 @@ func (g *Game) advanceToNextRound() {
 	// Reset flag for next round
 	g.table.CurrentHand.HasRealActionThisRound = false
 
-	// Only recalculate pot if there were bets in this round
+	// Recalculate the pots from everything put in so far this hand
 	hasBets := false
 	for _, p := range g.table.Players {
-		if p != nil && p.Bet > 0 {
+		if p != nil && p.TotalInvestedThisHand > 0 {
 			hasBets = true
 			break
 		}
 	}
 
 	if hasBets {
-		g.table.CurrentHand.Pot = g.potCalculator.CalculatePots(g.table.Players)
+		g.table.CurrentHand.Pot = g.potCalculator.CalculateHandPots(g.table.Players)
 	}
 
 	// Reset HasActedThisRound flags for all players
@@ -366,6 +584,7 @@ func (g *Game) advanceToNextRound() {
 
 	g.table.CurrentHand.CurrentBet = 0
 	g.table.CurrentHand.MinRaise = g.table.Config.BigBlind
+	g.table.CurrentHand.Raises = 0
 
 	activePlayers := countPlayers(g.table.Players, isNotFolded)
 	playersNotAllIn := countPlayers(g.table.Players, canAct)
@@ -376,6 +595,7 @@ func (g *Game) advanceToNextRound() {
 	}
 
 	if playersNotAllIn <= 1 {
+		g.snapshotAllInEquity()
 		g.dealAllRemainingCards()
 		g.completeHand()
 		return
@@ -386,6 +606,7 @@ func (g *Game) advanceToNextRound() {
 		return
 	}
 
+	g.publishSnapshot()
 	// CRITICAL DEADLOCK FIX: Fire event asynchronously to prevent deadlock
 	if g.onEvent != nil {
 		event := models.Event{
@@ -396,7 +617,7 @@ func (g *Game) advanceToNextRound() {
 				"communityCards": g.table.CurrentHand.CommunityCards,
 			},
 		}
-		go g.onEvent(event)
+		g.emit(event)
 	}
 
 	// Only set position and start timer if there are players who can still act
@@ -486,23 +707,25 @@ func (g *Game) completeHand() {
 
 	hasBets := false
 	for _, p := range g.table.Players {
-		if p != nil && p.Bet > 0 {
+		if p != nil && p.TotalInvestedThisHand > 0 {
 			hasBets = true
 			break
 		}
 	}
 
 	if hasBets {
-		g.table.CurrentHand.Pot = g.potCalculator.CalculatePots(g.table.Players)
+		g.table.CurrentHand.Pot = g.potCalculator.CalculateHandPots(g.table.Players)
 	}
 
-	g.table.Winners = DistributeWinnings(g.table.CurrentHand.Pot, g.table.Players, g.table.CurrentHand.CommunityCards)
+	var potWinners [][]string
+	g.table.Winners, potWinners = distributePots(g.table.CurrentHand.Pot, g.table.Players, g.table.CurrentHand.CommunityCards)
 
 	for _, winner := range g.table.Winners {
 		if player := findPlayerByID(g.table.Players, winner.PlayerID); player != nil {
 			player.Chips += winner.Amount
 		}
 	}
+	knockedOut := knockouts(g.table.CurrentHand.Pot, g.table.Players, potWinners)
 
 	g.table.Status = models.StatusHandComplete
 	g.stopActionTimer()
@@ -510,16 +733,20 @@ func (g *Game) completeHand() {
 	// Add hand complete to history
 	g.addHandCompleteHistory()
 
+	g.publishSnapshot()
 	// CRITICAL DEADLOCK FIX: Fire event asynchronously
 	if g.onEvent != nil {
 		event := models.Event{
 			Event:   "handComplete",
 			TableID: g.table.TableID,
-			Data:    models.HandCompleteEvent{Winners: g.table.Winners},
+			Data:    models.HandCompleteEvent{Winners: g.table.Winners, AllInEquity: g.allInResults(), Knockouts: knockedOut},
 		}
-		go g.onEvent(event)
+		g.emit(event)
 	}
 
+	// The winner of a pot nobody contested may show their cards before the next hand
+	g.openShowCards()
+
 	// Check if game is complete (only one player with chips left)
 	playersWithChips := 0
 	var lastPlayerStanding *models.Player
@@ -530,6 +757,7 @@ func (g *Game) completeHand() {
 		}
 	}
 
+	g.publishSnapshot()
 	// CRITICAL DEADLOCK FIX: Fire event asynchronously
 	if playersWithChips == 1 && lastPlayerStanding != nil && g.onEvent != nil {
 		event := models.Event{
@@ -542,7 +770,7 @@ func (g *Game) completeHand() {
 				"totalPlayers": len(g.table.Players),
 			},
 		}
-		go g.onEvent(event)
+		g.emit(event)
 	}
 }
 
@@ -558,6 +786,7 @@ func (g *Game) terminateAbandonedGame() {
 	g.table.CurrentHand = nil
 
 	// Fire gameAbandoned event
+	g.publishSnapshot()
 	if g.onEvent != nil {
 		event := models.Event{
 			Event:   "gameAbandoned",
@@ -567,7 +796,7 @@ func (g *Game) terminateAbandonedGame() {
 				"totalPlayers": len(g.table.Players),
 			},
 		}
-		go g.onEvent(event)
+		g.emit(event)
 	}
 
 	log.Printf("[GAME_TERMINATED] Game %s abandoned due to all players being inactive", g.table.TableID)
@@ -620,45 +849,70 @@ func (g *Game) startActionTimer() {
 		}
 	}
 
-	deadline := time.Now().Add(time.Duration(g.table.Config.ActionTimeout) * time.Second)
+	timeout := g.actionTimeoutFor(currentPlayer)
+	if currentPlayer.Disconnected {
+		currentPlayer.DisconnectGraceUsed = true
+	}
+	g.armActionTimer(currentPlayer, timeout)
+}
+
+// armActionTimer starts a deadline for the player and announces it. Caller
+// must hold g.mu.
+func (g *Game) armActionTimer(currentPlayer *models.Player, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
 	g.table.CurrentHand.ActionDeadline = &deadline
+	g.table.CurrentHand.DeadlineToken++
+	token := g.table.CurrentHand.DeadlineToken
 
+	g.publishSnapshot()
 	// CRITICAL DEADLOCK FIX: Fire event asynchronously
 	if g.onEvent != nil {
 		event := models.Event{
 			Event:   "actionRequired",
 			TableID: g.table.TableID,
-			Data: models.ActionRequiredEvent{
-				PlayerID: currentPlayer.PlayerID,
-				Deadline: deadline.Format(time.RFC3339),
-			},
+			Data:    g.actionRequired(currentPlayer, deadline),
 		}
-		go g.onEvent(event)
+		g.emit(event)
 	}
 
-	g.actionTimer = time.AfterFunc(time.Duration(g.table.Config.ActionTimeout)*time.Second, func() {
+	g.actionTimer = time.AfterFunc(timeout, func() {
 		if g.onTimeout != nil {
-			g.onTimeout(currentPlayer.PlayerID)
+			g.onTimeout(currentPlayer.PlayerID, token)
 		}
 	})
 }
 
+// stopActionTimer cancels the current deadline. A timer that has already
+// fired is left holding a token that no longer matches.
 func (g *Game) stopActionTimer() {
 	if g.actionTimer != nil {
 		g.actionTimer.Stop()
 		g.actionTimer = nil
 	}
-	g.table.CurrentHand.ActionDeadline = nil
+	if g.table.CurrentHand.ActionDeadline != nil {
+		g.table.CurrentHand.ActionDeadline = nil
+		g.table.CurrentHand.DeadlineToken++
+	}
+	g.chargeTimeBank()
 }
 
-func (g *Game) HandleTimeout(playerID string) error {
+// HandleTimeout acts for a player whose deadline expired. deadline is the
+// token the timer was started with; a timeout for any other deadline is stale
+// and ignored.
+func (g *Game) HandleTimeout(playerID string, deadline uint64) error {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlock()
 
 	if g.table == nil || g.table.CurrentHand == nil {
 		return nil // No active game, ignore timeout
 	}
 
+	if deadline != g.table.CurrentHand.DeadlineToken {
+		log.Printf("[TIMEOUT] Ignoring stale timeout for %s on table %s (deadline %d, current %d)",
+			playerID, g.table.TableID, deadline, g.table.CurrentHand.DeadlineToken)
+		return nil
+	}
+
 	// Check if game is in progress
 	if g.table.Status != models.StatusPlaying {
 		return nil // Game not in progress, ignore timeout
@@ -675,6 +929,14 @@ func (g *Game) HandleTimeout(playerID string) error {
 		return nil // Not this player's turn anymore, ignore
 	}
 
+	// A player with reserve left gets it before anything is done for them
+	if g.drawTimeBank(currentPlayer) {
+		return nil
+	}
+	g.chargeTimeBank()
+
+	g.recordAction(RecordedAction{PlayerID: playerID, Timeout: true})
+
 	// Smart timeout logic: check if possible, fold if facing a bet
 	currentBet := g.table.CurrentHand.CurrentBet
 	playerBet := currentPlayer.Bet
@@ -690,6 +952,7 @@ func (g *Game) HandleTimeout(playerID string) error {
 		currentPlayer.LastActionAmount = 0
 		currentPlayer.HasActedThisRound = true
 
+		g.publishSnapshot()
 		// CRITICAL DEADLOCK FIX: Fire event asynchronously
 		if g.onEvent != nil {
 			event := models.Event{
@@ -700,7 +963,7 @@ func (g *Game) HandleTimeout(playerID string) error {
 					"reason":   "consecutive_timeouts",
 				},
 			}
-			go g.onEvent(event)
+			g.emit(event)
 		}
 	} else {
 		// Determine the appropriate auto-action
@@ -711,6 +974,7 @@ func (g *Game) HandleTimeout(playerID string) error {
 			currentPlayer.LastActionAmount = 0
 			currentPlayer.HasActedThisRound = true
 
+			g.publishSnapshot()
 			// CRITICAL DEADLOCK FIX: Fire event asynchronously
 			if g.onEvent != nil {
 				event := models.Event{
@@ -723,7 +987,7 @@ func (g *Game) HandleTimeout(playerID string) error {
 						"consecutiveTimeouts": currentPlayer.ConsecutiveTimeouts,
 					},
 				}
-				go g.onEvent(event)
+				g.emit(event)
 			}
 		} else {
 			// No bet to call -> auto-check
@@ -732,6 +996,7 @@ func (g *Game) HandleTimeout(playerID string) error {
 			currentPlayer.HasActedThisRound = true
 			// Status remains Active
 
+			g.publishSnapshot()
 			// CRITICAL DEADLOCK FIX: Fire event asynchronously
 			if g.onEvent != nil {
 				event := models.Event{
@@ -744,7 +1009,7 @@ func (g *Game) HandleTimeout(playerID string) error {
 						"consecutiveTimeouts": currentPlayer.ConsecutiveTimeouts,
 					},
 				}
-				go g.onEvent(event)
+				g.emit(event)
 			}
 		}
 	}
@@ -762,7 +1027,7 @@ func (g *Game) HandleTimeout(playerID string) error {
 // Pause pauses the active game and stops the action timer
 func (g *Game) Pause() error {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlock()
 
 	if g.table.Status != models.StatusPlaying {
 		return fmt.Errorf("can only pause playing game, current status: %s", g.table.Status)
@@ -776,7 +1041,8 @@ func (g *Game) Pause() error {
 		}
 	}
 
-	// Stop action timer
+	// Stop action timer, charging any time bank used so far
+	g.pausedTimeBank = g.timeBankPlayer
 	g.stopActionTimer()
 
 	// Mark as paused
@@ -785,8 +1051,9 @@ func (g *Game) Pause() error {
 	g.table.Status = models.StatusPaused
 
 	// Fire pause event
+	g.publishSnapshot()
 	if g.onEvent != nil {
-		g.onEvent(models.Event{
+		g.emitInline(models.Event{
 			Event:   "gamePaused",
 			TableID: g.table.TableID,
 			Data: map[string]interface{}{
@@ -801,7 +1068,7 @@ func (g *Game) Pause() error {
 // Resume resumes a paused game and restarts the timer with remaining time
 func (g *Game) Resume() error {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlock()
 
 	if g.table.Status != models.StatusPaused {
 		return fmt.Errorf("game not paused, current status: %s", g.table.Status)
@@ -824,22 +1091,26 @@ func (g *Game) Resume() error {
 			if currentPlayer != nil && isActive(currentPlayer) {
 				deadline := time.Now().Add(g.timerRemaining)
 				g.table.CurrentHand.ActionDeadline = &deadline
+				g.table.CurrentHand.DeadlineToken++
+				token := g.table.CurrentHand.DeadlineToken
 
 				playerID := currentPlayer.PlayerID
+				if g.pausedTimeBank == playerID {
+					g.timeBankPlayer = playerID
+					g.timeBankStart = time.Now()
+				}
 				g.actionTimer = time.AfterFunc(g.timerRemaining, func() {
 					if g.onTimeout != nil {
-						g.onTimeout(playerID)
+						g.onTimeout(playerID, token)
 					}
 				})
 
+				g.publishSnapshot()
 				if g.onEvent != nil {
-					g.onEvent(models.Event{
+					g.emitInline(models.Event{
 						Event:   "actionRequired",
 						TableID: g.table.TableID,
-						Data: models.ActionRequiredEvent{
-							PlayerID: playerID,
-							Deadline: deadline.Format(time.RFC3339),
-						},
+						Data:    g.actionRequired(currentPlayer, deadline),
 					})
 				}
 			}
@@ -847,8 +1118,9 @@ func (g *Game) Resume() error {
 	}
 
 	// Fire resume event
+	g.publishSnapshot()
 	if g.onEvent != nil {
-		g.onEvent(models.Event{
+		g.emitInline(models.Event{
 			Event:   "gameResumed",
 			TableID: g.table.TableID,
 			Data: map[string]interface{}{
@@ -949,6 +1221,7 @@ func (g *Game) addHandCompleteHistory() {
 // UpdateStatus updates the game status (for external control, e.g., tournament completion)
 func (g *Game) UpdateStatus(status models.TableStatus) {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlock()
 	g.table.Status = status
+	g.publishSnapshot()
 }
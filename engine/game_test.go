@@ -113,7 +113,7 @@ func TestGame_PauseResume(t *testing.T) {
 	table.Players[1] = players[1]
 	table.Players[2] = players[2]
 	
-	game := NewGame(table, func(pid string) {
+	game := NewGame(table, func(pid string, deadline uint64) {
 		// Timeout handler
 	}, func(e models.Event) {
 		// Event handler
@@ -185,7 +185,7 @@ func TestGame_PauseResumeRapidly(t *testing.T) {
 	table.Players[1] = players[1]
 	table.Players[2] = players[2]
 
-	game := NewGame(table, func(pid string) {
+	game := NewGame(table, func(pid string, deadline uint64) {
 		// Timeout handler - do nothing
 	}, func(e models.Event) {
 		// Event handler - do nothing
@@ -248,7 +248,7 @@ func TestGame_InactivePlayerTermination(t *testing.T) {
 	gameAbandonedEventFired := false
 	var eventMu sync.Mutex
 
-	game := NewGame(table, func(pid string) {
+	game := NewGame(table, func(pid string, deadline uint64) {
 		// Timeout handler - do nothing
 	}, func(e models.Event) {
 		eventMu.Lock()
@@ -269,7 +269,7 @@ func TestGame_InactivePlayerTermination(t *testing.T) {
 	// Simulate all players timing out until hand completes
 	for table.Status == models.StatusPlaying && table.CurrentHand != nil {
 		currentPlayer := table.Players[table.CurrentHand.CurrentPosition]
-		err = game.HandleTimeout(currentPlayer.PlayerID)
+		err = game.HandleTimeout(currentPlayer.PlayerID, table.CurrentHand.DeadlineToken)
 		if err != nil {
 			t.Fatalf("Failed to handle timeout for player %s: %v", currentPlayer.PlayerID, err)
 		}
@@ -297,7 +297,7 @@ func TestGame_InactivePlayerTermination(t *testing.T) {
 	// Simulate all players timing out until game is abandoned
 	for table.Status == models.StatusPlaying && table.CurrentHand != nil {
 		currentPlayer := table.Players[table.CurrentHand.CurrentPosition]
-		err = game.HandleTimeout(currentPlayer.PlayerID)
+		err = game.HandleTimeout(currentPlayer.PlayerID, table.CurrentHand.DeadlineToken)
 		if err != nil {
 			t.Fatalf("Failed to handle timeout for player %s: %v", currentPlayer.PlayerID, err)
 		}
@@ -358,7 +358,7 @@ func TestGame_InactivePlayerTerminationWithRealAction(t *testing.T) {
 	table.Players[0] = players[0]
 	table.Players[1] = players[1]
 
-	game := NewGame(table, func(pid string) {
+	game := NewGame(table, func(pid string, deadline uint64) {
 		// Timeout handler - do nothing
 	}, func(e models.Event) {
 		// Event handler - do nothing
@@ -373,7 +373,7 @@ func TestGame_InactivePlayerTerminationWithRealAction(t *testing.T) {
 	// Simulate all timeouts until hand completes
 	for table.Status == models.StatusPlaying && table.CurrentHand != nil {
 		currentPlayer := table.Players[table.CurrentHand.CurrentPosition]
-		err = game.HandleTimeout(currentPlayer.PlayerID)
+		err = game.HandleTimeout(currentPlayer.PlayerID, table.CurrentHand.DeadlineToken)
 		if err != nil {
 			t.Fatalf("Failed to handle timeout: %v", err)
 		}
@@ -402,7 +402,7 @@ func TestGame_InactivePlayerTerminationWithRealAction(t *testing.T) {
 	// Now let hand complete (other player can timeout or act)
 	for table.Status == models.StatusPlaying && table.CurrentHand != nil {
 		currentPlayer := table.Players[table.CurrentHand.CurrentPosition]
-		err = game.HandleTimeout(currentPlayer.PlayerID)
+		err = game.HandleTimeout(currentPlayer.PlayerID, table.CurrentHand.DeadlineToken)
 		if err != nil {
 			t.Fatalf("Failed to handle timeout: %v", err)
 		}
@@ -420,3 +420,53 @@ func TestGame_InactivePlayerTerminationWithRealAction(t *testing.T) {
 		t.Errorf("Expected game to be hand complete, got status: %s", table.Status)
 	}
 }
+
+func TestGame_StaleTimeoutIgnored(t *testing.T) {
+	table := &models.Table{
+		TableID:  "test-table",
+		GameType: models.GameTypeCash,
+		Status:   models.StatusWaiting,
+		Config: models.TableConfig{
+			SmallBlind:    10,
+			BigBlind:      20,
+			MaxPlayers:    3,
+			ActionTimeout: 30,
+		},
+		Players: []*models.Player{
+			models.NewPlayer("p1", "Player 1", 0, 1000),
+			models.NewPlayer("p2", "Player 2", 1, 1000),
+			models.NewPlayer("p3", "Player 3", 2, 1000),
+		},
+		CurrentHand: &models.CurrentHand{
+			HandNumber:     0,
+			DealerPosition: -1,
+		},
+	}
+
+	game := NewGame(table, func(pid string, deadline uint64) {}, nil)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	// The first player's deadline expires just as they act
+	staleDeadline := table.CurrentHand.DeadlineToken
+	first := table.Players[table.CurrentHand.CurrentPosition]
+	if err := game.ProcessAction(first.PlayerID, models.ActionCall, 0); err != nil {
+		t.Fatalf("Failed to call: %v", err)
+	}
+
+	next := table.Players[table.CurrentHand.CurrentPosition]
+	if err := game.HandleTimeout(next.PlayerID, staleDeadline); err != nil {
+		t.Fatalf("HandleTimeout failed: %v", err)
+	}
+	if next.Status == models.StatusFolded || table.Players[table.CurrentHand.CurrentPosition] != next {
+		t.Fatal("Expected the stale timeout to be ignored")
+	}
+
+	if err := game.HandleTimeout(next.PlayerID, table.CurrentHand.DeadlineToken); err != nil {
+		t.Fatalf("HandleTimeout failed: %v", err)
+	}
+	if next.Status != models.StatusFolded {
+		t.Errorf("Expected the current deadline to fold %s facing a bet, got %s", next.PlayerID, next.Status)
+	}
+}
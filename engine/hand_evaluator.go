@@ -2,7 +2,9 @@ package engine
 
 import (
 	"poker-engine/models"
+	"runtime"
 	"sort"
+	"sync"
 )
 
 type HandRank int
@@ -75,6 +77,53 @@ func EvaluateHand(playerCards []models.Card, communityCards []models.Card) HandE
 	return checkHighCard(allCards)
 }
 
+// concurrentEvalThreshold is the number of hands at which EvaluateHands
+// switches from serial to concurrent evaluation; below it goroutine overhead
+// outweighs the gain.
+const concurrentEvalThreshold = 4
+
+// maxEvalWorkers bounds the goroutines used by EvaluateHands
+var maxEvalWorkers = runtime.GOMAXPROCS(0)
+
+// EvaluateHands evaluates several hands against the same board.
+// Multi-way showdowns are evaluated concurrently with a bounded worker pool;
+// results are returned in the same order as hands.
+func EvaluateHands(hands [][]models.Card, communityCards []models.Card) []HandEvaluation {
+	evals := make([]HandEvaluation, len(hands))
+
+	workers := maxEvalWorkers
+	if workers > len(hands) {
+		workers = len(hands)
+	}
+	if len(hands) < concurrentEvalThreshold || workers < 2 {
+		for i, cards := range hands {
+			evals[i] = EvaluateHand(cards, communityCards)
+		}
+		return evals
+	}
+
+	jobs := make(chan int, len(hands))
+	for i := range hands {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Each worker writes a distinct index, so no locking is needed
+				evals[i] = EvaluateHand(hands[i], communityCards)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return evals
+}
+
 func CompareHands(eval1, eval2 HandEvaluation) int {
 	if eval1.Value > eval2.Value {
 		return 1
@@ -0,0 +1,426 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"poker-engine/models"
+)
+
+// TableStateVersion is the format version of TableState
+const TableStateVersion = 1
+
+// Errors of restoring a table
+var (
+	ErrTableStateVersion = errors.New("unsupported table state version")
+	ErrTableInHand       = errors.New("table already has a hand in progress")
+)
+
+// minRestoredActionTime is the least a player to act gets once a hand is
+// restored, however little was left on their clock when it was saved
+const minRestoredActionTime = 5 * time.Second
+
+// TableState is everything needed to carry a table on after a restart from
+// exactly where it stopped, including a hand in progress: seats and hole
+// cards, bets and pots, positions, the street, where the deck is and the
+// clock of the player to act. It reveals every card, so it must never leave
+// the server.
+type TableState struct {
+	Version  int                `json:"version"`
+	SavedAt  time.Time          `json:"savedAt"`
+	TableID  string             `json:"tableId"`
+	GameType models.GameType    `json:"gameType"`
+	Status   models.TableStatus `json:"status"`
+	Config   models.TableConfig `json:"config"`
+	// Published state version, so the state after a restore is newer
+	StateVersion uint64                `json:"stateVersion"`
+	Hand         *HandState            `json:"hand,omitempty"`
+	Players      []*SeatState          `json:"players"` // nil for empty seats
+	Winners      []models.Winner       `json:"winners,omitempty"`
+	History      []models.HistoryEntry `json:"history,omitempty"`
+	Deck         *DeckState            `json:"deck,omitempty"`
+	Clock        *ClockState           `json:"clock,omitempty"`     // Only while a player is on the clock or paused on it
+	TimeBanks    map[string]int64      `json:"timeBanks,omitempty"` // Milliseconds of reserve left, for players who have drawn on it
+	Record       *HandRecord           `json:"record,omitempty"`
+	LastBlinds   *BlindSeats           `json:"lastBlinds,omitempty"`
+	Straddler    string                `json:"straddler,omitempty"`
+	Held         bool                  `json:"held,omitempty"`
+	Frozen       []string              `json:"frozen,omitempty"`
+	SeatChanges  []SeatChangeState     `json:"seatChanges,omitempty"`
+	ColorUp      *ColorUpState         `json:"colorUp,omitempty"`
+
+	ConsecutiveAllTimeoutHands int `json:"consecutiveAllTimeoutHands,omitempty"`
+}
+
+// InHand reports whether the state holds a hand in progress
+func (s *TableState) InHand() bool {
+	return s.Hand != nil && (s.Status == models.StatusPlaying || s.Status == models.StatusPaused)
+}
+
+// HandState is the current hand with the counters clients never see
+type HandState struct {
+	models.CurrentHand
+	HasRealActionThisRound      bool `json:"hasRealActionThisRound,omitempty"`
+	HasRealActionThisHand       bool `json:"hasRealActionThisHand,omitempty"`
+	ConsecutiveAllTimeoutRounds int  `json:"consecutiveAllTimeoutRounds,omitempty"`
+}
+
+// SeatState is a seated player with the fields clients never see
+type SeatState struct {
+	models.Player
+	HasActedThisRound   bool `json:"hasActedThisRound,omitempty"`
+	ConsecutiveTimeouts int  `json:"consecutiveTimeouts,omitempty"`
+	MustPostBigBlind    bool `json:"mustPostBigBlind,omitempty"`
+	DisconnectGraceUsed bool `json:"disconnectGraceUsed,omitempty"`
+}
+
+// DeckState is where the deck of the current hand is. A deck that still
+// follows its seed is dealt again from the seed up to the same position. Once
+// cards were taken out of order, as in a practice hand, only the reshuffle
+// marker is kept and the cards nobody has seen are shuffled again on restore.
+type DeckState struct {
+	Seed      int64 `json:"seed"`
+	Dealt     int   `json:"dealt"`
+	Reshuffle bool  `json:"reshuffle,omitempty"`
+}
+
+// ClockState is the action clock of the player to act. Only the time left is
+// kept, so the clock stands still while the server is down.
+type ClockState struct {
+	PlayerID    string `json:"playerId"`
+	RemainingMs int64  `json:"remainingMs"`
+	TimeBank    bool   `json:"timeBank,omitempty"` // The player is acting on their reserve
+}
+
+// SeatChangeState is a seat move waiting for the hand to end
+type SeatChangeState struct {
+	PlayerID   string `json:"playerId"`
+	TargetSeat int    `json:"targetSeat"`
+}
+
+// ColorUpState is a color-up waiting for the hand to end
+type ColorUpState struct {
+	RemovedDenomination int           `json:"removedDenomination"`
+	MinDenomination     int           `json:"minDenomination"`
+	Method              ColorUpMethod `json:"method"`
+}
+
+// State captures the full table state for crash recovery
+func (t *Table) State() *TableState {
+	return t.game.State()
+}
+
+// Restore puts a table back into a saved state, restarting the clock of the
+// player to act with the time they had left. A hand saved while paused stays
+// paused. The table must not be in a hand; players and config are replaced.
+func (t *Table) Restore(state *TableState) error {
+	return t.game.Restore(state)
+}
+
+// State captures the full game state
+func (g *Game) State() *TableState {
+	g.mu.Lock()
+	defer g.unlock()
+
+	t := g.table
+	state := &TableState{
+		Version:                    TableStateVersion,
+		SavedAt:                    time.Now(),
+		TableID:                    t.TableID,
+		GameType:                   t.GameType,
+		Status:                     t.Status,
+		Config:                     t.Config,
+		StateVersion:               t.Version,
+		Players:                    make([]*SeatState, len(t.Players)),
+		LastBlinds:                 g.lastBlinds,
+		Straddler:                  g.straddler,
+		Held:                       g.heldBetweenHands,
+		ConsecutiveAllTimeoutHands: t.ConsecutiveAllTimeoutHands,
+	}
+
+	if h := t.CurrentHand.Clone(); h != nil {
+		h.ActionDeadline = nil
+		state.Hand = &HandState{
+			CurrentHand:                 *h,
+			HasRealActionThisRound:      h.HasRealActionThisRound,
+			HasRealActionThisHand:       h.HasRealActionThisHand,
+			ConsecutiveAllTimeoutRounds: h.ConsecutiveAllTimeoutRounds,
+		}
+	}
+	for i, p := range t.Players {
+		if p == nil {
+			continue
+		}
+		state.Players[i] = &SeatState{
+			Player:              *p.Clone(),
+			HasActedThisRound:   p.HasActedThisRound,
+			ConsecutiveTimeouts: p.ConsecutiveTimeouts,
+			MustPostBigBlind:    p.MustPostBigBlind,
+			DisconnectGraceUsed: p.DisconnectGraceUsed,
+		}
+	}
+	for _, w := range t.Winners {
+		w.HandCards = append([]models.Card(nil), w.HandCards...)
+		state.Winners = append(state.Winners, w)
+	}
+	state.History = append([]models.HistoryEntry(nil), t.History...)
+
+	if t.Deck != nil {
+		dealt, ok := t.Deck.Position()
+		state.Deck = &DeckState{Seed: t.Deck.Seed(), Dealt: dealt, Reshuffle: !ok}
+		if !ok {
+			state.Deck.Dealt = 0
+		}
+	}
+
+	state.Clock = g.clockState()
+	for playerID, left := range g.timeBanks {
+		if state.TimeBanks == nil {
+			state.TimeBanks = make(map[string]int64)
+		}
+		state.TimeBanks[playerID] = left.Milliseconds()
+	}
+	if state.Clock != nil && state.Clock.TimeBank && g.timeBankPlayer != "" {
+		// The reserve is only charged when the clock stops; what is left is on the clock
+		if state.TimeBanks == nil {
+			state.TimeBanks = make(map[string]int64)
+		}
+		state.TimeBanks[g.timeBankPlayer] = state.Clock.RemainingMs
+	}
+
+	if g.handRecord != nil {
+		state.Record = g.handRecord.clone()
+	}
+	for playerID, frozen := range g.frozen {
+		if frozen {
+			state.Frozen = append(state.Frozen, playerID)
+		}
+	}
+	sort.Strings(state.Frozen)
+	for _, change := range g.seatChanges {
+		state.SeatChanges = append(state.SeatChanges, SeatChangeState{PlayerID: change.playerID, TargetSeat: change.targetSeat})
+	}
+	if c := g.pendingColorUp; c != nil {
+		state.ColorUp = &ColorUpState{RemovedDenomination: c.removedDenomination, MinDenomination: c.minDenomination, Method: c.method}
+	}
+	return state
+}
+
+// clockState returns the clock of the player to act, if one is running or was
+// paused. Caller must hold g.mu.
+func (g *Game) clockState() *ClockState {
+	h := g.table.CurrentHand
+	if h == nil || h.CurrentPosition < 0 || h.CurrentPosition >= len(g.table.Players) {
+		return nil
+	}
+	player := g.table.Players[h.CurrentPosition]
+	if player == nil {
+		return nil
+	}
+
+	switch {
+	case g.table.Status == models.StatusPlaying && h.ActionDeadline != nil:
+		remaining := time.Until(*h.ActionDeadline)
+		if remaining < 0 {
+			remaining = 0
+		}
+		return &ClockState{PlayerID: player.PlayerID, RemainingMs: remaining.Milliseconds(), TimeBank: g.timeBankPlayer == player.PlayerID}
+	case g.table.Status == models.StatusPaused && g.timerRemaining > 0:
+		return &ClockState{PlayerID: player.PlayerID, RemainingMs: g.timerRemaining.Milliseconds(), TimeBank: g.pausedTimeBank == player.PlayerID}
+	}
+	return nil
+}
+
+// Restore puts the game back into a saved state
+func (g *Game) Restore(state *TableState) error {
+	if state == nil {
+		return fmt.Errorf("no table state to restore")
+	}
+	if state.Version != TableStateVersion {
+		return fmt.Errorf("%w: %d", ErrTableStateVersion, state.Version)
+	}
+
+	g.mu.Lock()
+	defer g.unlock()
+
+	if state.TableID != g.table.TableID {
+		return fmt.Errorf("state of table %s can't be restored on table %s", state.TableID, g.table.TableID)
+	}
+	if g.table.Status == models.StatusPlaying || g.table.Status == models.StatusPaused {
+		return ErrTableInHand
+	}
+	if len(state.Players) != state.Config.MaxPlayers {
+		return fmt.Errorf("state has %d seats for a table of %d", len(state.Players), state.Config.MaxPlayers)
+	}
+
+	var hand *models.CurrentHand
+	if state.Hand != nil {
+		h := state.Hand.CurrentHand
+		h.HasRealActionThisRound = state.Hand.HasRealActionThisRound
+		h.HasRealActionThisHand = state.Hand.HasRealActionThisHand
+		h.ConsecutiveAllTimeoutRounds = state.Hand.ConsecutiveAllTimeoutRounds
+		h.ActionDeadline = nil
+		// A winner's chance to show cards isn't carried over; the hand is over
+		h.ShowCardsPlayerID, h.ShowCardsDeadline = "", nil
+		hand = h.Clone()
+		if hand.CommunityCards == nil {
+			hand.CommunityCards = make([]models.Card, 0)
+		}
+	}
+
+	players := make([]*models.Player, len(state.Players))
+	for i, seat := range state.Players {
+		if seat == nil {
+			continue
+		}
+		p := seat.Player.Clone()
+		p.HasActedThisRound = seat.HasActedThisRound
+		p.ConsecutiveTimeouts = seat.ConsecutiveTimeouts
+		p.MustPostBigBlind = seat.MustPostBigBlind
+		p.DisconnectGraceUsed = seat.DisconnectGraceUsed
+		if p.Cards == nil {
+			p.Cards = make([]models.Card, 0, 2)
+		}
+		players[i] = p
+	}
+
+	deck, reshuffled, err := restoreDeck(state.Deck, players, hand)
+	if err != nil {
+		return err
+	}
+
+	if g.actionTimer != nil {
+		g.actionTimer.Stop()
+		g.actionTimer = nil
+	}
+	if g.showCardsTimer != nil {
+		g.showCardsTimer.Stop()
+		g.showCardsTimer = nil
+	}
+
+	t := g.table
+	t.Status = state.Status
+	t.Config = state.Config
+	t.CurrentHand = hand
+	if t.CurrentHand == nil {
+		t.CurrentHand = &models.CurrentHand{DealerPosition: -1, CommunityCards: make([]models.Card, 0)}
+	}
+	t.Players = players
+	t.Winners = state.Winners
+	t.History = append([]models.HistoryEntry(nil), state.History...)
+	t.Deck = deck
+	t.ConsecutiveAllTimeoutHands = state.ConsecutiveAllTimeoutHands
+	if state.StateVersion > t.Version {
+		t.Version = state.StateVersion
+	}
+
+	g.lastBlinds = state.LastBlinds
+	g.straddler = state.Straddler
+	g.heldBetweenHands = state.Held
+	g.handRecord = nil
+	if state.Record != nil && !reshuffled {
+		// A reshuffled deck no longer matches the seed, so the hand can't be replayed
+		g.handRecord = state.Record.clone()
+	}
+	g.frozen = nil
+	for _, playerID := range state.Frozen {
+		if g.frozen == nil {
+			g.frozen = make(map[string]bool)
+		}
+		g.frozen[playerID] = true
+	}
+	g.seatChanges = nil
+	for _, change := range state.SeatChanges {
+		g.seatChanges = append(g.seatChanges, seatChangeRequest{playerID: change.PlayerID, targetSeat: change.TargetSeat})
+	}
+	g.pendingColorUp = nil
+	if c := state.ColorUp; c != nil {
+		g.pendingColorUp = &colorUpRequest{removedDenomination: c.RemovedDenomination, minDenomination: c.MinDenomination, method: c.Method}
+	}
+	g.timeBanks = nil
+	for playerID, ms := range state.TimeBanks {
+		if g.timeBanks == nil {
+			g.timeBanks = make(map[string]time.Duration)
+		}
+		g.timeBanks[playerID] = time.Duration(ms) * time.Millisecond
+	}
+	g.timeBankPlayer = ""
+	g.pausedTimeBank = ""
+	g.timerRemaining = 0
+	g.pausedAt = nil
+
+	g.restoreClock(state)
+	g.publishSnapshot()
+	return nil
+}
+
+// restoreClock restarts the clock of the player to act, or leaves it paused
+// with the time they had. Caller must hold g.mu.
+func (g *Game) restoreClock(state *TableState) {
+	clock := state.Clock
+	if clock == nil || !state.InHand() {
+		return
+	}
+	pos := g.table.CurrentHand.CurrentPosition
+	if pos < 0 || pos >= len(g.table.Players) {
+		return
+	}
+	player := g.table.Players[pos]
+	if player == nil || player.PlayerID != clock.PlayerID {
+		return
+	}
+	remaining := time.Duration(clock.RemainingMs) * time.Millisecond
+	if remaining < minRestoredActionTime {
+		remaining = minRestoredActionTime
+	}
+
+	if state.Status == models.StatusPaused {
+		now := time.Now()
+		g.pausedAt = &now
+		g.timerRemaining = remaining
+		if clock.TimeBank {
+			g.pausedTimeBank = player.PlayerID
+		}
+		return
+	}
+	if clock.TimeBank {
+		if g.timeBanks == nil {
+			g.timeBanks = make(map[string]time.Duration)
+		}
+		g.timeBanks[player.PlayerID] = remaining
+		g.timeBankPlayer = player.PlayerID
+		g.timeBankStart = time.Now()
+	}
+	g.armActionTimer(player, remaining)
+}
+
+// restoreDeck recreates the deck of a saved hand. A deck that was taken out
+// of order is replaced by a fresh shuffle of the cards nobody holds, which
+// reshuffled reports.
+func restoreDeck(saved *DeckState, players []*models.Player, hand *models.CurrentHand) (deck *models.Deck, reshuffled bool, err error) {
+	if saved == nil {
+		return nil, false, nil
+	}
+	if !saved.Reshuffle {
+		deck, err := models.NewDeckAt(saved.Seed, saved.Dealt)
+		if err != nil {
+			return nil, false, err
+		}
+		return deck, false, nil
+	}
+
+	var seen []models.Card
+	for _, p := range players {
+		if p != nil {
+			seen = append(seen, p.Cards...)
+		}
+	}
+	if hand != nil {
+		seen = append(seen, hand.CommunityCards...)
+	}
+	deck = models.NewDeck()
+	deck.Remove(seen)
+	return deck, true, nil
+}
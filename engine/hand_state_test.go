@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func newStateTable(t *testing.T) *Table {
+	t.Helper()
+	config := models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 4, ActionTimeout: 30}
+	table := NewTable("state-table", models.GameTypeCash, config, nil, nil)
+	for i, id := range []string{"a", "b", "c"} {
+		if err := table.AddPlayer(id, "Player "+id, i, 1000); err != nil {
+			t.Fatalf("AddPlayer failed: %v", err)
+		}
+	}
+	return table
+}
+
+func TestTable_StateRoundTripsMidHand(t *testing.T) {
+	original := newStateTable(t)
+	if err := original.game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	toAct := original.model.Players[original.model.CurrentHand.CurrentPosition]
+	if err := original.game.ProcessAction(toAct.PlayerID, models.ActionCall, 0); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+
+	data, err := json.Marshal(original.State())
+	if err != nil {
+		t.Fatalf("Failed to encode state: %v", err)
+	}
+	var state TableState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("Failed to decode state: %v", err)
+	}
+	if !state.InHand() || state.Deck == nil || state.Deck.Reshuffle || state.Clock == nil {
+		t.Fatalf("Expected a hand in progress with a seeded deck and a running clock, got %+v", state)
+	}
+
+	restored := newStateTable(t)
+	if err := restored.Restore(&state); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	a, b := original.model, restored.model
+	if b.Status != models.StatusPlaying || b.CurrentHand.CurrentPosition != a.CurrentHand.CurrentPosition ||
+		b.CurrentHand.Pot.Main != a.CurrentHand.Pot.Main || b.CurrentHand.CurrentBet != a.CurrentHand.CurrentBet {
+		t.Fatalf("Restored hand differs: %+v vs %+v", b.CurrentHand, a.CurrentHand)
+	}
+	for i := range a.Players {
+		if a.Players[i] == nil {
+			continue
+		}
+		if !reflect.DeepEqual(a.Players[i].Cards, b.Players[i].Cards) || a.Players[i].Chips != b.Players[i].Chips ||
+			a.Players[i].HasActedThisRound != b.Players[i].HasActedThisRound {
+			t.Errorf("Seat %d differs after restore", i)
+		}
+	}
+	if b.CurrentHand.ActionDeadline == nil {
+		t.Error("Expected the clock of the player to act to be running again")
+	}
+	if err := restored.Restore(&state); !errors.Is(err, ErrTableInHand) {
+		t.Errorf("Expected ErrTableInHand on a table in a hand, got %v", err)
+	}
+
+	// The rest of the hand comes off the same deck
+	playToShowdown(t, original.game, a)
+	playToShowdown(t, restored.game, b)
+	if !reflect.DeepEqual(a.CurrentHand.CommunityCards, b.CurrentHand.CommunityCards) || !reflect.DeepEqual(a.Winners, b.Winners) {
+		t.Errorf("Expected the same board and winners, got %v/%v and %v/%v",
+			a.CurrentHand.CommunityCards, a.Winners, b.CurrentHand.CommunityCards, b.Winners)
+	}
+
+	state.Version = TableStateVersion + 1
+	if err := newStateTable(t).Restore(&state); !errors.Is(err, ErrTableStateVersion) {
+		t.Errorf("Expected ErrTableStateVersion, got %v", err)
+	}
+}
+
+func TestTable_StatePausedHandStaysPaused(t *testing.T) {
+	original := newStateTable(t)
+	if err := original.game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if err := original.Pause(); err != nil {
+		t.Fatalf("Pause failed: %v", err)
+	}
+
+	restored := newStateTable(t)
+	if err := restored.Restore(original.State()); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if restored.model.Status != models.StatusPaused || restored.model.CurrentHand.ActionDeadline != nil {
+		t.Fatalf("Expected the hand to stay paused with no clock running, got %s", restored.model.Status)
+	}
+	if err := restored.Resume(); err != nil || restored.model.CurrentHand.ActionDeadline == nil {
+		t.Errorf("Expected Resume to restart the clock, got %v", err)
+	}
+}
+
+func TestTable_StateReshufflesOutOfOrderDeck(t *testing.T) {
+	original := newStateTable(t)
+	if err := original.game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	// Take the second card out from under the first, as fixing a board does
+	deck := original.model.Deck
+	next, _ := deck.Deal()
+	deck.Deal()
+	deck.PutOnTop([]models.Card{next})
+
+	state := original.State()
+	if state.Deck == nil || !state.Deck.Reshuffle {
+		t.Fatalf("Expected a deck taken out of order to be marked for reshuffling, got %+v", state.Deck)
+	}
+
+	restored := newStateTable(t)
+	if err := restored.Restore(state); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	deck = restored.model.Deck
+	if deck.CardsRemaining() != 52-6 {
+		t.Errorf("Expected the 46 cards nobody holds, got %d", deck.CardsRemaining())
+	}
+	for deck.CardsRemaining() > 0 {
+		card, _ := deck.Deal()
+		for _, p := range restored.model.Players {
+			if p != nil && (reflect.DeepEqual(p.Cards[0], card) || reflect.DeepEqual(p.Cards[1], card)) {
+				t.Fatalf("Reshuffled deck still holds %v, dealt to %s", card, p.PlayerID)
+			}
+		}
+	}
+	if restored.HandRecord() != nil {
+		t.Error("Expected no hand record once the deck no longer matches its seed")
+	}
+}
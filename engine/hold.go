@@ -0,0 +1,32 @@
+package engine
+
+import "errors"
+
+// ErrHandsHeld is returned when a hand is started at a table held between hands
+var ErrHandsHeld = errors.New("table is held between hands")
+
+// HoldBetweenHands stops the table from starting another hand until it is
+// released. A hand in progress is played out. Tournaments use it for
+// hand-for-hand play, where no table deals until every table has finished.
+func (t *Table) HoldBetweenHands(held bool) {
+	t.game.HoldBetweenHands(held)
+}
+
+// IsHeldBetweenHands reports whether the table is held
+func (t *Table) IsHeldBetweenHands() bool {
+	return t.game.IsHeldBetweenHands()
+}
+
+// HoldBetweenHands sets or clears the hold
+func (g *Game) HoldBetweenHands(held bool) {
+	g.mu.Lock()
+	defer g.unlock()
+	g.heldBetweenHands = held
+}
+
+// IsHeldBetweenHands reports whether the game is held
+func (g *Game) IsHeldBetweenHands() bool {
+	g.mu.Lock()
+	defer g.unlock()
+	return g.heldBetweenHands
+}
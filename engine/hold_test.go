@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestGame_HoldBetweenHands(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000, 1000)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	// A hold taken during a hand lets the hand finish
+	game.HoldBetweenHands(true)
+	playToShowdown(t, game, table)
+
+	if err := game.StartNewHand(); !errors.Is(err, ErrHandsHeld) {
+		t.Fatalf("Expected ErrHandsHeld, got %v", err)
+	}
+	if table.Status != models.StatusHandComplete {
+		t.Errorf("Expected the table to stay between hands, got %s", table.Status)
+	}
+	if !game.IsHeldBetweenHands() {
+		t.Error("Expected the table to report the hold")
+	}
+
+	game.HoldBetweenHands(false)
+	if err := game.StartNewHand(); err != nil {
+		t.Errorf("Expected the next hand to start once released, got %v", err)
+	}
+}
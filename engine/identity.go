@@ -0,0 +1,42 @@
+package engine
+
+import "fmt"
+
+// WithPlayerIDs returns a copy of the record with every player ID replaced
+// through ids, for moving a hand into a system that names its players
+// differently. Every seated player needs a new ID and no two may share one,
+// so the copy replays exactly like the original.
+func (r *HandRecord) WithPlayerIDs(ids map[string]string) (*HandRecord, error) {
+	clone := r.clone()
+	used := make(map[string]string, len(ids))
+	for _, p := range clone.Players {
+		if p == nil {
+			continue
+		}
+		newID, ok := ids[p.PlayerID]
+		if !ok || newID == "" {
+			return nil, fmt.Errorf("no new ID for player %s", p.PlayerID)
+		}
+		if other, taken := used[newID]; taken && other != p.PlayerID {
+			return nil, fmt.Errorf("players %s and %s would both become %s", other, p.PlayerID, newID)
+		}
+		used[newID] = p.PlayerID
+		p.PlayerID = newID
+	}
+
+	for i, action := range clone.Actions {
+		newID, ok := ids[action.PlayerID]
+		if !ok {
+			return nil, fmt.Errorf("recorded action %d is by unseated player %s", i+1, action.PlayerID)
+		}
+		clone.Actions[i].PlayerID = newID
+	}
+	if clone.Straddle != "" {
+		newID, ok := ids[clone.Straddle]
+		if !ok {
+			return nil, fmt.Errorf("straddle is by unseated player %s", clone.Straddle)
+		}
+		clone.Straddle = newID
+	}
+	return clone, nil
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestKnockouts_HighestPotTakesTheLastChips(t *testing.T) {
+	// p1 and p4 are all in for 50 and 100. p2 wins the main pot and the
+	// first side pot, but p3 wins the pot p4 went all in for.
+	players := []*models.Player{
+		{PlayerID: "p1", TotalInvestedThisHand: 50, Status: models.StatusAllIn, Cards: mustCards(t, "3h4h")},
+		{PlayerID: "p2", TotalInvestedThisHand: 80, Status: models.StatusAllIn, Cards: mustCards(t, "KhKd")},
+		{PlayerID: "p3", TotalInvestedThisHand: 120, Status: models.StatusActive, Cards: mustCards(t, "AhAd")},
+		{PlayerID: "p4", TotalInvestedThisHand: 100, Status: models.StatusAllIn, Cards: mustCards(t, "5s6s")},
+	}
+	community := mustCards(t, "2c7d9hJsKc")
+	pot := NewPotCalculator().CalculateHandPots(players)
+
+	winners, potWinners := distributePots(pot, players, community)
+	for _, w := range winners {
+		findPlayerByID(players, w.PlayerID).Chips += w.Amount
+	}
+	if players[0].Chips != 0 || players[3].Chips != 0 {
+		t.Fatalf("Expected p1 and p4 to bust, got %d and %d", players[0].Chips, players[3].Chips)
+	}
+
+	got := knockouts(pot, players, potWinners)
+	want := []models.Knockout{
+		{PlayerID: "p1", EliminatedBy: []string{"p2"}},
+		{PlayerID: "p4", EliminatedBy: []string{"p3"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected knockouts %+v, got %+v", want, got)
+	}
+}
+
+func TestKnockouts_SplitPot(t *testing.T) {
+	// p2 and p3 chop the pot p1 was all in for
+	players := []*models.Player{
+		{PlayerID: "p1", TotalInvestedThisHand: 50, Status: models.StatusAllIn, Cards: mustCards(t, "3h4h")},
+		{PlayerID: "p2", TotalInvestedThisHand: 50, Status: models.StatusActive, Chips: 100, Cards: mustCards(t, "AhQd")},
+		{PlayerID: "p3", TotalInvestedThisHand: 50, Status: models.StatusActive, Chips: 100, Cards: mustCards(t, "AsQc")},
+	}
+	community := mustCards(t, "2c7d9hJsKc")
+	pot := NewPotCalculator().CalculateHandPots(players)
+
+	_, potWinners := distributePots(pot, players, community)
+	got := knockouts(pot, players, potWinners)
+	want := []models.Knockout{{PlayerID: "p1", EliminatedBy: []string{"p2", "p3"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected knockouts %+v, got %+v", want, got)
+	}
+
+	// Nobody busts when everyone keeps chips
+	players[0].Chips = 10
+	if got := knockouts(pot, players, potWinners); len(got) != 0 {
+		t.Errorf("Expected no knockouts, got %+v", got)
+	}
+}
@@ -0,0 +1,25 @@
+package engine
+
+import "fmt"
+
+// SetPlayerLevel sets the experience level shown for a seated player. It has
+// no effect on play.
+func (t *Table) SetPlayerLevel(playerID string, level int) error {
+	return t.game.SetPlayerLevel(playerID, level)
+}
+
+// SetPlayerLevel updates a seated player's level
+func (g *Game) SetPlayerLevel(playerID string, level int) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return fmt.Errorf("player not found")
+	}
+	if player.Level != level {
+		player.Level = level
+		g.publishSnapshot()
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package engine
+
+import (
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestGame_SetPlayerLevel(t *testing.T) {
+	table := &models.Table{
+		TableID:     "level-table",
+		GameType:    models.GameTypeCash,
+		Status:      models.StatusWaiting,
+		Config:      models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 2},
+		Players:     []*models.Player{models.NewPlayer("p1", "Player 1", 0, 1000), nil},
+		CurrentHand: &models.CurrentHand{DealerPosition: -1},
+	}
+	game := NewGame(table, func(string, uint64) {}, func(models.Event) {})
+	game.SetSynchronousEvents(true)
+	game.Snapshot()
+
+	if err := game.SetPlayerLevel("p9", 3); err == nil {
+		t.Error("Expected an error setting the level of an unknown player")
+	}
+	if err := game.SetPlayerLevel("p1", 3); err != nil {
+		t.Fatalf("SetPlayerLevel failed: %v", err)
+	}
+	if level := game.Snapshot().Players[0].Level; level != 3 {
+		t.Errorf("Expected the snapshot to show level 3, got %d", level)
+	}
+}
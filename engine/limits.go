@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"poker-engine/models"
+)
+
+// SetBettingMode sets how much a bet or raise may be: one of the
+// models.Betting modes, or empty for no limit
+func (t *Table) SetBettingMode(mode string) error {
+	if t.game != nil {
+		t.game.mu.Lock()
+		defer t.game.mu.Unlock()
+	}
+
+	if !models.ValidBettingMode(mode) {
+		return fmt.Errorf("unknown betting mode %q", mode)
+	}
+	t.model.Config.BettingMode = mode
+
+	if t.game != nil {
+		t.game.publishSnapshot()
+	}
+	return nil
+}
+
+// bettingValidator returns a validator for the current betting round under
+// the table's betting mode. Caller must hold g.mu.
+func (g *Game) bettingValidator() *BettingValidator {
+	hand := g.table.CurrentHand
+	pot := 0
+	for _, p := range g.table.Players {
+		if p != nil {
+			pot += p.TotalInvestedThisHand
+		}
+	}
+	return NewBettingValidator(hand.CurrentBet, hand.MinRaise).
+		withLimits(g.table.Config.BettingMode, pot, g.fixedBetSize(), hand.Raises)
+}
+
+// fixedBetSize is the size of a fixed limit bet this round: the big blind
+// before the turn and twice it from the turn on. Caller must hold g.mu.
+func (g *Game) fixedBetSize() int {
+	switch g.table.CurrentHand.BettingRound {
+	case models.RoundTurn, models.RoundRiver:
+		return 2 * g.table.Config.BigBlind
+	}
+	return g.table.Config.BigBlind
+}
+
+// actionRequired builds the actionRequired event for a player's turn, with
+// the actions they may take, what they need to call and the totals they may
+// raise to. Caller must hold g.mu.
+func (g *Game) actionRequired(player *models.Player, deadline time.Time) models.ActionRequiredEvent {
+	call := g.table.CurrentHand.CurrentBet - player.Bet
+	if call > player.Chips {
+		call = player.Chips
+	}
+	validator := g.bettingValidator()
+	minRaise, maxRaise := validator.raiseBounds(player.Bet, player.Chips)
+	return models.ActionRequiredEvent{
+		PlayerID:       player.PlayerID,
+		Deadline:       deadline.Format(time.RFC3339),
+		TimeBank:       player.TimeBank,
+		InTimeBank:     g.timeBankPlayer == player.PlayerID,
+		LegalActions:   validator.legalActions(player.Bet, player.Chips),
+		CallAmount:     call,
+		MinRaiseTo:     minRaise,
+		MaxRaiseTo:     maxRaise,
+		EffectiveStack: g.effectiveStack(player),
+		BettingMode:    g.table.Config.BettingMode,
+	}
+}
+
+// effectiveStack is the most a player can win or lose from here on: their
+// stack, this round's bet included, or the biggest stack among the others
+// still in the hand, whichever is smaller. Caller must hold g.mu.
+func (g *Game) effectiveStack(player *models.Player) int {
+	biggest := 0
+	for _, p := range g.table.Players {
+		if p == nil || p == player || !isActive(p) || len(p.Cards) == 0 {
+			continue
+		}
+		if stack := p.Chips + p.Bet; stack > biggest {
+			biggest = stack
+		}
+	}
+	if own := player.Chips + player.Bet; own < biggest {
+		return own
+	}
+	return biggest
+}
@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"poker-engine/models"
+)
+
+func newLimitTestGame(t *testing.T, mode string, events *[]models.Event) (*Game, *models.Table) {
+	t.Helper()
+	table := &models.Table{
+		TableID:  "limit-table",
+		GameType: models.GameTypeCash,
+		Status:   models.StatusWaiting,
+		Config:   models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 3, ActionTimeout: 30, BettingMode: mode},
+		Players: []*models.Player{
+			models.NewPlayer("p1", "Player 1", 0, 1000),
+			models.NewPlayer("p2", "Player 2", 1, 1000),
+			models.NewPlayer("p3", "Player 3", 2, 1000),
+		},
+		CurrentHand: &models.CurrentHand{HandNumber: 0, DealerPosition: -1},
+	}
+	game := NewGame(table, func(string, uint64) {}, func(e models.Event) { *events = append(*events, e) })
+	game.SetSynchronousEvents(true)
+	game.replay = true // Rounds can open with the player who closed the last one
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	return game, table
+}
+
+// lastActionRequired returns the latest actionRequired event
+func lastActionRequired(t *testing.T, events []models.Event) models.ActionRequiredEvent {
+	t.Helper()
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Event == "actionRequired" {
+			return events[i].Data.(models.ActionRequiredEvent)
+		}
+	}
+	t.Fatal("Expected an actionRequired event")
+	return models.ActionRequiredEvent{}
+}
+
+func toAct(table *models.Table) string {
+	return table.Players[table.CurrentHand.CurrentPosition].PlayerID
+}
+
+func TestFixedLimit_BetSizesAndCap(t *testing.T) {
+	var events []models.Event
+	game, table := newLimitTestGame(t, models.BettingFixedLimit, &events)
+
+	required := lastActionRequired(t, events)
+	if required.CallAmount != 20 || required.MinRaiseTo != 40 || required.MaxRaiseTo != 40 {
+		t.Fatalf("Expected to call 20 or raise to exactly 40, got %+v", required)
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionRaise, 60); err == nil {
+		t.Error("Expected a raise of two bets to fail")
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionAllIn, 0); err == nil {
+		t.Error("Expected an all-in over the limit to fail")
+	}
+
+	// The big blind, then three raises, caps the betting
+	for _, to := range []int{40, 60, 80} {
+		if err := game.ProcessAction(toAct(table), models.ActionRaise, to); err != nil {
+			t.Fatalf("Raise to %d failed: %v", to, err)
+		}
+	}
+	if table.CurrentHand.Raises != models.FixedLimitCap {
+		t.Fatalf("Expected %d bets, got %d", models.FixedLimitCap, table.CurrentHand.Raises)
+	}
+	required = lastActionRequired(t, events)
+	if required.MinRaiseTo != 0 || required.MaxRaiseTo != 0 || required.CallAmount != 40 {
+		t.Errorf("Expected a capped round to offer only the call of 40, got %+v", required)
+	}
+	if want := []models.PlayerAction{models.ActionFold, models.ActionCall}; !reflect.DeepEqual(required.LegalActions, want) {
+		t.Errorf("Expected %v once capped, got %v", want, required.LegalActions)
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionRaise, 100); err == nil {
+		t.Error("Expected a fifth bet to fail")
+	}
+	for table.CurrentHand.BettingRound == models.RoundPreflop {
+		if err := game.ProcessAction(toAct(table), models.ActionCall, 0); err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+	}
+
+	// One big blind on the flop, two on the turn
+	if table.CurrentHand.Raises != 0 {
+		t.Fatalf("Expected the count to start over on the flop, got %d", table.CurrentHand.Raises)
+	}
+	if required = lastActionRequired(t, events); required.MinRaiseTo != 20 || required.MaxRaiseTo != 20 {
+		t.Errorf("Expected a flop bet of 20, got %+v", required)
+	}
+	for table.CurrentHand.BettingRound == models.RoundFlop {
+		if err := game.ProcessAction(toAct(table), models.ActionCheck, 0); err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionRaise, 20); err == nil {
+		t.Error("Expected a turn bet of one big blind to fail")
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionRaise, 40); err != nil {
+		t.Errorf("Expected a turn bet of two big blinds, got %v", err)
+	}
+}
+
+func TestPotLimit_RaiseSizing(t *testing.T) {
+	var events []models.Event
+	game, table := newLimitTestGame(t, models.BettingPotLimit, &events)
+
+	// 30 in the pot and 20 to call: the pot after calling is 50
+	required := lastActionRequired(t, events)
+	if required.CallAmount != 20 || required.MinRaiseTo != 40 || required.MaxRaiseTo != 70 {
+		t.Fatalf("Expected to raise to between 40 and 70, got %+v", required)
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionRaise, 80); err == nil {
+		t.Error("Expected a raise over the pot to fail")
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionRaise, 70); err != nil {
+		t.Fatalf("Pot raise failed: %v", err)
+	}
+
+	// The small blind has 10 in: 100 in the pot and 60 to call
+	required = lastActionRequired(t, events)
+	if required.CallAmount != 60 || required.MinRaiseTo != 120 || required.MaxRaiseTo != 230 {
+		t.Errorf("Expected to raise to between 120 and 230, got %+v", required)
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionAllIn, 0); err == nil {
+		t.Error("Expected an all-in over the pot to fail")
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionRaise, 230); err != nil {
+		t.Errorf("Pot re-raise failed: %v", err)
+	}
+}
+
+func TestNoLimit_RaiseBounds(t *testing.T) {
+	var events []models.Event
+	game, table := newLimitTestGame(t, "", &events)
+
+	required := lastActionRequired(t, events)
+	if required.MinRaiseTo != 40 || required.MaxRaiseTo != 1000 {
+		t.Errorf("Expected to raise to between 40 and all in, got %+v", required)
+	}
+	if err := game.ProcessAction(toAct(table), models.ActionAllIn, 0); err != nil {
+		t.Errorf("All-in failed: %v", err)
+	}
+}
+
+func TestActionRequired_LegalActions(t *testing.T) {
+	var events []models.Event
+	game, table := newLimitTestGame(t, "", &events)
+
+	required := lastActionRequired(t, events)
+	want := []models.PlayerAction{models.ActionFold, models.ActionCall, models.ActionRaise, models.ActionAllIn}
+	if !reflect.DeepEqual(required.LegalActions, want) || required.EffectiveStack != 1000 {
+		t.Errorf("Expected %v with 1000 effective, got %v with %d", want, required.LegalActions, required.EffectiveStack)
+	}
+
+	// A stack short of a full raise can call or go all in
+	player := table.Players[table.CurrentHand.CurrentPosition]
+	player.Chips = 30
+	required = game.actionRequired(player, time.Now())
+	want = []models.PlayerAction{models.ActionFold, models.ActionCall, models.ActionAllIn}
+	if !reflect.DeepEqual(required.LegalActions, want) || required.EffectiveStack != 30 {
+		t.Errorf("Expected %v with 30 effective, got %v with %d", want, required.LegalActions, required.EffectiveStack)
+	}
+	if required.MinRaiseTo != 30 || required.MaxRaiseTo != 30 {
+		t.Errorf("Expected only the all-in of 30, got %d to %d", required.MinRaiseTo, required.MaxRaiseTo)
+	}
+
+	// The big blind can check their option
+	for _, action := range []models.PlayerAction{models.ActionCall, models.ActionCall} {
+		if err := game.ProcessAction(toAct(table), action, 0); err != nil {
+			t.Fatalf("%s failed: %v", action, err)
+		}
+	}
+	required = lastActionRequired(t, events)
+	want = []models.PlayerAction{models.ActionFold, models.ActionCheck, models.ActionRaise, models.ActionAllIn}
+	if !reflect.DeepEqual(required.LegalActions, want) || required.CallAmount != 0 {
+		t.Errorf("Expected %v for the big blind, got %v calling %d", want, required.LegalActions, required.CallAmount)
+	}
+}
+
+func TestSetBettingMode(t *testing.T) {
+	table := NewTable("mode", models.GameTypeCash, models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 6}, nil, nil)
+	if err := table.SetBettingMode("spread_limit"); err == nil {
+		t.Error("Expected an unknown betting mode to be refused")
+	}
+	if err := table.SetBettingMode(models.BettingPotLimit); err != nil {
+		t.Fatalf("SetBettingMode failed: %v", err)
+	}
+	if mode := table.GetState().Config.BettingMode; mode != models.BettingPotLimit {
+		t.Errorf("Expected pot limit, got %q", mode)
+	}
+}
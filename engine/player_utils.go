@@ -52,7 +52,10 @@ func resetPlayerForNewHand(p *models.Player) {
 	p.IsDealer = false
 	p.IsSmallBlind = false
 	p.IsBigBlind = false
+	p.IsStraddle = false
+	p.CalledFinalBet = false
 	p.Cards = nil
+	p.ShownCards = nil
 	p.TotalInvestedThisHand = 0
 }
 
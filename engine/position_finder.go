@@ -53,10 +53,10 @@ func (pf *PositionFinder) calculateBlindPositions(dealerPos, activePlayers int)
 	}
 
 	if activePlayers == 2 {
-		return dealerPos, pf.findNextActive(dealerPos)
+		return dealerPos, pf.findNextWithChips(dealerPos)
 	}
 
-	sbPos := pf.findNextActive(dealerPos)
-	bbPos := pf.findNextActive(sbPos)
+	sbPos := pf.findNextWithChips(dealerPos)
+	bbPos := pf.findNextWithChips(sbPos)
 	return sbPos, bbPos
 }
@@ -1,6 +1,10 @@
 package engine
 
-import "poker-engine/models"
+import (
+	"slices"
+
+	"poker-engine/models"
+)
 
 type PotCalculator struct {
 	mainPot  int
@@ -11,7 +15,18 @@ func NewPotCalculator() *PotCalculator {
 	return &PotCalculator{mainPot: 0, sidePots: make([]models.SidePot, 0)}
 }
 
+// CalculatePots splits the players' current bets into a main pot and side pots
 func (pc *PotCalculator) CalculatePots(players []*models.Player) models.Pot {
+	return calculatePots(players, func(p *models.Player) int { return p.Bet })
+}
+
+// CalculateHandPots splits everything the players have put in this hand,
+// antes and blinds included, into a main pot and side pots
+func (pc *PotCalculator) CalculateHandPots(players []*models.Player) models.Pot {
+	return calculatePots(players, func(p *models.Player) int { return p.TotalInvestedThisHand })
+}
+
+func calculatePots(players []*models.Player, contribution func(*models.Player) int) models.Pot {
 	// Create a list of players with their bets, sorted by bet amount
 	type PlayerBet struct {
 		Player *models.Player
@@ -20,8 +35,8 @@ func (pc *PotCalculator) CalculatePots(players []*models.Player) models.Pot {
 
 	playerBets := []PlayerBet{}
 	for _, p := range players {
-		if p != nil && p.Bet > 0 {
-			playerBets = append(playerBets, PlayerBet{Player: p, Bet: p.Bet})
+		if p != nil && contribution(p) > 0 {
+			playerBets = append(playerBets, PlayerBet{Player: p, Bet: contribution(p)})
 		}
 	}
 
@@ -69,7 +84,7 @@ func (pc *PotCalculator) CalculatePots(players []*models.Player) models.Pot {
 		// Determine eligible players for this pot (those who bet at least to this level)
 		eligible := []string{}
 		for _, p := range players {
-			if p != nil && p.Bet >= level && p.Status != models.StatusFolded {
+			if p != nil && contribution(p) >= level && p.Status != models.StatusFolded {
 				eligible = append(eligible, p.PlayerID)
 			}
 		}
@@ -100,7 +115,15 @@ func (pc *PotCalculator) CalculatePots(players []*models.Player) models.Pot {
 }
 
 func DistributeWinnings(pot models.Pot, players []*models.Player, communityCards []models.Card) []models.Winner {
+	winners, _ := distributePots(pot, players, communityCards)
+	return winners
+}
+
+// distributePots works out who wins the pot. Along with the winners it
+// returns who won each pot: the main pot first, then the side pots in order.
+func distributePots(pot models.Pot, players []*models.Player, communityCards []models.Card) ([]models.Winner, [][]string) {
 	winners := make([]models.Winner, 0)
+	potWinners := make([][]string, 1+len(pot.Side))
 
 	// Collect active players (not folded)
 	activePlayers := []*models.Player{}
@@ -111,7 +134,7 @@ func DistributeWinnings(pot models.Pot, players []*models.Player, communityCards
 	}
 
 	if len(activePlayers) == 0 {
-		return winners
+		return winners, potWinners
 	}
 
 	// If only one player left, they win everything
@@ -127,7 +150,10 @@ func DistributeWinnings(pot models.Pot, players []*models.Player, communityCards
 			HandRank:   "Winner by default",
 			HandCards:  activePlayers[0].Cards,
 		})
-		return winners
+		for i := range potWinners {
+			potWinners[i] = []string{activePlayers[0].PlayerID}
+		}
+		return winners, potWinners
 	}
 
 	// Evaluate all hands
@@ -136,10 +162,15 @@ func DistributeWinnings(pot models.Pot, players []*models.Player, communityCards
 		Eval   HandEvaluation
 	}
 
-	playerEvals := []PlayerEval{}
-	for _, p := range activePlayers {
-		eval := EvaluateHand(p.Cards, communityCards)
-		playerEvals = append(playerEvals, PlayerEval{Player: p, Eval: eval})
+	hands := make([][]models.Card, len(activePlayers))
+	for i, p := range activePlayers {
+		hands[i] = p.Cards
+	}
+	evals := EvaluateHands(hands, communityCards)
+
+	playerEvals := make([]PlayerEval, len(activePlayers))
+	for i, p := range activePlayers {
+		playerEvals[i] = PlayerEval{Player: p, Eval: evals[i]}
 	}
 
 	// Track total winnings per player
@@ -174,12 +205,13 @@ func DistributeWinnings(pot models.Pot, players []*models.Player, communityCards
 					remainder--
 				}
 				playerWinnings[pe.Player.PlayerID] += amount
+				potWinners[0] = append(potWinners[0], pe.Player.PlayerID)
 			}
 		}
 	}
 
 	// Distribute each side pot
-	for _, sidePot := range pot.Side {
+	for i, sidePot := range pot.Side {
 		if sidePot.Amount == 0 {
 			continue
 		}
@@ -226,6 +258,7 @@ func DistributeWinnings(pot models.Pot, players []*models.Player, communityCards
 					remainder--
 				}
 				playerWinnings[pe.Player.PlayerID] += amount
+				potWinners[i+1] = append(potWinners[i+1], pe.Player.PlayerID)
 			}
 		}
 	}
@@ -243,5 +276,28 @@ func DistributeWinnings(pot models.Pot, players []*models.Player, communityCards
 		}
 	}
 
-	return winners
+	return winners, potWinners
+}
+
+// knockouts names who knocked out the players a hand left without chips:
+// whoever won the highest pot the busted player had a share in, which holds
+// the last of their chips. A split pot knocks them out together.
+func knockouts(pot models.Pot, players []*models.Player, potWinners [][]string) []models.Knockout {
+	var result []models.Knockout
+	for _, p := range players {
+		if p == nil || p.Chips > 0 || p.TotalInvestedThisHand == 0 {
+			continue
+		}
+		eliminatedBy := potWinners[0]
+		for i := len(pot.Side) - 1; i >= 0; i-- {
+			if len(potWinners[i+1]) > 0 && slices.Contains(pot.Side[i].EligiblePlayers, p.PlayerID) {
+				eliminatedBy = potWinners[i+1]
+				break
+			}
+		}
+		if len(eliminatedBy) > 0 {
+			result = append(result, models.Knockout{PlayerID: p.PlayerID, EliminatedBy: eliminatedBy})
+		}
+	}
+	return result
 }
@@ -137,3 +137,34 @@ func TestPotCalculator_NoBets(t *testing.T) {
 		t.Errorf("Expected no side pots, got %d", len(pot.Side))
 	}
 }
+
+func TestEvaluateHands_MatchesSerialEvaluation(t *testing.T) {
+	players := newShowdownPlayers()
+	board := []models.Card{
+		{Rank: models.Ace, Suit: models.Hearts},
+		{Rank: models.Jack, Suit: models.Clubs},
+		{Rank: models.Seven, Suit: models.Hearts},
+		{Rank: models.Four, Suit: models.Clubs},
+		{Rank: models.Two, Suit: models.Hearts},
+	}
+
+	hands := make([][]models.Card, len(players))
+	for i, p := range players {
+		hands[i] = p.Cards
+	}
+
+	previous := maxEvalWorkers
+	maxEvalWorkers = 4
+	defer func() { maxEvalWorkers = previous }()
+
+	evals := EvaluateHands(hands, board)
+	if len(evals) != len(hands) {
+		t.Fatalf("Expected %d evaluations, got %d", len(hands), len(evals))
+	}
+	for i, cards := range hands {
+		expected := EvaluateHand(cards, board)
+		if evals[i].Rank != expected.Rank || evals[i].Value != expected.Value {
+			t.Errorf("Hand %d: expected %s/%d, got %s/%d", i, expected.Rank, expected.Value, evals[i].Rank, evals[i].Value)
+		}
+	}
+}
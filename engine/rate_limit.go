@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"poker-engine/models"
+)
+
+// RateLimits caps how fast actions reach a table. They are enforced by the
+// engine, so every transport gets the same protection.
+type RateLimits struct {
+	MinActionInterval   time.Duration // Per player, between two action attempts
+	MaxActionsPerSecond int           // Per table, across all players' actions in turn; 0 disables the cap
+}
+
+// DefaultRateLimits are generous for people and bots playing normally but stop
+// a client flooding a table
+var DefaultRateLimits = RateLimits{
+	MinActionInterval:   100 * time.Millisecond,
+	MaxActionsPerSecond: 10,
+}
+
+// actionLimiter tracks recent action attempts at one table
+type actionLimiter struct {
+	limits     RateLimits
+	lastAction map[string]time.Time // Player ID -> last allowed attempt
+	recent     []time.Time          // Actions in turn within the last second
+}
+
+func newActionLimiter(limits RateLimits) *actionLimiter {
+	return &actionLimiter{
+		limits:     limits,
+		lastAction: make(map[string]time.Time),
+	}
+}
+
+// allowAttempt checks an action attempt against the player's interval and
+// records it if allowed. Attempts out of turn count too.
+func (l *actionLimiter) allowAttempt(playerID string, now time.Time) error {
+	if last, ok := l.lastAction[playerID]; ok && l.limits.MinActionInterval > 0 {
+		if elapsed := now.Sub(last); elapsed < l.limits.MinActionInterval {
+			return fmt.Errorf("action rate limited: wait %v before acting again", l.limits.MinActionInterval-elapsed)
+		}
+	}
+	l.lastAction[playerID] = now
+
+	// Forget players who have gone quiet, so players who left don't pile up
+	if len(l.lastAction) > 2*models.MaxSeats {
+		for id, at := range l.lastAction {
+			if now.Sub(at) >= l.limits.MinActionInterval {
+				delete(l.lastAction, id)
+			}
+		}
+	}
+	return nil
+}
+
+// allowAction checks an action by the player to act against the table's cap
+// and records it if allowed. Only actions in turn count, so players acting out
+// of turn can't use up the table's budget.
+func (l *actionLimiter) allowAction(now time.Time) error {
+	cutoff := now.Add(-time.Second)
+	kept := l.recent[:0]
+	for _, at := range l.recent {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+	l.recent = kept
+	if l.limits.MaxActionsPerSecond > 0 && len(l.recent) >= l.limits.MaxActionsPerSecond {
+		return fmt.Errorf("table action rate limit of %d per second exceeded", l.limits.MaxActionsPerSecond)
+	}
+	l.recent = append(l.recent, now)
+	return nil
+}
+
+// SetRateLimits turns on engine rate limiting for the table. A zero RateLimits
+// turns it off again.
+func (t *Table) SetRateLimits(limits RateLimits) {
+	t.game.SetRateLimits(limits)
+}
+
+// SetRateLimits replaces the table's rate limits and forgets recent attempts
+func (g *Game) SetRateLimits(limits RateLimits) {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if limits == (RateLimits{}) {
+		g.limiter = nil
+		return
+	}
+	g.limiter = newActionLimiter(limits)
+}
+
+// checkPlayerRate applies the player's interval to an action attempt.
+// Replays are exempt. Caller must hold g.mu.
+func (g *Game) checkPlayerRate(playerID string) error {
+	if g.limiter == nil || g.replay {
+		return nil
+	}
+	return g.limiter.allowAttempt(playerID, time.Now())
+}
+
+// checkTableRate applies the table's cap to an action by the player to act.
+// Replays are exempt. Caller must hold g.mu.
+func (g *Game) checkTableRate() error {
+	if g.limiter == nil || g.replay {
+		return nil
+	}
+	return g.limiter.allowAction(time.Now())
+}
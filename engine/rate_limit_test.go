@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"poker-engine/models"
+)
+
+func TestActionLimiter_PlayerInterval(t *testing.T) {
+	limiter := newActionLimiter(RateLimits{MinActionInterval: 100 * time.Millisecond})
+	now := time.Now()
+
+	if err := limiter.allowAttempt("p1", now); err != nil {
+		t.Fatalf("First action should be allowed: %v", err)
+	}
+	if err := limiter.allowAttempt("p1", now.Add(50*time.Millisecond)); err == nil {
+		t.Error("Expected a second action within the interval to be limited")
+	}
+	if err := limiter.allowAttempt("p2", now.Add(50*time.Millisecond)); err != nil {
+		t.Errorf("Other players should not share the interval: %v", err)
+	}
+	if err := limiter.allowAttempt("p1", now.Add(100*time.Millisecond)); err != nil {
+		t.Errorf("Expected an action after the interval to be allowed: %v", err)
+	}
+}
+
+func TestActionLimiter_TableCap(t *testing.T) {
+	limiter := newActionLimiter(RateLimits{MaxActionsPerSecond: 3})
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.allowAction(now.Add(time.Duration(i) * time.Millisecond)); err != nil {
+			t.Fatalf("Action %d should be within the cap: %v", i+1, err)
+		}
+	}
+	if err := limiter.allowAction(now.Add(10 * time.Millisecond)); err == nil {
+		t.Error("Expected the fourth action within a second to be limited")
+	}
+	if err := limiter.allowAction(now.Add(1001 * time.Millisecond)); err != nil {
+		t.Errorf("Expected the cap to reset after a second: %v", err)
+	}
+}
+
+func TestGame_RateLimitsRejectFloods(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000, 1000)
+	game.SetRateLimits(RateLimits{MinActionInterval: time.Minute})
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	// The player after the one to act tries to jump the queue. The attempt is
+	// rejected but still counts against them.
+	current := table.Players[table.CurrentHand.CurrentPosition]
+	other := table.Players[(table.CurrentHand.CurrentPosition+1)%len(table.Players)]
+	if err := game.ProcessAction(other.PlayerID, models.ActionFold, 0); err == nil || strings.Contains(err.Error(), "rate limited") {
+		t.Fatalf("Expected the out of turn attempt to be rejected by turn validation, got %v", err)
+	}
+
+	if err := game.ProcessAction(current.PlayerID, models.ActionCall, 0); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if err := game.ProcessAction(other.PlayerID, models.ActionCall, 0); err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Errorf("Expected the player to be rate limited after their early attempt, got %v", err)
+	}
+
+	game.SetRateLimits(RateLimits{})
+	if err := game.ProcessAction(other.PlayerID, models.ActionCall, 0); err != nil {
+		t.Errorf("Expected actions to go through with limits off: %v", err)
+	}
+}
+
+func TestGame_OutOfTurnAttemptsDontUseTheTableCap(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000, 1000)
+	game.SetRateLimits(RateLimits{MaxActionsPerSecond: 2})
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	// The players waiting to act keep trying to fold
+	current := table.Players[table.CurrentHand.CurrentPosition]
+	for i := 0; i < 5; i++ {
+		for _, p := range table.Players {
+			if p == current {
+				continue
+			}
+			if err := game.ProcessAction(p.PlayerID, models.ActionFold, 0); err == nil || strings.Contains(err.Error(), "rate limit") {
+				t.Fatalf("Expected the out of turn fold to be rejected by turn validation, got %v", err)
+			}
+		}
+	}
+
+	if err := game.ProcessAction(current.PlayerID, models.ActionCall, 0); err != nil {
+		t.Errorf("Expected the player to act not to be limited by out of turn attempts, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"poker-engine/models"
+)
+
+// ErrPlayerToAct is returned when removing the player whose turn it is in a
+// paused hand, which couldn't move on without them until it is resumed
+var ErrPlayerToAct = errors.New("cannot remove the player to act while the hand is paused")
+
+// RemovePlayer takes a player off the table. Between hands they leave at once
+// and are returned as they were, stack included. During a hand they are
+// folded, and if it was their turn the hand moves on as it would after a
+// timeout, or ends if only one player is left in it; nil is returned and they
+// stay seated until the hand is over. An all-in player keeps their stake in
+// the pot.
+func (t *Table) RemovePlayer(playerID string) (*models.Player, error) {
+	return t.game.RemovePlayer(playerID)
+}
+
+// RemovePlayer removes or folds a player as Table.RemovePlayer describes
+func (g *Game) RemovePlayer(playerID string) (*models.Player, error) {
+	g.mu.Lock()
+	defer g.unlock()
+
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	inHand := g.table.CurrentHand != nil &&
+		(g.table.Status == models.StatusPlaying || g.table.Status == models.StatusPaused)
+	if !inHand {
+		for i, p := range g.table.Players {
+			if p == player {
+				g.table.Players[i] = nil
+			}
+		}
+		g.cancelSeatChange(playerID)
+		delete(g.frozen, playerID)
+		g.publishSnapshot()
+		return player.Clone(), nil
+	}
+
+	if player.Status != models.StatusActive {
+		return nil, nil
+	}
+	position := g.table.CurrentHand.CurrentPosition
+	wasTurn := position >= 0 && position < len(g.table.Players) && g.table.Players[position] == player
+	if wasTurn && g.table.Status == models.StatusPaused {
+		return nil, ErrPlayerToAct
+	}
+
+	player.Status = models.StatusFolded
+	player.LastAction = models.ActionFold
+	player.LastActionAmount = 0
+	player.HasActedThisRound = true
+	if countPlayers(g.table.Players, isNotFolded) == 1 {
+		// The last player left in the hand wins it
+		g.stopActionTimer()
+		g.completeHand()
+		return nil, nil
+	}
+	if wasTurn {
+		g.stopActionTimer()
+		if g.isBettingRoundComplete() {
+			g.advanceToNextRound()
+		} else {
+			g.moveToNextPlayer()
+		}
+	}
+	g.publishSnapshot()
+	return nil, nil
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestGame_RemovePlayerToActMovesTheHandOn(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000, 1000)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	removed := table.Players[table.CurrentHand.CurrentPosition]
+	player, err := game.RemovePlayer(removed.PlayerID)
+	if err != nil || player != nil {
+		t.Fatalf("Expected the player to be folded and left seated, got %v, %v", player, err)
+	}
+	if removed.Status != models.StatusFolded {
+		t.Errorf("Expected the removed player to be folded, got %s", removed.Status)
+	}
+	if table.Players[table.CurrentHand.CurrentPosition] == removed {
+		t.Error("Expected the turn to move on from the removed player")
+	}
+
+	// Once the hand is over they leave with their stack
+	for i := 0; table.Status == models.StatusPlaying && i < 20; i++ {
+		p := table.Players[table.CurrentHand.CurrentPosition]
+		action := models.ActionCheck
+		if p.Bet < table.CurrentHand.CurrentBet {
+			action = models.ActionCall
+		}
+		if err := game.ProcessAction(p.PlayerID, action, 0); err != nil {
+			t.Fatalf("Action by %s failed: %v", p.PlayerID, err)
+		}
+	}
+	chips := removed.Chips
+	player, err = game.RemovePlayer(removed.PlayerID)
+	if err != nil || player == nil || player.Chips != chips {
+		t.Fatalf("Expected the player back with %d chips, got %+v, %v", chips, player, err)
+	}
+	if findPlayerByID(table.Players, removed.PlayerID) != nil {
+		t.Error("Expected the player's seat to be empty")
+	}
+}
+
+func TestGame_RemovePlayerHeadsUpAwardsThePot(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	toAct := table.Players[table.CurrentHand.CurrentPosition]
+	var removed *models.Player
+	for _, p := range table.Players {
+		if p != nil && p != toAct {
+			removed = p
+		}
+	}
+	if _, err := game.RemovePlayer(removed.PlayerID); err != nil {
+		t.Fatalf("Failed to remove the player waiting to act: %v", err)
+	}
+
+	if table.Status != models.StatusHandComplete {
+		t.Fatalf("Expected the hand to end with one player left, got %s", table.Status)
+	}
+	if len(table.Winners) != 1 || table.Winners[0].PlayerID != toAct.PlayerID {
+		t.Fatalf("Expected %s to win the pot, got %+v", toAct.PlayerID, table.Winners)
+	}
+	if toAct.Chips+removed.Chips != 2000 {
+		t.Errorf("Expected every chip to stay at the table, got %d and %d", toAct.Chips, removed.Chips)
+	}
+	if err := game.ProcessAction(toAct.PlayerID, models.ActionFold, 0); err == nil {
+		t.Error("Expected no action once the hand is over")
+	}
+}
+
+func TestGame_RemovePlayerFromPausedHand(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000, 1000)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if err := game.Pause(); err != nil {
+		t.Fatalf("Failed to pause: %v", err)
+	}
+
+	toAct := table.Players[table.CurrentHand.CurrentPosition]
+	if _, err := game.RemovePlayer(toAct.PlayerID); !errors.Is(err, ErrPlayerToAct) {
+		t.Errorf("Expected ErrPlayerToAct, got %v", err)
+	}
+
+	var other *models.Player
+	for _, p := range table.Players {
+		if p != nil && p != toAct {
+			other = p
+			break
+		}
+	}
+	if _, err := game.RemovePlayer(other.PlayerID); err != nil {
+		t.Fatalf("Failed to remove a player waiting to act: %v", err)
+	}
+	if other.Status != models.StatusFolded || table.Status != models.StatusPaused {
+		t.Errorf("Expected the player folded and the table still paused, got %s and %s", other.Status, table.Status)
+	}
+}
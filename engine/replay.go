@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"fmt"
+
+	"poker-engine/models"
+)
+
+// RecordedAction is one player decision of a recorded hand
+type RecordedAction struct {
+	PlayerID string              `json:"playerId"`
+	Action   models.PlayerAction `json:"action,omitempty"`
+	Amount   int                 `json:"amount,omitempty"`
+	Timeout  bool                `json:"timeout,omitempty"` // The player ran out of time and the engine acted for them
+}
+
+// HandRecord holds everything needed to deal and play a hand again exactly as
+// it happened: the seats before the deal, the deck seed and every decision.
+// The seed reveals every card, so a record must not leave the server until the
+// hand is over.
+type HandRecord struct {
+	TableID        string             `json:"tableId"`
+	GameType       models.GameType    `json:"gameType"`
+	Config         models.TableConfig `json:"config"`
+	HandNumber     int                `json:"handNumber"`
+	DealerPosition int                `json:"dealerPosition"`
+	Blinds         *BlindSeats        `json:"blinds,omitempty"` // Button and blinds as dealt; without them the blinds follow the button
+	Players        []*models.Player   `json:"players"`          // Seats before the deal, nil for empty seats
+	DeckSeed       int64              `json:"deckSeed"`
+	Scenario       *Scenario          `json:"scenario,omitempty"` // Cards fixed for a practice hand
+	Straddle       string             `json:"straddle,omitempty"` // Player who straddled
+	Actions        []RecordedAction   `json:"actions"`
+}
+
+// clone returns a deep copy of the record
+func (r *HandRecord) clone() *HandRecord {
+	clone := *r
+	clone.Players = clonePlayers(r.Players)
+	if r.Scenario != nil {
+		clone.Scenario = r.Scenario.clone()
+	}
+	clone.Actions = append([]RecordedAction(nil), r.Actions...)
+	return &clone
+}
+
+// recordSeats copies the seats before a deal for a hand record. It runs on
+// every deal, so the players are copied into one array rather than cloned one
+// by one, and the cards left over from the last hand are not copied at all:
+// they are never dealt again and must not be revealed by the record.
+func recordSeats(players []*models.Player) []*models.Player {
+	seats := make([]models.Player, len(players))
+	record := make([]*models.Player, len(players))
+	for i, p := range players {
+		if p == nil {
+			continue
+		}
+		seats[i] = *p
+		seats[i].Cards = p.Cards[:0:0]
+		seats[i].ShownCards = nil
+		record[i] = &seats[i]
+	}
+	return record
+}
+
+func clonePlayers(players []*models.Player) []*models.Player {
+	clones := make([]*models.Player, len(players))
+	for i, p := range players {
+		clones[i] = p.Clone()
+	}
+	return clones
+}
+
+// HandRecord returns the record of the current hand, or of the last hand once
+// it is over. It is nil before the first hand.
+func (t *Table) HandRecord() *HandRecord {
+	return t.game.HandRecord()
+}
+
+// HandRecord returns a copy of the record of the current or last hand
+func (g *Game) HandRecord() *HandRecord {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if g.handRecord == nil {
+		return nil
+	}
+	return g.handRecord.clone()
+}
+
+// recordAction adds a decision to the hand record. Caller must hold g.mu.
+func (g *Game) recordAction(action RecordedAction) {
+	if g.handRecord != nil {
+		g.handRecord.Actions = append(g.handRecord.Actions, action)
+	}
+}
+
+// ReplayHand deals a recorded hand again from its seed and replays every
+// decision, returning the table as it stood when the hand ended. The board,
+// pots and winners match the original hand exactly.
+func ReplayHand(record *HandRecord) (*models.Table, error) {
+	if record == nil || len(record.Players) == 0 {
+		return nil, fmt.Errorf("hand record has no seats")
+	}
+
+	config := record.Config
+	config.ActionTimeout = 0 // Decisions come from the record, not the clock
+
+	table := &models.Table{
+		TableID:  record.TableID,
+		GameType: record.GameType,
+		Status:   models.StatusWaiting,
+		Config:   config,
+		Players:  clonePlayers(record.Players),
+		CurrentHand: &models.CurrentHand{
+			HandNumber:     record.HandNumber - 1,
+			DealerPosition: -1,
+		},
+	}
+
+	game := NewGame(table, nil, nil)
+	game.replay = true
+	seed := record.DeckSeed
+	game.nextDeckSeed = &seed
+	if record.Scenario != nil {
+		game.nextScenario = record.Scenario.clone()
+	}
+	game.straddler = record.Straddle
+	if record.Blinds != nil {
+		blinds := *record.Blinds
+		game.forcedBlinds = &blinds
+	} else {
+		button := record.DealerPosition
+		game.forcedButton = &button
+	}
+
+	if err := game.StartNewHand(); err != nil {
+		return nil, fmt.Errorf("failed to deal recorded hand: %w", err)
+	}
+
+	for i, action := range record.Actions {
+		var err error
+		if action.Timeout {
+			err = game.HandleTimeout(action.PlayerID, table.CurrentHand.DeadlineToken)
+		} else {
+			err = game.ProcessAction(action.PlayerID, action.Action, action.Amount)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("recorded action %d (%s %s) was rejected: %w", i+1, action.PlayerID, action.Action, err)
+		}
+	}
+
+	if table.Status != models.StatusHandComplete {
+		return nil, fmt.Errorf("hand did not finish after %d recorded actions", len(record.Actions))
+	}
+	return table, nil
+}
@@ -0,0 +1,184 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestNewDeckWithSeed_IsDeterministic(t *testing.T) {
+	first, _ := models.NewDeckWithSeed(42).DealMultiple(52)
+	second, _ := models.NewDeckWithSeed(42).DealMultiple(52)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected decks with the same seed to deal the same cards")
+	}
+
+	other, _ := models.NewDeckWithSeed(43).DealMultiple(52)
+	if reflect.DeepEqual(first, other) {
+		t.Error("Expected decks with different seeds to deal different cards")
+	}
+	if seed := models.NewDeckWithSeed(42).Seed(); seed != 42 {
+		t.Errorf("Expected seed 42, got %d", seed)
+	}
+}
+
+// playToShowdown plays the current hand out with calls and checks, letting the
+// first player to act on the flop time out
+func playToShowdown(t *testing.T, game *Game, table *models.Table) {
+	t.Helper()
+	timedOut := false
+	for i := 0; table.Status == models.StatusPlaying && i < 20; i++ {
+		hand := table.CurrentHand
+		player := table.Players[hand.CurrentPosition]
+
+		var err error
+		switch {
+		case hand.BettingRound == models.RoundFlop && !timedOut:
+			timedOut = true
+			err = game.HandleTimeout(player.PlayerID, hand.DeadlineToken)
+		case player.Bet < hand.CurrentBet:
+			err = game.ProcessAction(player.PlayerID, models.ActionCall, 0)
+		default:
+			err = game.ProcessAction(player.PlayerID, models.ActionCheck, 0)
+		}
+		if err != nil {
+			t.Fatalf("Action by %s failed: %v", player.PlayerID, err)
+		}
+	}
+	if table.Status != models.StatusHandComplete {
+		t.Fatalf("Expected the hand to finish, got status %s", table.Status)
+	}
+}
+
+func TestReplayHand_ReproducesHand(t *testing.T) {
+	game, table := newAnteGame(5, 1000, 1500, 800)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	playToShowdown(t, game, table)
+
+	record := game.HandRecord()
+	if record == nil {
+		t.Fatal("Expected a record of the hand")
+	}
+	if record.DeckSeed != table.CurrentHand.DeckSeed || record.HandNumber != 1 {
+		t.Errorf("Expected the record to hold the seed of hand 1, got seed %d hand %d", record.DeckSeed, record.HandNumber)
+	}
+	if len(record.Actions) == 0 || !record.Actions[3].Timeout {
+		t.Errorf("Expected the timeout on the flop to be recorded, got %+v", record.Actions)
+	}
+	if record.Players[0].Chips != 1000 {
+		t.Errorf("Expected the record to hold stacks from before the deal, got %d", record.Players[0].Chips)
+	}
+
+	replayed, err := ReplayHand(record)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(replayed.CurrentHand.CommunityCards, table.CurrentHand.CommunityCards) {
+		t.Errorf("Expected board %v, got %v", table.CurrentHand.CommunityCards, replayed.CurrentHand.CommunityCards)
+	}
+	if !reflect.DeepEqual(replayed.Winners, table.Winners) {
+		t.Errorf("Expected winners %+v, got %+v", table.Winners, replayed.Winners)
+	}
+	for i, p := range table.Players {
+		if replayed.Players[i].Chips != p.Chips || !reflect.DeepEqual(replayed.Players[i].Cards, p.Cards) {
+			t.Errorf("Expected seat %d to end with %d chips and %v, got %d and %v",
+				i, p.Chips, p.Cards, replayed.Players[i].Chips, replayed.Players[i].Cards)
+		}
+	}
+}
+
+func TestHandRecord_LeavesOutLastHandsCards(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000, 1000)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	playToShowdown(t, game, table)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start second hand: %v", err)
+	}
+	playToShowdown(t, game, table)
+
+	record := game.HandRecord()
+	for i, p := range record.Players {
+		if p != nil && (len(p.Cards) > 0 || len(p.ShownCards) > 0) {
+			t.Errorf("Expected seat %d to be recorded without cards, got %v and %v", i, p.Cards, p.ShownCards)
+		}
+	}
+	replayed, err := ReplayHand(record)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !reflect.DeepEqual(replayed.Winners, table.Winners) {
+		t.Errorf("Expected winners %+v, got %+v", table.Winners, replayed.Winners)
+	}
+}
+
+func TestReplayHand_RejectsDivergentActions(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000, 1000)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	playToShowdown(t, game, table)
+
+	record := game.HandRecord()
+	record.Actions[0].PlayerID = record.Actions[1].PlayerID
+	if _, err := ReplayHand(record); err == nil {
+		t.Error("Expected an out of turn action to fail the replay")
+	}
+
+	if _, err := ReplayHand(&HandRecord{}); err == nil {
+		t.Error("Expected an empty record to fail the replay")
+	}
+}
+
+func TestHandRecord_WithPlayerIDs(t *testing.T) {
+	game, table := newAnteGame(0, 1000, 1000, 1000)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	playToShowdown(t, game, table)
+	record := game.HandRecord()
+
+	ids := make(map[string]string)
+	for _, p := range record.Players {
+		if p != nil {
+			ids[p.PlayerID] = "platform-" + p.PlayerID
+		}
+	}
+	moved, err := record.WithPlayerIDs(ids)
+	if err != nil {
+		t.Fatalf("WithPlayerIDs failed: %v", err)
+	}
+	for i, action := range moved.Actions {
+		if action.PlayerID != ids[record.Actions[i].PlayerID] {
+			t.Errorf("Expected action %d to be by %s, got %s", i+1, ids[record.Actions[i].PlayerID], action.PlayerID)
+		}
+	}
+	if record.Players[0].PlayerID == moved.Players[0].PlayerID {
+		t.Error("Expected the original record to be left alone")
+	}
+
+	replayed, err := ReplayHand(moved)
+	if err != nil {
+		t.Fatalf("Replay of the renamed hand failed: %v", err)
+	}
+	for i, p := range table.Players {
+		if replayed.Players[i].PlayerID != ids[p.PlayerID] || replayed.Players[i].Chips != p.Chips {
+			t.Errorf("Expected seat %d to be %s with %d chips, got %s with %d",
+				i, ids[p.PlayerID], p.Chips, replayed.Players[i].PlayerID, replayed.Players[i].Chips)
+		}
+	}
+
+	delete(ids, record.Players[1].PlayerID)
+	if _, err := record.WithPlayerIDs(ids); err == nil {
+		t.Error("Expected a seated player without a new ID to be refused")
+	}
+	ids[record.Players[1].PlayerID] = ids[record.Players[0].PlayerID]
+	if _, err := record.WithPlayerIDs(ids); err == nil {
+		t.Error("Expected two players mapped to one ID to be refused")
+	}
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"poker-engine/models"
+)
+
+// Scenario fixes the button and cards of the next hand so a practice table can
+// deal an exact spot. Seats without hole cards and board cards left out are
+// dealt at random from the rest of the deck.
+type Scenario struct {
+	Button    int                   `json:"button"`
+	HoleCards map[int][]models.Card `json:"holeCards,omitempty"` // By seat
+	Board     []models.Card         `json:"board,omitempty"`     // Up to five cards in the order they come
+}
+
+// cards lists every card the scenario fixes
+func (s *Scenario) cards() []models.Card {
+	var cards []models.Card
+	for _, hole := range s.HoleCards {
+		cards = append(cards, hole...)
+	}
+	return append(cards, s.Board...)
+}
+
+func (s *Scenario) clone() *Scenario {
+	clone := &Scenario{Button: s.Button, Board: append([]models.Card(nil), s.Board...)}
+	if s.HoleCards != nil {
+		clone.HoleCards = make(map[int][]models.Card, len(s.HoleCards))
+		for seat, hole := range s.HoleCards {
+			clone.HoleCards[seat] = append([]models.Card(nil), hole...)
+		}
+	}
+	return clone
+}
+
+// SetScenario makes the next hand deal the scenario. Only allowed between hands.
+func (t *Table) SetScenario(scenario Scenario) error {
+	return t.game.SetScenario(scenario)
+}
+
+// SetScenario validates the scenario and stores it with its button
+func (g *Game) SetScenario(scenario Scenario) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if g.table.Status == models.StatusPlaying {
+		return fmt.Errorf("cannot set up a scenario while a hand is in progress")
+	}
+	if scenario.Button < 0 || scenario.Button >= len(g.table.Players) || !isActiveWithChips(g.table.Players[scenario.Button]) {
+		return fmt.Errorf("button seat %d has no active player with chips", scenario.Button)
+	}
+	for seat, hole := range scenario.HoleCards {
+		if seat < 0 || seat >= len(g.table.Players) || !isActiveWithChips(g.table.Players[seat]) {
+			return fmt.Errorf("seat %d has no active player with chips to deal to", seat)
+		}
+		if len(hole) != 2 {
+			return fmt.Errorf("seat %d needs 2 hole cards, got %d", seat, len(hole))
+		}
+	}
+	if len(scenario.Board) > 5 {
+		return fmt.Errorf("a board has at most 5 cards, got %d", len(scenario.Board))
+	}
+
+	seen := make(map[models.Card]bool)
+	for _, card := range scenario.cards() {
+		if !strings.Contains(equityRanks, string(card.Rank)) || !strings.Contains(equitySuits, string(card.Suit)) ||
+			len(card.Rank) != 1 || len(card.Suit) != 1 {
+			return fmt.Errorf("invalid card %s", card)
+		}
+		if seen[card] {
+			return fmt.Errorf("card %s is used twice", card)
+		}
+		seen[card] = true
+	}
+
+	button := scenario.Button
+	g.forcedButton = &button
+	g.nextScenario = scenario.clone()
+	g.publishSnapshot()
+	return nil
+}
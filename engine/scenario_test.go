@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"testing"
+
+	"poker-engine/models"
+)
+
+func mustCards(t *testing.T, s string) []models.Card {
+	t.Helper()
+	cards, err := ParseCards(s)
+	if err != nil {
+		t.Fatalf("ParseCards(%q) failed: %v", s, err)
+	}
+	return cards
+}
+
+func TestTable_ScenarioDealsExactSpot(t *testing.T) {
+	table := newBalanceTestTable("spot", 3)
+	scenario := Scenario{
+		Button: 2,
+		HoleCards: map[int][]models.Card{
+			0: mustCards(t, "AhKh"),
+			1: mustCards(t, "QsQd"),
+		},
+		Board: mustCards(t, "2c7d9hTsJs"),
+	}
+	if err := table.SetScenario(scenario); err != nil {
+		t.Fatalf("SetScenario failed: %v", err)
+	}
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	state := table.GetState()
+	if state.CurrentHand.DealerPosition != 2 {
+		t.Errorf("Expected the button on seat 2, got %d", state.CurrentHand.DealerPosition)
+	}
+	if got := cardsString(state.Players[0].Cards); got != "AhKh" {
+		t.Errorf("Expected seat 0 to hold AhKh, got %s", got)
+	}
+	if got := cardsString(state.Players[1].Cards); got != "QsQd" {
+		t.Errorf("Expected seat 1 to hold QsQd, got %s", got)
+	}
+	for _, card := range state.Players[2].Cards {
+		for _, fixed := range scenario.cards() {
+			if card == fixed {
+				t.Errorf("Seat 2 was dealt %s, which the scenario fixed elsewhere", card)
+			}
+		}
+	}
+
+	playToShowdown(t, table.GetGame(), state)
+	if got := cardsString(state.CurrentHand.CommunityCards); got != "2c7d9hTsJs" {
+		t.Errorf("Expected board 2c7d9hTsJs, got %s", got)
+	}
+
+	// The record replays the same spot
+	replayed, err := ReplayHand(table.HandRecord())
+	if err != nil {
+		t.Fatalf("ReplayHand failed: %v", err)
+	}
+	if got := cardsString(replayed.CurrentHand.CommunityCards); got != "2c7d9hTsJs" {
+		t.Errorf("Expected the replay to run out 2c7d9hTsJs, got %s", got)
+	}
+	if got := cardsString(replayed.Players[2].Cards); got != cardsString(state.Players[2].Cards) {
+		t.Errorf("Expected the replay to deal seat 2 %s, got %s", cardsString(state.Players[2].Cards), got)
+	}
+
+	// The next hand is random again
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if cardsString(state.Players[0].Cards) == "AhKh" && cardsString(state.Players[1].Cards) == "QsQd" {
+		t.Error("Expected the scenario to apply to one hand only")
+	}
+}
+
+func TestTable_ScenarioPartialBoard(t *testing.T) {
+	table := newBalanceTestTable("flop", 2)
+	flop := mustCards(t, "AsAdAc")
+	if err := table.SetScenario(Scenario{Button: 0, Board: flop}); err != nil {
+		t.Fatalf("SetScenario failed: %v", err)
+	}
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	state := table.GetState()
+	playToShowdown(t, table.GetGame(), state)
+
+	board := state.CurrentHand.CommunityCards
+	if len(board) != 5 || cardsString(board[:3]) != "AsAdAc" {
+		t.Fatalf("Expected the flop AsAdAc and a random turn and river, got %s", cardsString(board))
+	}
+	for _, p := range state.Players {
+		if p == nil {
+			continue
+		}
+		for _, card := range append(append([]models.Card(nil), p.Cards...), board[3:]...) {
+			if card.Rank == models.Ace && card.Suit != models.Hearts {
+				t.Errorf("Expected the fixed flop cards to be dealt once, got %s again", card)
+			}
+		}
+	}
+}
+
+func TestTable_ScenarioValidation(t *testing.T) {
+	table := newBalanceTestTable("bad", 3)
+	cases := []struct {
+		name     string
+		scenario Scenario
+	}{
+		{"empty button seat", Scenario{Button: 4}},
+		{"empty hole card seat", Scenario{Button: 0, HoleCards: map[int][]models.Card{5: mustCards(t, "AhKh")}}},
+		{"three hole cards", Scenario{Button: 0, HoleCards: map[int][]models.Card{1: mustCards(t, "AhKhQh")}}},
+		{"six card board", Scenario{Button: 0, Board: mustCards(t, "2c3c4c5c6c7c")}},
+		{"repeated card", Scenario{Button: 0, HoleCards: map[int][]models.Card{1: mustCards(t, "AhKh")}, Board: mustCards(t, "Ah7d2c")}},
+		{"invalid card", Scenario{Button: 0, Board: []models.Card{{Rank: "1", Suit: "h"}}}},
+	}
+	for _, c := range cases {
+		if err := table.SetScenario(c.scenario); err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if err := table.SetScenario(Scenario{Button: 0}); err == nil {
+		t.Error("Expected setting a scenario during a hand to fail")
+	}
+}
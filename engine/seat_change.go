@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"fmt"
+	"poker-engine/models"
+)
+
+// seatChangeRequest is a pending move of a seated player to another seat
+type seatChangeRequest struct {
+	playerID   string
+	targetSeat int
+}
+
+// RequestSeatChange asks to move a seated cash game player to another open seat.
+// Between hands the move is applied immediately; during a hand it is queued and
+// applied before the next hand starts. It reports whether the move was applied.
+func (t *Table) RequestSeatChange(playerID string, targetSeat int) (bool, error) {
+	return t.game.RequestSeatChange(playerID, targetSeat)
+}
+
+// PendingSeatChange returns the seat a player is queued to move to, if any
+func (t *Table) PendingSeatChange(playerID string) (int, bool) {
+	return t.game.PendingSeatChange(playerID)
+}
+
+// RequestSeatChange validates and applies or queues a seat change request.
+// A player has at most one pending request; a newer request replaces the older one.
+func (g *Game) RequestSeatChange(playerID string, targetSeat int) (bool, error) {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if g.table.GameType == models.GameTypeTournament {
+		return false, fmt.Errorf("seat changes are not allowed in tournaments")
+	}
+	if targetSeat < 0 || targetSeat >= len(g.table.Players) {
+		return false, fmt.Errorf("invalid seat number")
+	}
+
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return false, fmt.Errorf("player not found")
+	}
+	if player.SeatNumber == targetSeat {
+		return false, fmt.Errorf("player is already in seat %d", targetSeat)
+	}
+	if g.table.Players[targetSeat] != nil {
+		return false, fmt.Errorf("seat %d is occupied", targetSeat)
+	}
+
+	g.cancelSeatChange(playerID)
+
+	if g.table.Status != models.StatusPlaying {
+		g.moveSeat(player, targetSeat)
+		return true, nil
+	}
+
+	g.seatChanges = append(g.seatChanges, seatChangeRequest{playerID: playerID, targetSeat: targetSeat})
+	return false, nil
+}
+
+// PendingSeatChange returns the queued target seat for a player
+func (g *Game) PendingSeatChange(playerID string) (int, bool) {
+	g.mu.Lock()
+	defer g.unlock()
+
+	for _, req := range g.seatChanges {
+		if req.playerID == playerID {
+			return req.targetSeat, true
+		}
+	}
+	return 0, false
+}
+
+// cancelSeatChange drops any pending request for a player. Caller must hold g.mu.
+func (g *Game) cancelSeatChange(playerID string) {
+	pending := g.seatChanges[:0]
+	for _, req := range g.seatChanges {
+		if req.playerID != playerID {
+			pending = append(pending, req)
+		}
+	}
+	g.seatChanges = pending
+}
+
+// applySeatChanges moves queued players whose target seat is now free, in the
+// order requests were made. Requests for players who have left are dropped;
+// requests for seats that are still occupied stay queued. Caller must hold g.mu.
+func (g *Game) applySeatChanges() {
+	if len(g.seatChanges) == 0 {
+		return
+	}
+
+	pending := make([]seatChangeRequest, 0, len(g.seatChanges))
+	for _, req := range g.seatChanges {
+		player := findPlayerByID(g.table.Players, req.playerID)
+		if player == nil {
+			continue
+		}
+		if g.table.Players[req.targetSeat] != nil {
+			pending = append(pending, req)
+			continue
+		}
+		g.moveSeat(player, req.targetSeat)
+	}
+	g.seatChanges = pending
+}
+
+// moveSeat relocates a player and fires a seatChanged event. The button stays
+// with the seat, not the player, so a moved player cannot dodge the blinds:
+// once a hand has been played they must post a big blind to play from the new seat.
+// Caller must hold g.mu.
+func (g *Game) moveSeat(player *models.Player, targetSeat int) {
+	fromSeat := player.SeatNumber
+	g.table.Players[fromSeat] = nil
+	g.table.Players[targetSeat] = player
+	player.SeatNumber = targetSeat
+
+	if g.table.CurrentHand != nil && g.table.CurrentHand.HandNumber > 0 {
+		player.MustPostBigBlind = true
+	}
+
+	g.publishSnapshot()
+	// CRITICAL DEADLOCK FIX: Fire event asynchronously
+	if g.onEvent != nil {
+		event := models.Event{
+			Event:   "seatChanged",
+			TableID: g.table.TableID,
+			Data: map[string]interface{}{
+				"playerId":   player.PlayerID,
+				"playerName": player.PlayerName,
+				"fromSeat":   fromSeat,
+				"toSeat":     targetSeat,
+			},
+		}
+		g.emit(event)
+	}
+}
+
+// postSeatChangeBlinds makes players who changed seats post a live big blind
+// unless they are already in the blinds this hand. Heads-up play posts both
+// blinds every hand, so the obligation is simply cleared there.
+func (g *Game) postSeatChangeBlinds(sbPos, bbPos, activePlayers int) {
+	for i, p := range g.table.Players {
+		if p == nil || !p.MustPostBigBlind || p.Status != models.StatusActive {
+			continue
+		}
+		if i != sbPos && i != bbPos && activePlayers > 2 {
+			g.postBlind(p, g.table.Config.BigBlind, false)
+		}
+		p.MustPostBigBlind = false
+	}
+}
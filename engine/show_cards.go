@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"poker-engine/models"
+)
+
+var (
+	// ErrShowCardsClosed is returned when cards are shown with no chance to show them open
+	ErrShowCardsClosed = errors.New("no cards can be shown now")
+	// ErrInvalidShowCards is returned for a choice that isn't one or both of the player's cards
+	ErrInvalidShowCards = errors.New("show one or both of your cards")
+)
+
+// ShowCards shows the table some of the hole cards of the player who won a
+// pot nobody contested, by their index in the hand. No cards declines to
+// show any. Either way the chance to show closes, so the next hand can start.
+func (t *Table) ShowCards(playerID string, cards []int) error {
+	return t.game.ShowCards(playerID, cards)
+}
+
+// ShowCards shows the winner's chosen cards and closes their chance to show
+func (g *Game) ShowCards(playerID string, cards []int) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	hand := g.table.CurrentHand
+	if hand == nil || hand.ShowCardsDeadline == nil || hand.ShowCardsPlayerID != playerID {
+		return ErrShowCardsClosed
+	}
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return ErrShowCardsClosed
+	}
+	if len(cards) > len(player.Cards) {
+		return ErrInvalidShowCards
+	}
+	shown := make([]models.Card, 0, len(cards))
+	picked := make(map[int]bool, len(cards))
+	for _, i := range cards {
+		if i < 0 || i >= len(player.Cards) || picked[i] {
+			return ErrInvalidShowCards
+		}
+		picked[i] = true
+		shown = append(shown, player.Cards[i])
+	}
+
+	if len(shown) > 0 {
+		player.ShownCards = shown
+		g.addCardsShownHistory(player)
+	}
+	g.closeShowCards()
+	return nil
+}
+
+// IsShowingCards reports whether the winner of the last hand may still show
+// their cards, so the next hand should wait
+func (t *Table) IsShowingCards() bool {
+	t.game.mu.Lock()
+	defer t.game.unlock()
+	return t.game.table.CurrentHand != nil && t.game.table.CurrentHand.ShowCardsDeadline != nil
+}
+
+// openShowCards gives the winner of a pot everyone else folded to the
+// table's show cards window to show their cards, when the table has one. The
+// window closes by itself once it runs out. Caller must hold g.mu.
+func (g *Game) openShowCards() {
+	if g.table.Config.ShowCardsWindow <= 0 {
+		return
+	}
+	var winner *models.Player
+	for _, p := range g.table.Players {
+		if p == nil || len(p.Cards) == 0 || !isActive(p) {
+			continue
+		}
+		if winner != nil {
+			return
+		}
+		winner = p
+	}
+	if winner == nil {
+		return
+	}
+
+	timeout := time.Duration(g.table.Config.ShowCardsWindow) * time.Second
+	deadline := time.Now().Add(timeout)
+	g.table.CurrentHand.ShowCardsPlayerID = winner.PlayerID
+	g.table.CurrentHand.ShowCardsDeadline = &deadline
+
+	hand := g.table.CurrentHand
+	g.showCardsTimer = time.AfterFunc(timeout, func() {
+		g.mu.Lock()
+		defer g.unlock()
+		// A show or the next hand may have closed this window already
+		if g.table.CurrentHand == hand && hand.ShowCardsDeadline != nil {
+			g.closeShowCards()
+		}
+	})
+
+	g.emit(models.Event{
+		Event:   "showCardsPrompt",
+		TableID: g.table.TableID,
+		Data: map[string]interface{}{
+			"playerId": winner.PlayerID,
+			"deadline": deadline,
+		},
+	})
+}
+
+// closeShowCards ends the winner's chance to show and announces what they
+// showed. Caller must hold g.mu.
+func (g *Game) closeShowCards() {
+	hand := g.table.CurrentHand
+	if g.showCardsTimer != nil {
+		g.showCardsTimer.Stop()
+		g.showCardsTimer = nil
+	}
+	playerID := hand.ShowCardsPlayerID
+	hand.ShowCardsPlayerID = ""
+	hand.ShowCardsDeadline = nil
+
+	var shown []models.Card
+	if player := findPlayerByID(g.table.Players, playerID); player != nil {
+		shown = player.ShownCards
+	}
+	g.publishSnapshot()
+	g.emit(models.Event{
+		Event:   "showCardsClosed",
+		TableID: g.table.TableID,
+		Data: map[string]interface{}{
+			"playerId": playerID,
+			"cards":    shown,
+		},
+	})
+}
+
+// dropShowCards ends a chance to show that is still open when the next hand
+// starts, without announcing it. Caller must hold g.mu.
+func (g *Game) dropShowCards() {
+	if g.showCardsTimer != nil {
+		g.showCardsTimer.Stop()
+		g.showCardsTimer = nil
+	}
+	if hand := g.table.CurrentHand; hand != nil {
+		hand.ShowCardsPlayerID = ""
+		hand.ShowCardsDeadline = nil
+	}
+	for _, p := range g.table.Players {
+		if p != nil {
+			p.ShownCards = nil
+		}
+	}
+}
+
+// addCardsShownHistory adds the cards a winner showed to the history
+func (g *Game) addCardsShownHistory(player *models.Player) {
+	cards := make([]interface{}, len(player.ShownCards))
+	for i, card := range player.ShownCards {
+		cards[i] = map[string]interface{}{
+			"rank": card.Rank,
+			"suit": card.Suit,
+		}
+	}
+	g.addHistoryEntry(models.HistoryEntry{
+		ID:         fmt.Sprintf("cards_shown-%d", time.Now().UnixNano()),
+		EventType:  models.HistoryCardsShown,
+		PlayerID:   player.PlayerID,
+		PlayerName: player.PlayerName,
+		Timestamp:  time.Now(),
+		Metadata: map[string]interface{}{
+			"cards": cards,
+		},
+	})
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"poker-engine/models"
+)
+
+// foldToWinner starts a heads-up hand and has the first player to act fold,
+// returning the winner and the events fired
+func foldToWinner(t *testing.T, window int) (*Table, string, *[]models.Event) {
+	var events []models.Event
+	config := models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 2, ShowCardsWindow: window}
+	table := NewTable("show-cards", models.GameTypeCash, config, nil, func(e models.Event) { events = append(events, e) })
+	table.SetSynchronousEvents(true)
+	table.AddPlayer("p0", "Player 0", 0, 1000)
+	table.AddPlayer("p1", "Player 1", 1, 1000)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	state := table.GetState()
+	folder := state.Players[state.CurrentHand.CurrentPosition].PlayerID
+	winner := "p0"
+	if folder == "p0" {
+		winner = "p1"
+	}
+	if err := table.ProcessAction(folder, models.ActionFold, 0); err != nil {
+		t.Fatalf("Fold failed: %v", err)
+	}
+	return table, winner, &events
+}
+
+func hasEvent(events []models.Event, name string) bool {
+	for _, e := range events {
+		if e.Event == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestShowCards_UncontestedWinner(t *testing.T) {
+	table, winner, events := foldToWinner(t, 30)
+	state := table.GetState()
+	if !table.IsShowingCards() || state.CurrentHand.ShowCardsPlayerID != winner {
+		t.Fatalf("Expected %s to be asked to show, got %q", winner, state.CurrentHand.ShowCardsPlayerID)
+	}
+	if !hasEvent(*events, "showCardsPrompt") {
+		t.Error("Expected a showCardsPrompt event")
+	}
+	player := findPlayerByID(state.Players, winner)
+	if state.ShowsCards(player) {
+		t.Error("Expected an uncontested winner's cards to stay hidden")
+	}
+
+	loser := "p0"
+	if winner == "p0" {
+		loser = "p1"
+	}
+	if err := table.ShowCards(loser, []int{0}); !errors.Is(err, ErrShowCardsClosed) {
+		t.Errorf("Expected ErrShowCardsClosed for the loser, got %v", err)
+	}
+	for _, cards := range [][]int{{2}, {0, 0}, {0, 1, 1}} {
+		if err := table.ShowCards(winner, cards); !errors.Is(err, ErrInvalidShowCards) {
+			t.Errorf("Expected ErrInvalidShowCards for %v, got %v", cards, err)
+		}
+	}
+
+	if err := table.ShowCards(winner, []int{1}); err != nil {
+		t.Fatalf("ShowCards failed: %v", err)
+	}
+	state = table.GetState()
+	player = findPlayerByID(state.Players, winner)
+	if len(player.ShownCards) != 1 || player.ShownCards[0] != player.Cards[1] {
+		t.Errorf("Expected the second card shown, got %v of %v", player.ShownCards, player.Cards)
+	}
+	if table.IsShowingCards() || !hasEvent(*events, "showCardsClosed") {
+		t.Error("Expected showing to close the window")
+	}
+	last := state.History[len(state.History)-1]
+	if last.EventType != models.HistoryCardsShown || last.PlayerID != winner {
+		t.Errorf("Expected the show in the history, got %+v", last)
+	}
+	if err := table.ShowCards(winner, []int{0}); !errors.Is(err, ErrShowCardsClosed) {
+		t.Errorf("Expected a second show to be refused, got %v", err)
+	}
+
+	// The next hand forgets what was shown
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start the next hand: %v", err)
+	}
+	if player := findPlayerByID(table.GetState().Players, winner); len(player.ShownCards) != 0 {
+		t.Errorf("Expected no shown cards in the next hand, got %v", player.ShownCards)
+	}
+}
+
+func TestShowCards_WindowClosesWithNextHand(t *testing.T) {
+	table, winner, _ := foldToWinner(t, 30)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start the next hand: %v", err)
+	}
+	if table.IsShowingCards() {
+		t.Error("Expected the next hand to close the window")
+	}
+	if err := table.ShowCards(winner, []int{0}); !errors.Is(err, ErrShowCardsClosed) {
+		t.Errorf("Expected ErrShowCardsClosed, got %v", err)
+	}
+
+	// Without a window, nobody is asked
+	table, _, events := foldToWinner(t, 0)
+	if table.IsShowingCards() || hasEvent(*events, "showCardsPrompt") {
+		t.Error("Expected no prompt without a show cards window")
+	}
+}
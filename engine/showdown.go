@@ -0,0 +1,42 @@
+package engine
+
+import (
+	"fmt"
+
+	"poker-engine/models"
+)
+
+// SetShowdownPolicy sets whose hole cards the whole table sees once a hand is
+// complete: one of the models.Showdown policies, or empty for all of them
+func (t *Table) SetShowdownPolicy(policy string) error {
+	if t.game != nil {
+		t.game.mu.Lock()
+		defer t.game.mu.Unlock()
+	}
+
+	if !models.ValidShowdownPolicy(policy) {
+		return fmt.Errorf("unknown showdown policy %q", policy)
+	}
+	t.model.Config.ShowdownPolicy = policy
+
+	if t.game != nil {
+		t.game.publishSnapshot()
+	}
+	return nil
+}
+
+// trackFinalBet keeps CalledFinalBet set on the players who called the last
+// bet or raise of the hand: a bet or raise clears it everywhere, and a call,
+// all-in ones included, sets it. Caller must hold g.mu.
+func (g *Game) trackFinalBet(player *models.Player, action models.PlayerAction, betBefore int) {
+	switch {
+	case g.table.CurrentHand.CurrentBet > betBefore:
+		for _, p := range g.table.Players {
+			if p != nil {
+				p.CalledFinalBet = false
+			}
+		}
+	case action == models.ActionCall || action == models.ActionAllIn:
+		player.CalledFinalBet = true
+	}
+}
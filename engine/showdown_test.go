@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"testing"
+
+	"poker-engine/models"
+)
+
+func TestShowdownPolicy(t *testing.T) {
+	config := models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 6}
+	table := NewTable("showdown", models.GameTypeCash, config, nil, nil)
+	for i, id := range []string{"p0", "p1", "p2"} {
+		table.AddPlayer(id, id, i, 1000)
+	}
+	if err := table.SetShowdownPolicy("muck"); err == nil {
+		t.Error("Expected an unknown showdown policy to be refused")
+	}
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+
+	// The first player raises, the next calls, the last folds, then the
+	// two left check it down
+	var raiser, caller, folder string
+	for table.GetState().Status == models.StatusPlaying {
+		state := table.GetState()
+		player := state.Players[state.CurrentHand.CurrentPosition]
+		action, amount := models.ActionCheck, 0
+		switch {
+		case raiser == "":
+			raiser, action, amount = player.PlayerID, models.ActionRaise, 60
+		case caller == "":
+			caller, action = player.PlayerID, models.ActionCall
+		case folder == "":
+			folder, action = player.PlayerID, models.ActionFold
+		}
+		if err := table.ProcessAction(player.PlayerID, action, amount); err != nil {
+			t.Fatalf("%s by %s failed: %v", action, player.PlayerID, err)
+		}
+	}
+
+	state := table.GetState()
+	players := map[string]*models.Player{}
+	for _, p := range state.Players {
+		if p != nil {
+			players[p.PlayerID] = p
+		}
+	}
+	winner := state.Winners[0].PlayerID
+
+	cases := []struct {
+		policy string
+		shown  map[string]bool
+	}{
+		{"", map[string]bool{raiser: true, caller: true}},
+		{models.ShowdownShowAll, map[string]bool{raiser: true, caller: true}},
+		{models.ShowdownCallers, map[string]bool{caller: true}},
+		{models.ShowdownWinners, map[string]bool{winner: true}},
+		{models.ShowdownNever, map[string]bool{}},
+	}
+	for _, tc := range cases {
+		state.Config.ShowdownPolicy = tc.policy
+		for id, p := range players {
+			if got := state.ShowsCards(p); got != tc.shown[id] {
+				t.Errorf("Policy %q: expected %s shown=%v, got %v", tc.policy, id, tc.shown[id], got)
+			}
+		}
+		winners := state.PublicWinners()
+		if hasCards := len(winners[0].HandCards) > 0; hasCards != tc.shown[winner] {
+			t.Errorf("Policy %q: expected the winner's best five shown=%v, got %v", tc.policy, tc.shown[winner], hasCards)
+		}
+	}
+}
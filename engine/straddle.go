@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"poker-engine/models"
+)
+
+// StraddleMultiple is the size of a straddle in big blinds
+const StraddleMultiple = 2
+
+// ErrStraddleNotAllowed is returned for a straddle at a table without the option
+var ErrStraddleNotAllowed = errors.New("straddling is not allowed at this table")
+
+// SetStraddle turns the under the gun straddle on or off. Only cash tables
+// can offer it.
+func (t *Table) SetStraddle(allowed bool) error {
+	return t.game.SetStraddle(allowed)
+}
+
+// SetStraddle turns the straddle option on or off and drops a pending straddle
+// when it is turned off
+func (g *Game) SetStraddle(allowed bool) error {
+	g.mu.Lock()
+	defer g.unlock()
+
+	if allowed && g.table.GameType != models.GameTypeCash {
+		return fmt.Errorf("only cash tables can allow straddling")
+	}
+	g.table.Config.AllowStraddle = allowed
+	if !allowed {
+		g.straddler = ""
+	}
+	g.publishSnapshot()
+	return nil
+}
+
+// underTheGun returns the seat of the next hand's first player after the big
+// blind. There is no such seat heads-up. Caller must hold g.mu.
+func (g *Game) underTheGun() (int, bool) {
+	seats, live, ok := g.upcomingBlindSeats()
+	if !ok || live < 3 {
+		return 0, false
+	}
+	return NewPositionFinder(g.table.Players).findNextWithChips(seats.BigBlind), true
+}
+
+// declareStraddle records that playerID straddles the next hand. Only the
+// player who will be under the gun can straddle, and only before the cards
+// are dealt. Caller must hold g.mu.
+func (g *Game) declareStraddle(playerID string) error {
+	if !g.table.Config.AllowStraddle || g.table.GameType != models.GameTypeCash {
+		return ErrStraddleNotAllowed
+	}
+	if g.table.Status == models.StatusPlaying {
+		return fmt.Errorf("a straddle must be declared before the cards are dealt")
+	}
+
+	player := findPlayerByID(g.table.Players, playerID)
+	if player == nil {
+		return fmt.Errorf("player not found")
+	}
+	if g.frozen[playerID] {
+		return ErrPlayerFrozen
+	}
+	if utg, ok := g.underTheGun(); !ok || g.table.Players[utg] != player {
+		return fmt.Errorf("only the player under the gun can straddle")
+	}
+
+	amount := g.table.Config.BigBlind * StraddleMultiple
+	validator := NewBettingValidator(g.table.Config.BigBlind, g.table.Config.BigBlind)
+	if err := validator.validateStraddle(amount, player.Chips); err != nil {
+		return err
+	}
+
+	g.straddler = playerID
+	g.publishSnapshot()
+	g.emit(models.Event{
+		Event:   "straddleDeclared",
+		TableID: g.table.TableID,
+		Data: map[string]interface{}{
+			"playerId": playerID,
+			"amount":   amount,
+		},
+	})
+	return nil
+}
+
+// postStraddle posts the pending straddle of the hand being dealt. The
+// straddle becomes the bet to call, the player after the straddler acts
+// first and the straddler acts last preflop. A straddle is dropped when its
+// player is no longer under the gun, for example because someone left. Caller
+// must hold g.mu, after the blinds are posted.
+func (g *Game) postStraddle(bbPos, activePlayers int) {
+	playerID := g.straddler
+	g.straddler = ""
+	if playerID == "" || !g.table.Config.AllowStraddle || activePlayers < 3 {
+		return
+	}
+
+	pf := NewPositionFinder(g.table.Players)
+	utg := pf.findNextWithChips(bbPos)
+	player := g.table.Players[utg]
+	if player == nil || player.PlayerID != playerID || player.Status != models.StatusActive || player.Bet > 0 {
+		log.Printf("[STRADDLE] Dropped %s's straddle on table %s: no longer under the gun", playerID, g.table.TableID)
+		return
+	}
+
+	g.postBlind(player, g.table.Config.BigBlind*StraddleMultiple, false)
+	player.IsStraddle = true
+	g.handRecord.Straddle = playerID
+
+	hand := g.table.CurrentHand
+	if player.Bet > hand.CurrentBet {
+		hand.CurrentBet = player.Bet
+		hand.MinRaise = player.Bet
+		hand.Raises++
+	}
+	hand.CurrentPosition = pf.findNext(utg, canAct)
+}
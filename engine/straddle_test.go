@@ -0,0 +1,189 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"poker-engine/models"
+)
+
+func newStraddleTestTable(t *testing.T, tableID string, players int) *Table {
+	t.Helper()
+	config := models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 6}
+	table := NewTable(tableID, models.GameTypeCash, config, nil, nil)
+	for i := 0; i < players; i++ {
+		table.AddPlayer(fmt.Sprintf("%s-p%d", tableID, i), fmt.Sprintf("Player %d", i), i, 1000)
+	}
+	if err := table.SetStraddle(true); err != nil {
+		t.Fatalf("SetStraddle failed: %v", err)
+	}
+	return table
+}
+
+// declareUnderTheGun finds the player who may straddle the next hand and
+// checks everyone else is turned away
+func declareUnderTheGun(t *testing.T, table *Table) string {
+	t.Helper()
+	straddler := ""
+	for _, p := range table.GetState().Players {
+		if p == nil {
+			continue
+		}
+		if err := table.ProcessAction(p.PlayerID, models.ActionStraddle, 0); err == nil {
+			if straddler != "" {
+				t.Fatalf("Both %s and %s were allowed to straddle", straddler, p.PlayerID)
+			}
+			straddler = p.PlayerID
+		}
+	}
+	if straddler == "" {
+		t.Fatal("Expected the player under the gun to be allowed to straddle")
+	}
+	return straddler
+}
+
+func TestStraddle_PostedAndActsLast(t *testing.T) {
+	table := newStraddleTestTable(t, "str", 4)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	foldToEnd(t, table)
+
+	straddler := declareUnderTheGun(t, table)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	state := table.GetState()
+	hand := state.CurrentHand
+	utg := NewPositionFinder(state.Players).findNextWithChips(hand.BigBlindPosition)
+	player := state.Players[utg]
+	if player.PlayerID != straddler || !player.IsStraddle || player.Bet != 40 {
+		t.Fatalf("Expected %s to post a straddle of 40 under the gun, got %s betting %d", straddler, player.PlayerID, player.Bet)
+	}
+	if hand.CurrentBet != 40 || hand.MinRaise != 40 {
+		t.Errorf("Expected the straddle to set the bet to 40 with a min raise of 40, got %d/%d", hand.CurrentBet, hand.MinRaise)
+	}
+	if hand.CurrentPosition != NewPositionFinder(state.Players).findNext(utg, canAct) {
+		t.Errorf("Expected the player after the straddler to act first, got seat %d", hand.CurrentPosition)
+	}
+	if err := table.ProcessAction(state.Players[hand.CurrentPosition].PlayerID, models.ActionRaise, 60); err == nil {
+		t.Error("Expected a raise to less than twice the straddle to fail")
+	}
+
+	// Everyone calls, then the straddler has the option
+	for state.Players[hand.CurrentPosition].PlayerID != straddler {
+		if err := table.ProcessAction(state.Players[hand.CurrentPosition].PlayerID, models.ActionCall, 0); err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+	}
+	if hand.BettingRound != models.RoundPreflop {
+		t.Fatalf("Expected the straddler to get the option before the flop, got %s", hand.BettingRound)
+	}
+	if err := table.ProcessAction(straddler, models.ActionCheck, 0); err != nil {
+		t.Fatalf("Straddler failed to check their option: %v", err)
+	}
+	if hand.BettingRound != models.RoundFlop || hand.Pot.Main != 160 {
+		t.Errorf("Expected a pot of 160 on the flop, got %d on the %s", hand.Pot.Main, hand.BettingRound)
+	}
+	foldToEnd(t, table)
+
+	// The straddle only applies to the hand it was declared for
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if table.GetState().CurrentHand.CurrentBet != 20 {
+		t.Errorf("Expected no straddle on the next hand, got current bet %d", table.GetState().CurrentHand.CurrentBet)
+	}
+}
+
+func TestStraddle_Rejected(t *testing.T) {
+	table := newStraddleTestTable(t, "rej", 3)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	for _, p := range table.GetState().Players {
+		if p != nil && table.ProcessAction(p.PlayerID, models.ActionStraddle, 0) == nil {
+			t.Errorf("Expected %s's straddle during a hand to fail", p.PlayerID)
+		}
+	}
+	foldToEnd(t, table)
+
+	if err := table.SetStraddle(false); err != nil {
+		t.Fatalf("SetStraddle failed: %v", err)
+	}
+	for _, p := range table.GetState().Players {
+		if p == nil {
+			continue
+		}
+		if err := table.ProcessAction(p.PlayerID, models.ActionStraddle, 0); !errors.Is(err, ErrStraddleNotAllowed) {
+			t.Errorf("Expected ErrStraddleNotAllowed for %s, got %v", p.PlayerID, err)
+		}
+	}
+
+	// Heads-up there is nobody under the gun
+	headsUp := newStraddleTestTable(t, "hu", 2)
+	for _, p := range headsUp.GetState().Players {
+		if p != nil && headsUp.ProcessAction(p.PlayerID, models.ActionStraddle, 0) == nil {
+			t.Errorf("Expected %s's heads-up straddle to fail", p.PlayerID)
+		}
+	}
+
+	// A short stack can't straddle
+	short := newStraddleTestTable(t, "short", 3)
+	for _, p := range short.GetState().Players {
+		if p != nil {
+			p.Chips = 30
+		}
+	}
+	for _, p := range short.GetState().Players {
+		if p != nil && short.ProcessAction(p.PlayerID, models.ActionStraddle, 0) == nil {
+			t.Errorf("Expected %s's straddle with 30 chips to fail", p.PlayerID)
+		}
+	}
+
+	if err := newBalanceTestTable("tour", 3).SetStraddle(true); err == nil {
+		t.Error("Expected a tournament table to refuse straddling")
+	}
+}
+
+func TestStraddle_TurnedOffDropsPendingStraddle(t *testing.T) {
+	table := newStraddleTestTable(t, "off", 3)
+	declareUnderTheGun(t, table)
+	if err := table.SetStraddle(false); err != nil {
+		t.Fatalf("SetStraddle failed: %v", err)
+	}
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if table.GetState().CurrentHand.CurrentBet != 20 {
+		t.Errorf("Expected the pending straddle to be dropped, got current bet %d", table.GetState().CurrentHand.CurrentBet)
+	}
+}
+
+func TestStraddle_Replay(t *testing.T) {
+	table := newStraddleTestTable(t, "rep", 4)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	foldToEnd(t, table)
+
+	straddler := declareUnderTheGun(t, table)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	if table.HandRecord().Straddle != straddler {
+		t.Errorf("Expected the record to name straddler %s, got %q", straddler, table.HandRecord().Straddle)
+	}
+	foldToEnd(t, table)
+
+	replayed, err := ReplayHand(table.HandRecord())
+	if err != nil {
+		t.Fatalf("ReplayHand failed: %v", err)
+	}
+	for i, p := range table.GetState().Players {
+		if p != nil && replayed.Players[i].Chips != p.Chips {
+			t.Errorf("Seat %d: replay ended with %d chips, original with %d", i, replayed.Players[i].Chips, p.Chips)
+		}
+	}
+}
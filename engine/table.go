@@ -12,7 +12,7 @@ type Table struct {
 	blindsTimer *time.Timer
 }
 
-func NewTable(tableID string, gameType models.GameType, config models.TableConfig, onTimeout func(string), onEvent func(models.Event)) *Table {
+func NewTable(tableID string, gameType models.GameType, config models.TableConfig, onTimeout func(playerID string, deadline uint64), onEvent func(models.Event)) *Table {
 	// Validate ActionTimeout
 	if config.ActionTimeout < 0 {
 		config.ActionTimeout = 0 // Disable timeout
@@ -35,6 +35,7 @@ func NewTable(tableID string, gameType models.GameType, config models.TableConfi
 
 	t := &Table{model: table}
 	t.game = NewGame(table, onTimeout, onEvent)
+	t.game.refreshSnapshot()
 	return t
 }
 
@@ -71,39 +72,10 @@ func (t *Table) AddPlayer(playerID, playerName string, seatNumber int, buyIn int
 
 	player := models.NewPlayer(playerID, playerName, seatNumber, chips)
 	t.model.Players[seatNumber] = player
+	t.game.refreshSnapshot()
 	return nil
 }
 
-func (t *Table) RemovePlayer(playerID string) error {
-	// Check if hand is in progress
-	if t.model.Status == models.StatusPlaying {
-		// Find the player
-		for _, player := range t.model.Players {
-			if player != nil && player.PlayerID == playerID {
-				// If player is active (hasn't folded yet), fold them first
-				if player.Status != models.StatusFolded && player.Status != models.StatusSittingOut {
-					player.Status = models.StatusFolded
-					player.LastAction = models.ActionFold
-				}
-				// Note: Player will be fully removed when hand completes
-				// For now, just mark them as sitting out to prevent them from playing future hands
-				// The actual removal should happen in the next hand start or when game is not playing
-				return nil
-			}
-		}
-		return fmt.Errorf("player not found")
-	}
-
-	// Hand not in progress - safe to remove immediately
-	for i, player := range t.model.Players {
-		if player != nil && player.PlayerID == playerID {
-			t.model.Players[i] = nil
-			return nil
-		}
-	}
-	return fmt.Errorf("player not found")
-}
-
 func (t *Table) SitOut(playerID string) error {
 	for _, player := range t.model.Players {
 		if player != nil && player.PlayerID == playerID {
@@ -113,6 +85,7 @@ func (t *Table) SitOut(playerID string) error {
 				player.LastAction = models.ActionFold
 			}
 			player.Status = models.StatusSittingOut
+			t.game.refreshSnapshot()
 			return nil
 		}
 	}
@@ -120,39 +93,31 @@ func (t *Table) SitOut(playerID string) error {
 }
 
 func (t *Table) SitIn(playerID string) error {
+	if t.IsPlayerFrozen(playerID) {
+		return ErrPlayerFrozen
+	}
 	for _, player := range t.model.Players {
 		if player != nil && player.PlayerID == playerID {
 			if player.Chips > 0 {
 				player.Status = models.StatusActive
 			}
+			t.game.refreshSnapshot()
 			return nil
 		}
 	}
 	return fmt.Errorf("player not found")
 }
 
+// AddChips reloads a cash game player's stack. Chips can only be added between
+// hands, and the new stack must be within the table's buy-in limits.
 func (t *Table) AddChips(playerID string, amount int) error {
-	if t.model.GameType == models.GameTypeTournament {
-		return fmt.Errorf("cannot add chips in tournament mode")
-	}
-	if amount <= 0 {
-		return fmt.Errorf("amount must be positive")
-	}
-	for _, player := range t.model.Players {
-		if player != nil && player.PlayerID == playerID {
-			// Check max buy-in if configured
-			if t.model.Config.MaxBuyIn > 0 {
-				newTotal := player.Chips + amount
-				if newTotal > t.model.Config.MaxBuyIn {
-					return fmt.Errorf("adding %d chips would exceed max buy-in of %d (current: %d)",
-						amount, t.model.Config.MaxBuyIn, player.Chips)
-				}
-			}
-			player.AddChips(amount)
-			return nil
-		}
-	}
-	return fmt.Errorf("player not found")
+	return t.game.AddChips(playerID, amount)
+}
+
+// CheckAddChips reports whether AddChips would succeed, and the stack it
+// would leave, without changing anything
+func (t *Table) CheckAddChips(playerID string, amount int) (int, error) {
+	return t.game.CheckAddChips(playerID, amount)
 }
 
 func (t *Table) StartGame() error {
@@ -196,14 +161,20 @@ func (t *Table) ProcessAction(playerID string, action models.PlayerAction, amoun
 	return t.game.ProcessAction(playerID, action, amount)
 }
 
-func (t *Table) HandleTimeout(playerID string) error {
-	return t.game.HandleTimeout(playerID)
+func (t *Table) HandleTimeout(playerID string, deadline uint64) error {
+	return t.game.HandleTimeout(playerID, deadline)
 }
 
 func (t *Table) GetState() *models.Table {
 	return t.model
 }
 
+// Snapshot returns an immutable copy of the table state, regenerated only when
+// the table changes. Prefer it over GetState for broadcasts and other reads.
+func (t *Table) Snapshot() *models.Table {
+	return t.game.Snapshot()
+}
+
 func (t *Table) GetGame() *Game {
 	return t.game
 }
@@ -251,5 +222,52 @@ func (t *Table) UpdateBlinds(smallBlind, bigBlind int) error {
 	t.model.Config.SmallBlind = smallBlind
 	t.model.Config.BigBlind = bigBlind
 
+	if t.game != nil {
+		t.game.publishSnapshot()
+	}
+
+	return nil
+}
+
+// UpdateAnte updates the ante for the next hand. Like UpdateBlinds it is safe
+// to call during an active hand. An ante of zero turns antes off.
+func (t *Table) UpdateAnte(ante int) error {
+	if t.game != nil {
+		t.game.mu.Lock()
+		defer t.game.mu.Unlock()
+	}
+
+	if ante < 0 {
+		return fmt.Errorf("ante cannot be negative")
+	}
+
+	t.model.Config.Ante = ante
+
+	if t.game != nil {
+		t.game.publishSnapshot()
+	}
+
+	return nil
+}
+
+// SetDisplay sets how clients should format amounts at the table. It has no
+// effect on play. A chip scale of zero shows chips as they are.
+func (t *Table) SetDisplay(currencySymbol string, chipScale int) error {
+	if t.game != nil {
+		t.game.mu.Lock()
+		defer t.game.mu.Unlock()
+	}
+
+	if chipScale < 0 {
+		return fmt.Errorf("chip scale cannot be negative")
+	}
+
+	t.model.Config.CurrencySymbol = currencySymbol
+	t.model.Config.ChipScale = chipScale
+
+	if t.game != nil {
+		t.game.publishSnapshot()
+	}
+
 	return nil
 }
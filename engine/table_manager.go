@@ -27,10 +27,10 @@ func (tm *TableManager) CreateTable(tableID string, gameType models.GameType, co
 		return fmt.Errorf("table already exists")
 	}
 
-	onTimeout := func(playerID string) {
+	onTimeout := func(playerID string, deadline uint64) {
 		table := tm.tables[tableID]
 		if table != nil {
-			table.HandleTimeout(playerID)
+			table.HandleTimeout(playerID, deadline)
 		}
 	}
 
@@ -39,6 +39,7 @@ func (tm *TableManager) CreateTable(tableID string, gameType models.GameType, co
 	}
 
 	table := NewTable(tableID, gameType, config, onTimeout, onEvent)
+	table.SetRateLimits(DefaultRateLimits)
 	tm.tables[tableID] = table
 	return nil
 }
@@ -99,7 +100,8 @@ func (tm *TableManager) RemovePlayer(tableID, playerID string) error {
 	if !exists {
 		return fmt.Errorf("table not found")
 	}
-	return table.RemovePlayer(playerID)
+	_, err := table.RemovePlayer(playerID)
+	return err
 }
 
 func (tm *TableManager) SitOut(tableID, playerID string) error {
@@ -168,6 +170,22 @@ func (tm *TableManager) ProcessAction(tableID, playerID string, action models.Pl
 	return table.ProcessAction(playerID, action, amount)
 }
 
+// HandRecord returns the record of a table's current or last hand
+func (tm *TableManager) HandRecord(tableID string) (*HandRecord, error) {
+	tm.mu.RLock()
+	table, exists := tm.tables[tableID]
+	tm.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+	record := table.HandRecord()
+	if record == nil {
+		return nil, fmt.Errorf("no hand has been dealt")
+	}
+	return record, nil
+}
+
 func (tm *TableManager) GetEventChannel() <-chan models.Event {
 	return tm.eventChannel
 }
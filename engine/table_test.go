@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"fmt"
 	"poker-engine/models"
 	"testing"
 )
@@ -29,10 +30,13 @@ func TestTable_RemovePlayerDuringHand(t *testing.T) {
 	}
 	
 	// Remove a player during active hand
-	err = table.RemovePlayer("p2")
+	removed, err := table.RemovePlayer("p2")
 	if err != nil {
 		t.Errorf("Failed to remove player: %v", err)
 	}
+	if removed != nil {
+		t.Error("A player in a hand should not be removed until it is over")
+	}
 	
 	// Player should be folded
 	state := table.GetState()
@@ -69,10 +73,13 @@ func TestTable_RemovePlayerWhenNotPlaying(t *testing.T) {
 	table.AddPlayer("p2", "Player 2", 1, 0)
 	
 	// Remove player when game is not active
-	err := table.RemovePlayer("p2")
+	removed, err := table.RemovePlayer("p2")
 	if err != nil {
 		t.Errorf("Failed to remove player: %v", err)
 	}
+	if removed == nil || removed.Chips != 1000 {
+		t.Errorf("Expected the removed player back with their stack, got %+v", removed)
+	}
 	
 	// Player should be completely removed (nil in array)
 	state := table.GetState()
@@ -149,6 +156,17 @@ func TestTable_AddChipsValidation(t *testing.T) {
 		t.Errorf("Should not allow adding chips that exceed max buy-in")
 	}
 	
+	// Checking a valid amount leaves the stack alone
+	if stack, err := table.CheckAddChips("p1", 400); err != nil || stack != 900 {
+		t.Errorf("Expected a check for 400 chips to pass with a 900 stack, got %d, %v", stack, err)
+	}
+	if _, err := table.CheckAddChips("p1", 600); err == nil {
+		t.Errorf("Check should refuse chips that exceed max buy-in")
+	}
+	if state := table.GetState(); state.Players[0].Chips != 500 {
+		t.Errorf("Expected a check not to add chips, got %d", state.Players[0].Chips)
+	}
+
 	// Add valid amount
 	err = table.AddChips("p1", 400)
 	if err != nil {
@@ -162,6 +180,53 @@ func TestTable_AddChipsValidation(t *testing.T) {
 	}
 }
 
+func TestTable_AddChipsBetweenHandsOnly(t *testing.T) {
+	config := models.TableConfig{
+		SmallBlind:    10,
+		BigBlind:      20,
+		MaxPlayers:    3,
+		MinBuyIn:      200,
+		MaxBuyIn:      1000,
+		ActionTimeout: 0,
+	}
+
+	table := NewTable("test-rebuy", models.GameTypeCash, config, nil, nil)
+	table.AddPlayer("p1", "Player 1", 0, 200)
+	table.AddPlayer("p2", "Player 2", 1, 200)
+
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	if err := table.AddChips("p2", 100); err == nil {
+		t.Error("Should not allow adding chips while a hand is in progress")
+	}
+
+	// The small blind folds and drops below the min buy-in
+	foldToEnd(t, table)
+	var loser *models.Player
+	for _, p := range table.GetState().Players {
+		if p != nil && p.Chips < 200 {
+			loser = p
+		}
+	}
+	if loser == nil || loser.Chips != 190 {
+		t.Fatalf("Expected one player to be left with 190 chips, got %+v", loser)
+	}
+
+	if err := table.AddChips(loser.PlayerID, 5); err == nil {
+		t.Error("Should not allow a reload that leaves the stack below min buy-in")
+	}
+	if err := table.AddChips("p3", 500); err == nil {
+		t.Error("Should not allow adding chips for a player who is not seated")
+	}
+	if err := table.AddChips(loser.PlayerID, 110); err != nil {
+		t.Fatalf("Should allow reloading between hands: %v", err)
+	}
+	if chips := table.Snapshot().Players[loser.SeatNumber].Chips; chips != 300 {
+		t.Errorf("Expected the snapshot to show 300 chips, got %d", chips)
+	}
+}
+
 func TestTable_AddChipsInTournament(t *testing.T) {
 	config := models.TableConfig{
 		SmallBlind:    10,
@@ -306,3 +371,416 @@ func TestUpdateBlinds_DuringActiveHand(t *testing.T) {
 	t.Logf("Successfully updated blinds from %d/%d to %d/%d during active hand",
 		oldSB, oldBB, state.Config.SmallBlind, state.Config.BigBlind)
 }
+
+func newSnapshotTestTable() *Table {
+	config := models.TableConfig{
+		SmallBlind:    10,
+		BigBlind:      20,
+		MaxPlayers:    6,
+		StartingChips: 1000,
+		ActionTimeout: 0,
+	}
+
+	table := NewTable("snapshot-table", models.GameTypeTournament, config, nil, nil)
+	for i := 0; i < 6; i++ {
+		table.AddPlayer(fmt.Sprintf("p%d", i+1), fmt.Sprintf("Player %d", i+1), i, 0)
+	}
+	return table
+}
+
+// TestUpdateAnte verifies antes can be changed and can't be negative
+func TestUpdateAnte(t *testing.T) {
+	table := NewTable("ante-table", models.GameTypeTournament, models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 6}, nil, nil)
+
+	if err := table.UpdateAnte(-1); err == nil {
+		t.Error("Expected a negative ante to be rejected")
+	}
+	if err := table.UpdateAnte(5); err != nil {
+		t.Fatalf("Failed to update ante: %v", err)
+	}
+	if ante := table.GetState().Config.Ante; ante != 5 {
+		t.Errorf("Expected ante 5, got %d", ante)
+	}
+}
+
+// TestSetDisplay verifies display settings reach snapshots
+func TestSetDisplay(t *testing.T) {
+	table := NewTable("display-table", models.GameTypeCash, models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 6}, nil, nil)
+
+	if err := table.SetDisplay("$", -1); err == nil {
+		t.Error("Expected a negative chip scale to be rejected")
+	}
+	if err := table.SetDisplay("$", 100); err != nil {
+		t.Fatalf("Failed to set display: %v", err)
+	}
+	config := table.Snapshot().Config
+	if config.CurrencySymbol != "$" || config.ChipScale != 100 {
+		t.Errorf("Expected $ with a scale of 100, got %q and %d", config.CurrencySymbol, config.ChipScale)
+	}
+}
+
+func TestTable_SnapshotCachedUntilChange(t *testing.T) {
+	table := newSnapshotTestTable()
+
+	first := table.Snapshot()
+	if second := table.Snapshot(); first != second {
+		t.Fatal("Snapshot should be reused while the table is unchanged")
+	}
+
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+
+	started := table.Snapshot()
+	if started == first {
+		t.Fatal("Snapshot should be regenerated after the table changes")
+	}
+	if started.Version <= first.Version {
+		t.Errorf("Snapshot version should increase, got %d after %d", started.Version, first.Version)
+	}
+	if started.Status != models.StatusPlaying {
+		t.Errorf("Snapshot should reflect new status, got %s", started.Status)
+	}
+	if started.Deck != nil {
+		t.Error("Snapshot must not expose the deck")
+	}
+}
+
+func TestTable_SnapshotIsolatedFromLiveState(t *testing.T) {
+	table := newSnapshotTestTable()
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+
+	snapshot := table.Snapshot()
+	chipsBefore := snapshot.Players[0].Chips
+	cardBefore := snapshot.Players[0].Cards[0]
+
+	live := table.GetState().Players[0]
+	live.Chips += 500
+	live.Cards[0] = models.Card{Rank: models.Two, Suit: models.Clubs}
+	if cardBefore == live.Cards[0] {
+		live.Cards[0] = models.Card{Rank: models.Three, Suit: models.Clubs}
+	}
+
+	if snapshot.Players[0].Chips != chipsBefore {
+		t.Error("Mutating live state should not affect a published snapshot")
+	}
+	if snapshot.Players[0].Cards[0] != cardBefore {
+		t.Error("Snapshot cards should not share backing storage with live state")
+	}
+}
+
+// BenchmarkTableState_CloneOnRead measures deep-copying the table for every
+// reader, as a broadcast to six clients would without snapshot caching.
+func BenchmarkTableState_CloneOnRead(b *testing.B) {
+	table := newSnapshotTestTable()
+	table.StartGame()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for c := 0; c < 6; c++ {
+			_ = table.GetState().Clone()
+		}
+	}
+}
+
+// BenchmarkTableState_Snapshot measures the same broadcast using the cached snapshot
+func BenchmarkTableState_Snapshot(b *testing.B) {
+	table := newSnapshotTestTable()
+	table.StartGame()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for c := 0; c < 6; c++ {
+			_ = table.Snapshot()
+		}
+	}
+}
+
+func TestTable_PositionsRotateOnLargeTables(t *testing.T) {
+	for _, seats := range []int{6, 9, 10} {
+		t.Run(fmt.Sprintf("%d-max", seats), func(t *testing.T) {
+			config := models.TableConfig{
+				SmallBlind:    10,
+				BigBlind:      20,
+				MaxPlayers:    seats,
+				StartingChips: 100000,
+				ActionTimeout: 0,
+			}
+			table := NewTable("big-table", models.GameTypeTournament, config, nil, nil)
+
+			// Leave one seat empty to make sure rotation skips it
+			emptySeat := seats / 2
+			for i := 0; i < seats; i++ {
+				if i == emptySeat {
+					continue
+				}
+				if err := table.AddPlayer(fmt.Sprintf("p%d", i), fmt.Sprintf("Player %d", i), i, 0); err != nil {
+					t.Fatalf("Failed to add player to seat %d: %v", i, err)
+				}
+			}
+
+			if err := table.StartGame(); err != nil {
+				t.Fatalf("Failed to start game: %v", err)
+			}
+
+			seen := make(map[int]bool)
+			for hand := 0; hand < seats-1; hand++ {
+				state := table.GetState()
+				h := state.CurrentHand
+
+				if h.DealerPosition == emptySeat || h.SmallBlindPosition == emptySeat || h.BigBlindPosition == emptySeat {
+					t.Fatalf("Hand %d: position assigned to empty seat %d", hand, emptySeat)
+				}
+				if seen[h.DealerPosition] {
+					t.Fatalf("Hand %d: button returned to seat %d before a full orbit", hand, h.DealerPosition)
+				}
+				seen[h.DealerPosition] = true
+
+				pf := NewPositionFinder(state.Players)
+				if sb := pf.findNextActive(h.DealerPosition); h.SmallBlindPosition != sb {
+					t.Errorf("Hand %d: expected SB at %d, got %d", hand, sb, h.SmallBlindPosition)
+				}
+				if bb := pf.findNextActive(h.SmallBlindPosition); h.BigBlindPosition != bb {
+					t.Errorf("Hand %d: expected BB at %d, got %d", hand, bb, h.BigBlindPosition)
+				}
+				if utg := pf.findNextActive(h.BigBlindPosition); h.CurrentPosition != utg {
+					t.Errorf("Hand %d: expected first action at %d, got %d", hand, utg, h.CurrentPosition)
+				}
+
+				// Everyone folds to the big blind to finish the hand
+				for table.GetState().Status == models.StatusPlaying {
+					current := table.GetState().Players[table.GetState().CurrentHand.CurrentPosition]
+					if err := table.ProcessAction(current.PlayerID, models.ActionFold, 0); err != nil {
+						t.Fatalf("Hand %d: fold failed: %v", hand, err)
+					}
+				}
+
+				if err := table.DealNewHand(); err != nil {
+					t.Fatalf("Failed to deal hand %d: %v", hand+1, err)
+				}
+			}
+
+			if len(seen) != seats-1 {
+				t.Errorf("Expected button to visit %d occupied seats, visited %d", seats-1, len(seen))
+			}
+		})
+	}
+}
+
+func newSeatChangeTestTable() *Table {
+	config := models.TableConfig{
+		SmallBlind:    10,
+		BigBlind:      20,
+		MaxPlayers:    6,
+		MinBuyIn:      100,
+		MaxBuyIn:      2000,
+		ActionTimeout: 0,
+	}
+
+	table := NewTable("seat-change-table", models.GameTypeCash, config, nil, nil)
+	for i := 0; i < 5; i++ {
+		table.AddPlayer(fmt.Sprintf("p%d", i), fmt.Sprintf("Player %d", i), i, 1000)
+	}
+	return table
+}
+
+func foldToEnd(t *testing.T, table *Table) {
+	t.Helper()
+	for table.GetState().Status == models.StatusPlaying {
+		current := table.GetState().Players[table.GetState().CurrentHand.CurrentPosition]
+		if err := table.ProcessAction(current.PlayerID, models.ActionFold, 0); err != nil {
+			t.Fatalf("Fold failed: %v", err)
+		}
+	}
+}
+
+func TestTable_SeatChangeAppliedImmediatelyBetweenHands(t *testing.T) {
+	table := newSeatChangeTestTable()
+
+	applied, err := table.RequestSeatChange("p1", 5)
+	if err != nil {
+		t.Fatalf("Seat change failed: %v", err)
+	}
+	if !applied {
+		t.Error("Seat change before the first hand should apply immediately")
+	}
+
+	state := table.GetState()
+	if state.Players[1] != nil {
+		t.Error("Old seat should be empty after the move")
+	}
+	if p := state.Players[5]; p == nil || p.PlayerID != "p1" || p.SeatNumber != 5 {
+		t.Errorf("Expected p1 in seat 5, got %+v", p)
+	}
+	if state.Players[5].MustPostBigBlind {
+		t.Error("No blind obligation should apply before any hand is played")
+	}
+}
+
+func TestTable_SeatChangeQueuedUntilHandEnds(t *testing.T) {
+	table := newSeatChangeTestTable()
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+
+	applied, err := table.RequestSeatChange("p0", 5)
+	if err != nil {
+		t.Fatalf("Seat change failed: %v", err)
+	}
+	if applied {
+		t.Fatal("Seat change during a hand should be queued")
+	}
+	if seat, ok := table.PendingSeatChange("p0"); !ok || seat != 5 {
+		t.Errorf("Expected pending move to seat 5, got %d (%v)", seat, ok)
+	}
+	if table.GetState().Players[0].PlayerID != "p0" {
+		t.Error("Player should keep their seat until the hand ends")
+	}
+
+	foldToEnd(t, table)
+	if err := table.DealNewHand(); err != nil {
+		t.Fatalf("Failed to deal next hand: %v", err)
+	}
+
+	state := table.GetState()
+	if state.Players[0] != nil || state.Players[5] == nil || state.Players[5].PlayerID != "p0" {
+		t.Fatal("Queued seat change should be applied before the next hand")
+	}
+	if _, ok := table.PendingSeatChange("p0"); ok {
+		t.Error("Applied seat change should no longer be pending")
+	}
+
+	// Seat 5 is outside the blinds this hand, so the mover posts a live big blind
+	h := state.CurrentHand
+	if h.SmallBlindPosition == 5 || h.BigBlindPosition == 5 {
+		t.Fatalf("Test setup expected seat 5 outside the blinds, got SB=%d BB=%d", h.SmallBlindPosition, h.BigBlindPosition)
+	}
+	if p := state.Players[5]; p.Bet != 20 || p.MustPostBigBlind {
+		t.Errorf("Expected moved player to post a 20 big blind, got bet=%d must_post=%v", p.Bet, p.MustPostBigBlind)
+	}
+}
+
+func TestTable_SeatChangeRejections(t *testing.T) {
+	table := newSeatChangeTestTable()
+
+	if _, err := table.RequestSeatChange("p0", 1); err == nil {
+		t.Error("Expected error moving to an occupied seat")
+	}
+	if _, err := table.RequestSeatChange("p0", 0); err == nil {
+		t.Error("Expected error moving to the current seat")
+	}
+	if _, err := table.RequestSeatChange("p0", 6); err == nil {
+		t.Error("Expected error moving to an out-of-range seat")
+	}
+	if _, err := table.RequestSeatChange("nobody", 5); err == nil {
+		t.Error("Expected error for a player who is not seated")
+	}
+
+	tournament := newSnapshotTestTable()
+	tournament.RemovePlayer("p6")
+	if _, err := tournament.RequestSeatChange("p1", 5); err == nil {
+		t.Error("Expected seat changes to be rejected in tournaments")
+	}
+}
+
+func TestTable_ForceButtonAppliesNextHand(t *testing.T) {
+	table := newSnapshotTestTable()
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+
+	if err := table.ForceButton(4); err != nil {
+		t.Fatalf("ForceButton failed: %v", err)
+	}
+	if audit := table.AuditButton(); audit.ForcedButton == nil || *audit.ForcedButton != 4 {
+		t.Fatalf("Expected pending forced button on seat 4, got %+v", audit.ForcedButton)
+	}
+
+	foldToEnd(t, table)
+	if err := table.DealNewHand(); err != nil {
+		t.Fatalf("Failed to deal next hand: %v", err)
+	}
+
+	audit := table.AuditButton()
+	if audit.DealerPosition != 4 || audit.SmallBlindPosition != 5 || audit.BigBlindPosition != 0 {
+		t.Errorf("Expected positions 4/5/0, got %d/%d/%d", audit.DealerPosition, audit.SmallBlindPosition, audit.BigBlindPosition)
+	}
+	if audit.ForcedButton != nil {
+		t.Error("Forced button should be consumed by the hand it applied to")
+	}
+	if len(audit.LastCorrections) != 0 {
+		t.Errorf("Expected no corrections, got %v", audit.LastCorrections)
+	}
+
+	if err := table.ForceButton(6); err == nil {
+		t.Error("Expected error forcing button out of range")
+	}
+	foldToEnd(t, table)
+	table.RemovePlayer("p3")
+	if err := table.ForceButton(2); err == nil {
+		t.Error("Expected error forcing button onto an empty seat")
+	}
+}
+
+func TestTable_RestoreHandPositionContinuesNumbering(t *testing.T) {
+	table := newSnapshotTestTable()
+	if err := table.RestoreHandPosition(41, 2); err != nil {
+		t.Fatalf("RestoreHandPosition failed: %v", err)
+	}
+	if snapshot := table.Snapshot(); snapshot.CurrentHand.HandNumber != 41 {
+		t.Errorf("Expected the snapshot to show hand 41, got %d", snapshot.CurrentHand.HandNumber)
+	}
+
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	audit := table.AuditButton()
+	if audit.HandNumber != 42 || audit.DealerPosition != 3 {
+		t.Errorf("Expected hand 42 with the button on seat 3, got hand %d button %d", audit.HandNumber, audit.DealerPosition)
+	}
+
+	if err := table.RestoreHandPosition(1, 0); err == nil {
+		t.Error("Expected restoring during a hand to fail")
+	}
+	foldToEnd(t, table)
+	if err := table.RestoreHandPosition(1, 6); err == nil {
+		t.Error("Expected a dealer position out of range to fail")
+	}
+	if err := table.RestoreHandPosition(-1, 0); err == nil {
+		t.Error("Expected a negative hand number to fail")
+	}
+}
+
+func TestGame_PositionInvariantsCorrectInvalidButton(t *testing.T) {
+	table := newSnapshotTestTable()
+	g := table.GetGame()
+	players := table.GetState().Players
+	players[2].Status = models.StatusSittingOut
+	pf := NewPositionFinder(players)
+
+	// Button on a sitting-out seat must move to the next live player
+	seats := g.enforcePositionInvariants(pf, BlindSeats{Button: 2, SmallBlind: 3, BigBlind: 4}, 5)
+	if seats.Button != 3 || seats.SmallBlind != 4 || seats.BigBlind != 5 {
+		t.Errorf("Expected corrected positions 3/4/5, got %d/%d/%d", seats.Button, seats.SmallBlind, seats.BigBlind)
+	}
+	if len(g.positionCorrections) == 0 {
+		t.Error("Expected the correction to be recorded")
+	}
+
+	// Blinds that skip a player are recomputed from the button
+	seats = g.enforcePositionInvariants(pf, BlindSeats{Button: 0, SmallBlind: 1, BigBlind: 4}, 5)
+	if seats.Button != 0 || seats.SmallBlind != 1 || seats.BigBlind != 3 {
+		t.Errorf("Expected corrected positions 0/1/3, got %d/%d/%d", seats.Button, seats.SmallBlind, seats.BigBlind)
+	}
+
+	if violations := positionViolations(pf, 0, 1, 3, 5); len(violations) != 0 {
+		t.Errorf("Expected valid positions, got violations %v", violations)
+	}
+	if violations := positionViolations(pf, 0, 0, 1, 2); len(violations) != 0 {
+		t.Errorf("Expected heads-up button to post the small blind, got violations %v", violations)
+	}
+}
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"time"
+
+	"poker-engine/models"
+)
+
+// timeBankOf returns how much reserve a player has left. Players start with
+// the table's full time bank. Caller must hold g.mu.
+func (g *Game) timeBankOf(playerID string) time.Duration {
+	if g.table.Config.TimeBank <= 0 {
+		return 0
+	}
+	if left, ok := g.timeBanks[playerID]; ok {
+		return left
+	}
+	return time.Duration(g.table.Config.TimeBank) * time.Second
+}
+
+// timeBankSeconds rounds a reserve up to whole seconds, so a player shown 0
+// has nothing left
+func timeBankSeconds(left time.Duration) int {
+	return int((left + time.Second - 1) / time.Second)
+}
+
+// syncTimeBanks copies every seated player's reserve into the table state.
+// Caller must hold g.mu.
+func (g *Game) syncTimeBanks() {
+	for _, p := range g.table.Players {
+		if p != nil {
+			p.TimeBank = timeBankSeconds(g.timeBankOf(p.PlayerID))
+		}
+	}
+}
+
+// drawTimeBank gives a player whose action timer ran out their reserve as a
+// new deadline, and reports whether it did. A player already in their time
+// bank, with none left, away or disconnected gets no more time. Caller must
+// hold g.mu.
+func (g *Game) drawTimeBank(player *models.Player) bool {
+	if g.timeBankPlayer != "" || player.Away || player.Disconnected {
+		return false
+	}
+	left := g.timeBankOf(player.PlayerID)
+	if left <= 0 {
+		return false
+	}
+
+	g.timeBankPlayer = player.PlayerID
+	g.timeBankStart = time.Now()
+	g.armActionTimer(player, left)
+	g.emit(models.Event{
+		Event:   "timeBankStarted",
+		TableID: g.table.TableID,
+		Data: map[string]interface{}{
+			"playerId": player.PlayerID,
+			"timeBank": timeBankSeconds(left),
+		},
+	})
+	return true
+}
+
+// chargeTimeBank takes the time spent in a time bank off the player's
+// reserve. It runs whenever the deadline stops, so a player who acts in
+// their time bank keeps what they didn't use. Caller must hold g.mu.
+func (g *Game) chargeTimeBank() {
+	if g.timeBankPlayer == "" {
+		return
+	}
+	playerID := g.timeBankPlayer
+	left := g.timeBankOf(playerID) - time.Since(g.timeBankStart)
+	if left < 0 {
+		left = 0
+	}
+	g.timeBankPlayer = ""
+
+	if g.timeBanks == nil {
+		g.timeBanks = make(map[string]time.Duration)
+	}
+	g.timeBanks[playerID] = left
+	if player := findPlayerByID(g.table.Players, playerID); player != nil {
+		player.TimeBank = timeBankSeconds(left)
+	}
+}
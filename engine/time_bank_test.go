@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"poker-engine/models"
+)
+
+func newTimeBankTestGame(t *testing.T, events *[]models.Event) (*Game, *models.Table) {
+	t.Helper()
+	table := &models.Table{
+		TableID:  "bank-table",
+		GameType: models.GameTypeCash,
+		Status:   models.StatusWaiting,
+		Config:   models.TableConfig{SmallBlind: 10, BigBlind: 20, MaxPlayers: 2, ActionTimeout: 30, TimeBank: 60},
+		Players: []*models.Player{
+			models.NewPlayer("p1", "Player 1", 0, 1000),
+			models.NewPlayer("p2", "Player 2", 1, 1000),
+		},
+		CurrentHand: &models.CurrentHand{HandNumber: 0, DealerPosition: -1},
+	}
+	game := NewGame(table, func(string, uint64) {}, func(e models.Event) { *events = append(*events, e) })
+	game.SetSynchronousEvents(true)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	return game, table
+}
+
+func TestTimeBank_DrawnBeforeAutoFold(t *testing.T) {
+	var events []models.Event
+	game, table := newTimeBankTestGame(t, &events)
+
+	current := table.Players[table.CurrentHand.CurrentPosition]
+	if current.TimeBank != 60 {
+		t.Fatalf("Expected 60 seconds in the time bank, got %d", current.TimeBank)
+	}
+
+	if err := game.HandleTimeout(current.PlayerID, table.CurrentHand.DeadlineToken); err != nil {
+		t.Fatalf("HandleTimeout failed: %v", err)
+	}
+	if current.Status != models.StatusActive || current.ConsecutiveTimeouts != 0 {
+		t.Fatal("Expected the player to draw on their time bank instead of folding")
+	}
+	remaining := time.Until(*table.CurrentHand.ActionDeadline)
+	if remaining > 61*time.Second || remaining < 59*time.Second {
+		t.Errorf("Expected a deadline about 60 seconds away, got %v", remaining)
+	}
+
+	var required *models.ActionRequiredEvent
+	started := false
+	for _, e := range events {
+		switch e.Event {
+		case "actionRequired":
+			data := e.Data.(models.ActionRequiredEvent)
+			required = &data
+		case "timeBankStarted":
+			started = true
+		}
+	}
+	if !started || required == nil || !required.InTimeBank || required.TimeBank != 60 {
+		t.Errorf("Expected timeBankStarted and an actionRequired in the time bank, got %+v", required)
+	}
+
+	// Twenty seconds of reserve are used before the player calls
+	game.timeBankStart = game.timeBankStart.Add(-20 * time.Second)
+	if err := game.ProcessAction(current.PlayerID, models.ActionCall, 0); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if current.TimeBank != 40 {
+		t.Errorf("Expected 40 seconds left in the time bank, got %d", current.TimeBank)
+	}
+	if game.Snapshot().Players[current.SeatNumber].TimeBank != 40 {
+		t.Error("Expected the snapshot to show the time bank balance")
+	}
+}
+
+func TestTimeBank_ExhaustedThenAutoActs(t *testing.T) {
+	var events []models.Event
+	game, table := newTimeBankTestGame(t, &events)
+	current := table.Players[table.CurrentHand.CurrentPosition]
+
+	game.HandleTimeout(current.PlayerID, table.CurrentHand.DeadlineToken)
+	game.timeBankStart = game.timeBankStart.Add(-time.Minute)
+	if err := game.HandleTimeout(current.PlayerID, table.CurrentHand.DeadlineToken); err != nil {
+		t.Fatalf("HandleTimeout failed: %v", err)
+	}
+	if current.Status != models.StatusFolded {
+		t.Fatalf("Expected a fold once the time bank ran out, got %s", current.Status)
+	}
+	if current.TimeBank != 0 {
+		t.Errorf("Expected an empty time bank, got %d", current.TimeBank)
+	}
+
+	// With nothing left, the next timeout acts straight away
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	for table.Players[table.CurrentHand.CurrentPosition] != current {
+		other := table.Players[table.CurrentHand.CurrentPosition]
+		if err := game.ProcessAction(other.PlayerID, models.ActionCall, 0); err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+	}
+	if err := game.HandleTimeout(current.PlayerID, table.CurrentHand.DeadlineToken); err != nil {
+		t.Fatalf("HandleTimeout failed: %v", err)
+	}
+	if current.LastAction != models.ActionCheck && current.Status != models.StatusFolded {
+		t.Errorf("Expected the timeout to act for the player, got %s", current.LastAction)
+	}
+}
+
+func TestTimeBank_AwayPlayerDoesNotDraw(t *testing.T) {
+	var events []models.Event
+	game, table := newTimeBankTestGame(t, &events)
+	current := table.Players[table.CurrentHand.CurrentPosition]
+
+	game.SetPlayerAway(current.PlayerID, true)
+	if err := game.HandleTimeout(current.PlayerID, table.CurrentHand.DeadlineToken); err != nil {
+		t.Fatalf("HandleTimeout failed: %v", err)
+	}
+	if current.Status != models.StatusFolded || current.TimeBank != 60 {
+		t.Errorf("Expected an away player to fold and keep their time bank, got %s with %d", current.Status, current.TimeBank)
+	}
+}
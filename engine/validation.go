@@ -9,7 +9,8 @@ import (
 
 // TurnValidator provides comprehensive turn validation
 type TurnValidator struct {
-	table *models.Table
+	table             *models.Table
+	allowRapidActions bool // Skips the anti-spam check, for replays
 }
 
 // NewTurnValidator creates a new turn validator
@@ -52,7 +53,7 @@ func (tv *TurnValidator) ValidateTurn(playerID string) error {
 	// 3. Check for rapid-fire duplicate actions (anti-spam)
 	// This prevents the same player from acting twice in quick succession,
 	// even across round boundaries (critical for heads-up)
-	if tv.table.CurrentHand.LastActionPlayerID == playerID {
+	if !tv.allowRapidActions && tv.table.CurrentHand.LastActionPlayerID == playerID {
 		elapsed := time.Since(tv.table.CurrentHand.LastActionTime)
 		if elapsed < 100*time.Millisecond {
 			return fmt.Errorf("action too fast: %v since last action", elapsed)
@@ -76,17 +76,58 @@ func (c Card) Value() int {
 type Deck struct {
 	cards []Card
 	rng   *rand.Rand
+	seed  int64
 }
 
 func NewDeck() *Deck {
+	return NewDeckWithSeed(time.Now().UnixNano())
+}
+
+// NewDeckWithSeed creates a deck whose shuffles are fully determined by seed,
+// so a hand can be dealt again exactly as it was
+func NewDeckWithSeed(seed int64) *Deck {
 	deck := &Deck{
 		cards: make([]Card, 0, 52),
-		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:   rand.New(rand.NewSource(seed)),
+		seed:  seed,
 	}
 	deck.Reset()
 	return deck
 }
 
+// Seed returns the seed the deck was created with
+func (d *Deck) Seed() int64 {
+	return d.seed
+}
+
+// NewDeckAt recreates a seeded deck with its first dealt cards already dealt,
+// as it stood part way through a hand
+func NewDeckAt(seed int64, dealt int) (*Deck, error) {
+	deck := NewDeckWithSeed(seed)
+	if dealt < 0 || dealt > len(deck.cards) {
+		return nil, fmt.Errorf("invalid deck position %d", dealt)
+	}
+	deck.cards = deck.cards[dealt:]
+	return deck, nil
+}
+
+// Position returns how many cards have been dealt from the deck. ok is false
+// once the deck no longer follows its seed, after Remove or PutOnTop, so it
+// can't be recreated with NewDeckAt.
+func (d *Deck) Position() (dealt int, ok bool) {
+	fresh := NewDeckWithSeed(d.seed)
+	dealt = len(fresh.cards) - len(d.cards)
+	if dealt < 0 {
+		return 0, false
+	}
+	for i, c := range d.cards {
+		if fresh.cards[dealt+i] != c {
+			return dealt, false
+		}
+	}
+	return dealt, true
+}
+
 func (d *Deck) Reset() {
 	d.cards = make([]Card, 0, 52)
 	suits := []Suit{Hearts, Diamonds, Clubs, Spades}
@@ -130,6 +171,29 @@ func (d *Deck) DealMultiple(n int) ([]Card, error) {
 	return cards, nil
 }
 
+// Remove takes the given cards out of the deck
+func (d *Deck) Remove(cards []Card) {
+	kept := d.cards[:0]
+	for _, c := range d.cards {
+		removed := false
+		for _, r := range cards {
+			if c == r {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			kept = append(kept, c)
+		}
+	}
+	d.cards = kept
+}
+
+// PutOnTop places cards on top of the deck so they are dealt next, in order
+func (d *Deck) PutOnTop(cards []Card) {
+	d.cards = append(append(make([]Card, 0, len(cards)+len(d.cards)), cards...), d.cards...)
+}
+
 func (d *Deck) CardsRemaining() int {
 	return len(d.cards)
 }
@@ -18,8 +18,16 @@ type Event struct {
 }
 
 type ActionRequiredEvent struct {
-	PlayerID string `json:"playerId"`
-	Deadline string `json:"deadline"`
+	PlayerID       string         `json:"playerId"`
+	Deadline       string         `json:"deadline"`
+	TimeBank       int            `json:"timeBank,omitempty"`       // Reserve seconds the player has left
+	InTimeBank     bool           `json:"inTimeBank,omitempty"`     // The deadline is reserve time, not the base timer
+	LegalActions   []PlayerAction `json:"legalActions"`             // What the player may do: fold, check, call, raise and allin
+	CallAmount     int            `json:"callAmount,omitempty"`     // Chips the player needs to call; 0 means they can check
+	MinRaiseTo     int            `json:"minRaiseTo,omitempty"`     // Smallest total bet a bet or raise may make; 0 means the player can't raise
+	MaxRaiseTo     int            `json:"maxRaiseTo,omitempty"`     // Largest total bet a bet or raise may make
+	EffectiveStack int            `json:"effectiveStack,omitempty"` // The smaller of the player's stack and the biggest other one still in the hand, bets included
+	BettingMode    string         `json:"bettingMode,omitempty"`    // The table's betting mode; empty means no limit
 }
 
 type ActionTimeoutEvent struct {
@@ -28,7 +36,16 @@ type ActionTimeoutEvent struct {
 }
 
 type HandCompleteEvent struct {
-	Winners []Winner `json:"winners"`
+	Winners     []Winner      `json:"winners"`
+	AllInEquity []AllInEquity `json:"allInEquity,omitempty"` // Set when the hand was all-in before the river
+	Knockouts   []Knockout    `json:"knockouts,omitempty"`   // Players the hand left without chips
+}
+
+// Knockout names who knocked a player out: the winners of the pot that took
+// the last of their chips
+type Knockout struct {
+	PlayerID     string   `json:"playerId"`
+	EliminatedBy []string `json:"eliminatedBy"`
 }
 
 type BlindsIncreasedEvent struct {
@@ -17,6 +17,10 @@ const (
 	ActionRaise PlayerAction = "raise"
 	ActionCheck PlayerAction = "check"
 	ActionAllIn PlayerAction = "allin"
+
+	// ActionStraddle asks to straddle the next hand. It is only accepted
+	// between hands, from the player who will be under the gun.
+	ActionStraddle PlayerAction = "straddle"
 )
 
 type Player struct {
@@ -30,11 +34,22 @@ type Player struct {
 	IsDealer               bool         `json:"isDealer"`
 	IsSmallBlind           bool         `json:"isSmallBlind"`
 	IsBigBlind             bool         `json:"isBigBlind"`
+	IsStraddle             bool         `json:"isStraddle,omitempty"` // Posted a straddle this hand
 	LastAction             PlayerAction `json:"lastAction,omitempty"`
 	LastActionAmount       int          `json:"lastActionAmount,omitempty"`
 	TotalInvestedThisHand  int          `json:"totalInvestedThisHand"`
 	HasActedThisRound      bool         `json:"-"`
 	ConsecutiveTimeouts    int          `json:"-"` // Tracks consecutive timeouts for sit-out logic
+	MustPostBigBlind       bool         `json:"-"` // Set after a seat change; cleared once a big blind is posted
+	Away                   bool         `json:"away,omitempty"` // Client stopped responding to turns; acts on a shorter timer
+	TimeBank               int          `json:"timeBank,omitempty"` // Reserve seconds left, when the table has a time bank
+	Disconnected           bool         `json:"disconnected,omitempty"` // Connection dropped; keeps the seat and the hand until the grace period runs out
+	DisconnectGraceUsed    bool         `json:"-"` // Set once a turn has been timed with the disconnect grace
+	CalledFinalBet         bool         `json:"calledFinalBet,omitempty"` // Called the last bet or raise of the hand so far
+	Level                  int          `json:"level,omitempty"` // The player's experience level, shown to the table
+	AvatarURL              string       `json:"avatarUrl,omitempty"` // Picture shown for the player
+	IsBot                  bool         `json:"isBot,omitempty"` // A computer player the server acts for
+	ShownCards             []Card       `json:"shownCards,omitempty"` // Hole cards the player showed after winning a pot nobody contested
 }
 
 func NewPlayer(id, name string, seatNumber, chips int) *Player {
@@ -52,13 +67,16 @@ func NewPlayer(id, name string, seatNumber, chips int) *Player {
 func (p *Player) Reset() {
 	p.Bet = 0
 	p.Cards = make([]Card, 0, 2)
+	p.ShownCards = nil
 	p.IsDealer = false
 	p.IsSmallBlind = false
 	p.IsBigBlind = false
+	p.IsStraddle = false
 	p.LastAction = ""
 	p.LastActionAmount = 0
 	p.TotalInvestedThisHand = 0
 	p.HasActedThisRound = false
+	p.CalledFinalBet = false
 	if p.Status != StatusSittingOut && p.Chips > 0 {
 		p.Status = StatusActive
 	}
@@ -77,3 +95,14 @@ func (p *Player) PlaceBet(amount int) {
 	p.Bet += amount
 	p.TotalInvestedThisHand += amount
 }
+
+// Clone returns a deep copy of the player
+func (p *Player) Clone() *Player {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	clone.Cards = append([]Card(nil), p.Cards...)
+	clone.ShownCards = append([]Card(nil), p.ShownCards...)
+	return &clone
+}
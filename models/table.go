@@ -19,6 +19,12 @@ const (
 	StatusCompleted    TableStatus = "completed"
 )
 
+// Supported table sizes, from heads-up to 10-handed
+const (
+	MinSeats = 2
+	MaxSeats = 10
+)
+
 const (
 	RoundPreflop BettingRound = "preflop"
 	RoundFlop    BettingRound = "flop"
@@ -29,12 +35,60 @@ const (
 type TableConfig struct {
 	SmallBlind            int      `json:"smallBlind"`
 	BigBlind              int      `json:"bigBlind"`
+	Ante                  int      `json:"ante,omitempty"` // Posted by every player before cards are dealt
 	MaxPlayers            int      `json:"maxPlayers"`
 	MinBuyIn              int      `json:"minBuyIn,omitempty"`
 	MaxBuyIn              int      `json:"maxBuyIn,omitempty"`
 	StartingChips         int      `json:"startingChips,omitempty"`
 	BlindIncreaseInterval int      `json:"blindIncreaseInterval,omitempty"`
 	ActionTimeout         int      `json:"actionTimeout"`
+	CurrencySymbol        string   `json:"currencySymbol,omitempty"` // Shown by clients in front of amounts
+	ChipScale             int      `json:"chipScale,omitempty"`      // Chips per displayed unit, e.g. 100 to show cents; 0 means 1
+	AllowStraddle         bool     `json:"allowStraddle,omitempty"`  // Cash tables only: the player under the gun may straddle
+	TimeBank              int      `json:"timeBank,omitempty"`       // Reserve seconds each player can draw on once their action timer runs out; 0 means none
+	DisconnectGrace       int      `json:"disconnectGrace,omitempty"` // Seconds a disconnected player gets for their first turn; 0 means the usual timer
+	ShowdownPolicy        string   `json:"showdownPolicy,omitempty"`  // Whose hole cards everyone sees when a hand completes; empty means ShowdownShowAll
+	BettingMode           string   `json:"bettingMode,omitempty"`     // How much a bet or raise may be; empty means BettingNoLimit
+	ShowCardsWindow       int      `json:"showCardsWindow,omitempty"` // Seconds the winner of an uncontested pot may show their cards before the next hand; 0 means no prompt
+}
+
+// Showdown policies: whose hole cards are shown to the whole table once a
+// hand is complete. A player always sees their own cards.
+const (
+	ShowdownShowAll = "all"     // Every player who didn't fold
+	ShowdownCallers = "callers" // Players who called the last bet or raise of the hand
+	ShowdownWinners = "winners" // Players who won a pot
+	ShowdownNever   = "never"   // Nobody
+)
+
+// ValidShowdownPolicy reports whether policy is one of the showdown policies
+// or empty
+func ValidShowdownPolicy(policy string) bool {
+	switch policy {
+	case "", ShowdownShowAll, ShowdownCallers, ShowdownWinners, ShowdownNever:
+		return true
+	}
+	return false
+}
+
+// Betting modes: how much a player may bet or raise
+const (
+	BettingNoLimit    = "no_limit"    // Anything from a min raise up to all in
+	BettingPotLimit   = "pot_limit"   // Up to the size of the pot after calling
+	BettingFixedLimit = "fixed_limit" // Exactly one big blind before the turn and two from the turn on
+)
+
+// FixedLimitCap is how many bets and raises a fixed limit betting round
+// allows, the big blind counting as the first one before the flop
+const FixedLimitCap = 4
+
+// ValidBettingMode reports whether mode is one of the betting modes or empty
+func ValidBettingMode(mode string) bool {
+	switch mode {
+	case "", BettingNoLimit, BettingPotLimit, BettingFixedLimit:
+		return true
+	}
+	return false
 }
 
 type Pot struct {
@@ -52,19 +106,25 @@ type CurrentHand struct {
 	DealerPosition             int          `json:"dealerPosition"`
 	SmallBlindPosition         int          `json:"smallBlindPosition"`
 	BigBlindPosition           int          `json:"bigBlindPosition"`
+	DeadSmallBlind             bool         `json:"deadSmallBlind,omitempty"` // The small blind seat is empty or sitting out, so nobody posted it
 	CurrentPosition            int          `json:"currentPosition"`
 	BettingRound               BettingRound `json:"bettingRound"`
 	CommunityCards             []Card       `json:"communityCards"`
 	Pot                        Pot          `json:"pot"`
 	CurrentBet                 int          `json:"currentBet"`
 	MinRaise                   int          `json:"minRaise"`
+	Raises                     int          `json:"raises"` // Bets and raises this betting round; the big blind is the first preflop
 	ActionDeadline             *time.Time   `json:"actionDeadline,omitempty"`
+	DeadlineToken              uint64       `json:"deadlineToken"` // Bumped whenever a deadline starts or stops; timeouts must match it
 	ActionSequence             uint64       `json:"actionSequence"`
 	LastActionPlayerID         string       `json:"lastActionPlayerId,omitempty"`
 	LastActionTime             time.Time    `json:"lastActionTime,omitempty"`
 	HasRealActionThisRound     bool         `json:"-"` // Tracks if any non-timeout action occurred this round
 	HasRealActionThisHand      bool         `json:"-"` // Tracks if any non-timeout action occurred this entire hand
 	ConsecutiveAllTimeoutRounds int         `json:"-"` // Counts consecutive rounds where all actions were timeouts
+	DeckSeed                   int64        `json:"-"` // Seed of this hand's deck; reveals every card, so never sent to clients
+	ShowCardsPlayerID          string       `json:"showCardsPlayerId,omitempty"` // Winner of the uncontested pot who may show their cards
+	ShowCardsDeadline          *time.Time   `json:"showCardsDeadline,omitempty"` // When the winner's chance to show runs out; nil once it has closed
 }
 
 type Winner struct {
@@ -75,6 +135,15 @@ type Winner struct {
 	HandCards  []Card `json:"handCards"`
 }
 
+// AllInEquity is what a player stood to win when the betting ended with
+// cards still to come, against what the runout gave them
+type AllInEquity struct {
+	PlayerID string  `json:"playerId"`
+	Equity   float64 `json:"equity"`   // Share of the whole pot the player could expect
+	Expected float64 `json:"expected"` // Chips the player could expect to win
+	Won      int     `json:"won"`      // Chips the player did win
+}
+
 type HistoryEventType string
 
 const (
@@ -83,6 +152,7 @@ const (
 	HistoryRoundAdvanced HistoryEventType = "round_advanced"
 	HistoryHandComplete  HistoryEventType = "hand_complete"
 	HistoryShowdown      HistoryEventType = "showdown"
+	HistoryCardsShown    HistoryEventType = "cards_shown"
 )
 
 type HistoryEntry struct {
@@ -108,4 +178,118 @@ type Table struct {
 	Deck                       *Deck          `json:"-"`
 	CreatedAt                  time.Time      `json:"createdAt"`
 	ConsecutiveAllTimeoutHands int            `json:"-"` // Tracks consecutive hands where all actions were timeouts
+	Version                    uint64         `json:"version"` // Incremented each time a new state snapshot is published
+}
+
+// ShowsCards reports whether a player's hole cards are shown to the whole
+// table once the hand is complete, under the table's showdown policy. The
+// winner of a pot nobody contested keeps theirs hidden unless they show them.
+func (t *Table) ShowsCards(p *Player) bool {
+	if p == nil || p.Status == StatusFolded || len(p.Cards) == 0 {
+		return false
+	}
+	if t.uncontested() {
+		return false
+	}
+	switch t.Config.ShowdownPolicy {
+	case ShowdownCallers:
+		return p.CalledFinalBet
+	case ShowdownWinners:
+		for _, w := range t.Winners {
+			if w.PlayerID == p.PlayerID {
+				return true
+			}
+		}
+		return false
+	case ShowdownNever:
+		return false
+	}
+	return true
+}
+
+// uncontested reports whether everyone but one player folded
+func (t *Table) uncontested() bool {
+	left := 0
+	for _, p := range t.Players {
+		if p != nil && len(p.Cards) > 0 && (p.Status == StatusActive || p.Status == StatusAllIn) {
+			left++
+		}
+	}
+	return left == 1
+}
+
+// PublicWinners returns the winners as the whole table may see them: the
+// best five cards of a winner whose hole cards aren't shown are left out
+func (t *Table) PublicWinners() []Winner {
+	if t.Winners == nil {
+		return nil
+	}
+	shown := make(map[string]bool, len(t.Players))
+	for _, p := range t.Players {
+		if p != nil {
+			shown[p.PlayerID] = t.ShowsCards(p)
+		}
+	}
+	winners := make([]Winner, len(t.Winners))
+	for i, w := range t.Winners {
+		if !shown[w.PlayerID] {
+			w.HandCards = nil
+		}
+		winners[i] = w
+	}
+	return winners
+}
+
+// Clone returns a deep copy of the current hand
+func (h *CurrentHand) Clone() *CurrentHand {
+	if h == nil {
+		return nil
+	}
+	clone := *h
+	clone.CommunityCards = append([]Card(nil), h.CommunityCards...)
+	if h.ActionDeadline != nil {
+		deadline := *h.ActionDeadline
+		clone.ActionDeadline = &deadline
+	}
+	if h.ShowCardsDeadline != nil {
+		deadline := *h.ShowCardsDeadline
+		clone.ShowCardsDeadline = &deadline
+	}
+	if h.Pot.Side != nil {
+		clone.Pot.Side = make([]SidePot, len(h.Pot.Side))
+		for i, side := range h.Pot.Side {
+			clone.Pot.Side[i] = SidePot{
+				Amount:          side.Amount,
+				EligiblePlayers: append([]string(nil), side.EligiblePlayers...),
+			}
+		}
+	}
+	return &clone
+}
+
+// Clone returns a deep copy of the table suitable for read-only sharing.
+// The deck is not copied so snapshots never expose undealt cards.
+func (t *Table) Clone() *Table {
+	clone := *t
+	clone.Deck = nil
+	clone.CurrentHand = t.CurrentHand.Clone()
+
+	clone.Players = make([]*Player, len(t.Players))
+	for i, p := range t.Players {
+		clone.Players[i] = p.Clone()
+	}
+
+	if t.Winners != nil {
+		clone.Winners = make([]Winner, len(t.Winners))
+		for i, w := range t.Winners {
+			w.HandCards = append([]Card(nil), w.HandCards...)
+			clone.Winners[i] = w
+		}
+	}
+
+	// History is append-only and entries are never modified, so the clone can
+	// share the backing array; capping capacity keeps later appends invisible
+	clone.History = t.History[:len(t.History):len(t.History)]
+
+	return &clone
 }
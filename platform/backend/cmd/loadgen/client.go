@@ -0,0 +1,411 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Script decides how synthetic players act when it is their turn
+type Script struct {
+	FoldRate  float64       // Chance of folding when facing a bet
+	RaiseRate float64       // Chance of raising instead of calling
+	Think     time.Duration // Delay before acting
+}
+
+// player is one synthetic client: a user account with a WebSocket
+// connection that queues for matchmaking and plays whatever it is seated at
+type player struct {
+	id       int
+	username string
+	userID   string
+	token    string
+
+	cfg      *Config
+	http     *http.Client
+	rec      *Recorder
+	rng      *rand.Rand
+	writeMu  sync.Mutex
+	conn     *websocket.Conn
+	tableID  string
+	myBet    int
+	bigBlind int
+	status   string
+	joinedAt time.Time
+
+	// Sent actions and pings waiting for their reply, oldest first
+	pending   []time.Time
+	pingSent  time.Time
+	lastEvent time.Time
+}
+
+func newPlayer(id int, cfg *Config, httpClient *http.Client, rec *Recorder) *player {
+	return &player{
+		id:       id,
+		username: fmt.Sprintf("%s_%05d", cfg.Prefix, id),
+		cfg:      cfg,
+		http:     httpClient,
+		rec:      rec,
+		rng:      rand.New(rand.NewSource(cfg.Seed + int64(id))),
+	}
+}
+
+// run keeps the player connected and playing until ctx is done. A dropped
+// connection is counted and reopened.
+func (p *player) run(ctx context.Context, active *counter) {
+	for backoff := time.Second; ctx.Err() == nil; {
+		start := time.Now()
+		if err := p.connect(ctx); err != nil {
+			p.rec.Count(countConnectFailed, 1)
+			if p.cfg.Verbose {
+				fmt.Printf("%s: %v\n", p.username, err)
+			}
+			if !sleep(ctx, backoff) {
+				return
+			}
+			if backoff < 30*time.Second {
+				backoff *= 2
+			}
+			continue
+		}
+		backoff = time.Second
+		p.rec.Count(countConnected, 1)
+		p.rec.Observe(metricConnect, time.Since(start))
+
+		active.add(1)
+		err := p.play(ctx)
+		active.add(-1)
+		p.conn.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		p.rec.Count(countDropped, 1)
+		if p.cfg.Verbose {
+			fmt.Printf("%s: connection dropped: %v\n", p.username, err)
+		}
+		if !sleep(ctx, time.Second) {
+			return
+		}
+	}
+}
+
+// connect logs in, registering the account the first time, and opens the WebSocket
+func (p *player) connect(ctx context.Context) error {
+	if p.token == "" {
+		if err := p.login(ctx); err != nil {
+			return err
+		}
+	}
+	u, err := url.Parse(p.cfg.Server)
+	if err != nil {
+		return err
+	}
+	u.Scheme = strings.Replace(u.Scheme, "http", "ws", 1)
+	u.Path = "/ws"
+	u.RawQuery = url.Values{"token": {p.token}}.Encode()
+
+	dialer := websocket.Dialer{HandshakeTimeout: p.cfg.Timeout}
+	conn, resp, err := dialer.DialContext(ctx, u.String(), nil)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			p.token = "" // Expired; log in again next time
+		}
+		return fmt.Errorf("websocket: %w", err)
+	}
+	p.conn = conn
+	p.tableID = ""
+	p.pending = nil
+	p.pingSent = time.Time{}
+	return p.send("hello", map[string]interface{}{
+		"protocol_version": 1,
+		"features":         []string{"action_required", "action_ack"},
+	})
+}
+
+func (p *player) login(ctx context.Context) error {
+	password := p.cfg.Password
+	var auth struct {
+		Token string `json:"token"`
+		User  struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	status, err := p.post(ctx, "/api/auth/login", "", map[string]string{
+		"username": p.username, "password": password,
+	}, &auth)
+	if err == nil && status == http.StatusUnauthorized {
+		status, err = p.post(ctx, "/api/auth/register", "", map[string]string{
+			"username": p.username, "email": p.username + "@loadgen.invalid", "password": password,
+		}, &auth)
+	}
+	if err != nil {
+		return err
+	}
+	if auth.Token == "" {
+		return fmt.Errorf("login as %s failed with status %d", p.username, status)
+	}
+	p.token, p.userID = auth.Token, auth.User.ID
+	return nil
+}
+
+// post sends a JSON request and decodes the response into out
+func (p *player) post(ctx context.Context, path, token string, body, out interface{}) (int, error) {
+	data, _ := json.Marshal(body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(p.cfg.Server, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+	if out != nil && resp.StatusCode < 300 {
+		if err := json.Unmarshal(raw, out); err != nil {
+			return resp.StatusCode, err
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// joinQueue puts the player in the matchmaking queue, leaving any queue a
+// previous run left it in first
+func (p *player) joinQueue(ctx context.Context) {
+	p.tableID = ""
+	p.post(ctx, "/api/matchmaking/leave", p.token, map[string]string{}, nil)
+	p.joinedAt = time.Now()
+	status, err := p.post(ctx, "/api/matchmaking/join", p.token, map[string]string{"game_mode": p.cfg.Mode}, nil)
+	if err != nil || status != http.StatusOK {
+		p.rec.Count(countMatchmakingFail, 1)
+		p.joinedAt = time.Time{}
+	}
+	p.lastEvent = time.Now()
+}
+
+func (p *player) send(msgType string, payload interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{"type": msgType, "payload": payload})
+	if err != nil {
+		return err
+	}
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	p.conn.SetWriteDeadline(time.Now().Add(p.cfg.Timeout))
+	return p.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// message is the part of a server message the script reads
+type message struct {
+	Type    string `json:"type"`
+	Payload struct {
+		TableID    string `json:"table_id"`
+		UserID     string `json:"user_id"`
+		Status     string `json:"status"`
+		CurrentBet int    `json:"current_bet"`
+		BigBlind   int    `json:"big_blind"`
+		Players    []struct {
+			UserID     string `json:"user_id"`
+			CurrentBet int    `json:"current_bet"`
+		} `json:"players"`
+	} `json:"payload"`
+}
+
+// play reads the connection until it closes, queueing, acting and
+// measuring as messages arrive
+func (p *player) play(ctx context.Context) error {
+	messages := make(chan message, 64)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			_, data, err := p.conn.ReadMessage()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			var msg message
+			if json.Unmarshal(data, &msg) != nil {
+				continue
+			}
+			select {
+			case messages <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	p.joinQueue(ctx)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	var nextPing time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			return err
+		case now := <-ticker.C:
+			p.expire(now)
+			if now.After(nextPing) && p.pingSent.IsZero() {
+				p.pingSent = now
+				nextPing = now.Add(5 * time.Second)
+				if err := p.send("ping", nil); err != nil {
+					return err
+				}
+			}
+			// Nothing happening at the table or in the queue: start over
+			if now.Sub(p.lastEvent) > p.cfg.Idle {
+				p.joinQueue(ctx)
+			}
+		case msg := <-messages:
+			if err := p.handle(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *player) handle(ctx context.Context, msg message) error {
+	now := time.Now()
+	switch msg.Type {
+	case "pong":
+		if !p.pingSent.IsZero() {
+			p.rec.Observe(metricPing, now.Sub(p.pingSent))
+			p.pingSent = time.Time{}
+		}
+
+	case "match_found":
+		if !p.joinedAt.IsZero() {
+			p.rec.Observe(metricMatchmaking, now.Sub(p.joinedAt))
+			p.joinedAt = time.Time{}
+		}
+		p.tableID = msg.Payload.TableID
+		p.status = ""
+		p.lastEvent = now
+		return p.send("subscribe_table", map[string]interface{}{"table_id": p.tableID})
+
+	case "table_state", "game_update":
+		if msg.Payload.TableID != p.tableID {
+			return nil
+		}
+		p.lastEvent = now
+		p.bigBlind = msg.Payload.BigBlind
+		for _, seat := range msg.Payload.Players {
+			if seat.UserID == p.userID {
+				p.myBet = seat.CurrentBet
+			}
+		}
+		if msg.Payload.Status == "handComplete" && p.status != "handComplete" {
+			p.rec.Count(countHands, 1)
+		}
+		p.status = msg.Payload.Status
+
+	case "action_required":
+		if msg.Payload.UserID != p.userID || msg.Payload.TableID != p.tableID {
+			return nil
+		}
+		p.lastEvent = now
+		if err := p.send("action_ack", map[string]interface{}{"table_id": p.tableID}); err != nil {
+			return err
+		}
+		if !sleep(ctx, p.cfg.Script.Think) {
+			return nil
+		}
+		return p.act(msg.Payload.CurrentBet)
+
+	case "action_confirmed":
+		if msg.Payload.UserID == p.userID && len(p.pending) > 0 {
+			p.rec.Observe(metricAction, now.Sub(p.pending[0]))
+			p.pending = p.pending[1:]
+		}
+
+	case "game_complete":
+		p.rec.Count(countGames, 1)
+		p.joinQueue(ctx)
+
+	case "error":
+		p.rec.Count(countServerErrors, 1)
+	}
+	return nil
+}
+
+// act plays the script: fold to some bets, raise some of the time, and
+// otherwise call, which checks when there is nothing to call
+func (p *player) act(currentBet int) error {
+	action, amount := "call", 0
+	roll := p.rng.Float64()
+	switch {
+	case currentBet > p.myBet && roll < p.cfg.Script.FoldRate:
+		action = "fold"
+	case roll > 1-p.cfg.Script.RaiseRate:
+		action, amount = "raise", 2*currentBet+p.bigBlind
+	}
+	p.pending = append(p.pending, time.Now())
+	p.rec.Count(countActions, 1)
+	return p.send("game_action", map[string]interface{}{
+		"action":     action,
+		"amount":     amount,
+		"request_id": fmt.Sprintf("%s-%d", p.username, time.Now().UnixNano()),
+	})
+}
+
+// expire gives up on actions and pings that went unanswered for too long.
+// The server confirms only accepted actions, so refused ones end up here too.
+func (p *player) expire(now time.Time) {
+	for len(p.pending) > 0 && now.Sub(p.pending[0]) > p.cfg.Timeout {
+		p.rec.Count(countUnconfirmed, 1)
+		p.pending = p.pending[1:]
+	}
+	if !p.pingSent.IsZero() && now.Sub(p.pingSent) > p.cfg.Timeout {
+		p.pingSent = time.Time{}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// counter is a gauge shared by all players
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c *counter) add(delta int) {
+	c.mu.Lock()
+	c.n += delta
+	c.mu.Unlock()
+}
+
+func (c *counter) get() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
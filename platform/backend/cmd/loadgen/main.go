@@ -0,0 +1,140 @@
+// Command loadgen drives a running server with synthetic players to find how
+// many connections and tables it holds up under. Each player logs in as
+// <prefix>_<n>, registering the account the first time, opens a WebSocket,
+// queues for matchmaking and plays scripted hands at whatever table it is
+// seated at, queueing again when the game ends.
+//
+// Clients are added in steps, and each step reports connection failures and
+// drops, unconfirmed actions, and latency percentiles for connecting,
+// matchmaking, actions and pings:
+//
+//	go run ./cmd/loadgen -server http://localhost:8080 -clients 500,1000,2000 -step 2m
+//
+// Run it against a test deployment only: it creates accounts and plays with
+// their chips. MATCHMAKING_COUNTDOWN_SECONDS=1 on the server keeps tables
+// from idling between games.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Config holds the load test settings
+type Config struct {
+	Server   string        // Base URL of the server's HTTP API
+	Steps    []int         // Total clients at each step
+	Step     time.Duration // How long each step runs once its clients are started
+	Ramp     int           // Clients started per second
+	Mode     string        // Matchmaking preset to queue for
+	Prefix   string        // Username prefix of the synthetic accounts
+	Password string        // Password of the synthetic accounts
+	Timeout  time.Duration // Longest wait for a reply before it counts as dropped
+	Idle     time.Duration // Quiet time after which a player queues again
+	Seed     int64         // Seed of the scripted decisions
+	Script   Script
+	Verbose  bool
+}
+
+func main() {
+	cfg := &Config{}
+	var steps string
+	flag.StringVar(&cfg.Server, "server", "http://localhost:8080", "server base URL")
+	flag.StringVar(&steps, "clients", "100", "comma-separated client totals, one per step")
+	flag.DurationVar(&cfg.Step, "step", time.Minute, "how long each step runs after its clients are started")
+	flag.IntVar(&cfg.Ramp, "ramp", 50, "clients started per second")
+	flag.StringVar(&cfg.Mode, "mode", "headsup", "matchmaking preset: headsup, 3player, 6max or 9max")
+	flag.StringVar(&cfg.Prefix, "prefix", "loadgen", "username prefix of the synthetic accounts")
+	flag.StringVar(&cfg.Password, "password", "Loadgen2026", "password of the synthetic accounts")
+	flag.DurationVar(&cfg.Timeout, "timeout", 10*time.Second, "wait for a reply before counting it as dropped")
+	flag.DurationVar(&cfg.Idle, "idle", time.Minute, "queue again after this long without table or queue activity")
+	flag.Int64Var(&cfg.Seed, "seed", 1, "seed of the scripted decisions")
+	flag.Float64Var(&cfg.Script.FoldRate, "fold", 0.15, "chance of folding when facing a bet")
+	flag.Float64Var(&cfg.Script.RaiseRate, "raise", 0.1, "chance of raising instead of calling")
+	flag.DurationVar(&cfg.Script.Think, "think", 200*time.Millisecond, "delay before acting")
+	flag.BoolVar(&cfg.Verbose, "v", false, "log each connection failure and drop")
+	flag.Parse()
+
+	var err error
+	if cfg.Steps, err = parseSteps(steps); err != nil {
+		log.Fatalf("loadgen: %v", err)
+	}
+	if cfg.Ramp <= 0 {
+		log.Fatal("loadgen: -ramp must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	run(ctx, cfg)
+}
+
+// parseSteps reads the client totals of the steps, which must grow
+func parseSteps(s string) ([]int, error) {
+	var steps []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid client count %q", field)
+		}
+		if len(steps) > 0 && n <= steps[len(steps)-1] {
+			return nil, fmt.Errorf("client counts must grow from step to step, got %d after %d", n, steps[len(steps)-1])
+		}
+		steps = append(steps, n)
+	}
+	return steps, nil
+}
+
+func run(ctx context.Context, cfg *Config) {
+	rec := NewRecorder()
+	httpClient := &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        1000,
+			MaxIdleConnsPerHost: 1000,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+	playCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	var active counter
+
+	started := 0
+	for i, target := range cfg.Steps {
+		fmt.Printf("step %d/%d: starting %d clients (%d in all)\n", i+1, len(cfg.Steps), target-started, target)
+		rec.Snapshot() // The step is measured from here
+		ticker := time.NewTicker(time.Second / time.Duration(cfg.Ramp))
+		for started < target && ctx.Err() == nil {
+			started++
+			p := newPlayer(started, cfg, httpClient, rec)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p.run(playCtx, &active)
+			}()
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+			}
+		}
+		ticker.Stop()
+
+		sleep(ctx, cfg.Step)
+		rec.Snapshot().Print(os.Stdout, i+1, len(cfg.Steps), started, active.get())
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	cancel()
+	wg.Wait()
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Latency metrics
+const (
+	metricConnect     = "connect"     // Login to WebSocket open
+	metricMatchmaking = "matchmaking" // Joining the queue to match_found
+	metricAction      = "action"      // game_action to action_confirmed
+	metricPing        = "ping"        // ping to pong
+)
+
+var metricOrder = []string{metricConnect, metricMatchmaking, metricAction, metricPing}
+
+// Event counters
+const (
+	countConnected       = "connected"
+	countConnectFailed   = "connect_failed"
+	countDropped         = "dropped"
+	countActions         = "actions"
+	countUnconfirmed     = "unconfirmed"
+	countHands           = "hands"
+	countGames           = "games"
+	countServerErrors    = "server_errors"
+	countMatchmakingFail = "matchmaking_failed"
+)
+
+// Recorder collects latency samples and counts for the current step
+type Recorder struct {
+	mu      sync.Mutex
+	samples map[string][]time.Duration
+	counts  map[string]int
+	since   time.Time
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		samples: make(map[string][]time.Duration),
+		counts:  make(map[string]int),
+		since:   time.Now(),
+	}
+}
+
+// Observe records one latency sample
+func (r *Recorder) Observe(metric string, d time.Duration) {
+	r.mu.Lock()
+	r.samples[metric] = append(r.samples[metric], d)
+	r.mu.Unlock()
+}
+
+// Count adds n to a counter
+func (r *Recorder) Count(name string, n int) {
+	r.mu.Lock()
+	r.counts[name] += n
+	r.mu.Unlock()
+}
+
+// Snapshot returns what was recorded since the last snapshot and starts afresh
+func (r *Recorder) Snapshot() Snapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	snap := Snapshot{
+		Elapsed: now.Sub(r.since),
+		Counts:  r.counts,
+		Latency: make(map[string]Summary, len(r.samples)),
+	}
+	for metric, samples := range r.samples {
+		snap.Latency[metric] = Summarize(samples)
+	}
+	r.samples = make(map[string][]time.Duration)
+	r.counts = make(map[string]int)
+	r.since = now
+	return snap
+}
+
+// Summary holds the percentiles of a set of latency samples
+type Summary struct {
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// Summarize computes nearest-rank percentiles. It sorts samples in place.
+func Summarize(samples []time.Duration) Summary {
+	if len(samples) == 0 {
+		return Summary{}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return Summary{
+		Count: len(samples),
+		P50:   percentile(samples, 50),
+		P90:   percentile(samples, 90),
+		P99:   percentile(samples, 99),
+		Max:   samples[len(samples)-1],
+	}
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Snapshot is what a recorder collected over one step
+type Snapshot struct {
+	Elapsed time.Duration
+	Counts  map[string]int
+	Latency map[string]Summary
+}
+
+// rate returns part as a percentage of whole
+func rate(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(whole)
+}
+
+// Print writes the step report
+func (s Snapshot) Print(w io.Writer, step, steps, clients, active int) {
+	c := s.Counts
+	seconds := s.Elapsed.Seconds()
+	attempts := c[countConnected] + c[countConnectFailed]
+
+	fmt.Fprintf(w, "\nstep %d/%d: %d clients, %d connected, over %s\n", step, steps, clients, active, s.Elapsed.Round(time.Second))
+	fmt.Fprintf(w, "  connections  %d opened, %d failed (%.2f%%), %d dropped (%.2f%%)\n",
+		c[countConnected], c[countConnectFailed], rate(c[countConnectFailed], attempts),
+		c[countDropped], rate(c[countDropped], active+c[countDropped]))
+	fmt.Fprintf(w, "  actions      %d sent (%.1f/s), %d unconfirmed (%.2f%%)\n",
+		c[countActions], float64(c[countActions])/seconds, c[countUnconfirmed], rate(c[countUnconfirmed], c[countActions]))
+	fmt.Fprintf(w, "  play         %d player hands (%.1f/s), %d games, %d failed joins, %d server errors\n",
+		c[countHands], float64(c[countHands])/seconds, c[countGames], c[countMatchmakingFail], c[countServerErrors])
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "  latency\tcount\tp50\tp90\tp99\tmax\t")
+	for _, metric := range metricOrder {
+		l := s.Latency[metric]
+		fmt.Fprintf(tw, "  %s\t%d\t%s\t%s\t%s\t%s\t\n", metric, l.Count,
+			ms(l.P50), ms(l.P90), ms(l.P99), ms(l.Max))
+	}
+	tw.Flush()
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	var samples []time.Duration
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	s := Summarize(samples)
+	if s.Count != 100 || s.P50 != 50*time.Millisecond || s.P90 != 90*time.Millisecond ||
+		s.P99 != 99*time.Millisecond || s.Max != 100*time.Millisecond {
+		t.Errorf("Unexpected summary %+v", s)
+	}
+	if s := Summarize([]time.Duration{7 * time.Millisecond}); s.P50 != 7*time.Millisecond || s.P99 != 7*time.Millisecond {
+		t.Errorf("Expected a single sample to be every percentile, got %+v", s)
+	}
+	if s := Summarize(nil); s.Count != 0 {
+		t.Errorf("Expected an empty summary, got %+v", s)
+	}
+}
+
+func TestRecorder_SnapshotStartsAfresh(t *testing.T) {
+	rec := NewRecorder()
+	rec.Observe(metricAction, time.Millisecond)
+	rec.Count(countActions, 2)
+
+	first := rec.Snapshot()
+	if first.Counts[countActions] != 2 || first.Latency[metricAction].Count != 1 {
+		t.Errorf("Expected the recorded action, got %+v", first)
+	}
+	if second := rec.Snapshot(); second.Counts[countActions] != 0 || second.Latency[metricAction].Count != 0 {
+		t.Errorf("Expected an empty second snapshot, got %+v", second)
+	}
+}
+
+func TestParseSteps(t *testing.T) {
+	steps, err := parseSteps("500, 1000,2000")
+	if err != nil || len(steps) != 3 || steps[2] != 2000 {
+		t.Errorf("Expected three steps, got %v (%v)", steps, err)
+	}
+	for _, bad := range []string{"", "100,50", "0", "abc"} {
+		if _, err := parseSteps(bad); err == nil {
+			t.Errorf("Expected %q to be refused", bad)
+		}
+	}
+}
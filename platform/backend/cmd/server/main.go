@@ -1,11 +1,28 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"poker-platform/backend/internal/audit"
+	"poker-platform/backend/internal/avatar"
+	"poker-platform/backend/internal/chaos"
+	"poker-platform/backend/internal/club"
+	"poker-platform/backend/internal/cluster"
 	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/fairness"
+	"poker-platform/backend/internal/freeze"
+	"poker-platform/backend/internal/leaderboard"
 	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/recovery"
 	redisClient "poker-platform/backend/internal/redis"
 	"poker-platform/backend/internal/server/config"
 	"poker-platform/backend/internal/middleware"
@@ -16,12 +33,14 @@ import (
 	"poker-platform/backend/internal/server/matchmaking"
 	serverTournament "poker-platform/backend/internal/server/tournament"
 	"poker-platform/backend/internal/server/websocket"
+	"poker-platform/backend/internal/tournament"
 	"poker-platform/backend/internal/validation"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 
+	"poker-engine/engine"
 	pokerModels "poker-engine/models"
 )
 
@@ -29,6 +48,8 @@ var (
 	appConfig         *config.AppConfig
 	bridge            *game.GameBridge
 	actionRateLimiter *middleware.WebSocketActionLimiter
+	broadcastThrottle *websocket.BroadcastThrottle
+	equityCalculator  *engine.EquityCalculator
 )
 
 func main() {
@@ -70,6 +91,54 @@ func main() {
 
 	// Initialize game bridge
 	bridge = game.NewGameBridge()
+	bridge.Spectators = game.NewSpectatorHub(game.SpectatorDelayLoader(appConfig.Database), bridge.DeliverToSpectators)
+	bridge.Fairness = fairness.NewAnalyzer(fairness.DefaultConfig)
+	bridge.Fairness.Start(appConfig.Database.DB)
+	defer bridge.Fairness.Stop()
+	bridge.Leaderboards = appConfig.Leaderboards
+	bridge.Progression = appConfig.Progression
+	bridge.Missions = appConfig.Missions
+	bridge.Referrals = appConfig.Referrals
+	bridge.Profiles = appConfig.Profiles
+	bridge.Avatars = appConfig.Avatars
+	bridge.Bots = game.NewBotPlayers(appConfig.Bots, actForBot)
+	bridge.HandStates = game.NewHandStateStore(appConfig.Database.DB)
+	appConfig.Leaderboards.SetOnTopChange(func(boardType, period string, window leaderboard.Window, top []leaderboard.Entry) {
+		game.SendLeaderboardUpdate(bridge, boardType, period, window, top)
+	})
+
+	// With CLUSTER_MODE on, backend instances sharing the database and Redis
+	// share the tables: each runs the tables it owns and forwards the rest
+	stopCluster := make(chan struct{})
+	clusterCtx, cancelCluster := context.WithCancel(context.Background())
+	defer cancelCluster()
+	if config.GetEnv("CLUSTER_MODE", "false") == "true" {
+		bridge.Cluster = cluster.NewNode(appConfig.LockManager.InstanceID(),
+			cluster.NewRedisTransport(appConfig.Redis.Client), cluster.NewLockOwners(appConfig.LockManager))
+		bridge.Cluster.Handle(handleClusterEnvelope)
+		go func() {
+			if err := bridge.Cluster.Run(clusterCtx); err != nil {
+				log.Printf("[CLUSTER] ERROR: Stopped receiving from other instances: %v", err)
+			}
+		}()
+		log.Printf("[CLUSTER] Running as instance %s", bridge.Cluster.ID())
+	}
+
+	equityCalculator = engine.NewEquityCalculator(engine.DefaultEquitySamples, 1000)
+
+	// With HOLE_CARD_AUDIT on, every message for a client is checked for other
+	// players' hidden hole cards. It is meant for test and staging runs.
+	if config.GetEnv("HOLE_CARD_AUDIT", "false") == "true" {
+		if config.GetEnv("ENV", "development") == "production" {
+			log.Println("[LEAK_AUDIT] Not auditing hole cards in production")
+		} else {
+			websocket.SetLeakAudit(true)
+			log.Println("[LEAK_AUDIT] Auditing every outgoing message for hidden hole cards")
+		}
+	}
+
+	// State broadcasts triggered by engine events are coalesced per table
+	broadcastThrottle = websocket.NewBroadcastThrottle(appConfig.BroadcastThrottle, broadcastTableStateWrapper)
 
 	// Initialize rate limiter for game actions
 	actionRateLimiter = middleware.NewWebSocketActionLimiter()
@@ -80,25 +149,21 @@ func main() {
 		change := newBalance - oldBalance
 		
 		// Broadcast balance update to the specific user
-		bridge.Mu.RLock()
-		clientInterface, exists := bridge.Clients[userID]
-		bridge.Mu.RUnlock()
-
-		if exists {
+		for _, clientInterface := range bridge.Clients.Of(userID) {
 			if client, ok := clientInterface.(*websocket.Client); ok {
 				websocket.SendToClient(client, websocket.WSMessage{
 					Type: "balance_update",
-					Payload: map[string]interface{}{
-						"user_id":     userID,
-						"old_balance": oldBalance,
-						"new_balance": newBalance,
-						"change":      change,
-						"reason":      reason,
+					Payload: eventschema.BalanceUpdatePayload{
+						UserID:     userID,
+						OldBalance: oldBalance,
+						NewBalance: newBalance,
+						Change:     change,
+						Reason:     reason,
 					},
 				})
-				log.Printf("[BALANCE_UPDATE] User %s: %d → %d (change: %+d) - %s", userID, oldBalance, newBalance, change, reason)
 			}
 		}
+		log.Printf("[BALANCE_UPDATE] User %s: %d → %d (change: %+d) - %s", userID, oldBalance, newBalance, change, reason)
 	})
 
 	// Setup tournament callbacks
@@ -109,6 +174,22 @@ func main() {
 
 	// Recover active tables from database
 	recoverTables()
+	game.RestoreCurrentHandIDs(bridge, appConfig.Database)
+	restoreFreezes()
+	restoreBots()
+	if bridge.Cluster != nil {
+		go game.RunOwnership(bridge, stopCluster)
+		go adoptTables(stopCluster)
+	}
+
+	// Send scheduled announcements when their time comes
+	stopAnnouncements := make(chan struct{})
+	go game.RunAnnouncements(bridge, appConfig.Database, stopAnnouncements)
+
+	// Close tables and end tournaments at their scheduled times
+	stopDeadlines := make(chan struct{})
+	go game.RunTableClosures(bridge, appConfig.Database, stopDeadlines)
+	go serverTournament.RunDeadlines(appConfig.TournamentService, appConfig.Database, bridge, stopDeadlines)
 
 	// Set Gin mode based on environment
 	if config.GetEnv("ENV", "development") == "production" {
@@ -133,18 +214,93 @@ func main() {
 	// Setup routes
 	setupRoutes(r)
 
+	// Fault injection controls, only in binaries built with -tags chaos
+	chaos.RegisterRoutes(r)
+
 	port := config.GetEnv("SERVER_PORT", "8080")
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(r.Run(":" + port))
+	srv := &http.Server{Addr: ":" + port, Handler: r}
+
+	go func() {
+		log.Printf("Server starting on port %s", port)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("Server failed:", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down server...")
+	close(stopAnnouncements)
+	close(stopDeadlines)
+	close(stopCluster)
+	shutdown(srv)
+}
+
+// shutdownNoticeGrace is how long clients get to receive server_restarting
+// before their connections are closed
+const shutdownNoticeGrace = 500 * time.Millisecond
+
+// shutdown stops the engine tables and saves them so recovery carries on
+// where they stopped, tells clients the server is restarting so they reconnect
+// instead of showing an error, and then stops the HTTP server
+func shutdown(srv *http.Server) {
+	states := game.PauseAllTables(bridge)
+	snapshots := make([]recovery.TableSnapshot, 0, len(states))
+	inFlight := 0
+	for _, state := range states {
+		snapshot := recovery.NewTableSnapshot(state)
+		if snapshot.InFlightHand != nil {
+			inFlight++
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	failed, err := recovery.NewTableRecovery(appConfig.Database.DB).SaveTables(snapshots)
+	if err != nil {
+		log.Printf("[SHUTDOWN] ERROR: Failed to save %d of %d tables %v: %v", len(failed), len(snapshots), failed, err)
+	}
+	// Hands whose state is saved carry on after the restart; the rest are void
+	carried, stateFailed := game.SaveHandStates(bridge)
+	if len(stateFailed) > 0 {
+		log.Printf("[SHUTDOWN] ERROR: Failed to save the hand state of tables %v", stateFailed)
+	}
+	voided := inFlight - carried
+	log.Printf("[SHUTDOWN] Saved %d tables, %d hands in progress saved, %d voided", len(snapshots)-len(failed), carried, voided)
+	// The saved tables can now be picked up by the other instances
+	if bridge.Cluster != nil {
+		bridge.Cluster.ReleaseAll()
+	}
+
+	notified := websocket.NotifyAll(bridge.Clients, websocket.WSMessage{
+		Type: "server_restarting",
+		Payload: eventschema.ServerRestartingPayload{
+			Message:    "The server is restarting. You will be reconnected shortly.",
+			HandVoided: voided > 0,
+		},
+	})
+	if notified > 0 {
+		time.Sleep(shutdownNoticeGrace)
+	}
+	websocket.DisconnectAll(bridge.Clients, websocket.CloseServerRestart, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("Server shutdown error: %v", err)
+	}
 }
 
 func setupRoutes(r *gin.Engine) {
 	// Public routes
+	if local, ok := appConfig.Avatars.Storage().(*avatar.LocalStorage); ok {
+		r.Static(local.Prefix, local.Dir)
+	}
 	r.POST("/api/auth/register", func(c *gin.Context) {
-		handlers.HandleRegister(c, appConfig.Database, appConfig.AuthService)
+		handlers.HandleRegister(c, appConfig.Database, appConfig.AuthService, appConfig.Moderation, appConfig.Referrals)
 	})
 	r.POST("/api/auth/login", func(c *gin.Context) {
-		handlers.HandleLogin(c, appConfig.Database, appConfig.AuthService)
+		handlers.HandleLogin(c, appConfig.Database, appConfig.AuthService, appConfig.AuditStore)
 	})
 
 	// Protected routes
@@ -155,10 +311,46 @@ func setupRoutes(r *gin.Engine) {
 		authorized.GET("/api/user", func(c *gin.Context) {
 			handlers.HandleGetCurrentUser(c, appConfig.Database)
 		})
+		authorized.GET("/api/announcements", func(c *gin.Context) {
+			handlers.HandleGetActiveAnnouncements(c, appConfig.Database)
+		})
+		authorized.PUT("/api/user/preferences", func(c *gin.Context) {
+			handlers.HandleUpdatePreferences(c, appConfig.Database)
+		})
+		authorized.GET("/api/user/timeline", func(c *gin.Context) {
+			handlers.HandleGetUserTimeline(c, appConfig.Timeline)
+		})
+		authorized.GET("/api/user/tickets", func(c *gin.Context) {
+			serverTournament.HandleListTickets(c, appConfig.TournamentService)
+		})
+		authorized.GET("/api/user/progress", func(c *gin.Context) {
+			handlers.HandleGetProgress(c, appConfig.Progression)
+		})
+		authorized.GET("/api/user/missions", func(c *gin.Context) {
+			handlers.HandleGetMissions(c, appConfig.Missions)
+		})
+		authorized.GET("/api/user/referrals", func(c *gin.Context) {
+			handlers.HandleGetReferrals(c, appConfig.Referrals)
+		})
+		authorized.PUT("/api/user/profile/privacy", func(c *gin.Context) {
+			handlers.HandleUpdateProfilePrivacy(c, appConfig.Profiles)
+		})
+		authorized.GET("/api/players/:username/profile", func(c *gin.Context) {
+			handlers.HandleGetPlayerProfile(c, appConfig.Profiles)
+		})
+		authorized.POST("/api/user/avatar", func(c *gin.Context) {
+			handlers.HandleUploadAvatar(c, appConfig.Avatars, bridge.SetPlayerAvatar)
+		})
+		authorized.DELETE("/api/user/avatar", func(c *gin.Context) {
+			handlers.HandleDeleteAvatar(c, appConfig.Avatars, bridge.SetPlayerAvatar)
+		})
+		authorized.POST("/api/players/:username/avatar/report", func(c *gin.Context) {
+			handlers.HandleReportAvatar(c, appConfig.Avatars)
+		})
 
 		// Table routes
 		authorized.GET("/api/tables", func(c *gin.Context) {
-			handlers.HandleGetTables(c, appConfig.Database)
+			handlers.HandleGetTables(c, appConfig.Database, bridge)
 		})
 		authorized.GET("/api/tables/active", func(c *gin.Context) {
 			handlers.HandleGetActiveTables(c, appConfig.Database)
@@ -167,10 +359,51 @@ func setupRoutes(r *gin.Engine) {
 			handlers.HandleGetPastTables(c, appConfig.Database)
 		})
 		authorized.POST("/api/tables", func(c *gin.Context) {
-			handlers.HandleCreateTable(c, appConfig.Database, createEngineTableWrapper)
+			handlers.HandleCreateTable(c, appConfig.Database, appConfig.Moderation, appConfig.Quotas, createEngineTableWrapper)
+		})
+		authorized.PUT("/api/tables/:id/spectator-delay", func(c *gin.Context) {
+			handlers.HandleSetSpectatorDelay(c, appConfig.Database, bridge.Spectators)
+		})
+		// Creator controls for cash tables
+		authorized.POST("/api/tables/:id/kick", func(c *gin.Context) {
+			handlers.HandleCreatorKick(c, appConfig.Database, bridge, broadcastTableStateWrapper)
+		})
+		authorized.POST("/api/tables/:id/pause", func(c *gin.Context) {
+			handlers.HandleCreatorPause(c, appConfig.Database, bridge, broadcastTableStateWrapper)
+		})
+		authorized.POST("/api/tables/:id/resume", func(c *gin.Context) {
+			handlers.HandleCreatorResume(c, appConfig.Database, bridge, broadcastTableStateWrapper)
+		})
+		authorized.POST("/api/tables/:id/add-bot", func(c *gin.Context) {
+			handlers.HandleAddBot(c, appConfig.Database, bridge, addPlayerToEngineWrapper)
+		})
+		authorized.POST("/api/tables/:id/close", func(c *gin.Context) {
+			handlers.HandleCreatorClose(c, appConfig.Database, bridge)
 		})
 		authorized.POST("/api/tables/:id/join", func(c *gin.Context) {
-			handlers.HandleJoinTable(c, appConfig.Database, addPlayerToEngineWrapper)
+			handlers.HandleJoinTable(c, appConfig.Database, checkSessionLimitsWrapper, addPlayerToEngineWrapper)
+		})
+
+		authorized.POST("/api/tables/:id/rebuy", func(c *gin.Context) {
+			handlers.HandleRebuy(c, appConfig.Database, appConfig.CurrencyService, bridge.GetTable, broadcastTableStateWrapper)
+		})
+
+		authorized.GET("/api/tables/:id/session-results", func(c *gin.Context) {
+			handlers.HandleGetSessionResults(c, appConfig.Database)
+		})
+
+		// Table chat; messages themselves are sent over the WebSocket
+		authorized.GET("/api/tables/:id/chat", func(c *gin.Context) {
+			handlers.HandleGetTableChat(c, appConfig.Chat)
+		})
+		authorized.GET("/api/chat/ignores", func(c *gin.Context) {
+			handlers.HandleListChatIgnores(c, appConfig.Chat)
+		})
+		authorized.POST("/api/chat/ignores", func(c *gin.Context) {
+			handlers.HandleIgnoreUser(c, appConfig.Chat)
+		})
+		authorized.DELETE("/api/chat/ignores/:userId", func(c *gin.Context) {
+			handlers.HandleUnignoreUser(c, appConfig.Chat)
 		})
 
 		// History routes
@@ -189,7 +422,13 @@ func setupRoutes(r *gin.Engine) {
 
 		// Matchmaking routes
 		authorized.POST("/api/matchmaking/join", func(c *gin.Context) {
-			matchmaking.HandleJoinMatchmaking(c, appConfig.Database, bridge, processMatchmakingWrapper)
+			matchmaking.HandleJoinMatchmaking(c, appConfig.Database, bridge, checkSessionLimitsWrapper, processMatchmakingWrapper)
+		})
+		authorized.GET("/api/session/summary", func(c *gin.Context) {
+			handlers.HandleGetSessionSummary(c, appConfig.Database, bridge, appConfig.SessionLimits)
+		})
+		authorized.GET("/api/matchmaking/presets", func(c *gin.Context) {
+			matchmaking.HandleGetMatchmakingPresets(c)
 		})
 		authorized.GET("/api/matchmaking/status", func(c *gin.Context) {
 			matchmaking.HandleMatchmakingStatus(c, appConfig.Database, bridge)
@@ -198,18 +437,58 @@ func setupRoutes(r *gin.Engine) {
 			matchmaking.HandleLeaveMatchmaking(c, appConfig.Database, bridge)
 		})
 
+		// Range equity analysis for training and hand review
+		authorized.POST("/api/analysis/equity", func(c *gin.Context) {
+			handlers.HandleCalculateEquity(c, equityCalculator)
+		})
+
+		// Practice tables dealing an exact spot
+		authorized.POST("/api/practice", func(c *gin.Context) {
+			handlers.HandleCreatePracticeTable(c, bridge.Practice)
+		})
+		authorized.GET("/api/practice/:id", func(c *gin.Context) {
+			handlers.HandleGetPracticeTable(c, bridge.Practice)
+		})
+		authorized.POST("/api/practice/:id/action", func(c *gin.Context) {
+			handlers.HandlePracticeAction(c, bridge.Practice)
+		})
+		authorized.DELETE("/api/practice/:id", func(c *gin.Context) {
+			handlers.HandleClosePracticeTable(c, bridge.Practice)
+		})
+
 		// Tournament routes
 		authorized.POST("/api/tournaments", func(c *gin.Context) {
-			serverTournament.HandleCreateTournament(c, appConfig.TournamentService, bridge)
+			serverTournament.HandleCreateTournament(c, appConfig.TournamentService, appConfig.Moderation, appConfig.Quotas, bridge)
 		})
 		authorized.GET("/api/tournaments", func(c *gin.Context) {
 			serverTournament.HandleListTournaments(c, appConfig.TournamentService)
 		})
+		authorized.POST("/api/tournaments/simulate", func(c *gin.Context) {
+			serverTournament.HandleSimulateTournament(c)
+		})
+		authorized.POST("/api/tournaments/structures/validate", func(c *gin.Context) {
+			serverTournament.HandleValidateStructure(c)
+		})
+		authorized.GET("/api/tournaments/presets", func(c *gin.Context) {
+			serverTournament.HandleListPresets(c, appConfig.TournamentService)
+		})
+		authorized.POST("/api/tournaments/presets", func(c *gin.Context) {
+			serverTournament.HandleCreatePreset(c, appConfig.TournamentService)
+		})
+		authorized.GET("/api/tournaments/presets/:presetId", func(c *gin.Context) {
+			serverTournament.HandleGetPreset(c, appConfig.TournamentService)
+		})
+		authorized.PUT("/api/tournaments/presets/:presetId", func(c *gin.Context) {
+			serverTournament.HandleUpdatePreset(c, appConfig.TournamentService)
+		})
+		authorized.DELETE("/api/tournaments/presets/:presetId", func(c *gin.Context) {
+			serverTournament.HandleDeletePreset(c, appConfig.TournamentService)
+		})
 		authorized.GET("/api/tournaments/:id", func(c *gin.Context) {
 			serverTournament.HandleGetTournament(c, appConfig.TournamentService)
 		})
 		authorized.POST("/api/tournaments/:id/register", func(c *gin.Context) {
-			serverTournament.HandleRegisterTournament(c, appConfig.TournamentService, broadcastTournamentUpdateWrapper)
+			serverTournament.HandleRegisterTournament(c, appConfig.TournamentService, appConfig.TournamentStarter, broadcastTournamentUpdateWrapper)
 		})
 		authorized.POST("/api/tournaments/:id/unregister", func(c *gin.Context) {
 			serverTournament.HandleUnregisterTournament(c, appConfig.TournamentService, broadcastTournamentUpdateWrapper)
@@ -218,7 +497,7 @@ func setupRoutes(r *gin.Engine) {
 			serverTournament.HandleCancelTournament(c, appConfig.TournamentService, broadcastTournamentUpdateWrapper)
 		})
 		authorized.GET("/api/tournaments/:id/players", func(c *gin.Context) {
-			serverTournament.HandleGetTournamentPlayers(c, appConfig.Database, appConfig.TournamentService)
+			serverTournament.HandleGetTournamentPlayers(c, appConfig.Database, appConfig.TournamentService, appConfig.Avatars)
 		})
 		authorized.POST("/api/tournaments/:id/start", func(c *gin.Context) {
 			serverTournament.HandleStartTournament(c, appConfig.TournamentStarter, initializeTournamentTablesWrapper, broadcastTournamentStartedWrapper)
@@ -229,17 +508,191 @@ func setupRoutes(r *gin.Engine) {
 		authorized.POST("/api/tournaments/:id/resume", func(c *gin.Context) {
 			serverTournament.HandleResumeTournament(c, appConfig.TournamentService, resumeTournamentTablesWrapper, broadcastTournamentResumedWrapper)
 		})
+		authorized.PUT("/api/tournaments/:id/broadcast", func(c *gin.Context) {
+			serverTournament.HandleSetBroadcastDelay(c, appConfig.TournamentService, appConfig.Database, bridge)
+		})
+		authorized.PUT("/api/tournaments/:id/seating-plan", func(c *gin.Context) {
+			serverTournament.HandleSetSeatingPlan(c, appConfig.TournamentService)
+		})
+		authorized.POST("/api/tournaments/:id/final-table/hold", func(c *gin.Context) {
+			serverTournament.HandleHoldFinalTable(c, appConfig.TournamentService, bridge)
+		})
+		authorized.POST("/api/tournaments/:id/final-table/release", func(c *gin.Context) {
+			serverTournament.HandleReleaseFinalTable(c, appConfig.TournamentService, bridge)
+		})
 		authorized.GET("/api/tournaments/:id/prizes", func(c *gin.Context) {
 			serverTournament.HandleGetTournamentPrizes(c, appConfig.PrizeDistributor)
 		})
 		authorized.GET("/api/tournaments/:id/standings", func(c *gin.Context) {
-			serverTournament.HandleGetTournamentStandings(c, appConfig.EliminationTracker)
+			serverTournament.HandleGetTournamentStandings(c, appConfig.EliminationTracker, appConfig.Profiles)
+		})
+		authorized.GET("/api/tournaments/:id/bracket", func(c *gin.Context) {
+			serverTournament.HandleGetTournamentBracket(c, appConfig.TournamentService, appConfig.BracketManager)
+		})
+		authorized.GET("/api/tournaments/:id/all-ins", func(c *gin.Context) {
+			serverTournament.HandleGetTournamentAllInReport(c, appConfig.TournamentService)
 		})
 		authorized.GET("/api/tournaments/:id/tables", func(c *gin.Context) {
 			serverTournament.HandleGetTournamentTables(c, appConfig.Database)
 		})
+		authorized.GET("/api/tournaments/:id/timeline", func(c *gin.Context) {
+			serverTournament.HandleGetTournamentTimeline(c, appConfig.TournamentService)
+		})
+
+		authorized.GET("/api/leaderboards/:type", func(c *gin.Context) {
+			handlers.HandleGetLeaderboard(c, appConfig.Leaderboards)
+		})
+
+		// Club routes
+		authorized.POST("/api/clubs", func(c *gin.Context) {
+			handlers.HandleCreateClub(c, appConfig.ClubService)
+		})
+		authorized.GET("/api/clubs", func(c *gin.Context) {
+			handlers.HandleListClubs(c, appConfig.ClubService)
+		})
+		authorized.GET("/api/clubs/:id", func(c *gin.Context) {
+			handlers.HandleGetClub(c, appConfig.ClubService)
+		})
+		authorized.POST("/api/clubs/:id/invite", func(c *gin.Context) {
+			handlers.HandleInviteClubMember(c, appConfig.Database, appConfig.ClubService)
+		})
+		authorized.POST("/api/clubs/:id/accept", func(c *gin.Context) {
+			handlers.HandleAcceptClubInvite(c, appConfig.ClubService)
+		})
+		authorized.DELETE("/api/clubs/:id/members/:userId", func(c *gin.Context) {
+			handlers.HandleRemoveClubMember(c, appConfig.ClubService)
+		})
+		authorized.PUT("/api/clubs/:id/members/:userId/role", func(c *gin.Context) {
+			handlers.HandleSetClubMemberRole(c, appConfig.ClubService)
+		})
+		authorized.GET("/api/clubs/:id/lobby", func(c *gin.Context) {
+			handlers.HandleGetClubLobby(c, appConfig.Database, bridge, appConfig.ClubService)
+		})
+		authorized.GET("/api/clubs/:id/leaderboard", func(c *gin.Context) {
+			handlers.HandleGetClubLeaderboard(c, appConfig.ClubService)
+		})
+		authorized.POST("/api/clubs/:id/chips/issue", func(c *gin.Context) {
+			handlers.HandleIssueClubChips(c, appConfig.ClubService)
+		})
+		authorized.POST("/api/clubs/:id/chips/void", func(c *gin.Context) {
+			handlers.HandleVoidClubChips(c, appConfig.ClubService)
+		})
+		authorized.GET("/api/clubs/:id/wallet", func(c *gin.Context) {
+			handlers.HandleGetClubWallet(c, appConfig.ClubService)
+		})
+		authorized.GET("/api/clubs/:id/ledger", func(c *gin.Context) {
+			handlers.HandleGetClubLedger(c, appConfig.ClubService)
+		})
+		authorized.GET("/api/clubs/:id/reconciliation", func(c *gin.Context) {
+			handlers.HandleGetClubReconciliation(c, appConfig.ClubService)
+		})
+	}
+
+	// Admin routes (users with the admin role or listed in ADMIN_USER_IDS)
+	admin := r.Group("/api/admin")
+	admin.Use(handlers.AuthMiddleware(appConfig.AuthService), handlers.AdminMiddleware(appConfig.Database, handlers.ParseAdminUserIDs(config.GetEnv("ADMIN_USER_IDS", ""))))
+	{
+		admin.GET("/tables", func(c *gin.Context) {
+			handlers.HandleListLiveTables(c, bridge)
+		})
+		admin.POST("/tables/:id/complete", func(c *gin.Context) {
+			handlers.HandleForceCompleteTable(c, appConfig.Database, bridge, appConfig.AuditStore)
+		})
+		admin.POST("/tables/:id/kick", func(c *gin.Context) {
+			handlers.HandleKickPlayer(c, appConfig.Database, bridge, appConfig.AuditStore, broadcastTableStateWrapper)
+		})
+		admin.GET("/tables/:id/button", func(c *gin.Context) {
+			handlers.HandleGetTableButton(c, bridge.GetTable)
+		})
+		admin.PUT("/tables/:id/button", func(c *gin.Context) {
+			handlers.HandleForceTableButton(c, bridge.GetTable)
+		})
+		admin.GET("/audit", func(c *gin.Context) {
+			handlers.HandleGetAuditLogs(c, appConfig.AuditStore)
+		})
+		admin.GET("/players/find", func(c *gin.Context) {
+			handlers.HandleFindPlayer(c, appConfig.Database, bridge)
+		})
+		admin.POST("/users/:id/freeze", func(c *gin.Context) {
+			handlers.HandleFreezeUser(c, appConfig.Database, bridge, broadcastTableStateWrapper)
+		})
+		admin.DELETE("/users/:id/freeze", func(c *gin.Context) {
+			handlers.HandleUnfreezeUser(c, appConfig.Database, bridge, broadcastTableStateWrapper)
+		})
+		admin.POST("/users/:id/chips", func(c *gin.Context) {
+			handlers.HandleAdjustChips(c, appConfig.CurrencyService, appConfig.AuditStore)
+		})
+		admin.PUT("/users/:id/role", func(c *gin.Context) {
+			handlers.HandleSetUserRole(c, appConfig.Database, appConfig.AuditStore)
+		})
+		admin.GET("/users/:id/freezes", func(c *gin.Context) {
+			handlers.HandleGetUserFreezes(c, appConfig.Database)
+		})
+		admin.POST("/users/:id/chat-mute", func(c *gin.Context) {
+			handlers.HandleMuteUser(c, appConfig.Chat)
+		})
+		admin.DELETE("/users/:id/chat-mute", func(c *gin.Context) {
+			handlers.HandleUnmuteUser(c, appConfig.Chat)
+		})
+		admin.GET("/snapshot", func(c *gin.Context) {
+			handlers.HandleExportSnapshot(c, appConfig.Database, bridge, appConfig.HistoryWriter)
+		})
+		admin.POST("/snapshot/import", func(c *gin.Context) {
+			handlers.HandleImportSnapshot(c, appConfig.Database, bridge, appConfig.HistoryWriter, func() {
+				// Recovery asks the bridge who runs each table, so the tables are
+				// adopted as from a stopped instance rather than filled in under its lock
+				adoptOrphanedTables()
+			})
+		})
+		admin.POST("/announcements", func(c *gin.Context) {
+			handlers.HandleCreateAnnouncement(c, appConfig.Database, bridge)
+		})
+		admin.GET("/announcements", func(c *gin.Context) {
+			handlers.HandleListAnnouncements(c, appConfig.Database)
+		})
+		admin.DELETE("/announcements/:id", func(c *gin.Context) {
+			handlers.HandleCancelAnnouncement(c, appConfig.Database, bridge)
+		})
+		admin.GET("/moderation", func(c *gin.Context) {
+			handlers.HandleListModeration(c, appConfig.Moderation)
+		})
+		admin.POST("/moderation/:id/approve", func(c *gin.Context) {
+			handlers.HandleResolveModeration(c, appConfig.Moderation, appConfig.Avatars, bridge.SetPlayerAvatar, true)
+		})
+		admin.POST("/moderation/:id/reject", func(c *gin.Context) {
+			handlers.HandleResolveModeration(c, appConfig.Moderation, appConfig.Avatars, bridge.SetPlayerAvatar, false)
+		})
+		admin.GET("/outbox", func(c *gin.Context) {
+			handlers.HandleListOutbox(c, appConfig.Outbox)
+		})
+		admin.POST("/outbox/:id/retry", func(c *gin.Context) {
+			handlers.HandleRetryOutbox(c, appConfig.Outbox)
+		})
+		admin.GET("/identities", func(c *gin.Context) {
+			handlers.HandleListIdentities(c, appConfig.Identity)
+		})
+		admin.POST("/identities", func(c *gin.Context) {
+			handlers.HandleLinkIdentity(c, appConfig.Identity)
+		})
+		admin.POST("/identities/import", func(c *gin.Context) {
+			handlers.HandleImportIdentities(c, appConfig.Identity)
+		})
+		admin.GET("/tournaments/:id/abort", func(c *gin.Context) {
+			serverTournament.HandlePreviewTournamentAbort(c, appConfig.TournamentService)
+		})
+		admin.POST("/tournaments/:id/abort", func(c *gin.Context) {
+			serverTournament.HandleAbortTournament(c, appConfig.TournamentService, appConfig.Database, bridge)
+		})
 	}
 
+	// Public catalog of WebSocket messages and engine events
+	r.GET("/api/events-schema", handlers.HandleGetEventsSchema)
+
+	// Public deck fairness report
+	r.GET("/api/fairness", func(c *gin.Context) {
+		handlers.HandleGetFairnessReport(c, bridge.Fairness)
+	})
+
 	// Public tournament endpoint
 	r.GET("/api/tournaments/code/:code", func(c *gin.Context) {
 		serverTournament.HandleGetTournamentByCode(c, appConfig.TournamentService)
@@ -247,7 +700,7 @@ func setupRoutes(r *gin.Engine) {
 
 	// WebSocket endpoint
 	r.GET("/ws", func(c *gin.Context) {
-		websocket.HandleWebSocket(c, appConfig.AuthService, bridge.Clients, &bridge.Mu, handleWSMessageWrapper)
+		websocket.HandleWebSocket(c, appConfig.AuthService, bridge.Clients, handleWSMessageWrapper, bridge.SetPlayerConnected)
 	})
 }
 
@@ -256,10 +709,15 @@ func setupTournamentCallbacks() {
 		appConfig,
 		onTournamentStart,
 		onBlindIncrease,
+		onBreak,
 		onPlayerEliminated,
 		onTournamentComplete,
 		onConsolidation,
+		onConsolidationPlanned,
+		onFinalTable,
 		onPrizeDistributed,
+		onBracketUpdate,
+		onBountyPaid,
 	)
 }
 
@@ -267,21 +725,125 @@ func recoverTables() {
 	config.RecoverTablesOnStartup(
 		appConfig.Database,
 		bridge.Tables,
+		bridge,
 		handleTimeout,
 		handleEvent,
 	)
 }
 
+// adoptTables takes over the tables of instances that stopped renewing them
+// until stop is closed
+func adoptTables(stop <-chan struct{}) {
+	ticker := time.NewTicker(cluster.OwnershipTTL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if adopted := adoptOrphanedTables(); adopted > 0 {
+				log.Printf("[CLUSTER] Adopted %d tables from other instances", adopted)
+			}
+		}
+	}
+}
+
+// adoptOrphanedTables recovers the tables no instance runs into the running
+// bridge, with their hand IDs, freezes and bots. It returns how many were
+// adopted.
+func adoptOrphanedTables() int {
+	adopted := config.AdoptTables(appConfig.Database, bridge, handleTimeout, handleEvent)
+	if adopted > 0 {
+		game.RestoreCurrentHandIDs(bridge, appConfig.Database)
+		restoreFreezes()
+		restoreBots()
+	}
+	return adopted
+}
+
+// handleClusterEnvelope handles what other instances send this one
+func handleClusterEnvelope(env cluster.Envelope) {
+	switch env.Kind {
+	case cluster.KindTableState:
+		state, ok := bridge.RemoteTableState(env)
+		if !ok {
+			return
+		}
+		if env.UserID == "" {
+			websocket.BroadcastSnapshot(env.TableID, state, bridge.Clients, game.SumSidePots, bridge.Spectators)
+			return
+		}
+		// The answer to a subscription made here
+		for _, clientInterface := range bridge.Clients.Of(env.UserID) {
+			if client, ok := clientInterface.(*websocket.Client); ok && client.TableID == env.TableID {
+				websocket.SendSnapshot(client, env.TableID, state, game.SumSidePots, nil, bridge.Spectators)
+			}
+		}
+
+	case cluster.KindTableMessage, cluster.KindUserMessage:
+		bridge.DeliverRemoteMessage(env)
+
+	case cluster.KindStateRequest:
+		bridge.AnswerStateRequest(env)
+
+	case cluster.KindGameAction:
+		var action cluster.GameAction
+		if err := json.Unmarshal(env.Data, &action); err != nil {
+			log.Printf("[CLUSTER] Dropped action of user %s on table %s: %v", env.UserID, env.TableID, err)
+			return
+		}
+		events.ProcessGameAction(env.UserID, env.TableID, action.Action, action.RequestID, action.Amount,
+			appConfig.Database, bridge, appConfig.HistoryTracker)
+	}
+}
+
+// restoreFreezes re-applies admin freezes to the recovered tables
+func restoreFreezes() {
+	userIDs, err := freeze.FrozenUserIDs(appConfig.Database.DB)
+	if err != nil {
+		log.Printf("⚠️  Failed to load frozen accounts: %v", err)
+		return
+	}
+	for _, userID := range userIDs {
+		bridge.ApplyFreeze(userID, true)
+	}
+}
+
+// restoreBots starts acting again for the bots at the recovered tables
+func restoreBots() {
+	bridge.Mu.RLock()
+	seats := make(map[string][]string, len(bridge.Tables))
+	for tableID, table := range bridge.Tables {
+		for _, player := range table.Snapshot().Players {
+			if player != nil {
+				seats[tableID] = append(seats[tableID], player.PlayerID)
+			}
+		}
+	}
+	bridge.Mu.RUnlock()
+	for tableID, userIDs := range seats {
+		for _, userID := range userIDs {
+			bridge.ShowPlayerBot(tableID, userID)
+		}
+	}
+}
+
+// actForBot takes a bot's action down the path players' actions take
+func actForBot(userID, tableID, action, requestID string, amount int) {
+	events.ProcessGameAction(userID, tableID, action, requestID, amount, appConfig.Database, bridge, appConfig.HistoryTracker)
+}
+
 // Wrapper functions for callbacks
 
 func createEngineTableWrapper(tableID, gameType string, smallBlind, bigBlind, maxPlayers, minBuyIn, maxBuyIn int) {
-	onTimeout := func(playerID string) {
-		handleTimeout(tableID, playerID)
+	onTimeout := func(playerID string, deadline uint64) {
+		handleTimeout(tableID, playerID, deadline)
 	}
 	onEvent := func(event pokerModels.Event) {
 		handleEvent(tableID, event, pokerModels.GameTypeCash)
 	}
 	game.CreateEngineTable(bridge, tableID, gameType, smallBlind, bigBlind, maxPlayers, minBuyIn, maxBuyIn, onTimeout, onEvent)
+	game.ApplyTableSettings(bridge, appConfig.Database, tableID)
 }
 
 func addPlayerToEngineWrapper(tableID, userID, username string, seatNumber, buyIn int) {
@@ -294,7 +856,8 @@ func addPlayerToEngineWrapper(tableID, userID, username string, seatNumber, buyI
 }
 
 func broadcastTableStateWrapper(tableID string) {
-	websocket.BroadcastTableState(tableID, bridge.Clients, &bridge.Mu, getTableFunc, game.SumSidePots)
+	websocket.BroadcastTableState(tableID, bridge.Clients, getTableFunc, game.SumSidePots, bridge.Spectators)
+	bridge.PublishTableState(tableID)
 }
 
 func checkAndStartGameWrapper(tableID string) {
@@ -305,6 +868,14 @@ func syncPlayerChipsWrapper(tableID string) {
 	game.SyncPlayerChipsToDatabase(bridge, appConfig.Database, tableID)
 }
 
+func checkSessionLimitsWrapper(userID string) error {
+	// Frozen accounts cannot take a new seat anywhere
+	if bridge.Frozen.IsFrozen(userID) {
+		return errors.New("your account is frozen, please contact support")
+	}
+	return game.CheckSessionLimits(appConfig.Database, bridge.Sessions, appConfig.SessionLimits, userID)
+}
+
 func syncFinalChipsWrapper(tableID string) {
 	game.SyncFinalChipsOnGameComplete(bridge, appConfig.Database, tableID)
 }
@@ -385,11 +956,57 @@ func handleWSMessageWrapper(c *websocket.Client, msg websocket.WSMessage) {
 			return
 		}
 
+		// Club tables can only be watched by members
+		if err := club.CheckTableAccess(appConfig.Database.DB, tableID, c.UserID); err != nil {
+			log.Printf("[CLUB] User %s denied subscription to table %s: %v", c.UserID, tableID, err)
+			websocket.SendToClient(c, websocket.WSMessage{
+				Type: "error",
+				Payload: map[string]interface{}{
+					"message": "Table not found",
+					"code":    "TABLE_NOT_FOUND",
+				},
+			})
+			return
+		}
+
+		// Tournament directors can watch a broadcast final table cards up
+		cardsUp, _ := payload["cards_up"].(bool)
+		if cardsUp {
+			if err := serverTournament.CheckCardsUpAccess(appConfig.Database, bridge, tableID, c.UserID); err != nil {
+				log.Printf("[BROADCAST] User %s denied cards-up feed of table %s: %v", c.UserID, tableID, err)
+				websocket.SendToClient(c, websocket.WSMessage{
+					Type: "error",
+					Payload: map[string]interface{}{
+						"message": "Cards-up feed not available",
+						"code":    "CARDS_UP_DENIED",
+					},
+				})
+				return
+			}
+		}
+
 		c.TableID = tableID
-		websocket.SendTableState(c, tableID, getTableFunc, game.SumSidePots)
+		c.CardsUp = cardsUp
+		// The instance running another instance's table answers with its state
+		if _, local := bridge.GetTable(tableID); !local && bridge.RequestTableState(tableID, c.UserID) {
+			return
+		}
+		websocket.SendTableState(c, tableID, getTableFunc, game.SumSidePots, bridge.StatsSummary(tableID), bridge.Spectators)
 		log.Printf("Sent table state to client %s for table %s", c.UserID, tableID)
 
 	case "game_action":
+		if bridge.Frozen.IsFrozen(c.UserID) {
+			log.Printf("[FREEZE] Action refused for frozen user %s", c.UserID)
+			websocket.SendToClient(c, websocket.WSMessage{
+				Type: "error",
+				Payload: map[string]interface{}{
+					"message": "Your account is frozen. Please contact support.",
+					"code":    "ACCOUNT_FROZEN",
+				},
+			})
+			return
+		}
+
 		// CRITICAL: Rate limiting to prevent action spam and DoS attacks
 		if !actionRateLimiter.AllowAction(c.UserID) {
 			log.Printf("[RATELIMIT] Action denied for user %s - rate limit exceeded", c.UserID)
@@ -500,10 +1117,139 @@ func handleWSMessageWrapper(c *websocket.Client, msg websocket.WSMessage) {
 			}
 		}
 
+		appConfig.AuditStore.RecordGameAction(c.UserID, c.TableID, action, amount, requestID, audit.ClientInfo{
+			IPAddress:    c.IPAddress,
+			UserAgent:    c.UserAgent,
+			ConnectionID: c.ConnectionID,
+		})
+
+		if bridge.ForwardGameAction(c.UserID, c.TableID, cluster.GameAction{Action: action, Amount: amount, RequestID: requestID}) {
+			return
+		}
 		events.ProcessGameAction(c.UserID, c.TableID, action, requestID, amount, appConfig.Database, bridge, appConfig.HistoryTracker)
 
+	case "seat_change":
+		if c.TableID == "" {
+			websocket.SendToClient(c, websocket.WSMessage{
+				Type: "error",
+				Payload: map[string]interface{}{
+					"message": "Not subscribed to a table",
+					"code":    "NOT_AT_TABLE",
+				},
+			})
+			return
+		}
+
+		// CRITICAL: Validate payload type before casting to prevent panic
+		payload, ok := msg.Payload.(map[string]interface{})
+		if !ok {
+			log.Printf("[VALIDATION] Invalid payload type for seat_change from user %s", c.UserID)
+			websocket.SendToClient(c, websocket.WSMessage{
+				Type: "error",
+				Payload: map[string]interface{}{
+					"message": "Invalid message format",
+					"code":    "INVALID_PAYLOAD",
+				},
+			})
+			return
+		}
+
+		seatRaw, ok := payload["seat_number"].(float64)
+		if !ok || seatRaw != float64(int(seatRaw)) {
+			log.Printf("[VALIDATION] Invalid seat_number for seat_change from user %s", c.UserID)
+			websocket.SendToClient(c, websocket.WSMessage{
+				Type: "error",
+				Payload: map[string]interface{}{
+					"message": "Invalid seat number",
+					"code":    "INVALID_SEAT",
+				},
+			})
+			return
+		}
+
+		events.ProcessSeatChange(c.UserID, c.TableID, int(seatRaw), bridge)
+
+	case "show_cards":
+		if c.TableID == "" {
+			websocket.SendToClient(c, websocket.WSMessage{
+				Type: "error",
+				Payload: map[string]interface{}{
+					"message": "Not subscribed to a table",
+					"code":    "NOT_AT_TABLE",
+				},
+			})
+			return
+		}
+
+		// CRITICAL: Validate payload type before casting to prevent panic
+		payload, ok := msg.Payload.(map[string]interface{})
+		if !ok {
+			log.Printf("[VALIDATION] Invalid payload type for show_cards from user %s", c.UserID)
+			websocket.SendToClient(c, websocket.WSMessage{
+				Type: "error",
+				Payload: map[string]interface{}{
+					"message": "Invalid message format",
+					"code":    "INVALID_PAYLOAD",
+				},
+			})
+			return
+		}
+
+		rawCards, _ := payload["cards"].([]interface{})
+		cards := make([]int, 0, len(rawCards))
+		for _, raw := range rawCards {
+			index, ok := raw.(float64)
+			if !ok || index != float64(int(index)) {
+				log.Printf("[VALIDATION] Invalid cards for show_cards from user %s", c.UserID)
+				websocket.SendToClient(c, websocket.WSMessage{
+					Type: "error",
+					Payload: map[string]interface{}{
+						"message": "Invalid cards",
+						"code":    "INVALID_CARDS",
+					},
+				})
+				return
+			}
+			cards = append(cards, int(index))
+		}
+
+		events.ProcessShowCards(c.UserID, c.TableID, cards, bridge)
+
+	case "chat_message":
+		handlers.HandleChatMessage(c, msg.Payload, appConfig.Chat, bridge)
+
+	case "director":
+		// Tournament directors run their tournaments' tables in bulk
+		serverTournament.HandleDirectorMessage(c, msg.Payload, appConfig.Database, bridge, appConfig.TournamentService)
+
+	case "hello":
+		websocket.HandleHello(c, msg.Payload)
+
+	case "resync":
+		// A delta_updates client that missed a game_delta starts again from a full state
+		if c.TableID == "" {
+			return
+		}
+		c.ResetDelta()
+		if _, local := bridge.GetTable(c.TableID); !local && bridge.RequestTableState(c.TableID, c.UserID) {
+			return
+		}
+		websocket.SendTableState(c, c.TableID, getTableFunc, game.SumSidePots, bridge.StatsSummary(c.TableID), bridge.Spectators)
+
 	case "ping":
 		websocket.SendToClient(c, websocket.WSMessage{Type: "pong"})
+
+	case "action_ack":
+		// Clients with the action_ack feature acknowledge each action_required
+		payload, ok := msg.Payload.(map[string]interface{})
+		if !ok {
+			return
+		}
+		tableID, _ := payload["table_id"].(string)
+		if tableID == "" || !c.HasFeature(websocket.FeatureActionAck) {
+			return
+		}
+		bridge.AcknowledgeTurn(tableID, c.UserID)
 	}
 }
 
@@ -514,13 +1260,13 @@ func getTableFunc(tableID string) (interface{}, bool) {
 	return table, exists
 }
 
-func handleTimeout(tableID, playerID string) {
+func handleTimeout(tableID, playerID string, deadline uint64) {
 	log.Printf("Player %s timed out", playerID)
 	bridge.Mu.RLock()
 	table, exists := bridge.Tables[tableID]
 	bridge.Mu.RUnlock()
 	if exists {
-		err := table.HandleTimeout(playerID)
+		err := table.HandleTimeout(playerID, deadline)
 		if err != nil {
 			log.Printf("Error handling timeout for player %s: %v", playerID, err)
 		} else {
@@ -531,16 +1277,25 @@ func handleTimeout(tableID, playerID string) {
 }
 
 func handleEvent(tableID string, event pokerModels.Event, gameType pokerModels.GameType) {
+	broadcastEvent := func(tableID string) {
+		broadcastThrottle.Broadcast(tableID, event.Event)
+	}
+	if event.Event == "gameComplete" {
+		defer broadcastThrottle.Forget(tableID)
+		defer bridge.Away.Forget(tableID)
+	}
+
 	if gameType == pokerModels.GameTypeTournament {
 		serverTournament.HandleTournamentEngineEvent(
 			tableID,
 			event,
 			appConfig.Database,
 			bridge,
-			broadcastTableStateWrapper,
+			broadcastEvent,
 			syncPlayerChipsWrapper,
 			appConfig.EliminationTracker,
 			appConfig.Consolidator,
+			appConfig.TournamentService,
 		)
 	} else {
 		events.HandleEngineEvent(
@@ -548,7 +1303,7 @@ func handleEvent(tableID string, event pokerModels.Event, gameType pokerModels.G
 			event,
 			appConfig.Database,
 			bridge,
-			broadcastTableStateWrapper,
+			broadcastEvent,
 			syncPlayerChipsWrapper,
 			syncFinalChipsWrapper,
 			appConfig.HistoryTracker,
@@ -561,6 +1316,7 @@ func handleEvent(tableID string, event pokerModels.Event, gameType pokerModels.G
 func onTournamentStart(tournamentID string) {
 	go initializeTournamentTablesWrapper(tournamentID)
 	go broadcastTournamentStartedWrapper(tournamentID)
+	go serverTournament.BroadcastBracketUpdate(tournamentID, appConfig.TournamentService, appConfig.BracketManager, bridge)
 }
 
 func onBlindIncrease(tournamentID string, newLevel models.BlindLevel) {
@@ -568,6 +1324,11 @@ func onBlindIncrease(tournamentID string, newLevel models.BlindLevel) {
 	go serverTournament.BroadcastBlindIncrease(tournamentID, newLevel, appConfig.TournamentService, appConfig.BlindManager, bridge)
 }
 
+// onBreak runs synchronously so the tables are held before their blinds change
+func onBreak(tournamentID string, level int, resumesAt time.Time) {
+	serverTournament.StartLevelBreak(tournamentID, level, resumesAt, appConfig.Database, bridge)
+}
+
 func onPlayerEliminated(tournamentID, userID string, position int) {
 	go serverTournament.HandlePlayerElimination(
 		tournamentID, userID, position,
@@ -580,8 +1341,31 @@ func onTournamentComplete(tournamentID string) {
 	go serverTournament.HandleTournamentComplete(tournamentID, appConfig.Database, bridge, appConfig.EliminationTracker)
 }
 
-func onConsolidation(tournamentID string) {
-	go serverTournament.HandleTableConsolidation(tournamentID, bridge, reinitializeTournamentTablesWrapper)
+func onConsolidation(plan *tournament.ConsolidationPlan) {
+	go serverTournament.HandleTableConsolidation(plan, bridge, reinitializeTournamentTablesWrapper)
+}
+
+func onConsolidationPlanned(plan *tournament.ConsolidationPlan) {
+	go serverTournament.ScheduleConsolidation(plan, appConfig.Database, bridge, appConfig.Consolidator)
+}
+
+// onFinalTable runs synchronously so the final table is held for its break
+// before the consolidated tables are reloaded and start dealing
+func onFinalTable(tournamentID, tableID string) {
+	serverTournament.HandleFinalTable(tournamentID, tableID, appConfig.Database, bridge, appConfig.TournamentService)
+}
+
+// onBracketUpdate starts the tables of a bracket's next round once it is drawn
+func onBracketUpdate(tournamentID string, round int) {
+	if round > 0 {
+		go initializeTournamentTablesWrapper(tournamentID)
+	}
+	go serverTournament.BroadcastBracketUpdate(tournamentID, appConfig.TournamentService, appConfig.BracketManager, bridge)
+}
+
+// onBountyPaid tells a bounty tournament's lobby about a knockout
+func onBountyPaid(knockout models.TournamentKnockout) {
+	go serverTournament.BroadcastKnockout(knockout, appConfig.TournamentService, appConfig.Database, bridge)
 }
 
 func onPrizeDistributed(tournamentID, userID string, amount int) {
@@ -0,0 +1,169 @@
+// Package announcements stores the banner messages operators send to
+// clients: to everyone, to the players of a tournament or to everyone at a
+// table, now or at a scheduled time, until they expire.
+package announcements
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxMessageLength matches the announcements.message column size
+const MaxMessageLength = 500
+
+// Announcement kinds
+const (
+	KindInfo        = "info"
+	KindMaintenance = "maintenance"
+	KindPromotion   = "promotion"
+)
+
+// Announcement targets
+const (
+	TargetAll        = "all"
+	TargetTournament = "tournament"
+	TargetTable      = "table"
+)
+
+// Announcement errors
+var (
+	ErrNotFound         = errors.New("announcement not found")
+	ErrInvalidKind      = errors.New("kind must be info, maintenance or promotion")
+	ErrInvalidTarget    = errors.New("target must be all, tournament or table")
+	ErrMissingTargetID  = errors.New("a tournament or table announcement needs a target_id")
+	ErrInvalidMessage   = errors.New("message must be between 1 and 500 characters")
+	ErrInvalidExpiry    = errors.New("an announcement must expire after it starts and in the future")
+	ErrAlreadyCancelled = errors.New("announcement is already cancelled")
+)
+
+// Request describes a new announcement. StartsAt defaults to now.
+type Request struct {
+	Kind      string     `json:"kind"`
+	Message   string     `json:"message"`
+	Target    string     `json:"target"`
+	TargetID  string     `json:"target_id"`
+	StartsAt  *time.Time `json:"starts_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Create validates and stores an announcement
+func Create(db *gorm.DB, req Request, adminID string, now time.Time) (*models.Announcement, error) {
+	switch req.Kind {
+	case KindInfo, KindMaintenance, KindPromotion:
+	default:
+		return nil, ErrInvalidKind
+	}
+	switch req.Target {
+	case TargetAll:
+		req.TargetID = ""
+	case TargetTournament, TargetTable:
+		if req.TargetID == "" {
+			return nil, ErrMissingTargetID
+		}
+	default:
+		return nil, ErrInvalidTarget
+	}
+	if length := utf8.RuneCountInString(req.Message); length == 0 || length > MaxMessageLength {
+		return nil, ErrInvalidMessage
+	}
+
+	startsAt := now
+	if req.StartsAt != nil && req.StartsAt.After(now) {
+		startsAt = *req.StartsAt
+	}
+	if req.ExpiresAt != nil && (!req.ExpiresAt.After(startsAt) || !req.ExpiresAt.After(now)) {
+		return nil, ErrInvalidExpiry
+	}
+
+	announcement := &models.Announcement{
+		ID:        uuid.New().String(),
+		Kind:      req.Kind,
+		Message:   req.Message,
+		Target:    req.Target,
+		StartsAt:  startsAt,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: adminID,
+	}
+	if req.TargetID != "" {
+		announcement.TargetID = &req.TargetID
+	}
+	if err := db.Create(announcement).Error; err != nil {
+		return nil, err
+	}
+	return announcement, nil
+}
+
+// Cancel withdraws an announcement, whether or not it has been sent
+func Cancel(db *gorm.DB, id string, now time.Time) (*models.Announcement, error) {
+	var announcement models.Announcement
+	if err := db.Where("id = ?", id).First(&announcement).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if announcement.CancelledAt != nil {
+		return nil, ErrAlreadyCancelled
+	}
+	announcement.CancelledAt = &now
+	if err := db.Model(&announcement).Update("cancelled_at", now).Error; err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// List returns announcements newest first. Unless all is set, only those not
+// cancelled and not yet expired.
+func List(db *gorm.DB, all bool, now time.Time) ([]models.Announcement, error) {
+	query := db.Order("starts_at DESC")
+	if !all {
+		query = live(query, now)
+	}
+	var list []models.Announcement
+	err := query.Find(&list).Error
+	return list, err
+}
+
+// Due returns the announcements whose time has come but haven't been sent
+func Due(db *gorm.DB, now time.Time) ([]models.Announcement, error) {
+	var due []models.Announcement
+	err := live(db, now).Where("sent_at IS NULL AND starts_at <= ?", now).Order("starts_at").Find(&due).Error
+	return due, err
+}
+
+// Claim marks an announcement sent and reports whether this caller did so,
+// so only one sender delivers it
+func Claim(db *gorm.DB, id string, now time.Time) (bool, error) {
+	result := db.Model(&models.Announcement{}).Where("id = ? AND sent_at IS NULL", id).Update("sent_at", now)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ActiveFor returns the announcements showing right now that a user should
+// see, for a client that just connected: those for everyone, for the
+// tournaments they are still playing and for the tables they sit at
+func ActiveFor(db *gorm.DB, userID string, now time.Time) ([]models.Announcement, error) {
+	tournaments := db.Model(&models.TournamentPlayer{}).Select("tournament_id").
+		Where("user_id = ? AND eliminated_at IS NULL", userID)
+	tables := db.Model(&models.TableSeat{}).Select("table_id").
+		Where("user_id = ? AND left_at IS NULL", userID)
+
+	var active []models.Announcement
+	err := live(db, now).
+		Where("sent_at IS NOT NULL").
+		Where(db.Where("target = ?", TargetAll).
+			Or("target = ? AND target_id IN (?)", TargetTournament, tournaments).
+			Or("target = ? AND target_id IN (?)", TargetTable, tables)).
+		Order("starts_at DESC").Find(&active).Error
+	return active, err
+}
+
+// live limits a query to announcements neither cancelled nor expired
+func live(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Where("cancelled_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", now)
+}
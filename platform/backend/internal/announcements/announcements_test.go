@@ -0,0 +1,134 @@
+package announcements
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.Announcement{}, &models.TournamentPlayer{}, &models.TableSeat{})
+	return db
+}
+
+func TestCreate_Validation(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	later := now.Add(time.Hour)
+
+	cases := []struct {
+		name string
+		req  Request
+		err  error
+	}{
+		{"kind", Request{Kind: "alert", Message: "hi", Target: TargetAll}, ErrInvalidKind},
+		{"target", Request{Kind: KindInfo, Message: "hi", Target: "club"}, ErrInvalidTarget},
+		{"target id", Request{Kind: KindInfo, Message: "hi", Target: TargetTable}, ErrMissingTargetID},
+		{"empty message", Request{Kind: KindInfo, Target: TargetAll}, ErrInvalidMessage},
+		{"expired", Request{Kind: KindInfo, Message: "hi", Target: TargetAll, ExpiresAt: &past}, ErrInvalidExpiry},
+		{"expires before start", Request{Kind: KindInfo, Message: "hi", Target: TargetAll, StartsAt: &later, ExpiresAt: &later}, ErrInvalidExpiry},
+	}
+	for _, c := range cases {
+		if _, err := Create(db, c.req, "admin", now); !errors.Is(err, c.err) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.err, err)
+		}
+	}
+
+	// A start in the past means now
+	a, err := Create(db, Request{Kind: KindMaintenance, Message: "Restart at 3am", Target: TargetAll, StartsAt: &past}, "admin", now)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if !a.StartsAt.Equal(now) || a.TargetID != nil {
+		t.Errorf("Expected an announcement for everyone starting now, got %+v", a)
+	}
+}
+
+func TestDueAndClaim(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	current, _ := Create(db, Request{Kind: KindInfo, Message: "now", Target: TargetAll}, "admin", now)
+	scheduled, _ := Create(db, Request{Kind: KindPromotion, Message: "later", Target: TargetAll, StartsAt: &later}, "admin", now)
+	cancelled, _ := Create(db, Request{Kind: KindInfo, Message: "cancelled", Target: TargetAll}, "admin", now)
+	if _, err := Cancel(db, cancelled.ID, now); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if _, err := Cancel(db, cancelled.ID, now); !errors.Is(err, ErrAlreadyCancelled) {
+		t.Errorf("Expected ErrAlreadyCancelled, got %v", err)
+	}
+
+	due, err := Due(db, now)
+	if err != nil {
+		t.Fatalf("Due failed: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != current.ID {
+		t.Fatalf("Expected only the current announcement due, got %+v", due)
+	}
+
+	if claimed, _ := Claim(db, current.ID, now); !claimed {
+		t.Error("Expected the first claim to win")
+	}
+	if claimed, _ := Claim(db, current.ID, now); claimed {
+		t.Error("Expected a second claim to lose")
+	}
+
+	due, _ = Due(db, later)
+	if len(due) != 1 || due[0].ID != scheduled.ID {
+		t.Errorf("Expected the scheduled announcement due once its time comes, got %+v", due)
+	}
+}
+
+func TestActiveFor(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Now()
+	expires := now.Add(time.Minute)
+
+	db.Exec(`INSERT INTO tournament_players (tournament_id, user_id, eliminated_at) VALUES
+		('t-1', 'alice', NULL), ('t-2', 'alice', ?)`, now)
+	db.Exec(`INSERT INTO table_seats (table_id, user_id) VALUES ('table-1', 'alice')`)
+
+	for _, req := range []Request{
+		{Kind: KindInfo, Message: "everyone", Target: TargetAll, ExpiresAt: &expires},
+		{Kind: KindInfo, Message: "t-1", Target: TargetTournament, TargetID: "t-1"},
+		{Kind: KindInfo, Message: "t-2", Target: TargetTournament, TargetID: "t-2"},
+		{Kind: KindInfo, Message: "table-1", Target: TargetTable, TargetID: "table-1"},
+		{Kind: KindInfo, Message: "table-2", Target: TargetTable, TargetID: "table-2"},
+	} {
+		a, err := Create(db, req, "admin", now)
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		Claim(db, a.ID, now)
+	}
+
+	active, err := ActiveFor(db, "alice", now)
+	if err != nil {
+		t.Fatalf("ActiveFor failed: %v", err)
+	}
+	got := map[string]bool{}
+	for _, a := range active {
+		got[a.Message] = true
+	}
+	if len(got) != 3 || !got["everyone"] || !got["t-1"] || !got["table-1"] {
+		t.Errorf("Expected the announcements for everyone, t-1 and table-1, got %v", got)
+	}
+
+	// Once expired, the announcement for everyone is gone
+	active, _ = ActiveFor(db, "alice", expires.Add(time.Second))
+	if len(active) != 2 {
+		t.Errorf("Expected 2 announcements after expiry, got %d", len(active))
+	}
+}
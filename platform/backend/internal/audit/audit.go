@@ -0,0 +1,234 @@
+package audit
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Audit event types
+const (
+	EventLogin       = "login"
+	EventLoginFailed = "login_failed"
+	EventGameAction  = "game_action"
+	EventAdminAction = "admin_action"
+)
+
+// maxUserAgentLength matches the audit_logs.user_agent column size
+const maxUserAgentLength = 512
+
+// Config holds retention settings for the audit store
+type Config struct {
+	Retention       time.Duration // Rows older than this are purged
+	CleanupInterval time.Duration // How often the purge runs
+	DeleteBatchSize int           // Maximum rows deleted per statement
+}
+
+// DefaultConfig keeps 90 days of audit history
+var DefaultConfig = Config{
+	Retention:       90 * 24 * time.Hour,
+	CleanupInterval: time.Hour,
+	DeleteBatchSize: 5000,
+}
+
+// ClientInfo identifies where a request came from
+type ClientInfo struct {
+	IPAddress    string
+	UserAgent    string
+	ConnectionID string // Empty for plain HTTP requests
+}
+
+// Filter narrows an audit log query. Zero values are ignored.
+type Filter struct {
+	UserID    string
+	TableID   string
+	EventType string
+	IPAddress string
+	From      time.Time
+	To        time.Time
+	Limit     int
+	Offset    int
+}
+
+// Store persists and queries the per-user audit trail
+type Store struct {
+	db       *gorm.DB
+	config   Config
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewStore creates a new audit store
+func NewStore(db *gorm.DB, config Config) *Store {
+	return &Store{
+		db:       db,
+		config:   config,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// RecordLogin records a successful or failed login attempt
+func (s *Store) RecordLogin(userID string, success bool, client ClientInfo) {
+	eventType := EventLogin
+	if !success {
+		eventType = EventLoginFailed
+	}
+	s.record(models.AuditLog{UserID: userID, EventType: eventType}, client)
+}
+
+// RecordGameAction records a game action as received from the client
+func (s *Store) RecordGameAction(userID, tableID, action string, amount int, requestID string, client ClientInfo) {
+	entry := models.AuditLog{
+		UserID:    userID,
+		EventType: EventGameAction,
+		TableID:   optional(tableID),
+		Action:    optional(action),
+		Amount:    amount,
+		RequestID: optional(requestID),
+	}
+	s.record(entry, client)
+}
+
+// RecordAdminAction records a change an admin made to a player's account or
+// seat, on the player's trail. tableID is empty for account changes.
+func (s *Store) RecordAdminAction(adminID, userID, action, tableID string, amount int, client ClientInfo) {
+	entry := models.AuditLog{
+		UserID:    userID,
+		ActorID:   optional(adminID),
+		EventType: EventAdminAction,
+		TableID:   optional(tableID),
+		Action:    optional(action),
+		Amount:    amount,
+	}
+	s.record(entry, client)
+}
+
+func (s *Store) record(entry models.AuditLog, client ClientInfo) {
+	if s == nil {
+		return
+	}
+
+	entry.IPAddress = client.IPAddress
+	entry.UserAgent = client.UserAgent
+	if len(entry.UserAgent) > maxUserAgentLength {
+		entry.UserAgent = entry.UserAgent[:maxUserAgentLength]
+	}
+	entry.ConnectionID = optional(client.ConnectionID)
+
+	if err := s.db.Create(&entry).Error; err != nil {
+		log.Printf("[AUDIT] ERROR: Failed to record %s for user %s: %v", entry.EventType, entry.UserID, err)
+	}
+}
+
+// Query returns audit entries matching the filter, newest first, and the total match count
+func (s *Store) Query(filter Filter) ([]models.AuditLog, int64, error) {
+	query := s.db.Model(&models.AuditLog{})
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.TableID != "" {
+		query = query.Where("table_id = ?", filter.TableID)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.IPAddress != "" {
+		query = query.Where("ip_address = ?", filter.IPAddress)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var entries []models.AuditLog
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(filter.Offset).Find(&entries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query audit logs: %w", err)
+	}
+	return entries, total, nil
+}
+
+// Start begins the background retention purge
+func (s *Store) Start() {
+	go s.cleanupLoop()
+	log.Printf("[AUDIT] Retention purge started (retention=%v interval=%v)", s.config.Retention, s.config.CleanupInterval)
+}
+
+// Stop stops the background retention purge
+func (s *Store) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+func (s *Store) cleanupLoop() {
+	ticker := time.NewTicker(s.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if deleted, err := s.PurgeExpired(time.Now()); err != nil {
+				log.Printf("[AUDIT] ERROR: Retention purge failed: %v", err)
+			} else if deleted > 0 {
+				log.Printf("[AUDIT] Purged %d expired audit entries", deleted)
+			}
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// PurgeExpired deletes entries older than the retention period, in batches
+func (s *Store) PurgeExpired(now time.Time) (int64, error) {
+	cutoff := now.Add(-s.config.Retention)
+	var total int64
+
+	for {
+		var ids []int64
+		err := s.db.Model(&models.AuditLog{}).
+			Where("created_at < ?", cutoff).
+			Limit(s.config.DeleteBatchSize).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return total, fmt.Errorf("failed to find expired audit logs: %w", err)
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		result := s.db.Where("id IN ?", ids).Delete(&models.AuditLog{})
+		if result.Error != nil {
+			return total, fmt.Errorf("failed to delete expired audit logs: %w", result.Error)
+		}
+		total += result.RowsAffected
+
+		if len(ids) < s.config.DeleteBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestStore creates an audit store backed by an in-memory SQLite database
+func setupTestStore(t *testing.T) *Store {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.AuditLog{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	config := DefaultConfig
+	config.Retention = 24 * time.Hour
+	config.DeleteBatchSize = 2
+	return NewStore(db, config)
+}
+
+func TestStore_RecordsClientMetadata(t *testing.T) {
+	store := setupTestStore(t)
+	client := ClientInfo{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0", ConnectionID: "conn-1"}
+
+	store.RecordLogin("user-1", true, ClientInfo{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"})
+	store.RecordGameAction("user-1", "table-1", "raise", 200, "req-1", client)
+	store.RecordGameAction("user-2", "table-1", "fold", 0, "", ClientInfo{IPAddress: "198.51.100.2"})
+
+	entries, total, err := store.Query(Filter{UserID: "user-1", EventType: EventGameAction})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if total != 1 || len(entries) != 1 {
+		t.Fatalf("Expected 1 game action for user-1, got total=%d len=%d", total, len(entries))
+	}
+
+	entry := entries[0]
+	if entry.IPAddress != "203.0.113.7" || entry.UserAgent != "Mozilla/5.0" {
+		t.Errorf("Client metadata not recorded: %+v", entry)
+	}
+	if entry.ConnectionID == nil || *entry.ConnectionID != "conn-1" {
+		t.Errorf("Expected connection ID conn-1, got %v", entry.ConnectionID)
+	}
+	if entry.Action == nil || *entry.Action != "raise" || entry.Amount != 200 {
+		t.Errorf("Expected raise 200, got %v %d", entry.Action, entry.Amount)
+	}
+
+	if _, total, _ := store.Query(Filter{IPAddress: "203.0.113.7"}); total != 2 {
+		t.Errorf("Expected 2 entries from 203.0.113.7, got %d", total)
+	}
+	if _, total, _ := store.Query(Filter{TableID: "table-1"}); total != 2 {
+		t.Errorf("Expected 2 entries for table-1, got %d", total)
+	}
+}
+
+func TestStore_RecordsAdminActionOnPlayerTrail(t *testing.T) {
+	store := setupTestStore(t)
+	store.RecordAdminAction("admin-1", "user-1", "adjust_chips", "", -500, ClientInfo{IPAddress: "192.0.2.1"})
+
+	entries, total, err := store.Query(Filter{UserID: "user-1", EventType: EventAdminAction})
+	if err != nil || total != 1 {
+		t.Fatalf("Expected 1 admin action for user-1, got %d (%v)", total, err)
+	}
+	entry := entries[0]
+	if entry.ActorID == nil || *entry.ActorID != "admin-1" || entry.TableID != nil || entry.Amount != -500 {
+		t.Errorf("Expected a -500 adjustment by admin-1, got %+v", entry)
+	}
+}
+
+func TestStore_PurgeExpired(t *testing.T) {
+	store := setupTestStore(t)
+	now := time.Now()
+
+	for i, age := range []time.Duration{time.Hour, 30 * time.Hour, 48 * time.Hour, 72 * time.Hour, 2 * time.Hour} {
+		entry := models.AuditLog{
+			UserID:    "user-1",
+			EventType: EventLogin,
+			IPAddress: "203.0.113.7",
+			CreatedAt: now.Add(-age),
+		}
+		if err := store.db.Create(&entry).Error; err != nil {
+			t.Fatalf("Failed to insert entry %d: %v", i, err)
+		}
+	}
+
+	deleted, err := store.PurgeExpired(now)
+	if err != nil {
+		t.Fatalf("PurgeExpired failed: %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("Expected 3 expired entries deleted, got %d", deleted)
+	}
+
+	if _, total, _ := store.Query(Filter{}); total != 2 {
+		t.Errorf("Expected 2 entries within retention, got %d", total)
+	}
+}
+
+func TestStore_NilIsNoop(t *testing.T) {
+	var store *Store
+	store.RecordLogin("user-1", true, ClientInfo{})
+}
@@ -0,0 +1,262 @@
+// Package avatar takes avatar uploads. An upload is checked for size and
+// type, then decoded and re-encoded as a square PNG, so nothing but its
+// pixels is kept: no EXIF location, comments or trailing data. The image goes
+// to avatar storage, local or S3, is shown straight away and is queued for
+// moderation, as are avatars other players report.
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // Registers the GIF decoder
+	_ "image/jpeg" // Registers the JPEG decoder
+	"image/png"
+	"log"
+	"net/http"
+	"strings"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/moderation"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTooLarge        = errors.New("avatar is too large")
+	ErrUnsupportedType = errors.New("avatar must be a PNG, JPEG or GIF image")
+	ErrInvalidImage    = errors.New("avatar image can't be read")
+	ErrNoAvatar        = errors.New("player has no avatar")
+	ErrPlayerNotFound  = errors.New("player not found")
+)
+
+const (
+	MaxUploadSize = 2 << 20 // Bytes
+	MaxDimension  = 4096    // Most pixels an upload may be wide or high
+	Size          = 256     // Avatars are kept as PNGs this many pixels square, or smaller uploads' shorter side
+)
+
+// Why an avatar is queued for moderation, kept as the queued item's term
+const (
+	ReasonUpload   = "upload"
+	ReasonReported = "reported"
+)
+
+// allowedTypes are the content types an upload may sniff as
+var allowedTypes = map[string]bool{"image/png": true, "image/jpeg": true, "image/gif": true}
+
+// Service keeps users' avatars
+type Service struct {
+	db         *gorm.DB
+	storage    Storage
+	moderation *moderation.Service
+}
+
+// NewService creates an avatar service keeping images in storage
+func NewService(db *gorm.DB, storage Storage, moderationService *moderation.Service) *Service {
+	return &Service{db: db, storage: storage, moderation: moderationService}
+}
+
+// Storage returns where the service keeps images
+func (s *Service) Storage() Storage {
+	return s.storage
+}
+
+// Upload re-encodes an uploaded image and makes it a user's avatar, in place
+// of the one they had
+func (s *Service) Upload(ctx context.Context, userID string, data []byte) (*models.Avatar, error) {
+	encoded, err := reencode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	key := userID + "/" + uuid.New().String() + ".png"
+	if err := s.storage.Put(ctx, key, encoded, "image/png"); err != nil {
+		return nil, fmt.Errorf("failed to store avatar: %w", err)
+	}
+	var previous models.Avatar
+	hadAvatar := s.db.Where("user_id = ?", userID).First(&previous).Error == nil
+
+	avatar := &models.Avatar{UserID: userID, URL: s.storage.URL(key), StorageKey: key}
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"url", "storage_key", "created_at"}),
+	}).Create(avatar).Error; err != nil {
+		s.deleteImage(ctx, key)
+		return nil, err
+	}
+	if hadAvatar {
+		s.deleteImage(ctx, previous.StorageKey)
+	}
+
+	if _, err := s.moderation.Report(moderation.KindAvatar, ReasonUpload, userID, userID, avatar.URL); err != nil {
+		log.Printf("[AVATAR] Failed to queue avatar of %s for moderation: %v", userID, err)
+	}
+	return avatar, nil
+}
+
+// Remove takes a user's avatar down
+func (s *Service) Remove(ctx context.Context, userID string) error {
+	var avatar models.Avatar
+	if err := s.db.Where("user_id = ?", userID).First(&avatar).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNoAvatar
+		}
+		return err
+	}
+	if err := s.db.Where("user_id = ? AND storage_key = ?", userID, avatar.StorageKey).
+		Delete(&models.Avatar{}).Error; err != nil {
+		return err
+	}
+	s.deleteImage(ctx, avatar.StorageKey)
+	return nil
+}
+
+// Discard deletes the image of an avatar moderation rejected, once no user
+// has it as their avatar
+func (s *Service) Discard(ctx context.Context, url string) error {
+	prefix := s.storage.URL("")
+	if !strings.HasPrefix(url, prefix) {
+		return nil
+	}
+	key := strings.TrimPrefix(url, prefix)
+	var inUse int64
+	if err := s.db.Model(&models.Avatar{}).Where("storage_key = ?", key).Count(&inUse).Error; err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return nil
+	}
+	return s.storage.Delete(ctx, key)
+}
+
+func (s *Service) deleteImage(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		log.Printf("[AVATAR] Failed to delete avatar image %s: %v", key, err)
+	}
+}
+
+// Report queues the avatar of the player with a username for moderation on
+// another player's behalf
+func (s *Service) Report(username, reporterID string) (*models.ModerationItem, error) {
+	var user models.User
+	if err := s.db.Select("id").Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPlayerNotFound
+		}
+		return nil, err
+	}
+	url, err := s.URL(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if url == "" {
+		return nil, ErrNoAvatar
+	}
+	item, err := s.moderation.Report(moderation.KindAvatar, ReasonReported, user.ID, user.ID, url)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[AVATAR] %s reported the avatar of %s", reporterID, user.ID)
+	return item, nil
+}
+
+// URL returns a user's avatar URL, or empty if they have none
+func (s *Service) URL(userID string) (string, error) {
+	urls, err := s.URLs([]string{userID})
+	if err != nil {
+		return "", err
+	}
+	return urls[userID], nil
+}
+
+// URLs returns the avatar URLs of the given users who have one, by user ID
+func (s *Service) URLs(userIDs []string) (map[string]string, error) {
+	urls := make(map[string]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return urls, nil
+	}
+	var avatars []models.Avatar
+	if err := s.db.Select("user_id", "url").Where("user_id IN ?", userIDs).Find(&avatars).Error; err != nil {
+		return nil, err
+	}
+	for _, avatar := range avatars {
+		urls[avatar.UserID] = avatar.URL
+	}
+	return urls, nil
+}
+
+// reencode checks an upload and returns it as a PNG of the centre square,
+// scaled down to Size
+func reencode(data []byte) ([]byte, error) {
+	if len(data) > MaxUploadSize {
+		return nil, fmt.Errorf("%w: at most %d MB", ErrTooLarge, MaxUploadSize>>20)
+	}
+	if !allowedTypes[http.DetectContentType(data)] {
+		return nil, ErrUnsupportedType
+	}
+	// Check the size before decoding, so a small file can't claim huge dimensions
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width > MaxDimension || config.Height > MaxDimension {
+		return nil, fmt.Errorf("%w: at most %dx%d pixels", ErrTooLarge, MaxDimension, MaxDimension)
+	}
+	if config.Width == 0 || config.Height == 0 {
+		return nil, ErrInvalidImage
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, squareThumbnail(img, Size)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// squareThumbnail crops an image to its centre square and scales it down to
+// at most size pixels square, averaging the pixels each one covers
+func squareThumbnail(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	left := bounds.Min.X + (bounds.Dx()-side)/2
+	top := bounds.Min.Y + (bounds.Dy()-side)/2
+	if size > side {
+		size = side
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := top+y*side/size, top+(y+1)*side/size
+		for x := 0; x < size; x++ {
+			x0, x1 := left+x*side/size, left+(x+1)*side/size
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			thumb.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return thumb
+}
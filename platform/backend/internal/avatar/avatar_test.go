@@ -0,0 +1,192 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/moderation"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestService(t *testing.T) (*Service, *LocalStorage, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Avatar{}))
+	testutil.Schema(t, db, &models.ModerationItem{})
+	for _, user := range []models.User{
+		{ID: "alice", Username: "alice", Email: "alice@test.com"},
+		{ID: "bob", Username: "bob", Email: "bob@test.com"},
+	} {
+		require.NoError(t, db.Create(&user).Error)
+	}
+	storage := NewLocalStorage(t.TempDir(), "/avatars/")
+	return NewService(db, storage, moderation.NewService(db, moderation.DefaultFilter())), storage, db
+}
+
+func testJPEG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+func TestUpload(t *testing.T) {
+	s, storage, db := setupTestService(t)
+	ctx := context.Background()
+
+	_, err := s.Upload(ctx, "alice", []byte("not an image at all"))
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	_, err = s.Upload(ctx, "alice", make([]byte, MaxUploadSize+1))
+	assert.ErrorIs(t, err, ErrTooLarge)
+	var wide bytes.Buffer
+	require.NoError(t, png.Encode(&wide, image.NewGray(image.Rect(0, 0, MaxDimension+1, 1))))
+	_, err = s.Upload(ctx, "alice", wide.Bytes())
+	assert.ErrorIs(t, err, ErrTooLarge, "too many pixels")
+
+	// Anything besides the pixels, such as data after the image, is dropped
+	upload := append(testJPEG(t, 600, 400), []byte("GPS 51.5N 0.1W")...)
+	first, err := s.Upload(ctx, "alice", upload)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first.URL, "/avatars/alice/"))
+	stored, err := os.ReadFile(filepath.Join(storage.Dir, first.StorageKey))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), "GPS")
+	img, err := png.Decode(bytes.NewReader(stored))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, Size, Size), img.Bounds())
+
+	small, err := s.Upload(ctx, "alice", testJPEG(t, 40, 64))
+	require.NoError(t, err)
+	stored, err = os.ReadFile(filepath.Join(storage.Dir, small.StorageKey))
+	require.NoError(t, err)
+	img, err = png.Decode(bytes.NewReader(stored))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 40, 40), img.Bounds(), "small images aren't scaled up")
+	_, err = os.Stat(filepath.Join(storage.Dir, first.StorageKey))
+	assert.True(t, os.IsNotExist(err), "the replaced image is deleted")
+
+	url, err := s.URL("alice")
+	require.NoError(t, err)
+	assert.Equal(t, small.URL, url)
+	urls, err := s.URLs([]string{"alice", "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"alice": small.URL}, urls)
+
+	// Every upload is queued for review
+	var queued []models.ModerationItem
+	require.NoError(t, db.Where("kind = ?", moderation.KindAvatar).Order("created_at").Find(&queued).Error)
+	require.Len(t, queued, 2)
+	assert.Equal(t, small.URL, queued[1].Text)
+	assert.Equal(t, ReasonUpload, queued[1].Term)
+
+	require.NoError(t, s.Remove(ctx, "alice"))
+	assert.ErrorIs(t, s.Remove(ctx, "alice"), ErrNoAvatar)
+	_, err = os.Stat(filepath.Join(storage.Dir, small.StorageKey))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestReportAndReject(t *testing.T) {
+	s, storage, db := setupTestService(t)
+	ctx := context.Background()
+
+	_, err := s.Report("bob", "alice")
+	assert.ErrorIs(t, err, ErrNoAvatar)
+	_, err = s.Report("carol", "alice")
+	assert.ErrorIs(t, err, ErrPlayerNotFound)
+
+	avatar, err := s.Upload(ctx, "bob", testJPEG(t, 64, 64))
+	require.NoError(t, err)
+	item, err := s.Report("bob", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, ReasonUpload, item.Term, "the avatar is already waiting for review")
+
+	require.NoError(t, s.Discard(ctx, avatar.URL))
+	_, err = os.Stat(filepath.Join(storage.Dir, avatar.StorageKey))
+	require.NoError(t, err, "an avatar in use isn't discarded")
+
+	_, err = s.moderation.Resolve(item.ID, "admin", false, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, s.Discard(ctx, avatar.URL))
+	_, err = os.Stat(filepath.Join(storage.Dir, avatar.StorageKey))
+	assert.True(t, os.IsNotExist(err))
+	var left int64
+	require.NoError(t, db.Model(&models.Avatar{}).Count(&left).Error)
+	assert.Zero(t, left)
+
+	// Approved avatars can be reported again
+	avatar, err = s.Upload(ctx, "bob", testJPEG(t, 64, 64))
+	require.NoError(t, err)
+	pending, err := s.moderation.List(moderation.StatusPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	_, err = s.moderation.Resolve(pending[0].ID, "admin", true, time.Now())
+	require.NoError(t, err)
+	item, err = s.Report("bob", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, ReasonReported, item.Term)
+	assert.Equal(t, avatar.URL, item.Text)
+}
+
+func TestS3Storage(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := new(bytes.Buffer)
+		body.ReadFrom(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, body.String())
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	storage := NewS3Storage(S3Config{
+		Bucket: "avatars", Region: "eu-west-1", Endpoint: server.URL,
+		PublicURL: "https://cdn.example.com/", AccessKeyID: "AKID", SecretAccessKey: "secret",
+	})
+	storage.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, storage.Put(context.Background(), "alice/1.png", []byte("png"), "image/png"))
+	require.NoError(t, storage.Delete(context.Background(), "alice/1.png"), "a missing object is already deleted")
+	assert.Equal(t, "https://cdn.example.com/alice/1.png", storage.URL("alice/1.png"))
+
+	require.Len(t, requests, 2)
+	put := requests[0]
+	assert.Equal(t, http.MethodPut, put.Method)
+	assert.Equal(t, "/avatars/alice/1.png", put.URL.Path)
+	assert.Equal(t, "png", bodies[0])
+	assert.Equal(t, "20240501T120000Z", put.Header.Get("X-Amz-Date"))
+	auth := put.Header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(auth,
+		"AWS4-HMAC-SHA256 Credential=AKID/20240501/eu-west-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="), auth)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+	}))
+	defer failing.Close()
+	storage.config.Endpoint = failing.URL
+	assert.ErrorContains(t, storage.Put(context.Background(), "alice/1.png", []byte("png"), "image/png"), "AccessDenied")
+}
@@ -0,0 +1,219 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Storage keeps avatar images under keys and says where clients load them
+// from
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error // Deleting a missing key is not an error
+	URL(key string) string
+}
+
+// StorageFromEnv returns the storage AVATAR_STORAGE names: "local" (the
+// default) keeps images in AVATAR_DIR, served by this server under
+// AVATAR_URL_PREFIX; "s3" keeps them in the AVATAR_S3_BUCKET bucket of
+// AVATAR_S3_REGION, signed with AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+// AVATAR_S3_ENDPOINT points at an S3-compatible store instead of AWS, and
+// AVATAR_PUBLIC_URL, such as a CDN, is where clients load the images from.
+func StorageFromEnv(getEnv func(key, fallback string) string) (Storage, error) {
+	switch kind := getEnv("AVATAR_STORAGE", "local"); kind {
+	case "local":
+		return NewLocalStorage(getEnv("AVATAR_DIR", "uploads/avatars"), getEnv("AVATAR_URL_PREFIX", "/avatars")), nil
+	case "s3":
+		config := S3Config{
+			Bucket:          getEnv("AVATAR_S3_BUCKET", ""),
+			Region:          getEnv("AVATAR_S3_REGION", "us-east-1"),
+			Endpoint:        getEnv("AVATAR_S3_ENDPOINT", ""),
+			PublicURL:       getEnv("AVATAR_PUBLIC_URL", ""),
+			AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", ""),
+			SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
+		}
+		if config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+			return nil, errors.New("s3 avatar storage needs AVATAR_S3_BUCKET, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+		return NewS3Storage(config), nil
+	default:
+		return nil, fmt.Errorf("unknown avatar storage %q", kind)
+	}
+}
+
+// LocalStorage keeps avatars as files in a directory, which the server
+// serves under Prefix
+type LocalStorage struct {
+	Dir    string
+	Prefix string
+}
+
+// NewLocalStorage creates storage in dir, served under the URL prefix
+func NewLocalStorage(dir, prefix string) *LocalStorage {
+	return &LocalStorage{Dir: dir, Prefix: "/" + strings.Trim(prefix, "/")}
+}
+
+func (s *LocalStorage) path(key string) string {
+	return filepath.Join(s.Dir, filepath.FromSlash(key))
+}
+
+// Put writes an image to its file
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Delete removes an image's file
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL returns the path the server serves an image at
+func (s *LocalStorage) URL(key string) string {
+	return s.Prefix + "/" + key
+}
+
+// S3Config says where S3Storage keeps avatars
+type S3Config struct {
+	Bucket          string
+	Region          string
+	Endpoint        string // An S3-compatible store, addressed path-style; empty for AWS
+	PublicURL       string // Where clients load images from; empty for the bucket itself
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Storage keeps avatars in an S3 bucket, with requests signed by AWS
+// Signature Version 4. The bucket, or PublicURL in front of it, must let
+// anyone read the images.
+type S3Storage struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Storage creates storage in an S3 bucket
+func NewS3Storage(config S3Config) *S3Storage {
+	return &S3Storage{config: config, client: &http.Client{Timeout: 30 * time.Second}, now: time.Now}
+}
+
+func (s *S3Storage) objectURL(key string) string {
+	if s.config.Endpoint != "" {
+		return strings.TrimRight(s.config.Endpoint, "/") + "/" + s.config.Bucket + "/" + key
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.config.Bucket, s.config.Region, key)
+}
+
+// Put uploads an image
+func (s *S3Storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	return s.do(ctx, http.MethodPut, key, data, contentType)
+}
+
+// Delete deletes an image
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.do(ctx, http.MethodDelete, key, nil, "")
+}
+
+// URL returns where clients load an image from
+func (s *S3Storage) URL(key string) string {
+	if s.config.PublicURL != "" {
+		return strings.TrimRight(s.config.PublicURL, "/") + "/" + key
+	}
+	return s.objectURL(key)
+}
+
+func (s *S3Storage) do(ctx context.Context, method, key string, body []byte, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 && !(method == http.MethodDelete && resp.StatusCode == http.StatusNotFound) {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("s3 %s %s: %s: %s", method, key, resp.Status, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sign adds the headers of an AWS Signature Version 4 to a request
+func (s *S3Storage) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature))
+}
@@ -0,0 +1,243 @@
+// Package bot keeps the computer players that fill empty seats at cash
+// tables. A bot is a user with the bot role, so its seat, chips and hands are
+// kept like anyone's; it can't log in, and the server decides its actions
+// with the engine's Bot. Bots are made as they are needed and reused once
+// they leave, and their bankrolls are topped up from the house to cover a
+// buy-in.
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+
+	"poker-engine/engine"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTableNotFound   = errors.New("table not found")
+	ErrNotCashTable    = errors.New("bots only sit at cash tables")
+	ErrClubTable       = errors.New("bots can't sit at club tables")
+	ErrTableFull       = errors.New("table is full")
+	ErrBuyInOutOfRange = errors.New("buy-in is outside the table's limits")
+)
+
+// DefaultDifficulty is the difficulty of bots when none is asked for
+const DefaultDifficulty = engine.BotMedium
+
+// names are given to new bots, followed by part of their ID
+var names = []string{"ada", "boris", "cleo", "dario", "edie", "felix", "greta", "hugo", "iris", "jonas"}
+
+// Seated is a bot that just took a seat
+type Seated struct {
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	Difficulty string `json:"difficulty"`
+	SeatNumber int    `json:"seat_number"`
+	BuyIn      int    `json:"buy_in"`
+}
+
+// Service seats bots and says which users are bots
+type Service struct {
+	db       *gorm.DB
+	currency *currency.Service
+	mu       sync.Mutex // Held while a bot is chosen and committed, so no bot is taken twice
+}
+
+// NewService creates a bot service
+func NewService(db *gorm.DB, currencyService *currency.Service) *Service {
+	return &Service{db: db, currency: currencyService}
+}
+
+// CheckDifficulty returns the difficulty to use for a requested one: the
+// default for none, or ErrUnknownBotDifficulty
+func CheckDifficulty(difficulty string) (string, error) {
+	if difficulty == "" {
+		return DefaultDifficulty, nil
+	}
+	if _, err := engine.NewBot(difficulty, 0); err != nil {
+		return "", err
+	}
+	return difficulty, nil
+}
+
+// Seat sits a bot of a difficulty down at a cash table with a buy-in, the
+// table minimum for 0. The caller adds it to the engine table.
+func (s *Service) Seat(ctx context.Context, tableID, difficulty string, buyIn int) (*Seated, error) {
+	difficulty, err := CheckDifficulty(difficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	var table models.Table
+	if err := s.db.Where("id = ?", tableID).First(&table).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTableNotFound
+		}
+		return nil, err
+	}
+	if table.TournamentID != nil || table.GameType != "cash" {
+		return nil, ErrNotCashTable
+	}
+	if table.ClubID != nil {
+		return nil, ErrClubTable
+	}
+	if buyIn == 0 && table.MinBuyIn != nil {
+		buyIn = *table.MinBuyIn
+	}
+	if buyIn <= 0 || (table.MinBuyIn != nil && buyIn < *table.MinBuyIn) || (table.MaxBuyIn != nil && buyIn > *table.MaxBuyIn) {
+		return nil, ErrBuyInOutOfRange
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var seated *Seated
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var taken int64
+		if err := tx.Model(&models.TableSeat{}).Where("table_id = ? AND left_at IS NULL", tableID).Count(&taken).Error; err != nil {
+			return err
+		}
+		if int(taken) >= table.MaxPlayers {
+			return ErrTableFull
+		}
+
+		user, err := s.bankroll(ctx, tx, difficulty, buyIn, tableID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&models.TableSeat{
+			TableID:    tableID,
+			UserID:     user.ID,
+			SeatNumber: int(taken),
+			Chips:      buyIn,
+			BoughtIn:   buyIn,
+			Status:     "active",
+		}).Error; err != nil {
+			return fmt.Errorf("failed to create table seat: %w", err)
+		}
+		if err := tx.Model(&models.User{}).Where("id = ?", user.ID).
+			UpdateColumn("chips", gorm.Expr("chips - ?", buyIn)).Error; err != nil {
+			return fmt.Errorf("failed to deduct chips: %w", err)
+		}
+		seated = &Seated{UserID: user.ID, Username: user.Username, Difficulty: difficulty, SeatNumber: int(taken), BuyIn: buyIn}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seated, nil
+}
+
+// Queue puts a bot of a difficulty in a matchmaking queue with a buy-in, as
+// a player joining it would be, and returns its user ID
+func (s *Service) Queue(ctx context.Context, gameMode, difficulty string, buyIn, minBuyIn, maxBuyIn int) (string, error) {
+	difficulty, err := CheckDifficulty(difficulty)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var userID string
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := s.bankroll(ctx, tx, difficulty, buyIn, gameMode)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&models.MatchmakingEntry{
+			UserID:    user.ID,
+			GameType:  "cash",
+			QueueType: gameMode,
+			Status:    "waiting",
+			MinBuyIn:  &minBuyIn,
+			MaxBuyIn:  &maxBuyIn,
+			BuyIn:     &buyIn,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to queue bot: %w", err)
+		}
+		userID = user.ID
+		return nil
+	})
+	return userID, err
+}
+
+// bankroll returns an idle bot of a difficulty, made if there is none, with
+// at least buyIn chips
+func (s *Service) bankroll(ctx context.Context, tx *gorm.DB, difficulty string, buyIn int, refID string) (*models.User, error) {
+	busy := tx.Model(&models.TableSeat{}).Select("user_id").Where("left_at IS NULL")
+	queued := tx.Model(&models.MatchmakingEntry{}).Select("user_id").Where("status = ?", "waiting")
+
+	var user models.User
+	err := tx.Model(&models.User{}).
+		Joins("JOIN bots ON bots.user_id = users.id").
+		Where("bots.difficulty = ? AND users.id NOT IN (?) AND users.id NOT IN (?)", difficulty, busy, queued).
+		Order("bots.created_at").
+		First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		made, err := create(tx, difficulty)
+		if err != nil {
+			return nil, err
+		}
+		user = *made
+	} else if err != nil {
+		return nil, err
+	}
+
+	if short := buyIn - user.Chips; short > 0 {
+		if err := s.currency.PayRewardWithTx(ctx, tx, user.ID, short, currency.TxTypeBotBankroll, refID, "Bot bankroll"); err != nil {
+			return nil, fmt.Errorf("failed to fund bot: %w", err)
+		}
+		user.Chips += short
+	}
+	return &user, nil
+}
+
+// create makes a new bot
+func create(tx *gorm.DB, difficulty string) (*models.User, error) {
+	id := uuid.New().String()
+	user := &models.User{
+		ID:           id,
+		Username:     fmt.Sprintf("%s_bot_%s", names[int(id[0])%len(names)], strings.ReplaceAll(id, "-", "")[:6]),
+		Email:        id + "@bots.invalid",
+		PasswordHash: "!", // Matches no password
+		Role:         models.RoleBot,
+		Level:        1,
+	}
+	if err := tx.Create(user).Error; err != nil {
+		return nil, fmt.Errorf("failed to create bot: %w", err)
+	}
+	// A zero balance would be replaced by the column's default for new users
+	if err := tx.Model(user).UpdateColumn("chips", 0).Error; err != nil {
+		return nil, fmt.Errorf("failed to create bot: %w", err)
+	}
+	user.Chips = 0
+	if err := tx.Create(&models.Bot{UserID: id, Difficulty: difficulty, CreatedAt: time.Now()}).Error; err != nil {
+		return nil, fmt.Errorf("failed to create bot: %w", err)
+	}
+	return user, nil
+}
+
+// Difficulty returns how well a user plays if they are a bot, and false if
+// they aren't
+func (s *Service) Difficulty(userID string) (string, bool, error) {
+	var bot models.Bot
+	err := s.db.Where("user_id = ?", userID).First(&bot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return bot.Difficulty, true, nil
+}
@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"poker-engine/engine"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Bot{}, &currency.Transaction{}))
+	testutil.Schema(t, db, &models.Table{}, &models.TableSeat{}, &models.MatchmakingEntry{})
+	for _, stmt := range []string{
+		`INSERT INTO tables (id, game_type, status, max_players, min_buy_in, max_buy_in) VALUES
+			('cash', 'cash', 'waiting', 2, 400, 2000),
+			('cash-2', 'cash', 'waiting', 2, 400, 2000),
+			('tourney', 'tournament', 'waiting', 9, NULL, NULL)`,
+		`INSERT INTO tables (id, game_type, club_id, status, max_players, min_buy_in, max_buy_in) VALUES
+			('club', 'cash', 'club-1', 'waiting', 6, 400, 2000)`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	require.NoError(t, db.Create(&models.User{ID: currency.OperatorAccountID, Username: "house", Email: "house@localhost", Chips: 1000}).Error)
+	return NewService(db, currency.NewService(db)), db
+}
+
+func TestSeat(t *testing.T) {
+	s, db := setupTestService(t)
+	ctx := context.Background()
+
+	_, err := s.Seat(ctx, "cash", "expert", 0)
+	assert.ErrorIs(t, err, engine.ErrUnknownBotDifficulty)
+	_, err = s.Seat(ctx, "tourney", "", 0)
+	assert.ErrorIs(t, err, ErrNotCashTable)
+	_, err = s.Seat(ctx, "club", "", 0)
+	assert.ErrorIs(t, err, ErrClubTable)
+	_, err = s.Seat(ctx, "cash", "", 5000)
+	assert.ErrorIs(t, err, ErrBuyInOutOfRange)
+	_, err = s.Seat(ctx, "missing", "", 0)
+	assert.ErrorIs(t, err, ErrTableNotFound)
+
+	first, err := s.Seat(ctx, "cash", "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDifficulty, first.Difficulty)
+	assert.Equal(t, 400, first.BuyIn, "the table minimum")
+	assert.Equal(t, 0, first.SeatNumber)
+
+	var user models.User
+	require.NoError(t, db.First(&user, "id = ?", first.UserID).Error)
+	assert.Equal(t, models.RoleBot, user.Role)
+	assert.Equal(t, first.Username, user.Username)
+	assert.Zero(t, user.Chips, "the house funded exactly the buy-in")
+	var house models.User
+	require.NoError(t, db.First(&house, "id = ?", currency.OperatorAccountID).Error)
+	assert.Equal(t, 600, house.Chips)
+
+	difficulty, isBot, err := s.Difficulty(first.UserID)
+	require.NoError(t, err)
+	assert.True(t, isBot)
+	assert.Equal(t, DefaultDifficulty, difficulty)
+	_, isBot, err = s.Difficulty(currency.OperatorAccountID)
+	require.NoError(t, err)
+	assert.False(t, isBot)
+
+	// A seated bot isn't taken again
+	second, err := s.Seat(ctx, "cash", engine.BotMedium, 1000)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.UserID, second.UserID)
+	assert.Equal(t, 1, second.SeatNumber)
+	_, err = s.Seat(ctx, "cash", "", 0)
+	assert.ErrorIs(t, err, ErrTableFull)
+}
+
+func TestSeat_ReusesIdleBots(t *testing.T) {
+	s, db := setupTestService(t)
+	ctx := context.Background()
+
+	first, err := s.Seat(ctx, "cash", engine.BotHard, 400)
+	require.NoError(t, err)
+	// The bot leaves with more than it brought
+	require.NoError(t, db.Exec(`UPDATE table_seats SET left_at = CURRENT_TIMESTAMP WHERE user_id = ?`, first.UserID).Error)
+	require.NoError(t, db.Model(&models.User{}).Where("id = ?", first.UserID).Update("chips", 700).Error)
+
+	// Its seat row stays behind, so the next bot sits at another table
+	again, err := s.Seat(ctx, "cash-2", engine.BotHard, 500)
+	require.NoError(t, err)
+	assert.Equal(t, first.UserID, again.UserID)
+	var user models.User
+	require.NoError(t, db.First(&user, "id = ?", first.UserID).Error)
+	assert.Equal(t, 200, user.Chips, "a bot with enough chips isn't topped up")
+
+	// Bots of another difficulty aren't used
+	other, err := s.Seat(ctx, "cash-2", engine.BotEasy, 400)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.UserID, other.UserID)
+}
+
+func TestQueue(t *testing.T) {
+	s, db := setupTestService(t)
+	ctx := context.Background()
+
+	userID, err := s.Queue(ctx, "headsup", "", 1000, 400, 2000)
+	require.NoError(t, err)
+	var entry models.MatchmakingEntry
+	require.NoError(t, db.Where("user_id = ?", userID).First(&entry).Error)
+	assert.Equal(t, "headsup", entry.QueueType)
+	assert.Equal(t, "waiting", entry.Status)
+	require.NotNil(t, entry.BuyIn)
+	assert.Equal(t, 1000, *entry.BuyIn)
+
+	var user models.User
+	require.NoError(t, db.First(&user, "id = ?", userID).Error)
+	assert.Equal(t, 1000, user.Chips, "chips are taken when the match is made")
+
+	// A queued bot isn't taken again
+	other, err := s.Queue(ctx, "headsup", "", 1000, 400, 2000)
+	require.NoError(t, err)
+	assert.NotEqual(t, userID, other)
+}
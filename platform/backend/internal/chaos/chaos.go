@@ -0,0 +1,137 @@
+// Package chaos injects faults into the WebSocket layer and the Redis and
+// database clients so resilience features (send queue backpressure, client
+// reconnection, load shedding, retries) can be exercised in integration runs.
+//
+// Faults are only injected in binaries built with the chaos tag:
+//
+//	go build -tags chaos ./cmd/server
+//
+// Without the tag every hook is a no-op the compiler inlines away.
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInjected is returned by Redis commands and queries failed on purpose
+var ErrInjected = errors.New("chaos: injected failure")
+
+// Fault delays calls and fails a share of them
+type Fault struct {
+	Latency   time.Duration `json:"latency"`    // Added to every call
+	Jitter    time.Duration `json:"jitter"`     // Up to this much more, at random
+	ErrorRate float64       `json:"error_rate"` // Share of calls failed with ErrInjected, 0 to 1
+}
+
+// WSFaults are the faults of the WebSocket layer
+type WSFaults struct {
+	Latency time.Duration `json:"latency"` // Added before every outgoing message
+	Jitter  time.Duration `json:"jitter"`
+	// Share of outgoing messages silently dropped
+	DropRate float64 `json:"drop_rate"`
+	// Share of outgoing messages after which the client stops reading for
+	// Stall, as a slow client on a bad network would, so its queues fill up
+	StallRate float64       `json:"stall_rate"`
+	Stall     time.Duration `json:"stall"`
+	// Share of incoming messages on which the connection is cut
+	DisconnectRate float64 `json:"disconnect_rate"`
+	// Only users whose ID starts with this prefix are affected, if set
+	UserPrefix string `json:"user_prefix"`
+}
+
+// Config is the full set of faults to inject
+type Config struct {
+	WS    WSFaults `json:"ws"`
+	Redis Fault    `json:"redis"`
+	DB    Fault    `json:"db"`
+	Seed  int64    `json:"seed"` // Seeds the random choices; 0 uses the clock
+}
+
+// Validate checks that rates are shares and durations aren't negative
+func (c Config) Validate() error {
+	rates := map[string]float64{
+		"ws.drop_rate":       c.WS.DropRate,
+		"ws.stall_rate":      c.WS.StallRate,
+		"ws.disconnect_rate": c.WS.DisconnectRate,
+		"redis.error_rate":   c.Redis.ErrorRate,
+		"db.error_rate":      c.DB.ErrorRate,
+	}
+	for name, rate := range rates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%s must be between 0 and 1, got %v", name, rate)
+		}
+	}
+	durations := map[string]time.Duration{
+		"ws.latency":    c.WS.Latency,
+		"ws.jitter":     c.WS.Jitter,
+		"ws.stall":      c.WS.Stall,
+		"redis.latency": c.Redis.Latency,
+		"redis.jitter":  c.Redis.Jitter,
+		"db.latency":    c.DB.Latency,
+		"db.jitter":     c.DB.Jitter,
+	}
+	for name, d := range durations {
+		if d < 0 {
+			return fmt.Errorf("%s must not be negative, got %v", name, d)
+		}
+	}
+	return nil
+}
+
+// FromEnv reads a Config from CHAOS_* environment variables, such as
+// CHAOS_WS_LATENCY=200ms or CHAOS_DB_ERROR_RATE=0.01
+func FromEnv() (Config, error) {
+	var c Config
+	var errs []string
+	duration := func(key string, into *time.Duration) {
+		if value := os.Getenv(key); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+				return
+			}
+			*into = d
+		}
+	}
+	rate := func(key string, into *float64) {
+		if value := os.Getenv(key); value != "" {
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+				return
+			}
+			*into = f
+		}
+	}
+
+	duration("CHAOS_WS_LATENCY", &c.WS.Latency)
+	duration("CHAOS_WS_JITTER", &c.WS.Jitter)
+	rate("CHAOS_WS_DROP_RATE", &c.WS.DropRate)
+	rate("CHAOS_WS_STALL_RATE", &c.WS.StallRate)
+	duration("CHAOS_WS_STALL", &c.WS.Stall)
+	rate("CHAOS_WS_DISCONNECT_RATE", &c.WS.DisconnectRate)
+	c.WS.UserPrefix = os.Getenv("CHAOS_WS_USER_PREFIX")
+	duration("CHAOS_REDIS_LATENCY", &c.Redis.Latency)
+	duration("CHAOS_REDIS_JITTER", &c.Redis.Jitter)
+	rate("CHAOS_REDIS_ERROR_RATE", &c.Redis.ErrorRate)
+	duration("CHAOS_DB_LATENCY", &c.DB.Latency)
+	duration("CHAOS_DB_JITTER", &c.DB.Jitter)
+	rate("CHAOS_DB_ERROR_RATE", &c.DB.ErrorRate)
+	if value := os.Getenv("CHAOS_SEED"); value != "" {
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("CHAOS_SEED: %v", err))
+		}
+		c.Seed = seed
+	}
+
+	if len(errs) > 0 {
+		return Config{}, errors.New(strings.Join(errs, "; "))
+	}
+	return c, c.Validate()
+}
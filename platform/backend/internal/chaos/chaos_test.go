@@ -0,0 +1,39 @@
+package chaos
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("CHAOS_WS_LATENCY", "150ms")
+	t.Setenv("CHAOS_WS_STALL_RATE", "0.1")
+	t.Setenv("CHAOS_WS_STALL", "5s")
+	t.Setenv("CHAOS_WS_USER_PREFIX", "loadgen")
+	t.Setenv("CHAOS_DB_ERROR_RATE", "0.02")
+	t.Setenv("CHAOS_SEED", "7")
+
+	c, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv failed: %v", err)
+	}
+	if c.WS.Latency != 150*time.Millisecond || c.WS.StallRate != 0.1 || c.WS.Stall != 5*time.Second || c.WS.UserPrefix != "loadgen" {
+		t.Errorf("Unexpected WebSocket faults: %+v", c.WS)
+	}
+	if c.DB.ErrorRate != 0.02 || c.Redis != (Fault{}) || c.Seed != 7 {
+		t.Errorf("Unexpected faults: %+v", c)
+	}
+}
+
+func TestFromEnv_Invalid(t *testing.T) {
+	t.Setenv("CHAOS_WS_LATENCY", "soon")
+	if _, err := FromEnv(); err == nil {
+		t.Error("Expected an error for an unparseable duration")
+	}
+
+	t.Setenv("CHAOS_WS_LATENCY", "")
+	t.Setenv("CHAOS_REDIS_ERROR_RATE", "1.5")
+	if _, err := FromEnv(); err == nil {
+		t.Error("Expected an error for a rate above 1")
+	}
+}
@@ -0,0 +1,219 @@
+//go:build chaos
+
+package chaos
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Enabled reports whether this binary was built with the chaos tag
+const Enabled = true
+
+var (
+	mu     sync.RWMutex
+	config Config
+	rngMu  sync.Mutex
+	rng    = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func init() {
+	c, err := FromEnv()
+	if err != nil {
+		log.Fatalf("[CHAOS] Invalid fault configuration: %v", err)
+	}
+	Set(c)
+	log.Printf("[CHAOS] WARNING: built with fault injection; faults: %+v", c)
+}
+
+// Set replaces the faults being injected
+func Set(c Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	mu.Lock()
+	config = c
+	mu.Unlock()
+	if c.Seed != 0 {
+		rngMu.Lock()
+		rng = rand.New(rand.NewSource(c.Seed))
+		rngMu.Unlock()
+	}
+	return nil
+}
+
+// Current returns the faults being injected
+func Current() Config {
+	mu.RLock()
+	defer mu.RUnlock()
+	return config
+}
+
+func chance(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	return rng.Float64() < rate
+}
+
+func delay(latency, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return latency
+	}
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	return latency + time.Duration(rng.Int63n(int64(jitter)+1))
+}
+
+// wait sleeps for d or until ctx is done
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// inject delays a call and reports whether it should fail
+func (f Fault) inject(ctx context.Context) error {
+	if err := wait(ctx, delay(f.Latency, f.Jitter)); err != nil {
+		return err
+	}
+	if chance(f.ErrorRate) {
+		return ErrInjected
+	}
+	return nil
+}
+
+func targeted(ws WSFaults, userID string) bool {
+	return ws.UserPrefix == "" || strings.HasPrefix(userID, ws.UserPrefix)
+}
+
+// WSWrite is called by the write pump before each outgoing message. It
+// delays the message, sometimes stalls as a slow reader would, and reports
+// whether the message should be dropped.
+func WSWrite(userID string) (drop bool) {
+	ws := Current().WS
+	if !targeted(ws, userID) {
+		return false
+	}
+	time.Sleep(delay(ws.Latency, ws.Jitter))
+	if chance(ws.StallRate) {
+		time.Sleep(ws.Stall)
+	}
+	return chance(ws.DropRate)
+}
+
+// WSRead is called by the read pump after each incoming message and reports
+// whether the connection should be cut
+func WSRead(userID string) (disconnect bool) {
+	ws := Current().WS
+	return targeted(ws, userID) && chance(ws.DisconnectRate)
+}
+
+// redisHook applies the Redis faults to connections, commands and pipelines
+type redisHook struct{}
+
+func (redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if err := Current().Redis.inject(ctx); err != nil {
+			return nil, err
+		}
+		return next(ctx, network, addr)
+	}
+}
+
+func (redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := Current().Redis.inject(ctx); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := Current().Redis.inject(ctx); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+		return next(ctx, cmds)
+	}
+}
+
+// InstrumentRedis applies the Redis faults to a client
+func InstrumentRedis(client *redis.Client) {
+	client.AddHook(redisHook{})
+}
+
+// InstrumentDB applies the database faults to every query, create, update,
+// delete and raw statement. A failed statement is never sent.
+func InstrumentDB(db *gorm.DB) error {
+	inject := func(tx *gorm.DB) {
+		ctx := tx.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if err := Current().DB.inject(ctx); err != nil {
+			tx.AddError(err)
+		}
+	}
+	callbacks := db.Callback()
+	for _, register := range []func() error{
+		func() error { return callbacks.Create().Before("gorm:create").Register("chaos:create", inject) },
+		func() error { return callbacks.Query().Before("gorm:query").Register("chaos:query", inject) },
+		func() error { return callbacks.Update().Before("gorm:update").Register("chaos:update", inject) },
+		func() error { return callbacks.Delete().Before("gorm:delete").Register("chaos:delete", inject) },
+		func() error { return callbacks.Row().Before("gorm:row").Register("chaos:row", inject) },
+		func() error { return callbacks.Raw().Before("gorm:raw").Register("chaos:raw", inject) },
+	} {
+		if err := register(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterRoutes lets an integration run read and change the faults with
+// GET and PUT /debug/chaos. The routes are unauthenticated: chaos builds
+// must never be deployed.
+func RegisterRoutes(r gin.IRoutes) {
+	r.GET("/debug/chaos", func(c *gin.Context) {
+		c.JSON(http.StatusOK, Current())
+	})
+	r.PUT("/debug/chaos", func(c *gin.Context) {
+		var req Config
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := Set(req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[CHAOS] Faults changed to %+v", req)
+		c.JSON(http.StatusOK, req)
+	})
+}
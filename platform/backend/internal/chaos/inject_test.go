@@ -0,0 +1,76 @@
+//go:build chaos
+
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func withFaults(t *testing.T, c Config) {
+	t.Helper()
+	previous := Current()
+	if err := Set(c); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	t.Cleanup(func() { Set(previous) })
+}
+
+func TestWSWrite_TargetsPrefixAndDelays(t *testing.T) {
+	withFaults(t, Config{WS: WSFaults{Latency: 20 * time.Millisecond, DropRate: 1, UserPrefix: "loadgen"}})
+
+	start := time.Now()
+	if !WSWrite("loadgen_00001") {
+		t.Error("Expected a targeted user's message to be dropped")
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Expected the message to be delayed, took %v", elapsed)
+	}
+	if WSWrite("alice") || WSRead("alice") {
+		t.Error("Expected users outside the prefix to be left alone")
+	}
+}
+
+func TestInstrumentDB_FailsStatements(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	if err := db.Exec("CREATE TABLE things (id integer PRIMARY KEY)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	if err := InstrumentDB(db); err != nil {
+		t.Fatalf("InstrumentDB failed: %v", err)
+	}
+
+	withFaults(t, Config{DB: Fault{ErrorRate: 1}})
+	var count int64
+	if err := db.Table("things").Count(&count).Error; !errors.Is(err, ErrInjected) {
+		t.Errorf("Expected ErrInjected from a query, got %v", err)
+	}
+	if err := db.Exec("INSERT INTO things (id) VALUES (1)").Error; !errors.Is(err, ErrInjected) {
+		t.Errorf("Expected ErrInjected from a raw statement, got %v", err)
+	}
+
+	Set(Config{})
+	if err := db.Table("things").Count(&count).Error; err != nil || count != 0 {
+		t.Errorf("Expected the failed insert never to run, got %d rows (%v)", count, err)
+	}
+}
+
+func TestInstrumentRedis_FailsCommands(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+	InstrumentRedis(client)
+
+	withFaults(t, Config{Redis: Fault{ErrorRate: 1}})
+	if err := client.Ping(context.Background()).Err(); !errors.Is(err, ErrInjected) {
+		t.Errorf("Expected ErrInjected, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build !chaos
+
+package chaos
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Enabled reports whether this binary was built with the chaos tag
+const Enabled = false
+
+// Set does nothing without the chaos tag
+func Set(Config) error { return nil }
+
+// Current returns no faults without the chaos tag
+func Current() Config { return Config{} }
+
+// WSWrite never delays or drops without the chaos tag
+func WSWrite(userID string) (drop bool) { return false }
+
+// WSRead never cuts connections without the chaos tag
+func WSRead(userID string) (disconnect bool) { return false }
+
+// InstrumentRedis does nothing without the chaos tag
+func InstrumentRedis(client *redis.Client) {}
+
+// InstrumentDB does nothing without the chaos tag
+func InstrumentDB(db *gorm.DB) error { return nil }
+
+// RegisterRoutes registers nothing without the chaos tag
+func RegisterRoutes(r gin.IRoutes) {}
@@ -0,0 +1,344 @@
+// Package chat keeps table chat: it checks each message against the rate
+// limit, mutes and filters, holds the last messages of every table and
+// tracks who ignores whom. Delivery is left to the WebSocket layer.
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/ids"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/moderation"
+	"poker-platform/backend/internal/validation"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Config holds chat limits
+type Config struct {
+	HistorySize int           // Messages kept per table
+	HistoryTTL  time.Duration // How long a quiet table's history is kept in Redis
+	Rate        rate.Limit    // Messages per second a user may send, on average
+	Burst       int           // Messages a user may send at once
+}
+
+// DefaultConfig keeps 50 messages per table and lets a user send 5 at once,
+// then one every 2 seconds
+var DefaultConfig = Config{
+	HistorySize: 50,
+	HistoryTTL:  24 * time.Hour,
+	Rate:        rate.Every(2 * time.Second),
+	Burst:       5,
+}
+
+// Chat errors
+var (
+	ErrRateLimited     = errors.New("sending messages too fast")
+	ErrMuted           = errors.New("muted from chat")
+	ErrInvalidMessage  = errors.New("invalid message")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrIgnoreSelf      = errors.New("cannot ignore yourself")
+	ErrInvalidDuration = errors.New("mute duration must be positive")
+)
+
+// Message is one table chat message
+type Message struct {
+	ID       string    `json:"id"` // ULID, so messages sort by when they were sent
+	TableID  string    `json:"table_id"`
+	UserID   string    `json:"user_id"`
+	Username string    `json:"username"`
+	Text     string    `json:"text"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// limiterIdle is how long a user's rate limiter is kept after their last message
+const limiterIdle = 10 * time.Minute
+
+type userLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Service runs table chat
+type Service struct {
+	db         *gorm.DB
+	redis      *redis.Client
+	moderation *moderation.Service
+	config     Config
+
+	mu       sync.Mutex
+	limiters map[string]*userLimiter
+	history  map[string][]Message // Per table, oldest first; used without Redis
+}
+
+// NewService creates a chat service. redisClient may be nil, in which case
+// history is kept in memory and lost on restart.
+func NewService(db *gorm.DB, redisClient *redis.Client, moderationService *moderation.Service, config Config) *Service {
+	return &Service{
+		db:         db,
+		redis:      redisClient,
+		moderation: moderationService,
+		config:     config,
+		limiters:   make(map[string]*userLimiter),
+		history:    make(map[string][]Message),
+	}
+}
+
+// Post checks a message and stores it in the table's history. Blocked words
+// are refused; borderline ones are sent and queued for review.
+func (s *Service) Post(tableID, userID, text string, now time.Time) (*Message, error) {
+	text, err := validation.ValidateChatMessage(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+
+	mutedUntil, err := s.MutedUntil(userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if mutedUntil != nil {
+		return nil, ErrMuted
+	}
+	if !s.allow(userID, now) {
+		return nil, ErrRateLimited
+	}
+
+	match, err := s.moderation.Screen("message", text)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	if err := s.db.Select("id", "username").Where("id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	msg := &Message{
+		ID:       ids.NewAt(now),
+		TableID:  tableID,
+		UserID:   userID,
+		Username: user.Username,
+		Text:     text,
+		SentAt:   now,
+	}
+	if err := s.store(*msg); err != nil {
+		// The message still goes out; only its place in history is lost
+		log.Printf("[CHAT] Failed to store message %s at table %s: %v", msg.ID, tableID, err)
+	}
+	if _, err := s.moderation.Flag(match, moderation.KindChat, tableID, userID, text); err != nil {
+		log.Printf("[MODERATION] Failed to queue chat from %s at table %s: %v", userID, tableID, err)
+	}
+	return msg, nil
+}
+
+// allow takes a token from the user's rate limiter
+func (s *Service) allow(userID string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, l := range s.limiters {
+		if now.Sub(l.lastSeen) > limiterIdle {
+			delete(s.limiters, id)
+		}
+	}
+	l, ok := s.limiters[userID]
+	if !ok {
+		l = &userLimiter{limiter: rate.NewLimiter(s.config.Rate, s.config.Burst)}
+		s.limiters[userID] = l
+	}
+	l.lastSeen = now
+	return l.limiter.AllowN(now, 1)
+}
+
+func historyKey(tableID string) string {
+	return "chat:table:" + tableID
+}
+
+// store adds a message to its table's history, dropping the oldest past
+// HistorySize
+func (s *Service) store(msg Message) error {
+	if s.redis == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		messages := append(s.history[msg.TableID], msg)
+		if len(messages) > s.config.HistorySize {
+			messages = messages[len(messages)-s.config.HistorySize:]
+		}
+		s.history[msg.TableID] = messages
+		return nil
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	key := historyKey(msg.TableID)
+	pipe := s.redis.TxPipeline()
+	pipe.RPush(ctx, key, data)
+	pipe.LTrim(ctx, key, int64(-s.config.HistorySize), -1)
+	pipe.Expire(ctx, key, s.config.HistoryTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Recent returns a table's last messages, oldest first, leaving out those
+// from users the viewer ignores
+func (s *Service) Recent(tableID, viewerID string) ([]Message, error) {
+	messages, err := s.load(tableID)
+	if err != nil {
+		return nil, err
+	}
+	ignored, err := s.Ignored(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ignored) == 0 {
+		return messages, nil
+	}
+	hidden := make(map[string]bool, len(ignored))
+	for _, id := range ignored {
+		hidden[id] = true
+	}
+	visible := messages[:0]
+	for _, msg := range messages {
+		if !hidden[msg.UserID] {
+			visible = append(visible, msg)
+		}
+	}
+	return visible, nil
+}
+
+func (s *Service) load(tableID string) ([]Message, error) {
+	if s.redis == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return append([]Message(nil), s.history[tableID]...), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	entries, err := s.redis.LRange(ctx, historyKey(tableID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]Message, 0, len(entries))
+	for _, entry := range entries {
+		var msg Message
+		if err := json.Unmarshal([]byte(entry), &msg); err != nil {
+			continue
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+// Ignore hides another user's chat from a user
+func (s *Service) Ignore(userID, ignoredUserID string) error {
+	if userID == ignoredUserID {
+		return ErrIgnoreSelf
+	}
+	var users int64
+	if err := s.db.Model(&models.User{}).Where("id = ?", ignoredUserID).Count(&users).Error; err != nil {
+		return err
+	}
+	if users == 0 {
+		return ErrUserNotFound
+	}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.ChatIgnore{UserID: userID, IgnoredUserID: ignoredUserID}).Error
+}
+
+// Unignore shows another user's chat to a user again
+func (s *Service) Unignore(userID, ignoredUserID string) error {
+	return s.db.Where("user_id = ? AND ignored_user_id = ?", userID, ignoredUserID).
+		Delete(&models.ChatIgnore{}).Error
+}
+
+// Ignored returns the users a user ignores
+func (s *Service) Ignored(userID string) ([]string, error) {
+	var ignored []string
+	err := s.db.Model(&models.ChatIgnore{}).Where("user_id = ?", userID).
+		Order("created_at").Pluck("ignored_user_id", &ignored).Error
+	return ignored, err
+}
+
+// IgnoredBy returns the users who ignore a user, who should not be sent
+// their messages
+func (s *Service) IgnoredBy(userID string) (map[string]bool, error) {
+	var users []string
+	if err := s.db.Model(&models.ChatIgnore{}).Where("ignored_user_id = ?", userID).
+		Pluck("user_id", &users).Error; err != nil {
+		return nil, err
+	}
+	ignoredBy := make(map[string]bool, len(users))
+	for _, id := range users {
+		ignoredBy[id] = true
+	}
+	return ignoredBy, nil
+}
+
+// Mute stops a user from sending chat for a while. Muting a muted user
+// replaces their mute.
+func (s *Service) Mute(userID, adminID, reason string, duration time.Duration, now time.Time) (*models.ChatMute, error) {
+	if duration <= 0 {
+		return nil, ErrInvalidDuration
+	}
+	var users int64
+	if err := s.db.Model(&models.User{}).Where("id = ?", userID).Count(&users).Error; err != nil {
+		return nil, err
+	}
+	if users == 0 {
+		return nil, ErrUserNotFound
+	}
+
+	mute := &models.ChatMute{
+		UserID:     userID,
+		MutedUntil: now.Add(duration),
+		MutedBy:    adminID,
+		Reason:     reason,
+		CreatedAt:  now,
+	}
+	err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"muted_until", "muted_by", "reason", "created_at"}),
+	}).Create(mute).Error
+	if err != nil {
+		return nil, err
+	}
+	return mute, nil
+}
+
+// Unmute lifts a user's mute
+func (s *Service) Unmute(userID string) error {
+	return s.db.Where("user_id = ?", userID).Delete(&models.ChatMute{}).Error
+}
+
+// MutedUntil returns when a user's mute ends, or nil if they may chat
+func (s *Service) MutedUntil(userID string, now time.Time) (*time.Time, error) {
+	var mute models.ChatMute
+	err := s.db.Where("user_id = ?", userID).First(&mute).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !now.Before(mute.MutedUntil) {
+		return nil, nil
+	}
+	return &mute.MutedUntil, nil
+}
@@ -0,0 +1,171 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/moderation"
+	"poker-platform/backend/internal/testutil"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestService(t *testing.T, config Config) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.User{}, &models.ChatIgnore{}, &models.ChatMute{}, &models.ModerationItem{})
+	for _, stmt := range []string{
+		`INSERT INTO users (id, username) VALUES ('alice', 'alice'), ('bob', 'bob'), ('carol', 'carol')`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to create test schema: %v", err)
+		}
+	}
+	return NewService(db, nil, moderation.NewService(db, moderation.DefaultFilter()), config), db
+}
+
+func TestService_PostAndRecent(t *testing.T) {
+	config := DefaultConfig
+	config.HistorySize = 3
+	service, _ := setupTestService(t, config)
+	now := time.Now()
+
+	for i := 0; i < 4; i++ {
+		user := "alice"
+		if i%2 == 1 {
+			user = "bob"
+		}
+		if _, err := service.Post("table-1", user, fmt.Sprintf("message %d", i), now); err != nil {
+			t.Fatalf("Post failed: %v", err)
+		}
+	}
+
+	messages, err := service.Recent("table-1", "carol")
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(messages) != 3 || messages[0].Text != "message 1" || messages[2].Text != "message 3" {
+		t.Errorf("Expected the last 3 messages, oldest first, got %+v", messages)
+	}
+	if messages[0].Username != "bob" {
+		t.Errorf("Expected the sender's username, got %q", messages[0].Username)
+	}
+	if other, _ := service.Recent("table-2", "carol"); len(other) != 0 {
+		t.Errorf("Expected another table's history to be empty, got %+v", other)
+	}
+}
+
+func TestService_PostRefused(t *testing.T) {
+	service, db := setupTestService(t, DefaultConfig)
+	now := time.Now()
+
+	if _, err := service.Post("table-1", "alice", "<script>alert(1)</script>", now); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("Expected ErrInvalidMessage for markup, got %v", err)
+	}
+	if _, err := service.Post("table-1", "alice", "you shit", now); !errors.Is(err, moderation.ErrBlocked) {
+		t.Errorf("Expected ErrBlocked, got %v", err)
+	}
+
+	// Borderline text is sent and queued for review
+	if _, err := service.Post("table-1", "bob", "scunthorpe", now); err != nil {
+		t.Fatalf("Expected borderline text to be sent, got %v", err)
+	}
+	var queued []models.ModerationItem
+	db.Find(&queued)
+	if len(queued) != 1 || queued[0].Kind != moderation.KindChat || queued[0].SubjectID != "table-1" {
+		t.Errorf("Expected the message queued as chat for the table, got %+v", queued)
+	}
+}
+
+func TestService_RateLimit(t *testing.T) {
+	service, _ := setupTestService(t, DefaultConfig)
+	now := time.Now()
+
+	for i := 0; i < DefaultConfig.Burst; i++ {
+		if _, err := service.Post("table-1", "alice", "gg", now); err != nil {
+			t.Fatalf("Expected message %d within the burst, got %v", i+1, err)
+		}
+	}
+	if _, err := service.Post("table-1", "alice", "gg", now); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited past the burst, got %v", err)
+	}
+	if _, err := service.Post("table-1", "bob", "gg", now); err != nil {
+		t.Errorf("Expected another user to have their own limit, got %v", err)
+	}
+	if _, err := service.Post("table-1", "alice", "gg", now.Add(2*time.Second)); err != nil {
+		t.Errorf("Expected a message to be allowed once the limit refills, got %v", err)
+	}
+}
+
+func TestService_Mute(t *testing.T) {
+	service, _ := setupTestService(t, DefaultConfig)
+	now := time.Now()
+
+	if _, err := service.Mute("missing", "admin", "", time.Hour, now); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if _, err := service.Mute("alice", "admin", "spam", time.Hour, now); err != nil {
+		t.Fatalf("Mute failed: %v", err)
+	}
+	if _, err := service.Post("table-1", "alice", "hello", now); !errors.Is(err, ErrMuted) {
+		t.Errorf("Expected ErrMuted, got %v", err)
+	}
+	if _, err := service.Post("table-1", "alice", "hello", now.Add(time.Hour)); err != nil {
+		t.Errorf("Expected the mute to expire, got %v", err)
+	}
+
+	// Muting again replaces the mute
+	if _, err := service.Mute("alice", "admin", "again", 2*time.Hour, now); err != nil {
+		t.Fatalf("Second mute failed: %v", err)
+	}
+	if until, _ := service.MutedUntil("alice", now); until == nil || !until.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("Expected the second mute to replace the first, got %v", until)
+	}
+	if err := service.Unmute("alice"); err != nil {
+		t.Fatalf("Unmute failed: %v", err)
+	}
+	if until, _ := service.MutedUntil("alice", now); until != nil {
+		t.Errorf("Expected no mute after unmuting, got %v", until)
+	}
+}
+
+func TestService_Ignore(t *testing.T) {
+	service, _ := setupTestService(t, DefaultConfig)
+	now := time.Now()
+
+	if err := service.Ignore("alice", "alice"); !errors.Is(err, ErrIgnoreSelf) {
+		t.Errorf("Expected ErrIgnoreSelf, got %v", err)
+	}
+	if err := service.Ignore("alice", "missing"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if err := service.Ignore("alice", "bob"); err != nil {
+		t.Fatalf("Ignore failed: %v", err)
+	}
+	if err := service.Ignore("alice", "bob"); err != nil {
+		t.Errorf("Expected ignoring twice to be harmless, got %v", err)
+	}
+
+	service.Post("table-1", "bob", "hi", now)
+	service.Post("table-1", "carol", "hey", now)
+
+	if messages, _ := service.Recent("table-1", "alice"); len(messages) != 1 || messages[0].UserID != "carol" {
+		t.Errorf("Expected alice not to see bob's message, got %+v", messages)
+	}
+	if ignoredBy, _ := service.IgnoredBy("bob"); !ignoredBy["alice"] || len(ignoredBy) != 1 {
+		t.Errorf("Expected bob to be ignored by alice only, got %v", ignoredBy)
+	}
+
+	if err := service.Unignore("alice", "bob"); err != nil {
+		t.Fatalf("Unignore failed: %v", err)
+	}
+	if messages, _ := service.Recent("table-1", "alice"); len(messages) != 2 {
+		t.Errorf("Expected both messages after unignoring, got %+v", messages)
+	}
+}
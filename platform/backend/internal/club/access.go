@@ -0,0 +1,93 @@
+package club
+
+import (
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Club roles
+const (
+	RoleOwner   = "owner"
+	RoleManager = "manager"
+	RoleMember  = "member"
+)
+
+// Membership statuses
+const (
+	StatusInvited = "invited"
+	StatusActive  = "active"
+)
+
+// CanManage reports whether a role may invite members and create club tables and tournaments
+func CanManage(role string) bool {
+	return role == RoleOwner || role == RoleManager
+}
+
+// MemberRole returns the role of an active club member, or ErrNotClubMember
+func MemberRole(tx *gorm.DB, clubID, userID string) (string, error) {
+	var member models.ClubMember
+	err := tx.Where("club_id = ? AND user_id = ? AND status = ?", clubID, userID, StatusActive).First(&member).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", ErrNotClubMember
+	}
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// CheckAccess returns ErrNotClubMember if a club-scoped table or tournament is
+// not open to the user. A nil clubID means the resource is public.
+func CheckAccess(tx *gorm.DB, clubID *string, userID string) error {
+	if clubID == nil {
+		return nil
+	}
+	_, err := MemberRole(tx, *clubID, userID)
+	return err
+}
+
+// CheckManager returns an error unless the user may create tables and
+// tournaments in the club. A nil clubID means a public resource.
+func CheckManager(tx *gorm.DB, clubID *string, userID string) error {
+	if clubID == nil {
+		return nil
+	}
+	role, err := MemberRole(tx, *clubID, userID)
+	if err != nil {
+		return err
+	}
+	if !CanManage(role) {
+		return ErrNotClubManager
+	}
+	return nil
+}
+
+// CheckTableAccess checks a user may see a table. Tables without a database
+// row (such as ones still being set up) are treated as public.
+func CheckTableAccess(tx *gorm.DB, tableID, userID string) error {
+	var table models.Table
+	err := tx.Select("id", "club_id").Where("id = ?", tableID).First(&table).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return CheckAccess(tx, table.ClubID, userID)
+}
+
+// MemberIDs returns the user IDs of a club's active members
+func MemberIDs(tx *gorm.DB, clubID string) (map[string]bool, error) {
+	var userIDs []string
+	if err := tx.Model(&models.ClubMember{}).
+		Where("club_id = ? AND status = ?", clubID, StatusActive).
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+	members := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		members[userID] = true
+	}
+	return members, nil
+}
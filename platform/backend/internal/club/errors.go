@@ -0,0 +1,21 @@
+package club
+
+import "errors"
+
+// Club errors
+var (
+	ErrClubNotFound        = errors.New("club not found")
+	ErrInvalidClubName     = errors.New("club name must be between 3 and 100 characters")
+	ErrNotClubMember       = errors.New("not a member of this club")
+	ErrNotClubManager      = errors.New("only club owners and managers can perform this action")
+	ErrNotClubOwner        = errors.New("only the club owner can perform this action")
+	ErrAlreadyMember       = errors.New("user is already a member of this club")
+	ErrAlreadyInvited      = errors.New("user has already been invited to this club")
+	ErrInviteNotFound      = errors.New("no pending invite to this club")
+	ErrInvalidRole         = errors.New("role must be manager or member")
+	ErrCannotRemoveOwner   = errors.New("the club owner cannot be removed")
+	ErrCannotRemoveManager = errors.New("only the club owner can remove managers")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidClubAmount   = errors.New("chip amount must be between 1 and 10,000,000")
+	ErrNotEnoughClubChips  = errors.New("insufficient club chips")
+)
@@ -0,0 +1,337 @@
+package club
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Service handles club membership, lobbies and leaderboards
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService creates a new club service
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// Membership is a club as seen by one of its members or invitees
+type Membership struct {
+	models.Club
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
+
+// Member is a club member with their username
+type Member struct {
+	UserID   string     `json:"user_id"`
+	Username string     `json:"username"`
+	Role     string     `json:"role"`
+	Status   string     `json:"status"`
+	JoinedAt *time.Time `json:"joined_at,omitempty"`
+}
+
+// LeaderboardEntry is a member's results in club tables and tournaments
+type LeaderboardEntry struct {
+	UserID            string `json:"user_id"`
+	Username          string `json:"username"`
+	TournamentsPlayed int    `json:"tournaments_played"`
+	TournamentWins    int    `json:"tournament_wins"`
+	PrizeTotal        int    `json:"prize_total"`
+	NetWinnings       int    `json:"net_winnings"` // Tournament prizes minus buy-ins
+	HandsDealt        int    `json:"hands_dealt"`  // Hands dealt at club cash tables
+}
+
+// CreateClub creates a club with the creator as its owner
+func (s *Service) CreateClub(name, description, ownerID string) (*models.Club, error) {
+	name = strings.TrimSpace(name)
+	if len(name) < 3 || len(name) > 100 {
+		return nil, ErrInvalidClubName
+	}
+
+	now := time.Now()
+	club := &models.Club{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		OwnerID:     ownerID,
+		CreatedAt:   now,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(club).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.ClubMember{
+			ClubID:   club.ID,
+			UserID:   ownerID,
+			Role:     RoleOwner,
+			Status:   StatusActive,
+			JoinedAt: &now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return club, nil
+}
+
+// GetClub retrieves a club by ID
+func (s *Service) GetClub(clubID string) (*models.Club, error) {
+	var club models.Club
+	if err := s.db.Where("id = ?", clubID).First(&club).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrClubNotFound
+		}
+		return nil, err
+	}
+	return &club, nil
+}
+
+// ListUserClubs returns the clubs a user belongs to or has been invited to
+func (s *Service) ListUserClubs(userID string) ([]Membership, error) {
+	var memberships []Membership
+	err := s.db.Table("clubs c").
+		Select("c.*, cm.role, cm.status").
+		Joins("JOIN club_members cm ON cm.club_id = c.id").
+		Where("cm.user_id = ? AND c.deleted_at IS NULL", userID).
+		Order("c.name ASC").
+		Scan(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+// RequireMember returns the role of an active member, or ErrNotClubMember
+func (s *Service) RequireMember(clubID, userID string) (string, error) {
+	if _, err := s.GetClub(clubID); err != nil {
+		return "", err
+	}
+	return MemberRole(s.db, clubID, userID)
+}
+
+// Members returns a club's members and pending invites
+func (s *Service) Members(clubID string) ([]Member, error) {
+	var members []Member
+	err := s.db.Table("club_members cm").
+		Select("cm.user_id, u.username, cm.role, cm.status, cm.joined_at").
+		Joins("JOIN users u ON u.id = cm.user_id").
+		Where("cm.club_id = ?", clubID).
+		Order("cm.created_at ASC").
+		Scan(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// Invite invites a user to a club. Only owners and managers can invite.
+func (s *Service) Invite(clubID, inviterID, userID string) error {
+	role, err := s.RequireMember(clubID, inviterID)
+	if err != nil {
+		return err
+	}
+	if !CanManage(role) {
+		return ErrNotClubManager
+	}
+
+	var user models.User
+	if err := s.db.Select("id").Where("id = ?", userID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	var existing models.ClubMember
+	err = s.db.Where("club_id = ? AND user_id = ?", clubID, userID).First(&existing).Error
+	if err == nil {
+		if existing.Status == StatusActive {
+			return ErrAlreadyMember
+		}
+		return ErrAlreadyInvited
+	}
+	if err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	return s.db.Create(&models.ClubMember{
+		ClubID:    clubID,
+		UserID:    userID,
+		Role:      RoleMember,
+		Status:    StatusInvited,
+		InvitedBy: &inviterID,
+	}).Error
+}
+
+// AcceptInvite makes an invited user an active member
+func (s *Service) AcceptInvite(clubID, userID string) error {
+	result := s.db.Model(&models.ClubMember{}).
+		Where("club_id = ? AND user_id = ? AND status = ?", clubID, userID, StatusInvited).
+		Updates(map[string]interface{}{
+			"status":    StatusActive,
+			"joined_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInviteNotFound
+	}
+	return nil
+}
+
+// RemoveMember removes a member or declines/cancels an invite. Members can
+// remove themselves; managers can remove members; only the owner can remove
+// managers, and the owner cannot be removed.
+func (s *Service) RemoveMember(clubID, actorID, userID string) error {
+	var target models.ClubMember
+	if err := s.db.Where("club_id = ? AND user_id = ?", clubID, userID).First(&target).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrNotClubMember
+		}
+		return err
+	}
+	if target.Role == RoleOwner {
+		return ErrCannotRemoveOwner
+	}
+
+	if actorID != userID {
+		role, err := MemberRole(s.db, clubID, actorID)
+		if err != nil {
+			return err
+		}
+		if !CanManage(role) {
+			return ErrNotClubManager
+		}
+		if target.Role == RoleManager && role != RoleOwner {
+			return ErrCannotRemoveManager
+		}
+	}
+
+	return s.db.Delete(&target).Error
+}
+
+// SetRole promotes a member to manager or demotes a manager. Owner only.
+func (s *Service) SetRole(clubID, actorID, userID, role string) error {
+	if role != RoleManager && role != RoleMember {
+		return ErrInvalidRole
+	}
+	actorRole, err := s.RequireMember(clubID, actorID)
+	if err != nil {
+		return err
+	}
+	if actorRole != RoleOwner {
+		return ErrNotClubOwner
+	}
+
+	result := s.db.Model(&models.ClubMember{}).
+		Where("club_id = ? AND user_id = ? AND status = ? AND role <> ?", clubID, userID, StatusActive, RoleOwner).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotClubMember
+	}
+	return nil
+}
+
+// Tournaments returns a club's tournaments that have not finished
+func (s *Service) Tournaments(clubID string) ([]models.Tournament, error) {
+	var tournaments []models.Tournament
+	if err := s.db.Where("club_id = ? AND status NOT IN ?", clubID, []string{"completed", "cancelled"}).
+		Order("created_at DESC").
+		Find(&tournaments).Error; err != nil {
+		return nil, err
+	}
+	return tournaments, nil
+}
+
+// Leaderboard ranks a club's active members by tournament net winnings,
+// then tournament wins, then hands dealt at club tables
+func (s *Service) Leaderboard(clubID string) ([]LeaderboardEntry, error) {
+	var members []Member
+	if err := s.db.Table("club_members cm").
+		Select("cm.user_id, u.username").
+		Joins("JOIN users u ON u.id = cm.user_id").
+		Where("cm.club_id = ? AND cm.status = ?", clubID, StatusActive).
+		Scan(&members).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]*LeaderboardEntry, len(members))
+	for _, m := range members {
+		entries[m.UserID] = &LeaderboardEntry{UserID: m.UserID, Username: m.Username}
+	}
+
+	var tournamentResults []struct {
+		UserID            string
+		TournamentsPlayed int
+		TournamentWins    int
+		PrizeTotal        int
+		BuyIns            int
+	}
+	if err := s.db.Table("tournament_players tp").
+		Select(`tp.user_id, COUNT(*) AS tournaments_played,
+			SUM(CASE WHEN tp.position = 1 THEN 1 ELSE 0 END) AS tournament_wins,
+			SUM(tp.prize_amount) AS prize_total, SUM(t.buy_in) AS buy_ins`).
+		Joins("JOIN tournaments t ON t.id = tp.tournament_id").
+		Where("t.club_id = ? AND t.status = ? AND tp.deleted_at IS NULL", clubID, "completed").
+		Group("tp.user_id").
+		Scan(&tournamentResults).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range tournamentResults {
+		if entry, ok := entries[r.UserID]; ok {
+			entry.TournamentsPlayed = r.TournamentsPlayed
+			entry.TournamentWins = r.TournamentWins
+			entry.PrizeTotal = r.PrizeTotal
+			entry.NetWinnings = r.PrizeTotal - r.BuyIns
+		}
+	}
+
+	var handResults []struct {
+		UserID     string
+		HandsDealt int
+	}
+	if err := s.db.Table("table_seats ts").
+		Select("ts.user_id, SUM(ts.hands_dealt) AS hands_dealt").
+		Joins("JOIN tables t ON t.id = ts.table_id").
+		Where("t.club_id = ?", clubID).
+		Group("ts.user_id").
+		Scan(&handResults).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range handResults {
+		if entry, ok := entries[r.UserID]; ok {
+			entry.HandsDealt = r.HandsDealt
+		}
+	}
+
+	leaderboard := make([]LeaderboardEntry, 0, len(entries))
+	for _, entry := range entries {
+		leaderboard = append(leaderboard, *entry)
+	}
+	sort.Slice(leaderboard, func(i, j int) bool {
+		a, b := leaderboard[i], leaderboard[j]
+		if a.NetWinnings != b.NetWinnings {
+			return a.NetWinnings > b.NetWinnings
+		}
+		if a.TournamentWins != b.TournamentWins {
+			return a.TournamentWins > b.TournamentWins
+		}
+		if a.HandsDealt != b.HandsDealt {
+			return a.HandsDealt > b.HandsDealt
+		}
+		return a.Username < b.Username
+	})
+	return leaderboard, nil
+}
@@ -0,0 +1,147 @@
+package club
+
+import (
+	"errors"
+	"testing"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestService creates a club service backed by an in-memory SQLite database
+func setupTestService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}, &models.Club{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.ClubMember{})
+	return NewService(db), db
+}
+
+func createUser(t *testing.T, db *gorm.DB, username string) string {
+	user := models.User{ID: uuid.New().String(), Username: username, Email: username + "@example.com", PasswordHash: "x"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	return user.ID
+}
+
+func TestCreateClub_OwnerIsActiveMember(t *testing.T) {
+	service, db := setupTestService(t)
+	owner := createUser(t, db, "owner")
+
+	club, err := service.CreateClub("  Home Game  ", "Friday nights", owner)
+	if err != nil {
+		t.Fatalf("CreateClub failed: %v", err)
+	}
+	if club.Name != "Home Game" {
+		t.Errorf("Expected trimmed name, got %q", club.Name)
+	}
+
+	role, err := MemberRole(db, club.ID, owner)
+	if err != nil || role != RoleOwner {
+		t.Errorf("Expected owner role, got %q (%v)", role, err)
+	}
+
+	if _, err := service.CreateClub("ab", "", owner); !errors.Is(err, ErrInvalidClubName) {
+		t.Errorf("Expected ErrInvalidClubName, got %v", err)
+	}
+}
+
+func TestInviteAndAccept(t *testing.T) {
+	service, db := setupTestService(t)
+	owner := createUser(t, db, "owner")
+	friend := createUser(t, db, "friend")
+	stranger := createUser(t, db, "stranger")
+	club, _ := service.CreateClub("Home Game", "", owner)
+
+	if err := service.Invite(club.ID, friend, stranger); !errors.Is(err, ErrNotClubMember) {
+		t.Errorf("Expected non-member invite to fail with ErrNotClubMember, got %v", err)
+	}
+	if err := service.Invite(club.ID, owner, friend); err != nil {
+		t.Fatalf("Invite failed: %v", err)
+	}
+	if err := service.Invite(club.ID, owner, friend); !errors.Is(err, ErrAlreadyInvited) {
+		t.Errorf("Expected ErrAlreadyInvited, got %v", err)
+	}
+
+	// Invited users are not members until they accept
+	if err := CheckAccess(db, &club.ID, friend); !errors.Is(err, ErrNotClubMember) {
+		t.Errorf("Expected pending invite to have no access, got %v", err)
+	}
+	if err := service.AcceptInvite(club.ID, friend); err != nil {
+		t.Fatalf("AcceptInvite failed: %v", err)
+	}
+	if err := CheckAccess(db, &club.ID, friend); err != nil {
+		t.Errorf("Expected member to have access, got %v", err)
+	}
+	if err := service.AcceptInvite(club.ID, stranger); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("Expected ErrInviteNotFound, got %v", err)
+	}
+
+	// Plain members cannot invite or create club tables
+	if err := service.Invite(club.ID, friend, stranger); !errors.Is(err, ErrNotClubManager) {
+		t.Errorf("Expected ErrNotClubManager, got %v", err)
+	}
+	if err := CheckManager(db, &club.ID, friend); !errors.Is(err, ErrNotClubManager) {
+		t.Errorf("Expected ErrNotClubManager, got %v", err)
+	}
+	if err := CheckAccess(db, nil, stranger); err != nil {
+		t.Errorf("Expected public resources to be open, got %v", err)
+	}
+}
+
+func TestRolesAndRemoval(t *testing.T) {
+	service, db := setupTestService(t)
+	owner := createUser(t, db, "owner")
+	manager := createUser(t, db, "manager")
+	member := createUser(t, db, "member")
+	club, _ := service.CreateClub("Home Game", "", owner)
+
+	for _, userID := range []string{manager, member} {
+		if err := service.Invite(club.ID, owner, userID); err != nil {
+			t.Fatalf("Invite failed: %v", err)
+		}
+		if err := service.AcceptInvite(club.ID, userID); err != nil {
+			t.Fatalf("AcceptInvite failed: %v", err)
+		}
+	}
+
+	if err := service.SetRole(club.ID, manager, member, RoleManager); !errors.Is(err, ErrNotClubOwner) {
+		t.Errorf("Expected only the owner to set roles, got %v", err)
+	}
+	if err := service.SetRole(club.ID, owner, manager, RoleOwner); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("Expected ErrInvalidRole, got %v", err)
+	}
+	if err := service.SetRole(club.ID, owner, manager, RoleManager); err != nil {
+		t.Fatalf("SetRole failed: %v", err)
+	}
+	if err := CheckManager(db, &club.ID, manager); err != nil {
+		t.Errorf("Expected manager to manage the club, got %v", err)
+	}
+
+	if err := service.RemoveMember(club.ID, manager, owner); !errors.Is(err, ErrCannotRemoveOwner) {
+		t.Errorf("Expected ErrCannotRemoveOwner, got %v", err)
+	}
+	if err := service.RemoveMember(club.ID, member, manager); !errors.Is(err, ErrNotClubManager) {
+		t.Errorf("Expected ErrNotClubManager, got %v", err)
+	}
+	if err := service.RemoveMember(club.ID, manager, member); err != nil {
+		t.Fatalf("Manager removing member failed: %v", err)
+	}
+	if err := CheckAccess(db, &club.ID, member); !errors.Is(err, ErrNotClubMember) {
+		t.Errorf("Expected removed member to lose access, got %v", err)
+	}
+
+	memberships, err := service.ListUserClubs(manager)
+	if err != nil || len(memberships) != 1 || memberships[0].Role != RoleManager {
+		t.Errorf("Expected one manager membership, got %+v (%v)", memberships, err)
+	}
+}
@@ -0,0 +1,298 @@
+package club
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"poker-platform/backend/internal/ids"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Club chip transaction types
+const (
+	TxTypeIssue        = "club_issue"
+	TxTypeVoid         = "club_void"
+	TxTypeTableBuyIn   = "club_table_buy_in"
+	TxTypeTableCashOut = "club_table_cash_out"
+)
+
+// maxClubTransaction caps a single issue or void
+const maxClubTransaction = 10000000
+
+// ReconciliationReport checks a club's chip supply against where the chips are now.
+// Issued - Voided must equal InWallets + AtTables; anything else is a Discrepancy.
+type ReconciliationReport struct {
+	ClubID      string                 `json:"club_id"`
+	Issued      int                    `json:"issued"`
+	Voided      int                    `json:"voided"`
+	Outstanding int                    `json:"outstanding"` // Issued - Voided
+	InWallets   int                    `json:"in_wallets"`
+	AtTables    int                    `json:"at_tables"` // Bought in at open club table seats
+	Discrepancy int                    `json:"discrepancy"`
+	Members     []MemberReconciliation `json:"members"`
+	GeneratedAt time.Time              `json:"generated_at"`
+}
+
+// MemberReconciliation is one member's line in a reconciliation report
+type MemberReconciliation struct {
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	Balance    int    `json:"balance"`
+	Issued     int    `json:"issued"`
+	Voided     int    `json:"voided"`
+	TableNet   int    `json:"table_net"` // Cash-outs minus buy-ins at club tables
+	AtTables   int    `json:"at_tables"`
+	Difference int    `json:"difference"` // Ledger total minus balance; 0 when consistent
+}
+
+// adjustWallet changes a member's club chip balance and records a ledger entry.
+// The wallet row is locked for the update and created on first use.
+func adjustWallet(tx *gorm.DB, clubID, userID string, amount int, txType string, actorID, referenceID *string, description string) error {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.ClubWallet{ClubID: clubID, UserID: userID}).Error; err != nil {
+		return fmt.Errorf("failed to create club wallet: %w", err)
+	}
+
+	var wallet models.ClubWallet
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("club_id = ? AND user_id = ?", clubID, userID).
+		First(&wallet).Error; err != nil {
+		return fmt.Errorf("failed to load club wallet: %w", err)
+	}
+
+	balanceBefore := wallet.Balance
+	newBalance := balanceBefore + amount
+	if newBalance < 0 {
+		return ErrNotEnoughClubChips
+	}
+
+	if err := tx.Model(&wallet).Update("balance", newBalance).Error; err != nil {
+		return fmt.Errorf("failed to update club wallet: %w", err)
+	}
+
+	return tx.Create(&models.ClubChipTransaction{
+		ID:              ids.New(),
+		ClubID:          clubID,
+		UserID:          userID,
+		ActorID:         actorID,
+		Amount:          amount,
+		BalanceBefore:   balanceBefore,
+		BalanceAfter:    newBalance,
+		TransactionType: txType,
+		ReferenceID:     referenceID,
+		Description:     description,
+		CreatedAt:       time.Now(),
+	}).Error
+}
+
+// BuyIn moves club chips from a member's wallet onto a club table seat.
+// Use inside the transaction that creates the seat.
+func BuyIn(tx *gorm.DB, clubID, userID, tableID string, amount int) error {
+	if amount <= 0 {
+		return ErrInvalidClubAmount
+	}
+	return adjustWallet(tx, clubID, userID, -amount, TxTypeTableBuyIn, nil, &tableID, "Club table buy-in")
+}
+
+// CheckBuyIn reports whether BuyIn would succeed and returns the club chips
+// the member would have left, without moving any
+func CheckBuyIn(tx *gorm.DB, clubID, userID string, amount int) (int, error) {
+	if amount <= 0 {
+		return 0, ErrInvalidClubAmount
+	}
+	var wallet models.ClubWallet
+	err := tx.Where("club_id = ? AND user_id = ?", clubID, userID).First(&wallet).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("failed to load club wallet: %w", err)
+	}
+	if wallet.Balance < amount {
+		return 0, ErrNotEnoughClubChips
+	}
+	return wallet.Balance - amount, nil
+}
+
+// CashOut returns a member's chips from a club table to their club wallet.
+// Use inside the transaction that closes the seat.
+func CashOut(tx *gorm.DB, clubID, userID, tableID string, amount int) error {
+	if amount <= 0 {
+		return ErrInvalidClubAmount
+	}
+	return adjustWallet(tx, clubID, userID, amount, TxTypeTableCashOut, nil, &tableID, "Club table cash-out")
+}
+
+// requireOwner returns ErrNotClubOwner unless the user owns the club
+func (s *Service) requireOwner(clubID, userID string) error {
+	role, err := s.RequireMember(clubID, userID)
+	if err != nil {
+		return err
+	}
+	if role != RoleOwner {
+		return ErrNotClubOwner
+	}
+	return nil
+}
+
+// IssueChips credits club chips to an active member's wallet. Owner only.
+func (s *Service) IssueChips(clubID, ownerID, userID string, amount int, note string) error {
+	if amount <= 0 || amount > maxClubTransaction {
+		return ErrInvalidClubAmount
+	}
+	if err := s.requireOwner(clubID, ownerID); err != nil {
+		return err
+	}
+	if _, err := MemberRole(s.db, clubID, userID); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return adjustWallet(tx, clubID, userID, amount, TxTypeIssue, &ownerID, nil, note)
+	})
+}
+
+// VoidChips removes club chips from a member's wallet. Owner only. Members
+// who have left the club can still have their remaining chips voided.
+func (s *Service) VoidChips(clubID, ownerID, userID string, amount int, note string) error {
+	if amount <= 0 || amount > maxClubTransaction {
+		return ErrInvalidClubAmount
+	}
+	if err := s.requireOwner(clubID, ownerID); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return adjustWallet(tx, clubID, userID, -amount, TxTypeVoid, &ownerID, nil, note)
+	})
+}
+
+// Balance returns a member's club chip balance
+func (s *Service) Balance(clubID, userID string) (int, error) {
+	var wallet models.ClubWallet
+	err := s.db.Where("club_id = ? AND user_id = ?", clubID, userID).First(&wallet).Error
+	if err == gorm.ErrRecordNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return wallet.Balance, nil
+}
+
+// Ledger returns a club's chip transactions, newest first, optionally for one member
+func (s *Service) Ledger(clubID, userID string, limit, offset int) ([]models.ClubChipTransaction, int64, error) {
+	query := s.db.Model(&models.ClubChipTransaction{}).Where("club_id = ?", clubID)
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var transactions []models.ClubChipTransaction
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&transactions).Error; err != nil {
+		return nil, 0, err
+	}
+	return transactions, total, nil
+}
+
+// Reconcile builds a reconciliation report from the club's ledger, wallets and
+// open club table seats
+func (s *Service) Reconcile(clubID string) (*ReconciliationReport, error) {
+	var ledger []struct {
+		UserID          string
+		TransactionType string
+		Total           int
+	}
+	if err := s.db.Model(&models.ClubChipTransaction{}).
+		Select("user_id, transaction_type, SUM(amount) AS total").
+		Where("club_id = ?", clubID).
+		Group("user_id, transaction_type").
+		Scan(&ledger).Error; err != nil {
+		return nil, err
+	}
+
+	var wallets []struct {
+		UserID   string
+		Username string
+		Balance  int
+	}
+	if err := s.db.Table("club_wallets w").
+		Select("w.user_id, u.username, w.balance").
+		Joins("JOIN users u ON u.id = w.user_id").
+		Where("w.club_id = ?", clubID).
+		Scan(&wallets).Error; err != nil {
+		return nil, err
+	}
+
+	var seats []struct {
+		UserID string
+		Chips  int
+	}
+	if err := s.db.Table("table_seats ts").
+		Select("ts.user_id, SUM(ts.chips) AS chips").
+		Joins("JOIN tables t ON t.id = ts.table_id").
+		Where("t.club_id = ? AND ts.left_at IS NULL AND ts.deleted_at IS NULL", clubID).
+		Group("ts.user_id").
+		Scan(&seats).Error; err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]*MemberReconciliation)
+	member := func(userID string) *MemberReconciliation {
+		m, ok := members[userID]
+		if !ok {
+			m = &MemberReconciliation{UserID: userID}
+			members[userID] = m
+		}
+		return m
+	}
+
+	report := &ReconciliationReport{ClubID: clubID, GeneratedAt: time.Now()}
+	ledgerTotals := make(map[string]int)
+	for _, row := range ledger {
+		m := member(row.UserID)
+		ledgerTotals[row.UserID] += row.Total
+		switch row.TransactionType {
+		case TxTypeIssue:
+			m.Issued += row.Total
+			report.Issued += row.Total
+		case TxTypeVoid:
+			m.Voided -= row.Total
+			report.Voided -= row.Total
+		case TxTypeTableBuyIn, TxTypeTableCashOut:
+			m.TableNet += row.Total
+		}
+	}
+	for _, w := range wallets {
+		m := member(w.UserID)
+		m.Username = w.Username
+		m.Balance = w.Balance
+		report.InWallets += w.Balance
+	}
+	for _, seat := range seats {
+		member(seat.UserID).AtTables = seat.Chips
+		report.AtTables += seat.Chips
+	}
+
+	report.Outstanding = report.Issued - report.Voided
+	report.Discrepancy = report.Outstanding - report.InWallets - report.AtTables
+	report.Members = make([]MemberReconciliation, 0, len(members))
+	for userID, m := range members {
+		m.Difference = ledgerTotals[userID] - m.Balance
+		report.Members = append(report.Members, *m)
+	}
+	sort.Slice(report.Members, func(i, j int) bool {
+		return report.Members[i].Username < report.Members[j].Username
+	})
+	return report, nil
+}
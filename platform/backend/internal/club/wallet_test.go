@@ -0,0 +1,131 @@
+package club
+
+import (
+	"errors"
+	"testing"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"gorm.io/gorm"
+)
+
+// setupWalletTest creates a club with an owner and one active member
+func setupWalletTest(t *testing.T) (*Service, *gorm.DB, string, string, string) {
+	service, db := setupTestService(t)
+	if err := db.AutoMigrate(&models.ClubWallet{}, &models.ClubChipTransaction{}); err != nil {
+		t.Fatalf("Failed to migrate wallet tables: %v", err)
+	}
+	// Reconciliation joins open club table seats
+	testutil.Schema(t, db, &models.Table{}, &models.TableSeat{})
+
+	owner := createUser(t, db, "owner")
+	member := createUser(t, db, "member")
+	club, _ := service.CreateClub("Home Game", "", owner)
+	if err := service.Invite(club.ID, owner, member); err != nil {
+		t.Fatalf("Invite failed: %v", err)
+	}
+	if err := service.AcceptInvite(club.ID, member); err != nil {
+		t.Fatalf("AcceptInvite failed: %v", err)
+	}
+	return service, db, club.ID, owner, member
+}
+
+func TestIssueAndVoidChips(t *testing.T) {
+	service, db, clubID, owner, member := setupWalletTest(t)
+	stranger := createUser(t, db, "stranger")
+
+	var before models.User
+	db.Where("id = ?", member).First(&before)
+
+	if err := service.IssueChips(clubID, member, member, 1000, ""); !errors.Is(err, ErrNotClubOwner) {
+		t.Errorf("Expected only the owner to issue chips, got %v", err)
+	}
+	if err := service.IssueChips(clubID, owner, stranger, 1000, ""); !errors.Is(err, ErrNotClubMember) {
+		t.Errorf("Expected ErrNotClubMember for a non-member, got %v", err)
+	}
+	if err := service.IssueChips(clubID, owner, member, 0, ""); !errors.Is(err, ErrInvalidClubAmount) {
+		t.Errorf("Expected ErrInvalidClubAmount, got %v", err)
+	}
+
+	if err := service.IssueChips(clubID, owner, member, 1000, "Weekly allowance"); err != nil {
+		t.Fatalf("IssueChips failed: %v", err)
+	}
+	if err := service.VoidChips(clubID, owner, member, 1500, ""); !errors.Is(err, ErrNotEnoughClubChips) {
+		t.Errorf("Expected ErrNotEnoughClubChips, got %v", err)
+	}
+	if err := service.VoidChips(clubID, owner, member, 300, ""); err != nil {
+		t.Fatalf("VoidChips failed: %v", err)
+	}
+
+	if balance, _ := service.Balance(clubID, member); balance != 700 {
+		t.Errorf("Expected balance 700, got %d", balance)
+	}
+
+	// Club chips never touch the global balance
+	var user models.User
+	db.Where("id = ?", member).First(&user)
+	if user.Chips != before.Chips {
+		t.Errorf("Expected global chips to stay at %d, got %d", before.Chips, user.Chips)
+	}
+
+	transactions, total, err := service.Ledger(clubID, member, 0, 0)
+	if err != nil || total != 2 {
+		t.Fatalf("Expected 2 ledger entries, got %d (%v)", total, err)
+	}
+	for _, tx := range transactions {
+		if tx.BalanceAfter != tx.BalanceBefore+tx.Amount {
+			t.Errorf("Ledger entry %s does not balance: %+v", tx.TransactionType, tx)
+		}
+	}
+}
+
+func TestBuyInCashOutAndReconcile(t *testing.T) {
+	service, db, clubID, owner, member := setupWalletTest(t)
+	tableID := "table-1"
+	db.Exec("INSERT INTO tables (id, club_id) VALUES (?, ?)", tableID, clubID)
+
+	if err := service.IssueChips(clubID, owner, member, 1000, ""); err != nil {
+		t.Fatalf("IssueChips failed: %v", err)
+	}
+	if left, err := CheckBuyIn(db, clubID, member, 600); err != nil || left != 400 {
+		t.Errorf("Expected a check for 600 to leave 400, got %d, %v", left, err)
+	}
+	if _, err := CheckBuyIn(db, clubID, owner, 600); !errors.Is(err, ErrNotEnoughClubChips) {
+		t.Errorf("Expected ErrNotEnoughClubChips without a wallet, got %v", err)
+	}
+	if err := BuyIn(db, clubID, member, tableID, 2000); !errors.Is(err, ErrNotEnoughClubChips) {
+		t.Errorf("Expected ErrNotEnoughClubChips, got %v", err)
+	}
+	if err := BuyIn(db, clubID, member, tableID, 600); err != nil {
+		t.Fatalf("BuyIn failed: %v", err)
+	}
+	db.Exec("INSERT INTO table_seats (table_id, user_id, chips) VALUES (?, ?, ?)", tableID, member, 600)
+
+	report, err := service.Reconcile(clubID)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if report.Outstanding != 1000 || report.InWallets != 400 || report.AtTables != 600 || report.Discrepancy != 0 {
+		t.Errorf("Unexpected reconciliation while seated: %+v", report)
+	}
+
+	// Cashing out 800 after winning 200 from outside the club's supply shows up as a discrepancy
+	db.Exec("UPDATE table_seats SET left_at = CURRENT_TIMESTAMP")
+	if err := CashOut(db, clubID, member, tableID, 800); err != nil {
+		t.Fatalf("CashOut failed: %v", err)
+	}
+
+	report, err = service.Reconcile(clubID)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if report.InWallets != 1200 || report.AtTables != 0 || report.Discrepancy != -200 {
+		t.Errorf("Unexpected reconciliation after cash-out: %+v", report)
+	}
+	for _, m := range report.Members {
+		if m.UserID == member && (m.TableNet != 200 || m.Difference != 0) {
+			t.Errorf("Unexpected member line: %+v", m)
+		}
+	}
+}
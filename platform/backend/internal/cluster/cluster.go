@@ -0,0 +1,259 @@
+// Package cluster lets several backend instances share the tables. Every
+// table is owned by one instance, which runs its engine table; ownership is a
+// lock in Redis that the owner renews while it runs the table. Instances talk
+// over Redis pub/sub: table broadcasts go to every instance for the clients
+// connected to it, and game actions go to the instance that owns the table.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// OwnershipTTL is how long a table stays owned by an instance that stops
+	// renewing it, such as one that crashed
+	OwnershipTTL = 30 * time.Second
+	// RenewInterval is how often an instance renews the tables it owns
+	RenewInterval = OwnershipTTL / 3
+
+	broadcastChannel      = "cluster:all"
+	instanceChannelPrefix = "cluster:instance:"
+)
+
+// ErrNotOwner is returned when renewing a table another instance took over
+var ErrNotOwner = errors.New("table is not owned by this instance")
+
+// Message kinds
+const (
+	KindTableState   = "table_state"   // Engine state of a table, for the viewers connected to each instance
+	KindTableMessage = "table_message" // Encoded message for every client at a table
+	KindUserMessage  = "user_message"  // Encoded message for every connection of a user
+	KindGameAction   = "game_action"   // A player's action, for the table's owner
+	KindStateRequest = "state_request" // Asks a table's owner to send its state to the instance in From
+)
+
+// Envelope is one message between instances
+type Envelope struct {
+	Kind    string          `json:"kind"`
+	From    string          `json:"from"` // Instance that sent it
+	TableID string          `json:"table_id,omitempty"`
+	UserID  string          `json:"user_id,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// GameAction is the data of a KindGameAction envelope
+type GameAction struct {
+	Action    string `json:"action"`
+	Amount    int    `json:"amount"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Transport carries envelopes between instances
+type Transport interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe delivers what is published on the channels until ctx is done
+	Subscribe(ctx context.Context, channels []string, deliver func(channel string, payload []byte)) error
+}
+
+// Owners keeps which instance owns each table
+type Owners interface {
+	// Claim takes a table nobody owns, reporting false if an instance,
+	// this one included, already does
+	Claim(ctx context.Context, tableID string, ttl time.Duration) (bool, error)
+	// Renew keeps a table this instance owns for ttl more; ErrNotOwner if it lost it
+	Renew(ctx context.Context, tableID string, ttl time.Duration) error
+	Release(ctx context.Context, tableID string) error
+	// Owner returns the instance owning a table, or "" if nobody does
+	Owner(ctx context.Context, tableID string) (string, error)
+}
+
+// Node is this instance in the cluster
+type Node struct {
+	id        string
+	transport Transport
+	owners    Owners
+
+	mu      sync.Mutex
+	owned   map[string]time.Time // tableID -> when it was claimed
+	handler func(Envelope)
+}
+
+// NewNode creates the node of instance id
+func NewNode(id string, transport Transport, owners Owners) *Node {
+	return &Node{id: id, transport: transport, owners: owners, owned: make(map[string]time.Time)}
+}
+
+// ID returns the instance ID of the node
+func (n *Node) ID() string {
+	return n.id
+}
+
+// Handle sets what is done with envelopes from other instances. It must be
+// set before Run.
+func (n *Node) Handle(handler func(Envelope)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handler = handler
+}
+
+// Run delivers envelopes sent to this instance or to every instance until ctx
+// is done. Envelopes this instance broadcast itself are skipped.
+func (n *Node) Run(ctx context.Context) error {
+	channels := []string{broadcastChannel, instanceChannelPrefix + n.id}
+	return n.transport.Subscribe(ctx, channels, func(_ string, payload []byte) {
+		var env Envelope
+		if err := json.Unmarshal(payload, &env); err != nil {
+			log.Printf("[CLUSTER] Dropped malformed envelope: %v", err)
+			return
+		}
+		if env.From == n.id {
+			return
+		}
+		n.mu.Lock()
+		handler := n.handler
+		n.mu.Unlock()
+		if handler != nil {
+			handler(env)
+		}
+	})
+}
+
+// Broadcast sends an envelope to every other instance
+func (n *Node) Broadcast(env Envelope) error {
+	return n.publish(broadcastChannel, env)
+}
+
+// Send sends an envelope to one instance
+func (n *Node) Send(instanceID string, env Envelope) error {
+	return n.publish(instanceChannelPrefix+instanceID, env)
+}
+
+func (n *Node) publish(channel string, env Envelope) error {
+	env.From = n.id
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s envelope: %w", env.Kind, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := n.transport.Publish(ctx, channel, payload); err != nil {
+		return fmt.Errorf("failed to publish %s envelope: %w", env.Kind, err)
+	}
+	return nil
+}
+
+// Claim takes a table nobody owns for this instance, or keeps one it owns.
+// It reports false if another instance owns it or Redis can't be reached.
+func (n *Node) Claim(tableID string) bool {
+	if n.Owns(tableID) {
+		n.mu.Lock()
+		n.owned[tableID] = time.Now()
+		n.mu.Unlock()
+		return true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	claimed, err := n.owners.Claim(ctx, tableID, OwnershipTTL)
+	if err != nil {
+		log.Printf("[CLUSTER] Failed to claim table %s: %v", tableID, err)
+		return false
+	}
+	if !claimed {
+		return false
+	}
+	n.mu.Lock()
+	n.owned[tableID] = time.Now()
+	n.mu.Unlock()
+	return true
+}
+
+// Owns reports whether this instance owns a table
+func (n *Node) Owns(tableID string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, ok := n.owned[tableID]
+	return ok
+}
+
+// Owner returns the instance owning a table, or "" if nobody does
+func (n *Node) Owner(tableID string) (string, error) {
+	if n.Owns(tableID) {
+		return n.id, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return n.owners.Owner(ctx, tableID)
+}
+
+// Release gives up a table this instance owns
+func (n *Node) Release(tableID string) {
+	n.mu.Lock()
+	_, ok := n.owned[tableID]
+	delete(n.owned, tableID)
+	n.mu.Unlock()
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := n.owners.Release(ctx, tableID); err != nil {
+		log.Printf("[CLUSTER] Failed to release table %s: %v", tableID, err)
+	}
+}
+
+// ReleaseAll gives up every table this instance owns, so others can adopt
+// them without waiting for OwnershipTTL
+func (n *Node) ReleaseAll() {
+	n.mu.Lock()
+	tableIDs := make([]string, 0, len(n.owned))
+	for tableID := range n.owned {
+		tableIDs = append(tableIDs, tableID)
+	}
+	n.mu.Unlock()
+	for _, tableID := range tableIDs {
+		n.Release(tableID)
+	}
+}
+
+// Renew renews every table this instance owns. Tables no longer running here
+// are released, once they've had OwnershipTTL since their claim to start.
+// It returns the tables another instance took over, which this one must stop
+// running.
+func (n *Node) Renew(running func(tableID string) bool) (lost []string) {
+	n.mu.Lock()
+	owned := make(map[string]time.Time, len(n.owned))
+	for tableID, claimedAt := range n.owned {
+		owned[tableID] = claimedAt
+	}
+	n.mu.Unlock()
+
+	for tableID, claimedAt := range owned {
+		if !running(tableID) {
+			if time.Since(claimedAt) >= OwnershipTTL {
+				n.Release(tableID)
+			}
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		err := n.owners.Renew(ctx, tableID, OwnershipTTL)
+		cancel()
+		if errors.Is(err, ErrNotOwner) {
+			n.mu.Lock()
+			delete(n.owned, tableID)
+			n.mu.Unlock()
+			lost = append(lost, tableID)
+			continue
+		}
+		if err != nil {
+			// Kept until OwnershipTTL runs out; the next renewal may make it
+			log.Printf("[CLUSTER] Failed to renew table %s: %v", tableID, err)
+		}
+	}
+	return lost
+}
@@ -0,0 +1,199 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryHub stands in for Redis: a transport and owners shared by the nodes
+// of one test
+type memoryHub struct {
+	mu          sync.Mutex
+	subscribers map[string][]chan []byte
+	owners      map[string]string
+}
+
+func newMemoryHub() *memoryHub {
+	return &memoryHub{subscribers: map[string][]chan []byte{}, owners: map[string]string{}}
+}
+
+func (h *memoryHub) Publish(_ context.Context, channel string, payload []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, sub := range h.subscribers[channel] {
+		sub <- payload
+	}
+	return nil
+}
+
+func (h *memoryHub) Subscribe(ctx context.Context, channels []string, deliver func(string, []byte)) error {
+	sub := make(chan []byte, 16)
+	h.mu.Lock()
+	for _, channel := range channels {
+		h.subscribers[channel] = append(h.subscribers[channel], sub)
+	}
+	h.mu.Unlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case payload := <-sub:
+			deliver("", payload)
+		}
+	}
+}
+
+// hubOwners is the owners view of one instance
+type hubOwners struct {
+	hub *memoryHub
+	id  string
+}
+
+func (o hubOwners) Claim(_ context.Context, tableID string, _ time.Duration) (bool, error) {
+	o.hub.mu.Lock()
+	defer o.hub.mu.Unlock()
+	if o.hub.owners[tableID] != "" {
+		return false, nil
+	}
+	o.hub.owners[tableID] = o.id
+	return true, nil
+}
+
+func (o hubOwners) Renew(_ context.Context, tableID string, _ time.Duration) error {
+	o.hub.mu.Lock()
+	defer o.hub.mu.Unlock()
+	if o.hub.owners[tableID] != o.id {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+func (o hubOwners) Release(_ context.Context, tableID string) error {
+	o.hub.mu.Lock()
+	defer o.hub.mu.Unlock()
+	if o.hub.owners[tableID] == o.id {
+		delete(o.hub.owners, tableID)
+	}
+	return nil
+}
+
+func (o hubOwners) Owner(_ context.Context, tableID string) (string, error) {
+	o.hub.mu.Lock()
+	defer o.hub.mu.Unlock()
+	return o.hub.owners[tableID], nil
+}
+
+// startNode runs a node on the hub, returning what it receives
+func startNode(t *testing.T, hub *memoryHub, id string) (*Node, chan Envelope) {
+	node := NewNode(id, hub, hubOwners{hub: hub, id: id})
+	received := make(chan Envelope, 16)
+	node.Handle(func(env Envelope) { received <- env })
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go node.Run(ctx)
+	// Wait for the subscription before anything is published
+	require.Eventually(t, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return len(hub.subscribers[instanceChannelPrefix+id]) > 0
+	}, time.Second, time.Millisecond)
+	return node, received
+}
+
+func receive(t *testing.T, received chan Envelope) Envelope {
+	select {
+	case env := <-received:
+		return env
+	case <-time.After(time.Second):
+		t.Fatal("Expected an envelope")
+		return Envelope{}
+	}
+}
+
+func TestNode_Routing(t *testing.T) {
+	hub := newMemoryHub()
+	a, fromA := startNode(t, hub, "a")
+	b, fromB := startNode(t, hub, "b")
+	c, fromC := startNode(t, hub, "c")
+
+	require.NoError(t, a.Broadcast(Envelope{Kind: KindTableMessage, TableID: "table-1"}))
+	for _, received := range []chan Envelope{fromB, fromC} {
+		env := receive(t, received)
+		assert.Equal(t, KindTableMessage, env.Kind)
+		assert.Equal(t, "a", env.From)
+	}
+
+	require.NoError(t, b.Send(c.ID(), Envelope{Kind: KindGameAction, UserID: "alice"}))
+	env := receive(t, fromC)
+	assert.Equal(t, KindGameAction, env.Kind)
+	assert.Equal(t, "b", env.From)
+
+	// Nothing else arrived, and a never hears its own broadcast
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, fromA)
+	assert.Empty(t, fromB)
+	assert.Empty(t, fromC)
+}
+
+func TestNode_Ownership(t *testing.T) {
+	hub := newMemoryHub()
+	a := NewNode("a", hub, hubOwners{hub: hub, id: "a"})
+	b := NewNode("b", hub, hubOwners{hub: hub, id: "b"})
+
+	assert.True(t, a.Claim("table-1"))
+	assert.True(t, a.Claim("table-1"), "a keeps a table it owns")
+	assert.False(t, b.Claim("table-1"))
+
+	owner, err := b.Owner("table-1")
+	require.NoError(t, err)
+	assert.Equal(t, "a", owner)
+	assert.True(t, a.Owns("table-1"))
+	assert.False(t, b.Owns("table-1"))
+
+	a.Release("table-1")
+	owner, err = b.Owner("table-1")
+	require.NoError(t, err)
+	assert.Empty(t, owner)
+	assert.True(t, b.Claim("table-1"))
+}
+
+func TestNode_Renew(t *testing.T) {
+	hub := newMemoryHub()
+	a := NewNode("a", hub, hubOwners{hub: hub, id: "a"})
+	running := map[string]bool{"table-1": true, "table-2": true, "table-3": false}
+	for tableID := range running {
+		require.True(t, a.Claim(tableID))
+	}
+
+	// table-2 expired and b took it over
+	hub.mu.Lock()
+	hub.owners["table-2"] = "b"
+	hub.mu.Unlock()
+
+	lost := a.Renew(func(tableID string) bool { return running[tableID] })
+	assert.Equal(t, []string{"table-2"}, lost)
+	assert.True(t, a.Owns("table-1"))
+	assert.False(t, a.Owns("table-2"))
+	// table-3 isn't running yet, but was only just claimed
+	assert.True(t, a.Owns("table-3"))
+
+	a.mu.Lock()
+	a.owned["table-3"] = time.Now().Add(-OwnershipTTL)
+	a.mu.Unlock()
+	assert.Empty(t, a.Renew(func(tableID string) bool { return running[tableID] }))
+	assert.False(t, a.Owns("table-3"))
+	owner, err := a.Owner("table-3")
+	require.NoError(t, err)
+	assert.Empty(t, owner)
+
+	a.ReleaseAll()
+	assert.False(t, a.Owns("table-1"))
+	owner, err = a.Owner("table-1")
+	require.NoError(t, err)
+	assert.Empty(t, owner)
+}
@@ -0,0 +1,120 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/locks"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisTransport carries envelopes over Redis pub/sub
+type RedisTransport struct {
+	client *redis.Client
+}
+
+// NewRedisTransport creates a transport on a Redis client
+func NewRedisTransport(client *redis.Client) *RedisTransport {
+	return &RedisTransport{client: client}
+}
+
+// Publish sends a payload to a channel
+func (t *RedisTransport) Publish(ctx context.Context, channel string, payload []byte) error {
+	return t.client.Publish(ctx, channel, payload).Err()
+}
+
+// Subscribe delivers what is published on the channels until ctx is done
+func (t *RedisTransport) Subscribe(ctx context.Context, channels []string, deliver func(channel string, payload []byte)) error {
+	sub := t.client.Subscribe(ctx, channels...)
+	defer sub.Close()
+	if _, err := sub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			deliver(msg.Channel, []byte(msg.Payload))
+		}
+	}
+}
+
+// LockOwners keeps table ownership as locks of the LockManager, whose values
+// name the instance holding them
+type LockOwners struct {
+	manager *locks.LockManager
+
+	mu    sync.Mutex
+	locks map[string]*locks.Lock
+}
+
+// NewLockOwners creates owners on a lock manager
+func NewLockOwners(manager *locks.LockManager) *LockOwners {
+	return &LockOwners{manager: manager, locks: make(map[string]*locks.Lock)}
+}
+
+func ownerKey(tableID string) string {
+	return "table-owner:" + tableID
+}
+
+// Claim takes a table nobody owns
+func (o *LockOwners) Claim(ctx context.Context, tableID string, ttl time.Duration) (bool, error) {
+	lock, err := o.manager.TryAcquireLock(ctx, ownerKey(tableID), ttl)
+	if errors.Is(err, locks.ErrLockAlreadyHeld) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	o.mu.Lock()
+	o.locks[tableID] = lock
+	o.mu.Unlock()
+	return true, nil
+}
+
+// Renew keeps a table owned for ttl more
+func (o *LockOwners) Renew(ctx context.Context, tableID string, ttl time.Duration) error {
+	o.mu.Lock()
+	lock := o.locks[tableID]
+	o.mu.Unlock()
+	if lock == nil {
+		return ErrNotOwner
+	}
+	err := lock.Extend(ctx, ttl)
+	if errors.Is(err, locks.ErrLockNotHeld) {
+		o.mu.Lock()
+		delete(o.locks, tableID)
+		o.mu.Unlock()
+		return ErrNotOwner
+	}
+	return err
+}
+
+// Release gives up a table
+func (o *LockOwners) Release(ctx context.Context, tableID string) error {
+	o.mu.Lock()
+	lock := o.locks[tableID]
+	delete(o.locks, tableID)
+	o.mu.Unlock()
+	if lock == nil {
+		return nil
+	}
+	if err := lock.Release(ctx); err != nil && !errors.Is(err, locks.ErrLockNotHeld) {
+		return err
+	}
+	return nil
+}
+
+// Owner returns the instance owning a table, or "" if nobody does
+func (o *LockOwners) Owner(ctx context.Context, tableID string) (string, error) {
+	return o.manager.LockHolder(ctx, ownerKey(tableID))
+}
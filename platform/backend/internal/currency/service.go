@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"poker-platform/backend/internal/ids"
 	"poker-platform/backend/internal/models"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -79,6 +79,12 @@ func (s *Service) ValidateAmount(amount int) error {
 // deductChipsInTx removes chips from a user's balance within an existing transaction
 // Internal function - use DeductChips for standalone operations
 func (s *Service) deductChipsInTx(ctx context.Context, tx *gorm.DB, userID string, amount int, txType TransactionType, refID string, description string) error {
+	return s.debitInTx(ctx, tx, userID, amount, txType, refID, description, false)
+}
+
+// debitInTx removes chips from a balance within an existing transaction. Only
+// the house may overdraw, when it pays out more than it has taken in.
+func (s *Service) debitInTx(ctx context.Context, tx *gorm.DB, userID string, amount int, txType TransactionType, refID string, description string, overdraft bool) error {
 	// Get current balance with row lock
 	var user models.User
 	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
@@ -89,8 +95,13 @@ func (s *Service) deductChipsInTx(ctx context.Context, tx *gorm.DB, userID strin
 		return fmt.Errorf("failed to lock user record: %w", err)
 	}
 
+	// A frozen account can still be paid, but nothing can be taken out of it
+	if user.FrozenAt != nil {
+		return ErrAccountFrozen
+	}
+
 	// Check sufficient balance
-	if user.Chips < amount {
+	if !overdraft && user.Chips < amount {
 		return ErrInsufficientChips
 	}
 
@@ -104,7 +115,7 @@ func (s *Service) deductChipsInTx(ctx context.Context, tx *gorm.DB, userID strin
 
 	// Create audit record
 	transaction := Transaction{
-		ID:              uuid.New().String(),
+		ID:              ids.New(),
 		UserID:          userID,
 		Amount:          -amount, // Negative for deduction
 		BalanceBefore:   balanceBefore,
@@ -135,6 +146,29 @@ func (s *Service) DeductChips(ctx context.Context, userID string, amount int, tx
 	})
 }
 
+// CheckDeduct runs DeductChips' checks without taking anything and returns
+// the balance the user would be left with
+func (s *Service) CheckDeduct(ctx context.Context, userID string, amount int) (int, error) {
+	if err := s.ValidateAmount(amount); err != nil {
+		return 0, err
+	}
+
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, ErrUserNotFound
+		}
+		return 0, fmt.Errorf("failed to get balance: %w", err)
+	}
+	if user.FrozenAt != nil {
+		return 0, ErrAccountFrozen
+	}
+	if user.Chips < amount {
+		return 0, ErrInsufficientChips
+	}
+	return user.Chips - amount, nil
+}
+
 // addChipsInTx adds chips to a user's balance within an existing transaction
 // Internal function - use AddChips for standalone operations
 func (s *Service) addChipsInTx(ctx context.Context, tx *gorm.DB, userID string, amount int, txType TransactionType, refID string, description string) error {
@@ -158,7 +192,7 @@ func (s *Service) addChipsInTx(ctx context.Context, tx *gorm.DB, userID string,
 
 	// Create audit record
 	transaction := Transaction{
-		ID:              uuid.New().String(),
+		ID:              ids.New(),
 		UserID:          userID,
 		Amount:          amount, // Positive for addition
 		BalanceBefore:   balanceBefore,
@@ -209,6 +243,44 @@ func (s *Service) AddChipsWithTx(ctx context.Context, tx *gorm.DB, userID string
 	return s.addChipsInTx(ctx, tx, userID, amount, txType, refID, description)
 }
 
+// CoverOverlayWithTx takes a tournament's overlay, the part of its guaranteed
+// prize pool the buy-ins fell short of, from the operator account. The house
+// covers it whatever its balance, so the account may go negative.
+func (s *Service) CoverOverlayWithTx(ctx context.Context, tx *gorm.DB, amount int, tournamentID string, description string) error {
+	if err := s.ValidateAmount(amount); err != nil {
+		return err
+	}
+	return s.debitInTx(ctx, tx, OperatorAccountID, amount, TxTypeTournamentOverlay, tournamentID, description, true)
+}
+
+// ReverseFeeWithTx takes a refunded entry fee back from the operator account.
+// A player always gets their fee back, so, as after an overlay, the account
+// may go negative.
+func (s *Service) ReverseFeeWithTx(ctx context.Context, tx *gorm.DB, amount int, tournamentID string, description string) error {
+	if err := s.ValidateAmount(amount); err != nil {
+		return err
+	}
+	return s.debitInTx(ctx, tx, OperatorAccountID, amount, TxTypeTournamentFeeRefund, tournamentID, description, true)
+}
+
+// CoverRewardWithTx takes the value of a reward the house gives a player, such
+// as a tournament ticket, from the operator account. Like an overlay, the
+// account may go negative.
+func (s *Service) CoverRewardWithTx(ctx context.Context, tx *gorm.DB, amount int, txType TransactionType, refID string, description string) error {
+	if err := s.ValidateAmount(amount); err != nil {
+		return err
+	}
+	return s.debitInTx(ctx, tx, OperatorAccountID, amount, txType, refID, description, true)
+}
+
+// PayRewardWithTx pays a player a reward in chips from the operator account
+func (s *Service) PayRewardWithTx(ctx context.Context, tx *gorm.DB, userID string, amount int, txType TransactionType, refID string, description string) error {
+	if err := s.CoverRewardWithTx(ctx, tx, amount, txType, refID, description); err != nil {
+		return err
+	}
+	return s.addChipsInTx(ctx, tx, userID, amount, txType, refID, description)
+}
+
 // TransferChips transfers chips from one user to another atomically
 // CRITICAL: Uses a single transaction to ensure atomicity - if either operation fails,
 // both are rolled back, preventing money loss or duplication
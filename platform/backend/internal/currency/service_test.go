@@ -2,8 +2,10 @@ package currency
 
 import (
 	"context"
+	"errors"
 	"poker-platform/backend/internal/models"
 	"testing"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -227,6 +229,59 @@ func TestAddChips_Success(t *testing.T) {
 	}
 }
 
+// TestDeductChips_FrozenAccount verifies a frozen account can be paid but not charged
+func TestDeductChips_FrozenAccount(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewService(db)
+	ctx := context.Background()
+
+	createTestUser(t, db, "user1", 1000)
+	if err := db.Model(&models.User{}).Where("id = ?", "user1").Update("frozen_at", time.Now()).Error; err != nil {
+		t.Fatalf("Failed to freeze user: %v", err)
+	}
+
+	err := service.DeductChips(ctx, "user1", 200, TxTypeCashGameBuyIn, "test-ref", "Cash game buy-in")
+	if !errors.Is(err, ErrAccountFrozen) {
+		t.Fatalf("Expected ErrAccountFrozen, got %v", err)
+	}
+	if balance := getBalance(t, db, "user1"); balance != 1000 {
+		t.Errorf("Expected balance 1000, got %d", balance)
+	}
+
+	if err := service.AddChips(ctx, "user1", 300, TxTypeCashGameCashOut, "test-ref", "Cash out"); err != nil {
+		t.Fatalf("Expected a frozen account to be paid, got %v", err)
+	}
+}
+
+// TestCheckDeduct verifies a check reports the outcome without moving chips
+func TestCheckDeduct(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewService(db)
+	ctx := context.Background()
+
+	createTestUser(t, db, "user1", 1000)
+
+	after, err := service.CheckDeduct(ctx, "user1", 300)
+	if err != nil || after != 700 {
+		t.Errorf("Expected a 700 balance after 300, got %d, %v", after, err)
+	}
+	if _, err := service.CheckDeduct(ctx, "user1", 1500); !errors.Is(err, ErrInsufficientChips) {
+		t.Errorf("Expected ErrInsufficientChips, got %v", err)
+	}
+	if _, err := service.CheckDeduct(ctx, "missing", 100); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if balance := getBalance(t, db, "user1"); balance != 1000 {
+		t.Errorf("Expected balance 1000, got %d", balance)
+	}
+
+	var txCount int64
+	db.Model(&Transaction{}).Count(&txCount)
+	if txCount != 0 {
+		t.Errorf("Expected no transaction records, got %d", txCount)
+	}
+}
+
 // TestConcurrentTransfers verifies thread safety
 // NOTE: Skipped because in-memory SQLite doesn't support true concurrent connections
 // In production with PostgreSQL/MySQL, row-level locking will handle concurrency correctly
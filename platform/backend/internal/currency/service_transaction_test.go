@@ -241,3 +241,55 @@ func TestCoordinatedOperations_PartialFailure(t *testing.T) {
 		t.Errorf("Expected 0 transaction records after rollback, got %d", txCount)
 	}
 }
+
+// TestCoverOverlayWithTx verifies the house covers an overlay beyond its balance
+func TestCoverOverlayWithTx(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewService(db)
+	ctx := context.Background()
+
+	createTestUser(t, db, OperatorAccountID, 100)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return service.CoverOverlayWithTx(ctx, tx, 300, "tournament-1", "Overlay")
+	})
+	if err != nil {
+		t.Fatalf("CoverOverlayWithTx failed: %v", err)
+	}
+
+	if balance := getBalance(t, db, OperatorAccountID); balance != -200 {
+		t.Errorf("Expected the house balance to go to -200, got %d", balance)
+	}
+
+	var transaction Transaction
+	if err := db.First(&transaction).Error; err != nil {
+		t.Fatalf("Failed to load ledger entry: %v", err)
+	}
+	if transaction.TransactionType != TxTypeTournamentOverlay || transaction.Amount != -300 {
+		t.Errorf("Expected a -300 overlay entry, got %s %d", transaction.TransactionType, transaction.Amount)
+	}
+}
+
+// TestPayRewardWithTx verifies a reward moves from the house to the player
+func TestPayRewardWithTx(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewService(db)
+	ctx := context.Background()
+
+	createTestUser(t, db, OperatorAccountID, 100)
+	createTestUser(t, db, "player-1", 1000)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return service.PayRewardWithTx(ctx, tx, "player-1", 250, TxTypeLevelReward, "player-1", "Level 2 reward")
+	})
+	if err != nil {
+		t.Fatalf("PayRewardWithTx failed: %v", err)
+	}
+
+	if balance := getBalance(t, db, "player-1"); balance != 1250 {
+		t.Errorf("Expected the player to have 1250, got %d", balance)
+	}
+	if balance := getBalance(t, db, OperatorAccountID); balance != -150 {
+		t.Errorf("Expected the house balance to go to -150, got %d", balance)
+	}
+}
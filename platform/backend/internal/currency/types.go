@@ -18,16 +18,31 @@ const (
 	BasisPointsMax   = 10000
 )
 
+// OperatorAccountID is the house account that tournament fees are credited to.
+// It is created by migration 013 and cannot log in.
+const OperatorAccountID = "00000000-0000-0000-0000-000000000000"
+
 // TransactionType represents the type of chip transaction
 type TransactionType string
 
 const (
-	TxTypeTournamentBuyIn   TransactionType = "tournament_buy_in"
-	TxTypeTournamentPrize   TransactionType = "tournament_prize"
-	TxTypeTournamentRefund  TransactionType = "tournament_refund"
-	TxTypeCashGameBuyIn     TransactionType = "cash_game_buy_in"
-	TxTypeCashGameCashOut   TransactionType = "cash_game_cash_out"
-	TxTypeAdminAdjustment   TransactionType = "admin_adjustment"
+	TxTypeTournamentBuyIn          TransactionType = "tournament_buy_in"
+	TxTypeTournamentPrize          TransactionType = "tournament_prize"
+	TxTypeTournamentRefund         TransactionType = "tournament_refund"
+	TxTypeTournamentLateCancelFee  TransactionType = "tournament_late_cancel_fee"
+	TxTypeTournamentFee            TransactionType = "tournament_fee"
+	TxTypeTournamentFeeRefund      TransactionType = "tournament_fee_refund"
+	TxTypeTournamentAbortRefund    TransactionType = "tournament_abort_refund"
+	TxTypeTournamentOverlay        TransactionType = "tournament_overlay"
+	TxTypeTournamentBounty         TransactionType = "tournament_bounty"
+	TxTypeCashGameBuyIn            TransactionType = "cash_game_buy_in"
+	TxTypeCashGameCashOut          TransactionType = "cash_game_cash_out"
+	TxTypeCashGameRebuy            TransactionType = "cash_game_rebuy"
+	TxTypeAdminAdjustment          TransactionType = "admin_adjustment"
+	TxTypeLevelReward              TransactionType = "level_reward"
+	TxTypeMissionReward            TransactionType = "mission_reward"
+	TxTypeReferralReward           TransactionType = "referral_reward"
+	TxTypeBotBankroll              TransactionType = "bot_bankroll"
 )
 
 // Transaction represents a chip transaction record
@@ -56,4 +71,5 @@ var (
 	ErrExceedsMaximum    = errors.New("amount exceeds maximum transaction limit")
 	ErrUserNotFound      = errors.New("user not found")
 	ErrBalanceMismatch   = errors.New("balance mismatch detected")
+	ErrAccountFrozen     = errors.New("account is frozen")
 )
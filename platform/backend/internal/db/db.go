@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"poker-platform/backend/internal/chaos"
 	"poker-platform/backend/internal/migrations"
 
 	"gorm.io/driver/mysql"
@@ -46,6 +47,10 @@ func New(cfg Config) (*DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if err := chaos.InstrumentDB(db); err != nil {
+		return nil, fmt.Errorf("failed to add fault injection: %w", err)
+	}
+
 	// Get underlying SQL DB to configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
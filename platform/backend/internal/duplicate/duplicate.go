@@ -0,0 +1,36 @@
+// Package duplicate runs duplicate poker, where the same deals are played at
+// several tables and players are scored against everyone who held the same
+// cards rather than on raw chip counts. That takes most of the luck of the
+// cards out of the result.
+package duplicate
+
+import (
+	"errors"
+	"math/rand"
+
+	"poker-engine/engine"
+)
+
+// Duplicate errors
+var (
+	ErrTooFewTables = errors.New("duplicate poker needs at least two tables")
+	ErrUnevenField  = errors.New("players must fill every seat of every table")
+	ErrInvalidDeals = errors.New("deal count must be positive")
+)
+
+// Deals draws the deals of an event. The button moves one seat per deal, as
+// it would at a normal table, and every deal starts from the same stack.
+func Deals(count, seatsPerTable, stack int, rng *rand.Rand) ([]engine.DuplicateDeal, error) {
+	if count <= 0 || seatsPerTable < 2 {
+		return nil, ErrInvalidDeals
+	}
+	deals := make([]engine.DuplicateDeal, count)
+	for i := range deals {
+		deals[i] = engine.DuplicateDeal{
+			Seed:   rng.Int63(),
+			Button: i % seatsPerTable,
+			Stack:  stack,
+		}
+	}
+	return deals, nil
+}
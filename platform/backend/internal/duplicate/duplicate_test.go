@@ -0,0 +1,98 @@
+package duplicate
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	pokerModels "poker-engine/models"
+)
+
+func TestDeals(t *testing.T) {
+	deals, err := Deals(4, 3, 1500, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Deals failed: %v", err)
+	}
+	for i, deal := range deals {
+		if deal.Button != i%3 || deal.Stack != 1500 {
+			t.Errorf("Deal %d: expected button %d and stack 1500, got %+v", i, i%3, deal)
+		}
+	}
+	if deals[0].Seed == deals[1].Seed {
+		t.Error("Expected every deal to have its own seed")
+	}
+
+	again, _ := Deals(4, 3, 1500, rand.New(rand.NewSource(1)))
+	if !reflect.DeepEqual(deals, again) {
+		t.Error("Expected the same source to draw the same deals")
+	}
+	if _, err := Deals(0, 3, 1500, rand.New(rand.NewSource(1))); !errors.Is(err, ErrInvalidDeals) {
+		t.Errorf("Expected ErrInvalidDeals, got %v", err)
+	}
+}
+
+func TestPair(t *testing.T) {
+	players := []string{"a", "b", "c", "d", "e", "f"}
+
+	seatings, err := Pair(players, 3, 0)
+	if err != nil {
+		t.Fatalf("Pair failed: %v", err)
+	}
+	expected := []Seating{
+		{Table: 0, Players: []string{"a", "b", "c"}},
+		{Table: 1, Players: []string{"f", "d", "e"}},
+	}
+	if !reflect.DeepEqual(seatings, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, seatings)
+	}
+
+	next, _ := Pair(players, 3, 1)
+	if next[0].Players[0] != "c" {
+		t.Errorf("Expected seats to rotate each round, got %v", next[0].Players)
+	}
+
+	if _, err := Pair(players, 4, 0); !errors.Is(err, ErrUnevenField) {
+		t.Errorf("Expected ErrUnevenField, got %v", err)
+	}
+	if _, err := Pair(players[:3], 3, 0); !errors.Is(err, ErrTooFewTables) {
+		t.Errorf("Expected ErrTooFewTables, got %v", err)
+	}
+}
+
+func TestScore(t *testing.T) {
+	// Seat 0 held the best hand on deal 0: a won 300 with it where f won 100
+	results := []Result{
+		{Deal: 0, Table: 0, Seat: 0, PlayerID: "a", ChipDelta: 300},
+		{Deal: 0, Table: 0, Seat: 1, PlayerID: "b", ChipDelta: -300},
+		{Deal: 0, Table: 1, Seat: 0, PlayerID: "f", ChipDelta: 100},
+		{Deal: 0, Table: 1, Seat: 1, PlayerID: "d", ChipDelta: -100},
+		{Deal: 1, Table: 0, Seat: 0, PlayerID: "a", ChipDelta: 50}, // Only played at one table
+	}
+
+	scores := Score(results)
+	expected := []PlayerScore{
+		{PlayerID: "a", Score: 100, Deals: 2},
+		{PlayerID: "d", Score: 100, Deals: 1},
+		{PlayerID: "b", Score: -100, Deals: 1},
+		{PlayerID: "f", Score: -100, Deals: 1},
+	}
+	if !reflect.DeepEqual(scores, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, scores)
+	}
+}
+
+func TestHandResults(t *testing.T) {
+	players := []*pokerModels.Player{
+		pokerModels.NewPlayer("a", "A", 0, 1400),
+		nil,
+		pokerModels.NewPlayer("c", "C", 2, 600),
+	}
+	expected := []Result{
+		{Deal: 3, Table: 1, Seat: 0, PlayerID: "a", ChipDelta: 400},
+		{Deal: 3, Table: 1, Seat: 2, PlayerID: "c", ChipDelta: -400},
+	}
+	if results := HandResults(3, 1, 1000, players); !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, results)
+	}
+}
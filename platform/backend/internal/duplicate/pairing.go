@@ -0,0 +1,32 @@
+package duplicate
+
+// Seating is one table of a duplicate round, listing its players by seat
+type Seating struct {
+	Table   int      `json:"table"`
+	Players []string `json:"players"`
+}
+
+// Pair splits players into tables of seatsPerTable and seats them for a
+// round. Every table plays the same deals, and table t turns its group
+// t+round seats clockwise, so the same group holds different cards at each
+// table and a player moves to a new seat every round.
+func Pair(players []string, seatsPerTable, round int) ([]Seating, error) {
+	if seatsPerTable < 2 || len(players)%seatsPerTable != 0 {
+		return nil, ErrUnevenField
+	}
+	tables := len(players) / seatsPerTable
+	if tables < 2 {
+		return nil, ErrTooFewTables
+	}
+
+	seatings := make([]Seating, tables)
+	for t := range seatings {
+		group := players[t*seatsPerTable : (t+1)*seatsPerTable]
+		seats := make([]string, seatsPerTable)
+		for i, playerID := range group {
+			seats[(i+t+round)%seatsPerTable] = playerID
+		}
+		seatings[t] = Seating{Table: t, Players: seats}
+	}
+	return seatings, nil
+}
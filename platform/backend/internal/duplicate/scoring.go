@@ -0,0 +1,82 @@
+package duplicate
+
+import (
+	"sort"
+
+	pokerModels "poker-engine/models"
+)
+
+// Result is what the player in one seat won or lost on one deal at one table
+type Result struct {
+	Deal      int    `json:"deal"`
+	Table     int    `json:"table"`
+	Seat      int    `json:"seat"`
+	PlayerID  string `json:"player_id"`
+	ChipDelta int    `json:"chip_delta"`
+}
+
+// PlayerScore is a player's standing in a duplicate event
+type PlayerScore struct {
+	PlayerID string  `json:"player_id"`
+	Score    float64 `json:"score"` // Chips won beyond the average of everyone who held the same cards
+	Deals    int     `json:"deals"`
+}
+
+// HandResults turns the seats of a table at the end of a deal into results.
+// Every player started the deal with stack chips.
+func HandResults(deal, table, stack int, players []*pokerModels.Player) []Result {
+	results := []Result{}
+	for seat, p := range players {
+		if p == nil {
+			continue
+		}
+		results = append(results, Result{
+			Deal:      deal,
+			Table:     table,
+			Seat:      seat,
+			PlayerID:  p.PlayerID,
+			ChipDelta: p.Chips - stack,
+		})
+	}
+	return results
+}
+
+type seatDeal struct{ deal, seat int }
+
+// Score compares every result with the average result of the same seat on the
+// same deal across all tables and totals the differences per player, best
+// first. A seat played at only one table has nothing to compare with and
+// scores zero.
+func Score(results []Result) []PlayerScore {
+	totals := make(map[seatDeal]int)
+	plays := make(map[seatDeal]int)
+	for _, r := range results {
+		key := seatDeal{r.Deal, r.Seat}
+		totals[key] += r.ChipDelta
+		plays[key]++
+	}
+
+	byPlayer := make(map[string]*PlayerScore)
+	for _, r := range results {
+		score, ok := byPlayer[r.PlayerID]
+		if !ok {
+			score = &PlayerScore{PlayerID: r.PlayerID}
+			byPlayer[r.PlayerID] = score
+		}
+		key := seatDeal{r.Deal, r.Seat}
+		score.Score += float64(r.ChipDelta) - float64(totals[key])/float64(plays[key])
+		score.Deals++
+	}
+
+	scores := make([]PlayerScore, 0, len(byPlayer))
+	for _, score := range byPlayer {
+		scores = append(scores, *score)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].PlayerID < scores[j].PlayerID
+	})
+	return scores
+}
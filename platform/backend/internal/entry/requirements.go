@@ -0,0 +1,168 @@
+package entry
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/validation"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Error codes returned to clients when an entry requirement is not met
+const (
+	CodeMinBalance   = "ENTRY_MIN_BALANCE"
+	CodeAccountAge   = "ENTRY_ACCOUNT_AGE"
+	CodeMinLevel     = "ENTRY_MIN_LEVEL"
+	CodeNotInvited   = "ENTRY_NOT_INVITED"
+	CodeUserNotFound = "ENTRY_USER_NOT_FOUND"
+)
+
+const (
+	handsPerLevel     = 100 // Hands dealt needed for each player level
+	maxInviteListSize = 500
+)
+
+// ErrInviteListWithoutInviteOnly is returned when an invite list is given for an open table or tournament
+var ErrInviteListWithoutInviteOnly = errors.New("invite list requires invite_only entry requirements")
+
+// Error is returned when a user does not meet an entry requirement
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Level returns a player's level from the number of hands they have been dealt.
+// Every player starts at level 1.
+func Level(handsDealt int) int {
+	return 1 + handsDealt/handsPerLevel
+}
+
+// Validate checks requirements and an invite list given by a creator
+func Validate(req *models.EntryRequirements, inviteList []string) error {
+	if len(inviteList) > maxInviteListSize {
+		return fmt.Errorf("%w: invite list cannot exceed %d users", validation.ErrInvalidRange, maxInviteListSize)
+	}
+	for _, userID := range inviteList {
+		if err := validation.ValidateUUID(userID); err != nil {
+			return fmt.Errorf("invalid invite list entry %q: %w", userID, err)
+		}
+	}
+	if req == nil {
+		if len(inviteList) > 0 {
+			return ErrInviteListWithoutInviteOnly
+		}
+		return nil
+	}
+
+	if err := validation.ValidateNonNegativeInt(req.MinBalance, "minimum balance"); err != nil {
+		return err
+	}
+	if err := validation.ValidateNonNegativeInt(req.MinAccountAgeDays, "minimum account age"); err != nil {
+		return err
+	}
+	if err := validation.ValidateNonNegativeInt(req.MinLevel, "minimum level"); err != nil {
+		return err
+	}
+	if len(inviteList) > 0 && !req.InviteOnly {
+		return ErrInviteListWithoutInviteOnly
+	}
+	return nil
+}
+
+// SetInvites adds users to the invite list of a table or tournament
+func SetInvites(tx *gorm.DB, resourceID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	invites := make([]models.EntryInvite, 0, len(userIDs))
+	for _, userID := range userIDs {
+		invites = append(invites, models.EntryInvite{ResourceID: resourceID, UserID: userID})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&invites).Error
+}
+
+// IsInvited reports whether a user is on the invite list of a table or tournament
+func IsInvited(tx *gorm.DB, resourceID, userID string) (bool, error) {
+	var count int64
+	if err := tx.Model(&models.EntryInvite{}).
+		Where("resource_id = ? AND user_id = ?", resourceID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UserLevel returns a user's level from the hands stored on their past seats
+func UserLevel(tx *gorm.DB, userID string) (int, error) {
+	var handsDealt int
+	if err := tx.Model(&models.TableSeat{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(hands_dealt), 0)").
+		Scan(&handsDealt).Error; err != nil {
+		return 0, err
+	}
+	return Level(handsDealt), nil
+}
+
+// Check returns an *Error if the user does not meet the requirements of a
+// table or tournament. Database failures are returned as plain errors.
+func Check(tx *gorm.DB, req *models.EntryRequirements, resourceID, userID string, now time.Time) error {
+	if req == nil {
+		return nil
+	}
+
+	var user models.User
+	if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &Error{Code: CodeUserNotFound, Message: "user not found"}
+		}
+		return err
+	}
+
+	if req.InviteOnly {
+		invited, err := IsInvited(tx, resourceID, userID)
+		if err != nil {
+			return err
+		}
+		if !invited {
+			return &Error{Code: CodeNotInvited, Message: "entry is by invitation only"}
+		}
+	}
+
+	if req.MinBalance > 0 && user.Chips < req.MinBalance {
+		return &Error{
+			Code:    CodeMinBalance,
+			Message: fmt.Sprintf("requires an account balance of at least %d chips", req.MinBalance),
+		}
+	}
+
+	if req.MinAccountAgeDays > 0 && now.Sub(user.CreatedAt) < time.Duration(req.MinAccountAgeDays)*24*time.Hour {
+		return &Error{
+			Code:    CodeAccountAge,
+			Message: fmt.Sprintf("requires an account at least %d days old", req.MinAccountAgeDays),
+		}
+	}
+
+	if req.MinLevel > 1 {
+		level, err := UserLevel(tx, userID)
+		if err != nil {
+			return err
+		}
+		if level < req.MinLevel {
+			return &Error{
+				Code:    CodeMinLevel,
+				Message: fmt.Sprintf("requires level %d (current level %d)", req.MinLevel, level),
+			}
+		}
+	}
+
+	return nil
+}
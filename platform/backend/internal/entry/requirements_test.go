@@ -0,0 +1,128 @@
+package entry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestDB creates an in-memory SQLite database with users, seats and invites
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}, &models.EntryInvite{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.TableSeat{})
+	return db
+}
+
+func createUser(t *testing.T, db *gorm.DB, id string, chips int, createdAt time.Time) {
+	user := models.User{ID: id, Username: id, Email: id + "@example.com", PasswordHash: "x", Chips: chips, CreatedAt: createdAt}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+}
+
+func entryCode(err error) string {
+	var entryErr *Error
+	if errors.As(err, &entryErr) {
+		return entryErr.Code
+	}
+	return ""
+}
+
+func TestCheck_NoRequirements(t *testing.T) {
+	db := setupTestDB(t)
+	if err := Check(db, nil, "table-1", "missing-user", time.Now()); err != nil {
+		t.Fatalf("Expected no error without requirements, got %v", err)
+	}
+}
+
+func TestCheck_MinBalanceAndAccountAge(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Now()
+	createUser(t, db, "veteran", 5000, now.AddDate(0, 0, -30))
+	createUser(t, db, "newbie", 5000, now.AddDate(0, 0, -1))
+	createUser(t, db, "short", 100, now.AddDate(0, 0, -30))
+
+	req := &models.EntryRequirements{MinBalance: 1000, MinAccountAgeDays: 7}
+
+	if err := Check(db, req, "table-1", "veteran", now); err != nil {
+		t.Errorf("Expected veteran to be allowed, got %v", err)
+	}
+	if code := entryCode(Check(db, req, "table-1", "newbie", now)); code != CodeAccountAge {
+		t.Errorf("Expected %s for newbie, got %q", CodeAccountAge, code)
+	}
+	if code := entryCode(Check(db, req, "table-1", "short", now)); code != CodeMinBalance {
+		t.Errorf("Expected %s for short stack, got %q", CodeMinBalance, code)
+	}
+}
+
+func TestCheck_MinLevel(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Now()
+	createUser(t, db, "grinder", 1000, now)
+	createUser(t, db, "tourist", 1000, now)
+
+	for _, hands := range []int{150, 60} {
+		if err := db.Exec("INSERT INTO table_seats (user_id, hands_dealt) VALUES (?, ?)", "grinder", hands).Error; err != nil {
+			t.Fatalf("Failed to create seat: %v", err)
+		}
+	}
+
+	req := &models.EntryRequirements{MinLevel: 3}
+	if err := Check(db, req, "table-1", "grinder", now); err != nil {
+		t.Errorf("Expected grinder (210 hands) to reach level 3, got %v", err)
+	}
+	if code := entryCode(Check(db, req, "table-1", "tourist", now)); code != CodeMinLevel {
+		t.Errorf("Expected %s for tourist, got %q", CodeMinLevel, code)
+	}
+}
+
+func TestCheck_InviteOnly(t *testing.T) {
+	db := setupTestDB(t)
+	now := time.Now()
+	createUser(t, db, "friend", 1000, now)
+	createUser(t, db, "stranger", 1000, now)
+
+	if err := SetInvites(db, "table-1", []string{"friend", "friend"}); err != nil {
+		t.Fatalf("SetInvites failed: %v", err)
+	}
+
+	req := &models.EntryRequirements{InviteOnly: true}
+	if err := Check(db, req, "table-1", "friend", now); err != nil {
+		t.Errorf("Expected invited user to be allowed, got %v", err)
+	}
+	if code := entryCode(Check(db, req, "table-1", "stranger", now)); code != CodeNotInvited {
+		t.Errorf("Expected %s for stranger, got %q", CodeNotInvited, code)
+	}
+	if code := entryCode(Check(db, req, "table-2", "friend", now)); code != CodeNotInvited {
+		t.Errorf("Expected invites to be scoped to their table, got %q", code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	invite := []string{"8b0c2a4e-6f1d-4c55-9a51-2f3e4d5c6b7a"}
+
+	if err := Validate(&models.EntryRequirements{InviteOnly: true}, invite); err != nil {
+		t.Errorf("Expected valid invite-only requirements, got %v", err)
+	}
+	if err := Validate(nil, invite); !errors.Is(err, ErrInviteListWithoutInviteOnly) {
+		t.Errorf("Expected ErrInviteListWithoutInviteOnly, got %v", err)
+	}
+	if err := Validate(&models.EntryRequirements{MinBalance: -1}, nil); err == nil {
+		t.Error("Expected negative minimum balance to be rejected")
+	}
+	if err := Validate(&models.EntryRequirements{InviteOnly: true}, []string{"not-a-uuid"}); err == nil {
+		t.Error("Expected invalid invite list entry to be rejected")
+	}
+}
@@ -0,0 +1,61 @@
+package eventschema
+
+// SubscribeTablePayload is the payload of "subscribe_table"
+type SubscribeTablePayload struct {
+	TableID string `json:"table_id"`
+	CardsUp bool   `json:"cards_up,omitempty" desc:"Production client of a broadcast table: delayed feed with every hole card"`
+}
+
+// GameActionPayload is the payload of "game_action"
+type GameActionPayload struct {
+	Action    string `json:"action" enum:"fold,check,call,raise,allin,straddle"`
+	Amount    int    `json:"amount,omitempty" desc:"Total bet for a raise; 0 otherwise"`
+	RequestID string `json:"request_id,omitempty" desc:"Lets the server drop a resent action"`
+}
+
+// SeatChangeRequestPayload is the payload of "seat_change"
+type SeatChangeRequestPayload struct {
+	SeatNumber int `json:"seat_number"`
+}
+
+// ShowCardsPayload is the payload of "show_cards"
+type ShowCardsPayload struct {
+	Cards []int `json:"cards" desc:"Indexes of the hole cards to show, 0 and/or 1; empty shows none"`
+}
+
+// ChatMessagePayload is the payload of a "chat_message" a client sends
+type ChatMessagePayload struct {
+	Text string `json:"text" desc:"Up to 200 characters"`
+}
+
+// DirectorPayload is the payload of "director"
+type DirectorPayload struct {
+	Op           string `json:"op" enum:"announce,break,add_time,progress"`
+	TournamentID string `json:"tournament_id,omitempty" desc:"Required except for progress, which then covers every running tournament the user hosts"`
+	Message      string `json:"message,omitempty" desc:"For announce, up to 500 characters"`
+	Seconds      int    `json:"seconds,omitempty" desc:"For break and add_time"`
+}
+
+// HelloPayload is the payload of "hello"
+type HelloPayload struct {
+	ProtocolVersion int      `json:"protocol_version"`
+	Features        []string `json:"features" desc:"Optional features the client supports, such as action_required and action_ack"`
+}
+
+// ActionAckPayload is the payload of "action_ack"
+type ActionAckPayload struct {
+	TableID string `json:"table_id"`
+}
+
+var clientMessages = []spec{
+	{"hello", SourceClient, "First, to negotiate the protocol version and features", HelloPayload{}},
+	{"ping", SourceClient, "Any time; answered with pong", nil},
+	{"subscribe_table", SourceClient, "To watch or play a table; answered with table_state", SubscribeTablePayload{}},
+	{"game_action", SourceClient, "On the player's turn", GameActionPayload{}},
+	{"resync", SourceClient, "By clients with delta_updates that missed a seq; answered with table_state", nil},
+	{"action_ack", SourceClient, "On receiving action_required, by clients that declared action_ack", ActionAckPayload{}},
+	{"seat_change", SourceClient, "To move to another seat at a cash table", SeatChangeRequestPayload{}},
+	{"show_cards", SourceClient, "In reply to show_cards_prompt, to show one or both hole cards or none", ShowCardsPayload{}},
+	{"chat_message", SourceClient, "To chat at the subscribed table", ChatMessagePayload{}},
+	{"director", SourceClient, "By a tournament's director to run a bulk operation", DirectorPayload{}},
+}
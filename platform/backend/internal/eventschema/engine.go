@@ -0,0 +1,162 @@
+package eventschema
+
+import (
+	"time"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+// The engine emits events as pokerModels.Event, with these as Data. Events
+// without a typed payload in the engine are described by the structs below.
+
+// HandStartEvent is the data of "handStart"
+type HandStartEvent struct {
+	HandNumber         int `json:"handNumber"`
+	DealerPosition     int `json:"dealerPosition"`
+	SmallBlindPosition int `json:"smallBlindPosition"`
+	BigBlindPosition   int `json:"bigBlindPosition"`
+}
+
+// PositionsCorrectedEvent is the data of "positionsCorrected"
+type PositionsCorrectedEvent struct {
+	HandNumber         int      `json:"handNumber"`
+	Violations         []string `json:"violations"`
+	DealerPosition     int      `json:"dealerPosition"`
+	SmallBlindPosition int      `json:"smallBlindPosition"`
+	BigBlindPosition   int      `json:"bigBlindPosition"`
+}
+
+// PlayerEvent is the data of "playerBusted"
+type PlayerEvent struct {
+	PlayerID   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+}
+
+// PlayerActionEvent is the data of "playerAction"
+type PlayerActionEvent struct {
+	PlayerID            string `json:"playerId"`
+	Action              string `json:"action" enum:"fold,check,call,raise,allin,straddle"`
+	Amount              int    `json:"amount,omitempty"`
+	Reason              string `json:"reason,omitempty" enum:"timeout"`
+	ConsecutiveTimeouts int    `json:"consecutiveTimeouts,omitempty" desc:"With a timeout"`
+}
+
+// RoundAdvancedEvent is the data of "roundAdvanced"
+type RoundAdvancedEvent struct {
+	BettingRound   string             `json:"bettingRound" enum:"preflop,flop,turn,river"`
+	CommunityCards []pokerModels.Card `json:"communityCards"`
+}
+
+// GameCompleteEvent is the data of "gameComplete"
+type GameCompleteEvent struct {
+	Winner       string `json:"winner"`
+	WinnerName   string `json:"winnerName"`
+	FinalChips   int    `json:"finalChips"`
+	TotalPlayers int    `json:"totalPlayers"`
+}
+
+// GameAbandonedEvent is the data of "gameAbandoned"
+type GameAbandonedEvent struct {
+	Reason       string `json:"reason" enum:"player_inactivity"`
+	TotalPlayers int    `json:"totalPlayers"`
+}
+
+// PlayerSitOutEvent is the data of "playerSitOut"
+type PlayerSitOutEvent struct {
+	PlayerID string `json:"playerId"`
+	Reason   string `json:"reason" enum:"consecutive_timeouts"`
+}
+
+// GamePausedEvent is the data of "gamePaused"
+type GamePausedEvent struct {
+	PausedAt string `json:"pausedAt" desc:"RFC 3339"`
+}
+
+// GameResumedEvent is the data of "gameResumed"
+type GameResumedEvent struct {
+	ResumedAt          string  `json:"resumedAt" desc:"RFC 3339"`
+	TotalPauseDuration float64 `json:"totalPauseDuration" desc:"Seconds"`
+}
+
+// PlayerAwayEvent is the data of "playerAway"
+type PlayerAwayEvent struct {
+	PlayerID string `json:"playerId"`
+	Away     bool   `json:"away"`
+}
+
+// PlayerConnectionEvent is the data of "playerConnection"
+type PlayerConnectionEvent struct {
+	PlayerID  string `json:"playerId"`
+	Connected bool   `json:"connected"`
+}
+
+// PlayerFrozenEvent is the data of "playerFrozen"
+type PlayerFrozenEvent struct {
+	PlayerID string `json:"playerId"`
+	Frozen   bool   `json:"frozen"`
+}
+
+// ColorUpEvent is the data of "colorUp"
+type ColorUpEvent struct {
+	RemovedDenomination int                    `json:"removedDenomination"`
+	MinDenomination     int                    `json:"minDenomination"`
+	Method              string                 `json:"method" enum:"chip_race,round_up"`
+	Results             []engine.ColorUpResult `json:"results"`
+}
+
+// SeatChangedEvent is the data of "seatChanged"
+type SeatChangedEvent struct {
+	PlayerID   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	FromSeat   int    `json:"fromSeat"`
+	ToSeat     int    `json:"toSeat"`
+}
+
+// StraddleDeclaredEvent is the data of "straddleDeclared"
+type StraddleDeclaredEvent struct {
+	PlayerID string `json:"playerId"`
+	Amount   int    `json:"amount"`
+}
+
+// TimeBankStartedEvent is the data of "timeBankStarted"
+type TimeBankStartedEvent struct {
+	PlayerID string `json:"playerId"`
+	TimeBank int    `json:"timeBank" desc:"Reserve seconds left"`
+}
+
+// ShowCardsPromptEvent is the data of "showCardsPrompt"
+type ShowCardsPromptEvent struct {
+	PlayerID string    `json:"playerId"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// ShowCardsClosedEvent is the data of "showCardsClosed"
+type ShowCardsClosedEvent struct {
+	PlayerID string             `json:"playerId"`
+	Cards    []pokerModels.Card `json:"cards" desc:"The cards shown; empty when none were"`
+}
+
+var engineEvents = []spec{
+	{"handStart", SourceEngine, "When a hand is dealt", HandStartEvent{}},
+	{"positionsCorrected", SourceEngine, "After handStart, when the button or blinds had to be moved to valid seats", PositionsCorrectedEvent{}},
+	{"actionRequired", SourceEngine, "When a player's turn starts", pokerModels.ActionRequiredEvent{}},
+	{"timeBankStarted", SourceEngine, "When a player's base timer runs out and their time bank starts", TimeBankStartedEvent{}},
+	{"playerAction", SourceEngine, "After every action, including timeouts", PlayerActionEvent{}},
+	{"straddleDeclared", SourceEngine, "When a player declares a straddle for the next hand", StraddleDeclaredEvent{}},
+	{"roundAdvanced", SourceEngine, "When the flop, turn or river is dealt", RoundAdvancedEvent{}},
+	{"handComplete", SourceEngine, "When a hand ends", pokerModels.HandCompleteEvent{}},
+	{"showCardsPrompt", SourceEngine, "After handComplete, when the winner of a pot nobody contested may show their cards", ShowCardsPromptEvent{}},
+	{"showCardsClosed", SourceEngine, "When that winner shows cards, declines or runs out of time; the next hand can be dealt", ShowCardsClosedEvent{}},
+	{"playerBusted", SourceEngine, "After a hand, for each player left without chips", PlayerEvent{}},
+	{"playerSitOut", SourceEngine, "When a player is sat out for timing out", PlayerSitOutEvent{}},
+	{"playerAway", SourceEngine, "When a player is marked away or back", PlayerAwayEvent{}},
+	{"playerConnection", SourceEngine, "When a player disconnects or reconnects", PlayerConnectionEvent{}},
+	{"playerFrozen", SourceEngine, "When a player's account is frozen or unfrozen", PlayerFrozenEvent{}},
+	{"seatChanged", SourceEngine, "When a requested seat change is applied", SeatChangedEvent{}},
+	{"colorUp", SourceEngine, "When small chips are colored up before a hand", ColorUpEvent{}},
+	{"gamePaused", SourceEngine, "When the table is paused", GamePausedEvent{}},
+	{"gameResumed", SourceEngine, "When the table resumes", GameResumedEvent{}},
+	{"gameComplete", SourceEngine, "When one player has all the chips of a sit & go or tournament table", GameCompleteEvent{}},
+	{"gameAbandoned", SourceEngine, "When every player at the table has gone inactive", GameAbandonedEvent{}},
+}
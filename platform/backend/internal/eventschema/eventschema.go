@@ -0,0 +1,190 @@
+// Package eventschema describes every WebSocket message and engine event as
+// a machine-readable catalog, built by reflection from the payload structs
+// the messages are encoded from. Field descriptions come from desc struct
+// tags and allowed values from enum tags.
+package eventschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sources of messages
+const (
+	SourceServer = "server" // Sent by the server over the WebSocket
+	SourceClient = "client" // Sent by clients over the WebSocket
+	SourceEngine = "engine" // Emitted by the game engine to the platform
+)
+
+// Field describes one field of a payload
+type Field struct {
+	Name        string   `json:"name,omitempty"`
+	Type        string   `json:"type"` // string, integer, number, boolean, timestamp, object, array or any
+	GoType      string   `json:"go_type,omitempty"`
+	Optional    bool     `json:"optional,omitempty"` // Left out when empty
+	Nullable    bool     `json:"nullable,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Fields      []Field  `json:"fields,omitempty"` // Of an object
+	Items       *Field   `json:"items,omitempty"`  // Of an array, or the values of a map
+}
+
+// Message describes one message type and its payload
+type Message struct {
+	Type    string  `json:"type"`
+	Source  string  `json:"source"`
+	Emitted string  `json:"emitted"` // When it is sent
+	Payload string  `json:"payload"` // Go type the payload is encoded from
+	Fields  []Field `json:"fields"`
+}
+
+// spec is a catalog entry before its payload is described
+type spec struct {
+	msgType string
+	source  string
+	emitted string
+	payload interface{} // A zero value of the payload type; nil for none
+}
+
+// Catalog returns every message of the catalog, by source and then type
+func Catalog() []Message {
+	specs := append(append(append([]spec{}, serverMessages...), clientMessages...), engineEvents...)
+	messages := make([]Message, 0, len(specs))
+	for _, s := range specs {
+		m := Message{Type: s.msgType, Source: s.source, Emitted: s.emitted, Fields: []Field{}}
+		if s.payload != nil {
+			t := reflect.TypeOf(s.payload)
+			m.Payload = t.String()
+			m.Fields = Describe(t)
+		}
+		messages = append(messages, m)
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		if messages[i].Source != messages[j].Source {
+			return sourceOrder[messages[i].Source] < sourceOrder[messages[j].Source]
+		}
+		return messages[i].Type < messages[j].Type
+	})
+	return messages
+}
+
+var sourceOrder = map[string]int{SourceServer: 0, SourceClient: 1, SourceEngine: 2}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Describe returns the JSON fields of a struct type
+func Describe(t reflect.Type) []Field {
+	return describeStruct(t, map[reflect.Type]bool{})
+}
+
+func describeStruct(t reflect.Type, seen map[reflect.Type]bool) []Field {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	fields := []Field{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue // Unexported
+		}
+		name, opts := parseTag(sf.Tag.Get("json"))
+		if name == "-" && opts == "" {
+			continue
+		}
+		// Embedded structs without a name are flattened, as encoding/json does
+		if sf.Anonymous && name == "" {
+			embedded := sf.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, describeStruct(embedded, seen)...)
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		f := describeType(sf.Type, seen)
+		f.Name = name
+		f.Optional = strings.Contains(opts, "omitempty")
+		f.Nullable = f.Nullable && !f.Optional // A nil pointer is left out, not null
+		f.Description = sf.Tag.Get("desc")
+		if enum := sf.Tag.Get("enum"); enum != "" {
+			f.Enum = strings.Split(enum, ",")
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func describeType(t reflect.Type, seen map[reflect.Type]bool) Field {
+	var f Field
+	for t.Kind() == reflect.Ptr {
+		f.Nullable = true
+		t = t.Elem()
+	}
+	if t == timeType {
+		f.Type = "timestamp"
+		return f
+	}
+	if t.PkgPath() != "" && t.Name() != "" {
+		f.GoType = t.String()
+	}
+
+	switch {
+	case t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType):
+		// Encodes itself; the Go type tells what it looks like
+		f.Type = "any"
+		return f
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		f.Type = "string"
+	case reflect.Bool:
+		f.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		f.Type = "number"
+	case reflect.Slice, reflect.Array:
+		f.Type = "array"
+		items := describeType(t.Elem(), seen)
+		f.Items = &items
+	case reflect.Map:
+		f.Type = "object"
+		values := describeType(t.Elem(), seen)
+		f.Items = &values
+	case reflect.Struct:
+		f.Type = "object"
+		f.Fields = describeStruct(t, seen)
+	default:
+		f.Type = "any"
+		f.Nullable = t.Kind() == reflect.Interface
+	}
+	return f
+}
+
+func parseTag(tag string) (name, opts string) {
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
@@ -0,0 +1,87 @@
+package eventschema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type describeBase struct {
+	ID string `json:"id"`
+}
+
+type describeItem struct {
+	Amount int `json:"amount"`
+}
+
+type describeSample struct {
+	describeBase
+	Name     string            `json:"name" desc:"Display name"`
+	Kind     string            `json:"kind,omitempty" enum:"a,b"`
+	Seen     *time.Time        `json:"seen"`
+	Items    []describeItem    `json:"items"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Extra    interface{}       `json:"extra"`
+	Hidden   string            `json:"-"`
+	internal string
+}
+
+func TestDescribe(t *testing.T) {
+	fields := Describe(reflect.TypeOf(describeSample{}))
+	byName := make(map[string]Field, len(fields))
+	var names []string
+	for _, f := range fields {
+		byName[f.Name] = f
+		names = append(names, f.Name)
+	}
+	if want := []string{"id", "name", "kind", "seen", "items", "labels", "extra"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Expected fields %v, got %v", want, names)
+	}
+
+	if f := byName["name"]; f.Type != "string" || f.Optional || f.Description != "Display name" {
+		t.Errorf("Unexpected name field: %+v", f)
+	}
+	if f := byName["kind"]; !f.Optional || !reflect.DeepEqual(f.Enum, []string{"a", "b"}) {
+		t.Errorf("Expected an optional enum, got %+v", f)
+	}
+	if f := byName["seen"]; f.Type != "timestamp" || !f.Nullable {
+		t.Errorf("Expected a nullable timestamp, got %+v", f)
+	}
+	items := byName["items"]
+	if items.Type != "array" || items.Items == nil || items.Items.Type != "object" ||
+		len(items.Items.Fields) != 1 || items.Items.Fields[0].Name != "amount" || items.Items.Fields[0].Type != "integer" {
+		t.Errorf("Expected an array of objects with an amount, got %+v", items)
+	}
+	if f := byName["labels"]; f.Type != "object" || f.Items == nil || f.Items.Type != "string" {
+		t.Errorf("Expected a map of strings, got %+v", f)
+	}
+	if f := byName["extra"]; f.Type != "any" {
+		t.Errorf("Expected any, got %+v", f)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	messages := Catalog()
+	seen := make(map[string]bool)
+	for i, m := range messages {
+		key := m.Source + "/" + m.Type
+		if seen[key] {
+			t.Errorf("Message %s is listed twice", key)
+		}
+		seen[key] = true
+		if m.Emitted == "" {
+			t.Errorf("Message %s doesn't say when it is sent", key)
+		}
+		if m.Payload != "" && len(m.Fields) == 0 {
+			t.Errorf("Message %s has a payload without fields", key)
+		}
+		if i > 0 && sourceOrder[messages[i-1].Source] == sourceOrder[m.Source] && messages[i-1].Type > m.Type {
+			t.Errorf("Messages are out of order at %s", key)
+		}
+	}
+	for _, key := range []string{"server/game_update", "client/game_action", "engine/handComplete"} {
+		if !seen[key] {
+			t.Errorf("Expected %s in the catalog", key)
+		}
+	}
+}
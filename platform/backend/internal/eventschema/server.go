@@ -0,0 +1,515 @@
+package eventschema
+
+import (
+	"time"
+
+	"poker-platform/backend/internal/chat"
+	"poker-platform/backend/internal/leaderboard"
+	"poker-platform/backend/internal/mission"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/tournament"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+// ErrorPayload is the payload of "error"
+type ErrorPayload struct {
+	Message            string `json:"message" desc:"Human-readable reason"`
+	Code               string `json:"code,omitempty" desc:"Machine-readable reason, such as INVALID_PAYLOAD, ACCOUNT_FROZEN or CHAT_RATE_LIMITED"`
+	MinProtocolVersion int    `json:"min_protocol_version,omitempty" desc:"With UNSUPPORTED_PROTOCOL"`
+	ProtocolVersion    int    `json:"protocol_version,omitempty" desc:"With UNSUPPORTED_PROTOCOL"`
+}
+
+// HelloAckPayload is the payload of "hello_ack"
+type HelloAckPayload struct {
+	ProtocolVersion int      `json:"protocol_version" desc:"Version the connection speaks"`
+	Features        []string `json:"features" desc:"Declared features the server accepted"`
+	ServerFeatures  []string `json:"server_features" desc:"Every optional feature the server supports"`
+	ConnectionID    string   `json:"connection_id"`
+}
+
+// TableStatePayload is the payload of "table_state" and "game_update"
+type TableStatePayload struct {
+	Players            []TableStatePlayer   `json:"players"`
+	TableID            string               `json:"table_id"`
+	CommunityCards     []string             `json:"community_cards" desc:"Cards like \"Ah\""`
+	Pot                int                  `json:"pot" desc:"Main pot plus side pots"`
+	CurrentTurn        *string              `json:"current_turn" desc:"User ID of the player to act"`
+	Status             string               `json:"status" enum:"waiting,playing,handComplete,paused,completed"`
+	BettingRound       string               `json:"betting_round" enum:",preflop,flop,turn,river"`
+	CurrentBet         int                  `json:"current_bet"`
+	ActionSequence     uint64               `json:"action_sequence" desc:"Count of actions in the hand, to order updates"`
+	BigBlind           int                  `json:"big_blind"`
+	PotBB              float64              `json:"pot_bb,omitempty" desc:"Pot in big blinds, one decimal"`
+	CurrencySymbol     string               `json:"currency_symbol,omitempty"`
+	ChipScale          int                  `json:"chip_scale" desc:"Chips per displayed unit"`
+	AllowStraddle      bool                 `json:"allow_straddle,omitempty"`
+	BettingMode        string               `json:"betting_mode,omitempty" enum:"no_limit,pot_limit,fixed_limit"`
+	DealerPosition     *int                 `json:"dealer_position,omitempty" desc:"Seat index, while a hand exists"`
+	SmallBlindPosition *int                 `json:"small_blind_position,omitempty"`
+	BigBlindPosition   *int                 `json:"big_blind_position,omitempty"`
+	ActionDeadline     *time.Time           `json:"action_deadline,omitempty"`
+	Winners            []pokerModels.Winner `json:"winners,omitempty" desc:"At handComplete"`
+	Stats              interface{}          `json:"stats,omitempty" desc:"Table statistics, in table_state only"`
+	Seq                uint64               `json:"seq,omitempty" desc:"In game_update, to clients with delta_updates"`
+}
+
+// TableStatePlayer is a seat of a TableStatePayload
+type TableStatePlayer struct {
+	UserID           string   `json:"user_id"`
+	Username         string   `json:"username"`
+	SeatNumber       int      `json:"seat_number"`
+	Chips            int      `json:"chips"`
+	Status           string   `json:"status" enum:"active,folded,allin,sitting_out"`
+	CurrentBet       int      `json:"current_bet"`
+	Folded           bool     `json:"folded"`
+	AllIn            bool     `json:"all_in"`
+	IsDealer         bool     `json:"is_dealer"`
+	LastAction       string   `json:"last_action"`
+	LastActionAmount int      `json:"last_action_amount"`
+	Away             bool     `json:"away"`
+	Straddle         bool     `json:"straddle,omitempty"`
+	Disconnected     bool     `json:"disconnected,omitempty"`
+	Level            int      `json:"level,omitempty" desc:"Experience level"`
+	AvatarURL        string   `json:"avatar_url,omitempty"`
+	IsBot            bool     `json:"is_bot,omitempty" desc:"A computer player the server acts for"`
+	TimeBank         *int     `json:"time_bank,omitempty" desc:"Reserve seconds, on tables with a time bank"`
+	ChipsBB          *float64 `json:"chips_bb,omitempty"`
+	CurrentBetBB     *float64 `json:"current_bet_bb,omitempty"`
+	Cards            []string `json:"cards,omitempty" desc:"The viewer's own cards, everyone's still in at showdown, or those the winner of a pot nobody contested shows"`
+}
+
+// GameDeltaPayload is the payload of "game_delta"
+type GameDeltaPayload struct {
+	TableID string                 `json:"table_id"`
+	Seq     uint64                 `json:"seq" desc:"One more than the last game_update or game_delta; otherwise send resync"`
+	Set     map[string]interface{} `json:"set" desc:"Payload fields of game_update that are new or changed"`
+	Unset   []string               `json:"unset" desc:"Payload fields that are gone"`
+	Players []TableStatePlayer     `json:"players" desc:"Players whose object changed, in full, matched by user_id"`
+}
+
+// HistoryLogPayload is the payload of "history_log"
+type HistoryLogPayload struct {
+	TableID string                     `json:"table_id"`
+	Entries []pokerModels.HistoryEntry `json:"entries"`
+}
+
+// SpectatorDelayPayload is the payload of "spectator_delay"
+type SpectatorDelayPayload struct {
+	TableID      string `json:"table_id"`
+	DelaySeconds int    `json:"delay_seconds"`
+}
+
+// ActionRequiredPayload is the payload of "action_required"
+type ActionRequiredPayload struct {
+	TableID        string   `json:"table_id"`
+	UserID         string   `json:"user_id"`
+	Deadline       string   `json:"deadline" desc:"RFC 3339"`
+	CurrentBet     *int     `json:"current_bet,omitempty"`
+	ActionSequence *uint64  `json:"action_sequence,omitempty"`
+	TimeBank       *int     `json:"time_bank,omitempty" desc:"Reserve seconds left"`
+	InTimeBank     *bool    `json:"in_time_bank,omitempty" desc:"The deadline is reserve time"`
+	LegalActions   []string `json:"legal_actions,omitempty" desc:"Of fold, check, call, raise and allin"`
+	CallAmount     *int     `json:"call_amount,omitempty" desc:"Chips needed to call; 0 means the player can check"`
+	MinRaiseTo     *int     `json:"min_raise_to,omitempty" desc:"Smallest total bet of a bet or raise, when the player can raise"`
+	MaxRaiseTo     *int     `json:"max_raise_to,omitempty" desc:"Largest total bet of a bet or raise, when the player can raise"`
+	EffectiveStack *int     `json:"effective_stack,omitempty" desc:"The smaller of the player's stack and the biggest other one in the hand"`
+}
+
+// ActionConfirmedPayload is the payload of "action_confirmed"
+type ActionConfirmedPayload struct {
+	UserID  string `json:"user_id"`
+	Action  string `json:"action"`
+	Amount  int    `json:"amount"`
+	Success bool   `json:"success"`
+}
+
+// PlayerActionBroadcastPayload is the payload of "player_action_broadcast"
+type PlayerActionBroadcastPayload struct {
+	UserID       string `json:"user_id"`
+	PlayerName   string `json:"player_name"`
+	Action       string `json:"action"`
+	Amount       int    `json:"amount"`
+	BettingRound string `json:"betting_round"`
+	PotAfter     int    `json:"pot_after"`
+	Timestamp    int64  `json:"timestamp" desc:"Unix seconds"`
+}
+
+// GameCompletePayload is the payload of "game_complete" and
+// "tournament_table_complete"
+type GameCompletePayload struct {
+	TableID      string `json:"table_id,omitempty" desc:"In tournament_table_complete"`
+	Winner       string `json:"winner" desc:"User ID"`
+	WinnerName   string `json:"winnerName"`
+	FinalChips   int    `json:"finalChips"`
+	TotalPlayers int    `json:"totalPlayers"`
+	Message      string `json:"message"`
+}
+
+// NewGameCompletePayload reads the data of the engine's gameComplete event
+func NewGameCompletePayload(data map[string]interface{}, message string) GameCompletePayload {
+	payload := GameCompletePayload{Message: message}
+	payload.Winner, _ = data["winner"].(string)
+	payload.WinnerName, _ = data["winnerName"].(string)
+	payload.FinalChips, _ = data["finalChips"].(int)
+	payload.TotalPlayers, _ = data["totalPlayers"].(int)
+	return payload
+}
+
+// SeatChangePayload is the payload of "seat_change_queued" and "seat_change_applied"
+type SeatChangePayload struct {
+	TableID    string `json:"table_id"`
+	SeatNumber int    `json:"seat_number"`
+}
+
+// ShowCardsPromptPayload is the payload of "show_cards_prompt"
+type ShowCardsPromptPayload struct {
+	TableID  string `json:"table_id"`
+	Deadline string `json:"deadline" desc:"RFC 3339; the next hand is dealt then if the player hasn't answered"`
+}
+
+// DirectorResultPayload is the payload of "director_result"
+type DirectorResultPayload struct {
+	Op             string             `json:"op" enum:"announce,break,add_time,progress"`
+	TournamentID   string             `json:"tournament_id,omitempty"`
+	Tables         int                `json:"tables,omitempty" desc:"Tables reached, for announce and break"`
+	Recipients     int                `json:"recipients,omitempty" desc:"Clients reached, for announce"`
+	ResumesAt      *time.Time         `json:"resumes_at,omitempty" desc:"For break"`
+	LevelStartedAt *time.Time         `json:"level_started_at,omitempty" desc:"For add_time"`
+	Tournaments    []DirectorProgress `json:"tournaments,omitempty" desc:"For progress"`
+}
+
+// DirectorProgress is one tournament of a progress report
+type DirectorProgress struct {
+	TournamentID   string                   `json:"tournament_id"`
+	Name           string                   `json:"name"`
+	Status         string                   `json:"status"`
+	Level          int                      `json:"level"`
+	LevelStartedAt *time.Time               `json:"level_started_at"`
+	HandForHand    bool                     `json:"hand_for_hand"`
+	Tables         []map[string]interface{} `json:"tables" desc:"table_id, table_number, held, status, players, chips, hand_number and betting_round of each table"`
+}
+
+// BalanceUpdatePayload is the payload of "balance_update"
+type BalanceUpdatePayload struct {
+	UserID     string `json:"user_id"`
+	OldBalance int    `json:"old_balance"`
+	NewBalance int    `json:"new_balance"`
+	Change     int    `json:"change"`
+	Reason     string `json:"reason" desc:"Chip transaction type"`
+}
+
+// MatchFoundPayload is the payload of "match_found"
+type MatchFoundPayload struct {
+	TableID       string `json:"table_id"`
+	GameMode      string `json:"game_mode"`
+	StartDeadline string `json:"start_deadline" desc:"RFC 3339; the game starts then if not full before"`
+}
+
+// LeaderboardUpdatePayload is the payload of "leaderboard_update"
+type LeaderboardUpdatePayload struct {
+	Type    string              `json:"type" enum:"tournaments,cash,hands"`
+	Period  string              `json:"period" enum:"daily,weekly,monthly"`
+	Window  leaderboard.Window  `json:"window"`
+	Entries []leaderboard.Entry `json:"entries"`
+}
+
+// MissionProgressPayload is the payload of "mission_progress"
+type MissionProgressPayload struct {
+	Missions []mission.Status `json:"missions" desc:"The missions that moved"`
+}
+
+// ReferralRewardPayload is the payload of "referral_reward"
+type ReferralRewardPayload struct {
+	Hands      int    `json:"hands" desc:"Hands the referred player has played"`
+	Chips      int    `json:"chips" desc:"Paid to the recipient"`
+	RefereeID  string `json:"referee_id" desc:"User ID of the referred player"`
+	ReferrerID string `json:"referrer_id" desc:"User ID of the player who referred them"`
+}
+
+// LevelUpPayload is the payload of "level_up"
+type LevelUpPayload struct {
+	Level     int      `json:"level"`
+	From      int      `json:"from" desc:"Level before, more than one below when several were reached at once"`
+	XP        int      `json:"xp"`
+	NextXP    int      `json:"next_xp" desc:"Total experience the next level takes"`
+	Chips     int      `json:"chips" desc:"Reward chips paid for the levels reached"`
+	TicketIDs []string `json:"ticket_ids,omitempty" desc:"Open tournament tickets given"`
+}
+
+// TableClosedPayload is the payload of "table_closed"
+type TableClosedPayload struct {
+	TableID    string `json:"table_id"`
+	HandVoided bool   `json:"hand_voided" desc:"A hand in progress was called off and bets returned"`
+	Message    string `json:"message"`
+}
+
+// SessionSummaryPayload is the payload of "session_summary"
+type SessionSummaryPayload struct {
+	TableID string                 `json:"table_id"`
+	Players []models.SessionResult `json:"players" desc:"Everyone who played the session, best result first"`
+}
+
+// TableControlPayload is the payload of "table_control"
+type TableControlPayload struct {
+	TableID string `json:"table_id"`
+	Action  string `json:"action" enum:"kick,pause,resume"`
+	UserID  string `json:"user_id,omitempty" desc:"The player removed, for kick"`
+	Message string `json:"message"`
+}
+
+// DeadlineWarningPayload is the payload of "deadline_warning"
+type DeadlineWarningPayload struct {
+	Kind         string    `json:"kind" enum:"table_close,registration_close,tournament_end"`
+	TableID      string    `json:"table_id,omitempty" desc:"For table_close"`
+	TournamentID string    `json:"tournament_id,omitempty" desc:"For registration_close and tournament_end"`
+	At           time.Time `json:"at" desc:"When the deadline falls"`
+	SecondsLeft  int       `json:"seconds_left"`
+	Message      string    `json:"message"`
+}
+
+// AnnouncementPayload is the payload of "announcement"
+type AnnouncementPayload struct {
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind" enum:"info,maintenance,promotion"`
+	Message   string     `json:"message"`
+	StartsAt  time.Time  `json:"starts_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// AnnouncementCancelledPayload is the payload of "announcement_cancelled"
+type AnnouncementCancelledPayload struct {
+	ID string `json:"id"`
+}
+
+// ServerRestartingPayload is the payload of "server_restarting"
+type ServerRestartingPayload struct {
+	Message    string `json:"message"`
+	HandVoided bool   `json:"hand_voided" desc:"Hands in progress were called off; stacks are restored to the start of the hand"`
+}
+
+// PlayerMovedPayload is the payload of "player_moved"
+type PlayerMovedPayload struct {
+	TournamentID string `json:"tournament_id"`
+	UserID       string `json:"user_id"`
+	FromTableID  string `json:"from_table_id"`
+	ToTableID    string `json:"to_table_id"`
+	SeatNumber   int    `json:"seat_number"`
+	Chips        int    `json:"chips"`
+}
+
+// TournamentAnnouncementPayload is the payload of "tournament_announcement"
+type TournamentAnnouncementPayload struct {
+	TournamentID string    `json:"tournament_id"`
+	Message      string    `json:"message"`
+	SentAt       time.Time `json:"sent_at"`
+}
+
+// TournamentBreakPayload is the payload of "tournament_break"
+type TournamentBreakPayload struct {
+	TournamentID string    `json:"tournament_id"`
+	Seconds      int       `json:"seconds"`
+	ResumesAt    time.Time `json:"resumes_at"`
+}
+
+// TournamentBreakStartedPayload is the payload of "tournament_break_started"
+type TournamentBreakStartedPayload struct {
+	TournamentID string    `json:"tournament_id"`
+	AfterLevel   int       `json:"after_level"`
+	Seconds      int       `json:"seconds" desc:"Countdown to the end of the break"`
+	ResumesAt    time.Time `json:"resumes_at"`
+}
+
+// TournamentBreakEndedPayload is the payload of "tournament_break_ended"
+type TournamentBreakEndedPayload struct {
+	TournamentID string `json:"tournament_id"`
+	Level        int    `json:"level" desc:"The level play resumes at"`
+}
+
+// TournamentClockPayload is the payload of "tournament_clock"
+type TournamentClockPayload struct {
+	TournamentID   string    `json:"tournament_id"`
+	SecondsAdded   int       `json:"seconds_added"`
+	LevelStartedAt time.Time `json:"level_started_at"`
+}
+
+// HandForHandPayload is the payload of "hand_for_hand"
+type HandForHandPayload struct {
+	TournamentID string `json:"tournament_id"`
+	Active       bool   `json:"active"`
+}
+
+// BracketUpdatePayload is the payload of "bracket_update"
+type BracketUpdatePayload struct {
+	TournamentID string                `json:"tournament_id"`
+	Round        int                   `json:"round" desc:"The round being played"`
+	Matches      []models.BracketMatch `json:"matches" desc:"Every match so far, round by round"`
+}
+
+// KnockoutPayload is the payload of "knockout"
+type KnockoutPayload struct {
+	TournamentID   string `json:"tournament_id"`
+	EliminatedID   string `json:"eliminated_id"`
+	EliminatedName string `json:"eliminated_name"`
+	EliminatorID   string `json:"eliminator_id"`
+	EliminatorName string `json:"eliminator_name"`
+	Cash           int    `json:"cash" desc:"Paid to the eliminator"`
+	Added          int    `json:"added" desc:"Added to the eliminator's bounty"`
+	Bounty         int    `json:"bounty" desc:"The eliminator's bounty now"`
+}
+
+// FinalTableStartedPayload is the payload of "final_table_started"
+type FinalTableStartedPayload struct {
+	TournamentID string              `json:"tournament_id"`
+	TableID      string              `json:"table_id"`
+	Players      []FinalTablePlayer  `json:"players"`
+	Payouts      []tournament.Payout `json:"payouts" desc:"Prizes still to be won"`
+	BreakSeconds int                 `json:"break_seconds"`
+	ResumesAt    *time.Time          `json:"resumes_at,omitempty" desc:"When the final table deals, after its break"`
+}
+
+// FinalTablePlayer is a seat of the final table
+type FinalTablePlayer struct {
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	SeatNumber int    `json:"seat_number"`
+	Chips      int    `json:"chips"`
+}
+
+// FinalTableHoldPayload is the payload of "final_table_hold"
+type FinalTableHoldPayload struct {
+	TournamentID string `json:"tournament_id"`
+	TableID      string `json:"table_id"`
+	Held         bool   `json:"held"`
+}
+
+// ColorUpPayload is the payload of "color_up"
+type ColorUpPayload struct {
+	TableID             string                 `json:"table_id"`
+	RemovedDenomination int                    `json:"removed_denomination"`
+	MinDenomination     int                    `json:"min_denomination"`
+	Method              string                 `json:"method" enum:"chip_race,round_up"`
+	Results             []engine.ColorUpResult `json:"results"`
+}
+
+// BlindLevelIncreasedPayload is the payload of "blind_level_increased"
+type BlindLevelIncreasedPayload struct {
+	TournamentID  string             `json:"tournament_id"`
+	CurrentLevel  int                `json:"current_level"`
+	SmallBlind    int                `json:"small_blind"`
+	BigBlind      int                `json:"big_blind"`
+	Ante          int                `json:"ante"`
+	Denominations []int              `json:"denominations" desc:"Chip denominations in play at this level"`
+	NextLevel     *models.BlindLevel `json:"next_level"`
+	TimeUntilNext float64            `json:"time_until_next" desc:"Seconds"`
+}
+
+// PlayerEliminatedPayload is the payload of "player_eliminated"
+type PlayerEliminatedPayload struct {
+	TournamentID     string `json:"tournament_id"`
+	UserID           string `json:"user_id"`
+	Username         string `json:"username"`
+	Position         int    `json:"position"`
+	RemainingPlayers int    `json:"remaining_players"`
+	IsFinalTable     bool   `json:"is_final_table"`
+}
+
+// TournamentCompletePayload is the payload of "tournament_complete"
+type TournamentCompletePayload struct {
+	TournamentID string                    `json:"tournament_id"`
+	WinnerID     string                    `json:"winner_id"`
+	WinnerName   string                    `json:"winner_name"`
+	Standings    []models.TournamentPlayer `json:"standings"`
+}
+
+// PrizeAwardedPayload is the payload of "prize_awarded"
+type PrizeAwardedPayload struct {
+	TournamentID string `json:"tournament_id"`
+	UserID       string `json:"user_id"`
+	Username     string `json:"username"`
+	Amount       int    `json:"amount"`
+}
+
+// ConsolidationPayload is the payload of "consolidation_planned" and
+// "tables_consolidated"
+type ConsolidationPayload struct {
+	TournamentID     string                        `json:"tournament_id"`
+	Plan             *tournament.ConsolidationPlan `json:"plan" desc:"Who moves where; seats at a final table are drawn again"`
+	CountdownSeconds int                           `json:"countdown_seconds,omitempty" desc:"In consolidation_planned, until the plan applies from each table's next hand"`
+}
+
+// TournamentPayload is the payload of "tournament_created", "tournament_started",
+// "tournament_paused" and "tournament_resumed"
+type TournamentPayload struct {
+	TournamentID    string             `json:"tournament_id"`
+	Tournament      *models.Tournament `json:"tournament,omitempty"`
+	Status          string             `json:"status,omitempty" desc:"In tournament_paused and tournament_resumed"`
+	PausedAt        *time.Time         `json:"paused_at,omitempty"`
+	ResumedAt       *time.Time         `json:"resumed_at,omitempty"`
+	TotalPausedTime int                `json:"total_paused_time,omitempty" desc:"Seconds, in tournament_resumed"`
+}
+
+// TournamentUpdatePayload is the payload of "tournament_update"
+type TournamentUpdatePayload struct {
+	Tournament *models.Tournament        `json:"tournament"`
+	Players    []models.TournamentPlayer `json:"players"`
+	SeatsLeft  int                       `json:"seats_left" desc:"A sit & go starts when this reaches 0"`
+}
+
+var serverMessages = []spec{
+	{"error", SourceServer, "In reply to a message the server refused", ErrorPayload{}},
+	{"hello_ack", SourceServer, "In reply to hello", HelloAckPayload{}},
+	{"pong", SourceServer, "In reply to ping", nil},
+	{"table_state", SourceServer, "In reply to subscribe_table. Tournament tables being set up send pot_main, pot_side and current_hand instead of pot and the hand fields.", TableStatePayload{}},
+	{"game_update", SourceServer, "To everyone subscribed to a table whenever its state changes", TableStatePayload{}},
+	{"game_delta", SourceServer, "Instead of game_update, to clients with delta_updates, when the seats are unchanged", GameDeltaPayload{}},
+	{"history_log", SourceServer, "With each game_update, when the table has history", HistoryLogPayload{}},
+	{"spectator_delay", SourceServer, "To a spectator subscribing to a delayed table before any state was released", SpectatorDelayPayload{}},
+	{"action_required", SourceServer, "To the player to act, to clients that declared the action_required feature", ActionRequiredPayload{}},
+	{"action_confirmed", SourceServer, "To a player whose game_action was accepted", ActionConfirmedPayload{}},
+	{"player_action_broadcast", SourceServer, "To everyone at a table after each accepted action", PlayerActionBroadcastPayload{}},
+	{"game_complete", SourceServer, "To everyone at a sit & go table when one player has all the chips", GameCompletePayload{}},
+	{"tournament_table_complete", SourceServer, "To everyone at a tournament table when its last player is left", GameCompletePayload{}},
+	{"seat_change_queued", SourceServer, "In reply to seat_change during a hand; the move happens when it ends", SeatChangePayload{}},
+	{"seat_change_applied", SourceServer, "In reply to seat_change between hands", SeatChangePayload{}},
+	{"show_cards_prompt", SourceServer, "To the winner of a cash table pot nobody contested, who may show their cards before the next hand", ShowCardsPromptPayload{}},
+	{"chat_message", SourceServer, "To everyone subscribed to a table, except those ignoring the sender", chat.Message{}},
+	{"director_result", SourceServer, "In reply to director", DirectorResultPayload{}},
+	{"balance_update", SourceServer, "To a user whenever their chip balance changes", BalanceUpdatePayload{}},
+	{"match_found", SourceServer, "To a queued player when matchmaking seats them", MatchFoundPayload{}},
+	{"leaderboard_update", SourceServer, "To everyone when the top of a leaderboard changes, at most every 5 seconds per board", LeaderboardUpdatePayload{}},
+	{"mission_progress", SourceServer, "To a player when a game event moves one of their daily missions", MissionProgressPayload{}},
+	{"referral_reward", SourceServer, "To both players when a referred player reaches a referral milestone", ReferralRewardPayload{}},
+	{"level_up", SourceServer, "To a player when experience takes them to a new level", LevelUpPayload{}},
+	{"table_closed", SourceServer, "To everyone at a cash table an admin force-completed or its creator closed", TableClosedPayload{}},
+	{"session_summary", SourceServer, "To everyone who played a timed cash session when it ends, just after table_closed", SessionSummaryPayload{}},
+	{"table_control", SourceServer, "To everyone at a cash table when its creator kicks a player, pauses or resumes it", TableControlPayload{}},
+	{"deadline_warning", SourceServer, "To everyone at a cash table, or a tournament's lobby, 15, 5 and 1 minutes before a scheduled close, registration close or end", DeadlineWarningPayload{}},
+	{"announcement", SourceServer, "To an announcement's audience when it starts", AnnouncementPayload{}},
+	{"announcement_cancelled", SourceServer, "To everyone when an admin cancels an announcement", AnnouncementCancelledPayload{}},
+	{"server_restarting", SourceServer, "To everyone when the server shuts down, just before the connection closes with code 4003", ServerRestartingPayload{}},
+	{"player_moved", SourceServer, "To a tournament player moved to another table", PlayerMovedPayload{}},
+	{"tournament_announcement", SourceServer, "To every table of a tournament when its director announces", TournamentAnnouncementPayload{}},
+	{"tournament_break", SourceServer, "To every table of a tournament when its director calls a break", TournamentBreakPayload{}},
+	{"tournament_break_started", SourceServer, "To every table of a tournament when a break its structure schedules between levels starts", TournamentBreakStartedPayload{}},
+	{"tournament_break_ended", SourceServer, "To every table of a tournament when a scheduled break ends and dealing resumes", TournamentBreakEndedPayload{}},
+	{"tournament_clock", SourceServer, "To every table of a tournament when its director adds level time", TournamentClockPayload{}},
+	{"hand_for_hand", SourceServer, "To a tournament's lobby when hand-for-hand play starts or ends", HandForHandPayload{}},
+	{"bracket_update", SourceServer, "To a heads-up bracket tournament's lobby when it starts and whenever a match is settled", BracketUpdatePayload{}},
+	{"knockout", SourceServer, "To a bounty tournament's lobby when the bounty of a knocked out player is paid, once per eliminator", KnockoutPayload{}},
+	{"final_table_started", SourceServer, "To a tournament's lobby when its final table is drawn", FinalTableStartedPayload{}},
+	{"final_table_hold", SourceServer, "To everyone at a final table held or released for broadcast", FinalTableHoldPayload{}},
+	{"color_up", SourceServer, "To everyone at a tournament table after a color-up", ColorUpPayload{}},
+	{"blind_level_increased", SourceServer, "To everyone when a tournament's blinds go up", BlindLevelIncreasedPayload{}},
+	{"player_eliminated", SourceServer, "To everyone when a tournament player busts", PlayerEliminatedPayload{}},
+	{"tournament_complete", SourceServer, "To everyone when a tournament ends", TournamentCompletePayload{}},
+	{"prize_awarded", SourceServer, "To everyone for each tournament prize paid", PrizeAwardedPayload{}},
+	{"consolidation_planned", SourceServer, "To everyone when a tournament's tables are about to be merged", ConsolidationPayload{}},
+	{"tables_consolidated", SourceServer, "To everyone when a tournament's tables are merged", ConsolidationPayload{}},
+	{"tournament_created", SourceServer, "To the lobby when a tournament is created", TournamentPayload{}},
+	{"tournament_started", SourceServer, "To a tournament's lobby when it starts", TournamentPayload{}},
+	{"tournament_paused", SourceServer, "To a tournament's lobby when it is paused", TournamentPayload{}},
+	{"tournament_resumed", SourceServer, "To a tournament's lobby when it resumes", TournamentPayload{}},
+	{"tournament_update", SourceServer, "To a tournament's lobby when registrations or standings change", TournamentUpdatePayload{}},
+}
@@ -0,0 +1,363 @@
+package fairness
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	pokerModels "poker-engine/models"
+
+	"gorm.io/gorm"
+)
+
+// Config holds the alerting settings of the analyzer
+type Config struct {
+	CheckInterval time.Duration // How often the distributions are tested
+	AlertPValue   float64       // Tests with a p-value below this raise an alert
+	BackfillDays  int           // Days of completed hands loaded from the database at startup
+}
+
+// DefaultConfig tests every 10 minutes and only alerts on a 1 in 100,000 result
+var DefaultConfig = Config{
+	CheckInterval: 10 * time.Minute,
+	AlertPValue:   1e-5,
+	BackfillDays:  30,
+}
+
+// minExpectedCount is the smallest expected count in any cell for a
+// chi-square test to be meaningful
+const minExpectedCount = 5
+
+var (
+	suits = []pokerModels.Suit{pokerModels.Hearts, pokerModels.Diamonds, pokerModels.Clubs, pokerModels.Spades}
+	ranks = []pokerModels.Rank{
+		pokerModels.Two, pokerModels.Three, pokerModels.Four, pokerModels.Five, pokerModels.Six,
+		pokerModels.Seven, pokerModels.Eight, pokerModels.Nine, pokerModels.Ten,
+		pokerModels.Jack, pokerModels.Queen, pokerModels.King, pokerModels.Ace,
+	}
+
+	// Shares of the 22,100 possible flops
+	flopSuitCategories    = []string{"monotone", "two_tone", "rainbow"}
+	flopSuitShares        = []float64{1144.0 / 22100, 12168.0 / 22100, 8788.0 / 22100}
+	flopPairingCategories = []string{"unpaired", "paired", "trips"}
+	flopPairingShares     = []float64{18304.0 / 22100, 3744.0 / 22100, 52.0 / 22100}
+
+	// Shares of the 1,326 possible starting hands
+	startingHandCategories = []string{"pair", "suited", "offsuit"}
+	startingHandShares     = []float64{78.0 / 1326, 312.0 / 1326, 936.0 / 1326}
+)
+
+// WinningHandReference is how often each hand rank is made from seven random
+// cards. Winning hands are stronger than this since players choose which
+// hands go to showdown, so they are reported for reference and never alert.
+var WinningHandReference = map[string]float64{
+	"High Card":       0.174119,
+	"One Pair":        0.438225,
+	"Two Pair":        0.234955,
+	"Three of a Kind": 0.048299,
+	"Straight":        0.046194,
+	"Flush":           0.030255,
+	"Full House":      0.025961,
+	"Four of a Kind":  0.001681,
+	"Straight Flush":  0.000279,
+	"Royal Flush":     0.000032,
+}
+
+// Test is a chi-square goodness of fit test of one distribution
+type Test struct {
+	Name             string             `json:"name"`
+	Description      string             `json:"description"`
+	Samples          int64              `json:"samples"`
+	Observed         map[string]int64   `json:"observed"`
+	Expected         map[string]float64 `json:"expected"` // Share of samples
+	ChiSquare        float64            `json:"chi_square"`
+	DegreesOfFreedom int                `json:"degrees_of_freedom"`
+	PValue           float64            `json:"p_value"`
+	Sufficient       bool               `json:"sufficient"` // Enough samples for the test to be meaningful
+	Alert            bool               `json:"alert"`
+}
+
+// Report is the public fairness report
+type Report struct {
+	GeneratedAt          time.Time          `json:"generated_at"`
+	HandsAnalyzed        int64              `json:"hands_analyzed"`
+	AlertPValue          float64            `json:"alert_p_value"`
+	Tests                []Test             `json:"tests"`
+	WinningHands         map[string]int64   `json:"winning_hands"`
+	WinningHandReference map[string]float64 `json:"winning_hand_reference"`
+	Alerts               []string           `json:"alerts"`
+}
+
+// Analyzer aggregates dealt cards across all tables and tests them against
+// the distributions a fair deck produces
+type Analyzer struct {
+	mu            sync.Mutex
+	config        Config
+	hands         int64
+	holeCards     [52]int64
+	boardCards    [52]int64
+	startingHands [3]int64
+	flopSuits     [3]int64
+	flopPairing   [3]int64
+	winningHands  map[string]int64
+	alerting      map[string]bool // Tests currently alerting, so each alert is raised once
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+}
+
+// NewAnalyzer creates an analyzer with no hands recorded
+func NewAnalyzer(config Config) *Analyzer {
+	return &Analyzer{
+		config:       config,
+		winningHands: make(map[string]int64),
+		alerting:     make(map[string]bool),
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// cardIndex returns a card's position in a 52 card deck, or -1 if invalid
+func cardIndex(card pokerModels.Card) int {
+	for s, suit := range suits {
+		if suit != card.Suit {
+			continue
+		}
+		for r, rank := range ranks {
+			if rank == card.Rank {
+				return s*len(ranks) + r
+			}
+		}
+	}
+	return -1
+}
+
+// RecordHand adds a completed hand. holeCards holds the cards dealt to each
+// player; it is nil for hands loaded from history, where they aren't stored.
+func (a *Analyzer) RecordHand(holeCards [][]pokerModels.Card, board []pokerModels.Card, winners []pokerModels.Winner) {
+	if a == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.hands++
+	for _, cards := range holeCards {
+		if len(cards) != 2 {
+			continue
+		}
+		first, second := cardIndex(cards[0]), cardIndex(cards[1])
+		if first < 0 || second < 0 {
+			continue
+		}
+		a.holeCards[first]++
+		a.holeCards[second]++
+
+		switch {
+		case cards[0].Rank == cards[1].Rank:
+			a.startingHands[0]++
+		case cards[0].Suit == cards[1].Suit:
+			a.startingHands[1]++
+		default:
+			a.startingHands[2]++
+		}
+	}
+
+	for _, card := range board {
+		if index := cardIndex(card); index >= 0 {
+			a.boardCards[index]++
+		}
+	}
+	if len(board) >= 3 {
+		a.recordFlop(board[:3])
+	}
+
+	// Each winner counts once per hand, however many pots they won
+	seen := make(map[string]bool)
+	for _, winner := range winners {
+		if seen[winner.PlayerID] {
+			continue
+		}
+		seen[winner.PlayerID] = true
+		if _, ok := WinningHandReference[winner.HandRank]; ok {
+			a.winningHands[winner.HandRank]++
+		}
+	}
+}
+
+// recordFlop classifies a flop by suits and pairing. Caller must hold a.mu.
+func (a *Analyzer) recordFlop(flop []pokerModels.Card) {
+	suitCount := map[pokerModels.Suit]bool{}
+	rankCount := map[pokerModels.Rank]bool{}
+	for _, card := range flop {
+		suitCount[card.Suit] = true
+		rankCount[card.Rank] = true
+	}
+	a.flopSuits[len(suitCount)-1]++
+	a.flopPairing[3-len(rankCount)]++
+}
+
+// Report tests every distribution recorded so far
+func (a *Analyzer) Report() Report {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cardLabels := make([]string, 0, 52)
+	for _, suit := range suits {
+		for _, rank := range ranks {
+			cardLabels = append(cardLabels, string(rank)+string(suit))
+		}
+	}
+	uniform := make([]float64, 52)
+	for i := range uniform {
+		uniform[i] = 1.0 / 52
+	}
+
+	report := Report{
+		GeneratedAt:          time.Now(),
+		HandsAnalyzed:        a.hands,
+		AlertPValue:          a.config.AlertPValue,
+		WinningHands:         make(map[string]int64, len(a.winningHands)),
+		WinningHandReference: WinningHandReference,
+		Alerts:               []string{},
+	}
+	for rank, count := range a.winningHands {
+		report.WinningHands[rank] = count
+	}
+
+	report.Tests = []Test{
+		a.test("hole_cards", "Every card is dealt to players equally often", cardLabels, a.holeCards[:], uniform),
+		a.test("starting_hands", "Pocket pairs, suited and offsuit hands are dealt at their natural rates",
+			startingHandCategories, a.startingHands[:], startingHandShares),
+		a.test("board_cards", "Every card comes on the board equally often", cardLabels, a.boardCards[:], uniform),
+		a.test("flop_suits", "Monotone, two-tone and rainbow flops come at their natural rates",
+			flopSuitCategories, a.flopSuits[:], flopSuitShares),
+		a.test("flop_pairing", "Paired and trips flops come at their natural rates",
+			flopPairingCategories, a.flopPairing[:], flopPairingShares),
+	}
+	for _, test := range report.Tests {
+		if test.Alert {
+			report.Alerts = append(report.Alerts, test.Name)
+		}
+	}
+	return report
+}
+
+// test runs a chi-square test of counts against expected shares. Caller must
+// hold a.mu.
+func (a *Analyzer) test(name, description string, labels []string, counts []int64, shares []float64) Test {
+	observed := append([]int64(nil), counts...)
+	test := Test{
+		Name:        name,
+		Description: description,
+		Observed:    make(map[string]int64, len(labels)),
+		Expected:    make(map[string]float64, len(labels)),
+	}
+
+	minShare := 1.0
+	for i, label := range labels {
+		test.Observed[label] = observed[i]
+		test.Expected[label] = shares[i]
+		test.Samples += observed[i]
+		if shares[i] < minShare {
+			minShare = shares[i]
+		}
+	}
+
+	test.ChiSquare, test.DegreesOfFreedom = chiSquare(observed, shares)
+	test.PValue = chiSquarePValue(test.ChiSquare, test.DegreesOfFreedom)
+	test.Sufficient = float64(test.Samples)*minShare >= minExpectedCount
+	test.Alert = test.Sufficient && test.PValue < a.config.AlertPValue
+	return test
+}
+
+// Check tests the distributions and logs an alert for each test that has
+// started failing. It returns the report.
+func (a *Analyzer) Check() Report {
+	report := a.Report()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, test := range report.Tests {
+		if test.Alert && !a.alerting[test.Name] {
+			log.Printf("[FAIRNESS_ALERT] %s deviates from a fair deck: chi-square %.1f with %d degrees of freedom, p=%.2g over %d samples",
+				test.Name, test.ChiSquare, test.DegreesOfFreedom, test.PValue, test.Samples)
+		} else if !test.Alert && a.alerting[test.Name] {
+			log.Printf("[FAIRNESS] %s is back within expectation (p=%.2g)", test.Name, test.PValue)
+		}
+		a.alerting[test.Name] = test.Alert
+	}
+	return report
+}
+
+// Backfill loads the boards and winners of hands completed in the last
+// BackfillDays. Hole cards aren't stored, so only live hands count for them.
+func (a *Analyzer) Backfill(db *gorm.DB) (int, error) {
+	const batchSize = 1000
+	since := time.Now().AddDate(0, 0, -a.config.BackfillDays)
+
+	type handRow struct {
+		ID             int64
+		CommunityCards string
+		Winners        string
+	}
+
+	loaded := 0
+	var lastID int64
+	for {
+		var rows []handRow
+		err := db.Table("hands").
+			Select("id", "community_cards", "winners").
+			Where("id > ? AND completed_at IS NOT NULL AND completed_at >= ?", lastID, since).
+			Order("id ASC").
+			Limit(batchSize).
+			Scan(&rows).Error
+		if err != nil {
+			return loaded, fmt.Errorf("failed to load hands: %w", err)
+		}
+
+		for _, row := range rows {
+			var board []pokerModels.Card
+			var winners []pokerModels.Winner
+			json.Unmarshal([]byte(row.CommunityCards), &board)
+			json.Unmarshal([]byte(row.Winners), &winners)
+			a.RecordHand(nil, board, winners)
+			lastID = row.ID
+			loaded++
+		}
+		if len(rows) < batchSize {
+			return loaded, nil
+		}
+	}
+}
+
+// Start loads recent history and begins testing the distributions periodically
+func (a *Analyzer) Start(db *gorm.DB) {
+	go func() {
+		loaded, err := a.Backfill(db)
+		if err != nil {
+			log.Printf("[FAIRNESS] ERROR: Backfill failed after %d hands: %v", loaded, err)
+		} else {
+			log.Printf("[FAIRNESS] Loaded %d hands from the last %d days", loaded, a.config.BackfillDays)
+		}
+
+		ticker := time.NewTicker(a.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.Check()
+			case <-a.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic tests
+func (a *Analyzer) Stop() {
+	a.stopOnce.Do(func() {
+		close(a.stopChan)
+	})
+}
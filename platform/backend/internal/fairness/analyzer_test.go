@@ -0,0 +1,168 @@
+package fairness
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	pokerModels "poker-engine/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestChiSquarePValue(t *testing.T) {
+	// Critical values at the 5% level
+	cases := []struct {
+		stat float64
+		df   int
+	}{
+		{3.841, 1},
+		{5.991, 2},
+		{11.070, 5},
+		{68.669, 51},
+	}
+	for _, c := range cases {
+		if p := chiSquarePValue(c.stat, c.df); math.Abs(p-0.05) > 0.001 {
+			t.Errorf("Expected p=0.05 for chi-square %.3f with %d degrees of freedom, got %.4f", c.stat, c.df, p)
+		}
+	}
+	if p := chiSquarePValue(0, 3); p != 1 {
+		t.Errorf("Expected p=1 for a perfect fit, got %v", p)
+	}
+}
+
+// dealHands deals hands of 6 players and a full board from shuffled decks
+func dealHands(analyzer *Analyzer, rng *rand.Rand, hands int, deck []pokerModels.Card) {
+	for h := 0; h < hands; h++ {
+		shuffled := append([]pokerModels.Card(nil), deck...)
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		holeCards := make([][]pokerModels.Card, 6)
+		for p := range holeCards {
+			holeCards[p] = shuffled[p*2 : p*2+2]
+		}
+		analyzer.RecordHand(holeCards, shuffled[12:17], nil)
+	}
+}
+
+func fullDeck() []pokerModels.Card {
+	deck := make([]pokerModels.Card, 0, 52)
+	for _, suit := range suits {
+		for _, rank := range ranks {
+			deck = append(deck, pokerModels.Card{Rank: rank, Suit: suit})
+		}
+	}
+	return deck
+}
+
+func testByName(report Report, name string) Test {
+	for _, test := range report.Tests {
+		if test.Name == name {
+			return test
+		}
+	}
+	return Test{}
+}
+
+func TestAnalyzer_FairDeckDoesNotAlert(t *testing.T) {
+	analyzer := NewAnalyzer(DefaultConfig)
+	dealHands(analyzer, rand.New(rand.NewSource(1)), 5000, fullDeck())
+
+	report := analyzer.Check()
+	if report.HandsAnalyzed != 5000 {
+		t.Errorf("Expected 5000 hands analyzed, got %d", report.HandsAnalyzed)
+	}
+	if len(report.Alerts) != 0 {
+		t.Errorf("Expected no alerts for a fair deck, got %v", report.Alerts)
+	}
+	for _, test := range report.Tests {
+		if !test.Sufficient {
+			t.Errorf("Expected 5000 hands to be enough for %s", test.Name)
+		}
+	}
+	if samples := testByName(report, "flop_suits").Samples; samples != 5000 {
+		t.Errorf("Expected 5000 flops, got %d", samples)
+	}
+}
+
+func TestAnalyzer_BiasedDeckAlerts(t *testing.T) {
+	analyzer := NewAnalyzer(DefaultConfig)
+
+	// A deck that deals the ace of hearts twice as often as it should
+	deck := append(fullDeck(), pokerModels.Card{Rank: pokerModels.Ace, Suit: pokerModels.Hearts})
+	dealHands(analyzer, rand.New(rand.NewSource(2)), 5000, deck)
+
+	report := analyzer.Check()
+	if !testByName(report, "hole_cards").Alert || !testByName(report, "board_cards").Alert {
+		t.Errorf("Expected card distribution alerts for a biased deck, got %v", report.Alerts)
+	}
+	if !analyzer.alerting["hole_cards"] {
+		t.Error("Expected the hole_cards alert to be tracked as raised")
+	}
+}
+
+func TestAnalyzer_FewSamplesDoNotAlert(t *testing.T) {
+	analyzer := NewAnalyzer(DefaultConfig)
+	flop := []pokerModels.Card{
+		{Rank: pokerModels.Ace, Suit: pokerModels.Spades},
+		{Rank: pokerModels.Ace, Suit: pokerModels.Hearts},
+		{Rank: pokerModels.Ace, Suit: pokerModels.Clubs},
+	}
+	for i := 0; i < 20; i++ {
+		analyzer.RecordHand(nil, flop, nil)
+	}
+
+	pairing := testByName(analyzer.Report(), "flop_pairing")
+	if pairing.Observed["trips"] != 20 {
+		t.Errorf("Expected 20 trips flops, got %d", pairing.Observed["trips"])
+	}
+	if pairing.Sufficient || pairing.Alert {
+		t.Error("Expected 20 flops to be too few to alert")
+	}
+}
+
+func TestAnalyzer_WinningHandsCountOncePerHand(t *testing.T) {
+	analyzer := NewAnalyzer(DefaultConfig)
+	analyzer.RecordHand(nil, nil, []pokerModels.Winner{
+		{PlayerID: "a", HandRank: "Flush"},
+		{PlayerID: "a", HandRank: "Flush"}, // Side pot
+		{PlayerID: "b", HandRank: "Winner by default"},
+	})
+
+	report := analyzer.Report()
+	if report.WinningHands["Flush"] != 1 || len(report.WinningHands) != 1 {
+		t.Errorf("Expected one flush and no uncontested wins, got %v", report.WinningHands)
+	}
+}
+
+func TestAnalyzer_Backfill(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.Hand{})
+	db.Exec(`INSERT INTO hands (community_cards, winners, completed_at) VALUES
+		('[{"rank":"A","suit":"s"},{"rank":"K","suit":"s"},{"rank":"2","suit":"s"}]', '[{"playerId":"a","handRank":"Flush"}]', CURRENT_TIMESTAMP),
+		('[]', '[]', NULL)`)
+
+	analyzer := NewAnalyzer(DefaultConfig)
+	loaded, err := analyzer.Backfill(db)
+	if err != nil {
+		t.Fatalf("Backfill failed: %v", err)
+	}
+	if loaded != 1 {
+		t.Fatalf("Expected 1 completed hand loaded, got %d", loaded)
+	}
+
+	report := analyzer.Report()
+	if testByName(report, "flop_suits").Observed["monotone"] != 1 {
+		t.Error("Expected the backfilled flop to count as monotone")
+	}
+	if testByName(report, "hole_cards").Samples != 0 {
+		t.Error("Expected no hole cards from history")
+	}
+}
@@ -0,0 +1,85 @@
+package fairness
+
+import "math"
+
+// chiSquare returns Pearson's chi-square statistic for observed counts
+// against expected shares of the total, and its degrees of freedom
+func chiSquare(observed []int64, expectedShares []float64) (float64, int) {
+	var total int64
+	for _, count := range observed {
+		total += count
+	}
+	if total == 0 {
+		return 0, len(observed) - 1
+	}
+
+	stat := 0.0
+	for i, count := range observed {
+		expected := expectedShares[i] * float64(total)
+		if expected == 0 {
+			continue
+		}
+		diff := float64(count) - expected
+		stat += diff * diff / expected
+	}
+	return stat, len(observed) - 1
+}
+
+// chiSquarePValue returns the probability of a chi-square statistic at least
+// this large if the counts follow the expected distribution
+func chiSquarePValue(stat float64, degreesOfFreedom int) float64 {
+	if degreesOfFreedom <= 0 || stat <= 0 {
+		return 1
+	}
+	return upperGammaRegularized(float64(degreesOfFreedom)/2, stat/2)
+}
+
+// upperGammaRegularized computes Q(a, x) = Γ(a, x) / Γ(a), using the series
+// for small x and the continued fraction otherwise
+func upperGammaRegularized(a, x float64) float64 {
+	const (
+		maxIterations = 500
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+	lgammaA, _ := math.Lgamma(a)
+
+	if x < a+1 {
+		sum := 1 / a
+		term := sum
+		for n := 1; n < maxIterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		lower := sum * math.Exp(-x+a*math.Log(x)-lgammaA)
+		return math.Max(0, 1-lower)
+	}
+
+	// Modified Lentz's method
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < maxIterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lgammaA) * h
+}
@@ -0,0 +1,85 @@
+package freeze
+
+import (
+	"errors"
+	"testing"
+
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}, &models.AccountFreeze{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	user := models.User{ID: "user-1", Username: "alice", Email: "alice@example.com", PasswordHash: "x"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	return db
+}
+
+func TestFreeze_FreezeAndLift(t *testing.T) {
+	db := setupTestDB(t)
+
+	record, err := Freeze(db, "user-1", "admin-1", "suspected compromise")
+	if err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+	if record.FrozenBy != "admin-1" || record.Reason != "suspected compromise" {
+		t.Errorf("Expected the freeze to record the admin and reason, got %+v", record)
+	}
+	if _, err := Freeze(db, "user-1", "admin-2", "again"); !errors.Is(err, ErrAlreadyFrozen) {
+		t.Errorf("Expected ErrAlreadyFrozen, got %v", err)
+	}
+
+	ids, err := FrozenUserIDs(db)
+	if err != nil || len(ids) != 1 || ids[0] != "user-1" {
+		t.Errorf("Expected user-1 to be frozen, got %v (%v)", ids, err)
+	}
+
+	lifted, err := Lift(db, "user-1", "admin-2")
+	if err != nil {
+		t.Fatalf("Lift failed: %v", err)
+	}
+	if lifted.LiftedBy == nil || *lifted.LiftedBy != "admin-2" || lifted.LiftedAt == nil {
+		t.Errorf("Expected the lift to be logged, got %+v", lifted)
+	}
+	if _, err := Lift(db, "user-1", "admin-2"); !errors.Is(err, ErrNotFrozen) {
+		t.Errorf("Expected ErrNotFrozen, got %v", err)
+	}
+
+	ids, _ = FrozenUserIDs(db)
+	if len(ids) != 0 {
+		t.Errorf("Expected no frozen users, got %v", ids)
+	}
+
+	history, err := History(db, "user-1")
+	if err != nil || len(history) != 1 {
+		t.Fatalf("Expected one freeze in the history, got %d (%v)", len(history), err)
+	}
+	if history[0].LiftedAt == nil {
+		t.Error("Expected the logged freeze to be closed")
+	}
+}
+
+func TestFreeze_Errors(t *testing.T) {
+	db := setupTestDB(t)
+
+	if _, err := Freeze(db, "missing", "admin-1", "reason"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	long := make([]byte, MaxReasonLength+1)
+	for i := range long {
+		long[i] = 'x'
+	}
+	if _, err := Freeze(db, "user-1", "admin-1", string(long)); !errors.Is(err, ErrReasonTooLong) {
+		t.Errorf("Expected ErrReasonTooLong, got %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+// Package identity maps player IDs from other systems onto platform users.
+// A standalone engine deployment names its players however its operator
+// chose; importing them gives each one a platform user of its own, or the
+// account an admin linked it to, so their IDs and hand histories can come
+// across without colliding with anyone already here.
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"poker-platform/backend/internal/auth"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/moderation"
+
+	"poker-engine/engine"
+
+	"gorm.io/gorm"
+)
+
+// SourceEngine is the source of players from a standalone engine deployment
+const SourceEngine = "engine"
+
+// importedChips matches the play chips a new account gets on registration
+const importedChips = 10000
+
+// Identity errors
+var (
+	ErrInvalidIdentity = errors.New("source and external ID are required")
+	ErrAlreadyLinked   = errors.New("external ID is already linked to a user")
+	ErrUserNotFound    = errors.New("user not found")
+)
+
+// Player is a player as another system knows them
+type Player struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Service keeps the map of external player IDs to platform users
+type Service struct {
+	db         *gorm.DB
+	moderation *moderation.Service
+}
+
+// NewService creates an identity service. Usernames made for imported
+// players are screened by the moderation service.
+func NewService(db *gorm.DB, moderationService *moderation.Service) *Service {
+	return &Service{db: db, moderation: moderationService}
+}
+
+// List returns the identities from a source, or from every source
+func (s *Service) List(source string) ([]models.PlayerIdentity, error) {
+	var identities []models.PlayerIdentity
+	query := s.db.Order("source, external_id")
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	err := query.Find(&identities).Error
+	return identities, err
+}
+
+// Link ties an external player ID to an existing user, so importing it later
+// brings its history to that user instead of a new one
+func (s *Service) Link(source, externalID, userID string) (*models.PlayerIdentity, error) {
+	if source == "" || externalID == "" {
+		return nil, ErrInvalidIdentity
+	}
+	identity := &models.PlayerIdentity{Source: source, ExternalID: externalID, UserID: userID}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var users int64
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Count(&users).Error; err != nil {
+			return err
+		}
+		if users == 0 {
+			return ErrUserNotFound
+		}
+		existing, err := findIdentity(tx, source, externalID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return ErrAlreadyLinked
+		}
+		return tx.Create(identity).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// Resolve returns the user an external player maps to. A player seen for the
+// first time gets a new user with a fresh ID, a username based on their name
+// and no usable password.
+func (s *Service) Resolve(source string, player Player) (string, error) {
+	if source == "" || player.ID == "" {
+		return "", ErrInvalidIdentity
+	}
+	existing, err := findIdentity(s.db, source, player.ID)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		return existing.UserID, nil
+	}
+
+	userID := auth.GenerateID()
+	username, match := s.pickUsername(player.Name, userID)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		user := models.User{
+			ID:           userID,
+			Username:     username,
+			Email:        userID + "@imported.invalid",
+			PasswordHash: "!", // Matches no password: an imported user holds history, not a login
+			Chips:        importedChips,
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.PlayerIdentity{
+			Source:     source,
+			ExternalID: player.ID,
+			UserID:     userID,
+			Imported:   true,
+		}).Error
+	})
+	if err != nil {
+		// Another import may have claimed the identity first
+		if existing, findErr := findIdentity(s.db, source, player.ID); findErr == nil && existing != nil {
+			return existing.UserID, nil
+		}
+		return "", fmt.Errorf("failed to import player %s: %w", player.ID, err)
+	}
+
+	if _, err := s.moderation.Flag(match, moderation.KindUsername, userID, userID, username); err != nil {
+		log.Printf("[MODERATION] Failed to queue imported username of %s: %v", userID, err)
+	}
+	return userID, nil
+}
+
+// ImportHand resolves every player of an engine hand record and returns the
+// record under their platform user IDs, ready to replay or store
+func (s *Service) ImportHand(source string, record *engine.HandRecord) (*engine.HandRecord, error) {
+	ids := make(map[string]string)
+	for _, p := range record.Players {
+		if p == nil {
+			continue
+		}
+		userID, err := s.Resolve(source, Player{ID: p.PlayerID, Name: p.PlayerName})
+		if err != nil {
+			return nil, err
+		}
+		ids[p.PlayerID] = userID
+	}
+	return record.WithPlayerIDs(ids)
+}
+
+func findIdentity(tx *gorm.DB, source, externalID string) (*models.PlayerIdentity, error) {
+	var identity models.PlayerIdentity
+	err := tx.Where("source = ? AND external_id = ?", source, externalID).First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+var usernameUnsafe = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// pickUsername turns an external name into a free, valid username, numbering
+// it when taken and falling back to one made from the user ID. Names the
+// filter blocks get the fallback too.
+func (s *Service) pickUsername(name, userID string) (string, moderation.Match) {
+	fallback := "player_" + userID[:8]
+	base := strings.Trim(usernameUnsafe.ReplaceAllString(name, "_"), "_-")
+	if len(base) > 20 {
+		base = base[:20]
+	}
+	if len(base) < 3 {
+		return fallback, moderation.Match{Verdict: moderation.Allow}
+	}
+	match, err := s.moderation.Screen("username", base)
+	if err != nil {
+		return fallback, moderation.Match{Verdict: moderation.Allow}
+	}
+
+	candidate := base
+	for n := 2; n <= 100; n++ {
+		var taken int64
+		if err := s.db.Model(&models.User{}).Where("username = ?", candidate).Count(&taken).Error; err != nil {
+			break
+		}
+		if taken == 0 {
+			return candidate, match
+		}
+		suffix := fmt.Sprintf("_%d", n)
+		if len(base)+len(suffix) > 20 {
+			candidate = base[:20-len(suffix)] + suffix
+		} else {
+			candidate = base + suffix
+		}
+	}
+	return fallback, moderation.Match{Verdict: moderation.Allow}
+}
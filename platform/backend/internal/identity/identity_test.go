@@ -0,0 +1,134 @@
+package identity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/moderation"
+	"poker-platform/backend/internal/testutil"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.User{}, &models.PlayerIdentity{}, &models.ModerationItem{})
+	return NewService(db, moderation.NewService(db, moderation.DefaultFilter())), db
+}
+
+func TestService_ResolveCreatesUserOnce(t *testing.T) {
+	service, db := setupTestService(t)
+	db.Create(&models.User{ID: "existing", Username: "alice", Email: "alice@example.com", PasswordHash: "x"})
+
+	userID, err := service.Resolve(SourceEngine, Player{ID: "existing", Name: "alice"})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if userID == "existing" {
+		t.Error("Expected an engine player ID that matches a platform user ID to get a user of its own")
+	}
+
+	var user models.User
+	db.Where("id = ?", userID).First(&user)
+	if user.Username != "alice_2" {
+		t.Errorf("Expected a numbered username since alice is taken, got %s", user.Username)
+	}
+
+	again, err := service.Resolve(SourceEngine, Player{ID: "existing", Name: "renamed"})
+	if err != nil || again != userID {
+		t.Errorf("Expected the same user on a second import, got %s, %v", again, err)
+	}
+	other, err := service.Resolve("other-deployment", Player{ID: "existing", Name: "alice"})
+	if err != nil || other == userID {
+		t.Errorf("Expected the same ID from another source to be a different player, got %s, %v", other, err)
+	}
+}
+
+func TestService_ResolveUnsafeNames(t *testing.T) {
+	service, db := setupTestService(t)
+
+	userID, _ := service.Resolve(SourceEngine, Player{ID: "p1", Name: "Bob the Builder!!"})
+	var user models.User
+	db.Where("id = ?", userID).First(&user)
+	if user.Username != "Bob_the_Builder" {
+		t.Errorf("Expected an invalid name to be cleaned up, got %s", user.Username)
+	}
+
+	userID, _ = service.Resolve(SourceEngine, Player{ID: "p2", Name: "shit"})
+	var blocked models.User
+	db.Where("id = ?", userID).First(&blocked)
+	if !strings.HasPrefix(blocked.Username, "player_") {
+		t.Errorf("Expected a blocked name to be replaced, got %s", blocked.Username)
+	}
+}
+
+func TestService_Link(t *testing.T) {
+	service, db := setupTestService(t)
+	db.Create(&models.User{ID: "user-1", Username: "carol", Email: "carol@example.com", PasswordHash: "x"})
+
+	if _, err := service.Link(SourceEngine, "p7", "missing"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if _, err := service.Link(SourceEngine, "p7", "user-1"); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+	if _, err := service.Link(SourceEngine, "p7", "user-1"); !errors.Is(err, ErrAlreadyLinked) {
+		t.Errorf("Expected ErrAlreadyLinked, got %v", err)
+	}
+
+	userID, err := service.Resolve(SourceEngine, Player{ID: "p7", Name: "Carol"})
+	if err != nil || userID != "user-1" {
+		t.Errorf("Expected the linked user, got %s, %v", userID, err)
+	}
+
+	identities, _ := service.List(SourceEngine)
+	if len(identities) != 1 || identities[0].Imported {
+		t.Errorf("Expected one linked identity, got %+v", identities)
+	}
+}
+
+func TestService_ImportHand(t *testing.T) {
+	service, _ := setupTestService(t)
+
+	table := &pokerModels.Table{
+		TableID:  "engine-table",
+		GameType: pokerModels.GameTypeCash,
+		Status:   pokerModels.StatusWaiting,
+		Config:   pokerModels.TableConfig{SmallBlind: 5, BigBlind: 10, MaxPlayers: 2},
+		Players: []*pokerModels.Player{
+			pokerModels.NewPlayer("1", "Dana", 0, 1000),
+			pokerModels.NewPlayer("2", "Eli", 1, 1000),
+		},
+		CurrentHand: &pokerModels.CurrentHand{HandNumber: 0, DealerPosition: -1},
+	}
+	game := engine.NewGame(table, func(string, uint64) {}, func(pokerModels.Event) {})
+	game.SetSynchronousEvents(true)
+	if err := game.StartNewHand(); err != nil {
+		t.Fatalf("Failed to start hand: %v", err)
+	}
+	current := table.Players[table.CurrentHand.CurrentPosition]
+	if err := game.ProcessAction(current.PlayerID, pokerModels.ActionFold, 0); err != nil {
+		t.Fatalf("Fold failed: %v", err)
+	}
+
+	imported, err := service.ImportHand(SourceEngine, game.HandRecord())
+	if err != nil {
+		t.Fatalf("ImportHand failed: %v", err)
+	}
+	dana, _ := service.Resolve(SourceEngine, Player{ID: "1"})
+	if imported.Players[0].PlayerID != dana || imported.Actions[0].PlayerID == current.PlayerID {
+		t.Errorf("Expected the hand under platform user IDs, got %+v", imported.Actions)
+	}
+	if _, err := engine.ReplayHand(imported); err != nil {
+		t.Errorf("Expected the imported hand to replay, got %v", err)
+	}
+}
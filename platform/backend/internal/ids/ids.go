@@ -0,0 +1,134 @@
+// Package ids generates the platform's IDs. New entities get ULIDs: 26
+// character strings that sort in the order they were made, so rows can be
+// ordered and sharded by ID without a central counter. Existing entities keep
+// their UUIDs.
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ULIDLength is the length of a ULID string
+const ULIDLength = 26
+
+// crockford is the Crockford base32 alphabet ULIDs are written in
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ErrInvalidULID is returned for strings that are not ULIDs
+var ErrInvalidULID = errors.New("invalid ULID")
+
+// decoding maps each character to its value, or 0xFF for characters outside
+// the alphabet. Lower case letters decode like upper case ones.
+var decoding = func() [256]byte {
+	var table [256]byte
+	for i := range table {
+		table[i] = 0xFF
+	}
+	for i := 0; i < len(crockford); i++ {
+		table[crockford[i]] = byte(i)
+		table[strings.ToLower(crockford[i : i+1])[0]] = byte(i)
+	}
+	return table
+}()
+
+// generator makes ULIDs that increase within a millisecond: two made in the
+// same millisecond differ in their random part by one
+type generator struct {
+	mu     sync.Mutex
+	lastMs uint64
+	last   [10]byte // Random part of the last ULID
+}
+
+var defaultGenerator generator
+
+// New returns a new ULID for the current time
+func New() string {
+	return NewAt(time.Now())
+}
+
+// NewAt returns a new ULID for a given time. IDs made for the same millisecond
+// still sort in the order they were made.
+func NewAt(t time.Time) string {
+	return defaultGenerator.next(uint64(t.UnixMilli()))
+}
+
+func (g *generator) next(ms uint64) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if ms == g.lastMs {
+		// Same millisecond: count up from the last ID so order is kept
+		if !increment(g.last[:]) {
+			ms++
+			rand.Read(g.last[:])
+		}
+	} else {
+		rand.Read(g.last[:])
+	}
+	g.lastMs = ms
+
+	var id [16]byte
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	binary.BigEndian.PutUint32(id[2:], uint32(ms))
+	copy(id[6:], g.last[:])
+	return encode(id)
+}
+
+// increment adds one to a big-endian number, reporting false on overflow
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encode writes 128 bits as 26 base32 characters, the first holding the top 3
+func encode(id [16]byte) string {
+	var out [ULIDLength]byte
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	for i := ULIDLength - 1; i >= 0; i-- {
+		out[i] = crockford[lo&0x1F]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// IsULID reports whether s is a well-formed ULID
+func IsULID(s string) bool {
+	if len(s) != ULIDLength {
+		return false
+	}
+	// The first character holds only 3 bits
+	if v := decoding[s[0]]; v == 0xFF || v > 7 {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if decoding[s[i]] == 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// Time returns the time a ULID was made, to the millisecond
+func Time(id string) (time.Time, error) {
+	if !IsULID(id) {
+		return time.Time{}, ErrInvalidULID
+	}
+	var ms uint64
+	for i := 0; i < 10; i++ {
+		ms = ms<<5 | uint64(decoding[id[i]])
+	}
+	return time.UnixMilli(int64(ms)), nil
+}
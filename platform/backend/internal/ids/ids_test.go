@@ -0,0 +1,68 @@
+package ids
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAt_EncodesTime(t *testing.T) {
+	// Timestamp from the ULID specification's example
+	at := time.UnixMilli(1469918176385)
+	id := NewAt(at)
+	if len(id) != ULIDLength || !strings.HasPrefix(id, "01ARYZ6S41") {
+		t.Fatalf("Expected a ULID starting 01ARYZ6S41, got %s", id)
+	}
+	got, err := Time(id)
+	if err != nil || !got.Equal(at) {
+		t.Errorf("Expected the ULID to decode to %v, got %v (%v)", at, got, err)
+	}
+}
+
+func TestNew_SortsInOrderMade(t *testing.T) {
+	made := make([]string, 1000)
+	for i := range made {
+		made[i] = New()
+	}
+	sorted := append([]string(nil), made...)
+	sort.Strings(sorted)
+	for i := range made {
+		if made[i] != sorted[i] {
+			t.Fatalf("Expected IDs to sort in the order they were made; %d differs", i)
+		}
+	}
+
+	at := time.Now()
+	first, second := NewAt(at), NewAt(at)
+	if first >= second {
+		t.Errorf("Expected IDs from the same millisecond to increase, got %s then %s", first, second)
+	}
+}
+
+func TestIsULID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"Generated", New(), true},
+		{"Lower case", strings.ToLower(New()), true},
+		{"UUID", "550e8400-e29b-41d4-a716-446655440000", false},
+		{"Too short", "01ARYZ6S41", false},
+		{"Excluded letter", "01ARYZ6S41UUUUUUUUUUUUUUUU", false},
+		{"Overflowing time", "81ARYZ6S41TSV4RRFFQ69G5FAV", false},
+		{"Empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsULID(tt.input); got != tt.want {
+				t.Errorf("IsULID(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Time("not-a-ulid"); err != ErrInvalidULID {
+		t.Errorf("Expected ErrInvalidULID, got %v", err)
+	}
+}
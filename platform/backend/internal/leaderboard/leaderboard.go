@@ -0,0 +1,428 @@
+// Package leaderboard keeps daily, weekly and monthly rankings of players in
+// Redis sorted sets, one per board, period and window. A window is the
+// calendar day, ISO week or month, in UTC, that a score was earned in.
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Leaderboard types
+const (
+	TypeTournaments = "tournaments" // Prize chips won in tournaments
+	TypeCash        = "cash"        // Net chips won or lost in cash game hands
+	TypeHands       = "hands"       // Hands won, at any table
+)
+
+// Leaderboard periods
+const (
+	PeriodDaily   = "daily"
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+)
+
+// Types and Periods list the valid leaderboard types and periods
+var (
+	Types   = []string{TypeTournaments, TypeCash, TypeHands}
+	Periods = []string{PeriodDaily, PeriodWeekly, PeriodMonthly}
+)
+
+// Leaderboard errors
+var (
+	ErrInvalidType   = errors.New("leaderboard type must be tournaments, cash or hands")
+	ErrInvalidPeriod = errors.New("period must be daily, weekly or monthly")
+)
+
+// Config holds leaderboard settings
+type Config struct {
+	TopSize           int           // Entries in the top list broadcast to the lobby
+	BroadcastInterval time.Duration // How often changed top lists are broadcast
+	Retention         time.Duration // How long a window is kept after it ends
+}
+
+// DefaultConfig broadcasts changes to the top 10 at most every 5 seconds
+var DefaultConfig = Config{
+	TopSize:           10,
+	BroadcastInterval: 5 * time.Second,
+	Retention:         24 * time.Hour,
+}
+
+// Entry is one player's place on a leaderboard
+type Entry struct {
+	Rank     int    `json:"rank"`
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Score    int64  `json:"score"`
+}
+
+// Window is the stretch of time a leaderboard covers
+type Window struct {
+	ID     string    `json:"id"` // Such as 2026-10-15, 2026-W42 or 2026-10
+	Starts time.Time `json:"starts_at"`
+	Ends   time.Time `json:"ends_at"`
+}
+
+// WindowOf returns the window of a period that contains a time
+func WindowOf(period string, at time.Time) (Window, error) {
+	at = at.UTC()
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case PeriodDaily:
+		return Window{ID: day.Format("2006-01-02"), Starts: day, Ends: day.AddDate(0, 0, 1)}, nil
+	case PeriodWeekly:
+		year, week := at.ISOWeek()
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)) // Back to Monday
+		return Window{ID: fmt.Sprintf("%d-W%02d", year, week), Starts: start, Ends: start.AddDate(0, 0, 7)}, nil
+	case PeriodMonthly:
+		start := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return Window{ID: start.Format("2006-01"), Starts: start, Ends: start.AddDate(0, 1, 0)}, nil
+	}
+	return Window{}, ErrInvalidPeriod
+}
+
+func validType(boardType string) bool {
+	for _, t := range Types {
+		if t == boardType {
+			return true
+		}
+	}
+	return false
+}
+
+func boardKey(boardType, period string, window Window) string {
+	return "leaderboard:" + boardType + ":" + period + ":" + window.ID
+}
+
+// board identifies a leaderboard whose top list may need broadcasting
+type board struct {
+	boardType string
+	period    string
+}
+
+// memoryBoard is a window's scores when there is no Redis
+type memoryBoard struct {
+	scores  map[string]int64
+	expires time.Time
+}
+
+// Service records and ranks leaderboard scores
+type Service struct {
+	db     *gorm.DB
+	redis  *redis.Client
+	config Config
+
+	mu          sync.Mutex
+	memory      map[string]*memoryBoard // By key; used without Redis
+	dirty       map[board]bool          // Boards whose top list may have changed
+	lastTop     map[board][]Entry       // Top lists as last broadcast
+	onTopChange func(boardType, period string, window Window, top []Entry)
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewService creates a leaderboard service. redisClient may be nil, in which
+// case scores are kept in memory and lost on restart.
+func NewService(db *gorm.DB, redisClient *redis.Client, config Config) *Service {
+	return &Service{
+		db:       db,
+		redis:    redisClient,
+		config:   config,
+		memory:   make(map[string]*memoryBoard),
+		dirty:    make(map[board]bool),
+		lastTop:  make(map[board][]Entry),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetOnTopChange sets the callback that gets a board's top list when it changes
+func (s *Service) SetOnTopChange(callback func(boardType, period string, window Window, top []Entry)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onTopChange = callback
+}
+
+// Record adds to a user's score on every period of a board. The score can go
+// down, as on the cash board after a losing hand.
+func (s *Service) Record(boardType, userID string, amount int64, at time.Time) error {
+	if !validType(boardType) {
+		return ErrInvalidType
+	}
+	if amount == 0 {
+		return nil
+	}
+
+	ranks := make(map[string]int64, len(Periods))
+	if s.redis == nil {
+		s.mu.Lock()
+		for _, period := range Periods {
+			window, _ := WindowOf(period, at)
+			key := boardKey(boardType, period, window)
+			b := s.memory[key]
+			if b == nil {
+				b = &memoryBoard{scores: make(map[string]int64), expires: window.Ends.Add(s.config.Retention)}
+				s.memory[key] = b
+			}
+			b.scores[userID] += amount
+			ranks[period] = int64(rankOf(b.scores, userID))
+		}
+		for key, b := range s.memory {
+			if at.After(b.expires) {
+				delete(s.memory, key)
+			}
+		}
+		s.mu.Unlock()
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		pipe := s.redis.TxPipeline()
+		rankCmds := make(map[string]*redis.IntCmd, len(Periods))
+		for _, period := range Periods {
+			window, _ := WindowOf(period, at)
+			key := boardKey(boardType, period, window)
+			pipe.ZIncrBy(ctx, key, float64(amount), userID)
+			pipe.ExpireAt(ctx, key, window.Ends.Add(s.config.Retention))
+			rankCmds[period] = pipe.ZRevRank(ctx, key, userID)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to record leaderboard score: %w", err)
+		}
+		for period, cmd := range rankCmds {
+			ranks[period] = cmd.Val()
+		}
+	}
+
+	// A score change that enters, moves within or leaves the top list changes it
+	s.mu.Lock()
+	for period, rank := range ranks {
+		b := board{boardType, period}
+		if rank < int64(s.config.TopSize) || inEntries(s.lastTop[b], userID) {
+			s.dirty[b] = true
+		}
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// rankOf returns a user's zero-based rank among scores, highest first. Ties
+// are broken by user ID, descending, as Redis does.
+func rankOf(scores map[string]int64, userID string) int {
+	rank := 0
+	score := scores[userID]
+	for id, other := range scores {
+		if other > score || (other == score && id > userID) {
+			rank++
+		}
+	}
+	return rank
+}
+
+// Top returns the best entries of a board's current window
+func (s *Service) Top(boardType, period string, limit int, at time.Time) ([]Entry, Window, error) {
+	if !validType(boardType) {
+		return nil, Window{}, ErrInvalidType
+	}
+	window, err := WindowOf(period, at)
+	if err != nil {
+		return nil, Window{}, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = s.config.TopSize
+	}
+	key := boardKey(boardType, period, window)
+
+	var entries []Entry
+	if s.redis == nil {
+		s.mu.Lock()
+		if b := s.memory[key]; b != nil {
+			for userID, score := range b.scores {
+				entries = append(entries, Entry{UserID: userID, Score: score})
+			}
+		}
+		s.mu.Unlock()
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].Score != entries[j].Score {
+				return entries[i].Score > entries[j].Score
+			}
+			return entries[i].UserID > entries[j].UserID
+		})
+		if len(entries) > limit {
+			entries = entries[:limit]
+		}
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		members, err := s.redis.ZRevRangeWithScores(ctx, key, 0, int64(limit-1)).Result()
+		if err != nil {
+			return nil, window, err
+		}
+		for _, member := range members {
+			userID, _ := member.Member.(string)
+			entries = append(entries, Entry{UserID: userID, Score: int64(member.Score)})
+		}
+	}
+
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	if err := s.fillUsernames(entries); err != nil {
+		return nil, window, err
+	}
+	return entries, window, nil
+}
+
+// Position returns a user's entry on a board's current window, or nil if
+// they have no score there
+func (s *Service) Position(boardType, period, userID string, at time.Time) (*Entry, error) {
+	if !validType(boardType) {
+		return nil, ErrInvalidType
+	}
+	window, err := WindowOf(period, at)
+	if err != nil {
+		return nil, err
+	}
+	key := boardKey(boardType, period, window)
+
+	entry := &Entry{UserID: userID}
+	if s.redis == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		b := s.memory[key]
+		if b == nil {
+			return nil, nil
+		}
+		score, ok := b.scores[userID]
+		if !ok {
+			return nil, nil
+		}
+		entry.Score = score
+		entry.Rank = rankOf(b.scores, userID) + 1
+		return entry, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	pipe := s.redis.Pipeline()
+	scoreCmd := pipe.ZScore(ctx, key, userID)
+	rankCmd := pipe.ZRevRank(ctx, key, userID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry.Score = int64(scoreCmd.Val())
+	entry.Rank = int(rankCmd.Val()) + 1
+	return entry, nil
+}
+
+func (s *Service) fillUsernames(entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	userIDs := make([]string, len(entries))
+	for i, entry := range entries {
+		userIDs[i] = entry.UserID
+	}
+	var users []models.User
+	if err := s.db.Select("id", "username").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return err
+	}
+	names := make(map[string]string, len(users))
+	for _, user := range users {
+		names[user.ID] = user.Username
+	}
+	for i := range entries {
+		entries[i].Username = names[entries[i].UserID]
+	}
+	return nil
+}
+
+// Start begins broadcasting changed top lists
+func (s *Service) Start() {
+	go s.broadcastLoop()
+	log.Printf("[LEADERBOARD] Top %d broadcasts started (interval=%v)", s.config.TopSize, s.config.BroadcastInterval)
+}
+
+// Stop stops broadcasting
+func (s *Service) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+}
+
+func (s *Service) broadcastLoop() {
+	ticker := time.NewTicker(s.config.BroadcastInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush(time.Now())
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// flush sends the top list of every board that may have changed since the
+// last flush, if it did
+func (s *Service) flush(now time.Time) {
+	s.mu.Lock()
+	dirty := s.dirty
+	s.dirty = make(map[board]bool)
+	callback := s.onTopChange
+	s.mu.Unlock()
+	if callback == nil {
+		return
+	}
+
+	for b := range dirty {
+		top, window, err := s.Top(b.boardType, b.period, s.config.TopSize, now)
+		if err != nil {
+			log.Printf("[LEADERBOARD] Failed to load %s %s top list: %v", b.period, b.boardType, err)
+			continue
+		}
+		s.mu.Lock()
+		changed := !sameEntries(s.lastTop[b], top)
+		if changed {
+			s.lastTop[b] = top
+		}
+		s.mu.Unlock()
+		if changed {
+			callback(b.boardType, b.period, window, top)
+		}
+	}
+}
+
+func inEntries(entries []Entry, userID string) bool {
+	for _, entry := range entries {
+		if entry.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func sameEntries(a, b []Entry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].UserID != b[i].UserID || a[i].Score != b[i].Score {
+			return false
+		}
+	}
+	return true
+}
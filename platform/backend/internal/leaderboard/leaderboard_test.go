@@ -0,0 +1,135 @@
+package leaderboard
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestService(t *testing.T) *Service {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.User{})
+	for _, stmt := range []string{
+		`INSERT INTO users (id, username) VALUES ('alice', 'Alice'), ('bob', 'Bob'), ('carol', 'Carol')`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to create test schema: %v", err)
+		}
+	}
+	config := DefaultConfig
+	config.TopSize = 2
+	return NewService(db, nil, config)
+}
+
+func TestWindowOf(t *testing.T) {
+	// Thursday 15 October 2026, late evening in New York
+	at := time.Date(2026, 10, 15, 22, 30, 0, 0, time.FixedZone("EDT", -4*3600))
+
+	tests := []struct {
+		period string
+		id     string
+		starts time.Time
+		ends   time.Time
+	}{
+		{PeriodDaily, "2026-10-16", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{PeriodWeekly, "2026-W42", time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{PeriodMonthly, "2026-10", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		window, err := WindowOf(tt.period, at)
+		if err != nil {
+			t.Fatalf("WindowOf(%s) failed: %v", tt.period, err)
+		}
+		if window.ID != tt.id || !window.Starts.Equal(tt.starts) || !window.Ends.Equal(tt.ends) {
+			t.Errorf("WindowOf(%s) = %+v, want %s from %v to %v", tt.period, window, tt.id, tt.starts, tt.ends)
+		}
+	}
+
+	// A Sunday belongs to the week that started the Monday before
+	sunday, _ := WindowOf(PeriodWeekly, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	if sunday.ID != "2026-W42" {
+		t.Errorf("Expected Sunday in week 42, got %s", sunday.ID)
+	}
+	if _, err := WindowOf("yearly", at); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("Expected ErrInvalidPeriod, got %v", err)
+	}
+}
+
+func TestService_RecordAndTop(t *testing.T) {
+	service := setupTestService(t)
+	monday := time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)
+	wednesday := monday.AddDate(0, 0, 2)
+
+	service.Record(TypeCash, "alice", 300, monday)
+	service.Record(TypeCash, "bob", 500, wednesday)
+	service.Record(TypeCash, "alice", 250, wednesday)
+	service.Record(TypeCash, "carol", -100, wednesday)
+
+	daily, window, err := service.Top(TypeCash, PeriodDaily, 10, wednesday)
+	if err != nil {
+		t.Fatalf("Top failed: %v", err)
+	}
+	if window.ID != "2026-10-14" || len(daily) != 3 || daily[0].UserID != "bob" || daily[1].Score != 250 {
+		t.Errorf("Expected Wednesday's scores only, got %s %+v", window.ID, daily)
+	}
+
+	weekly, _, _ := service.Top(TypeCash, PeriodWeekly, 10, wednesday)
+	if weekly[0].UserID != "alice" || weekly[0].Score != 550 || weekly[0].Rank != 1 || weekly[0].Username != "Alice" {
+		t.Errorf("Expected alice to lead the week with 550, got %+v", weekly[0])
+	}
+	if weekly[2].UserID != "carol" || weekly[2].Score != -100 {
+		t.Errorf("Expected carol last with a loss, got %+v", weekly[2])
+	}
+
+	position, err := service.Position(TypeCash, PeriodWeekly, "bob", wednesday)
+	if err != nil || position == nil || position.Rank != 2 || position.Score != 500 {
+		t.Errorf("Expected bob second with 500, got %+v (%v)", position, err)
+	}
+	if position, _ := service.Position(TypeHands, PeriodWeekly, "bob", wednesday); position != nil {
+		t.Errorf("Expected no position on an empty board, got %+v", position)
+	}
+	if _, _, err := service.Top("rake", PeriodWeekly, 10, wednesday); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("Expected ErrInvalidType, got %v", err)
+	}
+}
+
+func TestService_TopChangeBroadcast(t *testing.T) {
+	service := setupTestService(t)
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	var sent []string
+	service.SetOnTopChange(func(boardType, period string, window Window, top []Entry) {
+		if period == PeriodDaily {
+			sent = append(sent, top[0].UserID)
+		}
+	})
+
+	service.Record(TypeHands, "alice", 3, now)
+	service.Record(TypeHands, "bob", 2, now)
+	service.flush(now)
+	if len(sent) != 1 || sent[0] != "alice" {
+		t.Fatalf("Expected one broadcast led by alice, got %v", sent)
+	}
+
+	// carol stays out of the top 2, so nothing changes
+	service.Record(TypeHands, "carol", 1, now)
+	service.flush(now)
+	if len(sent) != 1 {
+		t.Errorf("Expected no broadcast for a change outside the top list, got %v", sent)
+	}
+
+	service.Record(TypeHands, "bob", 5, now)
+	service.flush(now)
+	if len(sent) != 2 || sent[1] != "bob" {
+		t.Errorf("Expected a broadcast led by bob, got %v", sent)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -54,6 +55,43 @@ func NewLockManager(redisClient *redis.Client) *LockManager {
 	}
 }
 
+// InstanceID identifies this instance in the locks it holds
+func (lm *LockManager) InstanceID() string {
+	return lm.instanceID
+}
+
+// TryAcquireLock takes a lock once, without waiting or retrying. It returns
+// ErrLockAlreadyHeld if any instance holds it, this one included.
+func (lm *LockManager) TryAcquireLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if ttl == 0 {
+		ttl = DefaultLockTTL
+	}
+	lockValue := fmt.Sprintf("%s:%s", lm.instanceID, uuid.New().String())
+	lockKey := fmt.Sprintf("lock:%s", key)
+
+	acquired, err := lm.redis.SetNX(ctx, lockKey, lockValue, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+	if !acquired {
+		return nil, ErrLockAlreadyHeld
+	}
+	return &Lock{key: lockKey, value: lockValue, manager: lm, ttl: ttl, acquiredAt: time.Now()}, nil
+}
+
+// LockHolder returns the instance holding a lock, or "" if nobody does
+func (lm *LockManager) LockHolder(ctx context.Context, key string) (string, error) {
+	exists, holder, _, err := lm.GetLockInfo(ctx, key)
+	if err != nil || !exists {
+		return "", err
+	}
+	// Lock values are "<instance ID>:<lock ID>"
+	if i := strings.Index(holder, ":"); i >= 0 {
+		return holder[:i], nil
+	}
+	return holder, nil
+}
+
 // AcquireLock attempts to acquire a distributed lock with timeout and retry logic
 // It implements:
 // - Atomic lock acquisition using Redis SET NX EX
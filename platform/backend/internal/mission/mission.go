@@ -0,0 +1,159 @@
+// Package mission runs daily missions, such as playing 50 hands or winning 3
+// showdowns in a day. Progress is counted from game events, every mission
+// starts over at midnight UTC, and completing one pays its reward from the
+// house.
+package mission
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Metrics missions count
+const (
+	MetricHandsPlayed  = "hands_played"  // Hands dealt into
+	MetricHandsWon     = "hands_won"     // Hands won all or part of a pot in
+	MetricShowdownsWon = "showdowns_won" // Hands won at a showdown
+	MetricTournaments  = "tournaments"   // Tournaments finished
+)
+
+// Mission is something to do in a day for a reward
+type Mission struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Metric string `json:"metric"`
+	Target int    `json:"target"`
+	Reward int    `json:"reward"` // Chips
+}
+
+// DefaultMissions are the missions every player gets each day
+var DefaultMissions = []Mission{
+	{ID: "play_50_hands", Title: "Play 50 hands", Metric: MetricHandsPlayed, Target: 50, Reward: 500},
+	{ID: "win_10_hands", Title: "Win 10 hands", Metric: MetricHandsWon, Target: 10, Reward: 300},
+	{ID: "win_3_showdowns", Title: "Win 3 showdowns", Metric: MetricShowdownsWon, Target: 3, Reward: 300},
+	{ID: "finish_tournament", Title: "Finish a tournament", Metric: MetricTournaments, Target: 1, Reward: 200},
+}
+
+// DayOf returns the mission day a time falls in
+func DayOf(at time.Time) string {
+	return at.UTC().Format("2006-01-02")
+}
+
+// Status is how far a user is through a mission today
+type Status struct {
+	Mission
+	Progress    int        `json:"progress"` // Never more than the target
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ResetsAt    time.Time  `json:"resets_at"`
+}
+
+func statusOf(mission Mission, row models.MissionProgress, at time.Time) Status {
+	progress := row.Progress
+	if progress > mission.Target {
+		progress = mission.Target
+	}
+	y, m, d := at.UTC().Date()
+	return Status{
+		Mission:     mission,
+		Progress:    progress,
+		Completed:   row.CompletedAt != nil,
+		CompletedAt: row.CompletedAt,
+		ResetsAt:    time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// Service counts mission progress and pays rewards
+type Service struct {
+	db       *gorm.DB
+	currency *currency.Service
+	missions []Mission
+}
+
+// NewService creates a mission service running the given missions
+func NewService(db *gorm.DB, currencyService *currency.Service, missions []Mission) *Service {
+	return &Service{db: db, currency: currencyService, missions: missions}
+}
+
+// Today returns a user's progress through every mission on the day of at
+func (s *Service) Today(userID string, at time.Time) ([]Status, error) {
+	var rows []models.MissionProgress
+	if err := s.db.Where("user_id = ? AND day = ?", userID, DayOf(at)).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	byMission := make(map[string]models.MissionProgress, len(rows))
+	for _, row := range rows {
+		byMission[row.MissionID] = row
+	}
+	statuses := make([]Status, 0, len(s.missions))
+	for _, mission := range s.missions {
+		statuses = append(statuses, statusOf(mission, byMission[mission.ID], at))
+	}
+	return statuses, nil
+}
+
+// Record adds to a user's counts of the given metrics, returning the missions
+// whose progress moved. A mission reaching its target is completed and its
+// reward paid in the same transaction, so it pays once.
+func (s *Service) Record(userID string, counts map[string]int, at time.Time) ([]Status, error) {
+	day := DayOf(at)
+	ctx := context.Background()
+	var changed []Status
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		changed = nil
+		for _, mission := range s.missions {
+			count := counts[mission.Metric]
+			if count <= 0 {
+				continue
+			}
+			row := models.MissionProgress{UserID: userID, MissionID: mission.ID, Day: day, Progress: count}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "mission_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"progress": gorm.Expr("progress + ?", count)}),
+			}).Create(&row).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("user_id = ? AND mission_id = ? AND day = ?", userID, mission.ID, day).
+				First(&row).Error; err != nil {
+				return err
+			}
+			if row.CompletedAt != nil {
+				continue
+			}
+
+			if row.Progress >= mission.Target {
+				result := tx.Model(&models.MissionProgress{}).
+					Where("user_id = ? AND mission_id = ? AND day = ? AND completed_at IS NULL", userID, mission.ID, day).
+					Update("completed_at", at)
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected == 0 {
+					continue
+				}
+				row.CompletedAt = &at
+				if mission.Reward > 0 {
+					if err := s.currency.PayRewardWithTx(ctx, tx, userID, mission.Reward, currency.TxTypeMissionReward,
+						userID, "Mission reward: "+mission.Title); err != nil {
+						return fmt.Errorf("failed to pay reward of mission %s: %w", mission.ID, err)
+					}
+				}
+				log.Printf("[MISSION] User %s completed %s on %s: %d chips", userID, mission.ID, day, mission.Reward)
+			}
+			changed = append(changed, statusOf(mission, row, at))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
@@ -0,0 +1,78 @@
+package mission
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var testMissions = []Mission{
+	{ID: "play_3", Title: "Play 3 hands", Metric: MetricHandsPlayed, Target: 3, Reward: 100},
+	{ID: "win_1", Title: "Win a showdown", Metric: MetricShowdownsWon, Target: 1, Reward: 50},
+}
+
+func setupTestService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &currency.Transaction{}, &models.MissionProgress{}))
+	for _, user := range []models.User{
+		{ID: currency.OperatorAccountID, Username: "house", Email: "house@localhost", Chips: 1000},
+		{ID: "alice", Username: "alice", Email: "alice@test.com", Chips: 1000},
+	} {
+		require.NoError(t, db.Create(&user).Error)
+	}
+	return NewService(db, currency.NewService(db), testMissions), db
+}
+
+func chipsOf(t *testing.T, db *gorm.DB, userID string) int {
+	var user models.User
+	require.NoError(t, db.First(&user, "id = ?", userID).Error)
+	return user.Chips
+}
+
+func TestRecord(t *testing.T) {
+	s, db := setupTestService(t)
+	now := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	changed, err := s.Record("alice", map[string]int{MetricHandsPlayed: 2}, now)
+	require.NoError(t, err)
+	require.Len(t, changed, 1, "only missions counting the metric move")
+	assert.Equal(t, 2, changed[0].Progress)
+	assert.False(t, changed[0].Completed)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), changed[0].ResetsAt)
+
+	// Reaching the target completes the mission and pays once
+	changed, err = s.Record("alice", map[string]int{MetricHandsPlayed: 2, MetricShowdownsWon: 1}, now)
+	require.NoError(t, err)
+	require.Len(t, changed, 2)
+	assert.Equal(t, 3, changed[0].Progress, "progress stops at the target")
+	assert.True(t, changed[0].Completed)
+	assert.True(t, changed[1].Completed)
+	assert.Equal(t, 1150, chipsOf(t, db, "alice"))
+	assert.Equal(t, 850, chipsOf(t, db, currency.OperatorAccountID))
+
+	changed, err = s.Record("alice", map[string]int{MetricHandsPlayed: 1}, now)
+	require.NoError(t, err)
+	assert.Empty(t, changed, "completed missions don't move")
+	assert.Equal(t, 1150, chipsOf(t, db, "alice"))
+
+	// The next day starts over
+	tomorrow := now.Add(3 * time.Hour)
+	statuses, err := s.Today("alice", tomorrow)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	for _, status := range statuses {
+		assert.Zero(t, status.Progress)
+		assert.False(t, status.Completed)
+	}
+	statuses, err = s.Today("alice", now)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Completed)
+}
@@ -13,10 +13,22 @@ type User struct {
 	Email        string    `gorm:"column:email;type:varchar(100);uniqueIndex;not null" json:"email"`
 	PasswordHash string    `gorm:"column:password_hash;type:varchar(255);not null" json:"-"`
 	Chips        int       `gorm:"column:chips;default:10000" json:"chips"`
+	Role         string    `gorm:"column:role;type:varchar(16);default:player" json:"role"` // RolePlayer, RoleAdmin or RoleBot
+	DisplayInBB  bool      `gorm:"column:display_in_bb;default:false" json:"display_in_bb"` // Show stacks and bets in big blinds
+	FrozenAt     *time.Time `gorm:"column:frozen_at" json:"frozen_at,omitempty"`                 // Set while an admin freeze blocks actions and withdrawals
+	XP           int       `gorm:"column:xp;default:0" json:"xp"`                                // Experience from hands played and tournaments finished
+	Level        int       `gorm:"column:level;default:1" json:"level"`                          // Follows from XP; see the progression package
 	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
 	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
 }
 
+// User roles
+const (
+	RolePlayer = "player"
+	RoleAdmin  = "admin" // Can use the /api/admin routes
+	RoleBot    = "bot"   // A computer player the server acts for; can't log in
+)
+
 // TableName specifies the table name for User model
 func (User) TableName() string {
 	return "users"
@@ -26,6 +38,7 @@ func (User) TableName() string {
 type Table struct {
 	ID           string         `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
 	TournamentID *string        `gorm:"column:tournament_id;type:varchar(36);index:idx_tournament_id" json:"tournament_id,omitempty"`
+	ClubID       *string        `gorm:"column:club_id;type:varchar(36);index:idx_table_club" json:"club_id,omitempty"` // Club-scoped tables are only visible to members
 	TableNumber  *int           `gorm:"column:table_number" json:"table_number,omitempty"`
 	Name         string         `gorm:"column:name;type:varchar(100);not null" json:"name"`
 	GameType     string         `gorm:"column:game_type;type:enum('cash', 'tournament');not null" json:"game_type"`
@@ -35,6 +48,18 @@ type Table struct {
 	MaxPlayers   int            `gorm:"column:max_players;not null" json:"max_players"`
 	MinBuyIn     *int           `gorm:"column:min_buy_in" json:"min_buy_in,omitempty"`
 	MaxBuyIn     *int           `gorm:"column:max_buy_in" json:"max_buy_in,omitempty"`
+	EntryRequirements *EntryRequirements `gorm:"column:entry_requirements;serializer:json" json:"entry_requirements,omitempty"`
+	CreatorID      *string        `gorm:"column:creator_id;type:varchar(36)" json:"creator_id,omitempty"`
+	SpectatorDelay int            `gorm:"column:spectator_delay;default:0" json:"spectator_delay"` // Seconds spectators lag behind the players
+	LastHandNumber int            `gorm:"column:last_hand_number;default:0" json:"last_hand_number"`
+	DealerPosition *int           `gorm:"column:dealer_position" json:"dealer_position,omitempty"` // Button seat of the last hand, restored on recovery
+	CurrencySymbol string         `gorm:"column:currency_symbol;type:varchar(8);default:''" json:"currency_symbol"`
+	ChipScale      int            `gorm:"column:chip_scale;default:1" json:"chip_scale"` // Chips per displayed unit, e.g. 100 to show cents
+	AllowStraddle  bool           `gorm:"column:allow_straddle;default:false" json:"allow_straddle"` // Cash tables: the player under the gun may straddle
+	ShowdownPolicy string         `gorm:"column:showdown_policy;type:enum('all', 'callers', 'winners', 'never');default:all" json:"showdown_policy"` // Whose hole cards everyone sees once a hand is complete
+	BettingMode    string         `gorm:"column:betting_mode;type:enum('no_limit', 'pot_limit', 'fixed_limit');default:no_limit" json:"betting_mode"` // How much a bet or raise may be
+	AutoCloseAt    *time.Time     `gorm:"column:auto_close_at" json:"auto_close_at,omitempty"` // Cash tables: closed at this time and every stack returned
+	SessionLength  int            `gorm:"column:session_length;default:0" json:"session_length"` // Cash tables: seconds a timed session runs from its first hand; 0 = untimed
 	CreatedAt      time.Time      `gorm:"column:created_at;autoCreateTime" json:"created_at"`
 	ReadyToStartAt *time.Time     `gorm:"column:ready_to_start_at" json:"ready_to_start_at,omitempty"`
 	StartedAt      *time.Time     `gorm:"column:started_at" json:"started_at,omitempty"`
@@ -47,17 +72,125 @@ func (Table) TableName() string {
 	return "tables"
 }
 
+// Club is a private group with its own lobby of tables and tournaments
+type Club struct {
+	ID          string         `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
+	Name        string         `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Description string         `gorm:"column:description;type:varchar(500)" json:"description,omitempty"`
+	OwnerID     string         `gorm:"column:owner_id;type:varchar(36);not null;index:idx_club_owner" json:"owner_id"`
+	CreatedAt   time.Time      `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index" json:"-"`
+}
+
+// TableName specifies the table name for Club model
+func (Club) TableName() string {
+	return "clubs"
+}
+
+// ClubMember is a user's membership of a club, or a pending invite to it
+type ClubMember struct {
+	ID        int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ClubID    string     `gorm:"column:club_id;type:varchar(36);not null;uniqueIndex:unique_club_member" json:"club_id"`
+	UserID    string     `gorm:"column:user_id;type:varchar(36);not null;uniqueIndex:unique_club_member;index:idx_club_member_user" json:"user_id"`
+	Role      string     `gorm:"column:role;type:enum('owner', 'manager', 'member');default:member" json:"role"`
+	Status    string     `gorm:"column:status;type:enum('invited', 'active');default:invited" json:"status"`
+	InvitedBy *string    `gorm:"column:invited_by;type:varchar(36)" json:"invited_by,omitempty"`
+	JoinedAt  *time.Time `gorm:"column:joined_at" json:"joined_at,omitempty"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for ClubMember model
+func (ClubMember) TableName() string {
+	return "club_members"
+}
+
+// ClubWallet holds a member's club chips, which are separate from their global chips
+type ClubWallet struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ClubID    string    `gorm:"column:club_id;type:varchar(36);not null;uniqueIndex:unique_club_wallet" json:"club_id"`
+	UserID    string    `gorm:"column:user_id;type:varchar(36);not null;uniqueIndex:unique_club_wallet" json:"user_id"`
+	Balance   int       `gorm:"column:balance;not null;default:0" json:"balance"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for ClubWallet model
+func (ClubWallet) TableName() string {
+	return "club_wallets"
+}
+
+// ClubChipTransaction is a ledger entry for a club wallet
+type ClubChipTransaction struct {
+	ID              string    `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
+	ClubID          string    `gorm:"column:club_id;type:varchar(36);not null;index:idx_club_tx_club_created" json:"club_id"`
+	UserID          string    `gorm:"column:user_id;type:varchar(36);not null;index:idx_club_tx_user" json:"user_id"`
+	ActorID         *string   `gorm:"column:actor_id;type:varchar(36)" json:"actor_id,omitempty"` // Owner who issued or voided chips
+	Amount          int       `gorm:"column:amount;not null" json:"amount"`                        // Positive for credits, negative for debits
+	BalanceBefore   int       `gorm:"column:balance_before;not null" json:"balance_before"`
+	BalanceAfter    int       `gorm:"column:balance_after;not null" json:"balance_after"`
+	TransactionType string    `gorm:"column:transaction_type;type:varchar(32);not null" json:"transaction_type"`
+	ReferenceID     *string   `gorm:"column:reference_id;type:varchar(36)" json:"reference_id,omitempty"` // Table ID for buy-ins and cash-outs
+	Description     string    `gorm:"column:description;type:text" json:"description,omitempty"`
+	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime;index:idx_club_tx_club_created" json:"created_at"`
+}
+
+// TableName specifies the table name for ClubChipTransaction model
+func (ClubChipTransaction) TableName() string {
+	return "club_chip_transactions"
+}
+
+// EntryRequirements gate who can join a table or register for a tournament.
+// Zero values disable a requirement.
+type EntryRequirements struct {
+	MinBalance        int  `json:"min_balance,omitempty"`          // Account chips needed to enter
+	MinAccountAgeDays int  `json:"min_account_age_days,omitempty"` // Days since the account was created
+	MinLevel          int  `json:"min_level,omitempty"`            // Player level, see entry.Level
+	InviteOnly        bool `json:"invite_only,omitempty"`          // Only users on the invite list
+}
+
+// SeatingPlan seeds where players sit when a tournament starts. Registered
+// players the plan leaves out are seated at random; players in it who are not
+// registered are ignored.
+type SeatingPlan struct {
+	Fixed []FixedSeat `json:"fixed,omitempty"` // Players given a table and seat
+	Apart [][]string  `json:"apart,omitempty"` // Groups of user IDs, such as teammates, seated at different tables
+	Seeds []string    `json:"seeds,omitempty"` // User IDs by ranking, best first, spread evenly across the tables
+}
+
+// FixedSeat puts a player at a table and seat of a SeatingPlan
+type FixedSeat struct {
+	UserID string `json:"user_id"`
+	Table  int    `json:"table"` // Table number, from 1
+	Seat   int    `json:"seat"`  // Seat number, from 0
+}
+
+// EntryInvite puts a user on the invite list of an invite-only table or tournament
+type EntryInvite struct {
+	ID         int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ResourceID string    `gorm:"column:resource_id;type:varchar(36);not null;uniqueIndex:unique_entry_invite" json:"resource_id"` // Table or tournament ID
+	UserID     string    `gorm:"column:user_id;type:varchar(36);not null;uniqueIndex:unique_entry_invite" json:"user_id"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for EntryInvite model
+func (EntryInvite) TableName() string {
+	return "entry_invites"
+}
+
 // TableSeat represents a player's seat at a poker table
 type TableSeat struct {
-	ID         int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	TableID    string         `gorm:"column:table_id;type:varchar(36);not null;index:idx_table_user" json:"table_id"`
-	UserID     string         `gorm:"column:user_id;type:varchar(36);not null;index:idx_table_user" json:"user_id"`
-	SeatNumber int            `gorm:"column:seat_number;not null;uniqueIndex:unique_seat" json:"seat_number"`
-	Chips      int            `gorm:"column:chips;not null" json:"chips"`
-	Status     string         `gorm:"column:status;type:enum('active', 'sitting_out', 'folded', 'busted');default:active" json:"status"`
-	JoinedAt   time.Time      `gorm:"column:joined_at;autoCreateTime" json:"joined_at"`
-	LeftAt     *time.Time     `gorm:"column:left_at" json:"left_at,omitempty"`
-	DeletedAt  gorm.DeletedAt `gorm:"column:deleted_at;index" json:"-"`
+	ID          int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	TableID     string         `gorm:"column:table_id;type:varchar(36);not null;index:idx_table_user;uniqueIndex:unique_seat,priority:1" json:"table_id"`
+	UserID      string         `gorm:"column:user_id;type:varchar(36);not null;index:idx_table_user" json:"user_id"`
+	SeatNumber  int            `gorm:"column:seat_number;not null;uniqueIndex:unique_seat,priority:2" json:"seat_number"`
+	Chips       int            `gorm:"column:chips;not null" json:"chips"`
+	Status      string         `gorm:"column:status;type:enum('active', 'sitting_out', 'folded', 'busted');default:active" json:"status"`
+	JoinedAt    time.Time      `gorm:"column:joined_at;autoCreateTime" json:"joined_at"`
+	LeftAt      *time.Time     `gorm:"column:left_at" json:"left_at,omitempty"`
+	HandsDealt  int            `gorm:"column:hands_dealt;not null;default:0" json:"hands_dealt"`
+	HandsPlayed int            `gorm:"column:hands_played;not null;default:0" json:"hands_played"` // Hands voluntarily played
+	BoughtIn    int            `gorm:"column:bought_in;not null;default:0" json:"bought_in"`       // Cash tables: chips brought to the seat, rebuys included
+	CashedOut   int            `gorm:"column:cashed_out;not null;default:0" json:"cashed_out"`     // Cash tables: the stack returned on leaving
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index" json:"-"`
 }
 
 // TableName specifies the table name for TableSeat model
@@ -65,25 +198,52 @@ func (TableSeat) TableName() string {
 	return "table_seats"
 }
 
+// SessionResult is how a player did over a cash table's session, summed over
+// every seat they took at it
+type SessionResult struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	BoughtIn    int    `json:"bought_in"`
+	CashedOut   int    `json:"cashed_out"` // Stacks returned, and the stack of a seat still taken
+	Net         int    `json:"net"`
+	HandsDealt  int    `json:"hands_dealt"`
+	HandsPlayed int    `json:"hands_played"`
+	PlaySeconds int    `json:"play_seconds"`
+}
+
 // Tournament represents a poker tournament
 type Tournament struct {
 	ID                    string         `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
 	TournamentCode        string         `gorm:"column:tournament_code;type:varchar(8);uniqueIndex;not null" json:"tournament_code"`
 	Name                  string         `gorm:"column:name;type:varchar(100);not null" json:"name"`
 	CreatorID             *string        `gorm:"column:creator_id;type:varchar(36);index:idx_creator" json:"creator_id,omitempty"`
+	ClubID                *string        `gorm:"column:club_id;type:varchar(36);index:idx_tournament_club" json:"club_id,omitempty"` // Club-scoped tournaments are only visible to members
+	SatelliteTargetID     *string        `gorm:"column:satellite_target_id;type:varchar(36);index:idx_satellite_target" json:"satellite_target_id,omitempty"` // Winners get tickets for this tournament instead of chips
 	Status                string         `gorm:"column:status;type:enum('registering', 'starting', 'in_progress', 'paused', 'completed', 'cancelled');default:registering" json:"status"`
+	TournamentType        string         `gorm:"column:tournament_type;type:enum('scheduled', 'sit_n_go');default:scheduled" json:"tournament_type"`
+	Format                string         `gorm:"column:format;type:enum('standard', 'heads_up_bracket');default:standard" json:"format"`
 	BuyIn                 int            `gorm:"column:buy_in;not null" json:"buy_in"`
+	EntryFee              int            `gorm:"column:entry_fee;default:0" json:"entry_fee"` // operator's fee charged on top of the buy-in; not part of the prize pool
+	Bounty                int            `gorm:"column:bounty;default:0" json:"bounty"` // part of the buy-in put on each player's head; not part of the prize pool
 	StartingChips         int            `gorm:"column:starting_chips;not null" json:"starting_chips"`
 	MaxPlayers            int            `gorm:"column:max_players;not null" json:"max_players"`
 	MinPlayers            int            `gorm:"column:min_players;not null;default:2" json:"min_players"`
 	CurrentPlayers        int            `gorm:"column:current_players;default:0" json:"current_players"`
 	PrizePool             int            `gorm:"column:prize_pool;default:0" json:"prize_pool"`
+	Guarantee             int            `gorm:"column:guarantee;default:0" json:"guarantee"` // Least the prizes add up to; the house covers any overlay
 	Structure             string         `gorm:"column:structure;type:json" json:"structure"`
 	PrizeStructure        string         `gorm:"column:prize_structure;type:json" json:"prize_structure"`
 	StartTime             *time.Time     `gorm:"column:start_time" json:"start_time,omitempty"`
 	RegistrationClosesAt  *time.Time     `gorm:"column:registration_closes_at" json:"registration_closes_at,omitempty"`
 	RegistrationCompletedAt *time.Time   `gorm:"column:registration_completed_at" json:"registration_completed_at,omitempty"`
+	EndsAt                *time.Time     `gorm:"column:ends_at" json:"ends_at,omitempty"` // Hard end: a tournament still running is aborted and its pool chopped
 	AutoStartDelay        int            `gorm:"column:auto_start_delay;default:300" json:"auto_start_delay"` // seconds
+	UnregisterDeadline    int            `gorm:"column:unregister_deadline;default:0" json:"unregister_deadline"` // seconds before start when free unregistration closes
+	LateCancelFee         int            `gorm:"column:late_cancel_fee;default:0" json:"late_cancel_fee"` // withheld from refunds after the deadline; 0 = no late unregistration
+	EntryRequirements     *EntryRequirements `gorm:"column:entry_requirements;serializer:json" json:"entry_requirements,omitempty"`
+	BroadcastDelay        int            `gorm:"column:broadcast_delay;default:0" json:"broadcast_delay"` // seconds; > 0 runs the final table in broadcast mode
+	FinalTableBreak       int            `gorm:"column:final_table_break;default:0" json:"final_table_break"` // seconds to wait before the final table deals
+	SeatingPlan           *SeatingPlan   `gorm:"column:seating_plan;serializer:json" json:"seating_plan,omitempty"`
 	CurrentLevel          int            `gorm:"column:current_level;default:1" json:"current_level"`
 	LevelStartedAt        *time.Time     `gorm:"column:level_started_at" json:"level_started_at,omitempty"`
 	PausedAt              *time.Time     `gorm:"column:paused_at" json:"paused_at,omitempty"`
@@ -96,22 +256,88 @@ type Tournament struct {
 	DeletedAt             gorm.DeletedAt `gorm:"column:deleted_at;index" json:"-"`
 }
 
+// Tournament types. A sit & go has no start time or countdown; it starts the
+// moment its last seat is taken.
+const (
+	TournamentTypeScheduled = "scheduled"
+	TournamentTypeSitNGo    = "sit_n_go"
+)
+
+// Tournament formats. A heads-up bracket pairs its players off at tables of
+// two, and the winners of each round are paired again until one is left.
+const (
+	TournamentFormatStandard = "standard"
+	TournamentFormatBracket  = "heads_up_bracket"
+)
+
 // TableName specifies the table name for Tournament model
 func (Tournament) TableName() string {
 	return "tournaments"
 }
 
+// TournamentEvent is one entry in a tournament's event log. The lifecycle
+// columns of the tournaments row (status, player count, prize pool, level and
+// their times) are a projection of these events.
+type TournamentEvent struct {
+	ID           string              `gorm:"column:id;type:char(26);primaryKey" json:"id"` // ULID
+	TournamentID string              `gorm:"column:tournament_id;type:varchar(36);not null;uniqueIndex:idx_tournament_event_sequence,priority:1" json:"tournament_id"`
+	Sequence     int                 `gorm:"column:sequence;not null;uniqueIndex:idx_tournament_event_sequence,priority:2" json:"sequence"`
+	Type         string              `gorm:"column:type;type:varchar(32);not null" json:"type"`
+	UserID       *string             `gorm:"column:user_id;type:varchar(36)" json:"user_id,omitempty"` // The player, or who made it happen
+	Data         TournamentEventData `gorm:"column:data;serializer:json" json:"data"`
+	CreatedAt    time.Time           `gorm:"column:created_at" json:"created_at"`
+}
+
+// TournamentEventData holds the details of a tournament event; which fields
+// are set depends on the type
+type TournamentEventData struct {
+	Level     int    `json:"level,omitempty"`      // level_changed: the new level
+	Position  int    `json:"position,omitempty"`   // eliminated: finishing position
+	Fee       int    `json:"fee,omitempty"`        // unregistered: late-cancel fee kept in the pool
+	PausedFor int    `json:"paused_for,omitempty"` // resumed: seconds the tournament was paused
+	Reason    string `json:"reason,omitempty"`     // cancelled: why
+}
+
+// TableName specifies the table name for TournamentEvent model
+func (TournamentEvent) TableName() string {
+	return "tournament_events"
+}
+
+// TournamentPreset is a user's saved blind or prize structure. Exactly one of
+// Structure and PrizeStructure is set, matching Kind.
+type TournamentPreset struct {
+	ID             string                `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
+	UserID         string                `gorm:"column:user_id;type:varchar(36);not null;uniqueIndex:unique_user_preset" json:"user_id"`
+	Kind           string                `gorm:"column:kind;type:varchar(16);not null;uniqueIndex:unique_user_preset" json:"kind"` // structure or prize
+	Name           string                `gorm:"column:name;type:varchar(100);not null;uniqueIndex:unique_user_preset" json:"name"`
+	Structure      *TournamentStructure  `gorm:"column:structure;serializer:json" json:"structure,omitempty"`
+	PrizeStructure *PrizeStructureConfig `gorm:"column:prize_structure;serializer:json" json:"prize_structure,omitempty"`
+	CreatedAt      time.Time             `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time             `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for TournamentPreset model
+func (TournamentPreset) TableName() string {
+	return "tournament_presets"
+}
+
 // TournamentPlayer represents a player in a tournament
 type TournamentPlayer struct {
-	ID           int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	TournamentID string         `gorm:"column:tournament_id;type:varchar(36);not null;index:idx_tournament;uniqueIndex:unique_tournament_player" json:"tournament_id"`
-	UserID       string         `gorm:"column:user_id;type:varchar(36);not null;uniqueIndex:unique_tournament_player" json:"user_id"`
-	Position     *int           `gorm:"column:position" json:"position,omitempty"`
-	Chips        *int           `gorm:"column:chips" json:"chips,omitempty"`
-	PrizeAmount  int            `gorm:"column:prize_amount;default:0" json:"prize_amount"`
-	RegisteredAt time.Time      `gorm:"column:registered_at;autoCreateTime" json:"registered_at"`
-	EliminatedAt *time.Time     `gorm:"column:eliminated_at" json:"eliminated_at,omitempty"`
-	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index" json:"-"`
+	ID            int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	TournamentID  string         `gorm:"column:tournament_id;type:varchar(36);not null;index:idx_tournament;uniqueIndex:unique_tournament_player" json:"tournament_id"`
+	UserID        string         `gorm:"column:user_id;type:varchar(36);not null;uniqueIndex:unique_tournament_player" json:"user_id"`
+	Position      *int           `gorm:"column:position" json:"position,omitempty"`
+	Chips         *int           `gorm:"column:chips" json:"chips,omitempty"`
+	PrizeAmount   int            `gorm:"column:prize_amount;default:0" json:"prize_amount"`
+	TicketID      *string        `gorm:"column:ticket_id;type:varchar(36)" json:"ticket_id,omitempty"` // The satellite ticket the player registered with
+	AllIns        int            `gorm:"column:all_ins;default:0" json:"all_ins"`                      // Hands all-in with cards to come
+	AllInExpected float64        `gorm:"column:all_in_expected;default:0" json:"all_in_expected"`      // Chips the player's equity was worth in those hands
+	AllInWon      int64          `gorm:"column:all_in_won;default:0" json:"all_in_won"`                // Chips the player won in those hands
+	Bounty        int            `gorm:"column:bounty;default:0" json:"bounty"`                        // On the player's head in a bounty tournament; grows with each knockout
+	BountiesWon   int            `gorm:"column:bounties_won;default:0" json:"bounties_won"`            // Cash paid to the player for knockouts
+	RegisteredAt  time.Time      `gorm:"column:registered_at;autoCreateTime" json:"registered_at"`
+	EliminatedAt  *time.Time     `gorm:"column:eliminated_at" json:"eliminated_at,omitempty"`
+	DeletedAt     gorm.DeletedAt `gorm:"column:deleted_at;index" json:"-"`
 }
 
 // TableName specifies the table name for TournamentPlayer model
@@ -119,9 +345,86 @@ func (TournamentPlayer) TableName() string {
 	return "tournament_players"
 }
 
+// TournamentTicket is good for one registration instead of the buy-in and
+// entry fee. A seat won in a satellite is for its target; an open ticket,
+// given as a level-up reward, is for any tournament costing up to its value.
+type TournamentTicket struct {
+	ID                 string     `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
+	UserID             string     `gorm:"column:user_id;type:varchar(36);not null;index:idx_ticket_user" json:"user_id"`
+	TournamentID       *string    `gorm:"column:tournament_id;type:varchar(36);index:idx_ticket_tournament" json:"tournament_id,omitempty"` // Nil for an open ticket
+	SourceTournamentID *string    `gorm:"column:source_tournament_id;type:varchar(36)" json:"source_tournament_id,omitempty"`            // The satellite it was won in
+	Level              *int       `gorm:"column:level" json:"level,omitempty"`                                                           // The level whose reward it was
+	Value              int        `gorm:"column:value;not null" json:"value"`                                                            // The target's buy-in and entry fee, or the most an open ticket covers
+	Status             string     `gorm:"column:status;type:enum('unused', 'used', 'refunded');default:unused" json:"status"`
+	CreatedAt          time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UsedAt             *time.Time `gorm:"column:used_at" json:"used_at,omitempty"`
+}
+
+// Tournament ticket statuses
+const (
+	TicketUnused   = "unused"
+	TicketUsed     = "used"
+	TicketRefunded = "refunded" // Paid out as chips when its tournament was cancelled
+)
+
+// TableName specifies the table name for TournamentTicket model
+func (TournamentTicket) TableName() string {
+	return "tournament_tickets"
+}
+
+// BracketMatch is one heads-up match of a bracket tournament. Round 1 pairs
+// off the field in slots; the winners of slots 2n and 2n+1 meet in slot n of
+// the next round. A player with no opponent has a bye and goes through.
+type BracketMatch struct {
+	ID           string     `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
+	TournamentID string     `gorm:"column:tournament_id;type:varchar(36);not null;uniqueIndex:idx_bracket_slot,priority:1" json:"tournament_id"`
+	Round        int        `gorm:"column:round;not null;uniqueIndex:idx_bracket_slot,priority:2" json:"round"`
+	Slot         int        `gorm:"column:slot;not null;uniqueIndex:idx_bracket_slot,priority:3" json:"slot"`
+	PlayerAID    string     `gorm:"column:player_a_id;type:varchar(36);not null" json:"player_a_id"`
+	PlayerBID    *string    `gorm:"column:player_b_id;type:varchar(36)" json:"player_b_id,omitempty"` // Nil for a bye
+	WinnerID     *string    `gorm:"column:winner_id;type:varchar(36)" json:"winner_id,omitempty"`
+	TableID      *string    `gorm:"column:table_id;type:varchar(36);index:idx_bracket_table" json:"table_id,omitempty"` // Nil for a bye
+	Status       string     `gorm:"column:status;type:varchar(16);not null" json:"status"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	CompletedAt  *time.Time `gorm:"column:completed_at" json:"completed_at,omitempty"`
+}
+
+// Bracket match statuses
+const (
+	BracketMatchPlaying   = "playing"
+	BracketMatchCompleted = "completed"
+	BracketMatchBye       = "bye"
+)
+
+// TableName specifies the table name for BracketMatch model
+func (BracketMatch) TableName() string {
+	return "bracket_matches"
+}
+
+// TournamentKnockout is a player knocking another out of a bounty
+// tournament. The eliminated player's bounty is shared by whoever knocked
+// them out; of each share, Cash is paid out and Added goes on the
+// eliminator's own bounty. PaidAt is set once the cash is paid.
+type TournamentKnockout struct {
+	ID           string     `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
+	TournamentID string     `gorm:"column:tournament_id;type:varchar(36);not null;uniqueIndex:idx_knockout,priority:1" json:"tournament_id"`
+	EliminatedID string     `gorm:"column:eliminated_id;type:varchar(36);not null;uniqueIndex:idx_knockout,priority:2" json:"eliminated_id"`
+	EliminatorID string     `gorm:"column:eliminator_id;type:varchar(36);not null;uniqueIndex:idx_knockout,priority:3" json:"eliminator_id"`
+	Cash         int        `gorm:"column:cash;not null" json:"cash"`
+	Added        int        `gorm:"column:added;not null" json:"added"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	PaidAt       *time.Time `gorm:"column:paid_at" json:"paid_at,omitempty"`
+}
+
+// TableName specifies the table name for TournamentKnockout model
+func (TournamentKnockout) TableName() string {
+	return "tournament_knockouts"
+}
+
 // Hand represents a single poker hand
 type Hand struct {
 	ID                   int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UID                  string         `gorm:"column:uid;type:char(26);uniqueIndex:idx_hands_uid" json:"uid,omitempty"` // ULID; sorts by when the hand started
 	TableID              string         `gorm:"column:table_id;type:varchar(36);not null;index:idx_table_hand" json:"table_id"`
 	HandNumber           int            `gorm:"column:hand_number;not null;index:idx_table_hand" json:"hand_number"`
 	DealerPosition       int            `gorm:"column:dealer_position;not null" json:"dealer_position"`
@@ -146,6 +449,7 @@ func (Hand) TableName() string {
 // HandAction represents a player action during a hand
 type HandAction struct {
 	ID           int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UID          string         `gorm:"column:uid;type:char(26);uniqueIndex:idx_hand_actions_uid" json:"uid,omitempty"` // ULID; lets a redelivered action be skipped
 	HandID       int64          `gorm:"column:hand_id;not null;index:idx_hand" json:"hand_id"`
 	UserID       string         `gorm:"column:user_id;type:varchar(36);not null" json:"user_id"`
 	ActionType   string         `gorm:"column:action_type;type:enum('fold', 'check', 'call', 'raise', 'allin');not null" json:"action_type"`
@@ -160,19 +464,42 @@ func (HandAction) TableName() string {
 	return "hand_actions"
 }
 
-// GameEvent represents a comprehensive event in a poker hand
+// GameEventKind is the kind of a game_events row
+type GameEventKind string
+
+// Game event kinds, matching the event_type enum of game_events
+const (
+	EventKindHandStarted      GameEventKind = "hand_started"
+	EventKindCardsDealt       GameEventKind = "cards_dealt"
+	EventKindBlindsPosted     GameEventKind = "blinds_posted"
+	EventKindPlayerAction     GameEventKind = "player_action"
+	EventKindRoundAdvanced    GameEventKind = "round_advanced"
+	EventKindShowdown         GameEventKind = "showdown"
+	EventKindHandComplete     GameEventKind = "hand_complete"
+	EventKindPlayerTimeout    GameEventKind = "player_timeout"
+	EventKindPlayerEliminated GameEventKind = "player_eliminated"
+	EventKindBlindsIncreased  GameEventKind = "blinds_increased"
+	EventKindCardsShown       GameEventKind = "cards_shown"
+)
+
+// GameEvent represents a comprehensive event in a poker hand. The fields that
+// replay, stats and integrity checks filter on have their own indexed columns;
+// Metadata only holds what is specific to the event.
 type GameEvent struct {
 	ID             int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	HandID         int64          `gorm:"column:hand_id;not null;index:idx_hand" json:"hand_id"`
-	TableID        string         `gorm:"column:table_id;type:varchar(36);not null;index:idx_table_created" json:"table_id"`
-	EventType      string         `gorm:"column:event_type;type:enum('hand_started', 'cards_dealt', 'blinds_posted', 'player_action', 'round_advanced', 'showdown', 'hand_complete', 'player_timeout', 'player_eliminated', 'blinds_increased');not null;index:idx_event_type" json:"event_type"`
-	UserID         *string        `gorm:"column:user_id;type:varchar(36);index:idx_user_id" json:"user_id,omitempty"`
-	BettingRound   *string        `gorm:"column:betting_round;type:enum('preflop', 'flop', 'turn', 'river')" json:"betting_round,omitempty"`
+	UID            string         `gorm:"column:uid;type:char(26);uniqueIndex:idx_game_events_uid" json:"uid,omitempty"` // ULID; sorts by when the event happened
+	HandID         int64          `gorm:"column:hand_id;not null;index:idx_hand;index:idx_sequence,priority:1" json:"hand_id"`
+	TableID        string         `gorm:"column:table_id;type:varchar(36);not null;index:idx_table_created;index:idx_table_kind,priority:1" json:"table_id"`
+	EventType      GameEventKind  `gorm:"column:event_type;type:enum('hand_started', 'cards_dealt', 'blinds_posted', 'player_action', 'round_advanced', 'showdown', 'hand_complete', 'player_timeout', 'player_eliminated', 'blinds_increased', 'cards_shown');not null;index:idx_event_type;index:idx_table_kind,priority:2;index:idx_user_kind,priority:2" json:"event_type"`
+	UserID         *string        `gorm:"column:user_id;type:varchar(36);index:idx_user_id;index:idx_user_kind,priority:1" json:"user_id,omitempty"`
+	BettingRound   *string        `gorm:"column:betting_round;type:enum('preflop', 'flop', 'turn', 'river', 'showdown')" json:"betting_round,omitempty"`
 	ActionType     *string        `gorm:"column:action_type;type:varchar(20)" json:"action_type,omitempty"`
 	Amount         int            `gorm:"column:amount;default:0" json:"amount"`
+	PotAfter       *int           `gorm:"column:pot_after" json:"pot_after,omitempty"` // Total pot once the event is applied
+	Board          *string        `gorm:"column:board;type:varchar(32)" json:"board,omitempty"` // Community cards, space separated
 	Metadata       string         `gorm:"column:metadata;type:json" json:"metadata,omitempty"`
-	SequenceNumber int            `gorm:"column:sequence_number;not null;index:idx_sequence" json:"sequence_number"`
-	CreatedAt      time.Time      `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	SequenceNumber int            `gorm:"column:sequence_number;not null;index:idx_sequence,priority:2" json:"sequence_number"`
+	CreatedAt      time.Time      `gorm:"column:created_at;autoCreateTime;index:idx_table_created;index:idx_table_kind,priority:3;index:idx_user_kind,priority:3" json:"created_at"`
 }
 
 // TableName specifies the table name for GameEvent model
@@ -180,6 +507,247 @@ func (GameEvent) TableName() string {
 	return "game_events"
 }
 
+// AuditLog records a login or game action with the client's network metadata
+type AuditLog struct {
+	ID           int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID       string    `gorm:"column:user_id;type:varchar(36);not null;index:idx_audit_user_created" json:"user_id"`
+	ActorID      *string   `gorm:"column:actor_id;type:varchar(36)" json:"actor_id,omitempty"` // Admin behind an admin_action
+	EventType    string    `gorm:"column:event_type;type:varchar(32);not null" json:"event_type"`
+	TableID      *string   `gorm:"column:table_id;type:varchar(36);index:idx_audit_table_created" json:"table_id,omitempty"`
+	Action       *string   `gorm:"column:action;type:varchar(32)" json:"action,omitempty"`
+	Amount       int       `gorm:"column:amount;default:0" json:"amount"`
+	RequestID    *string   `gorm:"column:request_id;type:varchar(64)" json:"request_id,omitempty"`
+	IPAddress    string    `gorm:"column:ip_address;type:varchar(45);not null;index:idx_audit_ip" json:"ip_address"`
+	UserAgent    string    `gorm:"column:user_agent;type:varchar(512)" json:"user_agent"`
+	ConnectionID *string   `gorm:"column:connection_id;type:varchar(36)" json:"connection_id,omitempty"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime;index:idx_audit_user_created,priority:2;index:idx_audit_table_created,priority:2;index:idx_audit_created" json:"created_at"`
+}
+
+// TableName specifies the table name for AuditLog model
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// AccountFreeze records an admin freezing an account's game actions and chip
+// withdrawals, and lifting it again. A freeze with no LiftedAt is in force.
+type AccountFreeze struct {
+	ID       int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID   string     `gorm:"column:user_id;type:varchar(36);not null;index:idx_freeze_user" json:"user_id"`
+	FrozenBy string     `gorm:"column:frozen_by;type:varchar(36);not null" json:"frozen_by"`
+	Reason   string     `gorm:"column:reason;type:varchar(255)" json:"reason"`
+	FrozenAt time.Time  `gorm:"column:frozen_at;not null" json:"frozen_at"`
+	LiftedBy *string    `gorm:"column:lifted_by;type:varchar(36)" json:"lifted_by,omitempty"`
+	LiftedAt *time.Time `gorm:"column:lifted_at" json:"lifted_at,omitempty"`
+}
+
+// TableName specifies the table name for AccountFreeze model
+func (AccountFreeze) TableName() string {
+	return "account_freezes"
+}
+
+// Announcement is a banner message an operator sends to connected clients:
+// everyone, the players of a tournament or everyone at a table. It is sent
+// once it starts and clients hide it once it expires.
+type Announcement struct {
+	ID          string     `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
+	Kind        string     `gorm:"column:kind;type:enum('info', 'maintenance', 'promotion');not null" json:"kind"`
+	Message     string     `gorm:"column:message;type:varchar(500);not null" json:"message"`
+	Target      string     `gorm:"column:target;type:enum('all', 'tournament', 'table');not null" json:"target"`
+	TargetID    *string    `gorm:"column:target_id;type:varchar(36)" json:"target_id,omitempty"` // Tournament or table of a targeted announcement
+	StartsAt    time.Time  `gorm:"column:starts_at;not null;index:idx_announcement_starts" json:"starts_at"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at" json:"expires_at,omitempty"`
+	CreatedBy   string     `gorm:"column:created_by;type:varchar(36);not null" json:"created_by"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	SentAt      *time.Time `gorm:"column:sent_at" json:"sent_at,omitempty"`
+	CancelledAt *time.Time `gorm:"column:cancelled_at" json:"cancelled_at,omitempty"`
+}
+
+// TableName specifies the table name for Announcement model
+func (Announcement) TableName() string {
+	return "announcements"
+}
+
+// ModerationItem is user-written text the profanity filter let through as
+// borderline, or an avatar, held for an admin to approve or reject
+type ModerationItem struct {
+	ID         string     `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
+	Kind       string     `gorm:"column:kind;type:enum('username', 'table_name', 'tournament_name', 'chat', 'avatar');not null" json:"kind"`
+	SubjectID  string     `gorm:"column:subject_id;type:varchar(36);not null" json:"subject_id"` // User, table or tournament the text belongs to
+	UserID     string     `gorm:"column:user_id;type:varchar(36);not null" json:"user_id"`       // Who wrote it
+	Text       string     `gorm:"column:text;type:varchar(500);not null" json:"text"` // For an avatar, its URL
+	Term       string     `gorm:"column:term;type:varchar(100);not null" json:"term"` // The wordlist entry that matched, or why an avatar was queued
+	Language   string     `gorm:"column:language;type:varchar(16);not null" json:"language"`
+	Status     string     `gorm:"column:status;type:enum('pending', 'approved', 'rejected');default:'pending';not null;index:idx_moderation_status" json:"status"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime;index:idx_moderation_status" json:"created_at"`
+	ReviewedBy *string    `gorm:"column:reviewed_by;type:varchar(36)" json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `gorm:"column:reviewed_at" json:"reviewed_at,omitempty"`
+}
+
+// TableName specifies the table name for ModerationItem model
+func (ModerationItem) TableName() string {
+	return "moderation_queue"
+}
+
+// PlayerIdentity ties a player ID from another system, such as a standalone
+// engine deployment, to the platform user it was imported as or linked to
+type PlayerIdentity struct {
+	ID         int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Source     string    `gorm:"column:source;type:varchar(50);not null;uniqueIndex:idx_identity_source_external" json:"source"` // The system the ID comes from
+	ExternalID string    `gorm:"column:external_id;type:varchar(100);not null;uniqueIndex:idx_identity_source_external" json:"external_id"`
+	UserID     string    `gorm:"column:user_id;type:varchar(36);not null;index:idx_identity_user" json:"user_id"`
+	Imported   bool      `gorm:"column:imported;default:false" json:"imported"` // The user was created for the identity, not an existing account
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for PlayerIdentity model
+func (PlayerIdentity) TableName() string {
+	return "player_identities"
+}
+
+// ChatIgnore hides one user's table chat from another
+type ChatIgnore struct {
+	UserID        string    `gorm:"column:user_id;type:varchar(36);primaryKey" json:"user_id"`
+	IgnoredUserID string    `gorm:"column:ignored_user_id;type:varchar(36);primaryKey;index:idx_chat_ignored" json:"ignored_user_id"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for ChatIgnore model
+func (ChatIgnore) TableName() string {
+	return "chat_ignores"
+}
+
+// ChatMute stops a user from sending table chat until it expires
+type ChatMute struct {
+	UserID     string    `gorm:"column:user_id;type:varchar(36);primaryKey" json:"user_id"`
+	MutedUntil time.Time `gorm:"column:muted_until;not null" json:"muted_until"`
+	MutedBy    string    `gorm:"column:muted_by;type:varchar(36);not null" json:"muted_by"`
+	Reason     string    `gorm:"column:reason;type:varchar(255)" json:"reason"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for ChatMute model
+func (ChatMute) TableName() string {
+	return "chat_mutes"
+}
+
+// MissionProgress is how far a user is through a mission on one day
+type MissionProgress struct {
+	UserID      string     `gorm:"column:user_id;type:varchar(36);primaryKey" json:"user_id"`
+	MissionID   string     `gorm:"column:mission_id;type:varchar(32);primaryKey" json:"mission_id"`
+	Day         string     `gorm:"column:day;type:char(10);primaryKey" json:"day"` // UTC date, YYYY-MM-DD
+	Progress    int        `gorm:"column:progress;not null;default:0" json:"progress"`
+	CompletedAt *time.Time `gorm:"column:completed_at" json:"completed_at,omitempty"` // Set when the reward was paid
+}
+
+// TableName specifies the table name for MissionProgress model
+func (MissionProgress) TableName() string {
+	return "mission_progress"
+}
+
+// ReferralCode is the code a user hands out to invite players
+type ReferralCode struct {
+	UserID    string    `gorm:"column:user_id;type:varchar(36);primaryKey" json:"user_id"`
+	Code      string    `gorm:"column:code;type:varchar(16);uniqueIndex;not null" json:"code"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for ReferralCode model
+func (ReferralCode) TableName() string {
+	return "referral_codes"
+}
+
+// Referral links a user to the player whose code they registered with
+type Referral struct {
+	RefereeID      string    `gorm:"column:referee_id;type:varchar(36);primaryKey" json:"referee_id"`
+	ReferrerID     string    `gorm:"column:referrer_id;type:varchar(36);not null;index:idx_referrer" json:"referrer_id"`
+	Code           string    `gorm:"column:code;type:varchar(16);not null" json:"code"`
+	HandsPlayed    int       `gorm:"column:hands_played;not null;default:0" json:"hands_played"`       // Hands the referee played without the referrer at the table
+	Milestones     int       `gorm:"column:milestones;not null;default:0" json:"milestones"`           // Milestones reached and paid
+	ReferrerEarned int       `gorm:"column:referrer_earned;not null;default:0" json:"referrer_earned"` // Chips the milestones paid the referrer
+	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for Referral model
+func (Referral) TableName() string {
+	return "referrals"
+}
+
+// PlayerProfile holds the parts of a user's public profile that aren't
+// kept elsewhere, and which of its fields they hide
+type PlayerProfile struct {
+	UserID       string     `gorm:"column:user_id;type:varchar(36);primaryKey" json:"user_id"`
+	BiggestPot   int        `gorm:"column:biggest_pot;not null;default:0" json:"biggest_pot"` // Most chips won in one hand
+	BiggestPotAt *time.Time `gorm:"column:biggest_pot_at" json:"biggest_pot_at,omitempty"`
+	Hidden       []string   `gorm:"column:hidden;serializer:json" json:"hidden"` // Profile fields only the owner sees
+}
+
+// TableName specifies the table name for PlayerProfile model
+func (PlayerProfile) TableName() string {
+	return "player_profiles"
+}
+
+// Avatar is the picture a user uploaded, re-encoded and kept in avatar
+// storage. It is shown straight away and queued for moderation.
+type Avatar struct {
+	UserID     string    `gorm:"column:user_id;type:varchar(36);primaryKey" json:"user_id"`
+	URL        string    `gorm:"column:url;type:varchar(500);not null" json:"url"`
+	StorageKey string    `gorm:"column:storage_key;type:varchar(255);not null" json:"-"` // Where the image is kept in avatar storage
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for Avatar model
+func (Avatar) TableName() string {
+	return "avatars"
+}
+
+// Bot is a computer player, a user with the bot role, and how well it plays.
+// Bots are made as they are needed and reused once they leave a table.
+type Bot struct {
+	UserID     string    `gorm:"column:user_id;type:varchar(36);primaryKey" json:"user_id"`
+	Difficulty string    `gorm:"column:difficulty;type:varchar(16);not null;index:idx_bot_difficulty" json:"difficulty"` // easy, medium or hard
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for Bot model
+func (Bot) TableName() string {
+	return "bots"
+}
+
+// OutboxMessage is a step of a multi-step operation, written in the same
+// transaction as the step before it and run by the outbox dispatcher until it
+// succeeds or is given up on
+type OutboxMessage struct {
+	ID            string     `gorm:"column:id;type:char(26);primaryKey" json:"id"` // ULID
+	Topic         string     `gorm:"column:topic;type:varchar(64);not null" json:"topic"`
+	AggregateID   string     `gorm:"column:aggregate_id;type:varchar(64);not null;index:idx_outbox_aggregate" json:"aggregate_id"` // What the step is about, such as a tournament ID
+	Payload       string     `gorm:"column:payload;type:json" json:"payload"`
+	Status        string     `gorm:"column:status;type:enum('pending', 'done', 'compensated', 'failed');default:'pending';not null;index:idx_outbox_due" json:"status"`
+	Attempts      int        `gorm:"column:attempts;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null;index:idx_outbox_due" json:"next_attempt_at"`
+	LastError     string     `gorm:"column:last_error;type:text" json:"last_error,omitempty"`
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"created_at"`
+	ProcessedAt   *time.Time `gorm:"column:processed_at" json:"processed_at,omitempty"`
+}
+
+// TableName specifies the table name for OutboxMessage model
+func (OutboxMessage) TableName() string {
+	return "outbox_messages"
+}
+
+// TableHandState is the engine state of a table in the middle of a hand,
+// restored after a restart. It holds hole cards and the deck seed.
+type TableHandState struct {
+	TableID    string    `gorm:"column:table_id;type:varchar(36);primaryKey" json:"table_id"`
+	HandNumber int       `gorm:"column:hand_number;not null" json:"hand_number"`
+	State      string    `gorm:"column:state;type:mediumtext;not null" json:"-"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for TableHandState model
+func (TableHandState) TableName() string {
+	return "table_hand_states"
+}
+
 // Session represents a user session token
 type Session struct {
 	ID        string         `gorm:"column:id;type:varchar(36);primaryKey" json:"id"`
@@ -203,6 +771,7 @@ type MatchmakingEntry struct {
 	QueueType string         `gorm:"column:queue_type;type:varchar(50);not null;index:idx_queue_type" json:"queue_type"`
 	MinBuyIn  *int           `gorm:"column:min_buy_in" json:"min_buy_in,omitempty"`
 	MaxBuyIn  *int           `gorm:"column:max_buy_in" json:"max_buy_in,omitempty"`
+	BuyIn     *int           `gorm:"column:buy_in" json:"buy_in,omitempty"` // Chosen buy-in; nil means the preset's starting stack
 	Status    string         `gorm:"column:status;type:enum('waiting', 'matched', 'cancelled');default:waiting;index:idx_status" json:"status"`
 	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime" json:"created_at"`
 	MatchedAt *time.Time     `gorm:"column:matched_at" json:"matched_at,omitempty"`
@@ -215,9 +784,10 @@ func (MatchmakingEntry) TableName() string {
 }
 
 type RegisterRequest struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	ReferralCode string `json:"referral_code,omitempty"` // Another player's code, crediting them with the invite
 }
 
 type LoginRequest struct {
@@ -242,6 +812,7 @@ type BlindLevel struct {
 	BigBlind   int `json:"big_blind"`
 	Ante       int `json:"ante"`
 	Duration   int `json:"duration"` // Duration in seconds
+	Denominations []int `json:"denominations,omitempty"` // Chip denominations in play; derived from the blinds when empty
 }
 
 // PrizePosition represents prize distribution for a position
@@ -255,6 +826,9 @@ type TournamentStructure struct {
 	Name         string       `json:"name"`
 	Description  string       `json:"description,omitempty"`
 	BlindLevels  []BlindLevel `json:"blind_levels"`
+	ColorUpMethod string      `json:"color_up_method,omitempty"` // "chip_race" (default) or "round_up"
+	BreakEvery   int          `json:"break_every,omitempty"`  // Levels between breaks; 0 for none
+	BreakLength  int          `json:"break_length,omitempty"` // Seconds each break lasts
 }
 
 // PrizeStructureConfig represents the prize distribution configuration
@@ -267,7 +841,12 @@ type PrizeStructureConfig struct {
 // CreateTournamentRequest represents the request to create a tournament
 type CreateTournamentRequest struct {
 	Name                string  `json:"name" binding:"required"`
+	TournamentType      string  `json:"tournament_type,omitempty"` // "scheduled" (default) or "sit_n_go"
+	Format              string  `json:"format,omitempty"`          // "standard" (default) or "heads_up_bracket"
 	BuyIn               int     `json:"buy_in" binding:"required,min=0"`
+	EntryFee            int     `json:"entry_fee" binding:"min=0"`
+	Bounty              int     `json:"bounty" binding:"min=0"` // Part of the buy-in put on each head; 0 for no bounties
+	Guarantee           int     `json:"guarantee" binding:"min=0"`
 	StartingChips       int     `json:"starting_chips" binding:"required,min=100"`
 	MaxPlayers          int     `json:"max_players" binding:"required,min=2,max=1000"`
 	MinPlayers          int     `json:"min_players" binding:"required,min=2"`
@@ -275,6 +854,19 @@ type CreateTournamentRequest struct {
 	CustomStructure     *TournamentStructure `json:"custom_structure,omitempty"`
 	PrizeStructurePreset string `json:"prize_structure_preset,omitempty"`
 	CustomPrizeStructure *PrizeStructureConfig `json:"custom_prize_structure,omitempty"`
+	StructurePresetID   string  `json:"structure_preset_id,omitempty"`       // A saved TournamentPreset of the creator
+	PrizePresetID       string  `json:"prize_structure_preset_id,omitempty"` // A saved TournamentPreset of the creator
 	StartTime           *time.Time `json:"start_time,omitempty"`
+	RegistrationClosesAt *time.Time `json:"registration_closes_at,omitempty"`
+	EndsAt              *time.Time `json:"ends_at,omitempty"`
 	AutoStartDelay      int     `json:"auto_start_delay" binding:"min=0"`
+	UnregisterDeadline  int     `json:"unregister_deadline" binding:"min=0"`
+	LateCancelFee       int     `json:"late_cancel_fee" binding:"min=0"`
+	BroadcastDelay      int     `json:"broadcast_delay" binding:"min=0"`
+	FinalTableBreak     int     `json:"final_table_break" binding:"min=0"`
+	EntryRequirements   *EntryRequirements `json:"entry_requirements,omitempty"`
+	SeatingPlan         *SeatingPlan `json:"seating_plan,omitempty"`
+	InviteList          []string `json:"invite_list,omitempty"` // User IDs allowed in when invite only
+	ClubID              *string `json:"club_id,omitempty"`
+	SatelliteTargetID   *string `json:"satellite_target_id,omitempty"` // Makes this a satellite awarding tickets to that tournament
 }
@@ -0,0 +1,200 @@
+// Package moderation screens user-written text (usernames, table and
+// tournament names, chat) against per-language wordlists. Clear abuse is
+// rejected; borderline text is let through and queued for an admin to review.
+package moderation
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Verdicts of the filter, from mildest to strongest
+const (
+	Allow  = "allow"
+	Review = "review"
+	Block  = "block"
+)
+
+// ErrBlocked is returned for text the filter rejects outright
+var ErrBlocked = errors.New("contains blocked language")
+
+//go:embed wordlists/*.txt
+var defaultWordlists embed.FS
+
+// Match is the filter's verdict on a piece of text, with the term and
+// language that decided it
+type Match struct {
+	Verdict  string `json:"verdict"`
+	Term     string `json:"term,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+// wordlist holds one language's terms, normalized
+type wordlist struct {
+	blocked []string
+	review  []string
+}
+
+// Filter checks text against wordlists in any number of languages. It is
+// safe for concurrent use once loaded.
+type Filter struct {
+	languages []string
+	lists     map[string]*wordlist
+}
+
+// NewFilter creates a filter with no wordlists, which allows everything
+func NewFilter() *Filter {
+	return &Filter{lists: make(map[string]*wordlist)}
+}
+
+// DefaultFilter returns a filter with the wordlists built into the server
+func DefaultFilter() *Filter {
+	filter, err := loadFS(defaultWordlists, "wordlists")
+	if err != nil {
+		panic(fmt.Sprintf("moderation: bad built-in wordlist: %v", err))
+	}
+	return filter
+}
+
+// LoadFilter reads every <language>.txt wordlist in a directory
+func LoadFilter(dir string) (*Filter, error) {
+	return loadFS(os.DirFS(dir), ".")
+}
+
+func loadFS(fsys fs.FS, dir string) (*Filter, error) {
+	paths, err := fs.Glob(fsys, path.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no wordlists found")
+	}
+	filter := NewFilter()
+	for _, name := range paths {
+		file, err := fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		language := strings.TrimSuffix(path.Base(name), ".txt")
+		err = filter.AddWordlist(language, file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return filter, nil
+}
+
+// AddWordlist adds a language's terms, one per line. Blank lines and lines
+// starting with # are skipped; a leading ? marks a borderline term.
+func (f *Filter) AddWordlist(language string, r io.Reader) error {
+	list := f.lists[language]
+	if list == nil {
+		list = &wordlist{}
+		f.lists[language] = list
+		f.languages = append(f.languages, language)
+		sort.Strings(f.languages)
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		borderline := strings.HasPrefix(line, "?")
+		term := strings.Join(tokenize(strings.TrimPrefix(line, "?")), "")
+		if term == "" {
+			continue
+		}
+		if borderline {
+			list.review = append(list.review, term)
+		} else {
+			list.blocked = append(list.blocked, term)
+		}
+	}
+	return scanner.Err()
+}
+
+// Languages returns the languages the filter has wordlists for
+func (f *Filter) Languages() []string {
+	return append([]string(nil), f.languages...)
+}
+
+// Check returns the filter's verdict on text. A blocked term standing as a
+// word blocks the text. A blocked term hidden inside a longer word or spelled
+// out across separators, or a borderline term standing as a word, sends it for
+// review. Text is compared after Unicode normalization, case folding and
+// undoing common character substitutions.
+func (f *Filter) Check(text string) Match {
+	tokens := tokenize(text)
+	words := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		words[token] = true
+	}
+	collapsed := strings.Join(tokens, "")
+
+	for _, language := range f.languages {
+		for _, term := range f.lists[language].blocked {
+			if words[term] {
+				return Match{Verdict: Block, Term: term, Language: language}
+			}
+		}
+	}
+	for _, language := range f.languages {
+		list := f.lists[language]
+		for _, term := range list.blocked {
+			if strings.Contains(collapsed, term) {
+				return Match{Verdict: Review, Term: term, Language: language}
+			}
+		}
+		for _, term := range list.review {
+			if words[term] {
+				return Match{Verdict: Review, Term: term, Language: language}
+			}
+		}
+	}
+	return Match{Verdict: Allow}
+}
+
+// substitutions undoes the usual digit and symbol stand-ins for letters
+var substitutions = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't',
+	'@': 'a', '$': 's',
+}
+
+// tokenize splits text into lower-case words after normalization
+func tokenize(text string) []string {
+	text = strings.ToLower(norm.NFKC.String(text))
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range text {
+		if sub, ok := substitutions[r]; ok {
+			r = sub
+		}
+		if unicode.IsLetter(r) {
+			word.WriteRune(r)
+		} else if !unicode.Is(unicode.Mn, r) {
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,60 @@
+package moderation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilter_Check(t *testing.T) {
+	filter := DefaultFilter()
+
+	cases := []struct {
+		text    string
+		verdict string
+	}{
+		{"Friday Night Deepstack", Allow},
+		{"Scunthorpe United", Review}, // blocked term inside a longer word
+		{"Shit Happens", Block},       // blocked term as a word
+		{"SH1T happens", Block},       // character substitutions
+		{"s.h.i.t happens", Review},   // spelled out across separators
+		{"Ｓｈｉｔ happens", Block},       // full-width letters
+		{"Damn Good Poker", Review},   // borderline term
+		{"Classic Hold'em", Allow},    // borderline term inside a word is fine
+		{"Noche de mierda", Block},    // other languages
+		{"Torneo Mañana", Allow},
+	}
+	for _, c := range cases {
+		if got := filter.Check(c.text); got.Verdict != c.verdict {
+			t.Errorf("%q: expected %s, got %+v", c.text, c.verdict, got)
+		}
+	}
+
+	match := filter.Check("Noche de mierda")
+	if match.Term != "mierda" || match.Language != "es" {
+		t.Errorf("Expected the Spanish term to match, got %+v", match)
+	}
+}
+
+func TestFilter_AddWordlist(t *testing.T) {
+	filter := NewFilter()
+	if got := filter.Check("anything goes"); got.Verdict != Allow {
+		t.Fatalf("Expected an empty filter to allow everything, got %+v", got)
+	}
+
+	list := "# comment\n\nbadword\n?iffy\n"
+	if err := filter.AddWordlist("xx", strings.NewReader(list)); err != nil {
+		t.Fatalf("AddWordlist failed: %v", err)
+	}
+	if got := filter.Check("a BADWORD here"); got.Verdict != Block {
+		t.Errorf("Expected a block, got %+v", got)
+	}
+	if got := filter.Check("somewhat iffy"); got.Verdict != Review {
+		t.Errorf("Expected a review, got %+v", got)
+	}
+	if got := filter.Check("comment"); got.Verdict != Allow {
+		t.Errorf("Expected comment lines to be skipped, got %+v", got)
+	}
+	if languages := filter.Languages(); len(languages) != 1 || languages[0] != "xx" {
+		t.Errorf("Expected one language, got %v", languages)
+	}
+}
@@ -0,0 +1,190 @@
+package moderation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// What a queued piece of text is
+const (
+	KindUsername       = "username"
+	KindTableName      = "table_name"
+	KindTournamentName = "tournament_name"
+	KindChat           = "chat"
+	KindAvatar         = "avatar" // The text is the avatar's URL
+)
+
+// Review states of a queued item
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// maxQueuedText matches the moderation_queue.text column size
+const maxQueuedText = 500
+
+// Queue errors
+var (
+	ErrNotFound        = errors.New("moderation item not found")
+	ErrAlreadyReviewed = errors.New("moderation item has already been reviewed")
+	ErrInvalidStatus   = errors.New("status must be pending, approved or rejected")
+)
+
+// Service screens text with a filter and keeps the review queue
+type Service struct {
+	db     *gorm.DB
+	filter *Filter
+}
+
+// NewService creates a moderation service
+func NewService(db *gorm.DB, filter *Filter) *Service {
+	return &Service{db: db, filter: filter}
+}
+
+// Screen checks text before it is saved. Blocked text comes back with an
+// error naming the field; anything else comes back with the match, to pass to
+// Flag once the text is saved.
+func (s *Service) Screen(field, text string) (Match, error) {
+	match := s.filter.Check(text)
+	if match.Verdict == Block {
+		return match, fmt.Errorf("%s %w", field, ErrBlocked)
+	}
+	return match, nil
+}
+
+// Flag queues saved text for review if the filter found it borderline. It
+// does nothing for text that was allowed.
+func (s *Service) Flag(match Match, kind, subjectID, userID, text string) (*models.ModerationItem, error) {
+	if match.Verdict != Review {
+		return nil, nil
+	}
+	if utf8.RuneCountInString(text) > maxQueuedText {
+		text = string([]rune(text)[:maxQueuedText])
+	}
+	item := &models.ModerationItem{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		SubjectID: subjectID,
+		UserID:    userID,
+		Text:      text,
+		Term:      match.Term,
+		Language:  match.Language,
+		Status:    StatusPending,
+	}
+	if err := s.db.Create(item).Error; err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Report queues something the filter can't check, such as an avatar, for
+// review, giving the reason in place of a matched term. Reporting what is
+// already pending returns the queued item.
+func (s *Service) Report(kind, reason, subjectID, userID, text string) (*models.ModerationItem, error) {
+	var pending models.ModerationItem
+	err := s.db.Where("kind = ? AND subject_id = ? AND text = ? AND status = ?", kind, subjectID, text, StatusPending).
+		First(&pending).Error
+	if err == nil {
+		return &pending, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	item := &models.ModerationItem{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		SubjectID: subjectID,
+		UserID:    userID,
+		Text:      text,
+		Term:      reason,
+		Status:    StatusPending,
+	}
+	if err := s.db.Create(item).Error; err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// List returns queued items with a status, oldest first
+func (s *Service) List(status string) ([]models.ModerationItem, error) {
+	switch status {
+	case StatusPending, StatusApproved, StatusRejected:
+	default:
+		return nil, ErrInvalidStatus
+	}
+	var items []models.ModerationItem
+	err := s.db.Where("status = ?", status).Order("created_at").Find(&items).Error
+	return items, err
+}
+
+// Resolve records an admin's decision. Rejecting a name replaces it with a
+// neutral one and rejecting an avatar takes it down; rejected chat has
+// already been seen, so it is only recorded.
+func (s *Service) Resolve(id, adminID string, approve bool, now time.Time) (*models.ModerationItem, error) {
+	var item models.ModerationItem
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).First(&item).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if item.Status != StatusPending {
+			return ErrAlreadyReviewed
+		}
+
+		item.Status = StatusRejected
+		if approve {
+			item.Status = StatusApproved
+		}
+		item.ReviewedBy = &adminID
+		item.ReviewedAt = &now
+		if err := tx.Model(&item).Updates(map[string]interface{}{
+			"status":      item.Status,
+			"reviewed_by": adminID,
+			"reviewed_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		if approve {
+			return nil
+		}
+		return takeDown(tx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// takeDown swaps a rejected name for one made from the subject's ID, or
+// removes a rejected avatar, unless it has changed since it was queued. The
+// avatar's image is left for the avatar service to delete.
+func takeDown(tx *gorm.DB, item models.ModerationItem) error {
+	short := item.SubjectID
+	if len(short) > 8 {
+		short = short[:8]
+	}
+	switch item.Kind {
+	case KindUsername:
+		return tx.Model(&models.User{}).Where("id = ? AND username = ?", item.SubjectID, item.Text).
+			Update("username", "player_"+short).Error
+	case KindTableName:
+		return tx.Model(&models.Table{}).Where("id = ? AND name = ?", item.SubjectID, item.Text).
+			Update("name", "Table "+short).Error
+	case KindTournamentName:
+		return tx.Model(&models.Tournament{}).Where("id = ? AND name = ?", item.SubjectID, item.Text).
+			Update("name", "Tournament "+short).Error
+	case KindAvatar:
+		return tx.Where("user_id = ? AND url = ?", item.SubjectID, item.Text).Delete(&models.Avatar{}).Error
+	}
+	return nil
+}
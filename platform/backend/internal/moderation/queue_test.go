@@ -0,0 +1,133 @@
+package moderation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.ModerationItem{}, &models.User{}, &models.Table{}, &models.Avatar{})
+	return NewService(db, DefaultFilter()), db
+}
+
+func TestService_ScreenAndFlag(t *testing.T) {
+	service, _ := setupTestService(t)
+
+	if _, err := service.Screen("table name", "Shit Table"); !errors.Is(err, ErrBlocked) {
+		t.Errorf("Expected ErrBlocked, got %v", err)
+	}
+
+	match, err := service.Screen("table name", "Friday Game")
+	if err != nil {
+		t.Fatalf("Screen failed: %v", err)
+	}
+	if item, err := service.Flag(match, KindTableName, "table-1", "alice", "Friday Game"); item != nil || err != nil {
+		t.Errorf("Expected allowed text not to be queued, got %v, %v", item, err)
+	}
+
+	match, _ = service.Screen("table name", "Damn Fine Poker")
+	item, err := service.Flag(match, KindTableName, "table-2", "alice", "Damn Fine Poker")
+	if err != nil || item == nil {
+		t.Fatalf("Expected borderline text to be queued, got %v, %v", item, err)
+	}
+
+	pending, err := service.List(StatusPending)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Term != "damn" || pending[0].Language != "en" {
+		t.Errorf("Expected one pending item for 'damn', got %+v", pending)
+	}
+	if _, err := service.List("bogus"); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("Expected ErrInvalidStatus, got %v", err)
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	service, db := setupTestService(t)
+	db.Exec(`INSERT INTO users (id, username) VALUES ('0123456789abcdef', 'damn_player')`)
+	db.Exec(`INSERT INTO tables (id, name) VALUES ('table-approved', 'Damn Good')`)
+
+	match := Match{Verdict: Review, Term: "damn", Language: "en"}
+	user, _ := service.Flag(match, KindUsername, "0123456789abcdef", "0123456789abcdef", "damn_player")
+	table, _ := service.Flag(match, KindTableName, "table-approved", "0123456789abcdef", "Damn Good")
+
+	approved, err := service.Resolve(table.ID, "admin", true, time.Now())
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if approved.Status != StatusApproved || approved.ReviewedBy == nil || *approved.ReviewedBy != "admin" {
+		t.Errorf("Expected an approval by admin, got %+v", approved)
+	}
+	var name string
+	db.Raw(`SELECT name FROM tables WHERE id = 'table-approved'`).Scan(&name)
+	if name != "Damn Good" {
+		t.Errorf("Expected an approved name to stay, got %q", name)
+	}
+
+	if _, err := service.Resolve(user.ID, "admin", false, time.Now()); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	var username string
+	db.Raw(`SELECT username FROM users WHERE id = '0123456789abcdef'`).Scan(&username)
+	if username != "player_01234567" {
+		t.Errorf("Expected a rejected username to be replaced, got %q", username)
+	}
+
+	if _, err := service.Resolve(user.ID, "admin", true, time.Now()); !errors.Is(err, ErrAlreadyReviewed) {
+		t.Errorf("Expected ErrAlreadyReviewed, got %v", err)
+	}
+	if _, err := service.Resolve("missing", "admin", true, time.Now()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestService_ReportAvatar(t *testing.T) {
+	service, db := setupTestService(t)
+	db.Exec(`INSERT INTO avatars (user_id, url, storage_key) VALUES ('alice', '/avatars/alice/2.png', 'alice/2.png')`)
+
+	stale, err := service.Report(KindAvatar, "upload", "alice", "alice", "/avatars/alice/1.png")
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	item, err := service.Report(KindAvatar, "upload", "alice", "alice", "/avatars/alice/2.png")
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	again, err := service.Report(KindAvatar, "reported", "alice", "alice", "/avatars/alice/2.png")
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if again.ID != item.ID || again.Term != "upload" {
+		t.Errorf("Expected reporting a pending avatar to return its item, got %+v", again)
+	}
+
+	countAvatars := func() int {
+		var count int
+		db.Raw(`SELECT COUNT(*) FROM avatars WHERE user_id = 'alice'`).Scan(&count)
+		return count
+	}
+	if _, err := service.Resolve(stale.ID, "admin", false, time.Now()); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if countAvatars() != 1 {
+		t.Error("Expected rejecting a replaced avatar to leave the new one")
+	}
+	if _, err := service.Resolve(item.ID, "admin", false, time.Now()); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if countAvatars() != 0 {
+		t.Error("Expected a rejected avatar to be removed")
+	}
+}
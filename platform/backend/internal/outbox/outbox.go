@@ -0,0 +1,329 @@
+// Package outbox runs the later steps of operations that span more than one
+// transaction. A step is written as a message in the same transaction as the
+// step before it, so it cannot be lost to a crash in between, and the
+// dispatcher runs it until it succeeds. A step that keeps failing is given up
+// on: its compensation, if it has one, undoes what came before.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/ids"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Message statuses
+const (
+	StatusPending     = "pending"
+	StatusDone        = "done"
+	StatusCompensated = "compensated"
+	StatusFailed      = "failed"
+)
+
+// Outbox errors
+var (
+	ErrMessageNotFound = errors.New("outbox message not found")
+	ErrNotRetryable    = errors.New("only failed outbox messages can be retried")
+	ErrInvalidStatus   = errors.New("status must be pending, done, compensated or failed")
+)
+
+// Config holds dispatcher settings
+type Config struct {
+	PollInterval time.Duration // How often due messages are looked for
+	BatchSize    int           // Messages run per poll
+	MaxAttempts  int           // Attempts before a step is given up on
+	RetryBackoff time.Duration // Wait after the first failure, doubled after each one
+	MaxBackoff   time.Duration // Longest wait between attempts
+	Lease        time.Duration // How long a running message is kept from other dispatchers
+	StepTimeout  time.Duration // Deadline of the context a step runs with
+}
+
+// DefaultConfig retries a step 10 times over roughly an hour before giving up
+var DefaultConfig = Config{
+	PollInterval: time.Second,
+	BatchSize:    50,
+	MaxAttempts:  10,
+	RetryBackoff: 2 * time.Second,
+	MaxBackoff:   15 * time.Minute,
+	Lease:        time.Minute,
+	StepTimeout:  30 * time.Second,
+}
+
+// Handler runs the messages of one topic. Do may run more than once for the
+// same message, after a crash or a lost update, so it must be idempotent.
+// Compensate is optional and runs once Do has failed MaxAttempts times.
+type Handler struct {
+	Do         func(ctx context.Context, msg models.OutboxMessage) error
+	Compensate func(ctx context.Context, msg models.OutboxMessage) error
+}
+
+// Enqueue writes a message for a topic inside the caller's transaction and
+// returns its ID. The payload is stored as JSON.
+func Enqueue(tx *gorm.DB, topic, aggregateID string, payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode outbox payload: %w", err)
+	}
+	now := time.Now()
+	msg := models.OutboxMessage{
+		ID:            ids.NewAt(now),
+		Topic:         topic,
+		AggregateID:   aggregateID,
+		Payload:       string(data),
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	if err := tx.Create(&msg).Error; err != nil {
+		return "", fmt.Errorf("failed to write outbox message: %w", err)
+	}
+	return msg.ID, nil
+}
+
+// Decode unmarshals a message's payload
+func Decode(msg models.OutboxMessage, v interface{}) error {
+	return json.Unmarshal([]byte(msg.Payload), v)
+}
+
+// Dispatcher runs due outbox messages with the handler of their topic
+type Dispatcher struct {
+	db     *gorm.DB
+	config Config
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher. Handlers must be registered before Start.
+func NewDispatcher(db *gorm.DB, config Config) *Dispatcher {
+	return &Dispatcher{
+		db:       db,
+		config:   config,
+		handlers: make(map[string]Handler),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Register sets the handler for a topic. Messages of topics without a
+// handler are left pending.
+func (d *Dispatcher) Register(topic string, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[topic] = handler
+}
+
+func (d *Dispatcher) handler(topic string) (Handler, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	handler, ok := d.handlers[topic]
+	return handler, ok
+}
+
+func (d *Dispatcher) topics() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	topics := make([]string, 0, len(d.handlers))
+	for topic := range d.handlers {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// Start begins polling for due messages, including any left pending by a
+// previous run
+func (d *Dispatcher) Start() {
+	d.wg.Add(1)
+	go d.loop()
+	log.Printf("[OUTBOX] Dispatcher started (poll=%v max_attempts=%d)", d.config.PollInterval, d.config.MaxAttempts)
+}
+
+// Stop stops polling and waits for the messages being run to finish
+func (d *Dispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopChan)
+	})
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) loop() {
+	defer d.wg.Done()
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := d.ProcessDue(time.Now()); err != nil {
+				log.Printf("[OUTBOX] ERROR: Failed to load due messages: %v", err)
+			}
+		case <-d.stopChan:
+			return
+		}
+	}
+}
+
+// ProcessDue runs up to BatchSize messages that are due, oldest first, and
+// returns how many it ran
+func (d *Dispatcher) ProcessDue(now time.Time) (int, error) {
+	topics := d.topics()
+	if len(topics) == 0 {
+		return 0, nil
+	}
+	var due []string
+	if err := d.db.Model(&models.OutboxMessage{}).
+		Where("status = ? AND next_attempt_at <= ? AND topic IN ?", StatusPending, now, topics).
+		Order("next_attempt_at, id").
+		Limit(d.config.BatchSize).
+		Pluck("id", &due).Error; err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, id := range due {
+		ran, err := d.process(id, now)
+		if err != nil {
+			log.Printf("[OUTBOX] ERROR: Failed to run message %s: %v", id, err)
+		}
+		if ran {
+			processed++
+		}
+	}
+	return processed, nil
+}
+
+// Process runs one message now if it is due, for callers that want a step
+// to follow the one before it straight away. If it fails, or the process
+// dies first, the dispatcher retries it later.
+func (d *Dispatcher) Process(id string) {
+	if _, err := d.process(id, time.Now()); err != nil {
+		log.Printf("[OUTBOX] ERROR: Failed to run message %s: %v", id, err)
+	}
+}
+
+// process claims a message and runs it. Claiming counts the attempt and
+// pushes the message's next attempt out by the lease, so no other
+// dispatcher runs it meanwhile and it is retried if this one dies.
+func (d *Dispatcher) process(id string, now time.Time) (bool, error) {
+	claim := d.db.Model(&models.OutboxMessage{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, StatusPending, now).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(d.config.Lease),
+		})
+	if claim.Error != nil {
+		return false, claim.Error
+	}
+	if claim.RowsAffected == 0 {
+		return false, nil // Not due, or claimed by someone else
+	}
+
+	var msg models.OutboxMessage
+	if err := d.db.Where("id = ?", id).First(&msg).Error; err != nil {
+		return false, err
+	}
+	handler, ok := d.handler(msg.Topic)
+	if !ok {
+		// Nothing here can run it; hand it back untouched
+		return false, d.db.Model(&msg).Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts - 1"),
+			"next_attempt_at": now,
+		}).Error
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.StepTimeout)
+	defer cancel()
+	stepErr := handler.Do(ctx, msg)
+	if stepErr == nil {
+		return true, d.finish(msg, StatusDone, "", now)
+	}
+
+	if msg.Attempts < d.config.MaxAttempts {
+		retryAt := now.Add(d.backoff(msg.Attempts))
+		log.Printf("[OUTBOX] %s %s (%s) failed, attempt %d of %d, retrying at %s: %v",
+			msg.Topic, msg.ID, msg.AggregateID, msg.Attempts, d.config.MaxAttempts, retryAt.Format(time.RFC3339), stepErr)
+		return true, d.db.Model(&msg).Updates(map[string]interface{}{
+			"next_attempt_at": retryAt,
+			"last_error":      stepErr.Error(),
+		}).Error
+	}
+
+	// Out of attempts: undo what came before, or leave it for an admin
+	log.Printf("[OUTBOX] ERROR: %s %s (%s) gave up after %d attempts: %v",
+		msg.Topic, msg.ID, msg.AggregateID, msg.Attempts, stepErr)
+	if handler.Compensate == nil {
+		return true, d.finish(msg, StatusFailed, stepErr.Error(), now)
+	}
+	if err := handler.Compensate(ctx, msg); err != nil {
+		log.Printf("[OUTBOX] ERROR: Compensating %s %s (%s) failed: %v", msg.Topic, msg.ID, msg.AggregateID, err)
+		return true, d.finish(msg, StatusFailed, fmt.Sprintf("%v; compensation failed: %v", stepErr, err), now)
+	}
+	log.Printf("[OUTBOX] Compensated %s %s (%s)", msg.Topic, msg.ID, msg.AggregateID)
+	return true, d.finish(msg, StatusCompensated, stepErr.Error(), now)
+}
+
+func (d *Dispatcher) finish(msg models.OutboxMessage, status, lastError string, now time.Time) error {
+	return d.db.Model(&msg).Updates(map[string]interface{}{
+		"status":       status,
+		"last_error":   lastError,
+		"processed_at": now,
+	}).Error
+}
+
+// backoff is the wait after a message's nth failed attempt
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.config.RetryBackoff
+	for i := 1; i < attempts && wait < d.config.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > d.config.MaxBackoff {
+		wait = d.config.MaxBackoff
+	}
+	return wait
+}
+
+// List returns messages with a status, newest first
+func (d *Dispatcher) List(status string, limit int) ([]models.OutboxMessage, error) {
+	switch status {
+	case StatusPending, StatusDone, StatusCompensated, StatusFailed:
+	default:
+		return nil, ErrInvalidStatus
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	var messages []models.OutboxMessage
+	err := d.db.Where("status = ?", status).Order("created_at DESC, id DESC").Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
+// Retry puts a failed message back in the queue with fresh attempts
+func (d *Dispatcher) Retry(id string) error {
+	var msg models.OutboxMessage
+	if err := d.db.Where("id = ?", id).First(&msg).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMessageNotFound
+		}
+		return err
+	}
+	if msg.Status != StatusFailed {
+		return ErrNotRetryable
+	}
+	return d.db.Model(&msg).Updates(map[string]interface{}{
+		"status":          StatusPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+		"processed_at":    nil,
+	}).Error
+}
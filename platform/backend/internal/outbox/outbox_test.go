@@ -0,0 +1,167 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDispatcher(t *testing.T, config Config) (*Dispatcher, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.OutboxMessage{})
+	return NewDispatcher(db, config), db
+}
+
+func loadMessage(t *testing.T, db *gorm.DB, id string) models.OutboxMessage {
+	var msg models.OutboxMessage
+	if err := db.Where("id = ?", id).First(&msg).Error; err != nil {
+		t.Fatalf("Failed to load message %s: %v", id, err)
+	}
+	return msg
+}
+
+func TestDispatcher_RunsCommittedMessages(t *testing.T) {
+	dispatcher, db := setupTestDispatcher(t, DefaultConfig)
+
+	var got []string
+	dispatcher.Register("payout", Handler{Do: func(ctx context.Context, msg models.OutboxMessage) error {
+		var payload struct {
+			TournamentID string `json:"tournament_id"`
+		}
+		if err := Decode(msg, &payload); err != nil {
+			return err
+		}
+		got = append(got, payload.TournamentID)
+		return nil
+	}})
+
+	id, err := Enqueue(db, "payout", "t-1", map[string]string{"tournament_id": "t-1"})
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	// A message whose transaction rolled back is never run
+	db.Transaction(func(tx *gorm.DB) error {
+		Enqueue(tx, "payout", "t-2", map[string]string{"tournament_id": "t-2"})
+		return errors.New("rolled back")
+	})
+	// Nor is one nothing here handles
+	otherID, _ := Enqueue(db, "elsewhere", "t-3", nil)
+
+	processed, err := dispatcher.ProcessDue(time.Now())
+	if err != nil {
+		t.Fatalf("ProcessDue failed: %v", err)
+	}
+	if processed != 1 || len(got) != 1 || got[0] != "t-1" {
+		t.Errorf("Expected only t-1 to be paid out, got %d runs: %v", processed, got)
+	}
+	if msg := loadMessage(t, db, id); msg.Status != StatusDone || msg.Attempts != 1 || msg.ProcessedAt == nil {
+		t.Errorf("Expected the message to be done after one attempt, got %+v", msg)
+	}
+	if msg := loadMessage(t, db, otherID); msg.Status != StatusPending || msg.Attempts != 0 {
+		t.Errorf("Expected the unhandled message to stay pending, got %+v", msg)
+	}
+
+	// Done messages are not run again
+	dispatcher.Process(id)
+	if len(got) != 1 {
+		t.Errorf("Expected a done message not to run again, got %v", got)
+	}
+}
+
+func TestDispatcher_RetriesWithBackoff(t *testing.T) {
+	config := DefaultConfig
+	config.MaxAttempts = 3
+	dispatcher, db := setupTestDispatcher(t, config)
+
+	calls := 0
+	dispatcher.Register("payout", Handler{Do: func(ctx context.Context, msg models.OutboxMessage) error {
+		calls++
+		if calls < 3 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	}})
+	id, _ := Enqueue(db, "payout", "t-1", nil)
+
+	now := time.Now()
+	dispatcher.ProcessDue(now)
+	msg := loadMessage(t, db, id)
+	if msg.Status != StatusPending || msg.LastError != "database unavailable" {
+		t.Fatalf("Expected the failed message to stay pending with its error, got %+v", msg)
+	}
+	if !msg.NextAttemptAt.Equal(now.Add(config.RetryBackoff)) {
+		t.Errorf("Expected a retry after %v, got %v", config.RetryBackoff, msg.NextAttemptAt.Sub(now))
+	}
+
+	// Not retried before it is due
+	if processed, _ := dispatcher.ProcessDue(now.Add(time.Second)); processed != 0 {
+		t.Errorf("Expected no run before the backoff, got %d", processed)
+	}
+
+	now = now.Add(config.RetryBackoff)
+	dispatcher.ProcessDue(now)
+	if msg := loadMessage(t, db, id); !msg.NextAttemptAt.Equal(now.Add(2 * config.RetryBackoff)) {
+		t.Errorf("Expected the backoff to double, got %v", msg.NextAttemptAt.Sub(now))
+	}
+
+	dispatcher.ProcessDue(now.Add(2 * config.RetryBackoff))
+	if msg := loadMessage(t, db, id); msg.Status != StatusDone || msg.Attempts != 3 {
+		t.Errorf("Expected the third attempt to succeed, got %+v", msg)
+	}
+}
+
+func TestDispatcher_Compensates(t *testing.T) {
+	config := DefaultConfig
+	config.MaxAttempts = 1
+	dispatcher, db := setupTestDispatcher(t, config)
+
+	compensated := ""
+	dispatcher.Register("start_tables", Handler{
+		Do: func(ctx context.Context, msg models.OutboxMessage) error { return errors.New("no engine") },
+		Compensate: func(ctx context.Context, msg models.OutboxMessage) error {
+			compensated = msg.AggregateID
+			return nil
+		},
+	})
+	dispatcher.Register("payout", Handler{
+		Do: func(ctx context.Context, msg models.OutboxMessage) error { return errors.New("user not found") },
+	})
+
+	compensatedID, _ := Enqueue(db, "start_tables", "t-1", nil)
+	failedID, _ := Enqueue(db, "payout", "t-2", nil)
+	dispatcher.ProcessDue(time.Now())
+
+	if msg := loadMessage(t, db, compensatedID); msg.Status != StatusCompensated || compensated != "t-1" {
+		t.Errorf("Expected the step to be compensated, got %+v", msg)
+	}
+	msg := loadMessage(t, db, failedID)
+	if msg.Status != StatusFailed || msg.LastError != "user not found" {
+		t.Fatalf("Expected a step without compensation to fail, got %+v", msg)
+	}
+
+	if failed, _ := dispatcher.List(StatusFailed, 0); len(failed) != 1 || failed[0].ID != failedID {
+		t.Errorf("Expected the failed message to be listed, got %+v", failed)
+	}
+	if err := dispatcher.Retry(compensatedID); !errors.Is(err, ErrNotRetryable) {
+		t.Errorf("Expected ErrNotRetryable for a compensated message, got %v", err)
+	}
+	if err := dispatcher.Retry("missing"); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("Expected ErrMessageNotFound, got %v", err)
+	}
+	if err := dispatcher.Retry(failedID); err != nil {
+		t.Fatalf("Retry failed: %v", err)
+	}
+	if msg := loadMessage(t, db, failedID); msg.Status != StatusPending || msg.Attempts != 0 {
+		t.Errorf("Expected the retried message to be pending with fresh attempts, got %+v", msg)
+	}
+}
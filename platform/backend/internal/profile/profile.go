@@ -0,0 +1,236 @@
+// Package profile builds public player profiles: a player's level,
+// tournaments won, biggest pot and the badges those earn them. Each of these
+// fields can be hidden by its owner, who still sees it.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrPlayerNotFound = errors.New("player not found")
+	ErrUnknownField   = errors.New("unknown profile field")
+)
+
+// Profile fields an owner can hide
+const (
+	FieldLevel          = "level"
+	FieldTournamentsWon = "tournaments_won"
+	FieldBiggestPot     = "biggest_pot"
+	FieldBadges         = "badges"
+)
+
+// Fields are the profile fields an owner can hide
+var Fields = []string{FieldLevel, FieldTournamentsWon, FieldBiggestPot, FieldBadges}
+
+// Badge is an achievement shown on a profile
+type Badge struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// badgeRule awards a badge from a player's stats. A badge is left off when
+// the field it is earned by is hidden, so it can't give the field away.
+type badgeRule struct {
+	Badge
+	field  string
+	earned func(stats) bool
+}
+
+var badgeRules = []badgeRule{
+	{Badge{"tournament_winner", "Tournament winner"}, FieldTournamentsWon, func(s stats) bool { return s.tournamentsWon >= 1 }},
+	{Badge{"champion", "Won 10 tournaments"}, FieldTournamentsWon, func(s stats) bool { return s.tournamentsWon >= 10 }},
+	{Badge{"big_pot", "Won a pot of 10,000 chips"}, FieldBiggestPot, func(s stats) bool { return s.biggestPot >= 10000 }},
+	{Badge{"veteran", "Reached level 10"}, FieldLevel, func(s stats) bool { return s.level >= 10 }},
+}
+
+type stats struct {
+	level          int
+	tournamentsWon int
+	biggestPot     int
+}
+
+// Profile is a player's profile as someone sees it. Fields hidden from the
+// viewer are left out.
+type Profile struct {
+	UserID         string    `json:"user_id"`
+	Username       string    `json:"username"`
+	AvatarURL      string    `json:"avatar_url,omitempty"`
+	MemberSince    time.Time `json:"member_since"`
+	Level          *int      `json:"level,omitempty"`
+	TournamentsWon *int      `json:"tournaments_won,omitempty"`
+	BiggestPot     *int      `json:"biggest_pot,omitempty"`
+	Badges         []Badge   `json:"badges,omitempty"`
+	Hidden         []string  `json:"hidden,omitempty"` // Only shown to the owner
+}
+
+// Service builds profiles and keeps their stats
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService creates a profile service
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// Get returns the profile of the player with a username as viewerID sees it
+func (s *Service) Get(username, viewerID string) (*Profile, error) {
+	var user models.User
+	if err := s.db.Select("id", "username", "level", "created_at").
+		Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPlayerNotFound
+		}
+		return nil, err
+	}
+	profiles, err := s.build([]models.User{user}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return profiles[0], nil
+}
+
+// Public returns the profiles of the given users as anyone sees them, by
+// user ID. Users that don't exist are left out.
+func (s *Service) Public(userIDs []string) (map[string]*Profile, error) {
+	byID := make(map[string]*Profile, len(userIDs))
+	if len(userIDs) == 0 {
+		return byID, nil
+	}
+	var users []models.User
+	if err := s.db.Select("id", "username", "level", "created_at").
+		Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	profiles, err := s.build(users, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range profiles {
+		byID[p.UserID] = p
+	}
+	return byID, nil
+}
+
+// build puts together the profiles of users as viewerID sees them
+func (s *Service) build(users []models.User, viewerID string) ([]*Profile, error) {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+
+	var rows []models.PlayerProfile
+	if err := s.db.Where("user_id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	stored := make(map[string]models.PlayerProfile, len(rows))
+	for _, row := range rows {
+		stored[row.UserID] = row
+	}
+
+	var wins []struct {
+		UserID string
+		Won    int
+	}
+	if err := s.db.Table("tournament_players tp").
+		Select("tp.user_id AS user_id, COUNT(*) AS won").
+		Joins("JOIN tournaments t ON t.id = tp.tournament_id AND t.status = ?", "completed").
+		Where("tp.user_id IN ? AND tp.position = 1 AND tp.deleted_at IS NULL", ids).
+		Group("tp.user_id").
+		Scan(&wins).Error; err != nil {
+		return nil, err
+	}
+	won := make(map[string]int, len(wins))
+	for _, w := range wins {
+		won[w.UserID] = w.Won
+	}
+
+	var avatars []models.Avatar
+	if err := s.db.Select("user_id", "url").Where("user_id IN ?", ids).Find(&avatars).Error; err != nil {
+		return nil, err
+	}
+	avatarURLs := make(map[string]string, len(avatars))
+	for _, avatar := range avatars {
+		avatarURLs[avatar.UserID] = avatar.URL
+	}
+
+	profiles := make([]*Profile, len(users))
+	for i, user := range users {
+		row := stored[user.ID]
+		st := stats{level: user.Level, tournamentsWon: won[user.ID], biggestPot: row.BiggestPot}
+		hidden := make(map[string]bool, len(row.Hidden))
+		for _, field := range row.Hidden {
+			hidden[field] = true
+		}
+		owner := user.ID == viewerID
+		shows := func(field string) bool { return owner || !hidden[field] }
+
+		p := &Profile{UserID: user.ID, Username: user.Username, AvatarURL: avatarURLs[user.ID], MemberSince: user.CreatedAt}
+		if shows(FieldLevel) {
+			p.Level = &st.level
+		}
+		if shows(FieldTournamentsWon) {
+			p.TournamentsWon = &st.tournamentsWon
+		}
+		if shows(FieldBiggestPot) {
+			p.BiggestPot = &st.biggestPot
+		}
+		if shows(FieldBadges) {
+			p.Badges = []Badge{}
+			for _, rule := range badgeRules {
+				if shows(rule.field) && rule.earned(st) {
+					p.Badges = append(p.Badges, rule.Badge)
+				}
+			}
+		}
+		if owner {
+			p.Hidden = row.Hidden
+		}
+		profiles[i] = p
+	}
+	return profiles, nil
+}
+
+// SetHidden sets which fields of a user's profile only they see
+func (s *Service) SetHidden(userID string, hidden []string) error {
+	valid := make(map[string]bool, len(Fields))
+	for _, field := range Fields {
+		valid[field] = true
+	}
+	seen := make(map[string]bool, len(hidden))
+	fields := make([]string, 0, len(hidden))
+	for _, field := range hidden {
+		if !valid[field] {
+			return fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"hidden"}),
+	}).Create(&models.PlayerProfile{UserID: userID, Hidden: fields}).Error
+}
+
+// RecordPot keeps a pot a user won in one hand as their biggest pot if it is
+func (s *Service) RecordPot(userID string, amount int, at time.Time) error {
+	result := s.db.Model(&models.PlayerProfile{}).
+		Where("user_id = ? AND biggest_pot < ?", userID, amount).
+		Updates(map[string]interface{}{"biggest_pot": amount, "biggest_pot_at": at})
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	// No row yet, or a bigger pot already
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.PlayerProfile{UserID: userID, BiggestPot: amount, BiggestPotAt: &at, Hidden: []string{}}).Error
+}
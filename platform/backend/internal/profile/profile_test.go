@@ -0,0 +1,94 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.PlayerProfile{}, &models.TournamentPlayer{}, &models.Avatar{}))
+	testutil.Schema(t, db, &models.Tournament{})
+	for _, user := range []models.User{
+		{ID: "alice", Username: "alice", Email: "alice@test.com", Level: 12},
+		{ID: "bob", Username: "bob", Email: "bob@test.com", Level: 3},
+	} {
+		require.NoError(t, db.Create(&user).Error)
+	}
+	return NewService(db), db
+}
+
+func TestProfile(t *testing.T) {
+	s, db := setupTestService(t)
+	first, second := 1, 2
+	require.NoError(t, db.Exec(`INSERT INTO tournaments (id, status) VALUES ('t-1', 'completed'), ('t-2', 'in_progress')`).Error)
+	for _, player := range []models.TournamentPlayer{
+		{TournamentID: "t-1", UserID: "alice", Position: &first},
+		{TournamentID: "t-1", UserID: "bob", Position: &second},
+		{TournamentID: "t-2", UserID: "bob", Position: &first},
+	} {
+		require.NoError(t, db.Create(&player).Error)
+	}
+
+	now := time.Now()
+	require.NoError(t, s.RecordPot("alice", 12000, now))
+	require.NoError(t, s.RecordPot("alice", 500, now))
+	require.NoError(t, s.RecordPot("bob", 800, now))
+
+	require.NoError(t, db.Create(&models.Avatar{UserID: "alice", URL: "/avatars/alice/a.png", StorageKey: "alice/a.png"}).Error)
+
+	p, err := s.Get("alice", "bob")
+	require.NoError(t, err)
+	assert.Equal(t, "/avatars/alice/a.png", p.AvatarURL)
+	assert.Equal(t, 12, *p.Level)
+	assert.Equal(t, 1, *p.TournamentsWon)
+	assert.Equal(t, 12000, *p.BiggestPot, "a smaller pot doesn't replace the biggest")
+	assert.Equal(t, []string{"tournament_winner", "big_pot", "veteran"}, badgeIDs(p.Badges))
+	assert.Nil(t, p.Hidden, "only the owner sees what is hidden")
+
+	_, err = s.Get("carol", "bob")
+	assert.ErrorIs(t, err, ErrPlayerNotFound)
+
+	// Hidden fields and the badges they earn are left out for everyone else
+	assert.ErrorIs(t, s.SetHidden("alice", []string{"password"}), ErrUnknownField)
+	require.NoError(t, s.SetHidden("alice", []string{FieldBiggestPot, FieldBiggestPot}))
+	p, err = s.Get("alice", "bob")
+	require.NoError(t, err)
+	assert.Nil(t, p.BiggestPot)
+	assert.Equal(t, []string{"tournament_winner", "veteran"}, badgeIDs(p.Badges))
+	p, err = s.Get("alice", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 12000, *p.BiggestPot)
+	assert.Equal(t, []string{FieldBiggestPot}, p.Hidden)
+
+	// The hidden setting survives a new biggest pot
+	require.NoError(t, s.RecordPot("alice", 20000, now))
+	profiles, err := s.Public([]string{"alice", "bob", "nobody"})
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+	assert.Nil(t, profiles["alice"].BiggestPot)
+	p, err = s.Get("alice", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 20000, *p.BiggestPot)
+	assert.Equal(t, []string{FieldBiggestPot}, p.Hidden)
+	assert.Equal(t, 0, *profiles["bob"].TournamentsWon, "a tournament in progress isn't won yet")
+	assert.Equal(t, 800, *profiles["bob"].BiggestPot)
+	assert.Empty(t, profiles["bob"].Badges)
+}
+
+func badgeIDs(badges []Badge) []string {
+	ids := make([]string, len(badges))
+	for i, badge := range badges {
+		ids[i] = badge.ID
+	}
+	return ids
+}
@@ -0,0 +1,214 @@
+// Package progression grants players experience for hands played and
+// tournaments finished, and pays a reward from the house for every level they
+// reach.
+package progression
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/ids"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// XPPerHand is the experience for each hand a player is dealt into
+const XPPerHand = 10
+
+// XPPerLevel is the experience between levels 1 and 2. Each level after takes
+// that much more than the one before.
+const XPPerLevel = 500
+
+// TicketEvery is how many levels apart the rewards with a tournament ticket are
+const TicketEvery = 5
+
+// TournamentXP is the experience for finishing a tournament in a position: a
+// base for taking part, and more for every player outlasted
+func TournamentXP(position, entrants int) int {
+	if position < 1 || position > entrants {
+		return 50
+	}
+	return 50 + 10*(entrants-position)
+}
+
+// XPForLevel is the total experience a level takes to reach
+func XPForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	return XPPerLevel * level * (level - 1) / 2
+}
+
+// LevelFor is the level a total of experience reaches
+func LevelFor(xp int) int {
+	level := 1
+	for XPForLevel(level+1) <= xp {
+		level++
+	}
+	return level
+}
+
+// Reward is what reaching a level pays
+type Reward struct {
+	Chips       int `json:"chips"`
+	TicketValue int `json:"ticket_value,omitempty"` // An open tournament ticket worth up to this, or none
+}
+
+// RewardFor returns the reward for reaching a level
+func RewardFor(level int) Reward {
+	if level <= 1 {
+		return Reward{}
+	}
+	reward := Reward{Chips: 100 * level}
+	if level%TicketEvery == 0 {
+		reward.TicketValue = 100 * level
+	}
+	return reward
+}
+
+// Progress is a player's experience and how far they are through their level
+type Progress struct {
+	UserID    string `json:"user_id"`
+	XP        int    `json:"xp"`
+	Level     int    `json:"level"`
+	LevelXP   int    `json:"level_xp"`   // Total experience the current level took
+	NextXP    int    `json:"next_xp"`    // Total experience the next level takes
+	NextLevel Reward `json:"next_level"` // What the next level pays
+}
+
+// ProgressOf describes a total of experience
+func ProgressOf(userID string, xp int) Progress {
+	level := LevelFor(xp)
+	return Progress{
+		UserID:    userID,
+		XP:        xp,
+		Level:     level,
+		LevelXP:   XPForLevel(level),
+		NextXP:    XPForLevel(level + 1),
+		NextLevel: RewardFor(level + 1),
+	}
+}
+
+// LevelUp is a grant of experience that reached one or more new levels
+type LevelUp struct {
+	UserID    string   `json:"user_id"`
+	From      int      `json:"from"`
+	Level     int      `json:"level"`
+	XP        int      `json:"xp"`
+	Chips     int      `json:"chips"`                // Reward chips paid for all the levels reached
+	TicketIDs []string `json:"ticket_ids,omitempty"` // Open tickets given
+}
+
+// Service keeps players' experience and pays level-up rewards
+type Service struct {
+	db       *gorm.DB
+	currency *currency.Service
+}
+
+// NewService creates a progression service
+func NewService(db *gorm.DB, currencyService *currency.Service) *Service {
+	return &Service{db: db, currency: currencyService}
+}
+
+// Progress returns a user's progress
+func (s *Service) Progress(userID string) (*Progress, error) {
+	var user models.User
+	if err := s.db.Select("id", "xp").Where("id = ?", userID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	progress := ProgressOf(userID, user.XP)
+	return &progress, nil
+}
+
+// Level returns a user's level
+func (s *Service) Level(userID string) (int, error) {
+	var user models.User
+	if err := s.db.Select("id", "level").Where("id = ?", userID).First(&user).Error; err != nil {
+		return 0, err
+	}
+	return user.Level, nil
+}
+
+// Grant adds experience to a user, paying the reward of every level it takes
+// them to. It returns nil unless they reached a new level.
+func (s *Service) Grant(userID string, xp int, reason string) (*LevelUp, error) {
+	if xp <= 0 {
+		return nil, nil
+	}
+	ctx := context.Background()
+	var levelUp *LevelUp
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "xp", "level").Where("id = ?", userID).First(&user).Error; err != nil {
+			return err
+		}
+		total := user.XP + xp
+		level := LevelFor(total)
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"xp": total, "level": level}).Error; err != nil {
+			return err
+		}
+		if level <= user.Level {
+			return nil
+		}
+
+		levelUp = &LevelUp{UserID: userID, From: user.Level, Level: level, XP: total}
+		for reached := user.Level + 1; reached <= level; reached++ {
+			ticketID, err := s.payReward(ctx, tx, userID, reached)
+			if err != nil {
+				return fmt.Errorf("failed to pay level %d reward: %w", reached, err)
+			}
+			levelUp.Chips += RewardFor(reached).Chips
+			if ticketID != "" {
+				levelUp.TicketIDs = append(levelUp.TicketIDs, ticketID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if levelUp != nil {
+		log.Printf("[PROGRESSION] User %s reached level %d (%s): %d chips, %d tickets",
+			userID, levelUp.Level, reason, levelUp.Chips, len(levelUp.TicketIDs))
+	}
+	return levelUp, nil
+}
+
+// payReward pays the reward of one level from the operator account, returning
+// the ID of any ticket given
+func (s *Service) payReward(ctx context.Context, tx *gorm.DB, userID string, level int) (string, error) {
+	reward := RewardFor(level)
+	description := fmt.Sprintf("Level %d reward", level)
+	if reward.Chips > 0 {
+		if err := s.currency.PayRewardWithTx(ctx, tx, userID, reward.Chips,
+			currency.TxTypeLevelReward, userID, description); err != nil {
+			return "", err
+		}
+	}
+	if reward.TicketValue == 0 {
+		return "", nil
+	}
+
+	ticket := &models.TournamentTicket{
+		ID:     ids.New(),
+		UserID: userID,
+		Level:  &level,
+		Value:  reward.TicketValue,
+		Status: models.TicketUnused,
+	}
+	if err := tx.Create(ticket).Error; err != nil {
+		return "", err
+	}
+	// The house stands behind the ticket's value, as it would chips
+	if err := s.currency.CoverRewardWithTx(ctx, tx, reward.TicketValue, currency.TxTypeLevelReward, ticket.ID,
+		description+": tournament ticket"); err != nil {
+		return "", err
+	}
+	return ticket.ID, nil
+}
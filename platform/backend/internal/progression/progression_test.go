@@ -0,0 +1,79 @@
+package progression
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &currency.Transaction{}))
+	testutil.Schema(t, db, &models.TournamentTicket{})
+	require.NoError(t, db.Exec(`INSERT INTO users (id, username, email, password_hash, chips) VALUES
+		('`+currency.OperatorAccountID+`', 'house', 'house@localhost', '', 1000),
+		('alice', 'alice', 'alice@test.com', '', 1000)`).Error)
+	return db
+}
+
+func TestLevels(t *testing.T) {
+	assert.Equal(t, 0, XPForLevel(1))
+	assert.Equal(t, 500, XPForLevel(2))
+	assert.Equal(t, 1500, XPForLevel(3))
+	assert.Equal(t, 1, LevelFor(0))
+	assert.Equal(t, 1, LevelFor(499))
+	assert.Equal(t, 2, LevelFor(500))
+	assert.Equal(t, 3, LevelFor(1500))
+
+	assert.Equal(t, Reward{Chips: 300}, RewardFor(3))
+	assert.Equal(t, Reward{Chips: 500, TicketValue: 500}, RewardFor(5))
+	assert.Equal(t, Reward{}, RewardFor(1))
+
+	assert.Equal(t, 140, TournamentXP(1, 10))
+	assert.Equal(t, 50, TournamentXP(10, 10))
+	assert.Equal(t, 50, TournamentXP(0, 10), "no position still counts for taking part")
+}
+
+func TestGrant(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewService(db, currency.NewService(db))
+
+	levelUp, err := s.Grant("alice", 490, "test")
+	require.NoError(t, err)
+	assert.Nil(t, levelUp)
+
+	// Straight from level 1 to 5 pays every level's reward on the way
+	levelUp, err = s.Grant("alice", XPForLevel(5)-490, "test")
+	require.NoError(t, err)
+	require.NotNil(t, levelUp)
+	assert.Equal(t, 1, levelUp.From)
+	assert.Equal(t, 5, levelUp.Level)
+	assert.Equal(t, 200+300+400+500, levelUp.Chips)
+	require.Len(t, levelUp.TicketIDs, 1)
+
+	var alice, house models.User
+	require.NoError(t, db.First(&alice, "id = ?", "alice").Error)
+	require.NoError(t, db.First(&house, "id = ?", currency.OperatorAccountID).Error)
+	assert.Equal(t, 5, alice.Level)
+	assert.Equal(t, XPForLevel(5), alice.XP)
+	assert.Equal(t, 1000+1400, alice.Chips)
+	assert.Equal(t, 1000-1400-500, house.Chips, "the house pays the chips and the ticket")
+
+	var ticket models.TournamentTicket
+	require.NoError(t, db.First(&ticket, "id = ?", levelUp.TicketIDs[0]).Error)
+	assert.Nil(t, ticket.TournamentID, "level tickets are open")
+	assert.Equal(t, 500, ticket.Value)
+
+	progress, err := s.Progress("alice")
+	require.NoError(t, err)
+	assert.Equal(t, 5, progress.Level)
+	assert.Equal(t, XPForLevel(6), progress.NextXP)
+}
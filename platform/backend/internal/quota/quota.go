@@ -0,0 +1,161 @@
+// Package quota limits how many tables and tournaments one user can create,
+// with separate limits for each user role.
+package quota
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Quotas a user can run out of
+const (
+	ActiveTables     = "active_tables"
+	DailyTournaments = "daily_tournaments"
+)
+
+// Window is how far back tournaments count towards the daily quota
+const Window = 24 * time.Hour
+
+// Limits are what one user may create. Zero disables a limit.
+type Limits struct {
+	MaxActiveTables     int `json:"max_active_tables"`     // Tables created and not yet completed
+	MaxDailyTournaments int `json:"max_daily_tournaments"` // Tournaments created over a rolling 24 hours
+}
+
+// Config holds the limits of each role. Roles not listed are unlimited.
+type Config map[string]Limits
+
+// DefaultConfig limits players; admins are unlimited
+var DefaultConfig = Config{
+	models.RolePlayer: {MaxActiveTables: 3, MaxDailyTournaments: 5},
+}
+
+// ConfigFromEnv returns DefaultConfig with the limits set by
+// QUOTA_<ROLE>_MAX_ACTIVE_TABLES and QUOTA_<ROLE>_MAX_DAILY_TOURNAMENTS,
+// where 0 disables a limit
+func ConfigFromEnv(getEnv func(key, fallback string) string) Config {
+	config := make(Config)
+	for role, limits := range DefaultConfig {
+		config[role] = limits
+	}
+	for _, role := range []string{models.RolePlayer, models.RoleAdmin} {
+		limits := config[role]
+		prefix := "QUOTA_" + strings.ToUpper(role) + "_"
+		if n, err := strconv.Atoi(getEnv(prefix+"MAX_ACTIVE_TABLES", "")); err == nil && n >= 0 {
+			limits.MaxActiveTables = n
+		}
+		if n, err := strconv.Atoi(getEnv(prefix+"MAX_DAILY_TOURNAMENTS", "")); err == nil && n >= 0 {
+			limits.MaxDailyTournaments = n
+		}
+		config[role] = limits
+	}
+	return config
+}
+
+// ExceededError is returned when a user has used up a quota
+type ExceededError struct {
+	Quota   string     `json:"quota"`
+	Limit   int        `json:"limit"`
+	Used    int        `json:"used"`
+	RetryAt *time.Time `json:"retry_at,omitempty"` // When a daily quota frees up
+}
+
+func (e *ExceededError) Error() string {
+	switch e.Quota {
+	case ActiveTables:
+		return fmt.Sprintf("you already have %d open tables, the most you can create; close one to create another", e.Used)
+	case DailyTournaments:
+		return fmt.Sprintf("you have created %d tournaments in the last 24 hours, the most you can", e.Used)
+	}
+	return "quota exceeded"
+}
+
+// Service checks users against their role's quotas
+type Service struct {
+	db     *gorm.DB
+	config Config
+}
+
+// NewService creates a quota service
+func NewService(db *gorm.DB, config Config) *Service {
+	return &Service{db: db, config: config}
+}
+
+// LimitsFor returns the limits of a user's role
+func (s *Service) LimitsFor(userID string) (Limits, error) {
+	var user models.User
+	if err := s.db.Select("id", "role").Where("id = ?", userID).First(&user).Error; err != nil {
+		return Limits{}, err
+	}
+	role := user.Role
+	if role == "" {
+		role = models.RolePlayer
+	}
+	return s.config[role], nil
+}
+
+// CheckTable returns an *ExceededError if the user can't create another table.
+// Database errors are logged and do not block the user.
+func (s *Service) CheckTable(userID string) error {
+	limits, err := s.LimitsFor(userID)
+	if err != nil {
+		log.Printf("[QUOTA] Failed to load limits of user %s: %v", userID, err)
+		return nil
+	}
+	if limits.MaxActiveTables <= 0 {
+		return nil
+	}
+
+	var open int64
+	if err := s.db.Model(&models.Table{}).
+		Where("creator_id = ? AND status <> ?", userID, "completed").
+		Count(&open).Error; err != nil {
+		log.Printf("[QUOTA] Failed to count tables of user %s: %v", userID, err)
+		return nil
+	}
+	if int(open) >= limits.MaxActiveTables {
+		return &ExceededError{Quota: ActiveTables, Limit: limits.MaxActiveTables, Used: int(open)}
+	}
+	return nil
+}
+
+// CheckTournament returns an *ExceededError if the user can't create another
+// tournament today. Database errors are logged and do not block the user.
+func (s *Service) CheckTournament(userID string, now time.Time) error {
+	limits, err := s.LimitsFor(userID)
+	if err != nil {
+		log.Printf("[QUOTA] Failed to load limits of user %s: %v", userID, err)
+		return nil
+	}
+	if limits.MaxDailyTournaments <= 0 {
+		return nil
+	}
+
+	var created []time.Time
+	if err := s.db.Model(&models.Tournament{}).
+		Where("creator_id = ? AND created_at > ?", userID, now.Add(-Window)).
+		Order("created_at").
+		Pluck("created_at", &created).Error; err != nil {
+		log.Printf("[QUOTA] Failed to count tournaments of user %s: %v", userID, err)
+		return nil
+	}
+	if len(created) < limits.MaxDailyTournaments {
+		return nil
+	}
+
+	// A tournament frees up once enough of the oldest ones leave the window
+	retryAt := created[len(created)-limits.MaxDailyTournaments].Add(Window)
+	return &ExceededError{
+		Quota:   DailyTournaments,
+		Limit:   limits.MaxDailyTournaments,
+		Used:    len(created),
+		RetryAt: &retryAt,
+	}
+}
@@ -0,0 +1,86 @@
+package quota
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	testutil.Schema(t, db, &models.User{}, &models.Table{}, &models.Tournament{})
+	for _, stmt := range []string{
+		`INSERT INTO users (id, role) VALUES ('alice', 'player'), ('root', 'admin')`,
+		`INSERT INTO tables (id, creator_id, status, deleted_at) VALUES ('t1', 'alice', 'waiting', NULL), ('t2', 'alice', 'playing', NULL),
+			('t3', 'alice', 'completed', NULL), ('t4', 'root', 'waiting', NULL), ('t5', 'root', 'playing', NULL)`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	return db
+}
+
+func TestCheckTable(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewService(db, Config{models.RolePlayer: {MaxActiveTables: 2}})
+
+	err := s.CheckTable("alice")
+	var exceeded *ExceededError
+	require.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, ActiveTables, exceeded.Quota)
+	assert.Equal(t, 2, exceeded.Limit)
+	assert.Equal(t, 2, exceeded.Used, "completed tables don't count")
+
+	// Closing a table frees its place; admins are unlimited
+	require.NoError(t, db.Exec(`UPDATE tables SET status = 'completed' WHERE id = 't1'`).Error)
+	assert.NoError(t, s.CheckTable("alice"))
+	assert.NoError(t, s.CheckTable("root"))
+}
+
+func TestCheckTournament(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewService(db, Config{models.RolePlayer: {MaxDailyTournaments: 2}})
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	for id, at := range map[string]time.Time{
+		"old":    now.Add(-30 * time.Hour),
+		"early":  now.Add(-20 * time.Hour),
+		"recent": now.Add(-time.Hour),
+	} {
+		require.NoError(t, db.Exec(`INSERT INTO tournaments (id, creator_id, created_at) VALUES (?, 'alice', ?)`, id, at).Error)
+	}
+
+	err := s.CheckTournament("alice", now)
+	var exceeded *ExceededError
+	require.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, DailyTournaments, exceeded.Quota)
+	assert.Equal(t, 2, exceeded.Used)
+	require.NotNil(t, exceeded.RetryAt)
+	assert.True(t, now.Add(4*time.Hour).Equal(*exceeded.RetryAt), "frees up when the earlier one is a day old, got %v", exceeded.RetryAt)
+
+	assert.NoError(t, s.CheckTournament("alice", now.Add(5*time.Hour)))
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	env := map[string]string{
+		"QUOTA_PLAYER_MAX_ACTIVE_TABLES":    "0",
+		"QUOTA_ADMIN_MAX_DAILY_TOURNAMENTS": "50",
+	}
+	config := ConfigFromEnv(func(key, fallback string) string {
+		if value, ok := env[key]; ok {
+			return value
+		}
+		return fallback
+	})
+
+	assert.Equal(t, Limits{MaxActiveTables: 0, MaxDailyTournaments: 5}, config[models.RolePlayer])
+	assert.Equal(t, Limits{MaxDailyTournaments: 50}, config[models.RoleAdmin])
+	assert.Equal(t, 3, DefaultConfig[models.RolePlayer].MaxActiveTables, "DefaultConfig is left alone")
+}
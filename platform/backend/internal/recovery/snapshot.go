@@ -0,0 +1,326 @@
+package recovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pokerModels "poker-engine/models"
+	backendModels "poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SnapshotVersion is the format version of platform snapshots
+const SnapshotVersion = 1
+
+// Snapshot errors
+var (
+	ErrSnapshotVersion      = errors.New("unsupported snapshot version")
+	ErrCheckpointNotReached = errors.New("database has not replicated up to the snapshot checkpoint")
+)
+
+// Checkpoint marks how far the database had got when a snapshot was taken.
+// A standby can only import the snapshot once its replica has caught up to it.
+type Checkpoint struct {
+	TakenAt               time.Time  `json:"taken_at"`
+	LastHandID            int64      `json:"last_hand_id"`
+	LastChipTransactionID string     `json:"last_chip_transaction_id,omitempty"`
+	LastChipTransactionAt *time.Time `json:"last_chip_transaction_at,omitempty"`
+	ChipTransactions      int64      `json:"chip_transactions"`
+	UserChips             int64      `json:"user_chips"` // Chips held by all users, for a quick consistency check
+}
+
+// SeatSnapshot is a player's seat and stack. The stack is taken as of the start
+// of any hand in flight, since in-flight hands are void on import.
+type SeatSnapshot struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Seat     int    `json:"seat"`
+	Chips    int    `json:"chips"`
+	Status   string `json:"status"`
+}
+
+// InFlightHand is a hand that was being played when the snapshot was taken
+type InFlightHand struct {
+	HandNumber   int    `json:"hand_number"`
+	BettingRound string `json:"betting_round"`
+	Pot          int    `json:"pot"` // Chips returned to their owners on import
+}
+
+// TableSnapshot is the live state of one engine table
+type TableSnapshot struct {
+	TableID        string         `json:"table_id"`
+	GameType       string         `json:"game_type"`
+	Status         string         `json:"status"`
+	SmallBlind     int            `json:"small_blind"`
+	BigBlind       int            `json:"big_blind"`
+	HandNumber     int            `json:"hand_number"`
+	DealerPosition int            `json:"dealer_position"`
+	Seats          []SeatSnapshot `json:"seats"`
+	InFlightHand   *InFlightHand  `json:"in_flight_hand,omitempty"`
+}
+
+// TournamentSnapshot is the clock of a running tournament
+type TournamentSnapshot struct {
+	ID               string     `json:"id"`
+	Status           string     `json:"status"`
+	CurrentLevel     int        `json:"current_level"`
+	LevelStartedAt   *time.Time `json:"level_started_at,omitempty"`
+	PausedAt         *time.Time `json:"paused_at,omitempty"`
+	PlayersRemaining int        `json:"players_remaining"`
+}
+
+// PendingWrite is a history row still queued in Redis when the snapshot was taken
+type PendingWrite struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Snapshot is a consistent export of all live platform state, imported on a
+// standby instance for failover
+type Snapshot struct {
+	Version       int                  `json:"version"`
+	Checkpoint    Checkpoint           `json:"checkpoint"`
+	Tables        []TableSnapshot      `json:"tables"`
+	Tournaments   []TournamentSnapshot `json:"tournaments"`
+	PendingWrites []PendingWrite       `json:"pending_writes"`
+}
+
+// ImportResult summarises what a snapshot import changed
+type ImportResult struct {
+	Tables         int `json:"tables"`
+	Seats          int `json:"seats"`
+	Tournaments    int `json:"tournaments"`
+	VoidedHands    int `json:"voided_hands"`
+	ReplayedWrites int `json:"replayed_writes"`
+}
+
+// NewTableSnapshot captures an engine table's state
+func NewTableSnapshot(state *pokerModels.Table) TableSnapshot {
+	snapshot := TableSnapshot{
+		TableID:    state.TableID,
+		GameType:   string(state.GameType),
+		Status:     string(state.Status),
+		SmallBlind: state.Config.SmallBlind,
+		BigBlind:   state.Config.BigBlind,
+		Seats:      []SeatSnapshot{},
+	}
+
+	// A table is only paused in the middle of a hand, which is just as void
+	inFlight := (state.Status == pokerModels.StatusPlaying || state.Status == pokerModels.StatusPaused) &&
+		state.CurrentHand != nil
+	if state.CurrentHand != nil {
+		snapshot.HandNumber = state.CurrentHand.HandNumber
+		snapshot.DealerPosition = state.CurrentHand.DealerPosition
+	}
+	if inFlight {
+		pot := state.CurrentHand.Pot.Main
+		for _, side := range state.CurrentHand.Pot.Side {
+			pot += side.Amount
+		}
+		snapshot.InFlightHand = &InFlightHand{
+			HandNumber:   state.CurrentHand.HandNumber,
+			BettingRound: string(state.CurrentHand.BettingRound),
+			Pot:          pot,
+		}
+	}
+
+	for _, player := range state.Players {
+		if player == nil {
+			continue
+		}
+		chips := player.Chips
+		if inFlight {
+			chips += player.TotalInvestedThisHand
+		}
+		snapshot.Seats = append(snapshot.Seats, SeatSnapshot{
+			UserID:   player.PlayerID,
+			Username: player.PlayerName,
+			Seat:     player.SeatNumber,
+			Chips:    chips,
+			Status:   string(player.Status),
+		})
+	}
+	return snapshot
+}
+
+// TakeCheckpoint records the current position of the database
+func (tr *TableRecovery) TakeCheckpoint() (Checkpoint, error) {
+	checkpoint := Checkpoint{TakenAt: time.Now()}
+
+	if err := tr.db.Model(&backendModels.Hand{}).Select("COALESCE(MAX(id), 0)").Scan(&checkpoint.LastHandID).Error; err != nil {
+		return checkpoint, fmt.Errorf("failed to read last hand: %w", err)
+	}
+
+	var last struct {
+		ID        string
+		CreatedAt time.Time
+	}
+	result := tr.db.Table("chip_transactions").Select("id", "created_at").Order("created_at DESC, id DESC").Limit(1).Scan(&last)
+	if result.Error != nil {
+		return checkpoint, fmt.Errorf("failed to read last chip transaction: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		checkpoint.LastChipTransactionID = last.ID
+		checkpoint.LastChipTransactionAt = &last.CreatedAt
+	}
+
+	if err := tr.db.Table("chip_transactions").Count(&checkpoint.ChipTransactions).Error; err != nil {
+		return checkpoint, fmt.Errorf("failed to count chip transactions: %w", err)
+	}
+	if err := tr.db.Model(&backendModels.User{}).Select("COALESCE(SUM(chips), 0)").Scan(&checkpoint.UserChips).Error; err != nil {
+		return checkpoint, fmt.Errorf("failed to sum user chips: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// TournamentSnapshots captures the clocks of tournaments that are running
+func (tr *TableRecovery) TournamentSnapshots() ([]TournamentSnapshot, error) {
+	var tournaments []backendModels.Tournament
+	if err := tr.db.Where("status IN ?", []string{"starting", "in_progress", "paused"}).Find(&tournaments).Error; err != nil {
+		return nil, fmt.Errorf("failed to query running tournaments: %w", err)
+	}
+
+	snapshots := make([]TournamentSnapshot, 0, len(tournaments))
+	for _, t := range tournaments {
+		var remaining int64
+		if err := tr.db.Model(&backendModels.TournamentPlayer{}).
+			Where("tournament_id = ? AND eliminated_at IS NULL", t.ID).
+			Count(&remaining).Error; err != nil {
+			return nil, fmt.Errorf("failed to count players in tournament %s: %w", t.ID, err)
+		}
+		snapshots = append(snapshots, TournamentSnapshot{
+			ID:               t.ID,
+			Status:           t.Status,
+			CurrentLevel:     t.CurrentLevel,
+			LevelStartedAt:   t.LevelStartedAt,
+			PausedAt:         t.PausedAt,
+			PlayersRemaining: int(remaining),
+		})
+	}
+	return snapshots, nil
+}
+
+// VerifyCheckpoint checks that the database holds everything written before
+// the checkpoint was taken
+func (tr *TableRecovery) VerifyCheckpoint(checkpoint Checkpoint) error {
+	var lastHandID int64
+	if err := tr.db.Model(&backendModels.Hand{}).Select("COALESCE(MAX(id), 0)").Scan(&lastHandID).Error; err != nil {
+		return fmt.Errorf("failed to read last hand: %w", err)
+	}
+	if lastHandID < checkpoint.LastHandID {
+		return fmt.Errorf("%w: last hand %d, snapshot has %d", ErrCheckpointNotReached, lastHandID, checkpoint.LastHandID)
+	}
+
+	if checkpoint.LastChipTransactionID != "" {
+		var count int64
+		if err := tr.db.Table("chip_transactions").Where("id = ?", checkpoint.LastChipTransactionID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to find chip transaction: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("%w: chip transaction %s is missing", ErrCheckpointNotReached, checkpoint.LastChipTransactionID)
+		}
+	}
+	return nil
+}
+
+// ApplySnapshot writes the live state in a snapshot to the database, so that
+// table recovery brings the tables back with the snapshot's stacks. Hands in
+// flight are void: every player gets back the chips they put in.
+func (tr *TableRecovery) ApplySnapshot(snapshot *Snapshot) (ImportResult, error) {
+	var result ImportResult
+	if snapshot.Version != SnapshotVersion {
+		return result, fmt.Errorf("%w: %d", ErrSnapshotVersion, snapshot.Version)
+	}
+
+	err := tr.db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range snapshot.Tables {
+			status := "playing"
+			switch pokerModels.TableStatus(table.Status) {
+			case pokerModels.StatusWaiting:
+				status = "waiting"
+			case pokerModels.StatusPaused:
+				status = "paused"
+			case pokerModels.StatusCompleted:
+				status = "completed"
+			}
+			if err := tx.Model(&backendModels.Table{}).Where("id = ?", table.TableID).Updates(map[string]interface{}{
+				"status":           status,
+				"last_hand_number": table.HandNumber,
+				"dealer_position":  table.DealerPosition,
+			}).Error; err != nil {
+				return fmt.Errorf("failed to restore table %s: %w", table.TableID, err)
+			}
+
+			for _, seat := range table.Seats {
+				if err := tx.Model(&backendModels.TableSeat{}).
+					Where("table_id = ? AND user_id = ? AND left_at IS NULL", table.TableID, seat.UserID).
+					Update("chips", seat.Chips).Error; err != nil {
+					return fmt.Errorf("failed to restore seat of %s at table %s: %w", seat.UserID, table.TableID, err)
+				}
+				result.Seats++
+			}
+
+			if table.InFlightHand != nil {
+				result.VoidedHands++
+			}
+			result.Tables++
+		}
+
+		for _, t := range snapshot.Tournaments {
+			if err := tx.Model(&backendModels.Tournament{}).Where("id = ?", t.ID).Updates(map[string]interface{}{
+				"status":           t.Status,
+				"current_level":    t.CurrentLevel,
+				"level_started_at": t.LevelStartedAt,
+				"paused_at":        t.PausedAt,
+			}).Error; err != nil {
+				return fmt.Errorf("failed to restore tournament %s: %w", t.ID, err)
+			}
+			result.Tournaments++
+		}
+		return nil
+	})
+	if err != nil {
+		return ImportResult{}, err
+	}
+	return result, nil
+}
+
+// SaveTables writes the stacks and hand position of live tables to the database
+// ahead of a shutdown, so recovery picks each table up from where it stopped.
+// Table status is left alone; a paused row would not be recovered. Each table is
+// saved in its own transaction so one failure doesn't lose the rest, and the
+// IDs of the tables that failed are returned with the first error.
+func (tr *TableRecovery) SaveTables(tables []TableSnapshot) ([]string, error) {
+	var failed []string
+	var firstErr error
+	for _, table := range tables {
+		err := tr.db.Transaction(func(tx *gorm.DB) error {
+			if table.HandNumber > 0 {
+				if err := tx.Model(&backendModels.Table{}).Where("id = ?", table.TableID).Updates(map[string]interface{}{
+					"last_hand_number": table.HandNumber,
+					"dealer_position":  table.DealerPosition,
+				}).Error; err != nil {
+					return fmt.Errorf("failed to save hand position of table %s: %w", table.TableID, err)
+				}
+			}
+			for _, seat := range table.Seats {
+				if err := tx.Model(&backendModels.TableSeat{}).
+					Where("table_id = ? AND user_id = ? AND left_at IS NULL", table.TableID, seat.UserID).
+					Update("chips", seat.Chips).Error; err != nil {
+					return fmt.Errorf("failed to save seat of %s at table %s: %w", seat.UserID, table.TableID, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			failed = append(failed, table.TableID)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return failed, firstErr
+}
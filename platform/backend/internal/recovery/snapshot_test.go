@@ -0,0 +1,197 @@
+package recovery
+
+import (
+	"errors"
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	pokerModels "poker-engine/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupSnapshotDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+
+	testutil.Schema(t, db, &models.Hand{}, &models.TableHandState{}, &models.User{}, &currency.Transaction{}, &models.Table{}, &models.TableSeat{}, &models.Tournament{})
+	return db
+}
+
+func TestNewTableSnapshot_InFlightHand(t *testing.T) {
+	state := &pokerModels.Table{
+		TableID:  "table-1",
+		GameType: pokerModels.GameTypeCash,
+		Status:   pokerModels.StatusPlaying,
+		Config:   pokerModels.TableConfig{SmallBlind: 5, BigBlind: 10},
+		CurrentHand: &pokerModels.CurrentHand{
+			HandNumber:   42,
+			BettingRound: pokerModels.RoundFlop,
+			Pot:          pokerModels.Pot{Main: 60, Side: []pokerModels.SidePot{{Amount: 20}}},
+		},
+		Players: []*pokerModels.Player{
+			{PlayerID: "a", SeatNumber: 1, Chips: 940, TotalInvestedThisHand: 60, Status: pokerModels.StatusActive},
+			nil,
+			{PlayerID: "b", SeatNumber: 3, Chips: 0, TotalInvestedThisHand: 20, Status: pokerModels.StatusAllIn},
+		},
+	}
+
+	snapshot := NewTableSnapshot(state)
+	if snapshot.InFlightHand == nil || snapshot.InFlightHand.Pot != 80 || snapshot.InFlightHand.HandNumber != 42 {
+		t.Fatalf("Expected in-flight hand 42 with a pot of 80, got %+v", snapshot.InFlightHand)
+	}
+	if len(snapshot.Seats) != 2 {
+		t.Fatalf("Expected 2 seats, got %d", len(snapshot.Seats))
+	}
+	// Stacks are rolled back to the start of the hand
+	if snapshot.Seats[0].Chips != 1000 || snapshot.Seats[1].Chips != 20 {
+		t.Errorf("Expected stacks of 1000 and 20, got %d and %d", snapshot.Seats[0].Chips, snapshot.Seats[1].Chips)
+	}
+
+	state.Status = pokerModels.StatusHandComplete
+	snapshot = NewTableSnapshot(state)
+	if snapshot.InFlightHand != nil || snapshot.Seats[0].Chips != 940 {
+		t.Errorf("Expected no in-flight hand and settled stacks between hands, got %+v", snapshot)
+	}
+}
+
+func TestVerifyCheckpoint(t *testing.T) {
+	db := setupSnapshotDB(t)
+	tr := NewTableRecovery(db)
+
+	db.Exec(`INSERT INTO hands (id, table_id) VALUES (1, 'table-1'), (2, 'table-1')`)
+	db.Exec(`INSERT INTO users (id, chips) VALUES ('a', 900), ('b', 1100)`)
+	db.Exec(`INSERT INTO chip_transactions (id, user_id, created_at) VALUES ('tx-1', 'a', CURRENT_TIMESTAMP)`)
+
+	checkpoint, err := tr.TakeCheckpoint()
+	if err != nil {
+		t.Fatalf("TakeCheckpoint failed: %v", err)
+	}
+	if checkpoint.LastHandID != 2 || checkpoint.LastChipTransactionID != "tx-1" || checkpoint.ChipTransactions != 1 || checkpoint.UserChips != 2000 {
+		t.Fatalf("Unexpected checkpoint: %+v", checkpoint)
+	}
+	if err := tr.VerifyCheckpoint(checkpoint); err != nil {
+		t.Errorf("Expected checkpoint to verify against its own database: %v", err)
+	}
+
+	behind := setupSnapshotDB(t)
+	behind.Exec(`INSERT INTO hands (id, table_id) VALUES (1, 'table-1')`)
+	if err := NewTableRecovery(behind).VerifyCheckpoint(checkpoint); !errors.Is(err, ErrCheckpointNotReached) {
+		t.Errorf("Expected ErrCheckpointNotReached for a lagging replica, got %v", err)
+	}
+
+	behind.Exec(`INSERT INTO hands (id, table_id) VALUES (2, 'table-1')`)
+	if err := NewTableRecovery(behind).VerifyCheckpoint(checkpoint); !errors.Is(err, ErrCheckpointNotReached) {
+		t.Errorf("Expected ErrCheckpointNotReached for a missing chip transaction, got %v", err)
+	}
+}
+
+func TestApplySnapshot(t *testing.T) {
+	db := setupSnapshotDB(t)
+	tr := NewTableRecovery(db)
+
+	db.Exec(`INSERT INTO tables (id, status) VALUES ('table-1', 'playing')`)
+	db.Exec(`INSERT INTO table_seats (table_id, user_id, chips) VALUES ('table-1', 'a', 500), ('table-1', 'b', 500)`)
+	db.Exec(`INSERT INTO tournaments (id, status, current_level) VALUES ('t-1', 'in_progress', 3)`)
+
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Tables: []TableSnapshot{{
+			TableID:        "table-1",
+			Status:         string(pokerModels.StatusPlaying),
+			HandNumber:     9,
+			DealerPosition: 1,
+			Seats:          []SeatSnapshot{{UserID: "a", Chips: 700}, {UserID: "b", Chips: 300}},
+			InFlightHand:   &InFlightHand{HandNumber: 9},
+		}},
+		Tournaments: []TournamentSnapshot{{ID: "t-1", Status: "in_progress", CurrentLevel: 5}},
+	}
+
+	result, err := tr.ApplySnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("ApplySnapshot failed: %v", err)
+	}
+	if result.Tables != 1 || result.Seats != 2 || result.Tournaments != 1 || result.VoidedHands != 1 {
+		t.Errorf("Unexpected import result: %+v", result)
+	}
+
+	var chips int
+	db.Raw(`SELECT chips FROM table_seats WHERE user_id = 'a'`).Scan(&chips)
+	if chips != 700 {
+		t.Errorf("Expected seat a to have 700 chips, got %d", chips)
+	}
+	var position struct {
+		LastHandNumber int
+		DealerPosition int
+	}
+	db.Raw(`SELECT last_hand_number, dealer_position FROM tables WHERE id = 'table-1'`).Scan(&position)
+	if position.LastHandNumber != 9 || position.DealerPosition != 1 {
+		t.Errorf("Expected the table to continue from hand 9 with the button on seat 1, got %+v", position)
+	}
+	var level int
+	db.Raw(`SELECT current_level FROM tournaments WHERE id = 't-1'`).Scan(&level)
+	if level != 5 {
+		t.Errorf("Expected tournament at level 5, got %d", level)
+	}
+
+	snapshot.Version = SnapshotVersion + 1
+	if _, err := tr.ApplySnapshot(snapshot); !errors.Is(err, ErrSnapshotVersion) {
+		t.Errorf("Expected ErrSnapshotVersion, got %v", err)
+	}
+}
+
+func TestSaveTables(t *testing.T) {
+	db := setupSnapshotDB(t)
+	tr := NewTableRecovery(db)
+
+	db.Exec(`INSERT INTO tables (id, status, last_hand_number, dealer_position) VALUES ('table-1', 'playing', 8, 0), ('table-2', 'waiting', 0, NULL)`)
+	db.Exec(`INSERT INTO table_seats (table_id, user_id, chips) VALUES ('table-1', 'a', 500), ('table-1', 'b', 500), ('table-2', 'c', 300)`)
+
+	paused := NewTableSnapshot(&pokerModels.Table{
+		TableID:     "table-1",
+		Status:      pokerModels.StatusPaused,
+		CurrentHand: &pokerModels.CurrentHand{HandNumber: 9, DealerPosition: 1},
+		Players: []*pokerModels.Player{
+			{PlayerID: "a", Chips: 440, TotalInvestedThisHand: 60},
+			{PlayerID: "b", Chips: 480, TotalInvestedThisHand: 20},
+		},
+	})
+	if paused.InFlightHand == nil {
+		t.Fatal("Expected a hand paused part way through to be in flight")
+	}
+	idle := NewTableSnapshot(&pokerModels.Table{
+		TableID: "table-2",
+		Status:  pokerModels.StatusWaiting,
+		Players: []*pokerModels.Player{{PlayerID: "c", Chips: 300}},
+	})
+
+	failed, err := tr.SaveTables([]TableSnapshot{paused, idle})
+	if err != nil || len(failed) != 0 {
+		t.Fatalf("SaveTables failed for %v: %v", failed, err)
+	}
+
+	var chips []int
+	db.Raw(`SELECT chips FROM table_seats ORDER BY user_id`).Scan(&chips)
+	if len(chips) != 3 || chips[0] != 500 || chips[1] != 500 || chips[2] != 300 {
+		t.Errorf("Expected stacks as of the start of the hand, got %v", chips)
+	}
+	var row struct {
+		Status         string
+		LastHandNumber int
+		DealerPosition *int
+	}
+	db.Raw(`SELECT status, last_hand_number, dealer_position FROM tables WHERE id = 'table-1'`).Scan(&row)
+	if row.Status != "playing" || row.LastHandNumber != 9 || row.DealerPosition == nil || *row.DealerPosition != 1 {
+		t.Errorf("Expected table-1 to stay playing and continue from hand 9 with the button on seat 1, got %+v", row)
+	}
+	db.Raw(`SELECT status, last_hand_number, dealer_position FROM tables WHERE id = 'table-2'`).Scan(&row)
+	if row.LastHandNumber != 0 || row.DealerPosition != nil {
+		t.Errorf("Expected table-2 to keep no hand position, got %+v", row)
+	}
+}
@@ -16,7 +16,31 @@ import (
 
 // TableRecovery handles recovering active tables on server restart
 type TableRecovery struct {
-	db *gorm.DB
+	db      *gorm.DB
+	claimer TableClaimer
+}
+
+// TableClaimer shares the tables of one database between backend instances,
+// so an instance only recovers tables no instance runs
+type TableClaimer interface {
+	AdoptTable(tableID string) bool // Takes a table nobody runs for this instance
+	TableOwned(tableID string) bool // Some instance, this one included, runs the table
+}
+
+// ShareWith makes recovery leave alone the tables other instances run
+func (tr *TableRecovery) ShareWith(claimer TableClaimer) {
+	tr.claimer = claimer
+}
+
+// ownedElsewhere reports whether a table is run by an instance, so recovery
+// must not touch it
+func (tr *TableRecovery) ownedElsewhere(tableID string) bool {
+	return tr.claimer != nil && tr.claimer.TableOwned(tableID)
+}
+
+// adopt takes a table for this instance to recover
+func (tr *TableRecovery) adopt(tableID string) bool {
+	return tr.claimer == nil || tr.claimer.AdoptTable(tableID)
 }
 
 // NewTableRecovery creates a new table recovery instance
@@ -46,6 +70,9 @@ func (tr *TableRecovery) RecoverActiveTables(createTableFn func(tableID, gameTyp
 
 	// Recover each table
 	for _, table := range activeTables {
+		if tr.ownedElsewhere(table.ID) {
+			continue
+		}
 		log.Printf("Recovering table %s (status: %s, type: %s)", table.ID, table.Status, table.GameType)
 
 		// Get all active seats for this table
@@ -73,6 +100,11 @@ func (tr *TableRecovery) RecoverActiveTables(createTableFn func(tableID, gameTyp
 			maxBuyIn = *table.MaxBuyIn
 		}
 
+		if !tr.adopt(table.ID) {
+			log.Printf("⚠️  Table %s was taken by another instance, skipping", table.ID)
+			continue
+		}
+
 		// Create engine table
 		engineTable := createTableFn(
 			table.ID,
@@ -90,6 +122,21 @@ func (tr *TableRecovery) RecoverActiveTables(createTableFn func(tableID, gameTyp
 			continue
 		}
 
+		if err := engineTable.SetDisplay(table.CurrencySymbol, table.ChipScale); err != nil {
+			log.Printf("⚠️  Failed to restore display settings for table %s: %v", table.ID, err)
+		}
+		if table.AllowStraddle {
+			if err := engineTable.SetStraddle(true); err != nil {
+				log.Printf("⚠️  Failed to restore the straddle option for table %s: %v", table.ID, err)
+			}
+		}
+		if err := engineTable.SetShowdownPolicy(table.ShowdownPolicy); err != nil {
+			log.Printf("⚠️  Failed to restore the showdown policy for table %s: %v", table.ID, err)
+		}
+		if err := engineTable.SetBettingMode(table.BettingMode); err != nil {
+			log.Printf("⚠️  Failed to restore the betting mode for table %s: %v", table.ID, err)
+		}
+
 		// Add players to engine table
 		playersAdded := 0
 		for _, seat := range seats {
@@ -106,11 +153,16 @@ func (tr *TableRecovery) RecoverActiveTables(createTableFn func(tableID, gameTyp
 				log.Printf("❌ Failed to add player %s to table %s: %v", user.Username, table.ID, err)
 				continue
 			}
+			engineTable.SetPlayerLevel(user.ID, user.Level)
+			tr.restoreAvatar(engineTable, user.ID)
 
 			playersAdded++
 			log.Printf("  ✓ Added player %s to seat %d with %d chips", user.Username, seat.SeatNumber, seat.Chips)
 		}
 
+		tr.restoreHandContinuity(engineTable, table.ID)
+		tr.restoreHandState(engineTable, table.ID)
+
 		if playersAdded < 2 {
 			log.Printf("⚠️  Table %s only has %d player(s), not enough to start game", table.ID, playersAdded)
 		}
@@ -158,6 +210,10 @@ func (tr *TableRecovery) RecoverTournamentTables(createTableFn func(tableID, gam
 
 		// Create engine tables
 		for _, modelTable := range modelTables {
+			if tr.ownedElsewhere(modelTable.TableID) || !tr.adopt(modelTable.TableID) {
+				continue
+			}
+
 			// Tournament tables use the same create function
 			engineTable := createTableFn(
 				modelTable.TableID,
@@ -184,10 +240,18 @@ func (tr *TableRecovery) RecoverTournamentTables(createTableFn func(tableID, gam
 						log.Printf("❌ Failed to add player %s to tournament table %s: %v", player.PlayerName, modelTable.TableID, err)
 						continue
 					}
+					var user backendModels.User
+					if err := tr.db.Select("id", "level").Where("id = ?", player.PlayerID).First(&user).Error; err == nil {
+						engineTable.SetPlayerLevel(user.ID, user.Level)
+					}
+					tr.restoreAvatar(engineTable, player.PlayerID)
 					playersAdded++
 				}
 			}
 
+			tr.restoreHandContinuity(engineTable, modelTable.TableID)
+			tr.restoreHandState(engineTable, modelTable.TableID)
+
 			recoveredTables[modelTable.TableID] = engineTable
 			log.Printf("✓ Recovered tournament table %s with %d players", modelTable.TableID, playersAdded)
 		}
@@ -199,6 +263,99 @@ func (tr *TableRecovery) RecoverTournamentTables(createTableFn func(tableID, gam
 	return recoveredTables, nil
 }
 
+// restoreHandContinuity seeds a recreated engine table with the last hand
+// number and button of the table, so hand numbers don't restart at 1 and the
+// button moves on from where it was. Tables from before the position was kept
+// on the table row fall back to their last recorded hand.
+func (tr *TableRecovery) restoreHandContinuity(engineTable *engine.Table, tableID string) {
+	var table backendModels.Table
+	if err := tr.db.Select("id", "last_hand_number", "dealer_position").Where("id = ?", tableID).First(&table).Error; err != nil {
+		log.Printf("⚠️  Failed to load hand position for table %s: %v", tableID, err)
+		return
+	}
+
+	lastHandNumber := table.LastHandNumber
+	dealerPosition := -1
+	if table.DealerPosition != nil {
+		dealerPosition = *table.DealerPosition
+	}
+
+	var lastHand backendModels.Hand
+	result := tr.db.Where("table_id = ?", tableID).Order("hand_number DESC").Limit(1).Find(&lastHand)
+	if result.Error == nil && result.RowsAffected > 0 && lastHand.HandNumber > lastHandNumber {
+		lastHandNumber = lastHand.HandNumber
+		dealerPosition = lastHand.DealerPosition
+	}
+
+	if lastHandNumber == 0 {
+		return
+	}
+	if err := engineTable.RestoreHandPosition(lastHandNumber, dealerPosition); err != nil {
+		log.Printf("⚠️  Failed to restore hand position on table %s: %v", tableID, err)
+		return
+	}
+	log.Printf("  ✓ Continuing table %s from hand #%d (button on seat %d)", tableID, lastHandNumber, dealerPosition)
+}
+
+// cancelHand marks a hand that can't be carried on as cancelled
+func (tr *TableRecovery) cancelHand(handID int64) {
+	now := time.Now()
+	tr.db.Model(&backendModels.Hand{}).Where("id = ?", handID).Updates(map[string]interface{}{
+		"completed_at":    &now,
+		"community_cards": "[]",
+		"winners":         json.RawMessage(`[{"note":"hand_cancelled_on_restart"}]`),
+	})
+}
+
+// restoreHandState carries on the hand a recreated table was in the middle
+// of, from the state saved after its last action. The state is only used if
+// every player in it has been seated again in the same seat; otherwise the
+// hand is cancelled and the table starts a new one with the stacks from
+// before it. It reports whether a hand was restored.
+func (tr *TableRecovery) restoreHandState(engineTable *engine.Table, tableID string) bool {
+	var saved backendModels.TableHandState
+	result := tr.db.Where("table_id = ?", tableID).Limit(1).Find(&saved)
+	if result.Error != nil {
+		log.Printf("⚠️  Failed to load hand state for table %s: %v", tableID, result.Error)
+		return false
+	}
+	if result.RowsAffected == 0 {
+		return false
+	}
+
+	err := func() error {
+		var state engine.TableState
+		if err := json.Unmarshal([]byte(saved.State), &state); err != nil {
+			return fmt.Errorf("failed to decode: %w", err)
+		}
+		seated := engineTable.GetState().Players
+		if len(seated) != len(state.Players) {
+			return fmt.Errorf("table has %d seats, state has %d", len(seated), len(state.Players))
+		}
+		for i, seat := range state.Players {
+			if seat == nil {
+				continue
+			}
+			if seated[i] == nil || seated[i].PlayerID != seat.PlayerID {
+				return fmt.Errorf("player %s is no longer in seat %d", seat.PlayerID, i)
+			}
+		}
+		return engineTable.Restore(&state)
+	}()
+	if err == nil {
+		log.Printf("  ✓ Carrying on hand #%d on table %s", saved.HandNumber, tableID)
+		return true
+	}
+
+	log.Printf("⚠️  Hand #%d on table %s can't be carried on and is cancelled: %v", saved.HandNumber, tableID, err)
+	var hand backendModels.Hand
+	if res := tr.db.Where("table_id = ? AND hand_number = ? AND completed_at IS NULL", tableID, saved.HandNumber).Limit(1).Find(&hand); res.Error == nil && res.RowsAffected > 0 {
+		tr.cancelHand(hand.ID)
+	}
+	tr.db.Where("table_id = ?", tableID).Delete(&backendModels.TableHandState{})
+	return false
+}
+
 // CheckAndStartGames checks recovered tables and starts games if they have enough players
 func (tr *TableRecovery) CheckAndStartGames(tables map[string]*engine.Table, startDelay time.Duration) {
 	log.Printf("🎮 Checking %d recovered tables to start games...", len(tables))
@@ -251,9 +408,12 @@ func (tr *TableRecovery) CheckAndStartGames(tables map[string]*engine.Table, sta
 func (tr *TableRecovery) CleanupOrphanedData() error {
 	log.Println("🧹 Cleaning up orphaned data from previous sessions...")
 
-	// Find hands that were started but never completed
+	// Find hands that were started but never completed, other than those
+	// whose state was saved to carry them on
 	var orphanedHands []backendModels.Hand
-	err := tr.db.Where("completed_at IS NULL").Find(&orphanedHands).Error
+	err := tr.db.Where("completed_at IS NULL").
+		Where("NOT EXISTS (SELECT 1 FROM table_hand_states s WHERE s.table_id = hands.table_id AND s.hand_number = hands.hand_number)").
+		Find(&orphanedHands).Error
 	if err != nil {
 		return fmt.Errorf("failed to find orphaned hands: %w", err)
 	}
@@ -261,17 +421,18 @@ func (tr *TableRecovery) CleanupOrphanedData() error {
 	if len(orphanedHands) > 0 {
 		log.Printf("Found %d orphaned hands (in-progress when server crashed)", len(orphanedHands))
 
-		// Mark them as cancelled/incomplete
+		// Mark them as cancelled/incomplete, leaving those of tables another
+		// instance runs
+		cancelled := 0
 		for _, hand := range orphanedHands {
-			now := time.Now()
-			tr.db.Model(&backendModels.Hand{}).Where("id = ?", hand.ID).Updates(map[string]interface{}{
-				"completed_at":    &now,
-				"community_cards": "[]",
-				"winners":         json.RawMessage(`[{"note":"hand_cancelled_on_restart"}]`),
-			})
+			if tr.ownedElsewhere(hand.TableID) {
+				continue
+			}
+			tr.cancelHand(hand.ID)
+			cancelled++
 		}
 
-		log.Printf("✓ Marked %d orphaned hands as cancelled", len(orphanedHands))
+		log.Printf("✓ Marked %d orphaned hands as cancelled", cancelled)
 	}
 
 	log.Println("✓ Cleanup complete")
@@ -304,3 +465,15 @@ func (tr *TableRecovery) GetRecoveryStats() (map[string]interface{}, error) {
 
 	return stats, nil
 }
+
+// restoreAvatar shows a recovered player's avatar at their table
+func (tr *TableRecovery) restoreAvatar(engineTable *engine.Table, userID string) {
+	var avatar backendModels.Avatar
+	if err := tr.db.Select("url").Where("user_id = ?", userID).Limit(1).Find(&avatar).Error; err != nil {
+		log.Printf("⚠️  Failed to load the avatar of %s: %v", userID, err)
+		return
+	}
+	if avatar.URL != "" {
+		engineTable.SetPlayerAvatar(userID, avatar.URL)
+	}
+}
@@ -0,0 +1,117 @@
+package recovery
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+func newRecoveredEngineTable(tableID string) *engine.Table {
+	table := engine.NewTable(tableID, pokerModels.GameTypeCash, pokerModels.TableConfig{SmallBlind: 5, BigBlind: 10, MaxPlayers: 6}, nil, nil)
+	table.AddPlayer("a", "Alice", 0, 1000)
+	table.AddPlayer("b", "Bob", 2, 1000)
+	table.AddPlayer("c", "Carol", 4, 1000)
+	return table
+}
+
+func TestRestoreHandContinuity(t *testing.T) {
+	db := setupSnapshotDB(t)
+	tr := NewTableRecovery(db)
+
+	db.Exec(`INSERT INTO tables (id, status, last_hand_number, dealer_position) VALUES ('table-1', 'playing', 17, 2)`)
+	db.Exec(`INSERT INTO hands (table_id, hand_number, dealer_position) VALUES ('table-1', 16, 0), ('table-1', 17, 2)`)
+	// A table from before the position was kept on the table row
+	db.Exec(`INSERT INTO tables (id, status) VALUES ('table-2', 'playing')`)
+	db.Exec(`INSERT INTO hands (table_id, hand_number, dealer_position) VALUES ('table-2', 5, 4)`)
+	db.Exec(`INSERT INTO tables (id, status) VALUES ('table-3', 'waiting')`)
+
+	for _, tc := range []struct {
+		tableID        string
+		handNumber     int
+		dealerPosition int
+	}{
+		{"table-1", 17, 2},
+		{"table-2", 5, 4},
+		{"table-3", 0, -1},
+	} {
+		table := newRecoveredEngineTable(tc.tableID)
+		tr.restoreHandContinuity(table, tc.tableID)
+
+		hand := table.GetState().CurrentHand
+		if hand.HandNumber != tc.handNumber || hand.DealerPosition != tc.dealerPosition {
+			t.Errorf("%s: expected hand %d with the button on seat %d, got hand %d button %d",
+				tc.tableID, tc.handNumber, tc.dealerPosition, hand.HandNumber, hand.DealerPosition)
+		}
+	}
+
+	// The next hand carries on numbering and moves the button to the next player
+	table := newRecoveredEngineTable("table-1")
+	tr.restoreHandContinuity(table, "table-1")
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	if hand := table.GetState().CurrentHand; hand.HandNumber != 18 || hand.DealerPosition != 4 {
+		t.Errorf("Expected hand 18 with the button on seat 4, got hand %d button %d", hand.HandNumber, hand.DealerPosition)
+	}
+}
+
+func TestRestoreHandState(t *testing.T) {
+	db := setupSnapshotDB(t)
+	tr := NewTableRecovery(db)
+
+	original := newRecoveredEngineTable("table-1")
+	if err := original.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	saved := original.State()
+	data, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatalf("Failed to encode state: %v", err)
+	}
+	for _, tableID := range []string{"table-1", "table-2"} {
+		db.Exec(`INSERT INTO hands (table_id, hand_number) VALUES (?, 1)`, tableID)
+		db.Exec(`INSERT INTO table_hand_states (table_id, hand_number, state) VALUES (?, 1, ?)`,
+			tableID, strings.Replace(string(data), `"tableId":"table-1"`, `"tableId":"`+tableID+`"`, 1))
+	}
+	// A hand with no saved state is cancelled as before
+	db.Exec(`INSERT INTO hands (table_id, hand_number) VALUES ('table-3', 4)`)
+
+	if err := tr.CleanupOrphanedData(); err != nil {
+		t.Fatalf("CleanupOrphanedData failed: %v", err)
+	}
+	var open int64
+	db.Table("hands").Where("completed_at IS NULL").Count(&open)
+	if open != 2 {
+		t.Fatalf("Expected the two hands with saved state to stay open, got %d", open)
+	}
+
+	restored := newRecoveredEngineTable("table-1")
+	if !tr.restoreHandState(restored, "table-1") {
+		t.Fatal("Expected the hand to be carried on")
+	}
+	state := restored.GetState()
+	if state.Status != pokerModels.StatusPlaying || state.CurrentHand.HandNumber != 1 ||
+		state.CurrentHand.CurrentPosition != saved.Hand.CurrentPosition {
+		t.Errorf("Expected hand 1 in play with the same player to act, got %s hand %d", state.Status, state.CurrentHand.HandNumber)
+	}
+
+	// Carol didn't come back, so table-2's hand can't be carried on
+	moved := engine.NewTable("table-2", pokerModels.GameTypeCash, pokerModels.TableConfig{SmallBlind: 5, BigBlind: 10, MaxPlayers: 6}, nil, nil)
+	moved.AddPlayer("a", "Alice", 0, 1000)
+	moved.AddPlayer("b", "Bob", 2, 1000)
+	if tr.restoreHandState(moved, "table-2") {
+		t.Fatal("Expected a hand with a missing player not to be carried on")
+	}
+	if moved.GetState().Status != pokerModels.StatusWaiting {
+		t.Errorf("Expected table-2 to wait for a new hand, got %s", moved.GetState().Status)
+	}
+	var states int64
+	db.Table("table_hand_states").Where("table_id = 'table-2'").Count(&states)
+	db.Table("hands").Where("table_id = 'table-2' AND completed_at IS NULL").Count(&open)
+	if states != 0 || open != 0 {
+		t.Errorf("Expected table-2's state dropped and its hand cancelled, got %d states and %d open hands", states, open)
+	}
+}
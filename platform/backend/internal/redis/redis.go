@@ -6,6 +6,8 @@ import (
 	"log"
 	"time"
 
+	"poker-platform/backend/internal/chaos"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -38,6 +40,8 @@ func New(config Config) (*Client, error) {
 		MinIdleConns: 5,
 	})
 
+	chaos.InstrumentRedis(client)
+
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -0,0 +1,311 @@
+// Package referral runs the referral program. Every user has a code to hand
+// out; a player who registers with it is credited to its owner, and as the
+// new player reaches milestones of hands played, both of them are paid a
+// reward from the house. Hands played at the same table as the referrer
+// don't count, and codes can't be used from the referrer's own address.
+package referral
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownCode  = errors.New("unknown referral code")
+	ErrSelfReferral = errors.New("a referral code can't be used by its owner")
+)
+
+// CodeLength is the number of characters in a referral code
+const CodeLength = 8
+
+// codeAlphabet leaves out letters easily mistaken for digits
+const codeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Milestone is a number of hands a referred player plays, paying both them
+// and their referrer
+type Milestone struct {
+	Hands          int `json:"hands"`
+	ReferrerReward int `json:"referrer_reward"` // Chips
+	RefereeReward  int `json:"referee_reward"`  // Chips
+}
+
+// DefaultMilestones are the milestones of the referral program
+var DefaultMilestones = []Milestone{
+	{Hands: 10, ReferrerReward: 200, RefereeReward: 200},
+	{Hands: 100, ReferrerReward: 1000, RefereeReward: 500},
+}
+
+// Payout is a milestone a referred player just reached, and paid
+type Payout struct {
+	Milestone
+	RefereeID  string
+	ReferrerID string
+}
+
+// Referred is a player a user referred, as their stats show them
+type Referred struct {
+	Username    string    `json:"username"`
+	JoinedAt    time.Time `json:"joined_at"`
+	HandsPlayed int       `json:"hands_played"`
+	Milestones  int       `json:"milestones"`
+}
+
+// Stats are a user's referral code and how the players they referred are
+// doing
+type Stats struct {
+	Code       string      `json:"code"`
+	Referred   int         `json:"referred"`  // Players who registered with the code
+	Qualified  int         `json:"qualified"` // Referred players who reached every milestone
+	Earned     int         `json:"earned"`    // Chips the milestones paid the user
+	Milestones []Milestone `json:"milestones"`
+	Referrals  []Referred  `json:"referrals"` // The latest 100, newest first
+}
+
+// Service hands out referral codes, credits registrations and pays milestones
+type Service struct {
+	db         *gorm.DB
+	currency   *currency.Service
+	milestones []Milestone
+}
+
+// NewService creates a referral service paying the given milestones, which
+// must be in order of hands
+func NewService(db *gorm.DB, currencyService *currency.Service, milestones []Milestone) *Service {
+	return &Service{db: db, currency: currencyService, milestones: milestones}
+}
+
+// NormalizeCode returns a code as it is stored: trimmed and upper case
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func newCode() (string, error) {
+	b := make([]byte, CodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = codeAlphabet[int(b[i])%len(codeAlphabet)]
+	}
+	return string(b), nil
+}
+
+// Code returns a user's referral code, making it the first time
+func (s *Service) Code(userID string) (string, error) {
+	var row models.ReferralCode
+	err := s.db.Where("user_id = ?", userID).First(&row).Error
+	if err == nil {
+		return row.Code, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+
+	// A new code can clash with another user's; try a few
+	for attempt := 0; attempt < 5; attempt++ {
+		code, err := newCode()
+		if err != nil {
+			return "", err
+		}
+		row = models.ReferralCode{UserID: userID, Code: code}
+		if err := s.db.Create(&row).Error; err == nil {
+			return code, nil
+		}
+		// The user may have been given a code meanwhile
+		if s.db.Where("user_id = ?", userID).First(&row).Error == nil {
+			return row.Code, nil
+		}
+	}
+	return "", fmt.Errorf("failed to make a referral code for %s", userID)
+}
+
+// normalizeEmail returns the mailbox an address delivers to, so aliases of
+// one address compare equal: lower case, without a +tag, and for Gmail
+// without dots
+func normalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+	if plus := strings.Index(local, "+"); plus >= 0 {
+		local = local[:plus]
+	}
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+	return local + "@" + domain
+}
+
+// Resolve returns the owner of a code a player is registering with. It is
+// refused as a self-referral when the player's email is an alias of the
+// owner's, or the owner has been seen at the player's IP address.
+func (s *Service) Resolve(code, email, ipAddress string) (string, error) {
+	var row models.ReferralCode
+	if err := s.db.Where("code = ?", NormalizeCode(code)).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrUnknownCode
+		}
+		return "", err
+	}
+
+	var owner models.User
+	if err := s.db.Select("id", "email").Where("id = ?", row.UserID).First(&owner).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrUnknownCode
+		}
+		return "", err
+	}
+	if normalizeEmail(owner.Email) == normalizeEmail(email) {
+		return "", ErrSelfReferral
+	}
+	if ipAddress != "" {
+		var seen int64
+		if err := s.db.Model(&models.AuditLog{}).
+			Where("user_id = ? AND ip_address = ?", owner.ID, ipAddress).
+			Count(&seen).Error; err != nil {
+			return "", err
+		}
+		if seen > 0 {
+			return "", ErrSelfReferral
+		}
+	}
+	return owner.ID, nil
+}
+
+// Attribute credits a new user to their referrer, in the transaction that
+// creates the user
+func (s *Service) Attribute(tx *gorm.DB, refereeID, referrerID, code string) error {
+	if refereeID == referrerID {
+		return ErrSelfReferral
+	}
+	return tx.Create(&models.Referral{
+		RefereeID:  refereeID,
+		ReferrerID: referrerID,
+		Code:       NormalizeCode(code),
+	}).Error
+}
+
+// RecordHand counts a hand towards the milestones of every referred player
+// dealt into it, unless their referrer was dealt in too. Returns the
+// milestones reached, which are paid in the same transaction, so they pay
+// once.
+func (s *Service) RecordHand(playerIDs []string) ([]Payout, error) {
+	if len(playerIDs) == 0 || len(s.milestones) == 0 {
+		return nil, nil
+	}
+	seated := make(map[string]bool, len(playerIDs))
+	for _, id := range playerIDs {
+		seated[id] = true
+	}
+
+	ctx := context.Background()
+	var payouts []Payout
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		payouts = nil
+		var rows []models.Referral
+		if err := tx.Where("referee_id IN ? AND milestones < ?", playerIDs, len(s.milestones)).
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if seated[row.ReferrerID] {
+				continue
+			}
+			if err := tx.Model(&models.Referral{}).Where("referee_id = ?", row.RefereeID).
+				Update("hands_played", gorm.Expr("hands_played + 1")).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("referee_id = ?", row.RefereeID).First(&row).Error; err != nil {
+				return err
+			}
+
+			for row.Milestones < len(s.milestones) && row.HandsPlayed >= s.milestones[row.Milestones].Hands {
+				milestone := s.milestones[row.Milestones]
+				result := tx.Model(&models.Referral{}).
+					Where("referee_id = ? AND milestones = ?", row.RefereeID, row.Milestones).
+					Updates(map[string]interface{}{
+						"milestones":      row.Milestones + 1,
+						"referrer_earned": gorm.Expr("referrer_earned + ?", milestone.ReferrerReward),
+					})
+				if result.Error != nil {
+					return result.Error
+				}
+				row.Milestones++
+				if result.RowsAffected == 0 {
+					continue
+				}
+				if err := s.pay(ctx, tx, row.ReferrerID, milestone.ReferrerReward, row.RefereeID, milestone); err != nil {
+					return err
+				}
+				if err := s.pay(ctx, tx, row.RefereeID, milestone.RefereeReward, row.RefereeID, milestone); err != nil {
+					return err
+				}
+				log.Printf("[REFERRAL] %s reached %d hands: %d chips to referrer %s, %d to them",
+					row.RefereeID, milestone.Hands, milestone.ReferrerReward, row.ReferrerID, milestone.RefereeReward)
+				payouts = append(payouts, Payout{Milestone: milestone, RefereeID: row.RefereeID, ReferrerID: row.ReferrerID})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payouts, nil
+}
+
+func (s *Service) pay(ctx context.Context, tx *gorm.DB, userID string, amount int, refereeID string, milestone Milestone) error {
+	if amount <= 0 {
+		return nil
+	}
+	description := fmt.Sprintf("Referral reward: %d hands", milestone.Hands)
+	if err := s.currency.PayRewardWithTx(ctx, tx, userID, amount, currency.TxTypeReferralReward, refereeID, description); err != nil {
+		return fmt.Errorf("failed to pay referral reward to %s: %w", userID, err)
+	}
+	return nil
+}
+
+// Stats returns a user's referral code and the players they referred
+func (s *Service) Stats(userID string) (*Stats, error) {
+	code, err := s.Code(userID)
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{Code: code, Milestones: s.milestones, Referrals: []Referred{}}
+
+	var totals struct {
+		Referred  int
+		Qualified int
+		Earned    int
+	}
+	if err := s.db.Model(&models.Referral{}).
+		Select("COUNT(*) AS referred, COALESCE(SUM(CASE WHEN milestones >= ? THEN 1 ELSE 0 END), 0) AS qualified, COALESCE(SUM(referrer_earned), 0) AS earned", len(s.milestones)).
+		Where("referrer_id = ?", userID).
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	stats.Referred, stats.Qualified, stats.Earned = totals.Referred, totals.Qualified, totals.Earned
+
+	if err := s.db.Table("referrals").
+		Select("users.username AS username, referrals.created_at AS joined_at, referrals.hands_played AS hands_played, referrals.milestones AS milestones").
+		Joins("JOIN users ON users.id = referrals.referee_id").
+		Where("referrals.referrer_id = ?", userID).
+		Order("referrals.created_at DESC").
+		Limit(100).
+		Scan(&stats.Referrals).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
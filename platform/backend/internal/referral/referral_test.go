@@ -0,0 +1,109 @@
+package referral
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var testMilestones = []Milestone{
+	{Hands: 2, ReferrerReward: 100, RefereeReward: 50},
+	{Hands: 3, ReferrerReward: 200, RefereeReward: 0},
+}
+
+func setupTestService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &currency.Transaction{}, &models.AuditLog{},
+		&models.ReferralCode{}, &models.Referral{}))
+	for _, user := range []models.User{
+		{ID: currency.OperatorAccountID, Username: "house", Email: "house@localhost", Chips: 1000},
+		{ID: "alice", Username: "alice", Email: "Alice.Smith@gmail.com", Chips: 1000},
+		{ID: "bob", Username: "bob", Email: "bob@test.com", Chips: 1000},
+	} {
+		require.NoError(t, db.Create(&user).Error)
+	}
+	return NewService(db, currency.NewService(db), testMilestones), db
+}
+
+func chipsOf(t *testing.T, db *gorm.DB, userID string) int {
+	var user models.User
+	require.NoError(t, db.First(&user, "id = ?", userID).Error)
+	return user.Chips
+}
+
+func TestResolve(t *testing.T) {
+	s, db := setupTestService(t)
+	code, err := s.Code("alice")
+	require.NoError(t, err)
+	assert.Len(t, code, CodeLength)
+	again, err := s.Code("alice")
+	require.NoError(t, err)
+	assert.Equal(t, code, again, "a user keeps their code")
+
+	_, err = s.Resolve("NOPE1234", "carol@test.com", "")
+	assert.ErrorIs(t, err, ErrUnknownCode)
+	_, err = s.Resolve(code, "alicesmith+poker@googlemail.com", "")
+	assert.ErrorIs(t, err, ErrSelfReferral, "an alias of the owner's email")
+
+	require.NoError(t, db.Create(&models.AuditLog{UserID: "alice", EventType: "login", IPAddress: "10.0.0.1"}).Error)
+	_, err = s.Resolve(code, "carol@test.com", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrSelfReferral, "the owner's address")
+
+	referrerID, err := s.Resolve(" "+code+" ", "carol@test.com", "10.0.0.2")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", referrerID)
+}
+
+func TestRecordHand(t *testing.T) {
+	s, db := setupTestService(t)
+	code, err := s.Code("alice")
+	require.NoError(t, err)
+	require.NoError(t, s.Attribute(db, "bob", "alice", code))
+	assert.ErrorIs(t, s.Attribute(db, "alice", "alice", code), ErrSelfReferral)
+
+	// Hands with the referrer at the table don't count
+	payouts, err := s.RecordHand([]string{"alice", "bob"})
+	require.NoError(t, err)
+	assert.Empty(t, payouts)
+
+	payouts, err = s.RecordHand([]string{"bob", "carol"})
+	require.NoError(t, err)
+	assert.Empty(t, payouts)
+	payouts, err = s.RecordHand([]string{"bob"})
+	require.NoError(t, err)
+	require.Len(t, payouts, 1)
+	assert.Equal(t, Payout{Milestone: testMilestones[0], RefereeID: "bob", ReferrerID: "alice"}, payouts[0])
+	assert.Equal(t, 1100, chipsOf(t, db, "alice"))
+	assert.Equal(t, 1050, chipsOf(t, db, "bob"))
+
+	payouts, err = s.RecordHand([]string{"bob"})
+	require.NoError(t, err)
+	require.Len(t, payouts, 1)
+	assert.Equal(t, 3, payouts[0].Hands)
+
+	// Every milestone is paid once
+	payouts, err = s.RecordHand([]string{"bob"})
+	require.NoError(t, err)
+	assert.Empty(t, payouts)
+	assert.Equal(t, 1300, chipsOf(t, db, "alice"))
+	assert.Equal(t, 1050, chipsOf(t, db, "bob"))
+	assert.Equal(t, 650, chipsOf(t, db, currency.OperatorAccountID))
+
+	stats, err := s.Stats("alice")
+	require.NoError(t, err)
+	assert.Equal(t, code, stats.Code)
+	assert.Equal(t, 1, stats.Referred)
+	assert.Equal(t, 1, stats.Qualified)
+	assert.Equal(t, 300, stats.Earned)
+	require.Len(t, stats.Referrals, 1)
+	assert.Equal(t, "bob", stats.Referrals[0].Username)
+	assert.Equal(t, 3, stats.Referrals[0].HandsPlayed)
+	assert.Equal(t, 2, stats.Referrals[0].Milestones)
+}
@@ -4,16 +4,34 @@ import (
 	"context"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
+	"poker-platform/backend/internal/audit"
 	"poker-platform/backend/internal/auth"
+	"poker-platform/backend/internal/avatar"
+	"poker-platform/backend/internal/bot"
+	"poker-platform/backend/internal/chat"
+	"poker-platform/backend/internal/club"
 	"poker-platform/backend/internal/currency"
 	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/identity"
+	"poker-platform/backend/internal/leaderboard"
 	"poker-platform/backend/internal/locks"
 	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/mission"
+	"poker-platform/backend/internal/moderation"
+	"poker-platform/backend/internal/outbox"
+	"poker-platform/backend/internal/profile"
+	"poker-platform/backend/internal/progression"
+	"poker-platform/backend/internal/referral"
+	"poker-platform/backend/internal/quota"
 	"poker-platform/backend/internal/recovery"
 	redisClient "poker-platform/backend/internal/redis"
+	"poker-platform/backend/internal/server/game"
 	"poker-platform/backend/internal/server/history"
+	"poker-platform/backend/internal/server/websocket"
+	"poker-platform/backend/internal/timeline"
 	"poker-platform/backend/internal/tournament"
 
 	"poker-engine/engine"
@@ -27,13 +45,32 @@ type AppConfig struct {
 	LockManager         *locks.LockManager
 	AuthService         *auth.Service
 	CurrencyService     *currency.Service
+	ClubService         *club.Service
 	TournamentService   *tournament.Service
 	TournamentStarter   *tournament.Starter
 	BlindManager        *tournament.BlindManager
 	EliminationTracker  *tournament.EliminationTracker
 	Consolidator        *tournament.Consolidator
+	BracketManager      *tournament.BracketManager
 	PrizeDistributor    *tournament.PrizeDistributor
 	HistoryTracker      *history.HistoryTracker
+	HistoryWriter       *history.BatchWriter
+	AuditStore          *audit.Store
+	SessionLimits       game.SessionLimits
+	BroadcastThrottle   websocket.ThrottleConfig
+	Moderation          *moderation.Service
+	Identity            *identity.Service
+	Chat                *chat.Service
+	Outbox              *outbox.Dispatcher
+	Leaderboards        *leaderboard.Service
+	Timeline            *timeline.Service
+	Quotas              *quota.Service
+	Progression         *progression.Service
+	Missions            *mission.Service
+	Referrals           *referral.Service
+	Profiles            *profile.Service
+	Avatars             *avatar.Service
+	Bots                *bot.Service
 }
 
 // GetEnv returns an environment variable value or a fallback
@@ -70,16 +107,72 @@ func InitializeServices(dbConfig db.Config, redisConfig redisClient.Config, jwtS
 
 	authService := auth.NewService(jwtSecret)
 	currencyService := currency.NewService(database.DB)
+	clubService := club.NewService(database.DB)
 	tournamentService := tournament.NewService(database.DB, currencyService)
 	tournamentStarter := tournament.NewStarter(database.DB, tournamentService)
 	blindManager := tournament.NewBlindManager(database.DB)
 	eliminationTracker := tournament.NewEliminationTracker(database.DB)
 	consolidator := tournament.NewConsolidator(database.DB)
+	bracketManager := tournament.NewBracketManager(database.DB)
+	eliminationTracker.SetBracketManager(bracketManager)
 	prizeDistributor := tournament.NewPrizeDistributor(database.DB, currencyService)
 	historyTracker := history.NewHistoryTracker(database)
 
-	// Connect prize distributor to elimination tracker
-	eliminationTracker.SetPrizeDistributor(prizeDistributor)
+	// Persist history off the action path in batches, queued through a Redis stream
+	historyWriter := history.NewBatchWriter(database, redis.Client, history.DefaultBatchWriterConfig)
+	historyTracker.SetWriter(historyWriter)
+	historyWriter.Start()
+
+	// Audit trail of logins and game actions, purged after AUDIT_RETENTION_DAYS
+	auditConfig := audit.DefaultConfig
+	if days, err := strconv.Atoi(GetEnv("AUDIT_RETENTION_DAYS", "")); err == nil && days > 0 {
+		auditConfig.Retention = time.Duration(days) * 24 * time.Hour
+	}
+	auditStore := audit.NewStore(database.DB, auditConfig)
+	auditStore.Start()
+
+	// Responsible gaming limits over a rolling 24 hours; unset or 0 disables a limit
+	sessionLimits := game.DefaultSessionLimits
+	if minutes, err := strconv.Atoi(GetEnv("RG_MAX_DAILY_PLAY_MINUTES", "")); err == nil && minutes > 0 {
+		sessionLimits.MaxDailyPlayTime = time.Duration(minutes) * time.Minute
+	}
+	if hands, err := strconv.Atoi(GetEnv("RG_MAX_DAILY_HANDS", "")); err == nil && hands > 0 {
+		sessionLimits.MaxDailyHands = hands
+	}
+
+	// Minimum gap between state broadcasts caused by engine events on a table
+	broadcastThrottle := websocket.DefaultThrottleConfig
+	if ms, err := strconv.Atoi(GetEnv("BROADCAST_MIN_INTERVAL_MS", "")); err == nil && ms >= 0 {
+		broadcastThrottle.Default.Interval = time.Duration(ms) * time.Millisecond
+	}
+
+	// Profanity filter wordlists: every <language>.txt in MODERATION_WORDLISTS_DIR, or the built-in ones
+	filter := moderation.DefaultFilter()
+	if dir := GetEnv("MODERATION_WORDLISTS_DIR", ""); dir != "" {
+		if filter, err = moderation.LoadFilter(dir); err != nil {
+			return nil, err
+		}
+	}
+	moderationService := moderation.NewService(database.DB, filter)
+
+	// Avatar images on local disk or in S3, chosen by AVATAR_STORAGE
+	avatarStorage, err := avatar.StorageFromEnv(GetEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	// Steps that follow a committed change, such as paying out a completed
+	// tournament, run from the outbox so a crash in between can't lose them
+	outboxDispatcher := outbox.NewDispatcher(database.DB, outbox.DefaultConfig)
+	outboxDispatcher.Register(tournament.TopicPayout, prizeDistributor.PayoutHandler())
+	outboxDispatcher.Register(tournament.TopicBounty, prizeDistributor.BountyHandler())
+	outboxDispatcher.Register(tournament.TopicConsolidate, consolidator.ConsolidationHandler(eliminationTracker))
+	eliminationTracker.SetOutbox(outboxDispatcher)
+	outboxDispatcher.Start()
+
+	// Daily, weekly and monthly leaderboards in Redis sorted sets
+	leaderboards := leaderboard.NewService(database.DB, redis.Client, leaderboard.DefaultConfig)
+	leaderboards.Start()
 
 	config := &AppConfig{
 		Database:           database,
@@ -87,13 +180,32 @@ func InitializeServices(dbConfig db.Config, redisConfig redisClient.Config, jwtS
 		LockManager:        lockManager,
 		AuthService:        authService,
 		CurrencyService:    currencyService,
+		ClubService:        clubService,
 		TournamentService:  tournamentService,
 		TournamentStarter:  tournamentStarter,
 		BlindManager:       blindManager,
 		EliminationTracker: eliminationTracker,
 		Consolidator:       consolidator,
+		BracketManager:     bracketManager,
 		PrizeDistributor:   prizeDistributor,
 		HistoryTracker:     historyTracker,
+		HistoryWriter:      historyWriter,
+		AuditStore:         auditStore,
+		SessionLimits:      sessionLimits,
+		BroadcastThrottle:  broadcastThrottle,
+		Moderation:         moderationService,
+		Identity:           identity.NewService(database.DB, moderationService),
+		Chat:               chat.NewService(database.DB, redis.Client, moderationService, chat.DefaultConfig),
+		Outbox:             outboxDispatcher,
+		Leaderboards:       leaderboards,
+		Timeline:           timeline.NewService(database.DB),
+		Quotas:             quota.NewService(database.DB, quota.ConfigFromEnv(GetEnv)),
+		Progression:        progression.NewService(database.DB, currencyService),
+		Missions:           mission.NewService(database.DB, currencyService, mission.DefaultMissions),
+		Referrals:          referral.NewService(database.DB, currencyService, referral.DefaultMilestones),
+		Profiles:           profile.NewService(database.DB),
+		Avatars:            avatar.NewService(database.DB, avatarStorage, moderationService),
+		Bots:               bot.NewService(database.DB, currencyService),
 	}
 
 	return config, nil
@@ -103,6 +215,24 @@ func InitializeServices(dbConfig db.Config, redisConfig redisClient.Config, jwtS
 func (cfg *AppConfig) Cleanup() {
 	log.Println("🧹 Cleaning up resources...")
 
+	// Flush queued history before the Redis connection goes away
+	if cfg.HistoryWriter != nil {
+		cfg.HistoryWriter.Stop()
+	}
+
+	if cfg.AuditStore != nil {
+		cfg.AuditStore.Stop()
+	}
+
+	if cfg.Leaderboards != nil {
+		cfg.Leaderboards.Stop()
+	}
+
+	// Let running outbox steps finish; pending ones are picked up on the next start
+	if cfg.Outbox != nil {
+		cfg.Outbox.Stop()
+	}
+
 	if cfg.Redis != nil {
 		if err := cfg.Redis.Close(); err != nil {
 			log.Printf("⚠️  Error closing Redis connection: %v", err)
@@ -116,7 +246,8 @@ func (cfg *AppConfig) Cleanup() {
 func RecoverTablesOnStartup(
 	database *db.DB,
 	tables map[string]*engine.Table,
-	onTimeout func(tableID, playerID string),
+	claimer recovery.TableClaimer,
+	onTimeout func(tableID, playerID string, deadline uint64),
 	onEvent func(tableID string, event pokerModels.Event, gameType pokerModels.GameType),
 ) error {
 	log.Println("============================================================")
@@ -124,12 +255,45 @@ func RecoverTablesOnStartup(
 	log.Println("============================================================")
 
 	tableRecovery := recovery.NewTableRecovery(database.DB)
+	tableRecovery.ShareWith(claimer)
 
 	// Cleanup orphaned data first
 	if err := tableRecovery.CleanupOrphanedData(); err != nil {
 		log.Printf("⚠️  Warning: Failed to cleanup orphaned data: %v", err)
 	}
 
+	allTables := recoverEngineTables(tableRecovery, onTimeout, onEvent)
+	for tableID, table := range allTables {
+		tables[tableID] = table
+	}
+
+	// Check and start games after a delay
+	if len(allTables) > 0 {
+		go tableRecovery.CheckAndStartGames(allTables, 3*time.Second)
+	}
+
+	// Print recovery stats
+	stats, _ := tableRecovery.GetRecoveryStats()
+	log.Println("============================================================")
+	log.Println("📊 RECOVERY STATISTICS:")
+	log.Printf("   Active Tables: %v", stats["active_tables"])
+	log.Printf("   Active Tournaments: %v", stats["active_tournaments"])
+	log.Printf("   Active Seats: %v", stats["active_seats"])
+	log.Printf("   Incomplete Hands: %v", stats["incomplete_hands"])
+	log.Println("============================================================")
+	log.Println("✅ TABLE RECOVERY COMPLETE")
+	log.Println("============================================================")
+
+	return nil
+}
+
+// recoverEngineTables creates the engine tables of the active cash and
+// tournament tables nobody runs
+func recoverEngineTables(
+	tableRecovery *recovery.TableRecovery,
+	onTimeout func(tableID, playerID string, deadline uint64),
+	onEvent func(tableID string, event pokerModels.Event, gameType pokerModels.GameType),
+) map[string]*engine.Table {
 	// Create table factory function
 	createTableFunc := func(tableID, gameType string, smallBlind, bigBlind, maxPlayers, minBuyIn, maxBuyIn int, tournamentID *string) *engine.Table {
 		var gt pokerModels.GameType
@@ -140,16 +304,21 @@ func RecoverTablesOnStartup(
 		}
 
 		config := pokerModels.TableConfig{
-			SmallBlind:    smallBlind,
-			BigBlind:      bigBlind,
-			MaxPlayers:    maxPlayers,
-			MinBuyIn:      minBuyIn,
-			MaxBuyIn:      maxBuyIn,
-			ActionTimeout: 30,
+			SmallBlind:      smallBlind,
+			BigBlind:        bigBlind,
+			MaxPlayers:      maxPlayers,
+			MinBuyIn:        minBuyIn,
+			MaxBuyIn:        maxBuyIn,
+			ActionTimeout:   30,
+			TimeBank:        60,
+			DisconnectGrace: game.DisconnectGrace,
+		}
+		if gt == pokerModels.GameTypeCash {
+			config.ShowCardsWindow = game.ShowCardsWindow
 		}
 
-		timeoutFunc := func(playerID string) {
-			onTimeout(tableID, playerID)
+		timeoutFunc := func(playerID string, deadline uint64) {
+			onTimeout(tableID, playerID, deadline)
 		}
 
 		eventFunc := func(event pokerModels.Event) {
@@ -157,6 +326,7 @@ func RecoverTablesOnStartup(
 		}
 
 		table := engine.NewTable(tableID, gt, config, timeoutFunc, eventFunc)
+		table.SetRateLimits(engine.DefaultRateLimits)
 		return table
 	}
 
@@ -165,9 +335,6 @@ func RecoverTablesOnStartup(
 	if err != nil {
 		log.Printf("❌ Failed to recover cash game tables: %v", err)
 	} else {
-		for tableID, table := range cashTables {
-			tables[tableID] = table
-		}
 		log.Printf("✓ Added %d cash game tables to engine", len(cashTables))
 	}
 
@@ -176,9 +343,6 @@ func RecoverTablesOnStartup(
 	if err != nil {
 		log.Printf("❌ Failed to recover tournament tables: %v", err)
 	} else {
-		for tableID, table := range tournamentTables {
-			tables[tableID] = table
-		}
 		log.Printf("✓ Added %d tournament tables to engine", len(tournamentTables))
 	}
 
@@ -190,25 +354,35 @@ func RecoverTablesOnStartup(
 	for k, v := range tournamentTables {
 		allTables[k] = v
 	}
+	return allTables
+}
 
-	// Check and start games after a delay
-	if len(allTables) > 0 {
-		go tableRecovery.CheckAndStartGames(allTables, 3*time.Second)
+// AdoptTables recovers the tables of instances that stopped renewing them,
+// such as one that crashed, into a running bridge. It returns how many were
+// adopted.
+func AdoptTables(
+	database *db.DB,
+	bridge *game.GameBridge,
+	onTimeout func(tableID, playerID string, deadline uint64),
+	onEvent func(tableID string, event pokerModels.Event, gameType pokerModels.GameType),
+) int {
+	tableRecovery := recovery.NewTableRecovery(database.DB)
+	tableRecovery.ShareWith(bridge)
+	if err := tableRecovery.CleanupOrphanedData(); err != nil {
+		log.Printf("⚠️  Warning: Failed to cleanup orphaned data: %v", err)
 	}
 
-	// Print recovery stats
-	stats, _ := tableRecovery.GetRecoveryStats()
-	log.Println("============================================================")
-	log.Println("📊 RECOVERY STATISTICS:")
-	log.Printf("   Active Tables: %v", stats["active_tables"])
-	log.Printf("   Active Tournaments: %v", stats["active_tournaments"])
-	log.Printf("   Active Seats: %v", stats["active_seats"])
-	log.Printf("   Incomplete Hands: %v", stats["incomplete_hands"])
-	log.Println("============================================================")
-	log.Println("✅ TABLE RECOVERY COMPLETE")
-	log.Println("============================================================")
+	adopted := recoverEngineTables(tableRecovery, onTimeout, onEvent)
+	bridge.Mu.Lock()
+	for tableID, table := range adopted {
+		bridge.Tables[tableID] = table
+	}
+	bridge.Mu.Unlock()
 
-	return nil
+	if len(adopted) > 0 {
+		go tableRecovery.CheckAndStartGames(adopted, 3*time.Second)
+	}
+	return len(adopted)
 }
 
 // SetupTournamentCallbacks sets up all tournament-related callbacks
@@ -216,10 +390,15 @@ func SetupTournamentCallbacks(
 	config *AppConfig,
 	onTournamentStart func(tournamentID string),
 	onBlindIncrease func(tournamentID string, newLevel models.BlindLevel),
+	onBreak func(tournamentID string, level int, resumesAt time.Time),
 	onPlayerEliminated func(tournamentID, userID string, position int),
 	onTournamentComplete func(tournamentID string),
-	onConsolidation func(tournamentID string),
+	onConsolidation func(plan *tournament.ConsolidationPlan),
+	onConsolidationPlanned func(plan *tournament.ConsolidationPlan),
+	onFinalTable func(tournamentID, tableID string),
 	onPrizeDistributed func(tournamentID, userID string, amount int),
+	onBracketUpdate func(tournamentID string, round int),
+	onBountyPaid func(knockout models.TournamentKnockout),
 ) {
 	// Set callback for when tournaments start automatically
 	config.TournamentStarter.SetOnStartCallback(onTournamentStart)
@@ -227,6 +406,9 @@ func SetupTournamentCallbacks(
 	// Set callback for when blinds increase
 	config.BlindManager.SetOnBlindIncreaseCallback(onBlindIncrease)
 
+	// Set callback for when a scheduled break starts
+	config.BlindManager.SetOnBreakCallback(onBreak)
+
 	// Set callback for player elimination
 	config.EliminationTracker.SetOnPlayerEliminatedCallback(onPlayerEliminated)
 
@@ -235,9 +417,19 @@ func SetupTournamentCallbacks(
 
 	// Set callback for table consolidation
 	config.Consolidator.SetOnConsolidationCallback(onConsolidation)
+	config.Consolidator.SetOnConsolidationPlannedCallback(onConsolidationPlanned)
+
+	// Set callback for reaching the final table
+	config.Consolidator.SetOnFinalTableCallback(onFinalTable)
+
+	// Set callback for a settled bracket match
+	config.BracketManager.SetOnUpdateCallback(onBracketUpdate)
 
 	// Set callback for prize distribution (synchronous to prevent race conditions)
 	config.PrizeDistributor.SetOnPrizeDistributedCallback(onPrizeDistributed)
+
+	// Set callback for the bounty of a knockout being paid
+	config.PrizeDistributor.SetOnBountyPaidCallback(onBountyPaid)
 }
 
 // StartTournamentServices starts the background tournament services
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/recovery"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/server/handlers"
+	"poker-platform/backend/internal/testutil"
+
+	pokerModels "poker-engine/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestImportSnapshot_AdoptsActiveTables(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, gormDB, &models.Table{}, &models.TableSeat{}, &models.User{}, &models.Avatar{},
+		&models.Hand{}, &models.TableHandState{}, &models.Tournament{})
+	for _, stmt := range []string{
+		`INSERT INTO tables (id, name, game_type, status, small_blind, big_blind, max_players)
+			VALUES ('table-1', 'Imported', 'cash', 'playing', 5, 10, 6)`,
+		`INSERT INTO users (id, username) VALUES ('a', 'Alice'), ('b', 'Bob')`,
+		`INSERT INTO table_seats (table_id, user_id, seat_number, chips) VALUES ('table-1', 'a', 0, 500), ('table-1', 'b', 1, 500)`,
+	} {
+		if err := gormDB.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to create test rows: %v", err)
+		}
+	}
+	database := &db.DB{DB: gormDB}
+	bridge := game.NewGameBridge()
+
+	body, _ := json.Marshal(recovery.Snapshot{
+		Version: recovery.SnapshotVersion,
+		Tables: []recovery.TableSnapshot{{
+			TableID: "table-1",
+			Status:  string(pokerModels.StatusPlaying),
+			Seats:   []recovery.SeatSnapshot{{UserID: "a", Chips: 700}, {UserID: "b", Chips: 300}},
+		}},
+	})
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/admin/snapshot/import", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("user_id", "admin")
+
+	// Recovery asks the bridge who runs each table while it loads them
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handlers.HandleImportSnapshot(c, database, bridge, nil, func() {
+			AdoptTables(database, bridge, func(string, string, uint64) {}, func(string, pokerModels.Event, pokerModels.GameType) {})
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Snapshot import hung while recovering an active table")
+	}
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	table, exists := bridge.GetTable("table-1")
+	if !exists {
+		t.Fatal("Expected the imported table to be running")
+	}
+	chips := map[string]int{}
+	for _, player := range table.GetState().Players {
+		if player != nil {
+			chips[player.PlayerID] = player.Chips
+		}
+	}
+	if chips["a"] != 700 || chips["b"] != 300 {
+		t.Errorf("Expected the snapshot's stacks of 700 and 300, got %v", chips)
+	}
+}
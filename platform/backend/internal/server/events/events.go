@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/ids"
 	"poker-platform/backend/internal/models"
 	"poker-platform/backend/internal/server/game"
 	"poker-platform/backend/internal/server/history"
@@ -26,6 +28,17 @@ func HandleEngineEvent(
 ) {
 	log.Printf("[ENGINE_EVENT] Table %s: %s", tableID, event.Event)
 
+	bridge.RecordStatsEvent(tableID, event)
+	bridge.RecordSessionEvent(tableID, event)
+	bridge.RecordFairnessEvent(tableID, event)
+	bridge.RecordHandStateEvent(tableID, event)
+	bridge.RecordLeaderboardEvent(tableID, event)
+	bridge.RecordProgressionEvent(tableID, event)
+	bridge.RecordMissionEvent(tableID, event)
+	bridge.RecordReferralEvent(tableID, event)
+	bridge.RecordProfileEvent(tableID, event)
+	bridge.RecordBotEvent(tableID, event)
+
 	switch event.Event {
 	case "handStart":
 		data, _ := event.Data.(map[string]interface{})
@@ -130,8 +143,11 @@ func HandleEngineEvent(
 
 				historyTracker.RecordHandComplete(handID, tableID, winnersData, finalPot, cardStrs, bettingRound)
 
-				// Cleanup sequence counter after hand completes
-				historyTracker.CleanupHandSequence(handID)
+				// Cleanup sequence counter after hand completes, or once the
+				// winner has had their chance to show cards
+				if !table.IsShowingCards() {
+					historyTracker.CleanupHandSequence(handID)
+				}
 			}
 		}
 
@@ -141,59 +157,59 @@ func HandleEngineEvent(
 		// Sync player chips to database after hand completion
 		syncChipsFunc(tableID)
 
+		// Bots leave once they have nobody left to play
+		game.ReleaseBots(bridge, database, tableID)
+
+		// Players an admin kicked during the hand leave now
+		game.ApplyPendingKicks(bridge, database, tableID)
+
 		broadcastFunc(tableID)
 
-		go func() {
-			time.Sleep(5 * time.Second)
+		// The winner of a pot nobody contested may show their cards first;
+		// the next hand is dealt once they have
+		if exists && table.IsShowingCards() {
+			return
+		}
+		go startNextHand(tableID, 5*time.Second, bridge, broadcastFunc)
 
-			bridge.Mu.RLock()
-			table, exists := bridge.Tables[tableID]
-			bridge.Mu.RUnlock()
+	case "showCardsPrompt":
+		bridge.SendShowCardsPrompt(tableID, event)
+		broadcastFunc(tableID)
+		return
 
-			if !exists {
-				log.Printf("[CASH_GAME] Table %s no longer exists, cannot start next hand", tableID)
-				return
-			}
+	case "showCardsClosed":
+		data, _ := event.Data.(map[string]interface{})
+		playerID, _ := data["playerId"].(string)
+		cards, _ := data["cards"].([]pokerModels.Card)
 
-			state := table.GetState()
-			log.Printf("[CASH_GAME] Checking players for next hand on table %s", tableID)
-
-			activeCount := 0
-			totalPlayers := 0
-			for i, p := range state.Players {
-				if p != nil {
-					totalPlayers++
-					log.Printf("[CASH_GAME] Player %d: %s (ID: %s) - Chips: %d, Status: %s",
-						i, p.PlayerName, p.PlayerID, p.Chips, p.Status)
-
-					if p.Status != pokerModels.StatusSittingOut && p.Chips > 0 {
-						activeCount++
-					} else {
-						log.Printf("[CASH_GAME] Player %s not active: Status=%s, Chips=%d",
-							p.PlayerName, p.Status, p.Chips)
+		handID, handExists := bridge.GetCurrentHandID(tableID)
+		if handExists && historyTracker != nil {
+			if len(cards) > 0 {
+				cardStrs := make([]string, len(cards))
+				for i, card := range cards {
+					cardStrs[i] = card.String()
+				}
+				playerName := ""
+				if table, exists := bridge.GetTable(tableID); exists {
+					for _, p := range table.GetState().Players {
+						if p != nil && p.PlayerID == playerID {
+							playerName = p.PlayerName
+						}
 					}
 				}
+				historyTracker.RecordCardsShown(handID, tableID, playerID, playerName, cardStrs)
 			}
+			historyTracker.CleanupHandSequence(handID)
+		}
+		log.Printf("[SHOW_CARDS] %s showed %d cards on table %s", playerID, len(cards), tableID)
+		broadcastFunc(tableID)
 
-			log.Printf("[CASH_GAME] Table %s: Total players: %d, Active players: %d",
-				tableID, totalPlayers, activeCount)
-
-			if activeCount >= 2 {
-				log.Printf("[CASH_GAME] Starting next hand on table %s with %d active players",
-					tableID, activeCount)
-				err := table.StartGame()
-				if err != nil {
-					log.Printf("[CASH_GAME] ERROR: Failed to start next hand on table %s: %v",
-						tableID, err)
-				} else {
-					log.Printf("[CASH_GAME] Successfully started next hand on table %s", tableID)
-					broadcastFunc(tableID)
-				}
-			} else {
-				log.Printf("[CASH_GAME] Cannot start next hand on table %s: Only %d active players (need 2+)",
-					tableID, activeCount)
-			}
-		}()
+		// Shown cards stay up a moment; otherwise the table moves straight on
+		delay := time.Duration(0)
+		if len(cards) > 0 {
+			delay = game.ShowCardsPause
+		}
+		go startNextHand(tableID, delay, bridge, broadcastFunc)
 
 	case "gameComplete":
 		// Game is over - only one player left
@@ -226,11 +242,40 @@ func HandleEngineEvent(
 
 	case "playerAction":
 		log.Printf("[ENGINE_EVENT] Player action completed on table %s", tableID)
+		if data, ok := event.Data.(map[string]interface{}); ok {
+			playerID, _ := data["playerId"].(string)
+			bridge.Away.Acked(tableID, playerID)
+		}
 		broadcastFunc(tableID)
 		return
 
+	case "playerAway":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[ENGINE_EVENT] Player %v away=%v on table %s", data["playerId"], data["away"], tableID)
+		broadcastFunc(tableID)
+		return
+
+	case "playerConnection":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[ENGINE_EVENT] Player %v connected=%v on table %s", data["playerId"], data["connected"], tableID)
+		broadcastFunc(tableID)
+		return
+
+	case "playerFrozen":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[ENGINE_EVENT] Player %v frozen=%v on table %s", data["playerId"], data["frozen"], tableID)
+		broadcastFunc(tableID)
+		return
+
+	case "timeBankStarted":
+		// The actionRequired sent with it carries the new deadline
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[ENGINE_EVENT] Player %v drawing on %vs of time bank on table %s", data["playerId"], data["timeBank"], tableID)
+		return
+
 	case "actionRequired":
 		log.Printf("[ENGINE_EVENT] Action required on table %s", tableID)
+		bridge.SendTurnNotification(tableID, event)
 		broadcastFunc(tableID)
 		return
 
@@ -265,10 +310,25 @@ func HandleEngineEvent(
 		broadcastFunc(tableID)
 		return
 
+	case "seatChanged":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[ENGINE_EVENT] Player %v moved from seat %v to seat %v on table %s",
+			data["playerId"], data["fromSeat"], data["toSeat"], tableID)
+		persistSeatChange(database, tableID, event)
+		broadcastFunc(tableID)
+		return
+
+	case "positionsCorrected":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[BUTTON_AUDIT] WARNING: Hand #%v on table %s had inconsistent positions %v - corrected to dealer=%v SB=%v BB=%v",
+			data["handNumber"], tableID, data["violations"], data["dealerPosition"], data["smallBlindPosition"], data["bigBlindPosition"])
+		return
+
 	case "cardDealt":
-		// Don't broadcast on every card dealt to reduce message frequency
-		// The next playerAction or roundAdvanced will trigger a broadcast
-		log.Printf("[ENGINE_EVENT] Card dealt on table %s (skipping broadcast)", tableID)
+		// The broadcast throttle skips these; the next playerAction or
+		// roundAdvanced carries the cards
+		log.Printf("[ENGINE_EVENT] Card dealt on table %s", tableID)
+		broadcastFunc(tableID)
 		return
 
 	default:
@@ -325,6 +385,8 @@ func ProcessGameAction(
 		playerAction = pokerModels.ActionRaise
 	case "allin":
 		playerAction = pokerModels.ActionAllIn
+	case "straddle":
+		playerAction = pokerModels.ActionStraddle
 	default:
 		log.Printf("Unknown action: %s", action)
 		return
@@ -345,9 +407,10 @@ func ProcessGameAction(
 		handID, hasHandID := bridge.CurrentHandIDs[tableID]
 		bridge.Mu.RUnlock()
 
-		if hasHandID && handID > 0 {
+		if hasHandID && handID > 0 && playerAction != pokerModels.ActionStraddle {
 			// Save to hand_actions table (legacy)
 			handAction := models.HandAction{
+				UID:          ids.New(),
 				HandID:       handID,
 				UserID:       userID,
 				ActionType:   action,
@@ -355,7 +418,14 @@ func ProcessGameAction(
 				BettingRound: bettingRound,
 			}
 
-			if err := database.Create(&handAction).Error; err != nil {
+			var saveErr error
+			if historyTracker != nil {
+				saveErr = historyTracker.RecordHandAction(handAction)
+			} else {
+				saveErr = database.Create(&handAction).Error
+			}
+
+			if err := saveErr; err != nil {
 				log.Printf("[ACTION] ERROR: Failed to save hand action to DB: %v", err)
 			} else {
 				log.Printf("[ACTION] Saved action %s by %s for hand %d", action, userID, handID)
@@ -392,7 +462,8 @@ func ProcessGameAction(
 					currentBet, potAfter,
 				)
 			}
-		} else {
+		} else if playerAction != pokerModels.ActionStraddle {
+			// A straddle is declared between hands, so it belongs to no hand yet
 			log.Printf("[ACTION] WARNING: No hand ID found for table %s to save action", tableID)
 		}
 
@@ -408,26 +479,23 @@ func ProcessGameAction(
 func SendActionConfirmation(bridge *game.GameBridge, userID string, action string, amount int, success bool) {
 	confirmMsg := map[string]interface{}{
 		"type": "action_confirmed",
-		"payload": map[string]interface{}{
-			"user_id": userID,
-			"action":  action,
-			"amount":  amount,
-			"success": success,
+		"payload": eventschema.ActionConfirmedPayload{
+			UserID:  userID,
+			Action:  action,
+			Amount:  amount,
+			Success: success,
 		},
 	}
 
 	msgData, _ := json.Marshal(confirmMsg)
 
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	if clientInterface, exists := bridge.Clients[userID]; exists {
-		type ClientWithSend interface {
-			GetSendChannel() chan []byte
-		}
-		if client, ok := clientInterface.(ClientWithSend); ok {
+	type ClientWithPriority interface {
+		GetPriorityChannel() chan []byte
+	}
+	for _, clientInterface := range bridge.Clients.Of(userID) {
+		if client, ok := clientInterface.(ClientWithPriority); ok {
 			select {
-			case client.GetSendChannel() <- msgData:
+			case client.GetPriorityChannel() <- msgData:
 				log.Printf("[ACTION_CONFIRM] Sent confirmation to user %s for action %s", userID, action)
 			default:
 				log.Printf("[ACTION_CONFIRM] WARNING: Send channel full for user %s", userID)
@@ -463,39 +531,19 @@ func BroadcastPlayerAction(
 
 	actionMsg := map[string]interface{}{
 		"type": "player_action_broadcast",
-		"payload": map[string]interface{}{
-			"user_id":       userID,
-			"player_name":   playerName,
-			"action":        action,
-			"amount":        amount,
-			"betting_round": bettingRound,
-			"pot_after":     pot,
-			"timestamp":     time.Now().Unix(),
+		"payload": eventschema.PlayerActionBroadcastPayload{
+			UserID:       userID,
+			PlayerName:   playerName,
+			Action:       action,
+			Amount:       amount,
+			BettingRound: bettingRound,
+			PotAfter:     pot,
+			Timestamp:    time.Now().Unix(),
 		},
 	}
 
 	msgData, _ := json.Marshal(actionMsg)
-
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	sentCount := 0
-	for _, clientInterface := range bridge.Clients {
-		type ClientWithTable interface {
-			GetTableID() string
-			GetSendChannel() chan []byte
-		}
-		if client, ok := clientInterface.(ClientWithTable); ok {
-			if client.GetTableID() == tableID {
-				select {
-				case client.GetSendChannel() <- msgData:
-					sentCount++
-				default:
-					// Channel full, skip
-				}
-			}
-		}
-	}
+	sentCount := bridge.SendToTable(tableID, msgData)
 
 	log.Printf("[ACTION_BROADCAST] Sent player_action_broadcast to %d clients for table %s", sentCount, tableID)
 }
@@ -503,35 +551,152 @@ func BroadcastPlayerAction(
 // SendGameCompleteMessage sends a game complete message to all clients at a table
 func SendGameCompleteMessage(bridge *game.GameBridge, tableID string, data map[string]interface{}) {
 	gameCompleteMsg := map[string]interface{}{
-		"type": "game_complete",
-		"payload": map[string]interface{}{
-			"winner":       data["winner"],
-			"winnerName":   data["winnerName"],
-			"finalChips":   data["finalChips"],
-			"totalPlayers": data["totalPlayers"],
-			"message":      "Game Over! Winner takes all!",
-		},
+		"type":    "game_complete",
+		"payload": eventschema.NewGameCompletePayload(data, "Game Over! Winner takes all!"),
 	}
 
 	msgData, _ := json.Marshal(gameCompleteMsg)
+	bridge.SendToTable(tableID, msgData)
+	log.Printf("Game complete message sent for table %s", tableID)
+}
+
+// startNextHand deals the next hand at a cash table after a delay, if it
+// still has two players ready to play
+func startNextHand(tableID string, delay time.Duration, bridge *game.GameBridge, broadcastFunc func(string)) {
+	time.Sleep(delay)
 
 	bridge.Mu.RLock()
-	for _, clientInterface := range bridge.Clients {
-		// Type assertion to access TableID and Send
-		type ClientWithTable interface {
-			GetTableID() string
-			GetSendChannel() chan []byte
-		}
-		if client, ok := clientInterface.(ClientWithTable); ok {
-			if client.GetTableID() == tableID {
-				select {
-				case client.GetSendChannel() <- msgData:
-				default:
-					// Channel full, skip
-				}
+	table, exists := bridge.Tables[tableID]
+	bridge.Mu.RUnlock()
+
+	if !exists {
+		log.Printf("[CASH_GAME] Table %s no longer exists, cannot start next hand", tableID)
+		return
+	}
+
+	state := table.GetState()
+	log.Printf("[CASH_GAME] Checking players for next hand on table %s", tableID)
+
+	activeCount := 0
+	totalPlayers := 0
+	for i, p := range state.Players {
+		if p != nil {
+			totalPlayers++
+			log.Printf("[CASH_GAME] Player %d: %s (ID: %s) - Chips: %d, Status: %s",
+				i, p.PlayerName, p.PlayerID, p.Chips, p.Status)
+
+			if p.Status != pokerModels.StatusSittingOut && p.Chips > 0 {
+				activeCount++
+			} else {
+				log.Printf("[CASH_GAME] Player %s not active: Status=%s, Chips=%d",
+					p.PlayerName, p.Status, p.Chips)
 			}
 		}
 	}
-	bridge.Mu.RUnlock()
-	log.Printf("Game complete message sent for table %s", tableID)
+
+	log.Printf("[CASH_GAME] Table %s: Total players: %d, Active players: %d",
+		tableID, totalPlayers, activeCount)
+
+	if activeCount >= 2 {
+		log.Printf("[CASH_GAME] Starting next hand on table %s with %d active players",
+			tableID, activeCount)
+		err := table.StartGame()
+		if err != nil {
+			log.Printf("[CASH_GAME] ERROR: Failed to start next hand on table %s: %v",
+				tableID, err)
+		} else {
+			log.Printf("[CASH_GAME] Successfully started next hand on table %s", tableID)
+			broadcastFunc(tableID)
+		}
+	} else {
+		log.Printf("[CASH_GAME] Cannot start next hand on table %s: Only %d active players (need 2+)",
+			tableID, activeCount)
+	}
+}
+
+// ProcessSeatChange handles a player's request to move to another seat at a cash table.
+// The engine applies the move right away between hands or queues it until the hand ends.
+func ProcessSeatChange(userID, tableID string, seatNumber int, bridge *game.GameBridge) {
+	table, exists := bridge.GetTable(tableID)
+	if !exists {
+		log.Printf("[SEAT_CHANGE] ERROR: Table %s not found", tableID)
+		SendSeatChangeResult(bridge, userID, "error", map[string]interface{}{
+			"message": "Table not found",
+			"code":    "TABLE_NOT_FOUND",
+		})
+		return
+	}
+
+	applied, err := table.RequestSeatChange(userID, seatNumber)
+	if err != nil {
+		log.Printf("[SEAT_CHANGE] Rejected: user=%s table=%s seat=%d: %v", userID, tableID, seatNumber, err)
+		SendSeatChangeResult(bridge, userID, "error", map[string]interface{}{
+			"message": "Seat change rejected: " + err.Error(),
+			"code":    "SEAT_CHANGE_REJECTED",
+		})
+		return
+	}
+
+	msgType := "seat_change_queued"
+	if applied {
+		msgType = "seat_change_applied"
+	}
+	log.Printf("[SEAT_CHANGE] %s: user=%s table=%s seat=%d", msgType, userID, tableID, seatNumber)
+
+	SendSeatChangeResult(bridge, userID, msgType, map[string]interface{}{
+		"table_id":    tableID,
+		"seat_number": seatNumber,
+	})
+}
+
+// ProcessShowCards shows the cards a player who won a pot nobody contested
+// picked, by their index in the hand; none declines to show. The table state
+// that follows carries them.
+func ProcessShowCards(userID, tableID string, cards []int, bridge *game.GameBridge) {
+	table, exists := bridge.GetTable(tableID)
+	if !exists {
+		log.Printf("[SHOW_CARDS] ERROR: Table %s not found", tableID)
+		return
+	}
+
+	if err := table.ShowCards(userID, cards); err != nil {
+		log.Printf("[SHOW_CARDS] Rejected: user=%s table=%s cards=%v: %v", userID, tableID, cards, err)
+		msgData, _ := json.Marshal(map[string]interface{}{
+			"type": "error",
+			"payload": map[string]interface{}{
+				"message": "Show cards rejected: " + err.Error(),
+				"code":    "SHOW_CARDS_REJECTED",
+			},
+		})
+		bridge.SendToUser(userID, msgData)
+	}
+}
+
+// SendSeatChangeResult sends a seat change response to the requesting player
+func SendSeatChangeResult(bridge *game.GameBridge, userID string, msgType string, payload map[string]interface{}) {
+	msgData, _ := json.Marshal(map[string]interface{}{
+		"type":    msgType,
+		"payload": payload,
+	})
+
+	if bridge.SendToUser(userID, msgData) == 0 && bridge.Clients.Connected(userID) {
+		log.Printf("[SEAT_CHANGE] WARNING: Send channel full for user %s", userID)
+	}
+}
+
+// persistSeatChange moves the player's active seat row to the new seat number
+func persistSeatChange(database *db.DB, tableID string, event pokerModels.Event) {
+	data, ok := event.Data.(map[string]interface{})
+	if !ok {
+		return
+	}
+	playerID, _ := data["playerId"].(string)
+	toSeat, _ := data["toSeat"].(int)
+
+	err := database.Model(&models.TableSeat{}).
+		Where("table_id = ? AND user_id = ? AND left_at IS NULL", tableID, playerID).
+		Update("seat_number", toSeat).Error
+	if err != nil {
+		log.Printf("[SEAT_CHANGE] ERROR: Failed to persist seat change for user %s on table %s: %v", playerID, tableID, err)
+	}
 }
@@ -0,0 +1,329 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+// Errors of admin table operations
+var (
+	ErrTableNotFound   = errors.New("table not found")
+	ErrPlayerNotSeated = errors.New("player is not seated at this table")
+	ErrTournamentTable = errors.New("tournament tables are run by their tournament; abort the tournament instead")
+)
+
+// LiveTable is an in-memory engine table as admins see it
+type LiveTable struct {
+	TableID      string            `json:"table_id"`
+	GameType     string            `json:"game_type"`
+	Status       string            `json:"status"`
+	HandNumber   int               `json:"hand_number"`
+	HandID       int64             `json:"hand_id,omitempty"`
+	BettingRound string            `json:"betting_round,omitempty"`
+	Pot          int               `json:"pot"`
+	LastAction   *time.Time        `json:"last_action_at,omitempty"`
+	Held         bool              `json:"held"`
+	Players      []LiveTablePlayer `json:"players"`
+	PendingKicks []string          `json:"pending_kicks,omitempty"`
+}
+
+// LiveTablePlayer is a seat of a LiveTable
+type LiveTablePlayer struct {
+	Seat     int    `json:"seat"`
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Chips    int    `json:"chips"`
+	Status   string `json:"status"`
+	ToAct    bool   `json:"to_act"`
+}
+
+// LiveTables lists every table the engine holds in memory, ordered by ID
+func (b *GameBridge) LiveTables() []LiveTable {
+	b.Mu.RLock()
+	tableIDs := make([]string, 0, len(b.Tables))
+	for tableID := range b.Tables {
+		tableIDs = append(tableIDs, tableID)
+	}
+	b.Mu.RUnlock()
+	sort.Strings(tableIDs)
+
+	result := make([]LiveTable, 0, len(tableIDs))
+	for _, tableID := range tableIDs {
+		table, exists := b.GetTable(tableID)
+		if !exists {
+			continue
+		}
+		state := table.Snapshot()
+		live := LiveTable{
+			TableID:      tableID,
+			GameType:     string(state.GameType),
+			Status:       string(state.Status),
+			Held:         b.HandHolds.IsHeld(tableID) || table.IsHeldBetweenHands(),
+			Players:      []LiveTablePlayer{},
+			PendingKicks: b.Kicks.For(tableID),
+		}
+		live.HandID, _ = b.GetCurrentHandID(tableID)
+		toAct := -1
+		if hand := state.CurrentHand; hand != nil {
+			live.HandNumber = hand.HandNumber
+			live.BettingRound = string(hand.BettingRound)
+			live.Pot = hand.Pot.Main + SumSidePots(hand.Pot.Side)
+			if !hand.LastActionTime.IsZero() {
+				lastAction := hand.LastActionTime
+				live.LastAction = &lastAction
+			}
+			if state.Status == pokerModels.StatusPlaying {
+				toAct = hand.CurrentPosition
+			}
+		}
+		for i, p := range state.Players {
+			if p == nil {
+				continue
+			}
+			live.Players = append(live.Players, LiveTablePlayer{
+				Seat:     i,
+				UserID:   p.PlayerID,
+				Username: p.PlayerName,
+				Chips:    p.Chips,
+				Status:   string(p.Status),
+				ToAct:    i == toAct,
+			})
+		}
+		result = append(result, live)
+	}
+	return result
+}
+
+// PendingKicks holds players an admin kicked during a hand, who leave their
+// table once the hand is over
+type PendingKicks struct {
+	mu      sync.Mutex
+	pending map[string]map[string]bool // tableID -> userIDs
+}
+
+// NewPendingKicks creates an empty set
+func NewPendingKicks() *PendingKicks {
+	return &PendingKicks{pending: make(map[string]map[string]bool)}
+}
+
+func (k *PendingKicks) add(tableID, userID string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.pending[tableID] == nil {
+		k.pending[tableID] = make(map[string]bool)
+	}
+	k.pending[tableID][userID] = true
+}
+
+// For returns the players waiting to be kicked from a table, sorted
+func (k *PendingKicks) For(tableID string) []string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	var userIDs []string
+	for userID := range k.pending[tableID] {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+	return userIDs
+}
+
+func (k *PendingKicks) take(tableID string) []string {
+	userIDs := k.For(tableID)
+	k.mu.Lock()
+	delete(k.pending, tableID)
+	k.mu.Unlock()
+	return userIDs
+}
+
+// cashTable loads a live table and its database row, refusing tournament tables
+func (b *GameBridge) cashTable(database *db.DB, tableID string) (*models.Table, error) {
+	if _, exists := b.GetTable(tableID); !exists {
+		return nil, ErrTableNotFound
+	}
+	var dbTable models.Table
+	if err := database.Select("id", "game_type", "club_id", "tournament_id", "creator_id").Where("id = ?", tableID).First(&dbTable).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTableNotFound
+		}
+		return nil, err
+	}
+	if dbTable.TournamentID != nil {
+		return nil, ErrTournamentTable
+	}
+	return &dbTable, nil
+}
+
+// KickPlayer removes a player from a live cash table and returns the chips
+// they were cashed out with. A player in a hand is folded at once and leaves
+// when the hand is over, in which case pending is true.
+func (b *GameBridge) KickPlayer(database *db.DB, tableID, userID string) (chips int, pending bool, err error) {
+	dbTable, err := b.cashTable(database, tableID)
+	if err != nil {
+		return 0, false, err
+	}
+	return b.kickPlayer(database, dbTable, userID)
+}
+
+func (b *GameBridge) kickPlayer(database *db.DB, dbTable *models.Table, userID string) (int, bool, error) {
+	table, exists := b.GetTable(dbTable.ID)
+	if !exists {
+		return 0, false, ErrTableNotFound
+	}
+	player, err := table.RemovePlayer(userID)
+	if errors.Is(err, engine.ErrPlayerToAct) {
+		return 0, false, err
+	}
+	if err != nil {
+		return 0, false, ErrPlayerNotSeated
+	}
+	if player == nil {
+		// A hand is in progress; the player leaves when it ends
+		b.Kicks.add(dbTable.ID, userID)
+		return 0, true, nil
+	}
+	return player.Chips, false, b.cashOutKicked(database, dbTable, userID, player.Chips)
+}
+
+// ApplyPendingKicks unseats and cashes out the players kicked from a table
+// during the hand that just ended
+func ApplyPendingKicks(bridge *GameBridge, database *db.DB, tableID string) {
+	userIDs := bridge.Kicks.take(tableID)
+	if len(userIDs) == 0 {
+		return
+	}
+	table, exists := bridge.GetTable(tableID)
+	if !exists {
+		return
+	}
+	var dbTable models.Table
+	if err := database.Select("id", "club_id").Where("id = ?", tableID).First(&dbTable).Error; err != nil {
+		log.Printf("[ADMIN] Failed to load table %s for pending kicks: %v", tableID, err)
+		return
+	}
+	for _, userID := range userIDs {
+		player, err := table.UnseatPlayer(userID)
+		if err != nil {
+			log.Printf("[ADMIN] Failed to unseat kicked player %s from table %s: %v", userID, tableID, err)
+			continue
+		}
+		if err := bridge.cashOutKicked(database, &dbTable, userID, player.Chips); err != nil {
+			log.Printf("[ADMIN] ERROR: Kicked player %s left table %s but %d chips were not returned: %v",
+				userID, tableID, player.Chips, err)
+			continue
+		}
+		log.Printf("[ADMIN] Kicked player %s left table %s with %d chips", userID, tableID, player.Chips)
+	}
+}
+
+func (b *GameBridge) cashOutKicked(database *db.DB, dbTable *models.Table, userID string, chips int) error {
+	for _, session := range b.Sessions.End(dbTable.ID, userID) {
+		if err := persistSession(database.DB, session); err != nil {
+			log.Printf("[SESSION] Failed to store session for user %s at table %s: %v", userID, dbTable.ID, err)
+		}
+	}
+	return database.Transaction(func(tx *gorm.DB) error {
+		return cashOutSeat(tx, dbTable.ClubID, dbTable.ID, userID, chips)
+	})
+}
+
+// ForceCompleteTable ends a stuck cash table for an admin. A hand in progress
+// is called off and every player gets back what they put into it, then each
+// stack is cashed out, the table is marked completed and dropped from memory.
+// It returns the chips returned to each player.
+func ForceCompleteTable(bridge *GameBridge, database *db.DB, tableID string) (map[string]int, error) {
+	dbTable, err := bridge.cashTable(database, tableID)
+	if err != nil {
+		return nil, err
+	}
+	return closeCashTable(bridge, database, dbTable,
+		"This table was closed by an administrator. Your chips have been returned.")
+}
+
+// closeCashTable takes a live cash table apart as ForceCompleteTable
+// describes, telling everyone at it why with message
+func closeCashTable(bridge *GameBridge, database *db.DB, dbTable *models.Table, message string) (map[string]int, error) {
+	tableID := dbTable.ID
+	table, exists := bridge.GetTable(tableID)
+	if !exists {
+		return nil, ErrTableNotFound
+	}
+
+	// Nothing may be dealt or acted on while the table is taken apart
+	table.HoldBetweenHands(true)
+	table.Stop()
+	bridge.Mu.Lock()
+	delete(bridge.Tables, tableID)
+	delete(bridge.CurrentHandIDs, tableID)
+	bridge.Mu.Unlock()
+	bridge.Kicks.take(tableID)
+	if bridge.HandStates != nil {
+		if err := bridge.HandStates.Delete(tableID); err != nil {
+			log.Printf("[ADMIN] %v", err)
+		}
+	}
+
+	// A paused hand is still in progress: its bets are returned like any other
+	state := table.Snapshot()
+	voided := state.CurrentHand != nil &&
+		(state.Status == pokerModels.StatusPlaying || state.Status == pokerModels.StatusPaused)
+	EndTableSessions(bridge, database, tableID)
+
+	returned := make(map[string]int)
+	var failed []string
+	for _, p := range state.Players {
+		if p == nil {
+			continue
+		}
+		chips := p.Chips
+		if voided {
+			chips += p.TotalInvestedThisHand
+		}
+		if chips <= 0 {
+			continue
+		}
+		if err := database.Transaction(func(tx *gorm.DB) error {
+			return cashOutSeat(tx, dbTable.ClubID, tableID, p.PlayerID, chips)
+		}); err != nil {
+			log.Printf("[ADMIN] ERROR: Failed to return %d chips to %s from table %s: %v", chips, p.PlayerID, tableID, err)
+			failed = append(failed, p.PlayerID)
+			continue
+		}
+		returned[p.PlayerID] = chips
+	}
+
+	now := time.Now()
+	if err := database.Model(&models.Table{}).Where("id = ?", tableID).Updates(map[string]interface{}{
+		"status":       "completed",
+		"completed_at": &now,
+	}).Error; err != nil {
+		log.Printf("[ADMIN] Failed to mark table %s completed: %v", tableID, err)
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "table_closed",
+		"payload": eventschema.TableClosedPayload{
+			TableID:    tableID,
+			HandVoided: voided,
+			Message:    message,
+		},
+	})
+	bridge.SendToTable(tableID, data)
+
+	if len(failed) > 0 {
+		return returned, fmt.Errorf("failed to return chips to %v", failed)
+	}
+	return returned, nil
+}
@@ -0,0 +1,47 @@
+package game
+
+import "testing"
+
+func TestLiveTables(t *testing.T) {
+	bridge := NewGameBridge()
+	tableB := newLookupTable(bridge, "table-b")
+	newLookupTable(bridge, "table-a")
+
+	if err := tableB.AddPlayer("alice", "Alice", 3, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	bridge.Kicks.add("table-b", "alice")
+
+	tables := bridge.LiveTables()
+	if len(tables) != 2 || tables[0].TableID != "table-a" || tables[1].TableID != "table-b" {
+		t.Fatalf("Expected both tables ordered by ID, got %+v", tables)
+	}
+	if len(tables[0].Players) != 0 {
+		t.Errorf("Expected an empty table, got %+v", tables[0].Players)
+	}
+	b := tables[1]
+	if len(b.Players) != 1 || b.Players[0].UserID != "alice" || b.Players[0].Seat != 3 || b.Players[0].Chips != 500 {
+		t.Errorf("Unexpected players: %+v", b.Players)
+	}
+	if len(b.PendingKicks) != 1 || b.PendingKicks[0] != "alice" {
+		t.Errorf("Expected alice's kick to be pending, got %v", b.PendingKicks)
+	}
+}
+
+func TestPendingKicks_TakeClearsTable(t *testing.T) {
+	kicks := NewPendingKicks()
+	kicks.add("table-a", "bob")
+	kicks.add("table-a", "alice")
+	kicks.add("table-a", "bob")
+	kicks.add("table-b", "carol")
+
+	if got := kicks.take("table-a"); len(got) != 2 || got[0] != "alice" || got[1] != "bob" {
+		t.Errorf("Expected alice and bob, got %v", got)
+	}
+	if got := kicks.For("table-a"); len(got) != 0 {
+		t.Errorf("Expected no kicks left at table-a, got %v", got)
+	}
+	if got := kicks.For("table-b"); len(got) != 1 {
+		t.Errorf("Expected carol's kick to stay, got %v", got)
+	}
+}
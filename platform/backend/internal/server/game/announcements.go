@@ -0,0 +1,119 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/announcements"
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/models"
+)
+
+// AnnouncementInterval is how often scheduled announcements are checked
+const AnnouncementInterval = 15 * time.Second
+
+// SendToUsers sends a message to the connected clients of the given users, or
+// to every connected client when userIDs is nil
+func (b *GameBridge) SendToUsers(userIDs map[string]bool, data []byte) int {
+	type Sender interface {
+		GetSendChannel() chan []byte
+	}
+	sent := 0
+	b.Clients.Each(func(userID string, clientInterface interface{}) {
+		if userIDs != nil && !userIDs[userID] {
+			return
+		}
+		if sender, ok := clientInterface.(Sender); ok {
+			select {
+			case sender.GetSendChannel() <- data:
+				sent++
+			default:
+				// Channel full, skip
+			}
+		}
+	})
+	return sent
+}
+
+// AnnouncementMessage encodes the announcement message clients show as a banner
+func AnnouncementMessage(a models.Announcement) []byte {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "announcement",
+		"payload": eventschema.AnnouncementPayload{
+			ID:        a.ID,
+			Kind:      a.Kind,
+			Message:   a.Message,
+			StartsAt:  a.StartsAt,
+			ExpiresAt: a.ExpiresAt,
+		},
+	})
+	return data
+}
+
+// SendAnnouncement delivers an announcement to its audience and returns how
+// many clients got it
+func SendAnnouncement(b *GameBridge, database *db.DB, a models.Announcement) int {
+	data := AnnouncementMessage(a)
+	switch a.Target {
+	case announcements.TargetTable:
+		return b.SendToTable(*a.TargetID, data)
+	case announcements.TargetTournament:
+		var userIDs []string
+		if err := database.Model(&models.TournamentPlayer{}).
+			Where("tournament_id = ? AND eliminated_at IS NULL", *a.TargetID).
+			Pluck("user_id", &userIDs).Error; err != nil {
+			log.Printf("[ANNOUNCEMENT] Failed to load players of tournament %s: %v", *a.TargetID, err)
+			return 0
+		}
+		players := make(map[string]bool, len(userIDs))
+		for _, id := range userIDs {
+			players[id] = true
+		}
+		return b.SendToUsers(players, data)
+	default:
+		return b.SendToUsers(nil, data)
+	}
+}
+
+// SendAnnouncementCancelled tells every client to take an announcement down
+func SendAnnouncementCancelled(b *GameBridge, id string) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type":    "announcement_cancelled",
+		"payload": eventschema.AnnouncementCancelledPayload{ID: id},
+	})
+	b.SendToUsers(nil, data)
+}
+
+// DeliverDueAnnouncements sends every announcement whose time has come. Each
+// is claimed before it is sent, so it goes out once.
+func DeliverDueAnnouncements(b *GameBridge, database *db.DB, now time.Time) {
+	due, err := announcements.Due(database.DB, now)
+	if err != nil {
+		log.Printf("[ANNOUNCEMENT] Failed to load due announcements: %v", err)
+		return
+	}
+	for _, a := range due {
+		claimed, err := announcements.Claim(database.DB, a.ID, now)
+		if err != nil || !claimed {
+			continue
+		}
+		sent := SendAnnouncement(b, database, a)
+		log.Printf("[ANNOUNCEMENT] Sent %s announcement %s (%s) to %d clients", a.Kind, a.ID, a.Target, sent)
+	}
+}
+
+// RunAnnouncements delivers scheduled announcements until stop is closed
+func RunAnnouncements(b *GameBridge, database *db.DB, stop <-chan struct{}) {
+	ticker := time.NewTicker(AnnouncementInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			DeliverDueAnnouncements(b, database, now)
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"poker-platform/backend/internal/models"
+)
+
+func TestSendToUsers(t *testing.T) {
+	bridge := NewGameBridge()
+	alice := newChannelClient(bridge, "alice", "table-a")
+	bob := newChannelClient(bridge, "bob", "")
+
+	if sent := bridge.SendToUsers(map[string]bool{"alice": true}, []byte("hello")); sent != 1 {
+		t.Fatalf("Expected 1 targeted client, sent to %d", sent)
+	}
+	if len(alice) != 1 || len(bob) != 0 {
+		t.Errorf("Expected only alice to get the targeted message")
+	}
+
+	if sent := bridge.SendToUsers(nil, []byte("everyone")); sent != 2 {
+		t.Errorf("Expected every client to get the broadcast, sent to %d", sent)
+	}
+}
+
+func TestAnnouncementMessage(t *testing.T) {
+	var msg struct {
+		Type    string                 `json:"type"`
+		Payload map[string]interface{} `json:"payload"`
+	}
+	data := AnnouncementMessage(models.Announcement{ID: "a-1", Kind: "maintenance", Message: "Restart at 3am"})
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("Failed to decode announcement: %v", err)
+	}
+	if msg.Type != "announcement" || msg.Payload["kind"] != "maintenance" || msg.Payload["message"] != "Restart at 3am" {
+		t.Errorf("Unexpected announcement message: %s", data)
+	}
+}
@@ -0,0 +1,31 @@
+package game
+
+import "log"
+
+// ShowPlayerAvatar sets a player's avatar at a table they just sat down at
+func (b *GameBridge) ShowPlayerAvatar(tableID, userID string) {
+	if b.Avatars == nil {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	url, err := b.Avatars.URL(userID)
+	if err != nil {
+		log.Printf("[AVATAR] Failed to load avatar of %s: %v", userID, err)
+		return
+	}
+	table.SetPlayerAvatar(userID, url)
+}
+
+// SetPlayerAvatar shows a player's new avatar, or none with an empty URL, at
+// every table they are seated at
+func (b *GameBridge) SetPlayerAvatar(userID, url string) {
+	b.Mu.RLock()
+	defer b.Mu.RUnlock()
+	for _, table := range b.Tables {
+		// Tables the player isn't at report them not found
+		_ = table.SetPlayerAvatar(userID, url)
+	}
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"log"
+	"sync"
+)
+
+// AwayAfterMissedAcks is how many turns in a row a connected client can leave
+// unacknowledged before its player is marked away
+const AwayAfterMissedAcks = 2
+
+// ackState follows one seat's acknowledgements of its turn notifications
+type ackState struct {
+	outstanding bool // The last action_required has not been acked
+	missed      int  // Turns in a row whose notification was never acked
+	away        bool
+}
+
+// AwayDetector spots players whose socket is live but whose client has stopped
+// responding, from the acks of clients that negotiated the action_ack feature.
+// Clients without the feature are never marked away.
+type AwayDetector struct {
+	mu    sync.Mutex
+	seats map[string]map[string]*ackState // tableID -> userID -> state
+}
+
+// NewAwayDetector creates an empty detector
+func NewAwayDetector() *AwayDetector {
+	return &AwayDetector{seats: make(map[string]map[string]*ackState)}
+}
+
+// TurnNotified records an action_required delivered to an acking client. It
+// returns true when the player has just crossed the threshold and should be
+// marked away.
+func (d *AwayDetector) TurnNotified(tableID, userID string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	table := d.seats[tableID]
+	if table == nil {
+		table = make(map[string]*ackState)
+		d.seats[tableID] = table
+	}
+	state := table[userID]
+	if state == nil {
+		state = &ackState{}
+		table[userID] = state
+	}
+
+	if state.outstanding {
+		state.missed++
+	}
+	state.outstanding = true
+	if state.missed >= AwayAfterMissedAcks && !state.away {
+		state.away = true
+		return true
+	}
+	return false
+}
+
+// Acked records an ack or an action from the player. It returns true when the
+// player was away and is now back.
+func (d *AwayDetector) Acked(tableID, userID string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state := d.seats[tableID][userID]
+	if state == nil {
+		return false
+	}
+	wasAway := state.away
+	*state = ackState{}
+	return wasAway
+}
+
+// Forget drops everything known about a table
+func (d *AwayDetector) Forget(tableID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.seats, tableID)
+}
+
+// AcknowledgeTurn handles an action_ack from a player's client, bringing the
+// player back if they had been marked away
+func (b *GameBridge) AcknowledgeTurn(tableID, userID string) {
+	if !b.Away.Acked(tableID, userID) {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	if err := table.SetPlayerAway(userID, false); err != nil {
+		log.Printf("[AWAY] Failed to mark %s back on table %s: %v", userID, tableID, err)
+		return
+	}
+	log.Printf("[AWAY] Player %s is back on table %s", userID, tableID)
+}
+
+// recordTurnNotified counts a turn notification towards away detection and
+// marks the player away once they have missed enough acks
+func (b *GameBridge) recordTurnNotified(tableID, userID string) {
+	if !b.Away.TurnNotified(tableID, userID) {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	if err := table.SetPlayerAway(userID, true); err != nil {
+		log.Printf("[AWAY] Failed to mark %s away on table %s: %v", userID, tableID, err)
+		return
+	}
+	log.Printf("[AWAY] Player %s missed %d turn acks on table %s - marked away", userID, AwayAfterMissedAcks, tableID)
+}
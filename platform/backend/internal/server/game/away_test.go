@@ -0,0 +1,53 @@
+package game
+
+import "testing"
+
+func TestAwayDetector_MarksAwayAfterMissedAcks(t *testing.T) {
+	d := NewAwayDetector()
+
+	// The first notification is outstanding, not yet missed
+	if d.TurnNotified("table-1", "alice") {
+		t.Fatal("Expected no away after one notification")
+	}
+	for i := 1; i < AwayAfterMissedAcks; i++ {
+		if d.TurnNotified("table-1", "alice") {
+			t.Fatalf("Expected no away after %d missed acks", i)
+		}
+	}
+	if !d.TurnNotified("table-1", "alice") {
+		t.Fatalf("Expected away after %d missed acks", AwayAfterMissedAcks)
+	}
+	if d.TurnNotified("table-1", "alice") {
+		t.Error("Expected away to be reported only once")
+	}
+
+	// Other seats are tracked separately
+	if d.TurnNotified("table-2", "alice") || d.TurnNotified("table-1", "bob") {
+		t.Error("Expected other seats to be unaffected")
+	}
+
+	if !d.Acked("table-1", "alice") {
+		t.Error("Expected an ack to bring the player back")
+	}
+	if d.Acked("table-1", "alice") {
+		t.Error("Expected a second ack not to report a change")
+	}
+	if d.TurnNotified("table-1", "alice") {
+		t.Error("Expected the count to start again after an ack")
+	}
+}
+
+func TestAwayDetector_AckedTurnsNeverMarkAway(t *testing.T) {
+	d := NewAwayDetector()
+	for i := 0; i < 10; i++ {
+		if d.TurnNotified("table-1", "alice") {
+			t.Fatalf("Expected a player acking every turn never to be marked away (turn %d)", i+1)
+		}
+		d.Acked("table-1", "alice")
+	}
+
+	d.Forget("table-1")
+	if d.Acked("table-1", "alice") {
+		t.Error("Expected a forgotten table to have no state")
+	}
+}
@@ -0,0 +1,188 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/bot"
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+var ErrBotsDisabled = errors.New("bots are not available")
+
+// DefaultBotThinkTime is how long a bot waits before it acts, so its play
+// doesn't look instant next to people's
+const DefaultBotThinkTime = 1500 * time.Millisecond
+
+// BotPlayers acts for the bots seated at the tables. A bot's action goes
+// down the same path as a player's, so it is validated, recorded and
+// broadcast the same way.
+type BotPlayers struct {
+	Service *bot.Service
+	Act     func(userID, tableID, action, requestID string, amount int) // Processes an action as the game action path does
+	Think   time.Duration
+
+	mu     sync.Mutex
+	seated map[string]*seatedBot // userID -> bot
+}
+
+// seatedBot decides for one bot, one turn at a time
+type seatedBot struct {
+	mu  sync.Mutex
+	bot *engine.Bot
+}
+
+// NewBotPlayers creates bot players that act through act
+func NewBotPlayers(service *bot.Service, act func(userID, tableID, action, requestID string, amount int)) *BotPlayers {
+	return &BotPlayers{Service: service, Act: act, Think: DefaultBotThinkTime, seated: make(map[string]*seatedBot)}
+}
+
+func (p *BotPlayers) get(userID string) *seatedBot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.seated[userID]
+}
+
+func (p *BotPlayers) add(userID, difficulty string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, exists := p.seated[userID]; exists {
+		return nil
+	}
+	b, err := engine.NewBot(difficulty, time.Now().UnixNano())
+	if err != nil {
+		return err
+	}
+	p.seated[userID] = &seatedBot{bot: b}
+	return nil
+}
+
+// ShowPlayerBot marks a player who just sat down as a bot, if they are one,
+// and starts acting for them
+func (b *GameBridge) ShowPlayerBot(tableID, userID string) {
+	if b.Bots == nil {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	difficulty, isBot, err := b.Bots.Service.Difficulty(userID)
+	if err != nil {
+		log.Printf("[BOT] Failed to check whether %s is a bot: %v", userID, err)
+		return
+	}
+	if !isBot {
+		return
+	}
+	if err := b.Bots.add(userID, difficulty); err != nil {
+		log.Printf("[BOT] Failed to start bot %s: %v", userID, err)
+		return
+	}
+	table.SetPlayerBot(userID, true)
+}
+
+// AddBot seats a bot at a live cash table for its creator or an admin. The
+// caller adds the bot to the engine table, which starts it playing.
+func (b *GameBridge) AddBot(ctx context.Context, database *db.DB, tableID, userID, difficulty string, buyIn int) (*bot.Seated, error) {
+	if b.Bots == nil {
+		return nil, ErrBotsDisabled
+	}
+	dbTable, err := b.cashTable(database, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if dbTable.CreatorID == nil || *dbTable.CreatorID != userID {
+		var user models.User
+		if err := database.Select("id", "role").Where("id = ?", userID).First(&user).Error; err != nil || user.Role != models.RoleAdmin {
+			return nil, ErrNotTableCreator
+		}
+	}
+	return b.Bots.Service.Seat(ctx, tableID, difficulty, buyIn)
+}
+
+// RecordBotEvent acts for a bot whose turn it is, after its think time.
+// Other events are ignored.
+func (b *GameBridge) RecordBotEvent(tableID string, event pokerModels.Event) {
+	if b.Bots == nil || event.Event != "actionRequired" {
+		return
+	}
+	turn, ok := event.Data.(pokerModels.ActionRequiredEvent)
+	if !ok {
+		return
+	}
+	seated := b.Bots.get(turn.PlayerID)
+	if seated == nil {
+		return
+	}
+	go func() {
+		time.Sleep(b.Bots.Think)
+		b.actForBot(tableID, seated, turn)
+	}()
+}
+
+func (b *GameBridge) actForBot(tableID string, seated *seatedBot, turn pokerModels.ActionRequiredEvent) {
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	state := table.Snapshot()
+	hand := state.CurrentHand
+	// The turn may have passed while the bot thought, such as to a timeout
+	if hand == nil || hand.CurrentPosition < 0 || hand.CurrentPosition >= len(state.Players) ||
+		state.Players[hand.CurrentPosition] == nil || state.Players[hand.CurrentPosition].PlayerID != turn.PlayerID {
+		return
+	}
+
+	seated.mu.Lock()
+	action, amount := seated.bot.Decide(state, turn)
+	seated.mu.Unlock()
+
+	requestID := fmt.Sprintf("bot-%s-%d-%d", tableID, hand.HandNumber, hand.ActionSequence)
+	b.Bots.Act(turn.PlayerID, tableID, string(action), requestID, amount)
+}
+
+// ReleaseBots unseats and cashes out the bots at a table once no player with
+// chips is left for them to play, so bots never play only each other
+func ReleaseBots(bridge *GameBridge, database *db.DB, tableID string) {
+	if bridge.Bots == nil {
+		return
+	}
+	table, exists := bridge.GetTable(tableID)
+	if !exists {
+		return
+	}
+	var bots []string
+	for _, player := range table.Snapshot().Players {
+		if player == nil {
+			continue
+		}
+		if !player.IsBot && player.Chips > 0 {
+			return
+		}
+		if player.IsBot {
+			bots = append(bots, player.PlayerID)
+		}
+	}
+	for _, userID := range bots {
+		chips, pending, err := bridge.KickPlayer(database, tableID, userID)
+		if err != nil {
+			log.Printf("[BOT] Failed to release bot %s from table %s: %v", userID, tableID, err)
+			continue
+		}
+		bridge.Bots.mu.Lock()
+		delete(bridge.Bots.seated, userID)
+		bridge.Bots.mu.Unlock()
+		if !pending {
+			log.Printf("[BOT] Bot %s left table %s with %d chips", userID, tableID, chips)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+func TestBotPlayers_ActOnTheirTurn(t *testing.T) {
+	bridge := NewGameBridge()
+	type acted struct{ userID, action, requestID string }
+	var actions []acted
+	bridge.Bots = NewBotPlayers(nil, func(userID, tableID, action, requestID string, amount int) {
+		actions = append(actions, acted{userID, action, requestID})
+	})
+	if err := bridge.Bots.add("bot-1", engine.BotMedium); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	// Live tables time turns out, which publishes each turn as it passes
+	config := pokerModels.TableConfig{SmallBlind: 5, BigBlind: 10, MaxPlayers: 6, ActionTimeout: 60}
+	table := engine.NewTable("table-a", pokerModels.GameTypeCash, config, func(string, uint64) {}, func(pokerModels.Event) {})
+	bridge.AddTable("table-a", table)
+	table.GetGame().SetSynchronousEvents(true)
+	table.AddPlayer("alice", "Alice", 0, 500)
+	table.AddPlayer("bot-1", "Bot", 1, 500)
+	if err := table.SetPlayerBot("bot-1", true); err != nil {
+		t.Fatalf("SetPlayerBot failed: %v", err)
+	}
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+
+	current := func() string {
+		state := table.Snapshot()
+		return state.Players[state.CurrentHand.CurrentPosition].PlayerID
+	}
+	turn := pokerModels.ActionRequiredEvent{
+		PlayerID:     "bot-1",
+		LegalActions: []pokerModels.PlayerAction{pokerModels.ActionFold, pokerModels.ActionCall, pokerModels.ActionRaise, pokerModels.ActionAllIn},
+		CallAmount:   5,
+		MinRaiseTo:   20,
+		MaxRaiseTo:   500,
+	}
+	seated := bridge.Bots.get("bot-1")
+
+	// A person's turn is left to them, and a bot acts only on its own
+	bridge.RecordBotEvent("table-a", pokerModels.Event{Event: "actionRequired", Data: pokerModels.ActionRequiredEvent{PlayerID: "alice"}})
+	botFirst := current() == "bot-1"
+	if !botFirst {
+		bridge.actForBot("table-a", seated, turn)
+		if len(actions) != 0 {
+			t.Fatalf("Expected no action on alice's turn, got %+v", actions)
+		}
+		if err := table.ProcessAction("alice", pokerModels.ActionCall, 0); err != nil {
+			t.Fatalf("ProcessAction failed: %v", err)
+		}
+		turn.LegalActions[1], turn.CallAmount = pokerModels.ActionCheck, 0
+	}
+
+	bridge.actForBot("table-a", seated, turn)
+	if len(actions) != 1 || actions[0].userID != "bot-1" || !strings.HasPrefix(actions[0].requestID, "bot-table-a-") {
+		t.Fatalf("Expected one action for the bot, got %+v", actions)
+	}
+	legal := false
+	for _, action := range turn.LegalActions {
+		legal = legal || string(action) == actions[0].action
+	}
+	if !legal {
+		t.Errorf("Expected a legal action, got %s", actions[0].action)
+	}
+
+	// Once the turn has passed, a late decision is dropped
+	if botFirst {
+		if err := table.ProcessAction("bot-1", pokerModels.ActionCall, 0); err != nil {
+			t.Fatalf("ProcessAction failed: %v", err)
+		}
+		bridge.actForBot("table-a", seated, turn)
+		if len(actions) != 1 {
+			t.Errorf("Expected no action after the turn passed, got %+v", actions)
+		}
+	}
+}
@@ -3,6 +3,15 @@ package game
 import (
 	"sync"
 
+	"poker-platform/backend/internal/avatar"
+	"poker-platform/backend/internal/cluster"
+	"poker-platform/backend/internal/fairness"
+	"poker-platform/backend/internal/leaderboard"
+	"poker-platform/backend/internal/mission"
+	"poker-platform/backend/internal/profile"
+	"poker-platform/backend/internal/progression"
+	"poker-platform/backend/internal/referral"
+
 	"poker-engine/engine"
 )
 
@@ -10,21 +19,48 @@ import (
 type GameBridge struct {
 	Mu               sync.RWMutex
 	Tables           map[string]*engine.Table
-	Clients          map[string]interface{} // Stores client connections (must implement GetTableID() and GetSendChannel())
+	Clients          *ClientRegistry        // Open WebSocket connections by user (must implement GetTableID() and GetSendChannel())
 	CurrentHandIDs   map[string]int64       // tableID -> current hand database ID
 	MatchmakingMu    sync.Mutex
-	MatchmakingQueue map[string][]string   // gameMode -> []userIDs
-	ActionTracker    *ActionTracker        // Tracks processed actions for idempotency
+	MatchmakingQueue map[string][]string    // gameMode -> []userIDs
+	ActionTracker    *ActionTracker         // Tracks processed actions for idempotency
+	TableStats       map[string]*TableStats // tableID -> rolling lobby stats
+	Sessions         *SessionTracker        // Live per-seat session time and hand counts
+	Spectators       *SpectatorHub          // Delayed feeds for spectators; nil delays nothing
+	HandHolds        *HandHolds             // Tables a tournament director has paused between hands
+	Fairness         *fairness.Analyzer     // Deck fairness statistics; nil records nothing
+	Leaderboards     *leaderboard.Service   // Daily, weekly and monthly rankings; nil records nothing
+	Progression      *progression.Service   // Experience and levels; nil grants nothing
+	Missions         *mission.Service       // Daily missions; nil counts nothing
+	Referrals        *referral.Service      // Referral milestones; nil counts nothing
+	Profiles         *profile.Service       // Public profile stats; nil records nothing
+	Avatars          *avatar.Service        // Avatars shown at the tables; nil shows none
+	Bots             *BotPlayers            // Acts for bots at the tables; nil seats none
+	Away             *AwayDetector          // Players whose clients stopped acking their turns
+	Frozen           *FrozenPlayers         // Accounts whose game actions are refused
+	Kicks            *PendingKicks          // Players kicked during a hand, unseated when it ends
+	Practice         *PracticeTables        // Practice tables dealing an exact spot, apart from Tables
+	HandStates       *HandStateStore        // Hands in progress saved for crash recovery; nil saves nothing
+	Cluster          *cluster.Node          // Shares tables with other instances; nil runs every table here
+	RemoteSeats      map[string]map[string]bool // tableID -> players seated at a table another instance runs
 }
 
 // NewGameBridge creates a new game bridge instance
 func NewGameBridge() *GameBridge {
 	return &GameBridge{
 		Tables:           make(map[string]*engine.Table),
-		Clients:          make(map[string]interface{}),
+		Clients:          NewClientRegistry(),
 		CurrentHandIDs:   make(map[string]int64),
 		MatchmakingQueue: make(map[string][]string),
 		ActionTracker:    NewActionTracker(),
+		TableStats:       make(map[string]*TableStats),
+		Sessions:         NewSessionTracker(),
+		HandHolds:        NewHandHolds(),
+		Away:             NewAwayDetector(),
+		Frozen:           NewFrozenPlayers(),
+		Kicks:            NewPendingKicks(),
+		Practice:         NewPracticeTables(),
+		RemoteSeats:      make(map[string]map[string]bool),
 	}
 }
 
@@ -0,0 +1,126 @@
+package game
+
+import "sync"
+
+// MaxConnectionsPerUser caps the WebSocket connections one user can hold open
+// at once; opening another closes their oldest
+const MaxConnectionsPerUser = 5
+
+// ClientRegistry holds every open WebSocket connection, grouped by user, so a
+// user can play from several tabs or devices at once. Like the rest of the
+// bridge it stores connections as interface{}; senders assert the methods they
+// need, such as GetTableID() and GetSendChannel().
+type ClientRegistry struct {
+	mu    sync.RWMutex
+	users map[string][]registeredClient // Oldest connection first
+}
+
+type registeredClient struct {
+	connectionID string
+	client       interface{}
+}
+
+// NewClientRegistry creates an empty registry
+func NewClientRegistry() *ClientRegistry {
+	return &ClientRegistry{users: make(map[string][]registeredClient)}
+}
+
+// Add registers a connection. It returns the connections closed to make room
+// under MaxConnectionsPerUser, which the caller must disconnect, and how many
+// the user now has.
+func (r *ClientRegistry) Add(userID, connectionID string, client interface{}) (evicted []interface{}, count int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	connections := append(r.users[userID], registeredClient{connectionID: connectionID, client: client})
+	for len(connections) > MaxConnectionsPerUser {
+		evicted = append(evicted, connections[0].client)
+		connections = connections[1:]
+	}
+	r.users[userID] = connections
+	return evicted, len(connections)
+}
+
+// Remove unregisters a connection and returns how many the user still has.
+// removed is false if the connection was already gone, such as one evicted by
+// Add.
+func (r *ClientRegistry) Remove(userID, connectionID string) (remaining int, removed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	connections := r.users[userID]
+	for i, c := range connections {
+		if c.connectionID == connectionID {
+			connections = append(connections[:i:i], connections[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	if len(connections) == 0 {
+		delete(r.users, userID)
+	} else {
+		r.users[userID] = connections
+	}
+	return len(connections), removed
+}
+
+// Of returns a user's open connections, oldest first
+func (r *ClientRegistry) Of(userID string) []interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	connections := r.users[userID]
+	clients := make([]interface{}, len(connections))
+	for i, c := range connections {
+		clients[i] = c.client
+	}
+	return clients
+}
+
+// Connected reports whether a user has at least one open connection
+func (r *ClientRegistry) Connected(userID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.users[userID]) > 0
+}
+
+// Each calls fn for every open connection. fn must not add or remove
+// connections.
+func (r *ClientRegistry) Each(fn func(userID string, client interface{})) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for userID, connections := range r.users {
+		for _, c := range connections {
+			fn(userID, c.client)
+		}
+	}
+}
+
+// SendToUser queues a message on every connection of a user, including those
+// to other instances in a cluster, and returns how many connections here got
+// it. Connections with a full queue are skipped.
+func (b *GameBridge) SendToUser(userID string, data []byte) int {
+	sent := b.deliverToUser(userID, data)
+	b.publishUserMessage(userID, data)
+	return sent
+}
+
+// deliverToUser queues a message on every connection of a user to this instance
+func (b *GameBridge) deliverToUser(userID string, data []byte) int {
+	type Sender interface {
+		GetSendChannel() chan []byte
+	}
+	sent := 0
+	for _, client := range b.Clients.Of(userID) {
+		if sender, ok := client.(Sender); ok {
+			select {
+			case sender.GetSendChannel() <- data:
+				sent++
+			default:
+				// Channel full, skip
+			}
+		}
+	}
+	return sent
+}
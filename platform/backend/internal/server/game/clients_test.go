@@ -0,0 +1,69 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestClientRegistry_AddAndRemove(t *testing.T) {
+	registry := NewClientRegistry()
+
+	if evicted, count := registry.Add("alice", "tab-1", fakeClient{tableID: "table-a"}); len(evicted) != 0 || count != 1 {
+		t.Fatalf("Expected alice's first connection, got %d evicted and %d open", len(evicted), count)
+	}
+	if _, count := registry.Add("alice", "tab-2", fakeClient{tableID: "table-b"}); count != 2 {
+		t.Fatalf("Expected a second tab to be kept alongside the first, got %d open", count)
+	}
+
+	if remaining, removed := registry.Remove("alice", "tab-1"); !removed || remaining != 1 {
+		t.Errorf("Expected one connection left after closing tab-1, got %d (removed %v)", remaining, removed)
+	}
+	if !registry.Connected("alice") {
+		t.Error("Expected alice to stay connected through tab-2")
+	}
+	if remaining, removed := registry.Remove("alice", "tab-2"); !removed || remaining != 0 {
+		t.Errorf("Expected no connections left, got %d (removed %v)", remaining, removed)
+	}
+	if _, removed := registry.Remove("alice", "tab-2"); removed {
+		t.Error("Expected a second remove of the same connection to do nothing")
+	}
+	if registry.Connected("alice") {
+		t.Error("Expected alice to be disconnected")
+	}
+}
+
+func TestClientRegistry_EvictsOldest(t *testing.T) {
+	registry := NewClientRegistry()
+	for i := 0; i < MaxConnectionsPerUser; i++ {
+		registry.Add("alice", fmt.Sprintf("tab-%d", i), fakeClient{tableID: fmt.Sprintf("table-%d", i)})
+	}
+
+	evicted, count := registry.Add("alice", "tab-new", fakeClient{tableID: "table-new"})
+	if len(evicted) != 1 || evicted[0].(fakeClient).tableID != "table-0" || count != MaxConnectionsPerUser {
+		t.Fatalf("Expected the oldest connection to make room, got %v and %d open", evicted, count)
+	}
+	if remaining, removed := registry.Remove("alice", "tab-0"); removed || remaining != MaxConnectionsPerUser {
+		t.Errorf("Expected the evicted connection's cleanup to leave the rest alone, got %d (removed %v)", remaining, removed)
+	}
+}
+
+func TestSendToUser_FansOut(t *testing.T) {
+	bridge := NewGameBridge()
+	first := newChannelClient(bridge, "alice", "table-a")
+	second := newChannelClient(bridge, "alice", "table-b")
+	other := newChannelClient(bridge, "bob", "table-a")
+
+	if sent := bridge.SendToUser("alice", []byte("hello")); sent != 2 {
+		t.Fatalf("Expected both of alice's connections to get the message, sent to %d", sent)
+	}
+	if len(first) != 1 || len(second) != 1 || len(other) != 0 {
+		t.Errorf("Expected only alice's connections to get it, got %d, %d and %d", len(first), len(second), len(other))
+	}
+
+	if sent := bridge.SendToTable("table-a", []byte("update")); sent != 2 {
+		t.Errorf("Expected table-a's update to reach alice's table-a tab and bob, sent to %d", sent)
+	}
+	if len(second) != 1 {
+		t.Error("Expected alice's table-b tab not to get table-a's update")
+	}
+}
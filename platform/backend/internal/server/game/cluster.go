@@ -0,0 +1,230 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/cluster"
+
+	pokerModels "poker-engine/models"
+)
+
+// ClaimTable takes a table for this instance before its engine table is
+// created here. Without a cluster every table runs here.
+func (b *GameBridge) ClaimTable(tableID string) bool {
+	if b.Cluster != nil && !b.Cluster.Claim(tableID) {
+		return false
+	}
+	// The hand state of a table this instance lost is saved again
+	if b.HandStates != nil {
+		b.HandStates.Reopen(tableID)
+	}
+	return true
+}
+
+// AdoptTable takes a table no instance runs, for recovery to run it here
+func (b *GameBridge) AdoptTable(tableID string) bool {
+	if _, exists := b.GetTable(tableID); exists {
+		return false
+	}
+	return b.ClaimTable(tableID)
+}
+
+// TableOwned reports whether any instance, this one included, runs a table.
+// A table whose owner can't be looked up counts as owned, so it is left alone.
+func (b *GameBridge) TableOwned(tableID string) bool {
+	if _, exists := b.GetTable(tableID); exists {
+		return true
+	}
+	if b.Cluster == nil {
+		return false
+	}
+	owner, err := b.Cluster.Owner(tableID)
+	return err != nil || owner != ""
+}
+
+// RunOwnership renews the tables this instance owns until stop is closed.
+// Tables another instance took over are stopped here.
+func RunOwnership(bridge *GameBridge, stop <-chan struct{}) {
+	if bridge.Cluster == nil {
+		return
+	}
+	ticker := time.NewTicker(cluster.RenewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			lost := bridge.Cluster.Renew(func(tableID string) bool {
+				_, exists := bridge.GetTable(tableID)
+				return exists
+			})
+			for _, tableID := range lost {
+				bridge.dropLostTable(tableID)
+			}
+		}
+	}
+}
+
+// dropLostTable stops running a table another instance took over. Its hand
+// state is left for the new owner.
+func (b *GameBridge) dropLostTable(tableID string) {
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	if b.HandStates != nil {
+		b.HandStates.Drop(tableID)
+	}
+	table.HoldBetweenHands(true)
+	table.Stop()
+	if table.Snapshot().Status == pokerModels.StatusPlaying {
+		if err := table.Pause(); err != nil {
+			log.Printf("[CLUSTER] Table %s was not paused: %v", tableID, err)
+		}
+	}
+	b.Mu.Lock()
+	delete(b.Tables, tableID)
+	delete(b.CurrentHandIDs, tableID)
+	b.Mu.Unlock()
+	log.Printf("[CLUSTER] ERROR: Lost ownership of table %s to another instance; stopped running it here", tableID)
+}
+
+// PublishTableState sends the state of a table this instance runs to the
+// other instances, for the clients connected to them
+func (b *GameBridge) PublishTableState(tableID string) {
+	b.sendTableState(tableID, "", "")
+}
+
+// sendTableState sends a table's state to every other instance, or only to
+// instanceID for one user's clients there
+func (b *GameBridge) sendTableState(tableID, instanceID, userID string) {
+	if b.Cluster == nil {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	data, err := json.Marshal(table.Snapshot())
+	if err != nil {
+		log.Printf("[CLUSTER] Failed to encode state of table %s: %v", tableID, err)
+		return
+	}
+	env := cluster.Envelope{Kind: cluster.KindTableState, TableID: tableID, UserID: userID, Data: data}
+	if instanceID != "" {
+		err = b.Cluster.Send(instanceID, env)
+	} else {
+		err = b.Cluster.Broadcast(env)
+	}
+	if err != nil {
+		log.Printf("[CLUSTER] %v", err)
+	}
+}
+
+// RemoteTableState decodes the state of a table run by another instance from
+// a KindTableState envelope, and remembers who is seated there so spectator
+// delays hold back the right clients here
+func (b *GameBridge) RemoteTableState(env cluster.Envelope) (*pokerModels.Table, bool) {
+	var state pokerModels.Table
+	if err := json.Unmarshal(env.Data, &state); err != nil {
+		log.Printf("[CLUSTER] Dropped state of table %s: %v", env.TableID, err)
+		return nil, false
+	}
+	seated := make(map[string]bool)
+	for _, p := range state.Players {
+		if p != nil {
+			seated[p.PlayerID] = true
+		}
+	}
+	b.Mu.Lock()
+	b.RemoteSeats[env.TableID] = seated
+	b.Mu.Unlock()
+	return &state, true
+}
+
+// RequestTableState asks the instance running a table for its state, for a
+// user who subscribed to it here. It reports false if no instance runs it.
+func (b *GameBridge) RequestTableState(tableID, userID string) bool {
+	if b.Cluster == nil {
+		return false
+	}
+	owner, err := b.Cluster.Owner(tableID)
+	if err != nil || owner == "" || owner == b.Cluster.ID() {
+		return false
+	}
+	env := cluster.Envelope{Kind: cluster.KindStateRequest, TableID: tableID, UserID: userID}
+	if err := b.Cluster.Send(owner, env); err != nil {
+		log.Printf("[CLUSTER] %v", err)
+		return false
+	}
+	return true
+}
+
+// AnswerStateRequest sends a table's state to the instance that asked for it
+func (b *GameBridge) AnswerStateRequest(env cluster.Envelope) {
+	b.sendTableState(env.TableID, env.From, env.UserID)
+}
+
+// ForwardGameAction sends a player's action on a table another instance runs
+// to that instance. It reports false if the table runs here, or nowhere.
+func (b *GameBridge) ForwardGameAction(userID, tableID string, action cluster.GameAction) bool {
+	if b.Cluster == nil {
+		return false
+	}
+	if _, exists := b.GetTable(tableID); exists {
+		return false
+	}
+	owner, err := b.Cluster.Owner(tableID)
+	if err != nil {
+		log.Printf("[CLUSTER] Failed to look up the owner of table %s: %v", tableID, err)
+		return false
+	}
+	if owner == "" || owner == b.Cluster.ID() {
+		return false
+	}
+	data, _ := json.Marshal(action)
+	env := cluster.Envelope{Kind: cluster.KindGameAction, TableID: tableID, UserID: userID, Data: data}
+	if err := b.Cluster.Send(owner, env); err != nil {
+		log.Printf("[CLUSTER] %v", err)
+		return false
+	}
+	return true
+}
+
+// publishTableMessage sends a message for everyone at a table to the other
+// instances
+func (b *GameBridge) publishTableMessage(tableID string, data []byte) {
+	if b.Cluster == nil {
+		return
+	}
+	env := cluster.Envelope{Kind: cluster.KindTableMessage, TableID: tableID, Data: data}
+	if err := b.Cluster.Broadcast(env); err != nil {
+		log.Printf("[CLUSTER] %v", err)
+	}
+}
+
+// publishUserMessage sends a message for a user to the other instances, for
+// the connections the user has there
+func (b *GameBridge) publishUserMessage(userID string, data []byte) {
+	if b.Cluster == nil {
+		return
+	}
+	env := cluster.Envelope{Kind: cluster.KindUserMessage, UserID: userID, Data: data}
+	if err := b.Cluster.Broadcast(env); err != nil {
+		log.Printf("[CLUSTER] %v", err)
+	}
+}
+
+// DeliverRemoteMessage hands a table or user message from another instance
+// to the clients connected here
+func (b *GameBridge) DeliverRemoteMessage(env cluster.Envelope) {
+	switch env.Kind {
+	case cluster.KindTableMessage:
+		b.deliverToTable(env.TableID, env.Data)
+	case cluster.KindUserMessage:
+		b.deliverToUser(env.UserID, env.Data)
+	}
+}
@@ -0,0 +1,151 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/cluster"
+)
+
+// recordingCluster records what a node publishes, and says who owns tables
+type recordingCluster struct {
+	mu        sync.Mutex
+	published map[string][]cluster.Envelope // channel -> envelopes
+	owners    map[string]string
+}
+
+func (r *recordingCluster) Publish(_ context.Context, channel string, payload []byte) error {
+	var env cluster.Envelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.published[channel] = append(r.published[channel], env)
+	return nil
+}
+
+func (r *recordingCluster) Subscribe(ctx context.Context, _ []string, _ func(string, []byte)) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (r *recordingCluster) Claim(_ context.Context, tableID string, _ time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.owners[tableID] != "" {
+		return false, nil
+	}
+	r.owners[tableID] = "here"
+	return true, nil
+}
+
+func (r *recordingCluster) Renew(context.Context, string, time.Duration) error { return nil }
+func (r *recordingCluster) Release(context.Context, string) error              { return nil }
+
+func (r *recordingCluster) Owner(_ context.Context, tableID string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.owners[tableID], nil
+}
+
+func newClusterBridge() (*GameBridge, *recordingCluster) {
+	recorder := &recordingCluster{published: map[string][]cluster.Envelope{}, owners: map[string]string{"table-remote": "there"}}
+	bridge := NewGameBridge()
+	bridge.Cluster = cluster.NewNode("here", recorder, recorder)
+	return bridge, recorder
+}
+
+func TestForwardGameAction(t *testing.T) {
+	bridge, recorder := newClusterBridge()
+	newLookupTable(bridge, "table-local")
+
+	if bridge.ForwardGameAction("alice", "table-local", cluster.GameAction{Action: "call"}) {
+		t.Error("Expected an action on a local table to be processed here")
+	}
+	if bridge.ForwardGameAction("alice", "table-none", cluster.GameAction{Action: "call"}) {
+		t.Error("Expected an action on a table nobody runs not to be forwarded")
+	}
+	if !bridge.ForwardGameAction("alice", "table-remote", cluster.GameAction{Action: "raise", Amount: 40}) {
+		t.Fatal("Expected an action on another instance's table to be forwarded")
+	}
+
+	sent := recorder.published["cluster:instance:there"]
+	if len(sent) != 1 || sent[0].Kind != cluster.KindGameAction || sent[0].UserID != "alice" {
+		t.Fatalf("Expected the action to go to the owner, got %+v", recorder.published)
+	}
+	var action cluster.GameAction
+	if err := json.Unmarshal(sent[0].Data, &action); err != nil || action.Action != "raise" || action.Amount != 40 {
+		t.Errorf("Expected the forwarded raise of 40, got %+v (%v)", action, err)
+	}
+}
+
+func TestClaimTable(t *testing.T) {
+	bridge, _ := newClusterBridge()
+	if bridge.ClaimTable("table-remote") {
+		t.Error("Expected another instance's table not to be claimed")
+	}
+	if !bridge.ClaimTable("table-new") || !bridge.TableOwned("table-new") {
+		t.Error("Expected a new table to be claimed and owned")
+	}
+	if bridge.TableOwned("table-none") {
+		t.Error("Expected a table nobody runs not to be owned")
+	}
+
+	newLookupTable(bridge, "table-new")
+	if bridge.AdoptTable("table-new") {
+		t.Error("Expected a table running here not to be adopted again")
+	}
+
+	if !NewGameBridge().ClaimTable("table-remote") {
+		t.Error("Expected every table to run here without a cluster")
+	}
+}
+
+func TestRemoteMessages(t *testing.T) {
+	bridge, recorder := newClusterBridge()
+	player := newChannelClient(bridge, "alice", "table-remote")
+	other := newChannelClient(bridge, "bob", "table-other")
+
+	// Messages sent here also go to the other instances
+	bridge.SendToTable("table-other", []byte(`{"type":"local"}`))
+	if len(other) != 1 || len(recorder.published["cluster:all"]) != 1 {
+		t.Fatalf("Expected the message here and on the cluster, got %d and %+v", len(other), recorder.published)
+	}
+	<-other
+
+	// A message from another instance is only delivered here
+	bridge.DeliverRemoteMessage(cluster.Envelope{Kind: cluster.KindTableMessage, TableID: "table-remote", Data: []byte(`{"type":"remote"}`)})
+	bridge.DeliverRemoteMessage(cluster.Envelope{Kind: cluster.KindUserMessage, UserID: "alice", Data: []byte(`{"type":"direct"}`)})
+	if len(player) != 2 || len(other) != 0 {
+		t.Errorf("Expected alice to get both messages, got %d (bob %d)", len(player), len(other))
+	}
+	if len(recorder.published["cluster:all"]) != 1 {
+		t.Errorf("Expected remote messages not to be published again, got %+v", recorder.published)
+	}
+}
+
+func TestRemoteTableState(t *testing.T) {
+	bridge, _ := newClusterBridge()
+	source := NewGameBridge()
+	table := newLookupTable(source, "table-remote")
+	if err := table.AddPlayer("alice", "Alice", 0, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	data, err := json.Marshal(table.Snapshot())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	state, ok := bridge.RemoteTableState(cluster.Envelope{Kind: cluster.KindTableState, TableID: "table-remote", Data: data})
+	if !ok || state.TableID != "table-remote" {
+		t.Fatalf("Expected the remote state to decode, got %+v", state)
+	}
+	seated := bridge.seatedPlayers("table-remote")
+	if !seated["alice"] || len(seated) != 1 {
+		t.Errorf("Expected alice seated at the remote table, got %v", seated)
+	}
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"log"
+
+	"poker-engine/engine"
+)
+
+// DisconnectGrace is how long a disconnected player gets for their first
+// turn, in seconds, before being checked or folded
+const DisconnectGrace = 60
+
+// SetPlayerConnected passes a change in a user's WebSocket connection to every
+// table they sit at, so a dropped player keeps their hand through the
+// disconnect grace instead of timing out on the usual clock
+func (b *GameBridge) SetPlayerConnected(userID string, connected bool) {
+	b.Mu.RLock()
+	tables := make(map[string]*engine.Table, len(b.Tables))
+	for tableID, table := range b.Tables {
+		tables[tableID] = table
+	}
+	b.Mu.RUnlock()
+
+	for tableID, table := range tables {
+		if !seatedAt(table, userID) {
+			continue
+		}
+		if err := table.SetPlayerConnected(userID, connected); err != nil {
+			log.Printf("[CONNECTION] Failed to update %s on table %s: %v", userID, tableID, err)
+			continue
+		}
+		log.Printf("[CONNECTION] Player %s connected=%v on table %s", userID, connected, tableID)
+	}
+}
+
+// seatedAt reports whether a user holds a seat at a table
+func seatedAt(table *engine.Table, userID string) bool {
+	for _, p := range table.Snapshot().Players {
+		if p != nil && p.PlayerID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package game
+
+import "testing"
+
+func TestSetPlayerConnected(t *testing.T) {
+	bridge := NewGameBridge()
+	tableA := newLookupTable(bridge, "table-a")
+	tableB := newLookupTable(bridge, "table-b")
+	if err := tableA.AddPlayer("alice", "Alice", 0, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	if err := tableB.AddPlayer("bob", "Bob", 0, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+
+	bridge.SetPlayerConnected("alice", false)
+	if !tableA.Snapshot().Players[0].Disconnected {
+		t.Error("Expected alice to be disconnected at their table")
+	}
+	if tableB.Snapshot().Players[0].Disconnected {
+		t.Error("Expected other tables to be left alone")
+	}
+
+	bridge.SetPlayerConnected("alice", true)
+	if tableA.Snapshot().Players[0].Disconnected {
+		t.Error("Expected alice to be back after reconnecting")
+	}
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/models"
+
+	pokerModels "poker-engine/models"
+)
+
+// Errors of table creator controls
+var (
+	ErrNotTableCreator = errors.New("only the table creator can do this")
+	ErrTablePaused     = errors.New("table is already paused")
+	ErrTableNotPaused  = errors.New("table is not paused")
+	ErrKickSelf        = errors.New("leave the table to give up your own seat")
+)
+
+// creatorTable loads a live cash table for its creator, refusing anyone else
+func (b *GameBridge) creatorTable(database *db.DB, tableID, userID string) (*models.Table, error) {
+	dbTable, err := b.cashTable(database, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if dbTable.CreatorID == nil || *dbTable.CreatorID != userID {
+		return nil, ErrNotTableCreator
+	}
+	return dbTable, nil
+}
+
+// CreatorKick removes a player from a cash table for its creator, as
+// KickPlayer does for an admin
+func (b *GameBridge) CreatorKick(database *db.DB, tableID, creatorID, userID string) (chips int, pending bool, err error) {
+	dbTable, err := b.creatorTable(database, tableID, creatorID)
+	if err != nil {
+		return 0, false, err
+	}
+	if userID == creatorID {
+		return 0, false, ErrKickSelf
+	}
+	chips, pending, err = b.kickPlayer(database, dbTable, userID)
+	if err != nil {
+		return 0, false, err
+	}
+	b.notifyTableControl(tableID, "kick", userID, "A player was removed from the table by its creator.")
+	return chips, pending, nil
+}
+
+// CreatorPause stops a cash table dealing for its creator. A hand in progress
+// is paused where it is, action timers included; otherwise no new hand starts
+// until the table is resumed.
+func (b *GameBridge) CreatorPause(database *db.DB, tableID, creatorID string) error {
+	if _, err := b.creatorTable(database, tableID, creatorID); err != nil {
+		return err
+	}
+	table, _ := b.GetTable(tableID)
+	if table.IsHeldBetweenHands() {
+		return ErrTablePaused
+	}
+
+	table.HoldBetweenHands(true)
+	if table.GetState().Status == pokerModels.StatusPlaying {
+		if err := table.Pause(); err != nil {
+			log.Printf("[TABLE_CONTROL] Hand at table %s could not be paused: %v", tableID, err)
+		}
+	}
+	b.notifyTableControl(tableID, "pause", "", "The table was paused by its creator.")
+	return nil
+}
+
+// CreatorResume lets a paused cash table deal again: a paused hand carries on
+// with the time its player had left, and between hands the next one starts
+// if enough players are seated
+func (b *GameBridge) CreatorResume(database *db.DB, tableID, creatorID string, broadcastFunc func(string)) error {
+	if _, err := b.creatorTable(database, tableID, creatorID); err != nil {
+		return err
+	}
+	table, _ := b.GetTable(tableID)
+	status := table.GetState().Status
+	if !table.IsHeldBetweenHands() && status != pokerModels.StatusPaused {
+		return ErrTableNotPaused
+	}
+
+	table.HoldBetweenHands(false)
+	b.notifyTableControl(tableID, "resume", "", "The table was resumed by its creator.")
+	switch status {
+	case pokerModels.StatusPaused:
+		if err := table.Resume(); err != nil {
+			return err
+		}
+	case pokerModels.StatusHandComplete:
+		// The next hand was held back when the last one ended
+		if err := table.StartGame(); err != nil {
+			log.Printf("[TABLE_CONTROL] Next hand at table %s not started: %v", tableID, err)
+		}
+	case pokerModels.StatusWaiting:
+		CheckAndStartGame(b, database, tableID, broadcastFunc)
+	}
+	return nil
+}
+
+// CreatorClose ends a cash table for its creator, returning every stack as
+// ForceCompleteTable does
+func (b *GameBridge) CreatorClose(database *db.DB, tableID, creatorID string) (map[string]int, error) {
+	dbTable, err := b.creatorTable(database, tableID, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	return closeCashTable(b, database, dbTable,
+		"This table was closed by its creator. Your chips have been returned.")
+}
+
+// notifyTableControl tells everyone at a table what its creator did
+func (b *GameBridge) notifyTableControl(tableID, action, userID, message string) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "table_control",
+		"payload": eventschema.TableControlPayload{
+			TableID: tableID,
+			Action:  action,
+			UserID:  userID,
+			Message: message,
+		},
+	})
+	b.SendToTable(tableID, data)
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	pokerModels "poker-engine/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCreatorPauseAndResume(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, gormDB, &models.Table{})
+	if err := gormDB.Exec(`INSERT INTO tables (id, game_type, creator_id, status) VALUES ('table-a', 'cash', 'alice', 'waiting')`).Error; err != nil {
+		t.Fatalf("Failed to create test table: %v", err)
+	}
+	database := &db.DB{DB: gormDB}
+
+	bridge := NewGameBridge()
+	table := newLookupTable(bridge, "table-a")
+	table.AddPlayer("alice", "Alice", 0, 500)
+	table.AddPlayer("bob", "Bob", 1, 500)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+
+	if err := bridge.CreatorPause(database, "table-a", "bob"); !errors.Is(err, ErrNotTableCreator) {
+		t.Errorf("Expected ErrNotTableCreator for a player who didn't create the table, got %v", err)
+	}
+	if err := bridge.CreatorResume(database, "table-a", "alice", func(string) {}); !errors.Is(err, ErrTableNotPaused) {
+		t.Errorf("Expected ErrTableNotPaused, got %v", err)
+	}
+
+	if err := bridge.CreatorPause(database, "table-a", "alice"); err != nil {
+		t.Fatalf("CreatorPause failed: %v", err)
+	}
+	if status := table.GetState().Status; status != pokerModels.StatusPaused || !table.IsHeldBetweenHands() {
+		t.Errorf("Expected the hand paused and the table held, got %s held=%v", status, table.IsHeldBetweenHands())
+	}
+	if err := bridge.CreatorPause(database, "table-a", "alice"); !errors.Is(err, ErrTablePaused) {
+		t.Errorf("Expected ErrTablePaused, got %v", err)
+	}
+
+	if err := bridge.CreatorResume(database, "table-a", "alice", func(string) {}); err != nil {
+		t.Fatalf("CreatorResume failed: %v", err)
+	}
+	if status := table.GetState().Status; status != pokerModels.StatusPlaying || table.IsHeldBetweenHands() {
+		t.Errorf("Expected the hand to carry on and the hold released, got %s held=%v", status, table.IsHeldBetweenHands())
+	}
+}
+
+func TestCreatorClose_PausedHandReturnsEveryChip(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, gormDB, &models.Table{}, &models.TableSeat{}, &models.User{})
+	for _, stmt := range []string{
+		`INSERT INTO tables (id, game_type, creator_id, status) VALUES ('table-a', 'cash', 'alice', 'playing')`,
+		`INSERT INTO users (id, username, chips) VALUES ('alice', 'Alice', 0), ('bob', 'Bob', 0)`,
+		`INSERT INTO table_seats (table_id, user_id, seat_number, chips) VALUES ('table-a', 'alice', 0, 500), ('table-a', 'bob', 1, 500)`,
+	} {
+		if err := gormDB.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to create test rows: %v", err)
+		}
+	}
+	database := &db.DB{DB: gormDB}
+
+	bridge := NewGameBridge()
+	table := newLookupTable(bridge, "table-a")
+	table.AddPlayer("alice", "Alice", 0, 500)
+	table.AddPlayer("bob", "Bob", 1, 500)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	if err := bridge.CreatorPause(database, "table-a", "alice"); err != nil {
+		t.Fatalf("CreatorPause failed: %v", err)
+	}
+	stacks := 0
+	for _, player := range table.GetState().Players {
+		if player != nil {
+			stacks += player.Chips
+		}
+	}
+	if stacks == 1000 {
+		t.Fatal("Expected the blinds to be in the paused hand")
+	}
+
+	returned, err := bridge.CreatorClose(database, "table-a", "alice")
+	if err != nil {
+		t.Fatalf("CreatorClose failed: %v", err)
+	}
+	if returned["alice"]+returned["bob"] != 1000 {
+		t.Errorf("Expected all 1000 chips returned, got %v", returned)
+	}
+	var users []models.User
+	if err := gormDB.Find(&users).Error; err != nil {
+		t.Fatalf("Failed to load users: %v", err)
+	}
+	for _, user := range users {
+		if user.Chips != 500 {
+			t.Errorf("Expected %s to get their 500 back, got %d", user.ID, user.Chips)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/models"
+)
+
+// DeadlineInterval is how often scheduled closures are checked
+const DeadlineInterval = 15 * time.Second
+
+// DeadlineWarnings are how long before a deadline its warnings go out
+var DeadlineWarnings = []time.Duration{15 * time.Minute, 5 * time.Minute, time.Minute}
+
+// Kinds of deadline
+const (
+	DeadlineTableClose        = "table_close"
+	DeadlineRegistrationClose = "registration_close"
+	DeadlineTournamentEnd     = "tournament_end"
+)
+
+// DeadlineWarner remembers which warnings of each deadline have gone out, so
+// every warning is sent once however often the deadline is checked
+type DeadlineWarner struct {
+	mu   sync.Mutex
+	sent map[string]time.Duration // Smallest warning sent, or -1 once passed
+	seen map[string]bool          // Checked since the last Prune
+}
+
+// NewDeadlineWarner creates a deadline warner
+func NewDeadlineWarner() *DeadlineWarner {
+	return &DeadlineWarner{sent: make(map[string]time.Duration), seen: make(map[string]bool)}
+}
+
+// Check reports whether a warning of the deadline identified by key is due
+// now, or whether it has passed and this is the first check since. A deadline
+// moved later gets its warnings again.
+func (w *DeadlineWarner) Check(key string, at, now time.Time) (warn, passed bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.seen[key] = true
+	last, ok := w.sent[key]
+
+	left := at.Sub(now)
+	if left <= 0 {
+		if ok && last < 0 {
+			return false, false
+		}
+		w.sent[key] = -1
+		return false, true
+	}
+
+	var due time.Duration
+	for _, warning := range DeadlineWarnings {
+		if left <= warning && (due == 0 || warning < due) {
+			due = warning
+		}
+	}
+	if due == 0 {
+		delete(w.sent, key)
+		return false, false
+	}
+	if ok && last > 0 && last <= due {
+		return false, false
+	}
+	w.sent[key] = due
+	return true, false
+}
+
+// Prune forgets the deadlines not checked since the last Prune, such as those
+// of tables and tournaments that have ended
+func (w *DeadlineWarner) Prune() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key := range w.sent {
+		if !w.seen[key] {
+			delete(w.sent, key)
+		}
+	}
+	w.seen = make(map[string]bool)
+}
+
+// DeadlineWarningMessage encodes a deadline_warning
+func DeadlineWarningMessage(payload eventschema.DeadlineWarningPayload, now time.Time) []byte {
+	payload.SecondsLeft = int(payload.At.Sub(now).Round(time.Second) / time.Second)
+	data, _ := json.Marshal(map[string]interface{}{
+		"type":    "deadline_warning",
+		"payload": payload,
+	})
+	return data
+}
+
+// CloseDueTables warns everyone at a cash table as its auto-close time
+// approaches, and closes it when the time comes, returning every stack. A
+// timed session plays out the hand in progress before it closes. Tables live
+// on another instance are left to that instance.
+func CloseDueTables(b *GameBridge, database *db.DB, warner *DeadlineWarner, now time.Time) {
+	var tables []models.Table
+	if err := database.Select("id", "game_type", "club_id", "tournament_id", "creator_id", "auto_close_at", "session_length").
+		Where("auto_close_at IS NOT NULL AND tournament_id IS NULL AND status <> ?", "completed").
+		Find(&tables).Error; err != nil {
+		log.Printf("[TABLE_CLOSE] Failed to load tables with a closing time: %v", err)
+		return
+	}
+	defer warner.Prune()
+
+	for i := range tables {
+		dbTable := &tables[i]
+		table, exists := b.GetTable(dbTable.ID)
+		if !exists {
+			continue
+		}
+		warn, _ := warner.Check(DeadlineTableClose+":"+dbTable.ID, *dbTable.AutoCloseAt, now)
+		if warn {
+			b.SendToTable(dbTable.ID, DeadlineWarningMessage(eventschema.DeadlineWarningPayload{
+				Kind:    DeadlineTableClose,
+				TableID: dbTable.ID,
+				At:      *dbTable.AutoCloseAt,
+				Message: "This table closes soon. Your chips will be returned when it does.",
+			}, now))
+			continue
+		}
+		if dbTable.AutoCloseAt.After(now) {
+			continue
+		}
+		if dbTable.SessionLength > 0 {
+			endTimedSession(b, database, dbTable, table, now)
+			continue
+		}
+
+		returned, err := closeCashTable(b, database, dbTable,
+			"This table reached its closing time. Your chips have been returned.")
+		if err != nil {
+			log.Printf("[TABLE_CLOSE] ERROR: Closing table %s at its closing time: %v", dbTable.ID, err)
+		}
+		log.Printf("[TABLE_CLOSE] Table %s closed at its closing time, %d stacks returned", dbTable.ID, len(returned))
+	}
+}
+
+// RunTableClosures closes cash tables at their auto-close time until stop is
+// closed
+func RunTableClosures(b *GameBridge, database *db.DB, stop <-chan struct{}) {
+	warner := NewDeadlineWarner()
+	ticker := time.NewTicker(DeadlineInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			CloseDueTables(b, database, warner, now)
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeadlineWarner(t *testing.T) {
+	w := NewDeadlineWarner()
+	at := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	check := func(before time.Duration) (bool, bool) {
+		return w.Check("table_close:t1", at, at.Add(-before))
+	}
+
+	if warn, _ := check(time.Hour); warn {
+		t.Errorf("Expected no warning an hour out")
+	}
+	if warn, _ := check(14 * time.Minute); !warn {
+		t.Errorf("Expected the 15 minute warning")
+	}
+	if warn, _ := check(13 * time.Minute); warn {
+		t.Errorf("Expected the 15 minute warning only once")
+	}
+	// A check that skips past a warning sends only the latest one
+	if warn, _ := check(30 * time.Second); !warn {
+		t.Errorf("Expected the 1 minute warning")
+	}
+	if warn, _ := check(10 * time.Second); warn {
+		t.Errorf("Expected no warning after the last one")
+	}
+	if _, passed := check(-time.Second); !passed {
+		t.Errorf("Expected the deadline to pass")
+	}
+	if _, passed := check(-time.Minute); passed {
+		t.Errorf("Expected the deadline to pass only once")
+	}
+
+	// Deadlines no longer checked are forgotten
+	w.Prune()
+	w.Prune()
+	if _, passed := check(-2 * time.Minute); !passed {
+		t.Errorf("Expected a pruned deadline to be forgotten")
+	}
+}
@@ -0,0 +1,22 @@
+package game
+
+import (
+	"fmt"
+
+	"poker-engine/engine"
+)
+
+// DealDuplicate sets up the next hand of every table of a duplicate event
+// with the same deal. Each table must be between hands.
+func (b *GameBridge) DealDuplicate(tableIDs []string, deal engine.DuplicateDeal) error {
+	for _, tableID := range tableIDs {
+		table, exists := b.GetTable(tableID)
+		if !exists {
+			return fmt.Errorf("table %s not found", tableID)
+		}
+		if err := table.SetNextDeal(deal); err != nil {
+			return fmt.Errorf("table %s: %w", tableID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package game
+
+import (
+	"testing"
+
+	"poker-engine/engine"
+)
+
+func TestDealDuplicate(t *testing.T) {
+	bridge := NewGameBridge()
+	for _, tableID := range []string{"dup-1", "dup-2"} {
+		table := newLookupTable(bridge, tableID)
+		if err := table.AddPlayer(tableID+"-a", "A", 0, 500); err != nil {
+			t.Fatalf("AddPlayer failed: %v", err)
+		}
+		if err := table.AddPlayer(tableID+"-b", "B", 1, 300); err != nil {
+			t.Fatalf("AddPlayer failed: %v", err)
+		}
+	}
+
+	deal := engine.DuplicateDeal{Seed: 11, Button: 1, Stack: 1000}
+	if err := bridge.DealDuplicate([]string{"dup-1", "dup-2"}, deal); err != nil {
+		t.Fatalf("DealDuplicate failed: %v", err)
+	}
+	for _, tableID := range []string{"dup-1", "dup-2"} {
+		table, _ := bridge.GetTable(tableID)
+		for _, p := range table.Snapshot().Players {
+			if p != nil && p.Chips != deal.Stack {
+				t.Errorf("Expected %s to start the deal with %d chips, got %d", p.PlayerID, deal.Stack, p.Chips)
+			}
+		}
+	}
+
+	if err := bridge.DealDuplicate([]string{"missing"}, deal); err == nil {
+		t.Error("Expected an unknown table to fail")
+	}
+}
@@ -0,0 +1,33 @@
+package game
+
+import (
+	pokerModels "poker-engine/models"
+)
+
+// RecordFairnessEvent feeds completed hands into the deck fairness analyzer.
+// Other events are ignored.
+func (b *GameBridge) RecordFairnessEvent(tableID string, event pokerModels.Event) {
+	if b.Fairness == nil || event.Event != "handComplete" {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	state := table.Snapshot()
+	if state.CurrentHand == nil {
+		return
+	}
+
+	// Folded players keep their cards until the next hand, so this is every
+	// hand dealt, not just those shown down
+	holeCards := make([][]pokerModels.Card, 0, len(state.Players))
+	for _, p := range state.Players {
+		if p != nil && len(p.Cards) > 0 {
+			holeCards = append(holeCards, p.Cards)
+		}
+	}
+
+	data, _ := event.Data.(pokerModels.HandCompleteEvent)
+	b.Fairness.RecordHand(holeCards, state.CurrentHand.CommunityCards, data.Winners)
+}
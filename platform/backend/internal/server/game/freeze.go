@@ -0,0 +1,59 @@
+package game
+
+import (
+	"log"
+	"sync"
+)
+
+// FrozenPlayers is the in-memory set of accounts an admin has frozen, checked
+// on every game action before it reaches the engine
+type FrozenPlayers struct {
+	mu    sync.RWMutex
+	users map[string]bool
+}
+
+// NewFrozenPlayers creates an empty set
+func NewFrozenPlayers() *FrozenPlayers {
+	return &FrozenPlayers{users: make(map[string]bool)}
+}
+
+// IsFrozen reports whether a user is frozen
+func (f *FrozenPlayers) IsFrozen(userID string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.users[userID]
+}
+
+func (f *FrozenPlayers) set(userID string, frozen bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if frozen {
+		f.users[userID] = true
+	} else {
+		delete(f.users, userID)
+	}
+}
+
+// ApplyFreeze freezes or unfreezes a user on every live table they are seated
+// at, sitting them out when frozen. It returns the tables that changed so
+// their state can be broadcast.
+func (b *GameBridge) ApplyFreeze(userID string, frozen bool) []string {
+	b.Frozen.set(userID, frozen)
+
+	tableIDs := []string{}
+	for _, seat := range b.LiveSeats(userID) {
+		table, exists := b.GetTable(seat.TableID)
+		if !exists {
+			continue
+		}
+		table.SetPlayerFrozen(userID, frozen)
+		tableIDs = append(tableIDs, seat.TableID)
+	}
+
+	if frozen {
+		log.Printf("[FREEZE] Player %s frozen on %d live tables", userID, len(tableIDs))
+	} else {
+		log.Printf("[FREEZE] Player %s unfrozen on %d live tables", userID, len(tableIDs))
+	}
+	return tableIDs
+}
@@ -0,0 +1,35 @@
+package game
+
+import "testing"
+
+func TestApplyFreeze(t *testing.T) {
+	bridge := NewGameBridge()
+	tableA := newLookupTable(bridge, "table-a")
+	tableB := newLookupTable(bridge, "table-b")
+
+	if err := tableA.AddPlayer("alice", "Alice", 0, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	if err := tableB.AddPlayer("bob", "Bob", 0, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+
+	changed := bridge.ApplyFreeze("alice", true)
+	if len(changed) != 1 || changed[0] != "table-a" {
+		t.Errorf("Expected only table-a to change, got %v", changed)
+	}
+	if !bridge.Frozen.IsFrozen("alice") || bridge.Frozen.IsFrozen("bob") {
+		t.Error("Expected only alice to be frozen")
+	}
+	if !tableA.IsPlayerFrozen("alice") {
+		t.Error("Expected alice to be frozen at their table")
+	}
+	if err := tableA.SitIn("alice"); err == nil {
+		t.Error("Expected a frozen player to be unable to sit in")
+	}
+
+	bridge.ApplyFreeze("alice", false)
+	if bridge.Frozen.IsFrozen("alice") || tableA.IsPlayerFrozen("alice") {
+		t.Error("Expected alice to be unfrozen")
+	}
+}
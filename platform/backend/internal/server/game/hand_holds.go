@@ -0,0 +1,55 @@
+package game
+
+import "sync"
+
+// HandHolds lets a tournament director stop a table from dealing its next hand,
+// e.g. to pause a broadcast final table between hands. A hand already in
+// progress is always played out.
+type HandHolds struct {
+	mu   sync.Mutex
+	held map[string]chan struct{} // tableID -> closed on release
+}
+
+// NewHandHolds creates an empty set of holds
+func NewHandHolds() *HandHolds {
+	return &HandHolds{held: make(map[string]chan struct{})}
+}
+
+// Hold stops a table from dealing its next hand until it is released
+func (h *HandHolds) Hold(tableID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.held[tableID]; !ok {
+		h.held[tableID] = make(chan struct{})
+	}
+}
+
+// Release lets a held table deal again. It reports whether the table was held.
+func (h *HandHolds) Release(tableID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	release, ok := h.held[tableID]
+	if ok {
+		close(release)
+		delete(h.held, tableID)
+	}
+	return ok
+}
+
+// IsHeld reports whether a table is held
+func (h *HandHolds) IsHeld(tableID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, ok := h.held[tableID]
+	return ok
+}
+
+// Wait blocks until the table is not held
+func (h *HandHolds) Wait(tableID string) {
+	h.mu.Lock()
+	release, ok := h.held[tableID]
+	h.mu.Unlock()
+	if ok {
+		<-release
+	}
+}
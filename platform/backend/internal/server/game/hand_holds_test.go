@@ -0,0 +1,44 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHandHolds_WaitBlocksUntilRelease(t *testing.T) {
+	holds := NewHandHolds()
+
+	// An unheld table deals straight away
+	holds.Wait("table-a")
+
+	holds.Hold("table-a")
+	holds.Hold("table-a")
+	if !holds.IsHeld("table-a") || holds.IsHeld("table-b") {
+		t.Fatal("Expected only table-a to be held")
+	}
+
+	dealt := make(chan struct{})
+	go func() {
+		holds.Wait("table-a")
+		close(dealt)
+	}()
+
+	select {
+	case <-dealt:
+		t.Fatal("Expected a held table not to deal")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if !holds.Release("table-a") {
+		t.Error("Expected Release to report the table was held")
+	}
+	select {
+	case <-dealt:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the table to deal once released")
+	}
+
+	if holds.Release("table-a") {
+		t.Error("Expected releasing an unheld table to report false")
+	}
+}
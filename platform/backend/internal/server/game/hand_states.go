@@ -0,0 +1,203 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+// HandStateStore keeps the engine state of every table in the middle of a
+// hand in the database, so recovery can carry the hand on after a restart
+type HandStateStore struct {
+	db     *gorm.DB
+	mu     sync.Mutex
+	tables map[string]*savedHandState
+}
+
+// savedHandState is what was last written for a table. Its lock keeps writes
+// for the table in order.
+type savedHandState struct {
+	mu      sync.Mutex
+	version uint64 // State version last written
+	cleared bool   // No row is kept; unknown for a table recovered at startup
+	closed  bool   // The table was closed, so late saves are dropped
+}
+
+// NewHandStateStore creates a store writing to db
+func NewHandStateStore(db *gorm.DB) *HandStateStore {
+	return &HandStateStore{db: db, tables: make(map[string]*savedHandState)}
+}
+
+func (s *HandStateStore) saved(tableID string) *savedHandState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved, ok := s.tables[tableID]
+	if !ok {
+		saved = &savedHandState{}
+		s.tables[tableID] = saved
+	}
+	return saved
+}
+
+// Save writes the state of a table in a hand, or deletes what was kept once
+// the table is out of it. States older than the last one written are skipped,
+// since events can be handled out of order. It reports whether the table is
+// in a hand whose state is now kept.
+func (s *HandStateStore) Save(tableID string, table *engine.Table) (bool, error) {
+	saved := s.saved(tableID)
+	saved.mu.Lock()
+	defer saved.mu.Unlock()
+
+	if saved.closed {
+		return false, nil
+	}
+	state := table.State()
+	if !state.InHand() {
+		if saved.cleared {
+			return false, nil
+		}
+		if err := s.db.Where("table_id = ?", tableID).Delete(&models.TableHandState{}).Error; err != nil {
+			return false, fmt.Errorf("failed to delete hand state of table %s: %w", tableID, err)
+		}
+		saved.version = 0
+		saved.cleared = true
+		return false, nil
+	}
+	if state.StateVersion <= saved.version {
+		return true, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode hand state of table %s: %w", tableID, err)
+	}
+	row := models.TableHandState{TableID: tableID, HandNumber: state.Hand.HandNumber, State: string(data)}
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "table_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"hand_number", "state", "updated_at"}),
+	}).Create(&row).Error; err != nil {
+		return false, fmt.Errorf("failed to save hand state of table %s: %w", tableID, err)
+	}
+	saved.version = state.StateVersion
+	saved.cleared = false
+	return true, nil
+}
+
+// Delete drops what is kept for a table that was closed. Saves still under
+// way for it are dropped.
+func (s *HandStateStore) Delete(tableID string) error {
+	saved := s.saved(tableID)
+	saved.mu.Lock()
+	defer saved.mu.Unlock()
+
+	if err := s.db.Where("table_id = ?", tableID).Delete(&models.TableHandState{}).Error; err != nil {
+		return fmt.Errorf("failed to delete hand state of table %s: %w", tableID, err)
+	}
+	saved.closed = true
+	return nil
+}
+
+// Drop stops saving a table another instance took over, leaving what is kept
+// for the new owner to restore
+func (s *HandStateStore) Drop(tableID string) {
+	saved := s.saved(tableID)
+	saved.mu.Lock()
+	defer saved.mu.Unlock()
+	saved.closed = true
+}
+
+// Reopen saves a table again once this instance runs it anew, such as one it
+// lost and then adopted back. Saves still under way for the earlier run are
+// dropped.
+func (s *HandStateStore) Reopen(tableID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tables, tableID)
+}
+
+// handStateEvents are the engine events after which a table's hand state is
+// saved or, once the hand is over, deleted
+var handStateEvents = map[string]bool{
+	"handStart":      true,
+	"playerAction":   true,
+	"roundAdvanced":  true,
+	"actionRequired": true,
+	"handComplete":   true,
+	"gameComplete":   true,
+	"gameAbandoned":  true,
+}
+
+// RecordHandStateEvent saves a table's hand state after every action. Pause
+// and Resume deliver their events under the game lock, so the state is read
+// on another goroutine.
+func (b *GameBridge) RecordHandStateEvent(tableID string, event pokerModels.Event) {
+	if b.HandStates == nil || !handStateEvents[event.Event] {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	go func() {
+		if _, err := b.HandStates.Save(tableID, table); err != nil {
+			log.Printf("[HAND_STATE] %v", err)
+		}
+	}()
+}
+
+// SaveHandStates saves the state of every table now, for a shutdown once the
+// tables are paused. It returns how many tables are in a hand whose state was
+// saved and the IDs of those whose state could not be.
+func SaveHandStates(bridge *GameBridge) (saved int, failed []string) {
+	if bridge.HandStates == nil {
+		return 0, nil
+	}
+	bridge.Mu.RLock()
+	tables := make(map[string]*engine.Table, len(bridge.Tables))
+	for tableID, table := range bridge.Tables {
+		tables[tableID] = table
+	}
+	bridge.Mu.RUnlock()
+
+	for tableID, table := range tables {
+		inHand, err := bridge.HandStates.Save(tableID, table)
+		if err != nil {
+			log.Printf("[HAND_STATE] %v", err)
+			failed = append(failed, tableID)
+			continue
+		}
+		if inHand {
+			saved++
+		}
+	}
+	sort.Strings(failed)
+	return saved, failed
+}
+
+// RestoreCurrentHandIDs points tables that recovery carried on mid-hand at
+// the database row of their hand, so the hand is recorded when it ends
+func RestoreCurrentHandIDs(bridge *GameBridge, database *db.DB) {
+	for _, live := range bridge.LiveTables() {
+		if live.Status != string(pokerModels.StatusPlaying) && live.Status != string(pokerModels.StatusPaused) {
+			continue
+		}
+		var hand models.Hand
+		result := database.Select("id").Where("table_id = ? AND hand_number = ? AND completed_at IS NULL", live.TableID, live.HandNumber).
+			Order("id DESC").Limit(1).Find(&hand)
+		if result.Error != nil || result.RowsAffected == 0 {
+			log.Printf("[HAND_STATE] No open record of hand #%d on table %s", live.HandNumber, live.TableID)
+			continue
+		}
+		bridge.SetCurrentHandID(live.TableID, hand.ID)
+	}
+}
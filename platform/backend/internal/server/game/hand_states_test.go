@@ -0,0 +1,115 @@
+package game
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	pokerModels "poker-engine/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestHandStateStore_SavesUntilHandEnds(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.TableHandState{})
+	count := func() int64 {
+		var n int64
+		db.Table("table_hand_states").Count(&n)
+		return n
+	}
+
+	bridge := NewGameBridge()
+	store := NewHandStateStore(db)
+	table := newLookupTable(bridge, "table-a")
+	table.AddPlayer("alice", "Alice", 0, 500)
+	table.AddPlayer("bob", "Bob", 1, 500)
+
+	if inHand, err := store.Save("table-a", table); err != nil || inHand || count() != 0 {
+		t.Fatalf("Expected nothing kept between hands, got inHand=%v err=%v rows=%d", inHand, err, count())
+	}
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	if inHand, err := store.Save("table-a", table); err != nil || !inHand || count() != 1 {
+		t.Fatalf("Expected the hand to be kept, got inHand=%v err=%v rows=%d", inHand, err, count())
+	}
+	var handNumber int
+	db.Raw(`SELECT hand_number FROM table_hand_states WHERE table_id = 'table-a'`).Scan(&handNumber)
+	if handNumber != 1 {
+		t.Errorf("Expected hand 1, got %d", handNumber)
+	}
+
+	// A player folding heads-up ends the hand
+	state := table.Snapshot()
+	toAct := state.Players[state.CurrentHand.CurrentPosition].PlayerID
+	if err := table.ProcessAction(toAct, pokerModels.ActionFold, 0); err != nil {
+		t.Fatalf("Fold failed: %v", err)
+	}
+	if inHand, err := store.Save("table-a", table); err != nil || inHand || count() != 0 {
+		t.Errorf("Expected the state to be deleted once the hand ended, got inHand=%v err=%v rows=%d", inHand, err, count())
+	}
+
+	// Nothing is written for a closed table, even by a save still under way
+	if err := store.Delete("table-a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	if inHand, err := store.Save("table-a", table); err != nil || inHand {
+		t.Errorf("Expected a closed table to be skipped, got inHand=%v err=%v", inHand, err)
+	}
+	if count() != 0 {
+		t.Errorf("Expected nothing saved for a closed table, got %d rows", count())
+	}
+}
+
+func TestHandStateStore_SavesAdoptedTableAgain(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, db, &models.TableHandState{})
+
+	bridge, recorder := newClusterBridge()
+	bridge.HandStates = NewHandStateStore(db)
+	table := newLookupTable(bridge, "table-a")
+	table.AddPlayer("alice", "Alice", 0, 500)
+	table.AddPlayer("bob", "Bob", 1, 500)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+
+	// Another instance takes the table, then stops before it is done with it
+	bridge.dropLostTable("table-a")
+	if inHand, _ := bridge.HandStates.Save("table-a", table); inHand {
+		t.Error("Expected a lost table not to be saved")
+	}
+	recorder.mu.Lock()
+	delete(recorder.owners, "table-a")
+	recorder.mu.Unlock()
+
+	if !bridge.AdoptTable("table-a") {
+		t.Fatal("Expected the table to be adopted back")
+	}
+	adopted := newLookupTable(bridge, "table-a")
+	adopted.AddPlayer("alice", "Alice", 0, 500)
+	adopted.AddPlayer("bob", "Bob", 1, 500)
+	if err := adopted.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	if inHand, err := bridge.HandStates.Save("table-a", adopted); err != nil || !inHand {
+		t.Errorf("Expected the adopted table's hand to be saved, got inHand=%v err=%v", inHand, err)
+	}
+	var n int64
+	db.Table("table_hand_states").Count(&n)
+	if n != 1 {
+		t.Errorf("Expected the adopted table's state kept, got %d rows", n)
+	}
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/leaderboard"
+
+	pokerModels "poker-engine/models"
+)
+
+// RecordLeaderboardEvent scores completed hands on the leaderboards: a hand
+// won for each winner, and at cash tables each player's net result. Other
+// events are ignored.
+func (b *GameBridge) RecordLeaderboardEvent(tableID string, event pokerModels.Event) {
+	if b.Leaderboards == nil || event.Event != "handComplete" {
+		return
+	}
+	data, ok := event.Data.(pokerModels.HandCompleteEvent)
+	if !ok {
+		return
+	}
+	now := time.Now()
+
+	won := make(map[string]int, len(data.Winners))
+	for _, winner := range data.Winners {
+		won[winner.PlayerID] += winner.Amount
+	}
+	for userID := range won {
+		if err := b.Leaderboards.Record(leaderboard.TypeHands, userID, 1, now); err != nil {
+			log.Printf("[LEADERBOARD] Failed to record hand won by %s at table %s: %v", userID, tableID, err)
+		}
+	}
+
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	state := table.Snapshot()
+	if state.GameType != pokerModels.GameTypeCash {
+		return
+	}
+	for _, p := range state.Players {
+		if p == nil || (p.TotalInvestedThisHand == 0 && won[p.PlayerID] == 0) {
+			continue
+		}
+		net := int64(won[p.PlayerID] - p.TotalInvestedThisHand)
+		if err := b.Leaderboards.Record(leaderboard.TypeCash, p.PlayerID, net, now); err != nil {
+			log.Printf("[LEADERBOARD] Failed to record cash result of %s at table %s: %v", p.PlayerID, tableID, err)
+		}
+	}
+}
+
+// SendLeaderboardUpdate tells every client that the top of a leaderboard changed
+func SendLeaderboardUpdate(b *GameBridge, boardType, period string, window leaderboard.Window, top []leaderboard.Entry) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "leaderboard_update",
+		"payload": eventschema.LeaderboardUpdatePayload{
+			Type:    boardType,
+			Period:  period,
+			Window:  window,
+			Entries: top,
+		},
+	})
+	b.SendToUsers(nil, data)
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/mission"
+	"poker-platform/backend/internal/models"
+
+	pokerModels "poker-engine/models"
+)
+
+// RecordMissionEvent counts a completed hand towards the daily missions of
+// everyone dealt into it: a hand played for each, a hand won for each winner,
+// and a showdown won when two or more players were still in at the end. Other
+// events are ignored.
+func (b *GameBridge) RecordMissionEvent(tableID string, event pokerModels.Event) {
+	if b.Missions == nil || event.Event != "handComplete" {
+		return
+	}
+	data, ok := event.Data.(pokerModels.HandCompleteEvent)
+	if !ok {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+
+	counts := make(map[string]map[string]int)
+	inAtEnd := 0
+	for _, p := range table.Snapshot().Players {
+		if p == nil || len(p.Cards) == 0 {
+			continue
+		}
+		counts[p.PlayerID] = map[string]int{mission.MetricHandsPlayed: 1}
+		if p.Status != pokerModels.StatusFolded {
+			inAtEnd++
+		}
+	}
+	for _, winner := range data.Winners {
+		playerCounts, ok := counts[winner.PlayerID]
+		if !ok {
+			continue
+		}
+		playerCounts[mission.MetricHandsWon] = 1
+		if inAtEnd > 1 {
+			playerCounts[mission.MetricShowdownsWon] = 1
+		}
+	}
+
+	now := time.Now()
+	for userID, playerCounts := range counts {
+		b.recordMissions(userID, playerCounts, now)
+	}
+}
+
+// RecordMissionTournamentFinish counts a completed tournament towards the
+// daily missions of every player in it
+func (b *GameBridge) RecordMissionTournamentFinish(tournamentID string, standings []models.TournamentPlayer) {
+	if b.Missions == nil {
+		return
+	}
+	now := time.Now()
+	for _, player := range standings {
+		b.recordMissions(player.UserID, map[string]int{mission.MetricTournaments: 1}, now)
+	}
+}
+
+// recordMissions adds to a player's mission counts and tells them which
+// missions moved
+func (b *GameBridge) recordMissions(userID string, counts map[string]int, now time.Time) {
+	changed, err := b.Missions.Record(userID, counts, now)
+	if err != nil {
+		log.Printf("[MISSION] Failed to record %v for %s: %v", counts, userID, err)
+		return
+	}
+	if len(changed) == 0 {
+		return
+	}
+	data, _ := json.Marshal(map[string]interface{}{
+		"type":    "mission_progress",
+		"payload": eventschema.MissionProgressPayload{Missions: changed},
+	})
+	b.SendToUser(userID, data)
+}
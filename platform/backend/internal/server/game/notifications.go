@@ -0,0 +1,82 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+
+	pokerModels "poker-engine/models"
+)
+
+// prioritySender is implemented by clients with a high priority queue that is
+// written before any queued table state
+type prioritySender interface {
+	GetPriorityChannel() chan []byte
+	HasFeature(feature string) bool
+}
+
+// SendTurnNotification sends an action_required message to the player whose
+// turn it is, on each of their connections that negotiated the
+// action_required feature. It goes
+// through the priority queue so it is never stuck behind state updates; the
+// full state still follows in the next broadcast. Clients that negotiated
+// action_ack are expected to acknowledge it; see AwayDetector.
+func (b *GameBridge) SendTurnNotification(tableID string, event pokerModels.Event) {
+	data, ok := event.Data.(pokerModels.ActionRequiredEvent)
+	if !ok {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"table_id": tableID,
+		"user_id":  data.PlayerID,
+		"deadline": data.Deadline,
+	}
+	if table, exists := b.GetTable(tableID); exists {
+		if state := table.Snapshot(); state.CurrentHand != nil {
+			payload["current_bet"] = state.CurrentHand.CurrentBet
+			payload["action_sequence"] = state.CurrentHand.ActionSequence
+		}
+	}
+	if data.TimeBank > 0 || data.InTimeBank {
+		payload["time_bank"] = data.TimeBank
+		payload["in_time_bank"] = data.InTimeBank
+	}
+	// Legal actions and bet sizes, so clients never have to guess them
+	if data.LegalActions != nil {
+		payload["legal_actions"] = data.LegalActions
+	}
+	payload["call_amount"] = data.CallAmount
+	if data.MaxRaiseTo > 0 {
+		payload["min_raise_to"] = data.MinRaiseTo
+		payload["max_raise_to"] = data.MaxRaiseTo
+	}
+	payload["effective_stack"] = data.EffectiveStack
+
+	msgData, err := json.Marshal(map[string]interface{}{
+		"type":    "action_required",
+		"payload": payload,
+	})
+	if err != nil {
+		return
+	}
+
+	// Every connection of the player is told; one that acks is enough
+	acks := false
+	for _, clientInterface := range b.Clients.Of(data.PlayerID) {
+		client, ok := clientInterface.(prioritySender)
+		if !ok || !client.HasFeature("action_required") {
+			continue
+		}
+		select {
+		case client.GetPriorityChannel() <- msgData:
+			acks = acks || client.HasFeature("action_ack")
+		default:
+			log.Printf("[TURN_NOTIFY] WARNING: Priority queue full for user %s", data.PlayerID)
+		}
+	}
+
+	// Only a delivered notification to a client that acks can count as missed
+	if acks {
+		b.recordTurnNotified(tableID, data.PlayerID)
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"sort"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+// LiveSeat is a player's seat at a table running on this server
+type LiveSeat struct {
+	TableID          string `json:"table_id"`
+	TableStatus      string `json:"table_status"`
+	HandNumber       int    `json:"hand_number,omitempty"`
+	Seat             int    `json:"seat"`
+	Chips            int    `json:"chips"`
+	Bet              int    `json:"bet"`
+	Status           string `json:"status"`
+	LastAction       string `json:"last_action,omitempty"`
+	LastActionAmount int    `json:"last_action_amount,omitempty"`
+	ToAct            bool   `json:"to_act"`
+}
+
+// LiveSeats returns every live table seat held by a player, ordered by table ID
+func (b *GameBridge) LiveSeats(userID string) []LiveSeat {
+	b.Mu.RLock()
+	tables := make([]*engine.Table, 0, len(b.Tables))
+	for _, table := range b.Tables {
+		tables = append(tables, table)
+	}
+	b.Mu.RUnlock()
+
+	seats := []LiveSeat{}
+	for _, table := range tables {
+		state := table.Snapshot()
+		for i, p := range state.Players {
+			if p == nil || p.PlayerID != userID {
+				continue
+			}
+			seat := LiveSeat{
+				TableID:          state.TableID,
+				TableStatus:      string(state.Status),
+				Seat:             i,
+				Chips:            p.Chips,
+				Bet:              p.Bet,
+				Status:           string(p.Status),
+				LastAction:       string(p.LastAction),
+				LastActionAmount: p.LastActionAmount,
+			}
+			if state.CurrentHand != nil {
+				seat.HandNumber = state.CurrentHand.HandNumber
+				seat.ToAct = state.Status == pokerModels.StatusPlaying && state.CurrentHand.CurrentPosition == i
+			}
+			seats = append(seats, seat)
+		}
+	}
+
+	sort.Slice(seats, func(i, j int) bool { return seats[i].TableID < seats[j].TableID })
+	return seats
+}
+
+// ConnectionStatus reports how many WebSocket connections a user has open and
+// the tables they are subscribed to
+func (b *GameBridge) ConnectionStatus(userID string) (connections int, tableIDs []string) {
+	tableIDs = []string{}
+	clients := b.Clients.Of(userID)
+	for _, client := range clients {
+		if c, ok := client.(interface{ GetTableID() string }); ok && c.GetTableID() != "" {
+			tableIDs = append(tableIDs, c.GetTableID())
+		}
+	}
+	return len(clients), tableIDs
+}
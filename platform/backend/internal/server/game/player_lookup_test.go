@@ -0,0 +1,67 @@
+package game
+
+import (
+	"testing"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+type fakeClient struct{ tableID string }
+
+func (c fakeClient) GetTableID() string { return c.tableID }
+
+func newLookupTable(bridge *GameBridge, tableID string) *engine.Table {
+	config := pokerModels.TableConfig{SmallBlind: 5, BigBlind: 10, MaxPlayers: 6}
+	table := engine.NewTable(tableID, pokerModels.GameTypeCash, config, func(string, uint64) {}, func(pokerModels.Event) {})
+	bridge.AddTable(tableID, table)
+	return table
+}
+
+func TestLiveSeats(t *testing.T) {
+	bridge := NewGameBridge()
+	tableB := newLookupTable(bridge, "table-b")
+	tableA := newLookupTable(bridge, "table-a")
+	newLookupTable(bridge, "table-c")
+
+	if err := tableB.AddPlayer("alice", "Alice", 2, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	if err := tableA.AddPlayer("alice", "Alice", 4, 300); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	if err := tableA.AddPlayer("bob", "Bob", 0, 300); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+
+	seats := bridge.LiveSeats("alice")
+	if len(seats) != 2 {
+		t.Fatalf("Expected 2 seats, got %d", len(seats))
+	}
+	if seats[0].TableID != "table-a" || seats[0].Seat != 4 || seats[0].Chips != 300 {
+		t.Errorf("Unexpected first seat: %+v", seats[0])
+	}
+	if seats[1].TableID != "table-b" || seats[1].Seat != 2 || seats[1].Chips != 500 {
+		t.Errorf("Unexpected second seat: %+v", seats[1])
+	}
+	if seats[0].ToAct || seats[1].ToAct {
+		t.Error("Expected no one to act at waiting tables")
+	}
+
+	if len(bridge.LiveSeats("carol")) != 0 {
+		t.Error("Expected no seats for a player who is not seated")
+	}
+}
+
+func TestConnectionStatus(t *testing.T) {
+	bridge := NewGameBridge()
+	bridge.Clients.Add("alice", "conn-1", fakeClient{tableID: "table-a"})
+	bridge.Clients.Add("alice", "conn-2", fakeClient{tableID: "table-b"})
+
+	if connections, tableIDs := bridge.ConnectionStatus("alice"); connections != 2 || len(tableIDs) != 2 || tableIDs[0] != "table-a" {
+		t.Errorf("Expected alice connected twice to table-a and table-b, got %d %v", connections, tableIDs)
+	}
+	if connections, _ := bridge.ConnectionStatus("bob"); connections != 0 {
+		t.Error("Expected bob to be disconnected")
+	}
+}
@@ -0,0 +1,179 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+const (
+	// MaxPracticeTablesPerUser bounds the practice tables one user keeps open
+	MaxPracticeTablesPerUser = 3
+
+	// maxPracticeSeats is the number of seats at a practice table
+	maxPracticeSeats = 10
+)
+
+var (
+	ErrPracticeTableNotFound = errors.New("practice table not found")
+	ErrTooManyPracticeTables = fmt.Errorf("at most %d practice tables can be open at once", MaxPracticeTablesPerUser)
+)
+
+// PracticeSeat is one player of a practice spot
+type PracticeSeat struct {
+	Seat  int    `json:"seat"`
+	Name  string `json:"name"`
+	Stack int    `json:"stack"`
+	Cards string `json:"cards"` // Hole cards such as "AhKh", empty to deal at random
+}
+
+// PracticeSpec describes the exact spot a practice table deals
+type PracticeSpec struct {
+	SmallBlind int            `json:"small_blind"`
+	BigBlind   int            `json:"big_blind"`
+	Ante       int            `json:"ante"`
+	Button     int            `json:"button"`
+	Seats      []PracticeSeat `json:"seats"`
+	Board      string         `json:"board"` // Board cards in the order they come, the rest at random
+}
+
+// PracticeTables holds the tables users set up to play an exact spot, for
+// coaching and UI testing. They live only in memory, have no action timer and
+// never touch balances, and they are kept apart from bridge.Tables so the
+// lobby, history and recovery never see them.
+type PracticeTables struct {
+	mu     sync.Mutex
+	tables map[string]*practiceTable // tableID -> table
+}
+
+type practiceTable struct {
+	owner string
+	table *engine.Table
+}
+
+// NewPracticeTables creates an empty set of practice tables
+func NewPracticeTables() *PracticeTables {
+	return &PracticeTables{tables: make(map[string]*practiceTable)}
+}
+
+// PracticePlayerID is the player ID of a seat at a practice table. The owner
+// plays every seat.
+func PracticePlayerID(seat int) string {
+	return fmt.Sprintf("seat-%d", seat)
+}
+
+// Create sets up a practice table for owner and deals the spot
+func (p *PracticeTables) Create(owner string, spec PracticeSpec) (string, *engine.Table, error) {
+	scenario, err := spec.scenario()
+	if err != nil {
+		return "", nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	open := 0
+	for _, pt := range p.tables {
+		if pt.owner == owner {
+			open++
+		}
+	}
+	if open >= MaxPracticeTablesPerUser {
+		return "", nil, ErrTooManyPracticeTables
+	}
+
+	tableID := uuid.New().String()
+	config := pokerModels.TableConfig{
+		SmallBlind: spec.SmallBlind,
+		BigBlind:   spec.BigBlind,
+		Ante:       spec.Ante,
+		MaxPlayers: maxPracticeSeats,
+	}
+	table := engine.NewTable(tableID, pokerModels.GameTypeCash, config, nil, nil)
+	for _, seat := range spec.Seats {
+		name := seat.Name
+		if name == "" {
+			name = fmt.Sprintf("Seat %d", seat.Seat+1)
+		}
+		if err := table.AddPlayer(PracticePlayerID(seat.Seat), name, seat.Seat, seat.Stack); err != nil {
+			return "", nil, fmt.Errorf("seat %d: %w", seat.Seat, err)
+		}
+	}
+	if err := table.SetScenario(scenario); err != nil {
+		return "", nil, err
+	}
+	if err := table.StartGame(); err != nil {
+		return "", nil, err
+	}
+
+	p.tables[tableID] = &practiceTable{owner: owner, table: table}
+	return tableID, table, nil
+}
+
+// Get returns one of owner's practice tables
+func (p *PracticeTables) Get(owner, tableID string) (*engine.Table, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pt, ok := p.tables[tableID]
+	if !ok || pt.owner != owner {
+		return nil, ErrPracticeTableNotFound
+	}
+	return pt.table, nil
+}
+
+// Close removes one of owner's practice tables
+func (p *PracticeTables) Close(owner, tableID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pt, ok := p.tables[tableID]
+	if !ok || pt.owner != owner {
+		return ErrPracticeTableNotFound
+	}
+	delete(p.tables, tableID)
+	return nil
+}
+
+// scenario validates the spec and turns its cards into an engine scenario
+func (s PracticeSpec) scenario() (engine.Scenario, error) {
+	scenario := engine.Scenario{Button: s.Button, HoleCards: make(map[int][]pokerModels.Card)}
+
+	if s.SmallBlind <= 0 || s.BigBlind < s.SmallBlind {
+		return scenario, fmt.Errorf("blinds must be positive with the big blind at least the small blind")
+	}
+	if s.Ante < 0 {
+		return scenario, fmt.Errorf("ante cannot be negative")
+	}
+	if len(s.Seats) < 2 {
+		return scenario, fmt.Errorf("a practice spot needs at least 2 players")
+	}
+	for _, seat := range s.Seats {
+		if seat.Seat < 0 || seat.Seat >= maxPracticeSeats {
+			return scenario, fmt.Errorf("seat %d is not between 0 and %d", seat.Seat, maxPracticeSeats-1)
+		}
+		if seat.Stack <= 0 {
+			return scenario, fmt.Errorf("seat %d needs a stack", seat.Seat)
+		}
+		if seat.Cards == "" {
+			continue
+		}
+		cards, err := engine.ParseCards(seat.Cards)
+		if err != nil {
+			return scenario, fmt.Errorf("seat %d: %w", seat.Seat, err)
+		}
+		scenario.HoleCards[seat.Seat] = cards
+	}
+
+	board, err := engine.ParseCards(s.Board)
+	if err != nil {
+		return scenario, fmt.Errorf("board: %w", err)
+	}
+	scenario.Board = board
+	return scenario, nil
+}
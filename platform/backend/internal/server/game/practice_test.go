@@ -0,0 +1,104 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	pokerModels "poker-engine/models"
+)
+
+func practiceSpec() PracticeSpec {
+	return PracticeSpec{
+		SmallBlind: 5,
+		BigBlind:   10,
+		Button:     3,
+		Seats: []PracticeSeat{
+			{Seat: 0, Name: "Hero", Stack: 400, Cards: "AhKh"},
+			{Seat: 3, Stack: 1200, Cards: "QsQd"},
+			{Seat: 5, Stack: 250},
+		},
+		Board: "Kd7h2c",
+	}
+}
+
+func TestPracticeTables_CreateDealsSpot(t *testing.T) {
+	practice := NewPracticeTables()
+	tableID, table, err := practice.Create("coach", practiceSpec())
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	state := table.Snapshot()
+	if state.Status != pokerModels.StatusPlaying || state.CurrentHand.DealerPosition != 3 {
+		t.Fatalf("Expected a hand dealt with the button on seat 3, got %s with button %d", state.Status, state.CurrentHand.DealerPosition)
+	}
+	hero := state.Players[0]
+	if hero.PlayerID != PracticePlayerID(0) || hero.PlayerName != "Hero" || hero.Cards[0].String()+hero.Cards[1].String() != "AhKh" {
+		t.Errorf("Expected Hero on seat 0 with AhKh, got %s %s %v", hero.PlayerID, hero.PlayerName, hero.Cards)
+	}
+	if name := state.Players[5].PlayerName; name != "Seat 6" {
+		t.Errorf("Expected an unnamed seat to be called Seat 6, got %q", name)
+	}
+	if total := state.Players[0].Chips + state.Players[0].Bet + state.Players[3].Chips + state.Players[3].Bet +
+		state.Players[5].Chips + state.Players[5].Bet; total != 1850 {
+		t.Errorf("Expected the stacks as given, got %d chips in play", total)
+	}
+
+	// Only the owner sees the table
+	if _, err := practice.Get("someone-else", tableID); !errors.Is(err, ErrPracticeTableNotFound) {
+		t.Errorf("Expected another user not to find the table, got %v", err)
+	}
+	if got, err := practice.Get("coach", tableID); err != nil || got != table {
+		t.Errorf("Expected the owner to get the table, got %v", err)
+	}
+	if err := practice.Close("someone-else", tableID); !errors.Is(err, ErrPracticeTableNotFound) {
+		t.Errorf("Expected another user not to close the table, got %v", err)
+	}
+	if err := practice.Close("coach", tableID); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := practice.Get("coach", tableID); !errors.Is(err, ErrPracticeTableNotFound) {
+		t.Error("Expected a closed table to be gone")
+	}
+}
+
+func TestPracticeTables_LimitPerUser(t *testing.T) {
+	practice := NewPracticeTables()
+	for i := 0; i < MaxPracticeTablesPerUser; i++ {
+		if _, _, err := practice.Create("coach", practiceSpec()); err != nil {
+			t.Fatalf("Create %d failed: %v", i+1, err)
+		}
+	}
+	if _, _, err := practice.Create("coach", practiceSpec()); !errors.Is(err, ErrTooManyPracticeTables) {
+		t.Errorf("Expected ErrTooManyPracticeTables, got %v", err)
+	}
+	if _, _, err := practice.Create("student", practiceSpec()); err != nil {
+		t.Errorf("Expected the limit to be per user, got %v", err)
+	}
+}
+
+func TestPracticeTables_InvalidSpec(t *testing.T) {
+	cases := map[string]func(*PracticeSpec){
+		"no blinds":         func(s *PracticeSpec) { s.SmallBlind = 0 },
+		"inverted blinds":   func(s *PracticeSpec) { s.BigBlind = 2 },
+		"one player":        func(s *PracticeSpec) { s.Seats = s.Seats[:1] },
+		"seat out of range": func(s *PracticeSpec) { s.Seats[2].Seat = 10 },
+		"no stack":          func(s *PracticeSpec) { s.Seats[2].Stack = 0 },
+		"bad cards":         func(s *PracticeSpec) { s.Seats[0].Cards = "AhK" },
+		"card twice":        func(s *PracticeSpec) { s.Board = "Ah7h2c" },
+		"empty button":      func(s *PracticeSpec) { s.Button = 1 },
+		"same seat twice":   func(s *PracticeSpec) { s.Seats[2].Seat = 3 },
+	}
+	practice := NewPracticeTables()
+	for name, modify := range cases {
+		spec := practiceSpec()
+		spec.Seats = append([]PracticeSeat(nil), spec.Seats...)
+		modify(&spec)
+		if _, _, err := practice.Create("coach", spec); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if len(practice.tables) != 0 {
+		t.Errorf("Expected no tables kept from invalid specs, got %d", len(practice.tables))
+	}
+}
@@ -0,0 +1,35 @@
+package game
+
+import (
+	"log"
+	"time"
+
+	pokerModels "poker-engine/models"
+)
+
+// RecordProfileEvent keeps the biggest pot of every winner of a completed
+// hand. Other events are ignored.
+func (b *GameBridge) RecordProfileEvent(tableID string, event pokerModels.Event) {
+	if b.Profiles == nil || event.Event != "handComplete" {
+		return
+	}
+	data, ok := event.Data.(pokerModels.HandCompleteEvent)
+	if !ok {
+		return
+	}
+
+	// A player can win more than one pot of a hand
+	won := make(map[string]int, len(data.Winners))
+	for _, winner := range data.Winners {
+		won[winner.PlayerID] += winner.Amount
+	}
+	now := time.Now()
+	for userID, amount := range won {
+		if amount <= 0 {
+			continue
+		}
+		if err := b.Profiles.RecordPot(userID, amount, now); err != nil {
+			log.Printf("[PROFILE] Failed to record a pot of %d for %s at table %s: %v", amount, userID, tableID, err)
+		}
+	}
+}
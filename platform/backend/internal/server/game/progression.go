@@ -0,0 +1,95 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/progression"
+
+	pokerModels "poker-engine/models"
+)
+
+// RecordProgressionEvent grants experience to everyone dealt into a completed
+// hand. Other events are ignored.
+func (b *GameBridge) RecordProgressionEvent(tableID string, event pokerModels.Event) {
+	if b.Progression == nil || event.Event != "handComplete" {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	for _, p := range table.Snapshot().Players {
+		if p == nil || len(p.Cards) == 0 {
+			continue
+		}
+		b.grantXP(p.PlayerID, progression.XPPerHand, "hand at table "+tableID)
+	}
+}
+
+// RecordTournamentFinish grants experience to every player of a completed
+// tournament by where they finished
+func (b *GameBridge) RecordTournamentFinish(tournamentID string, standings []models.TournamentPlayer) {
+	if b.Progression == nil {
+		return
+	}
+	for _, player := range standings {
+		position := 0
+		if player.Position != nil {
+			position = *player.Position
+		}
+		b.grantXP(player.UserID, progression.TournamentXP(position, len(standings)), "tournament "+tournamentID)
+	}
+}
+
+// grantXP grants experience and, when the player reaches a new level, shows it
+// at every table they sit at and tells them what it paid
+func (b *GameBridge) grantXP(userID string, xp int, reason string) {
+	levelUp, err := b.Progression.Grant(userID, xp, reason)
+	if err != nil {
+		log.Printf("[PROGRESSION] Failed to grant %d XP to %s for %s: %v", xp, userID, reason, err)
+		return
+	}
+	if levelUp == nil {
+		return
+	}
+
+	b.Mu.RLock()
+	for _, table := range b.Tables {
+		// Tables the player isn't at report them not found
+		_ = table.SetPlayerLevel(userID, levelUp.Level)
+	}
+	b.Mu.RUnlock()
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "level_up",
+		"payload": eventschema.LevelUpPayload{
+			Level:     levelUp.Level,
+			From:      levelUp.From,
+			XP:        levelUp.XP,
+			NextXP:    progression.XPForLevel(levelUp.Level + 1),
+			Chips:     levelUp.Chips,
+			TicketIDs: levelUp.TicketIDs,
+		},
+	})
+	b.SendToUser(userID, data)
+}
+
+// ShowPlayerLevel sets a player's level at a table they just sat down at
+func (b *GameBridge) ShowPlayerLevel(tableID, userID string) {
+	if b.Progression == nil {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+	level, err := b.Progression.Level(userID)
+	if err != nil {
+		log.Printf("[PROGRESSION] Failed to load level of %s: %v", userID, err)
+		return
+	}
+	table.SetPlayerLevel(userID, level)
+}
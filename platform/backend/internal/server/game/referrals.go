@@ -0,0 +1,53 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/referral"
+
+	pokerModels "poker-engine/models"
+)
+
+// RecordReferralEvent counts a completed hand towards the referral milestones
+// of the referred players dealt into it, and tells both players of every
+// milestone reached what it paid them. Other events are ignored.
+func (b *GameBridge) RecordReferralEvent(tableID string, event pokerModels.Event) {
+	if b.Referrals == nil || event.Event != "handComplete" {
+		return
+	}
+	table, exists := b.GetTable(tableID)
+	if !exists {
+		return
+	}
+
+	var dealtIn []string
+	for _, p := range table.Snapshot().Players {
+		if p != nil && len(p.Cards) > 0 {
+			dealtIn = append(dealtIn, p.PlayerID)
+		}
+	}
+	payouts, err := b.Referrals.RecordHand(dealtIn)
+	if err != nil {
+		log.Printf("[REFERRAL] Failed to count a hand at table %s: %v", tableID, err)
+		return
+	}
+	for _, payout := range payouts {
+		b.sendReferralReward(payout.ReferrerID, payout, payout.ReferrerReward)
+		b.sendReferralReward(payout.RefereeID, payout, payout.RefereeReward)
+	}
+}
+
+func (b *GameBridge) sendReferralReward(userID string, payout referral.Payout, chips int) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "referral_reward",
+		"payload": eventschema.ReferralRewardPayload{
+			Hands:      payout.Hands,
+			Chips:      chips,
+			RefereeID:  payout.RefereeID,
+			ReferrerID: payout.ReferrerID,
+		},
+	})
+	b.SendToUser(userID, data)
+}
@@ -0,0 +1,253 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+
+	pokerModels "poker-engine/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrSessionLimitReached is returned when a player has used up a responsible gaming limit
+var ErrSessionLimitReached = errors.New("responsible gaming limit reached")
+
+// SessionLimits are responsible gaming limits over a rolling 24 hour window.
+// Zero disables a limit.
+type SessionLimits struct {
+	MaxDailyPlayTime time.Duration
+	MaxDailyHands    int
+}
+
+// DefaultSessionLimits has no limits enabled
+var DefaultSessionLimits = SessionLimits{}
+
+// PlayerSession tracks one player's time and hands at one table
+type PlayerSession struct {
+	UserID      string    `json:"user_id"`
+	TableID     string    `json:"table_id"`
+	StartedAt   time.Time `json:"started_at"`
+	HandsDealt  int       `json:"hands_dealt"`
+	HandsPlayed int       `json:"hands_played"` // Hands the player voluntarily put chips in
+
+	playedThisHand bool
+}
+
+// Duration returns how long the session has lasted at now
+func (s PlayerSession) Duration(now time.Time) time.Duration {
+	return now.Sub(s.StartedAt)
+}
+
+// SessionTracker keeps the live sessions of all seated players
+type SessionTracker struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]*PlayerSession // tableID -> userID -> session
+}
+
+// NewSessionTracker creates an empty session tracker
+func NewSessionTracker() *SessionTracker {
+	return &SessionTracker{sessions: make(map[string]map[string]*PlayerSession)}
+}
+
+// session returns the session of a player, starting one if needed. Caller must hold t.mu.
+func (t *SessionTracker) session(tableID, userID string, now time.Time) *PlayerSession {
+	table, exists := t.sessions[tableID]
+	if !exists {
+		table = make(map[string]*PlayerSession)
+		t.sessions[tableID] = table
+	}
+	s, exists := table[userID]
+	if !exists {
+		s = &PlayerSession{UserID: userID, TableID: tableID, StartedAt: now}
+		table[userID] = s
+	}
+	return s
+}
+
+// Start begins a player's session when they sit down
+func (t *SessionTracker) Start(tableID, userID string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.session(tableID, userID, now)
+}
+
+// HandDealt counts a new hand for every player dealt in
+func (t *SessionTracker) HandDealt(tableID string, userIDs []string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, userID := range userIDs {
+		s := t.session(tableID, userID, now)
+		s.HandsDealt++
+		s.playedThisHand = false
+	}
+}
+
+// VoluntaryAction marks the current hand as voluntarily played, once per hand
+func (t *SessionTracker) VoluntaryAction(tableID, userID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, exists := t.sessions[tableID][userID]
+	if !exists || s.playedThisHand {
+		return
+	}
+	s.playedThisHand = true
+	s.HandsPlayed++
+}
+
+// EndTable removes and returns all sessions at a table
+func (t *SessionTracker) EndTable(tableID string) []PlayerSession {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var ended []PlayerSession
+	for _, s := range t.sessions[tableID] {
+		ended = append(ended, *s)
+	}
+	delete(t.sessions, tableID)
+	return ended
+}
+
+// End removes and returns a player's session at a table, if they have one
+func (t *SessionTracker) End(tableID, userID string) []PlayerSession {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, exists := t.sessions[tableID][userID]
+	if !exists {
+		return nil
+	}
+	delete(t.sessions[tableID], userID)
+	return []PlayerSession{*s}
+}
+
+// ForUser returns copies of a player's live sessions
+func (t *SessionTracker) ForUser(userID string) []PlayerSession {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var result []PlayerSession
+	for _, table := range t.sessions {
+		if s, exists := table[userID]; exists {
+			result = append(result, *s)
+		}
+	}
+	return result
+}
+
+// RecordSessionEvent counts dealt and voluntarily played hands from engine events
+func (b *GameBridge) RecordSessionEvent(tableID string, event pokerModels.Event) {
+	switch event.Event {
+	case "handStart":
+		table, exists := b.GetTable(tableID)
+		if !exists {
+			return
+		}
+		var dealt []string
+		for _, p := range table.Snapshot().Players {
+			if p != nil && len(p.Cards) > 0 && p.Status != pokerModels.StatusSittingOut {
+				dealt = append(dealt, p.PlayerID)
+			}
+		}
+		b.Sessions.HandDealt(tableID, dealt, time.Now())
+
+	case "playerAction":
+		data, ok := event.Data.(map[string]interface{})
+		if !ok {
+			return
+		}
+		playerID, _ := data["playerId"].(string)
+		switch pokerModels.PlayerAction(fmt.Sprint(data["action"])) {
+		case pokerModels.ActionCall, pokerModels.ActionRaise, pokerModels.ActionAllIn:
+			b.Sessions.VoluntaryAction(tableID, playerID)
+		}
+	}
+}
+
+// persistSession stores a finished session's hand counts on the player's open seat
+func persistSession(tx *gorm.DB, session PlayerSession) error {
+	return tx.Model(&models.TableSeat{}).
+		Where("table_id = ? AND user_id = ? AND left_at IS NULL", session.TableID, session.UserID).
+		Updates(map[string]interface{}{
+			"hands_dealt":  session.HandsDealt,
+			"hands_played": session.HandsPlayed,
+		}).Error
+}
+
+// EndTableSessions ends all sessions at a table and stores their hand counts
+func EndTableSessions(bridge *GameBridge, database *db.DB, tableID string) {
+	for _, session := range bridge.Sessions.EndTable(tableID) {
+		if err := persistSession(database.DB, session); err != nil {
+			log.Printf("[SESSION] Failed to store session for user %s at table %s: %v", session.UserID, tableID, err)
+		}
+	}
+}
+
+// PlayTotals sums a player's play over a time window
+type PlayTotals struct {
+	PlayTime    time.Duration
+	HandsDealt  int
+	HandsPlayed int
+}
+
+// DailyPlay returns a player's play over the 24 hours before now, combining
+// finished seats from the database with live sessions
+func DailyPlay(database *db.DB, sessions *SessionTracker, userID string, now time.Time) (PlayTotals, error) {
+	since := now.Add(-24 * time.Hour)
+
+	var seats []models.TableSeat
+	if err := database.Where("user_id = ? AND left_at >= ?", userID, since).Find(&seats).Error; err != nil {
+		return PlayTotals{}, fmt.Errorf("failed to load seats: %w", err)
+	}
+
+	var totals PlayTotals
+	for _, seat := range seats {
+		start := seat.JoinedAt
+		if start.Before(since) {
+			start = since
+		}
+		if seat.LeftAt.After(start) {
+			totals.PlayTime += seat.LeftAt.Sub(start)
+		}
+		totals.HandsDealt += seat.HandsDealt
+		totals.HandsPlayed += seat.HandsPlayed
+	}
+
+	for _, s := range sessions.ForUser(userID) {
+		start := s.StartedAt
+		if start.Before(since) {
+			start = since
+		}
+		totals.PlayTime += now.Sub(start)
+		totals.HandsDealt += s.HandsDealt
+		totals.HandsPlayed += s.HandsPlayed
+	}
+	return totals, nil
+}
+
+// Check returns ErrSessionLimitReached if the totals have used up a limit
+func (l SessionLimits) Check(totals PlayTotals) error {
+	if l.MaxDailyPlayTime > 0 && totals.PlayTime >= l.MaxDailyPlayTime {
+		return fmt.Errorf("%w: daily play time of %v", ErrSessionLimitReached, l.MaxDailyPlayTime)
+	}
+	if l.MaxDailyHands > 0 && totals.HandsDealt >= l.MaxDailyHands {
+		return fmt.Errorf("%w: daily limit of %d hands", ErrSessionLimitReached, l.MaxDailyHands)
+	}
+	return nil
+}
+
+// CheckSessionLimits checks a player's last 24 hours of play against the limits.
+// Database errors are logged and do not block the player.
+func CheckSessionLimits(database *db.DB, sessions *SessionTracker, limits SessionLimits, userID string) error {
+	if limits.MaxDailyPlayTime <= 0 && limits.MaxDailyHands <= 0 {
+		return nil
+	}
+	totals, err := DailyPlay(database, sessions, userID, time.Now())
+	if err != nil {
+		log.Printf("[SESSION] Failed to check limits for user %s: %v", userID, err)
+		return nil
+	}
+	return limits.Check(totals)
+}
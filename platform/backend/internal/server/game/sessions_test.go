@@ -0,0 +1,91 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pokerModels "poker-engine/models"
+)
+
+func TestSessionTracker_CountsDealtAndPlayedHands(t *testing.T) {
+	tracker := NewSessionTracker()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tracker.Start("table-1", "alice", start)
+	tracker.HandDealt("table-1", []string{"alice", "bob"}, start.Add(time.Minute))
+
+	// Several voluntary actions in one hand count once
+	tracker.VoluntaryAction("table-1", "alice")
+	tracker.VoluntaryAction("table-1", "alice")
+
+	tracker.HandDealt("table-1", []string{"alice", "bob"}, start.Add(2*time.Minute))
+	tracker.VoluntaryAction("table-1", "bob")
+
+	sessions := tracker.ForUser("alice")
+	if len(sessions) != 1 {
+		t.Fatalf("Expected 1 session for alice, got %d", len(sessions))
+	}
+	alice := sessions[0]
+	if alice.HandsDealt != 2 || alice.HandsPlayed != 1 {
+		t.Errorf("Expected alice 2 dealt / 1 played, got %d / %d", alice.HandsDealt, alice.HandsPlayed)
+	}
+	if !alice.StartedAt.Equal(start) {
+		t.Errorf("Expected session to start when alice sat down, got %v", alice.StartedAt)
+	}
+
+	// bob's session starts lazily with his first dealt hand
+	bob := tracker.ForUser("bob")[0]
+	if bob.HandsDealt != 2 || bob.HandsPlayed != 1 || !bob.StartedAt.Equal(start.Add(time.Minute)) {
+		t.Errorf("Unexpected session for bob: %+v", bob)
+	}
+
+	ended := tracker.EndTable("table-1")
+	if len(ended) != 2 {
+		t.Errorf("Expected 2 ended sessions, got %d", len(ended))
+	}
+	if len(tracker.ForUser("alice")) != 0 {
+		t.Error("Expected no live sessions after the table ended")
+	}
+}
+
+func TestGameBridge_RecordSessionEventCountsVoluntaryActions(t *testing.T) {
+	bridge := NewGameBridge()
+	bridge.Sessions.Start("table-1", "alice", time.Now())
+	bridge.Sessions.HandDealt("table-1", []string{"alice"}, time.Now())
+
+	for _, action := range []pokerModels.PlayerAction{pokerModels.ActionCheck, pokerModels.ActionFold} {
+		bridge.RecordSessionEvent("table-1", pokerModels.Event{
+			Event: "playerAction",
+			Data:  map[string]interface{}{"playerId": "alice", "action": string(action)},
+		})
+	}
+	if played := bridge.Sessions.ForUser("alice")[0].HandsPlayed; played != 0 {
+		t.Errorf("Expected checks and folds not to count as played, got %d", played)
+	}
+
+	bridge.RecordSessionEvent("table-1", pokerModels.Event{
+		Event: "playerAction",
+		Data:  map[string]interface{}{"playerId": "alice", "action": "call", "amount": 20},
+	})
+	if played := bridge.Sessions.ForUser("alice")[0].HandsPlayed; played != 1 {
+		t.Errorf("Expected a call to count as played, got %d", played)
+	}
+}
+
+func TestSessionLimits_Check(t *testing.T) {
+	limits := SessionLimits{MaxDailyPlayTime: 2 * time.Hour, MaxDailyHands: 500}
+
+	if err := limits.Check(PlayTotals{PlayTime: time.Hour, HandsDealt: 100}); err != nil {
+		t.Errorf("Expected play within limits to pass, got %v", err)
+	}
+	if err := limits.Check(PlayTotals{PlayTime: 2 * time.Hour}); !errors.Is(err, ErrSessionLimitReached) {
+		t.Errorf("Expected play time limit to be reached, got %v", err)
+	}
+	if err := limits.Check(PlayTotals{HandsDealt: 500}); !errors.Is(err, ErrSessionLimitReached) {
+		t.Errorf("Expected hand limit to be reached, got %v", err)
+	}
+	if err := DefaultSessionLimits.Check(PlayTotals{PlayTime: 100 * time.Hour, HandsDealt: 100000}); err != nil {
+		t.Errorf("Expected default limits to be disabled, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	pokerModels "poker-engine/models"
+)
+
+// ShowCardsWindow is how long the winner of a cash table pot nobody
+// contested has to show their cards, in seconds, before the next hand
+const ShowCardsWindow = 5
+
+// ShowCardsPause is how long cards a winner showed stay up before the next
+// hand is dealt. A winner who shows nothing doesn't hold the table up.
+const ShowCardsPause = 2 * time.Second
+
+// SendShowCardsPrompt asks the winner of a pot nobody contested whether to
+// show their cards
+func (b *GameBridge) SendShowCardsPrompt(tableID string, event pokerModels.Event) {
+	data, ok := event.Data.(map[string]interface{})
+	if !ok {
+		return
+	}
+	playerID, _ := data["playerId"].(string)
+	deadline, _ := data["deadline"].(time.Time)
+
+	msgData, err := json.Marshal(map[string]interface{}{
+		"type": "show_cards_prompt",
+		"payload": map[string]interface{}{
+			"table_id": tableID,
+			"deadline": deadline,
+		},
+	})
+	if err != nil {
+		return
+	}
+	if b.SendToUser(playerID, msgData) == 0 && b.Clients.Connected(playerID) {
+		log.Printf("[SHOW_CARDS] WARNING: Send channel full for user %s", playerID)
+	}
+}
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"log"
+	"sort"
+
+	pokerModels "poker-engine/models"
+)
+
+// PauseAllTables stops every engine table for a shutdown. Each table is held
+// between hands, a hand in progress is paused with its action timer and blind
+// timers are stopped, so nothing moves while the tables are saved. It returns
+// the state of each table once stopped, ordered by ID.
+func PauseAllTables(bridge *GameBridge) []*pokerModels.Table {
+	bridge.Mu.RLock()
+	tableIDs := make([]string, 0, len(bridge.Tables))
+	for tableID := range bridge.Tables {
+		tableIDs = append(tableIDs, tableID)
+	}
+	bridge.Mu.RUnlock()
+	sort.Strings(tableIDs)
+
+	states := make([]*pokerModels.Table, 0, len(tableIDs))
+	for _, tableID := range tableIDs {
+		table, exists := bridge.GetTable(tableID)
+		if !exists {
+			continue
+		}
+		table.HoldBetweenHands(true)
+		table.Stop()
+		if table.Snapshot().Status == pokerModels.StatusPlaying {
+			if err := table.Pause(); err != nil {
+				// The hand ended in between; the hold keeps the next one from starting
+				log.Printf("[SHUTDOWN] Table %s was not paused: %v", tableID, err)
+			}
+		}
+		states = append(states, table.Snapshot())
+	}
+	return states
+}
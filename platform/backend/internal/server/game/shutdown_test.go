@@ -0,0 +1,37 @@
+package game
+
+import (
+	"testing"
+
+	pokerModels "poker-engine/models"
+)
+
+func TestPauseAllTables(t *testing.T) {
+	bridge := NewGameBridge()
+	playing := newLookupTable(bridge, "table-b")
+	newLookupTable(bridge, "table-a")
+
+	if err := playing.AddPlayer("alice", "Alice", 0, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	if err := playing.AddPlayer("bob", "Bob", 1, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+	if err := playing.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+
+	states := PauseAllTables(bridge)
+	if len(states) != 2 || states[0].TableID != "table-a" || states[1].TableID != "table-b" {
+		t.Fatalf("Expected both tables ordered by ID, got %d tables", len(states))
+	}
+	if states[0].Status != pokerModels.StatusWaiting {
+		t.Errorf("Expected the idle table to stay waiting, got %s", states[0].Status)
+	}
+	if states[1].Status != pokerModels.StatusPaused || states[1].CurrentHand == nil {
+		t.Errorf("Expected the hand at table-b to be paused, got %s", states[1].Status)
+	}
+	if !playing.IsHeldBetweenHands() {
+		t.Error("Expected table-b to be held so no further hand starts")
+	}
+}
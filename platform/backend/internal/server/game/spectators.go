@@ -0,0 +1,313 @@
+package game
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+)
+
+// MaxSpectatorDelay is the longest spectator delay a table can have
+const MaxSpectatorDelay = 10 * time.Minute
+
+// maxDelayedFrames bounds how many messages a table's spectator feed holds back
+const maxDelayedFrames = 20000
+
+// delayedFrame is a table message waiting to be released to spectators
+type delayedFrame struct {
+	releaseAt time.Time
+	data      []byte
+	cardsUp   []byte // Table state with all hole cards for production clients; nil if the same as data
+	state     bool   // Full table state, kept for spectators who subscribe later
+}
+
+// spectatorFeed releases one table's messages to its spectators in order
+type spectatorFeed struct {
+	queue         []delayedFrame
+	latest        []byte
+	latestCardsUp []byte
+	wake          chan struct{}
+	stop          chan struct{}
+}
+
+// SpectatorHub holds back table data from spectators of tables with a
+// spectator delay, so a private game can be streamed without giving viewers
+// information the players don't have yet. Seated players are never delayed.
+// Production clients of a broadcast table get the same delayed feed with every
+// player's hole cards.
+type SpectatorHub struct {
+	mu        sync.Mutex
+	loadDelay func(tableID string) time.Duration
+	deliver   func(tableID string, data, cardsUp []byte)
+	delays    map[string]time.Duration
+	feeds     map[string]*spectatorFeed
+}
+
+// NewSpectatorHub creates a spectator hub. loadDelay looks up the delay of a
+// table the hub hasn't seen yet; deliver sends a released message to the
+// table's spectators.
+func NewSpectatorHub(loadDelay func(tableID string) time.Duration, deliver func(tableID string, data, cardsUp []byte)) *SpectatorHub {
+	return &SpectatorHub{
+		loadDelay: loadDelay,
+		deliver:   deliver,
+		delays:    make(map[string]time.Duration),
+		feeds:     make(map[string]*spectatorFeed),
+	}
+}
+
+// SpectatorDelayLoader returns a delay lookup that reads the table's setting
+func SpectatorDelayLoader(database *db.DB) func(tableID string) time.Duration {
+	return func(tableID string) time.Duration {
+		var table models.Table
+		if err := database.Select("spectator_delay").Where("id = ?", tableID).First(&table).Error; err != nil {
+			return 0
+		}
+		return time.Duration(table.SpectatorDelay) * time.Second
+	}
+}
+
+// Delay returns the spectator delay of a table. A nil hub delays nothing.
+func (h *SpectatorHub) Delay(tableID string) time.Duration {
+	if h == nil {
+		return 0
+	}
+
+	h.mu.Lock()
+	delay, ok := h.delays[tableID]
+	h.mu.Unlock()
+	if ok {
+		return delay
+	}
+
+	delay = 0
+	if h.loadDelay != nil {
+		delay = h.loadDelay(tableID)
+	}
+	h.mu.Lock()
+	if cached, ok := h.delays[tableID]; ok {
+		delay = cached // Set while we were loading
+	} else {
+		h.delays[tableID] = delay
+	}
+	h.mu.Unlock()
+	return delay
+}
+
+// SetDelay changes a table's spectator delay. Turning the delay off drops the
+// messages still held back; spectators pick up from the next live update.
+func (h *SpectatorHub) SetDelay(tableID string, delay time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.delays[tableID] = delay
+	if delay <= 0 {
+		if feed, ok := h.feeds[tableID]; ok {
+			close(feed.stop)
+			delete(h.feeds, tableID)
+		}
+	}
+}
+
+// HoldState queues a table state message for release to spectators after the
+// table's delay, along with its cards-up version for production clients. Once
+// released it is also sent to spectators who subscribe.
+func (h *SpectatorHub) HoldState(tableID string, data, cardsUp []byte) {
+	h.hold(tableID, delayedFrame{data: data, cardsUp: cardsUp, state: true})
+}
+
+// Hold queues any other table message for release to spectators
+func (h *SpectatorHub) Hold(tableID string, data []byte) {
+	h.hold(tableID, delayedFrame{data: data})
+}
+
+// Latest returns the most recent table state released to spectators, or its
+// cards-up version, or nil if none has been released yet
+func (h *SpectatorHub) Latest(tableID string, cardsUp bool) []byte {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	feed, ok := h.feeds[tableID]
+	if !ok {
+		return nil
+	}
+	if cardsUp && feed.latestCardsUp != nil {
+		return feed.latestCardsUp
+	}
+	return feed.latest
+}
+
+func (h *SpectatorHub) hold(tableID string, frame delayedFrame) {
+	delay := h.Delay(tableID)
+	if delay <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	feed, ok := h.feeds[tableID]
+	if !ok {
+		feed = &spectatorFeed{
+			wake: make(chan struct{}, 1),
+			stop: make(chan struct{}),
+		}
+		h.feeds[tableID] = feed
+		go h.run(tableID, feed)
+	}
+	if len(feed.queue) >= maxDelayedFrames {
+		log.Printf("[SPECTATOR] Feed for table %s is full, dropping message", tableID)
+		return
+	}
+	frame.releaseAt = time.Now().Add(delay)
+	feed.queue = append(feed.queue, frame)
+
+	select {
+	case feed.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run releases a feed's messages as their delay runs out
+func (h *SpectatorHub) run(tableID string, feed *spectatorFeed) {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		h.mu.Lock()
+		if len(feed.queue) == 0 {
+			h.mu.Unlock()
+			select {
+			case <-feed.wake:
+				continue
+			case <-feed.stop:
+				return
+			}
+		}
+		next := feed.queue[0]
+		h.mu.Unlock()
+
+		if wait := time.Until(next.releaseAt); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-feed.stop:
+				return
+			}
+		}
+
+		h.mu.Lock()
+		select {
+		case <-feed.stop:
+			h.mu.Unlock()
+			return
+		default:
+		}
+		feed.queue[0] = delayedFrame{}
+		feed.queue = feed.queue[1:]
+		if next.state {
+			feed.latest = next.data
+			feed.latestCardsUp = next.cardsUp
+		}
+		h.mu.Unlock()
+
+		h.deliver(tableID, next.data, next.cardsUp)
+	}
+}
+
+// seatedPlayers returns the players seated at a table. Caller must hold b.Mu.
+func (b *GameBridge) seatedPlayers(tableID string) map[string]bool {
+	seated := make(map[string]bool)
+	if table, ok := b.Tables[tableID]; ok {
+		for _, p := range table.Snapshot().Players {
+			if p != nil {
+				seated[p.PlayerID] = true
+			}
+		}
+	} else {
+		// A table another instance runs, as of its last state
+		for userID := range b.RemoteSeats[tableID] {
+			seated[userID] = true
+		}
+	}
+	return seated
+}
+
+// DeliverToSpectators sends a message to every client watching a table who
+// isn't seated at it. Production clients get the cards-up version if any.
+func (b *GameBridge) DeliverToSpectators(tableID string, data, cardsUp []byte) {
+	b.Mu.RLock()
+	defer b.Mu.RUnlock()
+
+	seated := b.seatedPlayers(tableID)
+
+	type ClientWithTable interface {
+		GetTableID() string
+		GetSendChannel() chan []byte
+	}
+	b.Clients.Each(func(userID string, clientInterface interface{}) {
+		if client, ok := clientInterface.(ClientWithTable); ok {
+			if client.GetTableID() == tableID && !seated[userID] {
+				message := data
+				if production, ok := clientInterface.(interface{ GetCardsUp() bool }); ok && production.GetCardsUp() && cardsUp != nil {
+					message = cardsUp
+				}
+				select {
+				case client.GetSendChannel() <- message:
+				default:
+					// Channel full, skip
+				}
+			}
+		}
+	})
+}
+
+// SendToTable sends a message to everyone at a table, including the clients
+// of other instances in a cluster. Spectators of a table with a spectator
+// delay get it once the delay has passed.
+func (b *GameBridge) SendToTable(tableID string, data []byte) int {
+	sent := b.deliverToTable(tableID, data)
+	b.publishTableMessage(tableID, data)
+	return sent
+}
+
+// deliverToTable sends a message to everyone at a table connected to this
+// instance
+func (b *GameBridge) deliverToTable(tableID string, data []byte) int {
+	delayed := b.Spectators.Delay(tableID) > 0
+
+	b.Mu.RLock()
+	var seated map[string]bool
+	if delayed {
+		seated = b.seatedPlayers(tableID)
+	}
+
+	sent := 0
+	type ClientWithTable interface {
+		GetTableID() string
+		GetSendChannel() chan []byte
+	}
+	b.Clients.Each(func(userID string, clientInterface interface{}) {
+		if client, ok := clientInterface.(ClientWithTable); ok {
+			if client.GetTableID() == tableID && (!delayed || seated[userID]) {
+				select {
+				case client.GetSendChannel() <- data:
+					sent++
+				default:
+					// Channel full, skip
+				}
+			}
+		}
+	})
+	b.Mu.RUnlock()
+
+	if delayed {
+		b.Spectators.Hold(tableID, data)
+	}
+	return sent
+}
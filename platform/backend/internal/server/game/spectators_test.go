@@ -0,0 +1,120 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+type channelClient struct {
+	tableID string
+	send    chan []byte
+}
+
+func (c channelClient) GetTableID() string          { return c.tableID }
+func (c channelClient) GetSendChannel() chan []byte { return c.send }
+
+func newChannelClient(bridge *GameBridge, userID, tableID string) chan []byte {
+	send := make(chan []byte, 8)
+	bridge.Clients.Add(userID, userID+"-"+tableID, channelClient{tableID: tableID, send: send})
+	return send
+}
+
+func TestSendToTable_DelaysSpectators(t *testing.T) {
+	bridge := NewGameBridge()
+	bridge.Spectators = NewSpectatorHub(func(string) time.Duration { return 50 * time.Millisecond }, bridge.DeliverToSpectators)
+	table := newLookupTable(bridge, "table-a")
+	if err := table.AddPlayer("alice", "Alice", 0, 500); err != nil {
+		t.Fatalf("AddPlayer failed: %v", err)
+	}
+
+	player := newChannelClient(bridge, "alice", "table-a")
+	spectator := newChannelClient(bridge, "bob", "table-a")
+
+	start := time.Now()
+	if sent := bridge.SendToTable("table-a", []byte("update")); sent != 1 {
+		t.Fatalf("Expected the update to go straight to 1 player, sent to %d", sent)
+	}
+
+	select {
+	case <-player:
+	default:
+		t.Fatal("Expected the seated player to get the update immediately")
+	}
+
+	select {
+	case <-spectator:
+		if time.Since(start) < 50*time.Millisecond {
+			t.Error("Expected the spectator to get the update after the delay")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the spectator to get the update once the delay passed")
+	}
+}
+
+func TestSpectatorHub_LatestState(t *testing.T) {
+	released := make(chan string, 4)
+	hub := NewSpectatorHub(func(string) time.Duration { return 10 * time.Millisecond }, func(_ string, data, _ []byte) {
+		released <- string(data)
+	})
+
+	hub.HoldState("table-a", []byte("state-1"), []byte("cards-up-1"))
+	hub.Hold("table-a", []byte("history"))
+
+	for _, want := range []string{"state-1", "history"} {
+		select {
+		case got := <-released:
+			if got != want {
+				t.Errorf("Expected %q to be released next, got %q", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for %q", want)
+		}
+	}
+	if latest := hub.Latest("table-a", false); string(latest) != "state-1" {
+		t.Errorf("Expected latest released state to be state-1, got %q", latest)
+	}
+	if latest := hub.Latest("table-a", true); string(latest) != "cards-up-1" {
+		t.Errorf("Expected latest cards-up state to be cards-up-1, got %q", latest)
+	}
+}
+
+func TestSpectatorHub_SetDelay(t *testing.T) {
+	loads := 0
+	released := make(chan string, 4)
+	hub := NewSpectatorHub(func(string) time.Duration {
+		loads++
+		return 0
+	}, func(_ string, data, _ []byte) {
+		released <- string(data)
+	})
+
+	hub.Delay("table-a")
+	hub.Delay("table-a")
+	if loads != 1 {
+		t.Errorf("Expected the delay to be loaded once, loaded %d times", loads)
+	}
+
+	// Nothing is held back without a delay
+	hub.HoldState("table-a", []byte("live"), nil)
+	if hub.Latest("table-a", false) != nil {
+		t.Error("Expected no spectator feed for a table without a delay")
+	}
+
+	hub.SetDelay("table-a", time.Hour)
+	hub.HoldState("table-a", []byte("held"), nil)
+	hub.SetDelay("table-a", 0)
+
+	select {
+	case got := <-released:
+		t.Errorf("Expected held messages to be dropped when the delay is turned off, got %q", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if hub.Delay("table-a") != 0 {
+		t.Error("Expected the delay to be off")
+	}
+
+	var nilHub *SpectatorHub
+	if nilHub.Delay("table-a") != 0 || nilHub.Latest("table-a", false) != nil {
+		t.Error("Expected a nil hub to delay nothing")
+	}
+}
@@ -0,0 +1,181 @@
+package game
+
+import (
+	"sync"
+	"time"
+
+	pokerModels "poker-engine/models"
+)
+
+// tableStatsWindow is the number of recent hands lobby stats are computed from
+const tableStatsWindow = 50
+
+// TableStatsSummary is the lobby view of a table's recent action
+type TableStatsSummary struct {
+	Hands          int     `json:"hands"`            // Hands the stats are based on
+	PlayersPerFlop float64 `json:"players_per_flop"` // Percentage of dealt-in players who saw the flop
+	AvgPot         int     `json:"avg_pot"`
+	HandsPerHour   float64 `json:"hands_per_hour"`
+}
+
+// handSample holds the numbers recorded for one hand
+type handSample struct {
+	playersDealt int
+	playersFlop  int
+	pot          int
+	completedAt  time.Time
+}
+
+// TableStats accumulates rolling statistics over a table's recent hands
+type TableStats struct {
+	mu      sync.Mutex
+	current *handSample
+	hands   []handSample // Ring buffer of completed hands, oldest first once full
+	next    int
+}
+
+// NewTableStats creates an empty stats accumulator
+func NewTableStats() *TableStats {
+	return &TableStats{hands: make([]handSample, 0, tableStatsWindow)}
+}
+
+// HandStarted begins a new hand with the number of players dealt in
+func (s *TableStats) HandStarted(playersDealt int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = &handSample{playersDealt: playersDealt}
+}
+
+// FlopSeen records how many players were still in the hand when the flop was dealt
+func (s *TableStats) FlopSeen(playersInHand int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != nil {
+		s.current.playersFlop = playersInHand
+	}
+}
+
+// HandCompleted adds the current hand to the window
+func (s *TableStats) HandCompleted(pot int, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current == nil || s.current.playersDealt == 0 {
+		s.current = nil
+		return
+	}
+	sample := *s.current
+	sample.pot = pot
+	sample.completedAt = at
+	s.current = nil
+
+	if len(s.hands) < tableStatsWindow {
+		s.hands = append(s.hands, sample)
+		return
+	}
+	s.hands[s.next] = sample
+	s.next = (s.next + 1) % tableStatsWindow
+}
+
+// Summary computes the lobby stats over the recorded hands.
+// Returns nil until at least one hand has completed.
+func (s *TableStats) Summary() *TableStatsSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.hands) == 0 {
+		return nil
+	}
+
+	var dealt, flop, pots int
+	first, last := s.hands[0].completedAt, s.hands[0].completedAt
+	for _, h := range s.hands {
+		dealt += h.playersDealt
+		flop += h.playersFlop
+		pots += h.pot
+		if h.completedAt.Before(first) {
+			first = h.completedAt
+		}
+		if h.completedAt.After(last) {
+			last = h.completedAt
+		}
+	}
+
+	summary := &TableStatsSummary{
+		Hands:          len(s.hands),
+		PlayersPerFlop: float64(flop) * 100 / float64(dealt),
+		AvgPot:         pots / len(s.hands),
+	}
+	// Hands per hour needs an elapsed time, so the first hand only marks the start
+	if elapsed := last.Sub(first); len(s.hands) > 1 && elapsed > 0 {
+		summary.HandsPerHour = float64(len(s.hands)-1) / elapsed.Hours()
+	}
+	return summary
+}
+
+// Stats returns the stats accumulator of a table, creating it on first use
+func (b *GameBridge) Stats(tableID string) *TableStats {
+	b.Mu.Lock()
+	defer b.Mu.Unlock()
+	stats, exists := b.TableStats[tableID]
+	if !exists {
+		stats = NewTableStats()
+		b.TableStats[tableID] = stats
+	}
+	return stats
+}
+
+// StatsSummary returns the lobby stats of a table, or nil if it has no completed hands
+func (b *GameBridge) StatsSummary(tableID string) *TableStatsSummary {
+	b.Mu.RLock()
+	stats, exists := b.TableStats[tableID]
+	b.Mu.RUnlock()
+	if !exists {
+		return nil
+	}
+	return stats.Summary()
+}
+
+// RecordStatsEvent feeds hand start, flop and hand completion events into the
+// table's stats accumulator. Other events are ignored.
+func (b *GameBridge) RecordStatsEvent(tableID string, event pokerModels.Event) {
+	switch event.Event {
+	case "handStart", "roundAdvanced":
+		table, exists := b.GetTable(tableID)
+		if !exists {
+			return
+		}
+		state := table.Snapshot()
+		if event.Event == "roundAdvanced" && (state.CurrentHand == nil || state.CurrentHand.BettingRound != pokerModels.RoundFlop) {
+			return
+		}
+
+		players := 0
+		for _, p := range state.Players {
+			if p == nil || len(p.Cards) == 0 {
+				continue
+			}
+			if p.Status == pokerModels.StatusActive || p.Status == pokerModels.StatusAllIn ||
+				(event.Event == "handStart" && p.Status == pokerModels.StatusFolded) {
+				players++
+			}
+		}
+
+		if event.Event == "handStart" {
+			b.Stats(tableID).HandStarted(players)
+		} else {
+			b.Stats(tableID).FlopSeen(players)
+		}
+
+	case "handComplete":
+		data, ok := event.Data.(pokerModels.HandCompleteEvent)
+		if !ok {
+			return
+		}
+		pot := 0
+		for _, winner := range data.Winners {
+			pot += winner.Amount
+		}
+		b.Stats(tableID).HandCompleted(pot, time.Now())
+	}
+}
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTableStats_Summary(t *testing.T) {
+	stats := NewTableStats()
+	if stats.Summary() != nil {
+		t.Fatal("Expected no summary before any hand completes")
+	}
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// 6 dealt, 3 see the flop, 200 pot
+	stats.HandStarted(6)
+	stats.FlopSeen(3)
+	stats.HandCompleted(200, start)
+
+	// 6 dealt, nobody sees the flop, 30 pot
+	stats.HandStarted(6)
+	stats.HandCompleted(30, start.Add(2*time.Minute))
+
+	// 4 dealt, 3 see the flop, 100 pot
+	stats.HandStarted(4)
+	stats.FlopSeen(3)
+	stats.HandCompleted(100, start.Add(4*time.Minute))
+
+	summary := stats.Summary()
+	if summary.Hands != 3 {
+		t.Errorf("Expected 3 hands, got %d", summary.Hands)
+	}
+	if summary.PlayersPerFlop != 37.5 {
+		t.Errorf("Expected 37.5%% players per flop, got %.2f", summary.PlayersPerFlop)
+	}
+	if summary.AvgPot != 110 {
+		t.Errorf("Expected average pot 110, got %d", summary.AvgPot)
+	}
+	if summary.HandsPerHour != 30 {
+		t.Errorf("Expected 30 hands per hour, got %.2f", summary.HandsPerHour)
+	}
+}
+
+func TestTableStats_RollingWindow(t *testing.T) {
+	stats := NewTableStats()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < tableStatsWindow+10; i++ {
+		pot := 10
+		if i >= 10 {
+			pot = 50
+		}
+		stats.HandStarted(2)
+		stats.HandCompleted(pot, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	summary := stats.Summary()
+	if summary.Hands != tableStatsWindow {
+		t.Errorf("Expected window of %d hands, got %d", tableStatsWindow, summary.Hands)
+	}
+	if summary.AvgPot != 50 {
+		t.Errorf("Expected oldest hands to drop out of the window, got average pot %d", summary.AvgPot)
+	}
+	if summary.HandsPerHour != 60 {
+		t.Errorf("Expected 60 hands per hour, got %.2f", summary.HandsPerHour)
+	}
+}
+
+func TestTableStats_CompletionWithoutStartIgnored(t *testing.T) {
+	stats := NewTableStats()
+	stats.HandCompleted(100, time.Now())
+	if stats.Summary() != nil {
+		t.Error("Expected a hand without a recorded start to be ignored")
+	}
+}
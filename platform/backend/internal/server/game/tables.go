@@ -6,7 +6,9 @@ import (
 	"log"
 	"time"
 
+	"poker-platform/backend/internal/club"
 	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/ids"
 	"poker-platform/backend/internal/models"
 
 	"poker-engine/engine"
@@ -16,32 +18,84 @@ import (
 
 // TablePreset defines a predefined table configuration
 type TablePreset struct {
-	MaxPlayers int
-	SmallBlind int
-	BigBlind   int
-	MinBuyIn   int
-	MaxBuyIn   int
-	Name       string
+	MaxPlayers   int
+	SmallBlind   int
+	BigBlind     int
+	MinBuyIn     int
+	MaxBuyIn     int
+	DefaultBuyIn int // Starting stack used when a player does not choose a buy-in
+	Name         string
 }
 
 // TablePresets contains all predefined table configurations
 var TablePresets = map[string]TablePreset{
 	"headsup": {
-		MaxPlayers: 2,
-		SmallBlind: 5,
-		BigBlind:   10,
-		MinBuyIn:   100,
-		MaxBuyIn:   1000,
-		Name:       "Heads-Up",
+		MaxPlayers:   2,
+		SmallBlind:   5,
+		BigBlind:     10,
+		MinBuyIn:     100,
+		MaxBuyIn:     1000,
+		DefaultBuyIn: 100,
+		Name:         "Heads-Up",
 	},
 	"3player": {
-		MaxPlayers: 3,
-		SmallBlind: 10,
-		BigBlind:   20,
-		MinBuyIn:   200,
-		MaxBuyIn:   2000,
-		Name:       "3-Player",
+		MaxPlayers:   3,
+		SmallBlind:   10,
+		BigBlind:     20,
+		MinBuyIn:     200,
+		MaxBuyIn:     2000,
+		DefaultBuyIn: 200,
+		Name:         "3-Player",
 	},
+	"6max": {
+		MaxPlayers:   6,
+		SmallBlind:   10,
+		BigBlind:     20,
+		MinBuyIn:     400,
+		MaxBuyIn:     4000,
+		DefaultBuyIn: 400,
+		Name:         "6-Max",
+	},
+	"9max": {
+		MaxPlayers:   9,
+		SmallBlind:   10,
+		BigBlind:     20,
+		MinBuyIn:     400,
+		MaxBuyIn:     4000,
+		DefaultBuyIn: 400,
+		Name:         "9-Max",
+	},
+	"10max": {
+		MaxPlayers:   10,
+		SmallBlind:   10,
+		BigBlind:     20,
+		MinBuyIn:     400,
+		MaxBuyIn:     4000,
+		DefaultBuyIn: 400,
+		Name:         "10-Max",
+	},
+}
+
+// PresetForSeats returns the preset key for a given seat count
+func PresetForSeats(maxPlayers int) (string, bool) {
+	for key, preset := range TablePresets {
+		if preset.MaxPlayers == maxPlayers {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// ResolveBuyIn returns the buy-in for a requested amount, using the preset's
+// starting stack when none was requested
+func (p TablePreset) ResolveBuyIn(requested int) (int, error) {
+	if requested == 0 {
+		return p.DefaultBuyIn, nil
+	}
+	if requested < p.MinBuyIn || requested > p.MaxBuyIn {
+		return 0, fmt.Errorf("buy-in must be between %d and %d", p.MinBuyIn, p.MaxBuyIn)
+	}
+	return requested, nil
 }
 
 // CreateEngineTable creates a new poker table in the game engine
@@ -49,9 +103,14 @@ func CreateEngineTable(
 	bridge *GameBridge,
 	tableID, gameType string,
 	smallBlind, bigBlind, maxPlayers, minBuyIn, maxBuyIn int,
-	onTimeout func(playerID string),
+	onTimeout func(playerID string, deadline uint64),
 	onEvent func(event pokerModels.Event),
 ) {
+	if !bridge.ClaimTable(tableID) {
+		log.Printf("[CLUSTER] Table %s is run by another instance; not created here", tableID)
+		return
+	}
+
 	bridge.Mu.Lock()
 	defer bridge.Mu.Unlock()
 
@@ -63,20 +122,56 @@ func CreateEngineTable(
 	}
 
 	config := pokerModels.TableConfig{
-		SmallBlind:    smallBlind,
-		BigBlind:      bigBlind,
-		MaxPlayers:    maxPlayers,
-		MinBuyIn:      minBuyIn,
-		MaxBuyIn:      maxBuyIn,
-		ActionTimeout: 30,
+		SmallBlind:      smallBlind,
+		BigBlind:        bigBlind,
+		MaxPlayers:      maxPlayers,
+		MinBuyIn:        minBuyIn,
+		MaxBuyIn:        maxBuyIn,
+		ActionTimeout:   30,
+		TimeBank:        60,
+		DisconnectGrace: DisconnectGrace,
+	}
+	if gt == pokerModels.GameTypeCash {
+		config.ShowCardsWindow = ShowCardsWindow
 	}
 
 	table := engine.NewTable(tableID, gt, config, onTimeout, onEvent)
+	table.SetRateLimits(engine.DefaultRateLimits)
 	bridge.Tables[tableID] = table
 
 	log.Printf("Created engine table %s", tableID)
 }
 
+// ApplyTableSettings copies a table's currency symbol, chip scale, straddle
+// option, showdown policy and betting mode from the database to its engine
+// table, so state updates carry them to clients
+func ApplyTableSettings(bridge *GameBridge, database *db.DB, tableID string) {
+	table, exists := bridge.GetTable(tableID)
+	if !exists {
+		return
+	}
+
+	var row models.Table
+	if err := database.Select("id", "currency_symbol", "chip_scale", "allow_straddle", "showdown_policy", "betting_mode").Where("id = ?", tableID).First(&row).Error; err != nil {
+		log.Printf("Failed to load display settings for table %s: %v", tableID, err)
+		return
+	}
+	if err := table.SetDisplay(row.CurrencySymbol, row.ChipScale); err != nil {
+		log.Printf("Failed to apply display settings to table %s: %v", tableID, err)
+	}
+	if row.AllowStraddle {
+		if err := table.SetStraddle(true); err != nil {
+			log.Printf("Failed to allow straddling at table %s: %v", tableID, err)
+		}
+	}
+	if err := table.SetShowdownPolicy(row.ShowdownPolicy); err != nil {
+		log.Printf("Failed to apply the showdown policy to table %s: %v", tableID, err)
+	}
+	if err := table.SetBettingMode(row.BettingMode); err != nil {
+		log.Printf("Failed to apply the betting mode to table %s: %v", tableID, err)
+	}
+}
+
 // AddPlayerToEngine adds a player to an existing poker table
 func AddPlayerToEngine(
 	bridge *GameBridge,
@@ -101,6 +196,13 @@ func AddPlayerToEngine(
 	}
 
 	log.Printf("Added player %s to table %s", userID, tableID)
+	bridge.Sessions.Start(tableID, userID, time.Now())
+	if bridge.Frozen.IsFrozen(userID) {
+		table.SetPlayerFrozen(userID, true)
+	}
+	bridge.ShowPlayerLevel(tableID, userID)
+	bridge.ShowPlayerAvatar(tableID, userID)
+	bridge.ShowPlayerBot(tableID, userID)
 
 	go func() {
 		time.Sleep(2 * time.Second)
@@ -151,6 +253,7 @@ func CheckAndStartGame(bridge *GameBridge, database *db.DB, tableID string, broa
 				"status":     "playing",
 				"started_at": &now,
 			})
+			startSessionClock(database, &tableRecord, now)
 			broadcastFunc(tableID)
 		}
 	}
@@ -198,28 +301,25 @@ func SyncFinalChipsOnGameComplete(bridge *GameBridge, database *db.DB, tableID s
 
 	state := table.GetState()
 
+	// Sessions end with the game; store hand counts while the seats are still open
+	EndTableSessions(bridge, database, tableID)
+
+	// Club tables play with club chips, which go back to the club wallet.
+	// Without knowing whose chips they are nothing is returned, and the seats
+	// stay open with their stacks rather than paying club chips out globally.
+	var dbTable models.Table
+	if err := database.Select("id", "club_id").Where("id = ?", tableID).First(&dbTable).Error; err != nil {
+		log.Printf("ERROR: Failed to load table %s for game complete sync, chips not returned: %v", tableID, err)
+		return
+	}
+
 	// CRITICAL: Use transaction to ensure atomic chip return and seat update
 	// If chip return fails, seat is not marked as left
 	// If seat update fails, chips are not returned
 	for _, player := range state.Players {
 		if player != nil && player.Chips > 0 {
 			err := database.Transaction(func(tx *gorm.DB) error {
-				// Add chips back to user account
-				if err := tx.Model(&models.User{}).
-					Where("id = ?", player.PlayerID).
-					UpdateColumn("chips", tx.Raw("chips + ?", player.Chips)).Error; err != nil {
-					return fmt.Errorf("failed to return chips: %w", err)
-				}
-
-				// Mark seat as left (atomic with chip return)
-				now := time.Now()
-				if err := tx.Model(&models.TableSeat{}).
-					Where("table_id = ? AND user_id = ? AND left_at IS NULL", tableID, player.PlayerID).
-					Update("left_at", &now).Error; err != nil {
-					return fmt.Errorf("failed to update seat: %w", err)
-				}
-
-				return nil
+				return cashOutSeat(tx, dbTable.ClubID, tableID, player.PlayerID, player.Chips)
 			})
 
 			if err != nil {
@@ -231,6 +331,35 @@ func SyncFinalChipsOnGameComplete(bridge *GameBridge, database *db.DB, tableID s
 	}
 }
 
+// cashOutSeat returns a player's stack to their account, or to the club
+// wallet at a club table, and marks their seat as left in the same transaction
+func cashOutSeat(tx *gorm.DB, clubID *string, tableID, userID string, chips int) error {
+	if clubID != nil {
+		if err := club.CashOut(tx, *clubID, userID, tableID, chips); err != nil {
+			return fmt.Errorf("failed to return club chips: %w", err)
+		}
+	} else {
+		// Add chips back to user account
+		if err := tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			UpdateColumn("chips", tx.Raw("chips + ?", chips)).Error; err != nil {
+			return fmt.Errorf("failed to return chips: %w", err)
+		}
+	}
+
+	// Mark seat as left (atomic with chip return)
+	now := time.Now()
+	if err := tx.Model(&models.TableSeat{}).
+		Where("table_id = ? AND user_id = ? AND left_at IS NULL", tableID, userID).
+		Updates(map[string]interface{}{
+			"left_at":    &now,
+			"cashed_out": chips,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update seat: %w", err)
+	}
+	return nil
+}
+
 // SumSidePots calculates the total of all side pots
 func SumSidePots(sidePots []pokerModels.SidePot) int {
 	if sidePots == nil {
@@ -258,6 +387,7 @@ func CreateHandRecord(bridge *GameBridge, database *db.DB, tableID string, event
 
 	// Insert hand record
 	hand := models.Hand{
+		UID:                ids.New(),
 		TableID:            tableID,
 		HandNumber:         handNumber,
 		DealerPosition:     dealerPos,
@@ -273,6 +403,14 @@ func CreateHandRecord(bridge *GameBridge, database *db.DB, tableID string, event
 		return
 	}
 
+	// Remember where the table got to so recovery can carry on from this hand
+	if err := database.Model(&models.Table{}).Where("id = ?", tableID).Updates(map[string]interface{}{
+		"last_hand_number": handNumber,
+		"dealer_position":  dealerPos,
+	}).Error; err != nil {
+		log.Printf("Failed to record hand position for table %s: %v", tableID, err)
+	}
+
 	// Store current hand ID for tracking actions
 	bridge.Mu.Lock()
 	bridge.CurrentHandIDs[tableID] = hand.ID
@@ -309,8 +447,8 @@ func UpdateHandRecord(bridge *GameBridge, database *db.DB, tableID string, event
 	// Convert community cards to JSON
 	communityCardsJSON, _ := json.Marshal(hand.CommunityCards)
 
-	// Convert winners to JSON
-	winnersJSON, _ := json.Marshal(state.Winners)
+	// Convert winners to JSON, as the whole table saw them
+	winnersJSON, _ := json.Marshal(state.PublicWinners())
 
 	// Calculate total pot
 	pot := hand.Pot.Main + SumSidePots(hand.Pot.Side)
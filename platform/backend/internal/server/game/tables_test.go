@@ -0,0 +1,75 @@
+package game
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestTablePreset_ResolveBuyIn(t *testing.T) {
+	preset := TablePresets["6max"]
+
+	buyIn, err := preset.ResolveBuyIn(0)
+	if err != nil || buyIn != preset.DefaultBuyIn {
+		t.Errorf("Expected starting stack %d when no buy-in is chosen, got %d (%v)", preset.DefaultBuyIn, buyIn, err)
+	}
+
+	buyIn, err = preset.ResolveBuyIn(preset.MaxBuyIn)
+	if err != nil || buyIn != preset.MaxBuyIn {
+		t.Errorf("Expected max buy-in %d to be accepted, got %d (%v)", preset.MaxBuyIn, buyIn, err)
+	}
+
+	for _, requested := range []int{preset.MinBuyIn - 1, preset.MaxBuyIn + 1, -100} {
+		if _, err := preset.ResolveBuyIn(requested); err == nil {
+			t.Errorf("Expected buy-in %d outside [%d, %d] to be rejected", requested, preset.MinBuyIn, preset.MaxBuyIn)
+		}
+	}
+}
+
+func TestTablePresets_DefaultBuyInWithinRange(t *testing.T) {
+	for key, preset := range TablePresets {
+		if preset.DefaultBuyIn < preset.MinBuyIn || preset.DefaultBuyIn > preset.MaxBuyIn {
+			t.Errorf("Preset %s starting stack %d outside [%d, %d]", key, preset.DefaultBuyIn, preset.MinBuyIn, preset.MaxBuyIn)
+		}
+	}
+}
+
+func TestSyncFinalChips_KeepsSeatsWhenTableUnknown(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	// No tables table, so whether the chips belong to a club can't be told
+	testutil.Schema(t, gormDB, &models.TableSeat{}, &models.User{})
+	for _, stmt := range []string{
+		`INSERT INTO users (id, username, chips) VALUES ('alice', 'Alice', 0)`,
+		`INSERT INTO table_seats (table_id, user_id, seat_number, chips) VALUES ('table-a', 'alice', 0, 500)`,
+	} {
+		if err := gormDB.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to create test rows: %v", err)
+		}
+	}
+
+	bridge := NewGameBridge()
+	table := newLookupTable(bridge, "table-a")
+	table.AddPlayer("alice", "Alice", 0, 500)
+	SyncFinalChipsOnGameComplete(bridge, &db.DB{DB: gormDB}, "table-a")
+
+	var user models.User
+	if err := gormDB.Where("id = ?", "alice").First(&user).Error; err != nil {
+		t.Fatalf("Failed to load user: %v", err)
+	}
+	if user.Chips != 0 {
+		t.Errorf("Expected no chips paid to the global balance, got %d", user.Chips)
+	}
+	var open int64
+	gormDB.Model(&models.TableSeat{}).Where("left_at IS NULL").Count(&open)
+	if open != 1 {
+		t.Errorf("Expected the seat to stay open with its stack, got %d open seats", open)
+	}
+}
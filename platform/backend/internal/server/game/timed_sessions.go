@@ -0,0 +1,134 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/models"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+// A timed session is a cash table that runs for a fixed time, such as a home
+// game night. Its clock starts with the first hand, which sets the table's
+// auto-close time, so players get the usual deadline warnings. When the time
+// is up the hand in progress is played out, every stack is returned and each
+// player is sent a summary of how the session went.
+
+// Session lengths a timed session may have
+const (
+	MinSessionLength = 10 * time.Minute
+	MaxSessionLength = 24 * time.Hour
+)
+
+// startSessionClock sets when a timed session ends as its first hand is dealt
+func startSessionClock(database *db.DB, dbTable *models.Table, now time.Time) {
+	if dbTable.SessionLength <= 0 || dbTable.AutoCloseAt != nil {
+		return
+	}
+	endsAt := now.Add(time.Duration(dbTable.SessionLength) * time.Second)
+	if err := database.Model(&models.Table{}).
+		Where("id = ? AND auto_close_at IS NULL", dbTable.ID).
+		Update("auto_close_at", endsAt).Error; err != nil {
+		log.Printf("[TIMED_SESSION] Failed to start the clock of table %s: %v", dbTable.ID, err)
+		return
+	}
+	log.Printf("[TIMED_SESSION] Table %s session ends at %s", dbTable.ID, endsAt.Format(time.RFC3339))
+}
+
+// SessionResults sums up how everyone who sat at a cash table did, best
+// result first. A seat still taken counts its stack as cashed out and its
+// time up to now.
+func SessionResults(database *db.DB, tableID string, now time.Time) ([]models.SessionResult, error) {
+	var seats []models.TableSeat
+	if err := database.Where("table_id = ?", tableID).Order("joined_at ASC").Find(&seats).Error; err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[string]*models.SessionResult)
+	var userIDs []string
+	for _, seat := range seats {
+		result, exists := byUser[seat.UserID]
+		if !exists {
+			result = &models.SessionResult{UserID: seat.UserID, Username: seat.UserID}
+			byUser[seat.UserID] = result
+			userIDs = append(userIDs, seat.UserID)
+		}
+		left := now
+		if seat.LeftAt != nil {
+			left = *seat.LeftAt
+			result.CashedOut += seat.CashedOut
+		} else {
+			result.CashedOut += seat.Chips
+		}
+		result.BoughtIn += seat.BoughtIn
+		result.HandsDealt += seat.HandsDealt
+		result.HandsPlayed += seat.HandsPlayed
+		if left.After(seat.JoinedAt) {
+			result.PlaySeconds += int(left.Sub(seat.JoinedAt) / time.Second)
+		}
+	}
+
+	if len(userIDs) > 0 {
+		var users []models.User
+		if err := database.Select("id", "username").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			byUser[user.ID].Username = user.Username
+		}
+	}
+
+	results := make([]models.SessionResult, 0, len(userIDs))
+	for _, userID := range userIDs {
+		result := byUser[userID]
+		result.Net = result.CashedOut - result.BoughtIn
+		results = append(results, *result)
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Net > results[j].Net })
+	return results, nil
+}
+
+// endTimedSession ends a timed session whose time is up. A hand in progress
+// is played out first: the table is held, and closed by a later check once it
+// is between hands. A paused hand waits for its table to be resumed. It
+// reports whether the table was closed.
+func endTimedSession(b *GameBridge, database *db.DB, dbTable *models.Table, table *engine.Table, now time.Time) bool {
+	table.HoldBetweenHands(true)
+	switch table.Snapshot().Status {
+	case pokerModels.StatusWaiting, pokerModels.StatusHandComplete:
+	default:
+		return false
+	}
+
+	returned, err := closeCashTable(b, database, dbTable,
+		"This session is over. Your chips have been returned.")
+	if err != nil {
+		log.Printf("[TIMED_SESSION] ERROR: Closing table %s at the end of its session: %v", dbTable.ID, err)
+	}
+	log.Printf("[TIMED_SESSION] Table %s session over, %d stacks returned", dbTable.ID, len(returned))
+
+	results, err := SessionResults(database, dbTable.ID, now)
+	if err != nil {
+		log.Printf("[TIMED_SESSION] Failed to sum up the session of table %s: %v", dbTable.ID, err)
+		return true
+	}
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "session_summary",
+		"payload": eventschema.SessionSummaryPayload{
+			TableID: dbTable.ID,
+			Players: results,
+		},
+	})
+	players := make(map[string]bool, len(results))
+	for _, result := range results {
+		players[result.UserID] = true
+	}
+	b.SendToUsers(players, data)
+	return true
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	pokerModels "poker-engine/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestTimedSession_ClockAndResults(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	start := time.Date(2026, 1, 2, 20, 0, 0, 0, time.UTC)
+	testutil.Schema(t, gormDB, &models.Table{}, &models.TableSeat{}, &models.User{})
+	for _, stmt := range []string{
+		`INSERT INTO tables (id, game_type, session_length) VALUES ('table-a', 'cash', 3600)`,
+		`INSERT INTO users (id, username) VALUES ('alice', 'Alice'), ('bob', 'Bob')`,
+	} {
+		if err := gormDB.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to create test rows: %v", err)
+		}
+	}
+	database := &db.DB{DB: gormDB}
+
+	// The clock starts with the first hand and isn't moved by later ones
+	dbTable := &models.Table{ID: "table-a", SessionLength: 3600}
+	startSessionClock(database, dbTable, start)
+	startSessionClock(database, dbTable, start.Add(time.Minute))
+	var table models.Table
+	if err := gormDB.Where("id = ?", "table-a").First(&table).Error; err != nil {
+		t.Fatalf("Failed to load table: %v", err)
+	}
+	if table.AutoCloseAt == nil || !table.AutoCloseAt.Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected the session to end at %v, got %v", start.Add(time.Hour), table.AutoCloseAt)
+	}
+
+	// Alice left once and sat down again; Bob is still seated
+	left := start.Add(20 * time.Minute)
+	seats := []models.TableSeat{
+		{TableID: "table-a", UserID: "alice", Chips: 0, JoinedAt: start, LeftAt: &left, BoughtIn: 500, CashedOut: 300, HandsDealt: 10, HandsPlayed: 4},
+		{TableID: "table-a", UserID: "bob", SeatNumber: 1, Chips: 1400, JoinedAt: start, BoughtIn: 1000, HandsDealt: 30, HandsPlayed: 12},
+		{TableID: "table-a", UserID: "alice", SeatNumber: 2, Chips: 100, JoinedAt: start.Add(30 * time.Minute), BoughtIn: 500, HandsDealt: 20, HandsPlayed: 8},
+	}
+	for i := range seats {
+		if err := gormDB.Create(&seats[i]).Error; err != nil {
+			t.Fatalf("Failed to seat player: %v", err)
+		}
+	}
+
+	results, err := SessionResults(database, "table-a", start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SessionResults failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 players, got %d", len(results))
+	}
+	bob, alice := results[0], results[1]
+	if bob.Username != "Bob" || bob.Net != 400 || bob.PlaySeconds != 3600 {
+		t.Errorf("Expected Bob up 400 over an hour, got %+v", bob)
+	}
+	want := models.SessionResult{
+		UserID: "alice", Username: "Alice", BoughtIn: 1000, CashedOut: 400, Net: -600,
+		HandsDealt: 30, HandsPlayed: 12, PlaySeconds: 50 * 60,
+	}
+	if alice != want {
+		t.Errorf("Expected %+v, got %+v", want, alice)
+	}
+}
+
+func TestEndTimedSession_WaitsForTheHand(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	testutil.Schema(t, gormDB, &models.Table{}, &models.TableSeat{}, &models.User{})
+	for _, stmt := range []string{
+		`INSERT INTO tables (id, game_type, status, session_length) VALUES ('table-a', 'cash', 'playing', 3600)`,
+		`INSERT INTO users (id, username, chips) VALUES ('alice', 'Alice', 0), ('bob', 'Bob', 0)`,
+		`INSERT INTO table_seats (table_id, user_id, seat_number, chips, bought_in) VALUES ('table-a', 'alice', 0, 500, 500), ('table-a', 'bob', 1, 500, 500)`,
+	} {
+		if err := gormDB.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to create test rows: %v", err)
+		}
+	}
+	database := &db.DB{DB: gormDB}
+	dbTable := &models.Table{ID: "table-a", GameType: "cash", SessionLength: 3600}
+	now := time.Date(2026, 1, 2, 21, 0, 0, 0, time.UTC)
+
+	bridge := NewGameBridge()
+	table := newLookupTable(bridge, "table-a")
+	table.AddPlayer("alice", "Alice", 0, 500)
+	table.AddPlayer("bob", "Bob", 1, 500)
+	if err := table.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+
+	// Neither a hand being played nor a paused one is cut short
+	if endTimedSession(bridge, database, dbTable, table, now) {
+		t.Fatal("Expected the session to wait for the hand in play")
+	}
+	if err := table.Pause(); err != nil {
+		t.Fatalf("Pause failed: %v", err)
+	}
+	if endTimedSession(bridge, database, dbTable, table, now) {
+		t.Fatal("Expected the session to wait for the paused hand")
+	}
+	if _, exists := bridge.GetTable("table-a"); !exists {
+		t.Fatal("Expected the table to stay open while its hand is paused")
+	}
+
+	// Once the hand is over the session ends with every chip returned
+	if err := table.Resume(); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	state := table.Snapshot()
+	toAct := state.Players[state.CurrentHand.CurrentPosition].PlayerID
+	if err := table.ProcessAction(toAct, pokerModels.ActionFold, 0); err != nil {
+		t.Fatalf("Fold failed: %v", err)
+	}
+	if !endTimedSession(bridge, database, dbTable, table, now) {
+		t.Fatal("Expected the session to end between hands")
+	}
+	var chips int
+	gormDB.Raw(`SELECT SUM(chips) FROM users`).Scan(&chips)
+	if chips != 1000 {
+		t.Errorf("Expected all 1000 chips returned, got %d", chips)
+	}
+}
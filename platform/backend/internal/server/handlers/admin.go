@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"poker-platform/backend/internal/audit"
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/freeze"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+
+	"poker-engine/engine"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ParseAdminUserIDs parses a comma separated list of admin user IDs
+func ParseAdminUserIDs(value string) map[string]bool {
+	admins := make(map[string]bool)
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			admins[id] = true
+		}
+	}
+	return admins
+}
+
+// AdminMiddleware only lets admins through: users with the admin role, and
+// those listed in adminUserIDs, who can grant the role to others. It must run
+// after AuthMiddleware.
+func AdminMiddleware(database *db.DB, adminUserIDs map[string]bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("user_id")
+		if adminUserIDs[userID] {
+			c.Next()
+			return
+		}
+		var user models.User
+		if err := database.Select("id", "role").Where("id = ?", userID).First(&user).Error; err != nil || user.Role != models.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// HandleGetTableButton returns the button and blind positions of a live table
+func HandleGetTableButton(c *gin.Context, getTable func(string) (*engine.Table, bool)) {
+	tableID := c.Param("id")
+
+	table, exists := getTable(tableID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		return
+	}
+
+	seats := []gin.H{}
+	for i, p := range table.Snapshot().Players {
+		if p == nil {
+			continue
+		}
+		seats = append(seats, gin.H{
+			"seat":      i,
+			"user_id":   p.PlayerID,
+			"username":  p.PlayerName,
+			"chips":     p.Chips,
+			"status":    p.Status,
+			"is_dealer": p.IsDealer,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"table_id": tableID,
+		"button":   table.AuditButton(),
+		"seats":    seats,
+	})
+}
+
+// HandleForceTableButton moves the button of a live table to a seat for the next hand
+func HandleForceTableButton(c *gin.Context, getTable func(string) (*engine.Table, bool)) {
+	userID := c.GetString("user_id")
+	tableID := c.Param("id")
+
+	var req struct {
+		Seat   *int   `json:"seat" binding:"required"`
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "seat and reason are required"})
+		return
+	}
+
+	table, exists := getTable(tableID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		return
+	}
+
+	before := table.AuditButton()
+	if err := table.ForceButton(*req.Seat); err != nil {
+		log.Printf("[ADMIN_AUDIT] Rejected button change by %s on table %s to seat %d: %v", userID, tableID, *req.Seat, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Printf("[ADMIN_AUDIT] Button forced by %s on table %s: dealer %d (hand #%d) -> seat %d next hand, reason: %q",
+		userID, tableID, before.DealerPosition, before.HandNumber, *req.Seat, req.Reason)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Button will move to the requested seat at the start of the next hand",
+		"button":  table.AuditButton(),
+	})
+}
+
+// HandleGetAuditLogs returns audit trail entries for dispute and fraud investigations.
+// Supports user_id, table_id, event_type, ip, from, to (RFC3339), limit and offset query params.
+func HandleGetAuditLogs(c *gin.Context, auditStore *audit.Store) {
+	filter := audit.Filter{
+		UserID:    c.Query("user_id"),
+		TableID:   c.Query("table_id"),
+		EventType: c.Query("event_type"),
+		IPAddress: c.Query("ip"),
+	}
+	if filter.UserID == "" && filter.TableID == "" && filter.IPAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id, table_id or ip is required"})
+		return
+	}
+
+	for param, dst := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + " time, expected RFC3339"})
+				return
+			}
+			*dst = parsed
+		}
+	}
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filter.Offset, _ = strconv.Atoi(c.Query("offset"))
+
+	entries, total, err := auditStore.Query(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query audit logs"})
+		return
+	}
+
+	log.Printf("[ADMIN_AUDIT] Audit logs queried by %s (user=%s table=%s ip=%s): %d results",
+		c.GetString("user_id"), filter.UserID, filter.TableID, filter.IPAddress, total)
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"total":   total,
+	})
+}
+
+// recentActionLimit is how many of a player's latest actions the player lookup returns
+const recentActionLimit = 20
+
+// HandleFindPlayer looks up where a player is right now: the live tables they
+// are seated at with their stacks, the tournaments they are still in, their
+// most recent actions and whether they are connected
+func HandleFindPlayer(c *gin.Context, database *db.DB, bridge *game.GameBridge) {
+	username := strings.TrimSpace(c.Query("username"))
+	if username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "username is required"})
+		return
+	}
+
+	var user models.User
+	if err := database.Select("id", "username", "chips").Where("username = ?", username).First(&user).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	seats := bridge.LiveSeats(user.ID)
+	tableIDs := make([]string, 0, len(seats))
+	for _, seat := range seats {
+		tableIDs = append(tableIDs, seat.TableID)
+	}
+	tableInfo := make(map[string]models.Table, len(tableIDs))
+	if len(tableIDs) > 0 {
+		var tables []models.Table
+		if err := database.Select("id", "name", "game_type", "tournament_id", "club_id").
+			Where("id IN ?", tableIDs).Find(&tables).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load tables"})
+			return
+		}
+		for _, table := range tables {
+			tableInfo[table.ID] = table
+		}
+	}
+
+	liveTables := make([]gin.H, 0, len(seats))
+	for _, seat := range seats {
+		info := tableInfo[seat.TableID]
+		liveTables = append(liveTables, gin.H{
+			"seat":          seat,
+			"table_name":    info.Name,
+			"game_type":     info.GameType,
+			"tournament_id": info.TournamentID,
+			"club_id":       info.ClubID,
+		})
+	}
+
+	var tournaments []struct {
+		TournamentID string    `json:"tournament_id"`
+		Name         string    `json:"name"`
+		Status       string    `json:"status"`
+		Chips        *int      `json:"chips,omitempty"`
+		RegisteredAt time.Time `json:"registered_at"`
+	}
+	if err := database.Table("tournament_players tp").
+		Select("tp.tournament_id, t.name, t.status, tp.chips, tp.registered_at").
+		Joins("JOIN tournaments t ON t.id = tp.tournament_id").
+		Where("tp.user_id = ? AND tp.eliminated_at IS NULL AND tp.deleted_at IS NULL AND t.status IN ?",
+			user.ID, []string{"registering", "starting", "in_progress", "paused"}).
+		Order("tp.registered_at DESC").
+		Scan(&tournaments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load tournaments"})
+		return
+	}
+
+	var actions []struct {
+		TableID      string    `json:"table_id"`
+		HandNumber   int       `json:"hand_number"`
+		ActionType   string    `json:"action_type"`
+		Amount       int       `json:"amount"`
+		BettingRound string    `json:"betting_round"`
+		CreatedAt    time.Time `json:"created_at"`
+	}
+	if err := database.Table("hand_actions ha").
+		Select("h.table_id, h.hand_number, ha.action_type, ha.amount, ha.betting_round, ha.created_at").
+		Joins("JOIN hands h ON h.id = ha.hand_id").
+		Where("ha.user_id = ? AND ha.deleted_at IS NULL", user.ID).
+		Order("ha.created_at DESC, ha.id DESC").
+		Limit(recentActionLimit).
+		Scan(&actions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load recent actions"})
+		return
+	}
+
+	connections, subscribedTables := bridge.ConnectionStatus(user.ID)
+
+	log.Printf("[ADMIN_AUDIT] Player lookup by %s: %s (%s) at %d live tables",
+		c.GetString("user_id"), user.Username, user.ID, len(seats))
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":        user.ID,
+		"username":       user.Username,
+		"balance":        user.Chips,
+		"tables":         liveTables,
+		"tournaments":    tournaments,
+		"recent_actions": actions,
+		"sessions":       bridge.Sessions.ForUser(user.ID),
+		"connection": gin.H{
+			"connected":         connections > 0,
+			"connections":       connections,
+			"subscribed_tables": subscribedTables,
+		},
+	})
+}
+
+// HandleFreezeUser freezes an account suspected of being compromised: its game
+// actions and chip withdrawals are refused and its seats are sat out straight
+// away. Unlike a ban the player keeps their seats and balance.
+func HandleFreezeUser(c *gin.Context, database *db.DB, bridge *game.GameBridge, broadcastFunc func(string)) {
+	adminID := c.GetString("user_id")
+	userID := c.Param("id")
+
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required"})
+		return
+	}
+
+	record, err := freeze.Freeze(database.DB, userID, adminID, req.Reason)
+	if err != nil {
+		respondFreezeError(c, err)
+		return
+	}
+
+	tableIDs := bridge.ApplyFreeze(userID, true)
+	for _, tableID := range tableIDs {
+		broadcastFunc(tableID)
+	}
+
+	log.Printf("[ADMIN_AUDIT] Account %s frozen by %s on %d live tables, reason: %q", userID, adminID, len(tableIDs), req.Reason)
+	c.JSON(http.StatusOK, gin.H{"freeze": record, "tables": tableIDs})
+}
+
+// HandleUnfreezeUser lifts a freeze. Seats stay sat out until the player sits
+// back in.
+func HandleUnfreezeUser(c *gin.Context, database *db.DB, bridge *game.GameBridge, broadcastFunc func(string)) {
+	adminID := c.GetString("user_id")
+	userID := c.Param("id")
+
+	record, err := freeze.Lift(database.DB, userID, adminID)
+	if err != nil {
+		respondFreezeError(c, err)
+		return
+	}
+
+	tableIDs := bridge.ApplyFreeze(userID, false)
+	for _, tableID := range tableIDs {
+		broadcastFunc(tableID)
+	}
+
+	log.Printf("[ADMIN_AUDIT] Account %s unfrozen by %s", userID, adminID)
+	c.JSON(http.StatusOK, gin.H{"freeze": record, "tables": tableIDs})
+}
+
+// HandleGetUserFreezes returns every freeze of an account, newest first
+func HandleGetUserFreezes(c *gin.Context, database *db.DB) {
+	records, err := freeze.History(database.DB, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load freezes"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"freezes": records})
+}
+
+func respondFreezeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, freeze.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, freeze.ErrAlreadyFrozen), errors.Is(err, freeze.ErrNotFrozen):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, freeze.ErrReasonTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update freeze"})
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"poker-platform/backend/internal/audit"
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/server/game"
+
+	"github.com/gin-gonic/gin"
+	"poker-engine/engine"
+)
+
+// HandleListLiveTables lists every table held in memory by the engine, with
+// its status, current hand and seats
+func HandleListLiveTables(c *gin.Context, bridge *game.GameBridge) {
+	tables := bridge.LiveTables()
+	c.JSON(http.StatusOK, gin.H{"tables": tables, "count": len(tables)})
+}
+
+// HandleForceCompleteTable ends a stuck cash table: a hand in progress is
+// called off, every stack is returned and the table is closed
+func HandleForceCompleteTable(c *gin.Context, database *db.DB, bridge *game.GameBridge, auditStore *audit.Store) {
+	adminID := c.GetString("user_id")
+	tableID := c.Param("id")
+
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required"})
+		return
+	}
+
+	returned, err := game.ForceCompleteTable(bridge, database, tableID)
+	if err != nil && returned == nil {
+		respondAdminTableError(c, err)
+		return
+	}
+
+	client := audit.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	for userID, chips := range returned {
+		auditStore.RecordAdminAction(adminID, userID, "force_complete_table", tableID, chips, client)
+	}
+	log.Printf("[ADMIN_AUDIT] Table %s force-completed by %s, %d stacks returned, reason: %q", tableID, adminID, len(returned), req.Reason)
+
+	if err != nil {
+		// The table is closed; some stacks need returning by hand
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "returned": returned})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Table closed", "returned": returned})
+}
+
+// HandleKickPlayer removes a player from a cash table and returns their
+// stack. A player in a hand is folded and leaves when the hand ends.
+func HandleKickPlayer(c *gin.Context, database *db.DB, bridge *game.GameBridge, auditStore *audit.Store, broadcastFunc func(string)) {
+	adminID := c.GetString("user_id")
+	tableID := c.Param("id")
+
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id and reason are required"})
+		return
+	}
+
+	chips, pending, err := bridge.KickPlayer(database, tableID, req.UserID)
+	if err != nil {
+		respondAdminTableError(c, err)
+		return
+	}
+	broadcastFunc(tableID)
+
+	auditStore.RecordAdminAction(adminID, req.UserID, "kick_player", tableID, chips,
+		audit.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()})
+	log.Printf("[ADMIN_AUDIT] Player %s kicked from table %s by %s (pending=%v, chips=%d), reason: %q",
+		req.UserID, tableID, adminID, pending, chips, req.Reason)
+
+	if pending {
+		c.JSON(http.StatusAccepted, gin.H{"message": "Player folded; they leave when the hand ends", "pending": true})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Player removed", "pending": false, "chips_returned": chips})
+}
+
+func respondAdminTableError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, game.ErrTableNotFound), errors.Is(err, game.ErrPlayerNotSeated):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, game.ErrTournamentTable), errors.Is(err, engine.ErrPlayerToAct):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Printf("[ADMIN] Table operation failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update table"})
+	}
+}
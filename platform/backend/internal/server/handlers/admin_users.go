@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"poker-platform/backend/internal/audit"
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleAdjustChips adds chips to or, with a negative amount, removes chips
+// from a player's balance. The chip transaction records the admin and reason.
+func HandleAdjustChips(c *gin.Context, currencyService *currency.Service, auditStore *audit.Store) {
+	adminID := c.GetString("user_id")
+	userID := c.Param("id")
+
+	var req struct {
+		Amount int    `json:"amount" binding:"required"`
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a non-zero amount and a reason are required"})
+		return
+	}
+	if len(req.Reason) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason must be at most 255 characters"})
+		return
+	}
+
+	ctx := context.Background()
+	description := fmt.Sprintf("Admin adjustment by %s: %s", adminID, req.Reason)
+	var err error
+	if req.Amount > 0 {
+		err = currencyService.AddChips(ctx, userID, req.Amount, currency.TxTypeAdminAdjustment, adminID, description)
+	} else {
+		err = currencyService.DeductChips(ctx, userID, -req.Amount, currency.TxTypeAdminAdjustment, adminID, description)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, currency.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, currency.ErrInsufficientChips), errors.Is(err, currency.ErrAccountFrozen):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, currency.ErrInvalidAmount), errors.Is(err, currency.ErrExceedsMaximum), errors.Is(err, currency.ErrNegativeAmount):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Printf("[ADMIN] Chip adjustment of %d for %s failed: %v", req.Amount, userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to adjust chips"})
+		}
+		return
+	}
+
+	balance, _ := currencyService.GetBalance(ctx, userID)
+	auditStore.RecordAdminAction(adminID, userID, "adjust_chips", "", req.Amount,
+		audit.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()})
+	log.Printf("[ADMIN_AUDIT] Chips of %s adjusted by %+d by %s, balance now %d, reason: %q", userID, req.Amount, adminID, balance, req.Reason)
+
+	c.JSON(http.StatusOK, gin.H{"user_id": userID, "amount": req.Amount, "balance": balance})
+}
+
+// HandleSetUserRole grants or takes away the admin role
+func HandleSetUserRole(c *gin.Context, database *db.DB, auditStore *audit.Store) {
+	adminID := c.GetString("user_id")
+	userID := c.Param("id")
+
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Role != models.RolePlayer && req.Role != models.RoleAdmin) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role must be player or admin"})
+		return
+	}
+	if userID == adminID && req.Role != models.RoleAdmin {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot remove your own admin role"})
+		return
+	}
+
+	result := database.Model(&models.User{}).Where("id = ?", userID).Update("role", req.Role)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	auditStore.RecordAdminAction(adminID, userID, "set_role_"+req.Role, "", 0,
+		audit.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()})
+	log.Printf("[ADMIN_AUDIT] Role of %s set to %s by %s", userID, req.Role, adminID)
+	c.JSON(http.StatusOK, gin.H{"user_id": userID, "role": req.Role})
+}
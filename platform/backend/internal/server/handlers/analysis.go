@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"poker-engine/engine"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EquityRequest asks for the equity of two or more ranges against each other
+type EquityRequest struct {
+	Ranges []string `json:"ranges" binding:"required"` // e.g. "QQ+,AKs", "JJ-99,AQ"
+	Board  string   `json:"board"`                     // e.g. "Ah7d2c", empty preflop
+}
+
+// HandleCalculateEquity returns the equity of each range against the others on
+// a board, for training and post-hand analysis
+func HandleCalculateEquity(c *gin.Context, calculator *engine.EquityCalculator) {
+	var req EquityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	result, err := calculator.Calculate(req.Ranges, req.Board)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"poker-platform/backend/internal/announcements"
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/server/game"
+
+	"github.com/gin-gonic/gin"
+)
+
+func respondAnnouncementError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, announcements.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, announcements.ErrAlreadyCancelled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, announcements.ErrInvalidKind), errors.Is(err, announcements.ErrInvalidTarget),
+		errors.Is(err, announcements.ErrMissingTargetID), errors.Is(err, announcements.ErrInvalidMessage),
+		errors.Is(err, announcements.ErrInvalidExpiry):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update announcement"})
+	}
+}
+
+// HandleCreateAnnouncement schedules a banner message. One that starts now
+// goes out straight away; later ones are sent by the announcement scheduler.
+func HandleCreateAnnouncement(c *gin.Context, database *db.DB, bridge *game.GameBridge) {
+	adminID := c.GetString("user_id")
+
+	var req announcements.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	announcement, err := announcements.Create(database.DB, req, adminID, now)
+	if err != nil {
+		respondAnnouncementError(c, err)
+		return
+	}
+	log.Printf("[ADMIN_AUDIT] Announcement %s (%s, %s) created by %s, starts %s",
+		announcement.ID, announcement.Kind, announcement.Target, adminID, announcement.StartsAt.Format(time.RFC3339))
+
+	if !announcement.StartsAt.After(now) {
+		game.DeliverDueAnnouncements(bridge, database, now)
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"announcement": announcement})
+}
+
+// HandleListAnnouncements lists announcements that are scheduled or showing,
+// or every announcement with ?all=true
+func HandleListAnnouncements(c *gin.Context, database *db.DB) {
+	list, err := announcements.List(database.DB, c.Query("all") == "true", time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load announcements"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"announcements": list})
+}
+
+// HandleCancelAnnouncement withdraws an announcement and takes it down on
+// every client showing it
+func HandleCancelAnnouncement(c *gin.Context, database *db.DB, bridge *game.GameBridge) {
+	adminID := c.GetString("user_id")
+
+	announcement, err := announcements.Cancel(database.DB, c.Param("id"), time.Now())
+	if err != nil {
+		respondAnnouncementError(c, err)
+		return
+	}
+	if announcement.SentAt != nil {
+		game.SendAnnouncementCancelled(bridge, announcement.ID)
+	}
+
+	log.Printf("[ADMIN_AUDIT] Announcement %s cancelled by %s", announcement.ID, adminID)
+	c.JSON(http.StatusOK, gin.H{"announcement": announcement})
+}
+
+// HandleGetActiveAnnouncements returns the banners the user should see now,
+// for clients that just connected
+func HandleGetActiveAnnouncements(c *gin.Context, database *db.DB) {
+	list, err := announcements.ActiveFor(database.DB, c.GetString("user_id"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load announcements"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"announcements": list})
+}
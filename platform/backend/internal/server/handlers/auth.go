@@ -1,18 +1,25 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 
+	"poker-platform/backend/internal/audit"
 	"poker-platform/backend/internal/auth"
 	"poker-platform/backend/internal/db"
 	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/moderation"
+	"poker-platform/backend/internal/referral"
 	"poker-platform/backend/internal/validation"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-// HandleRegister handles user registration
-func HandleRegister(c *gin.Context, database *db.DB, authService *auth.Service) {
+// HandleRegister handles user registration. A referral code credits the new
+// user to its owner.
+func HandleRegister(c *gin.Context, database *db.DB, authService *auth.Service, moderationService *moderation.Service, referrals *referral.Service) {
 	var req models.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
@@ -24,6 +31,11 @@ func HandleRegister(c *gin.Context, database *db.DB, authService *auth.Service)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	nameMatch, err := moderationService.Screen("username", req.Username)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	if err := validation.ValidateEmail(req.Email); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -35,6 +47,19 @@ func HandleRegister(c *gin.Context, database *db.DB, authService *auth.Service)
 		return
 	}
 
+	referrerID := ""
+	if req.ReferralCode != "" {
+		referrerID, err = referrals.Resolve(req.ReferralCode, req.Email, c.ClientIP())
+		if errors.Is(err, referral.ErrUnknownCode) || errors.Is(err, referral.ErrSelfReferral) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Server error"})
+			return
+		}
+	}
+
 	hash, err := authService.HashPassword(req.Password)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Server error"})
@@ -50,10 +75,22 @@ func HandleRegister(c *gin.Context, database *db.DB, authService *auth.Service)
 		Chips:        10000,
 	}
 
-	if err := database.Create(&user).Error; err != nil {
+	err = database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		if referrerID == "" {
+			return nil
+		}
+		return referrals.Attribute(tx, userID, referrerID, req.ReferralCode)
+	})
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Username or email already exists"})
 		return
 	}
+	if _, err := moderationService.Flag(nameMatch, moderation.KindUsername, userID, userID, user.Username); err != nil {
+		log.Printf("[MODERATION] Failed to queue username of %s: %v", userID, err)
+	}
 
 	token, _ := authService.GenerateToken(userID)
 	user.PasswordHash = ""
@@ -62,7 +99,7 @@ func HandleRegister(c *gin.Context, database *db.DB, authService *auth.Service)
 }
 
 // HandleLogin handles user login
-func HandleLogin(c *gin.Context, database *db.DB, authService *auth.Service) {
+func HandleLogin(c *gin.Context, database *db.DB, authService *auth.Service, auditStore *audit.Store) {
 	var req models.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
@@ -88,13 +125,17 @@ func HandleLogin(c *gin.Context, database *db.DB, authService *auth.Service) {
 		return
 	}
 
+	client := audit.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+
 	if !authService.CheckPassword(req.Password, user.PasswordHash) {
+		auditStore.RecordLogin(user.ID, false, client)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
 
 	token, _ := authService.GenerateToken(user.ID)
 	user.PasswordHash = ""
+	auditStore.RecordLogin(user.ID, true, client)
 
 	c.JSON(http.StatusOK, models.AuthResponse{Token: token, User: user})
 }
@@ -113,6 +154,31 @@ func HandleGetCurrentUser(c *gin.Context, database *db.DB) {
 	c.JSON(http.StatusOK, user)
 }
 
+// HandleUpdatePreferences changes the current user's display preferences
+func HandleUpdatePreferences(c *gin.Context, database *db.DB) {
+	userID := c.GetString("user_id")
+
+	var req struct {
+		DisplayInBB *bool `json:"display_in_bb" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	result := database.Model(&models.User{}).Where("id = ?", userID).Update("display_in_bb", *req.DisplayInBB)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update preferences"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"display_in_bb": *req.DisplayInBB})
+}
+
 // AuthMiddleware validates JWT tokens and sets user_id in context
 func AuthMiddleware(authService *auth.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"poker-platform/backend/internal/avatar"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is room in an upload request for the form around the image
+const multipartOverhead = 64 << 10
+
+// HandleUploadAvatar makes the image in the "avatar" field of a multipart
+// form the current user's avatar, and shows it at their tables
+func HandleUploadAvatar(c *gin.Context, service *avatar.Service, showAvatar func(userID, url string)) {
+	userID := c.GetString("user_id")
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, avatar.MaxUploadSize+multipartOverhead)
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": avatar.ErrTooLarge.Error(), "max_bytes": avatar.MaxUploadSize})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Expected an image in the avatar field"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read upload"})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, avatar.MaxUploadSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read upload"})
+		return
+	}
+
+	uploaded, err := service.Upload(c.Request.Context(), userID, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, avatar.ErrTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error(), "max_bytes": avatar.MaxUploadSize})
+		case errors.Is(err, avatar.ErrUnsupportedType):
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		case errors.Is(err, avatar.ErrInvalidImage):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Printf("[AVATAR] Failed to upload avatar of %s: %v", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save avatar"})
+		}
+		return
+	}
+	showAvatar(userID, uploaded.URL)
+	c.JSON(http.StatusOK, gin.H{"avatar": uploaded})
+}
+
+// HandleDeleteAvatar takes the current user's avatar down
+func HandleDeleteAvatar(c *gin.Context, service *avatar.Service, showAvatar func(userID, url string)) {
+	userID := c.GetString("user_id")
+	err := service.Remove(c.Request.Context(), userID)
+	if errors.Is(err, avatar.ErrNoAvatar) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove avatar"})
+		return
+	}
+	showAvatar(userID, "")
+	c.JSON(http.StatusOK, gin.H{"message": "Avatar removed"})
+}
+
+// HandleReportAvatar queues a player's avatar for moderation
+func HandleReportAvatar(c *gin.Context, service *avatar.Service) {
+	_, err := service.Report(c.Param("username"), c.GetString("user_id"))
+	if errors.Is(err, avatar.ErrPlayerNotFound) || errors.Is(err, avatar.ErrNoAvatar) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to report avatar"})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Avatar reported for review"})
+}
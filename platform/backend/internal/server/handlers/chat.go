@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"poker-platform/backend/internal/chat"
+	"poker-platform/backend/internal/moderation"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/server/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleChatMessage sends a "chat_message" from a WebSocket client to
+// everyone subscribed to the client's table, seated or watching, except
+// those who ignore the sender. The payload holds the "text".
+func HandleChatMessage(c *websocket.Client, payload interface{}, chatService *chat.Service, bridge *game.GameBridge) {
+	if c.TableID == "" {
+		sendChatError(c, "Not subscribed to a table", "NOT_AT_TABLE")
+		return
+	}
+	fields, ok := payload.(map[string]interface{})
+	if !ok {
+		sendChatError(c, "Invalid message format", "INVALID_PAYLOAD")
+		return
+	}
+	text, _ := fields["text"].(string)
+
+	msg, err := chatService.Post(c.TableID, c.UserID, text, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, chat.ErrRateLimited):
+			sendChatError(c, "You are sending messages too fast", "CHAT_RATE_LIMITED")
+		case errors.Is(err, chat.ErrMuted):
+			sendChatError(c, "You are muted from chat", "CHAT_MUTED")
+		case errors.Is(err, chat.ErrInvalidMessage):
+			sendChatError(c, "Invalid message", "INVALID_MESSAGE")
+		case errors.Is(err, moderation.ErrBlocked):
+			sendChatError(c, "Message contains blocked words", "CHAT_BLOCKED")
+		default:
+			log.Printf("[CHAT] Message from %s at table %s failed: %v", c.UserID, c.TableID, err)
+			sendChatError(c, "Failed to send message", "CHAT_FAILED")
+		}
+		return
+	}
+
+	ignoredBy, err := chatService.IgnoredBy(c.UserID)
+	if err != nil {
+		log.Printf("[CHAT] Failed to load who ignores %s: %v", c.UserID, err)
+	}
+	data, err := json.Marshal(websocket.WSMessage{Type: "chat_message", Payload: msg})
+	if err != nil {
+		return
+	}
+
+	type ChatRecipient interface {
+		GetTableID() string
+		Enqueue(data []byte, priority websocket.MessagePriority) bool
+	}
+	bridge.Clients.Each(func(userID string, client interface{}) {
+		if recipient, ok := client.(ChatRecipient); ok && recipient.GetTableID() == msg.TableID && !ignoredBy[userID] {
+			recipient.Enqueue(data, websocket.PriorityLow)
+		}
+	})
+}
+
+func sendChatError(c *websocket.Client, message, code string) {
+	websocket.SendToClient(c, websocket.WSMessage{
+		Type: "error",
+		Payload: map[string]interface{}{
+			"message": message,
+			"code":    code,
+		},
+	})
+}
+
+// HandleGetTableChat returns a table's recent chat, leaving out users the
+// caller ignores
+func HandleGetTableChat(c *gin.Context, chatService *chat.Service) {
+	messages, err := chatService.Recent(c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load chat"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"messages": messages})
+}
+
+// HandleListChatIgnores returns the users the caller ignores
+func HandleListChatIgnores(c *gin.Context, chatService *chat.Service) {
+	ignored, err := chatService.Ignored(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load ignored users"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"ignored": ignored})
+}
+
+// HandleIgnoreUser hides a user's chat from the caller
+func HandleIgnoreUser(c *gin.Context, chatService *chat.Service) {
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+	if err := chatService.Ignore(c.GetString("user_id"), req.UserID); err != nil {
+		switch {
+		case errors.Is(err, chat.ErrIgnoreSelf):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, chat.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to ignore user"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"ignored": req.UserID})
+}
+
+// HandleUnignoreUser shows a user's chat to the caller again
+func HandleUnignoreUser(c *gin.Context, chatService *chat.Service) {
+	if err := chatService.Unignore(c.GetString("user_id"), c.Param("userId")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unignore user"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unignored": c.Param("userId")})
+}
+
+// HandleMuteUser stops a user from sending table chat for a number of minutes
+func HandleMuteUser(c *gin.Context, chatService *chat.Service) {
+	adminID := c.GetString("user_id")
+	userID := c.Param("id")
+
+	var req struct {
+		Minutes int    `json:"minutes" binding:"required"`
+		Reason  string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "minutes is required"})
+		return
+	}
+
+	mute, err := chatService.Mute(userID, adminID, req.Reason, time.Duration(req.Minutes)*time.Minute, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, chat.ErrInvalidDuration):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, chat.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mute user"})
+		}
+		return
+	}
+
+	log.Printf("[ADMIN_AUDIT] User %s muted from chat by %s for %d minutes, reason: %q", userID, adminID, req.Minutes, req.Reason)
+	c.JSON(http.StatusOK, gin.H{"mute": mute})
+}
+
+// HandleUnmuteUser lifts a user's chat mute
+func HandleUnmuteUser(c *gin.Context, chatService *chat.Service) {
+	adminID := c.GetString("user_id")
+	userID := c.Param("id")
+
+	if err := chatService.Unmute(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unmute user"})
+		return
+	}
+
+	log.Printf("[ADMIN_AUDIT] User %s unmuted from chat by %s", userID, adminID)
+	c.JSON(http.StatusOK, gin.H{"unmuted": userID})
+}
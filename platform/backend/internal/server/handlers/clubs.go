@@ -0,0 +1,401 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"poker-platform/backend/internal/club"
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// clubErrorStatus maps club errors to HTTP status codes
+func clubErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, club.ErrClubNotFound), errors.Is(err, club.ErrUserNotFound), errors.Is(err, club.ErrInviteNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, club.ErrNotClubMember), errors.Is(err, club.ErrNotClubManager), errors.Is(err, club.ErrNotClubOwner),
+		errors.Is(err, club.ErrCannotRemoveOwner), errors.Is(err, club.ErrCannotRemoveManager):
+		return http.StatusForbidden
+	case errors.Is(err, club.ErrAlreadyMember), errors.Is(err, club.ErrAlreadyInvited):
+		return http.StatusConflict
+	case errors.Is(err, club.ErrInvalidClubName), errors.Is(err, club.ErrInvalidRole),
+		errors.Is(err, club.ErrInvalidClubAmount), errors.Is(err, club.ErrNotEnoughClubChips):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondClubError writes a club error, hiding internal errors from the client
+func respondClubError(c *gin.Context, err error) {
+	status := clubErrorStatus(err)
+	if status == http.StatusInternalServerError {
+		c.JSON(status, gin.H{"error": "Server error"})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
+
+// HandleCreateClub creates a club owned by the current user
+func HandleCreateClub(c *gin.Context, clubService *club.Service) {
+	var req struct {
+		Name        string `json:"name" binding:"required"`
+		Description string `json:"description"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := validation.ValidateStringLength(req.Description, 0, 500, "description"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := clubService.CreateClub(req.Name, req.Description, c.GetString("user_id"))
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// HandleListClubs returns the clubs the current user belongs to or is invited to
+func HandleListClubs(c *gin.Context, clubService *club.Service) {
+	memberships, err := clubService.ListUserClubs(c.GetString("user_id"))
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, memberships)
+}
+
+// HandleGetClub returns a club with its members. Members only.
+func HandleGetClub(c *gin.Context, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	role, err := clubService.RequireMember(clubID, c.GetString("user_id"))
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	details, err := clubService.GetClub(clubID)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	members, err := clubService.Members(clubID)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"club":    details,
+		"role":    role,
+		"members": members,
+	})
+}
+
+// HandleInviteClubMember invites a user to a club by user ID or username
+func HandleInviteClubMember(c *gin.Context, database *db.DB, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	var req struct {
+		UserID   string `json:"user_id"`
+		Username string `json:"username"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || (req.UserID == "" && req.Username == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id or username is required"})
+		return
+	}
+
+	userID := req.UserID
+	if userID == "" {
+		var user models.User
+		if err := database.Select("id").Where("username = ?", req.Username).First(&user).Error; err != nil {
+			respondClubError(c, club.ErrUserNotFound)
+			return
+		}
+		userID = user.ID
+	}
+
+	if err := clubService.Invite(clubID, c.GetString("user_id"), userID); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invite sent", "user_id": userID})
+}
+
+// HandleAcceptClubInvite accepts the current user's pending invite to a club
+func HandleAcceptClubInvite(c *gin.Context, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	if err := clubService.AcceptInvite(clubID, c.GetString("user_id")); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Joined club"})
+}
+
+// HandleRemoveClubMember removes a member, declines an invite or leaves a club
+func HandleRemoveClubMember(c *gin.Context, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	if err := clubService.RemoveMember(clubID, c.GetString("user_id"), c.Param("userId")); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
+
+// HandleSetClubMemberRole promotes a member to manager or demotes a manager. Owner only.
+func HandleSetClubMemberRole(c *gin.Context, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := clubService.SetRole(clubID, c.GetString("user_id"), c.Param("userId"), req.Role); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role updated", "role": req.Role})
+}
+
+// HandleGetClubLobby returns a club's open tables and tournaments. Members only.
+func HandleGetClubLobby(c *gin.Context, database *db.DB, bridge *game.GameBridge, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	if _, err := clubService.RequireMember(clubID, c.GetString("user_id")); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	tables, err := lobbyTables(database, bridge, &clubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Server error"})
+		return
+	}
+
+	tournaments, err := clubService.Tournaments(clubID)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"club_id":     clubID,
+		"tables":      tables,
+		"tournaments": tournaments,
+	})
+}
+
+// HandleGetClubLeaderboard ranks a club's members by their club results. Members only.
+func HandleGetClubLeaderboard(c *gin.Context, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	if _, err := clubService.RequireMember(clubID, c.GetString("user_id")); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	leaderboard, err := clubService.Leaderboard(clubID)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"club_id":     clubID,
+		"leaderboard": leaderboard,
+	})
+}
+
+// handleClubChips issues or voids club chips for a member. Owner only.
+func handleClubChips(c *gin.Context, adjust func(clubID, ownerID, userID string, amount int, note string) error) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+		Amount int    `json:"amount" binding:"required"`
+		Note   string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := validation.ValidateStringLength(req.Note, 0, 255, "note"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := adjust(clubID, c.GetString("user_id"), req.UserID, req.Amount, req.Note); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Club chips updated", "user_id": req.UserID})
+}
+
+// HandleIssueClubChips credits club chips to a member's club wallet. Owner only.
+func HandleIssueClubChips(c *gin.Context, clubService *club.Service) {
+	handleClubChips(c, clubService.IssueChips)
+}
+
+// HandleVoidClubChips removes club chips from a member's club wallet. Owner only.
+func HandleVoidClubChips(c *gin.Context, clubService *club.Service) {
+	handleClubChips(c, clubService.VoidChips)
+}
+
+// HandleGetClubWallet returns the current user's club chip balance and ledger
+func HandleGetClubWallet(c *gin.Context, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	userID := c.GetString("user_id")
+	if _, err := clubService.RequireMember(clubID, userID); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	balance, err := clubService.Balance(clubID, userID)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	transactions, total, err := clubService.Ledger(clubID, userID, limit, offset)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"club_id":      clubID,
+		"balance":      balance,
+		"transactions": transactions,
+		"total":        total,
+	})
+}
+
+// HandleGetClubLedger returns a club's chip ledger, optionally filtered by
+// user_id. Owners and managers only.
+func HandleGetClubLedger(c *gin.Context, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	if err := requireClubManager(clubService, clubID, c.GetString("user_id")); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	transactions, total, err := clubService.Ledger(clubID, c.Query("user_id"), limit, offset)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"club_id":      clubID,
+		"transactions": transactions,
+		"total":        total,
+	})
+}
+
+// HandleGetClubReconciliation checks the club's chip supply against wallets and
+// open club tables. Owners and managers only.
+func HandleGetClubReconciliation(c *gin.Context, clubService *club.Service) {
+	clubID := c.Param("id")
+	if err := validation.ValidateUUID(clubID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid club ID"})
+		return
+	}
+
+	if err := requireClubManager(clubService, clubID, c.GetString("user_id")); err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	report, err := clubService.Reconcile(clubID)
+	if err != nil {
+		respondClubError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// requireClubManager returns an error unless the user is a club owner or manager
+func requireClubManager(clubService *club.Service, clubID, userID string) error {
+	role, err := clubService.RequireMember(clubID, userID)
+	if err != nil {
+		return err
+	}
+	if !club.CanManage(role) {
+		return club.ErrNotClubManager
+	}
+	return nil
+}
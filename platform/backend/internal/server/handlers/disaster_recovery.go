@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/recovery"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/server/history"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleExportSnapshot exports all live state for a warm standby: every engine
+// table, the clocks of running tournaments, history rows still queued in Redis
+// and a checkpoint of the database. Table state is captured before the
+// checkpoint, so the checkpoint covers every completed hand the tables reflect.
+func HandleExportSnapshot(c *gin.Context, database *db.DB, bridge *game.GameBridge, historyWriter *history.BatchWriter) {
+	snapshot := recovery.Snapshot{
+		Version:       recovery.SnapshotVersion,
+		Tables:        []recovery.TableSnapshot{},
+		PendingWrites: []recovery.PendingWrite{},
+	}
+
+	bridge.Mu.RLock()
+	for _, table := range bridge.Tables {
+		snapshot.Tables = append(snapshot.Tables, recovery.NewTableSnapshot(table.GetState()))
+	}
+	bridge.Mu.RUnlock()
+	sort.Slice(snapshot.Tables, func(i, j int) bool { return snapshot.Tables[i].TableID < snapshot.Tables[j].TableID })
+
+	tableRecovery := recovery.NewTableRecovery(database.DB)
+	checkpoint, err := tableRecovery.TakeCheckpoint()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	snapshot.Checkpoint = checkpoint
+
+	if snapshot.Tournaments, err = tableRecovery.TournamentSnapshots(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if historyWriter != nil {
+		pending, err := historyWriter.PendingRecords(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for _, record := range pending {
+			snapshot.PendingWrites = append(snapshot.PendingWrites, recovery.PendingWrite{Kind: record.Kind, Data: record.Data})
+		}
+	}
+
+	log.Printf("[ADMIN_AUDIT] Platform snapshot exported by %s: %d tables, %d tournaments, %d pending writes, last hand %d",
+		c.GetString("user_id"), len(snapshot.Tables), len(snapshot.Tournaments), len(snapshot.PendingWrites), checkpoint.LastHandID)
+
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// HandleImportSnapshot loads a snapshot exported by the primary into this
+// standby. The standby must not be running any tables and its database must
+// have replicated up to the snapshot's checkpoint. Hands in flight are void.
+func HandleImportSnapshot(c *gin.Context, database *db.DB, bridge *game.GameBridge, historyWriter *history.BatchWriter, recoverTables func()) {
+	userID := c.GetString("user_id")
+
+	var snapshot recovery.Snapshot
+	if err := c.ShouldBindJSON(&snapshot); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	bridge.Mu.RLock()
+	running := len(bridge.Tables)
+	bridge.Mu.RUnlock()
+	if running > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "this instance is running tables and is not a standby"})
+		return
+	}
+
+	tableRecovery := recovery.NewTableRecovery(database.DB)
+	if err := tableRecovery.VerifyCheckpoint(snapshot.Checkpoint); err != nil {
+		log.Printf("[ADMIN_AUDIT] Rejected snapshot import by %s: %v", userID, err)
+		if errors.Is(err, recovery.ErrCheckpointNotReached) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := tableRecovery.ApplySnapshot(&snapshot)
+	if err != nil {
+		log.Printf("[ADMIN_AUDIT] Rejected snapshot import by %s: %v", userID, err)
+		if errors.Is(err, recovery.ErrSnapshotVersion) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if historyWriter != nil && len(snapshot.PendingWrites) > 0 {
+		records := make([]history.PendingRecord, len(snapshot.PendingWrites))
+		for i, write := range snapshot.PendingWrites {
+			records[i] = history.PendingRecord{Kind: write.Kind, Data: write.Data}
+		}
+		result.ReplayedWrites, err = historyWriter.Replay(records)
+		if err != nil {
+			log.Printf("[ADMIN_AUDIT] Snapshot import by %s replayed %d of %d pending writes: %v",
+				userID, result.ReplayedWrites, len(records), err)
+		}
+	}
+
+	recoverTables()
+
+	bridge.Mu.RLock()
+	loaded := len(bridge.Tables)
+	bridge.Mu.RUnlock()
+
+	log.Printf("[ADMIN_AUDIT] Platform snapshot from %s imported by %s: %d tables (%d loaded), %d tournaments, %d voided hands, %d replayed writes",
+		snapshot.Checkpoint.TakenAt.Format("2006-01-02T15:04:05Z07:00"), userID, result.Tables, loaded,
+		result.Tournaments, result.VoidedHands, result.ReplayedWrites)
+
+	c.JSON(http.StatusOK, gin.H{
+		"result":        result,
+		"tables_loaded": loaded,
+	})
+}
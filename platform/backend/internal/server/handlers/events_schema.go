@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/server/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	eventsCatalogOnce sync.Once
+	eventsCatalog     []eventschema.Message
+)
+
+// HandleGetEventsSchema returns the catalog of WebSocket messages and engine
+// events, with the fields of each payload
+func HandleGetEventsSchema(c *gin.Context) {
+	eventsCatalogOnce.Do(func() {
+		eventsCatalog = eventschema.Catalog()
+	})
+	c.JSON(http.StatusOK, gin.H{
+		"protocol_version":     websocket.ProtocolVersion,
+		"min_protocol_version": websocket.MinProtocolVersion,
+		"server_features":      websocket.ServerFeatures(),
+		"messages":             eventsCatalog,
+	})
+}
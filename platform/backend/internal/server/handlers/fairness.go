@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"net/http"
+
+	"poker-platform/backend/internal/fairness"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetFairnessReport returns the public deck fairness report: how the
+// cards dealt on the platform compare with the distributions of a fair deck
+func HandleGetFairnessReport(c *gin.Context, analyzer *fairness.Analyzer) {
+	c.JSON(http.StatusOK, analyzer.Report())
+}
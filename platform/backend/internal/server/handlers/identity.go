@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"poker-platform/backend/internal/identity"
+
+	"poker-engine/engine"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleListIdentities lists imported and linked player identities, from
+// every source or the one given with ?source=
+func HandleListIdentities(c *gin.Context, identityService *identity.Service) {
+	identities, err := identityService.List(c.Query("source"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load identities"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"identities": identities})
+}
+
+// HandleLinkIdentity ties a player ID from another system to an existing
+// user. Link before importing, so the player's history lands on their account.
+func HandleLinkIdentity(c *gin.Context, identityService *identity.Service) {
+	var req struct {
+		Source     string `json:"source"`
+		ExternalID string `json:"external_id" binding:"required"`
+		UserID     string `json:"user_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+	if req.Source == "" {
+		req.Source = identity.SourceEngine
+	}
+
+	linked, err := identityService.Link(req.Source, req.ExternalID, req.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, identity.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, identity.ErrAlreadyLinked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, identity.ErrInvalidIdentity):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link identity"})
+		}
+		return
+	}
+
+	log.Printf("[ADMIN_AUDIT] %s player %s linked to user %s by %s", linked.Source, linked.ExternalID, linked.UserID, c.GetString("user_id"))
+	c.JSON(http.StatusCreated, gin.H{"identity": linked})
+}
+
+// HandleImportIdentities maps players and hand records from another system
+// onto platform users, creating users for players not seen before. It returns
+// each player's user ID and the hands rewritten under those IDs.
+func HandleImportIdentities(c *gin.Context, identityService *identity.Service) {
+	var req struct {
+		Source  string               `json:"source"`
+		Players []identity.Player    `json:"players"`
+		Hands   []*engine.HandRecord `json:"hands"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+	if req.Source == "" {
+		req.Source = identity.SourceEngine
+	}
+
+	users := make(map[string]string, len(req.Players))
+	for _, player := range req.Players {
+		userID, err := identityService.Resolve(req.Source, player)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "player": player.ID})
+			return
+		}
+		users[player.ID] = userID
+	}
+
+	hands := make([]*engine.HandRecord, 0, len(req.Hands))
+	for i, record := range req.Hands {
+		if record == nil {
+			continue
+		}
+		imported, err := identityService.ImportHand(req.Source, record)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "hand": i})
+			return
+		}
+		for j, p := range record.Players {
+			if p != nil {
+				users[p.PlayerID] = imported.Players[j].PlayerID
+			}
+		}
+		hands = append(hands, imported)
+	}
+
+	log.Printf("[ADMIN_AUDIT] %d %s players and %d hands imported by %s", len(users), req.Source, len(hands), c.GetString("user_id"))
+	c.JSON(http.StatusOK, gin.H{"users": users, "hands": hands})
+}
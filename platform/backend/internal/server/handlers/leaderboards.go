@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"poker-platform/backend/internal/leaderboard"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetLeaderboard returns the top of a leaderboard for the current
+// daily, weekly (the default) or monthly window, and the caller's own place
+func HandleGetLeaderboard(c *gin.Context, service *leaderboard.Service) {
+	boardType := c.Param("type")
+	period := c.DefaultQuery("period", leaderboard.PeriodWeekly)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	now := time.Now()
+
+	entries, window, err := service.Top(boardType, period, limit, now)
+	if err != nil {
+		if errors.Is(err, leaderboard.ErrInvalidType) || errors.Is(err, leaderboard.ErrInvalidPeriod) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load leaderboard"})
+		return
+	}
+	me, err := service.Position(boardType, period, c.GetString("user_id"), now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load leaderboard"})
+		return
+	}
+	if entries == nil {
+		entries = []leaderboard.Entry{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"type":    boardType,
+		"period":  period,
+		"window":  window,
+		"entries": entries,
+		"me":      me,
+	})
+}
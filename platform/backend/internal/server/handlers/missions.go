@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"poker-platform/backend/internal/mission"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetMissions returns the current user's progress through today's
+// missions
+func HandleGetMissions(c *gin.Context, service *mission.Service) {
+	now := time.Now()
+	missions, err := service.Today(c.GetString("user_id"), now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load missions"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"day": mission.DayOf(now), "missions": missions})
+}
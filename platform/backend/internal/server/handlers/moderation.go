@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"poker-platform/backend/internal/avatar"
+	"poker-platform/backend/internal/moderation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleListModeration lists the moderation queue, pending items by default
+// or those with ?status=approved or rejected
+func HandleListModeration(c *gin.Context, moderationService *moderation.Service) {
+	status := c.DefaultQuery("status", moderation.StatusPending)
+	items, err := moderationService.List(status)
+	if err != nil {
+		if errors.Is(err, moderation.ErrInvalidStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load moderation queue"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// HandleResolveModeration approves or rejects a queued item. Rejecting a
+// username, table name or tournament name replaces it; rejecting an avatar
+// deletes it and takes it off the player's tables.
+func HandleResolveModeration(c *gin.Context, moderationService *moderation.Service, avatars *avatar.Service, showAvatar func(userID, url string), approve bool) {
+	adminID := c.GetString("user_id")
+
+	item, err := moderationService.Resolve(c.Param("id"), adminID, approve, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, moderation.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, moderation.ErrAlreadyReviewed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to review item"})
+		}
+		return
+	}
+
+	log.Printf("[ADMIN_AUDIT] Moderation item %s (%s of %s) %s by %s", item.ID, item.Kind, item.SubjectID, item.Status, adminID)
+	if item.Kind == moderation.KindAvatar && !approve {
+		if err := avatars.Discard(c.Request.Context(), item.Text); err != nil {
+			log.Printf("[AVATAR] Failed to delete rejected avatar %s: %v", item.Text, err)
+		}
+		// The player may have uploaded another since this one was queued
+		if url, err := avatars.URL(item.SubjectID); err == nil {
+			showAvatar(item.SubjectID, url)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"item": item})
+}
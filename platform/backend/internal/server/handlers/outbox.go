@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"poker-platform/backend/internal/outbox"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleListOutbox lists outbox messages, failed ones by default or those
+// with ?status=pending, done or compensated, newest first
+func HandleListOutbox(c *gin.Context, dispatcher *outbox.Dispatcher) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	messages, err := dispatcher.List(c.DefaultQuery("status", outbox.StatusFailed), limit)
+	if err != nil {
+		if errors.Is(err, outbox.ErrInvalidStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load outbox"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"messages": messages})
+}
+
+// HandleRetryOutbox puts a failed outbox message back in the queue
+func HandleRetryOutbox(c *gin.Context, dispatcher *outbox.Dispatcher) {
+	adminID := c.GetString("user_id")
+	id := c.Param("id")
+
+	if err := dispatcher.Retry(id); err != nil {
+		switch {
+		case errors.Is(err, outbox.ErrMessageNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, outbox.ErrNotRetryable):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retry message"})
+		}
+		return
+	}
+
+	log.Printf("[ADMIN_AUDIT] Outbox message %s retried by %s", id, adminID)
+	c.JSON(http.StatusOK, gin.H{"retried": id})
+}
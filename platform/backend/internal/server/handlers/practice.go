@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"poker-platform/backend/internal/server/game"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PracticeActionRequest is an action the practice table's owner takes for a seat
+type PracticeActionRequest struct {
+	Seat   int    `json:"seat"`
+	Action string `json:"action" binding:"required"`
+	Amount int    `json:"amount"`
+}
+
+// practiceResponse shows the whole practice table, hole cards included, since
+// its owner plays every seat
+func practiceResponse(tableID string, table *engine.Table) gin.H {
+	return gin.H{
+		"table_id": tableID,
+		"state":    table.Snapshot(),
+	}
+}
+
+// HandleCreatePracticeTable sets up a practice table dealing an exact spot.
+// Practice tables use no balance.
+func HandleCreatePracticeTable(c *gin.Context, practice *game.PracticeTables) {
+	var spec game.PracticeSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	tableID, table, err := practice.Create(c.GetString("user_id"), spec)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, game.ErrTooManyPracticeTables) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, practiceResponse(tableID, table))
+}
+
+// HandleGetPracticeTable returns the state of one of the user's practice tables
+func HandleGetPracticeTable(c *gin.Context, practice *game.PracticeTables) {
+	tableID := c.Param("id")
+	table, err := practice.Get(c.GetString("user_id"), tableID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, practiceResponse(tableID, table))
+}
+
+// HandlePracticeAction plays an action for a seat at one of the user's practice tables
+func HandlePracticeAction(c *gin.Context, practice *game.PracticeTables) {
+	tableID := c.Param("id")
+	table, err := practice.Get(c.GetString("user_id"), tableID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req PracticeActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+	if err := table.ProcessAction(game.PracticePlayerID(req.Seat), pokerModels.PlayerAction(req.Action), req.Amount); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, practiceResponse(tableID, table))
+}
+
+// HandleClosePracticeTable removes one of the user's practice tables
+func HandleClosePracticeTable(c *gin.Context, practice *game.PracticeTables) {
+	if err := practice.Close(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Practice table closed"})
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"poker-platform/backend/internal/profile"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetPlayerProfile returns a player's public profile. Its owner also
+// sees the fields they hide.
+func HandleGetPlayerProfile(c *gin.Context, service *profile.Service) {
+	p, err := service.Get(c.Param("username"), c.GetString("user_id"))
+	if errors.Is(err, profile.ErrPlayerNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load profile"})
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// HandleUpdateProfilePrivacy sets which fields of the current user's profile
+// are hidden from everyone else
+func HandleUpdateProfilePrivacy(c *gin.Context, service *profile.Service) {
+	var req struct {
+		Hidden []string `json:"hidden"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	err := service.SetHidden(c.GetString("user_id"), req.Hidden)
+	if errors.Is(err, profile.ErrUnknownField) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "fields": profile.Fields})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update privacy settings"})
+		return
+	}
+	if req.Hidden == nil {
+		req.Hidden = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{"hidden": req.Hidden})
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"net/http"
+
+	"poker-platform/backend/internal/progression"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetProgress returns the current user's experience, level, and what the
+// next level takes and pays
+func HandleGetProgress(c *gin.Context, service *progression.Service) {
+	progress, err := service.Progress(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load progress"})
+		return
+	}
+	c.JSON(http.StatusOK, progress)
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"net/http"
+
+	"poker-platform/backend/internal/referral"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetReferrals returns the current user's referral code and how the
+// players they referred are doing
+func HandleGetReferrals(c *gin.Context, service *referral.Service) {
+	stats, err := service.Stats(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load referrals"})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
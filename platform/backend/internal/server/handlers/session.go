@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/server/game"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetSessionSummary returns the player's live sessions, their play over
+// the last 24 hours and how much of each responsible gaming limit remains
+func HandleGetSessionSummary(c *gin.Context, database *db.DB, bridge *game.GameBridge, limits game.SessionLimits) {
+	userID := c.GetString("user_id")
+	now := time.Now()
+
+	totals, err := game.DailyPlay(database, bridge.Sessions, userID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session summary"})
+		return
+	}
+
+	active := []gin.H{}
+	for _, session := range bridge.Sessions.ForUser(userID) {
+		active = append(active, gin.H{
+			"table_id":         session.TableID,
+			"started_at":       session.StartedAt,
+			"duration_seconds": int(session.Duration(now).Seconds()),
+			"hands_dealt":      session.HandsDealt,
+			"hands_played":     session.HandsPlayed,
+		})
+	}
+
+	limitStatus := gin.H{
+		"limit_reached": limits.Check(totals) != nil,
+	}
+	if limits.MaxDailyPlayTime > 0 {
+		limitStatus["max_daily_play_seconds"] = int(limits.MaxDailyPlayTime.Seconds())
+		limitStatus["remaining_play_seconds"] = max(0, int((limits.MaxDailyPlayTime - totals.PlayTime).Seconds()))
+	}
+	if limits.MaxDailyHands > 0 {
+		limitStatus["max_daily_hands"] = limits.MaxDailyHands
+		limitStatus["remaining_hands"] = max(0, limits.MaxDailyHands-totals.HandsDealt)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"active_sessions": active,
+		"last_24h": gin.H{
+			"play_seconds": int(totals.PlayTime.Seconds()),
+			"hands_dealt":  totals.HandsDealt,
+			"hands_played": totals.HandsPlayed,
+		},
+		"limits": limitStatus,
+	})
+}
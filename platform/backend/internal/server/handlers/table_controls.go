@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"poker-platform/backend/internal/bot"
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/server/game"
+
+	"github.com/gin-gonic/gin"
+	"poker-engine/engine"
+)
+
+// HandleCreatorKick removes a player from a cash table for its creator and
+// returns their stack. A player in a hand is folded and leaves when it ends.
+func HandleCreatorKick(c *gin.Context, database *db.DB, bridge *game.GameBridge, broadcastFunc func(string)) {
+	creatorID := c.GetString("user_id")
+	tableID := c.Param("id")
+
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+
+	chips, pending, err := bridge.CreatorKick(database, tableID, creatorID, req.UserID)
+	if err != nil {
+		respondTableControlError(c, err)
+		return
+	}
+	broadcastFunc(tableID)
+	log.Printf("[TABLE_CONTROL] Player %s kicked from table %s by its creator %s (pending=%v, chips=%d)",
+		req.UserID, tableID, creatorID, pending, chips)
+
+	if pending {
+		c.JSON(http.StatusAccepted, gin.H{"message": "Player folded; they leave when the hand ends", "pending": true})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Player removed", "pending": false, "chips_returned": chips})
+}
+
+// HandleCreatorPause pauses a cash table for its creator
+func HandleCreatorPause(c *gin.Context, database *db.DB, bridge *game.GameBridge, broadcastFunc func(string)) {
+	tableID := c.Param("id")
+	if err := bridge.CreatorPause(database, tableID, c.GetString("user_id")); err != nil {
+		respondTableControlError(c, err)
+		return
+	}
+	broadcastFunc(tableID)
+	c.JSON(http.StatusOK, gin.H{"message": "Table paused"})
+}
+
+// HandleCreatorResume resumes a paused cash table for its creator
+func HandleCreatorResume(c *gin.Context, database *db.DB, bridge *game.GameBridge, broadcastFunc func(string)) {
+	tableID := c.Param("id")
+	if err := bridge.CreatorResume(database, tableID, c.GetString("user_id"), broadcastFunc); err != nil {
+		respondTableControlError(c, err)
+		return
+	}
+	broadcastFunc(tableID)
+	c.JSON(http.StatusOK, gin.H{"message": "Table resumed"})
+}
+
+// HandleCreatorClose closes a cash table for its creator: a hand in progress
+// is called off and every stack is returned
+func HandleCreatorClose(c *gin.Context, database *db.DB, bridge *game.GameBridge) {
+	creatorID := c.GetString("user_id")
+	tableID := c.Param("id")
+
+	returned, err := bridge.CreatorClose(database, tableID, creatorID)
+	if err != nil && returned == nil {
+		respondTableControlError(c, err)
+		return
+	}
+	log.Printf("[TABLE_CONTROL] Table %s closed by its creator %s, %d stacks returned", tableID, creatorID, len(returned))
+
+	if err != nil {
+		// The table is closed; some stacks need returning by hand
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "returned": returned})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Table closed", "returned": returned})
+}
+
+// HandleAddBot seats a bot at a cash table for its creator or an admin. The
+// bot plays its turns like any player, after a short think.
+func HandleAddBot(
+	c *gin.Context,
+	database *db.DB,
+	bridge *game.GameBridge,
+	addPlayerFunc func(tableID, userID, username string, seatNumber, buyIn int),
+) {
+	tableID := c.Param("id")
+
+	var req struct {
+		Difficulty string `json:"difficulty"` // easy, medium or hard; defaults to medium
+		BuyIn      int    `json:"buy_in"`     // optional, defaults to the table minimum
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	seated, err := bridge.AddBot(c.Request.Context(), database, tableID, c.GetString("user_id"), req.Difficulty, req.BuyIn)
+	if err != nil {
+		switch {
+		case errors.Is(err, engine.ErrUnknownBotDifficulty), errors.Is(err, bot.ErrBuyInOutOfRange),
+			errors.Is(err, bot.ErrTableFull), errors.Is(err, bot.ErrNotCashTable), errors.Is(err, bot.ErrClubTable):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, game.ErrBotsDisabled):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		default:
+			respondTableControlError(c, err)
+		}
+		return
+	}
+	addPlayerFunc(tableID, seated.UserID, seated.Username, seated.SeatNumber, seated.BuyIn)
+	log.Printf("[TABLE_CONTROL] %s bot %s seated at table %s by %s with %d chips",
+		seated.Difficulty, seated.UserID, tableID, c.GetString("user_id"), seated.BuyIn)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bot seated", "bot": seated})
+}
+
+func respondTableControlError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, game.ErrNotTableCreator):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, game.ErrKickSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, game.ErrTablePaused), errors.Is(err, game.ErrTableNotPaused):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		respondAdminTableError(c, err)
+	}
+}
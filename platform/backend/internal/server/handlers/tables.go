@@ -1,12 +1,22 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+	"poker-platform/backend/internal/club"
+	"poker-platform/backend/internal/currency"
 	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/entry"
 	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/moderation"
+	"poker-platform/backend/internal/quota"
+	"poker-platform/backend/internal/server/game"
 	"poker-platform/backend/internal/validation"
 
 	"github.com/gin-gonic/gin"
@@ -14,38 +24,52 @@ import (
 	"gorm.io/gorm"
 )
 
-// HandleGetTables returns all available tables
-func HandleGetTables(c *gin.Context, database *db.DB) {
-	userID := c.GetString("user_id")
-	_ = userID
-
-	type TableResult struct {
-		ID             string `json:"id"`
-		Name           string `json:"name"`
-		GameType       string `json:"game_type"`
-		Status         string `json:"status"`
-		SmallBlind     int    `json:"small_blind"`
-		BigBlind       int    `json:"big_blind"`
-		MaxPlayers     int    `json:"max_players"`
-		MinBuyIn       *int   `json:"min_buy_in"`
-		MaxBuyIn       *int   `json:"max_buy_in"`
-		CurrentPlayers int64  `json:"current_players"`
-	}
-
-	var results []TableResult
+// LobbyTable is a table as listed in a lobby
+type LobbyTable struct {
+	ID                string                    `json:"id"`
+	Name              string                    `json:"name"`
+	GameType          string                    `json:"game_type"`
+	Status            string                    `json:"status"`
+	SmallBlind        int                       `json:"small_blind"`
+	BigBlind          int                       `json:"big_blind"`
+	MaxPlayers        int                       `json:"max_players"`
+	MinBuyIn          *int                      `json:"min_buy_in"`
+	MaxBuyIn          *int                      `json:"max_buy_in"`
+	CurrentPlayers    int64                     `json:"current_players"`
+	Stats             *game.TableStatsSummary   `json:"stats,omitempty" gorm:"-"`
+	EntryRequirements *models.EntryRequirements `json:"entry_requirements,omitempty" gorm:"serializer:json"`
+}
 
-	err := database.
+// lobbyTables returns the open tables of a club's lobby, or of the public
+// lobby when clubID is nil
+func lobbyTables(database *db.DB, bridge *game.GameBridge, clubID *string) ([]LobbyTable, error) {
+	query := database.
 		Table("tables t").
 		Select(`t.id, t.name, t.game_type, t.status, t.small_blind, t.big_blind, t.max_players,
-			t.min_buy_in, t.max_buy_in,
+			t.min_buy_in, t.max_buy_in, t.entry_requirements,
 			COUNT(DISTINCT ts.user_id) as current_players`).
 		Joins("LEFT JOIN table_seats ts ON t.id = ts.table_id AND ts.left_at IS NULL").
-		Where("t.status IN ?", []string{"waiting", "playing"}).
-		Group("t.id").
-		Order("t.created_at DESC").
-		Limit(50).
-		Scan(&results).Error
+		Where("t.status IN ?", []string{"waiting", "playing"})
+	if clubID != nil {
+		query = query.Where("t.club_id = ?", *clubID)
+	} else {
+		query = query.Where("t.club_id IS NULL")
+	}
 
+	results := []LobbyTable{}
+	if err := query.Group("t.id").Order("t.created_at DESC").Limit(50).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Stats = bridge.StatsSummary(results[i].ID)
+	}
+	return results, nil
+}
+
+// HandleGetTables returns all available public tables with their recent-hand stats
+func HandleGetTables(c *gin.Context, database *db.DB, bridge *game.GameBridge) {
+	results, err := lobbyTables(database, bridge, nil)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Server error"})
 		return
@@ -183,19 +207,30 @@ func HandleGetPastTables(c *gin.Context, database *db.DB) {
 func HandleCreateTable(
 	c *gin.Context,
 	database *db.DB,
+	moderationService *moderation.Service,
+	quotas *quota.Service,
 	createEngineTableFunc func(tableID, gameType string, smallBlind, bigBlind, maxPlayers, minBuyIn, maxBuyIn int),
 ) {
-	var table models.Table
-	if err := c.ShouldBindJSON(&table); err != nil {
+	var req struct {
+		models.Table
+		InviteList []string `json:"invite_list"` // User IDs allowed in when invite only
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
+	table := req.Table
 
 	// CRITICAL: Validate all table parameters to prevent invalid game states
 	if err := validation.ValidateTableName(table.Name); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	nameMatch, err := moderationService.Screen("table name", table.Name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	if err := validation.ValidateBlinds(table.SmallBlind, table.BigBlind); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -228,23 +263,164 @@ func HandleCreateTable(
 		return
 	}
 
+	if err := entry.Validate(table.EntryRequirements, req.InviteList); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateSpectatorDelay(table.SpectatorDelay); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateDisplay(table.CurrencySymbol, table.ChipScale); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if table.AllowStraddle && table.GameType != "cash" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only cash tables can allow straddling"})
+		return
+	}
+
+	if table.AutoCloseAt != nil {
+		if table.GameType != "cash" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "only cash tables can have a closing time"})
+			return
+		}
+		if !table.AutoCloseAt.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "closing time must be in the future"})
+			return
+		}
+	}
+
+	if table.SessionLength != 0 {
+		if table.GameType != "cash" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "only cash tables can have a timed session"})
+			return
+		}
+		if table.AutoCloseAt != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "a timed session sets its own closing time"})
+			return
+		}
+		if err := validation.ValidateIntRange(table.SessionLength,
+			int(game.MinSessionLength/time.Second), int(game.MaxSessionLength/time.Second), "session length"); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if !pokerModels.ValidShowdownPolicy(table.ShowdownPolicy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "showdown policy must be all, callers, winners or never"})
+		return
+	}
+
+	if !pokerModels.ValidBettingMode(table.BettingMode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "betting mode must be no_limit, pot_limit or fixed_limit"})
+		return
+	}
+
+	// Only club owners and managers can create club tables
+	if err := club.CheckManager(database.DB, table.ClubID, c.GetString("user_id")); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	creatorID := c.GetString("user_id")
+	// The error says which quota ran out and its limit
+	if err := quotas.CheckTable(creatorID); err != nil {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "quota": err})
+		return
+	}
+
 	table.ID = uuid.New().String()
 	table.Status = "waiting"
+	table.CreatorID = &creatorID
+
+	// The creator is always on the invite list of an invite-only table
+	invites := req.InviteList
+	if table.EntryRequirements != nil && table.EntryRequirements.InviteOnly {
+		invites = append(invites, c.GetString("user_id"))
+	}
 
-	if err := database.Create(&table).Error; err != nil {
+	err = database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&table).Error; err != nil {
+			return err
+		}
+		return entry.SetInvites(tx, table.ID, invites)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create table"})
 		return
 	}
+	if _, err := moderationService.Flag(nameMatch, moderation.KindTableName, table.ID, creatorID, table.Name); err != nil {
+		log.Printf("[MODERATION] Failed to queue name of table %s: %v", table.ID, err)
+	}
 
 	createEngineTableFunc(table.ID, table.GameType, table.SmallBlind, table.BigBlind, table.MaxPlayers, minBuyIn, maxBuyIn)
 
 	c.JSON(http.StatusCreated, table)
 }
 
+// validateSpectatorDelay checks a spectator delay in seconds
+func validateSpectatorDelay(seconds int) error {
+	return validation.ValidateIntRange(seconds, 0, int(game.MaxSpectatorDelay/time.Second), "spectator delay")
+}
+
+// validateDisplay checks a table's currency symbol and chip scale. A chip
+// scale of zero falls back to the column default of 1.
+func validateDisplay(currencySymbol string, chipScale int) error {
+	if err := validation.ValidateStringLength(currencySymbol, 0, 8, "currency symbol"); err != nil {
+		return err
+	}
+	return validation.ValidateIntRange(chipScale, 0, 1000000, "chip scale")
+}
+
+// HandleSetSpectatorDelay changes how far spectators lag behind the players at
+// a table. Only the table's creator can change it; players are never delayed.
+func HandleSetSpectatorDelay(c *gin.Context, database *db.DB, spectators *game.SpectatorHub) {
+	userID := c.GetString("user_id")
+	tableID := c.Param("id")
+
+	var req struct {
+		SpectatorDelay *int `json:"spectator_delay" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+	if err := validateSpectatorDelay(*req.SpectatorDelay); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var table models.Table
+	if err := database.Where("id = ?", tableID).First(&table).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		return
+	}
+	if table.CreatorID == nil || *table.CreatorID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the table creator can change the spectator delay"})
+		return
+	}
+
+	if err := database.Model(&table).Update("spectator_delay", *req.SpectatorDelay).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update spectator delay"})
+		return
+	}
+	spectators.SetDelay(tableID, time.Duration(*req.SpectatorDelay)*time.Second)
+
+	c.JSON(http.StatusOK, gin.H{
+		"table_id":        tableID,
+		"spectator_delay": *req.SpectatorDelay,
+	})
+}
+
 // HandleJoinTable allows a player to join a table
 func HandleJoinTable(
 	c *gin.Context,
 	database *db.DB,
+	checkLimitsFunc func(userID string) error,
 	addPlayerFunc func(tableID, userID, username string, seatNumber, buyIn int),
 ) {
 	tableID := c.Param("id")
@@ -256,8 +432,13 @@ func HandleJoinTable(
 		return
 	}
 
+	if err := checkLimitsFunc(userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
 	var buyIn struct {
-		BuyIn int `json:"buy_in"`
+		BuyIn int `json:"buy_in"` // optional, defaults to the table minimum
 	}
 	if err := c.ShouldBindJSON(&buyIn); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
@@ -276,17 +457,32 @@ func HandleJoinTable(
 		return
 	}
 
-	if user.Chips < buyIn.BuyIn {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient chips"})
-		return
-	}
-
 	var table models.Table
 	if err := database.Where("id = ?", tableID).First(&table).Error; err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Table not found"})
 		return
 	}
 
+	// Club tables are only open to members
+	if err := club.CheckAccess(database.DB, table.ClubID, userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := entry.Check(database.DB, table.EntryRequirements, tableID, userID, time.Now()); err != nil {
+		var entryErr *entry.Error
+		if errors.As(err, &entryErr) {
+			c.JSON(http.StatusForbidden, gin.H{"error": entryErr.Message, "code": entryErr.Code})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Server error"})
+		return
+	}
+
+	if buyIn.BuyIn == 0 && table.MinBuyIn != nil {
+		buyIn.BuyIn = *table.MinBuyIn
+	}
+
 	// Validate buy-in is within table limits
 	if table.MinBuyIn != nil && buyIn.BuyIn < *table.MinBuyIn {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Buy-in below table minimum"})
@@ -297,6 +493,12 @@ func HandleJoinTable(
 		return
 	}
 
+	// Club tables are bought into with club chips, checked inside the transaction
+	if table.ClubID == nil && user.Chips < buyIn.BuyIn {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient chips"})
+		return
+	}
+
 	var currentPlayers int64
 	database.Model(&models.TableSeat{}).Where("table_id = ? AND left_at IS NULL", tableID).Count(&currentPlayers)
 
@@ -307,6 +509,27 @@ func HandleJoinTable(
 
 	seatNumber := int(currentPlayers)
 
+	// A dry run stops here with what joining would do
+	if c.Query("dry_run") == "true" {
+		outcome := gin.H{"status": "ok", "dry_run": true, "table_id": tableID, "seat_number": seatNumber, "buy_in": buyIn.BuyIn}
+		if table.ClubID != nil {
+			left, err := club.CheckBuyIn(database.DB, *table.ClubID, userID, buyIn.BuyIn)
+			if errors.Is(err, club.ErrNotEnoughClubChips) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient club chips"})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check club chips"})
+				return
+			}
+			outcome["club_balance_after"] = left
+		} else {
+			outcome["balance_after"] = user.Chips - buyIn.BuyIn
+		}
+		c.JSON(http.StatusOK, outcome)
+		return
+	}
+
 	// CRITICAL: Use transaction to ensure atomic operations
 	// If chip deduction fails, table seat creation is rolled back
 	// If table seat creation fails, chip deduction is rolled back
@@ -317,6 +540,7 @@ func HandleJoinTable(
 			UserID:     userID,
 			SeatNumber: seatNumber,
 			Chips:      buyIn.BuyIn,
+			BoughtIn:   buyIn.BuyIn,
 			Status:     "active",
 		}
 
@@ -324,6 +548,10 @@ func HandleJoinTable(
 			return fmt.Errorf("failed to create table seat: %w", err)
 		}
 
+		if table.ClubID != nil {
+			return club.BuyIn(tx, *table.ClubID, userID, tableID, buyIn.BuyIn)
+		}
+
 		// Deduct chips from user (atomic with seat creation)
 		if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("chips", user.Chips-buyIn.BuyIn).Error; err != nil {
 			return fmt.Errorf("failed to deduct chips: %w", err)
@@ -332,6 +560,10 @@ func HandleJoinTable(
 		return nil
 	})
 
+	if errors.Is(err, club.ErrNotEnoughClubChips) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient club chips"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to join table"})
 		return
@@ -341,3 +573,164 @@ func HandleJoinTable(
 
 	c.JSON(http.StatusOK, gin.H{"status": "joined", "table_id": tableID})
 }
+
+// HandleRebuy reloads a seated player's stack at a cash table between hands.
+// The chips come out of the player's balance (or club wallet at club tables)
+// in the same transaction that updates the seat; if the engine rejects the
+// reload, nothing is charged.
+func HandleRebuy(
+	c *gin.Context,
+	database *db.DB,
+	currencyService *currency.Service,
+	getTable func(string) (*engine.Table, bool),
+	broadcastFunc func(tableID string),
+) {
+	tableID := c.Param("id")
+	userID := c.GetString("user_id")
+
+	if err := validation.ValidateUUID(tableID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid table ID"})
+		return
+	}
+
+	var req struct {
+		Amount int `json:"amount" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "amount is required"})
+		return
+	}
+	if err := validation.ValidateBuyIn(req.Amount); err != nil || req.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rebuy amount"})
+		return
+	}
+
+	var table models.Table
+	if err := database.Where("id = ?", tableID).First(&table).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		return
+	}
+	if table.GameType != "cash" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rebuys are only available at cash tables"})
+		return
+	}
+
+	var seat models.TableSeat
+	if err := database.Where("table_id = ? AND user_id = ? AND left_at IS NULL", tableID, userID).First(&seat).Error; err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You are not seated at this table"})
+		return
+	}
+
+	engineTable, exists := getTable(tableID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Table is not running"})
+		return
+	}
+
+	// A dry run checks the engine and the balance without charging anything
+	if c.Query("dry_run") == "true" {
+		stack, err := engineTable.CheckAddChips(userID, req.Amount)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		outcome := gin.H{"status": "ok", "dry_run": true, "table_id": tableID, "amount": req.Amount, "stack_after": stack}
+		if table.ClubID != nil {
+			left, err := club.CheckBuyIn(database.DB, *table.ClubID, userID, req.Amount)
+			if errors.Is(err, club.ErrNotEnoughClubChips) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient club chips"})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check club chips"})
+				return
+			}
+			outcome["club_balance_after"] = left
+		} else {
+			left, err := currencyService.CheckDeduct(c.Request.Context(), userID, req.Amount)
+			if errors.Is(err, currency.ErrInsufficientChips) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient chips"})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			outcome["balance_after"] = left
+		}
+		c.JSON(http.StatusOK, outcome)
+		return
+	}
+
+	var rejected error
+	err := database.Transaction(func(tx *gorm.DB) error {
+		if table.ClubID != nil {
+			if err := club.BuyIn(tx, *table.ClubID, userID, tableID, req.Amount); err != nil {
+				return err
+			}
+		} else if err := currencyService.DeductChipsWithTx(c.Request.Context(), tx, userID, req.Amount,
+			currency.TxTypeCashGameRebuy, tableID, "Cash game rebuy"); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.TableSeat{}).Where("id = ?", seat.ID).Updates(map[string]interface{}{
+			"chips":     gorm.Expr("chips + ?", req.Amount),
+			"bought_in": gorm.Expr("bought_in + ?", req.Amount),
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update seat: %w", err)
+		}
+
+		// Last, so a rejected reload rolls the charge back
+		if err := engineTable.AddChips(userID, req.Amount); err != nil {
+			rejected = err
+			return err
+		}
+		return nil
+	})
+
+	switch {
+	case rejected != nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": rejected.Error()})
+		return
+	case errors.Is(err, currency.ErrInsufficientChips):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient chips"})
+		return
+	case errors.Is(err, club.ErrNotEnoughClubChips):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient club chips"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rebuy"})
+		return
+	}
+
+	broadcastFunc(tableID)
+
+	c.JSON(http.StatusOK, gin.H{"status": "rebought", "table_id": tableID, "amount": req.Amount})
+}
+
+// HandleGetSessionResults sums up how everyone who sat at a cash table did.
+// Timed sessions send the same summary to their players when they end.
+func HandleGetSessionResults(c *gin.Context, database *db.DB) {
+	tableID := c.Param("id")
+	if err := validation.ValidateUUID(tableID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid table ID"})
+		return
+	}
+
+	var table models.Table
+	if err := database.Where("id = ?", tableID).First(&table).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		return
+	}
+	if table.GameType != "cash" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Session results are only available at cash tables"})
+		return
+	}
+
+	results, err := game.SessionResults(database, tableID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch session results"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"table_id": tableID, "session_length": table.SessionLength, "players": results})
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"poker-platform/backend/internal/timeline"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetUserTimeline returns a page of the caller's activity across
+// tables, tournaments and their chip balance, newest first
+func HandleGetUserTimeline(c *gin.Context, service *timeline.Service) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	entries, err := service.Feed(c.GetString("user_id"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load timeline"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"offset":  offset,
+	})
+}
@@ -21,10 +21,7 @@ func GetHandHistory(c *gin.Context, database *db.DB) {
 	}
 
 	// Fetch all events for this hand ordered by sequence
-	var events []models.GameEvent
-	err = database.Where("hand_id = ?", handID).
-		Order("sequence_number ASC").
-		Find(&events).Error
+	events, err := HandEvents(database.DB, handID)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch hand history"})
@@ -48,6 +45,8 @@ func GetHandHistory(c *gin.Context, database *db.DB) {
 			"betting_round":   event.BettingRound,
 			"action_type":     event.ActionType,
 			"amount":          event.Amount,
+			"pot_after":       event.PotAfter,
+			"board":           event.Board,
 			"metadata":        metadata,
 			"sequence_number": event.SequenceNumber,
 			"created_at":      event.CreatedAt,
@@ -152,10 +151,7 @@ func GetCurrentHandHistory(c *gin.Context, database *db.DB, getCurrentHandID fun
 	}
 
 	// Fetch events for current hand
-	var events []models.GameEvent
-	err := database.Where("hand_id = ?", handID).
-		Order("sequence_number ASC").
-		Find(&events).Error
+	events, err := HandEvents(database.DB, handID)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch current hand history"})
@@ -177,6 +173,8 @@ func GetCurrentHandHistory(c *gin.Context, database *db.DB, getCurrentHandID fun
 			"betting_round":   event.BettingRound,
 			"action_type":     event.ActionType,
 			"amount":          event.Amount,
+			"pot_after":       event.PotAfter,
+			"board":           event.Board,
 			"metadata":        metadata,
 			"sequence_number": event.SequenceNumber,
 			"created_at":      event.CreatedAt,
@@ -0,0 +1,121 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// EventQuery filters game_events on their indexed columns. Zero fields match
+// everything.
+type EventQuery struct {
+	HandID  int64
+	TableID string
+	UserID  string
+	Kinds   []models.GameEventKind
+	Street  string
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+}
+
+// QueryEvents returns the matching events in the order they happened
+func QueryEvents(database *gorm.DB, q EventQuery) ([]models.GameEvent, error) {
+	query := database.Model(&models.GameEvent{})
+	if q.HandID != 0 {
+		query = query.Where("hand_id = ?", q.HandID)
+	}
+	if q.TableID != "" {
+		query = query.Where("table_id = ?", q.TableID)
+	}
+	if q.UserID != "" {
+		query = query.Where("user_id = ?", q.UserID)
+	}
+	if len(q.Kinds) > 0 {
+		query = query.Where("event_type IN ?", q.Kinds)
+	}
+	if q.Street != "" {
+		query = query.Where("betting_round = ?", q.Street)
+	}
+	if !q.Since.IsZero() {
+		query = query.Where("created_at >= ?", q.Since)
+	}
+	if !q.Until.IsZero() {
+		query = query.Where("created_at < ?", q.Until)
+	}
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
+	}
+
+	var events []models.GameEvent
+	if err := query.Order("hand_id ASC, sequence_number ASC").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to query game events: %w", err)
+	}
+	return events, nil
+}
+
+// HandEvents returns every event of a hand in sequence order
+func HandEvents(database *gorm.DB, handID int64) ([]models.GameEvent, error) {
+	return QueryEvents(database, EventQuery{HandID: handID})
+}
+
+// PlayerActions returns a player's decisions, optionally limited to one street
+func PlayerActions(database *gorm.DB, userID string, street string, since time.Time) ([]models.GameEvent, error) {
+	return QueryEvents(database, EventQuery{
+		UserID: userID,
+		Kinds:  []models.GameEventKind{models.EventKindPlayerAction, models.EventKindPlayerTimeout},
+		Street: street,
+		Since:  since,
+	})
+}
+
+// ActionCounts counts a player's decisions by action type, e.g. for VPIP and
+// aggression stats
+func ActionCounts(database *gorm.DB, userID string, since time.Time) (map[string]int, error) {
+	var rows []struct {
+		ActionType string
+		Count      int
+	}
+	query := database.Model(&models.GameEvent{}).
+		Select("action_type, COUNT(*) AS count").
+		Where("user_id = ? AND event_type = ?", userID, models.EventKindPlayerAction)
+	if !since.IsZero() {
+		query = query.Where("created_at >= ?", since)
+	}
+	if err := query.Group("action_type").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count actions: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.ActionType] = row.Count
+	}
+	return counts, nil
+}
+
+// SequenceGaps returns the sequence numbers missing from a hand's events, for
+// integrity checks on hands whose history was written asynchronously
+func SequenceGaps(database *gorm.DB, handID int64) ([]int, error) {
+	var sequences []int
+	if err := database.Model(&models.GameEvent{}).
+		Where("hand_id = ?", handID).
+		Order("sequence_number ASC").
+		Pluck("sequence_number", &sequences).Error; err != nil {
+		return nil, fmt.Errorf("failed to read sequence numbers: %w", err)
+	}
+
+	var gaps []int
+	next := 0
+	for _, seq := range sequences {
+		for ; next < seq; next++ {
+			gaps = append(gaps, next)
+		}
+		if seq >= next {
+			next = seq + 1
+		}
+	}
+	return gaps, nil
+}
@@ -0,0 +1,75 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/ids"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupQueryDB(t *testing.T) *gorm.DB {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	testutil.Schema(t, gormDB, &models.GameEvent{})
+	return gormDB
+}
+
+func TestQueryEvents_FiltersOnColumns(t *testing.T) {
+	gormDB := setupQueryDB(t)
+	tracker := NewHistoryTracker(&db.DB{DB: gormDB})
+
+	require.NoError(t, tracker.RecordHandStarted(1, "table-1", 1, 0, 1, 2, 5, 10, 3))
+	require.NoError(t, tracker.RecordPlayerAction(1, "table-1", "alice", "Alice", "raise", 30, "preflop", 30, 45))
+	require.NoError(t, tracker.RecordPlayerAction(1, "table-1", "bob", "Bob", "call", 30, "preflop", 30, 75))
+	require.NoError(t, tracker.RecordRoundAdvanced(1, "table-1", "flop", []string{"Ah", "Kd", "7c"}, 75))
+	require.NoError(t, tracker.RecordPlayerAction(1, "table-1", "alice", "Alice", "check", 0, "flop", 0, 75))
+	require.NoError(t, tracker.RecordPlayerAction(2, "table-1", "alice", "Alice", "fold", 0, "preflop", 10, 15))
+
+	events, err := HandEvents(gormDB, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+	for i, event := range events {
+		assert.Equal(t, i, event.SequenceNumber)
+	}
+
+	flop := events[3]
+	assert.Equal(t, models.EventKindRoundAdvanced, flop.EventType)
+	require.NotNil(t, flop.Board)
+	assert.Equal(t, "Ah Kd 7c", *flop.Board)
+	require.NotNil(t, events[2].PotAfter)
+	assert.Equal(t, 75, *events[2].PotAfter)
+
+	actions, err := PlayerActions(gormDB, "alice", "preflop", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	assert.Equal(t, int64(1), actions[0].HandID)
+	assert.Equal(t, int64(2), actions[1].HandID)
+
+	counts, err := ActionCounts(gormDB, "alice", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"raise": 1, "check": 1, "fold": 1}, counts)
+}
+
+func TestSequenceGaps(t *testing.T) {
+	gormDB := setupQueryDB(t)
+	for _, seq := range []int{0, 1, 3, 6} {
+		require.NoError(t, gormDB.Create(&models.GameEvent{UID: ids.New(), HandID: 7, TableID: "table-1",
+			EventType: models.EventKindPlayerAction, SequenceNumber: seq}).Error)
+	}
+
+	gaps, err := SequenceGaps(gormDB, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 5}, gaps)
+
+	gaps, err = SequenceGaps(gormDB, 8)
+	require.NoError(t, err)
+	assert.Empty(t, gaps)
+}
@@ -3,9 +3,12 @@ package history
 import (
 	"encoding/json"
 	"log"
+	"strings"
 	"sync"
+	"time"
 
 	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/ids"
 	"poker-platform/backend/internal/models"
 )
 
@@ -14,6 +17,7 @@ type HistoryTracker struct {
 	db            *db.DB
 	mu            sync.RWMutex
 	handSequences map[int64]int // hand_id -> next sequence number
+	writer        *BatchWriter  // optional async writer; nil means synchronous writes
 }
 
 // NewHistoryTracker creates a new history tracker instance
@@ -24,59 +28,96 @@ func NewHistoryTracker(database *db.DB) *HistoryTracker {
 	}
 }
 
+// SetWriter routes history writes through an async batch writer
+func (h *HistoryTracker) SetWriter(writer *BatchWriter) {
+	h.writer = writer
+}
+
+// RecordHandAction persists a legacy hand_actions row
+func (h *HistoryTracker) RecordHandAction(action models.HandAction) error {
+	if action.CreatedAt.IsZero() {
+		action.CreatedAt = time.Now()
+	}
+	if action.UID == "" {
+		action.UID = ids.NewAt(action.CreatedAt)
+	}
+
+	if h.writer != nil {
+		return h.writer.EnqueueHandAction(action)
+	}
+
+	return h.db.Create(&action).Error
+}
+
 // RecordEvent records a game event with automatic sequence numbering
 func (h *HistoryTracker) RecordEvent(
 	handID int64,
 	tableID string,
-	eventType string,
+	eventType models.GameEventKind,
 	userID *string,
 	bettingRound *string,
 	actionType *string,
 	amount int,
 	metadata map[string]interface{},
 ) error {
+	return h.record(models.GameEvent{
+		HandID:       handID,
+		TableID:      tableID,
+		EventType:    eventType,
+		UserID:       userID,
+		BettingRound: bettingRound,
+		ActionType:   actionType,
+		Amount:       amount,
+	}, metadata)
+}
+
+// record numbers an event, attaches its metadata and persists it
+func (h *HistoryTracker) record(event models.GameEvent, metadata map[string]interface{}) error {
 	// Get next sequence number for this hand
-	seq := h.getNextSequence(handID)
+	event.SequenceNumber = h.getNextSequence(event.HandID)
+	event.CreatedAt = time.Now()
+	if event.UID == "" {
+		event.UID = ids.NewAt(event.CreatedAt)
+	}
 
 	// Marshal metadata to JSON
-	var metadataJSON string
-	if metadata != nil && len(metadata) > 0 {
+	event.Metadata = "{}"
+	if len(metadata) > 0 {
 		jsonBytes, err := json.Marshal(metadata)
 		if err != nil {
 			log.Printf("[HISTORY_TRACKER] Failed to marshal metadata: %v", err)
-			metadataJSON = "{}"
 		} else {
-			metadataJSON = string(jsonBytes)
+			event.Metadata = string(jsonBytes)
 		}
-	} else {
-		metadataJSON = "{}"
 	}
 
-	// Create event record
-	event := models.GameEvent{
-		HandID:         handID,
-		TableID:        tableID,
-		EventType:      eventType,
-		UserID:         userID,
-		BettingRound:   bettingRound,
-		ActionType:     actionType,
-		Amount:         amount,
-		Metadata:       metadataJSON,
-		SequenceNumber: seq,
+	// Queue for batched persistence when an async writer is configured
+	if h.writer != nil {
+		if err := h.writer.EnqueueGameEvent(event); err != nil {
+			log.Printf("[HISTORY_TRACKER] ERROR: Failed to queue event %s for hand %d: %v", event.EventType, event.HandID, err)
+			return err
+		}
+		return nil
 	}
 
 	// Save to database
 	if err := h.db.Create(&event).Error; err != nil {
-		log.Printf("[HISTORY_TRACKER] ERROR: Failed to save event %s for hand %d: %v", eventType, handID, err)
+		log.Printf("[HISTORY_TRACKER] ERROR: Failed to save event %s for hand %d: %v", event.EventType, event.HandID, err)
 		return err
 	}
 
 	log.Printf("[HISTORY_TRACKER] Recorded event: hand_id=%d type=%s seq=%d user_id=%v betting_round=%v",
-		handID, eventType, seq, userID, bettingRound)
+		event.HandID, event.EventType, event.SequenceNumber, event.UserID, event.BettingRound)
 
 	return nil
 }
 
+// boardString joins community cards into the board column format
+func boardString(cards []string) *string {
+	board := strings.Join(cards, " ")
+	return &board
+}
+
 // getNextSequence returns the next sequence number for a hand and increments the counter
 func (h *HistoryTracker) getNextSequence(handID int64) int {
 	h.mu.Lock()
@@ -125,7 +166,7 @@ func (h *HistoryTracker) RecordHandStarted(
 		"num_players":          numPlayers,
 	}
 
-	return h.RecordEvent(handID, tableID, "hand_started", nil, nil, nil, 0, metadata)
+	return h.RecordEvent(handID, tableID, models.EventKindHandStarted, nil, nil, nil, 0, metadata)
 }
 
 // RecordPlayerAction records a player_action event
@@ -146,7 +187,16 @@ func (h *HistoryTracker) RecordPlayerAction(
 		"pot_after":   potAfter,
 	}
 
-	return h.RecordEvent(handID, tableID, "player_action", &userID, &bettingRound, &action, amount, metadata)
+	return h.record(models.GameEvent{
+		HandID:       handID,
+		TableID:      tableID,
+		EventType:    models.EventKindPlayerAction,
+		UserID:       &userID,
+		BettingRound: &bettingRound,
+		ActionType:   &action,
+		Amount:       amount,
+		PotAfter:     &potAfter,
+	}, metadata)
 }
 
 // RecordRoundAdvanced records a round_advanced event (flop, turn, river)
@@ -163,7 +213,14 @@ func (h *HistoryTracker) RecordRoundAdvanced(
 		"pot":             pot,
 	}
 
-	return h.RecordEvent(handID, tableID, "round_advanced", nil, &newRound, nil, 0, metadata)
+	return h.record(models.GameEvent{
+		HandID:       handID,
+		TableID:      tableID,
+		EventType:    models.EventKindRoundAdvanced,
+		BettingRound: &newRound,
+		PotAfter:     &pot,
+		Board:        boardString(communityCards),
+	}, metadata)
 }
 
 // RecordShowdown records a showdown event
@@ -177,7 +234,24 @@ func (h *HistoryTracker) RecordShowdown(
 	}
 
 	bettingRound := "showdown"
-	return h.RecordEvent(handID, tableID, "showdown", nil, &bettingRound, nil, 0, metadata)
+	return h.RecordEvent(handID, tableID, models.EventKindShowdown, nil, &bettingRound, nil, 0, metadata)
+}
+
+// RecordCardsShown records the cards the winner of a pot nobody contested
+// chose to show
+func (h *HistoryTracker) RecordCardsShown(
+	handID int64,
+	tableID string,
+	userID string,
+	playerName string,
+	cards []string,
+) error {
+	metadata := map[string]interface{}{
+		"player_name": playerName,
+		"cards":       cards,
+	}
+
+	return h.RecordEvent(handID, tableID, models.EventKindCardsShown, &userID, nil, nil, 0, metadata)
 }
 
 // RecordHandComplete records a hand_complete event
@@ -195,7 +269,15 @@ func (h *HistoryTracker) RecordHandComplete(
 		"final_community_cards": finalCommunityCards,
 	}
 
-	return h.RecordEvent(handID, tableID, "hand_complete", nil, &bettingRound, nil, finalPot, metadata)
+	return h.record(models.GameEvent{
+		HandID:       handID,
+		TableID:      tableID,
+		EventType:    models.EventKindHandComplete,
+		BettingRound: &bettingRound,
+		Amount:       finalPot,
+		PotAfter:     &finalPot,
+		Board:        boardString(finalCommunityCards),
+	}, metadata)
 }
 
 // RecordPlayerTimeout records a player_timeout event
@@ -212,7 +294,7 @@ func (h *HistoryTracker) RecordPlayerTimeout(
 		"auto_action": autoAction,
 	}
 
-	return h.RecordEvent(handID, tableID, "player_timeout", &userID, &bettingRound, &autoAction, 0, metadata)
+	return h.RecordEvent(handID, tableID, models.EventKindPlayerTimeout, &userID, &bettingRound, &autoAction, 0, metadata)
 }
 
 // RecordBlindsIncreased records a blinds_increased event (for tournaments)
@@ -229,5 +311,5 @@ func (h *HistoryTracker) RecordBlindsIncreased(
 		"level":           level,
 	}
 
-	return h.RecordEvent(handID, tableID, "blinds_increased", nil, nil, nil, 0, metadata)
+	return h.RecordEvent(handID, tableID, models.EventKindBlindsIncreased, nil, nil, nil, 0, metadata)
 }
@@ -65,7 +65,7 @@ func TestRecordEvent_BasicEvent(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, handID, event.HandID)
 	assert.Equal(t, tableID, event.TableID)
-	assert.Equal(t, "hand_started", event.EventType)
+	assert.Equal(t, models.EventKindHandStarted, event.EventType)
 	assert.Equal(t, 0, event.SequenceNumber)
 
 	// Verify metadata
@@ -173,7 +173,7 @@ func TestRecordHandStarted(t *testing.T) {
 	var event models.GameEvent
 	err = database.Where("hand_id = ?", handID).First(&event).Error
 	assert.NoError(t, err)
-	assert.Equal(t, "hand_started", event.EventType)
+	assert.Equal(t, models.EventKindHandStarted, event.EventType)
 
 	// Verify metadata
 	var metadata map[string]interface{}
@@ -208,7 +208,7 @@ func TestRecordPlayerAction(t *testing.T) {
 	var event models.GameEvent
 	err = database.Where("hand_id = ?", handID).First(&event).Error
 	assert.NoError(t, err)
-	assert.Equal(t, "player_action", event.EventType)
+	assert.Equal(t, models.EventKindPlayerAction, event.EventType)
 	assert.Equal(t, "user-456", *event.UserID)
 	assert.Equal(t, "preflop", *event.BettingRound)
 	assert.Equal(t, "raise", *event.ActionType)
@@ -243,7 +243,7 @@ func TestRecordRoundAdvanced(t *testing.T) {
 	var event models.GameEvent
 	err = database.Where("hand_id = ?", handID).First(&event).Error
 	assert.NoError(t, err)
-	assert.Equal(t, "round_advanced", event.EventType)
+	assert.Equal(t, models.EventKindRoundAdvanced, event.EventType)
 	assert.Equal(t, "flop", *event.BettingRound)
 
 	// Verify metadata
@@ -288,7 +288,7 @@ func TestRecordHandComplete(t *testing.T) {
 	var event models.GameEvent
 	err = database.Where("hand_id = ?", handID).First(&event).Error
 	assert.NoError(t, err)
-	assert.Equal(t, "hand_complete", event.EventType)
+	assert.Equal(t, models.EventKindHandComplete, event.EventType)
 	assert.Equal(t, 500, event.Amount)
 
 	// Verify metadata
@@ -0,0 +1,403 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	recordKindGameEvent  = "game_event"
+	recordKindHandAction = "hand_action"
+)
+
+// BatchWriterConfig holds configuration for the async history writer
+type BatchWriterConfig struct {
+	StreamKey     string        // Redis stream used as the durable write queue
+	GroupName     string        // Consumer group reading the stream
+	BatchSize     int           // Maximum records per DB insert
+	FlushInterval time.Duration // Maximum time a record waits before being flushed
+	ReclaimIdle   time.Duration // Pending entries idle longer than this are redelivered
+	BufferSize    int           // In-memory buffer size when Redis is unavailable
+}
+
+// DefaultBatchWriterConfig provides sensible defaults for history batching
+var DefaultBatchWriterConfig = BatchWriterConfig{
+	StreamKey:     "history:writes",
+	GroupName:     "history-writers",
+	BatchSize:     200,
+	FlushInterval: 500 * time.Millisecond,
+	ReclaimIdle:   30 * time.Second,
+	BufferSize:    10000,
+}
+
+// writeRecord is a single queued history row
+type writeRecord struct {
+	Kind     string          `json:"kind"`
+	Data     json.RawMessage `json:"data"`
+	streamID string
+}
+
+// BatchWriter persists history rows asynchronously in batches.
+// When a Redis client is configured, records are appended to a Redis stream
+// and only acknowledged after the DB insert succeeds, giving at-least-once
+// delivery across restarts. Without Redis, records are buffered in memory.
+type BatchWriter struct {
+	db       *db.DB
+	redis    *redis.Client
+	config   BatchWriterConfig
+	consumer string
+	buffer   chan writeRecord
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewBatchWriter creates a new async history writer; redisClient may be nil
+func NewBatchWriter(database *db.DB, redisClient *redis.Client, config BatchWriterConfig) *BatchWriter {
+	consumer, err := os.Hostname()
+	if err != nil || consumer == "" {
+		consumer = uuid.New().String()
+	}
+
+	return &BatchWriter{
+		db:       database,
+		redis:    redisClient,
+		config:   config,
+		consumer: consumer,
+		buffer:   make(chan writeRecord, config.BufferSize),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the background flush loop
+func (w *BatchWriter) Start() {
+	if w.redis != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := w.redis.XGroupCreateMkStream(ctx, w.config.StreamKey, w.config.GroupName, "0").Err()
+		cancel()
+		if err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+			log.Printf("[HISTORY_WRITER] Failed to create consumer group, falling back to memory buffer: %v", err)
+			w.redis = nil
+		}
+	}
+
+	w.wg.Add(1)
+	if w.redis != nil {
+		go w.streamLoop()
+	} else {
+		go w.memoryLoop()
+	}
+
+	log.Printf("[HISTORY_WRITER] Started (batch_size=%d flush_interval=%v redis=%v)",
+		w.config.BatchSize, w.config.FlushInterval, w.redis != nil)
+}
+
+// Stop stops the flush loop after writing any buffered records
+func (w *BatchWriter) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopChan)
+		w.wg.Wait()
+		log.Println("[HISTORY_WRITER] Stopped")
+	})
+}
+
+// EnqueueGameEvent queues a game_events row for persistence
+func (w *BatchWriter) EnqueueGameEvent(event models.GameEvent) error {
+	return w.enqueue(recordKindGameEvent, event)
+}
+
+// EnqueueHandAction queues a hand_actions row for persistence
+func (w *BatchWriter) EnqueueHandAction(action models.HandAction) error {
+	return w.enqueue(recordKindHandAction, action)
+}
+
+func (w *BatchWriter) enqueue(kind string, row interface{}) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", kind, err)
+	}
+	record := writeRecord{Kind: kind, Data: data}
+
+	if w.redis != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		err := w.redis.XAdd(ctx, &redis.XAddArgs{
+			Stream: w.config.StreamKey,
+			Values: map[string]interface{}{"kind": kind, "data": string(data)},
+		}).Err()
+		if err == nil {
+			return nil
+		}
+		log.Printf("[HISTORY_WRITER] Redis XADD failed, writing %s directly: %v", kind, err)
+		return w.insert([]writeRecord{record})
+	}
+
+	select {
+	case w.buffer <- record:
+		return nil
+	default:
+		// CRITICAL: Never drop history - write through when the buffer is full
+		log.Printf("[HISTORY_WRITER] Buffer full, writing %s directly", kind)
+		return w.insert([]writeRecord{record})
+	}
+}
+
+// memoryLoop drains the in-memory buffer in batches
+func (w *BatchWriter) memoryLoop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]writeRecord, 0, w.config.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := w.insert(batch); err != nil {
+			log.Printf("[HISTORY_WRITER] ERROR: Failed to flush %d records, will retry: %v", len(batch), err)
+			return
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case record := <-w.buffer:
+			batch = append(batch, record)
+			if len(batch) >= w.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-w.stopChan:
+			for {
+				select {
+				case record := <-w.buffer:
+					batch = append(batch, record)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// streamLoop consumes the Redis stream, inserting and acknowledging batches
+func (w *BatchWriter) streamLoop() {
+	defer w.wg.Done()
+
+	reclaimTicker := time.NewTicker(w.config.ReclaimIdle)
+	defer reclaimTicker.Stop()
+
+	// Pick up entries left pending by a previous run before reading new ones
+	w.reclaimPending()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-reclaimTicker.C:
+			w.reclaimPending()
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), w.config.FlushInterval+time.Second)
+		streams, err := w.redis.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    w.config.GroupName,
+			Consumer: w.consumer,
+			Streams:  []string{w.config.StreamKey, ">"},
+			Count:    int64(w.config.BatchSize),
+			Block:    w.config.FlushInterval,
+		}).Result()
+		cancel()
+
+		if err != nil {
+			if err != redis.Nil {
+				log.Printf("[HISTORY_WRITER] XREADGROUP failed: %v", err)
+				time.Sleep(w.config.FlushInterval)
+			}
+			continue
+		}
+
+		for _, stream := range streams {
+			w.processMessages(stream.Messages)
+		}
+	}
+}
+
+// reclaimPending claims entries that were delivered but never acknowledged
+func (w *BatchWriter) reclaimPending() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := "0-0"
+	for {
+		messages, next, err := w.redis.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   w.config.StreamKey,
+			Group:    w.config.GroupName,
+			Consumer: w.consumer,
+			MinIdle:  w.config.ReclaimIdle,
+			Start:    start,
+			Count:    int64(w.config.BatchSize),
+		}).Result()
+		if err != nil {
+			if err != redis.Nil {
+				log.Printf("[HISTORY_WRITER] XAUTOCLAIM failed: %v", err)
+			}
+			return
+		}
+
+		if len(messages) > 0 {
+			log.Printf("[HISTORY_WRITER] Reclaimed %d pending records", len(messages))
+			w.processMessages(messages)
+		}
+
+		if next == "0-0" || len(messages) == 0 {
+			return
+		}
+		start = next
+	}
+}
+
+// processMessages writes a batch of stream entries and acknowledges them on success
+func (w *BatchWriter) processMessages(messages []redis.XMessage) {
+	if len(messages) == 0 {
+		return
+	}
+
+	records := make([]writeRecord, 0, len(messages))
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+
+		kind, _ := msg.Values["kind"].(string)
+		data, _ := msg.Values["data"].(string)
+		if kind == "" || data == "" {
+			log.Printf("[HISTORY_WRITER] Dropping malformed stream entry %s", msg.ID)
+			continue
+		}
+		records = append(records, writeRecord{Kind: kind, Data: json.RawMessage(data), streamID: msg.ID})
+	}
+
+	if err := w.insert(records); err != nil {
+		// Leave entries pending; they are redelivered by reclaimPending
+		log.Printf("[HISTORY_WRITER] ERROR: Failed to persist %d records, leaving pending: %v", len(records), err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := w.redis.XAck(ctx, w.config.StreamKey, w.config.GroupName, ids...).Err(); err != nil {
+		log.Printf("[HISTORY_WRITER] XACK failed for %d records: %v", len(ids), err)
+		return
+	}
+	w.redis.XDel(ctx, w.config.StreamKey, ids...)
+}
+
+// insert decodes records and writes them with one batched insert per table,
+// all in one transaction. Records are delivered at least once, so a row whose
+// uid is already stored is skipped rather than failing the batch.
+func (w *BatchWriter) insert(records []writeRecord) error {
+	var events []models.GameEvent
+	var actions []models.HandAction
+
+	for _, record := range records {
+		switch record.Kind {
+		case recordKindGameEvent:
+			var event models.GameEvent
+			if err := json.Unmarshal(record.Data, &event); err != nil {
+				log.Printf("[HISTORY_WRITER] Dropping undecodable game event: %v", err)
+				continue
+			}
+			events = append(events, event)
+		case recordKindHandAction:
+			var action models.HandAction
+			if err := json.Unmarshal(record.Data, &action); err != nil {
+				log.Printf("[HISTORY_WRITER] Dropping undecodable hand action: %v", err)
+				continue
+			}
+			actions = append(actions, action)
+		default:
+			log.Printf("[HISTORY_WRITER] Dropping record with unknown kind %q", record.Kind)
+		}
+	}
+
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		skipStored := clause.OnConflict{Columns: []clause.Column{{Name: "uid"}}, DoNothing: true}
+		if len(events) > 0 {
+			if err := tx.Clauses(skipStored).CreateInBatches(events, w.config.BatchSize).Error; err != nil {
+				return fmt.Errorf("failed to insert game events: %w", err)
+			}
+		}
+		if len(actions) > 0 {
+			if err := tx.Clauses(skipStored).CreateInBatches(actions, w.config.BatchSize).Error; err != nil {
+				return fmt.Errorf("failed to insert hand actions: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// PendingRecord is a queued history row that has not reached the database yet
+type PendingRecord struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// PendingRecords returns the rows still queued in the Redis stream, oldest
+// first, without consuming them. The in-memory buffer is not durable and is
+// not included.
+func (w *BatchWriter) PendingRecords(ctx context.Context) ([]PendingRecord, error) {
+	if w.redis == nil {
+		return []PendingRecord{}, nil
+	}
+
+	messages, err := w.redis.XRange(ctx, w.config.StreamKey, "-", "+").Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to read history stream: %w", err)
+	}
+
+	records := make([]PendingRecord, 0, len(messages))
+	for _, msg := range messages {
+		kind, _ := msg.Values["kind"].(string)
+		data, _ := msg.Values["data"].(string)
+		if kind == "" || data == "" {
+			continue
+		}
+		records = append(records, PendingRecord{Kind: kind, Data: json.RawMessage(data)})
+	}
+	return records, nil
+}
+
+// Replay queues rows exported from another instance's history stream.
+// A row the other instance had already written is skipped.
+func (w *BatchWriter) Replay(records []PendingRecord) (int, error) {
+	replayed := 0
+	for _, record := range records {
+		if record.Kind != recordKindGameEvent && record.Kind != recordKindHandAction {
+			return replayed, fmt.Errorf("unknown history record kind %q", record.Kind)
+		}
+		if err := w.enqueue(record.Kind, record.Data); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
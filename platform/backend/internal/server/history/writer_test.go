@@ -0,0 +1,109 @@
+package history
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/ids"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupWriterDB creates game_events but not hand_actions, so a batch with a
+// hand action fails after its events were inserted
+func setupWriterDB(t *testing.T) *gorm.DB {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	// Every connection to :memory: is a database of its own
+	sqlDB, err := gormDB.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	testutil.Schema(t, gormDB, &models.GameEvent{})
+	return gormDB
+}
+
+func writerRecords(t *testing.T) []PendingRecord {
+	event, err := json.Marshal(models.GameEvent{
+		UID: ids.New(), HandID: 1, TableID: "table-1", EventType: models.EventKindPlayerAction, SequenceNumber: 1,
+	})
+	require.NoError(t, err)
+	action, err := json.Marshal(models.HandAction{UID: ids.New(), HandID: 1, UserID: "alice", ActionType: "call", BettingRound: "preflop"})
+	require.NoError(t, err)
+	return []PendingRecord{
+		{Kind: recordKindGameEvent, Data: event},
+		{Kind: recordKindHandAction, Data: action},
+	}
+}
+
+func countRows(t *testing.T, gormDB *gorm.DB, model interface{}) int64 {
+	var count int64
+	require.NoError(t, gormDB.Model(model).Count(&count).Error)
+	return count
+}
+
+func TestBatchWriter_StreamRedeliveryIsIdempotent(t *testing.T) {
+	gormDB := setupWriterDB(t)
+	// Acknowledgements fail, so every batch stays pending and is redelivered
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+	writer := NewBatchWriter(&db.DB{DB: gormDB}, client, DefaultBatchWriterConfig)
+
+	records := writerRecords(t)
+	messages := make([]redis.XMessage, len(records))
+	for i, record := range records {
+		messages[i] = redis.XMessage{
+			ID:     []string{"1-0", "1-1"}[i],
+			Values: map[string]interface{}{"kind": record.Kind, "data": string(record.Data)},
+		}
+	}
+
+	// The hand action fails, and the event inserted before it is rolled back
+	writer.processMessages(messages)
+	assert.Equal(t, int64(0), countRows(t, gormDB, &models.GameEvent{}))
+
+	testutil.Schema(t, gormDB, &models.HandAction{})
+	writer.processMessages(messages)
+	assert.Equal(t, int64(1), countRows(t, gormDB, &models.GameEvent{}))
+	assert.Equal(t, int64(1), countRows(t, gormDB, &models.HandAction{}))
+
+	// Redelivered rows that were already written are skipped
+	writer.processMessages(messages)
+	assert.Equal(t, int64(1), countRows(t, gormDB, &models.GameEvent{}))
+	assert.Equal(t, int64(1), countRows(t, gormDB, &models.HandAction{}))
+}
+
+func TestBatchWriter_MemoryRetryIsIdempotent(t *testing.T) {
+	gormDB := setupWriterDB(t)
+	config := DefaultBatchWriterConfig
+	config.FlushInterval = 10 * time.Millisecond
+	writer := NewBatchWriter(&db.DB{DB: gormDB}, nil, config)
+	writer.Start()
+	defer writer.Stop()
+
+	records := writerRecords(t)
+	replayed, err := writer.Replay(records)
+	require.NoError(t, err)
+	require.Equal(t, 2, replayed)
+
+	// Failed flushes are retried without leaving their events behind
+	assert.Never(t, func() bool {
+		return countRows(t, gormDB, &models.GameEvent{}) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	// The rows are queued again while the failed batch is still held
+	_, err = writer.Replay(records)
+	require.NoError(t, err)
+	testutil.Schema(t, gormDB, &models.HandAction{})
+	writer.Stop()
+
+	assert.Equal(t, int64(1), countRows(t, gormDB, &models.GameEvent{}))
+	assert.Equal(t, int64(1), countRows(t, gormDB, &models.HandAction{}))
+}
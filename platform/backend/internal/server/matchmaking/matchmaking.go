@@ -1,15 +1,18 @@
 package matchmaking
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 
 	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
 	"poker-platform/backend/internal/models"
 	"poker-platform/backend/internal/server/game"
 
@@ -34,6 +37,26 @@ func getMatchmakingCountdown() time.Duration {
 	return time.Duration(seconds) * time.Second
 }
 
+// getBotFill returns how long a player waits in a matchmaking queue before
+// bots take the seats nobody came for, from MATCHMAKING_BOT_FILL_SECONDS,
+// and the bots' difficulty, from MATCHMAKING_BOT_DIFFICULTY. A wait of 0, the
+// default, never fills a queue with bots.
+func getBotFill() (time.Duration, string) {
+	difficulty := os.Getenv("MATCHMAKING_BOT_DIFFICULTY")
+	secondsStr := os.Getenv("MATCHMAKING_BOT_FILL_SECONDS")
+	if secondsStr == "" {
+		return 0, difficulty
+	}
+
+	seconds, err := strconv.Atoi(secondsStr)
+	if err != nil || seconds < 0 {
+		log.Printf("Invalid MATCHMAKING_BOT_FILL_SECONDS value: %s, not filling with bots", secondsStr)
+		return 0, difficulty
+	}
+
+	return time.Duration(seconds) * time.Second, difficulty
+}
+
 // MatchmakingQueueEntry represents an entry in the matchmaking queue
 type MatchmakingQueueEntry struct {
 	UserID   string
@@ -46,18 +69,39 @@ func HandleJoinMatchmaking(
 	c *gin.Context,
 	database *db.DB,
 	bridge *game.GameBridge,
+	checkLimitsFunc func(userID string) error,
 	processFunc func(string),
 ) {
 	userID := c.GetString("user_id")
 
+	if err := checkLimitsFunc(userID); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req struct {
-		GameMode string `json:"game_mode"` // "headsup" or "3player"
+		GameMode   string `json:"game_mode"`   // preset key, e.g. "headsup", "6max"
+		MaxPlayers int    `json:"max_players"` // alternative to game_mode: pick preset by seat count
+		BuyIn      int    `json:"buy_in"`      // optional, defaults to the preset's starting stack
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		req.GameMode = "headsup" // default
 	}
 
+	// Allow choosing a table size instead of a named preset
+	if req.GameMode == "" && req.MaxPlayers > 0 {
+		gameMode, ok := game.PresetForSeats(req.MaxPlayers)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "No matchmaking preset for that seat count"})
+			return
+		}
+		req.GameMode = gameMode
+	}
+	if req.GameMode == "" {
+		req.GameMode = "headsup"
+	}
+
 	// Validate game mode
 	preset, ok := game.TablePresets[req.GameMode]
 	if !ok {
@@ -65,6 +109,22 @@ func HandleJoinMatchmaking(
 		return
 	}
 
+	buyIn, err := preset.ResolveBuyIn(req.BuyIn)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var user models.User
+	if err := database.Where("id = ?", userID).First(&user).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+	if user.Chips < buyIn {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient chips"})
+		return
+	}
+
 	// Check if user is already in queue
 	var existingCount int64
 	database.Model(&models.MatchmakingEntry{}).Where("user_id = ? AND status = ?", userID, "waiting").Count(&existingCount)
@@ -81,6 +141,7 @@ func HandleJoinMatchmaking(
 		Status:    "waiting",
 		MinBuyIn:  &preset.MinBuyIn,
 		MaxBuyIn:  &preset.MaxBuyIn,
+		BuyIn:     &buyIn,
 	}
 
 	if err := database.Create(&entry).Error; err != nil {
@@ -99,14 +160,57 @@ func HandleJoinMatchmaking(
 	// Process matchmaking if we have enough players
 	go processFunc(req.GameMode)
 
+	// If the player is still first in the queue once the wait is over, bots
+	// fill the rest of the table
+	if wait, difficulty := getBotFill(); wait > 0 && bridge.Bots != nil {
+		go func() {
+			time.Sleep(wait)
+			FillWithBots(req.GameMode, userID, difficulty, bridge, processFunc)
+		}()
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"status":     "queued",
 		"game_mode":  req.GameMode,
 		"queue_size": queueSize,
 		"required":   preset.MaxPlayers,
+		"buy_in":     buyIn,
 	})
 }
 
+// HandleGetMatchmakingPresets returns the available matchmaking presets ordered by seat count
+func HandleGetMatchmakingPresets(c *gin.Context) {
+	type PresetResult struct {
+		GameMode     string `json:"game_mode"`
+		Name         string `json:"name"`
+		MaxPlayers   int    `json:"max_players"`
+		SmallBlind   int    `json:"small_blind"`
+		BigBlind     int    `json:"big_blind"`
+		MinBuyIn     int    `json:"min_buy_in"`
+		MaxBuyIn     int    `json:"max_buy_in"`
+		DefaultBuyIn int    `json:"default_buy_in"`
+	}
+
+	results := make([]PresetResult, 0, len(game.TablePresets))
+	for key, preset := range game.TablePresets {
+		results = append(results, PresetResult{
+			GameMode:     key,
+			Name:         preset.Name,
+			MaxPlayers:   preset.MaxPlayers,
+			SmallBlind:   preset.SmallBlind,
+			BigBlind:     preset.BigBlind,
+			MinBuyIn:     preset.MinBuyIn,
+			MaxBuyIn:     preset.MaxBuyIn,
+			DefaultBuyIn: preset.DefaultBuyIn,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].MaxPlayers < results[j].MaxPlayers
+	})
+
+	c.JSON(http.StatusOK, results)
+}
+
 // HandleMatchmakingStatus returns the current matchmaking status for a user
 func HandleMatchmakingStatus(c *gin.Context, database *db.DB, bridge *game.GameBridge) {
 	userID := c.GetString("user_id")
@@ -179,6 +283,36 @@ func HandleLeaveMatchmaking(c *gin.Context, database *db.DB, bridge *game.GameBr
 	c.JSON(http.StatusOK, gin.H{"status": "left"})
 }
 
+// FillWithBots queues bots for the seats a matchmaking queue is short of, if
+// the player who has waited longest in it is userID, and makes the match
+func FillWithBots(gameMode, userID, difficulty string, bridge *game.GameBridge, processFunc func(string)) {
+	preset, ok := game.TablePresets[gameMode]
+	if !ok || bridge.Bots == nil {
+		return
+	}
+
+	bridge.MatchmakingMu.Lock()
+	queue := bridge.MatchmakingQueue[gameMode]
+	if len(queue) == 0 || queue[0] != userID || len(queue) >= preset.MaxPlayers {
+		bridge.MatchmakingMu.Unlock()
+		return
+	}
+	for short := preset.MaxPlayers - len(queue); short > 0; short-- {
+		botID, err := bridge.Bots.Service.Queue(context.Background(), gameMode, difficulty,
+			preset.DefaultBuyIn, preset.MinBuyIn, preset.MaxBuyIn)
+		if err != nil {
+			log.Printf("Failed to queue a bot for %s: %v", gameMode, err)
+			break
+		}
+		bridge.MatchmakingQueue[gameMode] = append(bridge.MatchmakingQueue[gameMode], botID)
+	}
+	queueSize := len(bridge.MatchmakingQueue[gameMode])
+	bridge.MatchmakingMu.Unlock()
+
+	log.Printf("Bots filled %s matchmaking queue for %s. Queue size: %d/%d", gameMode, userID, queueSize, preset.MaxPlayers)
+	processFunc(gameMode)
+}
+
 // ProcessMatchmaking attempts to create a match from the queue
 func ProcessMatchmaking(
 	gameMode string,
@@ -216,6 +350,7 @@ func ProcessMatchmaking(
 	type QueuedPlayer struct {
 		UserID   string
 		Username string
+		BuyIn    int
 	}
 	var players []QueuedPlayer
 
@@ -225,9 +360,22 @@ func ProcessMatchmaking(
 			log.Printf("Failed to get user info for %s: %v", userID, err)
 			continue
 		}
+
+		// Use the buy-in chosen when joining the queue
+		buyIn := preset.DefaultBuyIn
+		var entry models.MatchmakingEntry
+		if err := database.Where("user_id = ? AND status = ?", userID, "waiting").
+			Order("created_at DESC").
+			First(&entry).Error; err == nil && entry.BuyIn != nil {
+			if resolved, err := preset.ResolveBuyIn(*entry.BuyIn); err == nil {
+				buyIn = resolved
+			}
+		}
+
 		players = append(players, QueuedPlayer{
 			UserID:   user.ID,
 			Username: user.Username,
+			BuyIn:    buyIn,
 		})
 	}
 
@@ -266,8 +414,8 @@ func ProcessMatchmaking(
 	createTableFunc(tableID, "cash", preset.SmallBlind, preset.BigBlind, preset.MaxPlayers, preset.MinBuyIn, preset.MaxBuyIn)
 
 	// Add players to table
-	buyIn := preset.MinBuyIn
 	for i, player := range players {
+		buyIn := player.BuyIn
 		// CRITICAL: Use transaction to ensure atomic operations
 		// If chip deduction fails, seat creation is rolled back
 		// If seat creation fails, chip deduction is rolled back
@@ -277,6 +425,7 @@ func ProcessMatchmaking(
 				UserID:     player.UserID,
 				SeatNumber: i,
 				Chips:      buyIn,
+				BoughtIn:   buyIn,
 				Status:     "active",
 			}
 			if err := tx.Create(&seat).Error; err != nil {
@@ -294,10 +443,15 @@ func ProcessMatchmaking(
 			}
 
 			// Deduct chips from user (atomic with seat creation)
-			if err := tx.Model(&models.User{}).
-				Where("id = ?", player.UserID).
-				UpdateColumn("chips", tx.Raw("chips - ?", buyIn)).Error; err != nil {
-				return fmt.Errorf("failed to deduct chips: %w", err)
+			// The balance is checked again because it may have changed while queued
+			result := tx.Model(&models.User{}).
+				Where("id = ? AND chips >= ?", player.UserID, buyIn).
+				UpdateColumn("chips", gorm.Expr("chips - ?", buyIn))
+			if result.Error != nil {
+				return fmt.Errorf("failed to deduct chips: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("insufficient chips for buy-in of %d", buyIn)
 			}
 
 			return nil
@@ -328,36 +482,22 @@ func ProcessMatchmaking(
 
 // SendMatchFoundMessage sends a match found notification via WebSocket
 func SendMatchFoundMessage(bridge *game.GameBridge, userID, tableID, gameMode string) {
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	client, ok := bridge.Clients[userID]
-	if !ok {
+	if !bridge.Clients.Connected(userID) {
 		return
 	}
 
-	// Type assertion to get Send channel
-	type Sender interface {
-		GetSendChannel() chan []byte
-	}
-
-	if sender, ok := client.(Sender); ok {
-		// Calculate game start deadline using configured countdown duration
-		countdownDuration := getMatchmakingCountdown()
-		startDeadline := time.Now().Add(countdownDuration)
-
-		msg := map[string]interface{}{
-			"type": "match_found",
-			"payload": map[string]interface{}{
-				"table_id":        tableID,
-				"game_mode":       gameMode,
-				"start_deadline":  startDeadline.Format(time.RFC3339),
-			},
-		}
-		data, _ := json.Marshal(msg)
-		select {
-		case sender.GetSendChannel() <- data:
-		default:
-		}
+	// Calculate game start deadline using configured countdown duration
+	countdownDuration := getMatchmakingCountdown()
+	startDeadline := time.Now().Add(countdownDuration)
+
+	msg := map[string]interface{}{
+		"type": "match_found",
+		"payload": eventschema.MatchFoundPayload{
+			TableID:       tableID,
+			GameMode:      gameMode,
+			StartDeadline: startDeadline.Format(time.RFC3339),
+		},
 	}
+	data, _ := json.Marshal(msg)
+	bridge.SendToUser(userID, data)
 }
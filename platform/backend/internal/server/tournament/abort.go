@@ -0,0 +1,83 @@
+package tournament
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/tournament"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondAbortError maps tournament abort errors to HTTP responses
+func respondAbortError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, tournament.ErrTournamentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, tournament.ErrCannotAbort):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, tournament.ErrInvalidRefundMethod), errors.Is(err, tournament.ErrTooManyPlayersForICM),
+		errors.Is(err, tournament.ErrInvalidPrizeStructure):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// HandlePreviewTournamentAbort returns the refunds aborting a tournament would
+// pay, so an admin can compare refund methods before committing to one
+func HandlePreviewTournamentAbort(c *gin.Context, tournamentService *tournament.Service) {
+	method := c.DefaultQuery("method", tournament.RefundMethodChipChop)
+
+	plan, err := tournamentService.PreviewAbort(c.Param("id"), method)
+	if err != nil {
+		respondAbortError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// HandleAbortTournament aborts a tournament in progress, for example after a
+// prolonged outage, refunding the prize pool with the chosen method
+func HandleAbortTournament(c *gin.Context, tournamentService *tournament.Service, database *db.DB, bridge *game.GameBridge) {
+	userID := c.GetString("user_id")
+	tournamentID := c.Param("id")
+
+	var req struct {
+		Method string `json:"method" binding:"required"`
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" || len(req.Reason) > 500 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason must be between 1 and 500 characters"})
+		return
+	}
+
+	plan, err := tournamentService.AbortTournament(tournamentID, req.Method, userID, req.Reason)
+	if err != nil {
+		log.Printf("[ADMIN_AUDIT] Rejected abort of tournament %s by %s: %v", tournamentID, userID, err)
+		respondAbortError(c, err)
+		return
+	}
+
+	paid := 0
+	for _, refund := range plan.Refunds {
+		paid += refund.Amount
+	}
+	log.Printf("[ADMIN_AUDIT] Tournament %s aborted by %s with %s refunds: %d chips to %d players, reason: %q",
+		tournamentID, userID, plan.Method, paid, len(plan.Refunds), req.Reason)
+
+	stopTournamentTables(tournamentID, database, bridge)
+	go BroadcastTournamentUpdate(tournamentID, tournamentService, bridge)
+
+	c.JSON(http.StatusOK, plan)
+}
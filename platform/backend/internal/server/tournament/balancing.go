@@ -0,0 +1,132 @@
+package tournament
+
+import (
+	"encoding/json"
+	"log"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/tournament"
+
+	pokerModels "poker-engine/models"
+)
+
+// BalanceAfterHand keeps a tournament's tables within one player of each other.
+// It runs whenever a hand ends at tableID and moves the player due to post the
+// next big blind to the smallest table. Only tableID is known to be between
+// hands, so a move is made only away from it; a larger table is balanced when
+// its own hand ends.
+func BalanceAfterHand(tableID string, database *db.DB, bridge *game.GameBridge, broadcastFunc func(string)) {
+	var dbTable models.Table
+	if err := database.Where("id = ?", tableID).First(&dbTable).Error; err != nil || dbTable.TournamentID == nil {
+		return
+	}
+	// A bracket's matches stay heads-up
+	if tournament.IsBracket(database.DB, *dbTable.TournamentID) {
+		return
+	}
+
+	var tables []models.Table
+	if err := database.Where("tournament_id = ? AND status != ?", *dbTable.TournamentID, "completed").
+		Find(&tables).Error; err != nil {
+		log.Printf("[BALANCE] Error loading tables for tournament %s: %v", *dbTable.TournamentID, err)
+		return
+	}
+	if len(tables) < 2 {
+		return
+	}
+
+	counts := make(map[string]int, len(tables))
+	for _, t := range tables {
+		engineTable, exists := bridge.GetTable(t.ID)
+		if !exists {
+			continue
+		}
+		for _, p := range engineTable.Snapshot().Players {
+			if p != nil && p.Chips > 0 {
+				counts[t.ID]++
+			}
+		}
+	}
+
+	fromID, toID, ok := tournament.BalanceMove(counts, tableID)
+	if !ok || fromID != tableID {
+		return
+	}
+	source, _ := bridge.GetTable(fromID)
+	target, _ := bridge.GetTable(toID)
+
+	playerID, ok := source.NextBigBlind()
+	if !ok {
+		return
+	}
+	player, err := source.UnseatPlayer(playerID)
+	if err != nil {
+		log.Printf("[BALANCE] Cannot unseat %s from table %s: %v", playerID, fromID, err)
+		return
+	}
+	seat, err := target.SeatPlayer(player.PlayerID, player.PlayerName, player.Chips)
+	if err != nil {
+		log.Printf("[BALANCE] Cannot seat %s at table %s, returning them to %s: %v", playerID, toID, fromID, err)
+		if _, err := source.SeatPlayer(player.PlayerID, player.PlayerName, player.Chips); err != nil {
+			log.Printf("[BALANCE] ERROR: Failed to return %s to table %s: %v", playerID, fromID, err)
+		}
+		return
+	}
+	if bridge.Frozen.IsFrozen(playerID) {
+		target.SetPlayerFrozen(playerID, true)
+	}
+	target.SetPlayerLevel(playerID, player.Level)
+	target.SetPlayerAvatar(playerID, player.AvatarURL)
+
+	if err := database.Model(&models.TableSeat{}).
+		Where("table_id = ? AND user_id = ? AND left_at IS NULL", fromID, playerID).
+		Updates(map[string]interface{}{
+			"table_id":    toID,
+			"seat_number": seat,
+			"chips":       player.Chips,
+		}).Error; err != nil {
+		log.Printf("[BALANCE] Error moving seat of %s to table %s: %v", playerID, toID, err)
+	}
+
+	log.Printf("[BALANCE] Tournament %s: moved %s (%d chips) from table %s (%d players) to table %s seat %d (%d players)",
+		*dbTable.TournamentID, playerID, player.Chips, fromID, counts[fromID], toID, seat, counts[toID])
+
+	SendPlayerMovedMessage(bridge, *dbTable.TournamentID, playerID, fromID, toID, seat, player.Chips)
+
+	// A table that stalled short-handed needs its next hand started
+	if target.Snapshot().Status == pokerModels.StatusWaiting {
+		if err := target.StartGame(); err != nil {
+			log.Printf("[BALANCE] Table %s not started after the move: %v", toID, err)
+		}
+	}
+
+	broadcastFunc(fromID)
+	broadcastFunc(toID)
+}
+
+// SendPlayerMovedMessage tells a moved player which table and seat to join
+func SendPlayerMovedMessage(bridge *game.GameBridge, tournamentID, playerID, fromTableID, toTableID string, seat, chips int) {
+	message := map[string]interface{}{
+		"type": "player_moved",
+		"payload": map[string]interface{}{
+			"tournament_id": tournamentID,
+			"user_id":       playerID,
+			"from_table_id": fromTableID,
+			"to_table_id":   toTableID,
+			"seat_number":   seat,
+			"chips":         chips,
+		},
+	}
+
+	msgData, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("[BALANCE] Error marshaling player moved message: %v", err)
+		return
+	}
+
+	if bridge.SendToUser(playerID, msgData) == 0 && bridge.Clients.Connected(playerID) {
+		log.Printf("[BALANCE] WARNING: Send queue full for moved player %s", playerID)
+	}
+}
@@ -0,0 +1,58 @@
+package tournament
+
+import (
+	"encoding/json"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/tournament"
+)
+
+// BroadcastKnockout tells a bounty tournament's lobby who knocked out whom
+// and what the bounty paid
+func BroadcastKnockout(
+	knockout models.TournamentKnockout,
+	tournamentService *tournament.Service,
+	database *db.DB,
+	bridge *game.GameBridge,
+) {
+	tourney, err := tournamentService.GetTournament(knockout.TournamentID)
+	if err != nil {
+		return
+	}
+
+	names := map[string]string{
+		knockout.EliminatedID: knockout.EliminatedID,
+		knockout.EliminatorID: knockout.EliminatorID,
+	}
+	var users []models.User
+	if err := database.Select("id", "username").
+		Where("id IN ?", []string{knockout.EliminatedID, knockout.EliminatorID}).
+		Find(&users).Error; err == nil {
+		for _, user := range users {
+			names[user.ID] = user.Username
+		}
+	}
+
+	var eliminator models.TournamentPlayer
+	database.Where("tournament_id = ? AND user_id = ?", knockout.TournamentID, knockout.EliminatorID).First(&eliminator)
+
+	message := map[string]interface{}{
+		"type": "knockout",
+		"payload": map[string]interface{}{
+			"tournament_id":   knockout.TournamentID,
+			"eliminated_id":   knockout.EliminatedID,
+			"eliminated_name": names[knockout.EliminatedID],
+			"eliminator_id":   knockout.EliminatorID,
+			"eliminator_name": names[knockout.EliminatorID],
+			"cash":            knockout.Cash,
+			"added":           knockout.Added,
+			"bounty":          eliminator.Bounty,
+		},
+	}
+
+	data, _ := json.Marshal(message)
+
+	broadcastLobby(bridge, tournamentService, tourney, data)
+}
@@ -0,0 +1,88 @@
+package tournament
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/tournament"
+	"poker-platform/backend/internal/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BroadcastBracketUpdate sends a bracket tournament's matches to its lobby.
+// Other tournaments have no bracket and send nothing.
+func BroadcastBracketUpdate(
+	tournamentID string,
+	tournamentService *tournament.Service,
+	bracketManager *tournament.BracketManager,
+	bridge *game.GameBridge,
+) {
+	tourney, err := tournamentService.GetTournament(tournamentID)
+	if err != nil || tourney.Format != models.TournamentFormatBracket {
+		return
+	}
+	matches, err := bracketManager.GetBracket(tournamentID)
+	if err != nil {
+		log.Printf("[BRACKET] Error loading bracket of tournament %s: %v", tournamentID, err)
+		return
+	}
+
+	message := map[string]interface{}{
+		"type": "bracket_update",
+		"payload": map[string]interface{}{
+			"tournament_id": tournamentID,
+			"round":         bracketRound(matches),
+			"matches":       matches,
+		},
+	}
+
+	data, _ := json.Marshal(message)
+
+	broadcastLobby(bridge, tournamentService, tourney, data)
+}
+
+// bracketRound is the round being played, the last one drawn
+func bracketRound(matches []models.BracketMatch) int {
+	round := 0
+	for _, match := range matches {
+		if match.Round > round {
+			round = match.Round
+		}
+	}
+	return round
+}
+
+// HandleGetTournamentBracket returns the matches of a bracket tournament
+func HandleGetTournamentBracket(c *gin.Context, tournamentService *tournament.Service, bracketManager *tournament.BracketManager) {
+	tournamentID := c.Param("id")
+
+	if err := validation.ValidateUUID(tournamentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+		return
+	}
+
+	tourney, err := tournamentService.GetTournament(tournamentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		return
+	}
+	if err := tournamentService.CanView(tourney, c.GetString("user_id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		return
+	}
+	if tourney.Format != models.TournamentFormatBracket {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tournament is not a bracket"})
+		return
+	}
+
+	matches, err := bracketManager.GetBracket(tournamentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load bracket"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"round": bracketRound(matches), "matches": matches})
+}
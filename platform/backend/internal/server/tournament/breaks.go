@@ -0,0 +1,63 @@
+package tournament
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+)
+
+// holdTablesUntil stops the tables dealing new hands until resumesAt, then
+// releases them all together and calls released. Hands in play finish.
+func holdTablesUntil(bridge *game.GameBridge, tables []models.Table, resumesAt time.Time, released func()) {
+	for _, table := range tables {
+		bridge.HandHolds.Hold(table.ID)
+	}
+	time.AfterFunc(time.Until(resumesAt), func() {
+		for _, table := range tables {
+			bridge.HandHolds.Release(table.ID)
+		}
+		released()
+	})
+}
+
+// StartLevelBreak puts every table of a tournament on the break its structure
+// schedules after a level, telling the players when it starts and ends. The
+// blind manager has already moved the next level's clock past the break.
+func StartLevelBreak(tournamentID string, level int, resumesAt time.Time, database *db.DB, bridge *game.GameBridge) {
+	var tables []models.Table
+	if err := database.Where("tournament_id = ? AND status != ?", tournamentID, "completed").
+		Find(&tables).Error; err != nil {
+		log.Printf("[BREAK] Failed to load tables of tournament %s: %v", tournamentID, err)
+		return
+	}
+
+	holdTablesUntil(bridge, tables, resumesAt, func() {
+		log.Printf("[BREAK] Break after level %d over for %d tables of tournament %s", level, len(tables), tournamentID)
+		data, _ := json.Marshal(map[string]interface{}{
+			"type": "tournament_break_ended",
+			"payload": eventschema.TournamentBreakEndedPayload{
+				TournamentID: tournamentID,
+				Level:        level + 1,
+			},
+		})
+		sendToTournamentTables(bridge, tables, data)
+	})
+	log.Printf("[BREAK] Tournament %s: %d tables on break after level %d until %s",
+		tournamentID, len(tables), level, resumesAt.Format(time.RFC3339))
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "tournament_break_started",
+		"payload": eventschema.TournamentBreakStartedPayload{
+			TournamentID: tournamentID,
+			AfterLevel:   level,
+			Seconds:      int(time.Until(resumesAt).Round(time.Second) / time.Second),
+			ResumesAt:    resumesAt,
+		},
+	})
+	sendToTournamentTables(bridge, tables, data)
+}
@@ -0,0 +1,194 @@
+package tournament
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/tournament"
+
+	"github.com/gin-gonic/gin"
+)
+
+// broadcastHandPause is the extra gap between hands at a broadcast final
+// table, so production can keep up hand for hand
+const broadcastHandPause = 10 * time.Second
+
+// respondBroadcastError maps final table broadcast errors to HTTP responses
+func respondBroadcastError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, tournament.ErrTournamentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, tournament.ErrNotTournamentCreator):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, tournament.ErrNotBroadcast), errors.Is(err, tournament.ErrFinalTableNotReached),
+		errors.Is(err, tournament.ErrTournamentCompleted), errors.Is(err, tournament.ErrTournamentCancelled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, tournament.ErrInvalidBroadcastDelay):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// applyBroadcastDelay sets the spectator delay of a final table to the
+// tournament's broadcast delay
+func applyBroadcastDelay(table *models.Table, seconds int, database *db.DB, bridge *game.GameBridge) {
+	if table.SpectatorDelay == seconds {
+		return
+	}
+	if err := database.Model(table).Update("spectator_delay", seconds).Error; err != nil {
+		log.Printf("[BROADCAST] Failed to set spectator delay of table %s: %v", table.ID, err)
+		return
+	}
+	bridge.Spectators.SetDelay(table.ID, time.Duration(seconds)*time.Second)
+	if seconds == 0 {
+		bridge.HandHolds.Release(table.ID)
+	}
+	log.Printf("[BROADCAST] Final table %s broadcast delay set to %ds", table.ID, seconds)
+}
+
+// finalTableBroadcastDelay puts a tournament table into broadcast mode once it
+// is the final table of a broadcast tournament, and returns its delay. Tables
+// that aren't broadcast return 0.
+func finalTableBroadcastDelay(tableID string, database *db.DB, bridge *game.GameBridge, consolidator *tournament.Consolidator) time.Duration {
+	var table models.Table
+	if err := database.Where("id = ?", tableID).First(&table).Error; err != nil || table.TournamentID == nil {
+		return 0
+	}
+
+	var tourney models.Tournament
+	if err := database.Select("id", "broadcast_delay").Where("id = ?", *table.TournamentID).First(&tourney).Error; err != nil {
+		return 0
+	}
+	if tourney.BroadcastDelay <= 0 {
+		return 0
+	}
+	if isFinal, err := consolidator.IsFinalTable(tourney.ID); err != nil || !isFinal {
+		return 0
+	}
+
+	applyBroadcastDelay(&table, tourney.BroadcastDelay, database, bridge)
+	return time.Duration(tourney.BroadcastDelay) * time.Second
+}
+
+// CheckCardsUpAccess checks that a user may watch the cards-up production feed
+// of a table: the table must be a broadcast final table, the user must be its
+// tournament director and must not be playing at it
+func CheckCardsUpAccess(database *db.DB, bridge *game.GameBridge, tableID, userID string) error {
+	var table models.Table
+	if err := database.Where("id = ?", tableID).First(&table).Error; err != nil || table.TournamentID == nil {
+		return tournament.ErrNotBroadcast
+	}
+
+	var tourney models.Tournament
+	if err := database.Select("id", "creator_id").Where("id = ?", *table.TournamentID).First(&tourney).Error; err != nil {
+		return tournament.ErrTournamentNotFound
+	}
+	if tourney.CreatorID == nil || *tourney.CreatorID != userID {
+		return tournament.ErrNotTournamentCreator
+	}
+
+	// Hole cards are only ever shown behind the broadcast delay
+	if bridge.Spectators.Delay(tableID) <= 0 {
+		return tournament.ErrNotBroadcast
+	}
+	for _, seat := range bridge.LiveSeats(userID) {
+		if seat.TableID == tableID {
+			return tournament.ErrNotBroadcast
+		}
+	}
+	return nil
+}
+
+// HandleSetBroadcastDelay changes a tournament's broadcast delay. A delay
+// above zero runs the final table in broadcast mode: spectators are delayed,
+// the director gets a delayed cards-up feed and hands are dealt one at a time.
+func HandleSetBroadcastDelay(c *gin.Context, tournamentService *tournament.Service, database *db.DB, bridge *game.GameBridge) {
+	userID := c.GetString("user_id")
+	tournamentID := c.Param("id")
+
+	var req struct {
+		BroadcastDelay *int `json:"broadcast_delay" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	if _, err := tournamentService.SetBroadcastDelay(tournamentID, userID, *req.BroadcastDelay); err != nil {
+		respondBroadcastError(c, err)
+		return
+	}
+
+	// A final table already in play switches over straight away
+	if table, err := finalTableOf(tournamentID, database); err == nil {
+		applyBroadcastDelay(table, *req.BroadcastDelay, database, bridge)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tournament_id":   tournamentID,
+		"broadcast_delay": *req.BroadcastDelay,
+	})
+}
+
+// finalTableOf returns a tournament's only remaining table
+func finalTableOf(tournamentID string, database *db.DB) (*models.Table, error) {
+	var tables []models.Table
+	if err := database.Where("tournament_id = ? AND status != ?", tournamentID, "completed").Find(&tables).Error; err != nil {
+		return nil, err
+	}
+	if len(tables) != 1 {
+		return nil, tournament.ErrFinalTableNotReached
+	}
+	return &tables[0], nil
+}
+
+// HandleHoldFinalTable stops a broadcast final table from dealing its next
+// hand, so the director can pause between hands. The hand in play finishes.
+func HandleHoldFinalTable(c *gin.Context, tournamentService *tournament.Service, bridge *game.GameBridge) {
+	setFinalTableHold(c, tournamentService, bridge, true)
+}
+
+// HandleReleaseFinalTable lets a held broadcast final table deal again
+func HandleReleaseFinalTable(c *gin.Context, tournamentService *tournament.Service, bridge *game.GameBridge) {
+	setFinalTableHold(c, tournamentService, bridge, false)
+}
+
+func setFinalTableHold(c *gin.Context, tournamentService *tournament.Service, bridge *game.GameBridge, held bool) {
+	userID := c.GetString("user_id")
+	tournamentID := c.Param("id")
+
+	table, err := tournamentService.BroadcastFinalTable(tournamentID, userID)
+	if err != nil {
+		respondBroadcastError(c, err)
+		return
+	}
+
+	if held {
+		bridge.HandHolds.Hold(table.ID)
+	} else {
+		bridge.HandHolds.Release(table.ID)
+	}
+	log.Printf("[BROADCAST] Final table %s of tournament %s held=%v by %s", table.ID, tournamentID, held, userID)
+
+	message, _ := json.Marshal(map[string]interface{}{
+		"type": "final_table_hold",
+		"payload": map[string]interface{}{
+			"tournament_id": tournamentID,
+			"table_id":      table.ID,
+			"held":          held,
+		},
+	})
+	bridge.SendToTable(table.ID, message)
+
+	c.JSON(http.StatusOK, gin.H{
+		"table_id": table.ID,
+		"held":     held,
+	})
+}
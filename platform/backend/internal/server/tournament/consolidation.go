@@ -0,0 +1,79 @@
+package tournament
+
+import (
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/tournament"
+
+	"poker-engine/engine"
+	pokerModels "poker-engine/models"
+)
+
+// consolidationHandWait bounds how long a consolidation waits for the hands
+// in progress, so a stalled table can't hold up the tournament
+const consolidationHandWait = 2 * time.Minute
+
+// ScheduleConsolidation announces a consolidation plan and applies it once
+// its notice is up. The tournament's tables are then held between hands, and
+// the players move when every hand in progress has finished.
+func ScheduleConsolidation(
+	plan *tournament.ConsolidationPlan,
+	database *db.DB,
+	bridge *game.GameBridge,
+	consolidator *tournament.Consolidator,
+) {
+	broadcastConsolidation("consolidation_planned", plan, time.Until(plan.EffectiveAt), bridge)
+	time.Sleep(time.Until(plan.EffectiveAt))
+
+	var tableIDs []string
+	if err := database.Model(&models.Table{}).
+		Where("tournament_id = ? AND status != ?", plan.TournamentID, "completed").
+		Pluck("id", &tableIDs).Error; err != nil {
+		log.Printf("[CONSOLIDATION] Error loading tables of tournament %s: %v", plan.TournamentID, err)
+	}
+	var held []*engine.Table
+	for _, tableID := range tableIDs {
+		if table, exists := bridge.GetTable(tableID); exists {
+			table.HoldBetweenHands(true)
+			held = append(held, table)
+		}
+	}
+	if !waitForHands(held, consolidationHandWait) {
+		log.Printf("[CONSOLIDATION] WARNING: Tournament %s consolidating with hands still in progress", plan.TournamentID)
+	}
+
+	// The tables are reloaded once the players have moved, so the hold only
+	// needs lifting if they didn't
+	if err := consolidator.ApplyConsolidation(plan); err != nil {
+		log.Printf("[CONSOLIDATION] Error consolidating tables of tournament %s: %v", plan.TournamentID, err)
+		for _, table := range held {
+			table.HoldBetweenHands(false)
+		}
+	}
+}
+
+// waitForHands waits until none of the tables is in a hand, reporting false
+// if that took longer than maxWait
+func waitForHands(tables []*engine.Table, maxWait time.Duration) bool {
+	deadline := time.Now().Add(maxWait)
+	for {
+		inHand := false
+		for _, table := range tables {
+			if table.Snapshot().Status == pokerModels.StatusPlaying {
+				inHand = true
+				break
+			}
+		}
+		if !inHand {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
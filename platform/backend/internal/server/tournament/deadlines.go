@@ -0,0 +1,89 @@
+package tournament
+
+import (
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/tournament"
+)
+
+// EnforceTournamentDeadlines warns a tournament's lobby as its registration
+// close and end times approach, tells it when registration closes, and ends
+// tournaments that reach their end time
+func EnforceTournamentDeadlines(tournamentService *tournament.Service, database *db.DB, bridge *game.GameBridge, warner *game.DeadlineWarner, now time.Time) {
+	tournaments, err := tournamentService.ListScheduled()
+	if err != nil {
+		log.Printf("[TOURNAMENT] Failed to load tournaments with deadlines: %v", err)
+		return
+	}
+	defer warner.Prune()
+
+	for i := range tournaments {
+		tourney := &tournaments[i]
+
+		if tourney.RegistrationClosesAt != nil && tourney.Status == "registering" {
+			warn, passed := warner.Check(game.DeadlineRegistrationClose+":"+tourney.ID, *tourney.RegistrationClosesAt, now)
+			if warn {
+				broadcastLobby(bridge, tournamentService, tourney, game.DeadlineWarningMessage(eventschema.DeadlineWarningPayload{
+					Kind:         game.DeadlineRegistrationClose,
+					TournamentID: tourney.ID,
+					At:           *tourney.RegistrationClosesAt,
+					Message:      "Registration for " + tourney.Name + " closes soon.",
+				}, now))
+			}
+			if passed {
+				log.Printf("[TOURNAMENT] Registration for tournament %s closed", tourney.ID)
+				BroadcastTournamentUpdate(tourney.ID, tournamentService, bridge)
+			}
+		}
+
+		if tourney.EndsAt == nil {
+			continue
+		}
+		if warn, _ := warner.Check(game.DeadlineTournamentEnd+":"+tourney.ID, *tourney.EndsAt, now); warn {
+			broadcastLobby(bridge, tournamentService, tourney, game.DeadlineWarningMessage(eventschema.DeadlineWarningPayload{
+				Kind:         game.DeadlineTournamentEnd,
+				TournamentID: tourney.ID,
+				At:           *tourney.EndsAt,
+				Message:      tourney.Name + " ends soon. Players still in will share the prize pool by chip count.",
+			}, now))
+			continue
+		}
+		if tourney.EndsAt.After(now) {
+			continue
+		}
+
+		plan, err := tournamentService.EndTournament(tourney.ID)
+		if err != nil {
+			log.Printf("[TOURNAMENT] ERROR: Ending tournament %s at its end time: %v", tourney.ID, err)
+			continue
+		}
+		if plan != nil {
+			log.Printf("[TOURNAMENT] Tournament %s ended at its end time, pool of %d chopped between %d players",
+				tourney.ID, plan.PrizePool, len(plan.Refunds))
+			stopTournamentTables(tourney.ID, database, bridge)
+		} else {
+			log.Printf("[TOURNAMENT] Tournament %s cancelled at its end time before it started", tourney.ID)
+		}
+		BroadcastTournamentUpdate(tourney.ID, tournamentService, bridge)
+	}
+}
+
+// RunDeadlines enforces tournament registration close and end times until
+// stop is closed
+func RunDeadlines(tournamentService *tournament.Service, database *db.DB, bridge *game.GameBridge, stop <-chan struct{}) {
+	warner := game.NewDeadlineWarner()
+	ticker := time.NewTicker(game.DeadlineInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			EnforceTournamentDeadlines(tournamentService, database, bridge, warner, now)
+		case <-stop:
+			return
+		}
+	}
+}
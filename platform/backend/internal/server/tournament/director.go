@@ -0,0 +1,268 @@
+package tournament
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/server/websocket"
+	"poker-platform/backend/internal/tournament"
+	"poker-platform/backend/internal/validation"
+
+	pokerModels "poker-engine/models"
+)
+
+// maxAnnouncementLength bounds a director's announcement to the tables
+const maxAnnouncementLength = 500
+
+// HandleDirectorMessage runs a bulk operation a tournament director sent over
+// the WebSocket in a "director" message. The payload's "op" picks it:
+// "announce" (message), "break" (seconds), "add_time" (seconds) or
+// "progress". Every operation names a tournament_id the user created;
+// "progress" without one covers every running tournament they host.
+func HandleDirectorMessage(
+	c *websocket.Client,
+	payload interface{},
+	database *db.DB,
+	bridge *game.GameBridge,
+	tournamentService *tournament.Service,
+) {
+	fields, ok := payload.(map[string]interface{})
+	if !ok {
+		sendDirectorError(c, "Invalid message format", "INVALID_PAYLOAD")
+		return
+	}
+	op, _ := fields["op"].(string)
+	tournamentID, _ := fields["tournament_id"].(string)
+	seconds, _ := fields["seconds"].(float64)
+
+	if tournamentID == "" && op != "progress" {
+		sendDirectorError(c, "Missing tournament_id", "MISSING_TOURNAMENT_ID")
+		return
+	}
+
+	var result map[string]interface{}
+	var err error
+	switch op {
+	case "announce":
+		message, _ := fields["message"].(string)
+		result, err = directorAnnounce(c.UserID, tournamentID, message, bridge, tournamentService)
+	case "break":
+		result, err = directorBreak(c.UserID, tournamentID, int(seconds), bridge, tournamentService)
+	case "add_time":
+		result, err = directorAddTime(c.UserID, tournamentID, int(seconds), bridge, tournamentService)
+	case "progress":
+		result, err = directorProgress(c.UserID, tournamentID, bridge, tournamentService)
+	default:
+		sendDirectorError(c, "Unknown director operation", "INVALID_OPERATION")
+		return
+	}
+	if err != nil {
+		log.Printf("[DIRECTOR] %s by %s on tournament %s refused: %v", op, c.UserID, tournamentID, err)
+		sendDirectorError(c, err.Error(), directorErrorCode(err))
+		return
+	}
+
+	result["op"] = op
+	websocket.SendToClient(c, websocket.WSMessage{Type: "director_result", Payload: result})
+}
+
+func sendDirectorError(c *websocket.Client, message, code string) {
+	websocket.SendToClient(c, websocket.WSMessage{
+		Type: "error",
+		Payload: map[string]interface{}{
+			"message": message,
+			"code":    code,
+		},
+	})
+}
+
+// directorErrorCode maps director operation errors to WebSocket error codes
+func directorErrorCode(err error) string {
+	switch {
+	case errors.Is(err, tournament.ErrTournamentNotFound):
+		return "TOURNAMENT_NOT_FOUND"
+	case errors.Is(err, tournament.ErrNotTournamentCreator):
+		return "NOT_TOURNAMENT_DIRECTOR"
+	case errors.Is(err, tournament.ErrTournamentNotRunning), errors.Is(err, tournament.ErrTournamentCompleted),
+		errors.Is(err, tournament.ErrTournamentCancelled):
+		return "TOURNAMENT_NOT_RUNNING"
+	case errors.Is(err, tournament.ErrInvalidClockAdjustment), errors.Is(err, tournament.ErrInvalidBreakLength):
+		return "INVALID_SECONDS"
+	default:
+		return "DIRECTOR_FAILED"
+	}
+}
+
+// sendToTournamentTables sends a message to everyone at each table
+func sendToTournamentTables(bridge *game.GameBridge, tables []models.Table, data []byte) int {
+	sent := 0
+	for _, table := range tables {
+		sent += bridge.SendToTable(table.ID, data)
+	}
+	return sent
+}
+
+// directorAnnounce shows a message from the director at every table of the tournament
+func directorAnnounce(userID, tournamentID, message string, bridge *game.GameBridge, tournamentService *tournament.Service) (map[string]interface{}, error) {
+	message, err := validation.ValidateSafeString(message, 1, maxAnnouncementLength, "message")
+	if err != nil {
+		return nil, err
+	}
+	_, tables, err := tournamentService.DirectedTournament(tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "tournament_announcement",
+		"payload": map[string]interface{}{
+			"tournament_id": tournamentID,
+			"message":       message,
+			"sent_at":       time.Now(),
+		},
+	})
+	recipients := sendToTournamentTables(bridge, tables, data)
+	log.Printf("[DIRECTOR] Announcement by %s sent to %d tables of tournament %s", userID, len(tables), tournamentID)
+
+	return map[string]interface{}{
+		"tournament_id": tournamentID,
+		"tables":        len(tables),
+		"recipients":    recipients,
+	}, nil
+}
+
+// directorBreak puts every table of the tournament on a break. Hands in play
+// finish, then no table deals until the break is over and they all deal
+// together. The level clock stops for the break.
+func directorBreak(userID, tournamentID string, seconds int, bridge *game.GameBridge, tournamentService *tournament.Service) (map[string]interface{}, error) {
+	resumesAt, tables, err := tournamentService.StartBreak(tournamentID, userID, seconds)
+	if err != nil {
+		return nil, err
+	}
+
+	holdTablesUntil(bridge, tables, resumesAt, func() {
+		log.Printf("[DIRECTOR] Break over for %d tables of tournament %s", len(tables), tournamentID)
+	})
+	log.Printf("[DIRECTOR] %s called a %ds break on %d tables of tournament %s", userID, seconds, len(tables), tournamentID)
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "tournament_break",
+		"payload": map[string]interface{}{
+			"tournament_id": tournamentID,
+			"seconds":       seconds,
+			"resumes_at":    resumesAt,
+		},
+	})
+	sendToTournamentTables(bridge, tables, data)
+
+	return map[string]interface{}{
+		"tournament_id": tournamentID,
+		"tables":        len(tables),
+		"resumes_at":    resumesAt,
+	}, nil
+}
+
+// directorAddTime adds time to the current blind level of the tournament
+func directorAddTime(userID, tournamentID string, seconds int, bridge *game.GameBridge, tournamentService *tournament.Service) (map[string]interface{}, error) {
+	levelStartedAt, err := tournamentService.AddLevelTime(tournamentID, userID, seconds)
+	if err != nil {
+		return nil, err
+	}
+	_, tables, err := tournamentService.DirectedTournament(tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DIRECTOR] %s added %ds to the level clock of tournament %s", userID, seconds, tournamentID)
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"type": "tournament_clock",
+		"payload": map[string]interface{}{
+			"tournament_id":    tournamentID,
+			"seconds_added":    seconds,
+			"level_started_at": levelStartedAt,
+		},
+	})
+	sendToTournamentTables(bridge, tables, data)
+
+	return map[string]interface{}{
+		"tournament_id":    tournamentID,
+		"level_started_at": levelStartedAt,
+	}, nil
+}
+
+// directorProgress reports where every table of the director's tournaments is
+func directorProgress(userID, tournamentID string, bridge *game.GameBridge, tournamentService *tournament.Service) (map[string]interface{}, error) {
+	var tournamentIDs []string
+	if tournamentID != "" {
+		tournamentIDs = []string{tournamentID}
+	} else {
+		hosted, err := tournamentService.HostedTournaments(userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range hosted {
+			tournamentIDs = append(tournamentIDs, t.ID)
+		}
+	}
+
+	tournaments := make([]map[string]interface{}, 0, len(tournamentIDs))
+	for _, id := range tournamentIDs {
+		tourney, tables, err := tournamentService.DirectedTournament(id, userID)
+		if err != nil {
+			return nil, err
+		}
+		progress := make([]map[string]interface{}, 0, len(tables))
+		for _, table := range tables {
+			progress = append(progress, tableProgress(table, bridge))
+		}
+		tournaments = append(tournaments, map[string]interface{}{
+			"tournament_id":    tourney.ID,
+			"name":             tourney.Name,
+			"status":           tourney.Status,
+			"level":            tourney.CurrentLevel,
+			"level_started_at": tourney.LevelStartedAt,
+			"hand_for_hand":    tournamentService.HandForHand.IsActive(tourney.ID),
+			"tables":           progress,
+		})
+	}
+
+	return map[string]interface{}{"tournaments": tournaments}, nil
+}
+
+// tableProgress describes the hand in play at one tournament table
+func tableProgress(table models.Table, bridge *game.GameBridge) map[string]interface{} {
+	progress := map[string]interface{}{
+		"table_id":     table.ID,
+		"table_number": table.TableNumber,
+		"held":         bridge.HandHolds.IsHeld(table.ID),
+	}
+
+	engineTable, exists := bridge.GetTable(table.ID)
+	if !exists {
+		progress["status"] = table.Status
+		return progress
+	}
+	state := engineTable.Snapshot()
+	players, chips := 0, 0
+	for _, p := range state.Players {
+		if p != nil && p.Chips+p.TotalInvestedThisHand > 0 {
+			players++
+			chips += p.Chips
+		}
+	}
+	progress["status"] = state.Status
+	progress["players"] = players
+	progress["chips"] = chips
+	if state.CurrentHand != nil {
+		progress["hand_number"] = state.CurrentHand.HandNumber
+		if state.Status == pokerModels.StatusPlaying {
+			progress["betting_round"] = state.CurrentHand.BettingRound
+		}
+	}
+	return progress
+}
@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/eventschema"
+	"poker-platform/backend/internal/leaderboard"
 	"poker-platform/backend/internal/models"
 	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/server/websocket"
 	"poker-platform/backend/internal/tournament"
 
 	"poker-engine/engine"
@@ -24,9 +27,21 @@ func HandleTournamentEngineEvent(
 	syncChipsFunc func(string),
 	eliminationTracker *tournament.EliminationTracker,
 	consolidator *tournament.Consolidator,
+	tournamentService *tournament.Service,
 ) {
 	log.Printf("[ENGINE_EVENT] Tournament table %s: %s", tableID, event.Event)
 
+	bridge.RecordStatsEvent(tableID, event)
+	bridge.RecordSessionEvent(tableID, event)
+	bridge.RecordFairnessEvent(tableID, event)
+	bridge.RecordHandStateEvent(tableID, event)
+	bridge.RecordLeaderboardEvent(tableID, event)
+	bridge.RecordProgressionEvent(tableID, event)
+	bridge.RecordMissionEvent(tableID, event)
+	bridge.RecordReferralEvent(tableID, event)
+	bridge.RecordProfileEvent(tableID, event)
+	bridge.RecordBotEvent(tableID, event)
+
 	switch event.Event {
 	case "handStart":
 		data, _ := event.Data.(map[string]interface{})
@@ -62,11 +77,18 @@ func HandleTournamentEngineEvent(
 		// Update hand data with final results
 		game.UpdateHandRecord(bridge, database, tableID, event)
 
+		// Add an all-in's equity to the players' all-in EV
+		recordAllInEV(tableID, event, database, tournamentService)
+
 		// Sync player chips to database after hand completion
 		syncChipsFunc(tableID)
 
+		// Even out the tournament's tables while this one is between hands
+		BalanceAfterHand(tableID, database, bridge, broadcastFunc)
+
 		// Check for player eliminations
-		go CheckTournamentEliminations(tableID, database, bridge, eliminationTracker, consolidator)
+		data, _ := event.Data.(pokerModels.HandCompleteEvent)
+		go CheckTournamentEliminations(tableID, database, bridge, eliminationTracker, data.Knockouts)
 
 		// Broadcast current state
 		broadcastFunc(tableID)
@@ -75,6 +97,18 @@ func HandleTournamentEngineEvent(
 		go func() {
 			time.Sleep(5 * time.Second)
 
+			// A broadcast final table deals hand for hand at the production's
+			// pace
+			if finalTableBroadcastDelay(tableID, database, bridge, consolidator) > 0 {
+				time.Sleep(broadcastHandPause)
+			}
+
+			// No table deals while the director holds it or during a break
+			bridge.HandHolds.Wait(tableID)
+
+			// On the money bubble every table waits for the others
+			waitHandForHand(tableID, database, bridge, tournamentService)
+
 			bridge.Mu.RLock()
 			table, exists := bridge.Tables[tableID]
 			bridge.Mu.RUnlock()
@@ -157,7 +191,7 @@ func HandleTournamentEngineEvent(
 								continue
 							}
 
-							if err := eliminationTracker.EliminatePlayer(tournamentID, p.PlayerID); err != nil {
+							if err := eliminationTracker.EliminatePlayer(tournamentID, p.PlayerID, nil); err != nil {
 								log.Printf("[TOURNAMENT] Error eliminating player %s: %v", p.PlayerID, err)
 							}
 						}
@@ -198,11 +232,40 @@ func HandleTournamentEngineEvent(
 
 	case "playerAction":
 		log.Printf("[ENGINE_EVENT] Player action completed on tournament table %s", tableID)
+		if data, ok := event.Data.(map[string]interface{}); ok {
+			playerID, _ := data["playerId"].(string)
+			bridge.Away.Acked(tableID, playerID)
+		}
+		broadcastFunc(tableID)
+		return
+
+	case "playerAway":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[ENGINE_EVENT] Player %v away=%v on tournament table %s", data["playerId"], data["away"], tableID)
+		broadcastFunc(tableID)
+		return
+
+	case "playerConnection":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[ENGINE_EVENT] Player %v connected=%v on tournament table %s", data["playerId"], data["connected"], tableID)
+		broadcastFunc(tableID)
+		return
+
+	case "playerFrozen":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[ENGINE_EVENT] Player %v frozen=%v on tournament table %s", data["playerId"], data["frozen"], tableID)
 		broadcastFunc(tableID)
 		return
 
+	case "timeBankStarted":
+		// The actionRequired sent with it carries the new deadline
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[ENGINE_EVENT] Player %v drawing on %vs of time bank on tournament table %s", data["playerId"], data["timeBank"], tableID)
+		return
+
 	case "actionRequired":
 		log.Printf("[ENGINE_EVENT] Action required on tournament table %s", tableID)
+		bridge.SendTurnNotification(tableID, event)
 		broadcastFunc(tableID)
 		return
 
@@ -224,9 +287,26 @@ func HandleTournamentEngineEvent(
 		broadcastFunc(tableID)
 		return
 
+	case "positionsCorrected":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[BUTTON_AUDIT] WARNING: Hand #%v on tournament table %s had inconsistent positions %v - corrected to dealer=%v SB=%v BB=%v",
+			data["handNumber"], tableID, data["violations"], data["dealerPosition"], data["smallBlindPosition"], data["bigBlindPosition"])
+		return
+
+	case "colorUp":
+		data, _ := event.Data.(map[string]interface{})
+		log.Printf("[COLOR_UP] Tournament table %s colored up: removed %v chips, minimum %v (%v)",
+			tableID, data["removedDenomination"], data["minDenomination"], data["method"])
+		SendColorUpMessage(bridge, tableID, data)
+		syncChipsFunc(tableID)
+		broadcastFunc(tableID)
+		return
+
 	case "cardDealt":
-		// Don't broadcast on every card dealt to reduce message frequency
-		log.Printf("[ENGINE_EVENT] Card dealt on tournament table %s (skipping broadcast)", tableID)
+		// The broadcast throttle skips these; the next playerAction or
+		// roundAdvanced carries the cards
+		log.Printf("[ENGINE_EVENT] Card dealt on tournament table %s", tableID)
+		broadcastFunc(tableID)
 		return
 
 	case "playerBusted":
@@ -279,28 +359,14 @@ func HandleTournamentEngineEvent(
 		}
 
 		// Eliminate the player
-		if err := eliminationTracker.EliminatePlayer(tournamentID, playerID); err != nil {
+		if err := eliminationTracker.EliminatePlayer(tournamentID, playerID, nil); err != nil {
 			log.Printf("[PLAYER_BUSTED] Error eliminating player %s: %v", playerID, err)
 		} else {
 			log.Printf("[PLAYER_BUSTED] Successfully eliminated player %s from tournament %s", playerID, tournamentID)
 		}
 
-		// Check if we should consolidate or balance tables
-		go func() {
-			shouldConsolidate, _ := eliminationTracker.ShouldConsolidateTables(tournamentID)
-			if shouldConsolidate {
-				if err := consolidator.ConsolidateTables(tournamentID); err != nil {
-					log.Printf("[PLAYER_BUSTED] Error consolidating tables: %v", err)
-				}
-			} else {
-				shouldBalance, _ := eliminationTracker.ShouldBalanceTables(tournamentID)
-				if shouldBalance {
-					if err := consolidator.BalanceTables(tournamentID); err != nil {
-						log.Printf("[PLAYER_BUSTED] Error balancing tables: %v", err)
-					}
-				}
-			}
-		}()
+		// Tables are consolidated from the outbox after the elimination;
+		// balancing happens at hand boundaries
 
 		// Broadcast updated table state
 		broadcastFunc(tableID)
@@ -312,13 +378,31 @@ func HandleTournamentEngineEvent(
 	}
 }
 
-// CheckTournamentEliminations checks for player eliminations in a tournament
+// recordAllInEV adds the equity snapshot of a hand that was all-in before the
+// river to the tournament's all-in EV totals
+func recordAllInEV(tableID string, event pokerModels.Event, database *db.DB, tournamentService *tournament.Service) {
+	data, ok := event.Data.(pokerModels.HandCompleteEvent)
+	if !ok || len(data.AllInEquity) == 0 || tournamentService == nil {
+		return
+	}
+
+	var dbTable models.Table
+	if err := database.Where("id = ?", tableID).First(&dbTable).Error; err != nil || dbTable.TournamentID == nil {
+		return
+	}
+	if err := tournamentService.RecordAllIn(*dbTable.TournamentID, data.AllInEquity); err != nil {
+		log.Printf("[ALL_IN] Failed to record all-in EV at table %s: %v", tableID, err)
+	}
+}
+
+// CheckTournamentEliminations checks for player eliminations in a tournament.
+// knockouts are who the hand's busted players were knocked out by.
 func CheckTournamentEliminations(
 	tableID string,
 	database *db.DB,
 	bridge *game.GameBridge,
 	eliminationTracker *tournament.EliminationTracker,
-	consolidator *tournament.Consolidator,
+	knockouts []pokerModels.Knockout,
 ) {
 	// Get table state
 	bridge.Mu.RLock()
@@ -361,27 +445,20 @@ func CheckTournamentEliminations(
 			}
 
 			// Player is eliminated
-			if err := eliminationTracker.EliminatePlayer(tournamentID, player.PlayerID); err != nil {
+			var eliminatedBy []string
+			for _, knockout := range knockouts {
+				if knockout.PlayerID == player.PlayerID {
+					eliminatedBy = knockout.EliminatedBy
+				}
+			}
+			if err := eliminationTracker.EliminatePlayer(tournamentID, player.PlayerID, eliminatedBy); err != nil {
 				log.Printf("Error eliminating player %s: %v", player.PlayerID, err)
 			}
 		}
 	}
 
-	// Check if we should consolidate or balance tables
-	shouldConsolidate, _ := eliminationTracker.ShouldConsolidateTables(tournamentID)
-	if shouldConsolidate {
-		if err := consolidator.ConsolidateTables(tournamentID); err != nil {
-			log.Printf("Error consolidating tables: %v", err)
-		}
-	} else {
-		// Check if we should balance
-		shouldBalance, _ := eliminationTracker.ShouldBalanceTables(tournamentID)
-		if shouldBalance {
-			if err := consolidator.BalanceTables(tournamentID); err != nil {
-				log.Printf("Error balancing tables: %v", err)
-			}
-		}
-	}
+	// Each elimination consolidates tables from the outbox. Balancing is done
+	// by BalanceAfterHand, which moves players in the engine as well.
 }
 
 // HandleTournamentTableComplete handles when a tournament table completes
@@ -436,26 +513,20 @@ func HandleTournamentTableComplete(tableID string, event pokerModels.Event, data
 
 // SendTournamentTableCompleteMessage sends a table complete message for tournament
 func SendTournamentTableCompleteMessage(bridge *game.GameBridge, tableID string, data map[string]interface{}) {
+	payload := eventschema.NewGameCompletePayload(data, "Table Complete! Winner advances!")
+	payload.TableID = tableID
 	gameCompleteMsg := map[string]interface{}{
-		"type": "tournament_table_complete",
-		"payload": map[string]interface{}{
-			"table_id":     tableID,
-			"winner":       data["winner"],
-			"winnerName":   data["winnerName"],
-			"finalChips":   data["finalChips"],
-			"totalPlayers": data["totalPlayers"],
-			"message":      "Table Complete! Winner advances!",
-		},
+		"type":    "tournament_table_complete",
+		"payload": payload,
 	}
 
 	msgData, _ := json.Marshal(gameCompleteMsg)
 
-	bridge.Mu.RLock()
-	for _, clientInterface := range bridge.Clients {
-		type ClientWithTable interface {
-			GetTableID() string
-			GetSendChannel() chan []byte
-		}
+	type ClientWithTable interface {
+		GetTableID() string
+		GetSendChannel() chan []byte
+	}
+	bridge.Clients.Each(func(_ string, clientInterface interface{}) {
 		if client, ok := clientInterface.(ClientWithTable); ok {
 			if client.GetTableID() == tableID {
 				select {
@@ -465,11 +536,46 @@ func SendTournamentTableCompleteMessage(bridge *game.GameBridge, tableID string,
 				}
 			}
 		}
-	}
-	bridge.Mu.RUnlock()
+	})
 	log.Printf("Tournament table complete message sent for table %s", tableID)
 }
 
+// SendColorUpMessage sends the color-up results to all clients at a tournament table
+func SendColorUpMessage(bridge *game.GameBridge, tableID string, data map[string]interface{}) {
+	message := map[string]interface{}{
+		"type": "color_up",
+		"payload": map[string]interface{}{
+			"table_id":             tableID,
+			"removed_denomination": data["removedDenomination"],
+			"min_denomination":     data["minDenomination"],
+			"method":               data["method"],
+			"results":              data["results"],
+		},
+	}
+
+	msgData, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("[COLOR_UP] Error marshaling color-up message: %v", err)
+		return
+	}
+
+	type ClientWithTable interface {
+		GetTableID() string
+		GetSendChannel() chan []byte
+	}
+	bridge.Clients.Each(func(_ string, clientInterface interface{}) {
+		if client, ok := clientInterface.(ClientWithTable); ok {
+			if client.GetTableID() == tableID {
+				select {
+				case client.GetSendChannel() <- msgData:
+				default:
+					// Channel full, skip
+				}
+			}
+		}
+	})
+}
+
 // UpdateTournamentTableBlinds updates blinds for all tables in a tournament
 func UpdateTournamentTableBlinds(
 	tournamentID string,
@@ -485,6 +591,8 @@ func UpdateTournamentTableBlinds(
 		return
 	}
 
+	removedDenom, minDenom, colorUpMethod, colorUp := tournamentColorUp(database, tournamentID)
+
 	bridge.Mu.RLock()
 	updatedCount := 0
 	for _, dbTable := range tables {
@@ -501,6 +609,16 @@ func UpdateTournamentTableBlinds(
 			log.Printf("Error updating blinds for table %s: %v", dbTable.ID, err)
 			continue
 		}
+		if err := engineTable.UpdateAnte(newLevel.Ante); err != nil {
+			log.Printf("Error updating ante for table %s: %v", dbTable.ID, err)
+		}
+
+		// Like the blinds, the color-up is applied before the next hand starts
+		if colorUp {
+			if err := engineTable.ColorUp(removedDenom, minDenom, colorUpMethod); err != nil {
+				log.Printf("[COLOR_UP] Error scheduling color-up for table %s: %v", dbTable.ID, err)
+			}
+		}
 
 		log.Printf("Updated table %s blinds to %d/%d (will apply to next hand)", dbTable.ID, newLevel.SmallBlind, newLevel.BigBlind)
 		updatedCount++
@@ -510,6 +628,30 @@ func UpdateTournamentTableBlinds(
 	log.Printf("Tournament %s: Updated %d tables with new blinds", tournamentID, updatedCount)
 }
 
+// tournamentColorUp reports whether the tournament's current level removes its
+// smallest chip denomination, and how the odd chips should be colored up
+func tournamentColorUp(database *db.DB, tournamentID string) (int, int, engine.ColorUpMethod, bool) {
+	var tourney models.Tournament
+	if err := database.Where("id = ?", tournamentID).First(&tourney).Error; err != nil {
+		log.Printf("[COLOR_UP] Error loading tournament %s: %v", tournamentID, err)
+		return 0, 0, "", false
+	}
+
+	var structure models.TournamentStructure
+	if err := json.Unmarshal([]byte(tourney.Structure), &structure); err != nil {
+		log.Printf("[COLOR_UP] Error parsing structure of tournament %s: %v", tournamentID, err)
+		return 0, 0, "", false
+	}
+
+	removed, minimum, ok := tournament.ColorUpForLevel(structure, tourney.CurrentLevel)
+	if !ok {
+		return 0, 0, "", false
+	}
+
+	log.Printf("[COLOR_UP] Tournament %s level %d removes %d chips (new minimum %d)", tournamentID, tourney.CurrentLevel, removed, minimum)
+	return removed, minimum, tournament.ColorUpMethod(structure), true
+}
+
 // BroadcastBlindIncrease broadcasts a blind increase to all clients
 func BroadcastBlindIncrease(
 	tournamentID string,
@@ -538,6 +680,7 @@ func BroadcastBlindIncrease(
 			"small_blind":     newLevel.SmallBlind,
 			"big_blind":       newLevel.BigBlind,
 			"ante":            newLevel.Ante,
+			"denominations":   tournament.LevelDenominations(newLevel),
 			"next_level":      nextLevel,
 			"time_until_next": timeUntilNext.Seconds(),
 		},
@@ -546,20 +689,17 @@ func BroadcastBlindIncrease(
 	data, _ := json.Marshal(message)
 
 	// Broadcast to all clients
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
+	type Sender interface {
+		GetSendChannel() chan []byte
+	}
+	bridge.Clients.Each(func(_ string, clientInterface interface{}) {
 		if sender, ok := clientInterface.(Sender); ok {
 			select {
 			case sender.GetSendChannel() <- data:
 			default:
 			}
 		}
-	}
+	})
 
 	log.Printf("Broadcast blind increase for tournament %s: Level %d (%d/%d)",
 		tournamentID, tourney.CurrentLevel, newLevel.SmallBlind, newLevel.BigBlind)
@@ -601,20 +741,17 @@ func HandlePlayerElimination(
 
 	data, _ := json.Marshal(message)
 
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
+	type Sender interface {
+		GetSendChannel() chan []byte
+	}
+	bridge.Clients.Each(func(_ string, clientInterface interface{}) {
 		if sender, ok := clientInterface.(Sender); ok {
 			select {
 			case sender.GetSendChannel() <- data:
 			default:
 			}
 		}
-	}
+	})
 
 	log.Printf("Tournament %s: Player %s eliminated in position %d (%d remaining)",
 		tournamentID, user.Username, position, remainingCount)
@@ -629,10 +766,6 @@ func BroadcastTournamentTableState(bridge *game.GameBridge, tableID string) {
 		return table, exists
 	}
 
-	bridge.Mu.RLock()
-	clients := bridge.Clients
-	bridge.Mu.RUnlock()
-
 	tableInterface, exists := getTableFunc(tableID)
 	if !exists {
 		return
@@ -645,7 +778,7 @@ func BroadcastTournamentTableState(bridge *game.GameBridge, tableID string) {
 
 	state := table.GetState()
 
-	for _, clientInterface := range clients {
+	bridge.Clients.Each(func(_ string, clientInterface interface{}) {
 		type Sender interface {
 			GetTableID() string
 			GetSendChannel() chan []byte
@@ -663,6 +796,9 @@ func BroadcastTournamentTableState(bridge *game.GameBridge, tableID string) {
 						"has_acted_this_round": p.HasActedThisRound,
 						"last_action":         string(p.LastAction),
 						"last_action_amount":  p.LastActionAmount,
+						"away":                p.Away,
+						"chips_bb":            websocket.BigBlinds(p.Chips, state.Config.BigBlind),
+						"bet_bb":              websocket.BigBlinds(p.Bet, state.Config.BigBlind),
 					}
 					players = append(players, playerData)
 				}
@@ -680,9 +816,11 @@ func BroadcastTournamentTableState(bridge *game.GameBridge, tableID string) {
 				"status":        string(state.Status),
 				"players":       players,
 				"current_hand":  state.CurrentHand,
-				"winners":       state.Winners,
+				"winners":       state.PublicWinners(),
 				"pot_main":      potMain,
 				"pot_side":      potSide,
+				"big_blind":     state.Config.BigBlind,
+				"pot_bb":        websocket.BigBlinds(potMain+potSide, state.Config.BigBlind),
 			}
 
 			// For tournament tables, include tournament_id by checking the game type
@@ -701,7 +839,7 @@ func BroadcastTournamentTableState(bridge *game.GameBridge, tableID string) {
 			default:
 			}
 		}
-	}
+	})
 }
 
 // HandleTournamentComplete broadcasts tournament completion
@@ -711,33 +849,12 @@ func HandleTournamentComplete(
 	bridge *game.GameBridge,
 	eliminationTracker *tournament.EliminationTracker,
 ) {
-	// Get all tables for this tournament
-	var tables []models.Table
-	if err := database.Where("tournament_id = ?", tournamentID).Find(&tables).Error; err == nil {
-		// Update engine status for each table and broadcast final state
-		for _, table := range tables {
-			bridge.Mu.RLock()
-			engineTable, exists := bridge.Tables[table.ID]
-			bridge.Mu.RUnlock()
-
-			if exists {
-				// Update engine's game status to completed
-				state := engineTable.GetState()
-				if state.Status == pokerModels.StatusHandComplete || state.Status == pokerModels.StatusPlaying || state.Status == pokerModels.StatusWaiting {
-					// Set status to completed (tournament is over for this table)
-					engineTable.GetGame().UpdateStatus(pokerModels.StatusCompleted)
-					log.Printf("[TOURNAMENT] Updated table %s engine status to completed (tournament complete)", table.ID)
-
-					// Broadcast final table state to all clients
-					BroadcastTournamentTableState(bridge, table.ID)
-					log.Printf("[TOURNAMENT] Broadcasted final table state for table %s", table.ID)
-				}
-			}
-		}
-	}
+	stopTournamentTables(tournamentID, database, bridge)
 
 	// Get final standings
 	standings, _ := eliminationTracker.GetTournamentStandings(tournamentID)
+	bridge.RecordTournamentFinish(tournamentID, standings)
+	bridge.RecordMissionTournamentFinish(tournamentID, standings)
 
 	// Find winner
 	var winnerID, winnerName string
@@ -765,26 +882,62 @@ func HandleTournamentComplete(
 
 	data, _ := json.Marshal(message)
 
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
+	type Sender interface {
+		GetSendChannel() chan []byte
+	}
+	bridge.Clients.Each(func(_ string, clientInterface interface{}) {
 		if sender, ok := clientInterface.(Sender); ok {
 			select {
 			case sender.GetSendChannel() <- data:
 			default:
 			}
 		}
-	}
+	})
 
 	log.Printf("Tournament %s: Completed! Winner: %s", tournamentID, winnerName)
 }
 
-// HandlePrizeDistributed broadcasts prize distribution
+// stopTournamentTables marks the engine tables of a finished tournament
+// completed and broadcasts their final state
+func stopTournamentTables(tournamentID string, database *db.DB, bridge *game.GameBridge) {
+	var tables []models.Table
+	if err := database.Where("tournament_id = ?", tournamentID).Find(&tables).Error; err != nil {
+		return
+	}
+
+	// Update engine status for each table and broadcast final state
+	for _, table := range tables {
+		bridge.HandHolds.Release(table.ID)
+
+		bridge.Mu.RLock()
+		engineTable, exists := bridge.Tables[table.ID]
+		bridge.Mu.RUnlock()
+
+		if exists {
+			// Update engine's game status to completed
+			state := engineTable.GetState()
+			if state.Status == pokerModels.StatusHandComplete || state.Status == pokerModels.StatusPlaying ||
+				state.Status == pokerModels.StatusWaiting || state.Status == pokerModels.StatusPaused {
+				// Set status to completed (tournament is over for this table)
+				engineTable.GetGame().UpdateStatus(pokerModels.StatusCompleted)
+				log.Printf("[TOURNAMENT] Updated table %s engine status to completed (tournament over)", table.ID)
+
+				// Broadcast final table state to all clients
+				BroadcastTournamentTableState(bridge, table.ID)
+				log.Printf("[TOURNAMENT] Broadcasted final table state for table %s", table.ID)
+			}
+		}
+	}
+}
+
+// HandlePrizeDistributed scores a prize on the leaderboards and broadcasts it
 func HandlePrizeDistributed(tournamentID, userID string, amount int, database *db.DB, bridge *game.GameBridge) {
+	if bridge.Leaderboards != nil {
+		if err := bridge.Leaderboards.Record(leaderboard.TypeTournaments, userID, int64(amount), time.Now()); err != nil {
+			log.Printf("[LEADERBOARD] Failed to record prize of %s in tournament %s: %v", userID, tournamentID, err)
+		}
+	}
+
 	// Get user details
 	var user models.User
 	username := userID
@@ -805,59 +958,60 @@ func HandlePrizeDistributed(tournamentID, userID string, amount int, database *d
 
 	data, _ := json.Marshal(message)
 
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
+	type Sender interface {
+		GetSendChannel() chan []byte
+	}
+	bridge.Clients.Each(func(_ string, clientInterface interface{}) {
 		if sender, ok := clientInterface.(Sender); ok {
 			select {
 			case sender.GetSendChannel() <- data:
 			default:
 			}
 		}
-	}
+	})
 
 	log.Printf("Tournament %s: Prize distributed to %s: %d credits", tournamentID, username, amount)
 }
 
 // HandleTableConsolidation handles table consolidation
 func HandleTableConsolidation(
-	tournamentID string,
+	plan *tournament.ConsolidationPlan,
 	bridge *game.GameBridge,
 	reinitFunc func(string),
 ) {
 	// Reload tournament tables in the engine
-	go reinitFunc(tournamentID)
+	go reinitFunc(plan.TournamentID)
 
 	// Broadcast table consolidation
+	broadcastConsolidation("tables_consolidated", plan, 0, bridge)
+
+	log.Printf("Tournament %s: Tables consolidated", plan.TournamentID)
+}
+
+// broadcastConsolidation sends a consolidation plan to everyone
+func broadcastConsolidation(messageType string, plan *tournament.ConsolidationPlan, countdown time.Duration, bridge *game.GameBridge) {
 	message := map[string]interface{}{
-		"type": "tables_consolidated",
-		"payload": map[string]interface{}{
-			"tournament_id": tournamentID,
+		"type": messageType,
+		"payload": eventschema.ConsolidationPayload{
+			TournamentID:     plan.TournamentID,
+			Plan:             plan,
+			CountdownSeconds: int(countdown.Round(time.Second) / time.Second),
 		},
 	}
 
 	data, _ := json.Marshal(message)
 
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
+	type Sender interface {
+		GetSendChannel() chan []byte
+	}
+	bridge.Clients.Each(func(_ string, clientInterface interface{}) {
 		if sender, ok := clientInterface.(Sender); ok {
 			select {
 			case sender.GetSendChannel() <- data:
 			default:
 			}
 		}
-	}
-
-	log.Printf("Tournament %s: Tables consolidated", tournamentID)
+	})
 }
 
 // BroadcastTournamentCreated broadcasts tournament creation
@@ -881,21 +1035,7 @@ func BroadcastTournamentCreated(
 
 	data, _ := json.Marshal(message)
 
-	// Broadcast to all clients
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
-		if sender, ok := clientInterface.(Sender); ok {
-			select {
-			case sender.GetSendChannel() <- data:
-			default:
-			}
-		}
-	}
+	broadcastLobby(bridge, tournamentService, tourney, data)
 }
 
 // BroadcastTournamentStarted broadcasts tournament start
@@ -919,21 +1059,7 @@ func BroadcastTournamentStarted(
 
 	data, _ := json.Marshal(message)
 
-	// Broadcast to all clients
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
-		if sender, ok := clientInterface.(Sender); ok {
-			select {
-			case sender.GetSendChannel() <- data:
-			default:
-			}
-		}
-	}
+	broadcastLobby(bridge, tournamentService, tourney, data)
 }
 
 // BroadcastTournamentUpdate broadcasts tournament updates
@@ -955,26 +1081,13 @@ func BroadcastTournamentUpdate(
 		"payload": map[string]interface{}{
 			"tournament": tourney,
 			"players":    players,
+			"seats_left": tourney.MaxPlayers - tourney.CurrentPlayers, // A sit & go starts when this reaches 0
 		},
 	}
 
 	data, _ := json.Marshal(message)
 
-	// Broadcast to all clients
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
-		if sender, ok := clientInterface.(Sender); ok {
-			select {
-			case sender.GetSendChannel() <- data:
-			default:
-			}
-		}
-	}
+	broadcastLobby(bridge, tournamentService, tourney, data)
 }
 
 // BroadcastTournamentPaused broadcasts tournament paused
@@ -1000,20 +1113,7 @@ func BroadcastTournamentPaused(
 
 	data, _ := json.Marshal(message)
 
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
-
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
-		if sender, ok := clientInterface.(Sender); ok {
-			select {
-			case sender.GetSendChannel() <- data:
-			default:
-			}
-		}
-	}
+	broadcastLobby(bridge, tournamentService, tourney, data)
 }
 
 // BroadcastTournamentResumed broadcasts tournament resumed
@@ -1040,18 +1140,17 @@ func BroadcastTournamentResumed(
 
 	data, _ := json.Marshal(message)
 
-	bridge.Mu.RLock()
-	defer bridge.Mu.RUnlock()
+	broadcastLobby(bridge, tournamentService, tourney, data)
+}
 
-	for _, clientInterface := range bridge.Clients {
-		type Sender interface {
-			GetSendChannel() chan []byte
-		}
-		if sender, ok := clientInterface.(Sender); ok {
-			select {
-			case sender.GetSendChannel() <- data:
-			default:
-			}
-		}
+// broadcastLobby sends a lobby message about a tournament to every connected
+// client, or only to the members of a club tournament
+func broadcastLobby(bridge *game.GameBridge, tournamentService *tournament.Service, tourney *models.Tournament, data []byte) {
+	audience, err := tournamentService.LobbyAudience(tourney)
+	if err != nil {
+		log.Printf("[TOURNAMENT] Failed to load lobby audience for tournament %s: %v", tourney.ID, err)
+		return
 	}
+
+	bridge.SendToUsers(audience, data)
 }
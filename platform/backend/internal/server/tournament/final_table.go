@@ -0,0 +1,101 @@
+package tournament
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/tournament"
+)
+
+// HandleFinalTable announces a tournament's final table once consolidation
+// has redrawn its seats, and holds it for the tournament's final table break.
+// It runs before the tables are reloaded, so the hold is in place before the
+// first hand would be dealt.
+func HandleFinalTable(
+	tournamentID string,
+	tableID string,
+	database *db.DB,
+	bridge *game.GameBridge,
+	tournamentService *tournament.Service,
+) {
+	tourney, err := tournamentService.GetTournament(tournamentID)
+	if err != nil {
+		log.Printf("[FINAL_TABLE] Tournament %s not found: %v", tournamentID, err)
+		return
+	}
+
+	breakDuration := time.Duration(tourney.FinalTableBreak) * time.Second
+	var resumesAt *time.Time
+	if breakDuration > 0 {
+		bridge.HandHolds.Hold(tableID)
+		resume := time.Now().Add(breakDuration)
+		resumesAt = &resume
+		time.AfterFunc(breakDuration, func() {
+			if bridge.HandHolds.Release(tableID) {
+				log.Printf("[FINAL_TABLE] Break over, final table %s of tournament %s can deal", tableID, tournamentID)
+			}
+		})
+	}
+
+	go BroadcastFinalTableStarted(tourney, tableID, resumesAt, database, tournamentService, bridge)
+	log.Printf("[FINAL_TABLE] Tournament %s reached its final table %s (break %v)", tournamentID, tableID, breakDuration)
+}
+
+// BroadcastFinalTableStarted tells the tournament's lobby and players who made
+// the final table, where they sit, their stacks and the payouts left
+func BroadcastFinalTableStarted(
+	tourney *models.Tournament,
+	tableID string,
+	resumesAt *time.Time,
+	database *db.DB,
+	tournamentService *tournament.Service,
+	bridge *game.GameBridge,
+) {
+	seats, err := tournament.NewTableInitializer(database.DB).GetTableSeats(tableID)
+	if err != nil {
+		log.Printf("[FINAL_TABLE] Error loading seats of table %s: %v", tableID, err)
+		return
+	}
+
+	players := make([]map[string]interface{}, 0, len(seats))
+	for _, seat := range seats {
+		username := seat.UserID
+		var user models.User
+		if err := database.Select("id", "username").Where("id = ?", seat.UserID).First(&user).Error; err == nil {
+			username = user.Username
+		}
+		players = append(players, map[string]interface{}{
+			"user_id":     seat.UserID,
+			"username":    username,
+			"seat_number": seat.SeatNumber,
+			"chips":       seat.Chips,
+		})
+	}
+
+	payouts, err := tournamentService.RemainingPayouts(tourney.ID)
+	if err != nil {
+		log.Printf("[FINAL_TABLE] Error calculating payouts of tournament %s: %v", tourney.ID, err)
+	}
+
+	payload := map[string]interface{}{
+		"tournament_id": tourney.ID,
+		"table_id":      tableID,
+		"players":       players,
+		"payouts":       payouts,
+		"break_seconds": tourney.FinalTableBreak,
+	}
+	if resumesAt != nil {
+		payload["resumes_at"] = resumesAt
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"type":    "final_table_started",
+		"payload": payload,
+	})
+
+	broadcastLobby(bridge, tournamentService, tourney, data)
+}
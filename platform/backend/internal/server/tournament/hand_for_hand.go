@@ -0,0 +1,97 @@
+package tournament
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/server/game"
+	"poker-platform/backend/internal/tournament"
+)
+
+// handForHandMaxWait bounds how long a table waits for the others, so a
+// table that broke in the middle of a round cannot stall the rest
+const handForHandMaxWait = 2 * time.Minute
+
+// waitHandForHand starts hand-for-hand play when a tournament reaches the
+// money bubble and ends it once the bubble bursts. While it is on, the table
+// is held until every table of the tournament has finished its hand.
+func waitHandForHand(tableID string, database *db.DB, bridge *game.GameBridge, tournamentService *tournament.Service) {
+	var dbTable models.Table
+	if err := database.Where("id = ?", tableID).First(&dbTable).Error; err != nil || dbTable.TournamentID == nil {
+		return
+	}
+	tournamentID := *dbTable.TournamentID
+	coordinator := tournamentService.HandForHand
+
+	// A bracket's matches are played out whatever the others do
+	if tournament.IsBracket(database.DB, tournamentID) {
+		return
+	}
+
+	remaining, paid, err := tournamentService.BubbleStatus(tournamentID)
+	if err != nil {
+		log.Printf("[HAND_FOR_HAND] Error checking bubble of tournament %s: %v", tournamentID, err)
+		return
+	}
+
+	if tournament.IsBubble(remaining, paid) {
+		var tableIDs []string
+		if err := database.Model(&models.Table{}).
+			Where("tournament_id = ? AND status != ?", tournamentID, "completed").
+			Pluck("id", &tableIDs).Error; err != nil {
+			log.Printf("[HAND_FOR_HAND] Error loading tables of tournament %s: %v", tournamentID, err)
+			return
+		}
+		// A single table already plays one hand at a time
+		if len(tableIDs) > 1 && coordinator.Start(tournamentID, tableIDs) {
+			log.Printf("[HAND_FOR_HAND] Tournament %s on the bubble (%d left, %d paid) - playing hand for hand on %d tables",
+				tournamentID, remaining, paid, len(tableIDs))
+			BroadcastHandForHand(tournamentID, true, tournamentService, bridge)
+		}
+	} else if coordinator.Stop(tournamentID) {
+		log.Printf("[HAND_FOR_HAND] Tournament %s bubble over (%d left, %d paid) - hand-for-hand play ended",
+			tournamentID, remaining, paid)
+		BroadcastHandForHand(tournamentID, false, tournamentService, bridge)
+		return
+	}
+
+	if !coordinator.IsActive(tournamentID) {
+		return
+	}
+
+	table, exists := bridge.GetTable(tableID)
+	if exists {
+		table.HoldBetweenHands(true)
+		defer table.HoldBetweenHands(false)
+	}
+
+	select {
+	case <-coordinator.HandFinished(tournamentID, tableID):
+	case <-time.After(handForHandMaxWait):
+		log.Printf("[HAND_FOR_HAND] WARNING: Table %s gave up waiting for the other tables of tournament %s", tableID, tournamentID)
+	}
+}
+
+// BroadcastHandForHand tells the tournament's lobby and players that
+// hand-for-hand play has started or ended
+func BroadcastHandForHand(tournamentID string, active bool, tournamentService *tournament.Service, bridge *game.GameBridge) {
+	tourney, err := tournamentService.GetTournament(tournamentID)
+	if err != nil {
+		return
+	}
+
+	message := map[string]interface{}{
+		"type": "hand_for_hand",
+		"payload": map[string]interface{}{
+			"tournament_id": tournamentID,
+			"active":        active,
+		},
+	}
+
+	data, _ := json.Marshal(message)
+
+	broadcastLobby(bridge, tournamentService, tourney, data)
+}
@@ -2,15 +2,22 @@ package tournament
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"time"
 
+	"poker-platform/backend/internal/avatar"
+	"poker-platform/backend/internal/club"
 	"poker-platform/backend/internal/db"
+	"poker-platform/backend/internal/entry"
 	"poker-platform/backend/internal/locks"
 	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/moderation"
+	"poker-platform/backend/internal/profile"
+	"poker-platform/backend/internal/quota"
 	"poker-platform/backend/internal/server/game"
 	"poker-platform/backend/internal/tournament"
 	"poker-platform/backend/internal/validation"
@@ -22,7 +29,7 @@ import (
 )
 
 // HandleCreateTournament creates a new tournament
-func HandleCreateTournament(c *gin.Context, tournamentService *tournament.Service, bridge *game.GameBridge) {
+func HandleCreateTournament(c *gin.Context, tournamentService *tournament.Service, moderationService *moderation.Service, quotas *quota.Service, bridge *game.GameBridge) {
 	userID := c.GetString("user_id")
 
 	var req models.CreateTournamentRequest
@@ -36,6 +43,11 @@ func HandleCreateTournament(c *gin.Context, tournamentService *tournament.Servic
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	nameMatch, err := moderationService.Screen("tournament name", req.Name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	if err := validation.ValidateBuyIn(req.BuyIn); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -63,18 +75,79 @@ func HandleCreateTournament(c *gin.Context, tournamentService *tournament.Servic
 		return
 	}
 
+	// The error says which quota ran out, its limit and when it frees up
+	if err := quotas.CheckTournament(userID, time.Now()); err != nil {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "quota": err})
+		return
+	}
+
 	tourney, err := tournamentService.CreateTournament(req, userID)
 	if err != nil {
+		if errors.Is(err, club.ErrNotClubMember) || errors.Is(err, club.ErrNotClubManager) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	if _, err := moderationService.Flag(nameMatch, moderation.KindTournamentName, tourney.ID, userID, tourney.Name); err != nil {
+		log.Printf("[MODERATION] Failed to queue name of tournament %s: %v", tourney.ID, err)
+	}
+
 	// Broadcast tournament creation to all clients
 	go BroadcastTournamentCreated(tourney.ID, tournamentService, bridge)
 
 	c.JSON(http.StatusCreated, tourney)
 }
 
+// HandleSimulateTournament simulates a tournament structure with bots so
+// creators can check its duration, stack depths and bubble before publishing
+func HandleSimulateTournament(c *gin.Context) {
+	var req tournament.SimulationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	if err := validation.ValidateStartingChips(req.StartingChips); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := tournament.Simulate(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// HandleValidateStructure checks a blind structure while it is being designed,
+// returning level start times, starting stack depth per level and warnings
+func HandleValidateStructure(c *gin.Context) {
+	var req struct {
+		Structure     models.TournamentStructure `json:"structure" binding:"required"`
+		StartingChips int                        `json:"starting_chips" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	if err := validation.ValidateStartingChips(req.StartingChips); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.Structure.BlindLevels) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "structure cannot have more than 100 levels"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tournament.AnalyzeStructure(req.Structure, req.StartingChips))
+}
+
 // HandleListTournaments lists all tournaments
 func HandleListTournaments(c *gin.Context, tournamentService *tournament.Service) {
 	status := c.Query("status")
@@ -93,6 +166,17 @@ func HandleListTournaments(c *gin.Context, tournamentService *tournament.Service
 	c.JSON(http.StatusOK, tournaments)
 }
 
+// HandleListTickets lists the current user's tournament tickets
+func HandleListTickets(c *gin.Context, tournamentService *tournament.Service) {
+	tickets, err := tournamentService.ListTickets(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tickets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tickets": tickets})
+}
+
 // HandleGetTournament gets a tournament by ID
 func HandleGetTournament(c *gin.Context, tournamentService *tournament.Service) {
 	tournamentID := c.Param("id")
@@ -109,9 +193,42 @@ func HandleGetTournament(c *gin.Context, tournamentService *tournament.Service)
 		return
 	}
 
+	// Club tournaments are hidden from non-members
+	if err := tournamentService.CanView(tourney, c.GetString("user_id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		return
+	}
+
 	c.JSON(http.StatusOK, tourney)
 }
 
+// HandleGetTournamentTimeline returns a tournament's lifecycle events, oldest first
+func HandleGetTournamentTimeline(c *gin.Context, tournamentService *tournament.Service) {
+	tournamentID := c.Param("id")
+
+	if err := validation.ValidateUUID(tournamentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+		return
+	}
+
+	tourney, err := tournamentService.GetTournament(tournamentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		return
+	}
+	if err := tournamentService.CanView(tourney, c.GetString("user_id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		return
+	}
+
+	events, err := tournamentService.Timeline(tournamentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load timeline"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
+
 // HandleGetTournamentByCode gets a tournament by its join code
 func HandleGetTournamentByCode(c *gin.Context, tournamentService *tournament.Service) {
 	code := c.Param("code")
@@ -128,11 +245,23 @@ func HandleGetTournamentByCode(c *gin.Context, tournamentService *tournament.Ser
 		return
 	}
 
+	// This endpoint is public, so club tournaments are only found through their club lobby
+	if tourney.ClubID != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		return
+	}
+
 	c.JSON(http.StatusOK, tourney)
 }
 
-// HandleRegisterTournament registers a player for a tournament
-func HandleRegisterTournament(c *gin.Context, tournamentService *tournament.Service, broadcastFunc func(string)) {
+// HandleRegisterTournament registers a player for a tournament. Taking the
+// last seat of a sit & go starts it straight away.
+func HandleRegisterTournament(
+	c *gin.Context,
+	tournamentService *tournament.Service,
+	tournamentStarter *tournament.Starter,
+	broadcastFunc func(string),
+) {
 	userID := c.GetString("user_id")
 	tournamentID := c.Param("id")
 
@@ -142,15 +271,47 @@ func HandleRegisterTournament(c *gin.Context, tournamentService *tournament.Serv
 		return
 	}
 
+	// A dry run reports what registering would cost without registering
+	if c.Query("dry_run") == "true" {
+		quote, err := tournamentService.CheckRegistration(tournamentID, userID)
+		if err != nil {
+			respondRegisterError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "dry_run": true, "quote": quote})
+		return
+	}
+
 	if err := tournamentService.RegisterPlayer(tournamentID, userID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondRegisterError(c, err)
 		return
 	}
 
+	// Tables and the tournament_started broadcast come from the start callback
+	started, err := tournamentStarter.StartIfFull(tournamentID)
+	if err != nil {
+		log.Printf("Failed to start sit & go %s: %v", tournamentID, err)
+	}
+
 	// Broadcast tournament update to lobby
 	go broadcastFunc(tournamentID)
 
-	c.JSON(http.StatusOK, gin.H{"message": "Successfully registered"})
+	c.JSON(http.StatusOK, gin.H{"message": "Successfully registered", "started": started})
+}
+
+// respondRegisterError writes the response for a registration that failed
+// its checks
+func respondRegisterError(c *gin.Context, err error) {
+	var entryErr *entry.Error
+	if errors.As(err, &entryErr) {
+		c.JSON(http.StatusForbidden, gin.H{"error": entryErr.Message, "code": entryErr.Code})
+		return
+	}
+	if errors.Is(err, club.ErrNotClubMember) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 }
 
 // HandleUnregisterTournament unregisters a player from a tournament
@@ -158,7 +319,8 @@ func HandleUnregisterTournament(c *gin.Context, tournamentService *tournament.Se
 	userID := c.GetString("user_id")
 	tournamentID := c.Param("id")
 
-	if err := tournamentService.UnregisterPlayer(tournamentID, userID); err != nil {
+	fee, err := tournamentService.UnregisterPlayer(tournamentID, userID)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -166,7 +328,7 @@ func HandleUnregisterTournament(c *gin.Context, tournamentService *tournament.Se
 	// Broadcast tournament update to lobby
 	go broadcastFunc(tournamentID)
 
-	c.JSON(http.StatusOK, gin.H{"message": "Successfully unregistered"})
+	c.JSON(http.StatusOK, gin.H{"message": "Successfully unregistered", "late_cancel_fee": fee})
 }
 
 // HandleCancelTournament cancels a tournament
@@ -185,8 +347,9 @@ func HandleCancelTournament(c *gin.Context, tournamentService *tournament.Servic
 	c.JSON(http.StatusOK, gin.H{"message": "Tournament cancelled"})
 }
 
-// HandleGetTournamentPlayers gets all players in a tournament
-func HandleGetTournamentPlayers(c *gin.Context, database *db.DB, tournamentService *tournament.Service) {
+// HandleGetTournamentPlayers gets all players in a tournament, with their
+// usernames and avatars
+func HandleGetTournamentPlayers(c *gin.Context, database *db.DB, tournamentService *tournament.Service, avatars *avatar.Service) {
 	tournamentID := c.Param("id")
 
 	players, err := tournamentService.GetTournamentPlayers(tournamentID)
@@ -194,11 +357,21 @@ func HandleGetTournamentPlayers(c *gin.Context, database *db.DB, tournamentServi
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch players"})
 		return
 	}
+	userIDs := make([]string, len(players))
+	for i, player := range players {
+		userIDs[i] = player.UserID
+	}
+	avatarURLs, err := avatars.URLs(userIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load avatars"})
+		return
+	}
 
 	// Enrich player data with usernames
 	type PlayerResponse struct {
 		models.TournamentPlayer
-		Username string `json:"username"`
+		Username  string `json:"username"`
+		AvatarURL string `json:"avatar_url,omitempty"`
 	}
 
 	var response []PlayerResponse
@@ -208,6 +381,7 @@ func HandleGetTournamentPlayers(c *gin.Context, database *db.DB, tournamentServi
 			response = append(response, PlayerResponse{
 				TournamentPlayer: player,
 				Username:         user.Username,
+				AvatarURL:        avatarURLs[player.UserID],
 			})
 		}
 	}
@@ -313,8 +487,43 @@ func HandleGetTournamentPrizes(c *gin.Context, prizeDistributor *tournament.Priz
 	})
 }
 
-// HandleGetTournamentStandings gets tournament standings
-func HandleGetTournamentStandings(c *gin.Context, eliminationTracker *tournament.EliminationTracker) {
+// HandleGetTournamentAllInReport lists how each player's all-ins ran against
+// their equity
+func HandleGetTournamentAllInReport(c *gin.Context, tournamentService *tournament.Service) {
+	tournamentID := c.Param("id")
+
+	if err := validation.ValidateUUID(tournamentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+		return
+	}
+
+	tourney, err := tournamentService.GetTournament(tournamentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		return
+	}
+	if err := tournamentService.CanView(tourney, c.GetString("user_id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		return
+	}
+
+	report, err := tournamentService.AllInReport(tournamentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load all-in report"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"players": report})
+}
+
+// StandingWithProfile is a standings entry with the player's public profile
+type StandingWithProfile struct {
+	models.TournamentPlayer
+	Profile *profile.Profile `json:"profile,omitempty"`
+}
+
+// HandleGetTournamentStandings gets tournament standings, each with the
+// player's public profile
+func HandleGetTournamentStandings(c *gin.Context, eliminationTracker *tournament.EliminationTracker, profiles *profile.Service) {
 	tournamentID := c.Param("id")
 
 	standings, err := eliminationTracker.GetTournamentStandings(tournamentID)
@@ -323,7 +532,21 @@ func HandleGetTournamentStandings(c *gin.Context, eliminationTracker *tournament
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"standings": standings})
+	userIDs := make([]string, len(standings))
+	for i, player := range standings {
+		userIDs[i] = player.UserID
+	}
+	byUser, err := profiles.Public(userIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load profiles"})
+		return
+	}
+
+	entries := make([]StandingWithProfile, len(standings))
+	for i, player := range standings {
+		entries[i] = StandingWithProfile{TournamentPlayer: player, Profile: byUser[player.UserID]}
+	}
+	c.JSON(http.StatusOK, gin.H{"standings": entries})
 }
 
 // HandleGetTournamentTables gets all tables for a tournament
@@ -427,13 +650,19 @@ func InitializeTournamentTables(
 	for _, modelTable := range modelTables {
 		tableID := modelTable.TableID
 
+		// In a cluster another instance may already run the table
+		if !bridge.ClaimTable(tableID) {
+			log.Printf("[INIT] Table %s is run by another instance, skipping", tableID)
+			continue
+		}
+
 		// Create callbacks
-		onTimeout := func(playerID string) {
+		onTimeout := func(playerID string, deadline uint64) {
 			bridge.Mu.RLock()
 			table, exists := bridge.Tables[tableID]
 			bridge.Mu.RUnlock()
 			if exists {
-				table.HandleTimeout(playerID)
+				table.HandleTimeout(playerID, deadline)
 			}
 		}
 
@@ -443,6 +672,7 @@ func InitializeTournamentTables(
 
 		// Create engine table
 		table := engine.NewTable(tableID, modelTable.GameType, modelTable.Config, onTimeout, eventFunc)
+		table.SetRateLimits(engine.DefaultRateLimits)
 
 		// Add players to the engine table
 		playerCount := 0
@@ -460,6 +690,12 @@ func InitializeTournamentTables(
 		bridge.Mu.Lock()
 		bridge.Tables[tableID] = table
 		bridge.Mu.Unlock()
+		for _, player := range modelTable.Players {
+			if player != nil {
+				bridge.ShowPlayerLevel(tableID, player.PlayerID)
+				bridge.ShowPlayerAvatar(tableID, player.PlayerID)
+			}
+		}
 
 		log.Printf("[INIT] ✓ Initialized table %s with %d players", tableID, playerCount)
 		successCount++
@@ -469,6 +705,9 @@ func InitializeTournamentTables(
 			time.Sleep(2 * time.Second)
 			log.Printf("[INIT] Attempting to start game for tournament table %s", tid)
 
+			// A final table on its break waits for it to end
+			bridge.HandHolds.Wait(tid)
+
 			// Check current state before starting
 			state := t.GetState()
 			log.Printf("[INIT] Table %s pre-start state: status=%s, players=%d", tid, state.Status, len(state.Players))
@@ -647,6 +886,8 @@ func ReinitializeTournamentTables(
 		if existingTable, exists := bridge.Tables[table.ID]; exists {
 			existingTable.Stop()
 			delete(bridge.Tables, table.ID)
+			delete(bridge.TableStats, table.ID)
+			game.EndTableSessions(bridge, database, table.ID)
 		}
 	}
 	bridge.Mu.Unlock()
@@ -0,0 +1,110 @@
+package tournament
+
+import (
+	"errors"
+	"net/http"
+
+	"poker-platform/backend/internal/tournament"
+	"poker-platform/backend/internal/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondPresetError maps saved preset errors to HTTP responses
+func respondPresetError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, tournament.ErrPresetNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, tournament.ErrPresetNameTaken), errors.Is(err, tournament.ErrTooManyPresets):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, tournament.ErrInvalidPresetName), errors.Is(err, tournament.ErrInvalidPresetKind),
+		errors.Is(err, tournament.ErrInvalidStructure), errors.Is(err, tournament.ErrInvalidPrizeStructure):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save preset"})
+	}
+}
+
+// HandleListPresets lists the current user's saved presets, optionally filtered by kind
+func HandleListPresets(c *gin.Context, tournamentService *tournament.Service) {
+	presets, err := tournamentService.ListPresets(c.GetString("user_id"), c.Query("kind"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch presets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, presets)
+}
+
+// HandleCreatePreset saves a custom structure or prize structure as a named preset
+func HandleCreatePreset(c *gin.Context, tournamentService *tournament.Service) {
+	var req tournament.SavePresetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	preset, err := tournamentService.SavePreset(c.GetString("user_id"), req)
+	if err != nil {
+		respondPresetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, preset)
+}
+
+// HandleGetPreset returns one of the current user's saved presets
+func HandleGetPreset(c *gin.Context, tournamentService *tournament.Service) {
+	presetID := c.Param("presetId")
+	if err := validation.ValidateUUID(presetID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid preset ID"})
+		return
+	}
+
+	preset, err := tournamentService.GetPreset(c.GetString("user_id"), presetID)
+	if err != nil {
+		respondPresetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preset)
+}
+
+// HandleUpdatePreset renames a saved preset or replaces its structure
+func HandleUpdatePreset(c *gin.Context, tournamentService *tournament.Service) {
+	presetID := c.Param("presetId")
+	if err := validation.ValidateUUID(presetID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid preset ID"})
+		return
+	}
+
+	var req tournament.SavePresetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	preset, err := tournamentService.UpdatePreset(c.GetString("user_id"), presetID, req)
+	if err != nil {
+		respondPresetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preset)
+}
+
+// HandleDeletePreset deletes one of the current user's saved presets
+func HandleDeletePreset(c *gin.Context, tournamentService *tournament.Service) {
+	presetID := c.Param("presetId")
+	if err := validation.ValidateUUID(presetID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid preset ID"})
+		return
+	}
+
+	if err := tournamentService.DeletePreset(c.GetString("user_id"), presetID); err != nil {
+		respondPresetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Preset deleted"})
+}
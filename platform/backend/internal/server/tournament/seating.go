@@ -0,0 +1,48 @@
+package tournament
+
+import (
+	"errors"
+	"net/http"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/tournament"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleSetSeatingPlan lets a tournament's creator set or clear its seating
+// plan before it starts
+func HandleSetSeatingPlan(c *gin.Context, tournamentService *tournament.Service) {
+	userID := c.GetString("user_id")
+	tournamentID := c.Param("id")
+
+	var req struct {
+		SeatingPlan *models.SeatingPlan `json:"seating_plan"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		return
+	}
+
+	tourney, err := tournamentService.SetSeatingPlan(tournamentID, userID, req.SeatingPlan)
+	if err != nil {
+		switch {
+		case errors.Is(err, tournament.ErrTournamentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, tournament.ErrNotTournamentCreator):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, tournament.ErrTournamentAlreadyStarted):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, tournament.ErrInvalidSeatingPlan):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tournament_id": tournamentID,
+		"seating_plan":  tourney.SeatingPlan,
+	})
+}
@@ -0,0 +1,247 @@
+package websocket
+
+import (
+	"encoding/json"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	pokerModels "poker-engine/models"
+)
+
+// Field numbers of the TableState and Player messages in table_state.proto
+const (
+	stateType               protowire.Number = 1
+	stateTableID            protowire.Number = 2
+	statePlayers            protowire.Number = 3
+	stateCommunityCards     protowire.Number = 4
+	statePot                protowire.Number = 5
+	stateCurrentTurn        protowire.Number = 6
+	stateStatus             protowire.Number = 7
+	stateBettingRound       protowire.Number = 8
+	stateCurrentBet         protowire.Number = 9
+	stateActionSequence     protowire.Number = 10
+	stateBigBlind           protowire.Number = 11
+	statePotBB              protowire.Number = 12
+	stateCurrencySymbol     protowire.Number = 13
+	stateChipScale          protowire.Number = 14
+	stateAllowStraddle      protowire.Number = 15
+	stateDealerPosition     protowire.Number = 16
+	stateSmallBlindPosition protowire.Number = 17
+	stateBigBlindPosition   protowire.Number = 18
+	stateActionDeadline     protowire.Number = 19
+	stateWinners            protowire.Number = 20
+	stateExtra              protowire.Number = 21
+	stateBettingMode        protowire.Number = 22
+
+	playerUserID           protowire.Number = 1
+	playerUsername         protowire.Number = 2
+	playerSeatNumber       protowire.Number = 3
+	playerChips            protowire.Number = 4
+	playerStatus           protowire.Number = 5
+	playerCurrentBet       protowire.Number = 6
+	playerFolded           protowire.Number = 7
+	playerAllIn            protowire.Number = 8
+	playerIsDealer         protowire.Number = 9
+	playerLastAction       protowire.Number = 10
+	playerLastActionAmount protowire.Number = 11
+	playerAway             protowire.Number = 12
+	playerStraddle         protowire.Number = 13
+	playerDisconnected     protowire.Number = 14
+	playerTimeBank         protowire.Number = 15
+	playerChipsBB          protowire.Number = 16
+	playerCurrentBetBB     protowire.Number = 17
+	playerCards            protowire.Number = 18
+	playerLevel            protowire.Number = 19
+	playerAvatarURL        protowire.Number = 20
+	playerIsBot            protowire.Number = 21
+)
+
+// isBinaryFrame reports whether a queued message goes out as a binary frame.
+// JSON messages always start with '{', which no TableState can.
+func isBinaryFrame(message []byte) bool {
+	return len(message) > 0 && message[0] != '{'
+}
+
+// buildBinaryFrame encodes a table state message as a protobuf TableState,
+// for clients that negotiated binary_encoding. It carries what
+// buildTableStateFrame does, and protobuf lets the player fragments be
+// spliced in the same way.
+func buildBinaryFrame(
+	msgType string,
+	tableID string,
+	state *pokerModels.Table,
+	sumSidePots func([]pokerModels.SidePot) int,
+) *tableStateFrame {
+	frame := &tableStateFrame{owners: make(map[string]int), binary: true}
+	showdown := state.Status == pokerModels.StatusHandComplete
+	bigBlind := state.Config.BigBlind
+	timeBank := state.Config.TimeBank > 0
+	view := viewOf(state, sumSidePots)
+
+	var buf []byte
+	buf = appendStringField(buf, stateType, msgType)
+	buf = appendStringField(buf, stateTableID, tableID)
+	for _, card := range view.communityCards {
+		buf = protowire.AppendTag(buf, stateCommunityCards, protowire.BytesType)
+		buf = protowire.AppendString(buf, string(card.Rank)+string(card.Suit))
+	}
+	buf = appendIntField(buf, statePot, int64(view.pot))
+	if view.currentTurn != nil {
+		buf = appendStringField(buf, stateCurrentTurn, *view.currentTurn)
+	}
+	buf = appendStringField(buf, stateStatus, string(state.Status))
+	buf = appendStringField(buf, stateBettingRound, view.bettingRound)
+	buf = appendIntField(buf, stateCurrentBet, int64(view.currentBet))
+	if view.actionSequence != 0 {
+		buf = protowire.AppendTag(buf, stateActionSequence, protowire.VarintType)
+		buf = protowire.AppendVarint(buf, view.actionSequence)
+	}
+	buf = appendIntField(buf, stateBigBlind, int64(bigBlind))
+	if bigBlind > 0 {
+		buf = appendDoubleField(buf, statePotBB, BigBlinds(view.pot, bigBlind))
+	}
+	buf = appendStringField(buf, stateCurrencySymbol, state.Config.CurrencySymbol)
+	buf = appendIntField(buf, stateChipScale, int64(chipScale(state.Config)))
+	buf = appendBoolField(buf, stateAllowStraddle, state.Config.AllowStraddle)
+	buf = appendStringField(buf, stateBettingMode, state.Config.BettingMode)
+	if state.CurrentHand != nil {
+		// Optional fields are sent even when 0
+		for _, position := range []struct {
+			num   protowire.Number
+			value int
+		}{
+			{stateDealerPosition, state.CurrentHand.DealerPosition},
+			{stateSmallBlindPosition, state.CurrentHand.SmallBlindPosition},
+			{stateBigBlindPosition, state.CurrentHand.BigBlindPosition},
+		} {
+			buf = protowire.AppendTag(buf, position.num, protowire.VarintType)
+			buf = protowire.AppendVarint(buf, uint64(int64(position.value)))
+		}
+		if deadline := state.CurrentHand.ActionDeadline; deadline != nil && !deadline.IsZero() {
+			buf = appendIntField(buf, stateActionDeadline, deadline.UnixMilli())
+		}
+	}
+	if showdown && len(state.Winners) > 0 {
+		if winners, err := json.Marshal(state.PublicWinners()); err == nil {
+			buf = protowire.AppendTag(buf, stateWinners, protowire.BytesType)
+			buf = protowire.AppendBytes(buf, winners)
+		}
+	}
+	frame.prefix = buf
+
+	for _, p := range state.Players {
+		if p == nil {
+			continue
+		}
+
+		// Show the cards the table's showdown policy reveals once the hand is complete
+		revealed := showdown && state.ShowsCards(p)
+
+		public := appendBinaryPlayer(nil, p, bigBlind, timeBank, revealed)
+		var private []byte
+		if !revealed && len(p.Cards) > 0 {
+			private = appendBinaryPlayer(nil, p, bigBlind, timeBank, true)
+			frame.owners[p.PlayerID] = len(frame.public)
+		}
+		frame.ids = append(frame.ids, p.PlayerID)
+		frame.public = append(frame.public, public)
+		frame.private = append(frame.private, private)
+	}
+
+	frame.size = len(frame.prefix)
+	for i, fragment := range frame.public {
+		frame.size += len(fragment)
+		if len(frame.private[i]) > len(fragment) {
+			frame.size += len(frame.private[i]) - len(fragment)
+		}
+	}
+	frame.shared = frame.appendFor(make([]byte, 0, frame.size), -1)
+	return frame
+}
+
+// appendBinaryPlayer appends a player as a players entry of TableState
+func appendBinaryPlayer(dst []byte, p *pokerModels.Player, bigBlind int, timeBank, withCards bool) []byte {
+	var msg []byte
+	msg = appendStringField(msg, playerUserID, p.PlayerID)
+	msg = appendStringField(msg, playerUsername, p.PlayerName)
+	msg = appendIntField(msg, playerSeatNumber, int64(p.SeatNumber))
+	msg = appendIntField(msg, playerChips, int64(p.Chips))
+	msg = appendStringField(msg, playerStatus, string(p.Status))
+	msg = appendIntField(msg, playerCurrentBet, int64(p.Bet))
+	msg = appendBoolField(msg, playerFolded, p.Status == pokerModels.StatusFolded)
+	msg = appendBoolField(msg, playerAllIn, p.Status == pokerModels.StatusAllIn)
+	msg = appendBoolField(msg, playerIsDealer, p.IsDealer)
+	msg = appendStringField(msg, playerLastAction, string(p.LastAction))
+	msg = appendIntField(msg, playerLastActionAmount, int64(p.LastActionAmount))
+	msg = appendBoolField(msg, playerAway, p.Away)
+	msg = appendBoolField(msg, playerStraddle, p.IsStraddle)
+	msg = appendBoolField(msg, playerDisconnected, p.Disconnected)
+	msg = appendIntField(msg, playerLevel, int64(p.Level))
+	msg = appendStringField(msg, playerAvatarURL, p.AvatarURL)
+	msg = appendBoolField(msg, playerIsBot, p.IsBot)
+	if timeBank {
+		msg = protowire.AppendTag(msg, playerTimeBank, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(int64(p.TimeBank)))
+	}
+	if bigBlind > 0 {
+		msg = appendDoubleField(msg, playerChipsBB, BigBlinds(p.Chips, bigBlind))
+		msg = appendDoubleField(msg, playerCurrentBetBB, BigBlinds(p.Bet, bigBlind))
+	}
+	cards := p.ShownCards
+	if withCards {
+		cards = p.Cards
+	}
+	for _, card := range cards {
+		msg = protowire.AppendTag(msg, playerCards, protowire.BytesType)
+		msg = protowire.AppendString(msg, string(card.Rank)+string(card.Suit))
+	}
+
+	dst = protowire.AppendTag(dst, statePlayers, protowire.BytesType)
+	return protowire.AppendBytes(dst, msg)
+}
+
+// appendExtraField appends an extra_json map entry of TableState
+func appendExtraField(dst []byte, key string, value []byte) []byte {
+	var entry []byte
+	entry = appendStringField(entry, 1, key)
+	entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+	entry = protowire.AppendBytes(entry, value)
+
+	dst = protowire.AppendTag(dst, stateExtra, protowire.BytesType)
+	return protowire.AppendBytes(dst, entry)
+}
+
+// The append helpers leave out zero values, as proto3 does
+
+func appendStringField(dst []byte, num protowire.Number, value string) []byte {
+	if value == "" {
+		return dst
+	}
+	dst = protowire.AppendTag(dst, num, protowire.BytesType)
+	return protowire.AppendString(dst, value)
+}
+
+func appendIntField(dst []byte, num protowire.Number, value int64) []byte {
+	if value == 0 {
+		return dst
+	}
+	dst = protowire.AppendTag(dst, num, protowire.VarintType)
+	return protowire.AppendVarint(dst, uint64(value))
+}
+
+func appendBoolField(dst []byte, num protowire.Number, value bool) []byte {
+	if !value {
+		return dst
+	}
+	dst = protowire.AppendTag(dst, num, protowire.VarintType)
+	return protowire.AppendVarint(dst, protowire.EncodeBool(true))
+}
+
+func appendDoubleField(dst []byte, num protowire.Number, value float64) []byte {
+	if value == 0 {
+		return dst
+	}
+	dst = protowire.AppendTag(dst, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(dst, math.Float64bits(value))
+}
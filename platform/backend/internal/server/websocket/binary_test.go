@@ -0,0 +1,209 @@
+package websocket
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	pokerModels "poker-engine/models"
+)
+
+// protoFields decodes one protobuf message into its fields by number: the
+// bytes of length-delimited fields and the value of the rest
+func protoFields(t *testing.T, b []byte) map[protowire.Number][]interface{} {
+	t.Helper()
+	fields := map[protowire.Number][]interface{}{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatalf("Bad tag: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+		var value interface{}
+		switch typ {
+		case protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b)
+			value, n = v, m
+		case protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b)
+			value, n = math.Float64frombits(v), m
+		case protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b)
+			value, n = v, m
+		default:
+			t.Fatalf("Unexpected wire type %d of field %d", typ, num)
+		}
+		if n < 0 {
+			t.Fatalf("Bad field %d: %v", num, protowire.ParseError(n))
+		}
+		b = b[n:]
+		fields[num] = append(fields[num], value)
+	}
+	return fields
+}
+
+func protoString(fields map[protowire.Number][]interface{}, num protowire.Number) string {
+	values := fields[num]
+	if len(values) == 0 {
+		return ""
+	}
+	return string(values[len(values)-1].([]byte))
+}
+
+func protoStrings(fields map[protowire.Number][]interface{}, num protowire.Number) []string {
+	var values []string
+	for _, v := range fields[num] {
+		values = append(values, string(v.([]byte)))
+	}
+	return values
+}
+
+// binaryPlayers decodes the players of a TableState by user ID
+func binaryPlayers(t *testing.T, fields map[protowire.Number][]interface{}) map[string]map[protowire.Number][]interface{} {
+	players := map[string]map[protowire.Number][]interface{}{}
+	for _, raw := range fields[statePlayers] {
+		player := protoFields(t, raw.([]byte))
+		players[protoString(player, playerUserID)] = player
+	}
+	return players
+}
+
+func TestBinaryFrame_Fields(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	state.Config.TimeBank = 30
+	state.Players[3].Level = 4
+	state.Players[3].AvatarURL = "/avatars/user-3/a.png"
+	state.Players[4].IsBot = true
+	frame := buildBinaryFrame("game_update", "table-1", state, sumSidePotsForTest)
+
+	message := frame.messageFor("user-3")
+	if !isBinaryFrame(message) || isBinaryFrame(legacyGameUpdate("table-1", state, "user-3")) {
+		t.Fatal("Expected only the protobuf message to go out as a binary frame")
+	}
+	fields := protoFields(t, message)
+
+	if protoString(fields, stateType) != "game_update" || protoString(fields, stateTableID) != "table-1" {
+		t.Errorf("Unexpected type or table: %v", fields)
+	}
+	if !reflect.DeepEqual(protoStrings(fields, stateCommunityCards), []string{"As", "Kh", "2c"}) {
+		t.Errorf("Unexpected community cards %v", protoStrings(fields, stateCommunityCards))
+	}
+	if fields[statePot][0] != uint64(350) || fields[stateCurrentBet][0] != uint64(40) || fields[stateActionSequence][0] != uint64(17) {
+		t.Errorf("Unexpected pot, bet or sequence: %v %v %v", fields[statePot], fields[stateCurrentBet], fields[stateActionSequence])
+	}
+	if protoString(fields, stateCurrentTurn) != "user-3" || protoString(fields, stateCurrencySymbol) != "€" {
+		t.Errorf("Unexpected turn or currency: %v", fields)
+	}
+	if fields[statePotBB][0] != 17.5 || fields[stateChipScale][0] != uint64(100) {
+		t.Errorf("Unexpected pot in big blinds or chip scale: %v %v", fields[statePotBB], fields[stateChipScale])
+	}
+	// The dealer's seat 0 is still sent
+	if len(fields[stateDealerPosition]) != 1 || fields[stateDealerPosition][0] != uint64(0) {
+		t.Errorf("Expected dealer position 0 to be present, got %v", fields[stateDealerPosition])
+	}
+	if fields[stateActionDeadline][0] != uint64(state.CurrentHand.ActionDeadline.UnixMilli()) {
+		t.Errorf("Unexpected deadline %v", fields[stateActionDeadline])
+	}
+
+	players := binaryPlayers(t, fields)
+	if len(players) != 9 {
+		t.Fatalf("Expected 9 players, got %d", len(players))
+	}
+	me := players["user-3"]
+	if protoString(me, playerUsername) != `Player "3" <x>` || me[playerChips][0] != uint64(1003) || me[playerTimeBank] == nil || me[playerLevel][0] != uint64(4) ||
+		protoString(me, playerAvatarURL) != "/avatars/user-3/a.png" {
+		t.Errorf("Unexpected player: %v", me)
+	}
+	if players["user-4"][playerIsBot] == nil || me[playerIsBot] != nil {
+		t.Errorf("Expected only user-4 marked as a bot")
+	}
+	if !reflect.DeepEqual(protoStrings(me, playerCards), []string{"Qd", "Th"}) {
+		t.Errorf("Expected the viewer's own cards, got %v", protoStrings(me, playerCards))
+	}
+	for userID, player := range players {
+		if userID != "user-3" && player[playerCards] != nil {
+			t.Errorf("Expected %s's cards to be hidden from user-3", userID)
+		}
+	}
+	if players["user-4"][playerFolded] == nil || players["user-0"][playerIsDealer] == nil {
+		t.Error("Expected folded and dealer flags")
+	}
+}
+
+func TestBinaryFrame_Showdown(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusHandComplete)
+	fields := protoFields(t, buildBinaryFrame("game_update", "table-1", state, sumSidePotsForTest).messageFor(""))
+
+	for userID, player := range binaryPlayers(t, fields) {
+		if shown := player[playerCards] != nil; shown == (userID == "user-4") {
+			t.Errorf("Expected everyone but the folded player to show at showdown, %s shown=%v", userID, shown)
+		}
+	}
+	var winners []pokerModels.Winner
+	if err := json.Unmarshal([]byte(protoString(fields, stateWinners)), &winners); err != nil || len(winners) != 1 || winners[0].PlayerID != "user-1" {
+		t.Errorf("Unexpected winners %v (%v)", winners, err)
+	}
+}
+
+func TestBinaryFrame_AppendField(t *testing.T) {
+	frame := buildBinaryFrame("table_state", "table-1", newEncoderTestState(pokerModels.StatusPlaying), sumSidePotsForTest)
+	frame.appendField("stats", map[string]int{"hands": 12})
+	frame.appendField("nothing", nil)
+
+	for _, viewer := range []string{"", "user-3"} {
+		fields := protoFields(t, frame.messageFor(viewer))
+		if len(fields[stateExtra]) != 1 {
+			t.Fatalf("Expected one extra field for %q, got %d", viewer, len(fields[stateExtra]))
+		}
+		entry := protoFields(t, fields[stateExtra][0].([]byte))
+		if protoString(entry, 1) != "stats" || protoString(entry, 2) != `{"hands":12}` {
+			t.Errorf("Unexpected extra field %v", entry)
+		}
+		if len(binaryPlayers(t, fields)) != 9 {
+			t.Errorf("Expected the players to survive the extra field")
+		}
+	}
+}
+
+// listRegistry is a Registry over a fixed list of clients
+type listRegistry []*Client
+
+func (l listRegistry) Add(string, string, interface{}) ([]interface{}, int) { return nil, len(l) }
+func (l listRegistry) Remove(string, string) (int, bool)                    { return len(l), false }
+func (l listRegistry) Each(fn func(string, interface{})) {
+	for _, c := range l {
+		fn(c.UserID, c)
+	}
+}
+
+func TestBroadcastSnapshot_EncodingPerClient(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	jsonClient := &Client{UserID: "user-1", TableID: "table-1", Send: make(chan []byte, 4), done: make(chan struct{})}
+	binaryClient := &Client{UserID: "user-2", TableID: "table-1", Send: make(chan []byte, 4), done: make(chan struct{})}
+	binaryClient.setCapabilities(ProtocolVersion, []string{FeatureBinary})
+
+	BroadcastSnapshot("table-1", state, listRegistry{jsonClient, binaryClient}, sumSidePotsForTest, nil)
+
+	if data := <-jsonClient.Send; isBinaryFrame(data) {
+		t.Error("Expected JSON for a client without binary_encoding")
+	}
+	fields := protoFields(t, <-binaryClient.Send)
+	if protoString(fields, stateType) != "game_update" || binaryPlayers(t, fields)["user-2"][playerCards] == nil {
+		t.Errorf("Expected a binary game_update with user-2's cards, got %v", fields)
+	}
+}
+
+func BenchmarkBroadcast_Binary(b *testing.B) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	viewers := []string{"user-0", "user-1", "user-2", "user-3", "user-4", "user-5", "user-6", "user-7", "user-8", "spectator"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		frame := buildBinaryFrame("game_update", "table-1", state, sumSidePotsForTest)
+		for _, viewer := range viewers {
+			_ = frame.messageFor(viewer)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package websocket
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Protocol versions understood by the server. Clients that never send hello
+// are treated as MinProtocolVersion with no optional features.
+const (
+	MinProtocolVersion = 1
+	ProtocolVersion    = 1
+)
+
+// Optional protocol features a client can declare in its hello message
+const (
+	FeatureActionRequired = "action_required" // Priority turn notifications
+	FeatureActionAck      = "action_ack"      // Client acks action_required; unacked turns mark the player away
+	FeatureDeltaUpdates   = "delta_updates"   // State diffs instead of full game_update frames
+	FeatureBinary         = "binary_encoding" // Binary frames instead of JSON text
+	FeatureEquityDisplay  = "equity_display"  // All-in equity in showdown updates
+)
+
+// serverFeatures are the optional features this server can currently serve.
+// Features are rolled out by adding them here; clients only get the ones
+// they declared.
+var serverFeatures = map[string]bool{
+	FeatureActionRequired: true,
+	FeatureActionAck:      true,
+	FeatureDeltaUpdates:   true,
+	FeatureBinary:         true,
+}
+
+// Subprotocols a client can offer on /ws instead of sending hello:
+// "poker.v1" for JSON, and "poker.v1.protobuf" to also negotiate
+// binary_encoding. The number is the protocol version.
+const (
+	subprotocolPrefix   = "poker.v"
+	subprotocolProtobuf = ".protobuf"
+)
+
+// requestedProtocol is what a client asked for when connecting to /ws
+type requestedProtocol struct {
+	explicit    bool // Asked for anything at all
+	version     int
+	features    []string
+	subprotocol string // To answer with
+}
+
+// negotiateRequest negotiates the protocol a client asks for in its /ws
+// request, through the protocol_version and features query parameters or a
+// subprotocol. The first subprotocol offered that the server speaks is
+// chosen; offering only unknown versions is an error. A query version wins
+// over the subprotocol's.
+func negotiateRequest(r *http.Request) (requestedProtocol, error) {
+	var req requestedProtocol
+	clientVersion, versionGiven := MinProtocolVersion, false
+	var clientFeatures []string
+
+	query := r.URL.Query()
+	if raw := query.Get("protocol_version"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return req, fmt.Errorf("invalid protocol_version %q", raw)
+		}
+		clientVersion, versionGiven = v, true
+		req.explicit = true
+	}
+	if raw := query.Get("features"); raw != "" {
+		for _, feature := range strings.Split(raw, ",") {
+			if feature = strings.TrimSpace(feature); feature != "" {
+				clientFeatures = append(clientFeatures, feature)
+			}
+		}
+		req.explicit = true
+	}
+
+	offered := false
+	for _, protocol := range websocket.Subprotocols(r) {
+		if !strings.HasPrefix(protocol, subprotocolPrefix) {
+			continue
+		}
+		offered = true
+		name := strings.TrimPrefix(protocol, subprotocolPrefix)
+		binary := strings.HasSuffix(name, subprotocolProtobuf)
+		v, err := strconv.Atoi(strings.TrimSuffix(name, subprotocolProtobuf))
+		if err != nil || v < MinProtocolVersion || v > ProtocolVersion {
+			continue
+		}
+		req.subprotocol = protocol
+		req.explicit = true
+		if !versionGiven {
+			clientVersion = v
+		}
+		if binary {
+			clientFeatures = append(clientFeatures, FeatureBinary)
+		}
+		break
+	}
+	if offered && req.subprotocol == "" {
+		return req, fmt.Errorf("no supported subprotocol offered")
+	}
+
+	if !req.explicit {
+		return req, nil
+	}
+	version, accepted, ok := negotiate(clientVersion, clientFeatures)
+	if !ok {
+		return req, fmt.Errorf("unsupported protocol version %d", clientVersion)
+	}
+	req.version = version
+	req.features = accepted
+	return req, nil
+}
+
+// capabilities holds what was negotiated with a client
+type capabilities struct {
+	mu       sync.RWMutex
+	version  int
+	features map[string]bool
+}
+
+// HasFeature reports whether the client negotiated an optional feature
+func (c *Client) HasFeature(feature string) bool {
+	c.caps.mu.RLock()
+	defer c.caps.mu.RUnlock()
+	return c.caps.features[feature]
+}
+
+// ProtocolVersion returns the negotiated protocol version
+func (c *Client) ProtocolVersion() int {
+	c.caps.mu.RLock()
+	defer c.caps.mu.RUnlock()
+	if c.caps.version == 0 {
+		return MinProtocolVersion
+	}
+	return c.caps.version
+}
+
+// ServerFeatures returns the optional features the server supports, sorted
+func ServerFeatures() []string {
+	features := make([]string, 0, len(serverFeatures))
+	for feature := range serverFeatures {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+	return features
+}
+
+// negotiate picks the protocol version and the declared features the server supports
+func negotiate(clientVersion int, clientFeatures []string) (int, []string, bool) {
+	if clientVersion < MinProtocolVersion {
+		return 0, nil, false
+	}
+	version := clientVersion
+	if version > ProtocolVersion {
+		version = ProtocolVersion
+	}
+
+	accepted := []string{}
+	seen := make(map[string]bool)
+	for _, feature := range clientFeatures {
+		if serverFeatures[feature] && !seen[feature] {
+			seen[feature] = true
+			accepted = append(accepted, feature)
+		}
+	}
+	sort.Strings(accepted)
+	return version, accepted, true
+}
+
+// HandleHello negotiates the protocol version and features with a client and
+// replies with hello_ack. Payload: {"protocol_version": 1, "features": [...]}.
+func HandleHello(c *Client, payload interface{}) {
+	data, ok := payload.(map[string]interface{})
+	if !ok {
+		SendToClient(c, WSMessage{
+			Type: "error",
+			Payload: map[string]interface{}{
+				"message": "Invalid message format",
+				"code":    "INVALID_PAYLOAD",
+			},
+		})
+		return
+	}
+
+	clientVersion := MinProtocolVersion
+	if raw, ok := data["protocol_version"].(float64); ok {
+		clientVersion = int(raw)
+	}
+
+	var clientFeatures []string
+	if raw, ok := data["features"].([]interface{}); ok {
+		for _, feature := range raw {
+			if name, ok := feature.(string); ok {
+				clientFeatures = append(clientFeatures, name)
+			}
+		}
+	}
+
+	version, accepted, ok := negotiate(clientVersion, clientFeatures)
+	if !ok {
+		log.Printf("[WS_HELLO] User %s sent unsupported protocol version %d", c.UserID, clientVersion)
+		SendToClient(c, WSMessage{
+			Type: "error",
+			Payload: map[string]interface{}{
+				"message":              "Unsupported protocol version",
+				"code":                 "UNSUPPORTED_PROTOCOL",
+				"min_protocol_version": MinProtocolVersion,
+				"protocol_version":     ProtocolVersion,
+			},
+		})
+		return
+	}
+
+	c.setCapabilities(version, accepted)
+	log.Printf("[WS_HELLO] User %s negotiated protocol v%d with features %v", c.UserID, version, accepted)
+	sendHelloAck(c, version, accepted)
+}
+
+// setCapabilities records what was negotiated with a client
+func (c *Client) setCapabilities(version int, accepted []string) {
+	features := make(map[string]bool, len(accepted))
+	for _, feature := range accepted {
+		features[feature] = true
+	}
+	c.caps.mu.Lock()
+	c.caps.version = version
+	c.caps.features = features
+	c.caps.mu.Unlock()
+}
+
+// sendHelloAck tells a client what was negotiated
+func sendHelloAck(c *Client, version int, accepted []string) {
+	SendToClient(c, WSMessage{
+		Type: "hello_ack",
+		Payload: map[string]interface{}{
+			"protocol_version": version,
+			"features":         accepted,
+			"server_features":  ServerFeatures(),
+			"connection_id":    c.ConnectionID,
+		},
+	})
+}
@@ -0,0 +1,119 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	version, features, ok := negotiate(ProtocolVersion+5, []string{FeatureEquityDisplay, FeatureActionRequired, FeatureActionRequired, "unknown"})
+	if !ok {
+		t.Fatal("Expected a newer client to be accepted")
+	}
+	if version != ProtocolVersion {
+		t.Errorf("Expected version capped at %d, got %d", ProtocolVersion, version)
+	}
+	if !reflect.DeepEqual(features, []string{FeatureActionRequired}) {
+		t.Errorf("Expected only supported features once, got %v", features)
+	}
+
+	if _, _, ok := negotiate(MinProtocolVersion-1, nil); ok {
+		t.Error("Expected versions below the minimum to be rejected")
+	}
+}
+
+func TestHandleHello_NegotiatesAndAcks(t *testing.T) {
+	client := &Client{UserID: "user-1", ConnectionID: "conn-1", Send: make(chan []byte, 4), done: make(chan struct{})}
+	if client.HasFeature(FeatureActionRequired) || client.ProtocolVersion() != MinProtocolVersion {
+		t.Fatal("Expected a client without hello to have no features")
+	}
+
+	HandleHello(client, map[string]interface{}{
+		"protocol_version": float64(1),
+		"features":         []interface{}{FeatureActionRequired, FeatureEquityDisplay},
+	})
+
+	if !client.HasFeature(FeatureActionRequired) {
+		t.Error("Expected action_required to be negotiated")
+	}
+	if client.HasFeature(FeatureEquityDisplay) {
+		t.Error("Expected features the server does not serve to be refused")
+	}
+
+	var ack struct {
+		Type    string `json:"type"`
+		Payload struct {
+			ProtocolVersion int      `json:"protocol_version"`
+			Features        []string `json:"features"`
+			ServerFeatures  []string `json:"server_features"`
+			ConnectionID    string   `json:"connection_id"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(<-client.Send, &ack); err != nil {
+		t.Fatalf("Failed to decode hello_ack: %v", err)
+	}
+	if ack.Type != "hello_ack" || ack.Payload.ProtocolVersion != 1 || ack.Payload.ConnectionID != "conn-1" {
+		t.Errorf("Unexpected hello_ack: %+v", ack)
+	}
+	if !reflect.DeepEqual(ack.Payload.Features, []string{FeatureActionRequired}) {
+		t.Errorf("Expected accepted features [action_required], got %v", ack.Payload.Features)
+	}
+}
+
+func TestHandleHello_RejectsOldProtocol(t *testing.T) {
+	client := &Client{UserID: "user-1", Send: make(chan []byte, 4), done: make(chan struct{})}
+	HandleHello(client, map[string]interface{}{"protocol_version": float64(0)})
+
+	var reply struct {
+		Type    string                 `json:"type"`
+		Payload map[string]interface{} `json:"payload"`
+	}
+	json.Unmarshal(<-client.Send, &reply)
+	if reply.Type != "error" || reply.Payload["code"] != "UNSUPPORTED_PROTOCOL" {
+		t.Errorf("Expected UNSUPPORTED_PROTOCOL error, got %+v", reply)
+	}
+}
+
+func TestNegotiateRequest(t *testing.T) {
+	request := func(query string, subprotocols ...string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/ws?token=x"+query, nil)
+		if len(subprotocols) > 0 {
+			r.Header.Set("Sec-WebSocket-Protocol", strings.Join(subprotocols, ", "))
+		}
+		return r
+	}
+
+	req, err := negotiateRequest(request(""))
+	if err != nil || req.explicit {
+		t.Fatalf("Expected a plain connection to leave it to hello, got %+v (%v)", req, err)
+	}
+
+	req, err = negotiateRequest(request("&protocol_version=1&features=binary_encoding,unknown"))
+	if err != nil || req.version != 1 || !reflect.DeepEqual(req.features, []string{FeatureBinary}) || req.subprotocol != "" {
+		t.Errorf("Expected v1 with binary_encoding from the query, got %+v (%v)", req, err)
+	}
+
+	req, err = negotiateRequest(request("", "graphql-ws", "poker.v9", "poker.v1.protobuf", "poker.v1"))
+	if err != nil || req.subprotocol != "poker.v1.protobuf" || !reflect.DeepEqual(req.features, []string{FeatureBinary}) {
+		t.Errorf("Expected the first supported subprotocol to be chosen, got %+v (%v)", req, err)
+	}
+
+	req, err = negotiateRequest(request("&features=action_required", "poker.v1"))
+	if err != nil || req.subprotocol != "poker.v1" || !reflect.DeepEqual(req.features, []string{FeatureActionRequired}) {
+		t.Errorf("Expected JSON with the query's features, got %+v (%v)", req, err)
+	}
+
+	if _, err := negotiateRequest(request("", "poker.v9")); err == nil {
+		t.Error("Expected only unknown versions to be rejected")
+	}
+	if _, err := negotiateRequest(request("&protocol_version=0")); err == nil {
+		t.Error("Expected a version below the minimum to be rejected")
+	}
+	if _, err := negotiateRequest(request("&protocol_version=one")); err == nil {
+		t.Error("Expected a malformed version to be rejected")
+	}
+}
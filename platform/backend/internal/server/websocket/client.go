@@ -2,27 +2,51 @@ package websocket
 
 import (
 	"sync"
+	"time"
+
+	"poker-platform/backend/internal/chaos"
 
 	"github.com/gorilla/websocket"
 )
 
+// closeWriteTimeout bounds how long WritePump waits to deliver the close frame
+const closeWriteTimeout = time.Second
+
 // Client represents a WebSocket client connection
 type Client struct {
 	UserID  string
 	TableID string
+	CardsUp bool // Production client of a broadcast table: delayed feed with all hole cards
 	Conn    *websocket.Conn
-	Send    chan []byte
+	Send    chan []byte // Normal priority queue (table state and most messages)
+
+	ConnectionID string // Unique per socket, recorded in the audit trail
+	IPAddress    string
+	UserAgent    string
+
+	caps  capabilities // Protocol version and features negotiated in hello
+	delta deltaState   // Last table state sent, with delta_updates
+
+	priority chan []byte // High priority queue, drained before Send
+	bulk     chan []byte // Low priority queue, drained only when Send is empty
+
+	done        chan struct{} // Closed by Disconnect to make WritePump send a close frame
+	closeOnce   sync.Once
+	closeCode   int // Set by Disconnect before done is closed
+	closeReason string
 }
 
-// ReadPump handles incoming messages from the client
-// CRITICAL: Mutex protection added to prevent concurrent map access panics
-func (c *Client) ReadPump(clients map[string]interface{}, mu *sync.RWMutex, handleMessage func(*Client, WSMessage)) {
+// ReadPump handles incoming messages from the client and unregisters it when
+// the connection ends. onConnection, when set, is told once the user has no
+// connection left.
+func (c *Client) ReadPump(clients Registry, handleMessage func(*Client, WSMessage), onConnection func(userID string, connected bool)) {
 	defer func() {
-		// CRITICAL: Protect map deletion with mutex to prevent server crashes
-		mu.Lock()
-		delete(clients, c.UserID)
-		mu.Unlock()
+		// A connection evicted by a newer one is already gone from the registry
+		remaining, removed := clients.Remove(c.UserID, c.ConnectionID)
 		c.Conn.Close()
+		if removed && remaining == 0 && onConnection != nil {
+			onConnection(c.UserID, false)
+		}
 	}()
 
 	for {
@@ -33,6 +57,9 @@ func (c *Client) ReadPump(clients map[string]interface{}, mu *sync.RWMutex, hand
 		}
 
 		handleMessage(c, msg)
+		if chaos.WSRead(c.UserID) {
+			break
+		}
 	}
 }
 
@@ -41,14 +68,26 @@ func (c *Client) WritePump() {
 	defer c.Conn.Close()
 
 	for {
-		select {
-		case message, ok := <-c.Send:
-			if !ok {
-				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
-			c.Conn.WriteMessage(websocket.TextMessage, message)
+		message, ok, closed := c.nextMessage()
+		if closed {
+			c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+			return
+		}
+		if !ok {
+			c.Conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(c.closeCode, c.closeReason),
+				time.Now().Add(closeWriteTimeout))
+			return
+		}
+		if chaos.WSWrite(c.UserID) {
+			continue
 		}
+		auditLeak(c, message)
+		frameType := websocket.TextMessage
+		if isBinaryFrame(message) {
+			frameType = websocket.BinaryMessage
+		}
+		c.Conn.WriteMessage(frameType, message)
 	}
 }
 
@@ -57,6 +96,11 @@ func (c *Client) GetTableID() string {
 	return c.TableID
 }
 
+// GetCardsUp reports whether the client gets the cards-up production feed
+func (c *Client) GetCardsUp() bool {
+	return c.CardsUp
+}
+
 // GetSendChannel returns the send channel for this client
 func (c *Client) GetSendChannel() chan []byte {
 	return c.Send
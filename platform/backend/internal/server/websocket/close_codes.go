@@ -0,0 +1,77 @@
+package websocket
+
+import (
+	"log"
+)
+
+// Application close codes sent in the WebSocket close frame (4000-4999 is the
+// private range reserved by RFC 6455). Clients use them to show why they were
+// disconnected and to decide whether reconnecting makes sense.
+const (
+	CloseKicked         = 4000 // Removed by a moderator; do not reconnect automatically
+	CloseBanned         = 4001 // Account banned or suspended; do not reconnect
+	CloseDuplicateLogin = 4002 // Closed to make room for a newer connection of the same user; do not reconnect
+	CloseServerRestart  = 4003 // Server shutting down or restarting; reconnect with backoff
+	CloseSlowConsumer   = 4004 // Client fell too far behind on messages; reconnect and resubscribe
+)
+
+// closeReasons holds the default reason text for each application close code
+var closeReasons = map[int]string{
+	CloseKicked:         "kicked",
+	CloseBanned:         "banned",
+	CloseDuplicateLogin: "session_taken_over",
+	CloseServerRestart:  "server_restart",
+	CloseSlowConsumer:   "slow_consumer",
+}
+
+// CloseReason returns the default reason text for an application close code
+func CloseReason(code int) string {
+	return closeReasons[code]
+}
+
+// ShouldReconnect reports whether a client closed with code should reconnect automatically
+func ShouldReconnect(code int) bool {
+	switch code {
+	case CloseKicked, CloseBanned, CloseDuplicateLogin:
+		return false
+	default:
+		return true
+	}
+}
+
+// Disconnect makes WritePump send a close frame with the given code and reason
+// and close the connection. Send is left open so concurrent senders never hit a
+// closed channel. Safe to call more than once; only the first call takes effect.
+func (c *Client) Disconnect(code int, reason string) {
+	c.closeOnce.Do(func() {
+		if reason == "" {
+			reason = CloseReason(code)
+		}
+		c.closeCode = code
+		c.closeReason = reason
+		log.Printf("[WS_CLOSE] Disconnecting user %s: code=%d reason=%s", c.UserID, code, reason)
+		close(c.done)
+	})
+}
+
+// NotifyAll queues a message for every connected client at the priority of its
+// type and returns how many clients it was queued for
+func NotifyAll(clients Registry, msg WSMessage) int {
+	queued := 0
+	priority := PriorityFor(msg.Type)
+	clients.Each(func(_ string, clientInterface interface{}) {
+		if client, ok := clientInterface.(*Client); ok && SendWithPriority(client, msg, priority) {
+			queued++
+		}
+	})
+	return queued
+}
+
+// DisconnectAll disconnects every connected client with the same close code
+func DisconnectAll(clients Registry, code int, reason string) {
+	clients.Each(func(_ string, clientInterface interface{}) {
+		if client, ok := clientInterface.(*Client); ok {
+			client.Disconnect(code, reason)
+		}
+	})
+}
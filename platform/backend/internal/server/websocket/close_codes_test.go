@@ -0,0 +1,66 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestDisconnect_SendsCloseCodeAndReason(t *testing.T) {
+	connected := make(chan *Client, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		client := &Client{UserID: "user-1", Conn: conn, Send: make(chan []byte, 1), done: make(chan struct{})}
+		go client.WritePump()
+		connected <- client
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	client := <-connected
+	client.Disconnect(CloseDuplicateLogin, "")
+	client.Disconnect(CloseKicked, "ignored") // only the first call counts
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err = conn.ReadMessage()
+	closeErr, ok := err.(*websocket.CloseError)
+	if !ok {
+		t.Fatalf("Expected close error, got %v", err)
+	}
+	if closeErr.Code != CloseDuplicateLogin || closeErr.Text != "session_taken_over" {
+		t.Errorf("Expected %d session_taken_over, got %d %q", CloseDuplicateLogin, closeErr.Code, closeErr.Text)
+	}
+
+	// Senders must not panic after a disconnect
+	SendToClient(client, WSMessage{Type: "ping"})
+}
+
+func TestShouldReconnect(t *testing.T) {
+	cases := map[int]bool{
+		CloseKicked:                    false,
+		CloseBanned:                    false,
+		CloseDuplicateLogin:            false,
+		CloseServerRestart:             true,
+		CloseSlowConsumer:              true,
+		websocket.CloseGoingAway:       true,
+		websocket.CloseAbnormalClosure: true,
+	}
+	for code, want := range cases {
+		if got := ShouldReconnect(code); got != want {
+			t.Errorf("ShouldReconnect(%d) = %v, want %v", code, got, want)
+		}
+	}
+}
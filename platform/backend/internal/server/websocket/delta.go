@@ -0,0 +1,176 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// deltaFullEvery is how many game_delta messages a client gets between full
+// game_update snapshots, so one that went wrong recovers on its own
+const deltaFullEvery = 20
+
+// deltaState is what a client that negotiated delta_updates was last sent
+type deltaState struct {
+	mu        sync.Mutex
+	tableID   string // Empty until the next message is a full snapshot
+	seq       uint64
+	sinceFull int
+	fields    map[string]json.RawMessage
+	ids       []string
+	players   [][]byte
+}
+
+// ResetDelta makes the next table state broadcast to the client a full
+// game_update, as after a resync
+func (c *Client) ResetDelta() {
+	c.delta.mu.Lock()
+	c.delta.tableID = ""
+	c.delta.mu.Unlock()
+}
+
+// sendDelta queues a broadcast for a delta_updates client: a game_delta with
+// what changed since the last message, or a full game_update when the seats
+// changed, a snapshot is due or the delta would be no smaller. Both carry the
+// next seq. It reports false when the client's queue is full.
+func (c *Client) sendDelta(tableID string, frame *tableStateFrame) bool {
+	d := &c.delta
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fields := frame.payloadFields()
+	players := frame.fragmentsFor(c.UserID)
+	seq := d.seq + 1
+
+	var data []byte
+	if d.tableID == tableID && d.sinceFull < deltaFullEvery && equalIDs(d.ids, frame.ids) {
+		data = appendDelta(make([]byte, 0, 512), tableID, seq, d.fields, fields, d.players, players)
+	}
+	if data == nil || len(data) >= frame.size {
+		data = frame.messageWithSeq(c.UserID, seq)
+		d.sinceFull = 0
+	} else {
+		d.sinceFull++
+	}
+
+	auditLeak(c, data)
+	select {
+	case c.Send <- data:
+	default:
+		d.tableID = ""
+		return false
+	}
+	d.tableID, d.seq = tableID, seq
+	d.fields, d.ids, d.players = fields, frame.ids, players
+	return true
+}
+
+// appendDelta encodes a game_delta: the payload fields that were set or
+// changed, those that are gone, and each player whose object changed in full
+func appendDelta(dst []byte, tableID string, seq uint64, oldFields, fields map[string]json.RawMessage, oldPlayers, players [][]byte) []byte {
+	dst = append(dst, `{"type":"game_delta","payload":{"table_id":`...)
+	dst = appendJSONString(dst, tableID)
+	dst = append(dst, `,"seq":`...)
+	dst = strconv.AppendUint(dst, seq, 10)
+
+	dst = append(dst, `,"set":{`...)
+	first := true
+	for _, key := range sortedKeys(fields) {
+		if old, ok := oldFields[key]; ok && bytes.Equal(old, fields[key]) {
+			continue
+		}
+		if !first {
+			dst = append(dst, ',')
+		}
+		first = false
+		dst = appendJSONString(dst, key)
+		dst = append(dst, ':')
+		dst = append(dst, fields[key]...)
+	}
+
+	dst = append(dst, `},"unset":[`...)
+	first = true
+	for _, key := range sortedKeys(oldFields) {
+		if _, ok := fields[key]; ok {
+			continue
+		}
+		if !first {
+			dst = append(dst, ',')
+		}
+		first = false
+		dst = appendJSONString(dst, key)
+	}
+
+	dst = append(dst, `],"players":[`...)
+	first = true
+	for i, fragment := range players {
+		if bytes.Equal(oldPlayers[i], fragment) {
+			continue
+		}
+		if !first {
+			dst = append(dst, ',')
+		}
+		first = false
+		dst = append(dst, fragment...)
+	}
+	return append(dst, "]}}"...)
+}
+
+// payloadFields decodes the payload fields of a JSON frame other than
+// players, once per frame
+func (f *tableStateFrame) payloadFields() map[string]json.RawMessage {
+	f.fieldsOnce.Do(func() {
+		// The suffix is `],"table_id":...}}`, which is an object once the
+		// leading "]," and the outer '}' are swapped for braces
+		object := make([]byte, 0, len(f.suffix))
+		object = append(object, '{')
+		object = append(object, f.suffix[2:len(f.suffix)-1]...)
+		if err := json.Unmarshal(object, &f.fields); err != nil {
+			f.fields = map[string]json.RawMessage{}
+		}
+	})
+	return f.fields
+}
+
+// fragmentsFor lists the player objects a viewer is sent, in seat order
+func (f *tableStateFrame) fragmentsFor(viewerID string) [][]byte {
+	fragments := make([][]byte, len(f.public))
+	copy(fragments, f.public)
+	if index, ok := f.owners[viewerID]; ok {
+		fragments[index] = f.private[index]
+	}
+	return fragments
+}
+
+// messageWithSeq returns a viewer's full message with seq added to the payload
+func (f *tableStateFrame) messageWithSeq(viewerID string, seq uint64) []byte {
+	message := f.messageFor(viewerID)
+	dst := make([]byte, 0, len(message)+24)
+	dst = append(dst, message[:len(message)-2]...)
+	dst = append(dst, `,"seq":`...)
+	dst = strconv.AppendUint(dst, seq, 10)
+	return append(dst, "}}"...)
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
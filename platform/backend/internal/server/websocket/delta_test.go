@@ -0,0 +1,181 @@
+package websocket
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	pokerModels "poker-engine/models"
+)
+
+// deltaClient applies what a delta_updates client is sent, as a client would
+type deltaClient struct {
+	t       *testing.T
+	seq     float64
+	payload map[string]interface{}
+}
+
+func (d *deltaClient) apply(data []byte) string {
+	d.t.Helper()
+	var msg struct {
+		Type    string                 `json:"type"`
+		Payload map[string]interface{} `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		d.t.Fatalf("Bad message %s: %v", data, err)
+	}
+	seq := msg.Payload["seq"].(float64)
+	delete(msg.Payload, "seq")
+
+	switch msg.Type {
+	case "game_update":
+		d.payload = msg.Payload
+	case "game_delta":
+		if seq != d.seq+1 {
+			d.t.Fatalf("Expected seq %v, got %v", d.seq+1, seq)
+		}
+		for key, value := range msg.Payload["set"].(map[string]interface{}) {
+			d.payload[key] = value
+		}
+		for _, key := range msg.Payload["unset"].([]interface{}) {
+			delete(d.payload, key.(string))
+		}
+		players := d.payload["players"].([]interface{})
+		for _, changed := range msg.Payload["players"].([]interface{}) {
+			for i, p := range players {
+				if p.(map[string]interface{})["user_id"] == changed.(map[string]interface{})["user_id"] {
+					players[i] = changed
+				}
+			}
+		}
+	default:
+		d.t.Fatalf("Unexpected message type %s", msg.Type)
+	}
+	d.seq = seq
+	return msg.Type
+}
+
+// fullPayload decodes the full game_update payload a viewer would be sent
+func fullPayload(t *testing.T, state *pokerModels.Table, viewerID string) map[string]interface{} {
+	var msg struct {
+		Payload map[string]interface{} `json:"payload"`
+	}
+	frame := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+	if err := json.Unmarshal(frame.messageFor(viewerID), &msg); err != nil {
+		t.Fatalf("Bad full message: %v", err)
+	}
+	return msg.Payload
+}
+
+func newDeltaClient(userID string) *Client {
+	client := &Client{UserID: userID, TableID: "table-1", Send: make(chan []byte, 64), done: make(chan struct{})}
+	client.setCapabilities(ProtocolVersion, []string{FeatureDeltaUpdates})
+	return client
+}
+
+func TestBroadcastSnapshot_Deltas(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	client := newDeltaClient("user-3")
+	registry := listRegistry{client}
+	applied := &deltaClient{t: t}
+
+	BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+	full := <-client.Send
+	if applied.apply(full) != "game_update" {
+		t.Fatal("Expected the first broadcast to be a full game_update")
+	}
+
+	// user-3 calls and the turn moves on
+	state.Players[3].Chips -= 40
+	state.Players[3].Bet = 40
+	state.CurrentHand.Pot.Main += 40
+	state.CurrentHand.CurrentPosition = 5
+	state.CurrentHand.ActionSequence++
+	state.CurrentHand.ActionDeadline = nil
+	BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+	delta := <-client.Send
+	if applied.apply(delta) != "game_delta" {
+		t.Fatal("Expected a game_delta")
+	}
+	if len(delta)*3 > len(full) {
+		t.Errorf("Expected the delta to be far smaller than the %d byte state, got %d bytes: %s", len(full), len(delta), delta)
+	}
+	if !reflect.DeepEqual(applied.payload, fullPayload(t, state, "user-3")) {
+		t.Errorf("Applying the delta gave %v, expected %v", applied.payload, fullPayload(t, state, "user-3"))
+	}
+	// The viewer's own cards come through in their player object
+	players := applied.payload["players"].([]interface{})
+	if players[3].(map[string]interface{})["cards"] == nil {
+		t.Error("Expected user-3's cards in the delta")
+	}
+
+	// Nothing changed still moves the sequence on
+	BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+	if applied.apply(<-client.Send) != "game_delta" {
+		t.Error("Expected an empty game_delta")
+	}
+
+	// A new player means a full state
+	state.Players = append(state.Players, pokerModels.NewPlayer("user-9", "Player 9", 9, 500))
+	BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+	if applied.apply(<-client.Send) != "game_update" {
+		t.Error("Expected a full game_update after a player sat down")
+	}
+}
+
+func TestBroadcastSnapshot_PeriodicFullState(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	client := newDeltaClient("user-1")
+	registry := listRegistry{client}
+	applied := &deltaClient{t: t}
+
+	var types []string
+	for i := 0; i < deltaFullEvery+2; i++ {
+		state.CurrentHand.ActionSequence++
+		BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+		types = append(types, applied.apply(<-client.Send))
+	}
+	if types[0] != "game_update" || types[deltaFullEvery] != "game_delta" || types[deltaFullEvery+1] != "game_update" {
+		t.Errorf("Expected a full state after %d deltas, got %v", deltaFullEvery, types)
+	}
+
+	// A resync, or a table_state, starts again from a full state
+	client.ResetDelta()
+	BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+	if applied.apply(<-client.Send) != "game_update" {
+		t.Error("Expected a full game_update after a reset")
+	}
+	SendSnapshot(client, "table-1", state, sumSidePotsForTest, nil, nil)
+	<-client.Send
+	BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+	if applied.apply(<-client.Send) != "game_update" {
+		t.Error("Expected a full game_update after a table_state")
+	}
+}
+
+func TestBroadcastSnapshot_DeltaUnsetsFields(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusHandComplete)
+	client := newDeltaClient("user-2")
+	registry := listRegistry{client}
+	applied := &deltaClient{t: t}
+
+	BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+	applied.apply(<-client.Send)
+
+	// The next hand clears the winners
+	deadline := time.Now()
+	state.Status = pokerModels.StatusPlaying
+	state.Winners = nil
+	state.CurrentHand.ActionDeadline = &deadline
+	BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+	if applied.apply(<-client.Send) != "game_delta" {
+		t.Fatal("Expected a game_delta")
+	}
+	if _, ok := applied.payload["winners"]; ok {
+		t.Error("Expected winners to be unset")
+	}
+	if !reflect.DeepEqual(applied.payload, fullPayload(t, state, "user-2")) {
+		t.Errorf("Applying the delta gave %v, expected %v", applied.payload, fullPayload(t, state, "user-2"))
+	}
+}
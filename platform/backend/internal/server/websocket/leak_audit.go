@@ -0,0 +1,138 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+
+	pokerModels "poker-engine/models"
+)
+
+// The hole card leak audit is a safety net for test and staging runs: every
+// message for a client is checked for the hole cards of anyone else at its
+// table that aren't shown yet. A hand is only reported when all of its cards
+// turn up, so a stray match of one card in other data isn't taken for a leak.
+//
+// Messages are checked where this package queues them, so the stack trace
+// shows the path that built them, and again just before they are written,
+// which also covers messages other packages queue directly. Each leak is
+// logged once per hand and recipient.
+var leakAudit struct {
+	enabled    atomic.Bool
+	violations atomic.Uint64
+
+	mu     sync.Mutex
+	tables map[string]map[string]*hiddenHand // Table ID -> player ID -> hand
+}
+
+// hiddenHand is a player's hole cards while the rest of the table can't see them
+type hiddenHand struct {
+	key      string     // The cards, to tell one hand from the next
+	forms    [][][]byte // Per card, the ways a message can carry it
+	reported map[string]bool
+}
+
+// SetLeakAudit turns the hole card leak audit on or off
+func SetLeakAudit(enabled bool) {
+	leakAudit.mu.Lock()
+	leakAudit.tables = make(map[string]map[string]*hiddenHand)
+	leakAudit.mu.Unlock()
+	leakAudit.enabled.Store(enabled)
+}
+
+// LeakAuditViolations returns how many leaks the audit has logged, so
+// integration runs can fail on any
+func LeakAuditViolations() uint64 {
+	return leakAudit.violations.Load()
+}
+
+// observeHoleCards records whose cards at a table are hidden in a state about
+// to be sent: everyone's with cards, but those shown once the hand is complete
+func observeHoleCards(tableID string, state *pokerModels.Table) {
+	if !leakAudit.enabled.Load() {
+		return
+	}
+	showdown := state.Status == pokerModels.StatusHandComplete
+
+	leakAudit.mu.Lock()
+	defer leakAudit.mu.Unlock()
+	previous := leakAudit.tables[tableID]
+	hidden := make(map[string]*hiddenHand)
+	for _, p := range state.Players {
+		if p == nil || len(p.Cards) == 0 || (showdown && state.ShowsCards(p)) || len(p.ShownCards) == len(p.Cards) {
+			continue
+		}
+		key := string(appendCards(nil, p.Cards))
+		if hand, ok := previous[p.PlayerID]; ok && hand.key == key {
+			hidden[p.PlayerID] = hand
+			continue
+		}
+		hand := &hiddenHand{key: key, reported: make(map[string]bool)}
+		for _, card := range p.Cards {
+			name := string(card.Rank) + string(card.Suit)
+			struc, _ := json.Marshal(card)
+			hand.forms = append(hand.forms, [][]byte{
+				[]byte(`"` + name + `"`), // JSON string, as table states and hand histories have it
+				struc,                    // JSON object, as a Card marshals
+				[]byte("\x02" + name),    // Protobuf string, as binary table states have it
+			})
+		}
+		hidden[p.PlayerID] = hand
+	}
+	leakAudit.tables[tableID] = hidden
+}
+
+// auditLeak checks a message for a client for anyone else's hidden hole cards
+// and logs each leak it finds with a stack trace. Clients on the cards-up
+// feed are meant to see every card and aren't checked.
+func auditLeak(c *Client, data []byte) {
+	if !leakAudit.enabled.Load() || c.CardsUp || c.TableID == "" {
+		return
+	}
+
+	leakAudit.mu.Lock()
+	defer leakAudit.mu.Unlock()
+	for owner, hand := range leakAudit.tables[c.TableID] {
+		if owner == c.UserID || hand.reported[c.UserID] || !carriesHand(data, hand) {
+			continue
+		}
+		hand.reported[c.UserID] = true
+		leakAudit.violations.Add(1)
+		log.Printf("[LEAK_AUDIT] Hole cards %s of %s at table %s sent to %s in a %s message:\n%s",
+			hand.key, owner, c.TableID, c.UserID, messageType(data), debug.Stack())
+	}
+}
+
+// carriesHand reports whether every card of a hand is in a message, in any form
+func carriesHand(data []byte, hand *hiddenHand) bool {
+	for _, forms := range hand.forms {
+		found := false
+		for _, form := range forms {
+			if bytes.Contains(data, form) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// messageType names a message for the audit log
+func messageType(data []byte) string {
+	if isBinaryFrame(data) {
+		return "binary"
+	}
+	var msg struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil || msg.Type == "" {
+		return "unknown"
+	}
+	return msg.Type
+}
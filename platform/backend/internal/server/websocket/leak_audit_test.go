@@ -0,0 +1,88 @@
+package websocket
+
+import (
+	"testing"
+
+	pokerModels "poker-engine/models"
+)
+
+func TestLeakAudit_BroadcastsAreClean(t *testing.T) {
+	SetLeakAudit(true)
+	defer SetLeakAudit(false)
+	before := LeakAuditViolations()
+
+	// Everyone gets cards of their own, so one viewer's cards don't match another's
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	ranks := []pokerModels.Rank{pokerModels.Two, pokerModels.Three, pokerModels.Four, pokerModels.Five,
+		pokerModels.Six, pokerModels.Seven, pokerModels.Eight, pokerModels.Nine, pokerModels.Jack}
+	var registry listRegistry
+	for i, p := range state.Players {
+		p.Cards = []pokerModels.Card{{Rank: ranks[i], Suit: pokerModels.Hearts}, {Rank: ranks[i], Suit: pokerModels.Diamonds}}
+		client := newDeltaClient(p.PlayerID)
+		switch i % 3 {
+		case 0:
+			client.setCapabilities(ProtocolVersion, nil)
+		case 1:
+			client.setCapabilities(ProtocolVersion, []string{FeatureBinary})
+		}
+		registry = append(registry, client)
+	}
+
+	for _, status := range []pokerModels.TableStatus{pokerModels.StatusPlaying, pokerModels.StatusHandComplete} {
+		state.Status = status
+		BroadcastSnapshot("table-1", state, registry, sumSidePotsForTest, nil)
+		SendSnapshot(registry[2], "table-1", state, sumSidePotsForTest, nil, nil)
+	}
+	if got := LeakAuditViolations() - before; got != 0 {
+		t.Errorf("Expected no leaks from table state broadcasts, got %d", got)
+	}
+}
+
+func TestLeakAudit_ReportsLeaks(t *testing.T) {
+	SetLeakAudit(true)
+	defer SetLeakAudit(false)
+	before := LeakAuditViolations()
+
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	state.Players[1].Cards = []pokerModels.Card{
+		{Rank: pokerModels.Ace, Suit: pokerModels.Hearts},
+		{Rank: pokerModels.Ace, Suit: pokerModels.Diamonds},
+	}
+	observeHoleCards("table-1", state)
+	client := newDeltaClient("user-2")
+
+	// One of the cards alone isn't taken for a leak
+	client.Enqueue([]byte(`{"type":"chat_message","payload":{"message":"Ah"}}`), PriorityNormal)
+	if got := LeakAuditViolations() - before; got != 0 {
+		t.Fatalf("Expected no leak for a single card, got %d", got)
+	}
+
+	leak := []byte(`{"type":"debug","payload":{"hands":{"user-1":[{"rank":"A","suit":"h"},"Ad"]}}}`)
+	client.Enqueue(leak, PriorityNormal)
+	if got := LeakAuditViolations() - before; got != 1 {
+		t.Fatalf("Expected one leak, got %d", got)
+	}
+
+	// The same leak is reported once per hand and recipient
+	auditLeak(client, leak)
+	if got := LeakAuditViolations() - before; got != 1 {
+		t.Errorf("Expected the leak reported once, got %d", got)
+	}
+
+	// The owner and the cards-up feed may see the cards
+	auditLeak(newDeltaClient("user-1"), leak)
+	cardsUp := newDeltaClient("producer")
+	cardsUp.CardsUp = true
+	auditLeak(cardsUp, leak)
+	if got := LeakAuditViolations() - before; got != 1 {
+		t.Errorf("Expected no leak to the owner or the cards-up feed, got %d", got)
+	}
+
+	// Shown at showdown, the cards are no longer a secret
+	state.Status = pokerModels.StatusHandComplete
+	observeHoleCards("table-1", state)
+	auditLeak(newDeltaClient("user-3"), leak)
+	if got := LeakAuditViolations() - before; got != 1 {
+		t.Errorf("Expected shown cards not to count as a leak, got %d", got)
+	}
+}
@@ -0,0 +1,114 @@
+package websocket
+
+import "encoding/json"
+
+// MessagePriority decides which queue an outgoing message waits in. WritePump
+// always drains the high priority queue first and only sends low priority
+// messages when no state update is waiting.
+type MessagePriority int
+
+const (
+	// PriorityLow is for chat and history logs; dropped first when a client falls behind
+	PriorityLow MessagePriority = iota
+	// PriorityNormal is for table state updates and everything else
+	PriorityNormal
+	// PriorityHigh is for turn notifications, action results and errors
+	PriorityHigh
+)
+
+// Queue sizes per priority. Normal messages use the Send channel.
+const (
+	priorityQueueSize = 32
+	bulkQueueSize     = 64
+)
+
+// messagePriorities maps message types to their priority; unlisted types are normal
+var messagePriorities = map[string]MessagePriority{
+	"action_required":   PriorityHigh,
+	"action_confirmed":  PriorityHigh,
+	"error":             PriorityHigh,
+	"server_restarting": PriorityHigh,
+	"chat_message":      PriorityLow,
+	"history_log":       PriorityLow,
+}
+
+// PriorityFor returns the priority of a message type
+func PriorityFor(msgType string) MessagePriority {
+	if priority, ok := messagePriorities[msgType]; ok {
+		return priority
+	}
+	return PriorityNormal
+}
+
+// Enqueue queues an encoded message without blocking and reports whether it
+// was queued. Clients without separate queues fall back to Send.
+func (c *Client) Enqueue(data []byte, priority MessagePriority) bool {
+	queue := c.Send
+	switch priority {
+	case PriorityHigh:
+		if c.priority != nil {
+			queue = c.priority
+		}
+	case PriorityLow:
+		if c.bulk != nil {
+			queue = c.bulk
+		}
+	}
+
+	auditLeak(c, data)
+	select {
+	case queue <- data:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetPriorityChannel returns the high priority queue for this client
+func (c *Client) GetPriorityChannel() chan []byte {
+	if c.priority == nil {
+		return c.Send
+	}
+	return c.priority
+}
+
+// SendWithPriority encodes and queues a message at an explicit priority
+func SendWithPriority(c *Client, msg WSMessage, priority MessagePriority) bool {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return false
+	}
+	return c.Enqueue(data, priority)
+}
+
+// nextMessage waits for the next message to write, preferring high priority
+// messages, then state updates, then low priority ones. It returns false once
+// the client is disconnected or Send is closed; closed reports the latter.
+func (c *Client) nextMessage() (message []byte, ok bool, closed bool) {
+	select {
+	case <-c.done:
+		return nil, false, false
+	case message = <-c.priority:
+		return message, true, false
+	default:
+	}
+
+	select {
+	case message = <-c.priority:
+		return message, true, false
+	case message, ok = <-c.Send:
+		return message, ok, !ok
+	default:
+	}
+
+	select {
+	case message = <-c.priority:
+		return message, true, false
+	case message, ok = <-c.Send:
+		return message, ok, !ok
+	case message = <-c.bulk:
+		return message, true, false
+	case <-c.done:
+		return nil, false, false
+	}
+}
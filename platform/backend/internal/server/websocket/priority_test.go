@@ -0,0 +1,85 @@
+package websocket
+
+import (
+	"testing"
+)
+
+func newPriorityTestClient() *Client {
+	return &Client{
+		UserID:   "user-1",
+		Send:     make(chan []byte, 4),
+		priority: make(chan []byte, 2),
+		bulk:     make(chan []byte, 2),
+		done:     make(chan struct{}),
+	}
+}
+
+func TestNextMessage_DrainsByPriority(t *testing.T) {
+	client := newPriorityTestClient()
+
+	client.Enqueue([]byte("history"), PriorityLow)
+	client.Enqueue([]byte("state-1"), PriorityNormal)
+	client.Enqueue([]byte("state-2"), PriorityNormal)
+	client.Enqueue([]byte("your-turn"), PriorityHigh)
+
+	want := []string{"your-turn", "state-1", "state-2", "history"}
+	for _, expected := range want {
+		message, ok, closed := client.nextMessage()
+		if !ok || closed {
+			t.Fatalf("Expected a message, got ok=%v closed=%v", ok, closed)
+		}
+		if string(message) != expected {
+			t.Errorf("Expected %s, got %s", expected, message)
+		}
+	}
+
+	client.Disconnect(CloseKicked, "")
+	if _, ok, closed := client.nextMessage(); ok || closed {
+		t.Errorf("Expected disconnect to end the pump, got ok=%v closed=%v", ok, closed)
+	}
+}
+
+func TestEnqueue_FullQueueDoesNotBlock(t *testing.T) {
+	client := newPriorityTestClient()
+
+	for i := 0; i < cap(client.bulk); i++ {
+		if !client.Enqueue([]byte("history"), PriorityLow) {
+			t.Fatal("Expected bulk message to be queued")
+		}
+	}
+	if client.Enqueue([]byte("history"), PriorityLow) {
+		t.Error("Expected bulk message to be dropped when the queue is full")
+	}
+
+	// A full bulk queue must not affect turn notifications
+	if !client.Enqueue([]byte("your-turn"), PriorityHigh) {
+		t.Error("Expected high priority message to be queued")
+	}
+}
+
+func TestEnqueue_FallsBackToSend(t *testing.T) {
+	client := &Client{Send: make(chan []byte, 2), done: make(chan struct{})}
+
+	client.Enqueue([]byte("a"), PriorityHigh)
+	client.Enqueue([]byte("b"), PriorityLow)
+	if len(client.Send) != 2 {
+		t.Errorf("Expected clients without priority queues to use Send, got %d queued", len(client.Send))
+	}
+	if client.GetPriorityChannel() != client.Send {
+		t.Error("Expected priority channel to fall back to Send")
+	}
+}
+
+func TestPriorityFor(t *testing.T) {
+	cases := map[string]MessagePriority{
+		"action_required": PriorityHigh,
+		"error":           PriorityHigh,
+		"game_update":     PriorityNormal,
+		"history_log":     PriorityLow,
+	}
+	for msgType, expected := range cases {
+		if got := PriorityFor(msgType); got != expected {
+			t.Errorf("%s: expected priority %d, got %d", msgType, expected, got)
+		}
+	}
+}
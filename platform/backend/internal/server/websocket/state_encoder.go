@@ -0,0 +1,394 @@
+package websocket
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	pokerModels "poker-engine/models"
+)
+
+// tableStateFrame is a table state message pre-encoded once per broadcast.
+// Everything except hole cards is shared between viewers, so each client's
+// message is produced by splicing player fragments between a fixed prefix and
+// suffix and only swapping in the viewer's own card-bearing fragment.
+type tableStateFrame struct {
+	prefix  []byte
+	public  [][]byte       // per-player fragment visible to everyone
+	private [][]byte       // per-player fragment including hole cards, nil if identical to public
+	owners  map[string]int // player ID -> index of a fragment with private cards
+	ids     []string       // player ID of each fragment
+	suffix  []byte
+	shared  []byte // full message for viewers without private cards
+	size    int
+	binary  bool // Protobuf TableState, whose fragments need no separators
+
+	fieldsOnce sync.Once
+	fields     map[string]json.RawMessage // Top-level payload fields but players, for deltas
+}
+
+// framePool recycles scratch buffers used while building frames
+var framePool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 4096)
+		return &buf
+	},
+}
+
+// buildTableStateFrame encodes the shared parts of a table state message.
+// msgType is "game_update" for broadcasts and "table_state" for subscriptions.
+func buildTableStateFrame(
+	msgType string,
+	tableID string,
+	state *pokerModels.Table,
+	sumSidePots func([]pokerModels.SidePot) int,
+) *tableStateFrame {
+	bufPtr := framePool.Get().(*[]byte)
+	buf := (*bufPtr)[:0]
+	defer func() {
+		*bufPtr = buf[:0]
+		framePool.Put(bufPtr)
+	}()
+
+	frame := &tableStateFrame{owners: make(map[string]int)}
+	showdown := state.Status == pokerModels.StatusHandComplete
+	bigBlind := state.Config.BigBlind
+	timeBank := state.Config.TimeBank > 0
+
+	buf = append(buf, `{"type":`...)
+	buf = appendJSONString(buf, msgType)
+	buf = append(buf, `,"payload":{"players":[`...)
+	frame.prefix = append([]byte(nil), buf...)
+
+	for _, p := range state.Players {
+		if p == nil {
+			continue
+		}
+
+		// Show the cards the table's showdown policy reveals once the hand is complete
+		revealed := showdown && state.ShowsCards(p)
+
+		buf = appendPlayerFragment(buf[:0], p, bigBlind, timeBank, revealed)
+		public := append([]byte(nil), buf...)
+
+		var private []byte
+		if !revealed && len(p.Cards) > 0 {
+			buf = appendPlayerFragment(buf[:0], p, bigBlind, timeBank, true)
+			private = append([]byte(nil), buf...)
+		}
+
+		if private != nil {
+			frame.owners[p.PlayerID] = len(frame.public)
+		}
+		frame.ids = append(frame.ids, p.PlayerID)
+		frame.public = append(frame.public, public)
+		frame.private = append(frame.private, private)
+	}
+
+	view := viewOf(state, sumSidePots)
+	communityCards, pot, currentTurn := view.communityCards, view.pot, view.currentTurn
+	bettingRound, currentBet, actionSequence := view.bettingRound, view.currentBet, view.actionSequence
+
+	buf = append(buf[:0], `],"table_id":`...)
+	buf = appendJSONString(buf, tableID)
+	buf = append(buf, `,"community_cards":`...)
+	buf = appendCards(buf, communityCards)
+	buf = append(buf, `,"pot":`...)
+	buf = strconv.AppendInt(buf, int64(pot), 10)
+	buf = append(buf, `,"current_turn":`...)
+	if currentTurn != nil {
+		buf = appendJSONString(buf, *currentTurn)
+	} else {
+		buf = append(buf, "null"...)
+	}
+	buf = append(buf, `,"status":`...)
+	buf = appendJSONString(buf, string(state.Status))
+	buf = append(buf, `,"betting_round":`...)
+	buf = appendJSONString(buf, bettingRound)
+	buf = append(buf, `,"current_bet":`...)
+	buf = strconv.AppendInt(buf, int64(currentBet), 10)
+	buf = append(buf, `,"action_sequence":`...)
+	buf = strconv.AppendUint(buf, actionSequence, 10)
+
+	// Display settings, so every client formats amounts the same way
+	buf = append(buf, `,"big_blind":`...)
+	buf = strconv.AppendInt(buf, int64(bigBlind), 10)
+	if bigBlind > 0 {
+		buf = append(buf, `,"pot_bb":`...)
+		buf = appendBigBlinds(buf, pot, bigBlind)
+	}
+	if state.Config.CurrencySymbol != "" {
+		buf = append(buf, `,"currency_symbol":`...)
+		buf = appendJSONString(buf, state.Config.CurrencySymbol)
+	}
+	buf = append(buf, `,"chip_scale":`...)
+	buf = strconv.AppendInt(buf, int64(chipScale(state.Config)), 10)
+	if state.Config.AllowStraddle {
+		buf = append(buf, `,"allow_straddle":true`...)
+	}
+	if state.Config.BettingMode != "" {
+		buf = append(buf, `,"betting_mode":`...)
+		buf = appendJSONString(buf, state.Config.BettingMode)
+	}
+
+	// Add dealer and blind positions if hand is active
+	if state.CurrentHand != nil {
+		buf = append(buf, `,"dealer_position":`...)
+		buf = strconv.AppendInt(buf, int64(state.CurrentHand.DealerPosition), 10)
+		buf = append(buf, `,"small_blind_position":`...)
+		buf = strconv.AppendInt(buf, int64(state.CurrentHand.SmallBlindPosition), 10)
+		buf = append(buf, `,"big_blind_position":`...)
+		buf = strconv.AppendInt(buf, int64(state.CurrentHand.BigBlindPosition), 10)
+	}
+
+	// Add action deadline if there's an active player
+	if state.CurrentHand != nil && state.CurrentHand.ActionDeadline != nil && !state.CurrentHand.ActionDeadline.IsZero() {
+		buf = append(buf, `,"action_deadline":"`...)
+		buf = state.CurrentHand.ActionDeadline.AppendFormat(buf, time.RFC3339)
+		buf = append(buf, '"')
+	}
+
+	// Add winners if hand is complete
+	if showdown && len(state.Winners) > 0 {
+		if winners, err := json.Marshal(state.PublicWinners()); err == nil {
+			buf = append(buf, `,"winners":`...)
+			buf = append(buf, winners...)
+		}
+	}
+
+	buf = append(buf, "}}"...)
+	frame.suffix = append([]byte(nil), buf...)
+
+	frame.size = len(frame.prefix) + len(frame.suffix) + len(frame.public)
+	for i, fragment := range frame.public {
+		frame.size += len(fragment)
+		if len(frame.private[i]) > len(fragment) {
+			frame.size += len(frame.private[i]) - len(fragment)
+		}
+	}
+
+	frame.shared = frame.appendFor(make([]byte, 0, frame.size), -1)
+
+	return frame
+}
+
+// tableView is what a table state message says about the hand in progress,
+// apart from the players
+type tableView struct {
+	communityCards []pokerModels.Card
+	pot            int
+	currentTurn    *string
+	bettingRound   string
+	currentBet     int
+	actionSequence uint64
+}
+
+// viewOf reads the hand in progress of a table state, if any
+func viewOf(state *pokerModels.Table, sumSidePots func([]pokerModels.SidePot) int) tableView {
+	view := tableView{communityCards: []pokerModels.Card{}}
+
+	// Only access CurrentHand if it exists
+	if state.CurrentHand != nil {
+		view.communityCards = state.CurrentHand.CommunityCards
+		view.pot = state.CurrentHand.Pot.Main + sumSidePots(state.CurrentHand.Pot.Side)
+		view.bettingRound = string(state.CurrentHand.BettingRound)
+		view.currentBet = state.CurrentHand.CurrentBet
+		view.actionSequence = state.CurrentHand.ActionSequence
+
+		if state.CurrentHand.CurrentPosition >= 0 && state.CurrentHand.CurrentPosition < len(state.Players) {
+			if currentPlayer := state.Players[state.CurrentHand.CurrentPosition]; currentPlayer != nil {
+				view.currentTurn = &currentPlayer.PlayerID
+			}
+		}
+	}
+	return view
+}
+
+// appendField adds a top-level payload field to the shared suffix. Only valid
+// before any message has been handed out.
+func (f *tableStateFrame) appendField(key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" {
+		return
+	}
+	if f.binary {
+		suffix := appendExtraField(append([]byte(nil), f.suffix...), key, data)
+		f.size += len(suffix) - len(f.suffix)
+		f.suffix = suffix
+		f.shared = f.appendFor(make([]byte, 0, f.size), -1)
+		return
+	}
+
+	suffix := append([]byte(nil), f.suffix[:len(f.suffix)-2]...) // strip the closing "}}"
+	suffix = append(suffix, ',')
+	suffix = appendJSONString(suffix, key)
+	suffix = append(suffix, ':')
+	suffix = append(suffix, data...)
+	suffix = append(suffix, "}}"...)
+
+	f.size += len(suffix) - len(f.suffix)
+	f.suffix = suffix
+	f.shared = f.appendFor(make([]byte, 0, f.size), -1)
+}
+
+// messageFor returns the encoded message for a viewer. Viewers with no hole
+// cards at the table share a single buffer; seated players get their own copy.
+func (f *tableStateFrame) messageFor(viewerID string) []byte {
+	if index, ok := f.owners[viewerID]; ok {
+		return f.appendFor(make([]byte, 0, f.size), index)
+	}
+	return f.shared
+}
+
+// cardsUp returns the message with every player's hole cards, for the
+// delayed production feed of a broadcast table
+func (f *tableStateFrame) cardsUp() []byte {
+	dst := append(make([]byte, 0, f.size+len(f.private)*64), f.prefix...)
+	for i, fragment := range f.public {
+		if i > 0 && !f.binary {
+			dst = append(dst, ',')
+		}
+		if f.private[i] != nil {
+			fragment = f.private[i]
+		}
+		dst = append(dst, fragment...)
+	}
+	return append(dst, f.suffix...)
+}
+
+// appendFor appends the full message to dst, injecting the private fragment
+// at privateIndex (or none when privateIndex is negative)
+func (f *tableStateFrame) appendFor(dst []byte, privateIndex int) []byte {
+	dst = append(dst, f.prefix...)
+	for i, fragment := range f.public {
+		if i > 0 && !f.binary {
+			dst = append(dst, ',')
+		}
+		if i == privateIndex {
+			fragment = f.private[i]
+		}
+		dst = append(dst, fragment...)
+	}
+	return append(dst, f.suffix...)
+}
+
+// appendPlayerFragment encodes one player object, optionally with hole cards.
+// Stacks and bets are also given in big blinds when the big blind is known,
+// and the time bank balance when the table has one.
+func appendPlayerFragment(dst []byte, p *pokerModels.Player, bigBlind int, timeBank, withCards bool) []byte {
+	dst = append(dst, `{"user_id":`...)
+	dst = appendJSONString(dst, p.PlayerID)
+	dst = append(dst, `,"username":`...)
+	dst = appendJSONString(dst, p.PlayerName)
+	dst = append(dst, `,"seat_number":`...)
+	dst = strconv.AppendInt(dst, int64(p.SeatNumber), 10)
+	dst = append(dst, `,"chips":`...)
+	dst = strconv.AppendInt(dst, int64(p.Chips), 10)
+	dst = append(dst, `,"status":`...)
+	dst = appendJSONString(dst, string(p.Status))
+	dst = append(dst, `,"current_bet":`...)
+	dst = strconv.AppendInt(dst, int64(p.Bet), 10)
+	dst = append(dst, `,"folded":`...)
+	dst = strconv.AppendBool(dst, p.Status == pokerModels.StatusFolded)
+	dst = append(dst, `,"all_in":`...)
+	dst = strconv.AppendBool(dst, p.Status == pokerModels.StatusAllIn)
+	dst = append(dst, `,"is_dealer":`...)
+	dst = strconv.AppendBool(dst, p.IsDealer)
+	dst = append(dst, `,"last_action":`...)
+	dst = appendJSONString(dst, string(p.LastAction))
+	dst = append(dst, `,"last_action_amount":`...)
+	dst = strconv.AppendInt(dst, int64(p.LastActionAmount), 10)
+	dst = append(dst, `,"away":`...)
+	dst = strconv.AppendBool(dst, p.Away)
+	if p.IsStraddle {
+		dst = append(dst, `,"straddle":true`...)
+	}
+	if p.Disconnected {
+		dst = append(dst, `,"disconnected":true`...)
+	}
+	if p.Level > 0 {
+		dst = append(dst, `,"level":`...)
+		dst = strconv.AppendInt(dst, int64(p.Level), 10)
+	}
+	if p.AvatarURL != "" {
+		dst = append(dst, `,"avatar_url":`...)
+		dst = appendJSONString(dst, p.AvatarURL)
+	}
+	if p.IsBot {
+		dst = append(dst, `,"is_bot":true`...)
+	}
+	if timeBank {
+		dst = append(dst, `,"time_bank":`...)
+		dst = strconv.AppendInt(dst, int64(p.TimeBank), 10)
+	}
+	if bigBlind > 0 {
+		dst = append(dst, `,"chips_bb":`...)
+		dst = appendBigBlinds(dst, p.Chips, bigBlind)
+		dst = append(dst, `,"current_bet_bb":`...)
+		dst = appendBigBlinds(dst, p.Bet, bigBlind)
+	}
+	// Without their own cards, others see any the player chose to show
+	cards := p.ShownCards
+	if withCards {
+		cards = p.Cards
+	}
+	if len(cards) > 0 {
+		dst = append(dst, `,"cards":`...)
+		dst = appendCards(dst, cards)
+	}
+	return append(dst, '}')
+}
+
+// BigBlinds converts an amount to big blinds, rounded to one decimal place
+func BigBlinds(amount, bigBlind int) float64 {
+	if bigBlind <= 0 {
+		return 0
+	}
+	return math.Round(float64(amount)*10/float64(bigBlind)) / 10
+}
+
+// appendBigBlinds encodes an amount in big blinds as a JSON number
+func appendBigBlinds(dst []byte, amount, bigBlind int) []byte {
+	return strconv.AppendFloat(dst, BigBlinds(amount, bigBlind), 'f', -1, 64)
+}
+
+// chipScale returns the chips per displayed unit of a table, at least 1
+func chipScale(config pokerModels.TableConfig) int {
+	if config.ChipScale <= 0 {
+		return 1
+	}
+	return config.ChipScale
+}
+
+// appendCards encodes cards as a JSON array of short strings like "Ah"
+func appendCards(dst []byte, cards []pokerModels.Card) []byte {
+	dst = append(dst, '[')
+	for i, card := range cards {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = append(dst, '"')
+		dst = append(dst, card.Rank...)
+		dst = append(dst, card.Suit...)
+		dst = append(dst, '"')
+	}
+	return append(dst, ']')
+}
+
+// appendJSONString appends s as a JSON string. Plain ASCII takes the fast path;
+// anything needing escaping falls back to encoding/json for identical output.
+func appendJSONString(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c >= utf8.RuneSelf || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			encoded, _ := json.Marshal(s)
+			return append(dst, encoded...)
+		}
+	}
+	dst = append(dst, '"')
+	dst = append(dst, s...)
+	return append(dst, '"')
+}
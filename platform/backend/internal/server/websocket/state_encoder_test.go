@@ -0,0 +1,518 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/eventschema"
+
+	pokerModels "poker-engine/models"
+)
+
+func sumSidePotsForTest(sidePots []pokerModels.SidePot) int {
+	total := 0
+	for _, sp := range sidePots {
+		total += sp.Amount
+	}
+	return total
+}
+
+func newEncoderTestState(status pokerModels.TableStatus) *pokerModels.Table {
+	deadline := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	state := &pokerModels.Table{
+		TableID: "table-1",
+		Status:  status,
+		Config:  pokerModels.TableConfig{SmallBlind: 10, BigBlind: 20, CurrencySymbol: "€", ChipScale: 100},
+		Players: make([]*pokerModels.Player, 9),
+		CurrentHand: &pokerModels.CurrentHand{
+			DealerPosition:     0,
+			SmallBlindPosition: 1,
+			BigBlindPosition:   2,
+			CurrentPosition:    3,
+			BettingRound:       pokerModels.RoundFlop,
+			CommunityCards: []pokerModels.Card{
+				{Rank: pokerModels.Ace, Suit: pokerModels.Spades},
+				{Rank: pokerModels.King, Suit: pokerModels.Hearts},
+				{Rank: pokerModels.Two, Suit: pokerModels.Clubs},
+			},
+			Pot:            pokerModels.Pot{Main: 300, Side: []pokerModels.SidePot{{Amount: 50}}},
+			CurrentBet:     40,
+			ActionDeadline: &deadline,
+			ActionSequence: 17,
+		},
+	}
+
+	for i := 0; i < 9; i++ {
+		p := pokerModels.NewPlayer(fmt.Sprintf("user-%d", i), fmt.Sprintf("Player \"%d\" <x>", i), i, 1000+i)
+		p.Cards = []pokerModels.Card{
+			{Rank: pokerModels.Queen, Suit: pokerModels.Diamonds},
+			{Rank: pokerModels.Ten, Suit: pokerModels.Hearts},
+		}
+		p.IsDealer = i == 0
+		p.LastAction = pokerModels.ActionCall
+		p.LastActionAmount = 20
+		if i == 4 {
+			p.Status = pokerModels.StatusFolded
+		}
+		state.Players[i] = p
+	}
+
+	if status == pokerModels.StatusHandComplete {
+		state.Winners = []pokerModels.Winner{{PlayerID: "user-1", PlayerName: "Player 1", Amount: 350, HandRank: "Pair"}}
+	}
+
+	return state
+}
+
+// legacyGameUpdate builds a game_update message the way BroadcastTableState
+// did before frames were pre-encoded, for equivalence checks and benchmarks.
+func legacyGameUpdate(tableID string, state *pokerModels.Table, viewerID string) []byte {
+	players := []map[string]interface{}{}
+	for _, p := range state.Players {
+		if p == nil {
+			continue
+		}
+		playerData := map[string]interface{}{
+			"user_id":            p.PlayerID,
+			"username":           p.PlayerName,
+			"seat_number":        p.SeatNumber,
+			"chips":              p.Chips,
+			"status":             string(p.Status),
+			"current_bet":        p.Bet,
+			"folded":             p.Status == pokerModels.StatusFolded,
+			"all_in":             p.Status == pokerModels.StatusAllIn,
+			"is_dealer":          p.IsDealer,
+			"last_action":        string(p.LastAction),
+			"last_action_amount": p.LastActionAmount,
+			"away":               p.Away,
+			"chips_bb":           BigBlinds(p.Chips, state.Config.BigBlind),
+			"current_bet_bb":     BigBlinds(p.Bet, state.Config.BigBlind),
+		}
+		showdown := state.Status == pokerModels.StatusHandComplete && p.Status != pokerModels.StatusFolded
+		if len(p.Cards) > 0 && (p.PlayerID == viewerID || showdown) {
+			cards := make([]string, len(p.Cards))
+			for i, card := range p.Cards {
+				cards[i] = card.String()
+			}
+			playerData["cards"] = cards
+		}
+		players = append(players, playerData)
+	}
+
+	communityCards := make([]string, len(state.CurrentHand.CommunityCards))
+	for i, card := range state.CurrentHand.CommunityCards {
+		communityCards[i] = card.String()
+	}
+	currentTurn := state.Players[state.CurrentHand.CurrentPosition].PlayerID
+	pot := state.CurrentHand.Pot.Main + sumSidePotsForTest(state.CurrentHand.Pot.Side)
+
+	payload := map[string]interface{}{
+		"table_id":             tableID,
+		"players":              players,
+		"community_cards":      communityCards,
+		"pot":                  pot,
+		"current_turn":         &currentTurn,
+		"status":               string(state.Status),
+		"betting_round":        string(state.CurrentHand.BettingRound),
+		"current_bet":          state.CurrentHand.CurrentBet,
+		"action_sequence":      state.CurrentHand.ActionSequence,
+		"big_blind":            state.Config.BigBlind,
+		"pot_bb":               BigBlinds(pot, state.Config.BigBlind),
+		"currency_symbol":      state.Config.CurrencySymbol,
+		"chip_scale":           state.Config.ChipScale,
+		"dealer_position":      state.CurrentHand.DealerPosition,
+		"small_blind_position": state.CurrentHand.SmallBlindPosition,
+		"big_blind_position":   state.CurrentHand.BigBlindPosition,
+		"action_deadline":      state.CurrentHand.ActionDeadline.Format(time.RFC3339),
+	}
+	if state.Status == pokerModels.StatusHandComplete && len(state.Winners) > 0 {
+		payload["winners"] = state.Winners
+	}
+
+	data, _ := json.Marshal(WSMessage{Type: "game_update", Payload: payload})
+	return data
+}
+
+func assertSameJSON(t *testing.T, expected, actual []byte) {
+	t.Helper()
+	var want, got interface{}
+	if err := json.Unmarshal(expected, &want); err != nil {
+		t.Fatalf("Failed to decode expected JSON: %v", err)
+	}
+	if err := json.Unmarshal(actual, &got); err != nil {
+		t.Fatalf("Frame produced invalid JSON: %v\n%s", err, actual)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Frame output differs from legacy encoding\nwant: %s\ngot:  %s", expected, actual)
+	}
+}
+
+func TestTableStateFrame_MatchesLegacyEncoding(t *testing.T) {
+	for _, status := range []pokerModels.TableStatus{pokerModels.StatusPlaying, pokerModels.StatusHandComplete} {
+		state := newEncoderTestState(status)
+		frame := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+
+		for _, viewerID := range []string{"user-3", "user-4", "spectator"} {
+			assertSameJSON(t, legacyGameUpdate("table-1", state, viewerID), frame.messageFor(viewerID))
+		}
+	}
+}
+
+func TestTableStateFrame_MasksOtherPlayersCards(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	frame := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+
+	var msg struct {
+		Payload struct {
+			Players []struct {
+				UserID string   `json:"user_id"`
+				Cards  []string `json:"cards"`
+			} `json:"players"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(frame.messageFor("user-2"), &msg); err != nil {
+		t.Fatalf("Failed to decode frame: %v", err)
+	}
+
+	for _, p := range msg.Payload.Players {
+		if p.UserID == "user-2" && len(p.Cards) != 2 {
+			t.Errorf("Viewer should see own cards, got %v", p.Cards)
+		}
+		if p.UserID != "user-2" && len(p.Cards) != 0 {
+			t.Errorf("Viewer should not see cards of %s, got %v", p.UserID, p.Cards)
+		}
+	}
+
+	if spectator := frame.messageFor("spectator"); &spectator[0] != &frame.shared[0] {
+		t.Error("Viewers without hole cards should share the pre-encoded message")
+	}
+}
+
+func TestTableStateFrame_ShowdownPolicy(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusHandComplete)
+	state.Config.ShowdownPolicy = pokerModels.ShowdownWinners
+	state.Winners[0].HandCards = state.Players[1].Cards
+	frame := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+
+	var msg struct {
+		Payload struct {
+			Players []struct {
+				UserID string   `json:"user_id"`
+				Cards  []string `json:"cards"`
+			} `json:"players"`
+			Winners []pokerModels.Winner `json:"winners"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(frame.messageFor("user-2"), &msg); err != nil {
+		t.Fatalf("Failed to decode frame: %v", err)
+	}
+	for _, p := range msg.Payload.Players {
+		shown := p.UserID == "user-1" || p.UserID == "user-2"
+		if shown != (len(p.Cards) == 2) {
+			t.Errorf("Expected only the winner's and the viewer's cards, got %v for %s", p.Cards, p.UserID)
+		}
+	}
+
+	// Nobody's cards, not even in the winner's best five
+	state.Config.ShowdownPolicy = pokerModels.ShowdownNever
+	frame = buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+	msg.Payload.Players, msg.Payload.Winners = nil, nil
+	if err := json.Unmarshal(frame.messageFor("spectator"), &msg); err != nil {
+		t.Fatalf("Failed to decode frame: %v", err)
+	}
+	for _, p := range msg.Payload.Players {
+		if len(p.Cards) != 0 {
+			t.Errorf("Expected no cards shown, got %v for %s", p.Cards, p.UserID)
+		}
+	}
+	if len(msg.Payload.Winners) != 1 || msg.Payload.Winners[0].HandCards != nil {
+		t.Errorf("Expected the winner without their best five, got %+v", msg.Payload.Winners)
+	}
+}
+
+func TestTableStateFrame_UncontestedShownCards(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusHandComplete)
+	for i, p := range state.Players {
+		if i != 1 {
+			p.Status = pokerModels.StatusFolded
+		}
+	}
+	decode := func() map[string][]string {
+		frame := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+		var msg struct {
+			Payload struct {
+				Players []struct {
+					UserID string   `json:"user_id"`
+					Cards  []string `json:"cards"`
+				} `json:"players"`
+			} `json:"payload"`
+		}
+		if err := json.Unmarshal(frame.messageFor("spectator"), &msg); err != nil {
+			t.Fatalf("Failed to decode frame: %v", err)
+		}
+		cards := make(map[string][]string)
+		for _, p := range msg.Payload.Players {
+			if len(p.Cards) > 0 {
+				cards[p.UserID] = p.Cards
+			}
+		}
+		return cards
+	}
+
+	// A winner nobody contested keeps their cards hidden
+	if cards := decode(); len(cards) != 0 {
+		t.Errorf("Expected no cards shown, got %v", cards)
+	}
+
+	// Until they show one
+	state.Players[1].ShownCards = state.Players[1].Cards[1:]
+	if cards := decode(); len(cards) != 1 || len(cards["user-1"]) != 1 || cards["user-1"][0] != "Th" {
+		t.Errorf("Expected only the shown card, got %v", cards)
+	}
+}
+
+// BenchmarkBroadcast_LegacyMaps encodes a 9-handed table for 9 viewers using maps and json.Marshal
+func BenchmarkBroadcast_LegacyMaps(b *testing.B) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range state.Players {
+			_ = legacyGameUpdate("table-1", state, p.PlayerID)
+		}
+	}
+}
+
+// BenchmarkBroadcast_Frame encodes the same broadcast with a pre-encoded frame
+func BenchmarkBroadcast_Frame(b *testing.B) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		frame := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+		for _, p := range state.Players {
+			_ = frame.messageFor(p.PlayerID)
+		}
+	}
+}
+
+func TestTableStateFrame_SubscriptionUsesSamePublicView(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	broadcast := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+	subscribe := buildTableStateFrame("table_state", "table-1", state, sumSidePotsForTest)
+
+	var got struct {
+		Type    string          `json:"type"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(subscribe.messageFor("user-5"), &got); err != nil {
+		t.Fatalf("Failed to decode frame: %v", err)
+	}
+	if got.Type != "table_state" {
+		t.Errorf("Expected table_state message, got %s", got.Type)
+	}
+
+	var want struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	json.Unmarshal(broadcast.messageFor("user-5"), &want)
+	assertSameJSON(t, want.Payload, got.Payload)
+}
+
+func TestTableStateFrame_AppendField(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	frame := buildTableStateFrame("table_state", "table-1", state, sumSidePotsForTest)
+	frame.appendField("stats", map[string]int{"avg_pot": 120})
+
+	for _, viewer := range []string{"user-0", "spectator"} {
+		var got struct {
+			Payload struct {
+				TableID string         `json:"table_id"`
+				Players []interface{}  `json:"players"`
+				Stats   map[string]int `json:"stats"`
+			} `json:"payload"`
+		}
+		if err := json.Unmarshal(frame.messageFor(viewer), &got); err != nil {
+			t.Fatalf("Failed to decode frame for %s: %v", viewer, err)
+		}
+		if got.Payload.Stats["avg_pot"] != 120 || got.Payload.TableID != "table-1" || len(got.Payload.Players) == 0 {
+			t.Errorf("Expected stats alongside the table state for %s, got %+v", viewer, got.Payload)
+		}
+	}
+
+	// A nil value leaves the frame untouched
+	before := string(frame.shared)
+	var none *struct{}
+	frame.appendField("extra", none)
+	if string(frame.shared) != before {
+		t.Error("Expected nil field to be skipped")
+	}
+}
+
+func TestTableStateFrame_CardsUp(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	frame := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+
+	var msg struct {
+		Payload struct {
+			Players []struct {
+				UserID string   `json:"user_id"`
+				Cards  []string `json:"cards"`
+			} `json:"players"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(frame.cardsUp(), &msg); err != nil {
+		t.Fatalf("Failed to decode cards-up frame: %v", err)
+	}
+	if len(msg.Payload.Players) != 9 {
+		t.Fatalf("Expected 9 players, got %d", len(msg.Payload.Players))
+	}
+	for _, p := range msg.Payload.Players {
+		if len(p.Cards) != 2 {
+			t.Errorf("Cards-up feed should show the cards of %s, got %v", p.UserID, p.Cards)
+		}
+	}
+}
+
+func TestTableStateFrame_BigBlindValues(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	frame := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+
+	var msg struct {
+		Payload struct {
+			Players []struct {
+				ChipsBB *float64 `json:"chips_bb"`
+			} `json:"players"`
+			PotBB     *float64 `json:"pot_bb"`
+			ChipScale int      `json:"chip_scale"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(frame.messageFor("spectator"), &msg); err != nil {
+		t.Fatalf("Failed to decode frame: %v", err)
+	}
+	if msg.Payload.PotBB == nil || *msg.Payload.PotBB != 17.5 {
+		t.Errorf("Expected a pot of 17.5 big blinds, got %v", msg.Payload.PotBB)
+	}
+	if chips := msg.Payload.Players[1].ChipsBB; chips == nil || *chips != 50.1 {
+		t.Errorf("Expected 1001 chips to show as 50.1 big blinds, got %v", chips)
+	}
+
+	// Without a big blind there is nothing to divide by, and the scale defaults to 1
+	state.Config = pokerModels.TableConfig{}
+	frame = buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+	msg.Payload.PotBB = nil
+	msg.Payload.Players = nil
+	if err := json.Unmarshal(frame.messageFor("spectator"), &msg); err != nil {
+		t.Fatalf("Failed to decode frame: %v", err)
+	}
+	if msg.Payload.PotBB != nil || msg.Payload.Players[0].ChipsBB != nil || msg.Payload.ChipScale != 1 {
+		t.Errorf("Expected no big blind values and a scale of 1, got %+v", msg.Payload)
+	}
+}
+
+func TestTableStateFrame_Straddle(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	state.Config.AllowStraddle = true
+	state.Players[2].IsStraddle = true
+	frame := buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)
+
+	var msg struct {
+		Payload struct {
+			Players []struct {
+				Straddle bool `json:"straddle"`
+			} `json:"players"`
+			AllowStraddle bool `json:"allow_straddle"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(frame.messageFor("spectator"), &msg); err != nil {
+		t.Fatalf("Failed to decode frame: %v", err)
+	}
+	if !msg.Payload.AllowStraddle {
+		t.Error("Expected the table to offer the straddle")
+	}
+	for i, p := range msg.Payload.Players {
+		if p.Straddle != (i == 2) {
+			t.Errorf("Player %d: expected straddle %v, got %v", i, i == 2, p.Straddle)
+		}
+	}
+}
+
+func TestTableStateFrame_TimeBank(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusPlaying)
+	decode := func(frame *tableStateFrame) []map[string]interface{} {
+		var msg struct {
+			Payload struct {
+				Players []map[string]interface{} `json:"players"`
+			} `json:"payload"`
+		}
+		if err := json.Unmarshal(frame.messageFor("spectator"), &msg); err != nil {
+			t.Fatalf("Failed to decode frame: %v", err)
+		}
+		return msg.Payload.Players
+	}
+
+	for _, p := range decode(buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest)) {
+		if _, ok := p["time_bank"]; ok {
+			t.Fatal("Expected no time bank on a table without one")
+		}
+	}
+
+	state.Config.TimeBank = 60
+	state.Players[1].TimeBank = 42
+	players := decode(buildTableStateFrame("game_update", "table-1", state, sumSidePotsForTest))
+	if players[1]["time_bank"] != float64(42) {
+		t.Errorf("Expected 42 seconds of time bank, got %v", players[1]["time_bank"])
+	}
+}
+
+// The published event schema is read off eventschema.TableStatePayload, so
+// every field the encoder writes must be declared there
+func TestTableStateFrame_MatchesEventSchema(t *testing.T) {
+	state := newEncoderTestState(pokerModels.StatusHandComplete)
+	state.Config.AllowStraddle = true
+	state.Config.BettingMode = pokerModels.BettingPotLimit
+	state.Config.TimeBank = 60
+	state.Players[2].IsStraddle = true
+	state.Players[3].Disconnected = true
+	state.Players[3].Level = 7
+	state.Players[3].AvatarURL = "/avatars/user-3/a.png"
+	state.Players[3].IsBot = true
+	frame := buildTableStateFrame("table_state", "table-1", state, sumSidePotsForTest)
+	frame.appendField("stats", map[string]int{"hands": 3})
+
+	var msg struct {
+		Payload map[string]json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(frame.messageFor("user-0"), &msg); err != nil {
+		t.Fatalf("Failed to decode frame: %v", err)
+	}
+	var players []map[string]json.RawMessage
+	if err := json.Unmarshal(msg.Payload["players"], &players); err != nil {
+		t.Fatalf("Failed to decode players: %v", err)
+	}
+
+	check := func(what string, encoded map[string]json.RawMessage, schema []eventschema.Field) {
+		declared := make(map[string]bool, len(schema))
+		for _, f := range schema {
+			declared[f.Name] = true
+			if _, ok := encoded[f.Name]; !ok && !f.Optional {
+				t.Errorf("%s: required field %q was not encoded", what, f.Name)
+			}
+		}
+		for key := range encoded {
+			if !declared[key] {
+				t.Errorf("%s: field %q is missing from the event schema", what, key)
+			}
+		}
+	}
+	check("payload", msg.Payload, eventschema.Describe(reflect.TypeOf(eventschema.TableStatePayload{})))
+	playerSchema := eventschema.Describe(reflect.TypeOf(eventschema.TableStatePlayer{}))
+	for _, p := range players {
+		check("player", p, playerSchema)
+	}
+}
@@ -0,0 +1,130 @@
+package websocket
+
+import (
+	"sync"
+	"time"
+)
+
+// ThrottleRule decides how the table state broadcasts caused by an engine
+// event are sent
+type ThrottleRule struct {
+	Interval time.Duration // Broadcasts closer together than this are coalesced into one
+	Flush    bool          // Broadcast straight away, replacing any coalesced broadcast
+	Skip     bool          // Never broadcast for this event
+}
+
+// ThrottleConfig holds the throttle rule of each engine event. Events without
+// a rule use Default.
+type ThrottleConfig struct {
+	Default ThrottleRule
+	Events  map[string]ThrottleRule
+}
+
+// DefaultThrottleConfig sends at most one state broadcast per 100ms per table.
+// Players must see their turn and the result of a hand immediately, and the
+// cards of a round arrive with roundAdvanced, so cardDealt never broadcasts.
+var DefaultThrottleConfig = ThrottleConfig{
+	Default: ThrottleRule{Interval: 100 * time.Millisecond},
+	Events: map[string]ThrottleRule{
+		"actionRequired": {Flush: true},
+		"handComplete":   {Flush: true},
+		"gameComplete":   {Flush: true},
+		"cardDealt":      {Skip: true},
+	},
+}
+
+// Rule returns the throttle rule of an engine event
+func (c ThrottleConfig) Rule(event string) ThrottleRule {
+	if rule, ok := c.Events[event]; ok {
+		return rule
+	}
+	return c.Default
+}
+
+// tableThrottle is the broadcast state of one table
+type tableThrottle struct {
+	lastSent time.Time
+	pending  *time.Timer // Coalesced broadcast waiting for the interval to pass
+	sequence int         // Identifies the pending broadcast, so a stale timer can't send
+}
+
+// BroadcastThrottle coalesces the table state broadcasts triggered by engine
+// events, so bursts of events on a table don't flood its clients. Each table
+// is throttled independently.
+type BroadcastThrottle struct {
+	mu        sync.Mutex
+	config    ThrottleConfig
+	broadcast func(tableID string)
+	tables    map[string]*tableThrottle
+}
+
+// NewBroadcastThrottle creates a throttle that sends table state with broadcast
+func NewBroadcastThrottle(config ThrottleConfig, broadcast func(tableID string)) *BroadcastThrottle {
+	return &BroadcastThrottle{
+		config:    config,
+		broadcast: broadcast,
+		tables:    make(map[string]*tableThrottle),
+	}
+}
+
+// Broadcast sends a table's state for an engine event, following the event's
+// rule. A broadcast within the interval of the last one is deferred until the
+// interval has passed, and any further broadcasts until then join it.
+func (t *BroadcastThrottle) Broadcast(tableID, event string) {
+	rule := t.config.Rule(event)
+	if rule.Skip {
+		return
+	}
+
+	t.mu.Lock()
+	table, ok := t.tables[tableID]
+	if !ok {
+		table = &tableThrottle{}
+		t.tables[tableID] = table
+	}
+
+	now := time.Now()
+	wait := rule.Interval - now.Sub(table.lastSent)
+	switch {
+	case rule.Flush || wait <= 0:
+		if table.pending != nil {
+			table.pending.Stop()
+			table.pending = nil
+		}
+		table.lastSent = now
+		t.mu.Unlock()
+		t.broadcast(tableID)
+	case table.pending != nil:
+		// Coalesced into the broadcast already waiting
+		t.mu.Unlock()
+	default:
+		table.sequence++
+		sequence := table.sequence
+		table.pending = time.AfterFunc(wait, func() { t.flushPending(tableID, table, sequence) })
+		t.mu.Unlock()
+	}
+}
+
+// flushPending sends a coalesced broadcast, unless a flush already sent it
+func (t *BroadcastThrottle) flushPending(tableID string, table *tableThrottle, sequence int) {
+	t.mu.Lock()
+	if table.pending == nil || table.sequence != sequence {
+		t.mu.Unlock()
+		return
+	}
+	table.pending = nil
+	table.lastSent = time.Now()
+	t.mu.Unlock()
+
+	t.broadcast(tableID)
+}
+
+// Forget drops the throttle state of a table that has closed
+func (t *BroadcastThrottle) Forget(tableID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if table, ok := t.tables[tableID]; ok && table.pending != nil {
+		table.pending.Stop()
+	}
+	delete(t.tables, tableID)
+}
@@ -0,0 +1,86 @@
+package websocket
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// broadcastCounter records broadcasts per table
+type broadcastCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (b *broadcastCounter) broadcast(tableID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.counts[tableID]++
+}
+
+func (b *broadcastCounter) count(tableID string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.counts[tableID]
+}
+
+func newTestThrottle(interval time.Duration) (*BroadcastThrottle, *broadcastCounter) {
+	counter := &broadcastCounter{counts: make(map[string]int)}
+	config := DefaultThrottleConfig
+	config.Default.Interval = interval
+	return NewBroadcastThrottle(config, counter.broadcast), counter
+}
+
+func TestBroadcastThrottle_CoalescesBursts(t *testing.T) {
+	throttle, counter := newTestThrottle(50 * time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		throttle.Broadcast("table-1", "playerAction")
+	}
+	if got := counter.count("table-1"); got != 1 {
+		t.Fatalf("Expected only the first broadcast to be sent straight away, got %d", got)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if got := counter.count("table-1"); got != 2 {
+		t.Errorf("Expected the rest of the burst to be coalesced into one broadcast, got %d", got)
+	}
+}
+
+func TestBroadcastThrottle_FlushSendsImmediately(t *testing.T) {
+	throttle, counter := newTestThrottle(50 * time.Millisecond)
+
+	throttle.Broadcast("table-1", "playerAction")
+	throttle.Broadcast("table-1", "playerAction") // Coalesced
+	throttle.Broadcast("table-1", "actionRequired")
+	if got := counter.count("table-1"); got != 2 {
+		t.Fatalf("Expected actionRequired to flush straight away, got %d broadcasts", got)
+	}
+
+	// The coalesced broadcast was replaced by the flush
+	time.Sleep(100 * time.Millisecond)
+	if got := counter.count("table-1"); got != 2 {
+		t.Errorf("Expected no broadcast after the flush, got %d", got)
+	}
+}
+
+func TestBroadcastThrottle_SkipsAndTablesAreIndependent(t *testing.T) {
+	throttle, counter := newTestThrottle(time.Minute)
+
+	throttle.Broadcast("table-1", "cardDealt")
+	if got := counter.count("table-1"); got != 0 {
+		t.Errorf("Expected cardDealt not to broadcast, got %d", got)
+	}
+
+	throttle.Broadcast("table-1", "playerAction")
+	throttle.Broadcast("table-2", "playerAction")
+	if counter.count("table-1") != 1 || counter.count("table-2") != 1 {
+		t.Errorf("Expected each table to be throttled separately, got %v", counter.counts)
+	}
+
+	throttle.Forget("table-1")
+	throttle.Broadcast("table-1", "playerAction")
+	if got := counter.count("table-1"); got != 2 {
+		t.Errorf("Expected a forgotten table to start afresh, got %d", got)
+	}
+}
@@ -6,12 +6,12 @@ import (
 	"net/http"
 	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"poker-platform/backend/internal/auth"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 
 	"poker-engine/engine"
@@ -63,13 +63,24 @@ var Upgrader = websocket.Upgrader{
 	CheckOrigin: checkOrigin,
 }
 
-// HandleWebSocket upgrades HTTP connection to WebSocket
+// Registry is the set of open connections, grouped by user, that
+// HandleWebSocket adds clients to
+type Registry interface {
+	Add(userID, connectionID string, client interface{}) (evicted []interface{}, count int)
+	Remove(userID, connectionID string) (remaining int, removed bool)
+	Each(fn func(userID string, client interface{}))
+}
+
+// HandleWebSocket upgrades HTTP connection to WebSocket. A user may hold
+// several connections at once; each has its own table subscription and gets
+// everything sent to the user. onConnection, when set, is told when a user's
+// first connection opens and when their last one drops.
 func HandleWebSocket(
 	c *gin.Context,
 	authService *auth.Service,
-	clients map[string]interface{},
-	mu *sync.RWMutex,
+	clients Registry,
 	handleMessage func(*Client, WSMessage),
+	onConnection func(userID string, connected bool),
 ) {
 	token := c.Query("token")
 	userID, err := authService.ValidateToken(token)
@@ -78,41 +89,97 @@ func HandleWebSocket(
 		return
 	}
 
-	conn, err := Upgrader.Upgrade(c.Writer, c.Request, nil)
+	// The protocol can be settled here rather than by hello
+	requested, err := negotiateRequest(c.Request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":                err.Error(),
+			"code":                 "UNSUPPORTED_PROTOCOL",
+			"min_protocol_version": MinProtocolVersion,
+			"protocol_version":     ProtocolVersion,
+		})
+		return
+	}
+	var header http.Header
+	if requested.subprotocol != "" {
+		header = http.Header{"Sec-Websocket-Protocol": {requested.subprotocol}}
+	}
+
+	conn, err := Upgrader.Upgrade(c.Writer, c.Request, header)
 	if err != nil {
 		log.Println("WebSocket upgrade error:", err)
 		return
 	}
 
 	client := &Client{
-		UserID: userID,
-		Conn:   conn,
-		Send:   make(chan []byte, 256),
+		UserID:       userID,
+		Conn:         conn,
+		Send:         make(chan []byte, 256),
+		priority:     make(chan []byte, priorityQueueSize),
+		bulk:         make(chan []byte, bulkQueueSize),
+		done:         make(chan struct{}),
+		ConnectionID: uuid.New().String(),
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
+	}
+	if requested.explicit {
+		client.setCapabilities(requested.version, requested.features)
 	}
 
-	mu.Lock()
-	clients[userID] = client
-	mu.Unlock()
+	evicted, count := clients.Add(userID, client.ConnectionID, client)
+	for _, old := range evicted {
+		if oldClient, ok := old.(*Client); ok {
+			log.Printf("[WS_CONNECT] User %s opened too many connections; closing %s", userID, oldClient.ConnectionID)
+			oldClient.Disconnect(CloseDuplicateLogin, "too_many_connections")
+		}
+	}
+	log.Printf("[WS_CONNECT] User %s connected (%s, %d open)", userID, client.ConnectionID, count)
+
+	if count == 1 && onConnection != nil {
+		onConnection(userID, true)
+	}
 
+	if requested.explicit {
+		sendHelloAck(client, requested.version, requested.features)
+	}
 	go client.WritePump()
-	go client.ReadPump(clients, mu, handleMessage)
+	go client.ReadPump(clients, handleMessage, onConnection)
 }
 
 // SendToClient sends a message to a specific client
 func SendToClient(c *Client, msg WSMessage) {
-	data, _ := json.Marshal(msg)
-	select {
-	case c.Send <- data:
-	default:
+	SendWithPriority(c, msg, PriorityFor(msg.Type))
+}
+
+// SpectatorDelayer holds back table data from spectators of tables with a
+// spectator delay
+type SpectatorDelayer interface {
+	Delay(tableID string) time.Duration
+	HoldState(tableID string, data, cardsUp []byte)
+	Hold(tableID string, data []byte)
+	Latest(tableID string, cardsUp bool) []byte
+}
+
+// seatedAt reports whether a user holds a seat in a table state
+func seatedAt(state *pokerModels.Table, userID string) bool {
+	for _, p := range state.Players {
+		if p != nil && p.PlayerID == userID {
+			return true
+		}
 	}
+	return false
 }
 
-// SendTableState sends the current table state to a client
+// SendTableState sends the current table state to a client. Spectators of a
+// delayed table get the latest state released to spectators instead, with all
+// hole cards for production clients.
 func SendTableState(
 	c *Client,
 	tableID string,
 	getTable func(string) (interface{}, bool),
 	sumSidePots func([]pokerModels.SidePot) int,
+	stats interface{},
+	spectators SpectatorDelayer,
 ) {
 	tableInterface, exists := getTable(tableID)
 	if !exists {
@@ -133,101 +200,69 @@ func SendTableState(
 		return
 	}
 
-	state := table.GetState()
-
-	players := []map[string]interface{}{}
-	for _, p := range state.Players {
-		if p != nil {
-			playerData := map[string]interface{}{
-				"user_id":             p.PlayerID,
-				"username":            p.PlayerName,
-				"seat_number":         p.SeatNumber,
-				"chips":               p.Chips,
-				"status":              string(p.Status),
-				"current_bet":         p.Bet,
-				"folded":              p.Status == pokerModels.StatusFolded,
-				"all_in":              p.Status == pokerModels.StatusAllIn,
-				"is_dealer":           p.IsDealer,
-				"last_action":         string(p.LastAction),
-				"last_action_amount":  p.LastActionAmount,
-			}
+	SendSnapshot(c, tableID, table.Snapshot(), sumSidePots, stats, spectators)
+}
 
-			if p.PlayerID == c.UserID && len(p.Cards) > 0 {
-				cards := make([]string, len(p.Cards))
-				for i, card := range p.Cards {
-					cards[i] = card.String()
-				}
-				playerData["cards"] = cards
+// SendSnapshot sends a table state to a client, as SendTableState does. It
+// also serves the state of a table owned by another backend instance.
+func SendSnapshot(
+	c *Client,
+	tableID string,
+	state *pokerModels.Table,
+	sumSidePots func([]pokerModels.SidePot) int,
+	stats interface{},
+	spectators SpectatorDelayer,
+) {
+	observeHoleCards(tableID, state)
+	if spectators != nil && spectators.Delay(tableID) > 0 && !seatedAt(state, c.UserID) {
+		if latest := spectators.Latest(tableID, c.CardsUp); latest != nil {
+			auditLeak(c, latest)
+			select {
+			case c.Send <- latest:
+			default:
 			}
-
-			players = append(players, playerData)
+			return
 		}
+		SendToClient(c, WSMessage{
+			Type: "spectator_delay",
+			Payload: map[string]interface{}{
+				"table_id":      tableID,
+				"delay_seconds": int(spectators.Delay(tableID) / time.Second),
+			},
+		})
+		return
 	}
 
-	communityCards := []string{}
-	pot := 0
-	var currentTurn *string
-	bettingRound := ""
-	currentBet := 0
-
-	// Only access CurrentHand if it exists
-	if state.CurrentHand != nil {
-		communityCards = make([]string, len(state.CurrentHand.CommunityCards))
-		for i, card := range state.CurrentHand.CommunityCards {
-			communityCards[i] = card.String()
-		}
-
-		// Calculate pot
-		pot = state.CurrentHand.Pot.Main + sumSidePots(state.CurrentHand.Pot.Side)
+	// The next broadcast starts the client's deltas again from a full state
+	c.ResetDelta()
 
-		bettingRound = string(state.CurrentHand.BettingRound)
-		currentBet = state.CurrentHand.CurrentBet
-
-		if state.CurrentHand.CurrentPosition >= 0 && state.CurrentHand.CurrentPosition < len(state.Players) {
-			if currentPlayer := state.Players[state.CurrentHand.CurrentPosition]; currentPlayer != nil {
-				currentTurn = &currentPlayer.PlayerID
-			}
-		}
-	}
-
-	payload := map[string]interface{}{
-		"table_id":        tableID,
-		"players":         players,
-		"community_cards": communityCards,
-		"pot":             pot,
-		"current_turn":    currentTurn,
-		"status":          string(state.Status),
-		"betting_round":   bettingRound,
-		"current_bet":     currentBet,
+	// Uses the same public view as broadcasts, with only this viewer's cards injected
+	build := buildTableStateFrame
+	if c.HasFeature(FeatureBinary) {
+		build = buildBinaryFrame
 	}
-
-	// Add action deadline if there's an active player
-	if state.CurrentHand != nil && state.CurrentHand.ActionDeadline != nil && !state.CurrentHand.ActionDeadline.IsZero() {
-		payload["action_deadline"] = state.CurrentHand.ActionDeadline.Format(time.RFC3339)
+	frame := build("table_state", tableID, state, sumSidePots)
+	if stats != nil {
+		frame.appendField("stats", stats)
 	}
-
-	// Add winners if hand is complete
-	if state.Status == pokerModels.StatusHandComplete && len(state.Winners) > 0 {
-		payload["winners"] = state.Winners
+	message := frame.messageFor(c.UserID)
+	auditLeak(c, message)
+	select {
+	case c.Send <- message:
+	default:
 	}
-
-	SendToClient(c, WSMessage{
-		Type:    "table_state",
-		Payload: payload,
-	})
 }
 
-// BroadcastTableState broadcasts the table state to all connected clients at a table
+// BroadcastTableState broadcasts the table state to all connected clients at a
+// table. On a table with a spectator delay, clients who aren't seated get the
+// public view once the delay has passed.
 func BroadcastTableState(
 	tableID string,
-	clients map[string]interface{},
-	mu *sync.RWMutex,
+	clients Registry,
 	getTable func(string) (interface{}, bool),
 	sumSidePots func([]pokerModels.SidePot) int,
+	spectators SpectatorDelayer,
 ) {
-	mu.RLock()
-	defer mu.RUnlock()
-
 	tableInterface, exists := getTable(tableID)
 	if !exists {
 		return
@@ -239,136 +274,83 @@ func BroadcastTableState(
 		return
 	}
 
-	state := table.GetState()
+	BroadcastSnapshot(tableID, table.Snapshot(), clients, sumSidePots, spectators)
+}
 
-	for _, clientInterface := range clients {
-		client, ok := clientInterface.(*Client)
-		if !ok {
-			continue
+// BroadcastSnapshot broadcasts a table state to the clients at the table, as
+// BroadcastTableState does. It also serves the state of a table owned by
+// another backend instance.
+func BroadcastSnapshot(
+	tableID string,
+	state *pokerModels.Table,
+	clients Registry,
+	sumSidePots func([]pokerModels.SidePot) int,
+	spectators SpectatorDelayer,
+) {
+	observeHoleCards(tableID, state)
+
+	// Encode the shared payload once; only the viewer's hole cards differ per
+	// client. The binary form is only built if a client at the table wants it.
+	frame := buildTableStateFrame("game_update", tableID, state, sumSidePots)
+	var binaryFrame *tableStateFrame
+
+	var historyData []byte
+	if len(state.History) > 0 {
+		historyMsg := WSMessage{
+			Type: "history_log",
+			Payload: map[string]interface{}{
+				"table_id": tableID,
+				"entries":  state.History,
+			},
 		}
-		if client.TableID == tableID {
-			players := []map[string]interface{}{}
-			for _, p := range state.Players {
-				if p != nil {
-					playerData := map[string]interface{}{
-						"user_id":             p.PlayerID,
-						"username":            p.PlayerName,
-						"seat_number":         p.SeatNumber,
-						"chips":               p.Chips,
-						"status":              string(p.Status),
-						"current_bet":         p.Bet,
-						"folded":              p.Status == pokerModels.StatusFolded,
-						"all_in":              p.Status == pokerModels.StatusAllIn,
-						"is_dealer":           p.IsDealer,
-						"last_action":         string(p.LastAction),
-						"last_action_amount":  p.LastActionAmount,
-					}
-
-					// Show cards to owner or during showdown (hand complete and not folded)
-					if p.PlayerID == client.UserID && len(p.Cards) > 0 {
-						cards := make([]string, len(p.Cards))
-						for i, card := range p.Cards {
-							cards[i] = card.String()
-						}
-						playerData["cards"] = cards
-					} else if state.Status == pokerModels.StatusHandComplete && p.Status != pokerModels.StatusFolded && len(p.Cards) > 0 {
-						// Show all non-folded players' cards during showdown
-						cards := make([]string, len(p.Cards))
-						for i, card := range p.Cards {
-							cards[i] = card.String()
-						}
-						playerData["cards"] = cards
-					}
-
-					players = append(players, playerData)
-				}
-			}
-
-			communityCards := []string{}
-			pot := 0
-			var currentTurn *string
-			bettingRound := ""
-			currentBet := 0
-			var actionSequence uint64
-
-			// Only access CurrentHand if it exists
-			if state.CurrentHand != nil {
-				communityCards = make([]string, len(state.CurrentHand.CommunityCards))
-				for i, card := range state.CurrentHand.CommunityCards {
-					communityCards[i] = card.String()
-				}
-
-				// Calculate pot
-				pot = state.CurrentHand.Pot.Main + sumSidePots(state.CurrentHand.Pot.Side)
-
-				bettingRound = string(state.CurrentHand.BettingRound)
-				currentBet = state.CurrentHand.CurrentBet
-				actionSequence = state.CurrentHand.ActionSequence
-
-				if state.CurrentHand.CurrentPosition >= 0 && state.CurrentHand.CurrentPosition < len(state.Players) {
-					if currentPlayer := state.Players[state.CurrentHand.CurrentPosition]; currentPlayer != nil {
-						currentTurn = &currentPlayer.PlayerID
-					}
-				}
-			}
-
-			payload := map[string]interface{}{
-				"table_id":        tableID,
-				"players":         players,
-				"community_cards": communityCards,
-				"pot":             pot,
-				"current_turn":    currentTurn,
-				"status":          string(state.Status),
-				"betting_round":   bettingRound,
-				"current_bet":     currentBet,
-				"action_sequence": actionSequence,
-			}
-
-			// Add dealer and blind positions if hand is active
-			if state.CurrentHand != nil {
-				payload["dealer_position"] = state.CurrentHand.DealerPosition
-				payload["small_blind_position"] = state.CurrentHand.SmallBlindPosition
-				payload["big_blind_position"] = state.CurrentHand.BigBlindPosition
-			}
+		historyData, _ = json.Marshal(historyMsg)
+	}
 
-			// Add action deadline if there's an active player
-			if state.CurrentHand != nil && state.CurrentHand.ActionDeadline != nil && !state.CurrentHand.ActionDeadline.IsZero() {
-				payload["action_deadline"] = state.CurrentHand.ActionDeadline.Format(time.RFC3339)
-			}
+	delayed := spectators != nil && spectators.Delay(tableID) > 0
+	if delayed {
+		spectators.HoldState(tableID, frame.messageFor(""), frame.cardsUp())
+		if historyData != nil {
+			spectators.Hold(tableID, historyData)
+		}
+	}
 
-			// Add winners if hand is complete
-			if state.Status == pokerModels.StatusHandComplete && len(state.Winners) > 0 {
-				payload["winners"] = state.Winners
+	clients.Each(func(_ string, clientInterface interface{}) {
+		client, ok := clientInterface.(*Client)
+		if !ok || client.TableID != tableID {
+			return
+		}
+		if delayed && !seatedAt(state, client.UserID) {
+			return
+		}
+		var data []byte
+		switch {
+		case client.HasFeature(FeatureBinary):
+			if binaryFrame == nil {
+				binaryFrame = buildBinaryFrame("game_update", tableID, state, sumSidePots)
 			}
-
-			msg := WSMessage{
-				Type:    "game_update",
-				Payload: payload,
+			data = binaryFrame.messageFor(client.UserID)
+		case client.HasFeature(FeatureDeltaUpdates):
+			if !client.sendDelta(tableID, frame) {
+				client.Disconnect(CloseSlowConsumer, "")
+				return
 			}
-
-			data, _ := json.Marshal(msg)
+		default:
+			data = frame.messageFor(client.UserID)
+		}
+		if data != nil {
+			auditLeak(client, data)
 			select {
 			case client.Send <- data:
 			default:
-				close(client.Send)
+				client.Disconnect(CloseSlowConsumer, "")
+				return
 			}
+		}
 
-			// Send history log message separately
-			if len(state.History) > 0 {
-				historyMsg := WSMessage{
-					Type: "history_log",
-					Payload: map[string]interface{}{
-						"table_id": tableID,
-						"entries":  state.History,
-					},
-				}
-				historyData, _ := json.Marshal(historyMsg)
-				select {
-				case client.Send <- historyData:
-				default:
-					close(client.Send)
-				}
-			}
+		// Send history log message separately; it is dropped rather than
+		// disconnecting a client that is behind
+		if historyData != nil {
+			client.Enqueue(historyData, PriorityLow)
 		}
-	}
+	})
 }
@@ -0,0 +1,68 @@
+// Package testutil holds helpers shared by the backend's tests
+package testutil
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Schema creates the tables of models in a SQLite test database, with every
+// column and unique index the models have. MySQL-only column types such as
+// enum are created as varchar. NOT NULL and plain indexes are left out, so
+// tests can insert only the columns they care about.
+func Schema(t testing.TB, db *gorm.DB, models ...interface{}) {
+	t.Helper()
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("Failed to parse model %T: %v", model, err)
+		}
+
+		columns := make([]string, 0, len(stmt.Schema.DBNames)+1)
+		hasPrimaryKey := false
+		for _, dbName := range stmt.Schema.DBNames {
+			field := *stmt.Schema.FieldsByDBName[dbName]
+			if field.IgnoreMigration {
+				continue
+			}
+			field.NotNull = false
+			if strings.HasPrefix(strings.ToLower(string(field.DataType)), "enum") {
+				field.DataType = "varchar(32)"
+			}
+			column := db.Migrator().FullDataTypeOf(&field).SQL
+			hasPrimaryKey = hasPrimaryKey || strings.Contains(strings.ToUpper(column), "PRIMARY KEY")
+			columns = append(columns, dbName+" "+column)
+		}
+		if keys := primaryKeys(stmt.Schema); !hasPrimaryKey && len(keys) > 0 {
+			columns = append(columns, "PRIMARY KEY ("+strings.Join(keys, ", ")+")")
+		}
+		// Index names are global in SQLite, so unique indexes become constraints
+		for _, index := range stmt.Schema.ParseIndexes() {
+			if index.Class != "UNIQUE" {
+				continue
+			}
+			names := make([]string, 0, len(index.Fields))
+			for _, option := range index.Fields {
+				names = append(names, option.DBName)
+			}
+			columns = append(columns, "UNIQUE ("+strings.Join(names, ", ")+")")
+		}
+
+		ddl := "CREATE TABLE " + stmt.Schema.Table + " (" + strings.Join(columns, ", ") + ")"
+		if err := db.Exec(ddl).Error; err != nil {
+			t.Fatalf("Failed to create table %s: %v", stmt.Schema.Table, err)
+		}
+	}
+}
+
+// primaryKeys lists the primary key columns of a model
+func primaryKeys(s *schema.Schema) []string {
+	keys := make([]string, 0, len(s.PrimaryFields))
+	for _, field := range s.PrimaryFields {
+		keys = append(keys, field.DBName)
+	}
+	return keys
+}
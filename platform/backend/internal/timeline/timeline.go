@@ -0,0 +1,283 @@
+// Package timeline assembles a player's activity across cash tables,
+// tournaments and their chip balance into one feed, newest first.
+package timeline
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+
+	pokerModels "poker-engine/models"
+
+	"gorm.io/gorm"
+)
+
+// Kinds of timeline entries
+const (
+	KindTableJoined          = "table_joined"
+	KindTableLeft            = "table_left"
+	KindHandWon              = "hand_won"
+	KindTournamentRegistered = "tournament_registered"
+	KindTournamentLeft       = "tournament_unregistered"
+	KindTournamentFinished   = "tournament_finished"
+	KindPrizeReceived        = "prize_received"
+	KindTransaction          = "transaction"
+)
+
+const (
+	// DefaultLimit is the page size when none is asked for
+	DefaultLimit = 50
+
+	// MaxLimit is the largest page
+	MaxLimit = 100
+
+	// MaxOffset bounds how far back the feed can be paged, since every
+	// source is read up to the end of the page
+	MaxOffset = 1000
+
+	// handScanBatch is how many of the player's hands are read at a time
+	// while looking for the ones they won
+	handScanBatch = 200
+)
+
+// Entry is one thing the player did or that happened to them
+type Entry struct {
+	Kind            string    `json:"kind"`
+	At              time.Time `json:"at"`
+	TableID         string    `json:"table_id,omitempty"`
+	TableName       string    `json:"table_name,omitempty"`
+	HandID          int64     `json:"hand_id,omitempty"`
+	TournamentID    string    `json:"tournament_id,omitempty"`
+	TournamentName  string    `json:"tournament_name,omitempty"`
+	Position        int       `json:"position,omitempty"`         // tournament_finished
+	Amount          int       `json:"amount,omitempty"`           // Chips won, or the transaction's change to the balance
+	TransactionType string    `json:"transaction_type,omitempty"` // prize_received and transaction
+	Description     string    `json:"description,omitempty"`
+}
+
+// Service reads timelines
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService creates a timeline service
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// Feed returns a page of the user's timeline, newest first. limit is capped
+// at MaxLimit and defaults to DefaultLimit; offset is capped at MaxOffset.
+func (s *Service) Feed(userID string, limit, offset int) ([]Entry, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > MaxOffset {
+		offset = MaxOffset
+	}
+
+	// Each source is read up to the end of the page, newest first, so the
+	// merge has every entry the page could hold
+	n := offset + limit
+	var entries []Entry
+	for _, source := range []func(string, int) ([]Entry, error){
+		s.seats,
+		s.handsWon,
+		s.tournaments,
+		s.transactions,
+	} {
+		found, err := source(userID, n)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, found...)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.After(entries[j].At)
+	})
+	if offset >= len(entries) {
+		return []Entry{}, nil
+	}
+	entries = entries[offset:]
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// seats lists the cash and sit & go tables the user joined and left.
+// Tournament tables are covered by the tournament's own entries.
+func (s *Service) seats(userID string, n int) ([]Entry, error) {
+	type seatRow struct {
+		TableID  string
+		Name     string
+		JoinedAt time.Time
+		LeftAt   *time.Time
+	}
+
+	var joined []seatRow
+	if err := s.db.Table("table_seats ts").
+		Select("ts.table_id, t.name, ts.joined_at, ts.left_at").
+		Joins("JOIN tables t ON t.id = ts.table_id").
+		Where("ts.user_id = ? AND t.tournament_id IS NULL", userID).
+		Order("ts.joined_at DESC").Limit(n).
+		Scan(&joined).Error; err != nil {
+		return nil, err
+	}
+	var left []seatRow
+	if err := s.db.Table("table_seats ts").
+		Select("ts.table_id, t.name, ts.joined_at, ts.left_at").
+		Joins("JOIN tables t ON t.id = ts.table_id").
+		Where("ts.user_id = ? AND t.tournament_id IS NULL AND ts.left_at IS NOT NULL", userID).
+		Order("ts.left_at DESC").Limit(n).
+		Scan(&left).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(joined)+len(left))
+	for _, row := range joined {
+		entries = append(entries, Entry{Kind: KindTableJoined, At: row.JoinedAt, TableID: row.TableID, TableName: row.Name})
+	}
+	for _, row := range left {
+		entries = append(entries, Entry{Kind: KindTableLeft, At: *row.LeftAt, TableID: row.TableID, TableName: row.Name})
+	}
+	return entries, nil
+}
+
+// handsWon lists the hands the user won a pot in, at tables they were
+// seated at when the hand was played
+func (s *Service) handsWon(userID string, n int) ([]Entry, error) {
+	type handRow struct {
+		ID          int64
+		TableID     string
+		Name        string
+		Winners     string
+		CompletedAt time.Time
+	}
+
+	var entries []Entry
+	for offset := 0; len(entries) < n; offset += handScanBatch {
+		var hands []handRow
+		if err := s.db.Table("hands h").
+			Select("h.id, h.table_id, t.name, h.winners, h.completed_at").
+			Joins("JOIN table_seats ts ON ts.table_id = h.table_id AND ts.user_id = ? AND h.started_at >= ts.joined_at AND (ts.left_at IS NULL OR h.started_at <= ts.left_at)", userID).
+			Joins("JOIN tables t ON t.id = h.table_id").
+			Where("h.completed_at IS NOT NULL").
+			Order("h.completed_at DESC, h.id DESC").Limit(handScanBatch).Offset(offset).
+			Scan(&hands).Error; err != nil {
+			return nil, err
+		}
+
+		for _, hand := range hands {
+			var winners []pokerModels.Winner
+			if err := json.Unmarshal([]byte(hand.Winners), &winners); err != nil {
+				continue
+			}
+			won := 0
+			for _, winner := range winners {
+				if winner.PlayerID == userID {
+					won += winner.Amount
+				}
+			}
+			if won > 0 {
+				entries = append(entries, Entry{
+					Kind:      KindHandWon,
+					At:        hand.CompletedAt,
+					TableID:   hand.TableID,
+					TableName: hand.Name,
+					HandID:    hand.ID,
+					Amount:    won,
+				})
+			}
+		}
+		if len(hands) < handScanBatch {
+			break
+		}
+	}
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries, nil
+}
+
+// tournaments lists the user's registrations, unregistrations and finishes
+// from the tournaments' event logs
+func (s *Service) tournaments(userID string, n int) ([]Entry, error) {
+	type eventRow struct {
+		TournamentID string
+		Name         string
+		Type         string
+		Data         string
+		CreatedAt    time.Time
+	}
+
+	var events []eventRow
+	if err := s.db.Table("tournament_events e").
+		Select("e.tournament_id, t.name, e.type, e.data, e.created_at").
+		Joins("JOIN tournaments t ON t.id = e.tournament_id").
+		Where("e.user_id = ? AND e.type IN ?", userID, []string{"registered", "unregistered", "eliminated", "completed"}).
+		Order("e.created_at DESC, e.sequence DESC").Limit(n).
+		Scan(&events).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(events))
+	for _, event := range events {
+		entry := Entry{At: event.CreatedAt, TournamentID: event.TournamentID, TournamentName: event.Name}
+		switch event.Type {
+		case "registered":
+			entry.Kind = KindTournamentRegistered
+		case "unregistered":
+			entry.Kind = KindTournamentLeft
+		case "eliminated":
+			var data struct {
+				Position int `json:"position"`
+			}
+			json.Unmarshal([]byte(event.Data), &data)
+			entry.Kind, entry.Position = KindTournamentFinished, data.Position
+		case "completed":
+			// The completed event names the winner
+			entry.Kind, entry.Position = KindTournamentFinished, 1
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// transactions lists the changes to the user's chip balance, with prizes
+// told apart
+func (s *Service) transactions(userID string, n int) ([]Entry, error) {
+	var txs []currency.Transaction
+	if err := s.db.Where("user_id = ?", userID).
+		Order("created_at DESC").Limit(n).
+		Find(&txs).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(txs))
+	for _, tx := range txs {
+		entry := Entry{
+			Kind:            KindTransaction,
+			At:              tx.CreatedAt,
+			Amount:          tx.Amount,
+			TransactionType: string(tx.TransactionType),
+			Description:     tx.Description,
+		}
+		if tx.TransactionType == currency.TxTypeTournamentPrize {
+			entry.Kind = KindPrizeReceived
+			if tx.ReferenceID != nil {
+				entry.TournamentID = *tx.ReferenceID
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,88 @@
+package timeline
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	testutil.Schema(t, db, &models.Table{}, &models.TableSeat{}, &models.Hand{}, &models.Tournament{}, &models.TournamentEvent{}, &currency.Transaction{})
+	for _, stmt := range []string{
+		`INSERT INTO tables (id, name, tournament_id) VALUES ('cash', 'Cash Table', NULL), ('final', 'Final Table', 'sunday')`,
+		`INSERT INTO table_seats (table_id, user_id, chips, joined_at, left_at) VALUES
+			('cash', 'alice', 1000, '2026-01-01 10:00:00', '2026-01-01 11:00:00'),
+			('cash', 'bob', 1000, '2026-01-01 10:00:00', NULL),
+			('final', 'alice', 1000, '2026-01-02 10:00:00', NULL)`,
+		`INSERT INTO hands (table_id, winners, started_at, completed_at) VALUES
+			('cash', '[{"playerId":"alice","amount":4000}]', '2026-01-01 10:10:00', '2026-01-01 10:12:00'),
+			('cash', '[{"playerId":"bob","amount":300}]', '2026-01-01 10:20:00', '2026-01-01 10:22:00'),
+			('cash', '[{"playerId":"alice","amount":200}]', '2026-01-01 12:00:00', '2026-01-01 12:02:00')`,
+		`INSERT INTO tournaments (id, name) VALUES ('sunday', 'Sunday Major')`,
+		`INSERT INTO tournament_events (id, tournament_id, sequence, type, user_id, data, created_at) VALUES
+			('ev-1', 'sunday', 1, 'registered', 'alice', '{}', '2026-01-02 09:00:00'),
+			('ev-2', 'sunday', 2, 'started', NULL, '{}', '2026-01-02 10:00:00'),
+			('ev-3', 'sunday', 3, 'eliminated', 'alice', '{"position":3}', '2026-01-02 12:00:00')`,
+		`INSERT INTO chip_transactions (id, user_id, amount, transaction_type, reference_id, description, created_at) VALUES
+			('tx-1', 'alice', -1000, 'cash_game_buy_in', 'cash', 'Buy-in', '2026-01-01 10:00:00'),
+			('tx-2', 'alice', 5000, 'tournament_prize', 'sunday', 'Prize for position 3', '2026-01-02 12:30:00')`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	return db
+}
+
+func TestFeed(t *testing.T) {
+	s := NewService(setupTestDB(t))
+
+	entries, err := s.Feed("alice", 0, 0)
+	require.NoError(t, err)
+
+	var kinds []string
+	for _, e := range entries {
+		kinds = append(kinds, e.Kind)
+	}
+	// The hand after alice left and bob's hand are not hers, and the
+	// tournament table is covered by the tournament's entries
+	assert.Equal(t, []string{
+		KindPrizeReceived,
+		KindTournamentFinished,
+		KindTournamentRegistered,
+		KindTableLeft,
+		KindHandWon,
+		KindTableJoined,
+		KindTransaction,
+	}, kinds)
+
+	assert.Equal(t, "sunday", entries[0].TournamentID)
+	assert.Equal(t, 5000, entries[0].Amount)
+	assert.Equal(t, "Sunday Major", entries[1].TournamentName)
+	assert.Equal(t, 3, entries[1].Position)
+	assert.Equal(t, "Cash Table", entries[4].TableName)
+	assert.Equal(t, 4000, entries[4].Amount)
+	assert.Equal(t, -1000, entries[6].Amount)
+}
+
+func TestFeed_Pagination(t *testing.T) {
+	s := NewService(setupTestDB(t))
+
+	all, err := s.Feed("alice", 0, 0)
+	require.NoError(t, err)
+
+	page, err := s.Feed("alice", 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, all[3:5], page)
+
+	page, err = s.Feed("alice", 10, 100)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
@@ -0,0 +1,333 @@
+package tournament
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Refund methods for aborted tournaments
+const (
+	RefundMethodChipChop = "chip_chop" // Remaining pool split in proportion to stacks
+	RefundMethodICM      = "icm"       // Remaining pool split by each stack's ICM equity
+)
+
+// maxICMPlayers bounds the exact ICM calculation, which is exponential in the
+// number of players left
+const maxICMPlayers = 16
+
+// AbortRefund is what one player receives when a tournament is aborted
+type AbortRefund struct {
+	UserID    string `json:"user_id"`
+	Username  string `json:"username,omitempty"`
+	Position  *int   `json:"position,omitempty"` // Set for players already eliminated
+	Chips     int    `json:"chips"`              // Stack when the tournament was aborted
+	Amount    int    `json:"amount"`             // Share of the prize pool
+	FeeRefund int    `json:"fee_refund"`         // Entry fee returned by the operator
+}
+
+// AbortPlan is how the prize pool of an aborted tournament is paid out
+type AbortPlan struct {
+	TournamentID string        `json:"tournament_id"`
+	Method       string        `json:"method"`
+	PrizePool    int           `json:"prize_pool"`
+	Overlay      int           `json:"overlay,omitempty"` // Part of the pool the house covers to honour the guarantee
+	Refunds      []AbortRefund `json:"refunds"`
+}
+
+// ComputeAbortRefunds splits a prize pool between the players of an aborted
+// tournament. Players already eliminated in the money keep the prize for the
+// place they finished in; the rest of the pool goes to the players still in,
+// by stack or by ICM equity over the places left. Eliminated players outside
+// the money get nothing. Rounding leftovers go to the biggest stack.
+func ComputeAbortRefunds(prizePool int, structure models.PrizeStructureConfig, players []AbortRefund, method string) ([]AbortRefund, error) {
+	if method != RefundMethodChipChop && method != RefundMethodICM {
+		return nil, ErrInvalidRefundMethod
+	}
+
+	payouts := CalculatePrizeAmounts(prizePool, structure)
+	refunds := append([]AbortRefund(nil), players...)
+
+	remaining := prizePool
+	var alive []int
+	for i := range refunds {
+		refunds[i].Amount = 0
+		if refunds[i].Position != nil {
+			refunds[i].Amount = payouts[*refunds[i].Position]
+			remaining -= refunds[i].Amount
+		} else if refunds[i].Chips > 0 {
+			alive = append(alive, i)
+		}
+	}
+	if len(alive) == 0 || remaining <= 0 {
+		return refunds, nil
+	}
+	if method == RefundMethodICM && len(alive) > maxICMPlayers {
+		return nil, ErrTooManyPlayersForICM
+	}
+
+	stacks := make([]int, len(alive))
+	for i, idx := range alive {
+		stacks[i] = refunds[idx].Chips
+	}
+
+	var shares []float64
+	if method == RefundMethodICM {
+		places := make([]int, len(alive))
+		for place := range places {
+			places[place] = payouts[place+1]
+		}
+		shares = icmEquities(stacks, places)
+	} else {
+		shares = make([]float64, len(stacks))
+		for i, chips := range stacks {
+			shares[i] = float64(chips)
+		}
+	}
+
+	total := 0.0
+	for _, share := range shares {
+		total += share
+	}
+	biggest := 0
+	allocated := 0
+	for i, idx := range alive {
+		amount := int(float64(remaining) * shares[i] / total)
+		refunds[idx].Amount = amount
+		allocated += amount
+		if stacks[i] > stacks[biggest] {
+			biggest = i
+		}
+	}
+	refunds[alive[biggest]].Amount += remaining - allocated
+
+	return refunds, nil
+}
+
+// icmEquities returns each stack's expected winnings under the Malmuth-Harville
+// model, where the chance of finishing in the next place is proportional to
+// stack size. places[k] is the payout for place k+1.
+func icmEquities(stacks []int, places []int) []float64 {
+	n := len(stacks)
+	equities := make([]float64, n)
+
+	// prob[mask] is the chance the players in mask took the top places, in some order
+	prob := make([]float64, 1<<n)
+	prob[0] = 1
+	for mask := 0; mask < len(prob); mask++ {
+		if prob[mask] == 0 {
+			continue
+		}
+		place := 0
+		left := 0
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				place++
+			} else {
+				left += stacks[i]
+			}
+		}
+		if place >= len(places) || left == 0 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				continue
+			}
+			p := prob[mask] * float64(stacks[i]) / float64(left)
+			equities[i] += p * float64(places[place])
+			prob[mask|1<<i] += p
+		}
+	}
+	return equities
+}
+
+// tournamentPrizeStructure returns the prize structure stored on a tournament,
+// which is either a JSON structure or the name of a preset
+func tournamentPrizeStructure(tournament *models.Tournament) (models.PrizeStructureConfig, error) {
+	var structure models.PrizeStructureConfig
+	if err := json.Unmarshal([]byte(tournament.PrizeStructure), &structure); err == nil && len(structure.Positions) > 0 {
+		return structure, nil
+	}
+	if preset, ok := GetPrizeStructurePreset(tournament.PrizeStructure); ok {
+		return preset, nil
+	}
+	return structure, fmt.Errorf("%w: %s", ErrInvalidPrizeStructure, tournament.PrizeStructure)
+}
+
+// planAbort works out the refunds for aborting a started tournament. Stacks are
+// taken from the table seats, which hold the chips as of the last completed
+// hand, so a hand in progress when the tournament is aborted is void.
+func (s *Service) planAbort(tx *gorm.DB, tournament *models.Tournament, method string) (*AbortPlan, error) {
+	if tournament.Status != "starting" && tournament.Status != "in_progress" && tournament.Status != "paused" {
+		return nil, ErrCannotAbort
+	}
+
+	structure, err := tournamentPrizeStructure(tournament)
+	if err != nil {
+		return nil, err
+	}
+
+	var players []models.TournamentPlayer
+	if err := tx.Where("tournament_id = ?", tournament.ID).Find(&players).Error; err != nil {
+		return nil, err
+	}
+
+	var seats []models.TableSeat
+	if err := tx.Joins("JOIN tables ON tables.id = table_seats.table_id").
+		Where("tables.tournament_id = ? AND table_seats.left_at IS NULL", tournament.ID).
+		Find(&seats).Error; err != nil {
+		return nil, err
+	}
+	seated := make(map[string]int, len(seats))
+	for _, seat := range seats {
+		seated[seat.UserID] = seat.Chips
+	}
+
+	bounties := 0
+	entrants := make([]AbortRefund, 0, len(players))
+	for _, player := range players {
+		bounties += player.Bounty
+		entrant := AbortRefund{UserID: player.UserID, Position: player.Position, FeeRefund: tournament.EntryFee}
+		if player.Position == nil {
+			if chips, ok := seated[player.UserID]; ok {
+				entrant.Chips = chips
+			} else if player.Chips != nil {
+				entrant.Chips = *player.Chips
+			}
+		}
+		entrants = append(entrants, entrant)
+	}
+
+	// Bounties nobody has won yet are chopped with the prize pool
+	prizePool, overlay := GuaranteedPool(tournament, tournament.PrizePool)
+	prizePool += bounties
+	refunds, err := ComputeAbortRefunds(prizePool, structure, entrants, method)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []models.User
+	if len(refunds) > 0 {
+		ids := make([]string, len(refunds))
+		for i, refund := range refunds {
+			ids[i] = refund.UserID
+		}
+		if err := tx.Select("id", "username").Where("id IN ?", ids).Find(&users).Error; err != nil {
+			return nil, err
+		}
+	}
+	names := make(map[string]string, len(users))
+	for _, user := range users {
+		names[user.ID] = user.Username
+	}
+	for i := range refunds {
+		refunds[i].Username = names[refunds[i].UserID]
+	}
+
+	sort.SliceStable(refunds, func(i, j int) bool { return refunds[i].Amount > refunds[j].Amount })
+
+	return &AbortPlan{
+		TournamentID: tournament.ID,
+		Method:       method,
+		PrizePool:    prizePool,
+		Overlay:      overlay,
+		Refunds:      refunds,
+	}, nil
+}
+
+// PreviewAbort returns the refunds AbortTournament would pay without paying them
+func (s *Service) PreviewAbort(tournamentID, method string) (*AbortPlan, error) {
+	tournament, err := s.GetTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.planAbort(s.db, tournament, method)
+}
+
+// AbortTournament stops a tournament that has already started, for example
+// after a prolonged outage, and pays out the prize pool with the given refund
+// method. Entry fees are returned in full. Every payment is a ledger entry
+// naming the admin and reason, and the tournament is left cancelled.
+func (s *Service) AbortTournament(tournamentID, method, adminID, reason string) (*AbortPlan, error) {
+	return s.abortTournament(tournamentID, method, &adminID, fmt.Sprintf("%s refund by admin %s", method, adminID), reason)
+}
+
+// abortTournament pays out and cancels a started tournament for AbortTournament
+// and EndTournament. by is who the cancellation is recorded against and how
+// says in the ledger how it came to be aborted.
+func (s *Service) abortTournament(tournamentID, method string, by *string, how, reason string) (*AbortPlan, error) {
+	var plan *AbortPlan
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var tournament models.Tournament
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", tournamentID).
+			First(&tournament).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrTournamentNotFound
+			}
+			return err
+		}
+
+		var err error
+		plan, err = s.planAbort(tx, &tournament, method)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		description := fmt.Sprintf("Aborted tournament %s (%s): %s", tournament.Name, how, reason)
+		if plan.Overlay > 0 {
+			if err := s.currencyService.CoverOverlayWithTx(ctx, tx, plan.Overlay, tournament.ID, description); err != nil {
+				return fmt.Errorf("failed to cover overlay: %w", err)
+			}
+		}
+		for _, refund := range plan.Refunds {
+			if refund.Amount > 0 {
+				if err := s.currencyService.AddChipsWithTx(ctx, tx, refund.UserID, refund.Amount,
+					currency.TxTypeTournamentAbortRefund, tournament.ID, description); err != nil {
+					return fmt.Errorf("failed to refund player %s: %w", refund.UserID, err)
+				}
+				if err := tx.Model(&models.TournamentPlayer{}).
+					Where("tournament_id = ? AND user_id = ?", tournament.ID, refund.UserID).
+					Update("prize_amount", refund.Amount).Error; err != nil {
+					return err
+				}
+			}
+			if refund.FeeRefund > 0 {
+				if err := s.currencyService.ReverseFeeWithTx(ctx, tx, refund.FeeRefund,
+					tournament.ID, description); err != nil {
+					return fmt.Errorf("failed to reverse entry fee: %w", err)
+				}
+				if err := s.currencyService.AddChipsWithTx(ctx, tx, refund.UserID, refund.FeeRefund,
+					currency.TxTypeTournamentFeeRefund, tournament.ID, description); err != nil {
+					return fmt.Errorf("failed to refund entry fee: %w", err)
+				}
+			}
+		}
+
+		if err := recordEvent(tx, &tournament, EventCancelled, by, models.TournamentEventData{Reason: reason}, time.Now()); err != nil {
+			return err
+		}
+		// Prizes count as distributed so the elimination tracker never pays out again
+		if err := tx.Model(&tournament).Update("prizes_distributed", true).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Table{}).
+			Where("tournament_id = ?", tournament.ID).
+			Update("status", "completed").Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
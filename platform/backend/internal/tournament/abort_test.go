@@ -0,0 +1,113 @@
+package tournament
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eliminatedAt(position int) *int {
+	return &position
+}
+
+func refundsByUser(refunds []AbortRefund) map[string]int {
+	amounts := make(map[string]int, len(refunds))
+	for _, refund := range refunds {
+		amounts[refund.UserID] = refund.Amount
+	}
+	return amounts
+}
+
+func TestComputeAbortRefunds_ChipChop(t *testing.T) {
+	players := []AbortRefund{
+		{UserID: "a", Chips: 6000},
+		{UserID: "b", Chips: 3000},
+		{UserID: "c", Chips: 1000},
+		{UserID: "d", Position: eliminatedAt(4)},
+	}
+
+	refunds, err := ComputeAbortRefunds(1000, Top3Payout, players, RefundMethodChipChop)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 600, "b": 300, "c": 100, "d": 0}, refundsByUser(refunds))
+}
+
+func TestComputeAbortRefunds_ICMHeadsUp(t *testing.T) {
+	structure := models.PrizeStructureConfig{Positions: []models.PrizePosition{
+		{Position: 1, BasisPoints: 7000},
+		{Position: 2, BasisPoints: 3000},
+	}}
+	players := []AbortRefund{{UserID: "a", Chips: 3000}, {UserID: "b", Chips: 1000}}
+
+	// Heads up, each player gets 2nd place plus their chance of winning the difference
+	refunds, err := ComputeAbortRefunds(1000, structure, players, RefundMethodICM)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 600, "b": 400}, refundsByUser(refunds))
+}
+
+func TestComputeAbortRefunds_EliminatedInTheMoney(t *testing.T) {
+	players := []AbortRefund{
+		{UserID: "a", Chips: 3000},
+		{UserID: "b", Chips: 1000},
+		{UserID: "c", Position: eliminatedAt(3)},
+	}
+
+	refunds, err := ComputeAbortRefunds(1000, Top3Payout, players, RefundMethodICM)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 450, "b": 350, "c": 200}, refundsByUser(refunds))
+}
+
+func TestComputeAbortRefunds_PaysWholePool(t *testing.T) {
+	players := []AbortRefund{
+		{UserID: "a", Chips: 4100},
+		{UserID: "b", Chips: 2700},
+		{UserID: "c", Chips: 1900},
+		{UserID: "d", Chips: 1300},
+		{UserID: "e", Chips: 17},
+	}
+
+	for _, method := range []string{RefundMethodChipChop, RefundMethodICM} {
+		refunds, err := ComputeAbortRefunds(997, Top3Payout, players, method)
+		require.NoError(t, err)
+
+		total := 0
+		for _, refund := range refunds {
+			total += refund.Amount
+		}
+		assert.Equal(t, 997, total, method)
+
+		amounts := refundsByUser(refunds)
+		assert.Greater(t, amounts["a"], amounts["b"], method)
+		assert.Greater(t, amounts["d"], amounts["e"], method)
+	}
+}
+
+func TestComputeAbortRefunds_Errors(t *testing.T) {
+	_, err := ComputeAbortRefunds(1000, Top3Payout, nil, "split")
+	assert.ErrorIs(t, err, ErrInvalidRefundMethod)
+
+	players := make([]AbortRefund, maxICMPlayers+1)
+	for i := range players {
+		players[i] = AbortRefund{UserID: string(rune('a' + i)), Chips: 1000}
+	}
+	_, err = ComputeAbortRefunds(1000, Top3Payout, players, RefundMethodICM)
+	assert.ErrorIs(t, err, ErrTooManyPlayersForICM)
+
+	_, err = ComputeAbortRefunds(1000, Top3Payout, players, RefundMethodChipChop)
+	assert.NoError(t, err)
+}
+
+func TestTournamentPrizeStructure(t *testing.T) {
+	structure, err := tournamentPrizeStructure(&models.Tournament{PrizeStructure: `{"positions":[{"position":1,"basis_points":10000}]}`})
+	require.NoError(t, err)
+	assert.Len(t, structure.Positions, 1)
+
+	structure, err = tournamentPrizeStructure(&models.Tournament{PrizeStructure: "top_3"})
+	require.NoError(t, err)
+	assert.Len(t, structure.Positions, 3)
+
+	_, err = tournamentPrizeStructure(&models.Tournament{PrizeStructure: "nope"})
+	assert.ErrorIs(t, err, ErrInvalidPrizeStructure)
+}
@@ -0,0 +1,217 @@
+package tournament
+
+import (
+	"fmt"
+	"time"
+
+	"poker-platform/backend/internal/ids"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Tournament event types. Every change to a tournament's lifecycle is one of
+// these, appended to its event log; the tournaments row is their projection.
+const (
+	EventRegistered   = "registered"
+	EventUnregistered = "unregistered"
+	EventStarted      = "started"
+	EventLevelChanged = "level_changed"
+	EventPaused       = "paused"
+	EventResumed      = "resumed"
+	EventEliminated   = "eliminated"
+	EventCompleted    = "completed"
+	EventCancelled    = "cancelled"
+)
+
+// transition is the statuses an event can happen in and the status it leaves
+// the tournament in; an empty to keeps the status
+type transition struct {
+	from []string
+	to   string
+}
+
+// transitions is the tournament state machine. "starting" is only reachable
+// by tournaments that were mid-start before the event log existed.
+var transitions = map[string]transition{
+	EventRegistered:   {from: []string{"registering"}},
+	EventUnregistered: {from: []string{"registering"}},
+	EventStarted:      {from: []string{"registering"}, to: "in_progress"},
+	EventLevelChanged: {from: []string{"in_progress"}},
+	EventPaused:       {from: []string{"in_progress"}, to: "paused"},
+	EventResumed:      {from: []string{"paused"}, to: "in_progress"},
+	EventEliminated:   {from: []string{"in_progress", "paused"}},
+	EventCompleted:    {from: []string{"starting", "in_progress", "paused"}, to: "completed"},
+	EventCancelled:    {from: []string{"registering", "starting", "in_progress", "paused"}, to: "cancelled"},
+}
+
+// Apply applies an event to a tournament's projection and returns the
+// columns it changed. It refuses events the tournament's status doesn't
+// allow, leaving the tournament untouched.
+func Apply(tournament *models.Tournament, event models.TournamentEvent) (map[string]interface{}, error) {
+	rule, ok := transitions[event.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown event %q", ErrInvalidTransition, event.Type)
+	}
+	allowed := false
+	for _, status := range rule.from {
+		if tournament.Status == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: %s while %s", ErrInvalidTransition, event.Type, tournament.Status)
+	}
+
+	at := event.CreatedAt
+	updates := make(map[string]interface{})
+	switch event.Type {
+	case EventRegistered:
+		tournament.CurrentPlayers++
+		tournament.PrizePool += poolShare(tournament) // The entry fee and bounty are not part of the pool
+		updates["current_players"] = tournament.CurrentPlayers
+		updates["prize_pool"] = tournament.PrizePool
+
+		// Reaching min_players without a start time starts the auto-start
+		// countdown. A sit & go has no countdown.
+		if tournament.CurrentPlayers == tournament.MinPlayers && tournament.StartTime == nil &&
+			tournament.RegistrationCompletedAt == nil && tournament.TournamentType != models.TournamentTypeSitNGo {
+			tournament.RegistrationCompletedAt = &at
+			updates["registration_completed_at"] = at
+		}
+
+	case EventUnregistered:
+		tournament.CurrentPlayers--
+		tournament.PrizePool += event.Data.Fee - poolShare(tournament) // A late-cancel fee stays in the pool
+		updates["current_players"] = tournament.CurrentPlayers
+		updates["prize_pool"] = tournament.PrizePool
+
+		// Dropping below min_players stops the countdown
+		if tournament.CurrentPlayers < tournament.MinPlayers && tournament.RegistrationCompletedAt != nil {
+			tournament.RegistrationCompletedAt = nil
+			updates["registration_completed_at"] = nil
+		}
+
+	case EventStarted:
+		// Starting closes registration and unregistration
+		tournament.StartedAt = &at
+		tournament.LevelStartedAt = &at
+		updates["started_at"] = at
+		updates["level_started_at"] = at
+		if tournament.RegistrationClosesAt == nil {
+			tournament.RegistrationClosesAt = &at
+			updates["registration_closes_at"] = at
+		}
+
+	case EventLevelChanged:
+		if event.Data.Level != tournament.CurrentLevel+1 {
+			return nil, fmt.Errorf("%w: level %d after level %d", ErrInvalidTransition, event.Data.Level, tournament.CurrentLevel)
+		}
+		tournament.CurrentLevel = event.Data.Level
+		tournament.LevelStartedAt = &at
+		updates["current_level"] = tournament.CurrentLevel
+		updates["level_started_at"] = at
+
+	case EventPaused:
+		tournament.PausedAt = &at
+		updates["paused_at"] = at
+
+	case EventResumed:
+		// The level clock stands still while paused
+		paused := time.Duration(event.Data.PausedFor) * time.Second
+		tournament.ResumedAt = &at
+		tournament.TotalPausedDuration += event.Data.PausedFor
+		updates["resumed_at"] = at
+		updates["total_paused_duration"] = tournament.TotalPausedDuration
+		if tournament.LevelStartedAt != nil {
+			levelStart := tournament.LevelStartedAt.Add(paused)
+			tournament.LevelStartedAt = &levelStart
+			updates["level_started_at"] = levelStart
+		}
+
+	case EventCompleted:
+		tournament.CompletedAt = &at
+		updates["completed_at"] = at
+
+	case EventCancelled:
+		// Cancelling before the start refunds every entry
+		if tournament.Status == "registering" {
+			tournament.CurrentPlayers = 0
+			tournament.PrizePool = 0
+			updates["current_players"] = 0
+			updates["prize_pool"] = 0
+		}
+		tournament.CompletedAt = &at
+		updates["completed_at"] = at
+	}
+
+	if rule.to != "" {
+		tournament.Status = rule.to
+		updates["status"] = rule.to
+	}
+	return updates, nil
+}
+
+// Replay rebuilds a tournament's projection from its event log, starting
+// from its settings as created. Events are applied in sequence order.
+func Replay(tournament models.Tournament, events []models.TournamentEvent) (models.Tournament, error) {
+	tournament.Status = "registering"
+	tournament.CurrentPlayers = 0
+	tournament.PrizePool = 0
+	tournament.CurrentLevel = 1
+	tournament.RegistrationCompletedAt = nil
+	tournament.StartedAt = nil
+	tournament.LevelStartedAt = nil
+	tournament.PausedAt = nil
+	tournament.ResumedAt = nil
+	tournament.TotalPausedDuration = 0
+	tournament.CompletedAt = nil
+
+	for _, event := range events {
+		if _, err := Apply(&tournament, event); err != nil {
+			return tournament, fmt.Errorf("event %d: %w", event.Sequence, err)
+		}
+	}
+	return tournament, nil
+}
+
+// recordEvent appends an event to a tournament's log and writes the columns
+// it changes, inside the caller's transaction. The tournament must have been
+// read in tx, locked where writers can race; it is updated to match.
+func recordEvent(tx *gorm.DB, tournament *models.Tournament, eventType string, userID *string, data models.TournamentEventData, at time.Time) error {
+	event := models.TournamentEvent{
+		ID:           ids.NewAt(at),
+		TournamentID: tournament.ID,
+		Type:         eventType,
+		UserID:       userID,
+		Data:         data,
+		CreatedAt:    at,
+	}
+	updates, err := Apply(tournament, event)
+	if err != nil {
+		return err
+	}
+
+	var last int
+	if err := tx.Model(&models.TournamentEvent{}).Where("tournament_id = ?", tournament.ID).
+		Select("COALESCE(MAX(sequence), 0)").Scan(&last).Error; err != nil {
+		return fmt.Errorf("failed to read tournament event sequence: %w", err)
+	}
+	event.Sequence = last + 1
+	if err := tx.Create(&event).Error; err != nil {
+		return fmt.Errorf("failed to record tournament event: %w", err)
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+	return tx.Model(&models.Tournament{}).Where("id = ?", tournament.ID).Updates(updates).Error
+}
+
+// Timeline returns a tournament's events, oldest first
+func (s *Service) Timeline(tournamentID string) ([]models.TournamentEvent, error) {
+	var events []models.TournamentEvent
+	err := s.db.Where("tournament_id = ?", tournamentID).Order("sequence").Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,166 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func lifecycleEvent(eventType string, data models.TournamentEventData, at time.Time) models.TournamentEvent {
+	return models.TournamentEvent{Type: eventType, Data: data, CreatedAt: at}
+}
+
+func TestReplay_Lifecycle(t *testing.T) {
+	start := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	base := models.Tournament{ID: "t-1", BuyIn: 100, MinPlayers: 2, Status: "completed", CurrentPlayers: 9}
+
+	events := []models.TournamentEvent{
+		lifecycleEvent(EventRegistered, models.TournamentEventData{}, start),
+		lifecycleEvent(EventRegistered, models.TournamentEventData{}, start.Add(time.Minute)),
+		lifecycleEvent(EventRegistered, models.TournamentEventData{}, start.Add(2*time.Minute)),
+		lifecycleEvent(EventUnregistered, models.TournamentEventData{Fee: 20}, start.Add(3*time.Minute)),
+		lifecycleEvent(EventStarted, models.TournamentEventData{}, start.Add(5*time.Minute)),
+		lifecycleEvent(EventPaused, models.TournamentEventData{}, start.Add(10*time.Minute)),
+		lifecycleEvent(EventResumed, models.TournamentEventData{PausedFor: 120}, start.Add(12*time.Minute)),
+		lifecycleEvent(EventLevelChanged, models.TournamentEventData{Level: 2}, start.Add(20*time.Minute)),
+		lifecycleEvent(EventEliminated, models.TournamentEventData{Position: 2}, start.Add(30*time.Minute)),
+		lifecycleEvent(EventCompleted, models.TournamentEventData{Position: 1}, start.Add(30*time.Minute)),
+	}
+
+	// Each prefix of the log is a valid state
+	tourney, err := Replay(base, events[:2])
+	require.NoError(t, err)
+	assert.Equal(t, "registering", tourney.Status)
+	assert.Equal(t, 2, tourney.CurrentPlayers)
+	assert.Equal(t, 200, tourney.PrizePool)
+	require.NotNil(t, tourney.RegistrationCompletedAt)
+	assert.Equal(t, start.Add(time.Minute), *tourney.RegistrationCompletedAt)
+
+	tourney, err = Replay(base, events[:5])
+	require.NoError(t, err)
+	assert.Equal(t, "in_progress", tourney.Status)
+	assert.Equal(t, 2, tourney.CurrentPlayers)
+	assert.Equal(t, 220, tourney.PrizePool, "the late-cancel fee stays in the pool")
+	assert.Equal(t, start.Add(5*time.Minute), *tourney.StartedAt)
+	assert.Equal(t, start.Add(5*time.Minute), *tourney.RegistrationClosesAt)
+
+	tourney, err = Replay(base, events[:7])
+	require.NoError(t, err)
+	assert.Equal(t, "in_progress", tourney.Status)
+	assert.Equal(t, 120, tourney.TotalPausedDuration)
+	assert.Equal(t, start.Add(7*time.Minute), *tourney.LevelStartedAt, "the level clock skips the pause")
+
+	tourney, err = Replay(base, events)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", tourney.Status)
+	assert.Equal(t, 2, tourney.CurrentLevel)
+	assert.Equal(t, start.Add(30*time.Minute), *tourney.CompletedAt)
+}
+
+func TestApply_InvalidTransitions(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		status string
+		event  models.TournamentEvent
+	}{
+		{"register after the start", "in_progress", lifecycleEvent(EventRegistered, models.TournamentEventData{}, now)},
+		{"start twice", "in_progress", lifecycleEvent(EventStarted, models.TournamentEventData{}, now)},
+		{"pause before the start", "registering", lifecycleEvent(EventPaused, models.TournamentEventData{}, now)},
+		{"resume while running", "in_progress", lifecycleEvent(EventResumed, models.TournamentEventData{}, now)},
+		{"eliminate before the start", "registering", lifecycleEvent(EventEliminated, models.TournamentEventData{}, now)},
+		{"complete before the start", "registering", lifecycleEvent(EventCompleted, models.TournamentEventData{}, now)},
+		{"complete a cancelled tournament", "cancelled", lifecycleEvent(EventCompleted, models.TournamentEventData{}, now)},
+		{"cancel a completed tournament", "completed", lifecycleEvent(EventCancelled, models.TournamentEventData{}, now)},
+		{"skip a level", "in_progress", lifecycleEvent(EventLevelChanged, models.TournamentEventData{Level: 3}, now)},
+		{"unknown event", "in_progress", lifecycleEvent("rebought", models.TournamentEventData{}, now)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tourney := models.Tournament{Status: tt.status, CurrentLevel: 1, CurrentPlayers: 5}
+			updates, err := Apply(&tourney, tt.event)
+			assert.ErrorIs(t, err, ErrInvalidTransition)
+			assert.Nil(t, updates)
+			assert.Equal(t, tt.status, tourney.Status)
+			assert.Equal(t, 1, tourney.CurrentLevel)
+			assert.Equal(t, 5, tourney.CurrentPlayers)
+		})
+	}
+}
+
+func TestApply_CancelBeforeStartEmptiesTournament(t *testing.T) {
+	tourney := models.Tournament{Status: "registering", CurrentPlayers: 4, PrizePool: 400}
+	updates, err := Apply(&tourney, lifecycleEvent(EventCancelled, models.TournamentEventData{}, time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", updates["status"])
+	assert.Equal(t, 0, tourney.CurrentPlayers)
+	assert.Equal(t, 0, tourney.PrizePool)
+
+	// An aborted tournament keeps its pool, which was paid out
+	tourney = models.Tournament{Status: "in_progress", CurrentPlayers: 4, PrizePool: 400}
+	_, err = Apply(&tourney, lifecycleEvent(EventCancelled, models.TournamentEventData{Reason: "outage"}, time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, 400, tourney.PrizePool)
+}
+
+func TestRecordEvent(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.TournamentEvent{}))
+	testutil.Schema(t, db, &models.Tournament{})
+	for _, stmt := range []string{
+		`INSERT INTO tournaments (id, status, tournament_type, buy_in, min_players, current_players, prize_pool,
+			current_level, total_paused_duration) VALUES ('t-1', 'registering', 'scheduled', 50, 3, 0, 0, 1, 0)`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	service := NewService(db, nil)
+
+	load := func() models.Tournament {
+		var tourney models.Tournament
+		require.NoError(t, db.First(&tourney, "id = ?", "t-1").Error)
+		return tourney
+	}
+
+	now := time.Now()
+	for _, userID := range []string{"alice", "bob", "carol"} {
+		tourney := load()
+		id := userID
+		require.NoError(t, recordEvent(db, &tourney, EventRegistered, &id, models.TournamentEventData{}, now))
+	}
+	tourney := load()
+	require.NoError(t, recordEvent(db, &tourney, EventStarted, nil, models.TournamentEventData{}, now))
+
+	// A refused event leaves neither a log entry nor a change
+	tourney = load()
+	require.ErrorIs(t, recordEvent(db, &tourney, EventRegistered, nil, models.TournamentEventData{}, now), ErrInvalidTransition)
+
+	stored := load()
+	assert.Equal(t, "in_progress", stored.Status)
+	assert.Equal(t, 3, stored.CurrentPlayers)
+	assert.Equal(t, 150, stored.PrizePool)
+
+	timeline, err := service.Timeline("t-1")
+	require.NoError(t, err)
+	require.Len(t, timeline, 4)
+	for i, event := range timeline {
+		assert.Equal(t, i+1, event.Sequence)
+		assert.Len(t, event.ID, 26)
+	}
+	assert.Equal(t, "alice", *timeline[0].UserID)
+	assert.Equal(t, EventStarted, timeline[3].Type)
+
+	// The stored row is the projection of its log
+	replayed, err := Replay(stored, timeline)
+	require.NoError(t, err)
+	assert.Equal(t, stored.Status, replayed.Status)
+	assert.Equal(t, stored.CurrentPlayers, replayed.CurrentPlayers)
+	assert.Equal(t, stored.PrizePool, replayed.PrizePool)
+}
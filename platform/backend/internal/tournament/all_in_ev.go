@@ -0,0 +1,61 @@
+package tournament
+
+import (
+	"poker-platform/backend/internal/models"
+
+	pokerModels "poker-engine/models"
+
+	"gorm.io/gorm"
+)
+
+// AllInEV is a player's all-in luck over a tournament: the chips their
+// equity was worth in hands they were all-in in, against the chips they won
+type AllInEV struct {
+	UserID   string  `json:"user_id"`
+	AllIns   int     `json:"all_ins"`
+	Expected float64 `json:"expected"`
+	Won      int64   `json:"won"`
+	Luck     float64 `json:"luck"` // Won less expected; positive when the runouts went the player's way
+}
+
+// RecordAllIn adds a hand's all-in equity snapshot to the totals of the
+// tournament's players
+func (s *Service) RecordAllIn(tournamentID string, equities []pokerModels.AllInEquity) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, e := range equities {
+			if err := tx.Model(&models.TournamentPlayer{}).
+				Where("tournament_id = ? AND user_id = ?", tournamentID, e.PlayerID).
+				Updates(map[string]interface{}{
+					"all_ins":         gorm.Expr("all_ins + 1"),
+					"all_in_expected": gorm.Expr("all_in_expected + ?", e.Expected),
+					"all_in_won":      gorm.Expr("all_in_won + ?", e.Won),
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AllInReport lists the all-in luck of the tournament's players who were
+// all-in at least once, luckiest first
+func (s *Service) AllInReport(tournamentID string) ([]AllInEV, error) {
+	var players []models.TournamentPlayer
+	if err := s.db.Where("tournament_id = ? AND all_ins > 0", tournamentID).
+		Order("all_in_won - all_in_expected DESC").
+		Find(&players).Error; err != nil {
+		return nil, err
+	}
+
+	report := make([]AllInEV, len(players))
+	for i, p := range players {
+		report[i] = AllInEV{
+			UserID:   p.UserID,
+			AllIns:   p.AllIns,
+			Expected: p.AllInExpected,
+			Won:      p.AllInWon,
+			Luck:     float64(p.AllInWon) - p.AllInExpected,
+		}
+	}
+	return report, nil
+}
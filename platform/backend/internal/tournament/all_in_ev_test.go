@@ -0,0 +1,50 @@
+package tournament
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	pokerModels "poker-engine/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestAllInReport(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	testutil.Schema(t, db, &models.TournamentPlayer{})
+	for _, stmt := range []string{
+		`INSERT INTO tournament_players (tournament_id, user_id) VALUES
+			('tour', 'aces'), ('tour', 'kings'), ('tour', 'folder'), ('other', 'aces')`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	s := NewService(db, nil)
+
+	// Kings outdraw aces, then aces hold
+	require.NoError(t, s.RecordAllIn("tour", []pokerModels.AllInEquity{
+		{PlayerID: "aces", Equity: 0.8, Expected: 1600, Won: 0},
+		{PlayerID: "kings", Equity: 0.2, Expected: 400, Won: 2000},
+	}))
+	require.NoError(t, s.RecordAllIn("tour", []pokerModels.AllInEquity{
+		{PlayerID: "aces", Equity: 0.8, Expected: 800, Won: 1000},
+		{PlayerID: "kings", Equity: 0.2, Expected: 200, Won: 0},
+	}))
+
+	report, err := s.AllInReport("tour")
+	require.NoError(t, err)
+	assert.Equal(t, []AllInEV{
+		{UserID: "kings", AllIns: 2, Expected: 600, Won: 2000, Luck: 1400},
+		{UserID: "aces", AllIns: 2, Expected: 2400, Won: 1000, Luck: -1400},
+	}, report)
+
+	// Other tournaments are untouched
+	report, err = s.AllInReport("other")
+	require.NoError(t, err)
+	assert.Empty(t, report)
+}
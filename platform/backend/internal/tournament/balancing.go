@@ -0,0 +1,37 @@
+package tournament
+
+import "sort"
+
+// BalanceMove picks the tables a player should move from and to so that no
+// table has more than one player more than another. counts maps table IDs to
+// players left with chips. Among equally large tables preferFrom gives up the
+// player, since it is the table known to be between hands.
+func BalanceMove(counts map[string]int, preferFrom string) (from, to string, ok bool) {
+	if len(counts) < 2 {
+		return "", "", false
+	}
+
+	tableIDs := make([]string, 0, len(counts))
+	for id := range counts {
+		tableIDs = append(tableIDs, id)
+	}
+	sort.Strings(tableIDs)
+
+	from, to = tableIDs[0], tableIDs[0]
+	for _, id := range tableIDs[1:] {
+		if counts[id] > counts[from] {
+			from = id
+		}
+		if counts[id] < counts[to] {
+			to = id
+		}
+	}
+	if _, exists := counts[preferFrom]; exists && counts[preferFrom] == counts[from] {
+		from = preferFrom
+	}
+
+	if counts[from]-counts[to] <= 1 {
+		return "", "", false
+	}
+	return from, to, true
+}
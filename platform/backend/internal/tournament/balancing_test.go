@@ -0,0 +1,28 @@
+package tournament
+
+import "testing"
+
+func TestBalanceMove(t *testing.T) {
+	tests := []struct {
+		name       string
+		counts     map[string]int
+		preferFrom string
+		from, to   string
+		ok         bool
+	}{
+		{"single table", map[string]int{"a": 9}, "a", "", "", false},
+		{"balanced", map[string]int{"a": 8, "b": 7, "c": 8}, "a", "", "", false},
+		{"largest to smallest", map[string]int{"a": 6, "b": 9, "c": 7}, "b", "b", "a", true},
+		{"prefers the table between hands", map[string]int{"a": 8, "b": 8, "c": 6}, "b", "b", "c", true},
+		{"largest when preferred is smaller", map[string]int{"a": 8, "b": 7, "c": 6}, "b", "a", "c", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, ok := BalanceMove(tt.counts, tt.preferFrom)
+			if from != tt.from || to != tt.to || ok != tt.ok {
+				t.Errorf("Expected %q -> %q (%v), got %q -> %q (%v)", tt.from, tt.to, tt.ok, from, to, ok)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ type BlindManager struct {
 	db                   *gorm.DB
 	stopChan             chan struct{}
 	onBlindIncreaseCallback func(tournamentID string, newLevel models.BlindLevel) // Callback when blinds increase
+	onBreakCallback      func(tournamentID string, level int, resumesAt time.Time) // Callback when a scheduled break starts
 }
 
 // NewBlindManager creates a new blind manager
@@ -32,6 +33,21 @@ func (bm *BlindManager) SetOnBlindIncreaseCallback(callback func(tournamentID st
 	bm.onBlindIncreaseCallback = callback
 }
 
+// SetOnBreakCallback sets the callback function to be called when a break
+// scheduled by the structure starts, with the level just finished
+func (bm *BlindManager) SetOnBreakCallback(callback func(tournamentID string, level int, resumesAt time.Time)) {
+	bm.onBreakCallback = callback
+}
+
+// BreakAfter returns how long the break after a level lasts, or 0 if the
+// structure has no break there
+func BreakAfter(structure models.TournamentStructure, level int) time.Duration {
+	if structure.BreakEvery <= 0 || structure.BreakLength <= 0 || level <= 0 || level%structure.BreakEvery != 0 {
+		return 0
+	}
+	return time.Duration(structure.BreakLength) * time.Second
+}
+
 // Start begins monitoring tournaments for blind level increases
 func (bm *BlindManager) Start() {
 	log.Println("Blind manager service started")
@@ -153,20 +169,30 @@ func (bm *BlindManager) IncreaseBlinds(tournamentID string) error {
 	}
 
 	// Get next level
+	finishedLevel := tournament.CurrentLevel
 	newLevel := tournament.CurrentLevel + 1
 	newLevelIndex := newLevel - 1
 	newLevelConfig := structure.BlindLevels[newLevelIndex]
 
-	// Update tournament
+	// Move the tournament to the next level
 	now := time.Now()
-	if err := tx.Model(&tournament).Updates(map[string]interface{}{
-		"current_level":     newLevel,
-		"level_started_at":  now,
-	}).Error; err != nil {
+	if err := recordEvent(tx, &tournament, EventLevelChanged, nil, models.TournamentEventData{Level: newLevel}, now); err != nil {
 		tx.Rollback()
 		return err
 	}
 
+	// A scheduled break comes before the new level: its blinds are set now,
+	// and its clock starts when the break ends
+	breakLength := BreakAfter(structure, finishedLevel)
+	resumesAt := now.Add(breakLength)
+	if breakLength > 0 {
+		if err := tx.Model(&models.Tournament{}).Where("id = ?", tournamentID).
+			Update("level_started_at", resumesAt).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	// Get all tables for this tournament
 	var tables []models.Table
 	if err := tx.Where("tournament_id = ? AND status != ?", tournamentID, "completed").Find(&tables).Error; err != nil {
@@ -193,6 +219,14 @@ func (bm *BlindManager) IncreaseBlinds(tournamentID string) error {
 	log.Printf("Tournament %s: Increased to level %d (SB: %d, BB: %d, Ante: %d)",
 		tournamentID, newLevel, newLevelConfig.SmallBlind, newLevelConfig.BigBlind, newLevelConfig.Ante)
 
+	// Hold the tables for the break before their blinds change
+	if breakLength > 0 {
+		log.Printf("Tournament %s: Break of %v after level %d", tournamentID, breakLength, finishedLevel)
+		if bm.onBreakCallback != nil {
+			bm.onBreakCallback(tournamentID, finishedLevel, resumesAt)
+		}
+	}
+
 	// Call the callback if set
 	if bm.onBlindIncreaseCallback != nil {
 		bm.onBlindIncreaseCallback(tournamentID, newLevelConfig)
@@ -0,0 +1,208 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// In a bounty tournament part of each buy-in is put on the player's head
+// instead of in the prize pool. Whoever knocks a player out wins half of
+// their bounty in cash and adds the other half to their own; a split pot
+// shares it. The champion collects their own bounty with the prizes, along
+// with any bounty nobody won, such as a player's who was removed from the
+// tournament rather than knocked out in a hand.
+
+// TopicBounty is the outbox topic paying the cash of a knockout
+const TopicBounty = "tournament.bounty"
+
+// bountyStep is the payload of a bounty outbox message
+type bountyStep struct {
+	TournamentID string `json:"tournament_id"`
+	KnockoutID   string `json:"knockout_id"`
+}
+
+// poolShare is the part of a buy-in that goes to the prize pool
+func poolShare(tournament *models.Tournament) int {
+	return tournament.BuyIn - tournament.Bounty
+}
+
+// claimBounty shares an eliminated player's bounty between the players who
+// knocked them out: a knockout is recorded for each, its added half put on
+// their bounty and its cash queued for payment. It returns the queued outbox
+// messages. Eliminators who aren't still in the tournament are ignored.
+func claimBounty(tx *gorm.DB, player *models.TournamentPlayer, eliminatedBy []string, now time.Time) ([]string, error) {
+	if player.Bounty == 0 || len(eliminatedBy) == 0 {
+		return nil, nil
+	}
+
+	var live []string
+	if err := tx.Model(&models.TournamentPlayer{}).
+		Where("tournament_id = ? AND user_id IN ? AND user_id <> ? AND eliminated_at IS NULL",
+			player.TournamentID, eliminatedBy, player.UserID).
+		Pluck("user_id", &live).Error; err != nil {
+		return nil, err
+	}
+	var eliminators []string
+	for _, userID := range eliminatedBy {
+		for _, id := range live {
+			if id == userID {
+				eliminators = append(eliminators, userID)
+				break
+			}
+		}
+	}
+	if len(eliminators) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Model(&models.TournamentPlayer{}).Where("id = ?", player.ID).Update("bounty", 0).Error; err != nil {
+		return nil, err
+	}
+
+	share := player.Bounty / len(eliminators)
+	remainder := player.Bounty % len(eliminators)
+	messages := make([]string, 0, len(eliminators))
+	for i, eliminatorID := range eliminators {
+		amount := share
+		if i < remainder {
+			amount++
+		}
+		knockout := &models.TournamentKnockout{
+			ID:           uuid.New().String(),
+			TournamentID: player.TournamentID,
+			EliminatedID: player.UserID,
+			EliminatorID: eliminatorID,
+			Cash:         amount / 2,
+			Added:        amount - amount/2,
+			CreatedAt:    now,
+		}
+		if err := tx.Create(knockout).Error; err != nil {
+			return nil, err
+		}
+		if err := tx.Model(&models.TournamentPlayer{}).
+			Where("tournament_id = ? AND user_id = ?", player.TournamentID, eliminatorID).
+			Update("bounty", gorm.Expr("bounty + ?", knockout.Added)).Error; err != nil {
+			return nil, err
+		}
+		id, err := outbox.Enqueue(tx, TopicBounty, player.TournamentID, bountyStep{
+			TournamentID: player.TournamentID,
+			KnockoutID:   knockout.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, id)
+	}
+	return messages, nil
+}
+
+// collectBounties pays the champion of a bounty tournament their own bounty
+// and any left unclaimed, returning the amount paid
+func (pd *PrizeDistributor) collectBounties(ctx context.Context, tx *gorm.DB, tournament *models.Tournament) (int, error) {
+	if tournament.Bounty == 0 {
+		return 0, nil
+	}
+	var total int
+	if err := tx.Model(&models.TournamentPlayer{}).
+		Where("tournament_id = ?", tournament.ID).
+		Select("COALESCE(SUM(bounty), 0)").
+		Scan(&total).Error; err != nil {
+		return 0, err
+	}
+	if total == 0 {
+		return 0, nil
+	}
+
+	var champion models.TournamentPlayer
+	if err := tx.Where("tournament_id = ? AND position = 1", tournament.ID).First(&champion).Error; err != nil {
+		return 0, fmt.Errorf("failed to find the champion: %w", err)
+	}
+	description := fmt.Sprintf("Bounties collected by the champion of tournament %s", tournament.Name)
+	if err := pd.currencyService.AddChipsWithTx(ctx, tx, champion.UserID, total,
+		currency.TxTypeTournamentBounty, tournament.ID, description); err != nil {
+		return 0, fmt.Errorf("failed to pay bounties to user %s: %w", champion.UserID, err)
+	}
+	if err := tx.Model(&models.TournamentPlayer{}).
+		Where("tournament_id = ?", tournament.ID).
+		Update("bounty", 0).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Model(&champion).
+		Update("bounties_won", gorm.Expr("bounties_won + ?", total)).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// PayBounty pays the cash of a knockout to the eliminator. A knockout already
+// paid is left alone, so it is safe to retry.
+func (pd *PrizeDistributor) PayBounty(knockoutID string) error {
+	var knockout models.TournamentKnockout
+	paid := false
+	err := pd.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", knockoutID).
+			First(&knockout).Error; err != nil {
+			return err
+		}
+		if knockout.PaidAt != nil {
+			return nil
+		}
+
+		if knockout.Cash > 0 {
+			var tournament models.Tournament
+			if err := tx.Select("id", "name").Where("id = ?", knockout.TournamentID).First(&tournament).Error; err != nil {
+				return err
+			}
+			description := fmt.Sprintf("Bounty for a knockout in tournament %s", tournament.Name)
+			if err := pd.currencyService.AddChipsWithTx(context.Background(), tx, knockout.EliminatorID, knockout.Cash,
+				currency.TxTypeTournamentBounty, knockout.TournamentID, description); err != nil {
+				return fmt.Errorf("failed to pay bounty to user %s: %w", knockout.EliminatorID, err)
+			}
+			if err := tx.Model(&models.TournamentPlayer{}).
+				Where("tournament_id = ? AND user_id = ?", knockout.TournamentID, knockout.EliminatorID).
+				Update("bounties_won", gorm.Expr("bounties_won + ?", knockout.Cash)).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		knockout.PaidAt = &now
+		paid = true
+		return tx.Model(&knockout).Update("paid_at", now).Error
+	})
+	if err != nil || !paid {
+		return err
+	}
+
+	log.Printf("[BOUNTY] Tournament %s: Paid %d to %s for knocking out %s (%d added to their bounty)",
+		knockout.TournamentID, knockout.Cash, knockout.EliminatorID, knockout.EliminatedID, knockout.Added)
+	if pd.onBountyPaidCallback != nil {
+		pd.onBountyPaidCallback(knockout)
+	}
+	return nil
+}
+
+// BountyHandler pays the cash of a knockout. PayBounty checks whether it was
+// paid already, so a retry pays nothing twice.
+func (pd *PrizeDistributor) BountyHandler() outbox.Handler {
+	return outbox.Handler{
+		Do: func(ctx context.Context, msg models.OutboxMessage) error {
+			var step bountyStep
+			if err := outbox.Decode(msg, &step); err != nil {
+				return err
+			}
+			return pd.PayBounty(step.KnockoutID)
+		},
+	}
+}
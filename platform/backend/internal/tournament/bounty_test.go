@@ -0,0 +1,114 @@
+package tournament
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupBounties(t *testing.T) (*gorm.DB, *PrizeDistributor) {
+	// The distributor reads outside its transaction, so both connections need the same database
+	db, err := gorm.Open(sqlite.Open("file:bounties?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	require.NoError(t, db.AutoMigrate(&models.User{}, &currency.Transaction{}, &models.TournamentPlayer{}, &models.TournamentKnockout{}))
+	testutil.Schema(t, db, &models.Tournament{}, &models.OutboxMessage{})
+	for _, stmt := range []string{
+		`INSERT INTO tournaments (id, name, buy_in, bounty) VALUES ('t-1', 'Knockout', 100, 40)`,
+		`INSERT INTO users (id, username, email, password_hash, chips) VALUES ('a', 'a', 'a@test.com', '', 0),
+			('b', 'b', 'b@test.com', '', 0), ('c', 'c', 'c@test.com', '', 0)`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	for _, userID := range []string{"a", "b", "c"} {
+		require.NoError(t, db.Create(&models.TournamentPlayer{TournamentID: "t-1", UserID: userID, Bounty: 40}).Error)
+	}
+	return db, NewPrizeDistributor(db, currency.NewService(db))
+}
+
+func playerOf(t *testing.T, db *gorm.DB, userID string) models.TournamentPlayer {
+	var player models.TournamentPlayer
+	require.NoError(t, db.Where("tournament_id = ? AND user_id = ?", "t-1", userID).First(&player).Error)
+	return player
+}
+
+func TestPoolShare(t *testing.T) {
+	assert.Equal(t, 60, poolShare(&models.Tournament{BuyIn: 100, Bounty: 40}))
+	assert.Equal(t, 100, poolShare(&models.Tournament{BuyIn: 100}))
+
+	s := &Service{}
+	req := models.CreateTournamentRequest{Name: "Knockout", BuyIn: 100, StartingChips: 1000, MaxPlayers: 9, MinPlayers: 2}
+	req.Bounty = 100
+	assert.NoError(t, s.validateCreateRequest(req))
+	req.Bounty = 101
+	assert.ErrorIs(t, s.validateCreateRequest(req), ErrInvalidBounty)
+}
+
+func TestClaimBounty_HalfPaidHalfAdded(t *testing.T) {
+	db, pd := setupBounties(t)
+	var paid []models.TournamentKnockout
+	pd.SetOnBountyPaidCallback(func(knockout models.TournamentKnockout) { paid = append(paid, knockout) })
+
+	// A split pot shares the bounty; someone out of the tournament gets none
+	a := playerOf(t, db, "a")
+	messages, err := claimBounty(db, &a, []string{"b", "c", "nobody"}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Zero(t, playerOf(t, db, "a").Bounty)
+	assert.Equal(t, 50, playerOf(t, db, "b").Bounty)
+	assert.Equal(t, 50, playerOf(t, db, "c").Bounty)
+
+	var knockout models.TournamentKnockout
+	require.NoError(t, db.Where("eliminator_id = ?", "b").First(&knockout).Error)
+	assert.Equal(t, 10, knockout.Cash)
+	assert.Equal(t, 10, knockout.Added)
+
+	// The cash is paid once however often the step runs
+	require.NoError(t, pd.PayBounty(knockout.ID))
+	require.NoError(t, pd.PayBounty(knockout.ID))
+	assert.Equal(t, 10, chipsOf(t, db, "b"))
+	assert.Equal(t, 10, playerOf(t, db, "b").BountiesWon)
+	require.Len(t, paid, 1)
+	assert.Equal(t, "a", paid[0].EliminatedID)
+
+	var tx currency.Transaction
+	require.NoError(t, db.Where("user_id = ?", "b").First(&tx).Error)
+	assert.Equal(t, currency.TxTypeTournamentBounty, tx.TransactionType)
+}
+
+func TestCollectBounties_ChampionTakesWhatIsLeft(t *testing.T) {
+	db, pd := setupBounties(t)
+
+	// b knocks out a, then c is removed with nobody to claim their bounty
+	a := playerOf(t, db, "a")
+	_, err := claimBounty(db, &a, []string{"b"}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&models.TournamentPlayer{}).Where("user_id IN ?", []string{"a", "c"}).
+		Update("eliminated_at", time.Now()).Error)
+	require.NoError(t, db.Model(&models.TournamentPlayer{}).Where("user_id = ?", "b").Update("position", 1).Error)
+
+	tournament := &models.Tournament{ID: "t-1", Name: "Knockout", BuyIn: 100, Bounty: 40}
+	collected, err := pd.collectBounties(context.Background(), db, tournament)
+	require.NoError(t, err)
+	assert.Equal(t, 100, collected, "their own 60 and the 40 left on c")
+	assert.Equal(t, 100, chipsOf(t, db, "b"))
+	assert.Equal(t, 100, playerOf(t, db, "b").BountiesWon)
+	assert.Zero(t, playerOf(t, db, "c").Bounty)
+
+	// Nothing is left to collect a second time
+	collected, err = pd.collectBounties(context.Background(), db, tournament)
+	require.NoError(t, err)
+	assert.Zero(t, collected)
+}
@@ -0,0 +1,245 @@
+package tournament
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// A heads-up bracket is played in rounds of matches between two players.
+// Round 1 is drawn when the tournament starts. Each elimination settles the
+// loser's match, and once every match of a round is settled its winners are
+// seated at the tables of the next round. A field that isn't a power of two
+// gets byes in round 1, so every round after it is full.
+
+// BracketManager moves bracket tournaments on from round to round
+type BracketManager struct {
+	db               *gorm.DB
+	onUpdateCallback func(tournamentID string, round int)
+}
+
+// NewBracketManager creates a new bracket manager
+func NewBracketManager(db *gorm.DB) *BracketManager {
+	return &BracketManager{db: db}
+}
+
+// SetOnUpdateCallback sets the callback for a settled match. round is the
+// round whose tables were just created, or 0 while the round goes on.
+func (bm *BracketManager) SetOnUpdateCallback(callback func(tournamentID string, round int)) {
+	bm.onUpdateCallback = callback
+}
+
+// GetBracket returns the matches of a bracket tournament, round by round
+func (bm *BracketManager) GetBracket(tournamentID string) ([]models.BracketMatch, error) {
+	var matches []models.BracketMatch
+	if err := bm.db.Where("tournament_id = ?", tournamentID).
+		Order("round ASC, slot ASC").
+		Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// IsBracket reports whether a tournament is played as a heads-up bracket
+func IsBracket(db *gorm.DB, tournamentID string) bool {
+	var formats []string
+	if err := db.Model(&models.Tournament{}).Where("id = ?", tournamentID).Pluck("format", &formats).Error; err != nil {
+		return false
+	}
+	return len(formats) == 1 && formats[0] == models.TournamentFormatBracket
+}
+
+// drawBracket pairs off the players of round 1 in the order given. The byes
+// are spread evenly over the slots; a bye has no second player.
+func drawBracket(userIDs []string) [][2]string {
+	size := 2
+	for size < len(userIDs) {
+		size *= 2
+	}
+	slots := size / 2
+	byes := size - len(userIDs)
+
+	pairs := make([][2]string, slots)
+	next := 0
+	for i := range pairs {
+		pairs[i][0] = userIDs[next]
+		next++
+		if (i*byes)/slots == ((i+1)*byes)/slots {
+			pairs[i][1] = userIDs[next]
+			next++
+		}
+	}
+	return pairs
+}
+
+// seatBracket draws round 1 of a bracket tournament at random and seats it
+func seatBracket(tx *gorm.DB, tournament *models.Tournament, players []models.TournamentPlayer, level models.BlindLevel, now time.Time) (int, error) {
+	userIDs := make([]string, len(players))
+	for i, player := range players {
+		userIDs[i] = player.UserID
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rng.Shuffle(len(userIDs), func(i, j int) { userIDs[i], userIDs[j] = userIDs[j], userIDs[i] })
+	return seatBracketRound(tx, tournament, 1, drawBracket(userIDs), level, now)
+}
+
+// seatBracketRound creates the matches of a round, each one played at a new
+// table of two with starting stacks, and returns how many tables it created
+func seatBracketRound(tx *gorm.DB, tournament *models.Tournament, round int, pairs [][2]string, level models.BlindLevel, now time.Time) (int, error) {
+	var existing int64
+	if err := tx.Model(&models.Table{}).Where("tournament_id = ?", tournament.ID).Count(&existing).Error; err != nil {
+		return 0, err
+	}
+
+	played := 0
+	for slot, pair := range pairs {
+		playerA, playerB := pair[0], pair[1]
+		match := &models.BracketMatch{
+			ID:           uuid.New().String(),
+			TournamentID: tournament.ID,
+			Round:        round,
+			Slot:         slot,
+			PlayerAID:    playerA,
+			CreatedAt:    now,
+		}
+
+		if playerB == "" {
+			match.Status = models.BracketMatchBye
+			match.WinnerID = &playerA
+			match.CompletedAt = &now
+		} else {
+			tableNumber := int(existing) + played + 1
+			table := &models.Table{
+				ID:           uuid.New().String(),
+				TournamentID: &tournament.ID,
+				TableNumber:  &tableNumber,
+				Name:         fmt.Sprintf("%s - Round %d Match %d", tournament.Name, round, slot+1),
+				GameType:     "tournament",
+				Status:       "waiting",
+				SmallBlind:   level.SmallBlind,
+				BigBlind:     level.BigBlind,
+				MaxPlayers:   2,
+				CreatedAt:    now,
+			}
+			if err := tx.Create(table).Error; err != nil {
+				return 0, err
+			}
+			for seatNum, userID := range []string{playerA, playerB} {
+				seat := &models.TableSeat{
+					TableID:    table.ID,
+					UserID:     userID,
+					SeatNumber: seatNum,
+					Chips:      tournament.StartingChips,
+					Status:     "active",
+					JoinedAt:   now,
+				}
+				if err := tx.Create(seat).Error; err != nil {
+					return 0, err
+				}
+			}
+			match.PlayerBID = &playerB
+			match.TableID = &table.ID
+			match.Status = models.BracketMatchPlaying
+			played++
+		}
+
+		if err := tx.Create(match).Error; err != nil {
+			return 0, err
+		}
+	}
+	return played, nil
+}
+
+// settleMatch gives the match of an eliminated bracket player to their
+// opponent and closes its table. When that settles the round, the winners are
+// seated for the next round and its number returned; otherwise it returns 0.
+func settleMatch(tx *gorm.DB, tournament *models.Tournament, loserID string, now time.Time) (int, error) {
+	var match models.BracketMatch
+	err := tx.Where("tournament_id = ? AND status = ? AND (player_a_id = ? OR player_b_id = ?)",
+		tournament.ID, models.BracketMatchPlaying, loserID, loserID).
+		First(&match).Error
+	if err == gorm.ErrRecordNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	winnerID := match.PlayerAID
+	if winnerID == loserID {
+		winnerID = *match.PlayerBID
+	}
+	if err := tx.Model(&match).Updates(map[string]interface{}{
+		"winner_id":    winnerID,
+		"status":       models.BracketMatchCompleted,
+		"completed_at": now,
+	}).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Model(&models.Table{}).Where("id = ?", *match.TableID).Updates(map[string]interface{}{
+		"status":       "completed",
+		"completed_at": now,
+	}).Error; err != nil {
+		return 0, err
+	}
+
+	var unsettled int64
+	if err := tx.Model(&models.BracketMatch{}).
+		Where("tournament_id = ? AND round = ? AND status = ?", tournament.ID, match.Round, models.BracketMatchPlaying).
+		Count(&unsettled).Error; err != nil {
+		return 0, err
+	}
+	if unsettled > 0 {
+		return 0, nil
+	}
+
+	var matches []models.BracketMatch
+	if err := tx.Where("tournament_id = ? AND round = ?", tournament.ID, match.Round).
+		Order("slot ASC").
+		Find(&matches).Error; err != nil {
+		return 0, err
+	}
+	// The final leaves the champion
+	if len(matches) < 2 {
+		return 0, nil
+	}
+
+	pairs := make([][2]string, len(matches)/2)
+	for i, m := range matches {
+		pairs[i/2][i%2] = *m.WinnerID
+	}
+	level, err := currentBlindLevel(*tournament)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := seatBracketRound(tx, tournament, match.Round+1, pairs, level, now); err != nil {
+		return 0, err
+	}
+	return match.Round + 1, nil
+}
+
+// currentBlindLevel returns a tournament's current blind level
+func currentBlindLevel(tournament models.Tournament) (models.BlindLevel, error) {
+	var structure models.TournamentStructure
+	if err := json.Unmarshal([]byte(tournament.Structure), &structure); err != nil {
+		return models.BlindLevel{}, fmt.Errorf("failed to parse tournament structure: %w", err)
+	}
+	levelIndex := tournament.CurrentLevel - 1
+	if levelIndex < 0 || levelIndex >= len(structure.BlindLevels) {
+		return models.BlindLevel{}, ErrInvalidBlindLevel
+	}
+	return structure.BlindLevels[levelIndex], nil
+}
+
+// updated tells the callback a match of a tournament was settled
+func (bm *BracketManager) updated(tournamentID string, round int) {
+	if bm != nil && bm.onUpdateCallback != nil {
+		bm.onUpdateCallback(tournamentID, round)
+	}
+}
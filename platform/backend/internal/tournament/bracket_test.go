@@ -0,0 +1,147 @@
+package tournament
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDrawBracket(t *testing.T) {
+	assert.Equal(t, [][2]string{{"a", "b"}}, drawBracket([]string{"a", "b"}))
+	assert.Equal(t, [][2]string{{"a", "b"}, {"c", "d"}}, drawBracket([]string{"a", "b", "c", "d"}))
+
+	// Six players fill a bracket of eight with two byes, spread apart so
+	// each meets the winner of a match in round 2
+	assert.Equal(t, [][2]string{{"a", "b"}, {"c", ""}, {"d", "e"}, {"f", ""}},
+		drawBracket([]string{"a", "b", "c", "d", "e", "f"}))
+
+	// Five leave a single match
+	pairs := drawBracket([]string{"a", "b", "c", "d", "e"})
+	require.Len(t, pairs, 4)
+	played := 0
+	for _, pair := range pairs {
+		if pair[1] != "" {
+			played++
+		}
+	}
+	assert.Equal(t, 1, played)
+}
+
+func setupBracket(t *testing.T) (*gorm.DB, *models.Tournament) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	testutil.Schema(t, db, &models.Table{}, &models.TableSeat{})
+	require.NoError(t, db.AutoMigrate(&models.BracketMatch{}))
+
+	structure, err := json.Marshal(models.TournamentStructure{BlindLevels: []models.BlindLevel{
+		{Level: 1, SmallBlind: 10, BigBlind: 20, Duration: 300},
+		{Level: 2, SmallBlind: 20, BigBlind: 40, Duration: 300},
+	}})
+	require.NoError(t, err)
+	tournament := &models.Tournament{
+		ID:            "tour",
+		Name:          "Ladder",
+		Format:        models.TournamentFormatBracket,
+		StartingChips: 1500,
+		Structure:     string(structure),
+		CurrentLevel:  1,
+	}
+	return db, tournament
+}
+
+func TestSettleMatch_PlaysRoundByRound(t *testing.T) {
+	db, tournament := setupBracket(t)
+	now := time.Now()
+
+	pairs := drawBracket([]string{"a", "b", "c", "d", "e", "f"})
+	tables, err := seatBracketRound(db, tournament, 1, pairs, models.BlindLevel{SmallBlind: 10, BigBlind: 20}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, tables, "byes get no table")
+
+	var seats []models.TableSeat
+	require.NoError(t, db.Order("id").Find(&seats).Error)
+	require.Len(t, seats, 4)
+	assert.Equal(t, 1500, seats[0].Chips)
+
+	matchOf := func(round, slot int) models.BracketMatch {
+		var match models.BracketMatch
+		require.NoError(t, db.Where("round = ? AND slot = ?", round, slot).First(&match).Error)
+		return match
+	}
+	bye := matchOf(1, 1)
+	assert.Equal(t, models.BracketMatchBye, bye.Status)
+	assert.Equal(t, "c", *bye.WinnerID)
+	assert.Nil(t, bye.TableID)
+
+	// A player outside any match settles nothing
+	round, err := settleMatch(db, tournament, "c", now)
+	require.NoError(t, err)
+	assert.Zero(t, round)
+
+	// The round goes on until its last match is settled
+	round, err = settleMatch(db, tournament, "b", now)
+	require.NoError(t, err)
+	assert.Zero(t, round)
+	first := matchOf(1, 0)
+	assert.Equal(t, models.BracketMatchCompleted, first.Status)
+	assert.Equal(t, "a", *first.WinnerID)
+	var status string
+	db.Raw(`SELECT status FROM tables WHERE id = ?`, *first.TableID).Scan(&status)
+	assert.Equal(t, "completed", status)
+
+	// Round 2 is played at the blinds of the current level
+	tournament.CurrentLevel = 2
+	round, err = settleMatch(db, tournament, "d", now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, round)
+	semi := matchOf(2, 0)
+	assert.Equal(t, "a", semi.PlayerAID)
+	assert.Equal(t, "c", *semi.PlayerBID)
+	assert.Equal(t, "e", matchOf(2, 1).PlayerAID)
+	assert.Equal(t, "f", *matchOf(2, 1).PlayerBID)
+
+	var table models.Table
+	require.NoError(t, db.Where("id = ?", *semi.TableID).First(&table).Error)
+	assert.Equal(t, 40, table.BigBlind)
+	assert.Equal(t, 2, table.MaxPlayers)
+	assert.Equal(t, 3, *table.TableNumber)
+	assert.Equal(t, "Ladder - Round 2 Match 1", table.Name)
+
+	// The winners meet in the final, which leaves the champion
+	_, err = settleMatch(db, tournament, "c", now)
+	require.NoError(t, err)
+	round, err = settleMatch(db, tournament, "e", now)
+	require.NoError(t, err)
+	assert.Equal(t, 3, round)
+	final := matchOf(3, 0)
+	assert.Equal(t, "a", final.PlayerAID)
+	assert.Equal(t, "f", *final.PlayerBID)
+
+	round, err = settleMatch(db, tournament, "f", now)
+	require.NoError(t, err)
+	assert.Zero(t, round)
+	assert.Equal(t, "a", *matchOf(3, 0).WinnerID)
+}
+
+func TestValidateCreateRequest_Format(t *testing.T) {
+	s := &Service{}
+	req := models.CreateTournamentRequest{Name: "Ladder", BuyIn: 100, StartingChips: 1000, MaxPlayers: 8, MinPlayers: 2}
+
+	req.Format = models.TournamentFormatBracket
+	assert.NoError(t, s.validateCreateRequest(req))
+
+	req.SeatingPlan = &models.SeatingPlan{}
+	assert.ErrorIs(t, s.validateCreateRequest(req), ErrBracketSeatingPlan)
+
+	req.SeatingPlan = nil
+	req.Format = "knockout"
+	assert.ErrorIs(t, s.validateCreateRequest(req), ErrInvalidTournamentFormat)
+}
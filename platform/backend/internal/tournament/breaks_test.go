@@ -0,0 +1,88 @@
+package tournament
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestBreakAfter(t *testing.T) {
+	structure := models.TournamentStructure{BreakEvery: 4, BreakLength: 300}
+	assert.Zero(t, BreakAfter(structure, 3))
+	assert.Equal(t, 5*time.Minute, BreakAfter(structure, 4))
+	assert.Equal(t, 5*time.Minute, BreakAfter(structure, 8))
+	assert.Zero(t, BreakAfter(models.TournamentStructure{}, 4))
+}
+
+func TestValidateStructureBreaks(t *testing.T) {
+	structure := TurboStructure
+	for _, breaks := range []struct{ every, length int }{{4, 0}, {0, 300}, {-1, 300}, {4, 3601}} {
+		structure.BreakEvery, structure.BreakLength = breaks.every, breaks.length
+		assert.ErrorIs(t, ValidateStructure(structure), ErrInvalidBreakSchedule, "every %d for %d", breaks.every, breaks.length)
+	}
+	structure.BreakEvery, structure.BreakLength = 4, 300
+	assert.NoError(t, ValidateStructure(structure))
+
+	analysis := AnalyzeStructure(structure, 10000)
+	withoutBreaks := AnalyzeStructure(TurboStructure, 10000)
+	breaks := (len(structure.BlindLevels) - 1) / 4
+	assert.Equal(t, withoutBreaks.TotalDuration+breaks*300, analysis.TotalDuration)
+}
+
+func TestIncreaseBlindsTakesScheduledBreak(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.TournamentEvent{}))
+
+	structure := models.TournamentStructure{
+		BlindLevels: []models.BlindLevel{
+			{Level: 1, SmallBlind: 10, BigBlind: 20, Duration: 60},
+			{Level: 2, SmallBlind: 20, BigBlind: 40, Duration: 60},
+			{Level: 3, SmallBlind: 30, BigBlind: 60, Duration: 60},
+			{Level: 4, SmallBlind: 40, BigBlind: 80, Duration: 60},
+		},
+		BreakEvery:  2,
+		BreakLength: 300,
+	}
+	data, err := json.Marshal(structure)
+	require.NoError(t, err)
+	testutil.Schema(t, db, &models.Tournament{}, &models.Table{})
+	require.NoError(t, db.Exec(`INSERT INTO tournaments (id, status, structure, current_level, level_started_at) VALUES ('t-1', 'in_progress', ?, 1, ?)`,
+		string(data), time.Now().Add(-time.Minute)).Error)
+
+	bm := NewBlindManager(db)
+	var breaks []int
+	var resumesAt time.Time
+	bm.SetOnBreakCallback(func(tournamentID string, level int, at time.Time) {
+		breaks = append(breaks, level)
+		resumesAt = at
+	})
+	load := func() models.Tournament {
+		var tourney models.Tournament
+		require.NoError(t, db.First(&tourney, "id = ?", "t-1").Error)
+		return tourney
+	}
+
+	// No break after level 1
+	require.NoError(t, bm.IncreaseBlinds("t-1"))
+	assert.Empty(t, breaks)
+	assert.WithinDuration(t, time.Now(), *load().LevelStartedAt, time.Second)
+
+	// Level 3's clock starts when the break after level 2 ends
+	require.NoError(t, bm.IncreaseBlinds("t-1"))
+	assert.Equal(t, []int{2}, breaks)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), resumesAt, time.Second)
+	tourney := load()
+	assert.Equal(t, 3, tourney.CurrentLevel)
+	assert.WithinDuration(t, resumesAt, *tourney.LevelStartedAt, time.Second)
+	assert.False(t, bm.shouldIncreaseBlinds(tourney, time.Now().Add(5*time.Minute)), "the break doesn't count towards the level")
+	assert.True(t, bm.shouldIncreaseBlinds(tourney, time.Now().Add(6*time.Minute+time.Second)))
+}
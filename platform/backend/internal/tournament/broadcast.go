@@ -0,0 +1,82 @@
+package tournament
+
+import (
+	"errors"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MaxBroadcastDelay is the longest delay a broadcast final table can have
+const MaxBroadcastDelay = 10 * time.Minute
+
+// validBroadcastDelay checks a broadcast delay in seconds. Zero turns
+// broadcast mode off.
+func validBroadcastDelay(seconds int) bool {
+	return seconds >= 0 && seconds <= int(MaxBroadcastDelay/time.Second)
+}
+
+// SetBroadcastDelay changes the delay of a tournament's final table broadcast.
+// Only the creator can change it, and not once the tournament is over.
+func (s *Service) SetBroadcastDelay(tournamentID, userID string, seconds int) (*models.Tournament, error) {
+	if !validBroadcastDelay(seconds) {
+		return nil, ErrInvalidBroadcastDelay
+	}
+
+	tournament, err := s.creatorTournament(tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	switch tournament.Status {
+	case "completed":
+		return nil, ErrTournamentCompleted
+	case "cancelled":
+		return nil, ErrTournamentCancelled
+	}
+
+	if err := s.db.Model(tournament).Update("broadcast_delay", seconds).Error; err != nil {
+		return nil, err
+	}
+	return tournament, nil
+}
+
+// BroadcastFinalTable returns the final table of a broadcast tournament, for
+// its creator to run. The tournament must be down to one table.
+func (s *Service) BroadcastFinalTable(tournamentID, userID string) (*models.Table, error) {
+	tournament, err := s.creatorTournament(tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.BroadcastDelay <= 0 {
+		return nil, ErrNotBroadcast
+	}
+	if tournament.Status != "in_progress" && tournament.Status != "paused" {
+		return nil, ErrFinalTableNotReached
+	}
+
+	var tables []models.Table
+	if err := s.db.Where("tournament_id = ? AND status != ?", tournamentID, "completed").Find(&tables).Error; err != nil {
+		return nil, err
+	}
+	if len(tables) != 1 {
+		return nil, ErrFinalTableNotReached
+	}
+	return &tables[0], nil
+}
+
+// creatorTournament loads a tournament, checking that userID created it
+func (s *Service) creatorTournament(tournamentID, userID string) (*models.Tournament, error) {
+	var tournament models.Tournament
+	if err := s.db.Where("id = ?", tournamentID).First(&tournament).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTournamentNotFound
+		}
+		return nil, err
+	}
+	if tournament.CreatorID == nil || *tournament.CreatorID != userID {
+		return nil, ErrNotTournamentCreator
+	}
+	return &tournament, nil
+}
@@ -0,0 +1,69 @@
+package tournament
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupBroadcastService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	testutil.Schema(t, db, &models.Tournament{}, &models.Table{})
+	require.NoError(t, db.Exec(`INSERT INTO tournaments (id, creator_id, status) VALUES ('t-1', 'director', 'in_progress')`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO tables (id, tournament_id, status) VALUES
+		('table-1', 't-1', 'playing'), ('table-2', 't-1', 'playing')`).Error)
+
+	return NewService(db, currency.NewService(db)), db
+}
+
+func TestSetBroadcastDelay(t *testing.T) {
+	service, db := setupBroadcastService(t)
+
+	_, err := service.SetBroadcastDelay("t-1", "player", 120)
+	assert.ErrorIs(t, err, ErrNotTournamentCreator)
+
+	_, err = service.SetBroadcastDelay("t-1", "director", 601)
+	assert.ErrorIs(t, err, ErrInvalidBroadcastDelay)
+
+	_, err = service.SetBroadcastDelay("missing", "director", 120)
+	assert.ErrorIs(t, err, ErrTournamentNotFound)
+
+	_, err = service.SetBroadcastDelay("t-1", "director", 120)
+	require.NoError(t, err)
+
+	var delay int
+	db.Raw(`SELECT broadcast_delay FROM tournaments WHERE id = 't-1'`).Scan(&delay)
+	assert.Equal(t, 120, delay)
+
+	db.Exec(`UPDATE tournaments SET status = 'completed' WHERE id = 't-1'`)
+	_, err = service.SetBroadcastDelay("t-1", "director", 60)
+	assert.ErrorIs(t, err, ErrTournamentCompleted)
+}
+
+func TestBroadcastFinalTable(t *testing.T) {
+	service, db := setupBroadcastService(t)
+
+	_, err := service.BroadcastFinalTable("t-1", "director")
+	assert.ErrorIs(t, err, ErrNotBroadcast)
+
+	db.Exec(`UPDATE tournaments SET broadcast_delay = 300 WHERE id = 't-1'`)
+	_, err = service.BroadcastFinalTable("t-1", "director")
+	assert.ErrorIs(t, err, ErrFinalTableNotReached)
+
+	db.Exec(`UPDATE tables SET status = 'completed' WHERE id = 'table-2'`)
+	table, err := service.BroadcastFinalTable("t-1", "director")
+	require.NoError(t, err)
+	assert.Equal(t, "table-1", table.ID)
+
+	_, err = service.BroadcastFinalTable("t-1", "player")
+	assert.ErrorIs(t, err, ErrNotTournamentCreator)
+}
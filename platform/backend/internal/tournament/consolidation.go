@@ -1,8 +1,12 @@
 package tournament
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"sync"
+	"time"
 
 	"poker-platform/backend/internal/models"
 
@@ -12,42 +16,85 @@ import (
 // Consolidator handles table consolidation and balancing
 type Consolidator struct {
 	db                      *gorm.DB
-	onConsolidationCallback func(tournamentID string)
+	onConsolidationCallback func(plan *ConsolidationPlan)
+	onPlannedCallback       func(plan *ConsolidationPlan)
+	onFinalTableCallback    func(tournamentID, tableID string)
+
+	mu      sync.Mutex
+	pending map[string]bool // Tournaments with a plan announced -> check again once applied
 }
 
 // NewConsolidator creates a new consolidator
 func NewConsolidator(db *gorm.DB) *Consolidator {
 	return &Consolidator{
-		db: db,
+		db:      db,
+		pending: make(map[string]bool),
 	}
 }
 
 // SetOnConsolidationCallback sets the callback for table consolidation
-func (c *Consolidator) SetOnConsolidationCallback(callback func(tournamentID string)) {
+func (c *Consolidator) SetOnConsolidationCallback(callback func(plan *ConsolidationPlan)) {
 	c.onConsolidationCallback = callback
 }
 
+// ConsolidationNotice is how long players see a consolidation plan before
+// it applies
+const ConsolidationNotice = 10 * time.Second
+
+// SeatMove is a player a consolidation moves to another table
+type SeatMove struct {
+	UserID          string `json:"user_id"`
+	FromTableID     string `json:"from_table_id"`
+	FromTableNumber int    `json:"from_table_number"`
+	ToTableID       string `json:"to_table_id"`
+	ToTableNumber   int    `json:"to_table_number"`
+	Seat            int    `json:"seat"`
+}
+
+// ConsolidationPlan is who moves where when a tournament's tables are
+// consolidated
+type ConsolidationPlan struct {
+	TournamentID string     `json:"tournament_id"`
+	Moves        []SeatMove `json:"moves"`
+	ClosedTables []string   `json:"closed_tables"`
+	// FinalTableID is set when the tables left merge into one, whose seats
+	// are drawn again for everyone
+	FinalTableID string `json:"final_table_id,omitempty"`
+	// EffectiveAt is when the plan applies, from the next hand of each table
+	EffectiveAt time.Time `json:"effective_at"`
+}
+
+// SetOnConsolidationPlannedCallback sets the callback for a consolidation
+// plan. When set, consolidations after an elimination are announced rather
+// than applied, and the callback applies the plan with ApplyConsolidation
+// once its notice is up.
+func (c *Consolidator) SetOnConsolidationPlannedCallback(callback func(plan *ConsolidationPlan)) {
+	c.onPlannedCallback = callback
+}
+
 // ConsolidateTables consolidates tournament tables when possible
 func (c *Consolidator) ConsolidateTables(tournamentID string) error {
-	tx := c.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
+	plan, err := c.PlanConsolidation(tournamentID)
+	if err != nil {
+		return err
+	}
+	return c.ApplyConsolidation(plan)
+}
 
+// PlanConsolidation works out which tables to close and where their players
+// go, without moving anyone. The emptiest tables close, and each of their
+// players goes to the emptiest table left.
+func (c *Consolidator) PlanConsolidation(tournamentID string) (*ConsolidationPlan, error) {
 	// Get all active tables
 	var tables []models.Table
-	if err := tx.Where("tournament_id = ? AND status != ?", tournamentID, "completed").
+	if err := c.db.Where("tournament_id = ? AND status != ?", tournamentID, "completed").
 		Order("table_number ASC").
 		Find(&tables).Error; err != nil {
-		tx.Rollback()
-		return err
+		return nil, err
 	}
 
 	if len(tables) <= 1 {
-		tx.Rollback()
-		return fmt.Errorf("cannot consolidate single table")
+		return nil, fmt.Errorf("cannot consolidate single table")
 	}
 
 	// Get player counts for each table
@@ -55,6 +102,7 @@ func (c *Consolidator) ConsolidateTables(tournamentID string) error {
 		Table       models.Table
 		PlayerCount int
 		Players     []models.TableSeat
+		Taken       map[int]bool
 	}
 
 	tableInfos := make([]TableInfo, len(tables))
@@ -62,111 +110,150 @@ func (c *Consolidator) ConsolidateTables(tournamentID string) error {
 
 	for i, table := range tables {
 		var seats []models.TableSeat
-		if err := tx.Where("table_id = ? AND status != ?", table.ID, "busted").
-			Find(&seats).Error; err != nil {
-			tx.Rollback()
-			return err
+		if err := c.db.Where("table_id = ?", table.ID).Find(&seats).Error; err != nil {
+			return nil, err
 		}
 
-		tableInfos[i] = TableInfo{
-			Table:       table,
-			PlayerCount: len(seats),
-			Players:     seats,
+		info := TableInfo{Table: table, Taken: map[int]bool{}}
+		for _, seat := range seats {
+			// Busted players keep their seat
+			info.Taken[seat.SeatNumber] = true
+			if seat.Status != "busted" {
+				info.Players = append(info.Players, seat)
+			}
 		}
-		totalPlayers += len(seats)
+		info.PlayerCount = len(info.Players)
+		tableInfos[i] = info
+		totalPlayers += info.PlayerCount
 	}
 
 	// Calculate how many tables we need
-	maxPlayersPerTable := 8
-	minTablesNeeded := CalculateTablesNeeded(totalPlayers, maxPlayersPerTable)
+	minTablesNeeded := CalculateTablesNeeded(totalPlayers, TablePlayers)
 
 	if minTablesNeeded >= len(tables) {
-		tx.Rollback()
-		return fmt.Errorf("no consolidation needed")
+		return nil, fmt.Errorf("no consolidation needed")
 	}
 
 	// Determine which tables to close (those with fewest players)
 	tablesToClose := len(tables) - minTablesNeeded
 
 	// Sort tables by player count (ascending) to close the emptiest tables
-	for i := 0; i < len(tableInfos)-1; i++ {
-		for j := i + 1; j < len(tableInfos); j++ {
-			if tableInfos[i].PlayerCount > tableInfos[j].PlayerCount {
-				tableInfos[i], tableInfos[j] = tableInfos[j], tableInfos[i]
-			}
-		}
-	}
+	sort.SliceStable(tableInfos, func(i, j int) bool { return tableInfos[i].PlayerCount < tableInfos[j].PlayerCount })
 
-	// Collect players to redistribute
+	plan := &ConsolidationPlan{TournamentID: tournamentID, Moves: []SeatMove{}}
 	var playersToMove []models.TableSeat
-	var tablesToCloseIDs []string
-
 	for i := 0; i < tablesToClose; i++ {
 		playersToMove = append(playersToMove, tableInfos[i].Players...)
-		tablesToCloseIDs = append(tablesToCloseIDs, tableInfos[i].Table.ID)
+		plan.ClosedTables = append(plan.ClosedTables, tableInfos[i].Table.ID)
+	}
+	fromTable := map[string]models.Table{}
+	for i := 0; i < tablesToClose; i++ {
+		fromTable[tableInfos[i].Table.ID] = tableInfos[i].Table
 	}
 
 	// Remaining tables
 	remainingTables := tableInfos[tablesToClose:]
 
-	// Now assign moved players
 	for _, player := range playersToMove {
 		// Find table with most room
-		targetTableIndex := 0
-		minPlayers := remainingTables[0].PlayerCount
-
+		target := 0
 		for i := 1; i < len(remainingTables); i++ {
-			if remainingTables[i].PlayerCount < minPlayers {
-				minPlayers = remainingTables[i].PlayerCount
-				targetTableIndex = i
+			if remainingTables[i].PlayerCount < remainingTables[target].PlayerCount {
+				target = i
 			}
 		}
 
-		targetTable := remainingTables[targetTableIndex].Table
+		seat := 0
+		for remainingTables[target].Taken[seat] {
+			seat++
+		}
+		remainingTables[target].Taken[seat] = true
+		remainingTables[target].PlayerCount++
+
+		plan.Moves = append(plan.Moves, SeatMove{
+			UserID:          player.UserID,
+			FromTableID:     player.TableID,
+			FromTableNumber: tableNumber(fromTable[player.TableID]),
+			ToTableID:       remainingTables[target].Table.ID,
+			ToTableNumber:   tableNumber(remainingTables[target].Table),
+			Seat:            seat,
+		})
+	}
 
-		// Find available seat at target table
-		var occupiedSeats []int
-		var existingSeats []models.TableSeat
-		if err := tx.Where("table_id = ?", targetTable.ID).Find(&existingSeats).Error; err != nil {
+	if len(remainingTables) == 1 {
+		plan.FinalTableID = remainingTables[0].Table.ID
+	}
+	return plan, nil
+}
+
+func tableNumber(table models.Table) int {
+	if table.TableNumber == nil {
+		return 0
+	}
+	return *table.TableNumber
+}
+
+// ApplyConsolidation moves the players of a plan and closes its tables.
+// Players who busted since the plan was made stay where they are; anyone
+// still at a closed table who isn't in the plan goes to the emptiest table
+// left, and a planned seat taken in the meantime is swapped for the lowest
+// open one.
+func (c *Consolidator) ApplyConsolidation(plan *ConsolidationPlan) error {
+	defer c.finishPlan(plan.TournamentID)
+
+	tx := c.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
 			tx.Rollback()
-			return err
 		}
+	}()
 
-		for _, seat := range existingSeats {
-			occupiedSeats = append(occupiedSeats, seat.SeatNumber)
-		}
+	closed := map[string]bool{}
+	for _, tableID := range plan.ClosedTables {
+		closed[tableID] = true
+	}
 
-		// Find first available seat
-		newSeatNumber := 0
-		for {
-			found := false
-			for _, occupied := range occupiedSeats {
-				if occupied == newSeatNumber {
-					found = true
-					break
-				}
-			}
-			if !found {
-				break
-			}
-			newSeatNumber++
+	for _, move := range plan.Moves {
+		var seat models.TableSeat
+		err := tx.Where("table_id = ? AND user_id = ? AND status != ?", move.FromTableID, move.UserID, "busted").
+			First(&seat).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Player %s left table %s before the consolidation, not moved", move.UserID, move.FromTableID)
+			continue
 		}
-
-		// Update player's table and seat
-		if err := tx.Model(&player).Updates(map[string]interface{}{
-			"table_id":    targetTable.ID,
-			"seat_number": newSeatNumber,
-		}).Error; err != nil {
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := moveSeat(tx, seat, move.ToTableID, move.Seat); err != nil {
 			tx.Rollback()
 			return err
 		}
+	}
 
-		remainingTables[targetTableIndex].PlayerCount++
-		log.Printf("Moved player %s to table %s seat %d", player.UserID, targetTable.ID, newSeatNumber)
+	// Players who reached a closed table after the plan was made
+	if len(plan.ClosedTables) > 0 {
+		var stragglers []models.TableSeat
+		if err := tx.Where("table_id IN ? AND status != ?", plan.ClosedTables, "busted").
+			Find(&stragglers).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		for _, seat := range stragglers {
+			target, err := emptiestTable(tx, plan.TournamentID, closed)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+			if err := moveSeat(tx, seat, target, -1); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
 	}
 
 	// Close the empty tables
-	for _, tableID := range tablesToCloseIDs {
+	for _, tableID := range plan.ClosedTables {
 		if err := tx.Model(&models.Table{}).Where("id = ?", tableID).
 			Update("status", "completed").Error; err != nil {
 			tx.Rollback()
@@ -175,22 +262,133 @@ func (c *Consolidator) ConsolidateTables(tournamentID string) error {
 		log.Printf("Closed table %s", tableID)
 	}
 
+	// The final table starts from a random seat draw
+	if plan.FinalTableID != "" {
+		var finalTable models.Table
+		if err := tx.Where("id = ?", plan.FinalTableID).First(&finalTable).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := c.redrawFinalTable(tx, finalTable); err != nil {
+			tx.Rollback()
+			return err
+		}
+		log.Printf("Tournament %s: Redrew seats for final table %s", plan.TournamentID, plan.FinalTableID)
+	}
+
+	// An elimination while the plan was announced may leave room to close
+	// more tables
+	if c.recheckRequested(plan.TournamentID) {
+		if _, err := enqueueStep(tx, TopicConsolidate, plan.TournamentID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return err
 	}
 
-	log.Printf("Tournament %s: Consolidated from %d to %d tables",
-		tournamentID, len(tables), len(remainingTables))
+	log.Printf("Tournament %s: Consolidated tables, closing %d and moving %d players",
+		plan.TournamentID, len(plan.ClosedTables), len(plan.Moves))
+
+	// The final table is set up before the tables are reloaded, so it can be
+	// held for its break before the first hand
+	if plan.FinalTableID != "" && c.onFinalTableCallback != nil {
+		c.onFinalTableCallback(plan.TournamentID, plan.FinalTableID)
+	}
 
 	// Call callback
 	if c.onConsolidationCallback != nil {
-		c.onConsolidationCallback(tournamentID)
+		c.onConsolidationCallback(plan)
+	}
+
+	return nil
+}
+
+// moveSeat moves a player to a table, into seat if it's open or the lowest
+// open seat otherwise. A seat of -1 always takes the lowest open one.
+func moveSeat(tx *gorm.DB, player models.TableSeat, tableID string, seat int) error {
+	var existingSeats []models.TableSeat
+	if err := tx.Where("table_id = ?", tableID).Find(&existingSeats).Error; err != nil {
+		return err
+	}
+	taken := map[int]bool{}
+	for _, existing := range existingSeats {
+		taken[existing.SeatNumber] = true
+	}
+	if seat < 0 || taken[seat] {
+		seat = 0
+		for taken[seat] {
+			seat++
+		}
 	}
 
+	// Update player's table and seat
+	if err := tx.Model(&player).Updates(map[string]interface{}{
+		"table_id":    tableID,
+		"seat_number": seat,
+	}).Error; err != nil {
+		return err
+	}
+	log.Printf("Moved player %s to table %s seat %d", player.UserID, tableID, seat)
 	return nil
 }
 
+// emptiestTable returns the open table of a tournament with the fewest
+// players, leaving out the tables being closed
+func emptiestTable(tx *gorm.DB, tournamentID string, closed map[string]bool) (string, error) {
+	var tables []models.Table
+	if err := tx.Where("tournament_id = ? AND status != ?", tournamentID, "completed").
+		Order("table_number ASC").Find(&tables).Error; err != nil {
+		return "", err
+	}
+	best, bestCount := "", int64(0)
+	for _, table := range tables {
+		if closed[table.ID] {
+			continue
+		}
+		var count int64
+		if err := tx.Model(&models.TableSeat{}).Where("table_id = ? AND status != ?", table.ID, "busted").
+			Count(&count).Error; err != nil {
+			return "", err
+		}
+		if best == "" || count < bestCount {
+			best, bestCount = table.ID, count
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("no table left to move players to")
+	}
+	return best, nil
+}
+
+// startPlan marks a tournament as having a consolidation announced. It
+// reports false if one already is, asking it to check again once applied.
+func (c *Consolidator) startPlan(tournamentID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, pending := c.pending[tournamentID]; pending {
+		c.pending[tournamentID] = true
+		return false
+	}
+	c.pending[tournamentID] = false
+	return true
+}
+
+func (c *Consolidator) recheckRequested(tournamentID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pending[tournamentID]
+}
+
+func (c *Consolidator) finishPlan(tournamentID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, tournamentID)
+}
+
 // BalanceTables balances players across tables
 func (c *Consolidator) BalanceTables(tournamentID string) error {
 	tx := c.db.Begin()
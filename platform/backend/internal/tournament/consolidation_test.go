@@ -0,0 +1,108 @@
+package tournament
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupConsolidation creates three tables of 6, 5 and 2 players, so the
+// table of 2 closes
+func setupConsolidation(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	testutil.Schema(t, db, &models.Table{}, &models.TableSeat{})
+	for _, stmt := range []string{
+		`INSERT INTO tables (id, tournament_id, table_number, status, max_players) VALUES
+			('t1', 'tour', 1, 'playing', 8), ('t2', 'tour', 2, 'playing', 8), ('t3', 'tour', 3, 'playing', 8)`,
+		`INSERT INTO table_seats (table_id, user_id, seat_number, chips, status) VALUES
+			('t1', 'a1', 0, 100, 'active'), ('t1', 'a2', 1, 100, 'active'), ('t1', 'a3', 2, 100, 'active'),
+			('t1', 'a4', 3, 100, 'active'), ('t1', 'a5', 4, 100, 'active'), ('t1', 'a6', 5, 100, 'active'),
+			('t2', 'b1', 0, 100, 'busted'), ('t2', 'b2', 1, 100, 'active'), ('t2', 'b3', 2, 100, 'active'),
+			('t2', 'b4', 3, 100, 'active'), ('t2', 'b5', 4, 100, 'active'), ('t2', 'b6', 5, 100, 'active'),
+			('t3', 'c1', 0, 100, 'active'), ('t3', 'c2', 3, 100, 'active')`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	return db
+}
+
+func TestPlanConsolidation(t *testing.T) {
+	db := setupConsolidation(t)
+	c := NewConsolidator(db)
+
+	plan, err := c.PlanConsolidation("tour")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t3"}, plan.ClosedTables)
+	assert.Empty(t, plan.FinalTableID)
+	// Both go to the emptier table 2, past its busted player's seat
+	assert.Equal(t, []SeatMove{
+		{UserID: "c1", FromTableID: "t3", FromTableNumber: 3, ToTableID: "t2", ToTableNumber: 2, Seat: 6},
+		{UserID: "c2", FromTableID: "t3", FromTableNumber: 3, ToTableID: "t2", ToTableNumber: 2, Seat: 7},
+	}, plan.Moves)
+
+	// Planning moves nobody
+	var moved int64
+	db.Model(&models.TableSeat{}).Where("table_id = ?", "t3").Count(&moved)
+	assert.Equal(t, int64(2), moved)
+
+	_, err = c.PlanConsolidation("missing")
+	assert.Error(t, err)
+}
+
+func TestApplyConsolidation(t *testing.T) {
+	db := setupConsolidation(t)
+	c := NewConsolidator(db)
+	var applied *ConsolidationPlan
+	c.SetOnConsolidationCallback(func(plan *ConsolidationPlan) { applied = plan })
+
+	plan, err := c.PlanConsolidation("tour")
+	require.NoError(t, err)
+
+	// While the plan was announced c1 busted, a straggler sat down at the
+	// closing table and someone took c2's planned seat
+	require.NoError(t, db.Exec(`UPDATE table_seats SET status = 'busted' WHERE user_id = 'c1'`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO table_seats (table_id, user_id, seat_number, chips, status)
+		VALUES ('t3', 'late', 5, 100, 'active'), ('t2', 'other', 7, 100, 'active')`).Error)
+
+	require.NoError(t, c.ApplyConsolidation(plan))
+	assert.Equal(t, plan, applied)
+
+	seatOf := func(userID string) models.TableSeat {
+		var seat models.TableSeat
+		require.NoError(t, db.Where("user_id = ?", userID).First(&seat).Error)
+		return seat
+	}
+	assert.Equal(t, "t3", seatOf("c1").TableID, "busted players stay")
+	c2 := seatOf("c2")
+	assert.Equal(t, "t2", c2.TableID)
+	assert.Equal(t, 6, c2.SeatNumber, "a taken seat is swapped for the lowest open one")
+	// Table 1 has 6 players against table 2's 7
+	late := seatOf("late")
+	assert.Equal(t, "t1", late.TableID)
+	assert.Equal(t, 6, late.SeatNumber)
+
+	var status string
+	db.Raw(`SELECT status FROM tables WHERE id = 't3'`).Scan(&status)
+	assert.Equal(t, "completed", status)
+	assert.Empty(t, c.pending)
+}
+
+func TestConsolidator_OnePlanAtATime(t *testing.T) {
+	c := NewConsolidator(nil)
+	assert.True(t, c.startPlan("tour"))
+	assert.False(t, c.recheckRequested("tour"))
+
+	// An elimination while the plan is announced asks for another check
+	assert.False(t, c.startPlan("tour"))
+	assert.True(t, c.recheckRequested("tour"))
+
+	c.finishPlan("tour")
+	assert.True(t, c.startPlan("tour"))
+}
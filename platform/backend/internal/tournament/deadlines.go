@@ -0,0 +1,53 @@
+package tournament
+
+import (
+	"time"
+
+	"poker-platform/backend/internal/models"
+)
+
+// validateDeadlines checks the registration close and end times of a new
+// tournament
+func validateDeadlines(req models.CreateTournamentRequest, now time.Time) error {
+	if req.EndsAt != nil {
+		if !req.EndsAt.After(now) || (req.StartTime != nil && !req.EndsAt.After(*req.StartTime)) {
+			return ErrInvalidEndTime
+		}
+	}
+	if req.RegistrationClosesAt != nil {
+		if !req.RegistrationClosesAt.After(now) || (req.EndsAt != nil && !req.RegistrationClosesAt.Before(*req.EndsAt)) {
+			return ErrInvalidRegistrationClose
+		}
+	}
+	return nil
+}
+
+// ListScheduled returns the tournaments not yet over that have a registration
+// close or end time
+func (s *Service) ListScheduled() ([]models.Tournament, error) {
+	var tournaments []models.Tournament
+	err := s.db.Where("status IN ? AND (registration_closes_at IS NOT NULL OR ends_at IS NOT NULL)",
+		[]string{"registering", "starting", "in_progress", "paused"}).
+		Find(&tournaments).Error
+	return tournaments, err
+}
+
+// EndTournament stops a tournament at its end time. One still registering is
+// cancelled with every buy-in refunded, and one in play is aborted with its
+// prize pool chopped by stack, as an admin abort would. Both are recorded
+// against the creator, who set the end time. The plan is nil for a
+// cancellation.
+func (s *Service) EndTournament(tournamentID string) (*AbortPlan, error) {
+	tournament, err := s.GetTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.CreatorID == nil {
+		return nil, ErrNotTournamentCreator
+	}
+	if tournament.Status == "registering" {
+		return nil, s.CancelTournament(tournamentID, *tournament.CreatorID)
+	}
+	return s.abortTournament(tournamentID, RefundMethodChipChop, tournament.CreatorID,
+		"chip chop at its end time", "The tournament reached its end time")
+}
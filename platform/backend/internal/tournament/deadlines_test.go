@@ -0,0 +1,32 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDeadlines(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		when := now.Add(d)
+		return &when
+	}
+
+	assert.NoError(t, validateDeadlines(models.CreateTournamentRequest{}, now))
+	assert.NoError(t, validateDeadlines(models.CreateTournamentRequest{
+		StartTime: at(time.Hour), RegistrationClosesAt: at(2 * time.Hour), EndsAt: at(5 * time.Hour),
+	}, now), "late registration may run past the start")
+
+	assert.ErrorIs(t, validateDeadlines(models.CreateTournamentRequest{EndsAt: at(-time.Minute)}, now), ErrInvalidEndTime)
+	assert.ErrorIs(t, validateDeadlines(models.CreateTournamentRequest{
+		StartTime: at(time.Hour), EndsAt: at(time.Hour),
+	}, now), ErrInvalidEndTime)
+	assert.ErrorIs(t, validateDeadlines(models.CreateTournamentRequest{RegistrationClosesAt: at(0)}, now), ErrInvalidRegistrationClose)
+	assert.ErrorIs(t, validateDeadlines(models.CreateTournamentRequest{
+		RegistrationClosesAt: at(3 * time.Hour), EndsAt: at(2 * time.Hour),
+	}, now), ErrInvalidRegistrationClose)
+}
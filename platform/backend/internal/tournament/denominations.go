@@ -0,0 +1,65 @@
+package tournament
+
+import (
+	"poker-platform/backend/internal/models"
+
+	"poker-engine/engine"
+)
+
+// StandardDenominations are the chip values used when a level does not list its own
+var StandardDenominations = []int{1, 5, 25, 100, 500, 1000, 5000, 25000, 100000}
+
+// LevelDenominations returns the chip denominations in play at a blind level.
+// Without an explicit list, the smallest chip is the largest standard
+// denomination that divides the small blind, big blind and ante.
+func LevelDenominations(level models.BlindLevel) []int {
+	if len(level.Denominations) > 0 {
+		return level.Denominations
+	}
+
+	smallest := StandardDenominations[0]
+	for _, denom := range StandardDenominations {
+		if level.SmallBlind%denom == 0 && level.BigBlind%denom == 0 && level.Ante%denom == 0 {
+			smallest = denom
+		}
+	}
+
+	for i, denom := range StandardDenominations {
+		if denom == smallest {
+			return StandardDenominations[i:]
+		}
+	}
+	return StandardDenominations
+}
+
+// MinDenomination returns the smallest chip in play at a blind level
+func MinDenomination(level models.BlindLevel) int {
+	denominations := LevelDenominations(level)
+	smallest := denominations[0]
+	for _, denom := range denominations[1:] {
+		if denom < smallest {
+			smallest = denom
+		}
+	}
+	return smallest
+}
+
+// ColorUpForLevel reports whether moving to newLevel (1-indexed) removes the
+// smallest denomination, returning the removed and new minimum denominations
+func ColorUpForLevel(structure models.TournamentStructure, newLevel int) (removed, minimum int, ok bool) {
+	if newLevel < 2 || newLevel > len(structure.BlindLevels) {
+		return 0, 0, false
+	}
+
+	removed = MinDenomination(structure.BlindLevels[newLevel-2])
+	minimum = MinDenomination(structure.BlindLevels[newLevel-1])
+	return removed, minimum, minimum > removed
+}
+
+// ColorUpMethod returns the engine color-up method configured for a structure
+func ColorUpMethod(structure models.TournamentStructure) engine.ColorUpMethod {
+	if structure.ColorUpMethod == string(engine.ColorUpRoundUp) {
+		return engine.ColorUpRoundUp
+	}
+	return engine.ColorUpChipRace
+}
@@ -0,0 +1,50 @@
+package tournament
+
+import (
+	"reflect"
+	"testing"
+
+	"poker-platform/backend/internal/models"
+)
+
+func TestLevelDenominations_DerivedFromBlinds(t *testing.T) {
+	cases := []struct {
+		level models.BlindLevel
+		min   int
+	}{
+		{models.BlindLevel{SmallBlind: 10, BigBlind: 20}, 5},
+		{models.BlindLevel{SmallBlind: 25, BigBlind: 50}, 25},
+		{models.BlindLevel{SmallBlind: 100, BigBlind: 200, Ante: 25}, 25},
+		{models.BlindLevel{SmallBlind: 500, BigBlind: 1000, Ante: 100}, 100},
+		{models.BlindLevel{SmallBlind: 1000, BigBlind: 2000}, 1000},
+	}
+	for _, tc := range cases {
+		if got := MinDenomination(tc.level); got != tc.min {
+			t.Errorf("%d/%d ante %d: expected min denomination %d, got %d",
+				tc.level.SmallBlind, tc.level.BigBlind, tc.level.Ante, tc.min, got)
+		}
+	}
+
+	explicit := models.BlindLevel{SmallBlind: 100, BigBlind: 200, Denominations: []int{100, 500}}
+	if got := LevelDenominations(explicit); !reflect.DeepEqual(got, []int{100, 500}) {
+		t.Errorf("Explicit denominations should be used as-is, got %v", got)
+	}
+}
+
+func TestColorUpForLevel(t *testing.T) {
+	structure := TurboStructure
+
+	// Level 3 (25/50) removes the 5 chips used through level 2 (15/30)
+	removed, minimum, ok := ColorUpForLevel(structure, 3)
+	if !ok || removed != 5 || minimum != 25 {
+		t.Errorf("Expected color-up 5 -> 25 at level 3, got %d -> %d (%v)", removed, minimum, ok)
+	}
+
+	// Level 2 (15/30) still needs 5 chips
+	if _, _, ok := ColorUpForLevel(structure, 2); ok {
+		t.Error("Expected no color-up at level 2")
+	}
+	if _, _, ok := ColorUpForLevel(structure, 1); ok {
+		t.Error("Expected no color-up at the first level")
+	}
+}
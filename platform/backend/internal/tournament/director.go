@@ -0,0 +1,104 @@
+package tournament
+
+import (
+	"time"
+
+	"poker-platform/backend/internal/models"
+)
+
+const (
+	// MaxClockAdjustment is the most time a director can add to the level clock at once
+	MaxClockAdjustment = 30 * time.Minute
+
+	// MaxBreakLength is the longest break a director can call
+	MaxBreakLength = time.Hour
+)
+
+// DirectedTournament returns a running tournament and its tables still in
+// play, for its creator to run
+func (s *Service) DirectedTournament(tournamentID, userID string) (*models.Tournament, []models.Table, error) {
+	tournament, err := s.creatorTournament(tournamentID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch tournament.Status {
+	case "in_progress", "paused":
+	case "completed":
+		return nil, nil, ErrTournamentCompleted
+	case "cancelled":
+		return nil, nil, ErrTournamentCancelled
+	default:
+		return nil, nil, ErrTournamentNotRunning
+	}
+
+	var tables []models.Table
+	if err := s.db.Where("tournament_id = ? AND status != ?", tournamentID, "completed").
+		Order("table_number").Find(&tables).Error; err != nil {
+		return nil, nil, err
+	}
+	return tournament, tables, nil
+}
+
+// AddLevelTime lengthens a running tournament's current blind level by
+// moving its start forward, and returns the new start
+func (s *Service) AddLevelTime(tournamentID, userID string, seconds int) (time.Time, error) {
+	if seconds <= 0 || seconds > int(MaxClockAdjustment/time.Second) {
+		return time.Time{}, ErrInvalidClockAdjustment
+	}
+	tournament, _, err := s.DirectedTournament(tournamentID, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return s.delayLevel(tournament, time.Duration(seconds)*time.Second)
+}
+
+// StartBreak stops a running tournament's level clock for a break of the
+// given length and returns when the break ends. The caller holds the tables.
+func (s *Service) StartBreak(tournamentID, userID string, seconds int) (time.Time, []models.Table, error) {
+	if seconds <= 0 || seconds > int(MaxBreakLength/time.Second) {
+		return time.Time{}, nil, ErrInvalidBreakLength
+	}
+	tournament, tables, err := s.DirectedTournament(tournamentID, userID)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if tournament.Status != "in_progress" {
+		return time.Time{}, nil, ErrTournamentNotRunning
+	}
+
+	length := time.Duration(seconds) * time.Second
+	if _, err := s.delayLevel(tournament, length); err != nil {
+		return time.Time{}, nil, err
+	}
+	return time.Now().Add(length), tables, nil
+}
+
+// delayLevel moves the start of the current level forward, so the blind
+// manager raises the blinds that much later
+func (s *Service) delayLevel(tournament *models.Tournament, by time.Duration) (time.Time, error) {
+	start := time.Now()
+	if tournament.LevelStartedAt != nil {
+		start = *tournament.LevelStartedAt
+	}
+	start = start.Add(by)
+
+	result := s.db.Model(&models.Tournament{}).
+		Where("id = ? AND status IN ?", tournament.ID, []string{"in_progress", "paused"}).
+		Update("level_started_at", start)
+	if result.Error != nil {
+		return time.Time{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return time.Time{}, ErrTournamentNotRunning
+	}
+	tournament.LevelStartedAt = &start
+	return start, nil
+}
+
+// HostedTournaments lists the running tournaments userID created
+func (s *Service) HostedTournaments(userID string) ([]models.Tournament, error) {
+	var tournaments []models.Tournament
+	err := s.db.Where("creator_id = ? AND status IN ?", userID, []string{"in_progress", "paused"}).
+		Order("started_at").Find(&tournaments).Error
+	return tournaments, err
+}
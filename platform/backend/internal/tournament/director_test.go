@@ -0,0 +1,95 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDirectorService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	testutil.Schema(t, db, &models.Tournament{}, &models.Table{})
+	require.NoError(t, db.Exec(`INSERT INTO tournaments (id, creator_id, status, level_started_at) VALUES
+		('t-1', 'director', 'in_progress', ?), ('t-2', 'director', 'registering', NULL), ('t-3', 'other', 'in_progress', NULL)`,
+		time.Now().Add(-time.Minute)).Error)
+	require.NoError(t, db.Exec(`INSERT INTO tables (id, tournament_id, table_number, status) VALUES
+		('table-2', 't-1', 2, 'playing'), ('table-1', 't-1', 1, 'playing'), ('table-3', 't-1', 3, 'completed')`).Error)
+
+	return NewService(db, currency.NewService(db)), db
+}
+
+func TestDirectedTournament(t *testing.T) {
+	service, _ := setupDirectorService(t)
+
+	_, tables, err := service.DirectedTournament("t-1", "director")
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	assert.Equal(t, "table-1", tables[0].ID)
+	assert.Equal(t, "table-2", tables[1].ID)
+
+	_, _, err = service.DirectedTournament("t-1", "player")
+	assert.ErrorIs(t, err, ErrNotTournamentCreator)
+
+	_, _, err = service.DirectedTournament("t-2", "director")
+	assert.ErrorIs(t, err, ErrTournamentNotRunning)
+
+	_, _, err = service.DirectedTournament("missing", "director")
+	assert.ErrorIs(t, err, ErrTournamentNotFound)
+
+	hosted, err := service.HostedTournaments("director")
+	require.NoError(t, err)
+	require.Len(t, hosted, 1)
+	assert.Equal(t, "t-1", hosted[0].ID)
+}
+
+func TestAddLevelTime(t *testing.T) {
+	service, _ := setupDirectorService(t)
+	tourney, err := service.GetTournament("t-1")
+	require.NoError(t, err)
+
+	_, err = service.AddLevelTime("t-1", "director", 0)
+	assert.ErrorIs(t, err, ErrInvalidClockAdjustment)
+	_, err = service.AddLevelTime("t-1", "director", 1801)
+	assert.ErrorIs(t, err, ErrInvalidClockAdjustment)
+	_, err = service.AddLevelTime("t-1", "player", 60)
+	assert.ErrorIs(t, err, ErrNotTournamentCreator)
+
+	start, err := service.AddLevelTime("t-1", "director", 120)
+	require.NoError(t, err)
+	assert.WithinDuration(t, tourney.LevelStartedAt.Add(2*time.Minute), start, time.Second)
+
+	reloaded, err := service.GetTournament("t-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, start, *reloaded.LevelStartedAt, time.Second)
+}
+
+func TestStartBreak(t *testing.T) {
+	service, db := setupDirectorService(t)
+
+	_, _, err := service.StartBreak("t-1", "director", 3601)
+	assert.ErrorIs(t, err, ErrInvalidBreakLength)
+
+	resumesAt, tables, err := service.StartBreak("t-1", "director", 300)
+	require.NoError(t, err)
+	assert.Len(t, tables, 2)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), resumesAt, time.Second)
+
+	// The level clock stops for the break
+	reloaded, err := service.GetTournament("t-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(4*time.Minute), *reloaded.LevelStartedAt, time.Second)
+
+	db.Exec(`UPDATE tournaments SET status = 'paused' WHERE id = 't-1'`)
+	_, _, err = service.StartBreak("t-1", "director", 300)
+	assert.ErrorIs(t, err, ErrTournamentNotRunning)
+}
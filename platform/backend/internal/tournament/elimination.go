@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/outbox"
 
 	"gorm.io/gorm"
 )
@@ -13,7 +14,8 @@ import (
 // EliminationTracker handles player eliminations and tournament progression
 type EliminationTracker struct {
 	db                        *gorm.DB
-	prizeDistributor          *PrizeDistributor
+	outbox                    *outbox.Dispatcher
+	bracket                   *BracketManager
 	onPlayerEliminatedCallback func(tournamentID, userID string, position int)
 	onTournamentCompleteCallback func(tournamentID string)
 }
@@ -25,9 +27,16 @@ func NewEliminationTracker(db *gorm.DB) *EliminationTracker {
 	}
 }
 
-// SetPrizeDistributor sets the prize distributor for automatic prize distribution
-func (et *EliminationTracker) SetPrizeDistributor(pd *PrizeDistributor) {
-	et.prizeDistributor = pd
+// SetOutbox sets the dispatcher that runs the steps following an elimination
+// or completion: consolidating tables and paying out prizes
+func (et *EliminationTracker) SetOutbox(dispatcher *outbox.Dispatcher) {
+	et.outbox = dispatcher
+}
+
+// SetBracketManager sets the manager told when an elimination settles a
+// match of a bracket tournament
+func (et *EliminationTracker) SetBracketManager(bracket *BracketManager) {
+	et.bracket = bracket
 }
 
 // SetOnPlayerEliminatedCallback sets the callback for player elimination
@@ -40,8 +49,9 @@ func (et *EliminationTracker) SetOnTournamentCompleteCallback(callback func(tour
 	et.onTournamentCompleteCallback = callback
 }
 
-// EliminatePlayer records a player elimination
-func (et *EliminationTracker) EliminatePlayer(tournamentID, userID string) error {
+// EliminatePlayer records a player elimination. eliminatedBy are the players
+// who knocked them out, if known, who share the player's bounty.
+func (et *EliminationTracker) EliminatePlayer(tournamentID, userID string, eliminatedBy []string) error {
 	tx := et.db.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -93,6 +103,35 @@ func (et *EliminationTracker) EliminatePlayer(tournamentID, userID string) error
 		return err
 	}
 
+	// Record the elimination
+	if err := recordEvent(tx, &tournament, EventEliminated, &userID, models.TournamentEventData{Position: position}, now); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Whoever knocked the player out wins their bounty
+	bounties, err := claimBounty(tx, &tournamentPlayer, eliminatedBy, now)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// A bracket settles the loser's match, seating the next round once the
+	// last match of this one is settled. Other tournaments consolidate their
+	// tables afterwards, if the field fits on fewer.
+	bracketRound := 0
+	if tournament.Format == models.TournamentFormatBracket {
+		round, err := settleMatch(tx, &tournament, userID, now)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		bracketRound = round
+	} else if _, err := enqueueStep(tx, TopicConsolidate, tournamentID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Update table seat status to busted
 	if err := tx.Model(&models.TableSeat{}).
 		Where("user_id = ? AND table_id IN (SELECT id FROM tables WHERE tournament_id = ?)", userID, tournamentID).
@@ -109,10 +148,20 @@ func (et *EliminationTracker) EliminatePlayer(tournamentID, userID string) error
 	log.Printf("Tournament %s: Player %s eliminated in position %d (%d remaining)",
 		tournamentID, userID, position, remainingPlayers-1)
 
+	// Pay the bounty now; the outbox retries if this fails
+	if et.outbox != nil {
+		for _, id := range bounties {
+			et.outbox.Process(id)
+		}
+	}
+
 	// Call callback
 	if et.onPlayerEliminatedCallback != nil {
 		et.onPlayerEliminatedCallback(tournamentID, userID, position)
 	}
+	if tournament.Format == models.TournamentFormatBracket {
+		et.bracket.updated(tournamentID, bracketRound)
+	}
 
 	// Check if tournament is complete
 	// When we eliminate the 2nd place finisher, only the winner remains
@@ -188,11 +237,14 @@ func (et *EliminationTracker) CompleteTournament(tournamentID string) error {
 	}
 
 	// Mark tournament as completed
-	now := time.Now()
-	if err := tx.Model(&tournament).Updates(map[string]interface{}{
-		"status":       "completed",
-		"completed_at": now,
-	}).Error; err != nil {
+	if err := recordEvent(tx, &tournament, EventCompleted, &winner.UserID, models.TournamentEventData{Position: 1}, time.Now()); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Prizes are paid out afterwards, and retried until they are
+	payoutID, err := enqueueStep(tx, TopicPayout, tournamentID)
+	if err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -212,25 +264,11 @@ func (et *EliminationTracker) CompleteTournament(tournamentID string) error {
 
 	log.Printf("Tournament %s: Completed! Winner: %s", tournamentID, winner.UserID)
 
-	// Distribute prizes if prize distributor is set
-	if et.prizeDistributor != nil {
-		// Check if prizes haven't been distributed yet
-		distributed, err := et.prizeDistributor.HasPrizesBeenDistributed(tournamentID)
-		if err != nil {
-			log.Printf("ERROR: Failed to check prize distribution status for tournament %s: %v", tournamentID, err)
-		} else if !distributed {
-			log.Printf("Tournament %s: Starting prize distribution...", tournamentID)
-			if err := et.prizeDistributor.DistributePrizes(tournamentID); err != nil {
-				log.Printf("ERROR: Failed to distribute prizes for tournament %s: %v", tournamentID, err)
-				// Don't return error - tournament is already completed
-			} else {
-				log.Printf("Tournament %s: Prizes distributed successfully", tournamentID)
-			}
-		} else {
-			log.Printf("Tournament %s: Prizes already distributed", tournamentID)
-		}
+	// Pay out now; the outbox retries if this fails
+	if et.outbox != nil {
+		et.outbox.Process(payoutID)
 	} else {
-		log.Printf("WARNING: Tournament %s: No prize distributor set!", tournamentID)
+		log.Printf("WARNING: Tournament %s: No outbox set, prizes wait for a dispatcher", tournamentID)
 	}
 
 	// Call callback
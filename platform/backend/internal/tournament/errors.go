@@ -11,6 +11,7 @@ var (
 	ErrInvalidLevelDuration    = errors.New("level duration must be positive")
 	ErrNegativeAnte            = errors.New("ante cannot be negative")
 	ErrBlindsNotIncreasing     = errors.New("blinds must increase with each level")
+	ErrInvalidBreakSchedule    = errors.New("breaks need both break_every and break_length, with breaks of at most 60 minutes")
 
 	// Prize structure validation errors
 	ErrEmptyPrizeStructure      = errors.New("prize structure cannot be empty")
@@ -27,10 +28,24 @@ var (
 	ErrMinPlayersGreaterThanMax = errors.New("min players cannot exceed max players")
 	ErrInvalidAutoStartDelay    = errors.New("auto start delay must be non-negative")
 	ErrInvalidStartTime         = errors.New("start time cannot be in the past")
+	ErrInvalidUnregisterWindow  = errors.New("unregister deadline must be non-negative")
+	ErrInvalidLateCancelFee     = errors.New("late-cancel fee must be between 0 and the buy-in")
+	ErrInvalidEntryFee          = errors.New("entry fee must be between 0 and the buy-in")
+	ErrInvalidBounty            = errors.New("bounty must be between 0 and the buy-in")
+	ErrInvalidGuarantee         = errors.New("guarantee must be non-negative")
+	ErrInvalidBroadcastDelay    = errors.New("broadcast delay must be between 0 and 600 seconds")
+	ErrInvalidFinalTableBreak   = errors.New("final table break must be between 0 and 1800 seconds")
+	ErrInvalidTournamentType    = errors.New("tournament type must be scheduled or sit_n_go")
+	ErrSitNGoStartTime          = errors.New("a sit & go starts when full and cannot have a start time")
+	ErrInvalidTournamentFormat  = errors.New("tournament format must be standard or heads_up_bracket")
+	ErrBracketSeatingPlan       = errors.New("a bracket draws its matches and cannot have a seating plan")
+	ErrInvalidRegistrationClose = errors.New("registration close time must be in the future and before the end time")
+	ErrInvalidEndTime           = errors.New("end time must be in the future and after the start time")
 	ErrStructureNotFound        = errors.New("tournament structure preset not found")
 	ErrPrizeStructureNotFound   = errors.New("prize structure preset not found")
 	ErrInvalidStructure         = errors.New("invalid tournament structure")
 	ErrInvalidPrizeStructure    = errors.New("invalid prize structure")
+	ErrInvalidSeatingPlan       = errors.New("invalid seating plan")
 
 	// Tournament registration errors
 	ErrTournamentNotFound         = errors.New("tournament not found")
@@ -40,18 +55,53 @@ var (
 	ErrInsufficientChips          = errors.New("insufficient chips for buy-in")
 	ErrCannotUnregister           = errors.New("cannot unregister after tournament has started")
 	ErrNotRegistered              = errors.New("not registered for this tournament")
+	ErrRegistrationClosed         = errors.New("registration for this tournament has closed")
+	ErrUnregisterDeadlinePassed   = errors.New("unregistration deadline has passed")
 
 	// Tournament start errors
 	ErrNotEnoughPlayers           = errors.New("not enough players to start tournament")
 	ErrTournamentAlreadyStarted   = errors.New("tournament has already started")
 	ErrTournamentCancelled        = errors.New("tournament has been cancelled")
 	ErrTournamentCompleted        = errors.New("tournament has already completed")
+	ErrSeatingPlanUnfit           = errors.New("seating plan does not fit the registered players")
 
 	// Tournament operation errors
 	ErrNotTournamentCreator       = errors.New("only tournament creator can perform this action")
 	ErrCannotCancelStarted        = errors.New("cannot cancel tournament that has already started")
 	ErrInvalidBlindLevel          = errors.New("invalid blind level")
 	ErrNoMoreBlindLevels          = errors.New("no more blind levels in structure")
+	ErrInvalidTransition          = errors.New("invalid tournament state transition")
+
+	// Saved preset errors
+	ErrPresetNotFound             = errors.New("saved preset not found")
+	ErrInvalidPresetName          = errors.New("preset name must be between 1 and 100 characters")
+	ErrInvalidPresetKind          = errors.New("preset kind must be structure or prize")
+	ErrPresetNameTaken            = errors.New("you already have a preset with this name")
+	ErrTooManyPresets             = errors.New("saved preset limit reached")
+
+	// Simulation errors
+	ErrInvalidSimulationPlayers   = errors.New("simulated field size must be between 2 and 1000")
+	ErrInvalidBotBehavior         = errors.New("bot behavior must be tight, standard or loose")
+	ErrInvalidHandsPerHour        = errors.New("hands per hour must be between 10 and 300")
+	ErrInvalidSimulationRuns      = errors.New("simulation runs must be between 1 and 200")
+
+	// Tournament abort errors
+	ErrCannotAbort                = errors.New("only tournaments that have started can be aborted")
+	ErrInvalidRefundMethod        = errors.New("refund method must be chip_chop or icm")
+	ErrTooManyPlayersForICM       = errors.New("too many players left for an ICM refund, use chip_chop")
+
+	// Final table broadcast errors
+	ErrNotBroadcast               = errors.New("tournament is not broadcast")
+	ErrFinalTableNotReached       = errors.New("tournament has not reached its final table")
+
+	// Tournament director errors
+	ErrTournamentNotRunning       = errors.New("tournament is not running")
+	ErrInvalidClockAdjustment     = errors.New("clock adjustment must be between 1 second and 30 minutes")
+	ErrInvalidBreakLength         = errors.New("break must be between 1 second and 60 minutes")
+
+	// Satellite errors
+	ErrInvalidSatelliteTarget     = errors.New("satellite target must be another tournament taking registrations, with a buy-in")
+	ErrSatelliteBuyIn             = errors.New("a satellite needs a buy-in to pay for its tickets")
 
 	// Tournament code errors
 	ErrInvalidTournamentCode      = errors.New("invalid tournament code")
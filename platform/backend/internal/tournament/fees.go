@@ -0,0 +1,61 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// chargeEntry takes the buy-in and entry fee from a registering player as
+// separate ledger entries. The buy-in goes to the prize pool and the fee is
+// credited to the operator account.
+func (s *Service) chargeEntry(ctx context.Context, tx *gorm.DB, tournament *models.Tournament, userID string) error {
+	if tournament.BuyIn > 0 {
+		description := fmt.Sprintf("Buy-in for tournament: %s", tournament.Name)
+		if err := s.currencyService.DeductChipsWithTx(ctx, tx, userID, tournament.BuyIn,
+			currency.TxTypeTournamentBuyIn, tournament.ID, description); err != nil {
+			return err
+		}
+	}
+
+	if tournament.EntryFee > 0 {
+		description := fmt.Sprintf("Entry fee for tournament: %s", tournament.Name)
+		if err := s.currencyService.DeductChipsWithTx(ctx, tx, userID, tournament.EntryFee,
+			currency.TxTypeTournamentFee, tournament.ID, description); err != nil {
+			return err
+		}
+		description = fmt.Sprintf("Entry fee from %s for tournament: %s", userID, tournament.Name)
+		if err := s.currencyService.AddChipsWithTx(ctx, tx, currency.OperatorAccountID, tournament.EntryFee,
+			currency.TxTypeTournamentFee, tournament.ID, description); err != nil {
+			return fmt.Errorf("failed to credit entry fee: %w", err)
+		}
+	}
+	return nil
+}
+
+// refundEntry returns the buy-in and entry fee to a player, taking the fee
+// back from the operator account
+func (s *Service) refundEntry(ctx context.Context, tx *gorm.DB, tournament *models.Tournament, userID, description string) error {
+	if tournament.BuyIn > 0 {
+		if err := s.currencyService.AddChipsWithTx(ctx, tx, userID, tournament.BuyIn,
+			currency.TxTypeTournamentRefund, tournament.ID, description); err != nil {
+			return fmt.Errorf("failed to refund buy-in: %w", err)
+		}
+	}
+
+	if tournament.EntryFee > 0 {
+		if err := s.currencyService.ReverseFeeWithTx(ctx, tx, tournament.EntryFee,
+			tournament.ID, description); err != nil {
+			return fmt.Errorf("failed to reverse entry fee: %w", err)
+		}
+		if err := s.currencyService.AddChipsWithTx(ctx, tx, userID, tournament.EntryFee,
+			currency.TxTypeTournamentFeeRefund, tournament.ID, description); err != nil {
+			return fmt.Errorf("failed to refund entry fee: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package tournament
+
+import (
+	"context"
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupFeeService(t *testing.T) (*Service, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &currency.Transaction{}))
+
+	for _, user := range []models.User{
+		{ID: currency.OperatorAccountID, Username: "house", Email: "house@localhost", Chips: 5000},
+		{ID: "player-1", Username: "player1", Email: "player1@test.com", Chips: 1000},
+	} {
+		require.NoError(t, db.Create(&user).Error)
+	}
+	return NewService(db, currency.NewService(db)), db
+}
+
+func chipsOf(t *testing.T, db *gorm.DB, userID string) int {
+	var user models.User
+	require.NoError(t, db.First(&user, "id = ?", userID).Error)
+	return user.Chips
+}
+
+func TestChargeAndRefundEntry(t *testing.T) {
+	service, db := setupFeeService(t)
+	ctx := context.Background()
+	tourney := &models.Tournament{ID: "t-1", Name: "Sunday 100+10", BuyIn: 100, EntryFee: 10}
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return service.chargeEntry(ctx, tx, tourney, "player-1")
+	}))
+	assert.Equal(t, 890, chipsOf(t, db, "player-1"))
+	assert.Equal(t, 5010, chipsOf(t, db, currency.OperatorAccountID))
+
+	// The buy-in and fee are separate ledger entries
+	var types []string
+	require.NoError(t, db.Model(&currency.Transaction{}).Where("user_id = ?", "player-1").
+		Order("amount ASC").Pluck("transaction_type", &types).Error)
+	assert.Equal(t, []string{string(currency.TxTypeTournamentBuyIn), string(currency.TxTypeTournamentFee)}, types)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return service.refundEntry(ctx, tx, tourney, "player-1", "Refund")
+	}))
+	assert.Equal(t, 1000, chipsOf(t, db, "player-1"))
+	assert.Equal(t, 5000, chipsOf(t, db, currency.OperatorAccountID))
+}
+
+func TestUnregister_HouseInOverdraft(t *testing.T) {
+	service, db := setupFeeService(t)
+	testutil.Schema(t, db, &models.Tournament{}, &models.TournamentPlayer{}, &models.TournamentEvent{})
+	for _, stmt := range []string{
+		`INSERT INTO tournaments (id, name, status, buy_in, entry_fee, max_players, current_players, prize_pool) VALUES
+			('t-1', 'Sunday 100+10', 'registering', 100, 10, 9, 1, 100)`,
+		`INSERT INTO tournament_players (tournament_id, user_id) VALUES ('t-1', 'player-1')`,
+		// Overlays have left the house short
+		`UPDATE users SET chips = -500 WHERE id = '00000000-0000-0000-0000-000000000000'`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+
+	_, err := service.UnregisterPlayer("t-1", "player-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1110, chipsOf(t, db, "player-1"))
+	assert.Equal(t, -510, chipsOf(t, db, currency.OperatorAccountID))
+}
+
+func TestChargeEntry_Freeroll(t *testing.T) {
+	service, db := setupFeeService(t)
+	tourney := &models.Tournament{ID: "t-2", Name: "Freeroll"}
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return service.chargeEntry(context.Background(), tx, tourney, "player-1")
+	}))
+	assert.Equal(t, 1000, chipsOf(t, db, "player-1"))
+
+	var count int64
+	require.NoError(t, db.Model(&currency.Transaction{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestChargeEntry_InsufficientChips(t *testing.T) {
+	service, db := setupFeeService(t)
+	tourney := &models.Tournament{ID: "t-3", Name: "High roller", BuyIn: 1000, EntryFee: 100}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return service.chargeEntry(context.Background(), tx, tourney, "player-1")
+	})
+	assert.ErrorIs(t, err, currency.ErrInsufficientChips)
+	assert.Equal(t, 1000, chipsOf(t, db, "player-1"))
+	assert.Equal(t, 5000, chipsOf(t, db, currency.OperatorAccountID))
+}
+
+func TestValidateCreateRequest_EntryFee(t *testing.T) {
+	service := &Service{}
+	req := models.CreateTournamentRequest{Name: "Fees", BuyIn: 100, StartingChips: 1000, MaxPlayers: 10, MinPlayers: 2}
+
+	req.EntryFee = 10
+	assert.NoError(t, service.validateCreateRequest(req))
+
+	req.EntryFee = 101
+	assert.ErrorIs(t, service.validateCreateRequest(req), ErrInvalidEntryFee)
+
+	req.EntryFee = -1
+	assert.ErrorIs(t, service.validateCreateRequest(req), ErrInvalidEntryFee)
+}
+
+func TestCheckRegistration(t *testing.T) {
+	service, db := setupFeeService(t)
+	testutil.Schema(t, db, &models.Tournament{}, &models.TournamentPlayer{})
+	for _, stmt := range []string{
+		`INSERT INTO tournaments (id, name, status, buy_in, entry_fee, max_players, current_players, prize_pool) VALUES
+			('t-1', 'Sunday 100+10', 'registering', 100, 10, 9, 3, 300),
+			('t-2', 'High roller', 'registering', 1000, 100, 9, 0, 0),
+			('t-3', 'Full', 'registering', 100, 0, 2, 2, 200)`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+
+	quote, err := service.CheckRegistration("t-1", "player-1")
+	require.NoError(t, err)
+	assert.Equal(t, RegistrationQuote{TournamentID: "t-1", BuyIn: 100, EntryFee: 10, Total: 110,
+		BalanceAfter: 890, PlayersAfter: 4, PrizePoolAfter: 400}, *quote)
+
+	_, err = service.CheckRegistration("t-2", "player-1")
+	assert.ErrorIs(t, err, ErrInsufficientChips)
+	_, err = service.CheckRegistration("t-3", "player-1")
+	assert.ErrorIs(t, err, ErrTournamentFull)
+	_, err = service.CheckRegistration("missing", "player-1")
+	assert.ErrorIs(t, err, ErrTournamentNotFound)
+
+	// Nothing was charged or registered
+	assert.Equal(t, 1000, chipsOf(t, db, "player-1"))
+	var count int64
+	require.NoError(t, db.Model(&currency.Transaction{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
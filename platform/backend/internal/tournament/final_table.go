@@ -0,0 +1,134 @@
+package tournament
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MaxFinalTableBreak is the longest break a tournament can take before its
+// final table deals
+const MaxFinalTableBreak = 30 * time.Minute
+
+// validFinalTableBreak checks a final table break in seconds. Zero deals the
+// final table straight away.
+func validFinalTableBreak(seconds int) bool {
+	return seconds >= 0 && seconds <= int(MaxFinalTableBreak/time.Second)
+}
+
+// Payout is the prize for finishing in a place
+type Payout struct {
+	Position int `json:"position"`
+	Amount   int `json:"amount"`
+}
+
+// RedrawSeats deals players into random seats for the final table. Seats in
+// taken belong to busted players and are skipped; the players' current seats
+// are always available. It returns the new seat of each player.
+func RedrawSeats(current map[string]int, maxSeats int, taken map[int]bool, rng *rand.Rand) map[string]int {
+	free := map[int]bool{}
+	for seat := 0; seat < maxSeats; seat++ {
+		if !taken[seat] {
+			free[seat] = true
+		}
+	}
+	for _, seat := range current {
+		free[seat] = true
+	}
+
+	seats := make([]int, 0, len(free))
+	for seat := range free {
+		seats = append(seats, seat)
+	}
+	sort.Ints(seats)
+	rng.Shuffle(len(seats), func(i, j int) { seats[i], seats[j] = seats[j], seats[i] })
+
+	userIDs := make([]string, 0, len(current))
+	for userID := range current {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	redrawn := make(map[string]int, len(userIDs))
+	for i, userID := range userIDs {
+		redrawn[userID] = seats[i]
+	}
+	return redrawn
+}
+
+// SetOnFinalTableCallback sets the callback for a tournament reaching its
+// final table through consolidation
+func (c *Consolidator) SetOnFinalTableCallback(callback func(tournamentID, tableID string)) {
+	c.onFinalTableCallback = callback
+}
+
+// redrawFinalTable gives the players left at the final table new random seats
+func (c *Consolidator) redrawFinalTable(tx *gorm.DB, table models.Table) error {
+	var seats []models.TableSeat
+	if err := tx.Where("table_id = ?", table.ID).Find(&seats).Error; err != nil {
+		return err
+	}
+
+	current := map[string]int{}
+	taken := map[int]bool{}
+	var live []models.TableSeat
+	for _, seat := range seats {
+		if seat.Status == "busted" {
+			taken[seat.SeatNumber] = true
+			continue
+		}
+		current[seat.UserID] = seat.SeatNumber
+		live = append(live, seat)
+	}
+
+	redrawn := RedrawSeats(current, table.MaxPlayers, taken, rand.New(rand.NewSource(time.Now().UnixNano())))
+
+	// Seats are unique per table, so everyone steps out before sitting down again
+	for i, seat := range live {
+		if err := tx.Model(&seat).Update("seat_number", -(i + 1)).Error; err != nil {
+			return err
+		}
+	}
+	for _, seat := range live {
+		if err := tx.Model(&seat).Update("seat_number", redrawn[seat.UserID]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemainingPayouts returns the prizes of the places still to be decided
+func (s *Service) RemainingPayouts(tournamentID string) ([]Payout, error) {
+	var tournament models.Tournament
+	if err := s.db.Where("id = ?", tournamentID).First(&tournament).Error; err != nil {
+		return nil, ErrTournamentNotFound
+	}
+	structure, err := tournamentPrizeStructure(&tournament)
+	if err != nil {
+		return nil, err
+	}
+
+	var entrants, remaining int64
+	if err := s.db.Model(&models.TournamentPlayer{}).Where("tournament_id = ?", tournamentID).
+		Count(&entrants).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(&models.TournamentPlayer{}).
+		Where("tournament_id = ? AND eliminated_at IS NULL", tournamentID).
+		Count(&remaining).Error; err != nil {
+		return nil, err
+	}
+
+	payouts := []Payout{}
+	for position, amount := range CalculatePrizeAmounts(poolShare(&tournament)*int(entrants), structure) {
+		if position <= int(remaining) {
+			payouts = append(payouts, Payout{Position: position, Amount: amount})
+		}
+	}
+	sort.Slice(payouts, func(i, j int) bool { return payouts[i].Position < payouts[j].Position })
+	return payouts, nil
+}
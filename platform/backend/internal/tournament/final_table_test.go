@@ -0,0 +1,93 @@
+package tournament
+
+import (
+	"math/rand"
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRedrawSeats(t *testing.T) {
+	current := map[string]int{"a": 0, "b": 1, "c": 5, "d": 12}
+	taken := map[int]bool{2: true, 3: true}
+
+	redrawn := RedrawSeats(current, 9, taken, rand.New(rand.NewSource(3)))
+	require.Len(t, redrawn, 4)
+
+	used := map[int]bool{}
+	for userID, seat := range redrawn {
+		assert.False(t, used[seat], "seat %d given twice", seat)
+		assert.False(t, taken[seat], "seat %d belongs to a busted player", seat)
+		assert.True(t, seat < 9 || seat == 12, "player %s given seat %d off the table", userID, seat)
+		used[seat] = true
+	}
+
+	again := RedrawSeats(current, 9, taken, rand.New(rand.NewSource(3)))
+	assert.Equal(t, redrawn, again)
+}
+
+func TestRedrawFinalTable(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	testutil.Schema(t, db, &models.TableSeat{})
+	require.NoError(t, db.Exec(`INSERT INTO table_seats (table_id, user_id, seat_number, chips, status) VALUES
+		('final', 'a', 0, 500, 'active'), ('final', 'b', 1, 700, 'active'), ('final', 'c', 2, 0, 'busted'),
+		('final', 'd', 3, 900, 'active')`).Error)
+
+	c := NewConsolidator(db)
+	require.NoError(t, c.redrawFinalTable(db, models.Table{ID: "final", MaxPlayers: 4}))
+
+	var seats []models.TableSeat
+	require.NoError(t, db.Where("table_id = ?", "final").Order("user_id").Find(&seats).Error)
+	used := map[int]bool{}
+	for _, seat := range seats {
+		if seat.UserID == "c" {
+			assert.Equal(t, 2, seat.SeatNumber, "busted players keep their seat")
+			continue
+		}
+		assert.NotEqual(t, 2, seat.SeatNumber)
+		assert.True(t, seat.SeatNumber >= 0 && seat.SeatNumber < 4)
+		assert.False(t, used[seat.SeatNumber])
+		used[seat.SeatNumber] = true
+	}
+}
+
+func TestRemainingPayouts(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	testutil.Schema(t, db, &models.Tournament{}, &models.TournamentPlayer{})
+	for _, stmt := range []string{
+		`INSERT INTO tournaments (id, buy_in, prize_structure) VALUES ('t-1', 100, 'top_3')`,
+		`INSERT INTO tournament_players (tournament_id, user_id, eliminated_at) VALUES
+			('t-1', 'a', NULL), ('t-1', 'b', NULL), ('t-1', 'c', '2026-01-01'), ('t-1', 'd', '2026-01-01')`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	service := NewService(db, currency.NewService(db))
+
+	// 400 in the pool; third place has already been decided
+	payouts, err := service.RemainingPayouts("t-1")
+	require.NoError(t, err)
+	assert.Equal(t, []Payout{{Position: 1, Amount: 200}, {Position: 2, Amount: 120}}, payouts)
+
+	_, err = service.RemainingPayouts("missing")
+	assert.ErrorIs(t, err, ErrTournamentNotFound)
+}
+
+func TestValidateCreateRequest_FinalTableBreak(t *testing.T) {
+	s := &Service{}
+	req := models.CreateTournamentRequest{Name: "MTT", BuyIn: 100, StartingChips: 1000, MaxPlayers: 90, MinPlayers: 2}
+
+	req.FinalTableBreak = 600
+	assert.NoError(t, s.validateCreateRequest(req))
+
+	req.FinalTableBreak = 1801
+	assert.ErrorIs(t, s.validateCreateRequest(req), ErrInvalidFinalTableBreak)
+}
@@ -0,0 +1,13 @@
+package tournament
+
+import "poker-platform/backend/internal/models"
+
+// GuaranteedPool tops up the prize pool raised by a tournament's buy-ins to
+// its guarantee, returning the pool to pay out and the overlay, the part of it
+// the house covers
+func GuaranteedPool(tournament *models.Tournament, raised int) (pool, overlay int) {
+	if raised >= tournament.Guarantee {
+		return raised, 0
+	}
+	return tournament.Guarantee, tournament.Guarantee - raised
+}
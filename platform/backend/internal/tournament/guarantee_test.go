@@ -0,0 +1,62 @@
+package tournament
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGuaranteedPool(t *testing.T) {
+	tourney := &models.Tournament{Guarantee: 1000}
+
+	pool, overlay := GuaranteedPool(tourney, 600)
+	assert.Equal(t, 1000, pool)
+	assert.Equal(t, 400, overlay)
+
+	pool, overlay = GuaranteedPool(tourney, 1200)
+	assert.Equal(t, 1200, pool)
+	assert.Zero(t, overlay, "no overlay once the guarantee is met")
+
+	pool, overlay = GuaranteedPool(&models.Tournament{}, 300)
+	assert.Equal(t, 300, pool)
+	assert.Zero(t, overlay)
+}
+
+func TestDistributePrizes_Overlay(t *testing.T) {
+	// The distributor reads outside its transaction, so both connections need the same database
+	db, err := gorm.Open(sqlite.Open("file:overlay?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &currency.Transaction{}))
+	testutil.Schema(t, db, &models.Tournament{}, &models.TournamentPlayer{})
+	for _, stmt := range []string{
+		`INSERT INTO users (id, username, email, password_hash, chips) VALUES ('` + currency.OperatorAccountID + `', 'house', 'house@localhost', '', 5000),
+			('player-1', 'player1', 'player1@test.com', '', 1000)`,
+		`INSERT INTO users (id, username, email, password_hash, chips) VALUES ('player-2', 'player2', 'player2@test.com', '', 0),
+			('player-3', 'player3', 'player3@test.com', '', 0)`,
+		`INSERT INTO tournaments (id, name, buy_in, guarantee, prize_structure, prizes_distributed) VALUES ('t-1', 'Guaranteed 1k', 100, 1000, 'top_3', false)`,
+		`INSERT INTO tournament_players (tournament_id, user_id, position) VALUES
+			('t-1', 'player-1', 1), ('t-1', 'player-2', 2), ('t-1', 'player-3', 3)`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+
+	// 300 in buy-ins against a 1000 guarantee: the house adds 700
+	pd := NewPrizeDistributor(db, currency.NewService(db))
+	require.NoError(t, pd.DistributePrizes("t-1"))
+
+	assert.Equal(t, 1500, chipsOf(t, db, "player-1"))
+	assert.Equal(t, 300, chipsOf(t, db, "player-2"))
+	assert.Equal(t, 200, chipsOf(t, db, "player-3"))
+	assert.Equal(t, 4300, chipsOf(t, db, currency.OperatorAccountID))
+
+	var overlay currency.Transaction
+	require.NoError(t, db.Where("transaction_type = ?", currency.TxTypeTournamentOverlay).First(&overlay).Error)
+	assert.Equal(t, -700, overlay.Amount)
+}
@@ -0,0 +1,133 @@
+package tournament
+
+import (
+	"sync"
+
+	"poker-platform/backend/internal/models"
+)
+
+// HandForHand coordinates hand-for-hand play on the money bubble. While it is
+// on, a table that finishes its hand waits until every other table of the
+// tournament has finished theirs, then they all deal the next hand together,
+// so nobody can stall into the money at a slower table.
+type HandForHand struct {
+	mu          sync.Mutex
+	tournaments map[string]*handForHandRound
+}
+
+// handForHandRound tracks which tables have finished the current hand
+type handForHandRound struct {
+	finished map[string]bool // tableID -> finished this round
+	release  chan struct{}   // Closed once every table has finished
+}
+
+// NewHandForHand creates a coordinator with no tournament on the bubble
+func NewHandForHand() *HandForHand {
+	return &HandForHand{tournaments: make(map[string]*handForHandRound)}
+}
+
+// IsBubble reports whether one more elimination puts every remaining player
+// in the money
+func IsBubble(remaining, paid int) bool {
+	return paid > 0 && remaining == paid+1
+}
+
+// Start puts a tournament's tables into hand-for-hand play and reports whether
+// it was newly started. Called again while active, it updates the tables to
+// wait for, dropping tables that have broken.
+func (h *HandForHand) Start(tournamentID string, tableIDs []string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	round, active := h.tournaments[tournamentID]
+	if !active {
+		round = &handForHandRound{finished: make(map[string]bool), release: make(chan struct{})}
+		h.tournaments[tournamentID] = round
+	}
+
+	current := make(map[string]bool, len(tableIDs))
+	for _, tableID := range tableIDs {
+		current[tableID] = round.finished[tableID]
+	}
+	round.finished = current
+	round.releaseIfDone()
+	return !active
+}
+
+// Stop ends hand-for-hand play, releasing every waiting table. It reports
+// whether the tournament was playing hand for hand.
+func (h *HandForHand) Stop(tournamentID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	round, active := h.tournaments[tournamentID]
+	if active {
+		close(round.release)
+		delete(h.tournaments, tournamentID)
+	}
+	return active
+}
+
+// IsActive reports whether a tournament is playing hand for hand
+func (h *HandForHand) IsActive(tournamentID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, active := h.tournaments[tournamentID]
+	return active
+}
+
+// HandFinished records that a table has finished its hand and returns a
+// channel that is closed once every table has. Outside hand-for-hand play the
+// channel is already closed.
+func (h *HandForHand) HandFinished(tournamentID, tableID string) <-chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	round, active := h.tournaments[tournamentID]
+	if !active {
+		released := make(chan struct{})
+		close(released)
+		return released
+	}
+
+	release := round.release
+	round.finished[tableID] = true
+	round.releaseIfDone()
+	return release
+}
+
+// releaseIfDone starts the next round once every table has finished. Caller
+// must hold h.mu.
+func (r *handForHandRound) releaseIfDone() {
+	for _, finished := range r.finished {
+		if !finished {
+			return
+		}
+	}
+	close(r.release)
+	r.release = make(chan struct{})
+	for tableID := range r.finished {
+		r.finished[tableID] = false
+	}
+}
+
+// BubbleStatus returns how many players are still in a tournament and how
+// many places are paid
+func (s *Service) BubbleStatus(tournamentID string) (remaining, paid int, err error) {
+	var tournament models.Tournament
+	if err := s.db.Select("id", "prize_structure").Where("id = ?", tournamentID).First(&tournament).Error; err != nil {
+		return 0, 0, ErrTournamentNotFound
+	}
+
+	var count int64
+	if err := s.db.Model(&models.TournamentPlayer{}).
+		Where("tournament_id = ? AND eliminated_at IS NULL", tournamentID).
+		Count(&count).Error; err != nil {
+		return 0, 0, err
+	}
+
+	if structure, err := tournamentPrizeStructure(&tournament); err == nil {
+		paid = len(structure.Positions)
+	}
+	return int(count), paid, nil
+}
@@ -0,0 +1,86 @@
+package tournament
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHandForHand_TablesWaitForEachOther(t *testing.T) {
+	h := NewHandForHand()
+
+	// Outside hand-for-hand play nothing waits
+	assert.True(t, isClosed(h.HandFinished("t-1", "table-1")))
+
+	assert.True(t, h.Start("t-1", []string{"table-1", "table-2", "table-3"}))
+	assert.False(t, h.Start("t-1", []string{"table-1", "table-2", "table-3"}))
+	assert.True(t, h.IsActive("t-1"))
+
+	first := h.HandFinished("t-1", "table-1")
+	second := h.HandFinished("t-1", "table-2")
+	assert.False(t, isClosed(first))
+	assert.False(t, isClosed(second))
+
+	last := h.HandFinished("t-1", "table-3")
+	assert.True(t, isClosed(first) && isClosed(second) && isClosed(last))
+
+	// The next round waits again
+	next := h.HandFinished("t-1", "table-1")
+	assert.False(t, isClosed(next))
+
+	// A table that broke is no longer waited for
+	h.HandFinished("t-1", "table-2")
+	h.Start("t-1", []string{"table-1", "table-2"})
+	assert.True(t, isClosed(next))
+
+	waiting := h.HandFinished("t-1", "table-1")
+	assert.True(t, h.Stop("t-1"))
+	assert.True(t, isClosed(waiting))
+	assert.False(t, h.IsActive("t-1"))
+	assert.False(t, h.Stop("t-1"))
+}
+
+func TestIsBubble(t *testing.T) {
+	assert.True(t, IsBubble(4, 3))
+	assert.False(t, IsBubble(5, 3))
+	assert.False(t, IsBubble(3, 3))
+	assert.False(t, IsBubble(1, 0))
+}
+
+func TestBubbleStatus(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	testutil.Schema(t, db, &models.Tournament{}, &models.TournamentPlayer{})
+	for _, stmt := range []string{
+		`INSERT INTO tournaments (id, prize_structure) VALUES ('t-1', 'top_3')`,
+		`INSERT INTO tournament_players (tournament_id, user_id, eliminated_at) VALUES
+			('t-1', 'a', NULL), ('t-1', 'b', NULL), ('t-1', 'c', NULL), ('t-1', 'd', NULL), ('t-1', 'e', '2026-01-01')`,
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+	service := NewService(db, currency.NewService(db))
+
+	remaining, paid, err := service.BubbleStatus("t-1")
+	require.NoError(t, err)
+	assert.Equal(t, 4, remaining)
+	assert.Equal(t, 3, paid)
+
+	_, _, err = service.BubbleStatus("missing")
+	assert.ErrorIs(t, err, ErrTournamentNotFound)
+}
@@ -0,0 +1,79 @@
+package tournament
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/outbox"
+
+	"gorm.io/gorm"
+)
+
+// Outbox topics of the steps that follow a tournament change in its own
+// transaction
+const (
+	TopicPayout      = "tournament.payout"      // Pay the prizes of a completed tournament
+	TopicConsolidate = "tournament.consolidate" // Break tables after an elimination, if the field fits on fewer
+)
+
+// tournamentStep is the payload of a tournament outbox message
+type tournamentStep struct {
+	TournamentID string `json:"tournament_id"`
+}
+
+func enqueueStep(tx *gorm.DB, topic, tournamentID string) (string, error) {
+	return outbox.Enqueue(tx, topic, tournamentID, tournamentStep{TournamentID: tournamentID})
+}
+
+// PayoutHandler pays out a completed tournament. Paying twice is prevented
+// by the prizes_distributed flag, so it is safe to retry. A payout that keeps
+// failing has nothing to undo and is left for an admin to retry.
+func (pd *PrizeDistributor) PayoutHandler() outbox.Handler {
+	return outbox.Handler{
+		Do: func(ctx context.Context, msg models.OutboxMessage) error {
+			var step tournamentStep
+			if err := outbox.Decode(msg, &step); err != nil {
+				return err
+			}
+			return pd.DistributePrizes(step.TournamentID)
+		},
+	}
+}
+
+// ConsolidationHandler breaks tables after an elimination if the remaining
+// players fit on fewer. It checks again when it runs, so a late or repeated
+// run does nothing once the tables are consolidated. With a planned callback
+// set the plan is announced and applied later, once its notice is up.
+func (c *Consolidator) ConsolidationHandler(et *EliminationTracker) outbox.Handler {
+	return outbox.Handler{
+		Do: func(ctx context.Context, msg models.OutboxMessage) error {
+			var step tournamentStep
+			if err := outbox.Decode(msg, &step); err != nil {
+				return err
+			}
+			shouldConsolidate, err := et.ShouldConsolidateTables(step.TournamentID)
+			if err != nil || !shouldConsolidate {
+				return err
+			}
+			if c.onPlannedCallback == nil {
+				log.Printf("Tournament %s: Consolidating tables", step.TournamentID)
+				return c.ConsolidateTables(step.TournamentID)
+			}
+			// The plan already announced checks again when it applies
+			if !c.startPlan(step.TournamentID) {
+				return nil
+			}
+			plan, err := c.PlanConsolidation(step.TournamentID)
+			if err != nil {
+				c.finishPlan(step.TournamentID)
+				return err
+			}
+			plan.EffectiveAt = time.Now().Add(ConsolidationNotice)
+			log.Printf("Tournament %s: Consolidating tables at %s", step.TournamentID, plan.EffectiveAt.Format(time.RFC3339))
+			c.onPlannedCallback(plan)
+			return nil
+		},
+	}
+}
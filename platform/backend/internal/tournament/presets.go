@@ -1,6 +1,11 @@
 package tournament
 
-import "poker-platform/backend/internal/models"
+import (
+	"fmt"
+	"time"
+
+	"poker-platform/backend/internal/models"
+)
 
 // Predefined Tournament Structures
 var (
@@ -225,6 +230,46 @@ func GetDefaultPrizeStructure() models.PrizeStructureConfig {
 	return Top3Payout
 }
 
+// ResolveStructures returns the blind and prize structures for a tournament:
+// the named preset if given, otherwise the validated custom structure,
+// otherwise the default
+func ResolveStructures(structurePreset string, customStructure *models.TournamentStructure,
+	prizePreset string, customPrize *models.PrizeStructureConfig) (models.TournamentStructure, models.PrizeStructureConfig, error) {
+	var structure models.TournamentStructure
+	if structurePreset != "" {
+		preset, exists := GetStructurePreset(structurePreset)
+		if !exists {
+			return structure, models.PrizeStructureConfig{}, ErrStructureNotFound
+		}
+		structure = preset
+	} else if customStructure != nil {
+		if err := ValidateStructure(*customStructure); err != nil {
+			return structure, models.PrizeStructureConfig{}, fmt.Errorf("%w: %v", ErrInvalidStructure, err)
+		}
+		structure = *customStructure
+	} else {
+		structure = GetDefaultStructure()
+	}
+
+	var prizeStructure models.PrizeStructureConfig
+	if prizePreset != "" {
+		preset, exists := GetPrizeStructurePreset(prizePreset)
+		if !exists {
+			return structure, prizeStructure, ErrPrizeStructureNotFound
+		}
+		prizeStructure = preset
+	} else if customPrize != nil {
+		if err := ValidatePrizeStructure(*customPrize); err != nil {
+			return structure, prizeStructure, fmt.Errorf("%w: %v", ErrInvalidPrizeStructure, err)
+		}
+		prizeStructure = *customPrize
+	} else {
+		prizeStructure = GetDefaultPrizeStructure()
+	}
+
+	return structure, prizeStructure, nil
+}
+
 // ValidateStructure validates a tournament structure
 func ValidateStructure(structure models.TournamentStructure) error {
 	if len(structure.BlindLevels) == 0 {
@@ -249,6 +294,12 @@ func ValidateStructure(structure models.TournamentStructure) error {
 		}
 	}
 
+	if structure.BreakEvery < 0 || structure.BreakLength < 0 ||
+		(structure.BreakEvery > 0) != (structure.BreakLength > 0) ||
+		structure.BreakLength > int(MaxBreakLength/time.Second) {
+		return ErrInvalidBreakSchedule
+	}
+
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"poker-platform/backend/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PrizeDistributor handles prize calculation and distribution
@@ -16,6 +17,7 @@ type PrizeDistributor struct {
 	db                         *gorm.DB
 	currencyService            *currency.Service
 	onPrizeDistributedCallback func(tournamentID, userID string, amount int)
+	onBountyPaidCallback       func(knockout models.TournamentKnockout)
 }
 
 // NewPrizeDistributor creates a new prize distributor
@@ -31,12 +33,20 @@ func (pd *PrizeDistributor) SetOnPrizeDistributedCallback(callback func(tourname
 	pd.onPrizeDistributedCallback = callback
 }
 
+// SetOnBountyPaidCallback sets the callback for the cash of a knockout being
+// paid
+func (pd *PrizeDistributor) SetOnBountyPaidCallback(callback func(knockout models.TournamentKnockout)) {
+	pd.onBountyPaidCallback = callback
+}
+
 // PrizeInfo represents prize information for a player
 type PrizeInfo struct {
-	Position int    `json:"position"`
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	Amount   int    `json:"amount"`
+	Position    int    `json:"position"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	Amount      int    `json:"amount"`                 // Chips
+	TicketTo    string `json:"ticket_to,omitempty"`    // A satellite's seat in this tournament
+	TicketValue int    `json:"ticket_value,omitempty"` // What the seat is worth
 }
 
 // CalculatePrizes calculates prize amounts for all eligible positions
@@ -53,6 +63,10 @@ func (pd *PrizeDistributor) CalculatePrizes(tournamentID string) ([]PrizeInfo, e
 	log.Printf("[PRIZE_CALC] Tournament: name=%s, buy_in=%d, prize_structure=%s", 
 		tournament.Name, tournament.BuyIn, tournament.PrizeStructure)
 
+	if tournament.SatelliteTargetID != nil {
+		return pd.calculateSatellitePrizes(&tournament)
+	}
+
 	// Get prize structure
 	prizeStructure, ok := GetPrizeStructurePreset(tournament.PrizeStructure)
 	if !ok {
@@ -81,9 +95,9 @@ func (pd *PrizeDistributor) CalculatePrizes(tournamentID string) ([]PrizeInfo, e
 			i+1, player.UserID, posStr, player.Chips)
 	}
 
-	// Calculate total prize pool
-	prizePool := tournament.BuyIn * len(players)
-	log.Printf("[PRIZE_CALC] Prize pool: %d chips (%d buy-in × %d players)", prizePool, tournament.BuyIn, len(players))
+	// Calculate total prize pool, with the house's overlay if the buy-ins fell short of the guarantee
+	prizePool, overlay := GuaranteedPool(&tournament, poolShare(&tournament)*len(players))
+	log.Printf("[PRIZE_CALC] Prize pool: %d chips (%d buy-in × %d players, %d overlay)", prizePool, poolShare(&tournament), len(players), overlay)
 
 	// Calculate prizes for each position using integer math
 	var prizes []PrizeInfo
@@ -155,6 +169,22 @@ func (pd *PrizeDistributor) DistributePrizes(tournamentID string) error {
 		}
 	}()
 
+	// Lock the tournament so a retried payout waits for one in flight, then
+	// finds it done instead of paying twice
+	var tournament models.Tournament
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", tournamentID).
+		First(&tournament).Error; err != nil {
+		tx.Rollback()
+		log.Printf("[PRIZE_DIST] ERROR: Failed to get tournament %s: %v", tournamentID, err)
+		return err
+	}
+	if tournament.PrizesDistributed {
+		tx.Rollback()
+		log.Printf("[PRIZE_DIST] Tournament %s: Prizes already distributed", tournamentID)
+		return nil
+	}
+
 	// Calculate prizes
 	prizes, err := pd.CalculatePrizes(tournamentID)
 	if err != nil {
@@ -174,18 +204,42 @@ func (pd *PrizeDistributor) DistributePrizes(tournamentID string) error {
 		return fmt.Errorf("no prizes to distribute")
 	}
 
-	// Get tournament for description
-	var tournament models.Tournament
-	if err := tx.Where("id = ?", tournamentID).First(&tournament).Error; err != nil {
-		tx.Rollback()
-		log.Printf("[PRIZE_DIST] ERROR: Failed to get tournament %s: %v", tournamentID, err)
-		return err
-	}
-
 	// Distribute each prize using currency service for atomic operations and audit trail
 	// CRITICAL: Use AddChipsWithTx to ensure prize distribution is atomic with tournament update
 	ctx := context.Background()
+
+	// The house covers what the buy-ins fell short of the guarantee
+	var entrants int64
+	if err := tx.Model(&models.TournamentPlayer{}).Where("tournament_id = ?", tournamentID).Count(&entrants).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to count players: %w", err)
+	}
+	if _, overlay := GuaranteedPool(&tournament, poolShare(&tournament)*int(entrants)); overlay > 0 {
+		description := fmt.Sprintf("Overlay on the %d guarantee of tournament %s", tournament.Guarantee, tournament.Name)
+		if err := pd.currencyService.CoverOverlayWithTx(ctx, tx, overlay, tournamentID, description); err != nil {
+			tx.Rollback()
+			log.Printf("[PRIZE_DIST] ERROR: Failed to cover overlay of %d for tournament %s: %v", overlay, tournamentID, err)
+			return fmt.Errorf("failed to cover overlay: %w", err)
+		}
+		log.Printf("[PRIZE_DIST] House covered an overlay of %d chips for tournament %s", overlay, tournamentID)
+	}
 	for _, prize := range prizes {
+		// A satellite seat is a ticket, with any chips paid as well
+		if prize.TicketTo != "" {
+			if err := mintTicket(tx, tournamentID, prize); err != nil {
+				tx.Rollback()
+				log.Printf("[PRIZE_DIST] ERROR: Failed to give user %s a ticket to tournament %s: %v", prize.UserID, prize.TicketTo, err)
+				return fmt.Errorf("failed to give user %s a ticket: %w", prize.UserID, err)
+			}
+			log.Printf("[PRIZE_DIST] Gave user %s a ticket to tournament %s (position %d)", prize.UserID, prize.TicketTo, prize.Position)
+			if err := tx.Model(&models.TournamentPlayer{}).
+				Where("tournament_id = ? AND user_id = ?", tournamentID, prize.UserID).
+				Update("prize_amount", prize.TicketValue+prize.Amount).Error; err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to update prize amount for user %s: %w", prize.UserID, err)
+			}
+		}
+
 		// Skip zero prizes
 		if prize.Amount <= 0 {
 			log.Printf("[PRIZE_DIST] Skipping zero prize for user %s at position %d", prize.UserID, prize.Position)
@@ -215,7 +269,7 @@ func (pd *PrizeDistributor) DistributePrizes(tournamentID string) error {
 		// Update prize amount in tournament_players table
 		if err := tx.Model(&models.TournamentPlayer{}).
 			Where("tournament_id = ? AND user_id = ?", tournamentID, prize.UserID).
-			Update("prize_amount", prize.Amount).Error; err != nil {
+			Update("prize_amount", prize.TicketValue+prize.Amount).Error; err != nil {
 			tx.Rollback()
 			log.Printf("[PRIZE_DIST] ERROR: Failed to update prize_amount for user %s: %v", prize.UserID, err)
 			return fmt.Errorf("failed to update prize amount for user %s: %w", prize.UserID, err)
@@ -225,6 +279,15 @@ func (pd *PrizeDistributor) DistributePrizes(tournamentID string) error {
 			prize.UserID, prize.Amount, prize.Position)
 	}
 
+	// The champion of a bounty tournament collects their own bounty
+	if bounties, err := pd.collectBounties(ctx, tx, &tournament); err != nil {
+		tx.Rollback()
+		log.Printf("[PRIZE_DIST] ERROR: Failed to pay the champion's bounty for tournament %s: %v", tournamentID, err)
+		return err
+	} else if bounties > 0 {
+		log.Printf("[PRIZE_DIST] Champion collected %d chips of bounties in tournament %s", bounties, tournamentID)
+	}
+
 	// Mark prizes as distributed in tournament
 	if err := tx.Model(&models.Tournament{}).
 		Where("id = ?", tournamentID).
@@ -241,9 +304,46 @@ func (pd *PrizeDistributor) DistributePrizes(tournamentID string) error {
 	}
 
 	log.Printf("[PRIZE_DIST] SUCCESS: Tournament %s - Distributed %d prizes", tournamentID, len(prizes))
+
+	if pd.onPrizeDistributedCallback != nil {
+		for _, prize := range prizes {
+			if prize.Amount > 0 {
+				pd.onPrizeDistributedCallback(tournamentID, prize.UserID, prize.Amount)
+			}
+		}
+	}
 	return nil
 }
 
+// calculateSatellitePrizes works out a satellite's seats in its target from
+// its prize pool
+func (pd *PrizeDistributor) calculateSatellitePrizes(tournament *models.Tournament) ([]PrizeInfo, error) {
+	var target models.Tournament
+	if err := pd.db.Where("id = ?", *tournament.SatelliteTargetID).First(&target).Error; err != nil {
+		return nil, fmt.Errorf("satellite target not found: %w", err)
+	}
+	var players []models.TournamentPlayer
+	if err := pd.db.Where("tournament_id = ?", tournament.ID).
+		Order("position ASC").
+		Find(&players).Error; err != nil {
+		return nil, fmt.Errorf("failed to get players: %w", err)
+	}
+
+	prizePool, _ := GuaranteedPool(tournament, poolShare(tournament)*len(players))
+	prizes := satellitePrizes(players, prizePool, &target)
+	log.Printf("[PRIZE_CALC] Satellite %s: %d chips buy %d-chip seats in tournament %s",
+		tournament.ID, prizePool, TicketValue(&target), target.ID)
+
+	for i := range prizes {
+		prizes[i].Username = prizes[i].UserID
+		var user models.User
+		if err := pd.db.Where("id = ?", prizes[i].UserID).First(&user).Error; err == nil {
+			prizes[i].Username = user.Username
+		}
+	}
+	return prizes, nil
+}
+
 // GetPrizeInfo gets prize information for a tournament (before distribution)
 func (pd *PrizeDistributor) GetPrizeInfo(tournamentID string) ([]PrizeInfo, error) {
 	return pd.CalculatePrizes(tournamentID)
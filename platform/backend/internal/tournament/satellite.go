@@ -0,0 +1,197 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// A satellite's prize pool buys seats in its target tournament. Each seat is a
+// ticket worth the target's buy-in and entry fee, awarded to the top finishers,
+// and what's left over after the last whole seat is paid in chips to the next
+// finisher. A ticket is used in place of the buy-in when its holder registers.
+
+// TicketValue is what a ticket to a tournament is worth
+func TicketValue(target *models.Tournament) int {
+	return target.BuyIn + target.EntryFee
+}
+
+// validateSatelliteTarget checks the tournament a new satellite would award
+// tickets for
+func validateSatelliteTarget(db *gorm.DB, req models.CreateTournamentRequest) error {
+	if req.SatelliteTargetID == nil {
+		return nil
+	}
+	if req.BuyIn <= 0 {
+		return ErrSatelliteBuyIn
+	}
+	var target models.Tournament
+	if err := db.Where("id = ?", *req.SatelliteTargetID).First(&target).Error; err != nil {
+		return ErrInvalidSatelliteTarget
+	}
+	if target.Status != "registering" || TicketValue(&target) <= 0 {
+		return ErrInvalidSatelliteTarget
+	}
+	return nil
+}
+
+// satellitePrizes awards seats in the target to the players of a satellite,
+// best finish first, and the remainder of the pool in chips to the player
+// after the last seat, or to the winner if everyone won a seat
+func satellitePrizes(players []models.TournamentPlayer, prizePool int, target *models.Tournament) []PrizeInfo {
+	var finishers []models.TournamentPlayer
+	for _, player := range players {
+		if player.Position != nil {
+			finishers = append(finishers, player)
+		}
+	}
+	value := TicketValue(target)
+	seats := prizePool / value
+	if seats > len(finishers) {
+		seats = len(finishers)
+	}
+
+	var prizes []PrizeInfo
+	for _, player := range finishers[:seats] {
+		prizes = append(prizes, PrizeInfo{
+			Position:    *player.Position,
+			UserID:      player.UserID,
+			TicketTo:    target.ID,
+			TicketValue: value,
+		})
+	}
+
+	remainder := prizePool - seats*value
+	switch {
+	case remainder <= 0:
+	case seats < len(finishers):
+		player := finishers[seats]
+		prizes = append(prizes, PrizeInfo{Position: *player.Position, UserID: player.UserID, Amount: remainder})
+	case len(prizes) > 0:
+		// Everyone won a seat
+		prizes[0].Amount = remainder
+	}
+	return prizes
+}
+
+// mintTicket gives a satellite player their ticket to the target
+func mintTicket(tx *gorm.DB, satelliteID string, prize PrizeInfo) error {
+	ticket := &models.TournamentTicket{
+		ID:                 uuid.New().String(),
+		UserID:             prize.UserID,
+		TournamentID:       &prize.TicketTo,
+		SourceTournamentID: &satelliteID,
+		Value:              prize.TicketValue,
+		Status:             models.TicketUnused,
+	}
+	return tx.Create(ticket).Error
+}
+
+// usableTicket finds the unused ticket a player would register for a
+// tournament with: one won for it, or else the open ticket of least value that
+// covers its buy-in and entry fee. Free tournaments take no ticket.
+func usableTicket(query *gorm.DB, tournament *models.Tournament, userID string, ticket *models.TournamentTicket) error {
+	cost := tournament.BuyIn + tournament.EntryFee
+	if cost == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return query.Where("user_id = ? AND status = ?", userID, models.TicketUnused).
+		Where("tournament_id = ? OR (tournament_id IS NULL AND value >= ?)", tournament.ID, cost).
+		Order("tournament_id IS NULL, value, created_at").
+		First(ticket).Error
+}
+
+// redeemTicket uses one of a registering player's unused tickets in place of
+// the buy-in and entry fee, crediting the operator with the fee as chargeEntry
+// would. It returns nil if they hold none that fits.
+func (s *Service) redeemTicket(ctx context.Context, tx *gorm.DB, tournament *models.Tournament, userID string) (*models.TournamentTicket, error) {
+	var ticket models.TournamentTicket
+	err := usableTicket(tx.Clauses(clause.Locking{Strength: "UPDATE"}), tournament, userID, &ticket)
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := tx.Model(&ticket).Updates(map[string]interface{}{
+		"status":  models.TicketUsed,
+		"used_at": &now,
+	}).Error; err != nil {
+		return nil, err
+	}
+	if tournament.EntryFee > 0 {
+		description := fmt.Sprintf("Entry fee from %s's ticket for tournament: %s", userID, tournament.Name)
+		if err := s.currencyService.AddChipsWithTx(ctx, tx, currency.OperatorAccountID, tournament.EntryFee,
+			currency.TxTypeTournamentFee, tournament.ID, description); err != nil {
+			return nil, fmt.Errorf("failed to credit entry fee: %w", err)
+		}
+	}
+	return &ticket, nil
+}
+
+// returnTicket gives an unregistering player back the ticket they registered
+// with, taking the entry fee back from the operator
+func (s *Service) returnTicket(ctx context.Context, tx *gorm.DB, tournament *models.Tournament, ticketID string) error {
+	if err := tx.Model(&models.TournamentTicket{}).Where("id = ?", ticketID).Updates(map[string]interface{}{
+		"status":  models.TicketUnused,
+		"used_at": nil,
+	}).Error; err != nil {
+		return err
+	}
+	if tournament.EntryFee > 0 {
+		description := fmt.Sprintf("Entry fee returned to a ticket for tournament: %s", tournament.Name)
+		if err := s.currencyService.ReverseFeeWithTx(ctx, tx, tournament.EntryFee,
+			tournament.ID, description); err != nil {
+			return fmt.Errorf("failed to reverse entry fee: %w", err)
+		}
+	}
+	return nil
+}
+
+// refundTickets pays out every ticket to a cancelled tournament in chips:
+// unused ones for their value, and used ones, open tickets included, are
+// marked refunded since their holders get the buy-in and fee back with
+// everyone else
+func (s *Service) refundTickets(ctx context.Context, tx *gorm.DB, tournament *models.Tournament) error {
+	var unused []models.TournamentTicket
+	if err := tx.Where("tournament_id = ? AND status = ?", tournament.ID, models.TicketUnused).
+		Find(&unused).Error; err != nil {
+		return err
+	}
+	for _, ticket := range unused {
+		description := fmt.Sprintf("Refund of a ticket to cancelled tournament: %s", tournament.Name)
+		if err := s.currencyService.AddChipsWithTx(ctx, tx, ticket.UserID, ticket.Value,
+			currency.TxTypeTournamentRefund, tournament.ID, description); err != nil {
+			return fmt.Errorf("failed to refund ticket %s: %w", ticket.ID, err)
+		}
+		log.Printf("[SATELLITE] Refunded ticket %s of user %s to cancelled tournament %s: %d chips",
+			ticket.ID, ticket.UserID, tournament.ID, ticket.Value)
+	}
+	if err := tx.Model(&models.TournamentTicket{}).
+		Where("tournament_id = ? AND status IN ?", tournament.ID, []string{models.TicketUnused, models.TicketUsed}).
+		Update("status", models.TicketRefunded).Error; err != nil {
+		return err
+	}
+	registered := tx.Model(&models.TournamentPlayer{}).Select("ticket_id").
+		Where("tournament_id = ? AND ticket_id IS NOT NULL", tournament.ID)
+	return tx.Model(&models.TournamentTicket{}).
+		Where("id IN (?) AND status = ?", registered, models.TicketUsed).
+		Update("status", models.TicketRefunded).Error
+}
+
+// ListTickets returns a user's tickets, newest first
+func (s *Service) ListTickets(userID string) ([]models.TournamentTicket, error) {
+	var tickets []models.TournamentTicket
+	err := s.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&tickets).Error
+	return tickets, err
+}
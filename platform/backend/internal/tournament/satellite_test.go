@@ -0,0 +1,141 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/models"
+	"poker-platform/backend/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func satellitePlayers(n int) []models.TournamentPlayer {
+	players := make([]models.TournamentPlayer, n)
+	for i := range players {
+		position := i + 1
+		players[i] = models.TournamentPlayer{UserID: string(rune('a' + i)), Position: &position}
+	}
+	return players
+}
+
+func setupTicketService(t *testing.T) (*Service, *gorm.DB) {
+	service, db := setupFeeService(t)
+	testutil.Schema(t, db, &models.TournamentTicket{}, &models.TournamentPlayer{})
+	return service, db
+}
+
+func TestSatellitePrizes(t *testing.T) {
+	target := &models.Tournament{ID: "main", BuyIn: 100, EntryFee: 10}
+
+	// 10 players at 25 buy 2 seats, with 30 left over for third place
+	prizes := satellitePrizes(satellitePlayers(10), 250, target)
+	require.Len(t, prizes, 3)
+	for _, prize := range prizes[:2] {
+		assert.Equal(t, "main", prize.TicketTo)
+		assert.Equal(t, 110, prize.TicketValue)
+		assert.Zero(t, prize.Amount)
+	}
+	assert.Equal(t, PrizeInfo{Position: 3, UserID: "c", Amount: 30}, prizes[2])
+
+	// More seats than players: everyone gets one and the winner the rest
+	prizes = satellitePrizes(satellitePlayers(2), 250, target)
+	require.Len(t, prizes, 2)
+	assert.Equal(t, 30, prizes[0].Amount)
+	assert.Equal(t, "main", prizes[1].TicketTo)
+
+	// Too small a pool for a seat pays the winner in chips
+	prizes = satellitePrizes(satellitePlayers(3), 90, target)
+	assert.Equal(t, []PrizeInfo{{Position: 1, UserID: "a", Amount: 90}}, prizes)
+}
+
+func TestRedeemAndReturnTicket(t *testing.T) {
+	service, db := setupTicketService(t)
+	ctx := context.Background()
+	target := &models.Tournament{ID: "main", Name: "Main event", BuyIn: 100, EntryFee: 10}
+
+	require.NoError(t, mintTicket(db, "sat-1", PrizeInfo{UserID: "player-1", TicketTo: "main", TicketValue: 110}))
+
+	var ticket *models.TournamentTicket
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) (err error) {
+		ticket, err = service.redeemTicket(ctx, tx, target, "player-1")
+		return err
+	}))
+	require.NotNil(t, ticket)
+	assert.Equal(t, 1000, chipsOf(t, db, "player-1"), "the ticket pays the buy-in")
+	assert.Equal(t, 5010, chipsOf(t, db, currency.OperatorAccountID), "the operator still gets the fee")
+
+	// A used ticket can't be redeemed again
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		again, err := service.redeemTicket(ctx, tx, target, "player-1")
+		assert.Nil(t, again)
+		return err
+	}))
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return service.returnTicket(ctx, tx, target, ticket.ID)
+	}))
+	assert.Equal(t, 5000, chipsOf(t, db, currency.OperatorAccountID))
+
+	// Cancelling the target pays an unused ticket out in chips
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return service.refundTickets(ctx, tx, target)
+	}))
+	assert.Equal(t, 1110, chipsOf(t, db, "player-1"))
+	tickets, err := service.ListTickets("player-1")
+	require.NoError(t, err)
+	require.Len(t, tickets, 1)
+	assert.Equal(t, models.TicketRefunded, tickets[0].Status)
+}
+
+func TestOpenTickets(t *testing.T) {
+	service, db := setupTicketService(t)
+	ctx := context.Background()
+	target := &models.Tournament{ID: "main", Name: "Main event", BuyIn: 100, EntryFee: 10}
+	level := 5
+	for _, value := range []int{50, 500, 200} {
+		require.NoError(t, db.Create(&models.TournamentTicket{
+			ID: fmt.Sprintf("open-%d", value), UserID: "player-1", Level: &level, Value: value, Status: models.TicketUnused,
+		}).Error)
+	}
+	require.NoError(t, mintTicket(db, "sat-1", PrizeInfo{UserID: "player-1", TicketTo: "main", TicketValue: 110}))
+
+	redeem := func(tournament *models.Tournament) (ticket *models.TournamentTicket) {
+		require.NoError(t, db.Transaction(func(tx *gorm.DB) (err error) {
+			ticket, err = service.redeemTicket(ctx, tx, tournament, "player-1")
+			return err
+		}))
+		return ticket
+	}
+
+	// A seat won for the tournament goes first, then the smallest open ticket that covers it
+	ticket := redeem(target)
+	require.NotNil(t, ticket)
+	assert.Equal(t, "main", *ticket.TournamentID)
+	ticket = redeem(target)
+	require.NotNil(t, ticket)
+	assert.Nil(t, ticket.TournamentID)
+	assert.Equal(t, 200, ticket.Value)
+
+	// Free tournaments take no ticket
+	assert.Nil(t, redeem(&models.Tournament{ID: "freeroll"}))
+
+	// Cancelling refunds an open ticket registered with, along with the seat
+	require.NoError(t, db.Create(&models.TournamentPlayer{ID: 1, TournamentID: "main", UserID: "player-1", TicketID: &ticket.ID}).Error)
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return service.refundTickets(ctx, tx, target)
+	}))
+	tickets, err := service.ListTickets("player-1")
+	require.NoError(t, err)
+	statuses := make(map[int]string)
+	for _, ticket := range tickets {
+		if ticket.TournamentID == nil {
+			statuses[ticket.Value] = ticket.Status
+		}
+	}
+	assert.Equal(t, map[int]string{50: models.TicketUnused, 200: models.TicketRefunded, 500: models.TicketUnused}, statuses)
+}
@@ -0,0 +1,200 @@
+package tournament
+
+import (
+	"fmt"
+	"strings"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Saved preset kinds
+const (
+	PresetKindStructure = "structure"
+	PresetKindPrize     = "prize"
+)
+
+// maxSavedPresets is how many presets a user can save
+const maxSavedPresets = 50
+
+// SavePresetRequest creates or replaces a saved preset. Structure is required
+// for structure presets and PrizeStructure for prize presets.
+type SavePresetRequest struct {
+	Name           string                       `json:"name"`
+	Kind           string                       `json:"kind"`
+	Structure      *models.TournamentStructure  `json:"structure,omitempty"`
+	PrizeStructure *models.PrizeStructureConfig `json:"prize_structure,omitempty"`
+}
+
+// validatePreset checks a preset request and returns it with the unused structure cleared
+func validatePreset(req SavePresetRequest) (SavePresetRequest, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > 100 {
+		return req, ErrInvalidPresetName
+	}
+
+	switch req.Kind {
+	case PresetKindStructure:
+		if req.Structure == nil {
+			return req, ErrInvalidStructure
+		}
+		if err := ValidateStructure(*req.Structure); err != nil {
+			return req, fmt.Errorf("%w: %v", ErrInvalidStructure, err)
+		}
+		req.PrizeStructure = nil
+	case PresetKindPrize:
+		if req.PrizeStructure == nil {
+			return req, ErrInvalidPrizeStructure
+		}
+		if err := ValidatePrizeStructure(*req.PrizeStructure); err != nil {
+			return req, fmt.Errorf("%w: %v", ErrInvalidPrizeStructure, err)
+		}
+		req.Structure = nil
+	default:
+		return req, ErrInvalidPresetKind
+	}
+	return req, nil
+}
+
+// SavePreset saves a validated structure or prize structure as a named preset
+func (s *Service) SavePreset(userID string, req SavePresetRequest) (*models.TournamentPreset, error) {
+	req, err := validatePreset(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := s.db.Model(&models.TournamentPreset{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count >= maxSavedPresets {
+		return nil, ErrTooManyPresets
+	}
+	if err := s.checkPresetName(userID, req.Kind, req.Name, ""); err != nil {
+		return nil, err
+	}
+
+	preset := &models.TournamentPreset{
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		Kind:           req.Kind,
+		Name:           req.Name,
+		Structure:      req.Structure,
+		PrizeStructure: req.PrizeStructure,
+	}
+	if err := s.db.Create(preset).Error; err != nil {
+		return nil, err
+	}
+	return preset, nil
+}
+
+// UpdatePreset replaces the name and structure of a saved preset. The kind cannot change.
+func (s *Service) UpdatePreset(userID, presetID string, req SavePresetRequest) (*models.TournamentPreset, error) {
+	preset, err := s.GetPreset(userID, presetID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Kind = preset.Kind
+	req, err = validatePreset(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPresetName(userID, req.Kind, req.Name, preset.ID); err != nil {
+		return nil, err
+	}
+
+	preset.Name = req.Name
+	preset.Structure = req.Structure
+	preset.PrizeStructure = req.PrizeStructure
+	if err := s.db.Save(preset).Error; err != nil {
+		return nil, err
+	}
+	return preset, nil
+}
+
+// checkPresetName returns ErrPresetNameTaken if the user has another preset of
+// the kind with the name
+func (s *Service) checkPresetName(userID, kind, name, excludeID string) error {
+	query := s.db.Model(&models.TournamentPreset{}).Where("user_id = ? AND kind = ? AND name = ?", userID, kind, name)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrPresetNameTaken
+	}
+	return nil
+}
+
+// GetPreset returns one of a user's saved presets
+func (s *Service) GetPreset(userID, presetID string) (*models.TournamentPreset, error) {
+	var preset models.TournamentPreset
+	if err := s.db.Where("id = ? AND user_id = ?", presetID, userID).First(&preset).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrPresetNotFound
+		}
+		return nil, err
+	}
+	return &preset, nil
+}
+
+// ListPresets returns a user's saved presets by name, optionally of one kind
+func (s *Service) ListPresets(userID, kind string) ([]models.TournamentPreset, error) {
+	query := s.db.Where("user_id = ?", userID)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	var presets []models.TournamentPreset
+	if err := query.Order("kind ASC, name ASC").Find(&presets).Error; err != nil {
+		return nil, err
+	}
+	return presets, nil
+}
+
+// DeletePreset deletes a saved preset. Tournaments created from it keep their copy.
+func (s *Service) DeletePreset(userID, presetID string) error {
+	result := s.db.Where("id = ? AND user_id = ?", presetID, userID).Delete(&models.TournamentPreset{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPresetNotFound
+	}
+	return nil
+}
+
+// applySavedPresets replaces saved preset IDs in a create request with the
+// custom structures they hold
+func (s *Service) applySavedPresets(req *models.CreateTournamentRequest, userID string) error {
+	if req.StructurePresetID != "" {
+		preset, err := s.GetPreset(userID, req.StructurePresetID)
+		if err != nil {
+			return err
+		}
+		if preset.Kind != PresetKindStructure {
+			return ErrInvalidPresetKind
+		}
+		req.StructurePreset = ""
+		req.CustomStructure = preset.Structure
+	}
+
+	if req.PrizePresetID != "" {
+		preset, err := s.GetPreset(userID, req.PrizePresetID)
+		if err != nil {
+			return err
+		}
+		if preset.Kind != PresetKindPrize {
+			return ErrInvalidPresetKind
+		}
+		req.PrizeStructurePreset = ""
+		req.CustomPrizeStructure = preset.PrizeStructure
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package tournament
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupPresetService(t *testing.T) *Service {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.TournamentPreset{}))
+	return NewService(db, nil)
+}
+
+func TestSavePreset(t *testing.T) {
+	service := setupPresetService(t)
+
+	structure := TurboStructure
+	preset, err := service.SavePreset("user-1", SavePresetRequest{
+		Name:           "  Friday turbo ",
+		Kind:           PresetKindStructure,
+		Structure:      &structure,
+		PrizeStructure: &Top3Payout,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Friday turbo", preset.Name)
+	assert.Nil(t, preset.PrizeStructure, "unused structure should be dropped")
+
+	loaded, err := service.GetPreset("user-1", preset.ID)
+	require.NoError(t, err)
+	require.NotNil(t, loaded.Structure)
+	assert.Equal(t, TurboStructure.BlindLevels, loaded.Structure.BlindLevels)
+
+	// Presets are private to their owner
+	_, err = service.GetPreset("user-2", preset.ID)
+	assert.ErrorIs(t, err, ErrPresetNotFound)
+
+	_, err = service.SavePreset("user-1", SavePresetRequest{Name: "Friday turbo", Kind: PresetKindStructure, Structure: &structure})
+	assert.ErrorIs(t, err, ErrPresetNameTaken)
+
+	// The same name is fine for the other kind
+	_, err = service.SavePreset("user-1", SavePresetRequest{Name: "Friday turbo", Kind: PresetKindPrize, PrizeStructure: &Top3Payout})
+	assert.NoError(t, err)
+
+	invalid := models.TournamentStructure{BlindLevels: []models.BlindLevel{{Level: 1, SmallBlind: 20, BigBlind: 10, Duration: 60}}}
+	_, err = service.SavePreset("user-1", SavePresetRequest{Name: "Broken", Kind: PresetKindStructure, Structure: &invalid})
+	assert.ErrorIs(t, err, ErrInvalidStructure)
+
+	_, err = service.SavePreset("user-1", SavePresetRequest{Name: "Odd", Kind: "blinds"})
+	assert.ErrorIs(t, err, ErrInvalidPresetKind)
+
+	presets, err := service.ListPresets("user-1", PresetKindPrize)
+	require.NoError(t, err)
+	assert.Len(t, presets, 1)
+}
+
+func TestUpdateAndDeletePreset(t *testing.T) {
+	service := setupPresetService(t)
+
+	preset, err := service.SavePreset("user-1", SavePresetRequest{Name: "Payout", Kind: PresetKindPrize, PrizeStructure: &Top3Payout})
+	require.NoError(t, err)
+
+	// The kind of a preset cannot change
+	updated, err := service.UpdatePreset("user-1", preset.ID, SavePresetRequest{Name: "Top 5", Kind: PresetKindStructure, PrizeStructure: &Top5Payout})
+	require.NoError(t, err)
+	assert.Equal(t, PresetKindPrize, updated.Kind)
+	assert.Equal(t, "Top 5", updated.Name)
+	assert.Len(t, updated.PrizeStructure.Positions, len(Top5Payout.Positions))
+
+	assert.ErrorIs(t, service.DeletePreset("user-2", preset.ID), ErrPresetNotFound)
+	require.NoError(t, service.DeletePreset("user-1", preset.ID))
+	_, err = service.GetPreset("user-1", preset.ID)
+	assert.ErrorIs(t, err, ErrPresetNotFound)
+}
+
+func TestApplySavedPresets(t *testing.T) {
+	service := setupPresetService(t)
+
+	structure := DeepStackStructure
+	blinds, err := service.SavePreset("user-1", SavePresetRequest{Name: "Deep", Kind: PresetKindStructure, Structure: &structure})
+	require.NoError(t, err)
+	payout, err := service.SavePreset("user-1", SavePresetRequest{Name: "Top 5", Kind: PresetKindPrize, PrizeStructure: &Top5Payout})
+	require.NoError(t, err)
+
+	req := models.CreateTournamentRequest{StructurePreset: "turbo", StructurePresetID: blinds.ID, PrizePresetID: payout.ID}
+	require.NoError(t, service.applySavedPresets(&req, "user-1"))
+	assert.Empty(t, req.StructurePreset)
+	assert.Equal(t, DeepStackStructure.BlindLevels, req.CustomStructure.BlindLevels)
+	assert.Equal(t, Top5Payout.Positions, req.CustomPrizeStructure.Positions)
+
+	req = models.CreateTournamentRequest{StructurePresetID: payout.ID}
+	assert.ErrorIs(t, service.applySavedPresets(&req, "user-1"), ErrInvalidPresetKind)
+
+	req = models.CreateTournamentRequest{StructurePresetID: blinds.ID}
+	assert.ErrorIs(t, service.applySavedPresets(&req, "user-2"), ErrPresetNotFound)
+}
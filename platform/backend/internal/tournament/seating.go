@@ -0,0 +1,278 @@
+package tournament
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"poker-platform/backend/internal/models"
+)
+
+// TablePlayers is how many players a tournament table seats
+const TablePlayers = 8
+
+// validateSeatingPlan checks a seating plan against the most players a
+// tournament can have. Whether it fits the players who actually register is
+// checked again when the tournament starts.
+func validateSeatingPlan(plan *models.SeatingPlan, maxPlayers int) error {
+	if plan == nil {
+		return nil
+	}
+	maxTables := CalculateTablesNeeded(maxPlayers, TablePlayers)
+
+	fixedAt := map[string]int{}
+	seats := map[[2]int]bool{}
+	for _, f := range plan.Fixed {
+		if f.UserID == "" {
+			return fmt.Errorf("%w: a fixed seat has no player", ErrInvalidSeatingPlan)
+		}
+		if _, dup := fixedAt[f.UserID]; dup {
+			return fmt.Errorf("%w: player %s has more than one fixed seat", ErrInvalidSeatingPlan, f.UserID)
+		}
+		if f.Table < 1 || f.Table > maxTables {
+			return fmt.Errorf("%w: table %d is not between 1 and %d", ErrInvalidSeatingPlan, f.Table, maxTables)
+		}
+		if f.Seat < 0 || f.Seat >= TablePlayers {
+			return fmt.Errorf("%w: seat %d is not between 0 and %d", ErrInvalidSeatingPlan, f.Seat, TablePlayers-1)
+		}
+		key := [2]int{f.Table, f.Seat}
+		if seats[key] {
+			return fmt.Errorf("%w: seat %d of table %d is given twice", ErrInvalidSeatingPlan, f.Seat, f.Table)
+		}
+		seats[key] = true
+		fixedAt[f.UserID] = f.Table
+	}
+
+	for _, group := range plan.Apart {
+		if len(group) < 2 {
+			return fmt.Errorf("%w: a group kept apart needs at least 2 players", ErrInvalidSeatingPlan)
+		}
+		if len(group) > maxTables {
+			return fmt.Errorf("%w: a group of %d can't be kept apart at %d tables", ErrInvalidSeatingPlan, len(group), maxTables)
+		}
+		members := map[string]bool{}
+		tables := map[int]bool{}
+		for _, userID := range group {
+			if userID == "" || members[userID] {
+				return fmt.Errorf("%w: a group kept apart has a missing or repeated player", ErrInvalidSeatingPlan)
+			}
+			members[userID] = true
+			if table, ok := fixedAt[userID]; ok {
+				if tables[table] {
+					return fmt.Errorf("%w: players kept apart have fixed seats at table %d", ErrInvalidSeatingPlan, table)
+				}
+				tables[table] = true
+			}
+		}
+	}
+
+	seeded := map[string]bool{}
+	for _, userID := range plan.Seeds {
+		if userID == "" || seeded[userID] {
+			return fmt.Errorf("%w: seeds have a missing or repeated player", ErrInvalidSeatingPlan)
+		}
+		seeded[userID] = true
+	}
+	return nil
+}
+
+// seatingTable is a table being filled by seatPlayers
+type seatingTable struct {
+	room    int          // Players still to seat
+	seats   []string     // User ID in each seat, empty when open
+	players []string     // Players seated at the table
+	groups  map[int]bool // Apart groups with a player here
+}
+
+// seatPlayers deals registered players into tables of perTable seats, sized
+// as DistributePlayersToTables does, following a seating plan. Fixed players
+// take their seats, seeds are spread across the tables in ranking order,
+// groups kept apart go to different tables and everyone else is seated at
+// random. Players without a fixed seat take the lowest open seats. It returns
+// the user ID in each seat of each table, empty for open seats, or
+// ErrSeatingPlanUnfit when the plan can't be followed with these players.
+func seatPlayers(plan *models.SeatingPlan, userIDs []string, perTable int, rng *rand.Rand) ([][]string, error) {
+	if plan == nil {
+		plan = &models.SeatingPlan{}
+	}
+	distribution := DistributePlayersToTables(len(userIDs), perTable)
+	tables := make([]seatingTable, len(distribution))
+	for t, count := range distribution {
+		tables[t] = seatingTable{room: count, seats: make([]string, perTable), groups: map[int]bool{}}
+	}
+
+	registered := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		registered[userID] = true
+	}
+	groupsOf := map[string][]int{}
+	for g, group := range plan.Apart {
+		for _, userID := range group {
+			if registered[userID] {
+				groupsOf[userID] = append(groupsOf[userID], g)
+			}
+		}
+	}
+
+	placed := map[string]int{}
+	fits := func(userID string, t int) bool {
+		if tables[t].room == 0 {
+			return false
+		}
+		for _, g := range groupsOf[userID] {
+			if tables[t].groups[g] {
+				return false
+			}
+		}
+		return true
+	}
+	place := func(userID string, t int) {
+		placed[userID] = t
+		tables[t].room--
+		tables[t].players = append(tables[t].players, userID)
+		for _, g := range groupsOf[userID] {
+			tables[t].groups[g] = true
+		}
+	}
+
+	fixed := map[string]bool{}
+	for _, f := range plan.Fixed {
+		if !registered[f.UserID] {
+			continue
+		}
+		t := f.Table - 1
+		if t < 0 || t >= len(tables) || f.Seat < 0 || f.Seat >= perTable {
+			return nil, fmt.Errorf("%w: only %d tables are in play", ErrSeatingPlanUnfit, len(tables))
+		}
+		if tables[t].seats[f.Seat] != "" || !fits(f.UserID, t) {
+			return nil, fmt.Errorf("%w: table %d has too few players for its fixed seats", ErrSeatingPlanUnfit, f.Table)
+		}
+		tables[t].seats[f.Seat] = f.UserID
+		fixed[f.UserID] = true
+		place(f.UserID, t)
+	}
+
+	// Each seed goes to the table with the fewest seeds so far, so the top
+	// seeds are spread one per table
+	seeds := make([]int, len(tables))
+	for _, userID := range plan.Seeds {
+		if t, ok := placed[userID]; ok {
+			seeds[t]++
+		}
+	}
+	for _, userID := range plan.Seeds {
+		if !registered[userID] {
+			continue
+		}
+		if _, ok := placed[userID]; ok {
+			continue
+		}
+		best := -1
+		for t := range tables {
+			if fits(userID, t) && (best < 0 || seeds[t] < seeds[best]) {
+				best = t
+			}
+		}
+		if best < 0 {
+			return nil, fmt.Errorf("%w: no table left for seed %s", ErrSeatingPlanUnfit, userID)
+		}
+		place(userID, best)
+		seeds[best]++
+	}
+
+	// Larger groups first, each player at the emptiest table none of their
+	// groups sits at
+	groups := make([]int, len(plan.Apart))
+	for g := range groups {
+		groups[g] = g
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return len(plan.Apart[groups[i]]) > len(plan.Apart[groups[j]]) })
+	for _, g := range groups {
+		for _, userID := range plan.Apart[g] {
+			if !registered[userID] {
+				continue
+			}
+			if _, ok := placed[userID]; ok {
+				continue
+			}
+			var best []int
+			for t := range tables {
+				if !fits(userID, t) {
+					continue
+				}
+				if len(best) > 0 && tables[t].room < tables[best[0]].room {
+					continue
+				}
+				if len(best) > 0 && tables[t].room > tables[best[0]].room {
+					best = best[:0]
+				}
+				best = append(best, t)
+			}
+			if len(best) == 0 {
+				return nil, fmt.Errorf("%w: player %s can't be kept apart from their group", ErrSeatingPlanUnfit, userID)
+			}
+			place(userID, best[rng.Intn(len(best))])
+		}
+	}
+
+	var rest []string
+	for _, userID := range userIDs {
+		if _, ok := placed[userID]; !ok {
+			rest = append(rest, userID)
+		}
+	}
+	rng.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+	for t := range tables {
+		for tables[t].room > 0 {
+			place(rest[0], t)
+			rest = rest[1:]
+		}
+	}
+
+	assignments := make([][]string, len(tables))
+	for t := range tables {
+		var open []string
+		for _, userID := range tables[t].players {
+			if !fixed[userID] {
+				open = append(open, userID)
+			}
+		}
+		rng.Shuffle(len(open), func(i, j int) { open[i], open[j] = open[j], open[i] })
+		for seat := 0; seat < perTable && len(open) > 0; seat++ {
+			if tables[t].seats[seat] == "" {
+				tables[t].seats[seat] = open[0]
+				open = open[1:]
+			}
+		}
+		assignments[t] = tables[t].seats
+	}
+	return assignments, nil
+}
+
+// SetSeatingPlan replaces the seating plan of a tournament still taking
+// registrations, such as once teams or rankings are known. Only the creator
+// can change it; nil seats everyone at random.
+func (s *Service) SetSeatingPlan(tournamentID, userID string, plan *models.SeatingPlan) (*models.Tournament, error) {
+	tournament, err := s.creatorTournament(tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status != "registering" {
+		return nil, ErrTournamentAlreadyStarted
+	}
+	if err := validateSeatingPlan(plan, tournament.MaxPlayers); err != nil {
+		return nil, err
+	}
+
+	tournament.SeatingPlan = plan
+	// The start locks the row, so a plan set as it starts either makes it in
+	// or finds the tournament started
+	result := s.db.Model(tournament).Where("status = ?", "registering").Select("seating_plan").Updates(tournament)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrTournamentAlreadyStarted
+	}
+	return tournament, nil
+}
@@ -0,0 +1,146 @@
+package tournament
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seatingPlayers(n int) []string {
+	userIDs := make([]string, n)
+	for i := range userIDs {
+		userIDs[i] = fmt.Sprintf("p%02d", i+1)
+	}
+	return userIDs
+}
+
+// tableOf returns the table each player was seated at
+func tableOf(seated [][]string) map[string]int {
+	tables := map[string]int{}
+	for t, seats := range seated {
+		for _, userID := range seats {
+			if userID != "" {
+				tables[userID] = t
+			}
+		}
+	}
+	return tables
+}
+
+func TestSeatPlayers_FollowsPlan(t *testing.T) {
+	plan := &models.SeatingPlan{
+		Fixed: []models.FixedSeat{{UserID: "p01", Table: 2, Seat: 5}},
+		Apart: [][]string{{"p02", "p03", "p04"}, {"p05", "p06"}},
+		Seeds: []string{"p07", "p08", "p09", "p01", "gone"},
+	}
+	// Every draw must follow the plan
+	for i := int64(0); i < 50; i++ {
+		seated, err := seatPlayers(plan, seatingPlayers(20), TablePlayers, rand.New(rand.NewSource(i)))
+		require.NoError(t, err)
+		require.Len(t, seated, 3)
+
+		assert.Equal(t, "p01", seated[1][5])
+		tables := tableOf(seated)
+		assert.Len(t, tables, 20)
+		assert.Len(t, map[int]bool{tables["p02"]: true, tables["p03"]: true, tables["p04"]: true}, 3)
+		assert.NotEqual(t, tables["p05"], tables["p06"])
+		// p01 is the seed of table 2, so the top seeds take the other two
+		assert.Len(t, map[int]bool{tables["p07"]: true, tables["p08"]: true, tables["p01"]: true}, 3)
+
+		for _, seats := range seated {
+			count := 0
+			for _, userID := range seats {
+				if userID != "" {
+					count++
+				}
+			}
+			assert.Contains(t, []int{6, 7}, count)
+		}
+	}
+}
+
+func TestSeatPlayers_NoPlan(t *testing.T) {
+	seated, err := seatPlayers(nil, seatingPlayers(9), TablePlayers, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	require.Len(t, seated, 2)
+
+	// Without fixed seats everyone takes the lowest seats
+	assert.NotEmpty(t, seated[0][4])
+	assert.Empty(t, seated[0][5])
+	assert.NotEmpty(t, seated[1][3])
+	assert.Empty(t, seated[1][4])
+	assert.Len(t, tableOf(seated), 9)
+}
+
+func TestSeatPlayers_Unfit(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// Table 2 isn't in play with 6 players
+	_, err := seatPlayers(&models.SeatingPlan{Fixed: []models.FixedSeat{{UserID: "p01", Table: 2, Seat: 0}}},
+		seatingPlayers(6), TablePlayers, rng)
+	assert.ErrorIs(t, err, ErrSeatingPlanUnfit)
+
+	// Nor can two players be kept apart at one table
+	_, err = seatPlayers(&models.SeatingPlan{Apart: [][]string{{"p01", "p02"}}}, seatingPlayers(6), TablePlayers, rng)
+	assert.ErrorIs(t, err, ErrSeatingPlanUnfit)
+
+	// Unless one of them didn't register
+	_, err = seatPlayers(&models.SeatingPlan{Apart: [][]string{{"p01", "gone"}}}, seatingPlayers(6), TablePlayers, rng)
+	assert.NoError(t, err)
+}
+
+func TestValidateSeatingPlan(t *testing.T) {
+	valid := &models.SeatingPlan{
+		Fixed: []models.FixedSeat{{UserID: "a", Table: 1, Seat: 0}, {UserID: "b", Table: 2, Seat: 0}},
+		Apart: [][]string{{"a", "b"}},
+		Seeds: []string{"c", "a"},
+	}
+	assert.NoError(t, validateSeatingPlan(valid, 16))
+	assert.NoError(t, validateSeatingPlan(nil, 16))
+
+	for name, plan := range map[string]*models.SeatingPlan{
+		"table out of range":   {Fixed: []models.FixedSeat{{UserID: "a", Table: 3, Seat: 0}}},
+		"seat out of range":    {Fixed: []models.FixedSeat{{UserID: "a", Table: 1, Seat: 8}}},
+		"seat given twice":     {Fixed: []models.FixedSeat{{UserID: "a", Table: 1, Seat: 2}, {UserID: "b", Table: 1, Seat: 2}}},
+		"two fixed seats":      {Fixed: []models.FixedSeat{{UserID: "a", Table: 1, Seat: 1}, {UserID: "a", Table: 1, Seat: 2}}},
+		"group of one":         {Apart: [][]string{{"a"}}},
+		"group too large":      {Apart: [][]string{{"a", "b", "c"}}},
+		"group fixed together": {Fixed: []models.FixedSeat{{UserID: "a", Table: 1, Seat: 1}, {UserID: "b", Table: 1, Seat: 2}}, Apart: [][]string{{"a", "b"}}},
+		"repeated seed":        {Seeds: []string{"a", "a"}},
+	} {
+		assert.ErrorIs(t, validateSeatingPlan(plan, 16), ErrInvalidSeatingPlan, name)
+	}
+}
+
+func TestSetSeatingPlan(t *testing.T) {
+	service, db := setupBroadcastService(t)
+	db.Exec(`UPDATE tournaments SET status = 'registering', max_players = 16 WHERE id = 't-1'`)
+
+	plan := &models.SeatingPlan{Apart: [][]string{{"a", "b"}}}
+	_, err := service.SetSeatingPlan("t-1", "player", plan)
+	assert.ErrorIs(t, err, ErrNotTournamentCreator)
+
+	_, err = service.SetSeatingPlan("t-1", "director", &models.SeatingPlan{Apart: [][]string{{"a", "b", "c"}}})
+	assert.ErrorIs(t, err, ErrInvalidSeatingPlan)
+
+	_, err = service.SetSeatingPlan("t-1", "director", plan)
+	require.NoError(t, err)
+	tourney, err := service.GetTournament("t-1")
+	require.NoError(t, err)
+	assert.Equal(t, plan, tourney.SeatingPlan)
+
+	_, err = service.SetSeatingPlan("t-1", "director", nil)
+	require.NoError(t, err)
+	tourney, err = service.GetTournament("t-1")
+	require.NoError(t, err)
+	assert.Nil(t, tourney.SeatingPlan)
+
+	db.Exec(`UPDATE tournaments SET status = 'in_progress' WHERE id = 't-1'`)
+	_, err = service.SetSeatingPlan("t-1", "director", plan)
+	assert.ErrorIs(t, err, ErrTournamentAlreadyStarted)
+}
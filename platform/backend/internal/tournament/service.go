@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"time"
 
+	"poker-platform/backend/internal/club"
 	"poker-platform/backend/internal/currency"
+	"poker-platform/backend/internal/entry"
 	"poker-platform/backend/internal/models"
 
 	"github.com/google/uuid"
@@ -18,6 +20,7 @@ import (
 type Service struct {
 	db              *gorm.DB
 	currencyService *currency.Service
+	HandForHand     *HandForHand // Hand-for-hand play of tournaments on the money bubble
 }
 
 // NewService creates a new tournament service
@@ -25,6 +28,7 @@ func NewService(db *gorm.DB, currencyService *currency.Service) *Service {
 	return &Service{
 		db:              db,
 		currencyService: currencyService,
+		HandForHand:     NewHandForHand(),
 	}
 }
 
@@ -35,43 +39,28 @@ func (s *Service) CreateTournament(req models.CreateTournamentRequest, creatorID
 		return nil, err
 	}
 
-	// Get or validate structure
-	var structure models.TournamentStructure
-	if req.StructurePreset != "" {
-		preset, exists := GetStructurePreset(req.StructurePreset)
-		if !exists {
-			return nil, ErrStructureNotFound
-		}
-		structure = preset
-	} else if req.CustomStructure != nil {
-		if err := ValidateStructure(*req.CustomStructure); err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrInvalidStructure, err)
-		}
-		structure = *req.CustomStructure
-	} else {
-		structure = GetDefaultStructure()
+	// Only club owners and managers can create club tournaments
+	if err := club.CheckManager(s.db, req.ClubID, creatorID); err != nil {
+		return nil, err
 	}
 
-	// Get or validate prize structure
-	var prizeStructure models.PrizeStructureConfig
-	if req.PrizeStructurePreset != "" {
-		preset, exists := GetPrizeStructurePreset(req.PrizeStructurePreset)
-		if !exists {
-			return nil, ErrPrizeStructureNotFound
-		}
-		prizeStructure = preset
-	} else if req.CustomPrizeStructure != nil {
-		if err := ValidatePrizeStructure(*req.CustomPrizeStructure); err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrInvalidPrizeStructure, err)
-		}
-		prizeStructure = *req.CustomPrizeStructure
-	} else {
-		prizeStructure = GetDefaultPrizeStructure()
+	if err := validateSatelliteTarget(s.db, req); err != nil {
+		return nil, err
+	}
+
+	// Saved presets are private to the creator
+	if err := s.applySavedPresets(&req, creatorID); err != nil {
+		return nil, err
+	}
+
+	structure, prizeStructure, err := ResolveStructures(req.StructurePreset, req.CustomStructure,
+		req.PrizeStructurePreset, req.CustomPrizeStructure)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate unique tournament code
 	var tournamentCode string
-	var err error
 	for i := 0; i < 10; i++ { // Try up to 10 times
 		tournamentCode, err = GenerateTournamentCode()
 		if err != nil {
@@ -106,30 +95,65 @@ func (s *Service) CreateTournament(req models.CreateTournamentRequest, creatorID
 		autoStartDelay = 300 // 5 minutes default
 	}
 
+	tournamentType := req.TournamentType
+	if tournamentType == "" {
+		tournamentType = models.TournamentTypeScheduled
+	}
+
+	format := req.Format
+	if format == "" {
+		format = models.TournamentFormatStandard
+	}
+
 	// Create tournament
 	tournament := &models.Tournament{
 		ID:                   uuid.New().String(),
 		TournamentCode:       tournamentCode,
 		Name:                 req.Name,
 		CreatorID:            &creatorID,
+		ClubID:               req.ClubID,
+		SatelliteTargetID:    req.SatelliteTargetID,
 		Status:               "registering",
+		TournamentType:       tournamentType,
+		Format:               format,
 		BuyIn:                req.BuyIn,
+		EntryFee:             req.EntryFee,
+		Bounty:               req.Bounty,
 		StartingChips:        req.StartingChips,
 		MaxPlayers:           req.MaxPlayers,
 		MinPlayers:           req.MinPlayers,
 		CurrentPlayers:       0,
 		PrizePool:            0,
+		Guarantee:            req.Guarantee,
 		Structure:            string(structureJSON),
 		PrizeStructure:       string(prizeStructureJSON),
 		StartTime:            req.StartTime,
-		RegistrationClosesAt: nil, // Can be set later
+		RegistrationClosesAt: req.RegistrationClosesAt,
+		EndsAt:               req.EndsAt,
 		AutoStartDelay:       autoStartDelay,
+		UnregisterDeadline:   req.UnregisterDeadline,
+		LateCancelFee:        req.LateCancelFee,
+		EntryRequirements:    req.EntryRequirements,
+		BroadcastDelay:       req.BroadcastDelay,
+		FinalTableBreak:      req.FinalTableBreak,
+		SeatingPlan:          req.SeatingPlan,
 		CurrentLevel:         1,
 		LevelStartedAt:       nil,
 		CreatedAt:            time.Now(),
 	}
 
-	if err := s.db.Create(tournament).Error; err != nil {
+	// The creator is always on the invite list of an invite-only tournament
+	invites := req.InviteList
+	if req.EntryRequirements != nil && req.EntryRequirements.InviteOnly {
+		invites = append(invites, creatorID)
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(tournament).Error; err != nil {
+			return err
+		}
+		return entry.SetInvites(tx, tournament.ID, invites)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -160,44 +184,29 @@ func (s *Service) RegisterPlayer(tournamentID, userID string) error {
 		return err
 	}
 
-	// Validate tournament status
-	if tournament.Status != "registering" {
+	if err := checkRegistration(tx, &tournament, userID); err != nil {
 		tx.Rollback()
-		return ErrTournamentNotRegistering
-	}
-
-	// Check if tournament is full
-	if tournament.CurrentPlayers >= tournament.MaxPlayers {
-		tx.Rollback()
-		return ErrTournamentFull
+		return err
 	}
 
-	// Check if player is already registered
-	var existing models.TournamentPlayer
-	result := tx.Where("tournament_id = ? AND user_id = ?", tournamentID, userID).First(&existing)
-	if result.Error == nil {
+	// A ticket won in a satellite or given as a level reward pays for the entry
+	ctx := context.Background()
+	ticket, err := s.redeemTicket(ctx, tx, &tournament, userID)
+	if err != nil {
 		tx.Rollback()
-		return ErrAlreadyRegistered
+		return fmt.Errorf("failed to use ticket: %w", err)
 	}
 
-	// Deduct buy-in from user using currency service (with validation and audit trail)
-	// CRITICAL: Use DeductChipsWithTx to ensure buy-in deduction is atomic with registration
-	ctx := context.Background()
-	description := fmt.Sprintf("Buy-in for tournament: %s", tournament.Name)
-	if err := s.currencyService.DeductChipsWithTx(
-		ctx,
-		tx,
-		userID,
-		tournament.BuyIn,
-		currency.TxTypeTournamentBuyIn,
-		tournamentID,
-		description,
-	); err != nil {
-		tx.Rollback()
-		if err == currency.ErrInsufficientChips {
-			return ErrInsufficientChips
+	// Deduct buy-in and entry fee from user using currency service (with validation and audit trail)
+	// CRITICAL: Use the same transaction to ensure the deduction is atomic with registration
+	if ticket == nil {
+		if err := s.chargeEntry(ctx, tx, &tournament, userID); err != nil {
+			tx.Rollback()
+			if err == currency.ErrInsufficientChips {
+				return ErrInsufficientChips
+			}
+			return fmt.Errorf("failed to deduct buy-in: %w", err)
 		}
-		return fmt.Errorf("failed to deduct buy-in: %w", err)
 	}
 
 	// Create tournament player entry
@@ -207,31 +216,20 @@ func (s *Service) RegisterPlayer(tournamentID, userID string) error {
 		Position:     nil,
 		Chips:        &tournament.StartingChips,
 		PrizeAmount:  0,
+		Bounty:       tournament.Bounty,
 		RegisteredAt: time.Now(),
 	}
+	if ticket != nil {
+		tournamentPlayer.TicketID = &ticket.ID
+	}
 
 	if err := tx.Create(tournamentPlayer).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	// Update tournament player count and prize pool
-	newPlayerCount := tournament.CurrentPlayers + 1
-	newPrizePool := tournament.PrizePool + tournament.BuyIn
-
-	updates := map[string]interface{}{
-		"current_players": newPlayerCount,
-		"prize_pool":      newPrizePool,
-	}
-
-	// If we just reached min_players and don't have a scheduled start time,
-	// set registration_completed_at for auto-start countdown
-	if newPlayerCount == tournament.MinPlayers && tournament.StartTime == nil && tournament.RegistrationCompletedAt == nil {
-		now := time.Now()
-		updates["registration_completed_at"] = now
-	}
-
-	if err := tx.Model(&tournament).Updates(updates).Error; err != nil {
+	// Count the player and add the buy-in to the prize pool
+	if err := recordEvent(tx, &tournament, EventRegistered, &userID, models.TournamentEventData{}, time.Now()); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -244,8 +242,106 @@ func (s *Service) RegisterPlayer(tournamentID, userID string) error {
 	return nil
 }
 
-// UnregisterPlayer removes a player from a tournament
-func (s *Service) UnregisterPlayer(tournamentID, userID string) error {
+// RegistrationQuote is what registering would do, worked out without
+// registering
+type RegistrationQuote struct {
+	TournamentID   string `json:"tournament_id"`
+	BuyIn          int    `json:"buy_in"`
+	EntryFee       int    `json:"entry_fee"`
+	Total          int    `json:"total"`
+	BalanceAfter   int    `json:"balance_after"`
+	PlayersAfter   int    `json:"players_after"`
+	PrizePoolAfter int    `json:"prize_pool_after"`    // Including any overlay
+	Overlay        int    `json:"overlay,omitempty"`   // What the house would add to reach the guarantee
+	TicketID       string `json:"ticket_id,omitempty"` // The ticket that would pay for the entry
+}
+
+// CheckRegistration runs RegisterPlayer's checks, including the player's
+// balance, and returns the outcome without charging or registering anyone.
+// A later RegisterPlayer can still fail if things change in between.
+func (s *Service) CheckRegistration(tournamentID, userID string) (*RegistrationQuote, error) {
+	var tournament models.Tournament
+	if err := s.db.Where("id = ?", tournamentID).First(&tournament).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrTournamentNotFound
+		}
+		return nil, err
+	}
+	if err := checkRegistration(s.db, &tournament, userID); err != nil {
+		return nil, err
+	}
+
+	quote := &RegistrationQuote{
+		TournamentID: tournamentID,
+		BuyIn:        tournament.BuyIn,
+		EntryFee:     tournament.EntryFee,
+		Total:        tournament.BuyIn + tournament.EntryFee,
+		PlayersAfter: tournament.CurrentPlayers + 1,
+	}
+	quote.PrizePoolAfter, quote.Overlay = GuaranteedPool(&tournament, tournament.PrizePool+poolShare(&tournament))
+	var ticket models.TournamentTicket
+	if err := usableTicket(s.db, &tournament, userID, &ticket); err == nil {
+		quote.TicketID = ticket.ID
+		quote.Total = 0
+	}
+	ctx := context.Background()
+	if quote.Total == 0 {
+		balance, err := s.currencyService.GetBalance(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		quote.BalanceAfter = balance
+		return quote, nil
+	}
+	balanceAfter, err := s.currencyService.CheckDeduct(ctx, userID, quote.Total)
+	if err != nil {
+		if err == currency.ErrInsufficientChips {
+			return nil, ErrInsufficientChips
+		}
+		return nil, fmt.Errorf("failed to check buy-in: %w", err)
+	}
+	quote.BalanceAfter = balanceAfter
+	return quote, nil
+}
+
+// checkRegistration checks that a player may register for a tournament,
+// leaving the charge to the caller
+func checkRegistration(tx *gorm.DB, tournament *models.Tournament, userID string) error {
+	// Validate tournament status
+	if tournament.Status != "registering" {
+		return ErrTournamentNotRegistering
+	}
+
+	// Check if registration has been closed
+	if tournament.RegistrationClosesAt != nil && !time.Now().Before(*tournament.RegistrationClosesAt) {
+		return ErrRegistrationClosed
+	}
+
+	// Check if tournament is full
+	if tournament.CurrentPlayers >= tournament.MaxPlayers {
+		return ErrTournamentFull
+	}
+
+	// Check if player is already registered
+	var existing models.TournamentPlayer
+	result := tx.Where("tournament_id = ? AND user_id = ?", tournament.ID, userID).First(&existing)
+	if result.Error == nil {
+		return ErrAlreadyRegistered
+	}
+
+	// Club tournaments are only open to members
+	if err := club.CheckAccess(tx, tournament.ClubID, userID); err != nil {
+		return err
+	}
+
+	// Check the creator's entry requirements
+	return entry.Check(tx, tournament.EntryRequirements, tournament.ID, userID, time.Now())
+}
+
+// UnregisterPlayer removes a player from a tournament and refunds the buy-in.
+// After the unregistration deadline a late-cancel fee is withheld, which stays
+// in the prize pool; the fee charged is returned.
+func (s *Service) UnregisterPlayer(tournamentID, userID string) (int, error) {
 	// Start transaction
 	tx := s.db.Begin()
 	defer func() {
@@ -254,20 +350,23 @@ func (s *Service) UnregisterPlayer(tournamentID, userID string) error {
 		}
 	}()
 
-	// Get tournament
+	// Get tournament with row-level lock so the count and prize pool stay
+	// consistent with concurrent registrations and the starter
 	var tournament models.Tournament
-	if err := tx.Where("id = ?", tournamentID).First(&tournament).Error; err != nil {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", tournamentID).
+		First(&tournament).Error; err != nil {
 		tx.Rollback()
 		if err == gorm.ErrRecordNotFound {
-			return ErrTournamentNotFound
+			return 0, ErrTournamentNotFound
 		}
-		return err
+		return 0, err
 	}
 
 	// Check if tournament has started
 	if tournament.Status != "registering" {
 		tx.Rollback()
-		return ErrCannotUnregister
+		return 0, ErrCannotUnregister
 	}
 
 	// Get tournament player
@@ -275,59 +374,71 @@ func (s *Service) UnregisterPlayer(tournamentID, userID string) error {
 	if err := tx.Where("tournament_id = ? AND user_id = ?", tournamentID, userID).First(&tournamentPlayer).Error; err != nil {
 		tx.Rollback()
 		if err == gorm.ErrRecordNotFound {
-			return ErrNotRegistered
+			return 0, ErrNotRegistered
 		}
-		return err
+		return 0, err
 	}
 
-	// Refund buy-in to user using currency service (with audit trail)
-	// CRITICAL: Use AddChipsWithTx to ensure refund is atomic with unregistration
-	ctx := context.Background()
-	description := fmt.Sprintf("Refund for tournament: %s", tournament.Name)
-	if err := s.currencyService.AddChipsWithTx(
-		ctx,
-		tx,
-		userID,
-		tournament.BuyIn,
-		currency.TxTypeTournamentRefund,
-		tournamentID,
-		description,
-	); err != nil {
+	// Check the unregistration window
+	fee, err := UnregisterFee(tournament, time.Now())
+	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to refund buy-in: %w", err)
+		return 0, err
 	}
 
-	// Delete tournament player entry
-	if err := tx.Delete(&tournamentPlayer).Error; err != nil {
-		tx.Rollback()
-		return err
+	ctx := context.Background()
+	if tournamentPlayer.TicketID != nil {
+		// A ticket comes back whole, with no late-cancel fee
+		fee = 0
+		if err := s.returnTicket(ctx, tx, &tournament, *tournamentPlayer.TicketID); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	} else {
+		// Refund buy-in and entry fee to user using currency service (with audit trail)
+		// CRITICAL: Use the same transaction to ensure refund is atomic with unregistration
+		description := fmt.Sprintf("Refund for tournament: %s", tournament.Name)
+		if err := s.refundEntry(ctx, tx, &tournament, userID, description); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
 	}
 
-	// Update tournament player count and prize pool
-	newPlayerCount := tournament.CurrentPlayers - 1
-	newPrizePool := tournament.PrizePool - tournament.BuyIn
-
-	updates := map[string]interface{}{
-		"current_players": newPlayerCount,
-		"prize_pool":      newPrizePool,
+	// Charge the late-cancel fee as its own transaction so it shows in the audit trail
+	if fee > 0 {
+		description := fmt.Sprintf("Late-cancel fee for tournament: %s", tournament.Name)
+		if err := s.currencyService.DeductChipsWithTx(
+			ctx,
+			tx,
+			userID,
+			fee,
+			currency.TxTypeTournamentLateCancelFee,
+			tournamentID,
+			description,
+		); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("failed to charge late-cancel fee: %w", err)
+		}
 	}
 
-	// If we drop below min_players, clear the registration_completed_at timestamp
-	if newPlayerCount < tournament.MinPlayers && tournament.RegistrationCompletedAt != nil {
-		updates["registration_completed_at"] = nil
+	// Delete tournament player entry
+	if err := tx.Delete(&tournamentPlayer).Error; err != nil {
+		tx.Rollback()
+		return 0, err
 	}
 
-	if err := tx.Model(&tournament).Updates(updates).Error; err != nil {
+	// Uncount the player and take the buy-in out of the prize pool (the fee stays in the pool)
+	if err := recordEvent(tx, &tournament, EventUnregistered, &userID, models.TournamentEventData{Fee: fee}, time.Now()); err != nil {
 		tx.Rollback()
-		return err
+		return 0, err
 	}
 
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return fee, nil
 }
 
 // CancelTournament cancels a tournament and refunds all players
@@ -370,30 +481,24 @@ func (s *Service) CancelTournament(tournamentID, userID string) error {
 	}
 
 	// Refund all players using currency service (with audit trail)
-	// CRITICAL: Use AddChipsWithTx to ensure all refunds are atomic with tournament cancellation
+	// CRITICAL: Use the same transaction to ensure all refunds are atomic with tournament cancellation
 	ctx := context.Background()
 	for _, player := range players {
 		description := fmt.Sprintf("Refund from cancelled tournament: %s", tournament.Name)
-		if err := s.currencyService.AddChipsWithTx(
-			ctx,
-			tx,
-			player.UserID,
-			tournament.BuyIn,
-			currency.TxTypeTournamentRefund,
-			tournamentID,
-			description,
-		); err != nil {
+		if err := s.refundEntry(ctx, tx, &tournament, player.UserID, description); err != nil {
 			tx.Rollback()
 			return fmt.Errorf("failed to refund player %s: %w", player.UserID, err)
 		}
 	}
 
-	// Update tournament status
-	if err := tx.Model(&tournament).Updates(map[string]interface{}{
-		"status":          "cancelled",
-		"current_players": 0,
-		"prize_pool":      0,
-	}).Error; err != nil {
+	// Satellite tickets to the tournament are paid out in chips
+	if err := s.refundTickets(ctx, tx, &tournament); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Cancel the tournament, emptying it
+	if err := recordEvent(tx, &tournament, EventCancelled, &userID, models.TournamentEventData{}, time.Now()); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -431,9 +536,10 @@ func (s *Service) GetTournamentByCode(code string) (*models.Tournament, error) {
 	return &tournament, nil
 }
 
-// ListTournaments retrieves tournaments with optional filters
+// ListTournaments retrieves public tournaments with optional filters.
+// Club tournaments are listed in their club's lobby instead.
 func (s *Service) ListTournaments(status string, limit, offset int) ([]models.Tournament, error) {
-	query := s.db.Model(&models.Tournament{})
+	query := s.db.Model(&models.Tournament{}).Where("club_id IS NULL")
 
 	if status != "" {
 		query = query.Where("status = ?", status)
@@ -447,6 +553,20 @@ func (s *Service) ListTournaments(status string, limit, offset int) ([]models.To
 	return tournaments, nil
 }
 
+// CanView returns ErrNotClubMember if a club tournament is hidden from the user
+func (s *Service) CanView(tournament *models.Tournament, userID string) error {
+	return club.CheckAccess(s.db, tournament.ClubID, userID)
+}
+
+// LobbyAudience returns the user IDs lobby updates about a tournament may be
+// sent to, or nil if the tournament is public
+func (s *Service) LobbyAudience(tournament *models.Tournament) (map[string]bool, error) {
+	if tournament.ClubID == nil {
+		return nil, nil
+	}
+	return club.MemberIDs(s.db, *tournament.ClubID)
+}
+
 // GetTournamentPlayers retrieves all players registered for a tournament
 func (s *Service) GetTournamentPlayers(tournamentID string) ([]models.TournamentPlayer, error) {
 	var players []models.TournamentPlayer
@@ -491,6 +611,54 @@ func (s *Service) validateCreateRequest(req models.CreateTournamentRequest) erro
 	if req.StartTime != nil && req.StartTime.Before(time.Now()) {
 		return ErrInvalidStartTime
 	}
+	if err := validateDeadlines(req, time.Now()); err != nil {
+		return err
+	}
+	switch req.TournamentType {
+	case "", models.TournamentTypeScheduled:
+	case models.TournamentTypeSitNGo:
+		if req.StartTime != nil {
+			return ErrSitNGoStartTime
+		}
+	default:
+		return ErrInvalidTournamentType
+	}
+	switch req.Format {
+	case "", models.TournamentFormatStandard:
+	case models.TournamentFormatBracket:
+		if req.SeatingPlan != nil {
+			return ErrBracketSeatingPlan
+		}
+	default:
+		return ErrInvalidTournamentFormat
+	}
+	if req.UnregisterDeadline < 0 {
+		return ErrInvalidUnregisterWindow
+	}
+	if req.LateCancelFee < 0 || req.LateCancelFee > req.BuyIn {
+		return ErrInvalidLateCancelFee
+	}
+	if req.EntryFee < 0 || req.EntryFee > req.BuyIn {
+		return ErrInvalidEntryFee
+	}
+	if req.Bounty < 0 || req.Bounty > req.BuyIn {
+		return ErrInvalidBounty
+	}
+	if req.Guarantee < 0 {
+		return ErrInvalidGuarantee
+	}
+	if !validBroadcastDelay(req.BroadcastDelay) {
+		return ErrInvalidBroadcastDelay
+	}
+	if !validFinalTableBreak(req.FinalTableBreak) {
+		return ErrInvalidFinalTableBreak
+	}
+	if err := entry.Validate(req.EntryRequirements, req.InviteList); err != nil {
+		return err
+	}
+	if err := validateSeatingPlan(req.SeatingPlan, req.MaxPlayers); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -523,12 +691,8 @@ func (s *Service) PauseTournament(tournamentID string, pausedBy string) error {
 		return fmt.Errorf("only tournament creator can pause")
 	}
 
-	// Update tournament status
-	now := time.Now()
-	if err := tx.Model(&tournament).Updates(map[string]interface{}{
-		"status":    "paused",
-		"paused_at": now,
-	}).Error; err != nil {
+	// Pause the tournament
+	if err := recordEvent(tx, &tournament, EventPaused, &pausedBy, models.TournamentEventData{}, time.Now()); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -576,21 +740,8 @@ func (s *Service) ResumeTournament(tournamentID string, resumedBy string) error
 		pauseDuration = int(time.Since(*tournament.PausedAt).Seconds())
 	}
 
-	// Update tournament
-	now := time.Now()
-	updates := map[string]interface{}{
-		"status":                 "in_progress",
-		"resumed_at":            now,
-		"total_paused_duration": tournament.TotalPausedDuration + pauseDuration,
-	}
-
-	// Adjust level_started_at to account for pause
-	if tournament.LevelStartedAt != nil {
-		adjustedLevelStart := tournament.LevelStartedAt.Add(time.Duration(pauseDuration) * time.Second)
-		updates["level_started_at"] = adjustedLevelStart
-	}
-
-	if err := tx.Model(&tournament).Updates(updates).Error; err != nil {
+	// Resume the tournament; the level clock skips the pause
+	if err := recordEvent(tx, &tournament, EventResumed, &resumedBy, models.TournamentEventData{PausedFor: pauseDuration}, time.Now()); err != nil {
 		tx.Rollback()
 		return err
 	}
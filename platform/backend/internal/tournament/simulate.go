@@ -0,0 +1,318 @@
+package tournament
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"poker-platform/backend/internal/models"
+)
+
+// Bot behaviors for tournament simulations. Looser bots get all-in more often,
+// which busts players and shortens the tournament.
+const (
+	BotBehaviorTight    = "tight"
+	BotBehaviorStandard = "standard"
+	BotBehaviorLoose    = "loose"
+)
+
+// allInRates is the chance a hand ends in an all-in confrontation while every
+// stack at the table is deep
+var allInRates = map[string]float64{
+	BotBehaviorTight:    0.02,
+	BotBehaviorStandard: 0.04,
+	BotBehaviorLoose:    0.08,
+}
+
+const (
+	defaultSimulationRuns     = 25
+	maxSimulationRuns         = 200
+	defaultHandsPerHour       = 60 // Per table
+	simulationPlayersPerTable = 8  // Matches the seating used when tournaments start
+	maxSimulatedHands         = 20000
+)
+
+// SimulationRequest describes a tournament to simulate. Structures are chosen
+// the same way as when creating a tournament.
+type SimulationRequest struct {
+	Players              int                          `json:"players"`
+	StartingChips        int                          `json:"starting_chips"`
+	StructurePreset      string                       `json:"structure_preset,omitempty"`
+	CustomStructure      *models.TournamentStructure  `json:"custom_structure,omitempty"`
+	PrizeStructurePreset string                       `json:"prize_structure_preset,omitempty"`
+	CustomPrizeStructure *models.PrizeStructureConfig `json:"custom_prize_structure,omitempty"`
+	BotBehavior          string                       `json:"bot_behavior,omitempty"`   // tight, standard (default) or loose
+	HandsPerHour         int                          `json:"hands_per_hour,omitempty"` // Per table, default 60
+	Runs                 int                          `json:"runs,omitempty"`           // Default 25
+	Seed                 int64                        `json:"seed,omitempty"`           // Random when 0
+}
+
+// LevelSimulation is the average state of the field at the start of a blind level
+type LevelSimulation struct {
+	Level            int     `json:"level"`
+	SmallBlind       int     `json:"small_blind"`
+	BigBlind         int     `json:"big_blind"`
+	Ante             int     `json:"ante"`
+	StartsAt         int     `json:"starts_at"`    // Seconds after the tournament starts
+	RunsReached      int     `json:"runs_reached"` // Runs still going when the level started
+	PlayersRemaining float64 `json:"players_remaining"`
+	AverageStack     int     `json:"average_stack"`
+	AverageStackBB   float64 `json:"average_stack_bb"`
+}
+
+// SimulationResult summarises many simulated runs of a tournament
+type SimulationResult struct {
+	Players           int               `json:"players"`
+	PaidPlaces        int               `json:"paid_places"`
+	Runs              int               `json:"runs"`
+	Seed              int64             `json:"seed"`
+	BotBehavior       string            `json:"bot_behavior"`
+	HandsPerHour      int               `json:"hands_per_hour"`
+	ExpectedDuration  int               `json:"expected_duration"` // Median seconds until a winner
+	MinDuration       int               `json:"min_duration"`
+	MaxDuration       int               `json:"max_duration"`
+	BubbleLevel       int               `json:"bubble_level"` // Median level the bubble bursts in; 0 when every player is paid
+	BubbleTime        int               `json:"bubble_time"`  // Median seconds until the bubble bursts
+	RunsPastLastLevel int               `json:"runs_past_last_level"`
+	Levels            []LevelSimulation `json:"levels"`
+}
+
+// simulatedRun is the outcome of one simulated tournament
+type simulatedRun struct {
+	duration      int
+	bubbleLevel   int
+	bubbleTime    int
+	pastLastLevel bool
+	remaining     []int // Players remaining at the start of each level reached
+}
+
+// Simulate plays a tournament through many times with bots in accelerated
+// time, reporting how long it lasts, how deep stacks are at each level and
+// when the bubble bursts. Hands are modelled statistically rather than dealt:
+// blinds and antes go to a random player unless the hand is an all-in,
+// which gets more likely as stacks get short.
+func Simulate(req SimulationRequest) (*SimulationResult, error) {
+	if req.Players < 2 || req.Players > 1000 {
+		return nil, ErrInvalidSimulationPlayers
+	}
+	if req.StartingChips < 100 {
+		return nil, ErrInvalidStartingChips
+	}
+	if req.BotBehavior == "" {
+		req.BotBehavior = BotBehaviorStandard
+	}
+	if _, ok := allInRates[req.BotBehavior]; !ok {
+		return nil, ErrInvalidBotBehavior
+	}
+	if req.HandsPerHour == 0 {
+		req.HandsPerHour = defaultHandsPerHour
+	}
+	if req.HandsPerHour < 10 || req.HandsPerHour > 300 {
+		return nil, ErrInvalidHandsPerHour
+	}
+	if req.Runs == 0 {
+		req.Runs = defaultSimulationRuns
+	}
+	if req.Runs < 1 || req.Runs > maxSimulationRuns {
+		return nil, ErrInvalidSimulationRuns
+	}
+	if req.Seed == 0 {
+		req.Seed = time.Now().UnixNano()
+	}
+
+	structure, prizeStructure, err := ResolveStructures(req.StructurePreset, req.CustomStructure,
+		req.PrizeStructurePreset, req.CustomPrizeStructure)
+	if err != nil {
+		return nil, err
+	}
+
+	paidPlaces := min(len(prizeStructure.Positions), req.Players)
+	rng := rand.New(rand.NewSource(req.Seed))
+
+	runs := make([]simulatedRun, req.Runs)
+	for i := range runs {
+		runs[i] = simulateRun(req, structure.BlindLevels, paidPlaces, rng)
+	}
+
+	result := &SimulationResult{
+		Players:      req.Players,
+		PaidPlaces:   paidPlaces,
+		Runs:         req.Runs,
+		Seed:         req.Seed,
+		BotBehavior:  req.BotBehavior,
+		HandsPerHour: req.HandsPerHour,
+	}
+
+	durations := make([]int, len(runs))
+	bubbleLevels := make([]int, len(runs))
+	bubbleTimes := make([]int, len(runs))
+	for i, run := range runs {
+		durations[i] = run.duration
+		bubbleLevels[i] = run.bubbleLevel
+		bubbleTimes[i] = run.bubbleTime
+		if run.pastLastLevel {
+			result.RunsPastLastLevel++
+		}
+	}
+	sort.Ints(durations)
+	result.ExpectedDuration = median(durations)
+	result.MinDuration = durations[0]
+	result.MaxDuration = durations[len(durations)-1]
+	result.BubbleLevel = median(bubbleLevels)
+	result.BubbleTime = median(bubbleTimes)
+
+	totalChips := req.Players * req.StartingChips
+	startsAt := 0
+	for i, level := range structure.BlindLevels {
+		summary := LevelSimulation{
+			Level:      level.Level,
+			SmallBlind: level.SmallBlind,
+			BigBlind:   level.BigBlind,
+			Ante:       level.Ante,
+			StartsAt:   startsAt,
+		}
+		startsAt += level.Duration
+
+		var players, stacks float64
+		for _, run := range runs {
+			if i < len(run.remaining) {
+				summary.RunsReached++
+				players += float64(run.remaining[i])
+				stacks += float64(totalChips) / float64(run.remaining[i])
+			}
+		}
+		if summary.RunsReached == 0 {
+			break
+		}
+		summary.PlayersRemaining = roundTenth(players / float64(summary.RunsReached))
+		summary.AverageStack = int(stacks / float64(summary.RunsReached))
+		summary.AverageStackBB = roundTenth(float64(summary.AverageStack) / float64(level.BigBlind))
+		result.Levels = append(result.Levels, summary)
+	}
+
+	return result, nil
+}
+
+// simulateRun plays one tournament to a winner. Players are reseated at random
+// every hand, which stands in for table balancing and a moving button.
+func simulateRun(req SimulationRequest, levels []models.BlindLevel, paidPlaces int, rng *rand.Rand) simulatedRun {
+	stacks := make([]int, req.Players)
+	for i := range stacks {
+		stacks[i] = req.StartingChips
+	}
+
+	// Without a bubble (everyone paid) the bubble level stays 0
+	run := simulatedRun{remaining: []int{len(stacks)}}
+	if paidPlaces < len(stacks) {
+		run.bubbleLevel = -1
+	}
+
+	handSeconds := 3600.0 / float64(req.HandsPerHour)
+	levelIndex := 0
+	levelEnds := float64(levels[0].Duration)
+	elapsed := 0.0
+	baseRate := allInRates[req.BotBehavior]
+
+	for hands := 0; len(stacks) > 1 && hands < maxSimulatedHands; hands++ {
+		for !run.pastLastLevel && elapsed >= levelEnds {
+			if levelIndex+1 == len(levels) {
+				// Out of levels: play on at the last level, as the blind manager does
+				run.pastLastLevel = true
+				break
+			}
+			levelIndex++
+			levelEnds += float64(levels[levelIndex].Duration)
+			run.remaining = append(run.remaining, len(stacks))
+		}
+		level := levels[levelIndex]
+
+		rng.Shuffle(len(stacks), func(i, j int) { stacks[i], stacks[j] = stacks[j], stacks[i] })
+		seat := 0
+		for _, size := range DistributePlayersToTables(len(stacks), simulationPlayersPerTable) {
+			simulateHand(stacks[seat:seat+size], level, baseRate, rng)
+			seat += size
+		}
+		elapsed += handSeconds
+
+		alive := stacks[:0]
+		for _, chips := range stacks {
+			if chips > 0 {
+				alive = append(alive, chips)
+			}
+		}
+		stacks = alive
+
+		if run.bubbleLevel < 0 && len(stacks) <= paidPlaces {
+			run.bubbleLevel = level.Level
+			run.bubbleTime = int(elapsed)
+		}
+	}
+
+	run.duration = int(elapsed)
+	run.bubbleLevel = max(run.bubbleLevel, 0)
+	return run
+}
+
+// simulateHand plays one hand at a table. The first two seats post the blinds.
+func simulateHand(table []int, level models.BlindLevel, baseRate float64, rng *rand.Rand) {
+	if len(table) < 2 {
+		return
+	}
+
+	pot := 0
+	post := func(seat, amount int) {
+		amount = min(amount, table[seat])
+		table[seat] -= amount
+		pot += amount
+	}
+	for seat := range table {
+		post(seat, level.Ante)
+	}
+	post(0, level.SmallBlind)
+	post(1, level.BigBlind)
+
+	shortest := 0
+	for seat, chips := range table {
+		if chips < table[shortest] {
+			shortest = seat
+		}
+	}
+
+	// Short stacks shove far more often
+	rate := baseRate
+	switch {
+	case table[shortest] < 10*level.BigBlind:
+		rate += 0.25
+	case table[shortest] < 20*level.BigBlind:
+		rate += 0.08
+	}
+
+	if rng.Float64() >= rate {
+		table[rng.Intn(len(table))] += pot
+		return
+	}
+
+	a := rng.Intn(len(table))
+	if table[shortest] < 10*level.BigBlind && rng.Intn(2) == 0 {
+		a = shortest
+	}
+	b := rng.Intn(len(table) - 1)
+	if b >= a {
+		b++
+	}
+	if rng.Intn(2) == 0 {
+		a, b = b, a
+	}
+
+	// a wins the all-in
+	risk := min(table[a], table[b])
+	table[b] -= risk
+	table[a] += risk + pot
+}
+
+// median returns the middle value of a slice
+func median(values []int) int {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	return sorted[len(sorted)/2]
+}
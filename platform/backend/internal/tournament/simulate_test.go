@@ -0,0 +1,90 @@
+package tournament
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSimulate_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  SimulationRequest
+		want error
+	}{
+		{"too few players", SimulationRequest{Players: 1, StartingChips: 1000}, ErrInvalidSimulationPlayers},
+		{"low starting chips", SimulationRequest{Players: 9, StartingChips: 50}, ErrInvalidStartingChips},
+		{"unknown behavior", SimulationRequest{Players: 9, StartingChips: 1000, BotBehavior: "maniac"}, ErrInvalidBotBehavior},
+		{"too many runs", SimulationRequest{Players: 9, StartingChips: 1000, Runs: 1000}, ErrInvalidSimulationRuns},
+		{"unknown preset", SimulationRequest{Players: 9, StartingChips: 1000, StructurePreset: "nope"}, ErrStructureNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Simulate(tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSimulate_Report(t *testing.T) {
+	req := SimulationRequest{Players: 50, StartingChips: 1500, StructurePreset: "standard", PrizeStructurePreset: "top_5", Runs: 20, Seed: 42}
+	result, err := Simulate(req)
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+
+	if result.PaidPlaces != 5 {
+		t.Errorf("Expected 5 paid places, got %d", result.PaidPlaces)
+	}
+	if result.MinDuration > result.ExpectedDuration || result.ExpectedDuration > result.MaxDuration {
+		t.Errorf("Expected min <= median <= max, got %d/%d/%d", result.MinDuration, result.ExpectedDuration, result.MaxDuration)
+	}
+	if result.BubbleLevel < 1 || result.BubbleTime <= 0 || result.BubbleTime > result.ExpectedDuration {
+		t.Errorf("Unexpected bubble: level %d at %ds", result.BubbleLevel, result.BubbleTime)
+	}
+
+	first := result.Levels[0]
+	if first.RunsReached != 20 || first.PlayersRemaining != 50 || first.AverageStack != 1500 || first.AverageStackBB != 30 {
+		t.Errorf("Unexpected first level: %+v", first)
+	}
+	for i := 1; i < len(result.Levels); i++ {
+		prev, level := result.Levels[i-1], result.Levels[i]
+		if level.RunsReached > prev.RunsReached {
+			t.Errorf("Level %d reached by more runs than level %d", level.Level, prev.Level)
+		}
+		if level.StartsAt != prev.StartsAt+600 {
+			t.Errorf("Expected level %d to start 10 minutes after the previous one", level.Level)
+		}
+	}
+
+	// The same seed gives the same report
+	again, _ := Simulate(req)
+	if !reflect.DeepEqual(result, again) {
+		t.Error("Expected a seeded simulation to be reproducible")
+	}
+}
+
+func TestSimulate_FasterStructuresFinishSooner(t *testing.T) {
+	turbo, err := Simulate(SimulationRequest{Players: 30, StartingChips: 1500, StructurePreset: "turbo", Seed: 7})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	deep, err := Simulate(SimulationRequest{Players: 30, StartingChips: 1500, StructurePreset: "deep_stack", Seed: 7})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if turbo.ExpectedDuration >= deep.ExpectedDuration {
+		t.Errorf("Expected turbo (%ds) to finish before deep stack (%ds)", turbo.ExpectedDuration, deep.ExpectedDuration)
+	}
+}
+
+func TestSimulate_EveryonePaid(t *testing.T) {
+	result, err := Simulate(SimulationRequest{Players: 3, StartingChips: 1000, PrizeStructurePreset: "top_5", Runs: 5, Seed: 1})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if result.PaidPlaces != 3 || result.BubbleLevel != 0 || result.BubbleTime != 0 {
+		t.Errorf("Expected no bubble when everyone is paid, got %+v", result)
+	}
+}
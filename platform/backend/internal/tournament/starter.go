@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Starter manages tournament start conditions and initialization
@@ -80,6 +81,11 @@ func (s *Starter) checkTournaments() {
 
 // shouldStartTournament checks if a tournament should start
 func (s *Starter) shouldStartTournament(tournament models.Tournament, now time.Time) bool {
+	// A sit & go ignores start times and countdowns and starts when full
+	if tournament.TournamentType == models.TournamentTypeSitNGo {
+		return tournament.CurrentPlayers >= tournament.MaxPlayers
+	}
+
 	// Check if scheduled start time is reached
 	if tournament.StartTime != nil && !tournament.StartTime.After(now) {
 		if tournament.CurrentPlayers >= tournament.MinPlayers {
@@ -89,8 +95,15 @@ func (s *Starter) shouldStartTournament(tournament models.Tournament, now time.T
 			tournament.ID, tournament.CurrentPlayers, tournament.MinPlayers)
 	}
 
-	// Check if max players reached (immediate start)
+	// Check if max players reached (immediate start). When the creator set an
+	// unregistration deadline, players keep their free unregistration window
+	// until it passes.
 	if tournament.CurrentPlayers >= tournament.MaxPlayers {
+		if tournament.UnregisterDeadline > 0 {
+			if deadline := UnregisterDeadline(tournament); deadline != nil && now.Before(*deadline) {
+				return false
+			}
+		}
 		return true
 	}
 
@@ -106,6 +119,32 @@ func (s *Starter) shouldStartTournament(tournament models.Tournament, now time.T
 	return false
 }
 
+// StartIfFull starts a sit & go as soon as its last seat is taken, rather
+// than waiting for the next check. It reports whether the tournament started.
+func (s *Starter) StartIfFull(tournamentID string) (bool, error) {
+	var tournament models.Tournament
+	if err := s.db.Where("id = ?", tournamentID).First(&tournament).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, ErrTournamentNotFound
+		}
+		return false, err
+	}
+
+	if tournament.Status != "registering" || tournament.TournamentType != models.TournamentTypeSitNGo ||
+		!s.shouldStartTournament(tournament, time.Now()) {
+		return false, nil
+	}
+
+	if err := s.StartTournament(tournamentID); err != nil {
+		// The periodic check may have started it first
+		if err == ErrTournamentAlreadyStarted {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // StartTournament starts a tournament
 func (s *Starter) StartTournament(tournamentID string) error {
 	// Start transaction
@@ -116,9 +155,12 @@ func (s *Starter) StartTournament(tournamentID string) error {
 		}
 	}()
 
-	// Get tournament
+	// Get tournament with row-level lock so late unregistrations cannot
+	// race the start
 	var tournament models.Tournament
-	if err := tx.Where("id = ?", tournamentID).First(&tournament).Error; err != nil {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", tournamentID).
+		First(&tournament).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -135,16 +177,7 @@ func (s *Starter) StartTournament(tournamentID string) error {
 		return ErrNotEnoughPlayers
 	}
 
-	// Update tournament status to 'starting'
 	now := time.Now()
-	if err := tx.Model(&tournament).Updates(map[string]interface{}{
-		"status":           "starting",
-		"started_at":       now,
-		"level_started_at": now,
-	}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
 
 	// Get all registered players
 	var players []models.TournamentPlayer
@@ -153,13 +186,6 @@ func (s *Starter) StartTournament(tournamentID string) error {
 		return err
 	}
 
-	// Assign players to tables
-	tableAssignments, err := s.assignPlayersToTables(players, 8) // Max 8 players per table
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-
 	// Parse tournament structure to get first blind level
 	var structure models.TournamentStructure
 	if err := json.Unmarshal([]byte(tournament.Structure), &structure); err != nil {
@@ -174,6 +200,50 @@ func (s *Starter) StartTournament(tournamentID string) error {
 
 	firstLevel := structure.BlindLevels[0]
 
+	// A bracket draws its first round of heads-up matches instead
+	var tableCount int
+	var err error
+	if tournament.Format == models.TournamentFormatBracket {
+		if tableCount, err = seatBracket(tx, &tournament, players, firstLevel, now); err != nil {
+			tx.Rollback()
+			return err
+		}
+	} else if tableCount, err = s.seatTables(tx, &tournament, players, firstLevel, now); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Start the tournament, closing registration and unregistration
+	if err := recordEvent(tx, &tournament, EventStarted, nil, models.TournamentEventData{}, now); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	log.Printf("Tournament %s started with %d tables, %d players",
+		tournamentID, tableCount, len(players))
+
+	// Call the callback if set
+	if s.onStartCallback != nil {
+		s.onStartCallback(tournamentID)
+	}
+
+	return nil
+}
+
+// seatTables assigns the players of a starting tournament to tables and
+// creates them, returning how many it created
+func (s *Starter) seatTables(tx *gorm.DB, tournament *models.Tournament, players []models.TournamentPlayer, firstLevel models.BlindLevel, now time.Time) (int, error) {
+	// Assign players to tables
+	tableAssignments, err := s.assignPlayersToTables(players, tournament.SeatingPlan, TablePlayers)
+	if err != nil {
+		return 0, err
+	}
+
 	// Create tables for each assignment
 	for tableNum, assignment := range tableAssignments {
 		tableName := fmt.Sprintf("%s - Table %d", tournament.Name, tableNum+1)
@@ -188,19 +258,21 @@ func (s *Starter) StartTournament(tournamentID string) error {
 			Status:       "waiting",
 			SmallBlind:   firstLevel.SmallBlind,
 			BigBlind:     firstLevel.BigBlind,
-			MaxPlayers:   8,
+			MaxPlayers:   TablePlayers,
 			MinBuyIn:     nil,
 			MaxBuyIn:     nil,
 			CreatedAt:    now,
 		}
 
 		if err := tx.Create(table).Error; err != nil {
-			tx.Rollback()
-			return err
+			return 0, err
 		}
 
 		// Create table seats for assigned players
 		for seatNum, playerID := range assignment {
+			if playerID == "" {
+				continue
+			}
 			seat := &models.TableSeat{
 				TableID:    table.ID,
 				UserID:     playerID,
@@ -211,65 +283,35 @@ func (s *Starter) StartTournament(tournamentID string) error {
 			}
 
 			if err := tx.Create(seat).Error; err != nil {
-				tx.Rollback()
-				return err
+				return 0, err
 			}
 		}
 	}
-
-	// Update tournament status to 'in_progress'
-	if err := tx.Model(&tournament).Update("status", "in_progress").Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return err
-	}
-
-	log.Printf("Tournament %s started with %d tables, %d players",
-		tournamentID, len(tableAssignments), len(players))
-
-	// Call the callback if set
-	if s.onStartCallback != nil {
-		s.onStartCallback(tournamentID)
-	}
-
-	return nil
+	return len(tableAssignments), nil
 }
 
-// assignPlayersToTables assigns players to tables with randomized seating
-// Returns a map of tableIndex -> []playerIDs (with seat positions as array indices)
-func (s *Starter) assignPlayersToTables(players []models.TournamentPlayer, maxPlayersPerTable int) (map[int][]string, error) {
+// assignPlayersToTables assigns players to tables following the
+// tournament's seating plan, seating everyone it leaves out at random.
+// Returns a map of tableIndex -> []playerIDs (with seat positions as array
+// indices, empty for open seats)
+func (s *Starter) assignPlayersToTables(players []models.TournamentPlayer, plan *models.SeatingPlan, maxPlayersPerTable int) (map[int][]string, error) {
 	if len(players) == 0 {
 		return nil, fmt.Errorf("no players to assign")
 	}
 
-	// Shuffle players randomly
-	shuffled := make([]models.TournamentPlayer, len(players))
-	copy(shuffled, players)
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
-
-	// Calculate table distribution
-	distribution := DistributePlayersToTables(len(players), maxPlayersPerTable)
-
-	assignments := make(map[int][]string)
-	playerIndex := 0
-
-	for tableIndex, playerCount := range distribution {
-		tableAssignment := make([]string, playerCount)
-
-		for seatNum := 0; seatNum < playerCount; seatNum++ {
-			tableAssignment[seatNum] = shuffled[playerIndex].UserID
-			playerIndex++
-		}
-
-		assignments[tableIndex] = tableAssignment
+	userIDs := make([]string, len(players))
+	for i, player := range players {
+		userIDs[i] = player.UserID
+	}
+	seated, err := seatPlayers(plan, userIDs, maxPlayersPerTable, rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err != nil {
+		return nil, err
 	}
 
+	assignments := make(map[int][]string, len(seated))
+	for tableIndex, seats := range seated {
+		assignments[tableIndex] = seats
+	}
 	return assignments, nil
 }
 
@@ -0,0 +1,53 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldStartTournament_SitNGo(t *testing.T) {
+	starter := &Starter{}
+	now := time.Now()
+	past := now.Add(-time.Hour)
+
+	// Neither a passed start time nor an expired countdown starts a sit & go
+	sng := models.Tournament{
+		TournamentType:          models.TournamentTypeSitNGo,
+		MinPlayers:              2,
+		MaxPlayers:              6,
+		CurrentPlayers:          5,
+		StartTime:               &past,
+		RegistrationCompletedAt: &past,
+	}
+	assert.False(t, starter.shouldStartTournament(sng, now))
+
+	sng.CurrentPlayers = 6
+	sng.UnregisterDeadline = 600
+	assert.True(t, starter.shouldStartTournament(sng, now))
+
+	// A scheduled tournament in the same state starts on its countdown
+	scheduled := sng
+	scheduled.TournamentType = models.TournamentTypeScheduled
+	scheduled.CurrentPlayers = 5
+	assert.True(t, starter.shouldStartTournament(scheduled, now))
+}
+
+func TestValidateCreateRequest_TournamentType(t *testing.T) {
+	s := &Service{}
+	req := models.CreateTournamentRequest{Name: "SNG", BuyIn: 100, StartingChips: 1000, MaxPlayers: 6, MinPlayers: 2}
+
+	req.TournamentType = models.TournamentTypeSitNGo
+	assert.NoError(t, s.validateCreateRequest(req))
+
+	start := time.Now().Add(time.Hour)
+	req.StartTime = &start
+	assert.ErrorIs(t, s.validateCreateRequest(req), ErrSitNGoStartTime)
+
+	req.StartTime = nil
+	req.TournamentType = "turbo"
+	assert.ErrorIs(t, s.validateCreateRequest(req), ErrInvalidTournamentType)
+}
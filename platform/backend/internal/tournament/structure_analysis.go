@@ -0,0 +1,127 @@
+package tournament
+
+import (
+	"fmt"
+	"time"
+
+	"poker-platform/backend/internal/models"
+)
+
+// Structure warning codes
+const (
+	WarnShortStartingStack = "SHORT_STARTING_STACK"
+	WarnSteepJump          = "STEEP_JUMP"
+	WarnShortLevel         = "SHORT_LEVEL"
+	WarnLargeAnte          = "LARGE_ANTE"
+	WarnLevelNumbering     = "LEVEL_NUMBERING"
+	WarnStructureTooShort  = "STRUCTURE_TOO_SHORT"
+)
+
+// Thresholds for structure warnings
+const (
+	minStartingStackBB = 20  // Starting stacks below this many big blinds leave little play
+	steepJumpRatio     = 2.0 // Big blind increases above this ratio are steep
+	minLevelDuration   = 120 // Seconds
+	maxAnteRatio       = 0.5 // Antes above this fraction of the big blind dominate the pot
+	finalLevelStackBB  = 10  // A starting stack still this deep at the last level may outlast the structure
+)
+
+// StructureWarning is a problem with a structure that does not make it invalid
+type StructureWarning struct {
+	Code    string `json:"code"`
+	Level   int    `json:"level,omitempty"` // 0 when the warning is about the whole structure
+	Message string `json:"message"`
+}
+
+// LevelAnalysis is one blind level with its start time and the starting stack in big blinds
+type LevelAnalysis struct {
+	Level      int     `json:"level"`
+	SmallBlind int     `json:"small_blind"`
+	BigBlind   int     `json:"big_blind"`
+	Ante       int     `json:"ante"`
+	Duration   int     `json:"duration"`
+	StartsAt   int     `json:"starts_at"` // Seconds after the tournament starts
+	StackBB    float64 `json:"stack_bb"`  // Starting stack in big blinds
+	Increase   float64 `json:"increase"`  // Big blind increase over the previous level, in percent
+}
+
+// StructureAnalysis is the result of checking a structure while it is being designed
+type StructureAnalysis struct {
+	Valid         bool               `json:"valid"`
+	Error         string             `json:"error,omitempty"`
+	TotalDuration int                `json:"total_duration"` // Seconds until the last level ends, breaks included
+	Levels        []LevelAnalysis    `json:"levels"`
+	Warnings      []StructureWarning `json:"warnings"`
+}
+
+// AnalyzeStructure validates a structure and estimates how it plays for a
+// starting stack: when each level starts, how deep the starting stack is at
+// each level and which levels look like mistakes
+func AnalyzeStructure(structure models.TournamentStructure, startingChips int) StructureAnalysis {
+	analysis := StructureAnalysis{
+		Valid:    true,
+		Levels:   []LevelAnalysis{},
+		Warnings: []StructureWarning{},
+	}
+	if err := ValidateStructure(structure); err != nil {
+		analysis.Valid = false
+		analysis.Error = err.Error()
+	}
+
+	warn := func(code string, level int, format string, args ...interface{}) {
+		analysis.Warnings = append(analysis.Warnings, StructureWarning{Code: code, Level: level, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for i, level := range structure.BlindLevels {
+		la := LevelAnalysis{
+			Level:      level.Level,
+			SmallBlind: level.SmallBlind,
+			BigBlind:   level.BigBlind,
+			Ante:       level.Ante,
+			Duration:   level.Duration,
+			StartsAt:   analysis.TotalDuration,
+		}
+		analysis.TotalDuration += max(level.Duration, 0)
+		if i < len(structure.BlindLevels)-1 {
+			analysis.TotalDuration += int(BreakAfter(structure, i+1) / time.Second)
+		}
+
+		if level.BigBlind > 0 {
+			la.StackBB = roundTenth(float64(startingChips) / float64(level.BigBlind))
+		}
+		if level.Level != i+1 {
+			warn(WarnLevelNumbering, level.Level, "Level %d is numbered %d", i+1, level.Level)
+		}
+		if level.Duration > 0 && level.Duration < minLevelDuration {
+			warn(WarnShortLevel, level.Level, "Level %d lasts only %d seconds", level.Level, level.Duration)
+		}
+		if level.BigBlind > 0 && float64(level.Ante) > float64(level.BigBlind)*maxAnteRatio {
+			warn(WarnLargeAnte, level.Level, "The ante at level %d is more than half the big blind", level.Level)
+		}
+
+		if i > 0 {
+			prev := structure.BlindLevels[i-1]
+			if prev.BigBlind > 0 && level.BigBlind > prev.BigBlind {
+				ratio := float64(level.BigBlind) / float64(prev.BigBlind)
+				la.Increase = roundTenth((ratio - 1) * 100)
+				if ratio > steepJumpRatio {
+					warn(WarnSteepJump, level.Level, "The big blind goes up %.0f%% from level %d to level %d",
+						la.Increase, prev.Level, level.Level)
+				}
+			}
+		}
+
+		analysis.Levels = append(analysis.Levels, la)
+	}
+
+	if len(analysis.Levels) > 0 {
+		if first := analysis.Levels[0]; first.BigBlind > 0 && first.StackBB < minStartingStackBB {
+			warn(WarnShortStartingStack, first.Level, "Players start with only %.1f big blinds", first.StackBB)
+		}
+		if last := analysis.Levels[len(analysis.Levels)-1]; last.BigBlind > 0 && last.StackBB > finalLevelStackBB {
+			warn(WarnStructureTooShort, 0, "A starting stack is still %.1f big blinds at the last level, so play may continue past the structure", last.StackBB)
+		}
+	}
+
+	return analysis
+}
@@ -0,0 +1,90 @@
+package tournament
+
+import (
+	"testing"
+
+	"poker-platform/backend/internal/models"
+)
+
+func warningCodes(analysis StructureAnalysis) map[string]int {
+	codes := make(map[string]int)
+	for _, w := range analysis.Warnings {
+		codes[w.Code] = w.Level
+	}
+	return codes
+}
+
+func TestAnalyzeStructure_Levels(t *testing.T) {
+	analysis := AnalyzeStructure(StandardStructure, 10000)
+	if !analysis.Valid {
+		t.Fatalf("Expected the standard structure to be valid: %s", analysis.Error)
+	}
+	if analysis.TotalDuration != 18*600 {
+		t.Errorf("Expected total duration %d, got %d", 18*600, analysis.TotalDuration)
+	}
+
+	first, second := analysis.Levels[0], analysis.Levels[1]
+	if first.StartsAt != 0 || first.StackBB != 200 {
+		t.Errorf("Unexpected first level: %+v", first)
+	}
+	if second.StartsAt != 600 || second.StackBB != 100 || second.Increase != 100 {
+		t.Errorf("Unexpected second level: %+v", second)
+	}
+	if len(analysis.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %+v", analysis.Warnings)
+	}
+}
+
+func TestAnalyzeStructure_Warnings(t *testing.T) {
+	structure := models.TournamentStructure{
+		BlindLevels: []models.BlindLevel{
+			{Level: 1, SmallBlind: 50, BigBlind: 100, Duration: 600},
+			{Level: 2, SmallBlind: 150, BigBlind: 300, Ante: 200, Duration: 60},
+			{Level: 4, SmallBlind: 200, BigBlind: 400, Duration: 600},
+		},
+	}
+
+	analysis := AnalyzeStructure(structure, 1500)
+	if !analysis.Valid {
+		t.Fatalf("Expected structure with warnings to be valid: %s", analysis.Error)
+	}
+
+	codes := warningCodes(analysis)
+	expected := map[string]int{
+		WarnShortStartingStack: 1,
+		WarnSteepJump:          2,
+		WarnShortLevel:         2,
+		WarnLargeAnte:          2,
+		WarnLevelNumbering:     4,
+	}
+	for code, level := range expected {
+		if got, ok := codes[code]; !ok || got != level {
+			t.Errorf("Expected %s warning at level %d, got %+v", code, level, analysis.Warnings)
+		}
+	}
+	if _, ok := codes[WarnStructureTooShort]; ok {
+		t.Error("Expected no STRUCTURE_TOO_SHORT warning when the last level is under 10 big blinds")
+	}
+
+	deep := AnalyzeStructure(structure, 100000)
+	if _, ok := warningCodes(deep)[WarnStructureTooShort]; !ok {
+		t.Error("Expected STRUCTURE_TOO_SHORT when a starting stack is still deep at the last level")
+	}
+}
+
+func TestAnalyzeStructure_Invalid(t *testing.T) {
+	structure := models.TournamentStructure{
+		BlindLevels: []models.BlindLevel{
+			{Level: 1, SmallBlind: 50, BigBlind: 100, Duration: 600},
+			{Level: 2, SmallBlind: 25, BigBlind: 50, Duration: 600},
+		},
+	}
+
+	analysis := AnalyzeStructure(structure, 10000)
+	if analysis.Valid || analysis.Error != ErrBlindsNotIncreasing.Error() {
+		t.Errorf("Expected invalid structure with %q, got %+v", ErrBlindsNotIncreasing, analysis)
+	}
+	if len(analysis.Levels) != 2 {
+		t.Errorf("Expected levels to still be analysed, got %d", len(analysis.Levels))
+	}
+}
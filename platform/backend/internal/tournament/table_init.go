@@ -1,6 +1,7 @@
 package tournament
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 
@@ -40,6 +41,19 @@ func (ti *TableInitializer) GetTableSeats(tableID string) ([]models.TableSeat, e
 	return seats, nil
 }
 
+// currentAnte returns the ante of a tournament's current blind level
+func currentAnte(tournament models.Tournament) int {
+	var structure models.TournamentStructure
+	if err := json.Unmarshal([]byte(tournament.Structure), &structure); err != nil {
+		return 0
+	}
+	levelIndex := tournament.CurrentLevel - 1
+	if levelIndex < 0 || levelIndex >= len(structure.BlindLevels) {
+		return 0
+	}
+	return structure.BlindLevels[levelIndex].Ante
+}
+
 // BuildEngineTable creates a poker engine table from database models
 func (ti *TableInitializer) BuildEngineTable(table models.Table, seats []models.TableSeat) (*pokerModels.Table, error) {
 	if len(seats) == 0 {
@@ -49,9 +63,11 @@ func (ti *TableInitializer) BuildEngineTable(table models.Table, seats []models.
 	// Get tournament to fetch starting chips
 	var tournament models.Tournament
 	startingChips := 0
+	ante := 0
 	if table.TournamentID != nil {
 		if err := ti.db.Where("id = ?", *table.TournamentID).First(&tournament).Error; err == nil {
 			startingChips = tournament.StartingChips
+			ante = currentAnte(tournament)
 		} else {
 			log.Printf("Warning: could not fetch tournament %s: %v", *table.TournamentID, err)
 		}
@@ -59,13 +75,16 @@ func (ti *TableInitializer) BuildEngineTable(table models.Table, seats []models.
 
 	// Create table configuration
 	config := pokerModels.TableConfig{
-		SmallBlind:     table.SmallBlind,
-		BigBlind:       table.BigBlind,
-		MaxPlayers:     table.MaxPlayers,
-		MinBuyIn:       0,  // Not used in tournaments
-		MaxBuyIn:       0,  // Not used in tournaments
-		StartingChips:  startingChips,
-		ActionTimeout:  30, // 30 seconds default
+		SmallBlind:      table.SmallBlind,
+		BigBlind:        table.BigBlind,
+		Ante:            ante,
+		MaxPlayers:      table.MaxPlayers,
+		MinBuyIn:        0,  // Not used in tournaments
+		MaxBuyIn:        0,  // Not used in tournaments
+		StartingChips:   startingChips,
+		ActionTimeout:   30, // 30 seconds default
+		TimeBank:        60, // reserve seconds per player
+		DisconnectGrace: 60, // first turn after a disconnect
 	}
 
 	// Create engine table
@@ -0,0 +1,50 @@
+package tournament
+
+import (
+	"time"
+
+	"poker-platform/backend/internal/models"
+)
+
+// ExpectedStartTime returns when a registering tournament is due to start:
+// its scheduled start time, or the end of the auto-start countdown once
+// minimum players is reached. Returns nil when the start is not known yet,
+// which for a sit & go is until it fills up.
+func ExpectedStartTime(tournament models.Tournament) *time.Time {
+	if tournament.TournamentType == models.TournamentTypeSitNGo {
+		return nil
+	}
+	if tournament.StartTime != nil {
+		return tournament.StartTime
+	}
+	if tournament.RegistrationCompletedAt != nil {
+		start := tournament.RegistrationCompletedAt.Add(time.Duration(tournament.AutoStartDelay) * time.Second)
+		return &start
+	}
+	return nil
+}
+
+// UnregisterDeadline returns when free unregistration closes, or nil if it
+// is still open until a start time that is not known yet
+func UnregisterDeadline(tournament models.Tournament) *time.Time {
+	start := ExpectedStartTime(tournament)
+	if start == nil {
+		return nil
+	}
+	deadline := start.Add(-time.Duration(tournament.UnregisterDeadline) * time.Second)
+	return &deadline
+}
+
+// UnregisterFee returns the late-cancel fee a player pays to unregister at now.
+// After the deadline it returns ErrUnregisterDeadlinePassed unless the
+// tournament allows late unregistration for a fee.
+func UnregisterFee(tournament models.Tournament, now time.Time) (int, error) {
+	deadline := UnregisterDeadline(tournament)
+	if deadline == nil || now.Before(*deadline) {
+		return 0, nil
+	}
+	if tournament.LateCancelFee <= 0 {
+		return 0, ErrUnregisterDeadlinePassed
+	}
+	return min(tournament.LateCancelFee, tournament.BuyIn), nil
+}
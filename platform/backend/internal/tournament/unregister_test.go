@@ -0,0 +1,60 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"poker-platform/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedStartTime(t *testing.T) {
+	now := time.Now()
+
+	scheduled := now.Add(time.Hour)
+	tournament := models.Tournament{StartTime: &scheduled}
+	assert.Equal(t, scheduled, *ExpectedStartTime(tournament))
+
+	tournament = models.Tournament{RegistrationCompletedAt: &now, AutoStartDelay: 300}
+	assert.Equal(t, now.Add(5*time.Minute), *ExpectedStartTime(tournament))
+
+	assert.Nil(t, ExpectedStartTime(models.Tournament{AutoStartDelay: 300}))
+
+	// A sit & go starts when full, whatever the countdown says
+	tournament = models.Tournament{TournamentType: models.TournamentTypeSitNGo, RegistrationCompletedAt: &now, AutoStartDelay: 300}
+	assert.Nil(t, ExpectedStartTime(tournament))
+}
+
+func TestUnregisterFee(t *testing.T) {
+	now := time.Now()
+	start := now.Add(10 * time.Minute)
+
+	t.Run("free before deadline", func(t *testing.T) {
+		tournament := models.Tournament{BuyIn: 100, StartTime: &start, UnregisterDeadline: 300, LateCancelFee: 20}
+		fee, err := UnregisterFee(tournament, now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, fee)
+	})
+
+	t.Run("fee after deadline", func(t *testing.T) {
+		tournament := models.Tournament{BuyIn: 100, StartTime: &start, UnregisterDeadline: 900, LateCancelFee: 20}
+		fee, err := UnregisterFee(tournament, now)
+		require.NoError(t, err)
+		assert.Equal(t, 20, fee)
+	})
+
+	t.Run("blocked after deadline without fee", func(t *testing.T) {
+		tournament := models.Tournament{BuyIn: 100, StartTime: &start, UnregisterDeadline: 900}
+		_, err := UnregisterFee(tournament, now)
+		assert.ErrorIs(t, err, ErrUnregisterDeadlinePassed)
+	})
+
+	t.Run("open until start is known", func(t *testing.T) {
+		tournament := models.Tournament{BuyIn: 100, UnregisterDeadline: 900, AutoStartDelay: 300}
+		fee, err := UnregisterFee(tournament, now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, fee)
+	})
+}
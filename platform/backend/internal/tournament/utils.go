@@ -2,6 +2,7 @@ package tournament
 
 import (
 	"crypto/rand"
+	"math"
 	"math/big"
 	"strings"
 )
@@ -185,3 +186,8 @@ func IsOnTheBubble(playerCount int, prizePositions int) bool {
 func IsInTheMoney(position int, prizePositions int) bool {
 	return position <= prizePositions
 }
+
+// roundTenth rounds to one decimal place
+func roundTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -6,6 +6,11 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"poker-platform/backend/internal/ids"
+
+	pokerModels "poker-engine/models"
 )
 
 // Common validation errors
@@ -14,6 +19,8 @@ var (
 	ErrInvalidUsername    = errors.New("invalid username format")
 	ErrWeakPassword       = errors.New("password too weak")
 	ErrInvalidUUID        = errors.New("invalid UUID format")
+	ErrInvalidULID        = errors.New("invalid ULID format")
+	ErrInvalidID          = errors.New("invalid ID format")
 	ErrInvalidRange       = errors.New("value out of valid range")
 	ErrInvalidEnum        = errors.New("invalid enum value")
 	ErrInvalidString      = errors.New("invalid string format")
@@ -117,6 +124,30 @@ func ValidateUUID(uuid string) error {
 	return nil
 }
 
+// ValidateULID validates ULID format, used by hands, game events and chip
+// transactions created since IDs moved to ULIDs
+func ValidateULID(ulid string) error {
+	if ulid == "" {
+		return errors.New("ULID is required")
+	}
+	if !ids.IsULID(ulid) {
+		return ErrInvalidULID
+	}
+	return nil
+}
+
+// ValidateID validates an ID that may be either a UUID or a ULID, for
+// entities whose older rows still have UUIDs
+func ValidateID(id string) error {
+	if id == "" {
+		return errors.New("ID is required")
+	}
+	if !uuidRegex.MatchString(id) && !ids.IsULID(id) {
+		return ErrInvalidID
+	}
+	return nil
+}
+
 // ValidateIntRange validates integer is within range
 func ValidateIntRange(value, min, max int, fieldName string) error {
 	if value < min || value > max {
@@ -151,12 +182,14 @@ func ValidateEnum(value string, allowed []string, fieldName string) error {
 	return fmt.Errorf("%w: %s must be one of %v", ErrInvalidEnum, fieldName, allowed)
 }
 
-// ValidateStringLength validates string length
+// ValidateStringLength validates string length in characters, so names in
+// any script get the same room
 func ValidateStringLength(value string, minLen, maxLen int, fieldName string) error {
-	if len(value) < minLen {
+	length := utf8.RuneCountInString(value)
+	if length < minLen {
 		return fmt.Errorf("%w: %s must be at least %d characters", ErrStringTooShort, fieldName, minLen)
 	}
-	if len(value) > maxLen {
+	if length > maxLen {
 		return fmt.Errorf("%w: %s must be at most %d characters", ErrStringTooLong, fieldName, maxLen)
 	}
 	return nil
@@ -220,7 +253,7 @@ func ValidateSafeString(input string, minLen, maxLen int, fieldName string) (str
 }
 
 // GameAction validators
-var ValidGameActions = []string{"fold", "check", "call", "raise", "allin"}
+var ValidGameActions = []string{"fold", "check", "call", "raise", "allin", "straddle"}
 
 // ValidateGameAction validates poker game action
 func ValidateGameAction(action string) error {
@@ -242,13 +275,14 @@ func ValidateGameActionAmount(action string, amount int) error {
 
 // Table/Tournament validators
 
-// ValidateTableName validates poker table name
+// ValidateTableName validates poker table name. Letters from any language
+// are allowed; control characters are not.
 func ValidateTableName(name string) error {
 	sanitized, err := ValidateSafeString(name, 1, 100, "table name")
 	if err != nil {
 		return err
 	}
-	if sanitized != name {
+	if sanitized != name || hasControlCharacters(name) {
 		return errors.New("table name contains invalid characters")
 	}
 	return nil
@@ -272,9 +306,9 @@ func ValidateBlinds(smallBlind, bigBlind int) error {
 	return nil
 }
 
-// ValidateMaxPlayers validates max players count
+// ValidateMaxPlayers validates max players count (heads-up through 10-max)
 func ValidateMaxPlayers(maxPlayers int) error {
-	return ValidateIntRange(maxPlayers, 2, 10, "max players")
+	return ValidateIntRange(maxPlayers, pokerModels.MinSeats, pokerModels.MaxSeats, "max players")
 }
 
 // ValidateBuyIn validates buy-in amount
@@ -303,18 +337,49 @@ func ValidateBuyInRange(minBuyIn, maxBuyIn int) error {
 	return nil
 }
 
-// ValidateTournamentName validates tournament name
+// ValidateTournamentName validates tournament name, like ValidateTableName
 func ValidateTournamentName(name string) error {
 	sanitized, err := ValidateSafeString(name, 1, 100, "tournament name")
 	if err != nil {
 		return err
 	}
-	if sanitized != name {
+	if sanitized != name || hasControlCharacters(name) {
 		return errors.New("tournament name contains invalid characters")
 	}
 	return nil
 }
 
+// MaxChatMessageLength is the longest table chat message, in characters
+const MaxChatMessageLength = 200
+
+// ValidateChatMessage validates a table chat message and returns it trimmed.
+// Unlike names, chat may hold quotes and SQL keywords, so only markup is
+// refused.
+func ValidateChatMessage(text string) (string, error) {
+	sanitized := SanitizeString(text)
+	if err := ValidateStringLength(sanitized, 1, MaxChatMessageLength, "message"); err != nil {
+		return "", err
+	}
+	if hasControlCharacters(sanitized) {
+		return "", errors.New("message contains invalid characters")
+	}
+	if err := CheckXSS(sanitized); err != nil {
+		return "", fmt.Errorf("message: %w", err)
+	}
+	return sanitized, nil
+}
+
+// hasControlCharacters reports whether a name holds characters that are
+// never shown, such as line breaks or bidirectional overrides
+func hasControlCharacters(name string) bool {
+	for _, r := range name {
+		if unicode.IsControl(r) || unicode.Is(unicode.Bidi_Control, r) {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateTournamentPlayers validates min/max player counts
 func ValidateTournamentPlayers(minPlayers, maxPlayers int) error {
 	if err := ValidateIntRange(minPlayers, 2, 1000, "min players"); err != nil {
@@ -110,6 +110,53 @@ func TestValidateUUID(t *testing.T) {
 	}
 }
 
+func TestValidateULID(t *testing.T) {
+	tests := []struct {
+		name    string
+		ulid    string
+		wantErr bool
+	}{
+		{"Valid ULID", "01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+		{"Valid ULID lowercase", "01arz3ndektsv4rrffq69g5fav", false},
+		{"UUID", "550e8400-e29b-41d4-a716-446655440000", true},
+		{"Excluded letter", "01ARZ3NDEKTSV4RRFFQ69G5FAU", true},
+		{"Timestamp overflow", "81ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"Too short", "01ARZ3NDEKTSV4RRFFQ69G5FA", true},
+		{"Empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateULID(tt.ulid)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateULID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{"UUID", "550e8400-e29b-41d4-a716-446655440000", false},
+		{"ULID", "01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+		{"Neither", "not-an-id", true},
+		{"Empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateIntRange(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -148,6 +195,7 @@ func TestValidateGameAction(t *testing.T) {
 		{"Valid call", "call", false},
 		{"Valid raise", "raise", false},
 		{"Valid allin", "allin", false},
+		{"Valid straddle", "straddle", false},
 		{"Invalid action", "invalid", true},
 		{"Empty", "", true},
 		{"Case sensitive", "Fold", true},
@@ -368,3 +416,59 @@ func TestValidateSafeString(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTableName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"Valid name", "Friday Night Game", false},
+		{"Japanese name", "金曜日のポーカーナイト", false},
+		{"100 multi-byte characters", strings.Repeat("é", 100), false},
+		{"Too long", strings.Repeat("é", 101), true},
+		{"Line break", "Friday\nGame", true},
+		{"Bidirectional override", "Friday‮Game", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTableName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTableName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateChatMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{"Plain", "nice hand", "nice hand", false},
+		{"Quotes and keywords", "don't select the river", "don't select the river", false},
+		{"Trimmed", "  gg  ", "gg", false},
+		{"Unicode", "いい手だ", "いい手だ", false},
+		{"Max length", strings.Repeat("a", 200), strings.Repeat("a", 200), false},
+		{"Too long", strings.Repeat("a", 201), "", true},
+		{"Empty", "   ", "", true},
+		{"Script", "<script>alert(1)</script>", "", true},
+		{"Line break", "one\ntwo", "", true},
+		{"Bidi override", "abc\u202edef", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateChatMessage(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateChatMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ValidateChatMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
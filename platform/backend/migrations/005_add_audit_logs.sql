@@ -0,0 +1,22 @@
+-- Migration: Add audit_logs table for dispute and fraud investigations
+-- Every login and game action is recorded with the client's network metadata.
+-- Rows older than the configured retention period are purged by the backend.
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(32) NOT NULL COMMENT 'login, login_failed, game_action',
+    table_id VARCHAR(36) NULL,
+    action VARCHAR(32) NULL COMMENT 'For game_action events: fold, check, call, raise, allin',
+    amount INT DEFAULT 0,
+    request_id VARCHAR(64) NULL,
+    ip_address VARCHAR(45) NOT NULL COMMENT 'IPv4 or IPv6 address',
+    user_agent VARCHAR(512) NULL,
+    connection_id VARCHAR(36) NULL COMMENT 'WebSocket connection the action arrived on',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_audit_user_created (user_id, created_at),
+    INDEX idx_audit_table_created (table_id, created_at),
+    INDEX idx_audit_ip (ip_address),
+    INDEX idx_audit_created (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Per-user audit trail with client metadata';
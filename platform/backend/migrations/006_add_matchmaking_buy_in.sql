@@ -0,0 +1,4 @@
+-- Add buy_in column to matchmaking_queue
+-- Stores the buy-in a player chose when joining the queue (NULL = preset starting stack)
+
+ALTER TABLE matchmaking_queue ADD COLUMN buy_in INT NULL AFTER max_buy_in;
@@ -0,0 +1,5 @@
+-- Add session hand counts to table_seats
+-- Stored when a player's session ends, for responsible gaming limits and stats
+
+ALTER TABLE table_seats ADD COLUMN hands_dealt INT NOT NULL DEFAULT 0 AFTER left_at;
+ALTER TABLE table_seats ADD COLUMN hands_played INT NOT NULL DEFAULT 0 AFTER hands_dealt;
@@ -0,0 +1,6 @@
+-- Add unregistration deadline and late-cancel fee to tournaments
+-- unregister_deadline: seconds before start when free unregistration closes (0 = until start)
+-- late_cancel_fee: chips withheld when unregistering after the deadline (0 = not allowed)
+
+ALTER TABLE tournaments ADD COLUMN unregister_deadline INT NOT NULL DEFAULT 0 AFTER auto_start_delay;
+ALTER TABLE tournaments ADD COLUMN late_cancel_fee INT NOT NULL DEFAULT 0 AFTER unregister_deadline;
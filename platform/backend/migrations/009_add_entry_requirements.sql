@@ -0,0 +1,18 @@
+-- Add creator-configurable entry requirements to tables and tournaments
+-- entry_requirements: JSON {min_balance, min_account_age_days, min_level, invite_only}
+-- entry_invites: invite list of invite-only tables and tournaments
+
+ALTER TABLE tables ADD COLUMN entry_requirements JSON NULL AFTER max_buy_in;
+ALTER TABLE tournaments ADD COLUMN entry_requirements JSON NULL AFTER late_cancel_fee;
+
+CREATE TABLE IF NOT EXISTS entry_invites (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    resource_id VARCHAR(36) NOT NULL COMMENT 'Table or tournament ID',
+    user_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    UNIQUE KEY unique_entry_invite (resource_id, user_id),
+    INDEX idx_entry_invites_user_id (user_id)
+);
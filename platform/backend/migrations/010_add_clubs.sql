@@ -0,0 +1,38 @@
+-- Add clubs: private groups with their own lobby of tables and tournaments
+-- Club-scoped tables and tournaments are only visible to and joinable by active members
+
+CREATE TABLE IF NOT EXISTS clubs (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NULL,
+    owner_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+
+    FOREIGN KEY (owner_id) REFERENCES users(id),
+
+    INDEX idx_club_owner (owner_id),
+    INDEX idx_clubs_deleted_at (deleted_at)
+);
+
+CREATE TABLE IF NOT EXISTS club_members (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    club_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    role ENUM('owner', 'manager', 'member') DEFAULT 'member',
+    status ENUM('invited', 'active') DEFAULT 'invited',
+    invited_by VARCHAR(36) NULL,
+    joined_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (club_id) REFERENCES clubs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    UNIQUE KEY unique_club_member (club_id, user_id),
+    INDEX idx_club_member_user (user_id)
+);
+
+ALTER TABLE tables ADD COLUMN club_id VARCHAR(36) NULL AFTER tournament_id;
+ALTER TABLE tables ADD INDEX idx_table_club (club_id);
+ALTER TABLE tournaments ADD COLUMN club_id VARCHAR(36) NULL AFTER creator_id;
+ALTER TABLE tournaments ADD INDEX idx_tournament_club (club_id);
@@ -0,0 +1,36 @@
+-- Add club wallets: club chips issued by club owners, isolated from global chips
+-- Every change to a club wallet is recorded in club_chip_transactions
+
+CREATE TABLE IF NOT EXISTS club_wallets (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    club_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    balance INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (club_id) REFERENCES clubs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    UNIQUE KEY unique_club_wallet (club_id, user_id),
+    CONSTRAINT chk_club_wallet_balance CHECK (balance >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS club_chip_transactions (
+    id VARCHAR(36) PRIMARY KEY,
+    club_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    actor_id VARCHAR(36) NULL COMMENT 'Owner who issued or voided chips',
+    amount INT NOT NULL COMMENT 'Positive for credits, negative for debits',
+    balance_before INT NOT NULL,
+    balance_after INT NOT NULL,
+    transaction_type VARCHAR(32) NOT NULL COMMENT 'Type: club_issue, club_void, club_table_buy_in, club_table_cash_out',
+    reference_id VARCHAR(36) NULL COMMENT 'Table ID for buy-ins and cash-outs',
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (club_id) REFERENCES clubs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    INDEX idx_club_tx_club_created (club_id, created_at),
+    INDEX idx_club_tx_user (user_id)
+);
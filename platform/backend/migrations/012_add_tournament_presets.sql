@@ -0,0 +1,17 @@
+-- Add saved tournament presets: users' own blind and prize structures,
+-- selectable by ID when creating a tournament
+
+CREATE TABLE IF NOT EXISTS tournament_presets (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(16) NOT NULL COMMENT 'structure or prize',
+    name VARCHAR(100) NOT NULL,
+    structure JSON NULL COMMENT 'TournamentStructure when kind = structure',
+    prize_structure JSON NULL COMMENT 'PrizeStructureConfig when kind = prize',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    UNIQUE KEY unique_user_preset (user_id, kind, name)
+);
@@ -0,0 +1,18 @@
+-- Add an operator fee to tournaments, charged on top of the buy-in (e.g. 100+10)
+-- entry_fee: chips credited to the operator account on registration; not part of the prize pool
+
+-- Operator account that collects tournament fees. Its username and email are
+-- ones registration refuses, so no player can have taken them. The password
+-- hash is not a valid bcrypt hash, so the account cannot log in.
+INSERT INTO users (id, username, email, password_hash, chips)
+VALUES ('00000000-0000-0000-0000-000000000000', '#house', 'house@localhost', '!', 0)
+ON DUPLICATE KEY UPDATE id = id;
+
+-- Fees can't be charged without the account, so the migration fails if it is
+-- still missing: inserting NULL into a NOT NULL column is an error
+CREATE TEMPORARY TABLE operator_account_check (id CHAR(36) NOT NULL);
+INSERT INTO operator_account_check (id)
+VALUES ((SELECT id FROM users WHERE id = '00000000-0000-0000-0000-000000000000'));
+DROP TEMPORARY TABLE operator_account_check;
+
+ALTER TABLE tournaments ADD COLUMN entry_fee INT NOT NULL DEFAULT 0 AFTER buy_in;
@@ -0,0 +1,6 @@
+-- Let table creators delay what spectators see, for private games streamed publicly
+-- creator_id: user who created the table; only they can change the delay
+-- spectator_delay: seconds (0-600) spectators lag behind the players
+
+ALTER TABLE tables ADD COLUMN creator_id VARCHAR(36) NULL AFTER club_id;
+ALTER TABLE tables ADD COLUMN spectator_delay INT NOT NULL DEFAULT 0 AFTER entry_requirements;
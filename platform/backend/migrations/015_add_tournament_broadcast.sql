@@ -0,0 +1,5 @@
+-- Final table broadcast mode for tournaments streamed with hole cards up
+-- broadcast_delay: seconds (0-600) the final table's spectators and the
+-- director's cards-up feed lag behind the players; 0 turns broadcast mode off
+
+ALTER TABLE tournaments ADD COLUMN broadcast_delay INT NOT NULL DEFAULT 0 AFTER entry_requirements;
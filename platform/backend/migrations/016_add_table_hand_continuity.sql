@@ -0,0 +1,6 @@
+-- Keep hand numbers and the button going across server restarts
+-- last_hand_number: number of the last hand dealt at the table; recovery continues from it
+-- dealer_position: seat that held the button in that hand, NULL before the first hand
+
+ALTER TABLE tables ADD COLUMN last_hand_number INT NOT NULL DEFAULT 0 AFTER spectator_delay;
+ALTER TABLE tables ADD COLUMN dealer_position INT NULL AFTER last_hand_number;
@@ -0,0 +1,26 @@
+-- Normalize game_events so history consumers can filter on columns instead of metadata
+-- betting_round: showdown events were recorded with a street the enum did not allow
+-- pot_after: total pot once the event is applied (player actions, new streets, hand end)
+-- board: community cards at the time of the event, space separated (e.g. "Ah Kd 7c")
+
+ALTER TABLE game_events MODIFY COLUMN betting_round ENUM('preflop', 'flop', 'turn', 'river', 'showdown') COMMENT 'Current betting round when event occurred';
+ALTER TABLE game_events ADD COLUMN pot_after INT NULL AFTER amount;
+ALTER TABLE game_events ADD COLUMN board VARCHAR(32) NULL AFTER pot_after;
+
+-- Per-table and per-player lookups by kind, newest first
+ALTER TABLE game_events ADD INDEX idx_table_kind (table_id, event_type, created_at);
+ALTER TABLE game_events ADD INDEX idx_user_kind (user_id, event_type, created_at);
+
+-- Backfill from metadata written before these columns existed
+UPDATE game_events SET pot_after = JSON_EXTRACT(metadata, '$.pot_after')
+    WHERE event_type = 'player_action' AND JSON_EXTRACT(metadata, '$.pot_after') IS NOT NULL;
+UPDATE game_events SET pot_after = JSON_EXTRACT(metadata, '$.pot')
+    WHERE event_type = 'round_advanced' AND JSON_EXTRACT(metadata, '$.pot') IS NOT NULL;
+UPDATE game_events SET pot_after = JSON_EXTRACT(metadata, '$.final_pot')
+    WHERE event_type = 'hand_complete' AND JSON_EXTRACT(metadata, '$.final_pot') IS NOT NULL;
+UPDATE game_events
+    SET board = NULLIF(TRIM(REPLACE(REPLACE(REPLACE(REPLACE(JSON_EXTRACT(metadata, '$.community_cards'), '[', ''), ']', ''), '"', ''), ',', '')), '')
+    WHERE event_type = 'round_advanced' AND JSON_EXTRACT(metadata, '$.community_cards') IS NOT NULL;
+UPDATE game_events
+    SET board = NULLIF(TRIM(REPLACE(REPLACE(REPLACE(REPLACE(JSON_EXTRACT(metadata, '$.final_community_cards'), '[', ''), ']', ''), '"', ''), ',', '')), '')
+    WHERE event_type = 'hand_complete' AND JSON_EXTRACT(metadata, '$.final_community_cards') IS NOT NULL;
@@ -0,0 +1,8 @@
+-- How amounts are shown to players
+-- currency_symbol: shown by clients in front of amounts at the table
+-- chip_scale: chips per displayed unit, e.g. 100 to show cents
+-- display_in_bb: the player prefers stacks and bets in big blinds
+
+ALTER TABLE tables ADD COLUMN currency_symbol VARCHAR(8) NOT NULL DEFAULT '' AFTER dealer_position;
+ALTER TABLE tables ADD COLUMN chip_scale INT NOT NULL DEFAULT 1 AFTER currency_symbol;
+ALTER TABLE users ADD COLUMN display_in_bb BOOLEAN NOT NULL DEFAULT FALSE AFTER chips;
@@ -0,0 +1,4 @@
+-- Sit & go tournaments start the moment they are full, with no start time or countdown
+-- tournament_type: 'scheduled' for the existing behaviour, 'sit_n_go' to start when full
+
+ALTER TABLE tournaments ADD COLUMN tournament_type ENUM('scheduled', 'sit_n_go') NOT NULL DEFAULT 'scheduled' AFTER status;
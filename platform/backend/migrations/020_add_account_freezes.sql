@@ -0,0 +1,19 @@
+-- Admin kill switch for suspected compromised accounts
+-- users.frozen_at: set while a freeze blocks the account's game actions and withdrawals
+-- account_freezes: every freeze and its lifting, kept for review
+
+ALTER TABLE users ADD COLUMN frozen_at TIMESTAMP NULL AFTER display_in_bb;
+
+CREATE TABLE IF NOT EXISTS account_freezes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    frozen_by VARCHAR(36) NOT NULL,
+    reason VARCHAR(255) NULL,
+    frozen_at TIMESTAMP NOT NULL,
+    lifted_by VARCHAR(36) NULL,
+    lifted_at TIMESTAMP NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    INDEX idx_freeze_user (user_id)
+);
@@ -0,0 +1,4 @@
+-- Break before a tournament's final table deals its first hand
+-- final_table_break: seconds, 0 deals straight away
+
+ALTER TABLE tournaments ADD COLUMN final_table_break INT NOT NULL DEFAULT 0 AFTER broadcast_delay;
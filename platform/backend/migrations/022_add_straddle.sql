@@ -0,0 +1,4 @@
+-- Optional under the gun straddle at cash tables
+-- allow_straddle: the player under the gun may post a straddle of two big blinds before the cards are dealt
+
+ALTER TABLE tables ADD COLUMN allow_straddle BOOLEAN NOT NULL DEFAULT FALSE AFTER chip_scale;
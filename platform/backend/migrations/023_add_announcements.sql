@@ -0,0 +1,19 @@
+-- Operator announcements shown to clients as banners
+-- target: all connected clients, the players of a tournament or everyone at a table (target_id)
+-- sent_at: set once the announcement has gone out; cancelled_at: withdrawn by an operator
+
+CREATE TABLE IF NOT EXISTS announcements (
+    id VARCHAR(36) PRIMARY KEY,
+    kind ENUM('info', 'maintenance', 'promotion') NOT NULL,
+    message VARCHAR(500) NOT NULL,
+    target ENUM('all', 'tournament', 'table') NOT NULL,
+    target_id VARCHAR(36) NULL,
+    starts_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NULL,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP NULL,
+    cancelled_at TIMESTAMP NULL,
+
+    INDEX idx_announcement_starts (starts_at)
+);
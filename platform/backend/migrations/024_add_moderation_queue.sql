@@ -0,0 +1,19 @@
+-- Borderline user-written text held for review by the profanity filter
+-- kind: what the text is; subject_id: the user, table or tournament it belongs to (the table for chat)
+-- term, language: the wordlist entry that matched
+
+CREATE TABLE IF NOT EXISTS moderation_queue (
+    id VARCHAR(36) PRIMARY KEY,
+    kind ENUM('username', 'table_name', 'tournament_name', 'chat') NOT NULL,
+    subject_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    text VARCHAR(500) NOT NULL,
+    term VARCHAR(100) NOT NULL,
+    language VARCHAR(16) NOT NULL,
+    status ENUM('pending', 'approved', 'rejected') NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    reviewed_by VARCHAR(36) NULL,
+    reviewed_at TIMESTAMP NULL,
+
+    INDEX idx_moderation_status (status, created_at)
+);
@@ -0,0 +1,16 @@
+-- Player IDs from other systems, such as a standalone engine deployment, and the platform users they map to
+-- source: the system the ID comes from; the same external ID may appear in several sources
+-- imported: the user was created on import rather than linked to an existing account
+
+CREATE TABLE IF NOT EXISTS player_identities (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    source VARCHAR(50) NOT NULL,
+    external_id VARCHAR(100) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    imported BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY idx_identity_source_external (source, external_id),
+    INDEX idx_identity_user (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+-- ULIDs for hands and game events. New rows get a time-ordered uid alongside
+-- their auto-increment id, which existing foreign keys keep using. Chip
+-- transactions already have string ids, so new ones are simply ULIDs.
+-- Rows from before this migration keep a NULL uid.
+
+ALTER TABLE hands
+    ADD COLUMN uid CHAR(26) NULL AFTER id,
+    ADD UNIQUE INDEX idx_hands_uid (uid);
+
+ALTER TABLE game_events
+    ADD COLUMN uid CHAR(26) NULL AFTER id,
+    ADD UNIQUE INDEX idx_game_events_uid (uid);
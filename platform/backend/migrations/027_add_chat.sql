@@ -0,0 +1,24 @@
+-- Table chat. Messages themselves live in Redis; these hold who hides whom and who may not talk.
+-- chat_ignores: user_id no longer sees table chat from ignored_user_id
+-- chat_mutes: user_id cannot send table chat until muted_until; muted_by is the admin
+
+CREATE TABLE IF NOT EXISTS chat_ignores (
+    user_id VARCHAR(36) NOT NULL,
+    ignored_user_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, ignored_user_id),
+    INDEX idx_chat_ignored (ignored_user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (ignored_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS chat_mutes (
+    user_id VARCHAR(36) PRIMARY KEY,
+    muted_until TIMESTAMP NOT NULL,
+    muted_by VARCHAR(36) NOT NULL,
+    reason VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
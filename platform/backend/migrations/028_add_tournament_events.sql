@@ -0,0 +1,19 @@
+-- Event log of every tournament. The lifecycle columns of tournaments (status, current_players,
+-- prize_pool, current_level and their times) are kept as a projection of these events.
+-- type: registered, unregistered, started, level_changed, paused, resumed, eliminated, completed or cancelled
+-- user_id: the player registering, unregistering or eliminated, or who paused, resumed or cancelled
+-- data: details that depend on the type, such as the new level or a finishing position
+-- Tournaments from before this migration have no events for what already happened.
+
+CREATE TABLE IF NOT EXISTS tournament_events (
+    id CHAR(26) PRIMARY KEY,
+    tournament_id VARCHAR(36) NOT NULL,
+    sequence INT NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    user_id VARCHAR(36) NULL,
+    data JSON NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY idx_tournament_event_sequence (tournament_id, sequence),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE
+);
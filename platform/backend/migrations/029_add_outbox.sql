@@ -0,0 +1,22 @@
+-- Outbox of steps in multi-step operations, such as paying out a completed tournament.
+-- Each row is written in the same transaction as the step before it, so it survives a crash
+-- between the two, and the dispatcher retries it with backoff until it is done.
+-- status: pending until done; after too many attempts the step's compensation runs (compensated),
+--         or, without one or if it fails too, the step is left for an admin to retry (failed)
+-- next_attempt_at: when a pending row is due; also pushed forward while a dispatcher runs it
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id CHAR(26) PRIMARY KEY,
+    topic VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    payload JSON NULL,
+    status ENUM('pending', 'done', 'compensated', 'failed') NOT NULL DEFAULT 'pending',
+    attempts INT DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP NULL,
+
+    INDEX idx_outbox_due (status, next_attempt_at),
+    INDEX idx_outbox_aggregate (aggregate_id)
+);
@@ -0,0 +1,8 @@
+-- Admin role on accounts, and who made each admin change in the audit trail
+-- users.role: 'admin' lets an account use the /api/admin routes, besides those listed in ADMIN_USER_IDS
+-- audit_logs.actor_id: the admin behind an admin_action entry; user_id is the player it was done to
+
+ALTER TABLE users ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'player' AFTER chips;
+
+ALTER TABLE audit_logs ADD COLUMN actor_id VARCHAR(36) NULL AFTER user_id;
+ALTER TABLE audit_logs MODIFY COLUMN event_type VARCHAR(32) NOT NULL COMMENT 'login, login_failed, game_action, admin_action';
@@ -0,0 +1,11 @@
+-- Full engine state of each table in the middle of a hand, so a restart carries the hand on
+-- instead of calling it off. Saved after every action and deleted when the hand ends.
+-- hand_number: the hand the state is from; its hands row is left open while the state exists
+-- state: engine TableState as JSON, including hole cards and the deck seed, so it must never be exposed
+
+CREATE TABLE IF NOT EXISTS table_hand_states (
+    table_id VARCHAR(36) PRIMARY KEY,
+    hand_number INT NOT NULL,
+    state MEDIUMTEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
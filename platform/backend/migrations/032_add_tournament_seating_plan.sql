@@ -0,0 +1,4 @@
+-- Seeded seating for tournaments
+-- seating_plan: JSON {fixed: [{user_id, table, seat}], apart: [[user_id]], seeds: [user_id]}, NULL seats everyone at random
+
+ALTER TABLE tournaments ADD COLUMN seating_plan JSON NULL AFTER final_table_break;
@@ -0,0 +1,9 @@
+-- All-in EV of tournament players
+-- all_ins: hands the player was all-in in with cards to come
+-- all_in_expected: chips their equity entitled them to in those hands
+-- all_in_won: chips they won in those hands
+
+ALTER TABLE tournament_players
+    ADD COLUMN all_ins INT NOT NULL DEFAULT 0 AFTER prize_amount,
+    ADD COLUMN all_in_expected DOUBLE NOT NULL DEFAULT 0 AFTER all_ins,
+    ADD COLUMN all_in_won BIGINT NOT NULL DEFAULT 0 AFTER all_in_expected;
@@ -0,0 +1,4 @@
+-- Showdown card visibility per table
+-- showdown_policy: whose hole cards everyone sees once a hand is complete; all who didn't fold, callers of the last bet, winners, or nobody
+
+ALTER TABLE tables ADD COLUMN showdown_policy ENUM('all', 'callers', 'winners', 'never') NOT NULL DEFAULT 'all' AFTER allow_straddle;
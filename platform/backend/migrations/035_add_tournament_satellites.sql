@@ -0,0 +1,28 @@
+-- Satellite tournaments, which award seats in a target tournament instead of chips
+-- satellite_target_id: the tournament a satellite's winners get tickets for
+-- tournament_tickets: a seat won in a satellite, good for one registration in its tournament
+-- value: the target's buy-in and entry fee when the ticket was won
+-- status: unused until registered with, used while registered, refunded as chips if the target is cancelled
+-- tournament_players.ticket_id: the ticket a player registered with, given back if they unregister
+
+ALTER TABLE tournaments ADD COLUMN satellite_target_id VARCHAR(36) NULL AFTER club_id,
+    ADD INDEX idx_satellite_target (satellite_target_id);
+
+CREATE TABLE IF NOT EXISTS tournament_tickets (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    tournament_id VARCHAR(36) NOT NULL,
+    source_tournament_id VARCHAR(36) NOT NULL,
+    value INT NOT NULL,
+    status ENUM('unused', 'used', 'refunded') NOT NULL DEFAULT 'unused',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    used_at TIMESTAMP NULL,
+
+    INDEX idx_ticket_user (user_id, status),
+    INDEX idx_ticket_tournament (tournament_id, status),
+    UNIQUE KEY idx_ticket_source (source_tournament_id, user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE
+);
+
+ALTER TABLE tournament_players ADD COLUMN ticket_id VARCHAR(36) NULL AFTER prize_amount;
@@ -0,0 +1,9 @@
+-- Scheduled end times for tournaments and cash tables
+-- tournaments.ends_at: hard end; a tournament still registering is cancelled and one in play is aborted with a chip chop
+-- tables.auto_close_at: a cash table is closed at this time and every stack returned
+-- registration_closes_at already exists and can now be set when a tournament is created
+
+ALTER TABLE tournaments ADD COLUMN ends_at TIMESTAMP NULL AFTER registration_completed_at;
+
+ALTER TABLE tables ADD COLUMN auto_close_at TIMESTAMP NULL AFTER showdown_policy,
+    ADD INDEX idx_table_auto_close (auto_close_at);
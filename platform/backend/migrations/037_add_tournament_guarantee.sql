@@ -0,0 +1,5 @@
+-- Guaranteed prize pools
+-- guarantee: the least a tournament's prizes add up to; when the buy-ins fall short,
+-- the house covers the difference (the overlay) from the operator account, which may go negative
+
+ALTER TABLE tournaments ADD COLUMN guarantee INT NOT NULL DEFAULT 0 AFTER prize_pool;
@@ -0,0 +1,14 @@
+-- Experience levels
+-- users.xp: experience from hands played and tournaments finished
+-- users.level: follows from xp; each new level pays a reward from the operator account
+-- tournament_tickets: open tickets, given as level-up rewards, have no tournament or source
+--   and are good for any tournament whose buy-in and entry fee add up to no more than their value
+-- tournament_tickets.level: the level whose reward the ticket was
+
+ALTER TABLE users ADD COLUMN xp INT NOT NULL DEFAULT 0 AFTER frozen_at,
+    ADD COLUMN level INT NOT NULL DEFAULT 1 AFTER xp;
+
+ALTER TABLE tournament_tickets MODIFY COLUMN tournament_id VARCHAR(36) NULL,
+    MODIFY COLUMN source_tournament_id VARCHAR(36) NULL,
+    ADD COLUMN level INT NULL AFTER source_tournament_id,
+    ADD INDEX idx_ticket_open (user_id, status, value);
@@ -0,0 +1,14 @@
+-- Daily missions
+-- mission_progress: how far a user is through a mission on a UTC day; a new day starts every mission over
+-- completed_at: set once the mission's target was reached and its reward paid
+
+CREATE TABLE IF NOT EXISTS mission_progress (
+    user_id VARCHAR(36) NOT NULL,
+    mission_id VARCHAR(32) NOT NULL,
+    day CHAR(10) NOT NULL,
+    progress INT NOT NULL DEFAULT 0,
+    completed_at TIMESTAMP NULL,
+
+    PRIMARY KEY (user_id, day, mission_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- Betting mode per table
+-- betting_mode: how much a bet or raise may be; no limit, up to the pot, or fixed sizes with four bets a round
+
+ALTER TABLE tables ADD COLUMN betting_mode ENUM('no_limit', 'pot_limit', 'fixed_limit') NOT NULL DEFAULT 'no_limit' AFTER showdown_policy;
@@ -0,0 +1,27 @@
+-- Referral program
+-- referral_codes: the code each user hands out, made the first time they ask for it
+-- referrals: who a user registered with the code of; hands_played counts hands without the referrer at the table,
+--   and each milestone reached pays both players once
+
+CREATE TABLE IF NOT EXISTS referral_codes (
+    user_id VARCHAR(36) PRIMARY KEY,
+    code VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY idx_referral_code (code),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS referrals (
+    referee_id VARCHAR(36) PRIMARY KEY,
+    referrer_id VARCHAR(36) NOT NULL,
+    code VARCHAR(16) NOT NULL,
+    hands_played INT NOT NULL DEFAULT 0,
+    milestones INT NOT NULL DEFAULT 0,
+    referrer_earned INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_referrer (referrer_id),
+    FOREIGN KEY (referee_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (referrer_id) REFERENCES users(id) ON DELETE CASCADE
+);
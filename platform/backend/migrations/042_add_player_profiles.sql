@@ -0,0 +1,12 @@
+-- Public player profiles
+-- player_profiles.biggest_pot: most chips the user won in one hand, kept as hands complete
+-- player_profiles.hidden: JSON list of the profile fields only the user sees
+
+CREATE TABLE IF NOT EXISTS player_profiles (
+    user_id VARCHAR(36) PRIMARY KEY,
+    biggest_pot INT NOT NULL DEFAULT 0,
+    biggest_pot_at TIMESTAMP NULL,
+    hidden JSON,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
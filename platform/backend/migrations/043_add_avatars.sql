@@ -0,0 +1,14 @@
+-- Uploaded avatars
+-- avatars.url: where clients load the image from; storage_key: where it is kept in avatar storage
+-- Every upload, and every avatar players report, is queued for moderation as kind 'avatar'
+
+CREATE TABLE IF NOT EXISTS avatars (
+    user_id VARCHAR(36) PRIMARY KEY,
+    url VARCHAR(500) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE moderation_queue MODIFY COLUMN kind ENUM('username', 'table_name', 'tournament_name', 'chat', 'avatar') NOT NULL;
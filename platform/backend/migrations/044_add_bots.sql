@@ -0,0 +1,12 @@
+-- Bots: computer players seated at cash tables or filling matchmaking queues
+-- A bot is a user with role 'bot' and an unusable password; bots.difficulty is easy, medium or hard
+-- Their bankrolls are topped up from the house account as 'bot_bankroll' transactions
+
+CREATE TABLE IF NOT EXISTS bots (
+    user_id VARCHAR(36) PRIMARY KEY,
+    difficulty VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_bot_difficulty (difficulty),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
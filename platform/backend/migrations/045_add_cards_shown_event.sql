@@ -0,0 +1,16 @@
+-- Cards the winner of a pot nobody contested chose to show, recorded after hand_complete
+-- game_events.metadata: player_name and cards, e.g. ["Ah"]
+
+ALTER TABLE game_events MODIFY COLUMN event_type ENUM(
+    'hand_started',
+    'cards_dealt',
+    'blinds_posted',
+    'player_action',
+    'round_advanced',
+    'showdown',
+    'hand_complete',
+    'player_timeout',
+    'player_eliminated',
+    'blinds_increased',
+    'cards_shown'
+) NOT NULL;
@@ -0,0 +1,25 @@
+-- Heads-up bracket tournaments, played as 1v1 matches until one player is left
+-- format: 'standard' for the existing behaviour, 'heads_up_bracket' to pair players off each round
+-- bracket_matches: one match of a round; slots 2n and 2n+1 feed slot n of the next round
+-- player_b_id and table_id: NULL for a bye, which player_a goes through without playing
+-- status: playing, completed, or bye
+
+ALTER TABLE tournaments ADD COLUMN format ENUM('standard', 'heads_up_bracket') NOT NULL DEFAULT 'standard' AFTER tournament_type;
+
+CREATE TABLE IF NOT EXISTS bracket_matches (
+    id VARCHAR(36) PRIMARY KEY,
+    tournament_id VARCHAR(36) NOT NULL,
+    round INT NOT NULL,
+    slot INT NOT NULL,
+    player_a_id VARCHAR(36) NOT NULL,
+    player_b_id VARCHAR(36) NULL,
+    winner_id VARCHAR(36) NULL,
+    table_id VARCHAR(36) NULL,
+    status VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP NULL,
+
+    UNIQUE KEY idx_bracket_slot (tournament_id, round, slot),
+    INDEX idx_bracket_table (table_id),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE
+);
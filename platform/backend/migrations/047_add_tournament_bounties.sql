@@ -0,0 +1,25 @@
+-- Progressive knockout (bounty) tournaments
+-- tournaments.bounty: part of the buy-in put on each player's head instead of in the prize pool
+-- tournament_players.bounty: what is on the player's head now; bounties_won: cash paid for knockouts
+-- tournament_knockouts: one row per eliminator of a knocked out player, a split pot shares the bounty
+-- cash: paid to the eliminator once paid_at is set; added: put on the eliminator's own bounty
+
+ALTER TABLE tournaments ADD COLUMN bounty INT NOT NULL DEFAULT 0 AFTER entry_fee;
+
+ALTER TABLE tournament_players
+    ADD COLUMN bounty INT NOT NULL DEFAULT 0 AFTER all_in_won,
+    ADD COLUMN bounties_won INT NOT NULL DEFAULT 0 AFTER bounty;
+
+CREATE TABLE IF NOT EXISTS tournament_knockouts (
+    id VARCHAR(36) PRIMARY KEY,
+    tournament_id VARCHAR(36) NOT NULL,
+    eliminated_id VARCHAR(36) NOT NULL,
+    eliminator_id VARCHAR(36) NOT NULL,
+    cash INT NOT NULL,
+    added INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    paid_at TIMESTAMP NULL,
+
+    UNIQUE KEY idx_knockout (tournament_id, eliminated_id, eliminator_id),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE
+);
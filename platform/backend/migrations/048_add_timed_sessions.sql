@@ -0,0 +1,11 @@
+-- Timed cash sessions, which run for a fixed time from their first hand and then close
+-- tables.session_length: seconds a timed session runs; 0 for a table without one
+-- The session's end is kept in tables.auto_close_at once its first hand is dealt
+-- table_seats.bought_in: chips brought to the seat, rebuys included
+-- table_seats.cashed_out: the stack returned when the player left the seat
+
+ALTER TABLE tables ADD COLUMN session_length INT NOT NULL DEFAULT 0 AFTER auto_close_at;
+
+ALTER TABLE table_seats
+    ADD COLUMN bought_in INT NOT NULL DEFAULT 0 AFTER hands_played,
+    ADD COLUMN cashed_out INT NOT NULL DEFAULT 0 AFTER bought_in;
@@ -0,0 +1,7 @@
+-- ULIDs for hand actions, so the history writer can skip an action it is
+-- handed again after a retry, as it does game events. Rows from before this
+-- migration keep a NULL uid.
+
+ALTER TABLE hand_actions
+    ADD COLUMN uid CHAR(26) NULL AFTER id,
+    ADD UNIQUE INDEX idx_hand_actions_uid (uid);
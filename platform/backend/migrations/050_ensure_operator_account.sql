@@ -0,0 +1,12 @@
+-- Databases that ran 013_add_tournament_fees.sql before it inserted the
+-- operator account by id may lack it, if a player already had the username
+-- 'house'. Create it as 013 now does, and fail if it is still missing.
+
+INSERT INTO users (id, username, email, password_hash, chips)
+VALUES ('00000000-0000-0000-0000-000000000000', '#house', 'house@localhost', '!', 0)
+ON DUPLICATE KEY UPDATE id = id;
+
+CREATE TEMPORARY TABLE operator_account_check (id CHAR(36) NOT NULL);
+INSERT INTO operator_account_check (id)
+VALUES ((SELECT id FROM users WHERE id = '00000000-0000-0000-0000-000000000000'));
+DROP TEMPORARY TABLE operator_account_check;
@@ -259,6 +259,28 @@ export const WEBSOCKET = {
   RECONNECT_BACKOFF_MULTIPLIER: 1.5,
 } as const;
 
+// WebSocket close codes sent by the server (see platform/README.md)
+export const WS_CLOSE_CODES = {
+  KICKED: 4000,
+  BANNED: 4001,
+  DUPLICATE_LOGIN: 4002,
+  SERVER_RESTART: 4003,
+  SLOW_CONSUMER: 4004,
+} as const;
+
+// Close codes after which the client must not reconnect automatically
+export const WS_NO_RECONNECT_CODES: readonly number[] = [
+  WS_CLOSE_CODES.KICKED,
+  WS_CLOSE_CODES.BANNED,
+  WS_CLOSE_CODES.DUPLICATE_LOGIN,
+];
+
+// WebSocket protocol version and optional features sent in the hello message
+export const WS_PROTOCOL = {
+  VERSION: 1,
+  FEATURES: ['action_required'],
+} as const;
+
 // API
 export const API = {
   BASE_URL: process.env.REACT_APP_API_URL || 'http://localhost:8080',
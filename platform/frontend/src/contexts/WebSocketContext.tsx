@@ -1,6 +1,6 @@
 import React, { createContext, useContext, useState, useEffect, useRef, ReactNode, useCallback } from 'react';
 import { WSMessage } from '../types';
-import { WEBSOCKET, API } from '../constants';
+import { WEBSOCKET, API, WS_NO_RECONNECT_CODES, WS_PROTOCOL } from '../constants';
 import { useAuth } from './AuthContext';
 
 type MessageHandler = (message: WSMessage) => void;
@@ -81,13 +81,24 @@ export const WebSocketProvider: React.FC<WebSocketProviderProps> = ({ children }
         setIsConnected(true);
         reconnectAttemptRef.current = 0;
         startHeartbeat();
+
+        // Declare protocol version and features; the server replies with hello_ack
+        ws.send(JSON.stringify({
+          type: 'hello',
+          payload: { protocol_version: WS_PROTOCOL.VERSION, features: WS_PROTOCOL.FEATURES },
+        }));
       };
 
-      ws.onclose = () => {
-        console.log('WebSocket disconnected');
+      ws.onclose = (event) => {
+        console.log(`WebSocket disconnected (code ${event.code}${event.reason ? `: ${event.reason}` : ''})`);
         setIsConnected(false);
         clearHeartbeat();
 
+        // Kicked, banned or replaced by another session - reconnecting would not help
+        if (WS_NO_RECONNECT_CODES.includes(event.code)) {
+          return;
+        }
+
         // Attempt to reconnect if authenticated
         if (isAuthenticated && reconnectAttemptRef.current < WEBSOCKET.RECONNECT_ATTEMPTS) {
           const delay = getReconnectDelay();
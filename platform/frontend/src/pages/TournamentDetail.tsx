@@ -48,6 +48,7 @@ interface Tournament {
   creator_id?: string;
   status: 'registering' | 'starting' | 'in_progress' | 'paused' | 'completed' | 'cancelled';
   buy_in: number;
+  entry_fee?: number;
   starting_chips: number;
   max_players: number;
   min_players: number;
@@ -569,7 +570,7 @@ export const TournamentDetail: React.FC = () => {
                   onClick={handleRegister}
                   disabled={tournament.current_players >= tournament.max_players}
                 >
-                  Register ({tournament.buy_in} chips)
+                  Register ({tournament.buy_in + (tournament.entry_fee || 0)} chips)
                 </Button>
               )}
               {tournament.status === 'registering' && isRegistered && (
@@ -661,7 +662,7 @@ export const TournamentDetail: React.FC = () => {
                     {tournament.prize_pool.toLocaleString()} chips
                   </Typography>
                   <Typography variant="caption" color={COLORS.text.secondary}>
-                    Buy-in: {tournament.buy_in} chips
+                    Buy-in: {tournament.buy_in}{tournament.entry_fee ? `+${tournament.entry_fee}` : ''} chips
                   </Typography>
                 </Stack>
               </Card>
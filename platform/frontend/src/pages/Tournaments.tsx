@@ -35,6 +35,7 @@ interface Tournament {
   name: string;
   status: string;
   buy_in: number;
+  entry_fee?: number;
   starting_chips: number;
   max_players: number;
   min_players: number;
@@ -44,6 +45,8 @@ interface Tournament {
   prize_structure: string;
   start_time?: string;
   auto_start_delay: number;
+  unregister_deadline?: number;
+  late_cancel_fee?: number;
   created_at: string;
 }
 
@@ -62,12 +65,15 @@ export const Tournaments: React.FC = () => {
   const [formData, setFormData] = useState({
     name: '',
     buy_in: 100,
+    entry_fee: 0,
     starting_chips: 1000,
     max_players: 10,
     min_players: 2,
     structure: 'standard',
     prize_structure: 'top3',
     auto_start_delay: 300,
+    unregister_deadline: 0,
+    late_cancel_fee: 0,
   });
 
   const fetchTournaments = useCallback(async () => {
@@ -342,7 +348,7 @@ export const Tournaments: React.FC = () => {
                           Buy-in
                         </Typography>
                         <Typography variant="body1" fontWeight={600}>
-                          ${tournament.buy_in}
+                          ${tournament.buy_in}{tournament.entry_fee ? `+${tournament.entry_fee}` : ''}
                         </Typography>
                       </Box>
                       <Box>
@@ -432,6 +438,15 @@ export const Tournaments: React.FC = () => {
               fullWidth
               value={formData.buy_in}
               onChange={(e) => setFormData({ ...formData, buy_in: parseInt(e.target.value) })}
+              helperText="Goes to the prize pool"
+            />
+            <TextField
+              label="Entry Fee (chips)"
+              type="number"
+              fullWidth
+              value={formData.entry_fee}
+              onChange={(e) => setFormData({ ...formData, entry_fee: parseInt(e.target.value) })}
+              helperText="Charged on top of the buy-in and kept by the house. At most the buy-in"
             />
             <TextField
               label="Starting Chips"
@@ -488,6 +503,22 @@ export const Tournaments: React.FC = () => {
               onChange={(e) => setFormData({ ...formData, auto_start_delay: parseInt(e.target.value) })}
               helperText="Time to wait after reaching minimum players before auto-starting"
             />
+            <TextField
+              label="Unregister Deadline (seconds before start)"
+              type="number"
+              fullWidth
+              value={formData.unregister_deadline}
+              onChange={(e) => setFormData({ ...formData, unregister_deadline: parseInt(e.target.value) })}
+              helperText="Players can unregister for free until this long before the start"
+            />
+            <TextField
+              label="Late-cancel Fee"
+              type="number"
+              fullWidth
+              value={formData.late_cancel_fee}
+              onChange={(e) => setFormData({ ...formData, late_cancel_fee: parseInt(e.target.value) })}
+              helperText="Withheld when unregistering after the deadline. 0 disallows late unregistration"
+            />
           </Stack>
         </DialogContent>
         <DialogActions sx={{ px: 3, pb: 3 }}>
@@ -30,20 +30,62 @@ export const tableAPI = {
   createTable: (data: any) => api.post('/tables', data),
   joinTable: (tableId: string, buyIn: number) =>
     api.post(`/tables/${tableId}/join`, { buy_in: buyIn }),
+  setSpectatorDelay: (tableId: string, seconds: number) =>
+    api.put(`/tables/${tableId}/spectator-delay`, { spectator_delay: seconds }),
 };
 
 export const matchmakingAPI = {
-  join: (gameMode: string) => api.post('/matchmaking/join', { game_mode: gameMode }),
+  join: (gameMode: string, buyIn?: number) =>
+    api.post('/matchmaking/join', { game_mode: gameMode, buy_in: buyIn }),
   status: () => api.get('/matchmaking/status'),
   leave: () => api.post('/matchmaking/leave'),
 };
 
+export const fairnessAPI = {
+  report: () => api.get('/fairness'),
+};
+
+export const sessionAPI = {
+  summary: () => api.get('/session/summary'),
+};
+
+export const clubAPI = {
+  create: (name: string, description?: string) => api.post('/clubs', { name, description }),
+  list: () => api.get('/clubs'),
+  get: (id: string) => api.get(`/clubs/${id}`),
+  invite: (id: string, username: string) => api.post(`/clubs/${id}/invite`, { username }),
+  accept: (id: string) => api.post(`/clubs/${id}/accept`),
+  removeMember: (id: string, userId: string) => api.delete(`/clubs/${id}/members/${userId}`),
+  setRole: (id: string, userId: string, role: 'manager' | 'member') =>
+    api.put(`/clubs/${id}/members/${userId}/role`, { role }),
+  lobby: (id: string) => api.get(`/clubs/${id}/lobby`),
+  leaderboard: (id: string) => api.get(`/clubs/${id}/leaderboard`),
+
+  // Club chips
+  issueChips: (id: string, userId: string, amount: number, note?: string) =>
+    api.post(`/clubs/${id}/chips/issue`, { user_id: userId, amount, note }),
+  voidChips: (id: string, userId: string, amount: number, note?: string) =>
+    api.post(`/clubs/${id}/chips/void`, { user_id: userId, amount, note }),
+  wallet: (id: string) => api.get(`/clubs/${id}/wallet`),
+  ledger: (id: string, userId?: string) => api.get(`/clubs/${id}/ledger`, { params: { user_id: userId } }),
+  reconciliation: (id: string) => api.get(`/clubs/${id}/reconciliation`),
+};
+
 export const tournamentAPI = {
   // Tournament management
   createTournament: (data: any) => api.post('/tournaments', data),
   getTournaments: () => api.get('/tournaments'),
   getTournament: (id: string) => api.get(`/tournaments/${id}`),
   getTournamentByCode: (code: string) => api.get(`/tournaments/code/${code}`),
+  simulateTournament: (data: any) => api.post('/tournaments/simulate', data),
+  validateStructure: (structure: any, startingChips: number) =>
+    api.post('/tournaments/structures/validate', { structure, starting_chips: startingChips }),
+
+  // Saved structure and prize presets
+  getPresets: (kind?: 'structure' | 'prize') => api.get('/tournaments/presets', { params: { kind } }),
+  createPreset: (data: any) => api.post('/tournaments/presets', data),
+  updatePreset: (id: string, data: any) => api.put(`/tournaments/presets/${id}`, data),
+  deletePreset: (id: string) => api.delete(`/tournaments/presets/${id}`),
   cancelTournament: (id: string) => api.delete(`/tournaments/${id}`),
   startTournament: (id: string) => api.post(`/tournaments/${id}/start`),
   pauseTournament: (id: string) => api.post(`/tournaments/${id}/pause`),
@@ -60,6 +102,12 @@ export const tournamentAPI = {
 
   // Tables
   getTournamentTables: (id: string) => api.get(`/tournaments/${id}/tables`),
+
+  // Final table broadcast
+  setBroadcastDelay: (id: string, seconds: number) =>
+    api.put(`/tournaments/${id}/broadcast`, { broadcast_delay: seconds }),
+  holdFinalTable: (id: string) => api.post(`/tournaments/${id}/final-table/hold`),
+  releaseFinalTable: (id: string) => api.post(`/tournaments/${id}/final-table/release`),
 };
 
 export default api;
@@ -0,0 +1,69 @@
+#!/usr/bin/env bash
+# Compares engine benchmark results against the recorded baseline and fails
+# if any benchmark regresses beyond the allowed thresholds.
+#
+# Usage: scripts/bench_check.sh [results-file]
+#   BENCH_NS_THRESHOLD      allowed ns/op regression in percent (default 25)
+#   BENCH_ALLOCS_THRESHOLD  allowed allocs/op regression in percent (default 10)
+#   BENCH_BASELINE          baseline file (default engine/testdata/bench_baseline.txt)
+
+set -euo pipefail
+
+NS_THRESHOLD="${BENCH_NS_THRESHOLD:-25}"
+ALLOCS_THRESHOLD="${BENCH_ALLOCS_THRESHOLD:-10}"
+BASELINE="${BENCH_BASELINE:-engine/testdata/bench_baseline.txt}"
+
+if [ ! -f "$BASELINE" ]; then
+	echo "Baseline file not found: $BASELINE (run 'make bench-baseline')" >&2
+	exit 1
+fi
+
+RESULTS="${1:-}"
+if [ -z "$RESULTS" ]; then
+	RESULTS="$(mktemp)"
+	trap 'rm -f "$RESULTS"' EXIT
+	go test ./engine -run '^$' -bench . -benchmem -count 1 | tee "$RESULTS"
+fi
+
+awk -v ns_limit="$NS_THRESHOLD" -v allocs_limit="$ALLOCS_THRESHOLD" '
+	# Benchmark lines look like:
+	# BenchmarkName-8   1000   1234 ns/op   456 B/op   7 allocs/op
+	function parse(line, fields,    n, i, name) {
+		n = split(line, fields, /[ \t]+/)
+		name = fields[1]
+		sub(/-[0-9]+$/, "", name)
+		for (i = 2; i < n; i++) {
+			if (fields[i + 1] == "ns/op") ns[FILENAME, name] = fields[i]
+			if (fields[i + 1] == "allocs/op") allocs[FILENAME, name] = fields[i]
+		}
+		return name
+	}
+	FNR == NR && /^Benchmark/ { name = parse($0); base[name] = 1; next }
+	FNR != NR && /^Benchmark/ { name = parse($0); seen[name] = 1; order[++count] = name }
+	END {
+		failed = 0
+		for (i = 1; i <= count; i++) {
+			name = order[i]
+			if (!(name in base)) {
+				printf "NEW   %-40s (no baseline)\n", name
+				continue
+			}
+			baseNs = ns[ARGV[1], name]; curNs = ns[ARGV[2], name]
+			baseAllocs = allocs[ARGV[1], name]; curAllocs = allocs[ARGV[2], name]
+
+			status = "OK"
+			if (baseNs > 0 && (curNs - baseNs) * 100 / baseNs > ns_limit) status = "FAIL"
+			if (curAllocs > baseAllocs && (baseAllocs == 0 || (curAllocs - baseAllocs) * 100 / baseAllocs > allocs_limit)) status = "FAIL"
+			if (status == "FAIL") failed = 1
+
+			printf "%-5s %-40s ns/op %10.1f -> %10.1f   allocs/op %6d -> %6d\n", status, name, baseNs, curNs, baseAllocs, curAllocs
+		}
+		for (name in base) {
+			if (!(name in seen)) printf "MISS  %-40s (in baseline but not run)\n", name
+		}
+		if (failed) {
+			printf "\nBenchmark regression exceeds budget (ns/op +%s%%, allocs/op +%s%%)\n", ns_limit, allocs_limit
+			exit 1
+		}
+	}
+' "$BASELINE" "$RESULTS"
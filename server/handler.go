@@ -25,6 +25,8 @@ func (h *CommandHandler) Handle(cmd models.Command) models.Response {
 		return h.handleGetTable(cmd.Data)
 	case "table.list":
 		return h.handleListTables()
+	case "table.handRecord":
+		return h.handleGetHandRecord(cmd.Data)
 	case "player.join":
 		return h.handlePlayerJoin(cmd.Data)
 	case "player.leave":
@@ -80,11 +82,11 @@ func (h *CommandHandler) handleCreateTable(data map[string]interface{}) models.R
 	if config.MaxPlayers <= 0 {
 		return models.Response{Success: false, Error: "maxPlayers must be positive"}
 	}
-	if config.MaxPlayers > 10 {
-		return models.Response{Success: false, Error: "maxPlayers cannot exceed 10"}
+	if config.MaxPlayers > models.MaxSeats {
+		return models.Response{Success: false, Error: fmt.Sprintf("maxPlayers cannot exceed %d", models.MaxSeats)}
 	}
-	if config.MaxPlayers < 2 {
-		return models.Response{Success: false, Error: "maxPlayers must be at least 2"}
+	if config.MaxPlayers < models.MinSeats {
+		return models.Response{Success: false, Error: fmt.Sprintf("maxPlayers must be at least %d", models.MinSeats)}
 	}
 	if config.SmallBlind <= 0 {
 		return models.Response{Success: false, Error: "smallBlind must be positive"}
@@ -135,6 +137,20 @@ func (h *CommandHandler) handleGetTable(data map[string]interface{}) models.Resp
 	return models.Response{Success: true, Data: table}
 }
 
+// handleGetHandRecord returns the record of a table's current or last hand,
+// so a deployment can keep its own hand histories
+func (h *CommandHandler) handleGetHandRecord(data map[string]interface{}) models.Response {
+	tableID := getString(data, "tableId")
+	if tableID == "" {
+		return models.Response{Success: false, Error: "tableId is required"}
+	}
+	record, err := h.tableManager.HandRecord(tableID)
+	if err != nil {
+		return models.Response{Success: false, Error: err.Error()}
+	}
+	return models.Response{Success: true, Data: record}
+}
+
 func (h *CommandHandler) handleListTables() models.Response {
 	tables := h.tableManager.ListTables()
 	return models.Response{Success: true, Data: map[string]interface{}{"tables": tables}}